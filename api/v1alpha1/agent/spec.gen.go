@@ -19,111 +19,129 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9fXPbNtL4V8HwbiZJf5RkO2km1cxv7nEdp/U0jj1+uZu7Ks8FIlcSziTAAqActePv",
-	"/gzeSJAEJcpxejfTm/5Rh1gAi8Vi37AL/RYlLC8YBSpFNP0tEskKcqz/PC6KjCRYEkZP6fqvmOuvBWcF",
-	"cElA/wvqBpymRMHi7LIBIjcFRNNISE7oMnqIoxREwkmhYKNpdErXhDOaA5VojTnB8wzQHWxGa5yVgApM",
-	"uIgRof+CREKK0lINg3hJJclhjG5WGhphmiLTA3CyQnkpJJoDmoO8B6DoUAMcffsSJSvMcSKBi3EUO+TY",
-	"XA0fPTx0vsQ+Ga4LSPRSs+xiEU1//i36M4dFNI3+NKmpOLEknATo9xC3CUhxDur/TaKoVakWxBZIrgDh",
-	"eqhBS9OfhMRconsiVwijDKQEjhhHtMznwL3Fu50JLP63iFEYsNSzHC/BW+8lZ2uSAo8ePj583EFTiWUp",
-	"bjREmwymTREBI0HoMmtSglFNnBTWJAG1IKBlHk1/ji45FFgvKlZjcGn+vCopNX+dcs54FEe39I6yexrF",
-	"0QnLiwwkpNHHNmHi6PNIjTxaY642RagpOivw5+w0ekh02mqsOk0OzU5DjXenyVtIk9DiusxzzDcDCZ5l",
-	"Pq1FP7F/BJzJ1SaKo7ew5DiFNEDgvYnaxLaeoxfEm7wXJkDPJkCF7oPiCGoEWpdMVRNKGJWYUIFSkJhk",
-	"Ai0YR4wCwqKARLrzm5ScKwEnJJb2UBOBji/P0BUIVnJD0aZkyLCQNxxToWe6IX1yQsEhJQzNTBVqsuoL",
-	"KVpwlmu8hNlhyRCmTK6MIFgwnmMZTaMUSxipsbrSIY5yEAIvA1j8WOaYIg441cLbwiFCU01kuqyog+es",
-	"lBbjCr1xaDI2F8DXkP4AFDgOb4Na/TgHiVMs8XhZQSK5wrJFjXsskACJ5lhAisrCTFstnFD5+lWNB6ES",
-	"lkp8xREHLEKTH6Pnc05g8QIZCL3zjTmfiUErNTuiJtgmYSuWM4waVcJ6YDd93h/0en4pCYdUnTc9QoVB",
-	"HGK5igD1/ocEehu9LZKlQaNYMyVboBteQoze4UxAjOwx9KWMao/iSAPsLVda2NmxWl/d0K3PQZEQlp7q",
-	"q1pLzXWEohOcQ3aCRUNmHhcFZ2snrNyfb4ES/cc7TDLTmCQgBJln0P6HkxuXmAsNer2hif7jYg08w0VB",
-	"6PIaMkgk42pv/4ozoppvixRbVaTMGff5vMwkKTK4uKeg4d9qQf8WEpbnRAjCtJIaRu9TylmWKZPuCn4p",
-	"QUhvkSdKwi2UYIBrslSD7gFTUagXoiLdFRRMEMn4Jkg3Ra7ehg5x/caK0O8yANlDbd3maGtI6RHefPDJ",
-	"b74M3QTDiguydHaWM0yHWWs/EBnorqy9bb1+KufAKUgQ15BwkHt1PqMZofCIWX+Usgh10zQoSrcx54yq",
-	"vd7PQA91NgNzRk8/Fxw0yQP6nzOKoAJARo1oDaDGTstMKT2lR8V4RpWashBEoE/fIPvfpykaoXNCSwli",
-	"ij598wnlWCYrEOhg9O13YzRCP7KSd5qOXqqmt3ijRM05o3LVhDgcvTxUEMGmwyOv898A7tqjvx7P6HVZ",
-	"FIwrb0vZI1ixtEL1k8L43EJiurH+1nMYL8exHoZQtFIoV+PBGvhGf3uh5v00+jRFV5gu614HozefNOEO",
-	"j9DxubJL3qDjcwMdf5qi90TICvgwPjyy0EJqH+fwSK5Qrmlo+kw+TdG1hKJGa+L6GGTaPa6NX9Fcy5ua",
-	"JEpdvfG6zOjpZ6xMbEU5dDB6Ex++Hh29tFsa1PDmFHfZyHxHHBQjKc5EGBWrjSAJzjxDu2kW4oL8FXiY",
-	"L48vz2wbSmFBqEV/bb5BigznVwZoNbP1pxYIU2SU+hhdK/uLCyRWrMxSpdTWwCXikLAlJb9Wo2ljUmpD",
-	"VIKQSNlOnOLMkDTW25TjDeKgxkUl9UbQIGKMzhlX9uKCTdFKykJMJ5MlkeO7N2JMmDq6eUmJ3EyUuc3J",
-	"vFQsOUlhDdlEkOUI82RFJCSy5DDBBRlpZKm278d5+iduD7oIbs8doWmXlj8RmqrzipGBtBxSkUyf8BWg",
-	"q9PrG+QmMGQ1FPT2tSamIgShC+AGUpvlahSgacEItVZrRrSzUM5zItUuac2m6DxGJ5hSpv3/UukTSMfo",
-	"zDcyvjYpFfXESJEsTExnju8yTC80jc5BYm0DW7m9rUetNIdbzbaPNZlb1q93kiwTeOiHjFwzWsfj7kbF",
-	"wkGdlpvUE98JUlV12vSEiXQ4x5qdyhdVbHa/IskKYQ56OsVyA6fRMaOA+f6hmsXBIOehVY5PeHTPlRq2",
-	"Z+HYUHvzNIkdYTzMq1kGbWDT+w85ecIAuI1a6UCElpRbgyNNflDHcSc/KCBlJBjprfxlJ2K0F+kHvp7E",
-	"o9weGmrTeydVjZHWR8gTLwBSu4GGXopxF2TZJRsHmgKHtFffOWXXHM5188btRjr9tbXn2bpIwbJeVW6b",
-	"fY1uvV39OWGUQmIdw2qzu+teXl2enFqFED70CqLWGV7koTVPmD2M1Xr2Njy2bUZnb/cbuEXUxiL8Sfup",
-	"6/s5XdzOrWi2QSTstjttekdOXXbJKjFfghymMnxUbnS/cADFDDlsSd443fBIAQlZEGuwpSDUDJ2l5SBX",
-	"LG2yux9WuKWgPW8dQlCu6OYKRAO/bV77Noy9kbeBNWetqHCmdAAncrM7OmQ3lbge3W20EnnYPrZmtnKu",
-	"K93s9/6N7BmouxKrL5qCrlpOd+++UFOYw1BpiXqiJ9ER29b+ODWxZawdMcMtNKzufbAQzQBafVFyS4Vz",
-	"a/c6Dy2EqymCrdW8wdYamZ5mD8OKYO/JApJNksGjVGvmej8pq7UHt3N/MaO11vo4DgsN0sda0kZs+yhW",
-	"C1a3cya0afe4GZZrftmTzVpYt1ml1dzAItAeQm0HWIPpLoSLpIXMHNOKTNPc6iyjCtHFdWU19Mq4PHiL",
-	"dNMYRANZH4mj26v3uy0OM24/Y1yIRx2hi+vBS2jZo24ZwXOhW96SJYgeGy/Vbe2xTIgKiRU++vb1FB+M",
-	"x+MXQ0nTnLSfUFW0ey9yVUGWXTouKcph4qCJh5EGcZQScfcl/XPI2VDzITRCi7RqNdWgFruhpO25RlIH",
-	"YVMYQlaRJUNsE8LqXr3/DXN70E84kSTB2aMv4UOI+nf83dZ68lCrh1Co2SEZavPv3rzwT49YagklvCWE",
-	"Wnu+3cF0xLnl4KsxiIR8r+CFjVRZkmPO8Ub923il/fOadlTY647hcwdvVzrTt3yK/d0hfTs/UKFbPWJC",
-	"S0Y6BIIsCrUGr+fmQsaSouR7bkLrXidEBbEREvK0xwM2jUgAV4vQAW6LUpeZ9D3BJZYSOA1GkDK7rxoQ",
-	"FRaysZhO0MncSTg8SkqkVoWxyeZiXP9fWWOiXCzI51h9wkisIMtGQm4yQMuMzd1kGn89O15iQoV0+SjZ",
-	"BmUMp2Cm0Djl+PN7oEu5iqZH376OIztENI3+92c8+vV49I+D0XfT2Wz0z/FsNpt98/GbP4e0W5PeobQ6",
-	"E76+ZBlJBgrjW6+HYauHXjnbp7r8Vj9ME7ZzhZd/ZoUJsn1zrF0skpnQZyJLnNXpPV8qe6zp4cf8ahN7",
-	"0Bnoi1UHzgLuBgL3Hr0VSDViziRBiC35U94eaDqamLILqio6BrOnfPIOFY02l2urQN695EaUU1lxzoV7",
-	"lCetRlBu+zUAHZLcZdnC5DIBRfONYVMjp4ZnclU+zqPcsj0VQNWnoQL2tb200N6HOTsMaaTpmfV6BwxQ",
-	"w1fiKt1HUqU9907eyWhg1TyJUfhg+mT02a9iY703Nb411TxW8zmg31Z9/N2Ix6srzNN7zEFfA5t0AkKX",
-	"VrWhxsXs09+ZWBxczuPTRcSe4L5kr2zccLjrQifVhBNvr2DOmE03umT3wCG9WCwe6Qw0cPVm7bR5iARa",
-	"m6Z+o8lHN9DcWEGgPeAoNE570AioIOw1P2jVS1IxKUuSaquvpOSXErINIilQSRabrY6tf3ceFufHHoRS",
-	"fSbLZt4etsObijih+5rvGZPo7O0+Q1Vn0Kw/jOdFdVCv3UEdOEH7jt0nSbWOLhb956Rj9e24Oyk0pA5C",
-	"5ZjipUk/1nLAyERdTZJkZapa7ldA3XeX6TIHlLJ7ai1jJbe0IIa0u+MO7tpkfe3Up2YxFXSlVx7b/2EH",
-	"2dJHRbwMTk9/OdEY/inFcWOxjxPH3SH2iBnXBKsCxsUNe4ul4vmLUl4s7N9eDuhj5HADSW+KQKs/a7Bz",
-	"Kxm12eqLUyLunj7LMu45xNbZ0afXwOvzS8QdKoUNpTaZssDKVw0HULnOx90oP3jlOfF6+OaY26WYnqPL",
-	"O5o8pV+dsMBlpqzvA2WCdTHK8WeSlzlKbSeEs4zd+yk0JjtAMpTYMh5T4VZ1qEWUsFIvRVjnDTJ1ltb2",
-	"mgzUGu3Y841yo5QLoZz8MaqzO6uPAmEOU/RJmERJAcpEFTH6lJsPJvdRfViZDzrLU+9FHR54/pfpz4ej",
-	"7z7OZuk3L/4ym6U/i3z1MRgd6OSHdzewA9JMk7SX/BoZrBPHcabIZm6pt/rf/02f/G/65B8wfbJzoPbL",
-	"pOx2f0RSpcU0pIV7SkZwNkA0ONC6Gi9shFSCwgshWYmhy497M4ewK03p4HJmatxAKEtSroDbqzAjnVZY",
-	"oDkARW4Ab8/njGWAqQ3A6dbjnptALaextFmd/gT3SvZ7Yw8L/7ge328GVR4rWB7k1gzPIfuS4u9j53WZ",
-	"kXRZYlFkGycTO26GV6jd5Dq7QYNYK+xGBMGMCPMADe90YJ8Jd3etg5SBS0/Bw8S+PD0fAU2Y8jUufzq5",
-	"/tPhAUrq6iYkTHmTz5wBojZj3sNTor/GHrriSxuWRPfE1hLbbSWiCmQq70vJaO8QEhE6LT37rqg6bMt7",
-	"/KAewP2uBjqD9EkQI832ErOVGHyII48tdvOS4htIfVYKss7WMH23ahnCi/3SIHx/hDS4uzqO1Mm6761P",
-	"1vCuLHm3tV/VuT7E0TuSVXfOrQPNqIS+/Nwiw4QiCZ8len5782705gViXNcev35V7ZAdwRF2QbLeLVJw",
-	"p6qbvbFteeDs3qXpSmMfc6XX9CxjdG7fiwCi9dMs0sjNIoXRLDI4zaIxemu8Fy2EKyDfp9Wfoth26Tqu",
-	"D3G05KwswiRRy3smkIaIPe/FoqWdGJfuQ8scOEnQ2ds2WpwxabDqmk4sha1TF8DtFTZSsGP0d1Zqi9Ig",
-	"YwJbubL/FjgnGcEcsUTirH5CA+uY0a/AmasUO3j96pXeW2z0REJy28HkKIf6vDo6eKFMWlmSdCJALtX/",
-	"JEnuNmhufTFUZQKO0dkCKZO1olhswlzNxWhHSK1TydaaYAq9cC1Gv9uM54JlpYTKa3bM2apyQB+YBCPt",
-	"Md0g+EyEtuo1qJb5c0DKdLjnREoIR3lKAXzrprF7Cvwr8EvIw6+OWlDqhKtmO3JhSeSVkoGhNXFYAAeq",
-	"HB2GMPqByGaKg1aZEEoyYCWVl9WWuTDDpBNlUDCu2sfs0zNhdsTeuLTMSFcjrY6H6lrHF/SUDR1c71o/",
-	"8/g8YxNZLDZ1PXZP6ZFr3m2T1kNVnmNwTGORXcGaiN73I7ht1dF+AbVLuRXfTgFJhXxn1rgvehQPfPun",
-	"lQ+0GxtbGmUZMTRxT1F1h5eVBzyQmSn68ebmciA7K4a8DPLQTv6VzONfp0E5yJLT+nZCoyJgDdxj6G1i",
-	"aB/u413uc8yDTcBIbGiCtvClSdoJLZ5X1sDt1XsjWxOWg0B4Ia1vqbSvzodFZxIlmNrLDEC/lKBDnRzn",
-	"oN+AEmWyQlhM0SyaKB6cSDZxgZK/aOj/r6GHyMcGh1fb9/sztePI0My9j1B1+LonfffK52jHX7qi0ube",
-	"BiodUYGTu0FmZX96cu/jCF3Ezd3rliwzYwNIhhIO2mpvVyYOMtUrszeQLvN1N9iuMESmrQ9QTB/3sNpu",
-	"NONI6NmGKvUaS2Q67tTmj9ffZoKBSnsYQWqcgwOIAidbRtHNO4cK73w9fOxR6OOuEIDtXW9SiHXOdXr2",
-	"13ksxAvFduhStyEikIuDWqM5y5QVL4iQkHrZ8/p9vxVeQ2x32gp4oXuYNQmlbriFNSc9EHOglMk60/CR",
-	"4Z0a2Lyf1Uk56xBb42PfjxIS58WWqKZJ+tPx/nss7FL2CGWmkMFj5rLuie6+z3zLLc+RHSMBv5RaEtii",
-	"/MZtB3ZOTIK8p8qqi2RT8Wmih+iSFWWGvXwLc/rH6ApwOmI02wx8veyLo3vnuFA42kucO9iI+qlNG+tT",
-	"RsgcFEemSgQyvsSU/GoyvhIsYcm4+udzkbDCfBX6paQXjpmDXDRMXNnbtmCii/IcQ7vk3TZhqRxM4a7z",
-	"zPdYCeCZvryYqLlmkX2+p+/NBN2r/1aRIlbgX0pwRNTT2oQil7ViLOVnwrv+q2uJ6lvFQY92Rle2nv3f",
-	"8XLpMW1YRwrod31qtG3RBSnRKl2rHgywvLkYOcMvrc6sf8UbfuiiS/9tJStdmC9CCr19VL65TjEO1Luo",
-	"c5xCkbHNHkUXYabbowLmpjLInAPp7oP0kTxbUiLr57b6YqXugYZBydwauFUV8/uVxOz3vEXFES6ttYBk",
-	"q0j6b63Nf3atzb+vambf10/cLh9nwOWVTVRspUL6dO2SeVXmmI6qLMHWjap2qtXY4evNss/kctlXyrqW",
-	"zs5ja+Cek4TXwJXzXppHab2Hi+awYNxOTOhyjN5pwTLdnkz1TDxrZkk9y581s6SerZ71ZknNZun/60+M",
-	"KoAnQGVvfXTdrqhmVmTuWzlZLpVHEKKksUaNK7uGIdUqjf2+tp3CiZVuRG+bGutoquSdzNWYrJuCaVs7",
-	"POPuqIJ1sDoLfFieZS8u9cC9IN6MvTAGFW/RTm6qpRK11JxQbD/k5l1R9efJ5W3vtWr4FUyTudkrG3qy",
-	"Op2r3Nev35F+qIT15oO2DCMrxl3d9TDzrmc1u54J3YbXDinZQ4mHwC5tzT8Pp67ixhVFyzZz0nSbotZA",
-	"iCuoMbqg2cY8L66/FsCRO4A6ccJIqb2Vdy3WA+rb38beWvWGSdFU4d14Gs6LjNDlmXJ1ghlelVh3v3Hg",
-	"jBTdVRHid5DUVTJrn7hupw14dIr9vQ2sOCQGb0gO/2Auuuuu+N4zI1FaZFd67lfFCJUfyYVduxaMZ8cf",
-	"jt2js8dXp8eT9xcnxzdnFx9idL8CDvpjM6VWuReE6oQEjlgCmJrkU9ezuoPV6caYS5KUGeZIEAnaRiL2",
-	"4XXMAcfmxVXzUio61tezePIB7v/5d8bvYnRaqpMwucScOLYuKc7nZFmyUqCXo+q3LIxOV2tt3Yyj57Po",
-	"h/ObWRSjWXR7czKLXgTZ7bZTYdGuBqpTfe3rvSbSj0vJcixJUpWD6ANN01AhiVSCe2mr3EycRWPOylA2",
-	"0M5XyFovEJs0TS5/4DgBP+V8q2RzcOpQe8y1rU/FhJ0Mu9Cl+IOugDVFIdo7TfTCIMcki6aRBJz/zyIj",
-	"y5VMZDYmLHJhHS033ukWdMKo5CxDN4DzKI5Krrq63NtG705w6ufmEB+fh7q9cOVdJhtN5/5DkmFFnDWY",
-	"IiHIbSLOIgOQOq0L0qULwZuQl1wB4eie8TvFCmI8M3WUCVABdTwkOi5wsgJ0ND7oLOb+/n6MdfOY8eXE",
-	"9hWT92cnpx+uT0dH44PxSuaZ2TCpmDVqEen48iyKo7XzGKP1Ic6KFT60lV0UFySaRi/HB+NDe/OsGW6C",
-	"CzJZH07seia/KWQfJs7013kLEMhl+gFkw/WM25EIzxVtqkAXkWioP1v1xehZagYPREoU1u4KU1sL2wOA",
-	"rVmU7lm2kO5FUutJNajN/rA7WD0t6rhf8hJi+8tIgZBpt5ilqtzWZTSo5WFV0+o72HpeDXzV8sa2zftR",
-	"u/oFU0yk2o8ODlqZaV5MZ/Iv+zMW9XhDwjn+q7sPnQN48ZNivKODV4EXY5m7nlcgrw4Onww1k/4XwOaW",
-	"4lKudLQ5NZO++vqTfmDyHSupnfC7rz+h+5kfusiI+80qvNTei2H06KP61nPk62z/ogwc+Ftbm9fKcN15",
-	"lq+gyJRq8pOLv/wk13V1T3FMPxpgEPJ7Zl5TfpKNsq+7PzQ1pkLm4SueT3/W0Jl89YRz9bLi9zhFroLr",
-	"D3LId5y2OpHdlR3po8ZCJW4nJkMDUxQqdus7aaZXt4Lu6zB3d55BfH74tREIUTL9g/H9y68/6TvG5yRN",
-	"gf7btFscfft7LPTaeAe3FK8xyfDcFdfbo9451rtOvVW3Ww3rPQ/+FeA0dOz3UrL9E1rL+UmV7VfSfYNk",
-	"glODf5Cj+Ttbuv+xh1JfcuhiYX0ajAM+ifSvkJp+nRwtd8r0zzi0rFAdFLRnwOr7rrvXHKH/iPmDdZF/",
-	"+PjwfwEAAP//uM/eaY13AAA=",
+	"H4sIAAAAAAAC/+w9a3PcNpJ/BcXdKsU5asZyHpWo6mpPke1EF8tS6bGp3ci3xpA9M1iTAAOAkicu/fcr",
+	"NAASJMF5yI/kLql8iEwAjUaj32hg3iWZKCvBgWuVHL5LVLaEkuKfR1VVsIxqJvgzfvt3KvFrJUUFUjPA",
+	"f0HbQPOcmb60OO900asKksNEacn4IrlPkxxUJlll+iaHyTN+y6TgJXBNbqlkdFYAeQOr/Vta1EAqyqRK",
+	"CeP/hkxDTvLagCGy5pqVMCFXS+xNKM+JHQE0W5KyVprMgMxA3wFwcoAdnnz1BcmWVNJMg1STJPXIiZkB",
+	"n9zfD76kIRkuK8hwqUVxNk8Of36X/FXCPDlM/jJtqTh1JJxG6Hef9gmYQwU8V2fc/iOkjFkapyUoIuZE",
+	"L4HQFmDzLYdblgHRS6qbRStNpaHVDOZCmjamwrETchQCorIdwTixCAHPVkTIHCQSTmlRVbZdwi1IBYN+",
+	"hppMQxnfc/eBSklX5t9mXeMrjix4qx11uFKpyR3TS0JJAVqDJEISXpczi2UPuciev0sEhy12+KSkCwiI",
+	"eS7FLctBJvev7l9tYCVNda2uVlWEDLbNEIESxfii6FJC8GDnzYKA12Vy+HNyLqGiuKjUwJDa/nlRc27/",
+	"eialkEmaXPM3XNzxJE2ORVkVoCFPXvUJkyZv9w3k/VsqkQ3NFIMVhHMOGgMkBm0tVoMmj+agocV70BQs",
+	"pEtodVmXJZWrLQleFD0xGyP2D0ALvVwlafIUFpLmkEcIvDNRu9i2c4x2CSYf7ROhZ7dDg+59mnwnhFZa",
+	"0upKvAF+ATmUjkwxaZXwSw1KI+k4oQvgmmhBJOQAJaFk5qERbcAZAnb1X6ZkHPL5s9N94JnIISeZ6T83",
+	"qANRbMHRArQzmw0CLkVRoBUJekfEPU0Qk/ikEXSH2sLMzCTkZussrBSXEZP4MWpegKoEVyM6ML4YwpSq",
+	"ISdzIQl1JDZafiOJWxCbSb01HXuECCeJEeJYcOseDFFomkgmuKaMK5KDpqxQuFTBgVBVQdbsdVZLaRBU",
+	"2lAFPzJFjs5PyAUoUcsMhkQoqNJXknKFM12xMfNj+hHNSrAzNajpZqzZASlKxEtZxaEFoVzopbUvcyFL",
+	"qpPDJKca9g2sGBeWoBRdRLD4oS4pJxJojq6Q60cYz1F2+aKhDp2JWjuMG/SiLC9mCuQt5N8DB0nHxXlS",
+	"gqY51XSyaHpa36JLjTuqiAJNZlRBTupK8M7CGddff9niwbiGhbGKhmWoik1+RD6bSQbzR8T2wJ3vzLmn",
+	"tlqp3ZHkcL3hbljO6r/WQ9lyGJqRgS4wHxsM0hjLNQRo93+tsFw2qxkzWB0apciUYk6uZA0peU4LBSlx",
+	"2j00XqY9SRPssLO56mHnYPW+etC9z6Gl6VJzyI+rCtfSch3j5JiWUBxT1THFR1Ulxa23gf7Pp8AZ/vGc",
+	"ssI2ZhkoxWYF9P/h9cY5lQq7Xq54ZqFINtf419ktyIJWFeOLSygg00KaXf47LViOIDLBMzfTdZVT5/iY",
+	"mMH3Oa0LzaoCzu444OCn6FY8hUyUJVOKCecS/USZGf5cyFPKuAZOeQY/MZ6LuyRNzuuiuFpKoXWxteP2",
+	"rNHrF9ZwBpQ6bnX3pbWuO/RpyDzao6H/BVRCMS3kKkp8Q/PRhsEOhY3NboUf2517XgDoke3DNr8/+I/O",
+	"RtoNCrbTfgg31X7Zemvt9/UbbPv0ttlKzJwtfJTho9HtYpXvmY4Mv0/Xj/qxnoHkoEFdQiZB7zT4hBeM",
+	"wwNm/UHrKjYMaVDVfpdPBTfctFtUHhtsAUvBn72tJOBmRdwUKTiBpgOx1s78jxjYeV0Y22zMvZrccGNN",
+	"XQ+myOvPifvv9SHZJ6eM1xrUIXn9+WtSUp0tQZHH+199OyH75AdRy0HTky9M01O6MhrxVHC97PY42P/i",
+	"wPSINh08CQb/BPCmD/3ryQ2/rKtKYBJAVCCpEQ+D6muD8anrSfnKJVk+g8likiIYxsnSoNzAg1uQK/z2",
+	"yMz7ev/1IbmgfNGOerz/zWsk3METcnRKtCDfkKNT2zt9fUheMKWbzgfpwRPXW2mM8A+e6CUpkYZ2zPT1",
+	"IbnUULVoTf0Yi0x/xKWNqrtr+aYlibGq3wRDbvizt9QEmIZy5PH+N+nB1/tPvnBbGnVErAwP2ch+JxIM",
+	"IwHXilBSLVeKZbQIwsyu90or9neQcb48Oj9xbSSHOeMO/Vv7DXJiOb/xk5uZXTYBIzfre0zIJUgzkKil",
+	"qIvc2N5bkJpIyMSCs18baOjzavSXNShNGNcgOS0sSVPcppKuiAQDl9Q8gIBd1IScCgmE8bk4JEutK3U4",
+	"nS6Ynrz5Rk2YMKJb1pzp1TQTXEs2qw1LTnO4hWKq2GKfymzJNGS6ljClFdtHZLlZlJqU+V+kE3QV3Z43",
+	"jOdDWv7IeG7klRLb03FIQzLzyaz64tnlFfETWLJaCrZdVUtMQwjG5yBtT4webMyaV4Jx51wXDGOaelYy",
+	"rXx4a+g8IceUc4HZr9pYIsgn5CT0hT42KQ311L4hWZyYPmrY5D+fIY1OQVMzSjm9vW5Ea263d+7dGOfZ",
+	"95z0QJIcEwTox3xxC22QbxqmwuMpzV40N5LdjFLVDFqNJEkxmem8Y00ZB6nI3ZJlS0zn4kjC+JbTYMY0",
+	"EmW8bGbxfYgPJJv4LA49iPi227N4ZrS/eUhiT5gA82aWrTawm/uKxaLKdvAbtcQ0nPnX+tRglx+MOG7k",
+	"B8atk2C1twnrvYrBYDeY78MEvusTo316b6SqddLGCHkc5Gnq/olFhkOHZJPAc5CQj9o719AD54cFcNcn",
+	"rPrzrF2kEsWoKXfNoUV3QTl+zgTnkLn4tdns4boXF+fHz5xBiAu96dHajCBB0psnzh7Waz15GoftmsnJ",
+	"090A94jaWUQ46Th1wwhpiNupU80u10X9dufduMqbyyFZNZUL0NuZjBCVKxwXz/NYkNstKYAzzOJUkLE5",
+	"cw5bDsrMMFhaCXop8i67h9mPa25ztpjpyEx0fQGqg9+6vMA6jAPI67p1Z22ocGJsgGR6tTmJ5TaV+RHD",
+	"bXQaebt97M3s9NxQu7nv4xs5Ami4EtvQU3TNcoZ7956WwgpDYyXaiT6IjVi39oeZiTWwNqQ219CwOfWk",
+	"SnXzfO0x4TVXPqzdSR56CDdTRFubeaOtLTIjzQGGDcFesDlkq6yAB5nWwo/+oKzWB+7mfm9G6631YRwW",
+	"AzLGWtollsco1ipWv3M2eer2eJjQa7/syGY9rPus0mvuYBFpH8s1runWZTqxUGNOjmmzgfFM1NwcFBpf",
+	"1VOxMK1qybBGZLZqzPSeskfCkTNJs5JMQ3404uw0R3AIgDT9m/m2P2krGAcVn6UQC4LNKRFFDkqTOZNK",
+	"71bLMnam9RPW5NA8XIchUmcJntkubQoxqCLYiZHEQl0gGiGcfpuDa5Yga55Ro3MiWINegnUBHU2QQuQO",
+	"JJBc2kIgLYz4r7DMhvF2gXuKKParEaiS6VA3zIQogPLhuXHACMHpmN2zcXk/Uz7rG+NW20ps08z5V9Zt",
+	"I2eXjYc7ao/L6MHsVQcIdnLxvCTXFy82e8cW7tpFPUTdn11uvYRe7OSXEdXh2PKULUCNiGiObX1YNp1K",
+	"1JI++errQ/p4Mpk82pY03UnHCdUc8+xEriYhuMkfy6p6O9PVxcNarjTJmXrzPuNLKIVcPRxCX8KqOmmA",
+	"Ouy2Je3IyawRhFVlCemp6ogNKl4k9ROVzigdS6ZNsvvB5VIxRMNqrGFrO3msNUAo1uyRjLWFx9lBqnJE",
+	"LfWUEl2T7m+zNENgeDrSS0Z1LNa2iTaXVe3bM5tBGZ/XtpPKHc1tP3f0JHAwfS/+3T10N0DEls6nsyM2",
+	"DWq1QyQhaFDr8HppDw8dKWq54yb0ziBjVFArpaHMR7I1tpEokGYReBjjUBoyE55pnVOtQca46YgUbl+x",
+	"I6lcz85i+kNcVarHo+ZMoylMbd2tkPh/Ezmoej5nb1OCNWtqCUWxr/SqALIoxMxPhvjj7HRBGVfal3gV",
+	"K1IIU96IUyBOJX37AvhCL5PDJ199nSYORHKY/M/PdP/Xo/1/Pt7/9vDmZv9fk5ubm5vPX33+12gRYIfe",
+	"sbpve9RyLgqWbamMr4MRlq3uR/XsmOkKW8OUYjwmU0GlsFMmxI01h05aGifddKSZrmnRVsy9r+6xozv5",
+	"6TYc3EoGxs5VIrJAh0nrnaH3kv5Wzdm6IrWmJDHYA+sR4/lHW4JP4wWJIXm3VY12wvUKefOSOxl548X5",
+	"dMODsj4YPlGlL2GsYrZbL+nYwpYHAjexoPns9NQuIZsLWR+UQtjRADRjOiZgV9/LANgpSzlgSKtNT1yG",
+	"ZgsAbf9GXeW7aKp85Iw0kIwOVl1JTOKCGZIxZL+GjXFvWnxbqgWsFnLAuK/68HO8gFeXVOZ3VAKWLNjS",
+	"F5N1t8smnSKCD3++53DwZcQfLnv7Ac72dro3EU/NnmEBWPyKxAWYwnXrlZ8Lk1zIz+bzBwYDHVyDWQdt",
+	"ASKR1q6r32kK0Y00d1YQaY8ECh1pjzoBTQ9XkgJoelmupnXNcvT6as5+qaFYEZYD12y+WhvYhnUecXV+",
+	"FPQgElxF2KwPdsCbhjixs0Vz/8EcKu4AqpFBu/44nme+E7n0grrlBP16kJAkzTqGWIzLycDr23DOV2FP",
+	"TEKVlNOFreg3kFx1D153zIo6Ny13S+D+u6/KmgHJxR13nrHRW6iIIR/uuO/n04KbtIddTNO7sSsPHX+/",
+	"gWyXhdBj/N/2IMySToKqCx06WHtNpZQhKIYZEgqgCoiYp4R62hFViEgaGt5WTILamIR2DoyHbaRxISk3",
+	"/GYAt1ck/PE0ywkX2vff3vFxUNenZb3lEkWuektsCu8MR6wI08q3cnEXS8eaKUVdjVQcmKbW3Vd2nWpJ",
+	"pSVLMLFKbaq7Ywv2FJkXABYr89mW/vlOBZ1BYXcWu+0pkjMl68pGOUUh7ijPHBqKzFaTuK9YMr2pTMov",
+	"wM1sF6Ztgt4Tq6Wk20xFS8sDE3LNFWjC5sGy+2lYQxSz54gP5AGuTTVUv1rCbfYm3ZI/KC1ssfzwp80d",
+	"8B/SZ+ks9mE+yxDEDoeALcGaE8DqSjyl2kjqWa3P5u7v4DrAQ5yVDpLBFJHWcNbo4N69hG5r6HMw9ebD",
+	"l82nI5bOZQTQxNn+aOSYekNqRRcRpqyoSejETxkkXtZYEdMnyHQh+C7M9aYe5xjyDpKnDm/FzWldGE39",
+	"2MQpQ4xK+paVdUlyN8hqqrAm0pZ7aUEydyvZvlPQDGjtuLIEM2YcC8GFkaVbV/cAZo0ONp6qoj2qOTOF",
+	"2k25fvMRr/IfktfKVr4rMHGcSsnr0n6wxezmw9J+wLL9SdLJoX32t8OfD/a/fXVzk3/+6G83N/nPqly+",
+	"iqbQBleKhhs46NKtew9Mt9HBUtzSwpDNlh2tTVL9WQ//Zz38H7AefiBQu5XGD4c/oEreYRqzwiO3DGmx",
+	"hWrwXdtb4HEnpFEUQRgQXlsfLQWl/jbjAJcTe7faFDkEbrYDRJZUkRkAJx5A3I/2rWuDCapdmX44gUmX",
+	"hrC3CxX8iO9WWz2kYvrKuAONbvh7POFz5FMT3qEXzqN2OnEQiwfP7XS5zm3QVqwVj7Wj3awKCzpa3hn0",
+	"3VO+wMOIEzzwtYrzH48v/3LweN2rFdF9yHsHQ9vfcfkYe+gjWh/63rGiCLeVqSbbvwSOkWYghEzFpGVk",
+	"38cezxjTVlts+gPOzwZAxjQILTbtzrgaNKc5uzzHYfim+wBKlHXWnmUNX8uA+GLf96Rq/BghuruYbB1c",
+	"oxp9FwP7++cwNnv7zfsK92nynBVNYUZPoAXXMHbhoioo40TDW00+u756vv/NIyIkvnnx9ZfNDjkInrBz",
+	"Voxuken3zAxzZQ29CFzc+XsX2vrHEoibZUJO3fNXwNA+3SSI3E1iMLpJLE43yYQ8tdELKuGmUxjT4qck",
+	"dUOGgev6jJBZ3p6y+ZM0iF4cWhjE+Jo4XpcgWUZOnvbRkkJoi9XQdRI5rJ26AunqPIjpOyH/EDV6lBYZ",
+	"m/0thQQypyUrGJVEZJoW7Ytg1NCf/ApS+Ku/j7/+8kvcW2rtRMZKN8BeOomN+fLJ40fGpdU1y6cK9ML8",
+	"T7PszYrMXCxGmtLuCTmZY3KooViKePYWg4GQWafRrS3BDHrxy3XjYTOdKVHUGpqo2TNn79oaeSm0ez6O",
+	"8hWBt0yhV49dUefPgBjX4U4yrSGe5dFQVkVUn4VpSy8paIz9kPbiVgcvu1tzmmlFMIvR9SVSYjaB3CTv",
+	"3pGJtYWTH4TSyHr3914sgtYXaNYmimnbYUIucGJcK76mw+YYkc5BAs9MNE8zxBU3iC8mxL6AoIjSYohv",
+	"RrmhVDAeV/DuHVE4jNzg7cWbhNzfp0SJxsCumsRhRWWjRgyfdKVmTgsFcc+zViDXyoy44yA/grjGEiyN",
+	"posq/fgrFAO1vGD6AubxNTUkRj+TfM90twwLPRaIFUKJmuvzRmJ8lmc6SPKYPoSF27unrEC4U+GeF+9f",
+	"NTHayQxt0zs4JeQR0q2T3VBk7dI8Nu0LKlGQLSqbQ4IWVBO4x2UbHeILuGVqzStwthVPJBW0Ef1afAcX",
+	"MhvkB7OmY8m7bV+S7NUsbsbGXTV2jBibeOSRkgEvmwTElszMyQ9XV+dbsrNhyPMoD23kXy0C/vVqWYKu",
+	"JW9PUBEVBbcgA4ZeZwV24T455D7PPNTm69SKZ2QNX9rCwtjiWy16ffHC6tlMlKAInWtniozzY1on5ESj",
+	"6rYHrkB+qQEzzZKWoDHxV5uqRHVIbpKp4cGpFlOfp/ob9v5P7L2NfuxweLN9n56pPUfGZh590nTA1yNX",
+	"DC5Cjvb8RRkH6e4HRF4OIBXN3mzl1Y9foRh9bGiIOPZcVwlrXTAtSCYBg6b+Tf+tIqUm6njw+7cP3WC3",
+	"whiZ1j7otOWbFrujmSbWC9rWqLdYOvdpozV/uP22E2xptLcjSItzFICqaLYGCjZvBBXf+RZ8GlDo1aYM",
+	"jBvdblKMdU7xCsnHeXwryIQP6NK2oTvuXwSwMUtRkAqkYkpDHtzwwdeil/QWUrfTTsErHGHXpIy5ka6v",
+	"lfRIyodzodtq6Adm19rO9tnMQVnsgNiIj3s2UmlaVhsqVOxITCXbpeyQSc6hgIfM5aJDHL7LfIs1r5Ca",
+	"POQvNWoCV73ROWyiPojJSAulPce3LyjY5C05F1VtQszGo7HSbyI/mu8LXqy2fLT0vZOrpxSraGyzea5e",
+	"te/Vu1Srix9rZS9XCrmg5nQQ+2VUw0JI88/PVCYq+1XhK4aPPDNHuQgLzCB/zqDI1y5g+9uusSDTQEfG",
+	"1ksp6sXS+az7iuXWzq9SQhX578uzlwQ9J5DKkKHdmlB7IjxbG4fuGtUmgDV0gnLbXX2gR2X7x8sSTQwd",
+	"49fg2LPFlKnme0oYJzd4ijZ1KQDLc2OvMeGo8eNtTkRFf6nBsxNO68o/fY2hpf+eCs6h25uf7fH2Vo/h",
+	"JxcuQ/M7/SGEnX764AP8SMERDyEiYp/0VwX67nZ0c3p3n5skm1Mc833vleeNQg3LH+Kveg1ZYt2dx2Gf",
+	"90KKPH3QhSW8oxK5MEm0IDlUhVjtcGsvLgc7XKG8WkIvuvdnpaglThac6fZt0bFzBP8a1Va3gbBz71rl",
+	"p7tTudtbXr5/cy/CZEHjWhIPrcZeszp52kLEjt7GWvNqlILXh1rYtK2dOMXoNBNSQkGb6hZ83kLMQ58N",
+	"eVbM24cP1irzP++U/r7vlP52t0N3fZHO7/JRAVJfuIL8viEN6Dok89IU+u43hb69ogjkZwM7XqFQj7nt",
+	"voDSV+pjrCBuQQaBNr0FaRJAtf09g+AxSWetcWI8anmO+u9wfT3kntrrFjrulXvdQse95d5ooePNTf4f",
+	"47WNFcgMuB59B6RtN1SzK7InOpItFiBVlJI2okFRhFvY5lZmZ78v3aB4bbSHGGxTZx1dz2Ejc3UmG1ZR",
+	"u9YBz/hj5uh7D3jbabtS6VFcWsCjXYIZR/tYVIJFe71plsrMUkvGqftQ2nfjzZ/H59ejlRHxl8lt8fWo",
+	"bhgpzPbplrFx48mY+0ZZr16iA5s4Ne7fF9nOCx1Zzaan29fhtUFLjlDiPrJLa+9ZxavPaeeYq+dCem26",
+	"zlBjJyJNrwk548XK/jINfq1AEi+AeL5ttdTOxrtV6xHzHW7j6JssHZeia8KHOVnzpDnjC3PpW0aLNBu1",
+	"7n91zYEjOBTUJ9HUTT36mLruV/4EdErDvY2sOKYGTRrsn4JD95j4hbAapUd2Y+d+FRzaCFwqt3ZUjCdH",
+	"L4/8DwEcXTw7mr44Oz66Ojl7aRKTIAE/dqviM8E148A1EZKIDCi39eN+ZHOObzpXVGqW1QWVRDEN7YNc",
+	"VBMqgVqPFuzr9eQIj/jp9CXc/esfQr5JybPaSML0nErm2brmtJyxRS1qRb7Yb35dj2i/1l5xC/nsJvn+",
+	"9OomSclNcn11fJM8irLb9eAmYY/Zgmp994sK9rSI1lqUVLOsufaIAs3z2IVJzUrfKiqb6jLfQNSxgr6N",
+	"L8P2fhXCVlpL/b2kGYS3RtZqNt/PCHXAXOvGNEw4KJKNFVbc36fNvS4MojNcGJSUFclhooGW/zUv2GKp",
+	"M11MmEh8cgP1xnNsIceCaykKcgW0NBf0pRnqy+c7owdpwJ+7IF59Fhv2yF9jthEUXt+BrKCGOLfgEn6l",
+	"q6XDC4EYdUG+gOb+nrtIyCS5E/KNYQXzYxv4XkAG7vfU3MqOKpotgTyZPB4s5u7ubkKxeSLkYurGqumL",
+	"k+NnLy+f7T+ZPJ4sdVnYDdOGWZMekY7OT5I0ufWBbXJ7QItqSQ/cDWZOK5YcJl9MHk8OXPUCMpy5TTC9",
+	"PZg2P9qGv9mmpvbX3EyPSsQu0lyM/KCelQ8+9rNt5njbeeQCLVblU+YYtCpMO2fmSKQtdnMXL3olp83I",
+	"k7xBp/vjdkkTln8n8pXnRFdcGaRepv92ryVaVt8kCKO/R3jflQwta8AP9of1kNpPHj/+6Hg0v+SH+PRO",
+	"rn407PDlB8TCFs1GpvqO5sRfw8I5Dz7+nNec1nqJBxO5nfTLjz/pS6Gfi5rnNiynC4wWuuKUvDJtXtSc",
+	"6pi+M3rhflq4V06rOiJml/55zsHrpkEK0r9q6gscgmO8vpRUhTET7euqadKWlqAHvv44ovmtmOaEwWEz",
+	"g0IYWycmeNqfHPraO6f7mh9KCKUjDUjf96BefRzhDZb+icW1P/OfgvmbCKYVvrUC6dNeZmL3VH8X8Peg",
+	"O9nhtH9YEGSLu+Ef7UvQUEa/Bx05zHhfORVk0UN6FMkPJcHp6Otctn66l11spsUatnZe7HzR7Zts1Bwf",
+	"SYgjOzMqzE8sj/d50pc3/lFkz0z47cef0P/oNp8XLNO7inx7WTVqha/d0xK9C1obZbljby/9z7C+ryS3",
+	"z0L87g3tb2Nk/zSwv28D65IkzUtLBeho3a59Nqn3rg9ZQuFen3jqrt6wOaF8FRM/hDB43uk9RdC+oeTP",
+	"Yv07Th9MFj+qaARUWCMkf0yGTePK/8L/rj9vOMLsuavzwceVyFLcmXTRKnzWibpHnTa+5TTg26MMGebD",
+	"8y0u5E/G/X+sadu0m9ttNZ7CO7Z3CSgnsVdxxnwaO2ow4iMl24bzbOVRHHxsBGKUzP9gHsYXH3/S50LO",
+	"WJ4D/83iiDT56lMs9NKm7K45vaWsMIUwHVEfiPUmqXeu1toUxo6Cb8qZY2K/k00an9DlKP4vWKStdMJv",
+	"apI+vWh+4pzC71YosZRK3nppsMd80+T+VTNucJvIS5kigvedNiw9cDLg7P19uh7CuIiFwIbIb4KL11S7",
+	"h33Kn9pZaHhmF8zROwS5f3X/vwMAXTGvuN2RAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
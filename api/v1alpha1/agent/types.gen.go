@@ -13,6 +13,12 @@ type GetRenderedDeviceSpecParams struct {
 	KnownRenderedVersion *string `form:"knownRenderedVersion,omitempty" json:"knownRenderedVersion,omitempty"`
 }
 
+// RedeemBootstrapTokenJSONRequestBody defines body for RedeemBootstrapToken for application/json ContentType.
+type RedeemBootstrapTokenJSONRequestBody = externalRef0.BootstrapTokenRedemption
+
+// ReplaceDeviceLogsJSONRequestBody defines body for ReplaceDeviceLogs for application/json ContentType.
+type ReplaceDeviceLogsJSONRequestBody = externalRef0.DeviceLogs
+
 // ReplaceDeviceStatusJSONRequestBody defines body for ReplaceDeviceStatus for application/json ContentType.
 type ReplaceDeviceStatusJSONRequestBody = externalRef0.Device
 
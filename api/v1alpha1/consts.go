@@ -14,6 +14,19 @@ const (
 	DeviceAnnotationConsole         = "device-controller/console"
 	DeviceAnnotationRenderedVersion = "device-controller/renderedVersion"
 	DeviceAnnotationTemplateVersion = "fleet-controller/templateVersion"
+	// DeviceAnnotationRenderedTemplateVersion is the template version of the fleet the rendered
+	// version of the device was rendered from.
+	DeviceAnnotationRenderedTemplateVersion = "device-controller/renderedTemplateVersion"
+	// DeviceAnnotationRolloutBatch is the number of the batch of the rollout of its fleet the
+	// device was updated in, when the fleet rolls out in batches.
+	DeviceAnnotationRolloutBatch = "fleet-controller/rolloutBatch"
+	// DeviceAnnotationRenderedRequestID is the ID of the request that caused the rendered version
+	// of the device to be rendered.
+	DeviceAnnotationRenderedRequestID = "device-controller/renderedRequestID"
+
+	// DeviceLabelCordoned marks a device as unschedulable: while set to "true", fleet rollouts
+	// leave the device's spec unchanged.
+	DeviceLabelCordoned = "flightctl.io/cordoned"
 
 	// TODO: make configurable
 	// DeviceDisconnectedTimeout is the duration after which a device is considered to be not reporting and set to unknown status.
@@ -29,6 +42,9 @@ const (
 
 	FleetAnnotationTemplateVersion = "fleet-controller/templateVersion"
 
+	ResourceRevisionAPIVersion = "v1alpha1"
+	ResourceRevisionListKind   = "ResourceRevisionList"
+
 	RepositoryAPIVersion = "v1alpha1"
 	RepositoryKind       = "Repository"
 	RepositoryListKind   = "RepositoryList"
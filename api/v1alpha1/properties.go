@@ -25,6 +25,11 @@ func (d *Device) IsManagedBy(f *Fleet) bool {
 	return f.Metadata.Name == util.StrToPtr(strings.TrimPrefix("Fleet/", *d.Metadata.Owner))
 }
 
+// IsCordoned() is true if the device is labeled as unschedulable.
+func (d *Device) IsCordoned() bool {
+	return d != nil && d.Metadata.Labels != nil && (*d.Metadata.Labels)[DeviceLabelCordoned] == "true"
+}
+
 // IsUpdating() is true if the device's agent reports that it is updating.
 func (d *Device) IsUpdating() bool {
 	return d != nil && d.Status != nil && IsStatusConditionTrue(d.Status.Conditions, DeviceUpdating)
@@ -93,3 +98,11 @@ func (d *Device) IsDecommissioning() bool {
 	}
 	return decommissioningCondition.Status == ConditionStatusTrue
 }
+
+// GetUpdatePolicy() returns the update policy of the resourcesync, which defaults to tracking the target revision.
+func (s ResourceSyncSpec) GetUpdatePolicy() ResourceSyncUpdatePolicy {
+	if s.UpdatePolicy == nil {
+		return ResourceSyncUpdatePolicyTrackBranch
+	}
+	return *s.UpdatePolicy
+}
@@ -18,225 +18,292 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9i3Lctpbgr2B6Zkt2ptWynEflqurWXUW2E2380Epybs1EnjFEorsxIgEGACV3svr3",
-	"LRwAJEiCj5ZakmWzbtWN1cTzAOfgvM9fk4inGWeEKTnZ+2sioyVJMfxzP8sSGmFFOXvJLn/DAn7NBM+I",
-	"UJTAX6T8gOOY6rY4Oao0UauMTPYmUgnKFpPr6SQmMhI0020ne5OX7JIKzlLCFLrEguLzhKALstq+xElO",
-	"UIapkFNE2f+QSJEYxbkeBomcKZqSGTpdQmuEWYxMD4KjJUpzqdA5QedEXRHC0C40eP79tyhaYoEjRYSc",
-	"TaZucfxcDz+5vm78MvXBcJKRCLaaJO/mk73f/5r8myDzyd7kX3dKKO5YEO4E4Hc9rQOQ4ZTo/1aBonel",
-	"vyA+R2pJEC6HGrQ1+EkqLBS6omqJMEqIUkQgLhDL03MivM27kwls/q8JZ2TAVg9TvCDefo8Ev6QxEZPr",
-	"D9cfemCqsMrlKbSog8F800DASFK2SKqQ4AyAE5NLGhG9IcLydLL3++RIkAzDpqZ6DKHMP49zxsy/XgrB",
-	"xWQ6ec8uGL9ik+nkgKdZQhSJJx/qgJlOPm3rkbcvsdCHIvUUjR34czY+eotofCtX1fjkltn4UK678cnb",
-	"SBXQ8iRPUyxWAwGeJD6sZTuwfyE4UcvVZDp5QRYCxyQOAHhtoFZXW87R2sSbvLVNAJ7VBsVyNehytTzg",
-	"bE4XTTjpbyiCjxoUVZTGuVqGwQvdNBwC2DeFfu+PX7d0e3/8OoyzgvyRU0FiDcBi6nK0EPr9hFW0bM4D",
-	"PyOqqQciCQGSTBk6h58l+SMnzBx9db8JTakK07AUf6Jpnlqao6lPRkREmMILoG3mNkmkOMqzGCui59PX",
-	"DObUUw2jP0fFqEC0Usr0tJO93WLzlCmyMARpOpEkIZHiQi+6a9jX+JwkJ66x7phHEZHydCmIXPIk7hvA",
-	"X9d120GcWMi2HIj7jGIyp0wDa0lQQqXSAAQ4GQCeE0Q+kSjXryRlHeclW+fbr45rZoRHHR5Lqkgq+7Zs",
-	"7tb1VB/CoelQngIWAq/CoDjQC5xrrCQndKEp4rFepwzcrNamSJBMEKnXgzAS9sc5F/B+LBiJUVT2RXPB",
-	"U4DmwX4AizP6GxESZmzA6ejQfqscyqX5jcTIAMO83lSWy7Lv1lxjmNn6DJ0QoTsiueR5EmuqckmE3krE",
-	"F4z+WYwGhwxnj5Xelr7RguHEsD1TePJTvEKC6HFRzrwRoImcoTdcaBSb8z20VCqTezs7C6pmFz/KGeX6",
-	"NNOcUbXaiThTgp7nigu5E5NLkuxIutjGIlpSRSKVC7KDM7oNi2XmgqTxvwoieS4iIoP07YKyuAnLXymL",
-	"geYg09JycQXI9E9618cvT06Rm8CA1UDQO/QSmBoQlM2JMC2LkyYszjhlCv6IEqpJnMzPU6qkuy8azjN0",
-	"gBnjwGcZwhTP0CFDBzglyQGW5M5BqaEntzXIwsBMicIxVrgPHd8BjN4QhYF+WQa2q0crdgH3qweBp/Lm",
-	"w5jujaerxDd7VbxN2pV/WIduvKZr0Q7d3NxDRwNbm47E4u6JRfHWVIH5esjZDHqn2t+b6/pzNZKuByFd",
-	"+qwN4VqPVJjjX4tWOMG+er7/FDjLiEBY8JzFCKNcErEdCaKBig5Ojqco5TFJSKylo4v8nAhGFJGIcgAm",
-	"zujM4zfk7HJ31rmEJmEhnzIqjHRHIs7iAErY/kY3UtCMS5zQmKoVcD9wY8qJ9TRzLlKsDGP87fNJk0+e",
-	"TsgnJXCXZqfAs8YR1/GnpvLRAyOszOUi0mk5NHiRWmKFHIyBOdNwzniWJ/DT+Qp+3T86RBIwRsMe2uud",
-	"a7pG0zRX+DwhAQWPuUhBrvIUxA5Jfvhum7CIxyRGRy/flP/+9eDkX3ef6eXM0BvHdi8J0i/TrOA1KUmA",
-	"/cb+fehiWA1VqBzJ+UqREOIACyveBjVGhyw2lwzWJIo7YfoYgg+k6o8cJ3ROSQwKpiCC5jRA7N4fvriH",
-	"c/IWIfGCBK77e/gdoK63AdSXwJtwQVbI9PL2b+VJKmVe5f4rD0XvBdZbDqvq3npqunsATI0UuttcuRzr",
-	"kb6Cm2u7UDjLBL/EyU5MGMXJzhzTJBcEyUJZVOxSr16/GpgyGYA7CPian1kh8olKJZsEzzuhMIraEZvi",
-	"3LSEG+JaXi5APgi5NHU1om6AaSy+GZ2YPljuI9oM/cr4FUOR11AQtA+QI/EUvSCM6v9qAL3CNDGLKu7f",
-	"MIVysYzJ9QdNU+c4TzQhu74OCNj+LfH2FrwbxbjtOy+PNSYK00TCw8IZQVijonLXIMqFAM5E6cN2PK2+",
-	"7MceqaspkLBUpwIzCTOd0jaNuG6HFE2JmalYmir6ktjwS3pd9noqjjDjamlU3sU10IzRth4rzKFITUea",
-	"q/glTzFDguAYrplth6jBFc3vOejgc54ru+JieUFCx8+BDMQ/E0bM+x3e/cyxOLNF0dIQmyo0rrAEiqjf",
-	"shjlmZnWf+9/+C743guCZVCAQU/OBSXzp8i0KFkKN+eWHLTTgYKjG9UJim6kgd1A/1nHAGWUonYF09CV",
-	"KwBQnn8nsrQRzpMKWSxgNIVLyefoVGgB7BVOJJkiq3D29en6+2Q6gQZra9Brq7Nj1X51Q9d+9pXfVWg2",
-	"7+Mqg72Ut476Eoa3G0cCjdbd/dOQQ9ilpoX6IyhW6XlC6n84unGEhYSmJysWwT/eXRKR4CyjbOGUtPps",
-	"f9Osr4acln6sESgjkfv5TZ4omiXk3RUj0P4FKKFfEC34UKnFCt1pGLxfMsGTJCVM2efU22TrkzukTQGh",
-	"1hYF6I5JxiVVXKyCcNPgav3QAK7/sQD0q4QQ1QJt+OZga0DpAd784IPf/DL0EMxVnNOFsyg6SW2YXeBn",
-	"qgLdr6fdvX4tOPcTEgmi1up8yBLKyA1m/UWpLNQNYJDl7mDecKbPej1TdKizGVhw9vJTJogMK6/0d0SK",
-	"Bsg8I/AC6LHjPAElB02JnJ0x/UzZFlSij98g+7+Pe2gbvaFMC3t76OM3H1FqBahn29//bYa20S88F41P",
-	"z7/Vn17glSY1bzhTy2qL3e1vd3WL4Kfd517nfxJyUR/9h9kZO8mzjAvNlWt+BOsrrZf6Ua/YyXiaWzWK",
-	"nSdktphNYRjK0FIvuRiPXBKxgt+e6nk/bn/cQ8eYLcpez7Z//AiA232O9t9ovuRHtP/GtJ5+3EOg2nKN",
-	"d6e7z21rqYBr3H2uligFGJo+Ox/30IkiWbmsHdfHLKbe48RY0Kt7+bEEiX6ufvS6nLGXn3CaJURDDj3b",
-	"/nG6+8P282/tkQZf+INcKp5u/qpOG4+sEf+sI4Dec2ra6+sYwSpQSMHo3nF99w3Jad5583vVlpQtV5JG",
-	"OPHs36MGeDQXjeainfKFH87i2z43MASFOHIzWsMRpumsFlbg1GS6FrerIFR1p1WL95b1eJg7wVlfs6sl",
-	"jZagGYCeTjnVPw24cgVkjbfFLK4NcuJkIaWFR/fkvmFnFnbZqh8egNgBxlt5McugA6w65YQkUmkauINa",
-	"gn8QUMpOn6XqfdDo2HsfdCPN0RjqrYV7R2JA5PX90TYi/nZ7bNXh3QtVw1G2AfLA09aUMquBV6t/kyAs",
-	"JoLEre+de+yqw7lu3rh9us3qPJ2blDxpfcrtZ/9Ft6I5/BxxxkhkpdjisJv7XhwfHby0D0IY6XWL8s3w",
-	"1CS1ecLXw7DYhy/CY9vP6PDFegPXgFrZhD9pO3R9oay5tjeWNFuNF3bHHVdFuUJT2gCrwmJB1LAnw1/K",
-	"KfQLa3vMkMO25I2z18JmWoYtJlLP0NhaStSSx9Xr7utA3jMCagLQd2i5eXVMZGV9XSqGrhV7I3c1q85a",
-	"QOFQvwGCqlW/KsseKnU9Al5lhlYNO8fazJbONamb/b39IFsGau7EvhdVQldsp3l2t3wpDDIUr0Q50Ube",
-	"iK693+yZ6BirR8HZAcPCHRtLWdX2lf7L75l0Mvha+FBbcDFF8Gsxb/BruZiWz94KC4C9pnMSraKE/ML5",
-	"hYOT2/BPZM6FrwbbnysivL9Ng2NyzrnfovxhHVBUltKYOtCmvprWYfwFto3jrbkJnBvxHYnrvVE8rA9u",
-	"5741Ftb2ejP0Cw3ShnfK6t7bIFa+Ou5aGyW1RYCqgrX6y5o4WFt1HY9qnyurCHwPLa2nWQ0jQ14d5beq",
-	"c5/5XY6KnAd35fNOYpDjnlXbjV56n5uX3nQ9HrCV67uxe58Z950Me/P5X5H5dG4R2MgL6N1JIVq1MoJp",
-	"0C/gtDIINLKKJDEscMeM27mpmzyl704Gb6EmtLtthDFaf3lBF61+dDF8q49ljA5ILvHz73/Yw89ms9nT",
-	"oaCpTtoOqMJ+uRa4CgLWJwhEWT7sdlfXYbiC6SSm8uI2/VOS8qH4FRqh7heU5ZNiULu6oaBtcQzQiKAp",
-	"i9VJGmJqgG1ofDNs8J9Y2Af/QFBFI5zcOIAwtFA/PrH5tZw89NVbUOizW2Tom+9N4enIW8hSjSjhDjtT",
-	"qR5sf1P9VoMf1nqgc+CFjVriId285jvKrAF7+NxBe3nAjbjKIq6tMwJ/q4Echn1HjP7dUIcAR6iXVrnr",
-	"1g5pQWE9socDomb+DEFBrqQiadyiJjQfwbXUhVbaJTUvE1h+j7DSDKXsCgeEhiizLSubaWjmjZXZrUPz",
-	"KPAUTk0kOhfwXy2VyXw+p5+myITnLUmSbEu1SghaJPzcTQbrh9nxAlMmlfMwTFYo4TgmZgpYU4o/vSZs",
-	"oZaTveff/zCd2CEme5P/+h1v/7m//Z/Ptv+2d3a2/d+zs7Ozs28+fPNvodetP1bRcGxHPKHRQGL83uth",
-	"rtV1K51te7r8r74uOyzvSi923hITZPtq3lUJTBNjH4pUjpPSYfO2tMeyHr5hpBS11+Dwmwa9AC7gprVk",
-	"7dFr1qbhvsDFGQAcjeHNWZ40HIP+sD54h5JG5/XbRZD7t1wxBWkuzum5bqRu1CMkWKoTQtgQd117LYx3",
-	"KmHODd7SqeG+uYWu40bqmTUfgKJP5QlYl/daWzRqXEhDTQ+t9mvAAGX7glzF61CquMU472FGZVVVTJyE",
-	"EdMHo3/9imsMZ1Out4Sad9X8G9DOq97cgOzd1SUW8RUWBFQsxkGMsoV92qruRZs3LNs1OC/2zZkNNmBU",
-	"XiuTSNgm8A7cJMNJQ3y18xG/IoLE7+bzGwoDlbV6sza+eQsJfK2y+pVPTS155XNlB4HvAUGhgu1BJqBo",
-	"YTVbJgKKxnInz2lsEmow+kdOkhWiMWGKzledgq2vLgqT832vhX76jN/keX3Yxt3UwAkZtX/iXKHDF+sM",
-	"VeCg2X94ne8KRD1xiDpwgroeygdJsY/mKtrxpMH19RiYM2hp3BkxwwsTUAJ0wNBEyIQVJXmsv1wtCXO/",
-	"Oy3yOUExv2KWM9Z0ywYsNU/ctTsxfry976nZTNG6eFdu2v+6B2zxjTReZk2bt+BWht8kOa5s9mbkuDnE",
-	"GrajEmCF4Sg75S8wRMm9y9W7uf23ZzC8CR2uLNKbIvDVnzXYuWa5rH5tkNN2r4AGG+DyEVnHvHlCiEKC",
-	"qFwwEhuEmxMVLTX6FSnJIAKiU1oqb3JbKPWA8Cwv3m/a2Me5IPhCY3TnTs5X6Mxf19mkaQUtL5es81Cf",
-	"weLtmroXrrjCSYtuUn/ynDNDMw0Ml7PU73OCjmWcu6BT95QCUE0Dl7V+/rUNB6kRlRcP7fsfU3lhosCb",
-	"GJlhtWyzVwgIaFoh3cbTmcHw1TG7mQaY40M43oBKkcOs+0nCr3AwBVegUTXxF7kkiU3Qx69IrBdnOxj6",
-	"JHiS6JeLwgXJBF8IIgMyykLwPPtp1a7HSfA5SdAFWQE3mRGhLzKCbhrQhaWsnB+7Fa8XW5/iT+8ZvsQ0",
-	"0Y9wSyI5k9HNw1wHdFT0LBDD5Qg1kAg7PaeU7fdMWctdN0c5a85VHEPvnEF+J/cjfi0RmDzT2Na+oCLN",
-	"h5u78PQ2TqyKo8gmgTT5UYsOJZPo0ifECEN4C9fczKX15iL62tuxz1cIGyVOzqiaoTJiqvgRYt330Edp",
-	"go+kSVQyRR9T84OJJ9I/LM0PEDkFN7JU0D75x97vu9t/+3B2Fn/z9B9nZ/HvMl1+COpny5jLMj1jPSut",
-	"a7Ft9Ut9vFg55ontUEfswJghGtgICG1erkaTjrR1NvWCPlOzgE717Oi5MoYgfYUhSA2EWi8aqdl9sxnq",
-	"WmLEQyxqa9My/UZYRi0IhWdhQCXJave+xy4WvSMBzNWSqCURfsITtMQSnRPCkBvAO/NzzhOCmbXPwNf9",
-	"FkcReESwspFR/gRXWFbGHmYdcD1+Wg1Kqq3biuBtBe7nNnnN951SzowEeUiyLFk5mtjQQrVw6MUBDbpa",
-	"YSfIYLOqP2Sjyfi+PLhnZPBMBtkMm1zI6C75pSY1DL9+/TQAXI6o8TUqGpr3o9F2Szr3RrBjB/zipAgT",
-	"3FAKPT8HszQ5TfwHKkBYq24Rw0OL74KOu4xLVgpAV9SmyrekncrC1q3lcX2TvYeYytCL2UL7NVSHHXmL",
-	"qryl4XreI4OehpKjWYsuFazQ9bQ/9Zt/l5r532ZrZ3Vrpiojt6C5HX4a66Vja8qiHedqm3Txh0t+ZXUC",
-	"mgQC1tmiIK8SulgqdKBJIk/8a+q5ZTSLG2iyGBV6i7XE6v1cQXJ4T5rO6TbpjKp9f/zanc77wxL/8EIv",
-	"NJfGxy0T7hX5v8dIXxF4/RPKLkxGFJjPvV0dJsab6gva1AY1eJUTtMJg0JUAOPZfC1enokzIaN/Y6rIq",
-	"l8aky7/B1TBDb3soue1exBriQUMvsdULrHC5TB/NIbIYuAXslq7HR3OaQKohdPr6JIz4ZjEXZNW5iF/J",
-	"aq3JL8iqb+46srdApbnEQQc/nCQMoAwucFyjBb/hoXv70peKC6paQV623XdN26HvcwnFyKiST7kNgUmA",
-	"GTGcqH5/gXjEsSCyMB73bhw9cUzlkkulpci9jAs1IHyhA0DFYoMnDw4nDdVma2pKaO8yUvYvq0hxeD2d",
-	"vKIJsV4ThqQ7S7DNYguOW6nNWOecs4bZfitDHxTDVX4+Lsau/PzeTWRX6Nja2v3jTJG2lyNLMGVIkU8K",
-	"PXl/+mr7x6eIi3qSZzuCuwoau9tYCd3upe5mnc9rzgT6nTVpGZTR5Qot4MAsM/TGlu0iFHQpZxNY3NlE",
-	"r+hsYtZ0NpmhF8YMAI9a0cg3z8NPk6nt0jyH66mx7YRBore3JY0ZZ+qZAeyywBrgIpdYnhJBI3T4or4s",
-	"wbkyq2oKQjwmnVNnRFhvfMiePkP/wXOQD81ijI9OqqW5OU5pQrFAPFI4KSuZYXB/+ZMI7tKYPfvhu+/g",
-	"bLGRZyKa2g4mJ0Woz3fPnz3VAqrKabwjiVro/ygaXazQuTVqoCLye4YO50gLoAXEpsZjp7oZeBb0PrUM",
-	"UAJMLy9shmo3SeJzyZNckcIi6S5nLasNessVMVxRkVcZ7HO6Kcgm5wTxSyKuBFWKsJZk20R0Hhq/gizi",
-	"G78vIetpgWpBugjeFs21vrKuGp4hxcpt8RjpO9pLRnuJ1wNwZT0biemyWbsIjBlWWBefqkpq+HnE5IfX",
-	"TJcHMUg1Ymj2qIL+UlXQcL7HxvWlTRXZbLOeFtL6Ypb+NTU5wCjzWipbnrqSks6bpwwiPCfOb4fEaA3X",
-	"nZKIhrfaoV6HrfSq1O1Wh0UZHlca36bEpSJplrSqYN3XWqKEpgNlXWq9j/Sjdb/p8MNT94B0+2292J03",
-	"+sZXeXA0JrSeIgK8N06SFaKl36qHGkt8SUBEAW1K5KrPQCABqegyoDzR1ZKGMiytrTAvTvz2wYxxw117",
-	"nSwiU4cxg16jKrVaU0MPpTpodEwyXji4Bq1Lc6jyUE94OKCahRvaJX7IRYtD85OMQ2J/zUukXJGnEO5i",
-	"ygEMSz2ih7ZtgnsNptBv6GEWVB3r7YTWKMicCChhC1rGn6mqRsfbOkgBssFzpo4KEdn5R+403CN1G0eC",
-	"zC3akkYCtsF6NRcTB6EtacTr0jESpqzY5soHtl1Y92V0mwPBrqYsztCS2td97vdXKYeqlA1r+tfCs3JM",
-	"LqlsLSYj7FcIFJNeKdzO9TYStBaLb8w6bfOEng4seV9LJdG/Gpt62F7E0MSQsy5ySs7SJb0WKTTvDPoG",
-	"TYurwZ8SFfC+9SoxDyaMem2dxFHRlFji9shcg9GW3Kp6Bm+lW1XPYC0PbS23bu8dHODUhtYKKW/Hcc4m",
-	"1x/AZ7/6Y8DR+PI3LG7jXvCSXVLBGbzPl1hQcC6/IKttI/NkmAoI+tOb8dzMc6ZhHC5wmLfgvBZANKCr",
-	"N9SPKMRshbBY5CkwMrmEaGeFWYxFbDJ0ILliCn/Sl0fLUFDt0CpJJUptURc3k0QZzaBW2gIcCKf6RlFA",
-	"7xW6IsIrWJ6zmAiE0TmWS7QdGR36p7A7yBUXFy9oi75SfzRxIC6iw2w3ly6AS+SMOQnSLnQAqctZK0mp",
-	"lE8bfteKbvrxepf114fx+3g1W65719VV4GW/Ut6lJG5E3z8IdeRIiZzooyurPQVpng0RaXk8Q1tu4BNv",
-	"sVpwZxR6Ip8iPT+o2LECcw5JrOHFvMJ6CxIrKq0pAX4tlj5cZ1ExigUI8hqqe2wV98K/lgWogXGPlpgt",
-	"DM29BZjD6nSehe9uUXCol4FtvIYe86YX+cvp6ZEJitWUICBV4FkkAm/XT2DDckYyJDhXthh/gPmS8oqL",
-	"uI0BM1+N90KulsZa1FxX4UZcjBeyIV/QzKiNfiOiCDUL2JQvaGb5blfL89LrEHaJVokcBIzT1yfG1wFq",
-	"/g1duh79gqyGj35BVsMH5xdtyV7g02ag315r9dTWWAU+sW+ufs5g0lJyq0GWlkplA6UbZlYyTL7RVOEo",
-	"SEZ6BRrFPYHGmbCLSGWb6QCWIom+lyV/12UHXEccEU1xxEkT2FZGXrEIdQgqJgFYaPOiMMe/P35tK+ry",
-	"VJP8ubKBCOdYwtcZOlQowsyyMQT9kROI4xQ4JQqU9Xm0RFjuobPJjqaIO4rvOKXvP6D136H1EANlReQp",
-	"ju/+pRx3I9vo+g1VE8vKkzCsWt3QAp2DVRpwa+HcOYpwkuh3M0o4M1Jq8CZBtXMTvdxyp/R45r4ZVpCz",
-	"xCTacF01+wtVEsvSvoUkjN5LsCCAk5C+4O5mGgYY5CR4u+yqHb95vnIH7NKL6rPQTDWshEjLR4OZfkmS",
-	"zNAysE8VOypSFCmVFcaKtdQ6U/9cQzfmMMULPyOao4ZNStiSPPbYp4GOIkHRI5v5NVCMCGU4uhjkq9Se",
-	"HLe12GJz4SbzT0eOQ8NT6jsHbkrN4kGD2ca29JV3SxLsDkNg6ixoObBM1vrLnE4kzDZUL1iuEpmOvQrB",
-	"m6sAzQQD9X7DAFKuOTiAzHDUMQp87h0qfPLl8FMPQr2WD9u7PKTQ1anah0LoA2kCnLnJ2uvhN/MQ80sQ",
-	"7K0zTml1RuYGyDwpM4y+NiEWxjquomUpuNp66m9fkHiGXqaZWu2wPElqs9tynIhxtaRs0ZLw1Bu1D5vf",
-	"1NtDuoJipbcKK0lxpjf+1wVZTUHZc220PeGwkObBOCtu0Eivv3j5hJ39zUrHK6aWRNHIy3tdSKK+PkiT",
-	"RnMcl1hQnsvCjAXLkDO07yW+xSsjysLTaotd/1Va9KbILew6aHZSlOUBBHmDV6CVJMqqjkACgL8xSmhK",
-	"laPUZaIGoNQFN2zUi7QIZ61E8BABoazgb2jKWLkUD+aGGjUclYhn+I+cFJ4b7olXHFEp4QMUmS/iV+1D",
-	"6HkXYGOBA7sclebdUVwvU1ByaZgKRj4phytlsokC3AcGTCb9UMSZpBIYfxhLL8s6KFijEHEgszutSiV6",
-	"307tAElUBPgRMoTRnFw55aw50wzq6xRICyfu3GoME1TNkmR0h7BPd7QWlM4l0WSli0xuA1VC2tqRqYC8",
-	"CDLjTJIpylmiWbMVz816BIkILUBphU/w1GeI9HhCgzczpoyyxaEi6YGmmH1FHGV+LvXBMmUvl10nAL4s",
-	"66jBb+WQ2DRxB+22Ao6kRU93WRy7FFuCBl6koFt1lA3cTev3vNiHW5REuUl/BffUAFIP44CekLlCOQPk",
-	"YTHiqRYFC62yJILihP5plBeVhcI5GsMBemJ9P89JhDUzTOEzWJ6XOQPtKy+/Agis1z1kUoNGT8v9CGJB",
-	"Z25gfU9mI4Wy+UY7cS5APIlBesQMXe7Odr9HMTdOvUR5c5hbTrVIDemlpSfy1u+N3tk3RCqaggjxjcE2",
-	"+qe13Uc8SWxlPmQCTgrfMT2vIEAp28Y2kgRQA1Fo7XE0LEFV6M2oPWdN1i+oOTK5fG0yIJ962iffZBgE",
-	"H6n2pI1c9Gh2ywB5ICDwyto33Hm+H7LJdPKWK/jvy0/6cZpMJy84kW+5gr+D3vDGoa5lX5b5N22KZOPr",
-	"JDCqcVUahN6mPzTBPiDTeqmSH+5kVz9ck+To0HTdbUojb6Dsw92Ulff8eBp7Lb9p5KlyJlraz/SzIjUy",
-	"B7kTQ2wtkYX8S+55BMbAtjUyXMBTlDGuygzmN2TeysaAnc1U1g3Mg/VQzk5pSqTCadaRDsMkEwc/xiv9",
-	"RJuomeE5MGKSkJvMZSkrdF9nvgVhRLRoyPeReTaj4tmqeHFiZ22OUDlKmefOlNs0/nHoiGd5gr08rkau",
-	"m6FjguNtzXQOTNx365DwN4Zzt86pkCHN8MiGhoC2EjOfReRigZl+FXQ7zYUuuNB/PpERz8yvhpw+LXi9",
-	"yY11itZZOUiLrxgJSnGeFy1WiF+BowN4Q5vftVSAzsApdEfPdTZBBtJtBat9DjFodbT8tAUiTGsTFbts",
-	"uIZp3ZKe93RZo6h0yh6m6j/S1NFLyVWQ1DW0o73WSS9Rnv9u4diE0GWJkdFNMF3wrQobFffR/zl59xYd",
-	"cYAEmBXb1KB5ywUx3LV+Y2Pg9u1qZo33i2ddvjv1R+SIiIgwFVQKlt8c/2cP29ycKiXIysamVQWZ/+vJ",
-	"7rNn/w9cQP7x+7Ptv314+r+CqeGObcnoeimbwS+a1/Gl9e24ng5TkO2zinZTN5pt1EGlVUt7/eH6w7Sh",
-	"kQ1Colb4rKjJbSnQfLuURGQlzaZBuXAt+fJ6uFm7Ch4129xqUbZ+4LrVSnzmr1IzXnEUkyzhqzVK9oQv",
-	"3Rr1k04LhWpe44aB8B4uWOEQ0EZzo7IG+qBSINC4VlPp/goqrVdBvrgRrihCRqLOh2es1PR5V2p6uJpL",
-	"VWNu9Rp+CFI0z2oZoGXlV/fI+TnWRcWb1vEDC6qsTS7IAxx3GOErPsBerOvPVPkGeX1Q1hPBtxiOUXNj",
-	"/OsY/7pTItF6QbBev81GwpYDh8Nhq9+rMbHFNzrGuH8GkbGidhwDWYmC4o9Bsl9qkGyN6nQgeaMYbFU0",
-	"qDIVw2THesRar7O570PW1/hELsu2PVtviaWst1gvoLIKkVsGNFYHu9/Uf06m2E+IUMe2qFKtbJO/gyZT",
-	"v8xTzLaLika12GNwwdJjh/Ns5m1qXFenoOBxaWqSynguNfiSCLwgplAGWMzPrbn7nMw10sPElC1m6BWc",
-	"5153bFF/1FBXxNDZWfzv7SUEsg611alJ6+O0UXxud2QMX4IuFppQhiBpNNzG8emSDKmsWTnvE9spXATK",
-	"jegdU2UfVQVQ7+WqTBZIlma+Nu6ME2GCNbuhYt2wvGCtaykHbm3izdjaxizF27ST0vVWqd5qSpmzSqY4",
-	"y2xGr4Oj961InuUhe5cpe9MqibaUxHHmt1ZjXqtx7rogcKu3oIecWKWB86sd9iC07KaP1Hetq0cmb4HE",
-	"deCUOmvlhev+4EpMbI0JdtS0Sy0EjZDQrWbonXNhMr9m4HBkUYIWRV/WVhWVZD1UBsc7xta6+hUFVlVh",
-	"1PS+xGmWULY41Cx2sNxAQdbPiboihBUqMeiqAXEPlLoI7OyI6axkLvTgNPXPNrDjLjJ4smJBLqz8Wq/L",
-	"4nmrgnub9ZkyjsOQVMFTwShu4h/Aw8seGIhZtFAzjqLaqI4Z1TE7Psqtq5Dxem5aJVMO7ZQyI74+sGrF",
-	"dl6xaO2nF6j9qFz5cpUrNRrS+bAHjM76EX8inxbPtk373aVZ6EkHY1IzNeK+KWtElx1CzSbXYmrLKroO",
-	"JdorTJnxrg9xFMZqx7i+Oq431Tj9EkdLGwlTHco4WbkB9IJ9tqYbV+83UnRIShvnLlaktmlC+q4y2gTe",
-	"oe77dwMdl9//lloufDNS2pmexil7DniaUtXmRAyu7roBWmJpczVcYQnn3xJ85Qb+ucPLsBjccyIMjD3E",
-	"Z3odZZ1JIWb9WIh19A6V7HeExlbiMK5+RQ43LRh5eQ0b6omauC+VwIosVsNlfUiKeGL9MEFDW708xYjh",
-	"KD1bUNa1sqjbj0zFsB3AK+35NWzxPzuto1uJLZNfT0BX15NCujDjBHBaJk/q1FHkZbqPuHmsAxIw1i/D",
-	"NZxnqOxvj66k0QXi5SFA+XQpiFzyJO4bxnPOC7pUnMjlhvJ/nJz80pX+IxP0EivyK1kdYSmzpcCStOfx",
-	"MN+NRkEuj4q+n0f6jsqSetNs2J0DgIZn2mg5rBsG9Uv/mHvsOHcU0q+3X3NRcQH+XYH9XSHt5a5C5KXt",
-	"FbYvLzUKHZULZll7fdsinLgCXzFnWy6fBjKBfZ5j9sCSHEOsMeUTb6QH50rcwnRhGTb7pDhaUkZap7pa",
-	"rmoT2ALheg1nk1eYJrkgZeF4E/xFZRn/SNJMrWy8FoR7VXmWMmpyHx3DMlGUYGG8uZ0vkt0slGc6zzWU",
-	"iQkc45dECBoTRMOWKdl9nM7xvQAeegfhp3vobHJiiKYrtFHs9M6FJZmRaBuzeLtRi78LzU9tOtpW1UKt",
-	"QVVH6TvIF7l6R1XjqGocVY3Qo4Y862kb6503q3CsjR52BAs0qnqD1RqMZoaHV1uGjmSQvF1/Ckbt5Zeq",
-	"vQyRpT7cbziJVd5+GyjRzgLMw2WUTp1Aja6WXHr5/i2+z8H3hffz6mb8IZstaO+wCC0/4f/0r9s6e62Z",
-	"3alTBWZv9fBS9wVwr7A0+iuHGANjb9fRVzUixILnsJ5OstiAvXszOF+akv/kLteWy9n+mhuPndoaNEz+",
-	"1BJgEfsppPUtgNkO99/uu3jB/eOX+zuv3x3snx6+eztFVyCK6B+rPLDJNwIV/QTiEcHMvCGuZ5HgGrJb",
-	"Y6FolCdYIEltYVxqlYdYEDw11WM/gT8E2of6ZnjnLbn67//g4mKKXub6/u0cYUGd20jOcHpOFznPJfp2",
-	"O1pigSNIWuj2Wisth56cTX5+c3o2maKzyfvTg7PJ0yB5Mpqsk2hJYusYWFczli+2tK1ckkyujzFCMb9i",
-	"Ccc213Nsr5v0U/4omrqvPDMKBmRTjwd4iV6N2oGo5ioGXkuonwWOyAvP3XCoVk55l6vz7XTtGjQ6RJR0",
-	"I33bXSYiHMHGSIppMtmbKILT/z2HEqGRSmaUT1woNiB2rXjoKcHpxOpCJu4dq/RuBJT/Xh3iwxPv+Vvm",
-	"57OIp+UI5b+e2kfelvXQZx0TLXVjcNXxKn/wuaHqgLckXpR1W2yeGCogc7a+HHJ2pt+vhEaEGTWd3et+",
-	"hqMlQc9nzxrbu7q6mmH4PONisWP7yp3Xhwcv35683H4+ezZbqjQxR6j09Z3UwLZ/dDiZTi4dazq53MVJ",
-	"tsS7NoUIwxmd7E2+nT2b7VpTDFxB/dDvXO7u4Fwtd8rwykXocfuZNAofVzyrZ0XiDsrZYay3nCunZYLg",
-	"QkjhA/M+f/asVn7UiyLd+R+rpjHXse+yerPAVazly/hVg+C73R8D/HoOFr+ynAaJjVYBL2Sg+PQH/a0C",
-	"MJtlkrSC7DfbAIJ/q6CDpEthkLlecFAuDyu87IFk2YFRtUTglgZvs268JDgmokS9/UZl7QLY9WfyQ/jw",
-	"aouBmWFaAPiz3bY2lJWtBh/LdPL9Bq+MqQ4cuC2HVnoyXLtrNuxK+LWV6YJRtnD8u9ljQlTw3YGsUF5x",
-	"5xPT2WZbqBqSq5fF9G3tKu8S6wr5vQ3jzAW42+N6z2xN6D+JvXXf3v2kr7g4p3FMmLmV9zCjrUX+nhV6",
-	"4sqlbL144MIdJEwgXd/ozumenTeuk2RB5hLLFxUNNb0y2S6d5wSUvi1EZJv/20soaMUPGEEPAEmLTPS0",
-	"qjfachn0tmwONKu2zwS5hKSM1QRzjl7CgkpyWWRY7CKU01D+HpvmyziyKkEjVeaFA7csm/jPpWEy6Xmo",
-	"MEnDZLUWMLkkYlVk5wwtNKlkHL2/1QJs5dQx5pDGzmbx0iC+IGjr71tTtPV3/f9QsOZf/r7likmfTS7I",
-	"avfvcG670wuyev4v5o/nlp0P7RRmvNlO/aI/fj5Ac/GKTfpZCssMhKdlRkhI+mTS37VftEp3ROfVWw4V",
-	"p82gtVSPUNluSVijqlCJOOA17SVXBAi13gyaQuR8CSffo+Pb5yGPjg93+IK0UhFQ3nY8LPfAB/yEY+TS",
-	"HY2P2efzmGU8pNc/MCnH8YAXrfmgmc6tPSdGACZS/cTj1d1ffgOyUuZWIifXDSzcva+FhAAdj2h4p2j4",
-	"3bO/3QMaAv+u5eaEGs3y5479g0Stnb/0a3fdJXGZ36vUAtm7j0qsX0vUGiKq+z69/YTKZNKCUoPuPbf1",
-	"qOxzbvPPVynFDcT4+6ciX5WA+N2z7+5+xrdcveI5ix+xRCoINim3S1Y36sC2KnYeExzfM24ubNnmWyPm",
-	"dJIz+kdObKpheO9HXB1x9TNhuLGKwuViouUNGW7oe8/YmhVpyTf1kA4VCbZh6n9f7ywr6XYHCQQPTB5G",
-	"WeBLIUn3Inw8JrFjOsnyIL8CGaBrLMvBGiwL9L9nOmhcFh6EEN6bbuRBSeGomhnJ8UiOPxMt0A7OMsFt",
-	"7p4gFd+HBibGnLBVF0fbZGSNS1lrh303+cYouclq7i94pOQjUztS0c+Dij5qjbp1aBzgqWQ8yPvdkl7Y",
-	"EUcfpK/BbGvuT4/DUf/V0c3KizO6Eo2uRKMr0RfiShS4IzYvBJoneAGlgE1ZQpOkSa8mTbFYVYON5Az9",
-	"U+8EQMURMLY2UZEFC0Cyku8JMN8O5oXl2IgTADiUdtsyt6ly77dKGNUjT6DS5pYdWA+1BalWRN6K+l7b",
-	"0C0r8mTcqf3H0NfRyer+Xuu3XLmkt5/he93jU1V7tNscqEyzO/KWsoPfs2uUP+uobBv9oB4CPZsi2gAP",
-	"pxfOw6kXd31RbV09VW3wx+Ww1I7bo8fDl+7x0CerQqBjP+4cExxvDHM25k40os2INnfPMnZ7BfWiDjTc",
-	"GO6Mzj0bxN+Rmx2NHl8O+9zivGMst8MeeXDT2RitehQOOOuI2/dHm0bRfiSGIzG8C13Cjlf9PigROQcU",
-	"SA+lW+r/Mpuku0kyobErkn97mhk5VWRzcptM7XGITQ4io/Q0Iv9nhPwxgaIS0iU1DXJMRUq00hJnFH5e",
-	"36Zysfy4QRVjOeijYKN8KIzi3kjkvgoVUTu1EYTFBC5/R5o5Y883DadIkmS+bQ36JHbURzbqmQ4Q534m",
-	"6tiO62VC3Yj6trLo1kVuimRNW4v4XDB+xYqF/OZSi4YdEqDxcbXt5KG4pMDJdAiD3zWvzluO3EJGQjNy",
-	"Uw9C38pk+J3Uzc8DvIalyXq8jvamUWIa7U3W3rQ2OnnWp43h02iDGoWSkY589nSkwxh0g1fZMw1tjJCM",
-	"BqKRcIyE47Pl9gkTPElSwtSAbPll40rYQUgr8bJoWiTMH0xJ8MDkDyYwCjQlDFEp82qOLahamAl+SWMS",
-	"T4twKU2gTEjFkkQXiPYFKVtFjQxPAhEWEM1CJYqwJEXQB3UaFBsxU4cIlDvCSWLrSeq+U1tBqYCyP5EJ",
-	"nIGVnxNTf7E1IkuKB1N6NA5+JG9fLnlDnxV9KxEnGBLc+DwkOri8zoPrFzS6jDHDX0fMcOj+dYUPr3W3",
-	"dI/gzRqDiseg4jGoeKxPsAZnNtYlGB+r8GPVHTvLOp6stjjaRo87CqltznPP0bUtCxi9ccdA289ZBloj",
-	"/HY99G8RhtZVKbdP+bgCdAeRh9EI/KXrYNeQESFsdz2cOyY4vmOMeySOFiO6jejWzuV2hvuuh3LQ6Y5x",
-	"bnTGuBu8Hxnw0YfzEaeVbiFuXQHC67IT4BFyx9TtUXiI3FC98CCEbdRqjER1dIx/EDXKDTL0B0hykxLb",
-	"XndAiR9dDv7GFoq6BA9NkasLGVnOUbz9bMnU+lE9G1BE3cyneFRHjfj6FaujboWGYeXUXeDhqKIaVVQj",
-	"/RlVVLdWUd2S7QgrrO6C4o1qq5HxGRmfzQgq84SQQe74r3TDfhf8V2a80e3+a/BkhMvT42rfe290q+LW",
-	"jC71o0v96FL/pdbpOrQBmnpjJeRs2hu9HoKjJQKq0rYOHNv8NfKA50w9XO0rIFmjH//4+vXXvao+gW3u",
-	"+tDqjlz0zdj37JbvTToarUdX/AfAzIacs/MX/Pd6R5E0S7DSHJGknHUKQLGrgRXxJLHJojV7aIdAxRhh",
-	"iejUtvutbNarC4Eako4HbUzUovmYewTk4e0uo5j2WMQ0YDH7b7PmdT7juzwdpcVRWhylxTEAO0Q5a3Rr",
-	"FNvG13AN5nBAoGbBI9YfuGFM4a3f0bt7RuumuYEzf1Y+QHVoj4awr9AQ1sMFC4JjwwIW718vLh8THI+Y",
-	"PGLyiMmfyws+vKB5n1LWM2ev671SHfpxJUtoVdqOaPWVP5Cmlnkf2ugncUNIs0EH81ZLpBZp0xSLlVuG",
-	"Z4zUfw60RZ6YQR7YGjmi7deNtj211PtQF9ptCHdHp/TNoe6ojRod0b8Yk2xfGfV+/gL8zDdEph6FJ/ka",
-	"zhv3RpVGP5GRCo7hOBvUWfTFBYN6sozOqSoqHTVsEcVuFoNzpwLZKAuNstDDyUL1Al3DJaNNodIoH43y",
-	"0UhCPnMSkgffYZA/1n6KS6llUyRklF1GBmDE3n42W5CMS6q4oGRInOuxa77qD3Y99ocefam/Bu+x4jat",
-	"euJeh90j3bR2i8YQ2NGpeXRqHp2ae0lYSWFGf+bxRXIvUk8sauBZagtILZveUVSqN8E9h6bWZx7tDmN8",
-	"6kOhbIuoso4v4yCkroksq3U1EIFJHpdrYzfSj7qBL103MER0M06Og/DpmOB449j0SExsIyqNqOTznN2O",
-	"h4PQyZqYNoxPo51twzg9ssOjG84jdsOpE65OX8SBbACY9jZOuR6FeW9dCf5+qdWoMRhJ5EgiN6ecsFas",
-	"FYuGGVJN+5MVi4aYUsvWoy31a9Fclzeq15o67DIZe2rZdrSnjvbU0Z462lOHsXgl3RgtquO7VL5LvTbV",
-	"wOPUblWtvE53I5V5U9y7ZbU+9ygpjbbVh0PeNgFmPfPqIPxuCjLrq4ICEz02I2s3/o+2oS/fNjREqnOG",
-	"1kGYZUytd4BXj8bcOiLViFRVlrTP5DoIsay98Q4wazS8bhy7R255tCs8artCnYT1GF8HsgbW/HoHNOyR",
-	"mGDXFfbvm3KN6oWRYI4E8/aajOvpxKj5DVHLRTLZm+xMNGGxXeqU7p0jlRLNuUD62hCm7C5mXiLLyodJ",
-	"0z7hDcQZOiBC0bluTU7oglG2qFdplt7gUdlamtaiQJjueUxyzeCgJk1n7wjtdaT9wZolcvvGDRQ1reTp",
-	"7uvfFhxqB/FM8P0jtRlGi7G8W3T94fr/BwAA//+/DjidaOwBAA==",
+	"H4sIAAAAAAAC/+y9C3PcNrIw+lfwzfluOdlvNLKdbG7WVVt7FdlOdBM/jiQndc7K5wRDYmZwxAEYAJQ8",
+	"m6v/fgvdAAmSIIejpx+srdpYQzwaDXSj0c8/J4lc51IwYfTk2Z8TnazYmsI/D/I84wk1XIoX4uJXquDX",
+	"XMmcKcMZ/MWqDzRNuW1Ls7e1JmaTs8mziTaKi+XkajpJmU4Uz23bybPJC3HBlRRrJgy5oIrTecbIOdvs",
+	"XdCsYCSnXOkp4eJ/WGJYStLCDkNUIQxfsxk5XUFrQkVKsAejyYqsC23InJE5M5eMCfIEGjz96zckWVFF",
+	"E8OUnk2mHjg5t8NPrq5av0xDNJzkLIGlZtmbxeTZP/+c/G/FFpNnk3/br7C471C4H8Hf1bSJwJTlTKT6",
+	"jcA/QszYpQm6ZprIBTErRmg1YPlbyi54wohZUVMuWhuqLK7mbCGV/cZ12HdGDsKBqKp6cEEQICaSDZEq",
+	"ZQoQp43Mc/yu2AVTmrXaWWxyw9bxPXc/UKXoxv5t19W94siCB+2og5UqQy65WRFKMmYMU0QqIor1HKFs",
+	"ABfZ8z8nUrABO3y0pksWIPOtkhc8ZWpy9f7q/ZajZKgp9Okmj6ABv1kkUKK5WGZ1TEgR7LxdEBPFevLs",
+	"n5O3iuUUFjW1YyiD/zwuhMB/vVBKqsl08k6cC3kpJtPJoVznGTMsnbxvImY6+bBnR967oAqOoZ2itYJw",
+	"ztbHAIjWtwqq1icPZutDBXfrU7CQOqL1SbFeU7UZiPAsa5BZF7J/YjQzq81kOnnOloqmLI0geGek1qGt",
+	"5uhsEkze2SaCz3qDElyLusKsDqVY8GUbT/YbSeCjRUWdk9HCrOLohW4WDxHqm0K/d8e/dHR7d/xLnGYV",
+	"+6PgiqUWgeXU1Wgx8vuBmmTVngd+JpZHCsIyBjcRF2QOP2v2R8FEwtrrzfiamzgPW9MPfF2sHc8hUpGc",
+	"qYQJQ5fA2/A0aWIkKfKUGkY4HjOY0041jP+8LUcFprXmwk47efakXDwXhi2RIU0nmmUsMVJNnvUP+wud",
+	"s+zEN7YdiyRhWp+uFNMrmaWTZ8PhuuraiB+ZObbI1aZjS6oG7hq0GFJMy0I55C0Z7FTJJRU2b++VI7Wt",
+	"N2xz9Cmx16rUhjx5/PjxLldc44QiAJ2H8sSdsg5M+M8kZQsuHCYyro2FG84MQjxnhH1gSeGu8u6zqzvn",
+	"O6iPizOCXKdry+/bfqSzq6k9kEfY4UkEP21USGm0UTQ/ledMxGCTgu0ZvmbE2BaWYOmSCUMUSxlba7KQ",
+	"inCjCRNKZhlQcmJXvbBsL4IH9iHniumDDjqGqaykmZHLFU9WgHecOqHCohsnZqkdeiHVmprJs4mlaAAz",
+	"xu5MfG2nfuQZeSOyDaxiRTXwJW2kYumUcGOnFdLgzDQldEm52M4fcc5psNz3W9F/zFK2dtDFgHW0Bpem",
+	"3wYjHUIIJXM/mltWC/eJVvGR3754tcdEIlOWhrtHNF8KkP2rme1+dO/1LriPgDsMp3YZu2DzmOlcCt0h",
+	"/cYXQ7jWBUvheNPyzA1AcTXEdlQPxmMDEeEkQxDRwfLDM2UkrnjIMXJn+iiyrz/JS5JJsewm2ylhs+WM",
+	"nE2efrs6m8zIc7agRWaAmT79lqxkoXR1B3zzmKR0o4e9ISaHFV5O8OR2rr2zKVEsV0wzYTRsPP6Ix8CS",
+	"Q4NCFkquYbGHBxH5LOe/MqWjBH3w9sh9q10xF/gbSwmydiQ5riuw3IsEeAAufUZOmLIdiV7JIktJIsUF",
+	"U3YpiVwK/q9yNMAy3GTU2GVxYZgSNMN3/BQec2u6IYrZcUkhghGgiZ6RV1IxwsVCPiMrY3L9bH9/yc3s",
+	"/Hs949LeTetCcLPZT6Qwis8LI5XeT9kFy/Y1X+5Rlay4YYkpFNunOd8DYAVed+v030p5IMpOzrlI27j8",
+	"mYsUpEmCLRHWCmXcHcjjFyenpcCBaEUMVk11hUyLCC4WTGHLcqeZSHPJhYE/kowzYYgu5mtutD8vFs8z",
+	"cljeHChypjNyJMghXbPskGp256i02NN7FmVxZK6ZoSk1dJtw8QZw9IoZantpp5Hp69FJXaDOsYPAI+j6",
+	"w2D31qOkojd3VIJFOsjf78I3fuE78Q7bHM+hl+g6m47M4u6ZRSk515H5y5C9GSR1d44Q07+NrOsBWJfd",
+	"a2Rcu7EK3P6deIXXVNf39zdF85wpQpUsREooKTRTe4liFqnk8OR4StYyZRlLiRTkvJgzJZhhmnAJyKQ5",
+	"nwXyhp5dPJn1gtAhrqHejiVSpBGScP1R2V/yjAua8ZSbDUg/cGLqQmr5+uLCfPN00taA2OePUbTPVDFc",
+	"f92wYdiBCTV4uCp1gkUvquY9jkE4s3jOZV5k8NN8A78evD0iGijG4h7a25VbvsbX68JYu0jEYoEHqVOi",
+	"nlPNvvu2lPFDef/tz4cn//bksQVnRl55JcKKEXszzUpZk7MsRSVLcB76BFbkCrUtmW9M9C0MIqx6HbUF",
+	"HIkUD5lTzPgzgX2Q4QOr+qOgGV9wloIqJ0qgBY8wu3dHz+9hnwIgNF3GlFDv4HfAul0GcF8Gd4K1a2Gv",
+	"YP1OU+hehA0iGH6A7ZLjRpjXgQHmHhDTYIX+NNcOx26sr5Tmug4UzXMlL2i2nzLBaba/oDwr0ArmzADl",
+	"Ki309tagXOgI3uFtbuWZDWEfuDb6Gm9wN2L7OTet8EakSFiF8kHEZbkrKu4iQmP5Da0dLPXilVehkp+t",
+	"RYAkQUPFrOFQyQv7bn7OBGcpIugl5RlLa+dvmIW0BGNizWUpPr4nz/682qZODdYWPRvluN0rr7Y1ZYby",
+	"DJWHUjBCLSmW6qWkUAokE2M328u09rAfB6yuYRqg2pwqKjTMdMq7bJ22HSoZYaYSNFP2ZSnKSxYudzyN",
+	"JFRIs2Kqdgx6lY5rpi0fiahIijUVoEuEY+baEY60YuU9jx06l4VxEJfgRRmdnAMbSH9kguH9HV/9zIs4",
+	"s2XZEplNHRuXVANHtHdZSopcitrCuTDffRu97xWjOvqAIV/NFWeLrwm2qEQKP+cjPWilAx+OflT/UPQj",
+	"DewGlq2W+hHNXQ6CaezIlQio9r+XWLoY50mNLZY4msKhlAtyquwD7CXNNJsSZ0oMLaX2+2Q6gQY720Yb",
+	"0LmxGr/6oRs/h2bNOjbb53GTw1qqU8fDF0awGs8CJ9Pwn8gOYZU8w49gMuPzjDX/8HzjLVUamp5sRIKj",
+	"KL4w8K83F0xlNM+5WHpDnN3lX60QDEMkUiRupnf2UeSs/jlLfJtXRWZ4nrE3l4JB5+dgdXzO7HuIa82l",
+	"s7//Rrnt/lKqV5QLwwQVCfuNi1ReTqaTt0WWna6UNCYb7CXwolQluzs5wFTnvT2kTYnmzhYl/o9ZLjU3",
+	"Um2iyLc47/zQ2qHwY7lb4Y/Vzr3MGDMd2wff/P7AH7WNxA0KthN/CDcVfxm8tfh7/wZjm8Y2I8Us+NK7",
+	"tPgH5TDD9I/cRLpfTft7/Vw+ME5YopjZqfORyLhg15j1J2PyWDfAQV74XX4lhT1Nu7mAxTrjwEqKFx9y",
+	"xXRcx2a/E1Y2IHjb2f+APiwtMtDF8DXTszNhb1PXgmvy+1+I+9/vz8geecVFYZh+Rn7/y+9k7d55j/f+",
+	"+rcZ2SM/yUK1Pj39xn56TjeWI76SwqzqLZ7sffPEtoh+evI06PwbY+fN0b+bnYmTIs8leJzJnClqycOC",
+	"+ruF2D9FrVCN+qevrJFmCsNwgUaZcjx2wdQGfvvazvv73u/PyDEVy6rX473vfwfEPXlKDl4RI8n35OAV",
+	"tp7+/oyABs43fjJ98tS11gaE2ydPzYqsAYfYZ//3Z+TEsLwCa9/3QWCaPU7QOaG+lu8rlNhb9fugy5l4",
+	"8YFabyaLOfJ47/vpk+/2nn7jtjQqiBwW2sj17R/VaUsWwFeq80Sza15je3scE4CCxPSgXtywZ/85y5hh",
+	"hzKzjJFL8RKfX20i6GhIsNWcoU2s1ELaVyookZ2yMIXuaVs875SGf1ttam4gneO1NmCYP2OoHOl/BsN4",
+	"/XJbEzvHTOPraQsWsR1RzFIgnj5ZmESunWtSxkDupyQpu9gPtV1t+rACYjrW73ygghFwry6ZquF0gEDv",
+	"Huq6y3pcGx/JKyXzovtcDFKsd53XbY9Uj5b45tlLN7ZZ9ve62TdfbTRPaBY4IY7GmtGyO1p29yv5ePhr",
+	"3PW5hs22m45b3sjtQIn4BdFQv3T4vkexajtttrFcp+NiSjsHNqrQc2zj9cjbpwF/+gjLfR0ydmhDvOan",
+	"VKjERw84+rA9i/vNd9yZiJgA8nKWQRtY94yOKY80NvAbtQInbftXv+N4/TxYctx6HrjASxG5t9XDeRYD",
+	"2qlgvtvRVPW7zTfxvRWrlRdvl2TSbkWsfzHeUfhZkwUYK+cb6w1m2zrHXIHGiJr/rhQ16UJIg70j+I+b",
+	"40+b8049oWAwDM7DlZvWfVM7mupxhmhcjDQv7bSDfJW9ScgDDAtPJSwbzBE3cFjGbgFA3duMj+cuejkM",
+	"9OdFM2ypK5ZAMZEyxdJOscZ9aAznuwXjbrM21efpXaSWWafE5j6HgptTlsLPiRTCidIBTbfXvTx+e/jC",
+	"3fvxI2BbVKJBoLhuzBPnAqhNOHoeH9t9JkfPdxu4gdTaIsJJu7Ebaq7asL1yN7CzQVC/3Wld39Xt/m+o",
+	"WjIzjCpDUE6hX1z/jkMOW1IwzrOOF7WTy1Om7Qytpa2ZWcm0ftxDrfQ7ge67oIFOjFSbY6Zr8PXpa/sg",
+	"Dkbua1aftcTCkb3qFTeb7cYFt6nc92hvo7t4h+1jY2Z3nbUvMfd790Z2DNReCX5oMLpyOe29u6FAgMRQ",
+	"CgPVRLciCvSt/XrSQM9YW0xOPTgsQx+p1nX7SxUr+E5or27ciR4aAJdTRL+W80a/VsB0fA4gLBH2C1+w",
+	"ZJNk7Ccpzz2e/IJ/gNjewFZwsDBMBX9jg2M2lzJsUf2wCypqoLSmjrRpQtM5TAhg1zgBzG3kXEvuyHzv",
+	"W6XD5uBu7htTYWOt1yO/2CBddGecNbQLY9Wt4481WvwcAbStUNUvO9JgA+omHTU+16CIfO8ykPU0a1Ck",
+	"7nzGtN2t8Xc96use3Lk62Ikbvs9GxeCD+k1Pd5MBO6W+aztcO1qXS93JB+RS41mY23czS4nV23humtmv",
+	"esUhmwaoNJC/PtIYQhnxH0S7A0t7g1Tt2DAAKduX8w13E8u46DKuZHJJ4POUyCxFz2C1m4ah2yHrN7TX",
+	"pOE6LJJqS/CXzgnav4N8CztdKHKpjwGMcJzmNzeuXYIqREKj1q3fVsysGL6THU4AQ86ypTBlipFEG7qB",
+	"hCRcVAt8pInm/7IXqyXcgD7mUmaMiojPY3UQAtcu3LPus/pGx2MBwq+BRdXCh29b8uakVAN0PlrWUTvq",
+	"aW0QaOR022pYQgcct3dR1xH73pwMXkJDweSXEb997JfnfNnphZ/Ct+ZY6AtA9Io+/et3z+jj2Wz29VDU",
+	"1CftRlTpo7QTuipL6pZHa5IXwzhxHQ6UYKeTlOvzm/Rfs7VUm+uP0KSwvJiUgzrohqK2w63QEsImR0SW",
+	"Fz8im+l4OpnfqHLC6aHixhp+r51YJgZomLem/bWaPPY1ACj22QMZ+xb6YgZmuw621GBKtMf0XVksuuW/",
+	"sNVgIbCZ9ytynyUdeXL8vPid5M6vbPjcUTe2SBBS/Tmzs37TDiIHSsPuHkGTIHKHyOvFglY76849yKHC",
+	"xXMNR0TDKymGBb3Rhq07LCbuIwSm+JQ7DqSIX5C1Lr2lxjAldF9qFGhIcteytphmF5eZxsNh5Wm4CqeY",
+	"oUwq+K8srAi/WPAPU4LB/SuWZXvabDJGlpmc+8kAfpgdUn9o4+MTsg3JJE0ZTgEwremHX5hYmtXk2dO/",
+	"fjeduCEmzyb/9U+696+Dvf98vPe3Z2dne/89Ozs7O/vL+7/879jttj1vC74u3sqMJwOZ8bugBx6rq04+",
+	"23V1hV9Du0tcN6ODnGqOmRDX176zjLJCum1IE1PQrAr3uCnvwd41W22lFtrhNdr2MYjQAm0bcHcevWEA",
+	"Hx5JVO4BSsTgC1AlK6TxaJoQvUNZo48Z6mPI25dcM1taKc7rZK+lGofnE9XmhHVlmKkH+7hjgbEtTPgg",
+	"OsendnmyOdXVtVSJO14AZZ/aFbCr7LXzM751IJGbHjlN7YABqvYlu0p34VRph79QQBk1qOqUOIkTZojG",
+	"8PiVxxj2poK3wlpw1MIT0C2rXt+nJTirK6rSS6oYqAPRb9sqtnDZff6ht+Hr4mDwMXC3Z+K6BT+XnTJM",
+	"xu1XbyB6IZ5MMjSRvJVWuZC+WSyu+RiowRrM2voWABL5Whf1a5/aFp3a59oKIt8jD4UatUeFgLIF4UH8",
+	"NE/1flHwFBMtCv5HwbIN4SkThi82vQ/bULUZZ+cHQQvnTF3FQlfDts6mRU7MAcOmybKeFzsMVdIgrj8O",
+	"5xvfiJx4Qh04QVNnGqKkXEcbim46aUl9W5whcmiJUQZU0CWGo9qRnEIbEkMnWZHaL5crJvzv3uJhvb3l",
+	"pXCSseVbLty5veO+nVcLbuMeuJiydXmvXLf/1Ra0nWSy0/ZUtSAcUafQmS4QsB7pMH8YPDMUyxjVjMiF",
+	"NfE43BGdSXO9TImBAOPHttS4VFTY82YHruJ7vQ8PR79813644ONG7VfL+psLfArrSyyNWvZEYL5F91XI",
+	"y5g61k4pi7zDLct+qqV2tevUK6oQLcHEeoqq7tpd8EiTRcaYKd0a0azmG2U2IyvuLDR7pEnKtSpyfOVk",
+	"mbykInFgaDLfzOKyYmfG2spl2C/AzYwLcwEVHlkVJt1marrGMzAj74RmhvBFsOymGpY610yApxZQU3oG",
+	"N13K3GZv4y3ptdTCCOXtu+TUhr9NmaW22OvJLO0hdnAGqBBWegLkp/I5hUQUbwrzZuH+HXiAXEdYqQEZ",
+	"TBH5Gs4a7dxwRal/bckc3W5eLVnZEwwXFX0SxUyhBEvxVlowk6wwqMrpgyD4t1elUJ3krmxFAwKmgpQa",
+	"09Y65orRc3vt9a5kviFnIVxnk7ZbS3W4gFH9CrzrxqAPhHa+6WKYZMkvmPDLeQSs7IcNZLd+RHKq6JoZ",
+	"pmal/7TXxFUDeE6VyELUOVW1ZN18W30E++Vg6t8rIw3NOq5z+ylyKYQzDYzZc1LRx4Qd96Duw04r2a8B",
+	"q0ebPpv731hwlAFzff7QobrWtoW5pdpMKKdm1WXHVAzcfIltE+jSYfj6mP2PCZjjfTw8uBRsDrxcE5F7",
+	"243qydHZBctcQQd5ydJQXAKWbB3FLK1zOCC5kkvFdER34ZhGt34XGcU528ArM2fKHmQUmyyiSwt6RFzb",
+	"zXliTT+8E/SC8szKHfENchUAakG3iHRS9iwJw5fSQUzE47PWXBxsmbJR62BBCtGeq9yGrXNG30FFmEfI",
+	"MYHJY0tt3QCVyQP93H4rKAZiGEkSVzQEywiVHarHo0/KlhIKkbhSc8MvnEcys8fejQ2uPC5dPLeed2WC",
+	"g/JHTaiyIf0acwVoTH84Jb+v8QcM/7c/rPAHSHQwm9QMN1/949k/n+z97f3ZWfqXr/9xdpb+U69X76N2",
+	"myoJS1XOo1m8ybfYc8+2beJnNeaJ69Ak7MiYMR7YyhDTPlytJj3JsF1CN7unCECv2Wb0vhyjpb/AaOkW",
+	"Qe0WON3ufrt5rzuSRsVE1M6mVVK/+LO8ZBSBYiwsfKC682G75FQ9aSUvA8WTG4isqCZzxgTxA8Q1S/5r",
+	"r3qNGhfEHU5gDYjh2MOUZ77HD5tBRdhsWxVXKYFi6gbl/w68st6ruKTTMTme2NJOd0jo5QYNOlpxR/5o",
+	"s7pPf6vJeL88uHd/dE8G+RK0eo4u/59tqvT47bedB9hmuNFBQ7w/Wm0fae/2bK9Uds2aRz4xd0/toyhu",
+	"04a71PAsKHfBx72dxxuELnmWhayd69IHZsVEs2oX17Ebs4P3d5Vg6pJYBmz6NbzKBl0NlUSzE18qRSHr",
+	"47RLUad2VunZzrmi2wmQ2Q14bo//1m5Jnttv0Z59dU365MOVvHQ6AcsCgepcEdmXGV+uDDmUwiiZhcc0",
+	"cNdqF8Nkwjjt287Palv60q4xeE0XfI/1ZoZ4d/yL3513RxX9YTRNodH3NVf+Fvn3Y2KPCNz+GRfn8JDG",
+	"+fzd1eN6cF19QZfaoIGvaoJOHAw6EoDH7cfC1zWt0ry7O7YOVu3QYBGuaxwNHHovIMk9fyM2CA8aBplu",
+	"n1NDKzBDMrcDoLRAPeh2fLLgGWQGJae/nMQJH4Gx9bb7gPiZbXaa3FYu2DJ3k9g7sNIGcdDGD2cJAziD",
+	"T35iyUJec9ODddlDJRU3nSiv2h74pt3YD0Ym5cikVqWli4BZRBhBSdS7kdA0VUyXVrWtCydfeaFyJbWx",
+	"r8hnuVTm652qCDYRVAIb3XlwRGupNjtTfEJ7n+d+O1hlAs6r6eQlz5jzpkKW7o3frjYGOHSuXQpr77Q5",
+	"zNxdG/qwHK7283E5du3nd34iB6EXaxvnTwrDum6OPKNcEMM+GPLVu9OXe99/TaRqlo5xI/ijYKm7S5Sw",
+	"7V7Ybi4opV2V0aUWMqjLVYy4WWbklSvzzjjoUs4mANzZxEJ0NkGYmpUay0ahRwL8NJm6Lu196Pfnsct7",
+	"pNGMMw3MAA4ssAb4iEZRrJniCTl63gRLSWkQqvZDSKasd+qcKRelAzWZZuQ/ZAHvQwQGjd5rqRhZ0DXP",
+	"OFVEJtZqW1a+pxb/5F9MSZ91+PF3334Le0vxPZPwteuAeZVifb59+vhr+0A1BU/3NTNL+x/Dk/MNmTuj",
+	"Bimzl8zI0QIM5iXGpgBnYzFwLdh1apIGCLPgxc1Q3SZJOtcyKwwrLZL+cDYS8JHX0rgkwWW1FrDP8cy9",
+	"TeaMyAumLhU3hsV9dAxb51lU7g6dzjylwKPRd6lyk9Xgwt1a0MRoAj4odb1XWZ/0zz/JDN9ss58cZyVX",
+	"V54sgq/g3aBnmhtsMCPHMDGsFQp58AVYTxZMMZFYsxhNAFbYILGcEUy+7ssON+AtC6iW/WEFf/5JNHQj",
+	"Z5CH8WxCrq6mRMtSEN2UzhQ5VSUbgVpTNapZ0EyzuJa00Ez10oy8hNJQt06uMeN1yemi1xL497Rhfemc",
+	"gwI7lns2p2OykNFcNZqrgh5AK7uZqLDL7ZqlYMy4vaD8VLcRwM8jJT+8YaDaiEGaKWg+WgA+WwsAFtxB",
+	"z6MTExXjTles0nO6dMNWRMJru3Ja8o+MY9SWVUWUJtPJTzTD19uh8y4a/AgM4KsGDn+tJgl/LScMfwwm",
+	"jyw9pgRvt9lN/91CUp37AS5dlsTdQhB8zDCMgPLkAsaBsC445BDOYiSZM6hjgeGLmOOc12qCBm8KGO4t",
+	"E/FX6q4QwTPI2HdE4VzNe2Y9MVQNyehUn0djL+JVtA7Nwyz+OG+RJIylt7EDkOPIcRgMrFIl3uMrd4NA",
+	"uu+uYrV2eAdFlddgzrzLIEtJt6fiwBKH8YPsHUD9itsOihUqBfuAq9i6f7ZluHk63Lup9eawj08kajJn",
+	"5hIiwW17tkPWLu152dbLrcb8gvdsp1gCq3GNvDDStx/BgynOfuNG1/JTh6HVxzz1G1fdng3LQ3Fca2yx",
+	"6Oq0bb2X7APbF3XrVwrUsFdPpdV2pW/qL++jZkYzaCguAzdalevtvGN7b5hrXy2D83VA6ylhdjmcZja4",
+	"r8bRXAuyohc+ukMYWqbMg1BTVtNqQ/lroNZYfr4dTafljt883UXailXaJSfi1FPMrryjPxFC7FhAKVie",
+	"HLNclqEOUT8D0P40UTykWqof2qcGK1RHaMtXuYSajxui2FoaZovA+kqRw5LT2aFdm+hao7UPWxr5JTfH",
+	"bBGHsdSuob3pR27q+ZNcne0I25CFMG9LZan3lN9vOcrbNp4FVRGovMxU1HQ29BiyimnbtXKRX7dCo6qb",
+	"qVttG2prcWkemqpuZ3TICpTtnovVUH2V16Yul/8xu+Ddt6ByX0Hm1Kx6mPXC2yo3UQLfmnXaFRMztLhc",
+	"I9nY4Bpz7iDGJoYM3Ik3d1XBSfVDxxe9aYEwdtiZddbMROIw5oywDywpdqnKZmHrZY6Gr5ljbp9YkAh5",
+	"pB/VY0QerR/VY0SsxP1o9ejmcSIRSW1okdfqdBwXtoI7RG/Vf4yEnFz8StVNHM1eiAuupID7+YIqDkK9",
+	"dQ5A9UtOuYK0EP+DSXB9wFEhGi/B6pSrooPmrS7EIrp+QsOcE9aURNWyWIMgU1j7ib3sRUpVijnciN4I",
+	"Qz/Yw8Mth2VZ6s1lmqxdHV8/kyY5x5yxSzAnTe2JwlD6Db64PBCkEClToKLQK7KXoNHpQ/zBcinV+XPe",
+	"YTqxHzEi0Mf24XIL7aOXVSGEV2Y5QAewukJ0spRaef7hZ63sZi+vN/n2wr5hn6DY7tVWuPoq8x7U6vJW",
+	"zI3Z8wdx/pIYVTC7dVU18SjPc8GCHZdnbMktepId9mvp3QO+0l8TKZyxlRow7LPMmeDxFrZL0NRwvdhU",
+	"v5agD1ef1twjIgx5ByMudSZcFR7LEtUguCcrKpbIc2+A5rhlT+bxs1tWit4qwLZuw0B4s0D+dHr6FjNC",
+	"WE4QeVXQWaIid9cP4M3g3SWIktKQw4MO4UvrS6nSLgEMvwI01uEG7bhtuEodRDleZC59znPUYP/KVBl0",
+	"3J755JznTu52Miy5CDrEzb4m04OQcfrLCXq92YfzYNDt6OdsM3z0c7YZPrg870oHCJ9uB/uFZqpbRvRf",
+	"t841QIfTUSu9xZasYWHg60YgJMPeN5YrvI2yka0PGiODB4130SjTdLgkEgCKZvZcVvJdn0fILs8R1X6O",
+	"+NcERXOf3oiE9DxUMEVsbPGVR4V1A3a1sNdME7owzi1lTjV8nZEjA24cKMYw8kfB1KbKh6GJLpIVofoZ",
+	"OZvsW464b+S+tz/9A1r/HVoP8ZWoPXnK7bv/V44/kV18/ZqqiVXtSuiVRqqWJWXdkkoDTi3suyQJzTIi",
+	"FUkyKfCVGj1JFzTjKeax6DhTdjw8bygKSpFhKjbf1Yq/ScJ0aUWutnpG3mkwZoK7qD3g/mSiAAzvJLi7",
+	"HNRe3pxv/Ab7BPR2L8TSQcK0k6PBYWvFshx5mcv/4lZUJrE0Ji/tpjupdabhvsZOzJFNvh/kzPXcsM0J",
+	"O8oLHIc80HMkygVTrjZApIIuyWlyPshrtbt8whFkfBzCwjm07MuCjTKlPXOKgX6zWfF2sNjYleD8blmC",
+	"W2EMTT8Xc6YEM0yjM1w/qm4LzOkEfeiG6gUrKJ3z3VaF4PVVgDjBQL3fMIRUMEcH0DlNekaBz1uHiu98",
+	"Nfw0wNBWy4frXW1S7OjU7UMx8rENiDc3Odch+A0vYnnBVOUXWDnAkNNaXkfIAg+TaeeoY5JV9XBFRdLB",
+	"6+fWAeTFOjebfVFkWWN2jd2IkGblTNaRlPjBqNuo+VWzPSSuKSG9UYDhmkKGxT/P2WYKyp4r1PbEAwTb",
+	"G+MdSqL+QvZLUHHC29/c63gjzIoZnlTbUb1EQ32QZY24HVY1JQtdmrEADD0jB0FpBLqBAfBqlQJO85+V",
+	"RW9KPGBXUbOT4aKIEMgrugGtZJCFUTMFf1NMueg5dWXvB05dSsOoXuRlYoNaLCdTkNQAPM8BQ2WyHzyh",
+	"sDP2VMuc/lGw0onMX/FGEq41fJDgnOszGbiLMHB0omiBs53spQ/3jpEWTMXZRWBid7RSQlKh+xDRhLn3",
+	"Eik01yD4w1gWLOcr5YxCzKPMrbT+KrHr9moHSKcFyT2psOoKdumVs7inOVQLLYkWdtx7+KEQVE8RiLpD",
+	"WKffWodK75yOeYsTzHJjKkw7OzJX2tiZcik0m5JCZExrspEFwqNYwniJSvf4hJgtQdiWmBiIa6HcKgGP",
+	"DFsfWo65zYNEF3NtN1YYd7gcnIB4vFa8P7h7h6TYxG+0XwqEFJQ9/WHx4lLqGJpUDqslZ4PAg+Y5L9fh",
+	"gdKkwNyPZeJVHMYjPWMLQwqhXWZWueYm0CprpjjN+L9QeVEDlOvScEC+cm7oc5bQQjPC4bNderIqBGhf",
+	"ZfUVUODiryCNKDT6ulqP8hlU8QQ214QL4fomK/HeiDJL4fVIBbl4MnvyV19d3o5SzYGnnAvDoN5doYMn",
+	"b/Pc2JX9hWnD1/CE+As0g0JoYJl3Rc4ACAw9LN1YXZbgjedekbHxJQHcQJVae5oMS1UYuzMa11lb9Itq",
+	"jk7L/JE2DjLgnu7KB5keROeetN5SbdHsVqlSgIHALevucO+eeCQm08lraeC/L2zIi7YJUCXTr6WBv6Nx",
+	"URdl/s7Iupzwj23KcjS7pLJrSFUWhcGi37fRPqAWT6WSH+7v29xcTHd3hF2ftF8jr6Aw2O1nboQVM7W0",
+	"qpFkFeRDi0tKRhWsLRz9vydvXpO1HYXkgJGvjl8ekv/7m++/+xopq7SAk5eWZjXSsCQgFVLkIx3pFqaT",
+	"wM2otRXVN8KbgpNVRuRMwa2bxoUnvAvcHQCJAv3tDXKLa4tPzIhPvRDSVCV4rilbVo0BK+1aLC2EADxc",
+	"ilO+ZtrQdb7FExB7QrYmXMoOyZpSlrHrzOUYP3TfZb4lE0x1KPAPCN7qSXmr1vzdqTeGJ6QapUrIqi3N",
+	"O/c98lbmRUaDQgT47LQBazTdszLxwAyzN85d8gofFvgZU3miCI8sDpSpVIQSrFRLauMgoF1CDVtKZf/8",
+	"Sicyx1+R239diqKxU4SuZikSZN8ChmcJjcXGVeS+UrJYrpx0u6d5igqmDRiagYWA7M2UtmiotiZ8tsN4",
+	"zktOIXbkJaZNWA/d1Wsqf7F9/NK0oX+x8xpEXlSQcl3+bp9v5AwCCfZd5CKeuQ5BuCbKR83D7uGDnXBa",
+	"V3PEF7ZA/D/SQcRNVW60CuQZZpNp3hrRmyG4E7772+OnrTvhoHSpZ9oEMsYCQkYtzJcrmbm7pXbD7qAs",
+	"32qsDjLohmIMTTG2Ps9QZYM3lcUO65Bg4qZmh4u3eMTB2NylHC86TiN8AskrTbGEBgA1a0k1Mu/z6GpS",
+	"7VumEiZMVFVcffOvAney8JjWGXBeNcZWNR76X189efz4/wPHoH/88/He395//X9FU8f+eyENfac7a/tC",
+	"kmdPlH/YxtqfXGSMpTU3j7OxIUPaPxhNVsSSZq2mpItwh1Y7V5IM1rZNMu0OJTp2wdzNGqGDBcGg4wvn",
+	"EmXdWeroSlnORKrfiB4taJCl0Q/YcLnzYSNztkCFBddh692SUMf59IEIRwTAZrfqatZpb7FeZ9OWbSW6",
+	"OY0i12U8vrusF3uVTkHXUqfjyW6grPNI9BW3bbe5EVCucMGulSnDZ1z92EiSsjyTmx2IKk4HO9TKPV2x",
+	"hprNv2vhZj5aitK1p+tSTqTQcmgFxEPXuFE/9/6K5yLGOgWIRuFx374sgJezpEMyAQng6HkcxUfPqxGd",
+	"vhXlWhRpLVPwMggqTvzEU4t5kkilWEbLoFZblL8yvmCUBBWp/wmSjvUKUGPx4I+7ePDDlQGue4/UqSV+",
+	"FwduEhGWW3318lNY0UjV3Pe9xLnkxjkBRMXL4x6vn1rQQZDnwwZxVJPBRjnXp9BFYcwYMOb+GHN/7FdE",
+	"tFsCkKDf7WYBqQaOpwKpf6/nAym/8TG/z0eQFUQ1tmOgKFFy/DFByOeaIKTBdXqIvPF0o40XTF2oGPbE",
+	"bYbIbo1uCZ1WtzU+0auq7ZaldwRvN1vsFsFdx8gNI6jrg91v1mn/pjjImDLHrs5vU20TrKAt1K9sPoq9",
+	"Mh9FI9kBvJ7s2PEU70WXYcaXyCplXL7GfIaBDx+9YIouva4N2Izzr3G6IZgYcgC+hP181h/MuD1MsS9E",
+	"8ews/T/d1avyHo3oKWaUdN8t1nBFaGlXfLlkSkcxiTarCXhaXjCotj/wDQn7feI6xUuu+hGDbaqto66n",
+	"2nq4apNF8vTi19aZ8U+Y36gSmCvoUHHwG5pY99+FHJiNqBOWauDOJsGMnW0QlGDR/pVul8rtUtdceDeI",
+	"Nc1zl6bn8O27TiLPi5iBHSsudr5EO6oxent/p/dApzfAVcngNq9BXTpxSgPvyD/sQuhYzTZW3wfXljd5",
+	"ByauIrvUW749XnKS1oLwG0Kw56Z9aiFoRJRtNSNvvM8k/pozRTwBgsyFXGpnVVHF1mMVGINtjJvgnWIh",
+	"DO8JFEZtd2+6zm06oCNhmIpWuirZus8O5IYj0JXpe+HUZSR5TxB5LWl2gKdpuLeRFfexwd3NUBErlEvU",
+	"3zQitc9gXIi38/i+JvDl1GVqXlRldaR5HlRyvRzTTwIT2n/UFpJQ4XxmCuFKpzvfUaGHl+PVLL0leFZU",
+	"D3Wsq/l7oUQOgPTtfnc+jmYLfGTlVJvQju7TCzdM3W3ek3T62oW+B+BEjU5N4L6XBvmLazXvYU7r6NN2",
+	"JUJdOvsAtFvVoXUJwcpER3G9eV92krazTd3tYUWdjt+bDwacFB1l8acBVmvzUON1EAhovOT0QBemEocO",
+	"10Odl1rq4zK5SjVzr5anfrC6dD3tVk2NT73FqPZ5eLVPdE92kg18z1ED9BlrgHAPTjYi6SZ8+7VZjDgI",
+	"zJOCleEhGCMJ+eMC44+RGOptZLXrQOncjNxiNASNhqAW77Ukt6spKOh528agaujnii9MP6uAJoG2eOWy",
+	"72T2tRbkrbQsIfCSJylfLJhy58WeiYpF+GiqPrWq8/vur1VS+dRRHXqKt7OwLEon5Xg2P13zD7bVkKV2",
+	"a0Qiqi2nDvzUp5wgZ4D1mc8xOoO/pJ5BRDu+sYY7q+32ortBJHPPEPHHj4skdjjddsa8GDreCQ9sOHSd",
+	"NyLZWXgEiWIUHL8EwbHLeFhv0fD8tIKizdXmRUNXT7GPwdPCyEOpFEtMxy3kOX2l0KlytweBzI7vE1kY",
+	"CAlp5yvC+DBXEAqpnKvIfQTxqu4es7vNDUTFYjQAtEjhMrTnVEAKXpcA0/XHqz1+AW1JJouJnVtZ49oX",
+	"pQWIVi0cWGWHissZyoUHsy2kowuekJZSfG9uWdgL67MOgDSGMqtwAAtw+FLoZ033m2dqSEJcH81VJsaN",
+	"YPo5OLCDegs/ItsgOXj8TQklc0VFAp6MhkJ1TaNocj4tc01yq7vSkBMw58L5Nyp7cu1qNVtTYXhSua7S",
+	"pQ5kibPi8eNv2N+fzJ7OHhP4I3k6ezx73KGs3cWBMSTn0I3xtnL+RsTXfpZyDaN82P+GZnl6vduxP7t5",
+	"nKmdugNcCygNpE2EqSltDmVX17nXkftGVuDt64cwdlegOKQzCI46sGiqHWDRk+oH/rEnVLMcPFAOR8Ye",
+	"oAn2s/UzBCTlKRCyVO0lmcZiG7DAP+2GZtYhoSNx2fV8NVo02lmTFOkz4GkBSGWiLoQe72lrAAKWtYfL",
+	"P5uQrxyft7nXv4ZGOpSKXX/Hq2v8b2ormXKxh03OJkHnJb9gooZTK00LyFSIbMslhT6baLa2wZ2GLveA",
+	"UdbGWfHlykIR45xwo3kubnuG7gThIifTAMzJtDXjjh4Gze05tVP94GfqavWWi0MPQFebEwDslC6PESx7",
+	"JrCMgYvAYS7ZRMTwWn+uSxfPW9aRWEgVFolpeSw0PAC0UdSw5Wa4+R8qzJy4YGtw2qqz53LEKDE60Ihv",
+	"5QSA7SRVDhslqGaFlcZ9FH72jkgeErzzW0UwmjoLIBvcw9MqgXuv20JRpRxO29s6oAhM8zBcwX6qAtZ1",
+	"YK3oVGyvdf880gXE1kKzH9B6/wOW9tllRbqAHIunK8X0Smbptr5BJGk0SONEr24phfHJyU99GYxzxS+o",
+	"YT+zzVuqdb5SVLPuVMT4HcbVevW27PtxZCCugbQ1U7BbOSBoeLLgjs26Zl5SHW7zFs/QO8pKapffCHrx",
+	"OUr7cpP2ZeWsVhXjTl1iMv6O6hRMuuXUKRDYRrPMPYtTKR75lMAEc5MFyRsG1pcf4t9ZyeCosfHh7x0v",
+	"P6rjjqRrmqy4YJ1TXa42jQksDtwFfzaxtfgKBeIBvroxfxXXVQo3ZvMGupRTXBMh64+KKvHbATkGMEmS",
+	"UYV5Dnx0k1usJQ0yLyyWmR3JgHOq4ikjPO7xoPu30+GyQh55Axn0bNbiE2Savjx2udI7V1DpnCV7VKR7",
+	"LUVGH5mfbi3DVm9Qtz2GqSPKcmOjCXE0IY4mROjRIJ7drIjNzrdrSGyMHnc3ijSqexs1GozuAw9vKopt",
+	"ySC1UqPjaDH6bC1GMba0jfZbYWe1u99liOgWAeDRHRfW4ZPTovoBPL2DHjWaq7KBCxx/yGJL3jssW05Y",
+	"s7SVJWfn8LEdE9T36qjdqe6tBFzLo14i16o7QR3qCeN6Lq69KtBWapzoPuxmNGiWA57B/vI1+08pWKDD",
+	"sdxQYgxQAwaLk39JwaqsaFZPD9EKMNvRwesDn9zq4PjFwf4vbw4PTo/evLbJIpnNan784qAuA2PKZLvT",
+	"UhGZMCrwDvE9yxp96CiuDE+KjCqiuWGV2hPLeNO6l/bBmime0P3X7PK//0Oq8yl5Udjzt/+WKu4DUQpB",
+	"13O+LGShyTd7yYoqmkDdFb9WTMeuy4J/X51Nfnx1ejaxKt93p4dnk6+j7AkVYSe2CL0LNWxqKasbW7tW",
+	"vs6PtNuYkFReCpvcA8vVpZW2uMpabvjaf5U5KhiIq54YkSW2KuQOVb3cGshayvyoaMKeBwGMQ1VgJjhc",
+	"vXenb9fi0TGmZBvZ0+5YiKEJLIytKc8mzyaG0fX/s8hsAZPEZDMuvdcOEvZL+ALpxZXMyCmj64nThUz8",
+	"PVbr3UrN+M/6EO+/Cq6/VTGfJXJdjVD962t3ybucOwsw39tXN4Xgn6B4sa3QYRky0C1Ll1XpaZfqmiso",
+	"/mcPh56dicl0kvGECVTTubUe5DRZMfJ09ri1vMvLyxmFzzOplvuur97/5ejwxeuTF3vW0roy6wy30Njj",
+	"O2mg7eDt0WQ6ufCi6eTiCc3yFX3isiALmvPJs8k3s8ezJ85WCkfQXvT7F0/2bTGr/Sqv1DJ2uf3IDBS9",
+	"wtzpGEcTqjJnZe5hLsVRapdcGK9lmk58FnKY9+njx/60MMyAHqTP2v8fp6bB47jtsAazwFFs5NT92aLg",
+	"2yffR+T1AtwOqorALEWtAl2CVaW+2Ml7+62GMFcoh3Wi7FfXALKe1VEHeePjKPO9YKN8KSm42dvXYmxU",
+	"+yLwoNkZuG28YjRlqiK9g/ripgGym9fk+/jmNYCBmWFaQPjjJ11tuKhaDd6W6eSvt3hkXiglVey0HLnX",
+	"E0rtvtmwIzGX0mijaA6bgJp7GXuGHmHRBiIFSiZu16jAZF9EsZSxtfaeHvjjwdsjYFHcaMKENQUBa0qY",
+	"MqhyZ+3DBBP94MEC7E/K9GY/yHRzawitT+JTqF7Vrw+jCnbVOktP7giI2PYeYsJoPKD3cJx+oCkpkVES",
+	"xd3O+U7YQwpJlN1Cv7n7SV9KNedpygTO+O3dz/hampeyEI413MMST1BOeCdKZX2NM9Spv5cz7COBdzOI",
+	"Y/hufbp8x7DGpRhM/zjOgzCAlK1xNYN4wOM7h+PYTdgjIowMYWQI98QQAqrVfCm4WHpVXxVuEnui2t/J",
+	"YdX5BDu7E1V3fK2zAuzb2VXfpYBeqvo7Ke+LoIKP4VB2Hjzw6Iq+YUARf60zZ3v2nrje1w2k/3cqlLKh",
+	"fdpgbT/v6V1kJtCmu2rHQfk0p6mEEewAkMsbU7eaZqNHvl7YI1eawVn4c8UuoARdvZyWf1oBQNXLyg/S",
+	"+6aaxsqBuKJGGMtqFE9MVQVLLpw/BUvLqi6YEoErLJGkrZc4KAzBJsQumNqUtQhjgGa1+or3By3gVk+9",
+	"Dg+c2l3NIovic0Ye/f3RlDz6u/1/q2F59L/+/oh8ZZOEWCXfOds8+Tvs25PpOds8/V/4x1On+YutFGa8",
+	"3krtSVrTD3xdrOt5PQDP5SLDmmzlASGn5ZHEGjJYOaT7oNW62ywYtVPObJkpHLRR2M4al6Cm2ZyV+lmo",
+	"DVwRDgROB6XkAEOdJ4M7d9QST6Fv8zdPY6lJ3t/hDdLJRcDOO4p042XWvsziTyvUABA64EZrX2jYubPn",
+	"HT2tuue7Z/3KFkBGVctDvKz+dg9kCPK7VbFnPDGfAvUPemrt/2lvu6u+Fxf+XucWxJ19UlH9Tk+tIVr9",
+	"MAZxO6PCaiN20vI+h2C48jqH/zQ5xTU0/vfPRd78PKpJPm81yTVepIrRKmUcirpJD7U1daI0vWfaXDJz",
+	"O4Q5nRSC/1EwV1jVNh5pdaTVj0XgtkqVSNE9CmVyriVwQ997ptaqLuNtXaRDnwR7MPX/2W0vazUr7WaF",
+	"o0IV42sN266ifM+GnGuxnvGd8bmwu3t52HxKT5rpJC+ishBUTW2IQ4c7iEPQ/555LHpOPgiTvTe9y4Oy",
+	"wlHtM7LjkR1/JBqmfZrnSrqiBFEufgANMN8WE5s+abktJKNne2eHAz/5rXFyrCobAjxy8lGoHbnox8FF",
+	"P2ltvYurGOAFhYFs212enrsRt3mbdDs0lDkd79vr4i41e85IITOXqecYfAxGNvSFmtKR7rY4gW0nOdts",
+	"KMGN7l2je9fo3vXJuHdFzohL60UWGSacxTBYhol+LTTrNVWbeqy4npHf7EoAVZLAg8Anfke0ACZrOYPt",
+	"Zz9YEFXtAoYB4fJSMPUIT1Pt3D+qcNQMHIaiUY/cwHaoR5BoTxWdpB+0jZ2yMs3ZEGQhHfkFOOQQwRiG",
+	"FxuDqRemhM/YzGV3hi+CMKWkmpKULRVNMe90Ic6FvBQlmjDMfFq2xoeaax9cL7pqSS6xGuWUJK7mpO2E",
+	"vUvdXTCuC+ODAwnZhNdFZriN84bNsEmwDBRam9vzvJ5zS0eQ6Rk5Oe5PVfzNp3qYQfe/v8wYM/vrzR5E",
+	"3trwbipc/5wuuaCIHZsHS0iDH2qb2bWJFsX6wON3531M5HpN9zSzx8qeIs8PkdCxfEPJy8o12bmnLpOV",
+	"A/JsAinKcyUhZwizua5LfuOuWpv34y0MCfcacAjPTFwThL5+N9Asc1y4l2PqXS8FoCybiM8ye5eIQZK5",
+	"YvQcguNrR9mB6VZbbrNiSy7F2QQZXcVyfbecqfqtjZPyNjNGQv/VttWe0cpFOKnjEDch+aWSRf7D5hc7",
+	"1QMK6xY3o6/r/Qnor6XxhU8/QhF9i2trQ07v8mPFZnfktOoGv2cP1XDW0S4xuqM+BHm2tVn7c/sede/q",
+	"OO3+6F4OzSd2JTBh2nJ7K0HCGStfc7HMmE8S1qZySJH8Iwse5HcS+OtmeSCNOi6uAmLUYo1arCgNbnf2",
+	"fu6dvbfen6FmeVezWmPwT8t3u/t+HZ0/P3fnz20qYkgPtZ12rP/1rVHOrXlW3+tTH9VdH89L/2E5xngj",
+	"j1zqbl7J/f7oWzkVNLw1VjW6lX8UbuUjPxrdbb4kbUSH2zj6DA6T18BB/Nb44O26fk8jnpTOpOYYpDPQ",
+	"7kE5Vle0006TOjEO07yqKbFMzad2h0+acNsbUjm7HPsgltlG5Yq40MaGI4LR2GLKfuWmVxp7hVPuZn35",
+	"zSqCwu5TYui5N7ateF5Kphp+S62NBkvv1BaqQ5AXlGcWYNAyQe5nOMWd0EuVsH6L2fuHVyff32Uxqq7H",
+	"22m8ne5CT7efSKFl1p1Z2fuiU+Ja2v8KV3WwfYdB40M35s0vscSb2tqTu/IOn4Ymz2NkVOiNxP8REX/K",
+	"oCCu9mWWoiJsWaShcjpBZXrQt624rz7eovq+GvQjD4RB6EMsjO/vkcl9EfrAbm6TyaXuLXoBXmhyqcla",
+	"gv9uwoSxbmUrnuf4zqrS9ENhnx2MIL/YyW/FEFKBKRefjgQC6x/Fjy9cU19EJfwqswEc6xvRW6DEuhWS",
+	"80DNWSbF8raF/ru6+Stqu+8bfxudj7f+yFvu9dZXTKQMCGDLze8bTolm2WLPRaaw1L85XEBOUpXzH8CQ",
+	"wFcNxw0qMt6eHOCB7gTyzhTwp+Cyrw3BgBQPyK++xGFcswyNj+ttH8xnIbIzPSrgb9tH57UkHpCR0Yw6",
+	"lAfibxdc+1Ku3TG9yCywKVlxbWQVsWeZRlTAmhLBLpk2ZMFVzPO4CgM+LqG4OW/LmvDe5ktncGCon9o7",
+	"cE0JlOS0VrQyTNlhRwr2qSTYP3Z49vs1xhqN4tpHxc50Wee6V1gLyyvvoIVBLv9xOaSOftwjsT2kh+TO",
+	"5BT4S94aPX3aXpOjZWXkI1+s/ta5GF7jVg50tbfGSD6JjLMfp5fbyDhGxnHP0j5Sq86k6QvYPGYZoxpZ",
+	"DPYgtgtZWXfX+aZkNlObaomKTYzXwAjYDBnWiZ30hvxGwbDemdjC9Ok8CgIsjM+D8VrvdLyUojz+9oBb",
+	"x3pqCOi1yEpeWnfzTZnUB25+SKUDyac2Nn+Uo1jqHu6WnAxfRwSCgwSo4/aJFBYyUulIpZ/HHcqEklm2",
+	"ZsLYOA6+7FWWVY1rKfVihsoXZdNDHHcHwqMDC0Jg0k8wngrCtS7qNb1m5GhBbMZxnlqNu08FyhOfLnDF",
+	"knN7y/cnLne2Wx2fBBLMQcg11ySxgoVPaMgbMdtNjMzIkYBQbIy3sX0RyADL4UQYkAOQzxlh69x0pmpM",
+	"9MPlCG5t/PhE+HzZG/mo+FtFONE04a3PQzKGV8d5QO167NPqoid3SG9O2fGlX+gf6/nrS42909myPaIn",
+	"a0yYPSbMHhNmf64Js4/dqdDV0uyxrEREf5c1k/758kFOjz4jb5lIMQrddaCKEcE4SJ/YmqVEYHEeu/IN",
+	"64zp1l7DXq2NiWJtmaCbZjL19YnSyXTyHEacvJ+2atN+2LMd9y6oskMDG21xObziqoE7GgTzdbTwYNwI",
+	"zxjGmVodhH15LIChlmj32ogoT8OeB7ZL/FzY9/meHWIy3U5Uu4M8ZwtLCjtB+wP02R3c+3ljuO0dPY9G",
+	"sashdvWnOxY9wldX6uNWjztKjtqe554TIncAMCaYGHMjf8yv+R2yte5G/h3P+l1tCd1Tflr5XAexh9Ga",
+	"8LlbE3bQdkCW191ozrrZ3jHFfSJutyO5jeTWLeX2pivdjeSg0x3T3JjQ9KNIaLoTTxmF+zH48RMuyN7B",
+	"OPsSnO4qqoDv8R1zzk/CF/maqosHYWyjxmRkqmNE+YOoaPa9caozT5+z5WAFUrGJsuSI0yf2ugNObCSh",
+	"dZA+NU584FH+0By5Dsgoco5P54+WTe0eP34LSq7rRa+Nqq6RXr9gVdeNyDCu+LoLOhwj00cV1ch/RhXV",
+	"jVVUNxQ74gqru+B4o9pqFHxGwed2HiqLjLFBQSsvbcPtgSovcbwxOOVL8JKEw7MlIGXrubGtylMzBp6M",
+	"gSdj4MnnGnhy5MKY7cIqzPncDFwQRpMVAa7SBQdNXaZEfSgLYQbUALyjawhY1hgjMN5+W+MCGldgVygA",
+	"tLoj938c+55d/oNJR6P16Ob/AJTZeufs/wn/vdo3bJ1n1FiJqMxN3vUASp2DP0lklrnailY8dEOQcoz4",
+	"i+jUtfu1arZVFwK1dL0M2pqoQ/OxCBjIw9tdxmfap/JMwyjPrafZyjof8Vmejq/F8bU4vhY/3dfiXV5G",
+	"Db41PtvG23AH4XBAEGgpIzYvuGFC4Y3v0bu7RpumuYEzf1Q+QE1sj4awL9AQtkUKVoymZbEpvP+20rL1",
+	"tRspeaTkkZI/lht8cLaGrUrZwJy9q/dKfehPKxFDp9J2JKsv/IKEhAtbycZeibdENLfoYN5pibRP2vWa",
+	"VqUmA2Ok/XOgLfIEB3lga+RItl822fYnbthKutDulmh3zMnwUeRk2MoWRk3X6OT+2Zh7t2RgGCC7gA/7",
+	"LbHA2/VSn0aimTNQ2vuUrM5QsKe5lWswgaudJnUmgjUVdMnUlFh+5ovNwCdt60poZqzUYyT8DqYCLpbV",
+	"irjQxqpIwHhh8WS/ctNrMXmFU+5mMPnN5hwOu0+JoedOa6JXPLcgOLjtb1BhC+td1BaqQ5AXlGcWYEho",
+	"bC35eII7oZcqYQOkuQf11Lm3a2J0ChqvpTH26hYVVPvKVbfvjgMH33nk7tiUrLg2snqo6pwlBAob1a+e",
+	"qS/6v+Aqls+idLc/LmG48VWXNYG1EWR3dvN1WdT91N6mPiXaUHQPKP07HG6kYJ+K6frY4dlv12i7HpUL",
+	"HxEn25LOAqxqVVBp3b7mBe0ODeL1QkfvVI84qvBGKns4FV6zgvlwhd5tkdKYa2JUvY0s5CNnIUX0HgbV",
+	"1s5XcaUQuy0W8kkkb/gYtTAj9X5RYvYfhTR0e564QtNlSW3Yx/8l1ZIK/q/uesr/bpu/swPcZb6GYJYx",
+	"GOjhjxqckfpRUyyXmhupOBuSCeTYN99sTwdyHA49Rpt9CQesPE2bLZlBhp0j27RxisYkIWPY1xj2NYZ9",
+	"DdCdew4zas3HG8nfSFuydUSupa6UHVXTO8rbEUxwz8k7mjOPxvoxg8dDkWzHU2WXaI9BRN14smx2VXZF",
+	"Jvm0gj/6iX5UQ33uaqghTzcMAxlET9aSe+vU9IlYc0dSGkkplDn7QzMGkZOzZt4yPY2RGh9FpMYwfjGK",
+	"2qNf7CfsF9tkir3RGgNFDLBQ3zpXHIM3xuCNu1fX3O/1MaqHxjtrvLNuTxPlTJYbkQyzmmP7k41IhtjN",
+	"q9aj4fxLMVNUJ2qr6XzYYULjedV2NJ6PxvPReD4az3cJPLN8YzSfj/dSdS9tNaBHLqduE3rtdrqbV1kw",
+	"xb2b0Ztzjy+l0ZD+cMTb9YDZzZY+iL7bD5nddXORiT41i3o//Y+GwM/fEDjkVeet6oMoC+3qd0BXn4xt",
+	"fSSqkajqIuk2+/ogwnIG4DugrNHK/pFY2YdxjlESH20Wn7TNosket1jaB4odztZ+B/xxtLeP9vb70Ozc",
+	"91Uy6pLGG2y8wW6utrqaTpBj4y1TqGzybLI/uXpfdmlyxjf+7tJkIRWxx4YJ41Yxq7hX/cPkatozkBTk",
+	"kCnDF7Y1O+FLwcXSkUDdDOsGT6rWGlurkmD658HCAtFBsUTB1hFeCCWzbM2E6YOQla2GQlZP6RKOhVks",
+	"tvXfnqjCDQeNBg03l9Joo2hOjDxnQhO6tKeQ4OLgHgvGLVtD460TdIWpu9EC/5DtI3VZ7cuxglN/9f7q",
+	"/x8Ay9rBcslTAgA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
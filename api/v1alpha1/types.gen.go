@@ -44,13 +44,17 @@ const (
 	CertificateSigningRequestFailed   ConditionType = "Failed"
 	DeviceDecommissioning             ConditionType = "DeviceDecommissioning"
 	DeviceMultipleOwners              ConditionType = "MultipleOwners"
+	DevicePullThrottled               ConditionType = "PullThrottled"
 	DeviceSpecValid                   ConditionType = "SpecValid"
 	DeviceUpdating                    ConditionType = "Updating"
+	DeviceWaitingForMaintenanceWindow ConditionType = "WaitingForMaintenanceWindow"
 	EnrollmentRequestApproved         ConditionType = "Approved"
 	FleetOverlappingSelectors         ConditionType = "OverlappingSelectors"
+	FleetReconciled                   ConditionType = "Reconciled"
 	FleetValid                        ConditionType = "Valid"
 	RepositoryAccessible              ConditionType = "Accessible"
 	ResourceSyncAccessible            ConditionType = "Accessible"
+	ResourceSyncDrifted               ConditionType = "Drifted"
 	ResourceSyncResourceParsed        ConditionType = "ResourceParsed"
 	ResourceSyncSynced                ConditionType = "Synced"
 )
@@ -85,6 +89,12 @@ const (
 	DeviceLifecycleStatusUnknown         DeviceLifecycleStatusType = "Unknown"
 )
 
+// Defines values for DeviceLogsReason.
+const (
+	DeviceLogsReasonError     DeviceLogsReason = "Error"
+	DeviceLogsReasonScheduled DeviceLogsReason = "Scheduled"
+)
+
 // Defines values for DeviceResourceStatusType.
 const (
 	DeviceResourceStatusCritical DeviceResourceStatusType = "Critical"
@@ -125,6 +135,14 @@ const (
 	Plain  FileSpecContentEncoding = "plain"
 )
 
+// Defines values for FleetRolloutState.
+const (
+	FleetRolloutCompleted FleetRolloutState = "Completed"
+	FleetRolloutHalted    FleetRolloutState = "Halted"
+	FleetRolloutRolling   FleetRolloutState = "Rolling"
+	FleetRolloutWaiting   FleetRolloutState = "Waiting"
+)
+
 // Defines values for MatchExpressionOperator.
 const (
 	DoesNotExist MatchExpressionOperator = "DoesNotExist"
@@ -138,6 +156,7 @@ const (
 	Add     PatchRequestOp = "add"
 	Remove  PatchRequestOp = "remove"
 	Replace PatchRequestOp = "replace"
+	Test    PatchRequestOp = "test"
 )
 
 // Defines values for RepoSpecType.
@@ -153,6 +172,20 @@ const (
 	ResourceAlertSeverityTypeWarning  ResourceAlertSeverityType = "Warning"
 )
 
+// Defines values for ResourceSyncUpdatePolicy.
+const (
+	ResourceSyncUpdatePolicyPinCommit      ResourceSyncUpdatePolicy = "pin-commit"
+	ResourceSyncUpdatePolicySemverTagRange ResourceSyncUpdatePolicy = "semver-tag-range"
+	ResourceSyncUpdatePolicyTrackBranch    ResourceSyncUpdatePolicy = "track-branch"
+)
+
+// Defines values for ListEnrollmentRequestsParamsStatus.
+const (
+	EnrollmentRequestStatusApproved ListEnrollmentRequestsParamsStatus = "Approved"
+	EnrollmentRequestStatusDenied   ListEnrollmentRequestsParamsStatus = "Denied"
+	EnrollmentRequestStatusPending  ListEnrollmentRequestsParamsStatus = "Pending"
+)
+
 // ApplicationEnvVars defines model for ApplicationEnvVars.
 type ApplicationEnvVars struct {
 	// EnvVars Environment variable key-value pairs, injected during runtime. The key and value each must be between 1 and 253 characters.
@@ -161,6 +194,9 @@ type ApplicationEnvVars struct {
 
 // ApplicationSpec defines model for ApplicationSpec.
 type ApplicationSpec struct {
+	// DependsOn The names of the applications of the device that must be started before this application. Applications are started in dependency order and stopped in reverse dependency order.
+	DependsOn *[]string `json:"dependsOn,omitempty"`
+
 	// EnvVars Environment variable key-value pairs, injected during runtime. The key and value each must be between 1 and 253 characters.
 	EnvVars *map[string]string `json:"envVars,omitempty"`
 
@@ -210,6 +246,42 @@ type BatchSequence struct {
 	Sequence *[]Batch `json:"sequence,omitempty"`
 }
 
+// BatchGetRequest BatchGetRequest names the resources to get in a single request.
+type BatchGetRequest struct {
+	// Names The names of the resources to get, at most 1000.
+	Names []string `json:"names"`
+}
+
+// BootstrapToken A one-time token an agent redeems for its enrollment certificate.
+type BootstrapToken struct {
+	// ExpiresAt The time until which the token can be redeemed.
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Token The token. Only its hash is stored, it cannot be read again.
+	Token string `json:"token"`
+}
+
+// BootstrapTokenRedemption The request of an agent to redeem a bootstrap token.
+type BootstrapTokenRedemption struct {
+	// Csr The PEM-encoded certificate signing request of the enrollment certificate.
+	Csr string `json:"csr"`
+
+	// Token The bootstrap token.
+	Token string `json:"token"`
+}
+
+// BootstrapTokenRedemptionResponse The enrollment certificate issued for a redeemed bootstrap token.
+type BootstrapTokenRedemptionResponse struct {
+	// Certificate The PEM-encoded enrollment certificate.
+	Certificate string `json:"certificate"`
+}
+
+// BootstrapTokenRequest The request to issue a bootstrap token.
+type BootstrapTokenRequest struct {
+	// ExpiresIn How long the token can be redeemed, e.g. "24h". Defaults to 24 hours, at most 30 days.
+	ExpiresIn *string `json:"expiresIn,omitempty"`
+}
+
 // CertificateSigningRequest CertificateSigningRequest represents a request for a signed certificate from the CA.
 type CertificateSigningRequest struct {
 	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources.
@@ -329,6 +401,24 @@ type CustomResourceMonitorSpec struct {
 	SamplingInterval string `json:"samplingInterval"`
 }
 
+// DeleteCollectionFailure DeleteCollectionFailure describes a resource that could not be deleted.
+type DeleteCollectionFailure struct {
+	// Message Why the resource could not be deleted.
+	Message string `json:"message"`
+
+	// Name The name of the resource.
+	Name string `json:"name"`
+}
+
+// DeleteCollectionResult DeleteCollectionResult reports the outcome of deleting a collection of resources.
+type DeleteCollectionResult struct {
+	// Deleted The number of resources that were deleted.
+	Deleted int64 `json:"deleted"`
+
+	// Failures The resources that matched but could not be deleted.
+	Failures *[]DeleteCollectionFailure `json:"failures,omitempty"`
+}
+
 // Device Device represents a physical device.
 type Device struct {
 	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources.
@@ -371,6 +461,15 @@ type DeviceApplicationsSummaryStatus struct {
 	Status ApplicationsSummaryStatusType `json:"status"`
 }
 
+// DeviceBatchGetResult DeviceBatchGetResult holds the Devices found by a batch get, and the names of the ones that were not found.
+type DeviceBatchGetResult struct {
+	// Items The Devices found, in the order of their names in the request.
+	Items []Device `json:"items"`
+
+	// NotFound The names of the requested Devices that do not exist.
+	NotFound []string `json:"notFound"`
+}
+
 // DeviceConfigStatus Current status of the device config.
 type DeviceConfigStatus struct {
 	// RenderedVersion Version of the device rendered config.
@@ -446,6 +545,24 @@ type DeviceList struct {
 	Summary *DevicesSummary `json:"summary,omitempty"`
 }
 
+// DeviceLogs DeviceLogs is a bounded tail of the logs shipped by a device's agent.
+type DeviceLogs struct {
+	// CollectedAt The time the agent collected the logs.
+	CollectedAt time.Time `json:"collectedAt"`
+
+	// Lines The log lines, oldest first.
+	Lines []string `json:"lines"`
+
+	// Reason What made the agent ship the logs.
+	Reason DeviceLogsReason `json:"reason"`
+
+	// Truncated Whether the oldest lines were dropped to stay within the agent's size limits.
+	Truncated *bool `json:"truncated,omitempty"`
+}
+
+// DeviceLogsReason What made the agent ship the logs.
+type DeviceLogsReason string
+
 // DeviceOsSpec DeviceOsSpec describes the target OS for the device.
 type DeviceOsSpec struct {
 	// Image The target OS image name or URL.
@@ -575,6 +692,21 @@ type DeviceUpdatePolicySpec struct {
 	UpdateSchedule *UpdateSchedule `json:"updateSchedule,omitempty"`
 }
 
+// DeviceUpdateSlot DeviceUpdateSlot is the result of a device's request for, or release of, an update slot.
+type DeviceUpdateSlot struct {
+	// ExpiresAt The time the service releases a granted slot that the device did not release.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Granted Whether the device holds an update slot and may apply its update now.
+	Granted bool `json:"granted"`
+
+	// Group The group of devices that share the update slots, made of the device's fleet and the values of the labels the fleet's disruption allowance groups by.
+	Group *string `json:"group,omitempty"`
+
+	// Limit The number of devices of the group that may apply an update at the same time. Unset if the updates of the device are not limited.
+	Limit *int `json:"limit,omitempty"`
+}
+
 // DeviceUpdatedStatus Current status of the device update.
 type DeviceUpdatedStatus struct {
 	// Info Human readable information about the last device update transition.
@@ -592,6 +724,9 @@ type DevicesSummary struct {
 	// ApplicationStatus A breakdown of the devices in the fleet by "application" status.
 	ApplicationStatus map[string]int64 `json:"applicationStatus"`
 
+	// LabelValues A breakdown of the devices by the values of the label given in the 'groupByLabel' parameter. Devices without the label are not counted.
+	LabelValues *map[string]int64 `json:"labelValues,omitempty"`
+
 	// SummaryStatus A breakdown of the devices in the fleet by "summary" status.
 	SummaryStatus map[string]int64 `json:"summaryStatus"`
 
@@ -766,6 +901,9 @@ type FileSpec struct {
 	// Path The absolute path to the file on the device. Note that any existing file will be overwritten.
 	Path string `json:"path"`
 
+	// Template Whether the content is a template rendered on the device with facts known to the device, e.g. "{{ .Device.Hostname }}" or "{{ .Device.Labels.site }}". Rendering fails if a referenced fact is missing. Secrets stored on the device can be referenced with {{ secret "name" }}, so that they are not part of the spec. Defaults to false.
+	Template *bool `json:"template,omitempty"`
+
 	// User The file's owner, specified either as a name or numeric ID. Defaults to "root".
 	User *string `json:"user,omitempty"`
 }
@@ -808,10 +946,37 @@ type FleetList struct {
 
 // FleetRolloutStatus FleetRolloutStatus represents information about the status of a fleet rollout.
 type FleetRolloutStatus struct {
+	// BatchFailed The number of devices of the current batch that failed to update, or to become healthy in time.
+	BatchFailed *int `json:"batchFailed,omitempty"`
+
+	// BatchPending The number of devices of the current batch that are still updating.
+	BatchPending *int `json:"batchPending,omitempty"`
+
+	// BatchStartedAt The time the current batch started rolling out.
+	BatchStartedAt *time.Time `json:"batchStartedAt,omitempty"`
+
+	// BatchSucceeded The number of devices of the current batch that were updated and are healthy.
+	BatchSucceeded *int `json:"batchSucceeded,omitempty"`
+
 	// CurrentBatch The batch number currently being rolled out.
 	CurrentBatch *int `json:"currentBatch,omitempty"`
+
+	// Message Human readable information about the progress of the rollout.
+	Message *string `json:"message,omitempty"`
+
+	// NextBatchAt The time the next batch starts rolling out, while waiting between batches.
+	NextBatchAt *time.Time `json:"nextBatchAt,omitempty"`
+
+	// State The state of a batched fleet rollout.
+	State *FleetRolloutState `json:"state,omitempty"`
+
+	// TemplateVersion The template version being rolled out.
+	TemplateVersion *string `json:"templateVersion,omitempty"`
 }
 
+// FleetRolloutState The state of a batched fleet rollout.
+type FleetRolloutState string
+
 // FleetSpec FleetSpec is a description of a fleet's target state.
 type FleetSpec struct {
 	// RolloutPolicy RolloutPolicy is the rollout policy of the fleet.
@@ -1040,6 +1205,9 @@ type MatchExpressions = []MatchExpression
 // MemoryResourceMonitorSpec Specification for monitoring a resource.
 type MemoryResourceMonitorSpec = ResourceMonitorSpec
 
+// MergePatchRequest A JSON merge patch (RFC 7386) of a resource. Fields set to null are removed.
+type MergePatchRequest map[string]interface{}
+
 // ObjectMeta ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
 type ObjectMeta struct {
 	// Annotations Properties set by the service.
@@ -1057,6 +1225,9 @@ type ObjectMeta struct {
 	// Labels Map of string keys and values that can be used to organize and categorize (scope and select) objects.
 	Labels *map[string]string `json:"labels,omitempty"`
 
+	// ManagedFields The fields set through server-side apply, as JSON pointers keyed by the name of the field manager that owns them. Populated by the system. Read-only.
+	ManagedFields *map[string][]string `json:"managedFields,omitempty"`
+
 	// Name The name of the object.
 	Name *string `json:"name,omitempty"`
 
@@ -1067,7 +1238,7 @@ type ObjectMeta struct {
 	ResourceVersion *string `json:"resourceVersion,omitempty"`
 }
 
-// PatchRequest defines model for PatchRequest.
+// PatchRequest A JSON patch (RFC 6902) of a resource. A failed test operation fails the whole patch.
 type PatchRequest = []struct {
 	// Op The operation to perform.
 	Op PatchRequestOp `json:"op"`
@@ -1085,8 +1256,17 @@ type PatchRequestOp string
 // Percentage Percentage is the string format representing percentage string.
 type Percentage = string
 
+// QuotaUsage The usage of the quotas of an organization.
+type QuotaUsage struct {
+	// Items The usage of the quota of each kind of resource with a quota.
+	Items []ResourceQuotaUsage `json:"items"`
+}
+
 // RenderedApplicationSpec defines model for RenderedApplicationSpec.
 type RenderedApplicationSpec struct {
+	// DependsOn The names of the applications that must be started before this application.
+	DependsOn *[]string `json:"dependsOn,omitempty"`
+
 	// EnvVars Environment variable key-value pairs, injected during runtime. The key and value each must be between 1 and 253 characters.
 	EnvVars *map[string]string `json:"envVars,omitempty"`
 
@@ -1115,6 +1295,9 @@ type RenderedDeviceSpec struct {
 	// RenderedVersion Version of the rendered device spec.
 	RenderedVersion string `json:"renderedVersion"`
 
+	// RequestID The ID of the request that caused this version to be rendered, for correlating the logs of the service and of the agent.
+	RequestID *string `json:"requestID,omitempty"`
+
 	// Resources Array of resource monitor configurations.
 	Resources *[]ResourceMonitor `json:"resources,omitempty"`
 
@@ -1210,6 +1393,48 @@ type ResourceMonitorSpec struct {
 	SamplingInterval string `json:"samplingInterval"`
 }
 
+// ResourceQuotaUsage The usage of the quota of an organization for a kind of resource.
+type ResourceQuotaUsage struct {
+	// Kind The kind of the resources, e.g. "Device".
+	Kind string `json:"kind"`
+
+	// Limit The number of resources of the kind the organization can have, unlimited when unset.
+	Limit *int64 `json:"limit,omitempty"`
+
+	// Used The number of resources of the kind the organization has.
+	Used int64 `json:"used"`
+}
+
+// ResourceRevision ResourceRevision is a past version of the spec of a resource.
+type ResourceRevision struct {
+	// Actor The name of the user that applied the spec. Unset if the spec was set by the service, for example when rolling out a fleet.
+	Actor *string `json:"actor,omitempty"`
+
+	// Revision The generation of the resource that had this spec.
+	Revision int64 `json:"revision"`
+
+	// Spec The spec of the resource at this revision.
+	Spec map[string]interface{} `json:"spec"`
+
+	// Timestamp The time the spec was applied.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ResourceRevisionList ResourceRevisionList is a list of ResourceRevisions.
+type ResourceRevisionList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources.
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of ResourceRevisions.
+	Items []ResourceRevision `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds.
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata ListMeta `json:"metadata"`
+}
+
 // ResourceSync ResourceSync represents a reference to one or more files in a repository to sync to resource definitions.
 type ResourceSync struct {
 	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources.
@@ -1228,6 +1453,21 @@ type ResourceSync struct {
 	Status *ResourceSyncStatus `json:"status,omitempty"`
 }
 
+// ResourceSyncDrift ResourceSyncDrift describes how the live state of a synced resource differs from its definition in the repository.
+type ResourceSyncDrift struct {
+	// Deleted Whether the resource was deleted.
+	Deleted *bool `json:"deleted,omitempty"`
+
+	// Fields The paths of the fields whose live value differs from the repository, such as "spec.template.spec.os.image".
+	Fields []string `json:"fields"`
+
+	// Kind The kind of the resource.
+	Kind string `json:"kind"`
+
+	// Name The name of the resource.
+	Name string `json:"name"`
+}
+
 // ResourceSyncList defines model for ResourceSyncList.
 type ResourceSyncList struct {
 	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources.
@@ -1245,14 +1485,20 @@ type ResourceSyncList struct {
 
 // ResourceSyncSpec ResourceSyncSpec describes the file(s) to sync from a repository.
 type ResourceSyncSpec struct {
+	// AutoCorrectDrift Whether resources that were changed or deleted outside of the repository are restored to their definition in the last synced commit. If false, the drift is only reported in the status.
+	AutoCorrectDrift *bool `json:"autoCorrectDrift,omitempty"`
+
 	// Path The path of a file or directory in the repository. If a directory, the directory should contain only resource definitions with no subdirectories. Each file should contain the definition of one or more resources.
 	Path string `json:"path"`
 
 	// Repository The name of the repository resource to use as the sync source.
 	Repository string `json:"repository"`
 
-	// TargetRevision The desired revision in the repository.
+	// TargetRevision The desired revision in the repository. Depending on the update policy, a branch or tag to track, the commit hash to pin, or a range of semantic version tags such as ">=1.2.0 <2.0.0".
 	TargetRevision string `json:"targetRevision"`
+
+	// UpdatePolicy How the target revision is resolved to the commit to sync. "track-branch" (the default) syncs the latest commit of the branch or tag, "pin-commit" syncs the given commit hash and never updates, and "semver-tag-range" syncs the highest semantic version tag within the range.
+	UpdatePolicy *ResourceSyncUpdatePolicy `json:"updatePolicy,omitempty"`
 }
 
 // ResourceSyncStatus ResourceSyncStatus represents information about the status of a ResourceSync.
@@ -1260,13 +1506,22 @@ type ResourceSyncStatus struct {
 	// Conditions Current state of a resourcesync.
 	Conditions []Condition `json:"conditions"`
 
+	// Drift The synced resources whose live state differs from their definition in the last synced commit.
+	Drift *[]ResourceSyncDrift `json:"drift,omitempty"`
+
 	// ObservedCommit The last commit hash that was synced.
 	ObservedCommit *string `json:"observedCommit,omitempty"`
 
 	// ObservedGeneration The last generation that was synced.
 	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// ObservedRevision The branch, tag or commit hash that the last commit that was synced was resolved from.
+	ObservedRevision *string `json:"observedRevision,omitempty"`
 }
 
+// ResourceSyncUpdatePolicy How the target revision is resolved to the commit to sync. "track-branch" (the default) syncs the latest commit of the branch or tag, "pin-commit" syncs the given commit hash and never updates, and "semver-tag-range" syncs the highest semantic version tag within the range.
+type ResourceSyncUpdatePolicy string
+
 // RolloutDeviceSelection Describes how to select devices for rollout.
 type RolloutDeviceSelection struct {
 	// Strategy The rollout strategy to use.
@@ -1285,6 +1540,9 @@ type RolloutPolicy struct {
 	// DisruptionAllowance DisruptionAllowance defines the level of allowed disruption when rollout is in progress.
 	DisruptionAllowance *DisruptionAllowance `json:"disruptionAllowance,omitempty"`
 
+	// PauseBetweenBatches The maximum duration allowed for the action to complete. The duration should be specified as a positive integer followed by a time unit. Supported time units are: `s` for seconds, `m` for minutes, `h` for hours.
+	PauseBetweenBatches *Duration `json:"pauseBetweenBatches,omitempty"`
+
 	// SuccessThreshold Percentage is the string format representing percentage string.
 	SuccessThreshold *Percentage `json:"successThreshold,omitempty"`
 }
@@ -1436,6 +1694,12 @@ type ListCertificateSigningRequestsParams struct {
 	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// DeleteDevicesParams defines parameters for DeleteDevices.
+type DeleteDevicesParams struct {
+	// LabelSelector A selector to restrict the deleted objects by their labels. Defaults to everything.
+	LabelSelector *string `form:"labelSelector,omitempty" json:"labelSelector,omitempty"`
+}
+
 // ListDevicesParams defines parameters for ListDevices.
 type ListDevicesParams struct {
 	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
@@ -1452,6 +1716,30 @@ type ListDevicesParams struct {
 
 	// SummaryOnly A boolean flag to include only a summary of the devices. When set to true, the response will contain only the summary information. Only the 'owner' and 'labelSelector' parameters are supported when 'summaryOnly' is true.
 	SummaryOnly *bool `form:"summaryOnly,omitempty" json:"summaryOnly,omitempty"`
+
+	// NeedsAttention A boolean flag to list only the devices needing attention, i.e. reporting an error, degraded or unknown summary status, an error or degraded applications status, a warning, critical or error resource status, an invalid spec, or multiple owners. It can be combined with the selectors, e.g. "metadata.owner=Fleet/my-fleet", and with pagination, but not with 'summaryOnly'.
+	NeedsAttention *bool `form:"needsAttention,omitempty" json:"needsAttention,omitempty"`
+
+	// Fields A comma-separated list of field paths (e.g., "metadata.name,status.summary") to project each returned Device to. Paths must exist in the Device schema. Defaults to all fields.
+	Fields *string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// GroupByLabel A label key by which to break down the devices in the summary, e.g. "region". The number of devices per value of the label is returned in the 'labelValues' field of the summary. Only supported when 'summaryOnly' is true.
+	GroupByLabel *string `form:"groupByLabel,omitempty" json:"groupByLabel,omitempty"`
+}
+
+// ReadDeviceParams defines parameters for ReadDevice.
+type ReadDeviceParams struct {
+	// Fields A comma-separated list of field paths (e.g., "metadata.name,status.summary") to project the returned Device to. Paths must exist in the Device schema. Defaults to all fields.
+	Fields *string `form:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// ReplaceDeviceParams defines parameters for ReplaceDevice.
+type ReplaceDeviceParams struct {
+	// FieldManager Apply the request server-side as the named field manager, merging the fields it sets into the existing resource instead of replacing it.
+	FieldManager *string `form:"fieldManager,omitempty" json:"fieldManager,omitempty"`
+
+	// Force With fieldManager, take ownership of fields owned by other field managers instead of failing with a conflict.
+	Force *bool `form:"force,omitempty" json:"force,omitempty"`
 }
 
 // GetRenderedDeviceSpecParams defines parameters for GetRenderedDeviceSpec.
@@ -1460,6 +1748,12 @@ type GetRenderedDeviceSpecParams struct {
 	KnownRenderedVersion *string `form:"knownRenderedVersion,omitempty" json:"knownRenderedVersion,omitempty"`
 }
 
+// ListDeviceRevisionsParams defines parameters for ListDeviceRevisions.
+type ListDeviceRevisionsParams struct {
+	// Limit The maximum number of revisions returned, starting from the newest one.
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // GetEnrollmentConfigParams defines parameters for GetEnrollmentConfig.
 type GetEnrollmentConfigParams struct {
 	// Csr The name of a CertificateSigningRequest resource to query for an issued certificate. If provided, the service will check if the CertificateSigningRequest contains an issued certificate and in this case include it the returned EnrollmentConfig. In all other case, the enrollment certificate field will be empty.
@@ -1479,8 +1773,20 @@ type ListEnrollmentRequestsParams struct {
 
 	// Limit The maximum number of results returned in the list response. The server will set the 'continue' field in the list response if more results exist. The continue value may then be specified as parameter in a subsequent query.
 	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Status Restricts the list to enrollment requests with the given approval status. Pending requests are neither approved nor denied yet.
+	Status *ListEnrollmentRequestsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// CreatedAfter Restricts the list to enrollment requests created at or after the given time.
+	CreatedAfter *time.Time `form:"createdAfter,omitempty" json:"createdAfter,omitempty"`
+
+	// CreatedBefore Restricts the list to enrollment requests created before the given time.
+	CreatedBefore *time.Time `form:"createdBefore,omitempty" json:"createdBefore,omitempty"`
 }
 
+// ListEnrollmentRequestsParamsStatus defines parameters for ListEnrollmentRequests.
+type ListEnrollmentRequestsParamsStatus string
+
 // ListFleetsParams defines parameters for ListFleets.
 type ListFleetsParams struct {
 	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
@@ -1520,6 +1826,21 @@ type ReadFleetParams struct {
 	AddDevicesSummary *bool `form:"addDevicesSummary,omitempty" json:"addDevicesSummary,omitempty"`
 }
 
+// ReplaceFleetParams defines parameters for ReplaceFleet.
+type ReplaceFleetParams struct {
+	// FieldManager Apply the request server-side as the named field manager, merging the fields it sets into the existing resource instead of replacing it.
+	FieldManager *string `form:"fieldManager,omitempty" json:"fieldManager,omitempty"`
+
+	// Force With fieldManager, take ownership of fields owned by other field managers instead of failing with a conflict.
+	Force *bool `form:"force,omitempty" json:"force,omitempty"`
+}
+
+// ListFleetRevisionsParams defines parameters for ListFleetRevisions.
+type ListFleetRevisionsParams struct {
+	// Limit The maximum number of revisions returned, starting from the newest one.
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // ListRepositoriesParams defines parameters for ListRepositories.
 type ListRepositoriesParams struct {
 	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
@@ -1535,6 +1856,15 @@ type ListRepositoriesParams struct {
 	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// ReplaceRepositoryParams defines parameters for ReplaceRepository.
+type ReplaceRepositoryParams struct {
+	// FieldManager Apply the request server-side as the named field manager, merging the fields it sets into the existing resource instead of replacing it.
+	FieldManager *string `form:"fieldManager,omitempty" json:"fieldManager,omitempty"`
+
+	// Force With fieldManager, take ownership of fields owned by other field managers instead of failing with a conflict.
+	Force *bool `form:"force,omitempty" json:"force,omitempty"`
+}
+
 // ListResourceSyncParams defines parameters for ListResourceSync.
 type ListResourceSyncParams struct {
 	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
@@ -1550,12 +1880,30 @@ type ListResourceSyncParams struct {
 	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// ReplaceResourceSyncParams defines parameters for ReplaceResourceSync.
+type ReplaceResourceSyncParams struct {
+	// FieldManager Apply the request server-side as the named field manager, merging the fields it sets into the existing resource instead of replacing it.
+	FieldManager *string `form:"fieldManager,omitempty" json:"fieldManager,omitempty"`
+
+	// Force With fieldManager, take ownership of fields owned by other field managers instead of failing with a conflict.
+	Force *bool `form:"force,omitempty" json:"force,omitempty"`
+}
+
+// IssueBootstrapTokenJSONRequestBody defines body for IssueBootstrapToken for application/json ContentType.
+type IssueBootstrapTokenJSONRequestBody = BootstrapTokenRequest
+
+// RedeemBootstrapTokenJSONRequestBody defines body for RedeemBootstrapToken for application/json ContentType.
+type RedeemBootstrapTokenJSONRequestBody = BootstrapTokenRedemption
+
 // CreateCertificateSigningRequestJSONRequestBody defines body for CreateCertificateSigningRequest for application/json ContentType.
 type CreateCertificateSigningRequestJSONRequestBody = CertificateSigningRequest
 
 // PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody defines body for PatchCertificateSigningRequest for application/json-patch+json ContentType.
 type PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody = PatchRequest
 
+// PatchCertificateSigningRequestApplicationMergePatchPlusJSONRequestBody defines body for PatchCertificateSigningRequest for application/merge-patch+json ContentType.
+type PatchCertificateSigningRequestApplicationMergePatchPlusJSONRequestBody = MergePatchRequest
+
 // ReplaceCertificateSigningRequestJSONRequestBody defines body for ReplaceCertificateSigningRequest for application/json ContentType.
 type ReplaceCertificateSigningRequestJSONRequestBody = CertificateSigningRequest
 
@@ -1565,15 +1913,24 @@ type UpdateCertificateSigningRequestApprovalJSONRequestBody = CertificateSigning
 // CreateDeviceJSONRequestBody defines body for CreateDevice for application/json ContentType.
 type CreateDeviceJSONRequestBody = Device
 
+// BatchGetDevicesJSONRequestBody defines body for BatchGetDevices for application/json ContentType.
+type BatchGetDevicesJSONRequestBody = BatchGetRequest
+
 // PatchDeviceApplicationJSONPatchPlusJSONRequestBody defines body for PatchDevice for application/json-patch+json ContentType.
 type PatchDeviceApplicationJSONPatchPlusJSONRequestBody = PatchRequest
 
+// PatchDeviceApplicationMergePatchPlusJSONRequestBody defines body for PatchDevice for application/merge-patch+json ContentType.
+type PatchDeviceApplicationMergePatchPlusJSONRequestBody = MergePatchRequest
+
 // ReplaceDeviceJSONRequestBody defines body for ReplaceDevice for application/json ContentType.
 type ReplaceDeviceJSONRequestBody = Device
 
 // DecommissionDeviceJSONRequestBody defines body for DecommissionDevice for application/json ContentType.
 type DecommissionDeviceJSONRequestBody = DeviceDecommission
 
+// ReplaceDeviceLogsJSONRequestBody defines body for ReplaceDeviceLogs for application/json ContentType.
+type ReplaceDeviceLogsJSONRequestBody = DeviceLogs
+
 // PatchDeviceStatusApplicationJSONPatchPlusJSONRequestBody defines body for PatchDeviceStatus for application/json-patch+json ContentType.
 type PatchDeviceStatusApplicationJSONPatchPlusJSONRequestBody = PatchRequest
 
@@ -1586,6 +1943,9 @@ type CreateEnrollmentRequestJSONRequestBody = EnrollmentRequest
 // PatchEnrollmentRequestApplicationJSONPatchPlusJSONRequestBody defines body for PatchEnrollmentRequest for application/json-patch+json ContentType.
 type PatchEnrollmentRequestApplicationJSONPatchPlusJSONRequestBody = PatchRequest
 
+// PatchEnrollmentRequestApplicationMergePatchPlusJSONRequestBody defines body for PatchEnrollmentRequest for application/merge-patch+json ContentType.
+type PatchEnrollmentRequestApplicationMergePatchPlusJSONRequestBody = MergePatchRequest
+
 // ReplaceEnrollmentRequestJSONRequestBody defines body for ReplaceEnrollmentRequest for application/json ContentType.
 type ReplaceEnrollmentRequestJSONRequestBody = EnrollmentRequest
 
@@ -1604,6 +1964,9 @@ type CreateFleetJSONRequestBody = Fleet
 // PatchFleetApplicationJSONPatchPlusJSONRequestBody defines body for PatchFleet for application/json-patch+json ContentType.
 type PatchFleetApplicationJSONPatchPlusJSONRequestBody = PatchRequest
 
+// PatchFleetApplicationMergePatchPlusJSONRequestBody defines body for PatchFleet for application/merge-patch+json ContentType.
+type PatchFleetApplicationMergePatchPlusJSONRequestBody = MergePatchRequest
+
 // ReplaceFleetJSONRequestBody defines body for ReplaceFleet for application/json ContentType.
 type ReplaceFleetJSONRequestBody = Fleet
 
@@ -1619,6 +1982,9 @@ type CreateRepositoryJSONRequestBody = Repository
 // PatchRepositoryApplicationJSONPatchPlusJSONRequestBody defines body for PatchRepository for application/json-patch+json ContentType.
 type PatchRepositoryApplicationJSONPatchPlusJSONRequestBody = PatchRequest
 
+// PatchRepositoryApplicationMergePatchPlusJSONRequestBody defines body for PatchRepository for application/merge-patch+json ContentType.
+type PatchRepositoryApplicationMergePatchPlusJSONRequestBody = MergePatchRequest
+
 // ReplaceRepositoryJSONRequestBody defines body for ReplaceRepository for application/json ContentType.
 type ReplaceRepositoryJSONRequestBody = Repository
 
@@ -1628,6 +1994,9 @@ type CreateResourceSyncJSONRequestBody = ResourceSync
 // PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody defines body for PatchResourceSync for application/json-patch+json ContentType.
 type PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody = PatchRequest
 
+// PatchResourceSyncApplicationMergePatchPlusJSONRequestBody defines body for PatchResourceSync for application/merge-patch+json ContentType.
+type PatchResourceSyncApplicationMergePatchPlusJSONRequestBody = MergePatchRequest
+
 // ReplaceResourceSyncJSONRequestBody defines body for ReplaceResourceSync for application/json ContentType.
 type ReplaceResourceSyncJSONRequestBody = ResourceSync
 
@@ -1670,6 +2039,13 @@ func (t ApplicationSpec) MarshalJSON() ([]byte, error) {
 		}
 	}
 
+	if t.DependsOn != nil {
+		object["dependsOn"], err = json.Marshal(t.DependsOn)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'dependsOn': %w", err)
+		}
+	}
+
 	if t.EnvVars != nil {
 		object["envVars"], err = json.Marshal(t.EnvVars)
 		if err != nil {
@@ -1698,6 +2074,13 @@ func (t *ApplicationSpec) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	if raw, found := object["dependsOn"]; found {
+		err = json.Unmarshal(raw, &t.DependsOn)
+		if err != nil {
+			return fmt.Errorf("error reading 'dependsOn': %w", err)
+		}
+	}
+
 	if raw, found := object["envVars"]; found {
 		err = json.Unmarshal(raw, &t.EnvVars)
 		if err != nil {
@@ -2076,6 +2459,13 @@ func (t RenderedApplicationSpec) MarshalJSON() ([]byte, error) {
 		}
 	}
 
+	if t.DependsOn != nil {
+		object["dependsOn"], err = json.Marshal(t.DependsOn)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'dependsOn': %w", err)
+		}
+	}
+
 	if t.EnvVars != nil {
 		object["envVars"], err = json.Marshal(t.EnvVars)
 		if err != nil {
@@ -2104,6 +2494,13 @@ func (t *RenderedApplicationSpec) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	if raw, found := object["dependsOn"]; found {
+		err = json.Unmarshal(raw, &t.DependsOn)
+		if err != nil {
+			return fmt.Errorf("error reading 'dependsOn': %w", err)
+		}
+	}
+
 	if raw, found := object["envVars"]; found {
 		err = json.Unmarshal(raw, &t.EnvVars)
 		if err != nil {
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"slices"
@@ -395,3 +396,59 @@ func (e MatchExpression) String() string {
 	}
 	return sb.String()
 }
+
+// ErrApplicationDependencyCycle is returned when applications depend on each other.
+var ErrApplicationDependencyCycle = errors.New("application dependency cycle")
+
+// SortApplicationsByDependencies returns the given application names ordered so that every
+// application comes after the applications it depends on. Applications without dependencies
+// between them keep their relative order.
+func SortApplicationsByDependencies(names []string, dependsOn map[string][]string) ([]string, error) {
+	known := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		known[name] = struct{}{}
+	}
+	for _, name := range names {
+		for _, dep := range dependsOn[name] {
+			if _, exists := known[dep]; !exists {
+				return nil, fmt.Errorf("application %s depends on unknown application %s", name, dep)
+			}
+		}
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(names))
+	sorted := make([]string, 0, len(names))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(slices.Clone(path[slices.Index(path, name):]), name)
+			return fmt.Errorf("%w: %s", ErrApplicationDependencyCycle, strings.Join(cycle, " -> "))
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range dependsOn[name] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		sorted = append(sorted, name)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
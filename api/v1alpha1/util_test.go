@@ -127,3 +127,19 @@ func TestExecuteGoTemplateOnDevice(t *testing.T) {
 		})
 	}
 }
+
+func TestSortApplicationsByDependencies(t *testing.T) {
+	require := require.New(t)
+	names := []string{"web", "metrics", "cache", "db"}
+	dependsOn := map[string][]string{
+		"web":   {"cache", "db"},
+		"cache": {"db"},
+	}
+	sorted, err := SortApplicationsByDependencies(names, dependsOn)
+	require.NoError(err)
+	require.Equal([]string{"db", "cache", "web", "metrics"}, sorted)
+
+	dependsOn["db"] = []string{"web"}
+	_, err = SortApplicationsByDependencies(names, dependsOn)
+	require.ErrorIs(err, ErrApplicationDependencyCycle)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"text/template"
 	"text/template/parse"
@@ -318,6 +319,11 @@ func (c InlineConfigProviderSpec) Validate(fleetTemplate bool) []error {
 		allErrs = append(allErrs, validation.ValidateLinuxUserGroup(c.Inline[i].Group, fmt.Sprintf("spec.config[].inline[%d].group", i))...)
 		allErrs = append(allErrs, validation.ValidateLinuxFileMode(c.Inline[i].Mode, fmt.Sprintf("spec.config[].inline[%d].mode", i))...)
 
+		if c.Inline[i].Template != nil && *c.Inline[i].Template {
+			allErrs = append(allErrs, validateFileTemplate(c.Inline[i], fmt.Sprintf("spec.config[].inline[%d].content", i))...)
+			continue
+		}
+
 		if c.Inline[i].ContentEncoding != nil && *(c.Inline[i].ContentEncoding) == Base64 {
 			// Contents should be base64 encoded and limited to 1MB (1024*1024=1048576 bytes)
 			allErrs = append(allErrs, validation.ValidateBase64Field(c.Inline[i].Content, fmt.Sprintf("spec.config[].inline[%d].content", i), maxInlineConfigLength)...)
@@ -392,17 +398,7 @@ func (r Fleet) Validate() []error {
 	allErrs = append(allErrs, validation.ValidateAnnotations(r.Metadata.Annotations)...)
 	allErrs = append(allErrs, r.Spec.Selector.Validate()...)
 	if r.Spec.RolloutPolicy != nil {
-		i, err := r.Spec.RolloutPolicy.DeviceSelection.ValueByDiscriminator()
-		if err != nil {
-			allErrs = append(allErrs, err)
-		} else {
-			switch v := i.(type) {
-			case BatchSequence:
-				for _, b := range lo.FromPtr(v.Sequence) {
-					allErrs = append(allErrs, b.Selector.Validate()...)
-				}
-			}
-		}
+		allErrs = append(allErrs, r.Spec.RolloutPolicy.Validate()...)
 	}
 
 	// Validate the Device spec settings
@@ -411,6 +407,61 @@ func (r Fleet) Validate() []error {
 	return allErrs
 }
 
+func (p RolloutPolicy) Validate() []error {
+	allErrs := []error{}
+	if p.DeviceSelection != nil {
+		switch p.DeviceSelection.Strategy {
+		case "BatchSequence":
+			sequence, err := p.DeviceSelection.AsBatchSequence()
+			if err != nil {
+				allErrs = append(allErrs, fmt.Errorf("spec.rolloutPolicy.deviceSelection: %w", err))
+				break
+			}
+			for i, b := range lo.FromPtr(sequence.Sequence) {
+				allErrs = append(allErrs, b.Selector.Validate()...)
+				allErrs = append(allErrs, validatePercentage(b.SuccessThreshold, fmt.Sprintf("spec.rolloutPolicy.deviceSelection.sequence[%d].successThreshold", i))...)
+				if b.Limit != nil {
+					if percentage, err := b.Limit.AsPercentage(); err == nil {
+						allErrs = append(allErrs, validatePercentage(&percentage, fmt.Sprintf("spec.rolloutPolicy.deviceSelection.sequence[%d].limit", i))...)
+					} else if limit, err := b.Limit.AsBatchLimit1(); err != nil || limit < 1 {
+						allErrs = append(allErrs, fmt.Errorf("spec.rolloutPolicy.deviceSelection.sequence[%d].limit: must be a percentage or a positive number of devices", i))
+					}
+				}
+			}
+		default:
+			allErrs = append(allErrs, fmt.Errorf("spec.rolloutPolicy.deviceSelection.strategy: unsupported strategy %q", p.DeviceSelection.Strategy))
+		}
+	}
+	allErrs = append(allErrs, validatePercentage(p.SuccessThreshold, "spec.rolloutPolicy.successThreshold")...)
+	allErrs = append(allErrs, validateDuration(p.DefaultUpdateTimeout, "spec.rolloutPolicy.defaultUpdateTimeout")...)
+	allErrs = append(allErrs, validateDuration(p.PauseBetweenBatches, "spec.rolloutPolicy.pauseBetweenBatches")...)
+	if p.DisruptionAllowance != nil && p.DisruptionAllowance.MaxUnavailable != nil && *p.DisruptionAllowance.MaxUnavailable < 1 {
+		allErrs = append(allErrs, errors.New("spec.rolloutPolicy.disruptionAllowance.maxUnavailable: must be at least 1"))
+	}
+	return allErrs
+}
+
+func validatePercentage(percentage *Percentage, path string) []error {
+	if percentage == nil {
+		return nil
+	}
+	value, err := strconv.Atoi(strings.TrimSuffix(*percentage, "%"))
+	if err != nil || !strings.HasSuffix(*percentage, "%") || value < 0 || value > 100 {
+		return []error{fmt.Errorf("%s: %q is not a percentage between 0%% and 100%%", path, *percentage)}
+	}
+	return nil
+}
+
+func validateDuration(duration *Duration, path string) []error {
+	if duration == nil {
+		return nil
+	}
+	if d, err := time.ParseDuration(*duration); err != nil || d < 0 {
+		return []error{fmt.Errorf("%s: %q is not a duration such as 30s, 10m or 1h", path, *duration)}
+	}
+	return nil
+}
+
 func (u DeviceUpdatePolicySpec) Validate() []error {
 	allErrs := []error{}
 	if u.DownloadSchedule != nil {
@@ -490,11 +541,28 @@ func (r ResourceSync) Validate() []error {
 	allErrs = append(allErrs, validation.ValidateLabels(r.Metadata.Labels)...)
 	allErrs = append(allErrs, validation.ValidateAnnotations(r.Metadata.Annotations)...)
 	allErrs = append(allErrs, validation.ValidateResourceNameReference(&r.Spec.Repository, "spec.repository")...)
-	allErrs = append(allErrs, validation.ValidateGitRevision(&r.Spec.TargetRevision, "spec.targetRevision")...)
+	allErrs = append(allErrs, r.Spec.validateTargetRevision()...)
 	allErrs = append(allErrs, validation.ValidateString(&r.Spec.Path, "spec.path", 0, 2048, nil, "")...)
 	return allErrs
 }
 
+// validateTargetRevision validates the target revision according to the update policy.
+func (s ResourceSyncSpec) validateTargetRevision() []error {
+	switch s.GetUpdatePolicy() {
+	case ResourceSyncUpdatePolicyTrackBranch:
+		return validation.ValidateGitRevision(&s.TargetRevision, "spec.targetRevision")
+	case ResourceSyncUpdatePolicyPinCommit:
+		return validation.ValidateGitCommitHash(&s.TargetRevision, "spec.targetRevision")
+	case ResourceSyncUpdatePolicySemverTagRange:
+		if _, err := util.ParseSemverRange(s.TargetRevision); err != nil {
+			return []error{fmt.Errorf("spec.targetRevision: %w", err)}
+		}
+		return nil
+	default:
+		return []error{fmt.Errorf("spec.updatePolicy: unsupported update policy %q", *s.UpdatePolicy)}
+	}
+}
+
 func (l *LabelSelector) Validate() []error {
 	if l != nil && l.MatchExpressions == nil && l.MatchLabels == nil {
 		return []error{errors.New("at least one of [matchLabels,matchExpressions] must appear in a label selector")}
@@ -569,6 +637,8 @@ func (a ApplicationSpec) Validate() []error {
 func validateApplications(apps []ApplicationSpec) []error {
 	allErrs := []error{}
 	seenName := make(map[string]struct{})
+	names := make([]string, 0, len(apps))
+	dependsOn := make(map[string][]string)
 	for _, app := range apps {
 		providerType, err := validateAppProviderType(app)
 		if err != nil {
@@ -584,11 +654,18 @@ func validateApplications(apps []ApplicationSpec) []error {
 			allErrs = append(allErrs, fmt.Errorf("duplicate application name: %s", appName))
 		} else {
 			seenName[appName] = struct{}{}
+			names = append(names, appName)
+			dependsOn[appName] = lo.FromPtr(app.DependsOn)
 		}
 
 		allErrs = append(allErrs, validateAppProvider(app, providerType)...)
 		allErrs = append(allErrs, app.Validate()...)
 	}
+
+	// ensure dependencies refer to applications of the device and can be ordered
+	if _, err := SortApplicationsByDependencies(names, dependsOn); err != nil {
+		allErrs = append(allErrs, err)
+	}
 	return allErrs
 }
 
@@ -729,6 +806,101 @@ func validateGraceDuration(schedule cron.Schedule, duration string) error {
 	return nil
 }
 
+// validateFileTemplate checks the syntax of the content of a file rendered on the device. Its
+// parameters are facts of the device, so they are not validated as fleet template parameters.
+func validateFileTemplate(file FileSpec, path string) []error {
+	content := file.Content
+	if file.ContentEncoding != nil && *file.ContentEncoding == Base64 {
+		if errs := validation.ValidateBase64Field(file.Content, path, maxInlineConfigLength); len(errs) > 0 {
+			return errs
+		}
+		b, _ := base64.StdEncoding.DecodeString(file.Content)
+		content = string(b)
+	} else if errs := validation.ValidateString(&file.Content, path, 0, maxInlineConfigLength, nil, ""); len(errs) > 0 {
+		return errs
+	}
+	t, err := template.New("t").Funcs(fileTemplateFuncs).Parse(content)
+	if err != nil {
+		return validation.FormatInvalidError(content, path, fmt.Sprintf("invalid template syntax: %v", err))
+	}
+	if err := validateSecretReferences(t.Root); err != nil {
+		return validation.FormatInvalidError(content, path, err.Error())
+	}
+	return nil
+}
+
+// fileTemplateFuncs are the functions of the templates rendered on the device. They are resolved
+// by the agent, so only their signatures matter here.
+var fileTemplateFuncs = template.FuncMap{
+	"secret": func(string) (string, error) { return "", nil },
+}
+
+var deviceSecretNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// validateSecretReferences checks that each call to "secret" takes the name of a device-local
+// secret as a string literal, so that the secrets a file needs are known from its spec.
+func validateSecretReferences(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := validateSecretReferences(child); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return validateSecretReferences(n.Pipe)
+	case *parse.IfNode:
+		return validateBranchSecretReferences(&n.BranchNode)
+	case *parse.RangeNode:
+		return validateBranchSecretReferences(&n.BranchNode)
+	case *parse.WithNode:
+		return validateBranchSecretReferences(&n.BranchNode)
+	case *parse.TemplateNode:
+		return validateSecretReferences(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if err := validateSecretReferences(cmd); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		if ident, ok := n.Args[0].(*parse.IdentifierNode); ok && ident.Ident == "secret" {
+			if len(n.Args) != 2 {
+				return fmt.Errorf("secret takes the name of exactly one secret: %s", n)
+			}
+			name, ok := n.Args[1].(*parse.StringNode)
+			if !ok {
+				return fmt.Errorf("the name of a secret must be a string literal: %s", n)
+			}
+			if !deviceSecretNameRegexp.MatchString(name.Text) {
+				return fmt.Errorf("invalid secret name %q: must start with a letter or digit and contain only letters, digits, '-', '_' and '.'", name.Text)
+			}
+			return nil
+		}
+		for _, arg := range n.Args {
+			if err := validateSecretReferences(arg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateBranchSecretReferences(n *parse.BranchNode) error {
+	for _, child := range []parse.Node{n.Pipe, n.List, n.ElseList} {
+		if err := validateSecretReferences(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validateParametersInString(s *string, path string, fleetTemplate bool) (bool, []error) {
 	// If we're not dealing with a fleet template, assume no parameters
 	if s == nil || !fleetTemplate {
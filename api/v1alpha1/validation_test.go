@@ -377,3 +377,124 @@ func TestValidateParametersInString(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateApplicationDependencies(t *testing.T) {
+	newApp := func(name string, dependsOn ...string) ApplicationSpec {
+		app := ApplicationSpec{Name: &name}
+		if len(dependsOn) > 0 {
+			app.DependsOn = &dependsOn
+		}
+		require.NoError(t, app.FromImageApplicationProvider(ImageApplicationProvider{Image: "quay.io/flightctl/" + name + ":latest"}))
+		return app
+	}
+	tests := []struct {
+		name    string
+		apps    []ApplicationSpec
+		wantErr string
+	}{
+		{
+			name: "no dependencies",
+			apps: []ApplicationSpec{newApp("db"), newApp("web")},
+		},
+		{
+			name: "valid dependencies",
+			apps: []ApplicationSpec{newApp("web", "db", "cache"), newApp("cache", "db"), newApp("db")},
+		},
+		{
+			name:    "unknown dependency",
+			apps:    []ApplicationSpec{newApp("web", "db")},
+			wantErr: "application web depends on unknown application db",
+		},
+		{
+			name:    "self dependency",
+			apps:    []ApplicationSpec{newApp("web", "web")},
+			wantErr: "application dependency cycle: web -> web",
+		},
+		{
+			name:    "cycle",
+			apps:    []ApplicationSpec{newApp("web", "cache"), newApp("cache", "db"), newApp("db", "web")},
+			wantErr: "application dependency cycle: web -> cache -> db -> web",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateApplications(tt.apps)
+			if tt.wantErr == "" {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			require.EqualError(t, errs[0], tt.wantErr)
+		})
+	}
+}
+
+func TestValidateInlineFileTemplate(t *testing.T) {
+	newInline := func(content string, template bool) InlineConfigProviderSpec {
+		file := FileSpec{Path: "/etc/app.conf", Content: content}
+		if template {
+			file.Template = &template
+		}
+		return InlineConfigProviderSpec{Name: "config", Inline: []FileSpec{file}}
+	}
+
+	// device facts are not fleet template parameters
+	require.Empty(t, newInline("site={{ .Device.Labels.site }}", true).Validate(true))
+	require.NotEmpty(t, newInline("site={{ .Device.Labels.site }}", false).Validate(true))
+	require.Empty(t, newInline("site={{ .Device.Labels.site }}", true).Validate(false))
+	require.NotEmpty(t, newInline("site={{ .Device.Labels.site ", true).Validate(false))
+
+	// device-local secrets are referenced by name
+	require.Empty(t, newInline(`password={{ secret "registry-password" }}`, true).Validate(false))
+	require.Empty(t, newInline(`{{ if .Device.Labels.site }}token={{ secret "app.token" | printf "%s" }}{{ end }}`, true).Validate(false))
+	require.NotEmpty(t, newInline(`password={{ secret "../certs/agent.key" }}`, true).Validate(false))
+	require.NotEmpty(t, newInline(`password={{ secret .Device.Name }}`, true).Validate(false))
+	require.NotEmpty(t, newInline(`password={{ secret }}`, true).Validate(false))
+}
+
+func TestValidateResourceSyncUpdatePolicy(t *testing.T) {
+	newResourceSync := func(targetRevision string, policy *ResourceSyncUpdatePolicy) ResourceSync {
+		return ResourceSync{
+			Metadata: ObjectMeta{Name: util.StrToPtr("rs")},
+			Spec:     ResourceSyncSpec{Repository: "repo", Path: "/fleets", TargetRevision: targetRevision, UpdatePolicy: policy},
+		}
+	}
+	policy := func(p ResourceSyncUpdatePolicy) *ResourceSyncUpdatePolicy { return &p }
+
+	require.Empty(t, newResourceSync("main", nil).Validate())
+	require.Empty(t, newResourceSync("v1.0.0", policy(ResourceSyncUpdatePolicyTrackBranch)).Validate())
+	require.NotEmpty(t, newResourceSync(">=1.0.0", policy(ResourceSyncUpdatePolicyTrackBranch)).Validate())
+	require.Empty(t, newResourceSync("0123456789abcdef0123456789abcdef01234567", policy(ResourceSyncUpdatePolicyPinCommit)).Validate())
+	require.NotEmpty(t, newResourceSync("main", policy(ResourceSyncUpdatePolicyPinCommit)).Validate())
+	require.Empty(t, newResourceSync(">=1.2.0 <2.0.0", policy(ResourceSyncUpdatePolicySemverTagRange)).Validate())
+	require.NotEmpty(t, newResourceSync("main", policy(ResourceSyncUpdatePolicySemverTagRange)).Validate())
+	require.NotEmpty(t, newResourceSync("main", policy("latest")).Validate())
+}
+
+func TestValidateRolloutPolicy(t *testing.T) {
+	newPolicy := func(limit *Batch_Limit, successThreshold string, pause string) RolloutPolicy {
+		selection := RolloutDeviceSelection{}
+		require.NoError(t, selection.FromBatchSequence(BatchSequence{Sequence: &[]Batch{{Limit: limit, SuccessThreshold: &successThreshold}}}))
+		return RolloutPolicy{DeviceSelection: &selection, PauseBetweenBatches: &pause}
+	}
+	count := func(n int) *Batch_Limit {
+		limit := Batch_Limit{}
+		require.NoError(t, limit.FromBatchLimit1(n))
+		return &limit
+	}
+	percentage := func(p string) *Batch_Limit {
+		limit := Batch_Limit{}
+		require.NoError(t, limit.FromPercentage(p))
+		return &limit
+	}
+
+	require.Empty(t, newPolicy(nil, "100%", "1h").Validate())
+	require.Empty(t, newPolicy(count(5), "90%", "30m").Validate())
+	require.Empty(t, newPolicy(percentage("10%"), "0%", "0s").Validate())
+	require.NotEmpty(t, newPolicy(count(0), "90%", "30m").Validate())
+	require.NotEmpty(t, newPolicy(percentage("110%"), "90%", "30m").Validate())
+	require.NotEmpty(t, newPolicy(nil, "90", "30m").Validate())
+	require.NotEmpty(t, newPolicy(nil, "90%", "half an hour").Validate())
+	require.NotEmpty(t, RolloutPolicy{DeviceSelection: &RolloutDeviceSelection{Strategy: "Random"}}.Validate())
+	require.NotEmpty(t, RolloutPolicy{DisruptionAllowance: &DisruptionAllowance{MaxUnavailable: util.IntToPtr(0)}}.Validate())
+}
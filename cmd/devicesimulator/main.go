@@ -199,6 +199,7 @@ func createAgents(log *logrus.Logger, numDevices int, initialDeviceIndex int, ag
 		cfg.StatusUpdateInterval = agentConfigTemplate.StatusUpdateInterval
 		cfg.TPMPath = ""
 		cfg.LogPrefix = agentName
+		cfg.StructuredBanner = true
 
 		// create managementService config
 		cfg.ManagementService = agent.ManagementService{}
@@ -229,14 +230,9 @@ func approveAgent(ctx context.Context, log *logrus.Logger, serviceClient *apiCli
 		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 		defer cancel()
 		log.Infof("Approving device enrollment if exists for agent %s", filepath.Base(agentDir))
-		bannerFileData, err := readBannerFile(agentDir)
+		enrollmentId, err := readEnrollmentId(agentDir)
 		if err != nil {
-			log.Warnf("Error reading banner file: %v", err)
-			return false, nil
-		}
-		enrollmentId := testutil.GetEnrollmentIdFromText(bannerFileData)
-		if enrollmentId == "" {
-			log.Warnf("No enrollment id found in banner file %s", bannerFileData)
+			log.Warnf("Error reading enrollment id: %v", err)
 			return false, nil
 		}
 		_, err = serviceClient.ApproveEnrollmentRequestWithResponse(
@@ -258,18 +254,30 @@ func approveAgent(ctx context.Context, log *logrus.Logger, serviceClient *apiCli
 	}
 }
 
-func readBannerFile(agentDir string) (string, error) {
-	var data []byte
-	var err error
-	bannerFile := filepath.Join(agentDir, lifecycle.BannerFile)
-	if _, err = os.Stat(bannerFile); err != nil {
+// readEnrollmentId returns the enrollment ID of the agent from its structured banner, falling
+// back to the text banner.
+func readEnrollmentId(agentDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(agentDir, lifecycle.BannerJSONFile))
+	if err == nil {
+		banner, err := lifecycle.ParseBanner(data)
+		if err != nil {
+			return "", err
+		}
+		return banner.EnrollmentID, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
 		return "", err
 	}
-	data, err = os.ReadFile(bannerFile)
+
+	data, err = os.ReadFile(filepath.Join(agentDir, lifecycle.BannerFile))
 	if err != nil {
 		return "", err
 	}
-	return string(data), nil
+	enrollmentId := testutil.GetEnrollmentIdFromText(string(data))
+	if enrollmentId == "" {
+		return "", fmt.Errorf("no enrollment id found in banner file %s", data)
+	}
+	return enrollmentId, nil
 }
 
 func copyFile(from, to string) error {
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/flightctl/flightctl/internal/agent"
+	"github.com/flightctl/flightctl/internal/agent/diagnostics"
+)
+
+// runConfig runs the config command and returns the exit code to use. Its only subcommand, print,
+// prints the effective configuration of the agent: the config file merged with its drop-in files
+// and the environment variables, completed with the defaults, without its credentials.
+func runConfig(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprintln(stderr, "usage: config print [--config PATH]")
+		return exitUsageError
+	}
+	fs := flag.NewFlagSet("config print", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configFile := fs.String("config", agent.DefaultConfigFile, "Path to the agent's configuration file.")
+	if err := fs.Parse(args[1:]); err != nil {
+		return exitUsageError
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %v\n", fs.Args())
+		return exitUsageError
+	}
+
+	config := agent.NewDefault()
+	if err := config.ParseConfigFile(*configFile); err != nil {
+		fmt.Fprintf(stderr, "%s: parsing config: %v\n", *configFile, err)
+		return exitConfigInvalid
+	}
+	if err := config.Complete(); err != nil {
+		fmt.Fprintf(stderr, "%s: completing config: %v\n", *configFile, err)
+		return exitConfigInvalid
+	}
+	out, err := diagnostics.SanitizedConfig(config)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", *configFile, err)
+		return exitConfigInvalid
+	}
+	_, _ = stdout.Write(out)
+	return exitConfigValid
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/internal/agent"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestConfigPrint(t *testing.T) {
+	require := require.New(t)
+	rootDir := t.TempDir()
+	t.Setenv(agent.TestRootDirEnvKey, rootDir)
+	t.Setenv(agent.ConfigEnvPrefix+"LOG_LEVEL", "debug")
+	dropInDir := filepath.Join(rootDir, agent.DropInConfigDir(agent.DefaultConfigFile))
+	require.NoError(os.MkdirAll(dropInDir, 0755))
+	require.NoError(os.WriteFile(filepath.Join(rootDir, agent.DefaultConfigFile), []byte(fmt.Sprintf(validateTestConfig, "https://enrollment.endpoint")), 0600))
+	require.NoError(os.WriteFile(filepath.Join(dropInDir, "10-site.yaml"), []byte("default-labels:\n  site: berlin\n"), 0600))
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(exitConfigValid, runConfig([]string{"print", "--config", agent.DefaultConfigFile}, &stdout, &stderr), stderr.String())
+
+	var printed map[string]interface{}
+	require.NoError(yaml.Unmarshal(stdout.Bytes(), &printed))
+	require.Equal("debug", printed["log-level"])
+	require.Equal(map[string]interface{}{"site": "berlin"}, printed["default-labels"])
+	// the management service is completed from the enrollment service, and credentials are redacted
+	require.Equal("https://enrollment.endpoint", printed["management-service"].(map[string]interface{})["service"].(map[string]interface{})["server"])
+	require.NotContains(stdout.String(), "ijkl")
+}
+
+func TestConfigPrintUsage(t *testing.T) {
+	for _, args := range [][]string{nil, {"show"}, {"print", "extra"}} {
+		var stdout, stderr bytes.Buffer
+		require.Equal(t, exitUsageError, runConfig(args, &stdout, &stderr))
+	}
+}
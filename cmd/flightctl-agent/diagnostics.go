@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/diagnostics"
+	"github.com/flightctl/flightctl/pkg/executer"
+)
+
+const (
+	// exit codes of the diagnostics command
+	exitDiagnosticsCollected = 0
+	exitDiagnosticsFailed    = 1
+)
+
+// runDiagnostics collects the diagnostics of the agent into an archive for support and returns
+// the exit code to use. The diagnostics are collected even if the configuration is invalid.
+func runDiagnostics(args []string, stdout io.Writer, stderr io.Writer) int {
+	return collectDiagnostics(args, stdout, stderr, &executer.CommonExecuter{}, time.Now)
+}
+
+func collectDiagnostics(args []string, stdout io.Writer, stderr io.Writer, exec executer.Executer, now func() time.Time) int {
+	fs := flag.NewFlagSet("diagnostics", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configFile := fs.String("config", agent.DefaultConfigFile, "Path to the agent's configuration file.")
+	output := fs.String("output", "", "Path of the archive to write, defaults to flightctl-agent-diagnostics-<time>.tar.gz in the current directory. Use - for stdout.")
+	logLines := fs.Int("log-lines", diagnostics.DefaultLogLines, "Number of the most recent lines of the agent's journal to collect.")
+	timeout := fs.Duration("timeout", diagnostics.DefaultConnectivityTimeout, "Timeout of the connectivity test of each service.")
+	if err := fs.Parse(args); err != nil {
+		return exitUsageError
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %v\n", fs.Args())
+		return exitUsageError
+	}
+	if *logLines < 0 {
+		fmt.Fprintf(stderr, "invalid --log-lines %d: must not be negative\n", *logLines)
+		return exitUsageError
+	}
+
+	config := agent.NewDefault()
+	configErr := validateConfigFile(*configFile)
+	if configErr != nil {
+		fmt.Fprintf(stderr, "%s: configuration is invalid, collecting diagnostics anyway: %v\n", *configFile, configErr)
+	}
+	// load what can be loaded of the configuration, the errors are already reported
+	_ = config.ParseConfigFile(*configFile)
+	_ = config.Complete()
+
+	collector := diagnostics.New(config,
+		fileio.NewReadWriter(fileio.WithTestRootDir(config.GetTestRootDir())),
+		exec,
+		diagnostics.WithConfigError(configErr),
+		diagnostics.WithLogLines(*logLines),
+		diagnostics.WithConnectivityTimeout(*timeout),
+		diagnostics.WithClock(now),
+	)
+
+	if *output == "-" {
+		if err := collector.Collect(context.Background(), stdout); err != nil {
+			fmt.Fprintf(stderr, "collecting diagnostics: %v\n", err)
+			return exitDiagnosticsFailed
+		}
+		return exitDiagnosticsCollected
+	}
+
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("flightctl-agent-diagnostics-%s.tar.gz", now().UTC().Format("20060102T150405Z"))
+	}
+	// the archive is meant to be shared, but is still only readable by its owner until it is
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		fmt.Fprintf(stderr, "creating archive: %v\n", err)
+		return exitDiagnosticsFailed
+	}
+	if err := collector.Collect(context.Background(), f); err != nil {
+		f.Close()
+		fmt.Fprintf(stderr, "collecting diagnostics: %v\n", err)
+		return exitDiagnosticsFailed
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintf(stderr, "writing archive: %v\n", err)
+		return exitDiagnosticsFailed
+	}
+	fmt.Fprintf(stdout, "Diagnostics written to %s\n", path)
+	return exitDiagnosticsCollected
+}
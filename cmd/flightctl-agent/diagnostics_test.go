@@ -0,0 +1,88 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDiagnostics(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name         string
+		config       string
+		args         []string
+		expectedCode int
+		expectedErr  string
+	}{
+		{
+			name:         "valid config",
+			config:       fmt.Sprintf(validateTestConfig, "https://127.0.0.1:1"),
+			expectedCode: exitDiagnosticsCollected,
+		},
+		{
+			name:         "invalid config",
+			config:       fmt.Sprintf(validateTestConfig, "https://"),
+			expectedCode: exitDiagnosticsCollected,
+			expectedErr:  "configuration is invalid, collecting diagnostics anyway",
+		},
+		{
+			name:         "negative log lines",
+			args:         []string{"--log-lines", "-1"},
+			expectedCode: exitUsageError,
+			expectedErr:  "invalid --log-lines",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rootDir := t.TempDir()
+			t.Setenv(agent.TestRootDirEnvKey, rootDir)
+			require.NoError(os.MkdirAll(filepath.Join(rootDir, agent.DefaultConfigDir), 0755))
+			require.NoError(os.MkdirAll(filepath.Join(rootDir, agent.DefaultDataDir), 0755))
+			require.NoError(os.WriteFile(filepath.Join(rootDir, agent.DefaultConfigFile), []byte(tt.config), 0600))
+			output := filepath.Join(t.TempDir(), "diagnostics.tar.gz")
+			args := append([]string{"--config", agent.DefaultConfigFile, "--output", output, "--timeout", "1s"}, tt.args...)
+
+			exec := executer.NewMockExecuter(ctrl)
+			exec.EXPECT().ExecuteWithContext(gomock.Any(), gomock.Any(), gomock.Any()).Return("", "", 0).AnyTimes()
+
+			var stdout, stderr bytes.Buffer
+			code := collectDiagnostics(args, &stdout, &stderr, exec, func() time.Time { return now })
+			require.Equal(tt.expectedCode, code, stderr.String())
+			require.Contains(stderr.String(), tt.expectedErr)
+			if code != exitDiagnosticsCollected {
+				require.NoFileExists(output)
+				return
+			}
+
+			require.Contains(stdout.String(), "Diagnostics written to "+output)
+			info, err := os.Stat(output)
+			require.NoError(err)
+			require.Equal(os.FileMode(0600), info.Mode().Perm())
+
+			f, err := os.Open(output)
+			require.NoError(err)
+			defer f.Close()
+			gz, err := gzip.NewReader(f)
+			require.NoError(err)
+			header, err := tar.NewReader(gz).Next()
+			require.NoError(err)
+			require.Equal("flightctl-agent-diagnostics-20241001T120000Z/version.txt", header.Name)
+		})
+	}
+}
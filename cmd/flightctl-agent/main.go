@@ -18,6 +18,15 @@ func main() {
 		fmt.Printf("Git Commit: %s\n", versionInfo.GitCommit)
 		os.Exit(0)
 	}
+	if len(os.Args) > 1 && os.Args[1] == "validate-config" {
+		os.Exit(runValidateConfig(os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "diagnostics" {
+		os.Exit(runDiagnostics(os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfig(os.Args[2:], os.Stdout, os.Stderr))
+	}
 
 	command := NewAgentCommand()
 	if err := command.Execute(); err != nil {
@@ -37,14 +46,17 @@ func NewAgentCommand() *agentCmd {
 		config: agent.NewDefault(),
 	}
 
-	flag.StringVar(&a.configFile, "config", agent.DefaultConfigFile, "Path to the agent's configuration file.")
+	flag.StringVar(&a.configFile, "config", agent.DefaultConfigFile, "Path to the agent's configuration file. The *.yaml drop-in files of the directory named after it with a .d extension, e.g. config.d, override it in lexical order.")
 
 	flag.Usage = func() {
 		fmt.Fprintf(flag.CommandLine.Output(), "Usage of %s:\n", os.Args[0])
 		fmt.Println("flags:")
 		flag.PrintDefaults()
 		fmt.Println("commands:")
-		fmt.Println("  version    Display version information")
+		fmt.Println("  version          Display version information")
+		fmt.Println("  validate-config  Validate the configuration file without starting the agent")
+		fmt.Println("  diagnostics      Collect logs, sanitized configuration and system information for support")
+		fmt.Println("  config print     Print the effective configuration merged from the config file, its drop-in files and the environment")
 	}
 
 	flag.Parse()
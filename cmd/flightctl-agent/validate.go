@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/flightctl/flightctl/internal/agent"
+)
+
+const (
+	// exit codes of the validate-config command
+	exitConfigValid   = 0
+	exitConfigInvalid = 1
+	exitUsageError    = 2
+)
+
+// runValidateConfig parses, completes and validates the agent's configuration file the same way
+// the agent does on startup, without starting the agent, and returns the exit code to use.
+func runValidateConfig(args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := flag.NewFlagSet("validate-config", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configFile := fs.String("config", agent.DefaultConfigFile, "Path to the agent's configuration file.")
+	if err := fs.Parse(args); err != nil {
+		return exitUsageError
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %v\n", fs.Args())
+		return exitUsageError
+	}
+
+	if err := validateConfigFile(*configFile); err != nil {
+		fmt.Fprintf(stderr, "%s: configuration is invalid: %v\n", *configFile, err)
+		return exitConfigInvalid
+	}
+	fmt.Fprintf(stdout, "%s: configuration is valid\n", *configFile)
+	return exitConfigValid
+}
+
+func validateConfigFile(configFile string) error {
+	config := agent.NewDefault()
+	if err := config.ParseConfigFile(configFile); err != nil {
+		return fmt.Errorf("parsing config: %w", err)
+	}
+	if err := config.Complete(); err != nil {
+		return fmt.Errorf("completing config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("validating config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/internal/agent"
+	"github.com/stretchr/testify/require"
+)
+
+const validateTestConfig = `enrollment-service:
+  service:
+    server: %s
+    certificate-authority-data: abcd
+  authentication:
+    client-certificate-data: efgh
+    client-key-data: ijkl
+  enrollment-ui-endpoint: https://ui.enrollment.endpoint
+`
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name         string
+		config       string
+		skipDataDir  bool
+		args         []string
+		expectedCode int
+		expectedOut  string
+		expectedErr  string
+	}{
+		{
+			name:         "valid config",
+			config:       fmt.Sprintf(validateTestConfig, "https://enrollment.endpoint"),
+			expectedCode: exitConfigValid,
+			expectedOut:  "configuration is valid",
+		},
+		{
+			name:         "invalid server",
+			config:       fmt.Sprintf(validateTestConfig, "https://"),
+			expectedCode: exitConfigInvalid,
+			expectedErr:  "no hostname",
+		},
+		{
+			name:         "missing data dir",
+			config:       fmt.Sprintf(validateTestConfig, "https://enrollment.endpoint"),
+			skipDataDir:  true,
+			expectedCode: exitConfigInvalid,
+			expectedErr:  "data-dir does not exist",
+		},
+		{
+			name:         "malformed file",
+			config:       "enrollment-service: [",
+			expectedCode: exitConfigInvalid,
+			expectedErr:  "parsing config",
+		},
+		{
+			name:         "unknown flag",
+			args:         []string{"--unknown"},
+			expectedCode: exitUsageError,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			rootDir := t.TempDir()
+			t.Setenv(agent.TestRootDirEnvKey, rootDir)
+			require.NoError(os.MkdirAll(filepath.Join(rootDir, agent.DefaultConfigDir), 0755))
+			if !tt.skipDataDir {
+				require.NoError(os.MkdirAll(filepath.Join(rootDir, agent.DefaultDataDir), 0755))
+			}
+			require.NoError(os.WriteFile(filepath.Join(rootDir, agent.DefaultConfigFile), []byte(tt.config), 0600))
+			args := append([]string{"--config", agent.DefaultConfigFile}, tt.args...)
+
+			var stdout, stderr bytes.Buffer
+			code := runValidateConfig(args, &stdout, &stderr)
+			require.Equal(tt.expectedCode, code)
+			require.Contains(stdout.String(), tt.expectedOut)
+			require.Contains(stderr.String(), tt.expectedErr)
+		})
+	}
+}
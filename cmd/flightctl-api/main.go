@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	apiserver "github.com/flightctl/flightctl/internal/api_server"
 	"github.com/flightctl/flightctl/internal/api_server/agentserver"
@@ -16,9 +17,11 @@ import (
 	"github.com/flightctl/flightctl/internal/crypto"
 	"github.com/flightctl/flightctl/internal/instrumentation"
 	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/tasks"
 	"github.com/flightctl/flightctl/pkg/log"
 	"github.com/flightctl/flightctl/pkg/queues"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 const (
@@ -78,14 +81,18 @@ func main() {
 	if err != nil {
 		log.Fatalf("initializing data store: %v", err)
 	}
+	dbMetrics := store.NewMetricsCollector(time.Duration(cfg.Database.SlowQueryThreshold))
+	if err := db.Use(dbMetrics); err != nil {
+		log.Fatalf("instrumenting data store: %v", err)
+	}
 
-	store := store.NewStore(db, log.WithField("pkg", "store"))
-	defer store.Close()
-
-	if err := store.InitialMigration(); err != nil {
+	if err := migrate(cfg, log, db); err != nil {
 		log.Fatalf("running initial migration: %v", err)
 	}
 
+	store := store.NewStore(db, log.WithField("pkg", "store"), store.OptionsFromConfig(cfg)...)
+	defer store.Close()
+
 	tlsConfig, agentTlsConfig, err := crypto.TLSConfigForServer(ca.Config, serverCerts)
 	if err != nil {
 		log.Fatalf("failed creating TLS config: %v", err)
@@ -93,7 +100,17 @@ func main() {
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGQUIT)
 
-	provider, err := queues.NewRedisProvider(ctx, log, cfg.KV.Hostname, cfg.KV.Port, cfg.KV.Password)
+	if len(cfg.Service.CrlSource) > 0 {
+		crlChecker, err := crypto.NewCRLChecker(log.WithField("pkg", "crl"), cfg.Service.CrlSource, ca.Config, time.Duration(cfg.Service.CrlRefreshInterval))
+		if err != nil {
+			log.Fatalf("loading certificate revocation list: %v", err)
+		}
+		agentTlsConfig.VerifyPeerCertificate = crlChecker.VerifyPeerCertificate
+		go crlChecker.Run(ctx)
+	}
+
+	provider, err := queues.NewRedisProvider(ctx, log, cfg.KV.Hostname, cfg.KV.Port, cfg.KV.Password,
+		queues.WithCompression(queues.Compression(cfg.KV.QueueCompression), cfg.KV.QueueCompressionThreshold))
 	if err != nil {
 		log.Fatalf("failed connecting to Redis queue: %v", err)
 	}
@@ -130,7 +147,8 @@ func main() {
 
 	if cfg.Prometheus != nil {
 		go func() {
-			metricsServer := instrumentation.NewMetricsServer(log, cfg, metrics)
+			metricsServer := instrumentation.NewMetricsServer(log, cfg, metrics,
+				instrumentation.NewQueueCollector(log, provider, tasks.TaskQueue), dbMetrics)
 			if err := metricsServer.Run(ctx); err != nil {
 				log.Fatalf("Error running server: %s", err)
 			}
@@ -148,3 +166,17 @@ func certFile(name string) string {
 func keyFile(name string) string {
 	return filepath.Join(config.CertificateDir(), name+".key")
 }
+
+// migrate runs the migrations as the migration user when one is configured, as the tables must not
+// be owned by the user the services connect as, and on db otherwise.
+func migrate(cfg *config.Config, log *logrus.Logger, db *gorm.DB) error {
+	if cfg.Database.MigrationUser == "" {
+		return store.NewStore(db, log.WithField("pkg", "store")).InitialMigration()
+	}
+	migrationDB, err := store.InitMigrationDB(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer store.CloseDB(migrationDB)
+	return store.NewStore(migrationDB, log.WithField("pkg", "store")).InitialMigration()
+}
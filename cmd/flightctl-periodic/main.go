@@ -30,7 +30,7 @@ func main() {
 		log.Fatalf("initializing data store: %v", err)
 	}
 
-	store := store.NewStore(db, log.WithField("pkg", "store"))
+	store := store.NewStore(db, log.WithField("pkg", "store"), store.OptionsFromConfig(cfg)...)
 	defer store.Close()
 
 	server := periodic.New(cfg, log, store)
@@ -38,12 +38,13 @@ func main() {
 		log.Fatalf("initializing data store: %v", err)
 	}
 
-	store := store.NewStore(db, log.WithField("pkg", "store"))
+	store := store.NewStore(db, log.WithField("pkg", "store"), store.OptionsFromConfig(cfg)...)
 	defer store.Close()
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGQUIT)
 
-	provider, err := queues.NewRedisProvider(ctx, log, cfg.KV.Hostname, cfg.KV.Port, cfg.KV.Password)
+	provider, err := queues.NewRedisProvider(ctx, log, cfg.KV.Hostname, cfg.KV.Port, cfg.KV.Password,
+		queues.WithCompression(queues.Compression(cfg.KV.QueueCompression), cfg.KV.QueueCompressionThreshold))
 	if err != nil {
 		log.Fatalf("failed connecting to Redis queue: %v", err)
 	}
@@ -25,17 +25,26 @@ func NewFlightCtlCommand() *cobra.Command {
 	}
 	cmd.AddCommand(cli.NewCmdGet())
 	cmd.AddCommand(cli.NewCmdApply())
+	cmd.AddCommand(cli.NewCmdRollback())
 	cmd.AddCommand(cli.NewCmdDelete())
 	cmd.AddCommand(cli.NewCmdApprove())
 	cmd.AddCommand(cli.NewCmdCSRConfig())
 	cmd.AddCommand(cli.NewCmdDecommission())
+	cmd.AddCommand(cli.NewCmdDrain())
+	cmd.AddCommand(cli.NewCmdWait())
 	cmd.AddCommand(cli.NewCmdDeny())
 	cmd.AddCommand(cli.NewCmdLogin())
+	cmd.AddCommand(cli.NewCmdConfig())
+	cmd.AddCommand(cli.NewCmdExplain())
 	cmd.AddCommand(cli.NewCmdVersion())
 	cmd.AddCommand(cli.NewConsoleCmd())
+	cmd.AddCommand(cli.NewCmdLogs())
+	cmd.AddCommand(cli.NewCmdCp())
+	cmd.AddCommand(cli.NewCmdTop())
 	cmd.AddCommand(cli.NewCmdCompletion())
 	cmd.AddCommand(cli.NewCmdEnrollmentConfig())
 	cmd.AddCommand(cli.NewCmdCertificate())
+	cmd.AddCommand(cli.NewCmdDebug())
 
 	return cmd
 }
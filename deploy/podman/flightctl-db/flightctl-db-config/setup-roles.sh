@@ -0,0 +1,27 @@
+#!/bin/bash
+
+_psql () { psql --set ON_ERROR_STOP=1 "$@" ; }
+
+# Ensure POSTGRESQL_MASTER_USER is treated as a superuser, it owns the tables and runs the migrations
+if [ -n "${POSTGRESQL_MASTER_USER}" ]; then
+  echo "Granting superuser privileges to ${POSTGRESQL_MASTER_USER}"
+  _psql -c "ALTER ROLE ${POSTGRESQL_MASTER_USER} WITH SUPERUSER;"
+fi
+
+# The services connect as POSTGRESQL_USER, to which the row-level security policies apply: it must
+# neither be a superuser nor own the tables
+if [ -n "${POSTGRESQL_USER}" ]; then
+  echo "Granting table privileges to ${POSTGRESQL_USER}"
+  _psql -d "${POSTGRESQL_DATABASE}" <<SQL
+ALTER ROLE ${POSTGRESQL_USER} NOSUPERUSER NOBYPASSRLS;
+DO \$\$ BEGIN CREATE ROLE flightctl_org_bypass NOLOGIN; EXCEPTION WHEN duplicate_object THEN NULL; END \$\$;
+GRANT flightctl_org_bypass TO ${POSTGRESQL_USER};
+GRANT USAGE ON SCHEMA public TO ${POSTGRESQL_USER}, flightctl_org_bypass;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO ${POSTGRESQL_USER}, flightctl_org_bypass;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO ${POSTGRESQL_USER}, flightctl_org_bypass;
+ALTER DEFAULT PRIVILEGES FOR ROLE ${POSTGRESQL_MASTER_USER} IN SCHEMA public
+  GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO ${POSTGRESQL_USER}, flightctl_org_bypass;
+ALTER DEFAULT PRIVILEGES FOR ROLE ${POSTGRESQL_MASTER_USER} IN SCHEMA public
+  GRANT USAGE, SELECT ON SEQUENCES TO ${POSTGRESQL_USER}, flightctl_org_bypass;
+SQL
+fi
@@ -23,9 +23,6 @@ start_service flightctl.slice
 echo "Waiting for database to be ready..."
 test/scripts/wait_for_postgres.sh podman
 
-echo "Granting superuser privileges to admin role"
-sudo podman exec flightctl-db psql -c 'ALTER ROLE admin WITH SUPERUSER'
-
 echo "Checking if all services are running..."
 
 timeout --foreground 300s bash -c '
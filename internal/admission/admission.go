@@ -0,0 +1,63 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Operation is the operation a resource is admitted for.
+type Operation string
+
+const (
+	OperationCreate Operation = "CREATE"
+	OperationUpdate Operation = "UPDATE"
+)
+
+// Request describes a resource about to be persisted.
+type Request struct {
+	// Kind is the kind of the resource, e.g. "Device".
+	Kind string `json:"kind"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// Operation is the operation the resource is persisted by.
+	Operation Operation `json:"operation"`
+	// Object is the resource as it is about to be persisted.
+	Object interface{} `json:"object"`
+}
+
+// Validator decides whether a resource may be persisted. It returns a *DeniedError to reject the
+// resource, and any other error if it could not decide.
+type Validator interface {
+	Validate(ctx context.Context, request Request) error
+}
+
+// DeniedError is returned when a validator rejects a resource.
+type DeniedError struct {
+	// Validator is the name of the validator that rejected the resource.
+	Validator string
+	// Message explains why the resource was rejected.
+	Message string
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("denied by admission webhook %q: %s", e.Validator, e.Message)
+}
+
+// IsDenied returns true if the error rejects a resource.
+func IsDenied(err error) bool {
+	var deniedErr *DeniedError
+	return errors.As(err, &deniedErr)
+}
+
+// Chain admits a resource if all of its validators admit it, in order.
+type Chain []Validator
+
+func (c Chain) Validate(ctx context.Context, request Request) error {
+	for _, validator := range c {
+		if err := validator.Validate(ctx, request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package admission
+
+import (
+	"time"
+
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+// NewFromConfig returns the validators of the admission webhooks of the configuration, or nil if no
+// admission webhook is configured.
+func NewFromConfig(log logrus.FieldLogger, cfg *config.Config) (Validator, error) {
+	if cfg.Admission == nil || len(cfg.Admission.Webhooks) == 0 {
+		return nil, nil
+	}
+	webhooks := make([]WebhookConfig, len(cfg.Admission.Webhooks))
+	for i, webhook := range cfg.Admission.Webhooks {
+		webhooks[i] = WebhookConfig{
+			Name:          webhook.Name,
+			URL:           webhook.Url,
+			Kinds:         webhook.Kinds,
+			Timeout:       time.Duration(webhook.Timeout),
+			FailurePolicy: webhook.FailurePolicy,
+		}
+	}
+	return NewWebhooks(log, webhooks)
+}
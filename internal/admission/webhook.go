@@ -0,0 +1,154 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// FailurePolicyFail rejects the resource when the webhook cannot be reached or responds with
+	// an error.
+	FailurePolicyFail = "fail"
+	// FailurePolicyIgnore admits the resource when the webhook cannot be reached or responds with
+	// an error.
+	FailurePolicyIgnore = "ignore"
+
+	// DefaultTimeout is how long the webhook has to respond by default.
+	DefaultTimeout = 10 * time.Second
+
+	// maxResponseSize bounds the size of the response read from the webhook.
+	maxResponseSize = 64 * 1024
+)
+
+// Response is the decision of the webhook, the body of its response.
+type Response struct {
+	// Allowed is whether the resource may be persisted.
+	Allowed bool `json:"allowed"`
+	// Message explains why the resource was rejected.
+	Message string `json:"message,omitempty"`
+}
+
+// WebhookConfig configures a webhook the resources of the given kinds are sent to for validation.
+type WebhookConfig struct {
+	Name string
+	URL  string
+	// Kinds filter the resources by kind, all kinds are validated if empty.
+	Kinds []string
+	// Timeout is how long the webhook has to respond, DefaultTimeout if zero.
+	Timeout time.Duration
+	// FailurePolicy is FailurePolicyFail or FailurePolicyIgnore, FailurePolicyFail if empty.
+	FailurePolicy string
+}
+
+// Validate checks the URL and failure policy of the webhook.
+func (c *WebhookConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("webhook name must not be empty")
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook %s: invalid url %q", c.Name, c.URL)
+	}
+	switch c.FailurePolicy {
+	case "", FailurePolicyFail, FailurePolicyIgnore:
+	default:
+		return fmt.Errorf("webhook %s: invalid failure policy %q: must be %q or %q", c.Name, c.FailurePolicy, FailurePolicyFail, FailurePolicyIgnore)
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("webhook %s: timeout must not be negative", c.Name)
+	}
+	return nil
+}
+
+// Webhook validates resources by posting the Request as JSON to an external service, which
+// responds with its decision as a Response.
+type Webhook struct {
+	log    logrus.FieldLogger
+	config WebhookConfig
+	client *http.Client
+}
+
+// NewWebhook returns a validator calling the configured webhook.
+func NewWebhook(log logrus.FieldLogger, config WebhookConfig) (*Webhook, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	return &Webhook{
+		log:    log,
+		config: config,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// NewWebhooks returns a chain of validators calling the configured webhooks in order.
+func NewWebhooks(log logrus.FieldLogger, configs []WebhookConfig) (Chain, error) {
+	chain := Chain{}
+	for _, config := range configs {
+		webhook, err := NewWebhook(log, config)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, webhook)
+	}
+	return chain, nil
+}
+
+func (w *Webhook) Validate(ctx context.Context, request Request) error {
+	if len(w.config.Kinds) > 0 && !slices.Contains(w.config.Kinds, request.Kind) {
+		return nil
+	}
+	response, err := w.call(ctx, request)
+	if err != nil {
+		if w.config.FailurePolicy == FailurePolicyIgnore {
+			w.log.WithError(err).Warnf("admission webhook %s failed, admitting %s/%s", w.config.Name, request.Kind, request.Name)
+			return nil
+		}
+		return fmt.Errorf("admission webhook %q failed: %w", w.config.Name, err)
+	}
+	if !response.Allowed {
+		message := response.Message
+		if message == "" {
+			message = "no reason given"
+		}
+		return &DeniedError{Validator: w.config.Name, Message: message}
+	}
+	return nil
+}
+
+func (w *Webhook) call(ctx context.Context, request Request) (*Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected response %s", resp.Status)
+	}
+	var response Response
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &response, nil
+}
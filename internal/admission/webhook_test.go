@@ -0,0 +1,106 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, handler func(Request) (int, Response)) (*httptest.Server, *int) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var request Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		status, response := handler(request)
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestWebhookDecision(t *testing.T) {
+	require := require.New(t)
+	server, _ := newTestServer(t, func(request Request) (int, Response) {
+		if request.Name == "forbidden" {
+			return http.StatusOK, Response{Allowed: false, Message: "name is reserved"}
+		}
+		return http.StatusOK, Response{Allowed: true}
+	})
+
+	webhook, err := NewWebhook(logrus.New(), WebhookConfig{Name: "policy", URL: server.URL})
+	require.NoError(err)
+
+	err = webhook.Validate(context.Background(), Request{Kind: "Fleet", Name: "allowed", Operation: OperationCreate})
+	require.NoError(err)
+
+	err = webhook.Validate(context.Background(), Request{Kind: "Fleet", Name: "forbidden", Operation: OperationCreate})
+	require.True(IsDenied(err))
+	require.ErrorContains(err, "name is reserved")
+}
+
+func TestWebhookKinds(t *testing.T) {
+	require := require.New(t)
+	server, calls := newTestServer(t, func(Request) (int, Response) {
+		return http.StatusOK, Response{Allowed: false}
+	})
+
+	webhook, err := NewWebhook(logrus.New(), WebhookConfig{Name: "policy", URL: server.URL, Kinds: []string{"Device"}})
+	require.NoError(err)
+
+	require.NoError(webhook.Validate(context.Background(), Request{Kind: "Fleet", Name: "fleet"}))
+	require.Equal(0, *calls)
+	require.True(IsDenied(webhook.Validate(context.Background(), Request{Kind: "Device", Name: "device"})))
+	require.Equal(1, *calls)
+}
+
+func TestWebhookFailurePolicy(t *testing.T) {
+	require := require.New(t)
+	server, _ := newTestServer(t, func(Request) (int, Response) {
+		return http.StatusInternalServerError, Response{}
+	})
+
+	webhook, err := NewWebhook(logrus.New(), WebhookConfig{Name: "policy", URL: server.URL})
+	require.NoError(err)
+	err = webhook.Validate(context.Background(), Request{Kind: "Fleet", Name: "fleet"})
+	require.Error(err)
+	require.False(IsDenied(err))
+
+	webhook, err = NewWebhook(logrus.New(), WebhookConfig{Name: "policy", URL: server.URL, FailurePolicy: FailurePolicyIgnore})
+	require.NoError(err)
+	require.NoError(webhook.Validate(context.Background(), Request{Kind: "Fleet", Name: "fleet"}))
+}
+
+func TestWebhookConfigValidate(t *testing.T) {
+	require := require.New(t)
+	_, err := NewWebhook(logrus.New(), WebhookConfig{Name: "policy", URL: "ftp://example.com"})
+	require.ErrorContains(err, "invalid url")
+	_, err = NewWebhook(logrus.New(), WebhookConfig{Name: "policy", URL: "https://example.com", FailurePolicy: "retry"})
+	require.ErrorContains(err, "invalid failure policy")
+	_, err = NewWebhook(logrus.New(), WebhookConfig{Name: "policy", URL: "https://example.com", Timeout: -time.Second})
+	require.ErrorContains(err, "timeout")
+}
+
+func TestChain(t *testing.T) {
+	require := require.New(t)
+	allow, _ := newTestServer(t, func(Request) (int, Response) {
+		return http.StatusOK, Response{Allowed: true}
+	})
+	deny, _ := newTestServer(t, func(Request) (int, Response) {
+		return http.StatusOK, Response{Allowed: false, Message: "denied"}
+	})
+
+	chain, err := NewWebhooks(logrus.New(), []WebhookConfig{{Name: "allow", URL: allow.URL}, {Name: "deny", URL: deny.URL}})
+	require.NoError(err)
+	err = chain.Validate(context.Background(), Request{Kind: "Repository", Name: "repo"})
+	var deniedErr *DeniedError
+	require.ErrorAs(err, &deniedErr)
+	require.Equal("deny", deniedErr.Validator)
+}
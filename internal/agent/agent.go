@@ -3,33 +3,42 @@ package agent
 import (
 	"context"
 	"crypto"
-	"encoding/base32"
 	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
 
 	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
+	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/clockskew"
 	"github.com/flightctl/flightctl/internal/agent/device"
 	"github.com/flightctl/flightctl/internal/agent/device/applications"
 	"github.com/flightctl/flightctl/internal/agent/device/config"
 	"github.com/flightctl/flightctl/internal/agent/device/console"
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/health"
 	"github.com/flightctl/flightctl/internal/agent/device/hook"
 	"github.com/flightctl/flightctl/internal/agent/device/lifecycle"
+	"github.com/flightctl/flightctl/internal/agent/device/logshipper"
 	"github.com/flightctl/flightctl/internal/agent/device/os"
 	"github.com/flightctl/flightctl/internal/agent/device/policy"
 	"github.com/flightctl/flightctl/internal/agent/device/resource"
 	"github.com/flightctl/flightctl/internal/agent/device/spec"
 	"github.com/flightctl/flightctl/internal/agent/device/status"
 	"github.com/flightctl/flightctl/internal/agent/device/systemd"
+	"github.com/flightctl/flightctl/internal/agent/device/telemetry"
+	"github.com/flightctl/flightctl/internal/agent/device/verification"
+	"github.com/flightctl/flightctl/internal/agent/device/watchdog"
+	"github.com/flightctl/flightctl/internal/agent/identity"
 	"github.com/flightctl/flightctl/internal/agent/shutdown"
 	fcrypto "github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/tpm"
 	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
 )
 
 const (
@@ -75,12 +84,11 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
-	publicKeyHash, err := fcrypto.HashPublicKey(publicKey)
+	identityProvider := identity.NewProvider(a.log, a.config.Identity, deviceReadWriter, a.readEndorsementKey)
+	deviceName, err := identityProvider.DeviceName(publicKey)
 	if err != nil {
 		return err
 	}
-
-	deviceName := strings.ToLower(base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString(publicKeyHash))
 	csr, err := fcrypto.MakeCSR(privateKey.(crypto.Signer), deviceName)
 	if err != nil {
 		return err
@@ -88,6 +96,18 @@ func (a *Agent) Run(ctx context.Context) error {
 
 	executer := &executer.CommonExecuter{}
 
+	// tolerate the clock of the device being off when connecting to the services
+	clockSkew := clockskew.NewMonitor(a.log, a.config.ClockSkew, executer).ClockSkew()
+	a.config.EnrollmentService.Config.SetClockSkew(clockSkew)
+	a.config.ManagementService.Config.SetClockSkew(clockSkew)
+	a.config.EnrollmentService.Config.SetResolver(&a.config.DNS)
+	a.config.ManagementService.Config.SetResolver(&a.config.DNS)
+
+	// obtain the enrollment certificate with the bootstrap token, if the device has none
+	if err := redeemBootstrapToken(ctx, a.log, deviceReadWriter, &a.config.EnrollmentService); err != nil {
+		return err
+	}
+
 	// create enrollment client
 	enrollmentClient, err := newEnrollmentClient(a.config)
 	if err != nil {
@@ -105,9 +125,6 @@ func (a *Agent) Run(ctx context.Context) error {
 	// create bootc client
 	bootcClient := client.NewBootc(a.log, executer)
 
-	// create podman client
-	podmanClient := client.NewPodman(a.log, executer, backoff)
-
 	// create systemd client
 	systemdClient := client.NewSystemd(executer)
 
@@ -117,10 +134,39 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
+	// create the store of the device-local secrets referenced by file templates
+	secretsDir := a.config.SecretsDir
+	if secretsDir == "" {
+		secretsDir = DefaultSecretsDir
+	}
+	secretStore := config.NewSecretStore(deviceReadWriter, secretsDir)
+
+	// create status manager
+	statusManager := status.NewManager(
+		deviceName,
+		systemClient,
+		a.log,
+		status.WithRedactedFields(a.config.StatusRedactedFields),
+		status.WithRetryConfig(a.config.StatusRetry),
+		status.WithSecretRedaction(secretStore.Redact),
+	)
+
+	// create podman client, running the container operations with the configured runtime and
+	// reporting the throttled pulls in the device status
+	containerRuntime, err := client.DetectRuntime(ctx, a.log, executer, a.config.ContainerRuntime)
+	if err != nil {
+		return err
+	}
+	podmanClient := client.NewPodman(a.log, executer, backoff, client.WithMaxConcurrentPulls(a.config.MaxConcurrentPulls, pullThrottledReporter(a.log, statusManager, a.config.MaxConcurrentPulls)), client.WithRuntime(containerRuntime))
+
 	// create shutdown manager
 	shutdownManager := shutdown.New(a.log, gracefulShutdownTimeout, cancel)
 
 	policyManager := policy.NewManager(a.log)
+	maintenanceWindow, err := policy.NewMaintenanceWindow(a.config.MaintenanceWindow, clock.RealClock{})
+	if err != nil {
+		return err
+	}
 
 	// create spec manager
 	specManager := spec.NewManager(
@@ -142,7 +188,7 @@ func (a *Agent) Run(ctx context.Context) error {
 	hookManager := hook.NewManager(deviceReadWriter, executer, a.log)
 
 	// create application manager
-	applicationManager := applications.NewManager(a.log, executer, podmanClient, systemClient)
+	applicationManager := applications.NewManager(a.log, executer, podmanClient, systemClient, a.config.MaxConcurrentApplications)
 
 	// register the application manager with the shutdown manager
 	shutdownManager.Register("applications", applicationManager.Stop)
@@ -151,20 +197,14 @@ func (a *Agent) Run(ctx context.Context) error {
 	systemdManager := systemd.NewManager(a.log, systemdClient)
 
 	// create os manager
-	osManager := os.NewManager(a.log, bootcClient, podmanClient)
-
-	// create status manager
-	statusManager := status.NewManager(
-		deviceName,
-		systemClient,
-		a.log,
-	)
+	osManager := os.NewManager(a.log, bootcClient, podmanClient, deviceReadWriter, executer, a.config.OSHealthCheck, a.config.DataDir)
 
 	// create lifecycle manager
 	lifecycleManager := lifecycle.NewManager(
 		deviceName,
 		a.config.EnrollmentService.EnrollmentUIEndpoint,
 		a.config.ManagementService.GetClientCertificatePath(),
+		a.config.StructuredBanner,
 		deviceReadWriter,
 		enrollmentClient,
 		csr,
@@ -183,6 +223,8 @@ func (a *Agent) Run(ctx context.Context) error {
 	// create config controller
 	configController := config.NewController(
 		deviceReadWriter,
+		config.NewDeviceFacts(deviceName, a.config.DefaultLabels),
+		secretStore,
 		a.log,
 	)
 
@@ -193,16 +235,36 @@ func (a *Agent) Run(ctx context.Context) error {
 		specManager,
 		statusManager,
 		hookManager,
+		osManager,
 		lifecycleManager,
 		&a.config.ManagementService.Config,
 		systemClient,
 		a.log,
 	)
 
+	// start the systemd watchdog before bootstrap, which may wait for the enrollment of the device
+	watchdogTimeout, err := watchdog.Timeout()
+	if err != nil {
+		return err
+	}
+	var agentWatchdog *watchdog.Watchdog
+	if watchdogTimeout > 0 {
+		agentWatchdog = watchdog.New(a.log, a.config.Watchdog, watchdogTimeout)
+		go agentWatchdog.Run(ctx)
+	}
+
+	// start the health endpoints before bootstrap, to report that the device is not enrolled yet
+	var healthServer *health.Server
+	if a.config.Health.Enabled {
+		healthServer = health.New(a.log, a.config.Health, statusManager)
+		go healthServer.Run(ctx)
+	}
+
 	// bootstrap
 	if err := bootstrap.Initialize(ctx); err != nil {
 		return fmt.Errorf("bootstrap failed: %w", err)
 	}
+	healthServer.SetEnrolled()
 
 	// create the gRPC client this must be done after bootstrap
 	grpcClient, err := newGrpcClient(&a.config.ManagementService)
@@ -216,6 +278,39 @@ func (a *Agent) Run(ctx context.Context) error {
 		resourceManager,
 	)
 
+	// create disk guard
+	diskGuard := resource.NewDiskGuard(a.log, a.config.DiskGuard)
+
+	// create update deferral
+	updateDeferral := resource.NewUpdateDeferral(a.log, executer, a.config.UpdateDeferral)
+
+	// create log shipper
+	var logShipper *logshipper.Shipper
+	if a.config.LogShipping.Enabled {
+		managementClient, err := newManagementClient(&a.config.ManagementService)
+		if err != nil {
+			return err
+		}
+		logShipper = logshipper.New(a.log, a.config.LogShipping, deviceName, managementClient, executer, logshipper.WithRedaction(secretStore.Redact))
+		go logShipper.Run(ctx)
+	}
+
+	// create update slot
+	var updateSlot *policy.UpdateSlot
+	if a.config.UpdateSlot.Enabled {
+		managementClient, err := newManagementClient(&a.config.ManagementService)
+		if err != nil {
+			return err
+		}
+		updateSlot = policy.NewUpdateSlot(a.config.UpdateSlot, managementClient, deviceName)
+	}
+
+	// create custom metrics pusher
+	if a.config.CustomMetrics.Enabled() {
+		metricsPusher := telemetry.New(a.log, a.config.CustomMetrics, deviceName, deviceReadWriter, executer)
+		go metricsPusher.Run(ctx)
+	}
+
 	// create console controller
 	consoleController := console.NewController(
 		grpcClient,
@@ -224,6 +319,12 @@ func (a *Agent) Run(ctx context.Context) error {
 		a.log,
 	)
 
+	var imageVerifier verification.Verifier
+	if a.config.ImageVerification.Enabled {
+		imageVerifier = verification.NewCosignVerifier(executer, a.config.ImageVerification)
+	}
+	verificationManager := verification.NewManager(a.log, imageVerifier)
+
 	applicationsController := applications.NewController(
 		podmanClient,
 		applicationManager,
@@ -245,12 +346,22 @@ func (a *Agent) Run(ctx context.Context) error {
 		osManager,
 		policyManager,
 		lifecycleManager,
+		verificationManager,
 		applicationsController,
 		configController,
 		resourceController,
+		diskGuard,
+		updateDeferral,
 		consoleController,
 		bootcClient,
 		podmanClient,
+		agentWatchdog,
+		logShipper,
+		healthServer,
+		maintenanceWindow,
+		updateSlot,
+		hook.NewApplyHooks(a.log, executer, a.config.ApplyHooks),
+		policy.NewFailurePolicy(a.config.FailurePolicy),
 		backoff,
 		a.log,
 	)
@@ -272,6 +383,14 @@ func newEnrollmentClient(cfg *Config) (client.Enrollment, error) {
 	return client.NewEnrollment(httpClient), nil
 }
 
+func newManagementClient(cfg *ManagementService) (client.Management, error) {
+	httpClient, err := client.NewFromConfig(&cfg.Config)
+	if err != nil {
+		return nil, fmt.Errorf("creating management client: %w", err)
+	}
+	return client.NewManagement(httpClient), nil
+}
+
 func newGrpcClient(cfg *ManagementService) (grpc_v1.RouterServiceClient, error) {
 	client, err := client.NewGRPCClientFromConfig(&cfg.Config)
 	if err != nil {
@@ -279,3 +398,38 @@ func newGrpcClient(cfg *ManagementService) (grpc_v1.RouterServiceClient, error)
 	}
 	return client, nil
 }
+
+// readEndorsementKey returns the public endorsement key of the TPM of the device.
+func (a *Agent) readEndorsementKey() ([]byte, error) {
+	if a.config.TPMPath == "" {
+		return nil, fmt.Errorf("tpm-path is not set")
+	}
+	t, err := tpm.OpenTPM(a.config.PathFor(a.config.TPMPath))
+	if err != nil {
+		return nil, fmt.Errorf("opening TPM: %w", err)
+	}
+	defer t.Close()
+	return t.GetEndorsementKeyPublic()
+}
+
+// pullThrottledReporter reports the images whose pull waits for one of the maxPulls pull slots
+// with the PullThrottled condition of the device.
+func pullThrottledReporter(log *log.PrefixLogger, statusManager status.Manager, maxPulls int) client.PullThrottledFunc {
+	return func(ctx context.Context, waiting []string) {
+		condition := v1alpha1.Condition{
+			Type:    v1alpha1.DevicePullThrottled,
+			Status:  v1alpha1.ConditionStatusFalse,
+			Reason:  "PullsNotThrottled",
+			Message: "No image pull is throttled",
+		}
+		if len(waiting) > 0 {
+			condition.Status = v1alpha1.ConditionStatusTrue
+			condition.Reason = "PullsThrottled"
+			condition.Message = fmt.Sprintf("The pull of images %s waits for one of %d concurrent pulls to finish",
+				strings.Join(waiting, ", "), maxPulls)
+		}
+		if err := statusManager.UpdateCondition(ctx, condition); err != nil {
+			log.Warnf("Failed setting status: %v", err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestPullThrottledReporter(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	statusManager := status.NewMockManager(ctrl)
+
+	var conditions []v1alpha1.Condition
+	statusManager.EXPECT().UpdateCondition(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, condition v1alpha1.Condition) error {
+			conditions = append(conditions, condition)
+			return nil
+		}).Times(2)
+
+	report := pullThrottledReporter(log.NewPrefixLogger("test"), statusManager, 2)
+	report(context.Background(), []string{"quay.io/app:v1", "quay.io/os:v2"})
+	report(context.Background(), []string{})
+
+	require.Len(conditions, 2)
+	require.Equal(v1alpha1.DevicePullThrottled, conditions[0].Type)
+	require.Equal(v1alpha1.ConditionStatusTrue, conditions[0].Status)
+	require.Equal("The pull of images quay.io/app:v1, quay.io/os:v2 waits for one of 2 concurrent pulls to finish", conditions[0].Message)
+	require.Equal(v1alpha1.DevicePullThrottled, conditions[1].Type)
+	require.Equal(v1alpha1.ConditionStatusFalse, conditions[1].Status)
+}
@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	baseclient "github.com/flightctl/flightctl/internal/client"
+	fcrypto "github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+// redeemBootstrapToken obtains the enrollment certificate of the agent by redeeming its bootstrap
+// token, if it has a token and no enrollment certificate yet.
+func redeemBootstrapToken(ctx context.Context, log *log.PrefixLogger, readWriter fileio.ReadWriter, cfg *EnrollmentService) error {
+	if cfg.BootstrapToken == "" {
+		return nil
+	}
+	certPath := cfg.Config.GetClientCertificatePath()
+	exists, err := readWriter.PathExists(certPath)
+	if err != nil {
+		return fmt.Errorf("checking enrollment certificate: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	log.Infof("Redeeming bootstrap token for an enrollment certificate")
+	_, privateKey, _, err := fcrypto.EnsureKey(readWriter.PathFor(cfg.Config.GetClientKeyPath()))
+	if err != nil {
+		return fmt.Errorf("creating enrollment key: %w", err)
+	}
+	// the service names the enrollment certificate
+	csr, err := fcrypto.MakeCSR(privateKey.(crypto.Signer), "")
+	if err != nil {
+		return fmt.Errorf("creating enrollment csr: %w", err)
+	}
+
+	// the request is made without the enrollment certificate, which is what it obtains
+	clientConfig := cfg.Config.DeepCopy()
+	clientConfig.AuthInfo = baseclient.AuthInfo{}
+	bootstrapClient, err := client.NewFromConfig(clientConfig)
+	if err != nil {
+		return fmt.Errorf("creating bootstrap client: %w", err)
+	}
+	resp, err := bootstrapClient.RedeemBootstrapTokenWithResponse(ctx, api.BootstrapTokenRedemption{Token: cfg.BootstrapToken, Csr: string(csr)})
+	if err != nil {
+		return fmt.Errorf("redeeming bootstrap token: %w", err)
+	}
+	if resp.JSON200 == nil {
+		var apiErr *api.Error
+		switch {
+		case resp.JSON400 != nil:
+			apiErr = resp.JSON400
+		case resp.JSON401 != nil:
+			apiErr = resp.JSON401
+		case resp.JSON404 != nil:
+			apiErr = resp.JSON404
+		}
+		if apiErr == nil || apiErr.Message == "" {
+			return fmt.Errorf("redeeming bootstrap token: %s", resp.Status())
+		}
+		return fmt.Errorf("redeeming bootstrap token: %s: %s", resp.Status(), apiErr.Message)
+	}
+	if err := readWriter.WriteFile(certPath, []byte(resp.JSON200.Certificate), 0600); err != nil {
+		return fmt.Errorf("writing enrollment certificate: %w", err)
+	}
+	log.Infof("Obtained enrollment certificate with bootstrap token")
+	return nil
+}
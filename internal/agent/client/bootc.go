@@ -99,6 +99,16 @@ func (b *bootc) Apply(ctx context.Context) error {
 	return nil
 }
 
+// Rollback reboots into the previously booted image.
+func (b *bootc) Rollback(ctx context.Context) error {
+	args := []string{"rollback", "--apply"}
+	_, stderr, exitCode := b.executer.ExecuteWithContext(ctx, BootcCmd, args...)
+	if exitCode != 0 && exitCode != 137 { // 137 is the exit code for SIGKILL and is expected during reboot 128 + SIGKILL (9)
+		return fmt.Errorf("rollback image: %w", errors.FromStderr(stderr, exitCode))
+	}
+	return nil
+}
+
 // UsrOverlay adds a transient writable overlayfs on `/usr` that will be discarded on reboot.
 func (b *bootc) UsrOverlay(ctx context.Context) error {
 	args := []string{"usr-overlay"}
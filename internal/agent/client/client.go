@@ -13,6 +13,7 @@ import (
 	"github.com/flightctl/flightctl/internal/container"
 	"github.com/flightctl/flightctl/pkg/reqid"
 	"github.com/go-chi/chi/middleware"
+	"k8s.io/utils/clock"
 )
 
 // NewFromConfig returns a new FlightCtl API client from the given config.
@@ -22,6 +23,7 @@ func NewFromConfig(config *baseclient.Config) (*client.ClientWithResponses, erro
 	if err != nil {
 		return nil, fmt.Errorf("NewFromConfig: creating HTTP client %w", err)
 	}
+	httpClient.Transport = newRetryAfterTransport(httpClient.Transport, clock.RealClock{})
 	ref := client.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
 		req.Header.Set(middleware.RequestIDHeader, reqid.GetReqID())
 		return nil
@@ -45,6 +47,9 @@ func NewDefault() *Config {
 type Management interface {
 	UpdateDeviceStatus(ctx context.Context, name string, device v1alpha1.Device, rcb ...client.RequestEditorFn) error
 	GetRenderedDeviceSpec(ctx context.Context, name string, params *v1alpha1.GetRenderedDeviceSpecParams, rcb ...client.RequestEditorFn) (*v1alpha1.RenderedDeviceSpec, int, error)
+	UpdateDeviceLogs(ctx context.Context, name string, logs v1alpha1.DeviceLogs, rcb ...client.RequestEditorFn) error
+	AcquireDeviceUpdateSlot(ctx context.Context, name string, rcb ...client.RequestEditorFn) (*v1alpha1.DeviceUpdateSlot, error)
+	ReleaseDeviceUpdateSlot(ctx context.Context, name string, rcb ...client.RequestEditorFn) error
 }
 
 // Enrollment is client the interface for managing device enrollment.
@@ -63,6 +68,8 @@ type Bootc interface {
 	UsrOverlay(ctx context.Context) error
 	// Apply restart or reboot into the new target image.
 	Apply(ctx context.Context) error
+	// Rollback reboots into the previously booted image.
+	Rollback(ctx context.Context) error
 }
 
 type System interface {
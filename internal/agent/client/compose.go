@@ -23,8 +23,7 @@ func (p *Compose) UpFromWorkDir(ctx context.Context, workDir, projectName string
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{
-		"compose",
+	composeArgs := []string{
 		"-p",
 		projectName,
 		"up",
@@ -32,10 +31,14 @@ func (p *Compose) UpFromWorkDir(ctx context.Context, workDir, projectName string
 	}
 
 	if noRecreate {
-		args = append(args, "--no-recreate")
+		composeArgs = append(composeArgs, "--no-recreate")
 	}
 
-	_, stderr, exitCode := p.exec.ExecuteWithContextFromDir(ctx, workDir, podmanCmd, args)
+	args, err := p.args(OperationCompose, composeArgs...)
+	if err != nil {
+		return err
+	}
+	_, stderr, exitCode := p.exec.ExecuteWithContextFromDir(ctx, workDir, p.runtime.Command(), args)
 	if exitCode != 0 {
 		return fmt.Errorf("podman compose up: %w", errors.FromStderr(stderr, exitCode))
 	}
@@ -46,13 +49,11 @@ func (p *Compose) Up(ctx context.Context, path string) error {
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{
-		"-f",
-		path,
-		"up",
-		"-d",
+	args, err := p.args(OperationCompose, "-f", path, "up", "-d")
+	if err != nil {
+		return err
 	}
-	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode != 0 {
 		return fmt.Errorf("podman compose up: %w", errors.FromStderr(stderr, exitCode))
 	}
@@ -63,20 +64,23 @@ func (p *Compose) Down(ctx context.Context, path string) error {
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{
-		"-f",
-		path,
-		"down",
+	args, err := p.args(OperationCompose, "-f", path, "down")
+	if err != nil {
+		return err
 	}
-	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode == 0 {
 		return nil
 	}
-	_, err := os.Stat(path)
+	_, err = os.Stat(path)
 	if !os.IsNotExist(err) {
 		return fmt.Errorf("podman-compose down: %w", errors.FromStderr(stderr, exitCode))
 	}
-	psStdout, psStderr, psExitCode := p.exec.ExecuteWithContext(ctx, "podman", "ps", "-a", "--format=json")
+	psArgs, ok := p.runtime.Args(OperationListContainers)
+	if !ok {
+		return fmt.Errorf("podman compose down: %w", errors.FromStderr(stderr, exitCode))
+	}
+	psStdout, psStderr, psExitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), psArgs...)
 	if psExitCode != 0 {
 		p.log.Errorf("podman ps --all failed: %s", psStderr)
 		return fmt.Errorf("podman compose down: %w", errors.FromStderr(stderr, exitCode))
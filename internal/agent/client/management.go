@@ -15,6 +15,8 @@ var _ Management = (*management)(nil)
 
 var (
 	ErrEmptyResponse = errors.New("empty response")
+	// ErrUpdateSlotsUnsupported is returned when the service predates update slots
+	ErrUpdateSlotsUnsupported = errors.New("update slots are not supported by the service")
 )
 
 func NewManagement(
@@ -52,6 +54,89 @@ func (m *management) UpdateDeviceStatus(ctx context.Context, name string, device
 	return nil
 }
 
+// UpdateDeviceLogs ships the tail of the logs of the device with the given name.
+func (m *management) UpdateDeviceLogs(ctx context.Context, name string, logs v1alpha1.DeviceLogs, rcb ...client.RequestEditorFn) error {
+	start := time.Now()
+	resp, err := m.client.ReplaceDeviceLogsWithResponse(ctx, name, logs, rcb...)
+	if err != nil {
+		return err
+	}
+	if resp.HTTPResponse != nil {
+		defer resp.HTTPResponse.Body.Close()
+	}
+
+	if m.rpcMetricsCallbackFunc != nil {
+		m.rpcMetricsCallbackFunc("update_device_logs_duration", time.Since(start).Seconds(), err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("update device logs failed: %s", resp.Status())
+	}
+
+	return nil
+}
+
+// AcquireDeviceUpdateSlot requests an update slot for the device with the given name. The
+// returned slot tells whether it was granted.
+func (m *management) AcquireDeviceUpdateSlot(ctx context.Context, name string, rcb ...client.RequestEditorFn) (*v1alpha1.DeviceUpdateSlot, error) {
+	start := time.Now()
+	resp, err := m.client.AcquireDeviceUpdateSlotWithResponse(ctx, name, rcb...)
+	if err != nil {
+		return nil, err
+	}
+	if resp.HTTPResponse != nil {
+		defer resp.HTTPResponse.Body.Close()
+	}
+
+	if m.rpcMetricsCallbackFunc != nil {
+		m.rpcMetricsCallbackFunc("acquire_device_update_slot_duration", time.Since(start).Seconds(), err)
+	}
+
+	if err := checkUpdateSlotResponse(resp.StatusCode(), resp.JSON404, resp.Status()); err != nil {
+		return nil, fmt.Errorf("acquire device update slot failed: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, ErrEmptyResponse
+	}
+
+	return resp.JSON200, nil
+}
+
+// ReleaseDeviceUpdateSlot releases the update slot held by the device with the given name, if any.
+func (m *management) ReleaseDeviceUpdateSlot(ctx context.Context, name string, rcb ...client.RequestEditorFn) error {
+	start := time.Now()
+	resp, err := m.client.ReleaseDeviceUpdateSlotWithResponse(ctx, name, rcb...)
+	if err != nil {
+		return err
+	}
+	if resp.HTTPResponse != nil {
+		defer resp.HTTPResponse.Body.Close()
+	}
+
+	if m.rpcMetricsCallbackFunc != nil {
+		m.rpcMetricsCallbackFunc("release_device_update_slot_duration", time.Since(start).Seconds(), err)
+	}
+
+	if err := checkUpdateSlotResponse(resp.StatusCode(), resp.JSON404, resp.Status()); err != nil {
+		return fmt.Errorf("release device update slot failed: %w", err)
+	}
+
+	return nil
+}
+
+// checkUpdateSlotResponse tells a service without the update slot endpoints, which answers with
+// a plain not found, apart from other failures.
+func checkUpdateSlotResponse(statusCode int, notFound *v1alpha1.Error, status string) error {
+	switch {
+	case statusCode == http.StatusOK:
+		return nil
+	case statusCode == http.StatusNotFound && notFound == nil:
+		return ErrUpdateSlotsUnsupported
+	default:
+		return errors.New(status)
+	}
+}
+
 // GetRenderedDeviceSpec returns the rendered device spec for the given device
 // and the response code. If the server returns a 200, the rendered device spec
 // is returned. If the server returns a 204, the rendered device spec is nil,
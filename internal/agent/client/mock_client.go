@@ -42,6 +42,26 @@ func (m *MockManagement) EXPECT() *MockManagementMockRecorder {
 	return m.recorder
 }
 
+// AcquireDeviceUpdateSlot mocks base method.
+func (m *MockManagement) AcquireDeviceUpdateSlot(ctx context.Context, name string, rcb ...client.RequestEditorFn) (*v1alpha1.DeviceUpdateSlot, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, name}
+	for _, a := range rcb {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AcquireDeviceUpdateSlot", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.DeviceUpdateSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireDeviceUpdateSlot indicates an expected call of AcquireDeviceUpdateSlot.
+func (mr *MockManagementMockRecorder) AcquireDeviceUpdateSlot(ctx, name any, rcb ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, name}, rcb...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireDeviceUpdateSlot", reflect.TypeOf((*MockManagement)(nil).AcquireDeviceUpdateSlot), varargs...)
+}
+
 // GetRenderedDeviceSpec mocks base method.
 func (m *MockManagement) GetRenderedDeviceSpec(ctx context.Context, name string, params *v1alpha1.GetRenderedDeviceSpecParams, rcb ...client.RequestEditorFn) (*v1alpha1.RenderedDeviceSpec, int, error) {
 	m.ctrl.T.Helper()
@@ -63,6 +83,44 @@ func (mr *MockManagementMockRecorder) GetRenderedDeviceSpec(ctx, name, params an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRenderedDeviceSpec", reflect.TypeOf((*MockManagement)(nil).GetRenderedDeviceSpec), varargs...)
 }
 
+// ReleaseDeviceUpdateSlot mocks base method.
+func (m *MockManagement) ReleaseDeviceUpdateSlot(ctx context.Context, name string, rcb ...client.RequestEditorFn) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, name}
+	for _, a := range rcb {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReleaseDeviceUpdateSlot", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDeviceUpdateSlot indicates an expected call of ReleaseDeviceUpdateSlot.
+func (mr *MockManagementMockRecorder) ReleaseDeviceUpdateSlot(ctx, name any, rcb ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, name}, rcb...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDeviceUpdateSlot", reflect.TypeOf((*MockManagement)(nil).ReleaseDeviceUpdateSlot), varargs...)
+}
+
+// UpdateDeviceLogs mocks base method.
+func (m *MockManagement) UpdateDeviceLogs(ctx context.Context, name string, logs v1alpha1.DeviceLogs, rcb ...client.RequestEditorFn) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, name, logs}
+	for _, a := range rcb {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateDeviceLogs", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeviceLogs indicates an expected call of UpdateDeviceLogs.
+func (mr *MockManagementMockRecorder) UpdateDeviceLogs(ctx, name, logs any, rcb ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, name, logs}, rcb...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeviceLogs", reflect.TypeOf((*MockManagement)(nil).UpdateDeviceLogs), varargs...)
+}
+
 // UpdateDeviceStatus mocks base method.
 func (m *MockManagement) UpdateDeviceStatus(ctx context.Context, name string, device v1alpha1.Device, rcb ...client.RequestEditorFn) error {
 	m.ctrl.T.Helper()
@@ -194,6 +252,20 @@ func (mr *MockBootcMockRecorder) Apply(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockBootc)(nil).Apply), ctx)
 }
 
+// Rollback mocks base method.
+func (m *MockBootc) Rollback(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockBootcMockRecorder) Rollback(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockBootc)(nil).Rollback), ctx)
+}
+
 // Status mocks base method.
 func (m *MockBootc) Status(ctx context.Context) (*container.BootcHost, error) {
 	m.ctrl.T.Helper()
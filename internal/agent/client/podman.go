@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/flightctl/flightctl/internal/agent/device/errors"
@@ -19,24 +21,72 @@ const (
 	podmanCmd = "podman"
 )
 
+// Podman runs the container operations of the agent with the container runtime, podman unless
+// another runtime is selected with WithRuntime.
 type Podman struct {
-	exec    executer.Executer
-	log     *log.PrefixLogger
-	timeout time.Duration
-	backoff wait.Backoff
+	runtime  Runtime
+	exec     executer.Executer
+	log      *log.PrefixLogger
+	timeout  time.Duration
+	backoff  wait.Backoff
+	pullGate *pullGate
+}
+
+type PodmanOption func(*Podman)
+
+// PullThrottledFunc is called with the images whose pull waits for a free pull slot, each time
+// that set changes. An empty set means that no pull is throttled anymore.
+type PullThrottledFunc func(ctx context.Context, waiting []string)
+
+// WithMaxConcurrentPulls limits the number of images pulled at the same time, calling
+// onThrottled, if set, as pulls start and stop waiting for a free slot.
+// A value of zero or less means no limit.
+func WithMaxConcurrentPulls(max int, onThrottled PullThrottledFunc) PodmanOption {
+	return func(p *Podman) {
+		if max > 0 {
+			p.pullGate = newPullGate(max, onThrottled)
+		}
+	}
+}
+
+// WithRuntime runs the container operations with the given runtime instead of podman.
+func WithRuntime(runtime Runtime) PodmanOption {
+	return func(p *Podman) {
+		p.runtime = runtime
+	}
 }
 
 type ImageConfig struct {
 	Labels map[string]string `json:"Labels"`
 }
 
-func NewPodman(log *log.PrefixLogger, exec executer.Executer, backoff wait.Backoff) *Podman {
-	return &Podman{
+func NewPodman(log *log.PrefixLogger, exec executer.Executer, backoff wait.Backoff, opts ...PodmanOption) *Podman {
+	p := &Podman{
+		runtime: podmanRuntime(),
 		log:     log,
 		exec:    exec,
 		timeout: defaultPodmanTimeout,
 		backoff: backoff,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Runtime returns the container runtime the operations are run with.
+func (p *Podman) Runtime() Runtime {
+	return p.runtime
+}
+
+// args returns the arguments running the operation with the container runtime, or an error if the
+// runtime does not support it.
+func (p *Podman) args(op Operation, args ...string) ([]string, error) {
+	runtimeArgs, ok := p.runtime.Args(op, args...)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s with %s", errors.ErrUnsupportedRuntimeOperation, op, p.runtime.Name())
+	}
+	return runtimeArgs, nil
 }
 
 // Pull pulls the image from the registry and the response. Users can pass in options to configure the client.
@@ -75,11 +125,22 @@ func (p *Podman) Pull(ctx context.Context, image string, opts ...ClientOption) (
 }
 
 func (p *Podman) pullImage(ctx context.Context, image string) (string, error) {
+	if p.pullGate != nil {
+		release, err := p.pullGate.acquire(ctx, p.log, image)
+		if err != nil {
+			return "", err
+		}
+		defer release()
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{"pull", image}
-	stdout, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	args, err := p.args(OperationPull, image)
+	if err != nil {
+		return "", err
+	}
+	stdout, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode != 0 {
 		return "", fmt.Errorf("pull image: %w", errors.FromStderr(stderr, exitCode))
 	}
@@ -92,8 +153,11 @@ func (p *Podman) Inspect(ctx context.Context, image string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{"inspect", image}
-	stdout, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	args, err := p.args(OperationInspectImage, image)
+	if err != nil {
+		return "", err
+	}
+	stdout, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode != 0 {
 		return "", fmt.Errorf("inspect image: %s: %w", image, errors.FromStderr(stderr, exitCode))
 	}
@@ -105,32 +169,34 @@ func (p *Podman) ImageExists(ctx context.Context, image string) bool {
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{"image", "exists", image}
-	_, _, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	args, err := p.args(OperationImageExists, image)
+	if err != nil {
+		p.log.Warn(err)
+		return false
+	}
+	_, _, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	return exitCode == 0
 }
 
 // EventsSinceCmd returns a command to get podman events since the given time. After creating the command, it should be started with exec.Start().
 // When the events are in sync with the current time a sync event is emitted.
-func (p *Podman) EventsSinceCmd(ctx context.Context, events []string, sinceTime string) *exec.Cmd {
-	args := []string{"events", "--format", "json", "--since", sinceTime}
-	for _, event := range events {
-		args = append(args, "--filter", fmt.Sprintf("event=%s", event))
+func (p *Podman) EventsSinceCmd(ctx context.Context, events []string, sinceTime string) (*exec.Cmd, error) {
+	args, err := p.args(OperationEvents, append([]string{sinceTime}, events...)...)
+	if err != nil {
+		return nil, err
 	}
-
-	return p.exec.CommandContext(ctx, podmanCmd, args...)
+	return p.exec.CommandContext(ctx, p.runtime.Command(), args...), nil
 }
 
 func (p *Podman) Mount(ctx context.Context, image string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{
-		"image",
-		"mount",
-		image,
+	args, err := p.args(OperationImageMount, image)
+	if err != nil {
+		return "", err
 	}
-	stdout, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	stdout, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode != 0 {
 		return "", fmt.Errorf("mount image: %s: %w", image, errors.FromStderr(stderr, exitCode))
 	}
@@ -143,12 +209,11 @@ func (p *Podman) Unmount(ctx context.Context, image string) error {
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{
-		"image",
-		"unmount",
-		image,
+	args, err := p.args(OperationImageUnmount, image)
+	if err != nil {
+		return err
 	}
-	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode != 0 {
 		return fmt.Errorf("unmount image: %s: %w", image, errors.FromStderr(stderr, exitCode))
 	}
@@ -159,8 +224,11 @@ func (p *Podman) Copy(ctx context.Context, src, dst string) error {
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{"cp", src, dst}
-	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	args, err := p.args(OperationCopy, src, dst)
+	if err != nil {
+		return err
+	}
+	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode != 0 {
 		return fmt.Errorf("copy %s to %s: %w", src, dst, errors.FromStderr(stderr, exitCode))
 	}
@@ -196,11 +264,11 @@ func (p *Podman) StopContainers(ctx context.Context, labels []string) error {
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{"stop"}
-	for _, label := range labels {
-		args = append(args, "--filter", fmt.Sprintf("label=%s", label))
+	args, err := p.args(OperationStopContainers, labels...)
+	if err != nil {
+		return err
 	}
-	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode != 0 {
 		return fmt.Errorf("stop containers: %w", errors.FromStderr(stderr, exitCode))
 	}
@@ -211,11 +279,11 @@ func (p *Podman) RemoveContainer(ctx context.Context, labels []string) error {
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{"rm"}
-	for _, label := range labels {
-		args = append(args, "--filter", fmt.Sprintf("label=%s", label))
+	args, err := p.args(OperationRemoveContainers, labels...)
+	if err != nil {
+		return err
 	}
-	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode != 0 {
 		return fmt.Errorf("remove containers: %w", errors.FromStderr(stderr, exitCode))
 	}
@@ -226,8 +294,11 @@ func (p *Podman) RemoveVolumes(ctx context.Context, labels []string) error {
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{"volume", "rm"}
-	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	args, err := p.args(OperationRemoveVolumes, labels...)
+	if err != nil {
+		return err
+	}
+	_, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode != 0 {
 		return fmt.Errorf("remove volumes: %w", errors.FromStderr(stderr, exitCode))
 	}
@@ -238,17 +309,11 @@ func (p *Podman) ListNetworks(ctx context.Context, labels []string) ([]string, e
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args := []string{
-		"network",
-		"ls",
-		"--format",
-		"{{.Network.ID}}",
-	}
-	for _, label := range labels {
-		args = append(args, "--filter", fmt.Sprintf("label=%s", label))
+	args, err := p.args(OperationListNetworks, labels...)
+	if err != nil {
+		return nil, err
 	}
-
-	stdout, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	stdout, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode != 0 {
 		return nil, fmt.Errorf("list containers: %w", errors.FromStderr(stderr, exitCode))
 	}
@@ -275,9 +340,12 @@ func (p *Podman) ListNetworks(ctx context.Context, labels []string) ([]string, e
 
 func (p *Podman) RemoveNetworks(ctx context.Context, networks ...string) error {
 	for _, network := range networks {
+		args, err := p.args(OperationRemoveNetwork, network)
+		if err != nil {
+			return err
+		}
 		nctx, cancel := context.WithTimeout(ctx, p.timeout)
-		args := []string{"network", "rm", network}
-		_, stderr, exitCode := p.exec.ExecuteWithContext(nctx, podmanCmd, args...)
+		_, stderr, exitCode := p.exec.ExecuteWithContext(nctx, p.runtime.Command(), args...)
 		cancel()
 		if exitCode != 0 {
 			return fmt.Errorf("remove networks: %w", errors.FromStderr(stderr, exitCode))
@@ -291,8 +359,11 @@ func (p *Podman) Unshare(ctx context.Context, args ...string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
-	args = append([]string{"unshare"}, args...)
-	stdout, stderr, exitCode := p.exec.ExecuteWithContext(ctx, podmanCmd, args...)
+	args, err := p.args(OperationUnshare, args...)
+	if err != nil {
+		return "", err
+	}
+	stdout, stderr, exitCode := p.exec.ExecuteWithContext(ctx, p.runtime.Command(), args...)
 	if exitCode != 0 {
 		return "", fmt.Errorf("unshare: %w", errors.FromStderr(stderr, exitCode))
 	}
@@ -336,3 +407,67 @@ func SanitizePodmanLabel(name string) string {
 
 	return result.String()
 }
+
+// pullGate bounds the number of concurrent image pulls so that simultaneous
+// updates don't saturate constrained uplinks.
+type pullGate struct {
+	slots       chan struct{}
+	onThrottled PullThrottledFunc
+
+	// mu guards waiting and serializes the calls to onThrottled
+	mu      sync.Mutex
+	waiting map[string]int
+}
+
+func newPullGate(max int, onThrottled PullThrottledFunc) *pullGate {
+	return &pullGate{
+		slots:       make(chan struct{}, max),
+		onThrottled: onThrottled,
+		waiting:     make(map[string]int),
+	}
+}
+
+// acquire blocks until a pull slot is free or the context is done. The
+// returned function must be called to release the slot.
+func (g *pullGate) acquire(ctx context.Context, log *log.PrefixLogger, image string) (func(), error) {
+	release := func() { <-g.slots }
+	select {
+	case g.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	log.Infof("Throttling pull of image %s: waiting for one of %d concurrent pulls to finish", image, cap(g.slots))
+	g.setWaiting(ctx, image, 1)
+	// the end of the wait is reported even when it was cancelled, so that it is not left throttled
+	defer g.setWaiting(context.WithoutCancel(ctx), image, -1)
+	start := time.Now()
+	select {
+	case g.slots <- struct{}{}:
+		log.Infof("Pull of image %s was throttled for %s", image, time.Since(start))
+		return release, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting to pull image %s: %w", image, ctx.Err())
+	}
+}
+
+// setWaiting adds delta to the number of pulls of image waiting for a slot, and reports the
+// images with waiting pulls to onThrottled.
+func (g *pullGate) setWaiting(ctx context.Context, image string, delta int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.waiting[image] += delta
+	if g.waiting[image] <= 0 {
+		delete(g.waiting, image)
+	}
+	if g.onThrottled == nil {
+		return
+	}
+	waiting := make([]string, 0, len(g.waiting))
+	for image := range g.waiting {
+		waiting = append(waiting, image)
+	}
+	sort.Strings(waiting)
+	g.onThrottled(ctx, waiting)
+}
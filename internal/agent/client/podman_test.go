@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestPodmanPullConcurrency(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	mockExecuter := executer.NewMockExecuter(ctrl)
+
+	var running, maxRunning atomic.Int32
+	unblock := make(chan struct{})
+	mockExecuter.EXPECT().ExecuteWithContext(gomock.Any(), "podman", "pull", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, command string, args ...string) (string, string, int) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				current := maxRunning.Load()
+				if n <= current || maxRunning.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			<-unblock
+			return "digest", "", 0
+		}).Times(4)
+
+	var mu sync.Mutex
+	var reported [][]string
+	onThrottled := func(ctx context.Context, waiting []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, waiting)
+	}
+	podman := NewPodman(log.NewPrefixLogger("test"), mockExecuter, wait.Backoff{}, WithMaxConcurrentPulls(2, onThrottled))
+
+	var wg sync.WaitGroup
+	images := []string{"img1", "img2", "img3", "img4"}
+	errs := make(chan error, len(images))
+	for _, image := range images {
+		wg.Add(1)
+		go func(image string) {
+			defer wg.Done()
+			_, err := podman.Pull(context.Background(), image)
+			errs <- err
+		}(image)
+	}
+
+	require.Eventually(func() bool { return running.Load() == 2 }, time.Second, 10*time.Millisecond)
+	// the remaining pulls stay gated while the first two are in flight
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(int32(2), running.Load())
+
+	close(unblock)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(err)
+	}
+	require.Equal(int32(2), maxRunning.Load())
+
+	// the two gated pulls were reported while they waited, and no pull is left throttled
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(reported, 4)
+	maxWaiting := 0
+	for _, waiting := range reported {
+		maxWaiting = max(maxWaiting, len(waiting))
+	}
+	require.Equal(2, maxWaiting)
+	require.Empty(reported[len(reported)-1])
+}
+
+func TestPodmanPullGateContextCancelled(t *testing.T) {
+	require := require.New(t)
+	var reported [][]string
+	gate := newPullGate(1, func(ctx context.Context, waiting []string) {
+		reported = append(reported, waiting)
+	})
+	logger := log.NewPrefixLogger("test")
+
+	release, err := gate.acquire(context.Background(), logger, "img1")
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = gate.acquire(ctx, logger, "img2")
+	require.ErrorIs(err, context.DeadlineExceeded)
+	// the cancelled wait is no longer reported as throttled
+	require.Equal([][]string{{"img2"}, {}}, reported)
+
+	release()
+	release, err = gate.acquire(context.Background(), logger, "img2")
+	require.NoError(err)
+	release()
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+const (
+	// maxRetryAfter bounds the delay a service can ask the agent to wait before retrying.
+	maxRetryAfter = time.Hour
+)
+
+// ErrRetryAfter is returned for requests that the service asked to retry later.
+var ErrRetryAfter = errors.New("service asked to retry later")
+
+// retryAfterTransport honors the Retry-After header of 503 Service Unavailable responses to
+// requests that modify resources, such as those returned while the service is in maintenance
+// mode: until the delay has passed, such requests fail without reaching the service. Reads are
+// not delayed.
+type retryAfterTransport struct {
+	next  http.RoundTripper
+	clock clock.Clock
+
+	mu        sync.Mutex
+	notBefore time.Time
+}
+
+func newRetryAfterTransport(next http.RoundTripper, clock clock.Clock) *retryAfterTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryAfterTransport{next: next, clock: clock}
+}
+
+func (t *retryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isMutatingMethod(req.Method) {
+		return t.next.RoundTrip(req)
+	}
+
+	t.mu.Lock()
+	wait := t.notBefore.Sub(t.clock.Now())
+	t.mu.Unlock()
+	if wait > 0 {
+		return nil, fmt.Errorf("%w: %s %s in %s", ErrRetryAfter, req.Method, req.URL.Path, wait.Round(time.Second))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusServiceUnavailable {
+		return resp, err
+	}
+	if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), t.clock.Now()); ok {
+		t.mu.Lock()
+		t.notBefore = t.clock.Now().Add(delay)
+		t.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// parseRetryAfter parses the value of a Retry-After header, either a number of seconds or an
+// HTTP date, into a delay of at most maxRetryAfter.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = date.Sub(now)
+	} else {
+		return 0, false
+	}
+	if delay <= 0 {
+		return 0, false
+	}
+	return min(delay, maxRetryAfter), true
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestRetryAfterTransport(t *testing.T) {
+	require := require.New(t)
+	var maintenance atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if maintenance.Load() && r.Method != http.MethodGet {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	clock := clocktesting.NewFakeClock(time.Now())
+	httpClient := &http.Client{Transport: newRetryAfterTransport(nil, clock)}
+	do := func(method string) (int, error) {
+		req, err := http.NewRequest(method, server.URL+"/api/v1/devices/dev/status", nil)
+		require.NoError(err)
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	maintenance.Store(true)
+	code, err := do(http.MethodPut)
+	require.NoError(err)
+	require.Equal(http.StatusServiceUnavailable, code)
+	require.Equal(int32(1), requests.Load())
+
+	// writes are held back until the delay has passed, reads are not
+	_, err = do(http.MethodPut)
+	require.ErrorIs(err, ErrRetryAfter)
+	require.Equal(int32(1), requests.Load())
+	code, err = do(http.MethodGet)
+	require.NoError(err)
+	require.Equal(http.StatusOK, code)
+	require.Equal(int32(2), requests.Load())
+
+	maintenance.Store(false)
+	clock.Step(time.Minute)
+	code, err = do(http.MethodPut)
+	require.NoError(err)
+	require.Equal(http.StatusOK, code)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{value: "120", expected: 2 * time.Minute, ok: true},
+		{value: "Tue, 02 Jan 2024 03:05:05 GMT", expected: time.Minute, ok: true},
+		{value: "86400", expected: maxRetryAfter, ok: true},
+		{value: "0"},
+		{value: "Tue, 02 Jan 2024 03:00:00 GMT"},
+		{value: "soon"},
+		{value: ""},
+	}
+	for _, tt := range tests {
+		delay, ok := parseRetryAfter(tt.value, now)
+		require.Equal(t, tt.ok, ok, tt.value)
+		require.Equal(t, tt.expected, delay, tt.value)
+	}
+}
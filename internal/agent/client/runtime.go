@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+const (
+	RuntimePodman = "podman"
+
+	runtimeProbeTimeout = 10 * time.Second
+)
+
+// Operation is a container operation of the agent.
+type Operation string
+
+const (
+	// OperationPull pulls the image given as argument.
+	OperationPull Operation = "pull"
+	// OperationInspectImage prints the JSON inspection of the image given as argument.
+	OperationInspectImage Operation = "inspect image"
+	// OperationImageExists exits with zero if the image given as argument exists locally.
+	OperationImageExists Operation = "check image exists"
+	// OperationImageMount mounts the image given as argument and prints the mount point.
+	OperationImageMount Operation = "mount image"
+	// OperationImageUnmount unmounts the image given as argument.
+	OperationImageUnmount Operation = "unmount image"
+	// OperationCopy copies the source to the destination given as arguments.
+	OperationCopy Operation = "copy"
+	// OperationEvents streams the JSON events since the time given as first argument, filtered by
+	// the event types given as the remaining arguments.
+	OperationEvents Operation = "stream events"
+	// OperationStopContainers stops the containers with the labels given as arguments.
+	OperationStopContainers Operation = "stop containers"
+	// OperationRemoveContainers removes the containers with the labels given as arguments.
+	OperationRemoveContainers Operation = "remove containers"
+	// OperationListContainers prints the JSON list of all containers.
+	OperationListContainers Operation = "list containers"
+	// OperationRemoveVolumes removes the unused volumes.
+	OperationRemoveVolumes Operation = "remove volumes"
+	// OperationListNetworks prints the IDs of the networks with the labels given as arguments.
+	OperationListNetworks Operation = "list networks"
+	// OperationRemoveNetwork removes the network given as argument.
+	OperationRemoveNetwork Operation = "remove network"
+	// OperationUnshare runs the command given as arguments in the user namespace of the runtime.
+	OperationUnshare Operation = "unshare"
+	// OperationCompose runs the compose command given as arguments.
+	OperationCompose Operation = "compose"
+)
+
+// Runtime translates the container operations of the agent to the command line of a container
+// runtime.
+type Runtime interface {
+	// Name returns the name of the runtime, as selected in the agent config.
+	Name() string
+	// Command returns the binary the runtime is driven with.
+	Command() string
+	// Args returns the arguments running the operation, and false if the runtime does not
+	// support it.
+	Args(op Operation, args ...string) ([]string, bool)
+}
+
+// ValidateRuntime checks that the name selects a known runtime.
+func ValidateRuntime(name string) error {
+	_, err := NewRuntime(name)
+	return err
+}
+
+// NewRuntime returns the runtime of the given name, podman if empty.
+func NewRuntime(name string) (Runtime, error) {
+	switch name {
+	case "", RuntimePodman:
+		return podmanRuntime(), nil
+	default:
+		return nil, fmt.Errorf("invalid container-runtime %q: must be %s", name, RuntimePodman)
+	}
+}
+
+// DetectRuntime returns the runtime of the given name after checking that it is installed. Compose
+// is reported as unsupported if the runtime cannot run it. An empty name selects podman without
+// checking it, as the agent always did.
+func DetectRuntime(ctx context.Context, log *log.PrefixLogger, exec executer.Executer, name string) (Runtime, error) {
+	if name == "" {
+		return podmanRuntime(), nil
+	}
+	runtime, err := NewRuntime(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := exec.LookPath(runtime.Command()); err != nil {
+		return nil, fmt.Errorf("container runtime %s not found: %w", name, err)
+	}
+	return probeRuntime(ctx, log, exec, runtime.(*cliRuntime)), nil
+}
+
+// probeRuntime removes the operations the installed runtime turns out not to support.
+func probeRuntime(ctx context.Context, log *log.PrefixLogger, exec executer.Executer, runtime *cliRuntime) Runtime {
+	args, ok := runtime.Args(OperationCompose, "version")
+	if !ok {
+		log.Infof("Using container runtime %s", runtime.name)
+		return runtime
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, runtimeProbeTimeout)
+	defer cancel()
+	if _, stderr, exitCode := exec.ExecuteWithContext(ctx, runtime.command, args...); exitCode != 0 {
+		log.Warnf("Container runtime %s cannot run compose applications: %s", runtime.name, stderr)
+		runtime = runtime.without(OperationCompose)
+	}
+	log.Infof("Using container runtime %s", runtime.name)
+	return runtime
+}
+
+// cliRuntime is a runtime driven through its command line, each supported operation mapping the
+// arguments of the operation to the ones of the command.
+type cliRuntime struct {
+	name       string
+	command    string
+	operations map[Operation]func(args []string) []string
+}
+
+func (r *cliRuntime) Name() string {
+	return r.name
+}
+
+func (r *cliRuntime) Command() string {
+	return r.command
+}
+
+func (r *cliRuntime) Args(op Operation, args ...string) ([]string, bool) {
+	operation, ok := r.operations[op]
+	if !ok {
+		return nil, false
+	}
+	return operation(args), true
+}
+
+func (r *cliRuntime) without(ops ...Operation) *cliRuntime {
+	operations := make(map[Operation]func(args []string) []string, len(r.operations))
+	for op, operation := range r.operations {
+		operations[op] = operation
+	}
+	for _, op := range ops {
+		delete(operations, op)
+	}
+	return &cliRuntime{name: r.name, command: r.command, operations: operations}
+}
+
+// prefixed returns an operation appending its arguments to the given ones.
+func prefixed(prefix ...string) func(args []string) []string {
+	return func(args []string) []string {
+		return append(append([]string{}, prefix...), args...)
+	}
+}
+
+// filtered returns an operation appending a label filter for each of its arguments to the given
+// ones.
+func filtered(prefix ...string) func(args []string) []string {
+	return func(labels []string) []string {
+		args := append([]string{}, prefix...)
+		for _, label := range labels {
+			args = append(args, "--filter", fmt.Sprintf("label=%s", label))
+		}
+		return args
+	}
+}
+
+func podmanRuntime() *cliRuntime {
+	return &cliRuntime{
+		name:    RuntimePodman,
+		command: podmanCmd,
+		operations: map[Operation]func(args []string) []string{
+			OperationPull:         prefixed("pull"),
+			OperationInspectImage: prefixed("inspect"),
+			OperationImageExists:  prefixed("image", "exists"),
+			OperationImageMount:   prefixed("image", "mount"),
+			OperationImageUnmount: prefixed("image", "unmount"),
+			OperationCopy:         prefixed("cp"),
+			OperationEvents: func(args []string) []string {
+				events := []string{"events", "--format", "json", "--since", args[0]}
+				for _, event := range args[1:] {
+					events = append(events, "--filter", fmt.Sprintf("event=%s", event))
+				}
+				return events
+			},
+			OperationStopContainers:   filtered("stop"),
+			OperationRemoveContainers: filtered("rm"),
+			OperationListContainers:   prefixed("ps", "-a", "--format=json"),
+			OperationRemoveVolumes:    func([]string) []string { return []string{"volume", "rm"} },
+			OperationListNetworks:     filtered("network", "ls", "--format", "{{.Network.ID}}"),
+			OperationRemoveNetwork:    prefixed("network", "rm"),
+			OperationUnshare:          prefixed("unshare"),
+			OperationCompose:          prefixed("compose"),
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// mockRuntime supports pulling and inspecting images only, with its own command line.
+type mockRuntime struct{}
+
+func (mockRuntime) Name() string    { return "mock" }
+func (mockRuntime) Command() string { return "mockctl" }
+func (mockRuntime) Args(op Operation, args ...string) ([]string, bool) {
+	switch op {
+	case OperationPull:
+		return append([]string{"fetch"}, args...), true
+	case OperationInspectImage:
+		return append([]string{"describe", "--json"}, args...), true
+	default:
+		return nil, false
+	}
+}
+
+func TestPodmanWithRuntime(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	mockExecuter := executer.NewMockExecuter(ctrl)
+	podman := NewPodman(log.NewPrefixLogger("test"), mockExecuter, wait.Backoff{}, WithRuntime(mockRuntime{}))
+
+	mockExecuter.EXPECT().ExecuteWithContext(gomock.Any(), "mockctl", "fetch", "quay.io/org/app:v1").Return("digest", "", 0)
+	resp, err := podman.Pull(context.Background(), "quay.io/org/app:v1")
+	require.NoError(err)
+	require.Equal("digest", resp)
+
+	mockExecuter.EXPECT().ExecuteWithContext(gomock.Any(), "mockctl", "describe", "--json", "quay.io/org/app:v1").
+		Return(`[{"Config":{"Labels":{"appType":"compose"}}}]`, "", 0)
+	labels, err := podman.InspectLabels(context.Background(), "quay.io/org/app:v1")
+	require.NoError(err)
+	require.Equal(map[string]string{"appType": "compose"}, labels)
+
+	// unsupported operations fail without running the runtime
+	_, err = podman.Mount(context.Background(), "quay.io/org/app:v1")
+	require.ErrorIs(err, errors.ErrUnsupportedRuntimeOperation)
+	require.ErrorContains(err, "mount image with mock")
+	require.ErrorIs(podman.Compose().UpFromWorkDir(context.Background(), "/tmp", "app", false), errors.ErrUnsupportedRuntimeOperation)
+	_, err = podman.EventsSinceCmd(context.Background(), []string{"start"}, "now")
+	require.ErrorIs(err, errors.ErrUnsupportedRuntimeOperation)
+	require.False(podman.ImageExists(context.Background(), "quay.io/org/app:v1"))
+}
+
+func TestRuntimeArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		runtime  string
+		op       Operation
+		args     []string
+		expected []string
+	}{
+		{name: "podman stop", runtime: RuntimePodman, op: OperationStopContainers, args: []string{"a=b", "c=d"}, expected: []string{"stop", "--filter", "label=a=b", "--filter", "label=c=d"}},
+		{name: "podman events", runtime: RuntimePodman, op: OperationEvents, args: []string{"100", "start", "die"}, expected: []string{"events", "--format", "json", "--since", "100", "--filter", "event=start", "--filter", "event=die"}},
+		{name: "podman networks", runtime: RuntimePodman, op: OperationListNetworks, args: []string{"a=b"}, expected: []string{"network", "ls", "--format", "{{.Network.ID}}", "--filter", "label=a=b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime, err := NewRuntime(tt.runtime)
+			require.NoError(t, err)
+			args, ok := runtime.Args(tt.op, tt.args...)
+			require.Equal(t, tt.expected != nil, ok)
+			require.Equal(t, tt.expected, args)
+		})
+	}
+}
+
+func TestValidateRuntime(t *testing.T) {
+	require.NoError(t, ValidateRuntime(""))
+	require.NoError(t, ValidateRuntime(RuntimePodman))
+	require.ErrorContains(t, ValidateRuntime("docker"), `invalid container-runtime "docker": must be podman`)
+}
+
+func TestDetectRuntime(t *testing.T) {
+	logger := log.NewPrefixLogger("test")
+	notFound := fmt.Errorf("executable file not found in $PATH")
+
+	t.Run("empty selects podman unchecked", func(t *testing.T) {
+		mockExecuter := executer.NewMockExecuter(gomock.NewController(t))
+		runtime, err := DetectRuntime(context.Background(), logger, mockExecuter, "")
+		require.NoError(t, err)
+		require.Equal(t, RuntimePodman, runtime.Name())
+	})
+
+	t.Run("podman without compose", func(t *testing.T) {
+		mockExecuter := executer.NewMockExecuter(gomock.NewController(t))
+		mockExecuter.EXPECT().LookPath("podman").Return("/usr/bin/podman", nil)
+		mockExecuter.EXPECT().ExecuteWithContext(gomock.Any(), "podman", "compose", "version").Return("", "unknown command: compose", 1)
+
+		runtime, err := DetectRuntime(context.Background(), logger, mockExecuter, RuntimePodman)
+		require.NoError(t, err)
+		require.Equal(t, RuntimePodman, runtime.Name())
+		_, ok := runtime.Args(OperationCompose, "up")
+		require.False(t, ok)
+		_, ok = runtime.Args(OperationPull, "img")
+		require.True(t, ok)
+	})
+
+	t.Run("selected runtime not installed", func(t *testing.T) {
+		mockExecuter := executer.NewMockExecuter(gomock.NewController(t))
+		mockExecuter.EXPECT().LookPath("podman").Return("", notFound)
+
+		_, err := DetectRuntime(context.Background(), logger, mockExecuter, RuntimePodman)
+		require.ErrorContains(t, err, "container runtime podman not found")
+	})
+
+	t.Run("unknown runtime", func(t *testing.T) {
+		mockExecuter := executer.NewMockExecuter(gomock.NewController(t))
+		_, err := DetectRuntime(context.Background(), logger, mockExecuter, "docker")
+		require.ErrorContains(t, err, "invalid container-runtime")
+	})
+}
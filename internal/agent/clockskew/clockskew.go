@@ -0,0 +1,131 @@
+package clockskew
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	baseclient "github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// DefaultTolerance is how far the clock of the device may be off by default. It covers clocks
+	// that drift, not clocks that were reset.
+	DefaultTolerance = 5 * time.Minute
+	// MaxTolerance bounds the tolerance, as the certificates of the service are accepted that
+	// long after they expired.
+	MaxTolerance = 24 * time.Hour
+
+	// reportInterval is the minimum interval between two reports of the skew, as it is detected
+	// on every connection to the service.
+	reportInterval = 10 * time.Minute
+	// syncTimeout bounds the time synchronization.
+	syncTimeout = 30 * time.Second
+)
+
+// Config configures how the agent copes with the clock of the device being off, e.g. after its
+// real-time clock was reset.
+type Config struct {
+	// Tolerance is how far the certificates of the service may be outside of their validity
+	// period at the time of the device, zero to not tolerate any skew
+	Tolerance util.Duration `json:"tolerance,omitempty"`
+	// SyncOnSkew steps the clock with chronyd when a skew beyond the tolerance is detected
+	SyncOnSkew bool `json:"sync-on-skew,omitempty"`
+}
+
+// NewDefaultConfig returns the default clock skew configuration.
+func NewDefaultConfig() Config {
+	return Config{
+		Tolerance: util.Duration(DefaultTolerance),
+	}
+}
+
+// Validate checks that the tolerance is within bounds.
+func (c *Config) Validate() error {
+	if c.Tolerance < 0 || time.Duration(c.Tolerance) > MaxTolerance {
+		return fmt.Errorf("clock-skew tolerance %s must be between 0 and %s", time.Duration(c.Tolerance), MaxTolerance)
+	}
+	return nil
+}
+
+// Monitor reports the clock skew detected when connecting to the service, and steps the clock
+// when the skew is beyond the tolerance if configured to.
+type Monitor struct {
+	log    *log.PrefixLogger
+	config Config
+	exec   executer.Executer
+	clock  clock.Clock
+
+	mu         sync.Mutex
+	lastReport time.Time
+	lastSync   time.Time
+}
+
+type Option func(*Monitor)
+
+// WithClock sets the clock used by the monitor.
+func WithClock(clock clock.Clock) Option {
+	return func(m *Monitor) {
+		m.clock = clock
+	}
+}
+
+// NewMonitor returns a new clock skew monitor.
+func NewMonitor(log *log.PrefixLogger, config Config, exec executer.Executer, opts ...Option) *Monitor {
+	m := &Monitor{
+		log:    log,
+		config: config,
+		exec:   exec,
+		clock:  clock.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// ClockSkew returns the clock skew tolerance of the clients of the service.
+func (m *Monitor) ClockSkew() *baseclient.ClockSkew {
+	return &baseclient.ClockSkew{
+		Tolerance: time.Duration(m.config.Tolerance),
+		Detected:  m.Detected,
+		Now:       m.clock.Now,
+	}
+}
+
+// Detected reports the skew of the clock of the device, positive when the clock is behind.
+func (m *Monitor) Detected(skew time.Duration, tolerated bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+
+	direction := "behind"
+	if skew < 0 {
+		direction = "ahead"
+	}
+	if tolerated {
+		if now.Sub(m.lastReport) >= reportInterval {
+			m.lastReport = now
+			m.log.Warnf("The clock of the device is %s by about %s, within the tolerance of %s", direction, skew.Abs().Round(time.Second), time.Duration(m.config.Tolerance))
+		}
+		return
+	}
+
+	m.log.Errorf("The clock of the device is %s by about %s, beyond the tolerance of %s: the certificates of the service are rejected", direction, skew.Abs().Round(time.Second), time.Duration(m.config.Tolerance))
+	if !m.config.SyncOnSkew || now.Sub(m.lastSync) < reportInterval {
+		return
+	}
+	m.lastSync = now
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+	if _, stderr, exitCode := m.exec.ExecuteWithContext(ctx, "chronyc", "makestep"); exitCode != 0 {
+		m.log.Errorf("Failed to step the clock of the device: %s", stderr)
+		return
+	}
+	m.log.Info("Stepped the clock of the device with chronyd")
+}
@@ -0,0 +1,82 @@
+package clockskew
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	testclock "k8s.io/utils/clock/testing"
+)
+
+func TestValidate(t *testing.T) {
+	require := require.New(t)
+
+	config := NewDefaultConfig()
+	require.NoError(config.Validate())
+	config.Tolerance = 0
+	require.NoError(config.Validate())
+	config.Tolerance = util.Duration(-time.Second)
+	require.Error(config.Validate())
+	config.Tolerance = util.Duration(MaxTolerance + time.Second)
+	require.Error(config.Validate())
+}
+
+func TestMonitor(t *testing.T) {
+	testCases := []struct {
+		name          string
+		syncOnSkew    bool
+		tolerated     bool
+		expectedSyncs int
+	}{
+		{
+			name:      "skew within tolerance",
+			tolerated: true,
+		},
+		{
+			name: "skew beyond tolerance without sync",
+		},
+		{
+			name:          "skew beyond tolerance with sync",
+			syncOnSkew:    true,
+			expectedSyncs: 2,
+		},
+		{
+			name:       "skew within tolerance is not synced",
+			syncOnSkew: true,
+			tolerated:  true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			exec := executer.NewMockExecuter(ctrl)
+			exec.EXPECT().ExecuteWithContext(gomock.Any(), "chronyc", "makestep").Return("", "", 0).Times(tt.expectedSyncs)
+			clock := testclock.NewFakeClock(time.Now())
+			config := NewDefaultConfig()
+			config.SyncOnSkew = tt.syncOnSkew
+			monitor := NewMonitor(log.NewPrefixLogger("test"), config, exec, WithClock(clock))
+
+			clockSkew := monitor.ClockSkew()
+			require.Equal(DefaultTolerance, clockSkew.Tolerance)
+			require.Equal(clock.Now(), clockSkew.Now())
+
+			// the skew is detected on every connection, the clock is stepped at most once per interval
+			skew := 3 * time.Minute
+			if !tt.tolerated {
+				skew = 2 * time.Hour
+			}
+			clockSkew.Detected(skew, tt.tolerated)
+			clockSkew.Detected(skew, tt.tolerated)
+			clock.Step(reportInterval)
+			clockSkew.Detected(-skew, tt.tolerated)
+		})
+	}
+}
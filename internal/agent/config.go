@@ -6,10 +6,25 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/clockskew"
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/health"
+	"github.com/flightctl/flightctl/internal/agent/device/hook"
+	"github.com/flightctl/flightctl/internal/agent/device/logshipper"
+	agentos "github.com/flightctl/flightctl/internal/agent/device/os"
+	"github.com/flightctl/flightctl/internal/agent/device/policy"
+	"github.com/flightctl/flightctl/internal/agent/device/resource"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/internal/agent/device/telemetry"
+	"github.com/flightctl/flightctl/internal/agent/device/verification"
+	"github.com/flightctl/flightctl/internal/agent/device/watchdog"
+	"github.com/flightctl/flightctl/internal/agent/identity"
+	baseclient "github.com/flightctl/flightctl/internal/client"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/sirupsen/logrus"
 	"k8s.io/klog/v2"
@@ -27,6 +42,8 @@ const (
 	DefaultConfigFile = DefaultConfigDir + "/config.yaml"
 	// DefaultDataDir is the default directory where the device's data is stored
 	DefaultDataDir = "/var/lib/flightctl"
+	// DefaultSecretsDir is the default directory of the device-local secrets referenced by file templates
+	DefaultSecretsDir = DefaultConfigDir + "/secrets"
 	// DefaultCertsDir is the default directory where the device's certificates are stored
 	DefaultCertsDirName = "certs"
 	// DefaultManagementEndpoint is the default address of the device management server
@@ -43,6 +60,12 @@ const (
 	EnrollmentKeyFile = "client-enrollment.key"
 	// TestRootDirEnvKey is the environment variable key used to set the file system root when testing.
 	TestRootDirEnvKey = "FLIGHTCTL_TEST_ROOT_DIR"
+	// ConfigEnvPrefix prefixes the environment variables that override fields of the configuration.
+	// The rest of the name is the path to the field, with "__" between the keys of the path and "_"
+	// in place of "-" within a key, e.g. FLIGHTCTL_AGENT_CONFIG_MANAGEMENT_SERVICE__SERVICE__SERVER.
+	ConfigEnvPrefix = "FLIGHTCTL_AGENT_CONFIG_"
+	// dropInConfigExt is the extension of the drop-in configuration files.
+	dropInConfigExt = ".yaml"
 )
 
 type Config struct {
@@ -56,11 +79,92 @@ type Config struct {
 	// ManagementService is the client configuration for connecting to the device management server
 	ManagementService ManagementService `json:"management-service,omitempty"`
 
+	// Identity configures the sources of the identity the device enrolls with, e.g. the serial
+	// number of the hardware, so that the device keeps its identity across reinstalls
+	Identity identity.Config `json:"identity,omitempty"`
+
+	// ClockSkew configures how far the clock of the device may be off when validating the
+	// certificates of the services, so that devices with a drifting clock can still enroll
+	ClockSkew clockskew.Config `json:"clock-skew,omitempty"`
+
+	// DNS overrides how the host names of the services are resolved, for networks whose DNS
+	// servers do not resolve them
+	DNS baseclient.Resolver `json:"dns,omitempty"`
+
 	// SpecFetchInterval is the interval between two reads of the remote device spec
 	SpecFetchInterval util.Duration `json:"spec-fetch-interval,omitempty"`
 	// StatusUpdateInterval is the interval between two status updates
 	StatusUpdateInterval util.Duration `json:"status-update-interval,omitempty"`
 
+	// MaxConcurrentPulls is the maximum number of container images pulled at the same time,
+	// zero means unlimited
+	MaxConcurrentPulls int `json:"max-concurrent-pulls,omitempty"`
+
+	// MaxConcurrentApplications is the maximum number of applications added, updated or removed at
+	// the same time, in the order of their dependencies, defaults to 1
+	MaxConcurrentApplications int `json:"max-concurrent-applications,omitempty"`
+
+	// ContainerRuntime is the container runtime the agent pulls images and runs applications
+	// with. Only "podman", the default, is supported.
+	ContainerRuntime string `json:"container-runtime,omitempty"`
+
+	// ImageVerification is the policy used to verify the signatures of images before applying a spec
+	ImageVerification verification.Config `json:"image-verification,omitempty"`
+
+	// DiskGuard is the minimum free disk space required to apply an update
+	DiskGuard resource.DiskGuardConfig `json:"disk-guard,omitempty"`
+
+	// ApplyHooks are the commands run before and after a spec is applied
+	ApplyHooks hook.ApplyHooksConfig `json:"apply-hooks,omitempty"`
+
+	// UpdateDeferral defers updates while the device runs low on battery or is connected through
+	// a metered connection
+	UpdateDeferral resource.UpdateDeferralConfig `json:"update-deferral,omitempty"`
+
+	// Watchdog configures the systemd watchdog integration, active when WatchdogSec is set for
+	// the agent service
+	Watchdog watchdog.Config `json:"watchdog,omitempty"`
+
+	// LogShipping configures the shipping of the tail of the agent's logs to the management
+	// service, for remote troubleshooting
+	LogShipping logshipper.Config `json:"log-shipping,omitempty"`
+
+	// Health configures the health endpoints served on localhost, for local supervisors and
+	// monitoring to probe the agent
+	Health health.Config `json:"health,omitempty"`
+
+	// MaintenanceWindow restricts the application of updates to the approved windows, while specs
+	// are still fetched and their dependencies downloaded at any time
+	MaintenanceWindow policy.MaintenanceWindowConfig `json:"maintenance-window,omitempty"`
+
+	// UpdateSlot makes the device wait for one of the update slots of its fleet before it applies
+	// an update, limiting how many devices of the fleet update at the same time
+	UpdateSlot policy.UpdateSlotConfig `json:"update-slot,omitempty"`
+
+	// FailurePolicy quarantines a spec the device fails to apply too many times in a row, instead
+	// of retrying it forever
+	FailurePolicy policy.FailurePolicyConfig `json:"failure-policy,omitempty"`
+
+	// OSHealthCheck verifies the OS image the device booted into after an update, and rolls the
+	// device back to the previous image if the new one does not prove healthy in time
+	OSHealthCheck agentos.HealthCheckConfig `json:"os-health-check,omitempty"`
+
+	// CustomMetrics configures the push of custom metrics of the device, e.g. application KPIs, to
+	// a Prometheus Pushgateway
+	CustomMetrics telemetry.Config `json:"custom-metrics,omitempty"`
+
+	// StatusRedactedFields are the paths of the device status fields, e.g. "summary.info", that
+	// are removed from the status before it is sent to the management service
+	StatusRedactedFields []string `json:"status-redacted-fields,omitempty"`
+
+	// StatusRetry configures how status uploads are retried while the management service is
+	// unreachable
+	StatusRetry status.RetryConfig `json:"status-retry,omitempty"`
+
+	// SecretsDir is the directory of the device-local secrets that file templates reference with
+	// "{{ secret "name" }}", each secret being the content of the file of the same name
+	SecretsDir string `json:"secrets-dir,omitempty"`
+
 	// TPMPath is the path to the TPM device
 	TPMPath string `json:"tpm-path,omitempty"`
 
@@ -78,6 +182,10 @@ type Config struct {
 	// DefaultLabels are automatically applied to this device when the agent is enrolled in a service
 	DefaultLabels map[string]string `json:"default-labels,omitempty"`
 
+	// StructuredBanner enables writing the enrollment banner in machine-readable form, alongside
+	// the text banner, for tools that need the enrollment ID of the device
+	StructuredBanner bool `json:"structured-banner,omitempty"`
+
 	reader fileio.Reader
 }
 
@@ -86,6 +194,10 @@ type EnrollmentService struct {
 
 	// EnrollmentUIEndpoint is the address of the device enrollment UI
 	EnrollmentUIEndpoint string `json:"enrollment-ui-endpoint,omitempty"`
+
+	// BootstrapToken is a one-time token the agent redeems for its enrollment certificate when it
+	// has none, as an alternative to provisioning the device with an enrollment certificate
+	BootstrapToken string `json:"bootstrap-token,omitempty"`
 }
 
 type ManagementService struct {
@@ -96,7 +208,7 @@ func (s *EnrollmentService) Equal(s2 *EnrollmentService) bool {
 	if s == s2 {
 		return true
 	}
-	return s.Config.Equal(&s2.Config) && s.EnrollmentUIEndpoint == s2.EnrollmentUIEndpoint
+	return s.Config.Equal(&s2.Config) && s.EnrollmentUIEndpoint == s2.EnrollmentUIEndpoint && s.BootstrapToken == s2.BootstrapToken
 }
 
 func (s *ManagementService) Equal(s2 *ManagementService) bool {
@@ -112,11 +224,22 @@ func NewDefault() *Config {
 		DataDir:              DefaultDataDir,
 		EnrollmentService:    EnrollmentService{Config: *client.NewDefault()},
 		ManagementService:    ManagementService{Config: *client.NewDefault()},
+		Identity:             identity.NewDefaultConfig(),
+		ClockSkew:            clockskew.NewDefaultConfig(),
 		StatusUpdateInterval: DefaultStatusUpdateInterval,
 		SpecFetchInterval:    DefaultSpecFetchInterval,
 		reader:               fileio.NewReader(),
 		LogLevel:             logrus.InfoLevel.String(),
 		DefaultLabels:        make(map[string]string),
+		LogShipping:          logshipper.NewDefaultConfig(),
+		Health:               health.NewDefaultConfig(),
+		MaintenanceWindow:    policy.NewDefaultMaintenanceWindowConfig(),
+		UpdateSlot:           policy.NewDefaultUpdateSlotConfig(),
+		FailurePolicy:        policy.NewDefaultFailurePolicyConfig(),
+		OSHealthCheck:        agentos.NewDefaultHealthCheckConfig(),
+		CustomMetrics:        telemetry.NewDefaultConfig(),
+		StatusRetry:          status.NewDefaultRetryConfig(),
+		SecretsDir:           DefaultSecretsDir,
 	}
 
 	if value := os.Getenv(TestRootDirEnvKey); value != "" {
@@ -160,6 +283,13 @@ func (cfg *Config) Complete() error {
 		}
 		cfg.EnrollmentService.EnrollmentUIEndpoint = DefaultManagementEndpoint
 	}
+	// If a bootstrap token is used, the enrollment certificate it is redeemed for is stored at the default paths.
+	if cfg.EnrollmentService.BootstrapToken != "" && !cfg.EnrollmentService.Config.HasCredentials() {
+		cfg.EnrollmentService.AuthInfo = client.AuthInfo{
+			ClientCertificate: filepath.Join(cfg.DataDir, DefaultCertsDirName, EnrollmentCertFile),
+			ClientKey:         filepath.Join(cfg.DataDir, DefaultCertsDirName, EnrollmentKeyFile),
+		}
+	}
 	// If the enrollment UI endpoint hasn't been specified, attempt using the same endpoint as the enrollment service.
 	if cfg.EnrollmentService.EnrollmentUIEndpoint == "" {
 		cfg.EnrollmentService.EnrollmentUIEndpoint = cfg.EnrollmentService.Config.Service.Server
@@ -176,12 +306,75 @@ func (cfg *Config) Complete() error {
 
 // Validate checks that the required fields are set and that the paths exist.
 func (cfg *Config) Validate() error {
-	if err := cfg.EnrollmentService.Validate(); err != nil {
+	enrollmentService := &cfg.EnrollmentService.Config
+	if cfg.EnrollmentService.BootstrapToken != "" {
+		// the enrollment certificate and key are created when redeeming the bootstrap token
+		enrollmentService = enrollmentService.DeepCopy()
+		enrollmentService.AuthInfo = client.AuthInfo{}
+	}
+	if err := enrollmentService.Validate(); err != nil {
 		return err
 	}
 	if err := cfg.ManagementService.Validate(); err != nil {
 		return err
 	}
+	if err := cfg.Identity.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.ClockSkew.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.DNS.Validate(); err != nil {
+		return err
+	}
+	if err := client.ValidateRuntime(cfg.ContainerRuntime); err != nil {
+		return err
+	}
+	if err := cfg.ImageVerification.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.DiskGuard.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.ApplyHooks.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.UpdateDeferral.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.Watchdog.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.LogShipping.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.Health.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.MaintenanceWindow.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.FailurePolicy.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.OSHealthCheck.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.CustomMetrics.Validate(); err != nil {
+		return err
+	}
+	if err := status.ValidateRedactedFields(cfg.StatusRedactedFields); err != nil {
+		return fmt.Errorf("status-redacted-fields: %w", err)
+	}
+	if err := cfg.StatusRetry.Validate(); err != nil {
+		return err
+	}
+	if cfg.MaxConcurrentApplications < 0 {
+		return fmt.Errorf("max-concurrent-applications must not be negative: %d", cfg.MaxConcurrentApplications)
+	}
+	if cfg.SecretsDir != "" && !filepath.IsAbs(cfg.SecretsDir) {
+		return fmt.Errorf("secrets-dir must be an absolute path: %s", cfg.SecretsDir)
+	}
 
 	requiredFields := []struct {
 		value     string
@@ -210,7 +403,9 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
-// ParseConfigFile reads the config file and unmarshals it into the Config struct
+// ParseConfigFile reads the config file and unmarshals it into the Config struct. The drop-in files
+// of its drop-in directory are then merged in lexical order, and finally the environment variables
+// prefixed with ConfigEnvPrefix, each layer overriding the fields set by the previous ones.
 func (cfg *Config) ParseConfigFile(cfgFile string) error {
 	contents, err := cfg.reader.ReadFile(cfgFile)
 	if err != nil {
@@ -219,11 +414,107 @@ func (cfg *Config) ParseConfigFile(cfgFile string) error {
 	if err := yaml.Unmarshal(contents, cfg); err != nil {
 		return fmt.Errorf("unmarshalling config file: %w", err)
 	}
+	dropIns, err := cfg.dropInConfigFiles(cfgFile)
+	if err != nil {
+		return err
+	}
+	for _, dropIn := range dropIns {
+		contents, err := cfg.reader.ReadFile(dropIn)
+		if err != nil {
+			return fmt.Errorf("reading drop-in config file: %w", err)
+		}
+		if err := yaml.Unmarshal(contents, cfg); err != nil {
+			return fmt.Errorf("unmarshalling drop-in config file %s: %w", dropIn, err)
+		}
+	}
+	if err := cfg.applyConfigEnv(os.Environ()); err != nil {
+		return err
+	}
+	// relative paths of the drop-in files are also resolved from the directory of the config file
 	cfg.EnrollmentService.Config.SetBaseDir(filepath.Dir(cfgFile))
 	cfg.ManagementService.Config.SetBaseDir(filepath.Dir(cfgFile))
 	return nil
 }
 
+// DropInConfigDir returns the directory of the drop-in files of the config file, e.g.
+// /etc/flightctl/config.d for /etc/flightctl/config.yaml.
+func DropInConfigDir(cfgFile string) string {
+	return strings.TrimSuffix(cfgFile, filepath.Ext(cfgFile)) + ".d"
+}
+
+// dropInConfigFiles returns the drop-in files of the config file in lexical order.
+func (cfg *Config) dropInConfigFiles(cfgFile string) ([]string, error) {
+	dir := DropInConfigDir(cfgFile)
+	// a missing directory has no drop-in files
+	entries, err := cfg.reader.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading drop-in config dir: %w", err)
+	}
+	files := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == dropInConfigExt {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// applyConfigEnv overrides the fields of the configuration set by the environment variables
+// prefixed with ConfigEnvPrefix. The values are parsed as YAML, so strings that read as another
+// type must be quoted.
+func (cfg *Config) applyConfigEnv(environ []string) error {
+	overrides := map[string]interface{}{}
+	names := []string{}
+	values := map[string]string{}
+	for _, env := range environ {
+		name, value, _ := strings.Cut(env, "=")
+		if strings.HasPrefix(name, ConfigEnvPrefix) && len(name) > len(ConfigEnvPrefix) {
+			names = append(names, name)
+			values[name] = value
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(values[name]), &value); err != nil {
+			value = values[name]
+		}
+		keys := strings.Split(strings.TrimPrefix(name, ConfigEnvPrefix), "__")
+		fields := overrides
+		for i, key := range keys {
+			key = strings.ReplaceAll(strings.ToLower(key), "_", "-")
+			if i == len(keys)-1 {
+				if _, ok := fields[key]; ok {
+					return fmt.Errorf("environment variable %s conflicts with another one", name)
+				}
+				fields[key] = value
+				break
+			}
+			nested, ok := fields[key].(map[string]interface{})
+			if !ok {
+				if _, exists := fields[key]; exists {
+					return fmt.Errorf("environment variable %s conflicts with another one", name)
+				}
+				nested = map[string]interface{}{}
+				fields[key] = nested
+			}
+			fields = nested
+		}
+	}
+	contents, err := json.Marshal(overrides)
+	if err != nil {
+		return fmt.Errorf("marshalling config environment variables: %w", err)
+	}
+	if err := json.Unmarshal(contents, cfg); err != nil {
+		return fmt.Errorf("unmarshalling config environment variables: %w", err)
+	}
+	return nil
+}
+
 func (cfg *Config) String() string {
 	contents, err := json.Marshal(cfg)
 	if err != nil {
@@ -63,3 +63,84 @@ func TestParseConfigFile_NoFile(t *testing.T) {
 	// Expect an error because the file does not exist
 	require.Error(err)
 }
+
+func TestParseConfigFileDropIns(t *testing.T) {
+	require := require.New(t)
+
+	tmpDir := t.TempDir()
+	filePath := tmpDir + "/config.yaml"
+	require.NoError(os.WriteFile(filePath, []byte(yamlConfig+"\nlog-level: debug\ndefault-labels:\n  site: berlin\n  line: \"1\""), 0600))
+	dropInDir := DropInConfigDir(filePath)
+	require.Equal(tmpDir+"/config.d", dropInDir)
+	require.NoError(os.Mkdir(dropInDir, 0700))
+	// applied in lexical order, whatever order they are listed in
+	require.NoError(os.WriteFile(dropInDir+"/20-device.yaml", []byte("spec-fetch-interval: 30s\ndefault-labels:\n  line: \"2\""), 0600))
+	require.NoError(os.WriteFile(dropInDir+"/10-fleet.yaml", []byte("spec-fetch-interval: 20s\nstatus-update-interval: 20s\nlog-level: warn"), 0600))
+	require.NoError(os.WriteFile(dropInDir+"/README", []byte("not: yaml: ["), 0600))
+
+	t.Setenv(ConfigEnvPrefix+"LOG_LEVEL", "error")
+	t.Setenv(ConfigEnvPrefix+"MANAGEMENT_SERVICE__SERVICE__SERVER", "https://override.endpoint")
+	t.Setenv(ConfigEnvPrefix+"MAX_CONCURRENT_PULLS", "3")
+
+	cfg := NewDefault()
+	require.NoError(cfg.ParseConfigFile(filePath))
+
+	// the later drop-in overrides the earlier one, which overrides the config file
+	require.Equal("30s", cfg.SpecFetchInterval.String())
+	require.Equal("20s", cfg.StatusUpdateInterval.String())
+	// maps are merged key by key
+	require.Equal(map[string]string{"site": "berlin", "line": "2"}, cfg.DefaultLabels)
+	// the environment overrides all files
+	require.Equal("error", cfg.LogLevel)
+	require.Equal("https://override.endpoint", cfg.ManagementService.Service.Server)
+	require.Equal(3, cfg.MaxConcurrentPulls)
+	// the fields set by no layer keep their values
+	require.Equal("https://enrollment.endpoint", cfg.EnrollmentService.Service.Server)
+}
+
+func TestApplyConfigEnv(t *testing.T) {
+	testCases := []struct {
+		name          string
+		environ       []string
+		expectedError string
+		check         func(require *require.Assertions, cfg *Config)
+	}{
+		{
+			name:    "ignores other variables",
+			environ: []string{"FLIGHTCTL_AGENT_ENDPOINT=https://other", ConfigEnvPrefix + "=x", "PATH=/usr/bin"},
+			check: func(require *require.Assertions, cfg *Config) {
+				require.Equal(NewDefault().String(), cfg.String())
+			},
+		},
+		{
+			name:    "quoted string",
+			environ: []string{ConfigEnvPrefix + "DEFAULT_LABELS__ZONE=\"42\""},
+			check: func(require *require.Assertions, cfg *Config) {
+				require.Equal(map[string]string{"zone": "42"}, cfg.DefaultLabels)
+			},
+		},
+		{
+			name:          "wrong type",
+			environ:       []string{ConfigEnvPrefix + "MAX_CONCURRENT_PULLS=many"},
+			expectedError: "unmarshalling config environment variables",
+		},
+		{
+			name:          "conflicting paths",
+			environ:       []string{ConfigEnvPrefix + "IDENTITY__SOURCES=[serial]", ConfigEnvPrefix + "IDENTITY=x"},
+			expectedError: "conflicts with another one",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			cfg := NewDefault()
+			err := cfg.applyConfigEnv(tt.environ)
+			if tt.expectedError != "" {
+				require.ErrorContains(err, tt.expectedError)
+				return
+			}
+			require.NoError(err)
+			tt.check(require, cfg)
+		})
+	}
+}
@@ -96,6 +96,8 @@ type Application interface {
 	RemoveContainer(name string) bool
 	// IsEmbedded returns true if the application is embedded.
 	IsEmbedded() bool
+	// DependsOn returns the names of the applications the application depends on.
+	DependsOn() []string
 	// Status reports the status of an application using the name as defined by
 	// the user. In the case there is no name provided it will be populated
 	// according to the rules of the application type.
@@ -121,6 +123,7 @@ type application[T any] struct {
 	provider   T
 	status     *v1alpha1.DeviceApplicationStatus
 	embedded   bool
+	dependsOn  []string
 }
 
 func NewApplication[T any](id, name string, provider T, appType AppType) *application[T] {
@@ -165,6 +168,15 @@ func (a *application[T]) SetEnvVars(envVars map[string]string) bool {
 	return true
 }
 
+func (a *application[T]) DependsOn() []string {
+	return a.dependsOn
+}
+
+// SetDependsOn sets the names of the applications the application depends on.
+func (a *application[T]) SetDependsOn(dependsOn []string) {
+	a.dependsOn = dependsOn
+}
+
 func (a *application[T]) Container(name string) (*Container, bool) {
 	for i := range a.containers {
 		if a.containers[i].Name == name {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
@@ -78,23 +79,24 @@ func (c *Controller) ensureImages(ctx context.Context, currentApps, desiredApps
 		}
 	}
 
-	for _, app := range diff.Ensure {
-		if err := c.ensureImagePackage(ctx, app); err != nil {
-			return err
-		}
-		if err := c.manager.Ensure(app); err != nil {
-			return err
-		}
-	}
-
-	for _, app := range diff.Changed {
-		if err := c.removeImagePackage(app); err != nil {
-			return err
+	// desired apps are in dependency order, so they are ensured and updated in that order
+	for _, app := range desiredApps {
+		if slices.Contains(diff.Changed, app) {
+			if err := c.removeImagePackage(app); err != nil {
+				return err
+			}
+			if err := c.ensureImagePackage(ctx, app); err != nil {
+				return err
+			}
+			if err := c.manager.Update(app); err != nil {
+				return err
+			}
+			continue
 		}
 		if err := c.ensureImagePackage(ctx, app); err != nil {
 			return err
 		}
-		if err := c.manager.Update(app); err != nil {
+		if err := c.manager.Ensure(app); err != nil {
 			return err
 		}
 	}
@@ -151,6 +153,7 @@ func parseApps(ctx context.Context, podman *client.Podman, spec *v1alpha1.Render
 	if spec.Applications == nil {
 		return &apps, nil
 	}
+	dependsOn := make(map[string][]string)
 	for _, appSpec := range *spec.Applications {
 		providerType, err := appSpec.Type()
 		if err != nil {
@@ -179,14 +182,42 @@ func parseApps(ctx context.Context, podman *client.Podman, spec *v1alpha1.Render
 				appType,
 			)
 			application.SetEnvVars(util.FromPtr(appSpec.EnvVars))
+			application.SetDependsOn(util.FromPtr(appSpec.DependsOn))
 			apps.images = append(apps.images, application)
+			dependsOn[name] = util.FromPtr(appSpec.DependsOn)
 		default:
 			return nil, fmt.Errorf("%w: %s", errors.ErrUnsupportedAppType, providerType)
 		}
 	}
+
+	images, err := sortByDependencies(apps.images, dependsOn)
+	if err != nil {
+		return nil, err
+	}
+	apps.images = images
 	return &apps, nil
 }
 
+// sortByDependencies orders the applications so that every application comes after the
+// applications it depends on.
+func sortByDependencies[T any](apps []*application[T], dependsOn map[string][]string) ([]*application[T], error) {
+	names := make([]string, 0, len(apps))
+	byName := make(map[string]*application[T], len(apps))
+	for _, app := range apps {
+		names = append(names, app.Name())
+		byName[app.Name()] = app
+	}
+	sortedNames, err := v1alpha1.SortApplicationsByDependencies(names, dependsOn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrAppDependencyOrder, err)
+	}
+	sorted := make([]*application[T], 0, len(apps))
+	for _, name := range sortedNames {
+		sorted = append(sorted, byName[name])
+	}
+	return sorted, nil
+}
+
 type diff[T any] struct {
 	// Ensure contains both newly added and unchanged apps
 	Ensure []*application[T]
@@ -222,14 +253,16 @@ func diffApps[T any](
 		currentApps[app.Name()] = app
 	}
 
-	for name, app := range currentApps {
-		if _, exists := desiredApps[name]; !exists {
+	// applications are removed in reverse dependency order
+	for i := len(current) - 1; i >= 0; i-- {
+		app := current[i]
+		if _, exists := desiredApps[app.Name()]; !exists {
 			diff.Removed = append(diff.Removed, app)
 		}
 	}
 
-	for name, desiredApp := range desiredApps {
-		if currentApp, exists := currentApps[name]; !exists {
+	for _, desiredApp := range desired {
+		if currentApp, exists := currentApps[desiredApp.Name()]; !exists {
 			diff.Ensure = append(diff.Ensure, desiredApp)
 		} else {
 			if isEqual(currentApp, desiredApp) {
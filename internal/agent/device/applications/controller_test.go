@@ -21,8 +21,9 @@ import (
 )
 
 type testApp struct {
-	name  string
-	image string
+	name      string
+	image     string
+	dependsOn []string
 }
 
 func TestParseApps(t *testing.T) {
@@ -84,6 +85,30 @@ func TestParseApps(t *testing.T) {
 			wantNames:    []string{"app1", "quay.io/org/app2:latest", "app2"},
 			wantIDPrefix: []string{"app1-", "quay_io_org_app2_latest", "app2"},
 		},
+		{
+			name: "apps ordered by dependencies",
+			apps: []testApp{
+				{name: "web", image: "quay.io/org/web:latest", dependsOn: []string{"cache", "db"}},
+				{name: "metrics", image: "quay.io/org/metrics:latest"},
+				{name: "cache", image: "quay.io/org/cache:latest", dependsOn: []string{"db"}},
+				{name: "db", image: "quay.io/org/db:latest"},
+			},
+			labels: map[string]string{
+				AppTypeLabel: string(AppCompose),
+			},
+			wantNames: []string{"db", "cache", "web", "metrics"},
+		},
+		{
+			name: "dependency cycle",
+			apps: []testApp{
+				{name: "web", image: "quay.io/org/web:latest", dependsOn: []string{"db"}},
+				{name: "db", image: "quay.io/org/db:latest", dependsOn: []string{"web"}},
+			},
+			labels: map[string]string{
+				AppTypeLabel: string(AppCompose),
+			},
+			wantErr: errors.ErrAppDependencyOrder,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -150,6 +175,10 @@ func newTestRenderedDeviceSpec(appSpecs []testApp) (*v1alpha1.RenderedDeviceSpec
 		app := v1alpha1.RenderedApplicationSpec{
 			Name: util.StrToPtr(spec.name),
 		}
+		if len(spec.dependsOn) > 0 {
+			dependsOn := spec.dependsOn
+			app.DependsOn = &dependsOn
+		}
 		provider := v1alpha1.ImageApplicationProvider{
 			Image: spec.image,
 		}
@@ -299,6 +328,49 @@ func TestControllerSync(t *testing.T) {
 				mockAppManager.EXPECT().Remove(gomock.Any()).Return(nil)
 			},
 		},
+		{
+			name: "add and remove apps in dependency order",
+			steps: []transitionStep{
+				{
+					current: []testRendered{
+						{version: "1", apps: []testApp{}}, // empty
+					},
+					desired: []testRendered{
+						{version: "2", apps: []testApp{
+							{name: "app1", image: app1Image, dependsOn: []string{"app2"}},
+							{name: "app2", image: app2Image},
+						}},
+					},
+				},
+				{
+					current: []testRendered{
+						{version: "2", apps: []testApp{
+							{name: "app1", image: app1Image, dependsOn: []string{"app2"}},
+							{name: "app2", image: app2Image},
+						}},
+					},
+					desired: []testRendered{
+						{version: "3", apps: []testApp{}}, // remove all
+					},
+				},
+			},
+			setupMocks: func(
+				mockAppManager *MockManager,
+				mockExecuter *executer.MockExecuter,
+			) {
+				mockExecuter.EXPECT().ExecuteWithContext(gomock.Any(), "podman", []string{"inspect", app1Image}).Return(app1Labels, "", 0).Times(2)
+				mockExecuter.EXPECT().ExecuteWithContext(gomock.Any(), "podman", []string{"inspect", app2Image}).Return(app1Labels, "", 0).Times(2)
+				mockExecuter.EXPECT().ExecuteWithContext(gomock.Any(), "podman", gomock.Any()).Return("/mount", "", 0).AnyTimes()
+
+				// app2 is started before and stopped after app1, which depends on it
+				gomock.InOrder(
+					mockAppManager.EXPECT().Ensure(appNamed("app2")).Return(nil),
+					mockAppManager.EXPECT().Ensure(appNamed("app1")).Return(nil),
+					mockAppManager.EXPECT().Remove(appNamed("app1")).Return(nil),
+					mockAppManager.EXPECT().Remove(appNamed("app2")).Return(nil),
+				)
+			},
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -354,6 +426,13 @@ func TestControllerSync(t *testing.T) {
 	}
 }
 
+func appNamed(name string) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		app, ok := x.(Application)
+		return ok && app.Name() == name
+	})
+}
+
 const composeSpec = `
 version: "3"
 services:
@@ -37,6 +37,8 @@ type Action struct {
 	Type ActionType
 	// Embedded is true if the application is embedded in the device
 	Embedded bool
+	// DependsOn are the names of the applications the application depends on
+	DependsOn []string
 }
 
 // ApplicationPath returns the path to the application on the device
@@ -23,10 +23,11 @@ func NewManager(
 	exec executer.Executer,
 	podmanClient *client.Podman,
 	systemClient client.System,
+	parallelism int,
 ) Manager {
 	bootTime := systemClient.BootTime()
 	return &manager{
-		podmanMonitor: NewPodmanMonitor(log, exec, podmanClient, bootTime),
+		podmanMonitor: NewPodmanMonitor(log, exec, podmanClient, bootTime, parallelism),
 		log:           log,
 	}
 }
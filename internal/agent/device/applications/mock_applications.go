@@ -223,6 +223,20 @@ func (mr *MockApplicationMockRecorder) Container(name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Container", reflect.TypeOf((*MockApplication)(nil).Container), name)
 }
 
+// DependsOn mocks base method.
+func (m *MockApplication) DependsOn() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DependsOn")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// DependsOn indicates an expected call of DependsOn.
+func (mr *MockApplicationMockRecorder) DependsOn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DependsOn", reflect.TypeOf((*MockApplication)(nil).DependsOn))
+}
+
 // EnvVars mocks base method.
 func (m *MockApplication) EnvVars() map[string]string {
 	m.ctrl.T.Helper()
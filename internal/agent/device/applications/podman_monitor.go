@@ -8,6 +8,7 @@ import (
 	"hash/crc32"
 	"io"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -72,17 +73,20 @@ type PodmanMonitor struct {
 	compose  lifecycle.ActionHandler
 	client   *client.Podman
 	bootTime string
+	// parallelism is the maximum number of actions executed at the same time
+	parallelism int
 
 	log *log.PrefixLogger
 }
 
-func NewPodmanMonitor(log *log.PrefixLogger, exec executer.Executer, podman *client.Podman, bootTime string) *PodmanMonitor {
+func NewPodmanMonitor(log *log.PrefixLogger, exec executer.Executer, podman *client.Podman, bootTime string, parallelism int) *PodmanMonitor {
 	return &PodmanMonitor{
-		client:   podman,
-		compose:  lifecycle.NewCompose(log, podman),
-		apps:     make(map[string]Application),
-		bootTime: bootTime,
-		log:      log,
+		client:      podman,
+		compose:     lifecycle.NewCompose(log, podman),
+		apps:        make(map[string]Application),
+		bootTime:    bootTime,
+		parallelism: parallelism,
+		log:         log,
 	}
 }
 
@@ -92,7 +96,16 @@ func (m *PodmanMonitor) Run(ctx context.Context) error {
 
 	// list of podman events to listen for
 	events := []string{"init", "start", "die", "sync", "remove", "exited"}
-	m.cmd = m.client.EventsSinceCmd(ctx, events, m.bootTime)
+	cmd, err := m.client.EventsSinceCmd(ctx, events, m.bootTime)
+	if err != nil {
+		// applications still run on runtimes without events, their status is just not reported
+		if errors.Is(err, errors.ErrUnsupportedRuntimeOperation) {
+			m.log.Warnf("Not monitoring applications: %v", err)
+			return nil
+		}
+		return fmt.Errorf("failed to monitor applications: %w", err)
+	}
+	m.cmd = cmd
 
 	stdoutPipe, err := m.cmd.StdoutPipe()
 	if err != nil {
@@ -190,11 +203,12 @@ func (m *PodmanMonitor) ensure(app Application) error {
 
 	appName := app.Name()
 	action := lifecycle.Action{
-		Handler:  handler,
-		Type:     lifecycle.ActionAdd,
-		Name:     appName,
-		ID:       appID,
-		Embedded: app.IsEmbedded(),
+		Handler:   handler,
+		Type:      lifecycle.ActionAdd,
+		Name:      appName,
+		ID:        appID,
+		Embedded:  app.IsEmbedded(),
+		DependsOn: app.DependsOn(),
 	}
 
 	m.actions = append(m.actions, action)
@@ -221,10 +235,11 @@ func (m *PodmanMonitor) remove(app Application) error {
 
 	// currently we don't support removing embedded applications
 	action := lifecycle.Action{
-		Handler: handler,
-		Type:    lifecycle.ActionRemove,
-		Name:    app.Name(),
-		ID:      appID,
+		Handler:   handler,
+		Type:      lifecycle.ActionRemove,
+		Name:      app.Name(),
+		ID:        appID,
+		DependsOn: app.DependsOn(),
 	}
 
 	m.actions = append(m.actions, action)
@@ -253,10 +268,11 @@ func (m *PodmanMonitor) update(app Application) error {
 
 	// currently we don't support updating embedded applications
 	action := lifecycle.Action{
-		Handler: handler,
-		Type:    lifecycle.ActionUpdate,
-		Name:    appName,
-		ID:      appID,
+		Handler:   handler,
+		Type:      lifecycle.ActionUpdate,
+		Name:      appName,
+		ID:        appID,
+		DependsOn: app.DependsOn(),
 	}
 
 	m.actions = append(m.actions, action)
@@ -289,15 +305,16 @@ func (m *PodmanMonitor) IsInitialized() bool {
 
 func (m *PodmanMonitor) ExecuteActions(ctx context.Context) error {
 	actions := m.drainActions()
-	for i := range actions {
-		action := actions[i]
+	err := executeActions(ctx, actions, m.parallelism, func(ctx context.Context, action *lifecycle.Action) error {
 		if action.Handler == lifecycle.ActionHandlerCompose {
-			if err := m.compose.Execute(ctx, &action); err != nil {
-				// this error should result in a failed status for the revision
-				// and not retried.
-				return err
-			}
+			return m.compose.Execute(ctx, action)
 		}
+		return nil
+	})
+	if err != nil {
+		// this error should result in a failed status for the revision
+		// and not retried.
+		return err
 	}
 
 	// if this is the first time we are executing actions we need to ensure
@@ -312,6 +329,74 @@ func (m *PodmanMonitor) ExecuteActions(ctx context.Context) error {
 	return nil
 }
 
+// executeActions executes the actions in order, or with up to parallelism of them at the same time.
+// An action then starts once the actions queued before it that it conflicts with have completed.
+// After a failure, the actions not started yet are skipped and the first error is returned.
+func executeActions(ctx context.Context, actions []lifecycle.Action, parallelism int, execute func(context.Context, *lifecycle.Action) error) error {
+	if parallelism <= 1 {
+		for i := range actions {
+			if err := execute(ctx, &actions[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	done := make([]chan struct{}, len(actions))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	slots := make(chan struct{}, parallelism)
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	for i := range actions {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(done[i])
+			for j := 0; j < i; j++ {
+				if actionsConflict(&actions[i], &actions[j]) {
+					<-done[j]
+				}
+			}
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			mu.Lock()
+			failed := firstErr != nil
+			mu.Unlock()
+			if failed {
+				return
+			}
+			if err := execute(ctx, &actions[i]); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// actionsConflict returns true if the actions must run one after the other: they are for the same
+// application, one of the applications depends on the other, or only one of them is a removal,
+// since applications are removed before the others are added or updated.
+func actionsConflict(a, b *lifecycle.Action) bool {
+	if a.Name == b.Name {
+		return true
+	}
+	if slices.Contains(a.DependsOn, b.Name) || slices.Contains(b.DependsOn, a.Name) {
+		return true
+	}
+	return (a.Type == lifecycle.ActionRemove) != (b.Type == lifecycle.ActionRemove)
+}
+
 // drainActions returns a copy of the current actions and clears the existing. this
 // ensures actions can only be executed once and on failure the remaining
 // actions will not be executed.
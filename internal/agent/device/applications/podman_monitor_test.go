@@ -7,11 +7,13 @@ import (
 	"io"
 	"path"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device/applications/lifecycle"
 	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
 	"github.com/sirupsen/logrus"
@@ -150,7 +152,7 @@ func TestListenForEvents(t *testing.T) {
 			require.NoError(err)
 
 			podman := client.NewPodman(log, execMock, newTestBackoff())
-			podmanMonitor := NewPodmanMonitor(log, execMock, podman, "")
+			podmanMonitor := NewPodmanMonitor(log, execMock, podman, "", 1)
 
 			// add test apps to the monitor
 			for _, testApp := range tc.apps {
@@ -295,7 +297,7 @@ func TestApplicationAddRemove(t *testing.T) {
 			execMock := executer.NewMockExecuter(ctrl)
 
 			podman := client.NewPodman(log, execMock, newTestBackoff())
-			podmanMonitor := NewPodmanMonitor(log, execMock, podman, "")
+			podmanMonitor := NewPodmanMonitor(log, execMock, podman, "", 1)
 			testApp := createTestApplication(tc.appName, v1alpha1.ApplicationStatusPreparing)
 
 			switch tc.action {
@@ -413,3 +415,112 @@ func TestNewComposeID(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteActions(t *testing.T) {
+	add := func(name string, dependsOn ...string) lifecycle.Action {
+		return lifecycle.Action{Name: name, Type: lifecycle.ActionAdd, DependsOn: dependsOn}
+	}
+	remove := func(name string, dependsOn ...string) lifecycle.Action {
+		return lifecycle.Action{Name: name, Type: lifecycle.ActionRemove, DependsOn: dependsOn}
+	}
+
+	testCases := []struct {
+		name        string
+		actions     []lifecycle.Action
+		parallelism int
+		failing     string
+		// wantMaxRunning is the number of actions expected to run at the same time at most
+		wantMaxRunning int
+		// wantBefore maps an application to the applications whose actions must complete before its own starts
+		wantBefore  map[string][]string
+		wantSkipped []string
+	}{
+		{
+			name:           "serial by default",
+			actions:        []lifecycle.Action{add("a"), add("b"), add("c")},
+			wantMaxRunning: 1,
+			wantBefore:     map[string][]string{"b": {"a"}, "c": {"a", "b"}},
+		},
+		{
+			name:           "bounded concurrency",
+			actions:        []lifecycle.Action{add("a"), add("b"), add("c"), add("d"), add("e")},
+			parallelism:    2,
+			wantMaxRunning: 2,
+		},
+		{
+			name:           "dependencies respected",
+			actions:        []lifecycle.Action{add("db"), add("cache"), add("web", "db", "cache"), add("worker", "db")},
+			parallelism:    4,
+			wantMaxRunning: 2,
+			wantBefore:     map[string][]string{"web": {"db", "cache"}, "worker": {"db"}},
+		},
+		{
+			name: "removals before additions",
+			actions: []lifecycle.Action{
+				remove("web", "db"), remove("db"), add("new-db"), add("new-web", "new-db"),
+			},
+			parallelism:    4,
+			wantMaxRunning: 1,
+			wantBefore:     map[string][]string{"db": {"web"}, "new-db": {"web", "db"}, "new-web": {"new-db"}},
+		},
+		{
+			name:           "failure skips the actions not started",
+			actions:        []lifecycle.Action{add("a"), add("b", "a"), add("c", "b")},
+			parallelism:    2,
+			failing:        "a",
+			wantMaxRunning: 1,
+			wantSkipped:    []string{"b", "c"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			var (
+				mu         sync.Mutex
+				running    int
+				maxRunning int
+				started    = map[string]int{}
+				completed  = map[string]int{}
+				clock      int
+			)
+			execute := func(ctx context.Context, action *lifecycle.Action) error {
+				mu.Lock()
+				clock++
+				started[action.Name] = clock
+				running++
+				maxRunning = max(maxRunning, running)
+				mu.Unlock()
+
+				time.Sleep(20 * time.Millisecond)
+
+				mu.Lock()
+				defer mu.Unlock()
+				clock++
+				completed[action.Name] = clock
+				running--
+				if action.Name == tc.failing {
+					return fmt.Errorf("compose up failed")
+				}
+				return nil
+			}
+
+			err := executeActions(context.Background(), tc.actions, tc.parallelism, execute)
+			if tc.failing != "" {
+				require.ErrorContains(err, "compose up failed")
+			} else {
+				require.NoError(err)
+			}
+			require.Equal(tc.wantMaxRunning, maxRunning)
+			for app, before := range tc.wantBefore {
+				for _, other := range before {
+					require.Less(completed[other], started[app], "%s must complete before %s starts", other, app)
+				}
+			}
+			for _, app := range tc.wantSkipped {
+				require.NotContains(started, app)
+			}
+			require.Len(started, len(tc.actions)-len(tc.wantSkipped))
+		})
+	}
+}
@@ -3,13 +3,14 @@ package device
 import (
 	"context"
 	"fmt"
-	"os"
+	goos "os"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/agent/client"
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
 	"github.com/flightctl/flightctl/internal/agent/device/hook"
 	"github.com/flightctl/flightctl/internal/agent/device/lifecycle"
+	"github.com/flightctl/flightctl/internal/agent/device/os"
 	"github.com/flightctl/flightctl/internal/agent/device/spec"
 	"github.com/flightctl/flightctl/internal/agent/device/status"
 	"github.com/flightctl/flightctl/internal/util"
@@ -29,9 +30,13 @@ type Bootstrap struct {
 	specManager      spec.Manager
 	statusManager    status.Manager
 	hookManager      hook.Manager
+	osManager        os.Manager
 	lifecycle        lifecycle.Initializer
 	systemClient     client.System
 
+	// pendingOSUpdate is the state of an OS update interrupted by the last restart
+	pendingOSUpdate *os.UpdateState
+
 	managementServiceConfig *client.Config
 	managementClient        client.Management
 
@@ -45,6 +50,7 @@ func NewBootstrap(
 	specManager spec.Manager,
 	statusManager status.Manager,
 	hookManager hook.Manager,
+	osManager os.Manager,
 	lifecycleInitializer lifecycle.Initializer,
 	managementServiceConfig *client.Config,
 	systemClient client.System,
@@ -57,6 +63,7 @@ func NewBootstrap(
 		specManager:             specManager,
 		statusManager:           statusManager,
 		hookManager:             hookManager,
+		osManager:               osManager,
 		lifecycle:               lifecycleInitializer,
 		managementServiceConfig: managementServiceConfig,
 		systemClient:            systemClient,
@@ -105,7 +112,7 @@ func (b *Bootstrap) Initialize(ctx context.Context) error {
 	// unset NOTIFY_SOCKET on successful bootstrap to prevent subprocesses from
 	// using it.
 	// ref: https://bugzilla.redhat.com/show_bug.cgi?id=1781506
-	os.Unsetenv("NOTIFY_SOCKET")
+	goos.Unsetenv("NOTIFY_SOCKET")
 
 	b.log.Info(BootstrapComplete)
 	return nil
@@ -158,6 +165,15 @@ func (b *Bootstrap) updateStatus(ctx context.Context) {
 		updatingCondition.Reason = string(v1alpha1.UpdateStateUpdated)
 	}
 
+	if b.pendingOSUpdate != nil && b.pendingOSUpdate.Failed() {
+		// the device gave up on booting into the target image, report the failure until the
+		// device is given a different image to update to
+		updatingCondition.Status = v1alpha1.ConditionStatusFalse
+		updatingCondition.Reason = string(v1alpha1.UpdateStateError)
+		updatingCondition.Message = b.pendingOSUpdate.FailureMessage()
+		b.log.Error(updatingCondition.Message)
+	}
+
 	updateErr = b.statusManager.UpdateCondition(ctx, updatingCondition)
 	if updateErr != nil {
 		b.log.Warnf("Failed setting status: %v", updateErr)
@@ -188,6 +204,12 @@ func (b *Bootstrap) ensureBootstrap(ctx context.Context) error {
 		return err
 	}
 
+	pendingOSUpdate, err := b.osManager.RecoverUpdate(ctx)
+	if err != nil {
+		return fmt.Errorf("recovering OS update: %w", err)
+	}
+	b.pendingOSUpdate = pendingOSUpdate
+
 	if b.systemClient.IsRebooted() {
 		if err := b.hookManager.OnAfterRebooting(ctx); err != nil {
 			// TODO: rollback?
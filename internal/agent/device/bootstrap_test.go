@@ -10,6 +10,7 @@ import (
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
 	"github.com/flightctl/flightctl/internal/agent/device/hook"
 	"github.com/flightctl/flightctl/internal/agent/device/lifecycle"
+	"github.com/flightctl/flightctl/internal/agent/device/os"
 	"github.com/flightctl/flightctl/internal/agent/device/spec"
 	"github.com/flightctl/flightctl/internal/agent/device/status"
 	"github.com/flightctl/flightctl/internal/config"
@@ -31,6 +32,7 @@ func TestInitialization(t *testing.T) {
 			mockSpecManager *spec.MockManager,
 			mockReadWriter *fileio.MockReadWriter,
 			mockHookManager *hook.MockManager,
+			mockOSManager *os.MockManager,
 			mockEnrollmentClient *client.MockEnrollment,
 			mockSystemClient *client.MockSystem,
 			mockLifecycleInitializer *lifecycle.MockInitializer,
@@ -44,6 +46,7 @@ func TestInitialization(t *testing.T) {
 				mockSpecManager *spec.MockManager,
 				mockReadWriter *fileio.MockReadWriter,
 				mockHookManager *hook.MockManager,
+				mockOSManager *os.MockManager,
 				_ *client.MockEnrollment,
 				mockSystemClient *client.MockSystem,
 				mockLifecycleInitializer *lifecycle.MockInitializer,
@@ -58,6 +61,7 @@ func TestInitialization(t *testing.T) {
 					mockStatusManager.EXPECT().SetClient(gomock.Any()),
 					mockSpecManager.EXPECT().SetClient(gomock.Any()),
 					mockSpecManager.EXPECT().IsOSUpdate().Return(false),
+					mockOSManager.EXPECT().RecoverUpdate(gomock.Any()).Return(nil, nil),
 					mockSystemClient.EXPECT().IsRebooted().Return(false),
 					mockSpecManager.EXPECT().RenderedVersion(spec.Current).Return("1"),
 					mockStatusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil),
@@ -73,6 +77,7 @@ func TestInitialization(t *testing.T) {
 				mockSpecManager *spec.MockManager,
 				mockReadWriter *fileio.MockReadWriter,
 				mockHookManager *hook.MockManager,
+				mockOSManager *os.MockManager,
 				_ *client.MockEnrollment,
 				mockSystemClient *client.MockSystem,
 				mockLifecycleInitializer *lifecycle.MockInitializer,
@@ -89,6 +94,7 @@ func TestInitialization(t *testing.T) {
 					mockSpecManager.EXPECT().SetClient(gomock.Any()),
 					mockSpecManager.EXPECT().IsOSUpdate().Return(true),
 					mockSpecManager.EXPECT().CheckOsReconciliation(gomock.Any()).Return(bootedOSVersion, true, nil),
+					mockOSManager.EXPECT().RecoverUpdate(gomock.Any()).Return(nil, nil),
 					mockSystemClient.EXPECT().IsRebooted().Return(false),
 					mockSpecManager.EXPECT().RenderedVersion(spec.Current).Return("2"),
 					mockStatusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil),
@@ -97,6 +103,45 @@ func TestInitialization(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "initialization enrolled with failed OS upgrade",
+			setupMocks: func(
+				mockStatusManager *status.MockManager,
+				mockSpecManager *spec.MockManager,
+				mockReadWriter *fileio.MockReadWriter,
+				mockHookManager *hook.MockManager,
+				mockOSManager *os.MockManager,
+				_ *client.MockEnrollment,
+				mockSystemClient *client.MockSystem,
+				mockLifecycleInitializer *lifecycle.MockInitializer,
+			) {
+				failedUpdate := &os.UpdateState{Phase: os.UpdatePhaseFailed, TargetImage: "quay.io/org/os:2.0.0", Attempts: os.MaxUpdateAttempts}
+				gomock.InOrder(
+					mockLifecycleInitializer.EXPECT().IsInitialized().Return(true),
+					mockSpecManager.EXPECT().Ensure().Return(nil),
+					mockStatusManager.EXPECT().Collect(gomock.Any()).Return(nil),
+					mockStatusManager.EXPECT().Get(gomock.Any()).Return(&v1alpha1.DeviceStatus{}),
+					mockLifecycleInitializer.EXPECT().Initialize(gomock.Any(), gomock.Any()).Return(nil),
+					mockReadWriter.EXPECT().PathExists(gomock.Any()).Return(true, nil),
+					mockStatusManager.EXPECT().SetClient(gomock.Any()),
+					mockSpecManager.EXPECT().SetClient(gomock.Any()),
+					mockSpecManager.EXPECT().IsOSUpdate().Return(false),
+					mockOSManager.EXPECT().RecoverUpdate(gomock.Any()).Return(failedUpdate, nil),
+					mockSystemClient.EXPECT().IsRebooted().Return(true),
+					mockHookManager.EXPECT().OnAfterRebooting(gomock.Any()).Return(nil),
+					mockSpecManager.EXPECT().RenderedVersion(spec.Current).Return("1"),
+					mockStatusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil),
+					mockSpecManager.EXPECT().IsUpgrading().Return(false),
+					mockStatusManager.EXPECT().UpdateCondition(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, condition v1alpha1.Condition) error {
+							require.Equal(v1alpha1.ConditionStatusFalse, condition.Status)
+							require.Equal(string(v1alpha1.UpdateStateError), condition.Reason)
+							require.Contains(condition.Message, "after 3 attempts")
+							return nil
+						}),
+				)
+			},
+		},
 		{
 			name: "initialization not enrolled",
 			setupMocks: func(
@@ -104,6 +149,7 @@ func TestInitialization(t *testing.T) {
 				mockSpecManager *spec.MockManager,
 				mockReadWriter *fileio.MockReadWriter,
 				mockHookManager *hook.MockManager,
+				mockOSManager *os.MockManager,
 				mockEnrollmentClient *client.MockEnrollment,
 				mockSystemClient *client.MockSystem,
 				mockLifecycleInitializer *lifecycle.MockInitializer,
@@ -119,6 +165,7 @@ func TestInitialization(t *testing.T) {
 					mockStatusManager.EXPECT().SetClient(gomock.Any()),
 					mockSpecManager.EXPECT().SetClient(gomock.Any()),
 					mockSpecManager.EXPECT().IsOSUpdate().Return(false),
+					mockOSManager.EXPECT().RecoverUpdate(gomock.Any()).Return(nil, nil),
 					mockSystemClient.EXPECT().IsRebooted().Return(false),
 					mockSpecManager.EXPECT().RenderedVersion(spec.Current).Return("2"),
 					mockStatusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil),
@@ -138,6 +185,7 @@ func TestInitialization(t *testing.T) {
 			mockSpecManager := spec.NewMockManager(ctrl)
 			mockReadWriter := fileio.NewMockReadWriter(ctrl)
 			mockHookManager := hook.NewMockManager(ctrl)
+			mockOSManager := os.NewMockManager(ctrl)
 			mockEnrollmentClient := client.NewMockEnrollment(ctrl)
 			mockSystemClient := client.NewMockSystem(ctrl)
 			mockLifecycleInitializer := lifecycle.NewMockInitializer(ctrl)
@@ -146,6 +194,7 @@ func TestInitialization(t *testing.T) {
 				statusManager:           mockStatusManager,
 				specManager:             mockSpecManager,
 				hookManager:             mockHookManager,
+				osManager:               mockOSManager,
 				lifecycle:               mockLifecycleInitializer,
 				deviceReadWriter:        mockReadWriter,
 				managementServiceConfig: &client.Config{},
@@ -160,6 +209,7 @@ func TestInitialization(t *testing.T) {
 				mockSpecManager,
 				mockReadWriter,
 				mockHookManager,
+				mockOSManager,
 				mockEnrollmentClient,
 				mockSystemClient,
 				mockLifecycleInitializer,
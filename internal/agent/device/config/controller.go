@@ -18,16 +18,22 @@ import (
 // against the device spec.
 type Controller struct {
 	deviceWriter fileio.Writer
+	facts        *DeviceFacts
+	secrets      *SecretStore
 	log          *log.PrefixLogger
 }
 
 // NewController creates a new config controller.
 func NewController(
 	deviceWriter fileio.Writer,
+	facts *DeviceFacts,
+	secrets *SecretStore,
 	log *log.PrefixLogger,
 ) *Controller {
 	return &Controller{
 		deviceWriter: deviceWriter,
+		facts:        facts,
+		secrets:      secrets,
 		log:          log,
 	}
 }
@@ -120,6 +126,10 @@ func (c *Controller) removeObsoleteFiles(ctx context.Context, currentFiles, desi
 
 func (c *Controller) writeIgnitionFiles(ctx context.Context, files []ignv3types.File) error {
 	for _, file := range files {
+		file, err := renderFileTemplate(file, c.facts, c.secrets)
+		if err != nil {
+			return err
+		}
 		managedFile, err := c.deviceWriter.CreateManagedFile(file)
 		if err != nil {
 			return err
@@ -2,7 +2,11 @@ package config
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/coreos/ignition/v2/config/shared/errors"
 	ignv3types "github.com/coreos/ignition/v2/config/v3_4/types"
@@ -90,6 +94,8 @@ func TestSync(t *testing.T) {
 			mockManagedFile := fileio.NewMockManagedFile(ctrl)
 			controller := NewController(
 				mockWriter,
+				NewDeviceFacts("device", nil),
+				nil,
 				log.NewPrefixLogger("test"),
 			)
 
@@ -110,6 +116,51 @@ func TestSync(t *testing.T) {
 	}
 }
 
+func TestSyncLeavesUnchangedFilesUntouched(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	controller := NewController(
+		fileio.NewReadWriter(fileio.WithTestRootDir(tmpDir)),
+		NewDeviceFacts("device", nil),
+		nil,
+		log.NewPrefixLogger("test"),
+	)
+
+	current := &v1alpha1.RenderedDeviceSpec{Config: util.StrToPtr(ownedIgnitionConfig("File%201%20contents", "File%202%20contents"))}
+	require.NoError(controller.Sync(ctx, &v1alpha1.RenderedDeviceSpec{}, current))
+
+	// backdate the files so that rewriting them shows in their modification time
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	file1 := filepath.Join(tmpDir, "/etc/example/file1.txt")
+	file2 := filepath.Join(tmpDir, "/etc/example/file2.txt")
+	require.NoError(os.Chtimes(file1, past, past))
+	require.NoError(os.Chtimes(file2, past, past))
+
+	desired := &v1alpha1.RenderedDeviceSpec{Config: util.StrToPtr(ownedIgnitionConfig("File%201%20contents", "File%202%20changed"))}
+	require.NoError(controller.Sync(ctx, current, desired))
+
+	info, err := os.Stat(file1)
+	require.NoError(err)
+	require.True(info.ModTime().Equal(past), "unchanged file was rewritten")
+
+	info, err = os.Stat(file2)
+	require.NoError(err)
+	require.True(info.ModTime().After(past), "changed file was not rewritten")
+	contents, err := os.ReadFile(file2)
+	require.NoError(err)
+	require.Equal("File 2 changed", string(contents))
+}
+
+// ownedIgnitionConfig returns a config of two files with the given contents, owned by the user
+// running the test as the files the agent writes under a test root directory are.
+func ownedIgnitionConfig(contents1, contents2 string) string {
+	file := `{"path":"/etc/example/%s","contents":{"source":"data:,%s"},"mode":420,"user":{"id":%d},"group":{"id":%d}}`
+	return fmt.Sprintf(`{"ignition":{"version":"3.4.0"},"storage":{"files":[%s,%s]}}`,
+		fmt.Sprintf(file, "file1.txt", contents1, os.Getuid(), os.Getgid()),
+		fmt.Sprintf(file, "file2.txt", contents2, os.Getuid(), os.Getgid()))
+}
+
 func TestComputeRemoval(t *testing.T) {
 	require := require.New(t)
 	tests := []struct {
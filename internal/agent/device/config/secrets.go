@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+)
+
+// RedactedSecret replaces the values of secrets in the status sent to the management service.
+const RedactedSecret = "[redacted]"
+
+var secretNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// SecretStore resolves the secrets that file templates reference with "{{ secret "name" }}"
+// from the files of a directory mounted on the device, so that secrets are never part of the
+// device spec. It remembers the values it resolved to redact them from the device status.
+type SecretStore struct {
+	reader fileio.Reader
+	dir    string
+
+	mu     sync.Mutex
+	values map[string]string
+}
+
+// NewSecretStore creates a secret store reading the secret "name" from the file dir/name.
+func NewSecretStore(reader fileio.Reader, dir string) *SecretStore {
+	return &SecretStore{
+		reader: reader,
+		dir:    dir,
+		values: map[string]string{},
+	}
+}
+
+// Resolve returns the value of the secret, without its trailing newline.
+func (s *SecretStore) Resolve(name string) (string, error) {
+	if !secretNameRegexp.MatchString(name) {
+		return "", fmt.Errorf("%w: invalid secret name %q", errors.ErrNoRetry, name)
+	}
+	contents, err := s.reader.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("%w: %q in %s", errors.ErrSecretNotFound, name, s.dir)
+		}
+		return "", fmt.Errorf("reading secret %q: %w", name, err)
+	}
+	value := strings.TrimRight(string(contents), "\r\n")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[name] = value
+	return value, nil
+}
+
+// Redact replaces the values of the secrets resolved so far in the given string.
+func (s *SecretStore) Redact(str string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// replace the longest values first, in case a value contains another
+	values := make([]string, 0, len(s.values))
+	for _, value := range s.values {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, value := range values {
+		str = strings.ReplaceAll(str, value, RedactedSecret)
+	}
+	return str
+}
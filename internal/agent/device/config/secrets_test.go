@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/ignition"
+	"github.com/stretchr/testify/require"
+	"github.com/vincent-petithory/dataurl"
+)
+
+func TestSecretStore(t *testing.T) {
+	require := require.New(t)
+	tmpDir := t.TempDir()
+	require.NoError(os.MkdirAll(filepath.Join(tmpDir, "etc/flightctl/secrets"), 0o700))
+	require.NoError(os.WriteFile(filepath.Join(tmpDir, "etc/flightctl/secrets/registry-password"), []byte("hunter2\n"), 0o600))
+	require.NoError(os.WriteFile(filepath.Join(tmpDir, "etc/flightctl/secrets/app-token"), []byte("s3cr3t-hunter2"), 0o600))
+
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(tmpDir))
+	secrets := NewSecretStore(readWriter, "/etc/flightctl/secrets")
+	facts := &DeviceFacts{Name: "device-1", Labels: map[string]string{}}
+
+	// secrets are resolved at render time, without their trailing newline
+	rendered, err := RenderTemplate("/etc/auth.conf", []byte(`password={{ secret "registry-password" }}`), facts, secrets)
+	require.NoError(err)
+	require.Equal("password=hunter2", string(rendered))
+
+	// a missing secret is retried, as it may be provisioned later
+	_, err = RenderTemplate("/etc/auth.conf", []byte(`{{ secret "missing" }}`), facts, secrets)
+	require.ErrorIs(err, errors.ErrSecretNotFound)
+	require.ErrorContains(err, `"missing"`)
+	require.True(errors.IsRetryable(err))
+
+	wrapper, err := ignition.NewWrapper()
+	require.NoError(err)
+	wrapper.SetTemplateFile("/etc/missing.conf", []byte(`{{ secret "missing" }}`), 0o644, false, nil, nil)
+	wrapper.SetTemplateFile("/etc/app.conf", []byte(`token={{ secret "app-token" }}`), 0o644, false, nil, nil)
+	files := wrapper.AsIgnitionConfig().Storage.Files
+	_, err = renderFileTemplate(files[0], facts, secrets)
+	require.ErrorIs(err, errors.ErrSecretNotFound)
+	require.NotErrorIs(err, errors.ErrNoRetry)
+	file, err := renderFileTemplate(files[1], facts, secrets)
+	require.NoError(err)
+	source, err := dataurl.DecodeString(*file.Contents.Source)
+	require.NoError(err)
+	require.Equal("token=s3cr3t-hunter2", string(source.Data))
+
+	// names cannot escape the secrets directory
+	_, err = secrets.Resolve("../config.yaml")
+	require.ErrorIs(err, errors.ErrNoRetry)
+
+	// without a secret store, secrets cannot be resolved
+	_, err = RenderTemplate("/etc/auth.conf", []byte(`{{ secret "registry-password" }}`), facts, nil)
+	require.ErrorIs(err, errors.ErrSecretNotFound)
+
+	// resolved values are redacted, the longest first
+	require.Equal("failed to log in with [redacted] and [redacted]", secrets.Redact("failed to log in with hunter2 and s3cr3t-hunter2"))
+}
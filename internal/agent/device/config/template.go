@@ -0,0 +1,97 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"text/template"
+
+	ignv3types "github.com/coreos/ignition/v2/config/v3_4/types"
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/pkg/ignition"
+	"github.com/samber/lo"
+	"github.com/vincent-petithory/dataurl"
+)
+
+// DeviceFacts are the facts known to the device that file templates can reference, e.g.
+// "{{ .Device.Hostname }}" or "{{ .Device.Labels.site }}".
+type DeviceFacts struct {
+	// Name is the name of the device
+	Name string
+	// Hostname is the hostname of the device
+	Hostname string
+	// Architecture is the architecture of the device, e.g. amd64
+	Architecture string
+	// OperatingSystem is the operating system of the device, e.g. linux
+	OperatingSystem string
+	// Labels are the default labels of the device set in the agent's configuration
+	Labels map[string]string
+}
+
+// NewDeviceFacts collects the facts of the device.
+func NewDeviceFacts(name string, labels map[string]string) *DeviceFacts {
+	hostname, _ := os.Hostname()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return &DeviceFacts{
+		Name:            name,
+		Hostname:        hostname,
+		Architecture:    runtime.GOARCH,
+		OperatingSystem: runtime.GOOS,
+		Labels:          labels,
+	}
+}
+
+// RenderTemplate renders the template with the facts of the device and the secrets of its
+// secret store, e.g. "{{ secret "registry-password" }}". Referencing a fact that is not known to
+// the device or a secret that is not in the store is an error.
+func RenderTemplate(name string, content []byte, facts *DeviceFacts, secrets *SecretStore) ([]byte, error) {
+	funcs := template.FuncMap{
+		"secret": func(secretName string) (string, error) {
+			if secrets == nil {
+				return "", fmt.Errorf("%w: no secret store", errors.ErrSecretNotFound)
+			}
+			return secrets.Resolve(secretName)
+		},
+	}
+	t, err := template.New(name).Option("missingkey=error").Funcs(funcs).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, map[string]any{"Device": facts}); err != nil {
+		return nil, fmt.Errorf("rendering template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderFileTemplate returns the file with its content rendered if it is a template, or the file
+// unchanged otherwise.
+func renderFileTemplate(file ignv3types.File, facts *DeviceFacts, secrets *SecretStore) (ignv3types.File, error) {
+	if file.Contents.Source == nil {
+		return file, nil
+	}
+	source, err := dataurl.DecodeString(*file.Contents.Source)
+	if err != nil {
+		return file, fmt.Errorf("could not decode file content string: %w", err)
+	}
+	if source.MediaType.ContentType() != ignition.TemplateMediaType {
+		return file, nil
+	}
+	if lo.FromPtr(file.Contents.Compression) != "" {
+		return file, fmt.Errorf("%w: template %s must not be compressed", errors.ErrNoRetry, file.Path)
+	}
+
+	rendered, err := RenderTemplate(file.Path, source.Data, facts, secrets)
+	if err != nil {
+		if errors.Is(err, errors.ErrSecretNotFound) {
+			// retried, as the secret may be provisioned on the device later
+			return file, fmt.Errorf("file %s: %w", file.Path, err)
+		}
+		return file, fmt.Errorf("%w: file %s: %w", errors.ErrNoRetry, file.Path, err)
+	}
+	file.Contents.Source = lo.ToPtr(dataurl.New(rendered, "text/plain").String())
+	return file, nil
+}
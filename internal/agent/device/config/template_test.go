@@ -0,0 +1,97 @@
+package config
+
+import (
+	"testing"
+
+	ignv3types "github.com/coreos/ignition/v2/config/v3_4/types"
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/pkg/ignition"
+	"github.com/stretchr/testify/require"
+	"github.com/vincent-petithory/dataurl"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	facts := &DeviceFacts{
+		Name:            "device-1",
+		Hostname:        "edge-01",
+		Architecture:    "arm64",
+		OperatingSystem: "linux",
+		Labels:          map[string]string{"site": "berlin"},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "device facts",
+			content: "name={{ .Device.Name }}\nhost={{ .Device.Hostname }}\nsite={{ .Device.Labels.site }}\narch={{ .Device.Architecture }}\n",
+			want:    "name=device-1\nhost=edge-01\nsite=berlin\narch=arm64\n",
+		},
+		{
+			name:    "no placeholders",
+			content: "key=value\n",
+			want:    "key=value\n",
+		},
+		{
+			name:    "missing label",
+			content: "region={{ .Device.Labels.region }}\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown fact",
+			content: "{{ .Device.SerialNumber }}",
+			wantErr: true,
+		},
+		{
+			name:    "invalid syntax",
+			content: "{{ .Device.Name ",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := RenderTemplate("/etc/app.conf", []byte(tt.content), facts, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(rendered))
+		})
+	}
+}
+
+func TestRenderFileTemplate(t *testing.T) {
+	require := require.New(t)
+	facts := &DeviceFacts{Name: "device-1", Labels: map[string]string{"site": "berlin"}}
+
+	wrapper, err := ignition.NewWrapper()
+	require.NoError(err)
+	wrapper.SetFile("/etc/plain.conf", []byte("site={{ .Device.Labels.site }}"), 0o644, false, nil, nil)
+	wrapper.SetTemplateFile("/etc/template.conf", []byte("site={{ .Device.Labels.site }}"), 0o644, false, nil, nil)
+	wrapper.SetTemplateFile("/etc/missing.conf", []byte("region={{ .Device.Labels.region }}"), 0o644, false, nil, nil)
+	files := wrapper.AsIgnitionConfig().Storage.Files
+
+	contents := func(file ignv3types.File) string {
+		source, err := dataurl.DecodeString(*file.Contents.Source)
+		require.NoError(err)
+		return string(source.Data)
+	}
+
+	// files that are not templates are written as is
+	file, err := renderFileTemplate(files[0], facts, nil)
+	require.NoError(err)
+	require.Equal("site={{ .Device.Labels.site }}", contents(file))
+
+	file, err = renderFileTemplate(files[1], facts, nil)
+	require.NoError(err)
+	require.Equal("site=berlin", contents(file))
+	require.Equal("/etc/template.conf", file.Path)
+
+	_, err = renderFileTemplate(files[2], facts, nil)
+	require.ErrorContains(err, "/etc/missing.conf")
+	require.ErrorIs(err, errors.ErrNoRetry)
+}
@@ -13,14 +13,18 @@ import (
 	"github.com/flightctl/flightctl/internal/agent/device/console"
 	"github.com/flightctl/flightctl/internal/agent/device/errors"
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/health"
 	"github.com/flightctl/flightctl/internal/agent/device/hook"
 	"github.com/flightctl/flightctl/internal/agent/device/lifecycle"
+	"github.com/flightctl/flightctl/internal/agent/device/logshipper"
 	"github.com/flightctl/flightctl/internal/agent/device/os"
 	"github.com/flightctl/flightctl/internal/agent/device/policy"
 	"github.com/flightctl/flightctl/internal/agent/device/resource"
 	"github.com/flightctl/flightctl/internal/agent/device/spec"
 	"github.com/flightctl/flightctl/internal/agent/device/status"
 	"github.com/flightctl/flightctl/internal/agent/device/systemd"
+	"github.com/flightctl/flightctl/internal/agent/device/verification"
+	"github.com/flightctl/flightctl/internal/agent/device/watchdog"
 	"github.com/flightctl/flightctl/internal/container"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/flightctl/flightctl/pkg/log"
@@ -40,12 +44,22 @@ type Agent struct {
 	osManager              os.Manager
 	policyManager          policy.Manager
 	lifecycleManager       lifecycle.Manager
+	verificationManager    verification.Manager
 	applicationsController *applications.Controller
 	configController       *config.Controller
 	resourceController     *resource.Controller
+	diskGuard              *resource.DiskGuard
+	updateDeferral         *resource.UpdateDeferral
 	consoleController      *console.ConsoleController
 	bootcClient            container.BootcClient
 	podmanClient           *client.Podman
+	watchdog               *watchdog.Watchdog
+	logShipper             *logshipper.Shipper
+	health                 *health.Server
+	maintenanceWindow      *policy.MaintenanceWindow
+	updateSlot             *policy.UpdateSlot
+	applyHooks             *hook.ApplyHooks
+	failurePolicy          *policy.FailurePolicy
 
 	fetchSpecInterval   util.Duration
 	fetchStatusInterval util.Duration
@@ -70,12 +84,22 @@ func NewAgent(
 	osManager os.Manager,
 	policyManager policy.Manager,
 	lifecycleManager lifecycle.Manager,
+	verificationManager verification.Manager,
 	applicationsController *applications.Controller,
 	configController *config.Controller,
 	resourceController *resource.Controller,
+	diskGuard *resource.DiskGuard,
+	updateDeferral *resource.UpdateDeferral,
 	consoleController *console.ConsoleController,
 	bootcClient container.BootcClient,
 	podmanClient *client.Podman,
+	watchdog *watchdog.Watchdog,
+	logShipper *logshipper.Shipper,
+	health *health.Server,
+	maintenanceWindow *policy.MaintenanceWindow,
+	updateSlot *policy.UpdateSlot,
+	applyHooks *hook.ApplyHooks,
+	failurePolicy *policy.FailurePolicy,
 	backoff wait.Backoff,
 	log *log.PrefixLogger,
 ) *Agent {
@@ -88,6 +112,7 @@ func NewAgent(
 		osManager:              osManager,
 		policyManager:          policyManager,
 		lifecycleManager:       lifecycleManager,
+		verificationManager:    verificationManager,
 		appManager:             appManager,
 		systemdManager:         systemdManager,
 		fetchSpecInterval:      fetchSpecInterval,
@@ -95,9 +120,18 @@ func NewAgent(
 		applicationsController: applicationsController,
 		configController:       configController,
 		resourceController:     resourceController,
+		diskGuard:              diskGuard,
+		updateDeferral:         updateDeferral,
 		consoleController:      consoleController,
 		bootcClient:            bootcClient,
 		podmanClient:           podmanClient,
+		watchdog:               watchdog,
+		logShipper:             logShipper,
+		health:                 health,
+		maintenanceWindow:      maintenanceWindow,
+		updateSlot:             updateSlot,
+		applyHooks:             applyHooks,
+		failurePolicy:          failurePolicy,
 		cancelFn:               func() {},
 		backoff:                backoff,
 		log:                    log,
@@ -114,6 +148,8 @@ func (a *Agent) Run(ctx context.Context) error {
 	defer statusTicker.Stop()
 
 	for {
+		// the watchdog is only pinged while the loop makes progress
+		a.watchdog.Heartbeat()
 		select {
 		case <-ctx.Done():
 			return nil
@@ -165,10 +201,21 @@ func (a *Agent) sync(ctx context.Context, current, desired *v1alpha1.RenderedDev
 		return fmt.Errorf("before update: %w", err)
 	}
 
+	if err := a.checkMaintenanceWindow(ctx, desired); err != nil {
+		return err
+	}
+
 	if err := a.specManager.CheckPolicy(ctx, policy.Update, desired.RenderedVersion); err != nil {
 		return fmt.Errorf("update policy: %w", err)
 	}
 
+	// the slot is acquired last, so that it is not held while the update waits for anything else
+	if a.specManager.IsUpgrading() {
+		if err := a.updateSlot.Acquire(ctx); err != nil {
+			return err
+		}
+	}
+
 	// the agent has validated the desired spec, downloaded all dependencies,
 	// and is ready to update. No changes have been made to the device's
 	// configuration yet.
@@ -184,6 +231,12 @@ func (a *Agent) sync(ctx context.Context, current, desired *v1alpha1.RenderedDev
 		}
 	}
 
+	if a.specManager.IsUpgrading() {
+		if err := a.applyHooks.PreApply(ctx, current, desired); err != nil {
+			return err
+		}
+	}
+
 	if err := a.syncDevice(ctx, current, desired); err != nil {
 		// TODO: enable rollback on failure
 		return fmt.Errorf("sync device: %w", err)
@@ -196,6 +249,36 @@ func (a *Agent) sync(ctx context.Context, current, desired *v1alpha1.RenderedDev
 	return nil
 }
 
+// checkMaintenanceWindow defers the update of the device until the maintenance window opens, if
+// one is configured, and reports the wait with the WaitingForMaintenanceWindow condition.
+func (a *Agent) checkMaintenanceWindow(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
+	if a.maintenanceWindow == nil || !a.specManager.IsUpgrading() {
+		return nil
+	}
+
+	condition := v1alpha1.Condition{
+		Type:    v1alpha1.DeviceWaitingForMaintenanceWindow,
+		Status:  v1alpha1.ConditionStatusFalse,
+		Reason:  "MaintenanceWindowOpen",
+		Message: "The maintenance window is open",
+	}
+	open := a.maintenanceWindow.IsOpen()
+	if !open {
+		nextOpen := a.maintenanceWindow.NextOpen()
+		condition.Status = v1alpha1.ConditionStatusTrue
+		condition.Reason = "MaintenanceWindowClosed"
+		condition.Message = fmt.Sprintf("The update to renderedVersion: %s is deferred until the maintenance window opens at %s",
+			desired.RenderedVersion, nextOpen.Format(time.RFC3339))
+	}
+	if err := a.statusManager.UpdateCondition(ctx, condition); err != nil {
+		a.log.Warnf("Failed setting status: %v", err)
+	}
+	if !open {
+		return fmt.Errorf("%w: %s", errors.ErrOutsideMaintenanceWindow, condition.Message)
+	}
+	return nil
+}
+
 func (a *Agent) syncSpec(ctx context.Context, syncFn func(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error) {
 	startTime := time.Now()
 	a.log.Debug("Starting sync of device spec")
@@ -214,6 +297,11 @@ func (a *Agent) syncSpec(ctx context.Context, syncFn func(ctx context.Context, d
 		return
 	}
 
+	if wait, ok := a.failurePolicy.BackingOff(desired.RenderedVersion); ok {
+		a.log.Debugf("Retrying renderedVersion: %s in %v after its last failure", desired.RenderedVersion, wait)
+		return
+	}
+
 	if err := syncFn(ctx, desired); err != nil {
 		// if context is canceled return to exit the sync loop
 		if errors.Is(err, context.Canceled) {
@@ -222,6 +310,8 @@ func (a *Agent) syncSpec(ctx context.Context, syncFn func(ctx context.Context, d
 		a.handleSyncError(ctx, desired, err)
 		return
 	}
+	a.health.SyncSucceeded()
+	a.failurePolicy.Succeeded()
 
 	_, updateErr := a.statusManager.Update(ctx, status.SetDeviceSummary(v1alpha1.DeviceSummaryStatus{
 		Status: v1alpha1.DeviceSummaryStatusOnline,
@@ -251,9 +341,25 @@ func (a *Agent) syncSpecFn(ctx context.Context, desired *v1alpha1.RenderedDevice
 		a.log.Warnf("Failed updating status: %v", err)
 	}
 
+	a.releaseUpdateSlot(ctx)
+
 	return nil
 }
 
+// releaseUpdateSlot releases the update slot of the device once the update completed. The slot is
+// held across the reboot into a new OS image.
+func (a *Agent) releaseUpdateSlot(ctx context.Context) {
+	if a.updateSlot == nil {
+		return
+	}
+	if _, isOSReconciled, err := a.specManager.CheckOsReconciliation(ctx); err != nil || !isOSReconciled {
+		return
+	}
+	if err := a.updateSlot.Release(ctx); err != nil {
+		a.log.Warnf("Failed releasing update slot: %v", err)
+	}
+}
+
 func (a *Agent) updatedStatus(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
 	updateErr := a.statusManager.UpdateCondition(ctx, v1alpha1.Condition{
 		Type:    v1alpha1.DeviceUpdating,
@@ -299,8 +405,9 @@ func (a *Agent) pushStatus(ctx context.Context) {
 		a.log.Debugf("Completed pushing device status in: %v", duration)
 	}()
 
-	if err := a.statusManager.Sync(ctx); err != nil {
-		msg := err.Error()
+	syncErr := a.statusManager.Sync(ctx)
+	if syncErr != nil {
+		msg := syncErr.Error()
 		_, updateErr := a.statusManager.Update(ctx, status.SetDeviceSummary(v1alpha1.DeviceSummaryStatus{
 			Status: v1alpha1.DeviceSummaryStatusDegraded,
 			Info:   &msg,
@@ -308,11 +415,33 @@ func (a *Agent) pushStatus(ctx context.Context) {
 		if updateErr != nil {
 			a.log.Errorf("Updating device status: %v", updateErr)
 		}
-		a.log.Errorf("Syncing status: %v", err)
+		a.log.Errorf("Syncing status: %v", syncErr)
+	}
+
+	// pushing the status is the check in with the management service an OS update must complete
+	// before it is committed
+	if err := a.osManager.VerifyUpdate(ctx, syncErr == nil); err != nil {
+		a.log.Errorf("Verifying OS update: %v", err)
 	}
 }
 
 func (a *Agent) beforeUpdate(ctx context.Context, current, desired *v1alpha1.RenderedDeviceSpec) error {
+	// refuse to pull anything for an update while the device is low on disk space
+	if a.specManager.IsUpgrading() {
+		if err := a.diskGuard.Check(); err != nil {
+			return fmt.Errorf("disk: %w", err)
+		}
+		// keep from draining the battery or a metered data plan of mobile devices
+		if err := a.updateDeferral.Check(ctx, desired.RenderedVersion); err != nil {
+			return fmt.Errorf("deferral: %w", err)
+		}
+	}
+
+	// verify image signatures before anything from the desired spec is pulled or applied
+	if err := a.verificationManager.BeforeUpdate(ctx, current, desired); err != nil {
+		return fmt.Errorf("verification: %w", err)
+	}
+
 	if a.specManager.IsOSUpdate() {
 		if err := a.osManager.BeforeUpdate(ctx, current, desired); err != nil {
 			return fmt.Errorf("os: %w", err)
@@ -473,9 +602,25 @@ func (a *Agent) afterUpdate(ctx context.Context, current, desired *v1alpha1.Rend
 		return err
 	}
 
+	// execute the post-apply hooks of the agent config, which verify the update
+	if a.specManager.IsUpgrading() {
+		return a.applyHooks.PostApply(ctx, current, desired, func(ctx context.Context) error {
+			return a.rollbackUpdate(ctx, current, desired)
+		})
+	}
+
 	return nil
 }
 
+// rollbackUpdate re-applies the current spec over the desired spec. The OS image is not rolled
+// back, which is left to greenboot.
+func (a *Agent) rollbackUpdate(ctx context.Context, current, desired *v1alpha1.RenderedDeviceSpec) error {
+	if err := a.syncDevice(ctx, desired, current); err != nil {
+		return err
+	}
+	return a.appManager.AfterUpdate(ctx)
+}
+
 func (a *Agent) afterUpdateOS(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
 	if desired.Os == nil {
 		a.log.Debug("No OS image to update")
@@ -522,14 +667,56 @@ func (a *Agent) afterUpdateOS(ctx context.Context, desired *v1alpha1.RenderedDev
 	return a.osManager.Reboot(ctx, desired)
 }
 
+// rollbackQuarantined re-applies the current spec over the quarantined desired spec, so that the
+// device does not stay partially updated. Failing to do so leaves the device as it is.
+func (a *Agent) rollbackQuarantined(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) {
+	if !a.specManager.IsUpgrading() {
+		return
+	}
+	current, err := a.specManager.Read(spec.Current)
+	if err != nil {
+		a.log.Errorf("Failed reading the current spec to roll back to: %v", err)
+		return
+	}
+	a.log.Warnf("Rolling back from quarantined renderedVersion: %s to renderedVersion: %s", desired.RenderedVersion, current.RenderedVersion)
+	if err := a.rollbackUpdate(ctx, current, desired); err != nil {
+		a.log.Errorf("Failed rolling back to renderedVersion: %s: %v", current.RenderedVersion, err)
+	}
+}
+
 func (a *Agent) handleSyncError(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec, syncErr error) {
+	if errors.Is(syncErr, errors.ErrOutsideMaintenanceWindow) {
+		// waiting for the maintenance window is not a failure, the condition reports the wait
+		a.log.Info(syncErr)
+		return
+	}
+	if errors.Is(syncErr, errors.ErrWaitingForUpdateSlot) {
+		// waiting for an update slot is not a failure either
+		a.log.Info(syncErr)
+		updateErr := a.statusManager.UpdateCondition(ctx, v1alpha1.Condition{
+			Type:    v1alpha1.DeviceUpdating,
+			Status:  v1alpha1.ConditionStatusTrue,
+			Reason:  string(v1alpha1.UpdateStateReadyToUpdate),
+			Message: fmt.Sprintf("The update to renderedVersion: %s is deferred: %v", desired.RenderedVersion, syncErr),
+		})
+		if updateErr != nil {
+			a.log.Warnf("Failed setting status: %v", updateErr)
+		}
+		return
+	}
+
 	version := desired.RenderedVersion
 	statusUpdate := v1alpha1.DeviceSummaryStatus{}
 	conditionUpdate := v1alpha1.Condition{
 		Type: v1alpha1.DeviceUpdating,
 	}
 
-	if !errors.IsRetryable(syncErr) {
+	quarantined := false
+	if errors.IsRetryable(syncErr) && isApplyFailure(syncErr) {
+		quarantined = a.failurePolicy.Failed(version)
+	}
+
+	if !errors.IsRetryable(syncErr) || quarantined {
 		a.log.Errorf("Marking template version %v as failed: %v", version, syncErr)
 
 		statusUpdate.Status = v1alpha1.DeviceSummaryStatusError
@@ -537,9 +724,26 @@ func (a *Agent) handleSyncError(ctx context.Context, desired *v1alpha1.RenderedD
 
 		conditionUpdate.Reason = string(v1alpha1.UpdateStateError)
 		conditionUpdate.Message = fmt.Sprintf("Failed to update to renderedVersion: %s", version)
+		if errors.Is(syncErr, errors.ErrImageSignatureVerification) || errors.Is(syncErr, errors.ErrOSUpdateFailed) ||
+			errors.Is(syncErr, errors.ErrPostApplyHookFailed) {
+			conditionUpdate.Message = fmt.Sprintf("Failed to update to renderedVersion: %s: %v", version, syncErr)
+		}
+		if quarantined {
+			statusUpdate.Info = util.StrToPtr(fmt.Sprintf("Quarantined version %v after %d consecutive failures: %v",
+				version, a.failurePolicy.Failures(), syncErr))
+			conditionUpdate.Message = fmt.Sprintf("Quarantined renderedVersion: %s after %d consecutive failures, waiting for a new spec: %v",
+				version, a.failurePolicy.Failures(), syncErr)
+		}
 		conditionUpdate.Status = v1alpha1.ConditionStatusFalse
 
+		if quarantined && a.failurePolicy.Rollback() {
+			a.rollbackQuarantined(ctx, desired)
+		}
 		a.specManager.SetUpgradeFailed()
+		// the failed update no longer needs its slot
+		if err := a.updateSlot.Release(ctx); err != nil {
+			a.log.Warnf("Failed releasing update slot: %v", err)
+		}
 		a.log.Error(util.FromPtr(statusUpdate.Info))
 	} else {
 		statusUpdate.Status = v1alpha1.DeviceSummaryStatusDegraded
@@ -547,10 +751,20 @@ func (a *Agent) handleSyncError(ctx context.Context, desired *v1alpha1.RenderedD
 
 		conditionUpdate.Reason = string(v1alpha1.UpdateStateApplyingUpdate)
 		conditionUpdate.Message = fmt.Sprintf("Failed to update to renderedVersion: %s. Retrying", version)
+		if errors.Is(syncErr, errors.ErrInsufficientDiskSpace) {
+			conditionUpdate.Message = fmt.Sprintf("Waiting for free disk space to update to renderedVersion: %s: %v", version, syncErr)
+		}
+		if errors.Is(syncErr, errors.ErrUpdateDeferred) {
+			conditionUpdate.Message = fmt.Sprintf("Deferred update to renderedVersion: %s: %v", version, syncErr)
+		}
 		conditionUpdate.Status = v1alpha1.ConditionStatusTrue
 		a.log.Warn(util.FromPtr(statusUpdate.Info))
 	}
 
+	// ship the logs leading to the error, for troubleshooting from the service
+	a.logShipper.TriggerOnError()
+	a.health.SyncFailed(syncErr)
+
 	if _, err := a.statusManager.Update(ctx, status.SetDeviceSummary(statusUpdate)); err != nil {
 		a.log.Errorf("Failed to update device status: %v", err)
 	}
@@ -559,3 +773,23 @@ func (a *Agent) handleSyncError(ctx context.Context, desired *v1alpha1.RenderedD
 		a.log.Warnf("Failed to update device status condition: %v", err)
 	}
 }
+
+// isApplyFailure returns whether the retryable error is a failure of the device to apply the spec,
+// which counts towards the quarantine of the spec, rather than a wait for the policies, the
+// resources or the conditions of the device, or an outage of the network.
+func isApplyFailure(err error) bool {
+	switch {
+	case errors.Is(err, errors.ErrUpdateDeferred),
+		errors.Is(err, errors.ErrDownloadPolicyNotReady),
+		errors.Is(err, errors.ErrUpdatePolicyNotReady),
+		errors.Is(err, errors.ErrOutsideMaintenanceWindow),
+		errors.Is(err, errors.ErrWaitingForUpdateSlot),
+		errors.Is(err, errors.ErrInsufficientDiskSpace),
+		errors.Is(err, errors.ErrSecretNotFound),
+		errors.Is(err, errors.ErrNetwork),
+		errors.IsTimeoutError(err):
+		return false
+	default:
+		return true
+	}
+}
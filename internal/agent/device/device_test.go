@@ -0,0 +1,90 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/agent/device/policy"
+	"github.com/flightctl/flightctl/internal/agent/device/spec"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandleSyncErrorQuarantine(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatusManager := status.NewMockManager(ctrl)
+	mockSpecManager := spec.NewMockManager(ctrl)
+	a := &Agent{
+		statusManager: mockStatusManager,
+		specManager:   mockSpecManager,
+		failurePolicy: policy.NewFailurePolicy(policy.FailurePolicyConfig{MaxConsecutiveFailures: 3}),
+		log:           log.NewPrefixLogger("test"),
+	}
+	ctx := context.Background()
+	desired := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "2"}
+	syncErr := fmt.Errorf("%w: starting application: exit status 1", errors.ErrRetryable)
+
+	var conditions []v1alpha1.Condition
+	mockStatusManager.EXPECT().Update(gomock.Any(), gomock.Any()).AnyTimes()
+	mockStatusManager.EXPECT().UpdateCondition(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, condition v1alpha1.Condition) error {
+			conditions = append(conditions, condition)
+			return nil
+		}).AnyTimes()
+
+	// waiting for the device's conditions does not count towards the quarantine
+	a.handleSyncError(ctx, desired, fmt.Errorf("%w: battery low", errors.ErrUpdateDeferred))
+	a.handleSyncError(ctx, desired, fmt.Errorf("%w: connection refused", errors.ErrNetwork))
+	a.handleSyncError(ctx, desired, fmt.Errorf("%w: 3%% free on /var, 10%% required", errors.ErrInsufficientDiskSpace))
+	a.handleSyncError(ctx, desired, fmt.Errorf("%w: acquiring update slot: service unavailable", errors.ErrWaitingForUpdateSlot))
+	require.Equal(string(v1alpha1.UpdateStateReadyToUpdate), conditions[len(conditions)-1].Reason)
+	conditions = nil
+
+	// the spec is retried until the last allowed failure
+	a.handleSyncError(ctx, desired, syncErr)
+	a.handleSyncError(ctx, desired, syncErr)
+	for _, condition := range conditions {
+		require.Equal(v1alpha1.ConditionStatusTrue, condition.Status)
+		require.Equal(string(v1alpha1.UpdateStateApplyingUpdate), condition.Reason)
+	}
+
+	// which quarantines it until a new spec is received
+	mockSpecManager.EXPECT().SetUpgradeFailed().Times(1)
+	a.handleSyncError(ctx, desired, syncErr)
+	last := conditions[len(conditions)-1]
+	require.Equal(v1alpha1.ConditionStatusFalse, last.Status)
+	require.Equal(string(v1alpha1.UpdateStateError), last.Reason)
+	require.True(strings.HasPrefix(last.Message, "Quarantined renderedVersion: 2 after 3 consecutive failures"), last.Message)
+}
+
+func TestHandleSyncErrorRetriesForever(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatusManager := status.NewMockManager(ctrl)
+	mockSpecManager := spec.NewMockManager(ctrl)
+	a := &Agent{
+		statusManager: mockStatusManager,
+		specManager:   mockSpecManager,
+		failurePolicy: policy.NewFailurePolicy(policy.NewDefaultFailurePolicyConfig()),
+		log:           log.NewPrefixLogger("test"),
+	}
+	mockStatusManager.EXPECT().Update(gomock.Any(), gomock.Any()).AnyTimes()
+	mockStatusManager.EXPECT().UpdateCondition(gomock.Any(), gomock.Any()).AnyTimes()
+	// without a failure policy the spec is never marked failed
+	mockSpecManager.EXPECT().SetUpgradeFailed().Times(0)
+
+	desired := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "2"}
+	for i := 0; i < 10; i++ {
+		a.handleSyncError(context.Background(), desired, fmt.Errorf("%w: starting application", errors.ErrRetryable))
+	}
+}
@@ -24,8 +24,12 @@ var (
 	ErrUnsupportedAppType     = errors.New("unsupported application type")
 	ErrParseAppType           = errors.New("failed to parse application type")
 	ErrAppDependency          = errors.New("failed to resolve application dependency")
+	ErrAppDependencyOrder     = errors.New("failed to order applications by dependencies")
 	ErrUnsupportedAppProvider = errors.New("unsupported application provider")
 
+	// container runtime
+	ErrUnsupportedRuntimeOperation = errors.New("operation not supported by the container runtime")
+
 	// spec
 	ErrMissingRenderedSpec  = errors.New("missing rendered spec")
 	ErrReadingRenderedSpec  = errors.New("reading rendered spec")
@@ -59,12 +63,33 @@ var (
 	ErrNotFound    = errors.New("not found")
 
 	// images
-	ErrImageNotFound = errors.New("image not found")
+	ErrImageNotFound              = errors.New("image not found")
+	ErrImageSignatureVerification = errors.New("image signature verification failed")
+
+	// os
+	ErrOSUpdateFailed = errors.New("os update failed")
+
+	// resources
+	ErrInsufficientDiskSpace = errors.New("insufficient free disk space")
+	ErrUpdateDeferred        = errors.New("update deferred")
+
+	// secrets
+	ErrSecretNotFound = errors.New("secret not found")
 
 	// policy
 	ErrDownloadPolicyNotReady = errors.New("download policy not ready")
 	ErrUpdatePolicyNotReady   = errors.New("update policy not ready")
 	ErrInvalidPolicyType      = errors.New("invalid policy type")
+
+	// apply hooks
+	ErrPreApplyHookFailed  = errors.New("pre-apply hook failed")
+	ErrPostApplyHookFailed = errors.New("post-apply hook failed")
+
+	// maintenance window
+	ErrOutsideMaintenanceWindow = errors.New("outside of the maintenance window")
+
+	// update slots
+	ErrWaitingForUpdateSlot = errors.New("waiting for an update slot")
 )
 
 // TODO: tighten up the retryable errors ideally all retryable errors should be explicitly defined
@@ -78,6 +103,24 @@ func IsRetryable(err error) bool {
 		return true
 	case errors.Is(err, ErrDownloadPolicyNotReady), errors.Is(err, ErrUpdatePolicyNotReady):
 		return true
+	case errors.Is(err, ErrInsufficientDiskSpace):
+		// the update is retried once disk space was freed
+		return true
+	case errors.Is(err, ErrUpdateDeferred):
+		// the update is retried once the battery charged or the connection is no longer metered
+		return true
+	case errors.Is(err, ErrSecretNotFound):
+		// the update is retried once the secret was provisioned on the device
+		return true
+	case errors.Is(err, ErrPreApplyHookFailed):
+		// nothing was applied yet, the update is retried on the next sync
+		return true
+	case errors.Is(err, ErrOutsideMaintenanceWindow):
+		// the update is applied once the maintenance window opens
+		return true
+	case errors.Is(err, ErrWaitingForUpdateSlot):
+		// the update is applied once another device of the group released its slot
+		return true
 	case errors.Is(err, ErrNoContent):
 		// no content is a retryable error it means the server does not have a
 		// new template version
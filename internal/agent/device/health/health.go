@@ -0,0 +1,209 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/log"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// DefaultPort is the default localhost port of the health endpoints.
+	DefaultPort = 7445
+
+	LivenessPath  = "/livez"
+	ReadinessPath = "/readyz"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// Config configures the health endpoints the agent serves on localhost, for local supervisors
+// and monitoring to probe the agent without the management service.
+type Config struct {
+	// Enabled turns on the health endpoints
+	Enabled bool `json:"enabled,omitempty"`
+	// Port is the localhost port the health endpoints are served on
+	Port int `json:"port,omitempty"`
+}
+
+// NewDefaultConfig returns the default health endpoints config, which is disabled.
+func NewDefaultConfig() Config {
+	return Config{
+		Port: DefaultPort,
+	}
+}
+
+// Validate checks that the port is valid.
+func (c *Config) Validate() error {
+	if c.Port < 0 || c.Port > 65535 {
+		return fmt.Errorf("health port must be between 0 and 65535")
+	}
+	return nil
+}
+
+// Report is the JSON body of the responses of the health endpoints.
+type Report struct {
+	// Ready is true once the device is enrolled and has applied a spec
+	Ready bool `json:"ready"`
+	// Enrolled is true once the device is enrolled with the management service
+	Enrolled bool `json:"enrolled"`
+	// LastSuccessfulSync is when the agent last applied a spec
+	LastSuccessfulSync *time.Time `json:"lastSuccessfulSync,omitempty"`
+	// LastSyncError is the error of the last sync, if it failed
+	LastSyncError string `json:"lastSyncError,omitempty"`
+	// Conditions are the current conditions of the device
+	Conditions []v1alpha1.Condition `json:"conditions"`
+}
+
+// Server serves the liveness and readiness of the agent on localhost. The agent reports its
+// progress through SetEnrolled, SyncSucceeded and SyncFailed, which are no-ops on a nil server.
+type Server struct {
+	log    *log.PrefixLogger
+	addr   string
+	status status.Collector
+	clock  clock.Clock
+
+	mu                 sync.Mutex
+	enrolled           bool
+	lastSuccessfulSync time.Time
+	lastSyncError      string
+}
+
+type Option func(*Server)
+
+// WithClock sets the clock used by the server.
+func WithClock(clock clock.Clock) Option {
+	return func(s *Server) {
+		s.clock = clock
+	}
+}
+
+// New creates a health server reporting the conditions of the given status collector.
+func New(log *log.PrefixLogger, cfg Config, status status.Collector, opts ...Option) *Server {
+	port := cfg.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+	s := &Server{
+		log:    log,
+		addr:   net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
+		status: status,
+		clock:  clock.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// SetEnrolled reports that the device is enrolled.
+func (s *Server) SetEnrolled() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enrolled = true
+}
+
+// SyncSucceeded reports that the agent applied a spec.
+func (s *Server) SyncSucceeded() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSuccessfulSync = s.clock.Now()
+	s.lastSyncError = ""
+}
+
+// SyncFailed reports that the agent failed to apply a spec.
+func (s *Server) SyncFailed(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSyncError = err.Error()
+}
+
+// Run serves the health endpoints until the context is canceled.
+func (s *Server) Run(ctx context.Context) {
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: shutdownTimeout,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	s.log.Infof("Serving health endpoints on %s", s.addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.log.Errorf("Failed to serve health endpoints: %v", err)
+	}
+}
+
+// Handler returns the handler of the health endpoints. The liveness endpoint succeeds as long as
+// the agent responds, the readiness endpoint once the device is enrolled and has applied a spec.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(LivenessPath, func(w http.ResponseWriter, r *http.Request) {
+		s.respond(w, r, http.StatusOK)
+	})
+	mux.HandleFunc(ReadinessPath, func(w http.ResponseWriter, r *http.Request) {
+		code := http.StatusOK
+		if !s.report(r.Context()).Ready {
+			code = http.StatusServiceUnavailable
+		}
+		s.respond(w, r, code)
+	})
+	return mux
+}
+
+func (s *Server) respond(w http.ResponseWriter, r *http.Request, code int) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := json.Marshal(s.report(r.Context()))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+func (s *Server) report(ctx context.Context) Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report := Report{
+		Ready:         s.enrolled && !s.lastSuccessfulSync.IsZero(),
+		Enrolled:      s.enrolled,
+		LastSyncError: s.lastSyncError,
+		Conditions:    []v1alpha1.Condition{},
+	}
+	if !s.lastSuccessfulSync.IsZero() {
+		lastSuccessfulSync := s.lastSuccessfulSync
+		report.LastSuccessfulSync = &lastSuccessfulSync
+	}
+	if deviceStatus := s.status.Get(ctx); deviceStatus != nil && deviceStatus.Conditions != nil {
+		report.Conditions = append(report.Conditions, deviceStatus.Conditions...)
+	}
+	return report
+}
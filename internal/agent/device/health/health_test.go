@@ -0,0 +1,103 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type fakeCollector struct {
+	status *v1alpha1.DeviceStatus
+}
+
+func (c *fakeCollector) Get(context.Context) *v1alpha1.DeviceStatus {
+	return c.status
+}
+
+func TestHealthEndpoints(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	clock := clocktesting.NewFakeClock(now)
+	deviceStatus := v1alpha1.NewDeviceStatus()
+	s := New(log.NewPrefixLogger("test"), NewDefaultConfig(), &fakeCollector{status: &deviceStatus}, WithClock(clock))
+	handler := s.Handler()
+
+	get := func(path string) (int, Report) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal("application/json", rec.Header().Get("Content-Type"))
+		var report Report
+		require.NoError(json.Unmarshal(rec.Body.Bytes(), &report))
+		return rec.Code, report
+	}
+
+	// waiting for enrollment: alive but not ready
+	code, report := get(LivenessPath)
+	require.Equal(http.StatusOK, code)
+	require.False(report.Enrolled)
+	code, report = get(ReadinessPath)
+	require.Equal(http.StatusServiceUnavailable, code)
+	require.False(report.Ready)
+	require.Nil(report.LastSuccessfulSync)
+	require.Len(report.Conditions, len(deviceStatus.Conditions))
+
+	// enrolled, but no spec applied yet
+	s.SetEnrolled()
+	code, report = get(ReadinessPath)
+	require.Equal(http.StatusServiceUnavailable, code)
+	require.True(report.Enrolled)
+
+	// the first sync fails
+	deviceStatus.Conditions = []v1alpha1.Condition{{Type: v1alpha1.DeviceUpdating, Status: v1alpha1.ConditionStatusTrue, Reason: string(v1alpha1.UpdateStateApplyingUpdate)}}
+	s.SyncFailed(errors.New("pulling image: connection refused"))
+	code, report = get(ReadinessPath)
+	require.Equal(http.StatusServiceUnavailable, code)
+	require.Equal("pulling image: connection refused", report.LastSyncError)
+	require.Len(report.Conditions, 1)
+	require.Equal(v1alpha1.DeviceUpdating, report.Conditions[0].Type)
+
+	// a spec was applied
+	s.SyncSucceeded()
+	code, report = get(ReadinessPath)
+	require.Equal(http.StatusOK, code)
+	require.True(report.Ready)
+	require.Empty(report.LastSyncError)
+	require.Equal(now, *report.LastSuccessfulSync)
+
+	// a later failure is reported, but the agent stays ready
+	clock.Step(time.Minute)
+	s.SyncFailed(errors.New("invalid spec"))
+	code, report = get(ReadinessPath)
+	require.Equal(http.StatusOK, code)
+	require.Equal("invalid spec", report.LastSyncError)
+	require.Equal(now, *report.LastSuccessfulSync)
+
+	// only reads are served
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ReadinessPath, nil))
+	require.Equal(http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestNilServer(t *testing.T) {
+	var s *Server
+	s.SetEnrolled()
+	s.SyncSucceeded()
+	s.SyncFailed(errors.New("error"))
+}
+
+func TestConfigValidate(t *testing.T) {
+	require := require.New(t)
+	cfg := NewDefaultConfig()
+	require.NoError(cfg.Validate())
+	cfg.Port = 70000
+	require.Error(cfg.Validate())
+}
@@ -0,0 +1,130 @@
+package hook
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+const (
+	// DefaultApplyHookTimeout is the timeout of an apply hook without one.
+	DefaultApplyHookTimeout = time.Minute
+)
+
+// ApplyHook is a command the agent runs around the application of a spec. The command gets the
+// rendered versions in the FLIGHTCTL_CURRENT_RENDERED_VERSION and FLIGHTCTL_DESIRED_RENDERED_VERSION
+// environment variables.
+type ApplyHook struct {
+	// Run is the command line run, e.g. "systemctl stop my-service"
+	Run string `json:"run"`
+	// Timeout after which the command is killed and the hook failed, defaults to 1m
+	Timeout util.Duration `json:"timeout,omitempty"`
+}
+
+// ApplyHooksConfig configures the hooks the agent runs around the application of a spec, from its
+// config rather than from the device spec.
+type ApplyHooksConfig struct {
+	// PreApply hooks run before the spec is applied, a failure aborts the update and it is retried
+	PreApply []ApplyHook `json:"pre-apply,omitempty"`
+	// PostApply hooks run after the spec is applied, a failure rolls the device back to the
+	// previous spec and fails the update
+	PostApply []ApplyHook `json:"post-apply,omitempty"`
+}
+
+// Validate checks that every hook has a command and a valid timeout.
+func (c *ApplyHooksConfig) Validate() error {
+	for stage, hooks := range map[string][]ApplyHook{"pre-apply": c.PreApply, "post-apply": c.PostApply} {
+		for i, hook := range hooks {
+			if strings.TrimSpace(hook.Run) == "" {
+				return fmt.Errorf("apply-hooks %s[%d]: run must not be empty", stage, i)
+			}
+			if hook.Timeout < 0 {
+				return fmt.Errorf("apply-hooks %s[%d]: timeout must not be negative", stage, i)
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyHooks runs the pre-apply and post-apply hooks of the agent config.
+type ApplyHooks struct {
+	preApply  []ApplyHook
+	postApply []ApplyHook
+	exec      executer.Executer
+	log       *log.PrefixLogger
+}
+
+func NewApplyHooks(log *log.PrefixLogger, exec executer.Executer, cfg ApplyHooksConfig) *ApplyHooks {
+	return &ApplyHooks{
+		preApply:  cfg.PreApply,
+		postApply: cfg.PostApply,
+		exec:      exec,
+		log:       log,
+	}
+}
+
+// PreApply runs the pre-apply hooks in order, stopping at the first failure.
+func (h *ApplyHooks) PreApply(ctx context.Context, current, desired *api.RenderedDeviceSpec) error {
+	if h == nil {
+		return nil
+	}
+	for _, hook := range h.preApply {
+		if err := h.run(ctx, hook, current, desired); err != nil {
+			return fmt.Errorf("%w: %w", errors.ErrPreApplyHookFailed, err)
+		}
+	}
+	return nil
+}
+
+// PostApply runs the post-apply hooks in order. On the first failure, it rolls the device back
+// to the current spec with the rollback function and returns an error wrapping
+// ErrPostApplyHookFailed.
+func (h *ApplyHooks) PostApply(ctx context.Context, current, desired *api.RenderedDeviceSpec, rollback func(ctx context.Context) error) error {
+	if h == nil {
+		return nil
+	}
+	for _, hook := range h.postApply {
+		hookErr := h.run(ctx, hook, current, desired)
+		if hookErr == nil {
+			continue
+		}
+		h.log.Errorf("Post-apply hook failed, rolling back to renderedVersion: %s: %v", current.RenderedVersion, hookErr)
+		if err := rollback(ctx); err != nil {
+			return fmt.Errorf("%w: %w: rolling back to renderedVersion: %s: %w", errors.ErrPostApplyHookFailed, hookErr, current.RenderedVersion, err)
+		}
+		return fmt.Errorf("%w: %w: rolled back to renderedVersion: %s", errors.ErrPostApplyHookFailed, hookErr, current.RenderedVersion)
+	}
+	return nil
+}
+
+func (h *ApplyHooks) run(ctx context.Context, hook ApplyHook, current, desired *api.RenderedDeviceSpec) error {
+	timeout := time.Duration(hook.Timeout)
+	if timeout == 0 {
+		timeout = DefaultApplyHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	env := append(os.Environ(),
+		"FLIGHTCTL_CURRENT_RENDERED_VERSION="+current.RenderedVersion,
+		"FLIGHTCTL_DESIRED_RENDERED_VERSION="+desired.RenderedVersion,
+	)
+	cmd, args := splitCommandAndArgs(hook.Run)
+	h.log.Infof("Running apply hook %q", hook.Run)
+	_, stderr, exitCode := h.exec.ExecuteWithContextFromDir(ctx, "", cmd, args, env...)
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%q timed out after %s", hook.Run, timeout)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("%q: %s (exit code %d)", hook.Run, strings.TrimSpace(stderr), exitCode)
+	}
+	return nil
+}
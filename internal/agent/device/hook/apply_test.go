@@ -0,0 +1,117 @@
+package hook
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyHooks(t *testing.T) {
+	current := &api.RenderedDeviceSpec{RenderedVersion: "1"}
+	desired := &api.RenderedDeviceSpec{RenderedVersion: "2"}
+	exec := &executer.CommonExecuter{}
+	// the hooks get the rendered versions in their environment
+	checkVersions := filepath.Join(t.TempDir(), "check-versions.sh")
+	require.NoError(t, os.WriteFile(checkVersions, []byte(`#!/bin/sh
+test "$FLIGHTCTL_CURRENT_RENDERED_VERSION" = 1 && test "$FLIGHTCTL_DESIRED_RENDERED_VERSION" = 2
+`), 0700))
+	failing := filepath.Join(t.TempDir(), "failing.sh")
+	require.NoError(t, os.WriteFile(failing, []byte(`#!/bin/sh
+echo service busy >&2
+exit 3
+`), 0700))
+
+	testCases := []struct {
+		name            string
+		cfg             ApplyHooksConfig
+		wantPreErr      error
+		wantPostErr     error
+		wantErrContains string
+		wantRolledBack  bool
+		rollbackErr     error
+	}{
+		{
+			name: "success",
+			cfg: ApplyHooksConfig{
+				PreApply:  []ApplyHook{{Run: checkVersions}},
+				PostApply: []ApplyHook{{Run: checkVersions}},
+			},
+		},
+		{
+			name:            "pre-apply failure",
+			cfg:             ApplyHooksConfig{PreApply: []ApplyHook{{Run: failing}}},
+			wantPreErr:      errors.ErrPreApplyHookFailed,
+			wantErrContains: "service busy (exit code 3)",
+		},
+		{
+			name:            "post-apply failure rolls back",
+			cfg:             ApplyHooksConfig{PostApply: []ApplyHook{{Run: "true"}, {Run: "false"}}},
+			wantPostErr:     errors.ErrPostApplyHookFailed,
+			wantErrContains: "rolled back to renderedVersion: 1",
+			wantRolledBack:  true,
+		},
+		{
+			name:            "post-apply failure with failed rollback",
+			cfg:             ApplyHooksConfig{PostApply: []ApplyHook{{Run: "false"}}},
+			wantPostErr:     errors.ErrPostApplyHookFailed,
+			wantErrContains: "rolling back to renderedVersion: 1: disk full",
+			wantRolledBack:  true,
+			rollbackErr:     fmt.Errorf("disk full"),
+		},
+		{
+			name:            "post-apply timeout rolls back",
+			cfg:             ApplyHooksConfig{PostApply: []ApplyHook{{Run: "sleep 10", Timeout: util.Duration(100 * time.Millisecond)}}},
+			wantPostErr:     errors.ErrPostApplyHookFailed,
+			wantErrContains: "timed out after 100ms",
+			wantRolledBack:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			require.NoError(tc.cfg.Validate())
+			hooks := NewApplyHooks(log.NewPrefixLogger("test"), exec, tc.cfg)
+
+			err := hooks.PreApply(context.Background(), current, desired)
+			if tc.wantPreErr != nil {
+				require.ErrorIs(err, tc.wantPreErr)
+				require.ErrorContains(err, tc.wantErrContains)
+				require.True(errors.IsRetryable(err))
+				return
+			}
+			require.NoError(err)
+
+			rolledBack := false
+			err = hooks.PostApply(context.Background(), current, desired, func(ctx context.Context) error {
+				rolledBack = true
+				return tc.rollbackErr
+			})
+			require.Equal(tc.wantRolledBack, rolledBack)
+			if tc.wantPostErr != nil {
+				require.ErrorIs(err, tc.wantPostErr)
+				require.ErrorContains(err, tc.wantErrContains)
+				require.False(errors.IsRetryable(err))
+				return
+			}
+			require.NoError(err)
+		})
+	}
+}
+
+func TestApplyHooksConfigValidate(t *testing.T) {
+	require := require.New(t)
+	require.NoError((&ApplyHooksConfig{}).Validate())
+	require.ErrorContains((&ApplyHooksConfig{PreApply: []ApplyHook{{Run: " "}}}).Validate(), "pre-apply[0]: run must not be empty")
+	require.ErrorContains((&ApplyHooksConfig{PostApply: []ApplyHook{{Run: "true", Timeout: util.Duration(-time.Second)}}}).Validate(), "timeout must not be negative")
+}
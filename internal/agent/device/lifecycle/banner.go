@@ -0,0 +1,52 @@
+package lifecycle
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	fcrypto "github.com/flightctl/flightctl/internal/crypto"
+)
+
+const (
+	// agent banner file in machine-readable form, written alongside BannerFile when enabled
+	BannerJSONFile = "/etc/issue.d/flightctl-banner.json"
+)
+
+// Banner is the machine-readable form of the banner, for tools that need the enrollment ID of
+// the device without scraping the text banner.
+type Banner struct {
+	// EnrollmentID is the name of the enrollment request, and of the device once enrolled.
+	EnrollmentID string `json:"enrollmentId"`
+	// URL is the URL to enroll the device, or to manage it once enrolled.
+	URL string `json:"url"`
+	// Fingerprint is the hex-encoded SHA-256 hash of the public key of the device.
+	Fingerprint string `json:"fingerprint"`
+	// Enrolled is true once the enrollment of the device has been approved.
+	Enrolled bool `json:"enrolled"`
+}
+
+// ParseBanner parses the content of BannerJSONFile.
+func ParseBanner(data []byte) (*Banner, error) {
+	var banner Banner
+	if err := json.Unmarshal(data, &banner); err != nil {
+		return nil, fmt.Errorf("failed to parse banner: %w", err)
+	}
+	if banner.EnrollmentID == "" {
+		return nil, fmt.Errorf("failed to parse banner: missing enrollment ID")
+	}
+	return &banner, nil
+}
+
+// publicKeyFingerprint returns the fingerprint of the public key of the given PEM-encoded CSR.
+func publicKeyFingerprint(csrPEM []byte) (string, error) {
+	csr, err := fcrypto.ParseCSR(csrPEM)
+	if err != nil {
+		return "", err
+	}
+	hash, err := fcrypto.HashPublicKey(csr.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash), nil
+}
@@ -0,0 +1,101 @@
+package lifecycle
+
+import (
+	"crypto"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	fcrypto "github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestStructuredBanner(t *testing.T) {
+	require := require.New(t)
+	publicKey, privateKey, err := fcrypto.NewKeyPair()
+	require.NoError(err)
+	publicKeyHash, err := fcrypto.HashPublicKey(publicKey)
+	require.NoError(err)
+	deviceName := strings.ToLower(base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString(publicKeyHash))
+	csr, err := fcrypto.MakeCSR(privateKey.(crypto.Signer), deviceName)
+	require.NoError(err)
+
+	newManager := func(structuredBanner bool) (*LifecycleManager, fileio.ReadWriter) {
+		readWriter := fileio.NewReadWriter()
+		readWriter.SetRootdir(t.TempDir())
+		return NewManager(deviceName, "https://ui.flightctl.example.com", "/var/lib/flightctl/certs/agent.crt",
+			structuredBanner, readWriter, nil, csr, nil, wait.Backoff{}, log.NewPrefixLogger("test")), readWriter
+	}
+
+	m, readWriter := newManager(true)
+	require.NoError(m.writeEnrollmentBanner())
+	data, err := readWriter.ReadFile(BannerJSONFile)
+	require.NoError(err)
+	banner, err := ParseBanner(data)
+	require.NoError(err)
+	require.Equal(Banner{
+		EnrollmentID: deviceName,
+		URL:          "https://ui.flightctl.example.com/enroll/" + deviceName,
+		Fingerprint:  hex.EncodeToString(publicKeyHash),
+	}, *banner)
+
+	// the text banner is still written
+	text, err := readWriter.ReadFile(BannerFile)
+	require.NoError(err)
+	require.Contains(string(text), "/enroll/"+deviceName)
+
+	require.NoError(m.writeManagementBanner())
+	data, err = readWriter.ReadFile(BannerJSONFile)
+	require.NoError(err)
+	banner, err = ParseBanner(data)
+	require.NoError(err)
+	require.True(banner.Enrolled)
+	require.Equal("https://ui.flightctl.example.com/manage/"+deviceName, banner.URL)
+
+	// only the text banner is written by default
+	m, readWriter = newManager(false)
+	require.NoError(m.writeEnrollmentBanner())
+	exists, err := readWriter.PathExists(BannerJSONFile)
+	require.NoError(err)
+	require.False(exists)
+}
+
+func TestParseBanner(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *Banner
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: `{"enrollmentId":"abc123","url":"https://ui.example.com/enroll/abc123","fingerprint":"0a1b","enrolled":false}`,
+			want: &Banner{EnrollmentID: "abc123", URL: "https://ui.example.com/enroll/abc123", Fingerprint: "0a1b"},
+		},
+		{
+			name:    "missing enrollment id",
+			data:    `{"url":"https://ui.example.com/enroll/abc123"}`,
+			wantErr: true,
+		},
+		{
+			name:    "text banner",
+			data:    "Enroll your device to flightctl by following this URL:\nhttps://ui.example.com/enroll/abc123\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			banner, err := ParseBanner([]byte(tt.data))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, banner)
+		})
+	}
+}
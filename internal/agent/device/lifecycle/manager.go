@@ -3,6 +3,7 @@ package lifecycle
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
@@ -32,6 +33,7 @@ type LifecycleManager struct {
 	deviceName           string
 	enrollmentUIEndpoint string
 	managementCertPath   string
+	structuredBanner     bool
 	deviceReadWriter     fileio.ReadWriter
 
 	enrollmentClient client.Enrollment
@@ -47,6 +49,7 @@ func NewManager(
 	deviceName string,
 	enrollmentUIEndpoint string,
 	managementCertPath string,
+	structuredBanner bool,
 	deviceReadWriter fileio.ReadWriter,
 	enrollmentClient client.Enrollment,
 	enrollmentCSR []byte,
@@ -59,6 +62,7 @@ func NewManager(
 		deviceName:           deviceName,
 		enrollmentUIEndpoint: enrollmentUIEndpoint,
 		managementCertPath:   managementCertPath,
+		structuredBanner:     structuredBanner,
 		deviceReadWriter:     deviceReadWriter,
 		enrollmentClient:     enrollmentClient,
 		enrollmentCSR:        enrollmentCSR,
@@ -170,6 +174,9 @@ func (m *LifecycleManager) writeEnrollmentBanner() error {
 	if err := m.writeQRBanner("\nEnroll your device to flightctl by scanning\nthe above QR code or following this URL:\n%s\n\n", url); err != nil {
 		return fmt.Errorf("failed to write device enrollment banner: %w", err)
 	}
+	if err := m.writeStructuredBanner(url, false); err != nil {
+		return fmt.Errorf("failed to write device enrollment banner: %w", err)
+	}
 	return nil
 }
 
@@ -183,6 +190,33 @@ func (m *LifecycleManager) writeManagementBanner() error {
 	if err := m.writeQRBanner("\nYour device is enrolled to flightctl,\nyou can manage your device scanning the above QR. or following this URL:\n%s\n\n", url); err != nil {
 		return fmt.Errorf("failed to write device management banner: %w", err)
 	}
+	if err := m.writeStructuredBanner(url, true); err != nil {
+		return fmt.Errorf("failed to write device management banner: %w", err)
+	}
+	return nil
+}
+
+// writeStructuredBanner writes the banner in machine-readable form, if enabled.
+func (m *LifecycleManager) writeStructuredBanner(url string, enrolled bool) error {
+	if !m.structuredBanner {
+		return nil
+	}
+	fingerprint, err := publicKeyFingerprint(m.enrollmentCSR)
+	if err != nil {
+		return fmt.Errorf("failed to compute public key fingerprint: %w", err)
+	}
+	data, err := json.Marshal(Banner{
+		EnrollmentID: m.deviceName,
+		URL:          url,
+		Fingerprint:  fingerprint,
+		Enrolled:     enrolled,
+	})
+	if err != nil {
+		return err
+	}
+	if err := m.deviceReadWriter.WriteFile(BannerJSONFile, data, fileio.DefaultFilePermissions); err != nil {
+		return fmt.Errorf("failed to write banner to disk: %w", err)
+	}
 	return nil
 }
 
@@ -262,7 +296,7 @@ func SdNotify(state string) error {
 	}
 	defer conn.Close()
 
-	_, err = conn.Write([]byte("READY=1\n"))
+	_, err = conn.Write([]byte(state + "\n"))
 	if err != nil {
 		return fmt.Errorf("failed to write to systemd: %w", err)
 	}
@@ -0,0 +1,249 @@
+package logshipper
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// AgentUnit is the systemd unit of the agent, whose logs are always shipped.
+	AgentUnit = "flightctl-agent"
+
+	DefaultInterval    = time.Hour
+	DefaultMinInterval = 5 * time.Minute
+	DefaultMaxLines    = 500
+	DefaultMaxBytes    = 64 * 1024
+
+	// MaxBytesLimit is the largest shipment the service accepts.
+	MaxBytesLimit = 1024 * 1024
+)
+
+// Config configures the shipping of the tail of the agent's logs to the service. Logs are shipped
+// on a schedule and when the agent fails to apply a spec.
+type Config struct {
+	// Enabled turns on log shipping
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval between two scheduled shipments, zero only ships logs on errors
+	Interval util.Duration `json:"interval,omitempty"`
+	// MinInterval is the minimum time between two shipments, limiting the rate at which errors
+	// ship logs
+	MinInterval util.Duration `json:"min-interval,omitempty"`
+	// Units are additional systemd units whose journal is shipped along with the agent's
+	Units []string `json:"units,omitempty"`
+	// MaxLines is the maximum number of lines shipped at once
+	MaxLines int `json:"max-lines,omitempty"`
+	// MaxBytes is the maximum size of the lines shipped at once
+	MaxBytes int `json:"max-bytes,omitempty"`
+}
+
+// NewDefaultConfig returns the default log shipping config, which is disabled.
+func NewDefaultConfig() Config {
+	return Config{
+		Interval:    util.Duration(DefaultInterval),
+		MinInterval: util.Duration(DefaultMinInterval),
+		MaxLines:    DefaultMaxLines,
+		MaxBytes:    DefaultMaxBytes,
+	}
+}
+
+// Validate checks that the limits are within range.
+func (c *Config) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("log-shipping interval must not be negative")
+	}
+	if c.MinInterval < 0 {
+		return fmt.Errorf("log-shipping min-interval must not be negative")
+	}
+	if c.MaxLines < 0 {
+		return fmt.Errorf("log-shipping max-lines must not be negative")
+	}
+	if c.MaxBytes < 0 || c.MaxBytes > MaxBytesLimit {
+		return fmt.Errorf("log-shipping max-bytes must be between 0 and %d", MaxBytesLimit)
+	}
+	for _, unit := range c.Units {
+		if strings.TrimSpace(unit) == "" {
+			return fmt.Errorf("log-shipping units must not be empty")
+		}
+	}
+	return nil
+}
+
+// Shipper ships the tail of the journal of the agent, and of the configured units, to the
+// service. Shipments replace each other on the service, which keeps the most recent one.
+type Shipper struct {
+	log        *log.PrefixLogger
+	deviceName string
+	client     client.Management
+	exec       executer.Executer
+	clock      clock.WithTicker
+	redact     func(string) string
+
+	interval    time.Duration
+	minInterval time.Duration
+	units       []string
+	maxLines    int
+	maxBytes    int
+
+	errors      chan struct{}
+	lastShipped time.Time
+}
+
+type Option func(*Shipper)
+
+// WithClock sets the clock used by the shipper.
+func WithClock(clock clock.WithTicker) Option {
+	return func(s *Shipper) {
+		s.clock = clock
+	}
+}
+
+// WithRedaction applies redact to every log line before it is shipped.
+func WithRedaction(redact func(string) string) Option {
+	return func(s *Shipper) {
+		s.redact = redact
+	}
+}
+
+// New creates a log shipper for the given device.
+func New(log *log.PrefixLogger, cfg Config, deviceName string, client client.Management, exec executer.Executer, opts ...Option) *Shipper {
+	s := &Shipper{
+		log:         log,
+		deviceName:  deviceName,
+		client:      client,
+		exec:        exec,
+		clock:       clock.RealClock{},
+		interval:    time.Duration(cfg.Interval),
+		minInterval: time.Duration(cfg.MinInterval),
+		units:       append([]string{AgentUnit}, cfg.Units...),
+		maxLines:    cfg.MaxLines,
+		maxBytes:    cfg.MaxBytes,
+		errors:      make(chan struct{}, 1),
+	}
+	if s.maxLines == 0 {
+		s.maxLines = DefaultMaxLines
+	}
+	if s.maxBytes == 0 {
+		s.maxBytes = DefaultMaxBytes
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// TriggerOnError requests the logs to be shipped because of an error. It does not block, and is
+// a no-op on a nil shipper.
+func (s *Shipper) TriggerOnError() {
+	if s == nil {
+		return
+	}
+	select {
+	case s.errors <- struct{}{}:
+	default:
+		// a shipment is already pending
+	}
+}
+
+// Run ships the logs every interval, and when an error is reported, until the context is canceled.
+func (s *Shipper) Run(ctx context.Context) {
+	var scheduled <-chan time.Time
+	if s.interval > 0 {
+		s.log.Infof("Shipping logs every %s", s.interval)
+		ticker := s.clock.NewTicker(s.interval)
+		defer ticker.Stop()
+		scheduled = ticker.C()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-scheduled:
+			s.ship(ctx, v1alpha1.DeviceLogsReasonScheduled)
+		case <-s.errors:
+			s.ship(ctx, v1alpha1.DeviceLogsReasonError)
+		}
+	}
+}
+
+func (s *Shipper) ship(ctx context.Context, reason v1alpha1.DeviceLogsReason) {
+	if !s.lastShipped.IsZero() && s.clock.Since(s.lastShipped) < s.minInterval {
+		s.log.Debugf("Not shipping logs (%s), the last shipment is less than %s old", reason, s.minInterval)
+		return
+	}
+
+	lines, err := s.collect(ctx)
+	if err != nil {
+		s.log.Warnf("Failed to collect logs: %v", err)
+		return
+	}
+	if s.redact != nil {
+		for i := range lines {
+			lines[i] = s.redact(lines[i])
+		}
+	}
+	lines, truncated := tail(lines, s.maxLines, s.maxBytes)
+
+	logs := v1alpha1.DeviceLogs{
+		CollectedAt: s.clock.Now(),
+		Reason:      reason,
+		Truncated:   &truncated,
+		Lines:       lines,
+	}
+	if err := s.client.UpdateDeviceLogs(ctx, s.deviceName, logs); err != nil {
+		s.log.Warnf("Failed to ship logs: %v", err)
+		return
+	}
+	s.lastShipped = s.clock.Now()
+	s.log.Debugf("Shipped %d log lines (%s)", len(lines), reason)
+}
+
+// collect reads the last lines of the journal of the units, merged in chronological order.
+func (s *Shipper) collect(ctx context.Context) ([]string, error) {
+	args := []string{"--no-pager", "--output", "short-iso", "--lines", strconv.Itoa(s.maxLines)}
+	for _, unit := range s.units {
+		args = append(args, "--unit", unit)
+	}
+	stdout, stderr, exitCode := s.exec.ExecuteWithContext(ctx, "journalctl", args...)
+	if exitCode != 0 {
+		return nil, fmt.Errorf("journalctl: exit code %d: %s", exitCode, strings.TrimSpace(stderr))
+	}
+	stdout = strings.TrimRight(stdout, "\n")
+	if stdout == "" {
+		return []string{}, nil
+	}
+	return strings.Split(stdout, "\n"), nil
+}
+
+// tail returns the newest lines fitting within maxLines and maxBytes, and whether older lines
+// were dropped. A single line larger than maxBytes is cut to its last maxBytes bytes.
+func tail(lines []string, maxLines int, maxBytes int) ([]string, bool) {
+	truncated := false
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+		truncated = true
+	}
+
+	size := 0
+	for i := len(lines) - 1; i >= 0; i-- {
+		size += len(lines[i])
+		if size <= maxBytes {
+			continue
+		}
+		if i == len(lines)-1 {
+			return []string{lines[i][len(lines[i])-maxBytes:]}, true
+		}
+		return lines[i+1:], true
+	}
+	return lines, truncated
+}
@@ -0,0 +1,132 @@
+package logshipper
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	agentclient "github.com/flightctl/flightctl/internal/api/client/agent"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestShipOnError(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := clocktesting.NewFakeClock(time.Now())
+	mockExec := executer.NewMockExecuter(ctrl)
+	mockClient := client.NewMockManagement(ctrl)
+
+	cfg := NewDefaultConfig()
+	cfg.Interval = 0
+	cfg.MinInterval = util.Duration(time.Minute)
+	cfg.Units = []string{"podman"}
+	cfg.MaxLines = 2
+	s := New(log.NewPrefixLogger("test"), cfg, "device", mockClient, mockExec, WithClock(clock))
+
+	shipped := make(chan v1alpha1.DeviceLogs, 2)
+	mockExec.EXPECT().ExecuteWithContext(gomock.Any(), "journalctl",
+		"--no-pager", "--output", "short-iso", "--lines", "2", "--unit", AgentUnit, "--unit", "podman").
+		Return("one\ntwo\n", "", 0).Times(2)
+	mockClient.EXPECT().UpdateDeviceLogs(gomock.Any(), "device", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, logs v1alpha1.DeviceLogs, _ ...agentclient.RequestEditorFn) error {
+			shipped <- logs
+			return nil
+		}).Times(2)
+
+	go s.Run(ctx)
+
+	s.TriggerOnError()
+	logs := <-shipped
+	require.Equal(v1alpha1.DeviceLogsReasonError, logs.Reason)
+	require.Equal([]string{"one", "two"}, logs.Lines)
+	require.False(*logs.Truncated)
+
+	// errors within the minimum interval do not ship logs again
+	s.TriggerOnError()
+	time.Sleep(10 * time.Millisecond)
+	require.Empty(shipped)
+
+	clock.Step(time.Minute)
+	s.TriggerOnError()
+	logs = <-shipped
+	require.Equal(v1alpha1.DeviceLogsReasonError, logs.Reason)
+}
+
+func TestTriggerOnNilShipper(t *testing.T) {
+	var s *Shipper
+	require.NotPanics(t, s.TriggerOnError)
+}
+
+func TestTail(t *testing.T) {
+	tests := []struct {
+		name              string
+		lines             []string
+		maxLines          int
+		maxBytes          int
+		expected          []string
+		expectedTruncated bool
+	}{
+		{
+			name:     "within limits",
+			lines:    []string{"a", "b", "c"},
+			maxLines: 3,
+			maxBytes: 3,
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:              "too many lines",
+			lines:             []string{"a", "b", "c"},
+			maxLines:          2,
+			maxBytes:          10,
+			expected:          []string{"b", "c"},
+			expectedTruncated: true,
+		},
+		{
+			name:              "too many bytes",
+			lines:             []string{"aaa", "bbb", "ccc"},
+			maxLines:          10,
+			maxBytes:          7,
+			expected:          []string{"bbb", "ccc"},
+			expectedTruncated: true,
+		},
+		{
+			name:              "last line too large",
+			lines:             []string{"a", strings.Repeat("b", 5) + "cd"},
+			maxLines:          10,
+			maxBytes:          3,
+			expected:          []string{"bcd"},
+			expectedTruncated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, truncated := tail(tt.lines, tt.maxLines, tt.maxBytes)
+			require.Equal(t, tt.expected, lines)
+			require.Equal(t, tt.expectedTruncated, truncated)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := NewDefaultConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.MaxBytes = MaxBytesLimit + 1
+	require.Error(t, cfg.Validate())
+
+	cfg = NewDefaultConfig()
+	cfg.Units = []string{" "}
+	require.Error(t, cfg.Validate())
+}
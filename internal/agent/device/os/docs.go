@@ -0,0 +1,3 @@
+package os
+
+//go:generate go run -modfile=../../../../tools/go.mod go.uber.org/mock/mockgen -source=os.go -destination=mock_os.go -package=os
@@ -0,0 +1,76 @@
+package os
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/util"
+)
+
+const (
+	// DefaultHealthCheckTimeout is how long a single run of the health check command may take.
+	DefaultHealthCheckTimeout = util.Duration(time.Minute)
+)
+
+// HealthCheckConfig configures the verification of the OS image the device booted into after an
+// update. Until the check passes and the device checked in with the management service, the
+// update is not committed. If that does not happen within the grace period, the agent rolls the
+// device back to the image it booted before the update.
+type HealthCheckConfig struct {
+	// GracePeriod is how long the new OS image has to prove healthy. Verification is disabled
+	// when zero.
+	GracePeriod util.Duration `json:"grace-period,omitempty"`
+	// Command is the health check, run by /bin/sh until it exits with 0. Only the check in with
+	// the management service is required when empty.
+	Command string `json:"command,omitempty"`
+	// Timeout is how long a single run of the command may take
+	Timeout util.Duration `json:"timeout,omitempty"`
+}
+
+// NewDefaultHealthCheckConfig returns the default health check config, which commits OS updates
+// as soon as the device booted into the new image.
+func NewDefaultHealthCheckConfig() HealthCheckConfig {
+	return HealthCheckConfig{
+		Timeout: DefaultHealthCheckTimeout,
+	}
+}
+
+// Enabled returns true if updates are verified before they are committed.
+func (c *HealthCheckConfig) Enabled() bool {
+	return c.GracePeriod > 0
+}
+
+// Validate checks that the durations are valid.
+func (c *HealthCheckConfig) Validate() error {
+	if c.GracePeriod < 0 {
+		return fmt.Errorf("os-health-check grace-period must not be negative")
+	}
+	if c.Command != "" && c.GracePeriod == 0 {
+		return fmt.Errorf("os-health-check grace-period must be set with a command")
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("os-health-check timeout must not be negative")
+	}
+	return nil
+}
+
+// runHealthCheck runs the health check command once.
+func (m *manager) runHealthCheck(ctx context.Context) error {
+	if m.healthCheck.Command == "" {
+		return nil
+	}
+	timeout := time.Duration(m.healthCheck.Timeout)
+	if timeout == 0 {
+		timeout = time.Duration(DefaultHealthCheckTimeout)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, stderr, exitCode := m.executer.ExecuteWithContext(ctx, "/bin/sh", "-c", m.healthCheck.Command)
+	if exitCode != 0 {
+		return fmt.Errorf("health check: %w", errors.FromStderr(stderr, exitCode))
+	}
+	return nil
+}
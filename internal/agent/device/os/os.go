@@ -7,23 +7,40 @@ import (
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
 	"github.com/flightctl/flightctl/internal/agent/device/status"
 	"github.com/flightctl/flightctl/internal/container"
+	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
+	"k8s.io/utils/clock"
 )
 
 type Manager interface {
 	BeforeUpdate(ctx context.Context, current, desired *v1alpha1.RenderedDeviceSpec) error
 	AfterUpdate(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error
 	Reboot(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error
+	// RecoverUpdate inspects the persisted state of an OS update on startup. It clears the state
+	// once the target image is booted, or starts its verification if a health check is configured,
+	// and otherwise returns the state of the pending update.
+	RecoverUpdate(ctx context.Context) (*UpdateState, error)
+	// VerifyUpdate checks the health of the target image booted by an update being verified. It
+	// commits the update once the image is healthy and the device checked in with the management
+	// service, and rolls the device back once the grace period expired.
+	VerifyUpdate(ctx context.Context, checkedIn bool) error
 
 	status.Exporter
 }
 
-func NewManager(log *log.PrefixLogger, bootcClient container.BootcClient, podmanClient *client.Podman) Manager {
+func NewManager(log *log.PrefixLogger, bootcClient container.BootcClient, podmanClient *client.Podman, readWriter fileio.ReadWriter, executer executer.Executer, healthCheck HealthCheckConfig, dataDir string) Manager {
 	return &manager{
 		bootcClient:  bootcClient,
 		podmanClient: podmanClient,
+		readWriter:   readWriter,
+		executer:     executer,
+		healthCheck:  healthCheck,
+		statePath:    updateStatePath(dataDir),
+		clock:        clock.RealClock{},
 		log:          log,
 	}
 }
@@ -31,6 +48,11 @@ func NewManager(log *log.PrefixLogger, bootcClient container.BootcClient, podman
 type manager struct {
 	bootcClient  container.BootcClient
 	podmanClient *client.Podman
+	readWriter   fileio.ReadWriter
+	executer     executer.Executer
+	healthCheck  HealthCheckConfig
+	statePath    string
+	clock        clock.Clock
 	log          *log.PrefixLogger
 }
 
@@ -60,10 +82,26 @@ func (m *manager) BeforeUpdate(ctx context.Context, current, desired *v1alpha1.R
 	}
 
 	osImage := desired.Os.Image
+	state, err := m.readState()
+	if err != nil {
+		return err
+	}
+	if state == nil || state.TargetImage != osImage {
+		// a new target image starts over with a fresh attempt count
+		state = &UpdateState{TargetImage: osImage}
+	}
+	if state.Failed() {
+		return fmt.Errorf("%w: %s", errors.ErrOSUpdateFailed, state.FailureMessage())
+	}
+	state.Phase = UpdatePhaseDownloading
+	if err := m.writeState(state); err != nil {
+		return err
+	}
+
 	now := time.Now()
 	m.log.Infof("Fetching OS image: %s", osImage)
 
-	_, err := m.podmanClient.Pull(ctx, osImage, client.WithRetry())
+	_, err = m.podmanClient.Pull(ctx, osImage, client.WithRetry())
 	if err != nil {
 		return err
 	}
@@ -77,9 +115,123 @@ func (m *manager) AfterUpdate(ctx context.Context, desired *v1alpha1.RenderedDev
 		return nil
 	}
 	osImage := desired.Os.Image
+	if err := m.setPhase(osImage, UpdatePhaseStaging); err != nil {
+		return err
+	}
 	return m.bootcClient.Switch(ctx, osImage)
 }
 
 func (m *manager) Reboot(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
+	if desired.Os != nil {
+		if err := m.setPhase(desired.Os.Image, UpdatePhaseRebooting); err != nil {
+			return err
+		}
+	}
 	return m.bootcClient.Apply(ctx)
 }
+
+func (m *manager) RecoverUpdate(ctx context.Context) (*UpdateState, error) {
+	state, err := m.readState()
+	if err != nil || state == nil {
+		return nil, err
+	}
+
+	bootcInfo, err := m.bootcClient.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrGettingBootcStatus, err)
+	}
+	if bootcInfo.GetBootedImage() == state.TargetImage {
+		if !m.healthCheck.Enabled() {
+			m.log.Infof("OS update to %s completed after %d attempt(s)", state.TargetImage, state.Attempts)
+			return nil, m.clearState()
+		}
+		if state.Phase == UpdatePhaseVerifying {
+			// the agent restarted during the verification, which keeps its deadline
+			return state, nil
+		}
+		if state.Phase == UpdatePhaseRolledBack {
+			// the device restarted before booting into the previous image, retry the rollback
+			// once the verification is found expired
+			state.Phase = UpdatePhaseVerifying
+			return state, m.writeState(state)
+		}
+		m.log.Infof("Booted into OS image %s, verifying its health for %s", state.TargetImage, m.healthCheck.GracePeriod)
+		now := m.clock.Now()
+		state.Phase = UpdatePhaseVerifying
+		state.VerifyingSince = &now
+		return state, m.writeState(state)
+	}
+
+	switch state.Phase {
+	case UpdatePhaseDownloading, UpdatePhaseStaging:
+		// the device restarted before rebooting into the target image, the next sync picks the
+		// update up again without counting an attempt
+		m.log.Infof("Resuming OS update to %s interrupted while %s", state.TargetImage, state.Phase)
+	case UpdatePhaseVerifying:
+		// the device booted back into the previous image before the target image was verified
+		m.log.Warnf("OS image %s was rolled back before it was verified", state.TargetImage)
+		state.Phase = UpdatePhaseRolledBack
+		if err := m.writeState(state); err != nil {
+			return nil, err
+		}
+	case UpdatePhaseRolledBack:
+		m.log.Warnf("OS image %s failed its health check and was rolled back", state.TargetImage)
+	case UpdatePhaseRebooting:
+		m.log.Warnf("Failed to boot into OS image %s (attempt %d of %d)", state.TargetImage, state.Attempts, MaxUpdateAttempts)
+		if state.Attempts >= MaxUpdateAttempts {
+			state.Phase = UpdatePhaseFailed
+			if err := m.writeState(state); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return state, nil
+}
+
+func (m *manager) VerifyUpdate(ctx context.Context, checkedIn bool) error {
+	state, err := m.readState()
+	if err != nil || state == nil || state.Phase != UpdatePhaseVerifying {
+		return err
+	}
+
+	healthErr := m.runHealthCheck(ctx)
+	if healthErr == nil && checkedIn {
+		m.log.Infof("OS update to %s verified and completed after %d attempt(s)", state.TargetImage, state.Attempts)
+		return m.clearState()
+	}
+	if healthErr == nil {
+		healthErr = fmt.Errorf("device has not checked in with the management service")
+	}
+
+	deadline := state.VerifyingSince.Add(time.Duration(m.healthCheck.GracePeriod))
+	if m.clock.Now().Before(deadline) {
+		m.log.Infof("OS image %s is not verified yet, retrying until %s: %v", state.TargetImage, deadline.Format(time.RFC3339), healthErr)
+		return nil
+	}
+
+	m.log.Errorf("OS image %s failed verification within %s, rolling back: %v", state.TargetImage, m.healthCheck.GracePeriod, healthErr)
+	// the state is written first, so that the device does not retry the update once it booted
+	// into the previous image
+	state.Phase = UpdatePhaseRolledBack
+	if err := m.writeState(state); err != nil {
+		return err
+	}
+	return m.bootcClient.Rollback(ctx)
+}
+
+// setPhase records the phase reached by the update to the given image. Each reboot into the
+// image counts as an attempt.
+func (m *manager) setPhase(osImage string, phase UpdatePhase) error {
+	state, err := m.readState()
+	if err != nil {
+		return err
+	}
+	if state == nil || state.TargetImage != osImage {
+		state = &UpdateState{TargetImage: osImage}
+	}
+	if phase == UpdatePhaseRebooting {
+		state.Attempts++
+	}
+	state.Phase = phase
+	return m.writeState(state)
+}
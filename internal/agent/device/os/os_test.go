@@ -0,0 +1,335 @@
+package os
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/container"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+const (
+	bootedImage = "quay.io/org/os:1.0.0"
+	targetImage = "quay.io/org/os:2.0.0"
+)
+
+type testManager struct {
+	*manager
+	bootc *client.MockBootc
+	exec  *executer.MockExecuter
+}
+
+// newTestManager returns a manager backed by the given data dir, so that creating a second one
+// on the same dir simulates an agent restart.
+func newTestManager(ctrl *gomock.Controller, rootDir string) *testManager {
+	logger := log.NewPrefixLogger("test")
+	mockBootc := client.NewMockBootc(ctrl)
+	mockExec := executer.NewMockExecuter(ctrl)
+	podman := client.NewPodman(logger, mockExec, wait.Backoff{Steps: 1, Duration: time.Millisecond})
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(rootDir))
+	m := NewManager(logger, mockBootc, podman, readWriter, mockExec, NewDefaultHealthCheckConfig(), "/var/lib/flightctl").(*manager)
+	return &testManager{manager: m, bootc: mockBootc, exec: mockExec}
+}
+
+func bootedInto(image string) *container.BootcHost {
+	host := &container.BootcHost{}
+	host.Status.Booted.Image.Image.Image = image
+	return host
+}
+
+func desiredSpec(image string) *v1alpha1.RenderedDeviceSpec {
+	return &v1alpha1.RenderedDeviceSpec{Os: &v1alpha1.DeviceOsSpec{Image: image}}
+}
+
+func TestUpdatePhases(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	m := newTestManager(ctrl, t.TempDir())
+	desired := desiredSpec(targetImage)
+
+	m.exec.EXPECT().ExecuteWithContext(gomock.Any(), "podman", "pull", targetImage).Return("", "", 0)
+	require.NoError(m.BeforeUpdate(ctx, nil, desired))
+	state, err := m.readState()
+	require.NoError(err)
+	require.Equal(&UpdateState{Phase: UpdatePhaseDownloading, TargetImage: targetImage}, state)
+
+	m.bootc.EXPECT().Switch(ctx, targetImage).Return(nil)
+	require.NoError(m.AfterUpdate(ctx, desired))
+	state, err = m.readState()
+	require.NoError(err)
+	require.Equal(&UpdateState{Phase: UpdatePhaseStaging, TargetImage: targetImage}, state)
+
+	m.bootc.EXPECT().Apply(ctx).Return(nil)
+	require.NoError(m.Reboot(ctx, desired))
+	state, err = m.readState()
+	require.NoError(err)
+	require.Equal(&UpdateState{Phase: UpdatePhaseRebooting, TargetImage: targetImage, Attempts: 1}, state)
+}
+
+func TestRecoverUpdate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		state         *UpdateState
+		booted        string
+		expectedState *UpdateState
+	}{
+		{
+			name:   "no update in progress",
+			booted: bootedImage,
+		},
+		{
+			name:          "restart while downloading",
+			state:         &UpdateState{Phase: UpdatePhaseDownloading, TargetImage: targetImage},
+			booted:        bootedImage,
+			expectedState: &UpdateState{Phase: UpdatePhaseDownloading, TargetImage: targetImage},
+		},
+		{
+			name:          "restart while staging",
+			state:         &UpdateState{Phase: UpdatePhaseStaging, TargetImage: targetImage},
+			booted:        bootedImage,
+			expectedState: &UpdateState{Phase: UpdatePhaseStaging, TargetImage: targetImage},
+		},
+		{
+			name:   "rebooted into target image",
+			state:  &UpdateState{Phase: UpdatePhaseRebooting, TargetImage: targetImage, Attempts: 1},
+			booted: targetImage,
+		},
+		{
+			name:          "rebooted into previous image",
+			state:         &UpdateState{Phase: UpdatePhaseRebooting, TargetImage: targetImage, Attempts: 1},
+			booted:        bootedImage,
+			expectedState: &UpdateState{Phase: UpdatePhaseRebooting, TargetImage: targetImage, Attempts: 1},
+		},
+		{
+			name:          "rebooted into previous image on last attempt",
+			state:         &UpdateState{Phase: UpdatePhaseRebooting, TargetImage: targetImage, Attempts: MaxUpdateAttempts},
+			booted:        bootedImage,
+			expectedState: &UpdateState{Phase: UpdatePhaseFailed, TargetImage: targetImage, Attempts: MaxUpdateAttempts},
+		},
+		{
+			name:          "restart after failed update",
+			state:         &UpdateState{Phase: UpdatePhaseFailed, TargetImage: targetImage, Attempts: MaxUpdateAttempts},
+			booted:        bootedImage,
+			expectedState: &UpdateState{Phase: UpdatePhaseFailed, TargetImage: targetImage, Attempts: MaxUpdateAttempts},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+			rootDir := t.TempDir()
+
+			if tt.state != nil {
+				before := newTestManager(ctrl, rootDir)
+				require.NoError(before.readWriter.MkdirAll("/var/lib/flightctl", 0o755))
+				require.NoError(before.writeState(tt.state))
+			}
+
+			m := newTestManager(ctrl, rootDir)
+			if tt.state != nil {
+				m.bootc.EXPECT().Status(ctx).Return(bootedInto(tt.booted), nil)
+			}
+			state, err := m.RecoverUpdate(ctx)
+			require.NoError(err)
+			require.Equal(tt.expectedState, state)
+
+			persisted, err := m.readState()
+			require.NoError(err)
+			require.Equal(tt.expectedState, persisted)
+		})
+	}
+}
+
+func TestBeforeUpdateFailedImage(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	m := newTestManager(ctrl, t.TempDir())
+	require.NoError(m.readWriter.MkdirAll("/var/lib/flightctl", 0o755))
+	require.NoError(m.writeState(&UpdateState{Phase: UpdatePhaseFailed, TargetImage: targetImage, Attempts: MaxUpdateAttempts}))
+
+	// the image that failed to boot is not pulled again
+	err := m.BeforeUpdate(ctx, nil, desiredSpec(targetImage))
+	require.ErrorIs(err, errors.ErrOSUpdateFailed)
+	require.False(errors.IsRetryable(err))
+
+	// a different image starts a new update
+	newImage := "quay.io/org/os:2.0.1"
+	m.exec.EXPECT().ExecuteWithContext(gomock.Any(), "podman", "pull", newImage).Return("", "", 0)
+	require.NoError(m.BeforeUpdate(ctx, nil, desiredSpec(newImage)))
+	state, err := m.readState()
+	require.NoError(err)
+	require.Equal(&UpdateState{Phase: UpdatePhaseDownloading, TargetImage: newImage}, state)
+}
+
+func TestVerifyUpdateRollback(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	rootDir := t.TempDir()
+	fakeClock := clocktesting.NewFakeClock(time.Date(2024, 12, 20, 2, 0, 0, 0, time.UTC))
+	healthCheck := HealthCheckConfig{
+		GracePeriod: util.Duration(10 * time.Minute),
+		Command:     "systemctl is-active app.service",
+		Timeout:     DefaultHealthCheckTimeout,
+	}
+	newVerifyingManager := func() *testManager {
+		m := newTestManager(ctrl, rootDir)
+		m.healthCheck = healthCheck
+		m.clock = fakeClock
+		return m
+	}
+
+	m := newVerifyingManager()
+	require.NoError(m.readWriter.MkdirAll("/var/lib/flightctl", 0o755))
+	require.NoError(m.writeState(&UpdateState{Phase: UpdatePhaseRebooting, TargetImage: targetImage, Attempts: 1}))
+
+	// the device booted into the target image, which is verified instead of committed
+	m.bootc.EXPECT().Status(ctx).Return(bootedInto(targetImage), nil)
+	state, err := m.RecoverUpdate(ctx)
+	require.NoError(err)
+	require.Equal(UpdatePhaseVerifying, state.Phase)
+	require.True(fakeClock.Now().Equal(*state.VerifyingSince))
+
+	// the check fails within the grace period, which is retried
+	failingCheck := func() {
+		m.exec.EXPECT().ExecuteWithContext(gomock.Any(), "/bin/sh", "-c", healthCheck.Command).Return("", "inactive", 3)
+	}
+	failingCheck()
+	require.NoError(m.VerifyUpdate(ctx, true))
+	state, err = m.readState()
+	require.NoError(err)
+	require.Equal(UpdatePhaseVerifying, state.Phase)
+
+	// a restart of the agent does not extend the grace period
+	fakeClock.Step(6 * time.Minute)
+	m = newVerifyingManager()
+	m.bootc.EXPECT().Status(ctx).Return(bootedInto(targetImage), nil)
+	_, err = m.RecoverUpdate(ctx)
+	require.NoError(err)
+
+	// the check still fails once the grace period expired, the device is rolled back
+	fakeClock.Step(5 * time.Minute)
+	failingCheck()
+	m.bootc.EXPECT().Rollback(ctx).Return(nil)
+	require.NoError(m.VerifyUpdate(ctx, true))
+	state, err = m.readState()
+	require.NoError(err)
+	require.Equal(UpdatePhaseRolledBack, state.Phase)
+
+	// once booted into the previous image, the update is given up on
+	m = newVerifyingManager()
+	m.bootc.EXPECT().Status(ctx).Return(bootedInto(bootedImage), nil)
+	state, err = m.RecoverUpdate(ctx)
+	require.NoError(err)
+	require.True(state.Failed())
+	require.Contains(state.FailureMessage(), "rolled back")
+
+	err = m.BeforeUpdate(ctx, nil, desiredSpec(targetImage))
+	require.ErrorIs(err, errors.ErrOSUpdateFailed)
+}
+
+func TestVerifyUpdate(t *testing.T) {
+	verifyingSince := time.Date(2024, 12, 20, 2, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name          string
+		elapsed       time.Duration
+		healthy       bool
+		checkedIn     bool
+		expectedPhase UpdatePhase
+		rollback      bool
+	}{
+		{
+			name:      "healthy and checked in",
+			elapsed:   time.Minute,
+			healthy:   true,
+			checkedIn: true,
+		},
+		{
+			name:          "healthy but not checked in",
+			elapsed:       time.Minute,
+			healthy:       true,
+			expectedPhase: UpdatePhaseVerifying,
+		},
+		{
+			name:          "not checked in after the grace period",
+			elapsed:       10 * time.Minute,
+			healthy:       true,
+			expectedPhase: UpdatePhaseRolledBack,
+			rollback:      true,
+		},
+		{
+			name:      "healthy on the last check",
+			elapsed:   10 * time.Minute,
+			healthy:   true,
+			checkedIn: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+			m := newTestManager(ctrl, t.TempDir())
+			m.healthCheck = HealthCheckConfig{GracePeriod: util.Duration(10 * time.Minute), Command: "true"}
+			m.clock = clocktesting.NewFakeClock(verifyingSince.Add(tt.elapsed))
+			require.NoError(m.readWriter.MkdirAll("/var/lib/flightctl", 0o755))
+			require.NoError(m.writeState(&UpdateState{Phase: UpdatePhaseVerifying, TargetImage: targetImage, Attempts: 1, VerifyingSince: &verifyingSince}))
+
+			exitCode := 0
+			if !tt.healthy {
+				exitCode = 1
+			}
+			m.exec.EXPECT().ExecuteWithContext(gomock.Any(), "/bin/sh", "-c", "true").Return("", "", exitCode)
+			if tt.rollback {
+				m.bootc.EXPECT().Rollback(ctx).Return(nil)
+			}
+			require.NoError(m.VerifyUpdate(ctx, tt.checkedIn))
+
+			state, err := m.readState()
+			require.NoError(err)
+			if tt.expectedPhase == "" {
+				require.Nil(state)
+				return
+			}
+			require.Equal(tt.expectedPhase, state.Phase)
+		})
+	}
+}
+
+func TestHealthCheckConfigValidate(t *testing.T) {
+	require := require.New(t)
+	cfg := NewDefaultHealthCheckConfig()
+	require.NoError(cfg.Validate())
+	require.False(cfg.Enabled())
+
+	cfg.GracePeriod = util.Duration(5 * time.Minute)
+	require.NoError(cfg.Validate())
+	require.True(cfg.Enabled())
+
+	cfg = HealthCheckConfig{Command: "true"}
+	require.ErrorContains(cfg.Validate(), "grace-period")
+
+	cfg = HealthCheckConfig{GracePeriod: util.Duration(-time.Minute)}
+	require.ErrorContains(cfg.Validate(), "grace-period")
+}
@@ -0,0 +1,102 @@
+package os
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+)
+
+const (
+	// UpdateStateFile is the name of the file under the data dir holding the state of an OS update.
+	UpdateStateFile = "os-update.json"
+	// MaxUpdateAttempts is the number of times the agent reboots into a target OS image before it
+	// gives up on the update.
+	MaxUpdateAttempts = 3
+)
+
+// UpdatePhase is the last phase an OS update reached before the agent stopped.
+type UpdatePhase string
+
+const (
+	// UpdatePhaseDownloading is set while the target image is being pulled.
+	UpdatePhaseDownloading UpdatePhase = "Downloading"
+	// UpdatePhaseStaging is set while the target image is being staged for the next boot.
+	UpdatePhaseStaging UpdatePhase = "Staging"
+	// UpdatePhaseRebooting is set once the device is about to reboot into the target image.
+	UpdatePhaseRebooting UpdatePhase = "Rebooting"
+	// UpdatePhaseVerifying is set once the device booted into the target image, while the image is
+	// verified by the health check.
+	UpdatePhaseVerifying UpdatePhase = "Verifying"
+	// UpdatePhaseFailed is set once the device failed to boot into the target image too many times.
+	UpdatePhaseFailed UpdatePhase = "Failed"
+	// UpdatePhaseRolledBack is set once the target image failed its verification and the device
+	// was rolled back to the image it booted before the update.
+	UpdatePhaseRolledBack UpdatePhase = "RolledBack"
+)
+
+// UpdateState is the state of an OS update, persisted so that an update interrupted by a
+// restart or power loss is resumed instead of restarted from scratch.
+type UpdateState struct {
+	Phase       UpdatePhase `json:"phase"`
+	TargetImage string      `json:"targetImage"`
+	// Attempts is the number of times the device rebooted to boot into the target image.
+	Attempts int `json:"attempts"`
+	// VerifyingSince is when the verification of the target image started, so that restarts of
+	// the agent do not extend the grace period.
+	VerifyingSince *time.Time `json:"verifyingSince,omitempty"`
+}
+
+// Failed returns true if the update has been given up on.
+func (s *UpdateState) Failed() bool {
+	return s.Phase == UpdatePhaseFailed || s.Phase == UpdatePhaseRolledBack
+}
+
+// FailureMessage describes why the update has been given up on.
+func (s *UpdateState) FailureMessage() string {
+	if s.Phase == UpdatePhaseRolledBack {
+		return fmt.Sprintf("OS image %s failed its health check and was rolled back", s.TargetImage)
+	}
+	return fmt.Sprintf("Failed to boot into OS image %s after %d attempts", s.TargetImage, s.Attempts)
+}
+
+func (m *manager) readState() (*UpdateState, error) {
+	contents, err := m.readWriter.ReadFile(m.statePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading OS update state: %w", err)
+	}
+	var state UpdateState
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return nil, fmt.Errorf("decoding OS update state: %w", err)
+	}
+	return &state, nil
+}
+
+func (m *manager) writeState(state *UpdateState) error {
+	contents, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encoding OS update state: %w", err)
+	}
+	if err := m.readWriter.WriteFile(m.statePath, contents, fileio.DefaultFilePermissions); err != nil {
+		return fmt.Errorf("writing OS update state: %w", err)
+	}
+	return nil
+}
+
+func (m *manager) clearState() error {
+	if err := m.readWriter.RemoveFile(m.statePath); err != nil {
+		return fmt.Errorf("removing OS update state: %w", err)
+	}
+	return nil
+}
+
+func updateStatePath(dataDir string) string {
+	return filepath.Join(dataDir, UpdateStateFile)
+}
@@ -0,0 +1,134 @@
+package policy
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/util"
+)
+
+// FailurePolicyConfig bounds how often and how many times the agent retries a spec it fails to
+// apply. Each failure delays the next attempt by a backoff that doubles with every failure. Once
+// the limit is reached, the agent quarantines the spec: it stops applying it, reports the update
+// as failed, and waits for a new spec from the management service.
+type FailurePolicyConfig struct {
+	// MaxConsecutiveFailures is the number of consecutive failures to apply the same spec after
+	// which the spec is quarantined. Zero retries the spec forever
+	MaxConsecutiveFailures int `json:"max-consecutive-failures,omitempty"`
+	// Rollback makes the agent re-apply the current spec when it quarantines the desired spec, so
+	// that the device does not stay partially updated while waiting for an operator
+	Rollback bool `json:"rollback,omitempty"`
+	// Backoff is the delay before retrying a spec after its first failure, doubled after each
+	// further failure. Zero retries the spec on the next sync
+	Backoff util.Duration `json:"backoff,omitempty"`
+	// MaxBackoff caps the delay before retrying a spec. Zero leaves it uncapped
+	MaxBackoff util.Duration `json:"max-backoff,omitempty"`
+}
+
+// NewDefaultFailurePolicyConfig returns the default failure policy config, which retries the specs
+// that fail to apply forever.
+func NewDefaultFailurePolicyConfig() FailurePolicyConfig {
+	return FailurePolicyConfig{}
+}
+
+func (c *FailurePolicyConfig) Validate() error {
+	if c.MaxConsecutiveFailures < 0 {
+		return fmt.Errorf("failure-policy max-consecutive-failures must not be negative")
+	}
+	if c.Rollback && c.MaxConsecutiveFailures == 0 {
+		return fmt.Errorf("failure-policy rollback requires max-consecutive-failures")
+	}
+	if c.Backoff < 0 || c.MaxBackoff < 0 {
+		return fmt.Errorf("failure-policy backoff and max-backoff must not be negative")
+	}
+	if c.MaxBackoff > 0 && c.MaxBackoff < c.Backoff {
+		return fmt.Errorf("failure-policy max-backoff must not be less than backoff")
+	}
+	return nil
+}
+
+// FailurePolicy counts the consecutive failures to apply the desired spec, and backs off its
+// retries. The count is kept in memory, so a restart of the agent retries a quarantined spec as
+// many times again. A nil policy never quarantines a spec nor delays its retries.
+type FailurePolicy struct {
+	cfg             FailurePolicyConfig
+	now             func() time.Time
+	renderedVersion string
+	failures        int
+	retryAfter      time.Time
+}
+
+// NewFailurePolicy returns the failure policy of the agent, or nil if specs are retried forever
+// without delay.
+func NewFailurePolicy(cfg FailurePolicyConfig) *FailurePolicy {
+	if cfg.MaxConsecutiveFailures == 0 && cfg.Backoff == 0 {
+		return nil
+	}
+	return &FailurePolicy{cfg: cfg, now: time.Now}
+}
+
+// Failed records a failure to apply the given rendered version, and returns whether the version
+// must now be quarantined. A failure of another version than the previous failures restarts the
+// count. The version is not retried before the backoff of the failure elapsed.
+func (p *FailurePolicy) Failed(renderedVersion string) bool {
+	if p == nil {
+		return false
+	}
+	if renderedVersion != p.renderedVersion {
+		p.renderedVersion = renderedVersion
+		p.failures = 0
+	}
+	p.failures++
+	p.retryAfter = p.now().Add(p.backoff())
+	return p.cfg.MaxConsecutiveFailures > 0 && p.failures >= p.cfg.MaxConsecutiveFailures
+}
+
+// backoff returns the delay before the next retry after the consecutive failures.
+func (p *FailurePolicy) backoff() time.Duration {
+	backoff := time.Duration(p.cfg.Backoff)
+	maxBackoff := time.Duration(p.cfg.MaxBackoff)
+	for i := 1; i < p.failures && backoff > 0 && backoff < math.MaxInt64/2; i++ {
+		if maxBackoff > 0 && backoff >= maxBackoff {
+			break
+		}
+		backoff *= 2
+	}
+	if maxBackoff > 0 {
+		backoff = min(backoff, maxBackoff)
+	}
+	return backoff
+}
+
+// BackingOff returns how long the given rendered version must still wait before it is retried
+// after its last failure, and whether it must wait at all.
+func (p *FailurePolicy) BackingOff(renderedVersion string) (time.Duration, bool) {
+	if p == nil || renderedVersion != p.renderedVersion {
+		return 0, false
+	}
+	wait := p.retryAfter.Sub(p.now())
+	return wait, wait > 0
+}
+
+// Succeeded resets the count of failures once a spec is applied.
+func (p *FailurePolicy) Succeeded() {
+	if p == nil {
+		return
+	}
+	p.renderedVersion = ""
+	p.failures = 0
+	p.retryAfter = time.Time{}
+}
+
+// Failures returns the number of consecutive failures to apply the last failed version.
+func (p *FailurePolicy) Failures() int {
+	if p == nil {
+		return 0
+	}
+	return p.failures
+}
+
+// Rollback returns whether the current spec is re-applied when the desired spec is quarantined.
+func (p *FailurePolicy) Rollback() bool {
+	return p != nil && p.cfg.Rollback
+}
@@ -0,0 +1,87 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailurePolicy(t *testing.T) {
+	require := require.New(t)
+	p := NewFailurePolicy(FailurePolicyConfig{MaxConsecutiveFailures: 3, Rollback: true})
+	require.True(p.Rollback())
+
+	require.False(p.Failed("1"))
+	require.False(p.Failed("1"))
+	// a new version restarts the count
+	require.False(p.Failed("2"))
+	require.False(p.Failed("2"))
+	require.True(p.Failed("2"))
+	require.Equal(3, p.Failures())
+
+	// so does a successful sync
+	p.Succeeded()
+	require.Zero(p.Failures())
+	require.False(p.Failed("2"))
+}
+
+func TestFailurePolicyBackoff(t *testing.T) {
+	require := require.New(t)
+	p := NewFailurePolicy(FailurePolicyConfig{
+		Backoff:    util.Duration(time.Minute),
+		MaxBackoff: util.Duration(3 * time.Minute),
+	})
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	_, backingOff := p.BackingOff("1")
+	require.False(backingOff)
+
+	// the delay doubles with each failure, up to the maximum
+	for _, expected := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+		// without a maximum number of failures the spec is never quarantined
+		require.False(p.Failed("1"))
+		wait, backingOff := p.BackingOff("1")
+		require.True(backingOff)
+		require.Equal(expected, wait)
+	}
+
+	// another version is not delayed
+	_, backingOff = p.BackingOff("2")
+	require.False(backingOff)
+
+	now = now.Add(3 * time.Minute)
+	_, backingOff = p.BackingOff("1")
+	require.False(backingOff)
+
+	require.False(p.Failed("1"))
+	p.Succeeded()
+	_, backingOff = p.BackingOff("1")
+	require.False(backingOff)
+}
+
+func TestFailurePolicyDisabled(t *testing.T) {
+	require := require.New(t)
+	p := NewFailurePolicy(NewDefaultFailurePolicyConfig())
+	require.Nil(p)
+	for i := 0; i < 10; i++ {
+		require.False(p.Failed("1"))
+	}
+	require.Zero(p.Failures())
+	require.False(p.Rollback())
+	p.Succeeded()
+}
+
+func TestFailurePolicyConfigValidate(t *testing.T) {
+	require := require.New(t)
+	cfg := NewDefaultFailurePolicyConfig()
+	require.NoError(cfg.Validate())
+	require.NoError((&FailurePolicyConfig{MaxConsecutiveFailures: 5, Rollback: true}).Validate())
+	require.Error((&FailurePolicyConfig{MaxConsecutiveFailures: -1}).Validate())
+	require.Error((&FailurePolicyConfig{Rollback: true}).Validate())
+	require.NoError((&FailurePolicyConfig{Backoff: util.Duration(time.Second), MaxBackoff: util.Duration(time.Minute)}).Validate())
+	require.Error((&FailurePolicyConfig{Backoff: util.Duration(-time.Second)}).Validate())
+	require.Error((&FailurePolicyConfig{Backoff: util.Duration(time.Minute), MaxBackoff: util.Duration(time.Second)}).Validate())
+}
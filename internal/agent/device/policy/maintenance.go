@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/robfig/cron/v3"
+	"k8s.io/utils/clock"
+)
+
+// MaintenanceWindowConfig restricts the updates of the device to the windows approved by the
+// operator of the device. Specs are still fetched and their dependencies downloaded outside of
+// the windows, but they are only applied within one.
+type MaintenanceWindowConfig struct {
+	// Schedule is the cron expression of when the windows open, e.g. "0 2 * * *" for 2am. Updates
+	// are applied at any time when empty.
+	Schedule string `json:"schedule,omitempty"`
+	// Duration is how long each window stays open
+	Duration util.Duration `json:"duration,omitempty"`
+	// TimeZone is the IANA time zone of the schedule, e.g. "Europe/Berlin". The local time zone of
+	// the device is used when empty.
+	TimeZone string `json:"time-zone,omitempty"`
+}
+
+// NewDefaultMaintenanceWindowConfig returns the default maintenance window config, which applies
+// updates at any time.
+func NewDefaultMaintenanceWindowConfig() MaintenanceWindowConfig {
+	return MaintenanceWindowConfig{}
+}
+
+// Validate checks that the schedule, duration and time zone of the window are valid.
+func (c *MaintenanceWindowConfig) Validate() error {
+	if c.Schedule == "" {
+		return nil
+	}
+	if _, err := parseCron(c.Schedule); err != nil {
+		return fmt.Errorf("invalid maintenance-window schedule %q: %w", c.Schedule, err)
+	}
+	if c.Duration <= 0 {
+		return fmt.Errorf("maintenance-window duration must be positive")
+	}
+	if _, err := loadLocation(c.TimeZone); err != nil {
+		return fmt.Errorf("invalid maintenance-window time-zone %q: %w", c.TimeZone, err)
+	}
+	return nil
+}
+
+// MaintenanceWindow tells whether updates may be applied now. A nil window is always open.
+type MaintenanceWindow struct {
+	cron     cron.Schedule
+	duration time.Duration
+	location *time.Location
+	clock    clock.Clock
+}
+
+// NewMaintenanceWindow returns the window of the given config, or nil if no schedule is set.
+func NewMaintenanceWindow(cfg MaintenanceWindowConfig, clock clock.Clock) (*MaintenanceWindow, error) {
+	if cfg.Schedule == "" {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	schedule, err := parseCron(cfg.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	location, err := loadLocation(cfg.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	return &MaintenanceWindow{
+		cron:     schedule,
+		duration: time.Duration(cfg.Duration),
+		location: location,
+		clock:    clock,
+	}, nil
+}
+
+// IsOpen reports whether a window is open now.
+func (w *MaintenanceWindow) IsOpen() bool {
+	if w == nil {
+		return true
+	}
+	now := w.clock.Now().In(w.location)
+	return w.isOpenAt(now)
+}
+
+// NextOpen returns when the next window opens, or now if a window is open.
+func (w *MaintenanceWindow) NextOpen() time.Time {
+	if w == nil {
+		return time.Time{}
+	}
+	now := w.clock.Now().In(w.location)
+	if w.isOpenAt(now) {
+		return now
+	}
+	return w.cron.Next(now)
+}
+
+// isOpenAt reports whether a window opened within the duration before now: the first opening
+// after now-duration must not be later than now.
+func (w *MaintenanceWindow) isOpenAt(now time.Time) bool {
+	opened := w.cron.Next(now.Add(-w.duration))
+	return !opened.After(now)
+}
+
+func parseCron(expr string) (cron.Schedule, error) {
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	return parser.Parse(expr)
+}
+
+func loadLocation(timeZone string) (*time.Location, error) {
+	if timeZone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(timeZone)
+}
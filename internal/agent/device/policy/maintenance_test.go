@@ -0,0 +1,101 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	require := require.New(t)
+	berlinLoc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(err)
+
+	cfg := MaintenanceWindowConfig{
+		Schedule: "0 2 * * *", // 2am
+		Duration: util.Duration(2 * time.Hour),
+		TimeZone: "Europe/Berlin",
+	}
+
+	testCases := []struct {
+		name             string
+		currentTime      time.Time
+		expectedOpen     bool
+		expectedNextOpen time.Time
+	}{
+		{
+			name:             "closed: before the window",
+			currentTime:      time.Date(2024, 12, 20, 1, 59, 0, 0, berlinLoc),
+			expectedOpen:     false,
+			expectedNextOpen: time.Date(2024, 12, 20, 2, 0, 0, 0, berlinLoc),
+		},
+		{
+			name:             "open: window opens",
+			currentTime:      time.Date(2024, 12, 20, 2, 0, 0, 0, berlinLoc),
+			expectedOpen:     true,
+			expectedNextOpen: time.Date(2024, 12, 20, 2, 0, 0, 0, berlinLoc),
+		},
+		{
+			name:             "open: within the window",
+			currentTime:      time.Date(2024, 12, 20, 3, 59, 59, 0, berlinLoc),
+			expectedOpen:     true,
+			expectedNextOpen: time.Date(2024, 12, 20, 3, 59, 59, 0, berlinLoc),
+		},
+		{
+			name:             "closed: window closes",
+			currentTime:      time.Date(2024, 12, 20, 4, 0, 0, 0, berlinLoc),
+			expectedOpen:     false,
+			expectedNextOpen: time.Date(2024, 12, 21, 2, 0, 0, 0, berlinLoc),
+		},
+		{
+			name:             "closed: business hours in another time zone",
+			currentTime:      time.Date(2024, 12, 20, 14, 30, 0, 0, time.UTC), // 3:30pm in Berlin
+			expectedOpen:     false,
+			expectedNextOpen: time.Date(2024, 12, 21, 2, 0, 0, 0, berlinLoc),
+		},
+		{
+			name:             "open: within the window in another time zone",
+			currentTime:      time.Date(2024, 12, 20, 1, 30, 0, 0, time.UTC), // 2:30am in Berlin
+			expectedOpen:     true,
+			expectedNextOpen: time.Date(2024, 12, 20, 2, 30, 0, 0, berlinLoc),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			window, err := NewMaintenanceWindow(cfg, clocktesting.NewFakeClock(tc.currentTime))
+			require.NoError(err)
+			require.Equal(tc.expectedOpen, window.IsOpen())
+			require.True(tc.expectedNextOpen.Equal(window.NextOpen()), "next open: %s", window.NextOpen())
+		})
+	}
+}
+
+func TestMaintenanceWindowDisabled(t *testing.T) {
+	require := require.New(t)
+	window, err := NewMaintenanceWindow(NewDefaultMaintenanceWindowConfig(), clocktesting.NewFakeClock(time.Now()))
+	require.NoError(err)
+	require.Nil(window)
+	require.True(window.IsOpen())
+}
+
+func TestMaintenanceWindowConfigValidate(t *testing.T) {
+	require := require.New(t)
+	cfg := NewDefaultMaintenanceWindowConfig()
+	require.NoError(cfg.Validate())
+
+	cfg = MaintenanceWindowConfig{Schedule: "0 2 * * *", Duration: util.Duration(time.Hour)}
+	require.NoError(cfg.Validate())
+
+	cfg.Schedule = "at 2am"
+	require.ErrorContains(cfg.Validate(), "schedule")
+
+	cfg = MaintenanceWindowConfig{Schedule: "0 2 * * *"}
+	require.ErrorContains(cfg.Validate(), "duration")
+
+	cfg = MaintenanceWindowConfig{Schedule: "0 2 * * *", Duration: util.Duration(time.Hour), TimeZone: "Mars/Olympus"}
+	require.ErrorContains(cfg.Validate(), "time-zone")
+}
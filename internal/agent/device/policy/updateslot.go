@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/util"
+)
+
+// UpdateSlotConfig makes the device request an update slot from the management service before it
+// applies an update. The service hands out as many slots per fleet, or per group of the fleet such
+// as a site, as the disruption allowance of the fleet's rollout policy permits.
+type UpdateSlotConfig struct {
+	// Enabled makes the agent wait for an update slot before it applies an update
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// NewDefaultUpdateSlotConfig returns the default update slot config, which applies updates
+// without requesting a slot.
+func NewDefaultUpdateSlotConfig() UpdateSlotConfig {
+	return UpdateSlotConfig{}
+}
+
+// UpdateSlot acquires and releases the update slot of the device. A nil slot always grants updates.
+type UpdateSlot struct {
+	client     client.Management
+	deviceName string
+	held       bool
+}
+
+// NewUpdateSlot returns the update slot of the device, or nil if update slots are not enabled.
+func NewUpdateSlot(cfg UpdateSlotConfig, client client.Management, deviceName string) *UpdateSlot {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &UpdateSlot{
+		client:     client,
+		deviceName: deviceName,
+	}
+}
+
+// Acquire requests an update slot from the service. It returns ErrWaitingForUpdateSlot while the
+// slots of the device's group are held by other devices. Acquiring a slot the device already holds
+// succeeds, and services that predate update slots grant every update.
+func (u *UpdateSlot) Acquire(ctx context.Context) error {
+	if u == nil {
+		return nil
+	}
+	slot, err := u.client.AcquireDeviceUpdateSlot(ctx, u.deviceName)
+	if errors.Is(err, client.ErrUpdateSlotsUnsupported) {
+		return nil
+	}
+	if err != nil {
+		// the device waits for the slot until the service answers, it is not a failure of the update
+		return fmt.Errorf("%w: acquiring update slot: %w", errors.ErrWaitingForUpdateSlot, err)
+	}
+	if !slot.Granted {
+		return fmt.Errorf("%w: all %d update slots of %s are held by other devices",
+			errors.ErrWaitingForUpdateSlot, util.FromPtr(slot.Limit), util.FromPtr(slot.Group))
+	}
+	u.held = true
+	return nil
+}
+
+// Release releases the slot held by the device, if any. The service releases slots that are not
+// released by their device once they expire.
+func (u *UpdateSlot) Release(ctx context.Context) error {
+	if u == nil || !u.held {
+		return nil
+	}
+	err := u.client.ReleaseDeviceUpdateSlot(ctx, u.deviceName)
+	if err != nil && !errors.Is(err, client.ErrUpdateSlotsUnsupported) {
+		return fmt.Errorf("releasing update slot: %w", err)
+	}
+	u.held = false
+	return nil
+}
@@ -0,0 +1,78 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	agenterrors "github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateSlot(t *testing.T) {
+	testCases := []struct {
+		name          string
+		slot          *v1alpha1.DeviceUpdateSlot
+		acquireErr    error
+		expectedErr   error
+		expectRelease bool
+	}{
+		{
+			name:          "granted",
+			slot:          &v1alpha1.DeviceUpdateSlot{Granted: true},
+			expectRelease: true,
+		},
+		{
+			name:        "all slots held",
+			slot:        &v1alpha1.DeviceUpdateSlot{Granted: false, Group: util.StrToPtr("Fleet/fleet"), Limit: util.IntToPtr(2)},
+			expectedErr: agenterrors.ErrWaitingForUpdateSlot,
+		},
+		{
+			name:       "service without update slots",
+			acquireErr: client.ErrUpdateSlotsUnsupported,
+		},
+		{
+			name:        "service unreachable",
+			acquireErr:  errors.New("connection refused"),
+			expectedErr: agenterrors.ErrWaitingForUpdateSlot,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+			mockClient := client.NewMockManagement(ctrl)
+			slot := NewUpdateSlot(UpdateSlotConfig{Enabled: true}, mockClient, "device")
+
+			mockClient.EXPECT().AcquireDeviceUpdateSlot(ctx, "device").Return(tc.slot, tc.acquireErr)
+			if tc.expectRelease {
+				mockClient.EXPECT().ReleaseDeviceUpdateSlot(ctx, "device").Return(nil)
+			}
+
+			err := slot.Acquire(ctx)
+			if tc.expectedErr != nil {
+				require.ErrorIs(err, tc.expectedErr)
+				require.True(agenterrors.IsRetryable(err))
+			} else {
+				require.NoError(err)
+			}
+			// only a held slot is released, and only once
+			require.NoError(slot.Release(ctx))
+			require.NoError(slot.Release(ctx))
+		})
+	}
+}
+
+func TestUpdateSlotDisabled(t *testing.T) {
+	require := require.New(t)
+	slot := NewUpdateSlot(NewDefaultUpdateSlotConfig(), nil, "device")
+	require.Nil(slot)
+	require.NoError(slot.Acquire(context.Background()))
+	require.NoError(slot.Release(context.Background()))
+}
@@ -0,0 +1,212 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+const (
+	// DefaultPowerSupplyPath is the sysfs directory of the power supplies of the device.
+	DefaultPowerSupplyPath = "/sys/class/power_supply"
+
+	// meteredTimeout bounds the query of the metered state to NetworkManager.
+	meteredTimeout = 5 * time.Second
+)
+
+// UpdateDeferralConfig configures the deferral of updates while the device runs low on battery
+// or is connected through a metered connection, for battery-powered and cellular devices.
+type UpdateDeferralConfig struct {
+	// MinBatteryPercent is the minimum battery charge required to apply an update while the
+	// device is not charging, zero disables the check
+	MinBatteryPercent int64 `json:"min-battery-percent,omitempty"`
+	// DeferOnMetered defers updates while NetworkManager reports the connection as metered
+	DeferOnMetered bool `json:"defer-on-metered,omitempty"`
+	// MaxDeferral is how long an update is deferred at most before it is applied regardless,
+	// zero defers it for as long as the conditions hold
+	MaxDeferral util.Duration `json:"max-deferral,omitempty"`
+	// PowerSupplyPath is the sysfs directory of the power supplies, defaults to
+	// /sys/class/power_supply
+	PowerSupplyPath string `json:"power-supply-path,omitempty"`
+}
+
+// Validate checks that the threshold is a percentage and the maximum deferral is not negative.
+func (c *UpdateDeferralConfig) Validate() error {
+	if c.MinBatteryPercent < 0 || c.MinBatteryPercent > 100 {
+		return fmt.Errorf("update-deferral min-battery-percent must be between 0 and 100")
+	}
+	if c.MaxDeferral < 0 {
+		return fmt.Errorf("update-deferral max-deferral must not be negative")
+	}
+	return nil
+}
+
+// PowerState is the state of the batteries of the device.
+type PowerState struct {
+	// HasBattery is true if the device has at least one battery
+	HasBattery bool
+	// Charging is true if the device runs on external power
+	Charging bool
+	// BatteryPercent is the charge of the least charged battery
+	BatteryPercent int64
+}
+
+// UpdateDeferral defers updates while applying them would drain the battery or use up a metered
+// data plan, until the conditions clear or the maximum deferral elapsed.
+type UpdateDeferral struct {
+	minBatteryPercent int64
+	deferOnMetered    bool
+	maxDeferral       time.Duration
+
+	powerFn   func() (*PowerState, error)
+	meteredFn func(ctx context.Context) (bool, error)
+	now       func() time.Time
+
+	// the rendered version deferred, and since when
+	deferredVersion string
+	deferredSince   time.Time
+
+	log *log.PrefixLogger
+}
+
+func NewUpdateDeferral(log *log.PrefixLogger, exec executer.Executer, cfg UpdateDeferralConfig) *UpdateDeferral {
+	path := cfg.PowerSupplyPath
+	if path == "" {
+		path = DefaultPowerSupplyPath
+	}
+	return &UpdateDeferral{
+		minBatteryPercent: cfg.MinBatteryPercent,
+		deferOnMetered:    cfg.DeferOnMetered,
+		maxDeferral:       time.Duration(cfg.MaxDeferral),
+		powerFn:           func() (*PowerState, error) { return readPowerState(path) },
+		meteredFn:         func(ctx context.Context) (bool, error) { return readMetered(ctx, exec) },
+		now:               time.Now,
+		log:               log,
+	}
+}
+
+// Check returns an error wrapping ErrUpdateDeferred with the reason if the update to the given
+// rendered version should be deferred. Failures to read the state of the device are logged and
+// do not defer the update, so a misreading device is not kept from updating.
+func (d *UpdateDeferral) Check(ctx context.Context, version string) error {
+	if d == nil || (d.minBatteryPercent == 0 && !d.deferOnMetered) {
+		return nil
+	}
+
+	reason := d.reason(ctx)
+	if reason == "" {
+		d.deferredVersion = ""
+		return nil
+	}
+
+	now := d.now()
+	if d.deferredVersion != version {
+		d.deferredVersion = version
+		d.deferredSince = now
+	}
+	if d.maxDeferral > 0 && now.Sub(d.deferredSince) >= d.maxDeferral {
+		d.log.Warnf("Applying update to renderedVersion %s deferred for %s although %s", version, d.maxDeferral, reason)
+		return nil
+	}
+	return fmt.Errorf("%w: %s", errors.ErrUpdateDeferred, reason)
+}
+
+// reason returns why the update should be deferred, or an empty string if it should not.
+func (d *UpdateDeferral) reason(ctx context.Context) string {
+	if d.minBatteryPercent > 0 {
+		power, err := d.powerFn()
+		if err != nil {
+			d.log.Warnf("Failed to read the battery state: %v", err)
+		} else if power.HasBattery && !power.Charging && power.BatteryPercent < d.minBatteryPercent {
+			return fmt.Sprintf("battery at %d%%, %d%% required while not charging", power.BatteryPercent, d.minBatteryPercent)
+		}
+	}
+	if d.deferOnMetered {
+		metered, err := d.meteredFn(ctx)
+		if err != nil {
+			d.log.Warnf("Failed to read the metered state of the connection: %v", err)
+		} else if metered {
+			return "connection is metered"
+		}
+	}
+	return ""
+}
+
+// readPowerState reads the power supplies from sysfs. A device is charging if any external
+// power supply is online or a battery reports it is charging or full.
+func readPowerState(path string) (*PowerState, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &PowerState{}, nil
+		}
+		return nil, err
+	}
+
+	state := &PowerState{BatteryPercent: 100}
+	for _, entry := range entries {
+		supply := filepath.Join(path, entry.Name())
+		switch readSysfsValue(supply, "type") {
+		case "Battery":
+			if readSysfsValue(supply, "scope") == "Device" {
+				// batteries of peripherals, e.g. a wireless mouse
+				continue
+			}
+			capacity, err := strconv.ParseInt(readSysfsValue(supply, "capacity"), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("reading capacity of %s: %w", entry.Name(), err)
+			}
+			state.HasBattery = true
+			state.BatteryPercent = min(state.BatteryPercent, capacity)
+			switch readSysfsValue(supply, "status") {
+			case "Charging", "Full":
+				state.Charging = true
+			}
+		case "Mains", "USB":
+			if readSysfsValue(supply, "online") == "1" {
+				state.Charging = true
+			}
+		}
+	}
+	return state, nil
+}
+
+func readSysfsValue(dir, name string) string {
+	value, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(value))
+}
+
+// readMetered queries NetworkManager whether the primary connection is metered. NetworkManager
+// reports 1 for metered, 3 for guessed metered, e.g. for a cellular modem or a phone hotspot.
+func readMetered(ctx context.Context, exec executer.Executer) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, meteredTimeout)
+	defer cancel()
+	stdout, stderr, exitCode := exec.ExecuteWithContext(ctx, "busctl", "get-property",
+		"org.freedesktop.NetworkManager", "/org/freedesktop/NetworkManager", "org.freedesktop.NetworkManager", "Metered")
+	if exitCode != 0 {
+		return false, fmt.Errorf("querying NetworkManager: %s", strings.TrimSpace(stderr))
+	}
+	// the output is the signature followed by the value, e.g. "u 1"
+	fields := strings.Fields(stdout)
+	if len(fields) != 2 {
+		return false, fmt.Errorf("unexpected output from NetworkManager: %q", strings.TrimSpace(stdout))
+	}
+	switch fields[1] {
+	case "1", "3":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,185 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestUpdateDeferral(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          UpdateDeferralConfig
+		power        *PowerState
+		powerErr     error
+		metered      bool
+		meteredErr   error
+		wantDeferred string
+	}{
+		{
+			name:  "disabled",
+			power: &PowerState{HasBattery: true, BatteryPercent: 5},
+		},
+		{
+			name:  "battery above threshold",
+			cfg:   UpdateDeferralConfig{MinBatteryPercent: 30},
+			power: &PowerState{HasBattery: true, BatteryPercent: 50},
+		},
+		{
+			name:         "battery below threshold",
+			cfg:          UpdateDeferralConfig{MinBatteryPercent: 30},
+			power:        &PowerState{HasBattery: true, BatteryPercent: 20},
+			wantDeferred: "battery at 20%, 30% required while not charging",
+		},
+		{
+			name:  "battery below threshold while charging",
+			cfg:   UpdateDeferralConfig{MinBatteryPercent: 30},
+			power: &PowerState{HasBattery: true, Charging: true, BatteryPercent: 20},
+		},
+		{
+			name:  "no battery",
+			cfg:   UpdateDeferralConfig{MinBatteryPercent: 30},
+			power: &PowerState{},
+		},
+		{
+			name:     "battery unreadable",
+			cfg:      UpdateDeferralConfig{MinBatteryPercent: 30},
+			powerErr: fmt.Errorf("permission denied"),
+		},
+		{
+			name:         "metered",
+			cfg:          UpdateDeferralConfig{DeferOnMetered: true},
+			metered:      true,
+			wantDeferred: "connection is metered",
+		},
+		{
+			name:    "metered ignored",
+			cfg:     UpdateDeferralConfig{MinBatteryPercent: 30},
+			power:   &PowerState{},
+			metered: true,
+		},
+		{
+			name:       "metered unreadable",
+			cfg:        UpdateDeferralConfig{DeferOnMetered: true},
+			meteredErr: fmt.Errorf("NetworkManager not running"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			deferral := NewUpdateDeferral(log.NewPrefixLogger("test"), nil, tt.cfg)
+			deferral.powerFn = func() (*PowerState, error) { return tt.power, tt.powerErr }
+			deferral.meteredFn = func(ctx context.Context) (bool, error) { return tt.metered, tt.meteredErr }
+
+			err := deferral.Check(context.Background(), "1")
+			if tt.wantDeferred == "" {
+				require.NoError(err)
+				return
+			}
+			require.ErrorIs(err, errors.ErrUpdateDeferred)
+			require.ErrorContains(err, tt.wantDeferred)
+			require.True(errors.IsRetryable(err))
+		})
+	}
+}
+
+func TestUpdateDeferralMaxDeferral(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	deferral := NewUpdateDeferral(log.NewPrefixLogger("test"), nil, UpdateDeferralConfig{
+		DeferOnMetered: true,
+		MaxDeferral:    util.Duration(24 * time.Hour),
+	})
+	deferral.meteredFn = func(ctx context.Context) (bool, error) { return true, nil }
+	deferral.now = func() time.Time { return now }
+
+	require.ErrorIs(deferral.Check(context.Background(), "1"), errors.ErrUpdateDeferred)
+
+	// a new version restarts the deferral
+	now = now.Add(23 * time.Hour)
+	require.ErrorIs(deferral.Check(context.Background(), "2"), errors.ErrUpdateDeferred)
+	now = now.Add(23 * time.Hour)
+	require.ErrorIs(deferral.Check(context.Background(), "2"), errors.ErrUpdateDeferred)
+
+	// applied once deferred for longer than the maximum
+	now = now.Add(time.Hour)
+	require.NoError(deferral.Check(context.Background(), "2"))
+}
+
+func TestReadPowerState(t *testing.T) {
+	writeSupply := func(t *testing.T, dir, name string, values map[string]string) {
+		supply := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(supply, 0755))
+		for file, value := range values {
+			require.NoError(t, os.WriteFile(filepath.Join(supply, file), []byte(value+"\n"), 0600))
+		}
+	}
+
+	t.Run("discharging battery", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSupply(t, dir, "AC", map[string]string{"type": "Mains", "online": "0"})
+		writeSupply(t, dir, "BAT0", map[string]string{"type": "Battery", "capacity": "42", "status": "Discharging"})
+		writeSupply(t, dir, "hid-mouse", map[string]string{"type": "Battery", "scope": "Device", "capacity": "5", "status": "Discharging"})
+
+		state, err := readPowerState(dir)
+		require.NoError(t, err)
+		require.Equal(t, &PowerState{HasBattery: true, BatteryPercent: 42}, state)
+	})
+
+	t.Run("on external power", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSupply(t, dir, "AC", map[string]string{"type": "Mains", "online": "1"})
+		writeSupply(t, dir, "BAT0", map[string]string{"type": "Battery", "capacity": "42", "status": "Not charging"})
+
+		state, err := readPowerState(dir)
+		require.NoError(t, err)
+		require.Equal(t, &PowerState{HasBattery: true, Charging: true, BatteryPercent: 42}, state)
+	})
+
+	t.Run("no power supplies", func(t *testing.T) {
+		state, err := readPowerState(filepath.Join(t.TempDir(), "missing"))
+		require.NoError(t, err)
+		require.False(t, state.HasBattery)
+	})
+}
+
+func TestReadMetered(t *testing.T) {
+	tests := []struct {
+		name    string
+		stdout  string
+		want    bool
+		wantErr bool
+	}{
+		{name: "metered", stdout: "u 1\n", want: true},
+		{name: "guessed metered", stdout: "u 3\n", want: true},
+		{name: "not metered", stdout: "u 2\n"},
+		{name: "unknown", stdout: "u 0\n"},
+		{name: "unexpected output", stdout: "garbage", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecuter := executer.NewMockExecuter(gomock.NewController(t))
+			mockExecuter.EXPECT().ExecuteWithContext(gomock.Any(), "busctl", "get-property",
+				"org.freedesktop.NetworkManager", "/org/freedesktop/NetworkManager", "org.freedesktop.NetworkManager", "Metered").
+				Return(tt.stdout, "", 0)
+
+			metered, err := readMetered(context.Background(), mockExecuter)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, metered)
+		})
+	}
+}
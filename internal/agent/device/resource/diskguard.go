@@ -0,0 +1,70 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+const (
+	// DefaultDiskGuardPath is the filesystem holding the container and OS image storage.
+	DefaultDiskGuardPath = "/var"
+)
+
+// DiskGuardConfig configures the check of the free disk space before an update is applied.
+type DiskGuardConfig struct {
+	// MinFreePercent is the minimum percentage of free disk space required to apply an update,
+	// zero disables the check
+	MinFreePercent int64 `json:"min-free-percent,omitempty"`
+	// Path is the path of the filesystem that is checked, defaults to /var
+	Path string `json:"path,omitempty"`
+}
+
+// Validate checks that the threshold is a percentage.
+func (c *DiskGuardConfig) Validate() error {
+	if c.MinFreePercent < 0 || c.MinFreePercent > 100 {
+		return fmt.Errorf("disk-guard min-free-percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// DiskGuard refuses updates while the device is low on disk space, as pulling new images could
+// fill up the disk and leave the device unusable.
+type DiskGuard struct {
+	minFreePercent int64
+	path           string
+	usageFn        func(path string) (*DiskUsage, error)
+	log            *log.PrefixLogger
+}
+
+func NewDiskGuard(log *log.PrefixLogger, cfg DiskGuardConfig) *DiskGuard {
+	path := cfg.Path
+	if path == "" {
+		path = DefaultDiskGuardPath
+	}
+	return &DiskGuard{
+		minFreePercent: cfg.MinFreePercent,
+		path:           path,
+		usageFn:        getDirUsage,
+		log:            log,
+	}
+}
+
+// Check returns a retryable error wrapping ErrInsufficientDiskSpace if the free disk space is
+// below the threshold.
+func (g *DiskGuard) Check() error {
+	if g == nil || g.minFreePercent == 0 {
+		return nil
+	}
+	usage, err := g.usageFn(g.path)
+	if err != nil {
+		return fmt.Errorf("%w: collecting disk usage of %s: %w", errors.ErrRetryable, g.path, err)
+	}
+	freePercent := 100 - percentageDiskUsed(usage.Free, usage.Total)
+	if freePercent < g.minFreePercent {
+		return fmt.Errorf("%w: %d%% free on %s, %d%% required", errors.ErrInsufficientDiskSpace, freePercent, g.path, g.minFreePercent)
+	}
+	g.log.Debugf("Disk space check passed: %d%% free on %s", freePercent, g.path)
+	return nil
+}
@@ -0,0 +1,69 @@
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskGuard(t *testing.T) {
+	tests := []struct {
+		name           string
+		minFreePercent int64
+		usage          *DiskUsage
+		usageErr       error
+		wantErr        error
+	}{
+		{
+			name:           "above threshold",
+			minFreePercent: 10,
+			usage:          &DiskUsage{Total: 100, Free: 50},
+		},
+		{
+			name:           "at threshold",
+			minFreePercent: 10,
+			usage:          &DiskUsage{Total: 100, Free: 10},
+		},
+		{
+			name:           "below threshold",
+			minFreePercent: 10,
+			usage:          &DiskUsage{Total: 100, Free: 5},
+			wantErr:        errors.ErrInsufficientDiskSpace,
+		},
+		{
+			name:           "disabled",
+			minFreePercent: 0,
+			usage:          &DiskUsage{Total: 100, Free: 0},
+		},
+		{
+			name:           "usage error",
+			minFreePercent: 10,
+			usageErr:       fmt.Errorf("statfs failed"),
+			wantErr:        fmt.Errorf("statfs failed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			guard := NewDiskGuard(log.NewPrefixLogger("test"), DiskGuardConfig{MinFreePercent: tt.minFreePercent})
+			guard.usageFn = func(path string) (*DiskUsage, error) {
+				require.Equal(DefaultDiskGuardPath, path)
+				return tt.usage, tt.usageErr
+			}
+			err := guard.Check()
+			if tt.wantErr == nil {
+				require.NoError(err)
+				return
+			}
+			require.ErrorContains(err, tt.wantErr.Error())
+			require.True(errors.IsRetryable(err))
+		})
+	}
+
+	// a nil guard does not check anything
+	var guard *DiskGuard
+	require.NoError(t, guard.Check())
+}
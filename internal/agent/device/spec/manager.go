@@ -14,6 +14,7 @@ import (
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
 	"github.com/flightctl/flightctl/internal/agent/device/policy"
 	"github.com/flightctl/flightctl/internal/container"
+	"github.com/flightctl/flightctl/internal/util"
 	"github.com/flightctl/flightctl/pkg/log"
 	"golang.org/x/net/context"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -332,7 +333,7 @@ func (s *manager) getSpecFromQueue(ctx context.Context) (*v1alpha1.RenderedDevic
 
 	// if this is a new version ensure we persist it to disk
 	if desired.RenderedVersion != s.cache.getRenderedVersion(Desired) {
-		s.log.Infof("Writing new desired rendered spec to disk version: %s", desired.RenderedVersion)
+		s.log.Infof("Writing new desired rendered spec to disk version: %s request_id: %s", desired.RenderedVersion, util.FromPtr(desired.RequestID))
 		if err := s.write(Desired, desired); err != nil {
 			return nil, false, err
 		}
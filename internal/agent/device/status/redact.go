@@ -0,0 +1,125 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	agentclient "github.com/flightctl/flightctl/internal/api/client/agent"
+)
+
+// ValidateRedactedFields checks that each path, e.g. "summary.info", names an optional field of
+// the device status. Fields required by the API cannot be redacted because the service would
+// reject the status update.
+func ValidateRedactedFields(paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	swagger, err := v1alpha1.GetSwagger()
+	if err != nil {
+		return fmt.Errorf("loading API spec: %w", err)
+	}
+	statusSchema, ok := swagger.Components.Schemas["DeviceStatus"]
+	if !ok {
+		return fmt.Errorf("device status schema not found")
+	}
+
+	for _, path := range paths {
+		schema := statusSchema.Value
+		fields := strings.Split(path, ".")
+		for i, field := range fields {
+			if schema == nil || schema.Properties[field] == nil {
+				return fmt.Errorf("status field %q does not exist", path)
+			}
+			if i == len(fields)-1 && slices.Contains(schema.Required, field) {
+				return fmt.Errorf("status field %q is required and cannot be redacted", path)
+			}
+			schema = schema.Properties[field].Value
+		}
+	}
+	return nil
+}
+
+// redactFields returns a request editor that removes the fields at the given paths from the
+// status of the device sent in the request body.
+func redactFields(paths []string) agentclient.RequestEditorFn {
+	return editStatus(func(status map[string]interface{}) {
+		for _, path := range paths {
+			deleteField(status, strings.Split(path, "."))
+		}
+	})
+}
+
+// redactValues returns a request editor that applies redact to every string of the status of
+// the device sent in the request body.
+func redactValues(redact func(string) string) agentclient.RequestEditorFn {
+	return editStatus(func(status map[string]interface{}) {
+		redactStrings(status, redact)
+	})
+}
+
+// editStatus returns a request editor that applies edit to the status of the device sent in
+// the request body.
+func editStatus(edit func(status map[string]interface{})) agentclient.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		if req.Body == nil {
+			return nil
+		}
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return fmt.Errorf("reading request body: %w", err)
+		}
+		req.Body.Close()
+
+		var device map[string]interface{}
+		if err := json.Unmarshal(body, &device); err != nil {
+			return fmt.Errorf("decoding device: %w", err)
+		}
+		if status, ok := device["status"].(map[string]interface{}); ok {
+			edit(status)
+		}
+		body, err = json.Marshal(device)
+		if err != nil {
+			return fmt.Errorf("encoding device: %w", err)
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.ContentLength = int64(len(body))
+		return nil
+	}
+}
+
+func redactStrings(value interface{}, redact func(string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return redact(v)
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = redactStrings(child, redact)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactStrings(child, redact)
+		}
+	}
+	return value
+}
+
+func deleteField(obj map[string]interface{}, path []string) {
+	if len(path) == 1 {
+		delete(obj, path[0])
+		return
+	}
+	if child, ok := obj[path[0]].(map[string]interface{}); ok {
+		deleteField(child, path[1:])
+	}
+}
@@ -0,0 +1,115 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	agentclient "github.com/flightctl/flightctl/internal/api/client/agent"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidateRedactedFields(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(ValidateRedactedFields(nil))
+	require.NoError(ValidateRedactedFields([]string{"summary.info", "updated.info"}))
+	require.ErrorContains(ValidateRedactedFields([]string{"summary.hostname"}), "does not exist")
+	require.ErrorContains(ValidateRedactedFields([]string{"systemInfo.bootID"}), "is required")
+	require.ErrorContains(ValidateRedactedFields([]string{"systemInfo"}), "is required")
+}
+
+func TestSyncRedactsStatusFields(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	payloads := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(err)
+		var device map[string]interface{}
+		require.NoError(json.Unmarshal(body, &device))
+		payloads <- device
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	apiClient, err := agentclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	systemClient := client.NewMockSystem(ctrl)
+	systemClient.EXPECT().BootID().Return("boot-id")
+
+	m := NewManager("device", systemClient, log.NewPrefixLogger("test"), WithRedactedFields([]string{"summary.info", "updated.info"}))
+	m.SetClient(client.NewManagement(apiClient))
+	m.device.Status.Summary.Info = util.StrToPtr("connected from 10.0.0.1")
+	m.device.Status.Updated.Info = util.StrToPtr("updated by host.example.com")
+	m.device.Status.Summary.Status = v1alpha1.DeviceSummaryStatusOnline
+
+	require.NoError(m.Sync(context.Background()))
+
+	device := <-payloads
+	status := device["status"].(map[string]interface{})
+	summary := status["summary"].(map[string]interface{})
+	updated := status["updated"].(map[string]interface{})
+	require.NotContains(summary, "info")
+	require.NotContains(updated, "info")
+	require.Equal(string(v1alpha1.DeviceSummaryStatusOnline), summary["status"])
+	require.Equal("boot-id", status["systemInfo"].(map[string]interface{})["bootID"])
+
+	// the local status is left untouched
+	require.Equal("connected from 10.0.0.1", *m.Get(context.Background()).Summary.Info)
+}
+
+func TestSyncRedactsSecretValues(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	payloads := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(err)
+		payloads <- body
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	apiClient, err := agentclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	systemClient := client.NewMockSystem(ctrl)
+	systemClient.EXPECT().BootID().Return("boot-id")
+
+	redact := func(s string) string { return strings.ReplaceAll(s, "hunter2", "[redacted]") }
+	m := NewManager("device", systemClient, log.NewPrefixLogger("test"), WithSecretRedaction(redact))
+	m.SetClient(client.NewManagement(apiClient))
+	m.device.Status.Summary.Info = util.StrToPtr("Failed to sync device: login failed for hunter2")
+	m.device.Status.Conditions = []v1alpha1.Condition{{
+		Type:    v1alpha1.DeviceUpdating,
+		Status:  v1alpha1.ConditionStatusTrue,
+		Message: "error calling secret: hunter2",
+	}}
+
+	require.NoError(m.Sync(context.Background()))
+
+	body := string(<-payloads)
+	require.NotContains(body, "hunter2")
+	require.Contains(body, "login failed for [redacted]")
+	require.Contains(body, "error calling secret: [redacted]")
+
+	// the local status is left untouched
+	require.Equal("Failed to sync device: login failed for hunter2", *m.Get(context.Background()).Summary.Info)
+}
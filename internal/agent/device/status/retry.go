@@ -0,0 +1,108 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/util"
+	"k8s.io/utils/clock"
+)
+
+const (
+	DefaultRetryInitialInterval = 10 * time.Second
+	DefaultRetryMaxInterval     = 5 * time.Minute
+	DefaultRetryJitter          = 0.5
+)
+
+// ErrUploadDeferred is returned when the status is not uploaded because the previous upload
+// failed and the retry delay has not passed yet. The status is sent with the next upload.
+var ErrUploadDeferred = errors.New("status upload deferred")
+
+// RetryConfig bounds the status uploads the agent retries while the management service is
+// unreachable.
+type RetryConfig struct {
+	// InitialInterval is the delay before retrying after the first failed upload
+	InitialInterval util.Duration `json:"initial-interval,omitempty"`
+	// MaxInterval caps the delay between two retries, which doubles with every failed upload
+	MaxInterval util.Duration `json:"max-interval,omitempty"`
+	// Jitter is the fraction, between 0 and 1, by which the delays are randomized so that the
+	// devices of a fleet do not all upload their status as soon as the service recovers
+	Jitter float64 `json:"jitter,omitempty"`
+}
+
+// NewDefaultRetryConfig returns the default status upload retry config.
+func NewDefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		InitialInterval: util.Duration(DefaultRetryInitialInterval),
+		MaxInterval:     util.Duration(DefaultRetryMaxInterval),
+		Jitter:          DefaultRetryJitter,
+	}
+}
+
+// Validate checks that the retry delays are consistent.
+func (c *RetryConfig) Validate() error {
+	if c.InitialInterval < 0 {
+		return fmt.Errorf("status-retry initial-interval must not be negative")
+	}
+	if c.MaxInterval < c.InitialInterval {
+		return fmt.Errorf("status-retry max-interval must not be less than initial-interval")
+	}
+	if c.Jitter < 0 || c.Jitter > 1 {
+		return fmt.Errorf("status-retry jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// retryBudget spaces the status uploads while they fail. Failed uploads are not queued: the
+// status is coalesced into the latest one, which is sent once the jittered delay has passed.
+type retryBudget struct {
+	cfg    RetryConfig
+	clock  clock.Clock
+	random func() float64
+
+	mu          sync.Mutex
+	failures    int
+	nextAttempt time.Time
+}
+
+func newRetryBudget(cfg RetryConfig, clock clock.Clock) *retryBudget {
+	return &retryBudget{
+		cfg:    cfg,
+		clock:  clock,
+		random: rand.Float64, //nolint:gosec
+	}
+}
+
+// wait returns how long to wait before the next upload attempt.
+func (b *retryBudget) wait() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.nextAttempt.Sub(b.clock.Now())
+}
+
+// succeeded resets the budget after a successful upload.
+func (b *retryBudget) succeeded() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.nextAttempt = time.Time{}
+}
+
+// failed records a failed upload and returns the delay before the next attempt.
+func (b *retryBudget) failed() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	delay := time.Duration(b.cfg.InitialInterval)
+	for i := 1; i < b.failures && delay < time.Duration(b.cfg.MaxInterval); i++ {
+		delay *= 2
+	}
+	delay = min(delay, time.Duration(b.cfg.MaxInterval))
+	// spread the delay over [delay*(1-jitter), delay*(1+jitter)]
+	delay = time.Duration(float64(delay) * (1 + b.cfg.Jitter*(2*b.random()-1)))
+	b.nextAttempt = b.clock.Now().Add(delay)
+	return delay
+}
@@ -0,0 +1,109 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	agentclient "github.com/flightctl/flightctl/internal/api/client/agent"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestUploadCoalescesStatusWhileOffline(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	systemClient := client.NewMockSystem(ctrl)
+	systemClient.EXPECT().BootID().Return("boot-id")
+	managementClient := client.NewMockManagement(ctrl)
+
+	cfg := RetryConfig{InitialInterval: util.Duration(10 * time.Second), MaxInterval: util.Duration(time.Minute)}
+	m := NewManager("device", systemClient, log.NewPrefixLogger("test"), WithRetryConfig(cfg))
+	clock := clocktesting.NewFakeClock(time.Now())
+	m.retry.clock = clock
+	m.SetClient(managementClient)
+
+	// the service is down: the first upload fails, the following ones are deferred
+	managementClient.EXPECT().UpdateDeviceStatus(gomock.Any(), "device", gomock.Any()).Return(errors.New("connection refused"))
+	_, err := m.Update(ctx, SetDeviceSummary(v1alpha1.DeviceSummaryStatus{Status: v1alpha1.DeviceSummaryStatusDegraded}))
+	require.ErrorContains(err, "retrying in 10s")
+	_, err = m.Update(ctx, SetDeviceSummary(v1alpha1.DeviceSummaryStatus{Status: v1alpha1.DeviceSummaryStatusError}))
+	require.ErrorIs(err, ErrUploadDeferred)
+	require.NoError(m.Sync(ctx))
+
+	// the delay doubles with every failure
+	clock.Step(10 * time.Second)
+	managementClient.EXPECT().UpdateDeviceStatus(gomock.Any(), "device", gomock.Any()).Return(errors.New("connection refused"))
+	require.NoError(m.Sync(ctx))
+	require.Equal(20*time.Second, m.retry.wait())
+
+	// once the service is back, only the latest status is uploaded
+	clock.Step(20 * time.Second)
+	var uploaded v1alpha1.Device
+	managementClient.EXPECT().UpdateDeviceStatus(gomock.Any(), "device", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, device v1alpha1.Device, _ ...agentclient.RequestEditorFn) error {
+			uploaded = device
+			return nil
+		})
+	_, err = m.Update(ctx, SetDeviceSummary(v1alpha1.DeviceSummaryStatus{Status: v1alpha1.DeviceSummaryStatusOnline}))
+	require.NoError(err)
+	require.Equal(v1alpha1.DeviceSummaryStatusOnline, uploaded.Status.Summary.Status)
+
+	// the budget is reset after a successful upload
+	managementClient.EXPECT().UpdateDeviceStatus(gomock.Any(), "device", gomock.Any()).Return(nil)
+	require.NoError(m.Sync(ctx))
+}
+
+func TestRetryBudgetJitter(t *testing.T) {
+	require := require.New(t)
+	clock := clocktesting.NewFakeClock(time.Now())
+	cfg := RetryConfig{InitialInterval: util.Duration(10 * time.Second), MaxInterval: util.Duration(time.Minute), Jitter: 0.5}
+
+	delays := func(random float64) []time.Duration {
+		b := newRetryBudget(cfg, clock)
+		b.random = func() float64 { return random }
+		d := []time.Duration{}
+		for i := 0; i < 5; i++ {
+			d = append(d, b.failed())
+		}
+		return d
+	}
+
+	require.Equal([]time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute}, delays(0.5))
+	require.Equal([]time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second}, delays(0))
+	require.Equal([]time.Duration{15 * time.Second, 30 * time.Second, 60 * time.Second, 90 * time.Second, 90 * time.Second}, delays(1))
+
+	// the delays of a fleet are spread over the jitter range
+	b := newRetryBudget(cfg, clock)
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 20; i++ {
+		b.succeeded()
+		delay := b.failed()
+		require.GreaterOrEqual(delay, 5*time.Second)
+		require.LessOrEqual(delay, 15*time.Second)
+		seen[delay] = true
+	}
+	require.Greater(len(seen), 1)
+}
+
+func TestRetryConfigValidate(t *testing.T) {
+	require := require.New(t)
+	cfg := NewDefaultRetryConfig()
+	require.NoError(cfg.Validate())
+
+	cfg.Jitter = 1.5
+	require.Error(cfg.Validate())
+
+	cfg = NewDefaultRetryConfig()
+	cfg.MaxInterval = util.Duration(time.Second)
+	require.Error(cfg.Validate())
+}
@@ -5,24 +5,60 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/agent/client"
+	agentclient "github.com/flightctl/flightctl/internal/api/client/agent"
 	"github.com/flightctl/flightctl/pkg/log"
+	"k8s.io/utils/clock"
 )
 
 var _ Manager = (*StatusManager)(nil)
 
+type ManagerOption func(*StatusManager)
+
+// WithRedactedFields removes the status fields at the given paths, e.g. "summary.info", from
+// the status sent to the management service.
+func WithRedactedFields(paths []string) ManagerOption {
+	return func(m *StatusManager) {
+		if len(paths) > 0 {
+			m.log.Infof("Redacting device status fields: %s", strings.Join(paths, ", "))
+			m.requestEditors = append(m.requestEditors, redactFields(paths))
+		}
+	}
+}
+
+// WithSecretRedaction applies redact to every string of the status sent to the management
+// service, so that the values of device-local secrets never leave the device.
+func WithSecretRedaction(redact func(string) string) ManagerOption {
+	return func(m *StatusManager) {
+		if redact != nil {
+			m.requestEditors = append(m.requestEditors, redactValues(redact))
+		}
+	}
+}
+
+// WithRetryConfig sets how status uploads are retried while the management service is
+// unreachable.
+func WithRetryConfig(cfg RetryConfig) ManagerOption {
+	return func(m *StatusManager) {
+		m.retry.cfg = cfg
+	}
+}
+
 // NewManager creates a new device status manager.
 func NewManager(
 	deviceName string,
 	systemClient client.System,
 	log *log.PrefixLogger,
+	opts ...ManagerOption,
 ) *StatusManager {
 	status := v1alpha1.NewDeviceStatus()
 	bootID := systemClient.BootID()
 	systemInfoStatus(bootID, &status)
-	return &StatusManager{
+	m := &StatusManager{
 		deviceName: deviceName,
 		device: &v1alpha1.Device{
 			Metadata: v1alpha1.ObjectMeta{
@@ -30,8 +66,13 @@ func NewManager(
 			},
 			Status: &status,
 		},
-		log: log,
+		log:   log,
+		retry: newRetryBudget(NewDefaultRetryConfig(), clock.RealClock{}),
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m
 }
 
 // Collector aggregates device status from various exporters.
@@ -41,6 +82,8 @@ type StatusManager struct {
 	exporters        []Exporter
 	log              *log.PrefixLogger
 	device           *v1alpha1.Device
+	requestEditors   []agentclient.RequestEditorFn
+	retry            *retryBudget
 }
 
 type Exporter interface {
@@ -114,12 +157,30 @@ func (m *StatusManager) Sync(ctx context.Context) error {
 		m.log.Warn("management client not set")
 		return nil
 	}
-	if err := m.managementClient.UpdateDeviceStatus(ctx, m.deviceName, *m.device); err != nil {
+	if err := m.upload(ctx); err != nil {
+		if errors.Is(err, ErrUploadDeferred) {
+			m.log.Debugf("Not updating device status: %v", err)
+			return nil
+		}
 		m.log.Warnf("Failed to update device status: %v", err)
 	}
 	return nil
 }
 
+// upload sends the device status to the management service, unless a previous upload failed
+// and the retry delay has not passed yet.
+func (m *StatusManager) upload(ctx context.Context) error {
+	if wait := m.retry.wait(); wait > 0 {
+		return fmt.Errorf("%w: retrying in %s", ErrUploadDeferred, wait.Round(time.Second))
+	}
+	if err := m.managementClient.UpdateDeviceStatus(ctx, m.deviceName, *m.device, m.requestEditors...); err != nil {
+		delay := m.retry.failed()
+		return fmt.Errorf("%w (retrying in %s)", err, delay.Round(time.Second))
+	}
+	m.retry.succeeded()
+	return nil
+}
+
 func (m *StatusManager) UpdateCondition(ctx context.Context, condition v1alpha1.Condition) error {
 	if m.managementClient == nil {
 		return fmt.Errorf("management client not set")
@@ -137,7 +198,7 @@ func (m *StatusManager) UpdateCondition(ctx context.Context, condition v1alpha1.
 		return nil
 	}
 
-	err := m.managementClient.UpdateDeviceStatus(ctx, m.deviceName, *m.device)
+	err := m.upload(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to update device status: %w", err)
 	}
@@ -164,8 +225,7 @@ func (m *StatusManager) Update(ctx context.Context, updateFuncs ...UpdateStatusF
 		}
 	}
 
-	// TODO: handle retries
-	if err := m.managementClient.UpdateDeviceStatus(ctx, m.deviceName, *m.device); err != nil {
+	if err := m.upload(ctx); err != nil {
 		return nil, fmt.Errorf("failed to update device status: %w", err)
 	}
 
@@ -0,0 +1,122 @@
+package telemetry
+
+import (
+	"sort"
+
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DroppedSeriesMetric counts the series the agent did not push because of the cardinality
+	// limits, by the reason they were dropped.
+	DroppedSeriesMetric = "flightctl_agent_custom_metrics_dropped_series_total"
+
+	// DropReasonMaxSeries is the reason of the series dropped beyond max-series.
+	DropReasonMaxSeries = "max-series"
+	// DropReasonMaxLabelValues is the reason of the series dropped for a label value beyond
+	// max-label-values.
+	DropReasonMaxLabelValues = "max-label-values"
+
+	DefaultMaxSeries      = 1000
+	DefaultMaxLabelValues = 100
+)
+
+// cardinalityLimiter drops the series beyond the limits on the number of series and on the number
+// of values of each label of a metric, and counts the dropped series.
+type cardinalityLimiter struct {
+	maxSeries      int
+	maxLabelValues int
+	// dropped are the series dropped since the agent started, by reason
+	dropped map[string]uint64
+}
+
+func newCardinalityLimiter(maxSeries, maxLabelValues int) *cardinalityLimiter {
+	return &cardinalityLimiter{
+		maxSeries:      maxSeries,
+		maxLabelValues: maxLabelValues,
+		dropped:        map[string]uint64{},
+	}
+}
+
+// limit returns the families without the series beyond the limits, in the order of their names
+// so that the same series are kept from one push to the next, and the number of dropped series.
+// A limit of 0 is unlimited.
+func (l *cardinalityLimiter) limit(families []*dto.MetricFamily) ([]*dto.MetricFamily, int) {
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].GetName() < families[j].GetName()
+	})
+
+	series := 0
+	dropped := 0
+	kept := make([]*dto.MetricFamily, 0, len(families))
+	for _, family := range families {
+		// the values of each label of the metric
+		labelValues := map[string]map[string]bool{}
+		metrics := make([]*dto.Metric, 0, len(family.GetMetric()))
+		for _, metric := range family.GetMetric() {
+			if l.maxSeries > 0 && series >= l.maxSeries {
+				l.dropped[DropReasonMaxSeries]++
+				dropped++
+				continue
+			}
+			if !l.admitLabelValues(labelValues, metric) {
+				l.dropped[DropReasonMaxLabelValues]++
+				dropped++
+				continue
+			}
+			series++
+			metrics = append(metrics, metric)
+		}
+		if len(metrics) == 0 {
+			continue
+		}
+		family.Metric = metrics
+		kept = append(kept, family)
+	}
+	return kept, dropped
+}
+
+// admitLabelValues returns true if the labels of the metric have no value beyond the limit, and
+// then records them.
+func (l *cardinalityLimiter) admitLabelValues(labelValues map[string]map[string]bool, metric *dto.Metric) bool {
+	if l.maxLabelValues <= 0 {
+		return true
+	}
+	for _, label := range metric.GetLabel() {
+		values := labelValues[label.GetName()]
+		if !values[label.GetValue()] && len(values) >= l.maxLabelValues {
+			return false
+		}
+	}
+	for _, label := range metric.GetLabel() {
+		if labelValues[label.GetName()] == nil {
+			labelValues[label.GetName()] = map[string]bool{}
+		}
+		labelValues[label.GetName()][label.GetValue()] = true
+	}
+	return true
+}
+
+// droppedFamily returns the counter of the dropped series, or nil if no series was dropped.
+func (l *cardinalityLimiter) droppedFamily() *dto.MetricFamily {
+	if len(l.dropped) == 0 {
+		return nil
+	}
+	family := &dto.MetricFamily{
+		Name: proto.String(DroppedSeriesMetric),
+		Help: proto.String("Custom metric series not pushed because of the cardinality limits of the agent."),
+		Type: dto.MetricType_COUNTER.Enum(),
+	}
+	for _, reason := range []string{DropReasonMaxSeries, DropReasonMaxLabelValues} {
+		count, ok := l.dropped[reason]
+		if !ok {
+			continue
+		}
+		family.Metric = append(family.Metric, &dto.Metric{
+			Label:   []*dto.LabelPair{{Name: proto.String("reason"), Value: proto.String(reason)}},
+			Counter: &dto.Counter{Value: proto.Float64(float64(count))},
+		})
+	}
+	return family
+}
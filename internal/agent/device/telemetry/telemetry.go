@@ -0,0 +1,227 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// Job is the job the metrics of the devices are pushed as.
+	Job = "flightctl-agent"
+	// DeviceLabel is the label identifying the device that pushed the metrics.
+	DeviceLabel = "device"
+
+	DefaultInterval = time.Minute
+	DefaultTimeout  = 10 * time.Second
+)
+
+// Config configures the push of custom metrics, e.g. application KPIs, to a Prometheus
+// Pushgateway. The metrics are read in the Prometheus text format from a file or from the output
+// of a command.
+type Config struct {
+	// GatewayURL is the URL of the Pushgateway, custom metrics are disabled when empty
+	GatewayURL string `json:"gateway-url,omitempty"`
+	// Interval between two pushes
+	Interval util.Duration `json:"interval,omitempty"`
+	// File the metrics are read from
+	File string `json:"file,omitempty"`
+	// Command whose output are the metrics, run by /bin/sh
+	Command string `json:"command,omitempty"`
+	// Timeout of the command and of the push
+	Timeout util.Duration `json:"timeout,omitempty"`
+	// MaxSeries is the number of series pushed at most, the series beyond are dropped, unlimited
+	// when 0
+	MaxSeries int `json:"max-series,omitempty"`
+	// MaxLabelValues is the number of values each label of a metric has at most, the series with
+	// further values are dropped, unlimited when 0
+	MaxLabelValues int `json:"max-label-values,omitempty"`
+}
+
+// NewDefaultConfig returns the default custom metrics config, which is disabled.
+func NewDefaultConfig() Config {
+	return Config{
+		Interval:       util.Duration(DefaultInterval),
+		Timeout:        util.Duration(DefaultTimeout),
+		MaxSeries:      DefaultMaxSeries,
+		MaxLabelValues: DefaultMaxLabelValues,
+	}
+}
+
+// Enabled returns true if custom metrics are pushed.
+func (c *Config) Enabled() bool {
+	return c.GatewayURL != ""
+}
+
+// Validate checks that the gateway URL is valid and that exactly one source is set.
+func (c *Config) Validate() error {
+	if !c.Enabled() {
+		if c.File != "" || c.Command != "" {
+			return fmt.Errorf("custom-metrics gateway-url must be set with a file or a command")
+		}
+		return nil
+	}
+	gatewayURL, err := url.Parse(c.GatewayURL)
+	if err != nil || (gatewayURL.Scheme != "http" && gatewayURL.Scheme != "https") || gatewayURL.Host == "" {
+		return fmt.Errorf("invalid custom-metrics gateway-url %q", c.GatewayURL)
+	}
+	if (c.File == "") == (c.Command == "") {
+		return fmt.Errorf("custom-metrics must set exactly one of file and command")
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("custom-metrics interval must be positive")
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("custom-metrics timeout must not be negative")
+	}
+	if c.MaxSeries < 0 {
+		return fmt.Errorf("custom-metrics max-series must not be negative")
+	}
+	if c.MaxLabelValues < 0 {
+		return fmt.Errorf("custom-metrics max-label-values must not be negative")
+	}
+	return nil
+}
+
+// Pusher collects the custom metrics of the device and pushes them to the Pushgateway, grouped by
+// the name of the device. Each push replaces the metrics of the previous one, so that metrics
+// that are no longer reported disappear from the gateway. The series beyond the cardinality limits
+// are dropped, and counted in the DroppedSeriesMetric pushed along.
+type Pusher struct {
+	log        *log.PrefixLogger
+	deviceName string
+	reader     fileio.Reader
+	exec       executer.Executer
+	clock      clock.WithTicker
+	client     *http.Client
+	limiter    *cardinalityLimiter
+
+	gatewayURL string
+	interval   time.Duration
+	file       string
+	command    string
+	timeout    time.Duration
+}
+
+type Option func(*Pusher)
+
+// WithClock sets the clock used by the pusher.
+func WithClock(clock clock.WithTicker) Option {
+	return func(p *Pusher) {
+		p.clock = clock
+	}
+}
+
+// New creates a pusher of the custom metrics of the given device.
+func New(log *log.PrefixLogger, cfg Config, deviceName string, reader fileio.Reader, exec executer.Executer, opts ...Option) *Pusher {
+	p := &Pusher{
+		log:        log,
+		deviceName: deviceName,
+		reader:     reader,
+		exec:       exec,
+		clock:      clock.RealClock{},
+		gatewayURL: cfg.GatewayURL,
+		interval:   time.Duration(cfg.Interval),
+		file:       cfg.File,
+		command:    cfg.Command,
+		timeout:    time.Duration(cfg.Timeout),
+		limiter:    newCardinalityLimiter(cfg.MaxSeries, cfg.MaxLabelValues),
+	}
+	if p.interval == 0 {
+		p.interval = DefaultInterval
+	}
+	if p.timeout == 0 {
+		p.timeout = DefaultTimeout
+	}
+	p.client = &http.Client{Timeout: p.timeout}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Run pushes the metrics every interval until the context is canceled.
+func (p *Pusher) Run(ctx context.Context) {
+	p.log.Infof("Pushing custom metrics to %s every %s", p.gatewayURL, p.interval)
+	ticker := p.clock.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			if err := p.push(ctx); err != nil {
+				p.log.Warnf("Failed to push custom metrics: %v", err)
+			}
+		}
+	}
+}
+
+func (p *Pusher) push(ctx context.Context) error {
+	families, err := p.collect(ctx)
+	if err != nil {
+		return err
+	}
+	families, dropped := p.limiter.limit(families)
+	if dropped > 0 {
+		p.log.Warnf("Dropped %d custom metric series beyond the cardinality limits", dropped)
+	}
+	if droppedFamily := p.limiter.droppedFamily(); droppedFamily != nil {
+		families = append(families, droppedFamily)
+	}
+
+	gatherer := prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return families, nil
+	})
+	return push.New(p.gatewayURL, Job).
+		Grouping(DeviceLabel, p.deviceName).
+		Gatherer(gatherer).
+		Client(p.client).
+		PushContext(ctx)
+}
+
+// collect reads the metrics from the file or the output of the command.
+func (p *Pusher) collect(ctx context.Context) ([]*dto.MetricFamily, error) {
+	var contents []byte
+	if p.file != "" {
+		var err error
+		contents, err = p.reader.ReadFile(p.file)
+		if err != nil {
+			return nil, fmt.Errorf("reading custom metrics: %w", err)
+		}
+	} else {
+		ctx, cancel := context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+		stdout, stderr, exitCode := p.exec.ExecuteWithContext(ctx, "/bin/sh", "-c", p.command)
+		if exitCode != 0 {
+			return nil, fmt.Errorf("running custom metrics command: %w", errors.FromStderr(stderr, exitCode))
+		}
+		contents = []byte(stdout)
+	}
+
+	var parser expfmt.TextParser
+	parsed, err := parser.TextToMetricFamilies(bytes.NewReader(contents))
+	if err != nil {
+		return nil, fmt.Errorf("parsing custom metrics: %w", err)
+	}
+	families := make([]*dto.MetricFamily, 0, len(parsed))
+	for _, family := range parsed {
+		families = append(families, family)
+	}
+	return families, nil
+}
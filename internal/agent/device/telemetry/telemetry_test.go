@@ -0,0 +1,250 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testMetrics = `# HELP app_orders_total Orders processed by the app.
+# TYPE app_orders_total counter
+app_orders_total{line="a"} 42
+# TYPE app_queue_depth gauge
+app_queue_depth 7
+`
+
+type pushedMetrics struct {
+	method   string
+	path     string
+	families map[string]*dto.MetricFamily
+}
+
+// newTestGateway returns a Pushgateway recording the metrics pushed to it.
+func newTestGateway(t *testing.T, pushed chan<- pushedMetrics) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		families := map[string]*dto.MetricFamily{}
+		// the decoder buffers the body on each call unless it is buffered already
+		decoder := expfmt.NewDecoder(bufio.NewReader(r.Body), expfmt.ResponseFormat(r.Header))
+		for {
+			family := &dto.MetricFamily{}
+			if err := decoder.Decode(family); err != nil {
+				if err != io.EOF {
+					t.Errorf("decoding pushed metrics: %v", err)
+				}
+				break
+			}
+			families[family.GetName()] = family
+		}
+		pushed <- pushedMetrics{method: r.Method, path: r.URL.Path, families: families}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPushFromFile(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(t.TempDir()))
+	require.NoError(readWriter.MkdirAll("/var/lib/app", 0o755))
+	require.NoError(readWriter.WriteFile("/var/lib/app/metrics.prom", []byte(testMetrics), 0o644))
+
+	pushed := make(chan pushedMetrics, 1)
+	gateway := newTestGateway(t, pushed)
+	cfg := NewDefaultConfig()
+	cfg.GatewayURL = gateway.URL
+	cfg.File = "/var/lib/app/metrics.prom"
+	require.NoError(cfg.Validate())
+
+	p := New(log.NewPrefixLogger("test"), cfg, "mydevice", readWriter, executer.NewMockExecuter(ctrl))
+	require.NoError(p.push(ctx))
+
+	result := <-pushed
+	// the metrics replace the previous push of the device
+	require.Equal(http.MethodPut, result.method)
+	require.Equal("/metrics/job/"+Job+"/"+DeviceLabel+"/mydevice", result.path)
+	require.Len(result.families, 2)
+	orders := result.families["app_orders_total"]
+	require.NotNil(orders)
+	require.Equal(dto.MetricType_COUNTER, orders.GetType())
+	require.Equal(42.0, orders.GetMetric()[0].GetCounter().GetValue())
+	require.Equal(7.0, result.families["app_queue_depth"].GetMetric()[0].GetGauge().GetValue())
+}
+
+func TestPushFromCommand(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	pushed := make(chan pushedMetrics, 1)
+	gateway := newTestGateway(t, pushed)
+	cfg := NewDefaultConfig()
+	cfg.GatewayURL = gateway.URL
+	cfg.Command = "/usr/local/bin/app-metrics"
+
+	exec := executer.NewMockExecuter(ctrl)
+	p := New(log.NewPrefixLogger("test"), cfg, "mydevice", fileio.NewReadWriter(), exec)
+
+	exec.EXPECT().ExecuteWithContext(gomock.Any(), "/bin/sh", "-c", cfg.Command).Return(testMetrics, "", 0)
+	require.NoError(p.push(ctx))
+	result := <-pushed
+	require.Len(result.families, 2)
+
+	// nothing is pushed when the metrics cannot be collected
+	exec.EXPECT().ExecuteWithContext(gomock.Any(), "/bin/sh", "-c", cfg.Command).Return("", "no such file", 127)
+	require.ErrorContains(p.push(ctx), "custom metrics command")
+
+	exec.EXPECT().ExecuteWithContext(gomock.Any(), "/bin/sh", "-c", cfg.Command).Return("not metrics", "", 0)
+	require.ErrorContains(p.push(ctx), "parsing custom metrics")
+	require.Empty(pushed)
+}
+
+func TestPushGroupingLabelConflict(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pushed := make(chan pushedMetrics, 1)
+	gateway := newTestGateway(t, pushed)
+	cfg := NewDefaultConfig()
+	cfg.GatewayURL = gateway.URL
+	cfg.Command = "app-metrics"
+
+	exec := executer.NewMockExecuter(ctrl)
+	p := New(log.NewPrefixLogger("test"), cfg, "mydevice", fileio.NewReadWriter(), exec)
+
+	// a device cannot push metrics in the name of another one
+	exec.EXPECT().ExecuteWithContext(gomock.Any(), "/bin/sh", "-c", cfg.Command).Return(`app_up{device="other"} 1`+"\n", "", 0)
+	require.Error(p.push(context.Background()))
+	require.Empty(pushed)
+}
+
+func TestPushCardinalityLimits(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	pushed := make(chan pushedMetrics, 1)
+	gateway := newTestGateway(t, pushed)
+	cfg := NewDefaultConfig()
+	cfg.GatewayURL = gateway.URL
+	cfg.Command = "app-metrics"
+	cfg.MaxSeries = 4
+	cfg.MaxLabelValues = 2
+	require.NoError(cfg.Validate())
+
+	exec := executer.NewMockExecuter(ctrl)
+	p := New(log.NewPrefixLogger("test"), cfg, "mydevice", fileio.NewReadWriter(), exec)
+
+	// a label with a value per request, and more series than allowed
+	metrics := `app_requests_total{path="/a",code="200"} 1
+app_requests_total{path="/b",code="200"} 1
+app_requests_total{path="/c",code="200"} 1
+app_requests_total{path="/a",code="500"} 1
+app_up 1
+app_queue_depth 7
+`
+	exec.EXPECT().ExecuteWithContext(gomock.Any(), "/bin/sh", "-c", cfg.Command).Return(metrics, "", 0).Times(2)
+	require.NoError(p.push(ctx))
+	result := <-pushed
+
+	// the series are kept in the order of the names of the metrics
+	require.Len(result.families["app_queue_depth"].GetMetric(), 1)
+	requests := result.families["app_requests_total"].GetMetric()
+	require.Len(requests, 3)
+	for _, metric := range requests {
+		require.Contains([]string{"/a", "/b"}, metric.GetLabel()[1].GetValue())
+	}
+	require.NotContains(result.families, "app_up")
+
+	dropped := func(result pushedMetrics) map[string]float64 {
+		counts := map[string]float64{}
+		for _, metric := range result.families[DroppedSeriesMetric].GetMetric() {
+			counts[metric.GetLabel()[0].GetValue()] = metric.GetCounter().GetValue()
+		}
+		return counts
+	}
+	// "/c" is a third value of path, and app_up would be a fifth series
+	require.Equal(map[string]float64{DropReasonMaxLabelValues: 1, DropReasonMaxSeries: 1}, dropped(result))
+
+	// the dropped series are counted across pushes
+	require.NoError(p.push(ctx))
+	result = <-pushed
+	require.Equal(map[string]float64{DropReasonMaxLabelValues: 2, DropReasonMaxSeries: 2}, dropped(result))
+}
+
+func TestPushWithoutCardinalityLimits(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pushed := make(chan pushedMetrics, 1)
+	gateway := newTestGateway(t, pushed)
+	cfg := NewDefaultConfig()
+	cfg.GatewayURL = gateway.URL
+	cfg.Command = "app-metrics"
+	cfg.MaxSeries = 0
+	cfg.MaxLabelValues = 0
+
+	exec := executer.NewMockExecuter(ctrl)
+	p := New(log.NewPrefixLogger("test"), cfg, "mydevice", fileio.NewReadWriter(), exec)
+
+	var metrics strings.Builder
+	for i := 0; i < 2*DefaultMaxSeries; i++ {
+		fmt.Fprintf(&metrics, "app_sessions{id=\"%d\"} 1\n", i)
+	}
+	exec.EXPECT().ExecuteWithContext(gomock.Any(), "/bin/sh", "-c", cfg.Command).Return(metrics.String(), "", 0)
+	require.NoError(p.push(context.Background()))
+	result := <-pushed
+	require.Len(result.families["app_sessions"].GetMetric(), 2*DefaultMaxSeries)
+	require.NotContains(result.families, DroppedSeriesMetric)
+}
+
+func TestConfigValidate(t *testing.T) {
+	require := require.New(t)
+	cfg := NewDefaultConfig()
+	require.NoError(cfg.Validate())
+	require.False(cfg.Enabled())
+
+	cfg.File = "/var/lib/app/metrics.prom"
+	require.ErrorContains(cfg.Validate(), "gateway-url")
+
+	cfg.GatewayURL = "http://pushgateway.example.com:9091"
+	require.NoError(cfg.Validate())
+	require.True(cfg.Enabled())
+
+	cfg.Command = "app-metrics"
+	require.ErrorContains(cfg.Validate(), "exactly one")
+
+	cfg = Config{GatewayURL: "pushgateway:9091", File: "/metrics.prom", Interval: util.Duration(time.Minute)}
+	require.ErrorContains(cfg.Validate(), "gateway-url")
+
+	cfg = Config{GatewayURL: "http://pushgateway:9091", File: "/metrics.prom"}
+	require.ErrorContains(cfg.Validate(), "interval")
+
+	cfg = NewDefaultConfig()
+	cfg.GatewayURL = "http://pushgateway:9091"
+	cfg.File = "/metrics.prom"
+	cfg.MaxSeries = -1
+	require.ErrorContains(cfg.Validate(), "max-series")
+}
@@ -0,0 +1,52 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/pkg/executer"
+)
+
+const (
+	cosignCmd            = "cosign"
+	defaultVerifyTimeout = 2 * time.Minute
+)
+
+type cosignVerifier struct {
+	exec    executer.Executer
+	config  Config
+	timeout time.Duration
+}
+
+// NewCosignVerifier returns a verifier that checks image signatures using the cosign CLI.
+func NewCosignVerifier(exec executer.Executer, config Config) Verifier {
+	return &cosignVerifier{
+		exec:    exec,
+		config:  config,
+		timeout: defaultVerifyTimeout,
+	}
+}
+
+func (c *cosignVerifier) Verify(ctx context.Context, image string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	args := []string{"verify"}
+	if c.config.PublicKey != "" {
+		args = append(args, "--key", c.config.PublicKey)
+	} else {
+		args = append(args,
+			"--certificate-identity", c.config.CertificateIdentity,
+			"--certificate-oidc-issuer", c.config.CertificateOIDCIssuer,
+		)
+	}
+	args = append(args, image)
+
+	_, stderr, exitCode := c.exec.ExecuteWithContext(ctx, cosignCmd, args...)
+	if exitCode != 0 {
+		return fmt.Errorf("%w: %s: %w", errors.ErrImageSignatureVerification, image, errors.FromStderr(stderr, exitCode))
+	}
+	return nil
+}
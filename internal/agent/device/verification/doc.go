@@ -0,0 +1,3 @@
+package verification
+
+//go:generate go run -modfile=../../../../tools/go.mod go.uber.org/mock/mockgen -source=verification.go -destination=mock_verification.go -package=verification
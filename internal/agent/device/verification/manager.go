@@ -0,0 +1,76 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/applications"
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+type manager struct {
+	verifier Verifier
+	log      *log.PrefixLogger
+}
+
+// NewManager returns a new image verification manager. A nil verifier
+// disables verification.
+func NewManager(log *log.PrefixLogger, verifier Verifier) Manager {
+	return &manager{
+		verifier: verifier,
+		log:      log,
+	}
+}
+
+// BeforeUpdate verifies the signatures of all images of the desired spec that
+// are not already part of the current spec.
+func (m *manager) BeforeUpdate(ctx context.Context, current, desired *v1alpha1.RenderedDeviceSpec) error {
+	if m.verifier == nil {
+		return nil
+	}
+
+	currentImages, err := imagesFromSpec(current)
+	if err != nil {
+		return err
+	}
+	desiredImages, err := imagesFromSpec(desired)
+	if err != nil {
+		return err
+	}
+
+	for _, image := range desiredImages {
+		if slices.Contains(currentImages, image) {
+			continue
+		}
+		m.log.Debugf("Verifying signature of image %s", image)
+		if err := m.verifier.Verify(ctx, image); err != nil {
+			return fmt.Errorf("%w: %w", errors.ErrNoRetry, err)
+		}
+		m.log.Infof("Verified signature of image %s", image)
+	}
+	return nil
+}
+
+func imagesFromSpec(spec *v1alpha1.RenderedDeviceSpec) ([]string, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	var images []string
+	if spec.Os != nil && spec.Os.Image != "" {
+		images = append(images, spec.Os.Image)
+	}
+	if spec.Applications != nil {
+		providers, err := applications.ImageProvidersFromSpec(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%w: parsing image providers: %w", errors.ErrNoRetry, err)
+		}
+		for _, provider := range providers {
+			images = append(images, provider.Image)
+		}
+	}
+	return images, nil
+}
@@ -0,0 +1,163 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/errors"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeVerifier accepts the images it holds a valid signature for.
+type fakeVerifier struct {
+	signed   map[string]bool
+	verified []string
+}
+
+func (f *fakeVerifier) Verify(_ context.Context, image string) error {
+	f.verified = append(f.verified, image)
+	if !f.signed[image] {
+		return fmt.Errorf("%w: %s: no matching signatures", errors.ErrImageSignatureVerification, image)
+	}
+	return nil
+}
+
+func newSpec(osImage string, appImages ...string) *v1alpha1.RenderedDeviceSpec {
+	spec := &v1alpha1.RenderedDeviceSpec{}
+	if osImage != "" {
+		spec.Os = &v1alpha1.DeviceOsSpec{Image: osImage}
+	}
+	if len(appImages) > 0 {
+		apps := []v1alpha1.RenderedApplicationSpec{}
+		for _, image := range appImages {
+			app := v1alpha1.RenderedApplicationSpec{}
+			_ = app.FromImageApplicationProvider(v1alpha1.ImageApplicationProvider{Image: image})
+			apps = append(apps, app)
+		}
+		spec.Applications = &apps
+	}
+	return spec
+}
+
+func TestManagerBeforeUpdate(t *testing.T) {
+	testCases := []struct {
+		name             string
+		current          *v1alpha1.RenderedDeviceSpec
+		desired          *v1alpha1.RenderedDeviceSpec
+		signed           map[string]bool
+		expectedVerified []string
+		wantErr          bool
+	}{
+		{
+			name:             "valid signatures",
+			current:          newSpec(""),
+			desired:          newSpec("quay.io/org/os:v2", "quay.io/org/app:v1"),
+			signed:           map[string]bool{"quay.io/org/os:v2": true, "quay.io/org/app:v1": true},
+			expectedVerified: []string{"quay.io/org/os:v2", "quay.io/org/app:v1"},
+		},
+		{
+			name:             "invalid os image signature",
+			current:          newSpec("quay.io/org/os:v1"),
+			desired:          newSpec("quay.io/org/os:v2"),
+			signed:           map[string]bool{"quay.io/org/os:v1": true},
+			expectedVerified: []string{"quay.io/org/os:v2"},
+			wantErr:          true,
+		},
+		{
+			name:             "invalid application image signature",
+			current:          newSpec(""),
+			desired:          newSpec("", "quay.io/org/app:v1", "quay.io/org/unsigned:v1"),
+			signed:           map[string]bool{"quay.io/org/app:v1": true},
+			expectedVerified: []string{"quay.io/org/app:v1", "quay.io/org/unsigned:v1"},
+			wantErr:          true,
+		},
+		{
+			name:    "unchanged images are not verified again",
+			current: newSpec("quay.io/org/os:v1", "quay.io/org/app:v1"),
+			desired: newSpec("quay.io/org/os:v1", "quay.io/org/app:v1"),
+			signed:  map[string]bool{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			verifier := &fakeVerifier{signed: tc.signed}
+			manager := NewManager(log.NewPrefixLogger("test"), verifier)
+
+			err := manager.BeforeUpdate(context.Background(), tc.current, tc.desired)
+			if tc.wantErr {
+				require.ErrorIs(err, errors.ErrImageSignatureVerification)
+				require.False(errors.IsRetryable(err))
+			} else {
+				require.NoError(err)
+			}
+			require.Equal(tc.expectedVerified, verifier.verified)
+		})
+	}
+}
+
+func TestManagerDisabled(t *testing.T) {
+	require := require.New(t)
+	manager := NewManager(log.NewPrefixLogger("test"), nil)
+	require.NoError(manager.BeforeUpdate(context.Background(), nil, newSpec("quay.io/org/os:v1")))
+}
+
+func TestCosignVerifier(t *testing.T) {
+	testCases := []struct {
+		name         string
+		config       Config
+		expectedArgs []string
+		exitCode     int
+		wantErr      bool
+	}{
+		{
+			name:         "public key",
+			config:       Config{Enabled: true, PublicKey: "/etc/flightctl/cosign.pub"},
+			expectedArgs: []string{"verify", "--key", "/etc/flightctl/cosign.pub", "quay.io/org/os:v1"},
+		},
+		{
+			name:   "keyless",
+			config: Config{Enabled: true, CertificateIdentity: "builder@example.com", CertificateOIDCIssuer: "https://issuer.example.com"},
+			expectedArgs: []string{"verify", "--certificate-identity", "builder@example.com",
+				"--certificate-oidc-issuer", "https://issuer.example.com", "quay.io/org/os:v1"},
+		},
+		{
+			name:         "invalid signature",
+			config:       Config{Enabled: true, PublicKey: "/etc/flightctl/cosign.pub"},
+			expectedArgs: []string{"verify", "--key", "/etc/flightctl/cosign.pub", "quay.io/org/os:v1"},
+			exitCode:     1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			mockExec := executer.NewMockExecuter(ctrl)
+			mockExec.EXPECT().ExecuteWithContext(gomock.Any(), "cosign", tc.expectedArgs).Return("", "error: no matching signatures", tc.exitCode)
+
+			err := NewCosignVerifier(mockExec, tc.config).Verify(context.Background(), "quay.io/org/os:v1")
+			if tc.wantErr {
+				require.ErrorIs(err, errors.ErrImageSignatureVerification)
+				return
+			}
+			require.NoError(err)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	require := require.New(t)
+	require.NoError((&Config{}).Validate())
+	require.NoError((&Config{Enabled: true, PublicKey: "/key.pub"}).Validate())
+	require.NoError((&Config{Enabled: true, CertificateIdentity: "id", CertificateOIDCIssuer: "issuer"}).Validate())
+	require.Error((&Config{Enabled: true}).Validate())
+	require.Error((&Config{Enabled: true, CertificateIdentity: "id"}).Validate())
+}
@@ -0,0 +1,45 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+)
+
+// Verifier verifies the signature of a container image or OCI artifact.
+type Verifier interface {
+	// Verify returns an error if the signature of the image can not be verified.
+	Verify(ctx context.Context, image string) error
+}
+
+// Manager verifies the images referenced by a desired spec before it is applied.
+type Manager interface {
+	BeforeUpdate(ctx context.Context, current, desired *v1alpha1.RenderedDeviceSpec) error
+}
+
+// Config is the image signature verification policy of the agent.
+type Config struct {
+	// Enabled turns on signature verification of the OS and application images
+	Enabled bool `json:"enabled,omitempty"`
+	// PublicKey is the path to the cosign public key used to verify signatures
+	PublicKey string `json:"public-key,omitempty"`
+	// CertificateIdentity is the expected identity of the signing certificate for keyless verification
+	CertificateIdentity string `json:"certificate-identity,omitempty"`
+	// CertificateOIDCIssuer is the expected OIDC issuer of the signing certificate for keyless verification
+	CertificateOIDCIssuer string `json:"certificate-oidc-issuer,omitempty"`
+}
+
+// Validate checks that an enabled policy is either key based or keyless.
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.PublicKey != "" {
+		return nil
+	}
+	if c.CertificateIdentity == "" || c.CertificateOIDCIssuer == "" {
+		return fmt.Errorf("image-verification requires either public-key or both certificate-identity and certificate-oidc-issuer")
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/device/lifecycle"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/log"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// DefaultStallTimeout is how long the main loop of the agent may not make progress before the
+	// agent is restarted. It is long enough for the download of an OS image.
+	DefaultStallTimeout = time.Hour
+)
+
+// Config configures the systemd watchdog integration, which is only active when the agent runs
+// as a systemd service with WatchdogSec set.
+type Config struct {
+	// Interval between two watchdog pings, defaults to half of the watchdog timeout of the service
+	Interval util.Duration `json:"interval,omitempty"`
+	// StallTimeout is how long the main loop of the agent may not make progress before the agent
+	// asks systemd to restart it
+	StallTimeout util.Duration `json:"stall-timeout,omitempty"`
+}
+
+// Validate checks that the durations are not negative.
+func (c *Config) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("watchdog interval must not be negative")
+	}
+	if c.StallTimeout < 0 {
+		return fmt.Errorf("watchdog stall-timeout must not be negative")
+	}
+	return nil
+}
+
+// Timeout returns the watchdog timeout systemd set for the agent through the WATCHDOG_USEC and
+// WATCHDOG_PID environment variables, or zero if the watchdog is not enabled for the agent.
+func Timeout() (time.Duration, error) {
+	usec := os.Getenv("WATCHDOG_USEC")
+	if usec == "" {
+		return 0, nil
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		// the watchdog is meant for another process of the service
+		return 0, nil
+	}
+	timeout, err := strconv.ParseUint(usec, 10, 64)
+	if err != nil || timeout == 0 {
+		return 0, fmt.Errorf("invalid WATCHDOG_USEC %q", usec)
+	}
+	return time.Duration(timeout) * time.Microsecond, nil
+}
+
+// Watchdog pings the systemd watchdog as long as the main loop of the agent makes progress, so
+// that systemd restarts an agent that is wedged. The main loop reports its progress by calling
+// Heartbeat, and is only monitored once it has started.
+type Watchdog struct {
+	log          *log.PrefixLogger
+	clock        clock.WithTicker
+	notify       func(state string) error
+	interval     time.Duration
+	stallTimeout time.Duration
+
+	mu            sync.Mutex
+	lastHeartbeat time.Time
+	stalled       bool
+}
+
+type Option func(*Watchdog)
+
+// WithClock sets the clock used by the watchdog.
+func WithClock(clock clock.WithTicker) Option {
+	return func(w *Watchdog) {
+		w.clock = clock
+	}
+}
+
+// WithNotify sets the function sending notifications to systemd.
+func WithNotify(notify func(state string) error) Option {
+	return func(w *Watchdog) {
+		w.notify = notify
+	}
+}
+
+// New creates a watchdog for the given systemd watchdog timeout.
+func New(log *log.PrefixLogger, cfg Config, timeout time.Duration, opts ...Option) *Watchdog {
+	w := &Watchdog{
+		log:          log,
+		clock:        clock.RealClock{},
+		notify:       lifecycle.SdNotify,
+		interval:     timeout / 2,
+		stallTimeout: DefaultStallTimeout,
+	}
+	if cfg.Interval > 0 {
+		if time.Duration(cfg.Interval) < timeout {
+			w.interval = time.Duration(cfg.Interval)
+		} else {
+			log.Warnf("Watchdog interval %s is not shorter than the watchdog timeout %s, using %s", time.Duration(cfg.Interval), timeout, w.interval)
+		}
+	}
+	if cfg.StallTimeout > 0 {
+		w.stallTimeout = time.Duration(cfg.StallTimeout)
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Heartbeat reports that the main loop made progress. It is a no-op on a nil watchdog.
+func (w *Watchdog) Heartbeat() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lastHeartbeat = w.clock.Now()
+}
+
+// Run pings the watchdog every interval until the context is canceled. When the main loop
+// stalls, it reports it and asks systemd to restart the agent.
+func (w *Watchdog) Run(ctx context.Context) {
+	w.log.Infof("Pinging the systemd watchdog every %s", w.interval)
+	ticker := w.clock.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.ping()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			w.ping()
+		}
+	}
+}
+
+func (w *Watchdog) ping() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stalled {
+		return
+	}
+
+	if !w.lastHeartbeat.IsZero() {
+		if since := w.clock.Since(w.lastHeartbeat); since > w.stallTimeout {
+			w.stalled = true
+			w.log.Errorf("Main loop made no progress for %s, requesting a restart from systemd", since.Round(time.Second))
+			if err := w.notify(fmt.Sprintf("STATUS=Main loop stalled for %s", since.Round(time.Second))); err != nil {
+				w.log.Warnf("Failed to notify systemd: %v", err)
+			}
+			if err := w.notify("WATCHDOG=trigger"); err != nil {
+				w.log.Warnf("Failed to notify systemd: %v", err)
+			}
+			return
+		}
+	}
+
+	if err := w.notify("WATCHDOG=1"); err != nil {
+		w.log.Warnf("Failed to ping the systemd watchdog: %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package watchdog
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	states []string
+}
+
+func (r *recorder) notify(state string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states = append(r.states, state)
+	return nil
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.states...)
+}
+
+func TestWatchdog(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := clocktesting.NewFakeClock(time.Now())
+	rec := &recorder{}
+	cfg := Config{StallTimeout: util.Duration(time.Minute)}
+	w := New(log.NewPrefixLogger("test"), cfg, 20*time.Second, WithClock(clock), WithNotify(rec.notify))
+	require.Equal(10*time.Second, w.interval)
+
+	go w.Run(ctx)
+	require.Eventually(func() bool { return len(rec.get()) == 1 && clock.HasWaiters() }, time.Second, time.Millisecond)
+
+	tick := func(expected int) {
+		clock.Step(w.interval)
+		require.Eventually(func() bool { return len(rec.get()) == expected }, time.Second, time.Millisecond)
+	}
+
+	// no stall is detected before the main loop started
+	for i := 2; i <= 10; i++ {
+		tick(i)
+	}
+	w.Heartbeat()
+	for i := 11; i <= 16; i++ {
+		tick(i)
+	}
+	for _, state := range rec.get() {
+		require.Equal("WATCHDOG=1", state)
+	}
+
+	// the main loop made no progress for longer than the stall timeout
+	clock.Step(w.interval)
+	require.Eventually(func() bool { return len(rec.get()) == 18 }, time.Second, time.Millisecond)
+	states := rec.get()
+	require.Contains(states[16], "STATUS=Main loop stalled")
+	require.Equal("WATCHDOG=trigger", states[17])
+
+	// the watchdog is no longer pinged once stalled
+	w.Heartbeat()
+	clock.Step(w.interval)
+	time.Sleep(10 * time.Millisecond)
+	require.Len(rec.get(), 18)
+}
+
+func TestNewInterval(t *testing.T) {
+	logger := log.NewPrefixLogger("test")
+	w := New(logger, Config{Interval: util.Duration(5 * time.Second)}, 30*time.Second)
+	require.Equal(t, 5*time.Second, w.interval)
+	require.Equal(t, DefaultStallTimeout, w.stallTimeout)
+
+	// the interval must be shorter than the timeout
+	w = New(logger, Config{Interval: util.Duration(time.Minute)}, 30*time.Second)
+	require.Equal(t, 15*time.Second, w.interval)
+}
+
+func TestTimeout(t *testing.T) {
+	t.Setenv("WATCHDOG_USEC", "")
+	timeout, err := Timeout()
+	require.NoError(t, err)
+	require.Zero(t, timeout)
+
+	t.Setenv("WATCHDOG_USEC", "30000000")
+	timeout, err = Timeout()
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, timeout)
+
+	t.Setenv("WATCHDOG_PID", "1")
+	timeout, err = Timeout()
+	require.NoError(t, err)
+	require.Zero(t, timeout)
+
+	t.Setenv("WATCHDOG_PID", "")
+	t.Setenv("WATCHDOG_USEC", "abc")
+	_, err = Timeout()
+	require.Error(t, err)
+}
@@ -0,0 +1,406 @@
+package diagnostics
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent"
+	"github.com/flightctl/flightctl/internal/agent/device/config"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	baseclient "github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/version"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultLogLines is the default number of the most recent lines of the agent's journal that
+	// are collected.
+	DefaultLogLines = 2000
+	// DefaultConnectivityTimeout is the default timeout of the connectivity test of a service.
+	DefaultConnectivityTimeout = 10 * time.Second
+
+	// redacted replaces the sensitive values in the collected files.
+	redacted = config.RedactedSecret
+)
+
+var (
+	// sensitiveConfigFields are the fields of the agent's configuration whose values are redacted.
+	sensitiveConfigFields = map[string]bool{
+		"client-key-data": true,
+		"token":           true,
+		"password":        true,
+	}
+
+	privateKeyRegexp = regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`)
+
+	// systemCommands are the commands whose output describes the system of the device.
+	systemCommands = [][]string{
+		{"uname", "-a"},
+		{"uptime"},
+		{"df", "-h"},
+		{"free", "-m"},
+		{"ip", "address"},
+		{"bootc", "status"},
+		{"systemctl", "--failed", "--no-pager"},
+	}
+)
+
+// Collector gathers the information needed to troubleshoot the agent of a device into an
+// archive that can be attached to a support ticket. The values of the device-local secrets,
+// private keys and credentials are redacted from all files of the archive.
+type Collector struct {
+	config              *agent.Config
+	configErr           error
+	reader              fileio.Reader
+	exec                executer.Executer
+	secretsDir          string
+	secrets             *config.SecretStore
+	logLines            int
+	connectivityTimeout time.Duration
+	now                 func() time.Time
+}
+
+type Option func(*Collector)
+
+// WithConfigError records the error the configuration failed to load or validate with, so that
+// the diagnostics are still collected for a device whose configuration is broken.
+func WithConfigError(err error) Option {
+	return func(c *Collector) {
+		c.configErr = err
+	}
+}
+
+// WithLogLines sets the number of the most recent lines of the agent's journal to collect.
+func WithLogLines(lines int) Option {
+	return func(c *Collector) {
+		c.logLines = lines
+	}
+}
+
+// WithConnectivityTimeout sets the timeout of the connectivity test of a service.
+func WithConnectivityTimeout(timeout time.Duration) Option {
+	return func(c *Collector) {
+		c.connectivityTimeout = timeout
+	}
+}
+
+// WithClock sets the function returning the current time.
+func WithClock(now func() time.Time) Option {
+	return func(c *Collector) {
+		c.now = now
+	}
+}
+
+// New returns a collector of the diagnostics of the agent with the given configuration.
+func New(cfg *agent.Config, reader fileio.Reader, exec executer.Executer, opts ...Option) *Collector {
+	c := &Collector{
+		config:              cfg,
+		reader:              reader,
+		exec:                exec,
+		logLines:            DefaultLogLines,
+		connectivityTimeout: DefaultConnectivityTimeout,
+		now:                 time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.secretsDir = cfg.SecretsDir
+	if c.secretsDir == "" {
+		c.secretsDir = agent.DefaultSecretsDir
+	}
+	c.secrets = config.NewSecretStore(reader, c.secretsDir)
+	return c
+}
+
+type file struct {
+	name     string
+	contents string
+}
+
+// Collect writes the diagnostics as a gzipped tar archive to w. Failing to collect a piece of
+// information does not fail the collection, the error is written in its place instead.
+func (c *Collector) Collect(ctx context.Context, w io.Writer) error {
+	c.loadSecrets()
+
+	files := []file{
+		{name: "version.txt", contents: c.version()},
+		{name: "config.yaml", contents: c.sanitizedConfig()},
+		{name: "logs/flightctl-agent.log", contents: c.logs(ctx)},
+		{name: "system.txt", contents: c.system(ctx)},
+		{name: "connectivity.txt", contents: c.connectivity(ctx)},
+		{name: "certificates.txt", contents: c.certificates()},
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	prefix := fmt.Sprintf("flightctl-agent-diagnostics-%s", c.now().UTC().Format("20060102T150405Z"))
+	for _, f := range files {
+		contents := []byte(c.redact(f.contents))
+		header := &tar.Header{
+			Name:    path.Join(prefix, f.name),
+			Mode:    0600,
+			Size:    int64(len(contents)),
+			ModTime: c.now(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+		if _, err := tw.Write(contents); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// loadSecrets resolves all device-local secrets, so that their values are redacted.
+func (c *Collector) loadSecrets() {
+	entries, err := c.reader.ReadDir(c.secretsDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			_, _ = c.secrets.Resolve(entry.Name())
+		}
+	}
+}
+
+func (c *Collector) redact(contents string) string {
+	contents = privateKeyRegexp.ReplaceAllString(contents, redacted)
+	return c.secrets.Redact(contents)
+}
+
+func (c *Collector) version() string {
+	info := version.Get()
+	return fmt.Sprintf("Flightctl Agent Version: %s\nGit Commit: %s\nCollected: %s\n",
+		info.String(), info.GitCommit, c.now().UTC().Format(time.RFC3339))
+}
+
+// sanitizedConfig returns the configuration of the agent, without its credentials.
+func (c *Collector) sanitizedConfig() string {
+	var b strings.Builder
+	if c.configErr != nil {
+		fmt.Fprintf(&b, "# the configuration is invalid: %v\n", c.configErr)
+	}
+	out, err := SanitizedConfig(c.config)
+	if err != nil {
+		fmt.Fprintf(&b, "# error: %v\n", err)
+		return b.String()
+	}
+	b.Write(out)
+	return b.String()
+}
+
+// SanitizedConfig returns the given configuration of the agent as YAML, without its credentials.
+func SanitizedConfig(cfg *agent.Config) ([]byte, error) {
+	contents, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling config: %w", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(contents, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshalling config: %w", err)
+	}
+	out, err := yaml.Marshal(sanitize(fields))
+	if err != nil {
+		return nil, fmt.Errorf("marshalling config: %w", err)
+	}
+	return out, nil
+}
+
+// sanitize redacts the sensitive fields of value and the credentials of the URLs it contains.
+func sanitize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if sensitiveConfigFields[key] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = sanitize(field)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = sanitize(v[i])
+		}
+		return v
+	case string:
+		if u, err := url.Parse(v); err == nil && u.User != nil {
+			if _, hasPassword := u.User.Password(); hasPassword {
+				return u.Redacted()
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func (c *Collector) logs(ctx context.Context) string {
+	args := []string{"--no-pager", "--output", "short-iso", "--lines", strconv.Itoa(c.logLines), "--unit", "flightctl-agent"}
+	stdout, stderr, exitCode := c.exec.ExecuteWithContext(ctx, "journalctl", args...)
+	if exitCode != 0 {
+		return fmt.Sprintf("error: journalctl: exit code %d: %s\n", exitCode, strings.TrimSpace(stderr))
+	}
+	return stdout
+}
+
+func (c *Collector) system(ctx context.Context) string {
+	var b strings.Builder
+	b.WriteString("$ cat /etc/os-release\n")
+	if contents, err := c.reader.ReadFile("/etc/os-release"); err != nil {
+		fmt.Fprintf(&b, "error: %v\n\n", err)
+	} else {
+		fmt.Fprintf(&b, "%s\n\n", strings.TrimRight(string(contents), "\n"))
+	}
+	for _, command := range systemCommands {
+		fmt.Fprintf(&b, "$ %s\n", strings.Join(command, " "))
+		stdout, stderr, exitCode := c.exec.ExecuteWithContext(ctx, command[0], command[1:]...)
+		if exitCode != 0 {
+			fmt.Fprintf(&b, "error: exit code %d: %s\n\n", exitCode, strings.TrimSpace(stderr))
+			continue
+		}
+		fmt.Fprintf(&b, "%s\n\n", strings.TrimRight(stdout, "\n"))
+	}
+	return b.String()
+}
+
+// connectivity tests that the enrollment and management services can be reached with the
+// configured endpoints, CAs and proxies.
+func (c *Collector) connectivity(ctx context.Context) string {
+	var b strings.Builder
+	services := []struct {
+		name   string
+		config *baseclient.Config
+	}{
+		{name: "enrollment-service", config: &c.config.EnrollmentService.Config},
+		{name: "management-service", config: &c.config.ManagementService.Config},
+	}
+	for _, service := range services {
+		fmt.Fprintf(&b, "%s %s: %s\n", service.name, service.config.Service.Server, c.testConnectivity(ctx, service.config))
+	}
+	return b.String()
+}
+
+func (c *Collector) testConnectivity(ctx context.Context, cfg *baseclient.Config) string {
+	if cfg.Service.Server == "" {
+		return "not configured"
+	}
+	// the certificates of the device are not needed to reach the service, and the management
+	// certificate does not exist before enrollment
+	cfg = cfg.DeepCopy()
+	cfg.AuthInfo = baseclient.AuthInfo{}
+	httpClient, err := baseclient.NewHTTPClientFromConfig(cfg)
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	httpClient.Timeout = c.connectivityTimeout
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.Service.Server, nil)
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	start := c.now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Sprintf("unreachable: %v", err)
+	}
+	defer resp.Body.Close()
+	return fmt.Sprintf("reachable, responded %s in %s", resp.Status, c.now().Sub(start).Round(time.Millisecond))
+}
+
+// certificates reports the validity of the certificates of the device and of the CAs of the
+// services.
+func (c *Collector) certificates() string {
+	managementCert := c.config.ManagementService.AuthInfo.ClientCertificate
+	if !c.config.ManagementService.Config.HasCredentials() {
+		managementCert = filepath.Join(c.config.DataDir, agent.DefaultCertsDirName, agent.GeneratedCertFile)
+	}
+	certs := []struct {
+		name string
+		path string
+		data []byte
+	}{
+		{name: "management certificate", path: managementCert, data: c.config.ManagementService.AuthInfo.ClientCertificateData},
+		{name: "enrollment certificate", path: c.config.EnrollmentService.AuthInfo.ClientCertificate, data: c.config.EnrollmentService.AuthInfo.ClientCertificateData},
+		{name: "enrollment service CA", path: c.config.EnrollmentService.Service.CertificateAuthority, data: c.config.EnrollmentService.Service.CertificateAuthorityData},
+		{name: "management service CA", path: c.config.ManagementService.Service.CertificateAuthority, data: c.config.ManagementService.Service.CertificateAuthorityData},
+	}
+
+	var b strings.Builder
+	for _, cert := range certs {
+		source := cert.path
+		data := cert.data
+		if len(data) == 0 {
+			if cert.path == "" {
+				fmt.Fprintf(&b, "%s: not configured\n\n", cert.name)
+				continue
+			}
+			contents, err := c.reader.ReadFile(cert.path)
+			if err != nil {
+				fmt.Fprintf(&b, "%s (%s): error: %v\n\n", cert.name, cert.path, err)
+				continue
+			}
+			data = contents
+		} else {
+			source = "inline"
+		}
+		fmt.Fprintf(&b, "%s (%s):\n%s\n", cert.name, source, c.describeCertificates(data))
+	}
+	return b.String()
+}
+
+func (c *Collector) describeCertificates(data []byte) string {
+	var b strings.Builder
+	found := false
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		found = true
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			fmt.Fprintf(&b, "  error: %v\n", err)
+			continue
+		}
+		fmt.Fprintf(&b, "  subject: %s\n  issuer: %s\n  not before: %s\n  not after: %s\n",
+			cert.Subject, cert.Issuer, cert.NotBefore.UTC().Format(time.RFC3339), cert.NotAfter.UTC().Format(time.RFC3339))
+		now := c.now()
+		switch {
+		case now.After(cert.NotAfter):
+			fmt.Fprintf(&b, "  status: EXPIRED %s ago\n", now.Sub(cert.NotAfter).Round(time.Minute))
+		case now.Before(cert.NotBefore):
+			fmt.Fprintf(&b, "  status: NOT YET VALID, valid in %s\n", cert.NotBefore.Sub(now).Round(time.Minute))
+		default:
+			fmt.Fprintf(&b, "  status: valid, expires in %s\n", cert.NotAfter.Sub(now).Round(time.Minute))
+		}
+	}
+	if !found {
+		b.WriteString("  error: no PEM encoded certificate\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,235 @@
+package identity
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/base32"
+	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	fcrypto "github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+// Source is a source of the identity the device enrolls with.
+type Source string
+
+const (
+	// SourcePublicKey derives the identity from the public key of the agent, which is generated on
+	// the first start of the agent and so changes when the device is reinstalled.
+	SourcePublicKey Source = "public-key"
+	// SourceSerial derives the identity from the serial number of the hardware, as reported by
+	// the firmware.
+	SourceSerial Source = "serial"
+	// SourceMAC derives the identity from the MAC address of a network interface.
+	SourceMAC Source = "mac"
+	// SourceTPMEndorsementKey derives the identity from the endorsement key of the TPM.
+	SourceTPMEndorsementKey Source = "tpm-ek"
+)
+
+var (
+	// serialPaths are the files the serial number of the hardware is read from, in order.
+	serialPaths = []string{
+		"/sys/class/dmi/id/product_serial",
+		"/sys/firmware/devicetree/base/serial-number",
+	}
+
+	// placeholderSerials are the values firmwares report when no serial number was set.
+	placeholderSerials = []string{
+		"",
+		"0",
+		"none",
+		"default string",
+		"not specified",
+		"system serial number",
+		"to be filled by o.e.m.",
+	}
+)
+
+const netClassDir = "/sys/class/net"
+
+// Config configures the sources of the identity the device enrolls with. The sources are tried
+// in order and the first one available on the device is used, so that the identity is stable
+// across reinstalls on hardware that provides the preferred identifier.
+type Config struct {
+	// Sources are the identity sources to try, in order: "serial", "mac", "tpm-ek" and
+	// "public-key". "public-key" is always available and must be the last source if set.
+	Sources []Source `json:"sources,omitempty"`
+	// Interface is the network interface whose MAC address is used by the "mac" source. Defaults
+	// to the first physical interface, by name.
+	Interface string `json:"interface,omitempty"`
+}
+
+// NewDefaultConfig returns the default configuration, which derives the identity from the
+// public key of the agent.
+func NewDefaultConfig() Config {
+	return Config{
+		Sources: []Source{SourcePublicKey},
+	}
+}
+
+// Validate checks that the sources are known, are not repeated and that "public-key" is last.
+func (c *Config) Validate() error {
+	if len(c.Sources) == 0 {
+		return fmt.Errorf("identity sources must not be empty")
+	}
+	for i, source := range c.Sources {
+		switch source {
+		case SourcePublicKey:
+			if i != len(c.Sources)-1 {
+				return fmt.Errorf("identity source %q must be the last source", source)
+			}
+		case SourceSerial, SourceMAC, SourceTPMEndorsementKey:
+		default:
+			return fmt.Errorf("unknown identity source %q", source)
+		}
+		if slices.Contains(c.Sources[:i], source) {
+			return fmt.Errorf("duplicate identity source %q", source)
+		}
+	}
+	if c.Interface != "" && !slices.Contains(c.Sources, SourceMAC) {
+		return fmt.Errorf("identity interface is only used by the %q source", SourceMAC)
+	}
+	return nil
+}
+
+// Provider derives the name of the device from the configured identity sources.
+type Provider struct {
+	log            *log.PrefixLogger
+	config         Config
+	reader         fileio.Reader
+	endorsementKey func() ([]byte, error)
+}
+
+// NewProvider returns a new identity provider. endorsementKey returns the DER encoded public
+// endorsement key of the TPM, and is only called if the "tpm-ek" source is configured.
+func NewProvider(log *log.PrefixLogger, config Config, reader fileio.Reader, endorsementKey func() ([]byte, error)) *Provider {
+	return &Provider{
+		log:            log,
+		config:         config,
+		reader:         reader,
+		endorsementKey: endorsementKey,
+	}
+}
+
+// DeviceName returns the name of the device, derived from the first identity source available
+// on the device. The names derived from the hardware identifiers are hashes of the identifier,
+// so that it is not disclosed, and have the same format as the ones derived from the public key.
+func (p *Provider) DeviceName(publicKey crypto.PublicKey) (string, error) {
+	for _, source := range p.config.Sources {
+		if source == SourcePublicKey {
+			publicKeyHash, err := fcrypto.HashPublicKey(publicKey)
+			if err != nil {
+				return "", err
+			}
+			p.log.Infof("Using identity source %q", source)
+			return encodeName(publicKeyHash), nil
+		}
+
+		value, err := p.read(source)
+		if err != nil {
+			p.log.Warnf("Identity source %q is not available: %v", source, err)
+			continue
+		}
+		p.log.Infof("Using identity source %q", source)
+		hash := sha256.Sum256([]byte(string(source) + ":" + value))
+		return encodeName(hash[:]), nil
+	}
+	return "", fmt.Errorf("none of the identity sources %v is available", p.config.Sources)
+}
+
+func (p *Provider) read(source Source) (string, error) {
+	switch source {
+	case SourceSerial:
+		return p.readSerial()
+	case SourceMAC:
+		return p.readMAC()
+	case SourceTPMEndorsementKey:
+		if p.endorsementKey == nil {
+			return "", fmt.Errorf("no TPM configured")
+		}
+		key, err := p.endorsementKey()
+		if err != nil {
+			return "", err
+		}
+		return string(key), nil
+	default:
+		return "", fmt.Errorf("unknown identity source %q", source)
+	}
+}
+
+func (p *Provider) readSerial() (string, error) {
+	for _, path := range serialPaths {
+		exists, err := p.reader.PathExists(path)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			continue
+		}
+		contents, err := p.reader.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		// the devicetree value is NUL terminated
+		serial := strings.TrimSpace(strings.Trim(string(contents), "\x00"))
+		if slices.Contains(placeholderSerials, strings.ToLower(serial)) {
+			return "", fmt.Errorf("the serial number in %s is not set", path)
+		}
+		return serial, nil
+	}
+	return "", fmt.Errorf("no serial number reported by the firmware")
+}
+
+func (p *Provider) readMAC() (string, error) {
+	if p.config.Interface != "" {
+		return p.readInterfaceMAC(p.config.Interface)
+	}
+
+	entries, err := p.reader.ReadDir(netClassDir)
+	if err != nil {
+		return "", err
+	}
+	// ReadDir returns the entries sorted by name
+	for _, entry := range entries {
+		// only physical interfaces have a device, virtual ones like bridges are created with a
+		// different address on every boot
+		exists, err := p.reader.PathExists(filepath.Join(netClassDir, entry.Name(), "device"))
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			continue
+		}
+		mac, err := p.readInterfaceMAC(entry.Name())
+		if err != nil {
+			p.log.Debugf("Skipping network interface %s: %v", entry.Name(), err)
+			continue
+		}
+		return mac, nil
+	}
+	return "", fmt.Errorf("no physical network interface with a MAC address")
+}
+
+func (p *Provider) readInterfaceMAC(name string) (string, error) {
+	contents, err := p.reader.ReadFile(filepath.Join(netClassDir, name, "address"))
+	if err != nil {
+		return "", fmt.Errorf("reading the address of %s: %w", name, err)
+	}
+	mac, err := net.ParseMAC(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return "", fmt.Errorf("parsing the address of %s: %w", name, err)
+	}
+	if slices.Equal(mac, make(net.HardwareAddr, len(mac))) {
+		return "", fmt.Errorf("%s has no MAC address", name)
+	}
+	return mac.String(), nil
+}
+
+func encodeName(hash []byte) string {
+	return strings.ToLower(base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString(hash))
+}
@@ -0,0 +1,182 @@
+package identity
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	fcrypto "github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/tpm"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func sourceName(source Source, value string) string {
+	hash := sha256.Sum256([]byte(string(source) + ":" + value))
+	return encodeName(hash[:])
+}
+
+func TestDeviceName(t *testing.T) {
+	publicKey, _, err := fcrypto.NewKeyPair()
+	require.NoError(t, err)
+	publicKeyHash, err := fcrypto.HashPublicKey(publicKey)
+	require.NoError(t, err)
+	publicKeyName := encodeName(publicKeyHash)
+
+	endorsementKey := func() ([]byte, error) { return []byte("endorsement-key"), nil }
+	noTPM := func() ([]byte, error) { return nil, errors.New("no such device") }
+
+	tests := []struct {
+		name           string
+		config         Config
+		files          map[string]string
+		endorsementKey func() ([]byte, error)
+		want           string
+		wantErr        bool
+	}{
+		{
+			name:   "public key",
+			config: NewDefaultConfig(),
+			files:  map[string]string{"/sys/class/dmi/id/product_serial": "SN1234\n"},
+			want:   publicKeyName,
+		},
+		{
+			name:   "dmi serial",
+			config: Config{Sources: []Source{SourceSerial, SourcePublicKey}},
+			files:  map[string]string{"/sys/class/dmi/id/product_serial": "SN1234\n"},
+			want:   sourceName(SourceSerial, "SN1234"),
+		},
+		{
+			name:   "devicetree serial",
+			config: Config{Sources: []Source{SourceSerial}},
+			files:  map[string]string{"/sys/firmware/devicetree/base/serial-number": "10000000a1b2c3d4\x00"},
+			want:   sourceName(SourceSerial, "10000000a1b2c3d4"),
+		},
+		{
+			name:   "placeholder serial falls back",
+			config: Config{Sources: []Source{SourceSerial, SourcePublicKey}},
+			files:  map[string]string{"/sys/class/dmi/id/product_serial": "To Be Filled By O.E.M.\n"},
+			want:   publicKeyName,
+		},
+		{
+			name:   "mac of the first physical interface",
+			config: Config{Sources: []Source{SourceMAC}},
+			files: map[string]string{
+				"/sys/class/net/br0/address":          "02:42:ac:11:00:01\n",
+				"/sys/class/net/enp1s0/address":       "52:54:00:AB:CD:EF\n",
+				"/sys/class/net/enp1s0/device/uevent": "",
+				"/sys/class/net/enp2s0/address":       "52:54:00:12:34:56\n",
+				"/sys/class/net/enp2s0/device/uevent": "",
+				"/sys/class/net/lo/address":           "00:00:00:00:00:00\n",
+			},
+			want: sourceName(SourceMAC, "52:54:00:ab:cd:ef"),
+		},
+		{
+			name:   "mac of the configured interface",
+			config: Config{Sources: []Source{SourceMAC}, Interface: "enp2s0"},
+			files: map[string]string{
+				"/sys/class/net/enp1s0/address":       "52:54:00:ab:cd:ef\n",
+				"/sys/class/net/enp1s0/device/uevent": "",
+				"/sys/class/net/enp2s0/address":       "52:54:00:12:34:56\n",
+			},
+			want: sourceName(SourceMAC, "52:54:00:12:34:56"),
+		},
+		{
+			name:   "no physical interface falls back to the serial",
+			config: Config{Sources: []Source{SourceMAC, SourceSerial}},
+			files: map[string]string{
+				"/sys/class/net/lo/address":        "00:00:00:00:00:00\n",
+				"/sys/class/dmi/id/product_serial": "SN1234\n",
+			},
+			want: sourceName(SourceSerial, "SN1234"),
+		},
+		{
+			name:           "tpm endorsement key",
+			config:         Config{Sources: []Source{SourceTPMEndorsementKey, SourceSerial}},
+			files:          map[string]string{"/sys/class/dmi/id/product_serial": "SN1234\n"},
+			endorsementKey: endorsementKey,
+			want:           sourceName(SourceTPMEndorsementKey, "endorsement-key"),
+		},
+		{
+			name:           "no tpm falls back to the serial",
+			config:         Config{Sources: []Source{SourceTPMEndorsementKey, SourceSerial}},
+			files:          map[string]string{"/sys/class/dmi/id/product_serial": "SN1234\n"},
+			endorsementKey: noTPM,
+			want:           sourceName(SourceSerial, "SN1234"),
+		},
+		{
+			name:           "no source available",
+			config:         Config{Sources: []Source{SourceTPMEndorsementKey, SourceSerial}},
+			endorsementKey: noTPM,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			require.NoError(tt.config.Validate())
+			readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(t.TempDir()))
+			require.NoError(readWriter.MkdirAll("/sys/class/net", 0755))
+			for path, contents := range tt.files {
+				require.NoError(readWriter.WriteFile(path, []byte(contents), 0644))
+			}
+
+			provider := NewProvider(log.NewPrefixLogger("test"), tt.config, readWriter, tt.endorsementKey)
+			name, err := provider.DeviceName(publicKey)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.want, name)
+			// the server requires the name to be long enough to be a fingerprint
+			_, err = fcrypto.CNFromDeviceFingerprint(name)
+			require.NoError(err)
+		})
+	}
+}
+
+func TestDeviceNameFromTPMSimulator(t *testing.T) {
+	require := require.New(t)
+	simulator, err := tpm.OpenTPMSimulator()
+	require.NoError(err)
+	defer simulator.Close()
+
+	config := Config{Sources: []Source{SourceTPMEndorsementKey}}
+	provider := NewProvider(log.NewPrefixLogger("test"), config, fileio.NewReadWriter(fileio.WithTestRootDir(t.TempDir())), simulator.GetEndorsementKeyPublic)
+	name, err := provider.DeviceName(nil)
+	require.NoError(err)
+
+	// the endorsement key, and so the name, does not change when it is derived again
+	again, err := provider.DeviceName(nil)
+	require.NoError(err)
+	require.Equal(name, again)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{name: "default", config: NewDefaultConfig()},
+		{name: "all sources", config: Config{Sources: []Source{SourceTPMEndorsementKey, SourceSerial, SourceMAC, SourcePublicKey}, Interface: "eth0"}},
+		{name: "no fallback to the public key", config: Config{Sources: []Source{SourceSerial}}},
+		{name: "empty", config: Config{}, wantErr: "must not be empty"},
+		{name: "unknown", config: Config{Sources: []Source{"uuid"}}, wantErr: "unknown identity source"},
+		{name: "duplicate", config: Config{Sources: []Source{SourceSerial, SourceSerial}}, wantErr: "duplicate identity source"},
+		{name: "public key not last", config: Config{Sources: []Source{SourcePublicKey, SourceSerial}}, wantErr: "must be the last source"},
+		{name: "interface without mac", config: Config{Sources: []Source{SourceSerial}, Interface: "eth0"}, wantErr: "only used by"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
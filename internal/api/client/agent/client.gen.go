@@ -91,6 +91,16 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// RedeemBootstrapTokenWithBody request with any body
+	RedeemBootstrapTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RedeemBootstrapToken(ctx context.Context, body RedeemBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReplaceDeviceLogsWithBody request with any body
+	ReplaceDeviceLogsWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReplaceDeviceLogs(ctx context.Context, name string, body ReplaceDeviceLogsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetRenderedDeviceSpec request
 	GetRenderedDeviceSpec(ctx context.Context, name string, params *GetRenderedDeviceSpecParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -99,6 +109,12 @@ type ClientInterface interface {
 
 	ReplaceDeviceStatus(ctx context.Context, name string, body ReplaceDeviceStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ReleaseDeviceUpdateSlot request
+	ReleaseDeviceUpdateSlot(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AcquireDeviceUpdateSlot request
+	AcquireDeviceUpdateSlot(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// CreateEnrollmentRequestWithBody request with any body
 	CreateEnrollmentRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -108,6 +124,54 @@ type ClientInterface interface {
 	ReadEnrollmentRequest(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) RedeemBootstrapTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRedeemBootstrapTokenRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RedeemBootstrapToken(ctx context.Context, body RedeemBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRedeemBootstrapTokenRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplaceDeviceLogsWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceDeviceLogsRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplaceDeviceLogs(ctx context.Context, name string, body ReplaceDeviceLogsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceDeviceLogsRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetRenderedDeviceSpec(ctx context.Context, name string, params *GetRenderedDeviceSpecParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetRenderedDeviceSpecRequest(c.Server, name, params)
 	if err != nil {
@@ -144,6 +208,30 @@ func (c *Client) ReplaceDeviceStatus(ctx context.Context, name string, body Repl
 	return c.Client.Do(req)
 }
 
+func (c *Client) ReleaseDeviceUpdateSlot(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReleaseDeviceUpdateSlotRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcquireDeviceUpdateSlot(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcquireDeviceUpdateSlotRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) CreateEnrollmentRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewCreateEnrollmentRequestRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -180,6 +268,93 @@ func (c *Client) ReadEnrollmentRequest(ctx context.Context, name string, reqEdit
 	return c.Client.Do(req)
 }
 
+// NewRedeemBootstrapTokenRequest calls the generic RedeemBootstrapToken builder with application/json body
+func NewRedeemBootstrapTokenRequest(server string, body RedeemBootstrapTokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRedeemBootstrapTokenRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRedeemBootstrapTokenRequestWithBody generates requests for RedeemBootstrapToken with any type of body
+func NewRedeemBootstrapTokenRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/bootstraptokens/redeem")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewReplaceDeviceLogsRequest calls the generic ReplaceDeviceLogs builder with application/json body
+func NewReplaceDeviceLogsRequest(server string, name string, body ReplaceDeviceLogsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReplaceDeviceLogsRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewReplaceDeviceLogsRequestWithBody generates requests for ReplaceDeviceLogs with any type of body
+func NewReplaceDeviceLogsRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/devices/%s/logs", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewGetRenderedDeviceSpecRequest generates requests for GetRenderedDeviceSpec
 func NewGetRenderedDeviceSpecRequest(server string, name string, params *GetRenderedDeviceSpecParams) (*http.Request, error) {
 	var err error
@@ -283,6 +458,74 @@ func NewReplaceDeviceStatusRequestWithBody(server string, name string, contentTy
 	return req, nil
 }
 
+// NewReleaseDeviceUpdateSlotRequest generates requests for ReleaseDeviceUpdateSlot
+func NewReleaseDeviceUpdateSlotRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/devices/%s/updateslot", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAcquireDeviceUpdateSlotRequest generates requests for AcquireDeviceUpdateSlot
+func NewAcquireDeviceUpdateSlotRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/devices/%s/updateslot", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewCreateEnrollmentRequestRequest calls the generic CreateEnrollmentRequest builder with application/json body
 func NewCreateEnrollmentRequestRequest(server string, body CreateEnrollmentRequestJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -400,6 +643,16 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// RedeemBootstrapTokenWithBodyWithResponse request with any body
+	RedeemBootstrapTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RedeemBootstrapTokenResponse, error)
+
+	RedeemBootstrapTokenWithResponse(ctx context.Context, body RedeemBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*RedeemBootstrapTokenResponse, error)
+
+	// ReplaceDeviceLogsWithBodyWithResponse request with any body
+	ReplaceDeviceLogsWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceDeviceLogsResponse, error)
+
+	ReplaceDeviceLogsWithResponse(ctx context.Context, name string, body ReplaceDeviceLogsJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceDeviceLogsResponse, error)
+
 	// GetRenderedDeviceSpecWithResponse request
 	GetRenderedDeviceSpecWithResponse(ctx context.Context, name string, params *GetRenderedDeviceSpecParams, reqEditors ...RequestEditorFn) (*GetRenderedDeviceSpecResponse, error)
 
@@ -408,6 +661,12 @@ type ClientWithResponsesInterface interface {
 
 	ReplaceDeviceStatusWithResponse(ctx context.Context, name string, body ReplaceDeviceStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceDeviceStatusResponse, error)
 
+	// ReleaseDeviceUpdateSlotWithResponse request
+	ReleaseDeviceUpdateSlotWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReleaseDeviceUpdateSlotResponse, error)
+
+	// AcquireDeviceUpdateSlotWithResponse request
+	AcquireDeviceUpdateSlotWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*AcquireDeviceUpdateSlotResponse, error)
+
 	// CreateEnrollmentRequestWithBodyWithResponse request with any body
 	CreateEnrollmentRequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateEnrollmentRequestResponse, error)
 
@@ -417,6 +676,56 @@ type ClientWithResponsesInterface interface {
 	ReadEnrollmentRequestWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReadEnrollmentRequestResponse, error)
 }
 
+type RedeemBootstrapTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.BootstrapTokenRedemptionResponse
+	JSON400      *externalRef0.Error
+	JSON401      *externalRef0.Error
+	JSON404      *externalRef0.Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RedeemBootstrapTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RedeemBootstrapTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReplaceDeviceLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.DeviceLogs
+	JSON400      *externalRef0.Error
+	JSON401      *externalRef0.Error
+	JSON404      *externalRef0.Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReplaceDeviceLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReplaceDeviceLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetRenderedDeviceSpecResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -467,6 +776,54 @@ func (r ReplaceDeviceStatusResponse) StatusCode() int {
 	return 0
 }
 
+type ReleaseDeviceUpdateSlotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.DeviceUpdateSlot
+	JSON401      *externalRef0.Error
+	JSON404      *externalRef0.Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReleaseDeviceUpdateSlotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReleaseDeviceUpdateSlotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AcquireDeviceUpdateSlotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *externalRef0.DeviceUpdateSlot
+	JSON401      *externalRef0.Error
+	JSON404      *externalRef0.Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AcquireDeviceUpdateSlotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AcquireDeviceUpdateSlotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type CreateEnrollmentRequestResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -520,6 +877,40 @@ func (r ReadEnrollmentRequestResponse) StatusCode() int {
 	return 0
 }
 
+// RedeemBootstrapTokenWithBodyWithResponse request with arbitrary body returning *RedeemBootstrapTokenResponse
+func (c *ClientWithResponses) RedeemBootstrapTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RedeemBootstrapTokenResponse, error) {
+	rsp, err := c.RedeemBootstrapTokenWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRedeemBootstrapTokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) RedeemBootstrapTokenWithResponse(ctx context.Context, body RedeemBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*RedeemBootstrapTokenResponse, error) {
+	rsp, err := c.RedeemBootstrapToken(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRedeemBootstrapTokenResponse(rsp)
+}
+
+// ReplaceDeviceLogsWithBodyWithResponse request with arbitrary body returning *ReplaceDeviceLogsResponse
+func (c *ClientWithResponses) ReplaceDeviceLogsWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceDeviceLogsResponse, error) {
+	rsp, err := c.ReplaceDeviceLogsWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReplaceDeviceLogsResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReplaceDeviceLogsWithResponse(ctx context.Context, name string, body ReplaceDeviceLogsJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceDeviceLogsResponse, error) {
+	rsp, err := c.ReplaceDeviceLogs(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReplaceDeviceLogsResponse(rsp)
+}
+
 // GetRenderedDeviceSpecWithResponse request returning *GetRenderedDeviceSpecResponse
 func (c *ClientWithResponses) GetRenderedDeviceSpecWithResponse(ctx context.Context, name string, params *GetRenderedDeviceSpecParams, reqEditors ...RequestEditorFn) (*GetRenderedDeviceSpecResponse, error) {
 	rsp, err := c.GetRenderedDeviceSpec(ctx, name, params, reqEditors...)
@@ -546,6 +937,24 @@ func (c *ClientWithResponses) ReplaceDeviceStatusWithResponse(ctx context.Contex
 	return ParseReplaceDeviceStatusResponse(rsp)
 }
 
+// ReleaseDeviceUpdateSlotWithResponse request returning *ReleaseDeviceUpdateSlotResponse
+func (c *ClientWithResponses) ReleaseDeviceUpdateSlotWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReleaseDeviceUpdateSlotResponse, error) {
+	rsp, err := c.ReleaseDeviceUpdateSlot(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReleaseDeviceUpdateSlotResponse(rsp)
+}
+
+// AcquireDeviceUpdateSlotWithResponse request returning *AcquireDeviceUpdateSlotResponse
+func (c *ClientWithResponses) AcquireDeviceUpdateSlotWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*AcquireDeviceUpdateSlotResponse, error) {
+	rsp, err := c.AcquireDeviceUpdateSlot(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcquireDeviceUpdateSlotResponse(rsp)
+}
+
 // CreateEnrollmentRequestWithBodyWithResponse request with arbitrary body returning *CreateEnrollmentRequestResponse
 func (c *ClientWithResponses) CreateEnrollmentRequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateEnrollmentRequestResponse, error) {
 	rsp, err := c.CreateEnrollmentRequestWithBody(ctx, contentType, body, reqEditors...)
@@ -572,6 +981,100 @@ func (c *ClientWithResponses) ReadEnrollmentRequestWithResponse(ctx context.Cont
 	return ParseReadEnrollmentRequestResponse(rsp)
 }
 
+// ParseRedeemBootstrapTokenResponse parses an HTTP response from a RedeemBootstrapTokenWithResponse call
+func ParseRedeemBootstrapTokenResponse(rsp *http.Response) (*RedeemBootstrapTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RedeemBootstrapTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.BootstrapTokenRedemptionResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReplaceDeviceLogsResponse parses an HTTP response from a ReplaceDeviceLogsWithResponse call
+func ParseReplaceDeviceLogsResponse(rsp *http.Response) (*ReplaceDeviceLogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReplaceDeviceLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.DeviceLogs
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetRenderedDeviceSpecResponse parses an HTTP response from a GetRenderedDeviceSpecWithResponse call
 func ParseGetRenderedDeviceSpecResponse(rsp *http.Response) (*GetRenderedDeviceSpecResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -666,6 +1169,86 @@ func ParseReplaceDeviceStatusResponse(rsp *http.Response) (*ReplaceDeviceStatusR
 	return response, nil
 }
 
+// ParseReleaseDeviceUpdateSlotResponse parses an HTTP response from a ReleaseDeviceUpdateSlotWithResponse call
+func ParseReleaseDeviceUpdateSlotResponse(rsp *http.Response) (*ReleaseDeviceUpdateSlotResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReleaseDeviceUpdateSlotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.DeviceUpdateSlot
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAcquireDeviceUpdateSlotResponse parses an HTTP response from a AcquireDeviceUpdateSlotWithResponse call
+func ParseAcquireDeviceUpdateSlotResponse(rsp *http.Response) (*AcquireDeviceUpdateSlotResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AcquireDeviceUpdateSlotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest externalRef0.DeviceUpdateSlot
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseCreateEnrollmentRequestResponse parses an HTTP response from a CreateEnrollmentRequestWithResponse call
 func ParseCreateEnrollmentRequestResponse(rsp *http.Response) (*CreateEnrollmentRequestResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
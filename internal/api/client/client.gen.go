@@ -96,6 +96,16 @@ type ClientInterface interface {
 	// AuthValidate request
 	AuthValidate(ctx context.Context, params *AuthValidateParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// IssueBootstrapTokenWithBody request with any body
+	IssueBootstrapTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	IssueBootstrapToken(ctx context.Context, body IssueBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RedeemBootstrapTokenWithBody request with any body
+	RedeemBootstrapTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RedeemBootstrapToken(ctx context.Context, body RedeemBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteCertificateSigningRequests request
 	DeleteCertificateSigningRequests(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -118,6 +128,8 @@ type ClientInterface interface {
 
 	PatchCertificateSigningRequestWithApplicationJSONPatchPlusJSONBody(ctx context.Context, name string, body PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	PatchCertificateSigningRequestWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchCertificateSigningRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ReplaceCertificateSigningRequestWithBody request with any body
 	ReplaceCertificateSigningRequestWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -129,7 +141,7 @@ type ClientInterface interface {
 	UpdateCertificateSigningRequestApproval(ctx context.Context, name string, body UpdateCertificateSigningRequestApprovalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// DeleteDevices request
-	DeleteDevices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	DeleteDevices(ctx context.Context, params *DeleteDevicesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ListDevices request
 	ListDevices(ctx context.Context, params *ListDevicesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
@@ -139,21 +151,28 @@ type ClientInterface interface {
 
 	CreateDevice(ctx context.Context, body CreateDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// BatchGetDevicesWithBody request with any body
+	BatchGetDevicesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BatchGetDevices(ctx context.Context, body BatchGetDevicesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteDevice request
 	DeleteDevice(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ReadDevice request
-	ReadDevice(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ReadDevice(ctx context.Context, name string, params *ReadDeviceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// PatchDeviceWithBody request with any body
 	PatchDeviceWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PatchDeviceWithApplicationJSONPatchPlusJSONBody(ctx context.Context, name string, body PatchDeviceApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	PatchDeviceWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchDeviceApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ReplaceDeviceWithBody request with any body
-	ReplaceDeviceWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ReplaceDeviceWithBody(ctx context.Context, name string, params *ReplaceDeviceParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	ReplaceDevice(ctx context.Context, name string, body ReplaceDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ReplaceDevice(ctx context.Context, name string, params *ReplaceDeviceParams, body ReplaceDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// RequestConsole request
 	RequestConsole(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
@@ -163,9 +182,20 @@ type ClientInterface interface {
 
 	DecommissionDevice(ctx context.Context, name string, body DecommissionDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ReadDeviceLogs request
+	ReadDeviceLogs(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReplaceDeviceLogsWithBody request with any body
+	ReplaceDeviceLogsWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReplaceDeviceLogs(ctx context.Context, name string, body ReplaceDeviceLogsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetRenderedDeviceSpec request
 	GetRenderedDeviceSpec(ctx context.Context, name string, params *GetRenderedDeviceSpecParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ListDeviceRevisions request
+	ListDeviceRevisions(ctx context.Context, name string, params *ListDeviceRevisionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ReadDeviceStatus request
 	ReadDeviceStatus(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -179,6 +209,12 @@ type ClientInterface interface {
 
 	ReplaceDeviceStatus(ctx context.Context, name string, body ReplaceDeviceStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ReleaseDeviceUpdateSlot request
+	ReleaseDeviceUpdateSlot(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AcquireDeviceUpdateSlot request
+	AcquireDeviceUpdateSlot(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetEnrollmentConfig request
 	GetEnrollmentConfig(ctx context.Context, params *GetEnrollmentConfigParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -204,6 +240,8 @@ type ClientInterface interface {
 
 	PatchEnrollmentRequestWithApplicationJSONPatchPlusJSONBody(ctx context.Context, name string, body PatchEnrollmentRequestApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	PatchEnrollmentRequestWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchEnrollmentRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ReplaceEnrollmentRequestWithBody request with any body
 	ReplaceEnrollmentRequestWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -261,10 +299,15 @@ type ClientInterface interface {
 
 	PatchFleetWithApplicationJSONPatchPlusJSONBody(ctx context.Context, name string, body PatchFleetApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	PatchFleetWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchFleetApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ReplaceFleetWithBody request with any body
-	ReplaceFleetWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ReplaceFleetWithBody(ctx context.Context, name string, params *ReplaceFleetParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	ReplaceFleet(ctx context.Context, name string, body ReplaceFleetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ReplaceFleet(ctx context.Context, name string, params *ReplaceFleetParams, body ReplaceFleetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListFleetRevisions request
+	ListFleetRevisions(ctx context.Context, name string, params *ListFleetRevisionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ReadFleetStatus request
 	ReadFleetStatus(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
@@ -279,6 +322,9 @@ type ClientInterface interface {
 
 	ReplaceFleetStatus(ctx context.Context, name string, body ReplaceFleetStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetQuotaUsage request
+	GetQuotaUsage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteRepositories request
 	DeleteRepositories(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -301,10 +347,12 @@ type ClientInterface interface {
 
 	PatchRepositoryWithApplicationJSONPatchPlusJSONBody(ctx context.Context, name string, body PatchRepositoryApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	PatchRepositoryWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchRepositoryApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ReplaceRepositoryWithBody request with any body
-	ReplaceRepositoryWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ReplaceRepositoryWithBody(ctx context.Context, name string, params *ReplaceRepositoryParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	ReplaceRepository(ctx context.Context, name string, body ReplaceRepositoryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ReplaceRepository(ctx context.Context, name string, params *ReplaceRepositoryParams, body ReplaceRepositoryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// DeleteResourceSyncs request
 	DeleteResourceSyncs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
@@ -328,10 +376,12 @@ type ClientInterface interface {
 
 	PatchResourceSyncWithApplicationJSONPatchPlusJSONBody(ctx context.Context, name string, body PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	PatchResourceSyncWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchResourceSyncApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ReplaceResourceSyncWithBody request with any body
-	ReplaceResourceSyncWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ReplaceResourceSyncWithBody(ctx context.Context, name string, params *ReplaceResourceSyncParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	ReplaceResourceSync(ctx context.Context, name string, body ReplaceResourceSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ReplaceResourceSync(ctx context.Context, name string, params *ReplaceResourceSyncParams, body ReplaceResourceSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) AuthConfig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -358,6 +408,54 @@ func (c *Client) AuthValidate(ctx context.Context, params *AuthValidateParams, r
 	return c.Client.Do(req)
 }
 
+func (c *Client) IssueBootstrapTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewIssueBootstrapTokenRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) IssueBootstrapToken(ctx context.Context, body IssueBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewIssueBootstrapTokenRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RedeemBootstrapTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRedeemBootstrapTokenRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RedeemBootstrapToken(ctx context.Context, body RedeemBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRedeemBootstrapTokenRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) DeleteCertificateSigningRequests(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewDeleteCertificateSigningRequestsRequest(c.Server)
 	if err != nil {
@@ -454,6 +552,18 @@ func (c *Client) PatchCertificateSigningRequestWithApplicationJSONPatchPlusJSONB
 	return c.Client.Do(req)
 }
 
+func (c *Client) PatchCertificateSigningRequestWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchCertificateSigningRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchCertificateSigningRequestRequestWithApplicationMergePatchPlusJSONBody(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ReplaceCertificateSigningRequestWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewReplaceCertificateSigningRequestRequestWithBody(c.Server, name, contentType, body)
 	if err != nil {
@@ -502,8 +612,8 @@ func (c *Client) UpdateCertificateSigningRequestApproval(ctx context.Context, na
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteDevices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteDevicesRequest(c.Server)
+func (c *Client) DeleteDevices(ctx context.Context, params *DeleteDevicesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteDevicesRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -550,6 +660,30 @@ func (c *Client) CreateDevice(ctx context.Context, body CreateDeviceJSONRequestB
 	return c.Client.Do(req)
 }
 
+func (c *Client) BatchGetDevicesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBatchGetDevicesRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BatchGetDevices(ctx context.Context, body BatchGetDevicesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBatchGetDevicesRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) DeleteDevice(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewDeleteDeviceRequest(c.Server, name)
 	if err != nil {
@@ -562,8 +696,8 @@ func (c *Client) DeleteDevice(ctx context.Context, name string, reqEditors ...Re
 	return c.Client.Do(req)
 }
 
-func (c *Client) ReadDevice(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewReadDeviceRequest(c.Server, name)
+func (c *Client) ReadDevice(ctx context.Context, name string, params *ReadDeviceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReadDeviceRequest(c.Server, name, params)
 	if err != nil {
 		return nil, err
 	}
@@ -598,8 +732,20 @@ func (c *Client) PatchDeviceWithApplicationJSONPatchPlusJSONBody(ctx context.Con
 	return c.Client.Do(req)
 }
 
-func (c *Client) ReplaceDeviceWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewReplaceDeviceRequestWithBody(c.Server, name, contentType, body)
+func (c *Client) PatchDeviceWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchDeviceApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchDeviceRequestWithApplicationMergePatchPlusJSONBody(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplaceDeviceWithBody(ctx context.Context, name string, params *ReplaceDeviceParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceDeviceRequestWithBody(c.Server, name, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -610,8 +756,8 @@ func (c *Client) ReplaceDeviceWithBody(ctx context.Context, name string, content
 	return c.Client.Do(req)
 }
 
-func (c *Client) ReplaceDevice(ctx context.Context, name string, body ReplaceDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewReplaceDeviceRequest(c.Server, name, body)
+func (c *Client) ReplaceDevice(ctx context.Context, name string, params *ReplaceDeviceParams, body ReplaceDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceDeviceRequest(c.Server, name, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -658,6 +804,42 @@ func (c *Client) DecommissionDevice(ctx context.Context, name string, body Decom
 	return c.Client.Do(req)
 }
 
+func (c *Client) ReadDeviceLogs(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReadDeviceLogsRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplaceDeviceLogsWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceDeviceLogsRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplaceDeviceLogs(ctx context.Context, name string, body ReplaceDeviceLogsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceDeviceLogsRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetRenderedDeviceSpec(ctx context.Context, name string, params *GetRenderedDeviceSpecParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetRenderedDeviceSpecRequest(c.Server, name, params)
 	if err != nil {
@@ -670,6 +852,18 @@ func (c *Client) GetRenderedDeviceSpec(ctx context.Context, name string, params
 	return c.Client.Do(req)
 }
 
+func (c *Client) ListDeviceRevisions(ctx context.Context, name string, params *ListDeviceRevisionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListDeviceRevisionsRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ReadDeviceStatus(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewReadDeviceStatusRequest(c.Server, name)
 	if err != nil {
@@ -730,6 +924,30 @@ func (c *Client) ReplaceDeviceStatus(ctx context.Context, name string, body Repl
 	return c.Client.Do(req)
 }
 
+func (c *Client) ReleaseDeviceUpdateSlot(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReleaseDeviceUpdateSlotRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcquireDeviceUpdateSlot(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcquireDeviceUpdateSlotRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetEnrollmentConfig(ctx context.Context, params *GetEnrollmentConfigParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetEnrollmentConfigRequest(c.Server, params)
 	if err != nil {
@@ -838,6 +1056,18 @@ func (c *Client) PatchEnrollmentRequestWithApplicationJSONPatchPlusJSONBody(ctx
 	return c.Client.Do(req)
 }
 
+func (c *Client) PatchEnrollmentRequestWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchEnrollmentRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchEnrollmentRequestRequestWithApplicationMergePatchPlusJSONBody(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ReplaceEnrollmentRequestWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewReplaceEnrollmentRequestRequestWithBody(c.Server, name, contentType, body)
 	if err != nil {
@@ -1090,8 +1320,32 @@ func (c *Client) PatchFleetWithApplicationJSONPatchPlusJSONBody(ctx context.Cont
 	return c.Client.Do(req)
 }
 
-func (c *Client) ReplaceFleetWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewReplaceFleetRequestWithBody(c.Server, name, contentType, body)
+func (c *Client) PatchFleetWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchFleetApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchFleetRequestWithApplicationMergePatchPlusJSONBody(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplaceFleetWithBody(ctx context.Context, name string, params *ReplaceFleetParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceFleetRequestWithBody(c.Server, name, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplaceFleet(ctx context.Context, name string, params *ReplaceFleetParams, body ReplaceFleetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceFleetRequest(c.Server, name, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1102,8 +1356,8 @@ func (c *Client) ReplaceFleetWithBody(ctx context.Context, name string, contentT
 	return c.Client.Do(req)
 }
 
-func (c *Client) ReplaceFleet(ctx context.Context, name string, body ReplaceFleetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewReplaceFleetRequest(c.Server, name, body)
+func (c *Client) ListFleetRevisions(ctx context.Context, name string, params *ListFleetRevisionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListFleetRevisionsRequest(c.Server, name, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1174,6 +1428,18 @@ func (c *Client) ReplaceFleetStatus(ctx context.Context, name string, body Repla
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetQuotaUsage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuotaUsageRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) DeleteRepositories(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewDeleteRepositoriesRequest(c.Server)
 	if err != nil {
@@ -1270,8 +1536,20 @@ func (c *Client) PatchRepositoryWithApplicationJSONPatchPlusJSONBody(ctx context
 	return c.Client.Do(req)
 }
 
-func (c *Client) ReplaceRepositoryWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewReplaceRepositoryRequestWithBody(c.Server, name, contentType, body)
+func (c *Client) PatchRepositoryWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchRepositoryApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchRepositoryRequestWithApplicationMergePatchPlusJSONBody(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplaceRepositoryWithBody(ctx context.Context, name string, params *ReplaceRepositoryParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceRepositoryRequestWithBody(c.Server, name, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1282,8 +1560,8 @@ func (c *Client) ReplaceRepositoryWithBody(ctx context.Context, name string, con
 	return c.Client.Do(req)
 }
 
-func (c *Client) ReplaceRepository(ctx context.Context, name string, body ReplaceRepositoryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewReplaceRepositoryRequest(c.Server, name, body)
+func (c *Client) ReplaceRepository(ctx context.Context, name string, params *ReplaceRepositoryParams, body ReplaceRepositoryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceRepositoryRequest(c.Server, name, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1390,8 +1668,20 @@ func (c *Client) PatchResourceSyncWithApplicationJSONPatchPlusJSONBody(ctx conte
 	return c.Client.Do(req)
 }
 
-func (c *Client) ReplaceResourceSyncWithBody(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewReplaceResourceSyncRequestWithBody(c.Server, name, contentType, body)
+func (c *Client) PatchResourceSyncWithApplicationMergePatchPlusJSONBody(ctx context.Context, name string, body PatchResourceSyncApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchResourceSyncRequestWithApplicationMergePatchPlusJSONBody(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReplaceResourceSyncWithBody(ctx context.Context, name string, params *ReplaceResourceSyncParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceResourceSyncRequestWithBody(c.Server, name, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1402,8 +1692,8 @@ func (c *Client) ReplaceResourceSyncWithBody(ctx context.Context, name string, c
 	return c.Client.Do(req)
 }
 
-func (c *Client) ReplaceResourceSync(ctx context.Context, name string, body ReplaceResourceSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewReplaceResourceSyncRequest(c.Server, name, body)
+func (c *Client) ReplaceResourceSync(ctx context.Context, name string, params *ReplaceResourceSyncParams, body ReplaceResourceSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReplaceResourceSyncRequest(c.Server, name, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1483,8 +1773,19 @@ func NewAuthValidateRequest(server string, params *AuthValidateParams) (*http.Re
 	return req, nil
 }
 
-// NewDeleteCertificateSigningRequestsRequest generates requests for DeleteCertificateSigningRequests
-func NewDeleteCertificateSigningRequestsRequest(server string) (*http.Request, error) {
+// NewIssueBootstrapTokenRequest calls the generic IssueBootstrapToken builder with application/json body
+func NewIssueBootstrapTokenRequest(server string, body IssueBootstrapTokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewIssueBootstrapTokenRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewIssueBootstrapTokenRequestWithBody generates requests for IssueBootstrapToken with any type of body
+func NewIssueBootstrapTokenRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1492,7 +1793,7 @@ func NewDeleteCertificateSigningRequestsRequest(server string) (*http.Request, e
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/certificatesigningrequests")
+	operationPath := fmt.Sprintf("/api/v1/bootstraptokens")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1502,16 +1803,29 @@ func NewDeleteCertificateSigningRequestsRequest(server string) (*http.Request, e
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewListCertificateSigningRequestsRequest generates requests for ListCertificateSigningRequests
-func NewListCertificateSigningRequestsRequest(server string, params *ListCertificateSigningRequestsParams) (*http.Request, error) {
+// NewRedeemBootstrapTokenRequest calls the generic RedeemBootstrapToken builder with application/json body
+func NewRedeemBootstrapTokenRequest(server string, body RedeemBootstrapTokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRedeemBootstrapTokenRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRedeemBootstrapTokenRequestWithBody generates requests for RedeemBootstrapToken with any type of body
+func NewRedeemBootstrapTokenRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1519,7 +1833,7 @@ func NewListCertificateSigningRequestsRequest(server string, params *ListCertifi
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/certificatesigningrequests")
+	operationPath := fmt.Sprintf("/api/v1/bootstraptokens/redeem")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1529,13 +1843,69 @@ func NewListCertificateSigningRequestsRequest(server string, params *ListCertifi
 		return nil, err
 	}
 
-	if params != nil {
-		queryValues := queryURL.Query()
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-		if params.Continue != nil {
+	req.Header.Add("Content-Type", contentType)
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "continue", runtime.ParamLocationQuery, *params.Continue); err != nil {
-				return nil, err
+	return req, nil
+}
+
+// NewDeleteCertificateSigningRequestsRequest generates requests for DeleteCertificateSigningRequests
+func NewDeleteCertificateSigningRequestsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/certificatesigningrequests")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListCertificateSigningRequestsRequest generates requests for ListCertificateSigningRequests
+func NewListCertificateSigningRequestsRequest(server string, params *ListCertificateSigningRequestsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/certificatesigningrequests")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Continue != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "continue", runtime.ParamLocationQuery, *params.Continue); err != nil {
+				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
 			} else {
@@ -1726,6 +2096,17 @@ func NewPatchCertificateSigningRequestRequestWithApplicationJSONPatchPlusJSONBod
 	return NewPatchCertificateSigningRequestRequestWithBody(server, name, "application/json-patch+json", bodyReader)
 }
 
+// NewPatchCertificateSigningRequestRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchCertificateSigningRequest builder with application/merge-patch+json body
+func NewPatchCertificateSigningRequestRequestWithApplicationMergePatchPlusJSONBody(server string, name string, body PatchCertificateSigningRequestApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchCertificateSigningRequestRequestWithBody(server, name, "application/merge-patch+json", bodyReader)
+}
+
 // NewPatchCertificateSigningRequestRequestWithBody generates requests for PatchCertificateSigningRequest with any type of body
 func NewPatchCertificateSigningRequestRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
@@ -1857,7 +2238,7 @@ func NewUpdateCertificateSigningRequestApprovalRequestWithBody(server string, na
 }
 
 // NewDeleteDevicesRequest generates requests for DeleteDevices
-func NewDeleteDevicesRequest(server string) (*http.Request, error) {
+func NewDeleteDevicesRequest(server string, params *DeleteDevicesParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1875,6 +2256,28 @@ func NewDeleteDevicesRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.LabelSelector != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "labelSelector", runtime.ParamLocationQuery, *params.LabelSelector); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1985,6 +2388,54 @@ func NewListDevicesRequest(server string, params *ListDevicesParams) (*http.Requ
 
 		}
 
+		if params.NeedsAttention != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "needsAttention", runtime.ParamLocationQuery, *params.NeedsAttention); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Fields != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "fields", runtime.ParamLocationQuery, *params.Fields); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.GroupByLabel != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "groupByLabel", runtime.ParamLocationQuery, *params.GroupByLabel); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
 		queryURL.RawQuery = queryValues.Encode()
 	}
 
@@ -2036,6 +2487,46 @@ func NewCreateDeviceRequestWithBody(server string, contentType string, body io.R
 	return req, nil
 }
 
+// NewBatchGetDevicesRequest calls the generic BatchGetDevices builder with application/json body
+func NewBatchGetDevicesRequest(server string, body BatchGetDevicesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewBatchGetDevicesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewBatchGetDevicesRequestWithBody generates requests for BatchGetDevices with any type of body
+func NewBatchGetDevicesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/devices/batchget")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewDeleteDeviceRequest generates requests for DeleteDevice
 func NewDeleteDeviceRequest(server string, name string) (*http.Request, error) {
 	var err error
@@ -2071,7 +2562,7 @@ func NewDeleteDeviceRequest(server string, name string) (*http.Request, error) {
 }
 
 // NewReadDeviceRequest generates requests for ReadDevice
-func NewReadDeviceRequest(server string, name string) (*http.Request, error) {
+func NewReadDeviceRequest(server string, name string, params *ReadDeviceParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -2096,6 +2587,28 @@ func NewReadDeviceRequest(server string, name string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Fields != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "fields", runtime.ParamLocationQuery, *params.Fields); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -2115,6 +2628,17 @@ func NewPatchDeviceRequestWithApplicationJSONPatchPlusJSONBody(server string, na
 	return NewPatchDeviceRequestWithBody(server, name, "application/json-patch+json", bodyReader)
 }
 
+// NewPatchDeviceRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchDevice builder with application/merge-patch+json body
+func NewPatchDeviceRequestWithApplicationMergePatchPlusJSONBody(server string, name string, body PatchDeviceApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchDeviceRequestWithBody(server, name, "application/merge-patch+json", bodyReader)
+}
+
 // NewPatchDeviceRequestWithBody generates requests for PatchDevice with any type of body
 func NewPatchDeviceRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
@@ -2152,18 +2676,18 @@ func NewPatchDeviceRequestWithBody(server string, name string, contentType strin
 }
 
 // NewReplaceDeviceRequest calls the generic ReplaceDevice builder with application/json body
-func NewReplaceDeviceRequest(server string, name string, body ReplaceDeviceJSONRequestBody) (*http.Request, error) {
+func NewReplaceDeviceRequest(server string, name string, params *ReplaceDeviceParams, body ReplaceDeviceJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewReplaceDeviceRequestWithBody(server, name, "application/json", bodyReader)
+	return NewReplaceDeviceRequestWithBody(server, name, params, "application/json", bodyReader)
 }
 
 // NewReplaceDeviceRequestWithBody generates requests for ReplaceDevice with any type of body
-func NewReplaceDeviceRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
+func NewReplaceDeviceRequestWithBody(server string, name string, params *ReplaceDeviceParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -2188,6 +2712,44 @@ func NewReplaceDeviceRequestWithBody(server string, name string, contentType str
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.FieldManager != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "fieldManager", runtime.ParamLocationQuery, *params.FieldManager); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Force != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "force", runtime.ParamLocationQuery, *params.Force); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
@@ -2279,8 +2841,8 @@ func NewDecommissionDeviceRequestWithBody(server string, name string, contentTyp
 	return req, nil
 }
 
-// NewGetRenderedDeviceSpecRequest generates requests for GetRenderedDeviceSpec
-func NewGetRenderedDeviceSpecRequest(server string, name string, params *GetRenderedDeviceSpecParams) (*http.Request, error) {
+// NewReadDeviceLogsRequest generates requests for ReadDeviceLogs
+func NewReadDeviceLogsRequest(server string, name string) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -2295,7 +2857,7 @@ func NewGetRenderedDeviceSpecRequest(server string, name string, params *GetRend
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/devices/%s/rendered", pathParam0)
+	operationPath := fmt.Sprintf("/api/v1/devices/%s/logs", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2305,28 +2867,6 @@ func NewGetRenderedDeviceSpecRequest(server string, name string, params *GetRend
 		return nil, err
 	}
 
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if params.KnownRenderedVersion != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "knownRenderedVersion", runtime.ParamLocationQuery, *params.KnownRenderedVersion); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		queryURL.RawQuery = queryValues.Encode()
-	}
-
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -2335,13 +2875,172 @@ func NewGetRenderedDeviceSpecRequest(server string, name string, params *GetRend
 	return req, nil
 }
 
-// NewReadDeviceStatusRequest generates requests for ReadDeviceStatus
-func NewReadDeviceStatusRequest(server string, name string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+// NewReplaceDeviceLogsRequest calls the generic ReplaceDeviceLogs builder with application/json body
+func NewReplaceDeviceLogsRequest(server string, name string, body ReplaceDeviceLogsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReplaceDeviceLogsRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewReplaceDeviceLogsRequestWithBody generates requests for ReplaceDeviceLogs with any type of body
+func NewReplaceDeviceLogsRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/devices/%s/logs", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetRenderedDeviceSpecRequest generates requests for GetRenderedDeviceSpec
+func NewGetRenderedDeviceSpecRequest(server string, name string, params *GetRenderedDeviceSpecParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/devices/%s/rendered", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.KnownRenderedVersion != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "knownRenderedVersion", runtime.ParamLocationQuery, *params.KnownRenderedVersion); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListDeviceRevisionsRequest generates requests for ListDeviceRevisions
+func NewListDeviceRevisionsRequest(server string, name string, params *ListDeviceRevisionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/devices/%s/revisions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReadDeviceStatusRequest generates requests for ReadDeviceStatus
+func NewReadDeviceStatusRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
 	if err != nil {
 		return nil, err
 	}
@@ -2463,6 +3162,74 @@ func NewReplaceDeviceStatusRequestWithBody(server string, name string, contentTy
 	return req, nil
 }
 
+// NewReleaseDeviceUpdateSlotRequest generates requests for ReleaseDeviceUpdateSlot
+func NewReleaseDeviceUpdateSlotRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/devices/%s/updateslot", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAcquireDeviceUpdateSlotRequest generates requests for AcquireDeviceUpdateSlot
+func NewAcquireDeviceUpdateSlotRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/devices/%s/updateslot", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewGetEnrollmentConfigRequest generates requests for GetEnrollmentConfig
 func NewGetEnrollmentConfigRequest(server string, params *GetEnrollmentConfigParams) (*http.Request, error) {
 	var err error
@@ -2625,36 +3392,84 @@ func NewListEnrollmentRequestsRequest(server string, params *ListEnrollmentReque
 
 		}
 
-		queryURL.RawQuery = queryValues.Encode()
-	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return req, nil
-}
+		if params.Status != nil {
 
-// NewCreateEnrollmentRequestRequest calls the generic CreateEnrollmentRequest builder with application/json body
-func NewCreateEnrollmentRequestRequest(server string, body CreateEnrollmentRequestJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewCreateEnrollmentRequestRequestWithBody(server, "application/json", bodyReader)
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-// NewCreateEnrollmentRequestRequestWithBody generates requests for CreateEnrollmentRequest with any type of body
-func NewCreateEnrollmentRequestRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+		}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+		if params.CreatedAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdAfter", runtime.ParamLocationQuery, *params.CreatedAfter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedBefore != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdBefore", runtime.ParamLocationQuery, *params.CreatedBefore); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateEnrollmentRequestRequest calls the generic CreateEnrollmentRequest builder with application/json body
+func NewCreateEnrollmentRequestRequest(server string, body CreateEnrollmentRequestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateEnrollmentRequestRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateEnrollmentRequestRequestWithBody generates requests for CreateEnrollmentRequest with any type of body
+func NewCreateEnrollmentRequestRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
 	operationPath := fmt.Sprintf("/api/v1/enrollmentrequests")
 	if operationPath[0] == '/' {
@@ -2755,6 +3570,17 @@ func NewPatchEnrollmentRequestRequestWithApplicationJSONPatchPlusJSONBody(server
 	return NewPatchEnrollmentRequestRequestWithBody(server, name, "application/json-patch+json", bodyReader)
 }
 
+// NewPatchEnrollmentRequestRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchEnrollmentRequest builder with application/merge-patch+json body
+func NewPatchEnrollmentRequestRequestWithApplicationMergePatchPlusJSONBody(server string, name string, body PatchEnrollmentRequestApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchEnrollmentRequestRequestWithBody(server, name, "application/merge-patch+json", bodyReader)
+}
+
 // NewPatchEnrollmentRequestRequestWithBody generates requests for PatchEnrollmentRequest with any type of body
 func NewPatchEnrollmentRequestRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
@@ -3514,6 +4340,17 @@ func NewPatchFleetRequestWithApplicationJSONPatchPlusJSONBody(server string, nam
 	return NewPatchFleetRequestWithBody(server, name, "application/json-patch+json", bodyReader)
 }
 
+// NewPatchFleetRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchFleet builder with application/merge-patch+json body
+func NewPatchFleetRequestWithApplicationMergePatchPlusJSONBody(server string, name string, body PatchFleetApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchFleetRequestWithBody(server, name, "application/merge-patch+json", bodyReader)
+}
+
 // NewPatchFleetRequestWithBody generates requests for PatchFleet with any type of body
 func NewPatchFleetRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
@@ -3551,18 +4388,18 @@ func NewPatchFleetRequestWithBody(server string, name string, contentType string
 }
 
 // NewReplaceFleetRequest calls the generic ReplaceFleet builder with application/json body
-func NewReplaceFleetRequest(server string, name string, body ReplaceFleetJSONRequestBody) (*http.Request, error) {
+func NewReplaceFleetRequest(server string, name string, params *ReplaceFleetParams, body ReplaceFleetJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewReplaceFleetRequestWithBody(server, name, "application/json", bodyReader)
+	return NewReplaceFleetRequestWithBody(server, name, params, "application/json", bodyReader)
 }
 
 // NewReplaceFleetRequestWithBody generates requests for ReplaceFleet with any type of body
-func NewReplaceFleetRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
+func NewReplaceFleetRequestWithBody(server string, name string, params *ReplaceFleetParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -3587,6 +4424,44 @@ func NewReplaceFleetRequestWithBody(server string, name string, contentType stri
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.FieldManager != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "fieldManager", runtime.ParamLocationQuery, *params.FieldManager); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Force != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "force", runtime.ParamLocationQuery, *params.Force); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
@@ -3597,6 +4472,62 @@ func NewReplaceFleetRequestWithBody(server string, name string, contentType stri
 	return req, nil
 }
 
+// NewListFleetRevisionsRequest generates requests for ListFleetRevisions
+func NewListFleetRevisionsRequest(server string, name string, params *ListFleetRevisionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/fleets/%s/revisions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewReadFleetStatusRequest generates requests for ReadFleetStatus
 func NewReadFleetStatusRequest(server string, name string) (*http.Request, error) {
 	var err error
@@ -3725,6 +4656,33 @@ func NewReplaceFleetStatusRequestWithBody(server string, name string, contentTyp
 	return req, nil
 }
 
+// NewGetQuotaUsageRequest generates requests for GetQuotaUsage
+func NewGetQuotaUsageRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/quotas")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewDeleteRepositoriesRequest generates requests for DeleteRepositories
 func NewDeleteRepositoriesRequest(server string) (*http.Request, error) {
 	var err error
@@ -3968,6 +4926,17 @@ func NewPatchRepositoryRequestWithApplicationJSONPatchPlusJSONBody(server string
 	return NewPatchRepositoryRequestWithBody(server, name, "application/json-patch+json", bodyReader)
 }
 
+// NewPatchRepositoryRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchRepository builder with application/merge-patch+json body
+func NewPatchRepositoryRequestWithApplicationMergePatchPlusJSONBody(server string, name string, body PatchRepositoryApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchRepositoryRequestWithBody(server, name, "application/merge-patch+json", bodyReader)
+}
+
 // NewPatchRepositoryRequestWithBody generates requests for PatchRepository with any type of body
 func NewPatchRepositoryRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
@@ -4005,18 +4974,18 @@ func NewPatchRepositoryRequestWithBody(server string, name string, contentType s
 }
 
 // NewReplaceRepositoryRequest calls the generic ReplaceRepository builder with application/json body
-func NewReplaceRepositoryRequest(server string, name string, body ReplaceRepositoryJSONRequestBody) (*http.Request, error) {
+func NewReplaceRepositoryRequest(server string, name string, params *ReplaceRepositoryParams, body ReplaceRepositoryJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewReplaceRepositoryRequestWithBody(server, name, "application/json", bodyReader)
+	return NewReplaceRepositoryRequestWithBody(server, name, params, "application/json", bodyReader)
 }
 
 // NewReplaceRepositoryRequestWithBody generates requests for ReplaceRepository with any type of body
-func NewReplaceRepositoryRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
+func NewReplaceRepositoryRequestWithBody(server string, name string, params *ReplaceRepositoryParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -4041,6 +5010,44 @@ func NewReplaceRepositoryRequestWithBody(server string, name string, contentType
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.FieldManager != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "fieldManager", runtime.ParamLocationQuery, *params.FieldManager); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Force != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "force", runtime.ParamLocationQuery, *params.Force); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
@@ -4294,6 +5301,17 @@ func NewPatchResourceSyncRequestWithApplicationJSONPatchPlusJSONBody(server stri
 	return NewPatchResourceSyncRequestWithBody(server, name, "application/json-patch+json", bodyReader)
 }
 
+// NewPatchResourceSyncRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchResourceSync builder with application/merge-patch+json body
+func NewPatchResourceSyncRequestWithApplicationMergePatchPlusJSONBody(server string, name string, body PatchResourceSyncApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchResourceSyncRequestWithBody(server, name, "application/merge-patch+json", bodyReader)
+}
+
 // NewPatchResourceSyncRequestWithBody generates requests for PatchResourceSync with any type of body
 func NewPatchResourceSyncRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
@@ -4331,18 +5349,18 @@ func NewPatchResourceSyncRequestWithBody(server string, name string, contentType
 }
 
 // NewReplaceResourceSyncRequest calls the generic ReplaceResourceSync builder with application/json body
-func NewReplaceResourceSyncRequest(server string, name string, body ReplaceResourceSyncJSONRequestBody) (*http.Request, error) {
+func NewReplaceResourceSyncRequest(server string, name string, params *ReplaceResourceSyncParams, body ReplaceResourceSyncJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewReplaceResourceSyncRequestWithBody(server, name, "application/json", bodyReader)
+	return NewReplaceResourceSyncRequestWithBody(server, name, params, "application/json", bodyReader)
 }
 
 // NewReplaceResourceSyncRequestWithBody generates requests for ReplaceResourceSync with any type of body
-func NewReplaceResourceSyncRequestWithBody(server string, name string, contentType string, body io.Reader) (*http.Request, error) {
+func NewReplaceResourceSyncRequestWithBody(server string, name string, params *ReplaceResourceSyncParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -4367,18 +5385,56 @@ func NewReplaceResourceSyncRequestWithBody(server string, name string, contentTy
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
-	if err != nil {
-		return nil, err
-	}
+	if params != nil {
+		queryValues := queryURL.Query()
 
-	req.Header.Add("Content-Type", contentType)
+		if params.FieldManager != nil {
 
-	return req, nil
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "fieldManager", runtime.ParamLocationQuery, *params.FieldManager); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
+		}
+
+		if params.Force != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "force", runtime.ParamLocationQuery, *params.Force); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
 			return err
 		}
@@ -4426,6 +5482,16 @@ type ClientWithResponsesInterface interface {
 	// AuthValidateWithResponse request
 	AuthValidateWithResponse(ctx context.Context, params *AuthValidateParams, reqEditors ...RequestEditorFn) (*AuthValidateResponse, error)
 
+	// IssueBootstrapTokenWithBodyWithResponse request with any body
+	IssueBootstrapTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueBootstrapTokenResponse, error)
+
+	IssueBootstrapTokenWithResponse(ctx context.Context, body IssueBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueBootstrapTokenResponse, error)
+
+	// RedeemBootstrapTokenWithBodyWithResponse request with any body
+	RedeemBootstrapTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RedeemBootstrapTokenResponse, error)
+
+	RedeemBootstrapTokenWithResponse(ctx context.Context, body RedeemBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*RedeemBootstrapTokenResponse, error)
+
 	// DeleteCertificateSigningRequestsWithResponse request
 	DeleteCertificateSigningRequestsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteCertificateSigningRequestsResponse, error)
 
@@ -4448,6 +5514,8 @@ type ClientWithResponsesInterface interface {
 
 	PatchCertificateSigningRequestWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchCertificateSigningRequestResponse, error)
 
+	PatchCertificateSigningRequestWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchCertificateSigningRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchCertificateSigningRequestResponse, error)
+
 	// ReplaceCertificateSigningRequestWithBodyWithResponse request with any body
 	ReplaceCertificateSigningRequestWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceCertificateSigningRequestResponse, error)
 
@@ -4459,7 +5527,7 @@ type ClientWithResponsesInterface interface {
 	UpdateCertificateSigningRequestApprovalWithResponse(ctx context.Context, name string, body UpdateCertificateSigningRequestApprovalJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateCertificateSigningRequestApprovalResponse, error)
 
 	// DeleteDevicesWithResponse request
-	DeleteDevicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteDevicesResponse, error)
+	DeleteDevicesWithResponse(ctx context.Context, params *DeleteDevicesParams, reqEditors ...RequestEditorFn) (*DeleteDevicesResponse, error)
 
 	// ListDevicesWithResponse request
 	ListDevicesWithResponse(ctx context.Context, params *ListDevicesParams, reqEditors ...RequestEditorFn) (*ListDevicesResponse, error)
@@ -4469,21 +5537,28 @@ type ClientWithResponsesInterface interface {
 
 	CreateDeviceWithResponse(ctx context.Context, body CreateDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateDeviceResponse, error)
 
+	// BatchGetDevicesWithBodyWithResponse request with any body
+	BatchGetDevicesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BatchGetDevicesResponse, error)
+
+	BatchGetDevicesWithResponse(ctx context.Context, body BatchGetDevicesJSONRequestBody, reqEditors ...RequestEditorFn) (*BatchGetDevicesResponse, error)
+
 	// DeleteDeviceWithResponse request
 	DeleteDeviceWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*DeleteDeviceResponse, error)
 
 	// ReadDeviceWithResponse request
-	ReadDeviceWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReadDeviceResponse, error)
+	ReadDeviceWithResponse(ctx context.Context, name string, params *ReadDeviceParams, reqEditors ...RequestEditorFn) (*ReadDeviceResponse, error)
 
 	// PatchDeviceWithBodyWithResponse request with any body
 	PatchDeviceWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchDeviceResponse, error)
 
 	PatchDeviceWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchDeviceApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchDeviceResponse, error)
 
+	PatchDeviceWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchDeviceApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchDeviceResponse, error)
+
 	// ReplaceDeviceWithBodyWithResponse request with any body
-	ReplaceDeviceWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceDeviceResponse, error)
+	ReplaceDeviceWithBodyWithResponse(ctx context.Context, name string, params *ReplaceDeviceParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceDeviceResponse, error)
 
-	ReplaceDeviceWithResponse(ctx context.Context, name string, body ReplaceDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceDeviceResponse, error)
+	ReplaceDeviceWithResponse(ctx context.Context, name string, params *ReplaceDeviceParams, body ReplaceDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceDeviceResponse, error)
 
 	// RequestConsoleWithResponse request
 	RequestConsoleWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*RequestConsoleResponse, error)
@@ -4493,9 +5568,20 @@ type ClientWithResponsesInterface interface {
 
 	DecommissionDeviceWithResponse(ctx context.Context, name string, body DecommissionDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*DecommissionDeviceResponse, error)
 
+	// ReadDeviceLogsWithResponse request
+	ReadDeviceLogsWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReadDeviceLogsResponse, error)
+
+	// ReplaceDeviceLogsWithBodyWithResponse request with any body
+	ReplaceDeviceLogsWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceDeviceLogsResponse, error)
+
+	ReplaceDeviceLogsWithResponse(ctx context.Context, name string, body ReplaceDeviceLogsJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceDeviceLogsResponse, error)
+
 	// GetRenderedDeviceSpecWithResponse request
 	GetRenderedDeviceSpecWithResponse(ctx context.Context, name string, params *GetRenderedDeviceSpecParams, reqEditors ...RequestEditorFn) (*GetRenderedDeviceSpecResponse, error)
 
+	// ListDeviceRevisionsWithResponse request
+	ListDeviceRevisionsWithResponse(ctx context.Context, name string, params *ListDeviceRevisionsParams, reqEditors ...RequestEditorFn) (*ListDeviceRevisionsResponse, error)
+
 	// ReadDeviceStatusWithResponse request
 	ReadDeviceStatusWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReadDeviceStatusResponse, error)
 
@@ -4509,6 +5595,12 @@ type ClientWithResponsesInterface interface {
 
 	ReplaceDeviceStatusWithResponse(ctx context.Context, name string, body ReplaceDeviceStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceDeviceStatusResponse, error)
 
+	// ReleaseDeviceUpdateSlotWithResponse request
+	ReleaseDeviceUpdateSlotWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReleaseDeviceUpdateSlotResponse, error)
+
+	// AcquireDeviceUpdateSlotWithResponse request
+	AcquireDeviceUpdateSlotWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*AcquireDeviceUpdateSlotResponse, error)
+
 	// GetEnrollmentConfigWithResponse request
 	GetEnrollmentConfigWithResponse(ctx context.Context, params *GetEnrollmentConfigParams, reqEditors ...RequestEditorFn) (*GetEnrollmentConfigResponse, error)
 
@@ -4534,6 +5626,8 @@ type ClientWithResponsesInterface interface {
 
 	PatchEnrollmentRequestWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchEnrollmentRequestApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEnrollmentRequestResponse, error)
 
+	PatchEnrollmentRequestWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchEnrollmentRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEnrollmentRequestResponse, error)
+
 	// ReplaceEnrollmentRequestWithBodyWithResponse request with any body
 	ReplaceEnrollmentRequestWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceEnrollmentRequestResponse, error)
 
@@ -4591,10 +5685,15 @@ type ClientWithResponsesInterface interface {
 
 	PatchFleetWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchFleetApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchFleetResponse, error)
 
+	PatchFleetWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchFleetApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchFleetResponse, error)
+
 	// ReplaceFleetWithBodyWithResponse request with any body
-	ReplaceFleetWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceFleetResponse, error)
+	ReplaceFleetWithBodyWithResponse(ctx context.Context, name string, params *ReplaceFleetParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceFleetResponse, error)
+
+	ReplaceFleetWithResponse(ctx context.Context, name string, params *ReplaceFleetParams, body ReplaceFleetJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceFleetResponse, error)
 
-	ReplaceFleetWithResponse(ctx context.Context, name string, body ReplaceFleetJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceFleetResponse, error)
+	// ListFleetRevisionsWithResponse request
+	ListFleetRevisionsWithResponse(ctx context.Context, name string, params *ListFleetRevisionsParams, reqEditors ...RequestEditorFn) (*ListFleetRevisionsResponse, error)
 
 	// ReadFleetStatusWithResponse request
 	ReadFleetStatusWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReadFleetStatusResponse, error)
@@ -4609,6 +5708,9 @@ type ClientWithResponsesInterface interface {
 
 	ReplaceFleetStatusWithResponse(ctx context.Context, name string, body ReplaceFleetStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceFleetStatusResponse, error)
 
+	// GetQuotaUsageWithResponse request
+	GetQuotaUsageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetQuotaUsageResponse, error)
+
 	// DeleteRepositoriesWithResponse request
 	DeleteRepositoriesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteRepositoriesResponse, error)
 
@@ -4631,10 +5733,12 @@ type ClientWithResponsesInterface interface {
 
 	PatchRepositoryWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchRepositoryApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRepositoryResponse, error)
 
+	PatchRepositoryWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchRepositoryApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRepositoryResponse, error)
+
 	// ReplaceRepositoryWithBodyWithResponse request with any body
-	ReplaceRepositoryWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceRepositoryResponse, error)
+	ReplaceRepositoryWithBodyWithResponse(ctx context.Context, name string, params *ReplaceRepositoryParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceRepositoryResponse, error)
 
-	ReplaceRepositoryWithResponse(ctx context.Context, name string, body ReplaceRepositoryJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceRepositoryResponse, error)
+	ReplaceRepositoryWithResponse(ctx context.Context, name string, params *ReplaceRepositoryParams, body ReplaceRepositoryJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceRepositoryResponse, error)
 
 	// DeleteResourceSyncsWithResponse request
 	DeleteResourceSyncsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteResourceSyncsResponse, error)
@@ -4658,10 +5762,12 @@ type ClientWithResponsesInterface interface {
 
 	PatchResourceSyncWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchResourceSyncResponse, error)
 
+	PatchResourceSyncWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchResourceSyncApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchResourceSyncResponse, error)
+
 	// ReplaceResourceSyncWithBodyWithResponse request with any body
-	ReplaceResourceSyncWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceResourceSyncResponse, error)
+	ReplaceResourceSyncWithBodyWithResponse(ctx context.Context, name string, params *ReplaceResourceSyncParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceResourceSyncResponse, error)
 
-	ReplaceResourceSyncWithResponse(ctx context.Context, name string, body ReplaceResourceSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceResourceSyncResponse, error)
+	ReplaceResourceSyncWithResponse(ctx context.Context, name string, params *ReplaceResourceSyncParams, body ReplaceResourceSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceResourceSyncResponse, error)
 }
 
 type AuthConfigResponse struct {
@@ -4708,6 +5814,60 @@ func (r AuthValidateResponse) StatusCode() int {
 	return 0
 }
 
+type IssueBootstrapTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *BootstrapToken
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r IssueBootstrapTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r IssueBootstrapTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RedeemBootstrapTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BootstrapTokenRedemptionResponse
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RedeemBootstrapTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RedeemBootstrapTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type DeleteCertificateSigningRequestsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -4926,7 +6086,8 @@ func (r UpdateCertificateSigningRequestApprovalResponse) StatusCode() int {
 type DeleteDevicesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *Status
+	JSON200      *DeleteCollectionResult
+	JSON400      *Error
 	JSON401      *Error
 	JSON403      *Error
 	JSON503      *Error
@@ -5001,6 +6162,32 @@ func (r CreateDeviceResponse) StatusCode() int {
 	return 0
 }
 
+type BatchGetDevicesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DeviceBatchGetResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r BatchGetDevicesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BatchGetDevicesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type DeleteDeviceResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -5031,6 +6218,7 @@ type ReadDeviceResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *Device
+	JSON400      *Error
 	JSON401      *Error
 	JSON403      *Error
 	JSON404      *Error
@@ -5164,6 +6352,59 @@ func (r DecommissionDeviceResponse) StatusCode() int {
 	return 0
 }
 
+type ReadDeviceLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DeviceLogs
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReadDeviceLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReadDeviceLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReplaceDeviceLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DeviceLogs
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReplaceDeviceLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReplaceDeviceLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetRenderedDeviceSpecResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -5191,6 +6432,33 @@ func (r GetRenderedDeviceSpecResponse) StatusCode() int {
 	return 0
 }
 
+type ListDeviceRevisionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ResourceRevisionList
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListDeviceRevisionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListDeviceRevisionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ReadDeviceStatusResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -5271,6 +6539,58 @@ func (r ReplaceDeviceStatusResponse) StatusCode() int {
 	return 0
 }
 
+type ReleaseDeviceUpdateSlotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DeviceUpdateSlot
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReleaseDeviceUpdateSlotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReleaseDeviceUpdateSlotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AcquireDeviceUpdateSlotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DeviceUpdateSlot
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AcquireDeviceUpdateSlotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AcquireDeviceUpdateSlotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetEnrollmentConfigResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -5882,10 +7202,11 @@ func (r ReplaceFleetResponse) StatusCode() int {
 	return 0
 }
 
-type ReadFleetStatusResponse struct {
+type ListFleetRevisionsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *Fleet
+	JSON200      *ResourceRevisionList
+	JSON400      *Error
 	JSON401      *Error
 	JSON403      *Error
 	JSON404      *Error
@@ -5893,7 +7214,7 @@ type ReadFleetStatusResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r ReadFleetStatusResponse) Status() string {
+func (r ListFleetRevisionsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -5901,18 +7222,17 @@ func (r ReadFleetStatusResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ReadFleetStatusResponse) StatusCode() int {
+func (r ListFleetRevisionsResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PatchFleetStatusResponse struct {
+type ReadFleetStatusResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *Fleet
-	JSON400      *Error
 	JSON401      *Error
 	JSON403      *Error
 	JSON404      *Error
@@ -5920,7 +7240,7 @@ type PatchFleetStatusResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r PatchFleetStatusResponse) Status() string {
+func (r ReadFleetStatusResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -5928,17 +7248,18 @@ func (r PatchFleetStatusResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PatchFleetStatusResponse) StatusCode() int {
+func (r ReadFleetStatusResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ReplaceFleetStatusResponse struct {
+type PatchFleetStatusResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *Fleet
+	JSON400      *Error
 	JSON401      *Error
 	JSON403      *Error
 	JSON404      *Error
@@ -5946,7 +7267,7 @@ type ReplaceFleetStatusResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r ReplaceFleetStatusResponse) Status() string {
+func (r PatchFleetStatusResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -5954,17 +7275,68 @@ func (r ReplaceFleetStatusResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ReplaceFleetStatusResponse) StatusCode() int {
+func (r PatchFleetStatusResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type DeleteRepositoriesResponse struct {
+type ReplaceFleetStatusResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *Status
+	JSON200      *Fleet
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReplaceFleetStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReplaceFleetStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQuotaUsageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QuotaUsage
+	JSON401      *Error
+	JSON403      *Error
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQuotaUsageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQuotaUsageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteRepositoriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Status
 	JSON401      *Error
 	JSON403      *Error
 	JSON503      *Error
@@ -6353,6 +7725,40 @@ func (c *ClientWithResponses) AuthValidateWithResponse(ctx context.Context, para
 	return ParseAuthValidateResponse(rsp)
 }
 
+// IssueBootstrapTokenWithBodyWithResponse request with arbitrary body returning *IssueBootstrapTokenResponse
+func (c *ClientWithResponses) IssueBootstrapTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueBootstrapTokenResponse, error) {
+	rsp, err := c.IssueBootstrapTokenWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssueBootstrapTokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) IssueBootstrapTokenWithResponse(ctx context.Context, body IssueBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueBootstrapTokenResponse, error) {
+	rsp, err := c.IssueBootstrapToken(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssueBootstrapTokenResponse(rsp)
+}
+
+// RedeemBootstrapTokenWithBodyWithResponse request with arbitrary body returning *RedeemBootstrapTokenResponse
+func (c *ClientWithResponses) RedeemBootstrapTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RedeemBootstrapTokenResponse, error) {
+	rsp, err := c.RedeemBootstrapTokenWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRedeemBootstrapTokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) RedeemBootstrapTokenWithResponse(ctx context.Context, body RedeemBootstrapTokenJSONRequestBody, reqEditors ...RequestEditorFn) (*RedeemBootstrapTokenResponse, error) {
+	rsp, err := c.RedeemBootstrapToken(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRedeemBootstrapTokenResponse(rsp)
+}
+
 // DeleteCertificateSigningRequestsWithResponse request returning *DeleteCertificateSigningRequestsResponse
 func (c *ClientWithResponses) DeleteCertificateSigningRequestsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteCertificateSigningRequestsResponse, error) {
 	rsp, err := c.DeleteCertificateSigningRequests(ctx, reqEditors...)
@@ -6423,6 +7829,14 @@ func (c *ClientWithResponses) PatchCertificateSigningRequestWithApplicationJSONP
 	return ParsePatchCertificateSigningRequestResponse(rsp)
 }
 
+func (c *ClientWithResponses) PatchCertificateSigningRequestWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchCertificateSigningRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchCertificateSigningRequestResponse, error) {
+	rsp, err := c.PatchCertificateSigningRequestWithApplicationMergePatchPlusJSONBody(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchCertificateSigningRequestResponse(rsp)
+}
+
 // ReplaceCertificateSigningRequestWithBodyWithResponse request with arbitrary body returning *ReplaceCertificateSigningRequestResponse
 func (c *ClientWithResponses) ReplaceCertificateSigningRequestWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceCertificateSigningRequestResponse, error) {
 	rsp, err := c.ReplaceCertificateSigningRequestWithBody(ctx, name, contentType, body, reqEditors...)
@@ -6458,8 +7872,8 @@ func (c *ClientWithResponses) UpdateCertificateSigningRequestApprovalWithRespons
 }
 
 // DeleteDevicesWithResponse request returning *DeleteDevicesResponse
-func (c *ClientWithResponses) DeleteDevicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteDevicesResponse, error) {
-	rsp, err := c.DeleteDevices(ctx, reqEditors...)
+func (c *ClientWithResponses) DeleteDevicesWithResponse(ctx context.Context, params *DeleteDevicesParams, reqEditors ...RequestEditorFn) (*DeleteDevicesResponse, error) {
+	rsp, err := c.DeleteDevices(ctx, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -6492,6 +7906,23 @@ func (c *ClientWithResponses) CreateDeviceWithResponse(ctx context.Context, body
 	return ParseCreateDeviceResponse(rsp)
 }
 
+// BatchGetDevicesWithBodyWithResponse request with arbitrary body returning *BatchGetDevicesResponse
+func (c *ClientWithResponses) BatchGetDevicesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BatchGetDevicesResponse, error) {
+	rsp, err := c.BatchGetDevicesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBatchGetDevicesResponse(rsp)
+}
+
+func (c *ClientWithResponses) BatchGetDevicesWithResponse(ctx context.Context, body BatchGetDevicesJSONRequestBody, reqEditors ...RequestEditorFn) (*BatchGetDevicesResponse, error) {
+	rsp, err := c.BatchGetDevices(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBatchGetDevicesResponse(rsp)
+}
+
 // DeleteDeviceWithResponse request returning *DeleteDeviceResponse
 func (c *ClientWithResponses) DeleteDeviceWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*DeleteDeviceResponse, error) {
 	rsp, err := c.DeleteDevice(ctx, name, reqEditors...)
@@ -6502,8 +7933,8 @@ func (c *ClientWithResponses) DeleteDeviceWithResponse(ctx context.Context, name
 }
 
 // ReadDeviceWithResponse request returning *ReadDeviceResponse
-func (c *ClientWithResponses) ReadDeviceWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReadDeviceResponse, error) {
-	rsp, err := c.ReadDevice(ctx, name, reqEditors...)
+func (c *ClientWithResponses) ReadDeviceWithResponse(ctx context.Context, name string, params *ReadDeviceParams, reqEditors ...RequestEditorFn) (*ReadDeviceResponse, error) {
+	rsp, err := c.ReadDevice(ctx, name, params, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -6527,17 +7958,25 @@ func (c *ClientWithResponses) PatchDeviceWithApplicationJSONPatchPlusJSONBodyWit
 	return ParsePatchDeviceResponse(rsp)
 }
 
+func (c *ClientWithResponses) PatchDeviceWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchDeviceApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchDeviceResponse, error) {
+	rsp, err := c.PatchDeviceWithApplicationMergePatchPlusJSONBody(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchDeviceResponse(rsp)
+}
+
 // ReplaceDeviceWithBodyWithResponse request with arbitrary body returning *ReplaceDeviceResponse
-func (c *ClientWithResponses) ReplaceDeviceWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceDeviceResponse, error) {
-	rsp, err := c.ReplaceDeviceWithBody(ctx, name, contentType, body, reqEditors...)
+func (c *ClientWithResponses) ReplaceDeviceWithBodyWithResponse(ctx context.Context, name string, params *ReplaceDeviceParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceDeviceResponse, error) {
+	rsp, err := c.ReplaceDeviceWithBody(ctx, name, params, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseReplaceDeviceResponse(rsp)
 }
 
-func (c *ClientWithResponses) ReplaceDeviceWithResponse(ctx context.Context, name string, body ReplaceDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceDeviceResponse, error) {
-	rsp, err := c.ReplaceDevice(ctx, name, body, reqEditors...)
+func (c *ClientWithResponses) ReplaceDeviceWithResponse(ctx context.Context, name string, params *ReplaceDeviceParams, body ReplaceDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceDeviceResponse, error) {
+	rsp, err := c.ReplaceDevice(ctx, name, params, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -6570,6 +8009,32 @@ func (c *ClientWithResponses) DecommissionDeviceWithResponse(ctx context.Context
 	return ParseDecommissionDeviceResponse(rsp)
 }
 
+// ReadDeviceLogsWithResponse request returning *ReadDeviceLogsResponse
+func (c *ClientWithResponses) ReadDeviceLogsWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReadDeviceLogsResponse, error) {
+	rsp, err := c.ReadDeviceLogs(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReadDeviceLogsResponse(rsp)
+}
+
+// ReplaceDeviceLogsWithBodyWithResponse request with arbitrary body returning *ReplaceDeviceLogsResponse
+func (c *ClientWithResponses) ReplaceDeviceLogsWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceDeviceLogsResponse, error) {
+	rsp, err := c.ReplaceDeviceLogsWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReplaceDeviceLogsResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReplaceDeviceLogsWithResponse(ctx context.Context, name string, body ReplaceDeviceLogsJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceDeviceLogsResponse, error) {
+	rsp, err := c.ReplaceDeviceLogs(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReplaceDeviceLogsResponse(rsp)
+}
+
 // GetRenderedDeviceSpecWithResponse request returning *GetRenderedDeviceSpecResponse
 func (c *ClientWithResponses) GetRenderedDeviceSpecWithResponse(ctx context.Context, name string, params *GetRenderedDeviceSpecParams, reqEditors ...RequestEditorFn) (*GetRenderedDeviceSpecResponse, error) {
 	rsp, err := c.GetRenderedDeviceSpec(ctx, name, params, reqEditors...)
@@ -6579,6 +8044,15 @@ func (c *ClientWithResponses) GetRenderedDeviceSpecWithResponse(ctx context.Cont
 	return ParseGetRenderedDeviceSpecResponse(rsp)
 }
 
+// ListDeviceRevisionsWithResponse request returning *ListDeviceRevisionsResponse
+func (c *ClientWithResponses) ListDeviceRevisionsWithResponse(ctx context.Context, name string, params *ListDeviceRevisionsParams, reqEditors ...RequestEditorFn) (*ListDeviceRevisionsResponse, error) {
+	rsp, err := c.ListDeviceRevisions(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListDeviceRevisionsResponse(rsp)
+}
+
 // ReadDeviceStatusWithResponse request returning *ReadDeviceStatusResponse
 func (c *ClientWithResponses) ReadDeviceStatusWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReadDeviceStatusResponse, error) {
 	rsp, err := c.ReadDeviceStatus(ctx, name, reqEditors...)
@@ -6622,6 +8096,24 @@ func (c *ClientWithResponses) ReplaceDeviceStatusWithResponse(ctx context.Contex
 	return ParseReplaceDeviceStatusResponse(rsp)
 }
 
+// ReleaseDeviceUpdateSlotWithResponse request returning *ReleaseDeviceUpdateSlotResponse
+func (c *ClientWithResponses) ReleaseDeviceUpdateSlotWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReleaseDeviceUpdateSlotResponse, error) {
+	rsp, err := c.ReleaseDeviceUpdateSlot(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReleaseDeviceUpdateSlotResponse(rsp)
+}
+
+// AcquireDeviceUpdateSlotWithResponse request returning *AcquireDeviceUpdateSlotResponse
+func (c *ClientWithResponses) AcquireDeviceUpdateSlotWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*AcquireDeviceUpdateSlotResponse, error) {
+	rsp, err := c.AcquireDeviceUpdateSlot(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcquireDeviceUpdateSlotResponse(rsp)
+}
+
 // GetEnrollmentConfigWithResponse request returning *GetEnrollmentConfigResponse
 func (c *ClientWithResponses) GetEnrollmentConfigWithResponse(ctx context.Context, params *GetEnrollmentConfigParams, reqEditors ...RequestEditorFn) (*GetEnrollmentConfigResponse, error) {
 	rsp, err := c.GetEnrollmentConfig(ctx, params, reqEditors...)
@@ -6701,6 +8193,14 @@ func (c *ClientWithResponses) PatchEnrollmentRequestWithApplicationJSONPatchPlus
 	return ParsePatchEnrollmentRequestResponse(rsp)
 }
 
+func (c *ClientWithResponses) PatchEnrollmentRequestWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchEnrollmentRequestApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEnrollmentRequestResponse, error) {
+	rsp, err := c.PatchEnrollmentRequestWithApplicationMergePatchPlusJSONBody(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchEnrollmentRequestResponse(rsp)
+}
+
 // ReplaceEnrollmentRequestWithBodyWithResponse request with arbitrary body returning *ReplaceEnrollmentRequestResponse
 func (c *ClientWithResponses) ReplaceEnrollmentRequestWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceEnrollmentRequestResponse, error) {
 	rsp, err := c.ReplaceEnrollmentRequestWithBody(ctx, name, contentType, body, reqEditors...)
@@ -6884,23 +8384,40 @@ func (c *ClientWithResponses) PatchFleetWithApplicationJSONPatchPlusJSONBodyWith
 	return ParsePatchFleetResponse(rsp)
 }
 
+func (c *ClientWithResponses) PatchFleetWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchFleetApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchFleetResponse, error) {
+	rsp, err := c.PatchFleetWithApplicationMergePatchPlusJSONBody(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchFleetResponse(rsp)
+}
+
 // ReplaceFleetWithBodyWithResponse request with arbitrary body returning *ReplaceFleetResponse
-func (c *ClientWithResponses) ReplaceFleetWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceFleetResponse, error) {
-	rsp, err := c.ReplaceFleetWithBody(ctx, name, contentType, body, reqEditors...)
+func (c *ClientWithResponses) ReplaceFleetWithBodyWithResponse(ctx context.Context, name string, params *ReplaceFleetParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceFleetResponse, error) {
+	rsp, err := c.ReplaceFleetWithBody(ctx, name, params, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseReplaceFleetResponse(rsp)
 }
 
-func (c *ClientWithResponses) ReplaceFleetWithResponse(ctx context.Context, name string, body ReplaceFleetJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceFleetResponse, error) {
-	rsp, err := c.ReplaceFleet(ctx, name, body, reqEditors...)
+func (c *ClientWithResponses) ReplaceFleetWithResponse(ctx context.Context, name string, params *ReplaceFleetParams, body ReplaceFleetJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceFleetResponse, error) {
+	rsp, err := c.ReplaceFleet(ctx, name, params, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseReplaceFleetResponse(rsp)
 }
 
+// ListFleetRevisionsWithResponse request returning *ListFleetRevisionsResponse
+func (c *ClientWithResponses) ListFleetRevisionsWithResponse(ctx context.Context, name string, params *ListFleetRevisionsParams, reqEditors ...RequestEditorFn) (*ListFleetRevisionsResponse, error) {
+	rsp, err := c.ListFleetRevisions(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListFleetRevisionsResponse(rsp)
+}
+
 // ReadFleetStatusWithResponse request returning *ReadFleetStatusResponse
 func (c *ClientWithResponses) ReadFleetStatusWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ReadFleetStatusResponse, error) {
 	rsp, err := c.ReadFleetStatus(ctx, name, reqEditors...)
@@ -6944,6 +8461,15 @@ func (c *ClientWithResponses) ReplaceFleetStatusWithResponse(ctx context.Context
 	return ParseReplaceFleetStatusResponse(rsp)
 }
 
+// GetQuotaUsageWithResponse request returning *GetQuotaUsageResponse
+func (c *ClientWithResponses) GetQuotaUsageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetQuotaUsageResponse, error) {
+	rsp, err := c.GetQuotaUsage(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuotaUsageResponse(rsp)
+}
+
 // DeleteRepositoriesWithResponse request returning *DeleteRepositoriesResponse
 func (c *ClientWithResponses) DeleteRepositoriesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteRepositoriesResponse, error) {
 	rsp, err := c.DeleteRepositories(ctx, reqEditors...)
@@ -7014,17 +8540,25 @@ func (c *ClientWithResponses) PatchRepositoryWithApplicationJSONPatchPlusJSONBod
 	return ParsePatchRepositoryResponse(rsp)
 }
 
+func (c *ClientWithResponses) PatchRepositoryWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchRepositoryApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchRepositoryResponse, error) {
+	rsp, err := c.PatchRepositoryWithApplicationMergePatchPlusJSONBody(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchRepositoryResponse(rsp)
+}
+
 // ReplaceRepositoryWithBodyWithResponse request with arbitrary body returning *ReplaceRepositoryResponse
-func (c *ClientWithResponses) ReplaceRepositoryWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceRepositoryResponse, error) {
-	rsp, err := c.ReplaceRepositoryWithBody(ctx, name, contentType, body, reqEditors...)
+func (c *ClientWithResponses) ReplaceRepositoryWithBodyWithResponse(ctx context.Context, name string, params *ReplaceRepositoryParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceRepositoryResponse, error) {
+	rsp, err := c.ReplaceRepositoryWithBody(ctx, name, params, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseReplaceRepositoryResponse(rsp)
 }
 
-func (c *ClientWithResponses) ReplaceRepositoryWithResponse(ctx context.Context, name string, body ReplaceRepositoryJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceRepositoryResponse, error) {
-	rsp, err := c.ReplaceRepository(ctx, name, body, reqEditors...)
+func (c *ClientWithResponses) ReplaceRepositoryWithResponse(ctx context.Context, name string, params *ReplaceRepositoryParams, body ReplaceRepositoryJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceRepositoryResponse, error) {
+	rsp, err := c.ReplaceRepository(ctx, name, params, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -7101,17 +8635,25 @@ func (c *ClientWithResponses) PatchResourceSyncWithApplicationJSONPatchPlusJSONB
 	return ParsePatchResourceSyncResponse(rsp)
 }
 
+func (c *ClientWithResponses) PatchResourceSyncWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, name string, body PatchResourceSyncApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchResourceSyncResponse, error) {
+	rsp, err := c.PatchResourceSyncWithApplicationMergePatchPlusJSONBody(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchResourceSyncResponse(rsp)
+}
+
 // ReplaceResourceSyncWithBodyWithResponse request with arbitrary body returning *ReplaceResourceSyncResponse
-func (c *ClientWithResponses) ReplaceResourceSyncWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceResourceSyncResponse, error) {
-	rsp, err := c.ReplaceResourceSyncWithBody(ctx, name, contentType, body, reqEditors...)
+func (c *ClientWithResponses) ReplaceResourceSyncWithBodyWithResponse(ctx context.Context, name string, params *ReplaceResourceSyncParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReplaceResourceSyncResponse, error) {
+	rsp, err := c.ReplaceResourceSyncWithBody(ctx, name, params, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
 	return ParseReplaceResourceSyncResponse(rsp)
 }
 
-func (c *ClientWithResponses) ReplaceResourceSyncWithResponse(ctx context.Context, name string, body ReplaceResourceSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceResourceSyncResponse, error) {
-	rsp, err := c.ReplaceResourceSync(ctx, name, body, reqEditors...)
+func (c *ClientWithResponses) ReplaceResourceSyncWithResponse(ctx context.Context, name string, params *ReplaceResourceSyncParams, body ReplaceResourceSyncJSONRequestBody, reqEditors ...RequestEditorFn) (*ReplaceResourceSyncResponse, error) {
+	rsp, err := c.ReplaceResourceSync(ctx, name, params, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -7157,40 +8699,514 @@ func ParseAuthValidateResponse(rsp *http.Response) (*AuthValidateResponse, error
 		HTTPResponse: rsp,
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseIssueBootstrapTokenResponse parses an HTTP response from a IssueBootstrapTokenWithResponse call
+func ParseIssueBootstrapTokenResponse(rsp *http.Response) (*IssueBootstrapTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &IssueBootstrapTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest BootstrapToken
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRedeemBootstrapTokenResponse parses an HTTP response from a RedeemBootstrapTokenWithResponse call
+func ParseRedeemBootstrapTokenResponse(rsp *http.Response) (*RedeemBootstrapTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RedeemBootstrapTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BootstrapTokenRedemptionResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteCertificateSigningRequestsResponse parses an HTTP response from a DeleteCertificateSigningRequestsWithResponse call
+func ParseDeleteCertificateSigningRequestsResponse(rsp *http.Response) (*DeleteCertificateSigningRequestsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteCertificateSigningRequestsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListCertificateSigningRequestsResponse parses an HTTP response from a ListCertificateSigningRequestsWithResponse call
+func ParseListCertificateSigningRequestsResponse(rsp *http.Response) (*ListCertificateSigningRequestsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListCertificateSigningRequestsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CertificateSigningRequestList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateCertificateSigningRequestResponse parses an HTTP response from a CreateCertificateSigningRequestWithResponse call
+func ParseCreateCertificateSigningRequestResponse(rsp *http.Response) (*CreateCertificateSigningRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateCertificateSigningRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CertificateSigningRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteCertificateSigningRequestResponse parses an HTTP response from a DeleteCertificateSigningRequestWithResponse call
+func ParseDeleteCertificateSigningRequestResponse(rsp *http.Response) (*DeleteCertificateSigningRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteCertificateSigningRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CertificateSigningRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReadCertificateSigningRequestResponse parses an HTTP response from a ReadCertificateSigningRequestWithResponse call
+func ParseReadCertificateSigningRequestResponse(rsp *http.Response) (*ReadCertificateSigningRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReadCertificateSigningRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CertificateSigningRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchCertificateSigningRequestResponse parses an HTTP response from a PatchCertificateSigningRequestWithResponse call
+func ParsePatchCertificateSigningRequestResponse(rsp *http.Response) (*PatchCertificateSigningRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchCertificateSigningRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CertificateSigningRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.JSON503 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseDeleteCertificateSigningRequestsResponse parses an HTTP response from a DeleteCertificateSigningRequestsWithResponse call
-func ParseDeleteCertificateSigningRequestsResponse(rsp *http.Response) (*DeleteCertificateSigningRequestsResponse, error) {
+// ParseReplaceCertificateSigningRequestResponse parses an HTTP response from a ReplaceCertificateSigningRequestWithResponse call
+func ParseReplaceCertificateSigningRequestResponse(rsp *http.Response) (*ReplaceCertificateSigningRequestResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteCertificateSigningRequestsResponse{
+	response := &ReplaceCertificateSigningRequestResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Status
+		var dest CertificateSigningRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CertificateSigningRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7205,6 +9221,20 @@ func ParseDeleteCertificateSigningRequestsResponse(rsp *http.Response) (*DeleteC
 		}
 		response.JSON403 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7217,22 +9247,22 @@ func ParseDeleteCertificateSigningRequestsResponse(rsp *http.Response) (*DeleteC
 	return response, nil
 }
 
-// ParseListCertificateSigningRequestsResponse parses an HTTP response from a ListCertificateSigningRequestsWithResponse call
-func ParseListCertificateSigningRequestsResponse(rsp *http.Response) (*ListCertificateSigningRequestsResponse, error) {
+// ParseUpdateCertificateSigningRequestApprovalResponse parses an HTTP response from a UpdateCertificateSigningRequestApprovalWithResponse call
+func ParseUpdateCertificateSigningRequestApprovalResponse(rsp *http.Response) (*UpdateCertificateSigningRequestApprovalResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListCertificateSigningRequestsResponse{
+	response := &UpdateCertificateSigningRequestApprovalResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest CertificateSigningRequestList
+		var dest CertificateSigningRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -7259,6 +9289,20 @@ func ParseListCertificateSigningRequestsResponse(rsp *http.Response) (*ListCerti
 		}
 		response.JSON403 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7271,26 +9315,26 @@ func ParseListCertificateSigningRequestsResponse(rsp *http.Response) (*ListCerti
 	return response, nil
 }
 
-// ParseCreateCertificateSigningRequestResponse parses an HTTP response from a CreateCertificateSigningRequestWithResponse call
-func ParseCreateCertificateSigningRequestResponse(rsp *http.Response) (*CreateCertificateSigningRequestResponse, error) {
+// ParseDeleteDevicesResponse parses an HTTP response from a DeleteDevicesWithResponse call
+func ParseDeleteDevicesResponse(rsp *http.Response) (*DeleteDevicesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CreateCertificateSigningRequestResponse{
+	response := &DeleteDevicesResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest CertificateSigningRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DeleteCollectionResult
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON201 = &dest
+		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest Error
@@ -7313,13 +9357,6 @@ func ParseCreateCertificateSigningRequestResponse(rsp *http.Response) (*CreateCe
 		}
 		response.JSON403 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON409 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7332,47 +9369,47 @@ func ParseCreateCertificateSigningRequestResponse(rsp *http.Response) (*CreateCe
 	return response, nil
 }
 
-// ParseDeleteCertificateSigningRequestResponse parses an HTTP response from a DeleteCertificateSigningRequestWithResponse call
-func ParseDeleteCertificateSigningRequestResponse(rsp *http.Response) (*DeleteCertificateSigningRequestResponse, error) {
+// ParseListDevicesResponse parses an HTTP response from a ListDevicesWithResponse call
+func ParseListDevicesResponse(rsp *http.Response) (*ListDevicesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteCertificateSigningRequestResponse{
+	response := &ListDevicesResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest CertificateSigningRequest
+		var dest DeviceList
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON403 = &dest
+		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON404 = &dest
+		response.JSON403 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
@@ -7386,26 +9423,33 @@ func ParseDeleteCertificateSigningRequestResponse(rsp *http.Response) (*DeleteCe
 	return response, nil
 }
 
-// ParseReadCertificateSigningRequestResponse parses an HTTP response from a ReadCertificateSigningRequestWithResponse call
-func ParseReadCertificateSigningRequestResponse(rsp *http.Response) (*ReadCertificateSigningRequestResponse, error) {
+// ParseCreateDeviceResponse parses an HTTP response from a CreateDeviceWithResponse call
+func ParseCreateDeviceResponse(rsp *http.Response) (*CreateDeviceResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ReadCertificateSigningRequestResponse{
+	response := &CreateDeviceResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest CertificateSigningRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Device
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
@@ -7421,12 +9465,12 @@ func ParseReadCertificateSigningRequestResponse(rsp *http.Response) (*ReadCertif
 		}
 		response.JSON403 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON404 = &dest
+		response.JSON409 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
@@ -7440,22 +9484,22 @@ func ParseReadCertificateSigningRequestResponse(rsp *http.Response) (*ReadCertif
 	return response, nil
 }
 
-// ParsePatchCertificateSigningRequestResponse parses an HTTP response from a PatchCertificateSigningRequestWithResponse call
-func ParsePatchCertificateSigningRequestResponse(rsp *http.Response) (*PatchCertificateSigningRequestResponse, error) {
+// ParseBatchGetDevicesResponse parses an HTTP response from a BatchGetDevicesWithResponse call
+func ParseBatchGetDevicesResponse(rsp *http.Response) (*BatchGetDevicesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PatchCertificateSigningRequestResponse{
+	response := &BatchGetDevicesResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest CertificateSigningRequest
+		var dest DeviceBatchGetResult
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -7482,20 +9526,6 @@ func ParsePatchCertificateSigningRequestResponse(rsp *http.Response) (*PatchCert
 		}
 		response.JSON403 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON404 = &dest
-
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON409 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7508,41 +9538,27 @@ func ParsePatchCertificateSigningRequestResponse(rsp *http.Response) (*PatchCert
 	return response, nil
 }
 
-// ParseReplaceCertificateSigningRequestResponse parses an HTTP response from a ReplaceCertificateSigningRequestWithResponse call
-func ParseReplaceCertificateSigningRequestResponse(rsp *http.Response) (*ReplaceCertificateSigningRequestResponse, error) {
+// ParseDeleteDeviceResponse parses an HTTP response from a DeleteDeviceWithResponse call
+func ParseDeleteDeviceResponse(rsp *http.Response) (*DeleteDeviceResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ReplaceCertificateSigningRequestResponse{
+	response := &DeleteDeviceResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest CertificateSigningRequest
+		var dest Device
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest CertificateSigningRequest
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON201 = &dest
-
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7564,13 +9580,6 @@ func ParseReplaceCertificateSigningRequestResponse(rsp *http.Response) (*Replace
 		}
 		response.JSON404 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON409 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7583,22 +9592,22 @@ func ParseReplaceCertificateSigningRequestResponse(rsp *http.Response) (*Replace
 	return response, nil
 }
 
-// ParseUpdateCertificateSigningRequestApprovalResponse parses an HTTP response from a UpdateCertificateSigningRequestApprovalWithResponse call
-func ParseUpdateCertificateSigningRequestApprovalResponse(rsp *http.Response) (*UpdateCertificateSigningRequestApprovalResponse, error) {
+// ParseReadDeviceResponse parses an HTTP response from a ReadDeviceWithResponse call
+func ParseReadDeviceResponse(rsp *http.Response) (*ReadDeviceResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &UpdateCertificateSigningRequestApprovalResponse{
+	response := &ReadDeviceResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest CertificateSigningRequest
+		var dest Device
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -7632,13 +9641,6 @@ func ParseUpdateCertificateSigningRequestApprovalResponse(rsp *http.Response) (*
 		}
 		response.JSON404 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON409 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7651,27 +9653,34 @@ func ParseUpdateCertificateSigningRequestApprovalResponse(rsp *http.Response) (*
 	return response, nil
 }
 
-// ParseDeleteDevicesResponse parses an HTTP response from a DeleteDevicesWithResponse call
-func ParseDeleteDevicesResponse(rsp *http.Response) (*DeleteDevicesResponse, error) {
+// ParsePatchDeviceResponse parses an HTTP response from a PatchDeviceWithResponse call
+func ParsePatchDeviceResponse(rsp *http.Response) (*PatchDeviceResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteDevicesResponse{
+	response := &PatchDeviceResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Status
+		var dest Device
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7686,6 +9695,20 @@ func ParseDeleteDevicesResponse(rsp *http.Response) (*DeleteDevicesResponse, err
 		}
 		response.JSON403 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7698,27 +9721,34 @@ func ParseDeleteDevicesResponse(rsp *http.Response) (*DeleteDevicesResponse, err
 	return response, nil
 }
 
-// ParseListDevicesResponse parses an HTTP response from a ListDevicesWithResponse call
-func ParseListDevicesResponse(rsp *http.Response) (*ListDevicesResponse, error) {
+// ParseReplaceDeviceResponse parses an HTTP response from a ReplaceDeviceWithResponse call
+func ParseReplaceDeviceResponse(rsp *http.Response) (*ReplaceDeviceResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListDevicesResponse{
+	response := &ReplaceDeviceResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest DeviceList
+		var dest Device
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Device
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7740,6 +9770,20 @@ func ParseListDevicesResponse(rsp *http.Response) (*ListDevicesResponse, error)
 		}
 		response.JSON403 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7752,47 +9796,47 @@ func ParseListDevicesResponse(rsp *http.Response) (*ListDevicesResponse, error)
 	return response, nil
 }
 
-// ParseCreateDeviceResponse parses an HTTP response from a CreateDeviceWithResponse call
-func ParseCreateDeviceResponse(rsp *http.Response) (*CreateDeviceResponse, error) {
+// ParseRequestConsoleResponse parses an HTTP response from a RequestConsoleWithResponse call
+func ParseRequestConsoleResponse(rsp *http.Response) (*RequestConsoleResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CreateDeviceResponse{
+	response := &RequestConsoleResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest Device
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DeviceConsole
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON201 = &dest
+		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON403 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON403 = &dest
+		response.JSON404 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
 		var dest Error
@@ -7813,15 +9857,15 @@ func ParseCreateDeviceResponse(rsp *http.Response) (*CreateDeviceResponse, error
 	return response, nil
 }
 
-// ParseDeleteDeviceResponse parses an HTTP response from a DeleteDeviceWithResponse call
-func ParseDeleteDeviceResponse(rsp *http.Response) (*DeleteDeviceResponse, error) {
+// ParseDecommissionDeviceResponse parses an HTTP response from a DecommissionDeviceWithResponse call
+func ParseDecommissionDeviceResponse(rsp *http.Response) (*DecommissionDeviceResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteDeviceResponse{
+	response := &DecommissionDeviceResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -7834,6 +9878,13 @@ func ParseDeleteDeviceResponse(rsp *http.Response) (*DeleteDeviceResponse, error
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7867,22 +9918,22 @@ func ParseDeleteDeviceResponse(rsp *http.Response) (*DeleteDeviceResponse, error
 	return response, nil
 }
 
-// ParseReadDeviceResponse parses an HTTP response from a ReadDeviceWithResponse call
-func ParseReadDeviceResponse(rsp *http.Response) (*ReadDeviceResponse, error) {
+// ParseReadDeviceLogsResponse parses an HTTP response from a ReadDeviceLogsWithResponse call
+func ParseReadDeviceLogsResponse(rsp *http.Response) (*ReadDeviceLogsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ReadDeviceResponse{
+	response := &ReadDeviceLogsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Device
+		var dest DeviceLogs
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -7921,22 +9972,22 @@ func ParseReadDeviceResponse(rsp *http.Response) (*ReadDeviceResponse, error) {
 	return response, nil
 }
 
-// ParsePatchDeviceResponse parses an HTTP response from a PatchDeviceWithResponse call
-func ParsePatchDeviceResponse(rsp *http.Response) (*PatchDeviceResponse, error) {
+// ParseReplaceDeviceLogsResponse parses an HTTP response from a ReplaceDeviceLogsWithResponse call
+func ParseReplaceDeviceLogsResponse(rsp *http.Response) (*ReplaceDeviceLogsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PatchDeviceResponse{
+	response := &ReplaceDeviceLogsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Device
+		var dest DeviceLogs
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -7970,13 +10021,6 @@ func ParsePatchDeviceResponse(rsp *http.Response) (*PatchDeviceResponse, error)
 		}
 		response.JSON404 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON409 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -7989,41 +10033,27 @@ func ParsePatchDeviceResponse(rsp *http.Response) (*PatchDeviceResponse, error)
 	return response, nil
 }
 
-// ParseReplaceDeviceResponse parses an HTTP response from a ReplaceDeviceWithResponse call
-func ParseReplaceDeviceResponse(rsp *http.Response) (*ReplaceDeviceResponse, error) {
+// ParseGetRenderedDeviceSpecResponse parses an HTTP response from a GetRenderedDeviceSpecWithResponse call
+func ParseGetRenderedDeviceSpecResponse(rsp *http.Response) (*GetRenderedDeviceSpecResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ReplaceDeviceResponse{
+	response := &GetRenderedDeviceSpecResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Device
+		var dest RenderedDeviceSpec
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest Device
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON201 = &dest
-
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -8064,27 +10094,34 @@ func ParseReplaceDeviceResponse(rsp *http.Response) (*ReplaceDeviceResponse, err
 	return response, nil
 }
 
-// ParseRequestConsoleResponse parses an HTTP response from a RequestConsoleWithResponse call
-func ParseRequestConsoleResponse(rsp *http.Response) (*RequestConsoleResponse, error) {
+// ParseListDeviceRevisionsResponse parses an HTTP response from a ListDeviceRevisionsWithResponse call
+func ParseListDeviceRevisionsResponse(rsp *http.Response) (*ListDeviceRevisionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &RequestConsoleResponse{
+	response := &ListDeviceRevisionsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest DeviceConsole
+		var dest ResourceRevisionList
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -8106,13 +10143,6 @@ func ParseRequestConsoleResponse(rsp *http.Response) (*RequestConsoleResponse, e
 		}
 		response.JSON404 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON409 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -8125,15 +10155,15 @@ func ParseRequestConsoleResponse(rsp *http.Response) (*RequestConsoleResponse, e
 	return response, nil
 }
 
-// ParseDecommissionDeviceResponse parses an HTTP response from a DecommissionDeviceWithResponse call
-func ParseDecommissionDeviceResponse(rsp *http.Response) (*DecommissionDeviceResponse, error) {
+// ParseReadDeviceStatusResponse parses an HTTP response from a ReadDeviceStatusWithResponse call
+func ParseReadDeviceStatusResponse(rsp *http.Response) (*ReadDeviceStatusResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DecommissionDeviceResponse{
+	response := &ReadDeviceStatusResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -8146,13 +10176,6 @@ func ParseDecommissionDeviceResponse(rsp *http.Response) (*DecommissionDeviceRes
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -8186,27 +10209,34 @@ func ParseDecommissionDeviceResponse(rsp *http.Response) (*DecommissionDeviceRes
 	return response, nil
 }
 
-// ParseGetRenderedDeviceSpecResponse parses an HTTP response from a GetRenderedDeviceSpecWithResponse call
-func ParseGetRenderedDeviceSpecResponse(rsp *http.Response) (*GetRenderedDeviceSpecResponse, error) {
+// ParsePatchDeviceStatusResponse parses an HTTP response from a PatchDeviceStatusWithResponse call
+func ParsePatchDeviceStatusResponse(rsp *http.Response) (*PatchDeviceStatusResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetRenderedDeviceSpecResponse{
+	response := &PatchDeviceStatusResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest RenderedDeviceSpec
+		var dest Device
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -8228,13 +10258,6 @@ func ParseGetRenderedDeviceSpecResponse(rsp *http.Response) (*GetRenderedDeviceS
 		}
 		response.JSON404 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON409 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -8247,15 +10270,15 @@ func ParseGetRenderedDeviceSpecResponse(rsp *http.Response) (*GetRenderedDeviceS
 	return response, nil
 }
 
-// ParseReadDeviceStatusResponse parses an HTTP response from a ReadDeviceStatusWithResponse call
-func ParseReadDeviceStatusResponse(rsp *http.Response) (*ReadDeviceStatusResponse, error) {
+// ParseReplaceDeviceStatusResponse parses an HTTP response from a ReplaceDeviceStatusWithResponse call
+func ParseReplaceDeviceStatusResponse(rsp *http.Response) (*ReplaceDeviceStatusResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ReadDeviceStatusResponse{
+	response := &ReplaceDeviceStatusResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -8268,6 +10291,13 @@ func ParseReadDeviceStatusResponse(rsp *http.Response) (*ReadDeviceStatusRespons
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -8301,34 +10331,27 @@ func ParseReadDeviceStatusResponse(rsp *http.Response) (*ReadDeviceStatusRespons
 	return response, nil
 }
 
-// ParsePatchDeviceStatusResponse parses an HTTP response from a PatchDeviceStatusWithResponse call
-func ParsePatchDeviceStatusResponse(rsp *http.Response) (*PatchDeviceStatusResponse, error) {
+// ParseReleaseDeviceUpdateSlotResponse parses an HTTP response from a ReleaseDeviceUpdateSlotWithResponse call
+func ParseReleaseDeviceUpdateSlotResponse(rsp *http.Response) (*ReleaseDeviceUpdateSlotResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PatchDeviceStatusResponse{
+	response := &ReleaseDeviceUpdateSlotResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Device
+		var dest DeviceUpdateSlot
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -8362,34 +10385,27 @@ func ParsePatchDeviceStatusResponse(rsp *http.Response) (*PatchDeviceStatusRespo
 	return response, nil
 }
 
-// ParseReplaceDeviceStatusResponse parses an HTTP response from a ReplaceDeviceStatusWithResponse call
-func ParseReplaceDeviceStatusResponse(rsp *http.Response) (*ReplaceDeviceStatusResponse, error) {
+// ParseAcquireDeviceUpdateSlotResponse parses an HTTP response from a AcquireDeviceUpdateSlotWithResponse call
+func ParseAcquireDeviceUpdateSlotResponse(rsp *http.Response) (*AcquireDeviceUpdateSlotResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ReplaceDeviceStatusResponse{
+	response := &AcquireDeviceUpdateSlotResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Device
+		var dest DeviceUpdateSlot
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -9756,6 +11772,67 @@ func ParseReplaceFleetResponse(rsp *http.Response) (*ReplaceFleetResponse, error
 	return response, nil
 }
 
+// ParseListFleetRevisionsResponse parses an HTTP response from a ListFleetRevisionsWithResponse call
+func ParseListFleetRevisionsResponse(rsp *http.Response) (*ListFleetRevisionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListFleetRevisionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ResourceRevisionList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseReadFleetStatusResponse parses an HTTP response from a ReadFleetStatusWithResponse call
 func ParseReadFleetStatusResponse(rsp *http.Response) (*ReadFleetStatusResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -9925,6 +12002,53 @@ func ParseReplaceFleetStatusResponse(rsp *http.Response) (*ReplaceFleetStatusRes
 	return response, nil
 }
 
+// ParseGetQuotaUsageResponse parses an HTTP response from a GetQuotaUsageWithResponse call
+func ParseGetQuotaUsageResponse(rsp *http.Response) (*GetQuotaUsageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuotaUsageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuotaUsage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseDeleteRepositoriesResponse parses an HTTP response from a DeleteRepositoriesWithResponse call
 func ParseDeleteRepositoriesResponse(rsp *http.Response) (*DeleteRepositoriesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -19,12 +19,24 @@ import (
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (POST /api/v1/bootstraptokens/redeem)
+	RedeemBootstrapToken(w http.ResponseWriter, r *http.Request)
+
+	// (PUT /api/v1/devices/{name}/logs)
+	ReplaceDeviceLogs(w http.ResponseWriter, r *http.Request, name string)
+
 	// (GET /api/v1/devices/{name}/rendered)
 	GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams)
 
 	// (PUT /api/v1/devices/{name}/status)
 	ReplaceDeviceStatus(w http.ResponseWriter, r *http.Request, name string)
 
+	// (DELETE /api/v1/devices/{name}/updateslot)
+	ReleaseDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/devices/{name}/updateslot)
+	AcquireDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string)
+
 	// (POST /api/v1/enrollmentrequests)
 	CreateEnrollmentRequest(w http.ResponseWriter, r *http.Request)
 
@@ -36,6 +48,16 @@ type ServerInterface interface {
 
 type Unimplemented struct{}
 
+// (POST /api/v1/bootstraptokens/redeem)
+func (_ Unimplemented) RedeemBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /api/v1/devices/{name}/logs)
+func (_ Unimplemented) ReplaceDeviceLogs(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /api/v1/devices/{name}/rendered)
 func (_ Unimplemented) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -46,6 +68,16 @@ func (_ Unimplemented) ReplaceDeviceStatus(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (DELETE /api/v1/devices/{name}/updateslot)
+func (_ Unimplemented) ReleaseDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /api/v1/devices/{name}/updateslot)
+func (_ Unimplemented) AcquireDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (POST /api/v1/enrollmentrequests)
 func (_ Unimplemented) CreateEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -65,6 +97,47 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// RedeemBootstrapToken operation middleware
+func (siw *ServerInterfaceWrapper) RedeemBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RedeemBootstrapToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceDeviceLogs operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceDeviceLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceDeviceLogs(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetRenderedDeviceSpec operation middleware
 func (siw *ServerInterfaceWrapper) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -128,6 +201,58 @@ func (siw *ServerInterfaceWrapper) ReplaceDeviceStatus(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// ReleaseDeviceUpdateSlot operation middleware
+func (siw *ServerInterfaceWrapper) ReleaseDeviceUpdateSlot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReleaseDeviceUpdateSlot(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AcquireDeviceUpdateSlot operation middleware
+func (siw *ServerInterfaceWrapper) AcquireDeviceUpdateSlot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AcquireDeviceUpdateSlot(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // CreateEnrollmentRequest operation middleware
 func (siw *ServerInterfaceWrapper) CreateEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -282,12 +407,24 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/bootstraptokens/redeem", wrapper.RedeemBootstrapToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/devices/{name}/logs", wrapper.ReplaceDeviceLogs)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/devices/{name}/rendered", wrapper.GetRenderedDeviceSpec)
 	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/devices/{name}/status", wrapper.ReplaceDeviceStatus)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/devices/{name}/updateslot", wrapper.ReleaseDeviceUpdateSlot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/devices/{name}/updateslot", wrapper.AcquireDeviceUpdateSlot)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/enrollmentrequests", wrapper.CreateEnrollmentRequest)
 	})
@@ -298,6 +435,95 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	return r
 }
 
+type RedeemBootstrapTokenRequestObject struct {
+	Body *RedeemBootstrapTokenJSONRequestBody
+}
+
+type RedeemBootstrapTokenResponseObject interface {
+	VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error
+}
+
+type RedeemBootstrapToken200JSONResponse externalRef0.BootstrapTokenRedemptionResponse
+
+func (response RedeemBootstrapToken200JSONResponse) VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeemBootstrapToken400JSONResponse externalRef0.Error
+
+func (response RedeemBootstrapToken400JSONResponse) VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeemBootstrapToken401JSONResponse externalRef0.Error
+
+func (response RedeemBootstrapToken401JSONResponse) VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeemBootstrapToken404JSONResponse externalRef0.Error
+
+func (response RedeemBootstrapToken404JSONResponse) VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceLogsRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceDeviceLogsJSONRequestBody
+}
+
+type ReplaceDeviceLogsResponseObject interface {
+	VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error
+}
+
+type ReplaceDeviceLogs200JSONResponse externalRef0.DeviceLogs
+
+func (response ReplaceDeviceLogs200JSONResponse) VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceLogs400JSONResponse externalRef0.Error
+
+func (response ReplaceDeviceLogs400JSONResponse) VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceLogs401JSONResponse externalRef0.Error
+
+func (response ReplaceDeviceLogs401JSONResponse) VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceLogs404JSONResponse externalRef0.Error
+
+func (response ReplaceDeviceLogs404JSONResponse) VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetRenderedDeviceSpecRequestObject struct {
 	Name   string `json:"name"`
 	Params GetRenderedDeviceSpecParams
@@ -396,6 +622,76 @@ func (response ReplaceDeviceStatus404JSONResponse) VisitReplaceDeviceStatusRespo
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ReleaseDeviceUpdateSlotRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReleaseDeviceUpdateSlotResponseObject interface {
+	VisitReleaseDeviceUpdateSlotResponse(w http.ResponseWriter) error
+}
+
+type ReleaseDeviceUpdateSlot200JSONResponse externalRef0.DeviceUpdateSlot
+
+func (response ReleaseDeviceUpdateSlot200JSONResponse) VisitReleaseDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseDeviceUpdateSlot401JSONResponse externalRef0.Error
+
+func (response ReleaseDeviceUpdateSlot401JSONResponse) VisitReleaseDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseDeviceUpdateSlot404JSONResponse externalRef0.Error
+
+func (response ReleaseDeviceUpdateSlot404JSONResponse) VisitReleaseDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcquireDeviceUpdateSlotRequestObject struct {
+	Name string `json:"name"`
+}
+
+type AcquireDeviceUpdateSlotResponseObject interface {
+	VisitAcquireDeviceUpdateSlotResponse(w http.ResponseWriter) error
+}
+
+type AcquireDeviceUpdateSlot200JSONResponse externalRef0.DeviceUpdateSlot
+
+func (response AcquireDeviceUpdateSlot200JSONResponse) VisitAcquireDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcquireDeviceUpdateSlot401JSONResponse externalRef0.Error
+
+func (response AcquireDeviceUpdateSlot401JSONResponse) VisitAcquireDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcquireDeviceUpdateSlot404JSONResponse externalRef0.Error
+
+func (response AcquireDeviceUpdateSlot404JSONResponse) VisitAcquireDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type CreateEnrollmentRequestRequestObject struct {
 	Body *CreateEnrollmentRequestJSONRequestBody
 }
@@ -514,12 +810,24 @@ func (response ReadEnrollmentRequest503JSONResponse) VisitReadEnrollmentRequestR
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 
+	// (POST /api/v1/bootstraptokens/redeem)
+	RedeemBootstrapToken(ctx context.Context, request RedeemBootstrapTokenRequestObject) (RedeemBootstrapTokenResponseObject, error)
+
+	// (PUT /api/v1/devices/{name}/logs)
+	ReplaceDeviceLogs(ctx context.Context, request ReplaceDeviceLogsRequestObject) (ReplaceDeviceLogsResponseObject, error)
+
 	// (GET /api/v1/devices/{name}/rendered)
 	GetRenderedDeviceSpec(ctx context.Context, request GetRenderedDeviceSpecRequestObject) (GetRenderedDeviceSpecResponseObject, error)
 
 	// (PUT /api/v1/devices/{name}/status)
 	ReplaceDeviceStatus(ctx context.Context, request ReplaceDeviceStatusRequestObject) (ReplaceDeviceStatusResponseObject, error)
 
+	// (DELETE /api/v1/devices/{name}/updateslot)
+	ReleaseDeviceUpdateSlot(ctx context.Context, request ReleaseDeviceUpdateSlotRequestObject) (ReleaseDeviceUpdateSlotResponseObject, error)
+
+	// (PUT /api/v1/devices/{name}/updateslot)
+	AcquireDeviceUpdateSlot(ctx context.Context, request AcquireDeviceUpdateSlotRequestObject) (AcquireDeviceUpdateSlotResponseObject, error)
+
 	// (POST /api/v1/enrollmentrequests)
 	CreateEnrollmentRequest(ctx context.Context, request CreateEnrollmentRequestRequestObject) (CreateEnrollmentRequestResponseObject, error)
 
@@ -556,6 +864,70 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// RedeemBootstrapToken operation middleware
+func (sh *strictHandler) RedeemBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	var request RedeemBootstrapTokenRequestObject
+
+	var body RedeemBootstrapTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RedeemBootstrapToken(ctx, request.(RedeemBootstrapTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RedeemBootstrapToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RedeemBootstrapTokenResponseObject); ok {
+		if err := validResponse.VisitRedeemBootstrapTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReplaceDeviceLogs operation middleware
+func (sh *strictHandler) ReplaceDeviceLogs(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceDeviceLogsRequestObject
+
+	request.Name = name
+
+	var body ReplaceDeviceLogsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceDeviceLogs(ctx, request.(ReplaceDeviceLogsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceDeviceLogs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceDeviceLogsResponseObject); ok {
+		if err := validResponse.VisitReplaceDeviceLogsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetRenderedDeviceSpec operation middleware
 func (sh *strictHandler) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams) {
 	var request GetRenderedDeviceSpecRequestObject
@@ -616,6 +988,58 @@ func (sh *strictHandler) ReplaceDeviceStatus(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// ReleaseDeviceUpdateSlot operation middleware
+func (sh *strictHandler) ReleaseDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReleaseDeviceUpdateSlotRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReleaseDeviceUpdateSlot(ctx, request.(ReleaseDeviceUpdateSlotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReleaseDeviceUpdateSlot")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReleaseDeviceUpdateSlotResponseObject); ok {
+		if err := validResponse.VisitReleaseDeviceUpdateSlotResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AcquireDeviceUpdateSlot operation middleware
+func (sh *strictHandler) AcquireDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string) {
+	var request AcquireDeviceUpdateSlotRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AcquireDeviceUpdateSlot(ctx, request.(AcquireDeviceUpdateSlotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AcquireDeviceUpdateSlot")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AcquireDeviceUpdateSlotResponseObject); ok {
+		if err := validResponse.VisitAcquireDeviceUpdateSlotResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // CreateEnrollmentRequest operation middleware
 func (sh *strictHandler) CreateEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
 	var request CreateEnrollmentRequestRequestObject
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	. "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/go-chi/chi/v5"
@@ -24,6 +25,12 @@ type ServerInterface interface {
 	// (GET /api/v1/auth/validate)
 	AuthValidate(w http.ResponseWriter, r *http.Request, params AuthValidateParams)
 
+	// (POST /api/v1/bootstraptokens)
+	IssueBootstrapToken(w http.ResponseWriter, r *http.Request)
+
+	// (POST /api/v1/bootstraptokens/redeem)
+	RedeemBootstrapToken(w http.ResponseWriter, r *http.Request)
+
 	// (DELETE /api/v1/certificatesigningrequests)
 	DeleteCertificateSigningRequests(w http.ResponseWriter, r *http.Request)
 
@@ -49,7 +56,7 @@ type ServerInterface interface {
 	UpdateCertificateSigningRequestApproval(w http.ResponseWriter, r *http.Request, name string)
 
 	// (DELETE /api/v1/devices)
-	DeleteDevices(w http.ResponseWriter, r *http.Request)
+	DeleteDevices(w http.ResponseWriter, r *http.Request, params DeleteDevicesParams)
 
 	// (GET /api/v1/devices)
 	ListDevices(w http.ResponseWriter, r *http.Request, params ListDevicesParams)
@@ -57,17 +64,20 @@ type ServerInterface interface {
 	// (POST /api/v1/devices)
 	CreateDevice(w http.ResponseWriter, r *http.Request)
 
+	// (POST /api/v1/devices/batchget)
+	BatchGetDevices(w http.ResponseWriter, r *http.Request)
+
 	// (DELETE /api/v1/devices/{name})
 	DeleteDevice(w http.ResponseWriter, r *http.Request, name string)
 
 	// (GET /api/v1/devices/{name})
-	ReadDevice(w http.ResponseWriter, r *http.Request, name string)
+	ReadDevice(w http.ResponseWriter, r *http.Request, name string, params ReadDeviceParams)
 
 	// (PATCH /api/v1/devices/{name})
 	PatchDevice(w http.ResponseWriter, r *http.Request, name string)
 
 	// (PUT /api/v1/devices/{name})
-	ReplaceDevice(w http.ResponseWriter, r *http.Request, name string)
+	ReplaceDevice(w http.ResponseWriter, r *http.Request, name string, params ReplaceDeviceParams)
 
 	// (GET /api/v1/devices/{name}/console)
 	RequestConsole(w http.ResponseWriter, r *http.Request, name string)
@@ -75,9 +85,18 @@ type ServerInterface interface {
 	// (PUT /api/v1/devices/{name}/decommission)
 	DecommissionDevice(w http.ResponseWriter, r *http.Request, name string)
 
+	// (GET /api/v1/devices/{name}/logs)
+	ReadDeviceLogs(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/devices/{name}/logs)
+	ReplaceDeviceLogs(w http.ResponseWriter, r *http.Request, name string)
+
 	// (GET /api/v1/devices/{name}/rendered)
 	GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams)
 
+	// (GET /api/v1/devices/{name}/revisions)
+	ListDeviceRevisions(w http.ResponseWriter, r *http.Request, name string, params ListDeviceRevisionsParams)
+
 	// (GET /api/v1/devices/{name}/status)
 	ReadDeviceStatus(w http.ResponseWriter, r *http.Request, name string)
 
@@ -87,6 +106,12 @@ type ServerInterface interface {
 	// (PUT /api/v1/devices/{name}/status)
 	ReplaceDeviceStatus(w http.ResponseWriter, r *http.Request, name string)
 
+	// (DELETE /api/v1/devices/{name}/updateslot)
+	ReleaseDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/devices/{name}/updateslot)
+	AcquireDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string)
+
 	// (GET /api/v1/enrollmentconfig)
 	GetEnrollmentConfig(w http.ResponseWriter, r *http.Request, params GetEnrollmentConfigParams)
 
@@ -154,7 +179,10 @@ type ServerInterface interface {
 	PatchFleet(w http.ResponseWriter, r *http.Request, name string)
 
 	// (PUT /api/v1/fleets/{name})
-	ReplaceFleet(w http.ResponseWriter, r *http.Request, name string)
+	ReplaceFleet(w http.ResponseWriter, r *http.Request, name string, params ReplaceFleetParams)
+
+	// (GET /api/v1/fleets/{name}/revisions)
+	ListFleetRevisions(w http.ResponseWriter, r *http.Request, name string, params ListFleetRevisionsParams)
 
 	// (GET /api/v1/fleets/{name}/status)
 	ReadFleetStatus(w http.ResponseWriter, r *http.Request, name string)
@@ -165,6 +193,9 @@ type ServerInterface interface {
 	// (PUT /api/v1/fleets/{name}/status)
 	ReplaceFleetStatus(w http.ResponseWriter, r *http.Request, name string)
 
+	// (GET /api/v1/quotas)
+	GetQuotaUsage(w http.ResponseWriter, r *http.Request)
+
 	// (DELETE /api/v1/repositories)
 	DeleteRepositories(w http.ResponseWriter, r *http.Request)
 
@@ -184,7 +215,7 @@ type ServerInterface interface {
 	PatchRepository(w http.ResponseWriter, r *http.Request, name string)
 
 	// (PUT /api/v1/repositories/{name})
-	ReplaceRepository(w http.ResponseWriter, r *http.Request, name string)
+	ReplaceRepository(w http.ResponseWriter, r *http.Request, name string, params ReplaceRepositoryParams)
 
 	// (DELETE /api/v1/resourcesyncs)
 	DeleteResourceSyncs(w http.ResponseWriter, r *http.Request)
@@ -205,7 +236,7 @@ type ServerInterface interface {
 	PatchResourceSync(w http.ResponseWriter, r *http.Request, name string)
 
 	// (PUT /api/v1/resourcesyncs/{name})
-	ReplaceResourceSync(w http.ResponseWriter, r *http.Request, name string)
+	ReplaceResourceSync(w http.ResponseWriter, r *http.Request, name string, params ReplaceResourceSyncParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -222,6 +253,16 @@ func (_ Unimplemented) AuthValidate(w http.ResponseWriter, r *http.Request, para
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (POST /api/v1/bootstraptokens)
+func (_ Unimplemented) IssueBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/bootstraptokens/redeem)
+func (_ Unimplemented) RedeemBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (DELETE /api/v1/certificatesigningrequests)
 func (_ Unimplemented) DeleteCertificateSigningRequests(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -263,7 +304,7 @@ func (_ Unimplemented) UpdateCertificateSigningRequestApproval(w http.ResponseWr
 }
 
 // (DELETE /api/v1/devices)
-func (_ Unimplemented) DeleteDevices(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) DeleteDevices(w http.ResponseWriter, r *http.Request, params DeleteDevicesParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -277,13 +318,18 @@ func (_ Unimplemented) CreateDevice(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (POST /api/v1/devices/batchget)
+func (_ Unimplemented) BatchGetDevices(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (DELETE /api/v1/devices/{name})
 func (_ Unimplemented) DeleteDevice(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // (GET /api/v1/devices/{name})
-func (_ Unimplemented) ReadDevice(w http.ResponseWriter, r *http.Request, name string) {
+func (_ Unimplemented) ReadDevice(w http.ResponseWriter, r *http.Request, name string, params ReadDeviceParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -293,7 +339,7 @@ func (_ Unimplemented) PatchDevice(w http.ResponseWriter, r *http.Request, name
 }
 
 // (PUT /api/v1/devices/{name})
-func (_ Unimplemented) ReplaceDevice(w http.ResponseWriter, r *http.Request, name string) {
+func (_ Unimplemented) ReplaceDevice(w http.ResponseWriter, r *http.Request, name string, params ReplaceDeviceParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -307,11 +353,26 @@ func (_ Unimplemented) DecommissionDevice(w http.ResponseWriter, r *http.Request
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (GET /api/v1/devices/{name}/logs)
+func (_ Unimplemented) ReadDeviceLogs(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /api/v1/devices/{name}/logs)
+func (_ Unimplemented) ReplaceDeviceLogs(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /api/v1/devices/{name}/rendered)
 func (_ Unimplemented) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (GET /api/v1/devices/{name}/revisions)
+func (_ Unimplemented) ListDeviceRevisions(w http.ResponseWriter, r *http.Request, name string, params ListDeviceRevisionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /api/v1/devices/{name}/status)
 func (_ Unimplemented) ReadDeviceStatus(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -327,6 +388,16 @@ func (_ Unimplemented) ReplaceDeviceStatus(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (DELETE /api/v1/devices/{name}/updateslot)
+func (_ Unimplemented) ReleaseDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /api/v1/devices/{name}/updateslot)
+func (_ Unimplemented) AcquireDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /api/v1/enrollmentconfig)
 func (_ Unimplemented) GetEnrollmentConfig(w http.ResponseWriter, r *http.Request, params GetEnrollmentConfigParams) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -438,7 +509,12 @@ func (_ Unimplemented) PatchFleet(w http.ResponseWriter, r *http.Request, name s
 }
 
 // (PUT /api/v1/fleets/{name})
-func (_ Unimplemented) ReplaceFleet(w http.ResponseWriter, r *http.Request, name string) {
+func (_ Unimplemented) ReplaceFleet(w http.ResponseWriter, r *http.Request, name string, params ReplaceFleetParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/fleets/{name}/revisions)
+func (_ Unimplemented) ListFleetRevisions(w http.ResponseWriter, r *http.Request, name string, params ListFleetRevisionsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -457,6 +533,11 @@ func (_ Unimplemented) ReplaceFleetStatus(w http.ResponseWriter, r *http.Request
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (GET /api/v1/quotas)
+func (_ Unimplemented) GetQuotaUsage(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (DELETE /api/v1/repositories)
 func (_ Unimplemented) DeleteRepositories(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -488,7 +569,7 @@ func (_ Unimplemented) PatchRepository(w http.ResponseWriter, r *http.Request, n
 }
 
 // (PUT /api/v1/repositories/{name})
-func (_ Unimplemented) ReplaceRepository(w http.ResponseWriter, r *http.Request, name string) {
+func (_ Unimplemented) ReplaceRepository(w http.ResponseWriter, r *http.Request, name string, params ReplaceRepositoryParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -523,7 +604,7 @@ func (_ Unimplemented) PatchResourceSync(w http.ResponseWriter, r *http.Request,
 }
 
 // (PUT /api/v1/resourcesyncs/{name})
-func (_ Unimplemented) ReplaceResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+func (_ Unimplemented) ReplaceResourceSync(w http.ResponseWriter, r *http.Request, name string, params ReplaceResourceSyncParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -592,6 +673,36 @@ func (siw *ServerInterfaceWrapper) AuthValidate(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// IssueBootstrapToken operation middleware
+func (siw *ServerInterfaceWrapper) IssueBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.IssueBootstrapToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RedeemBootstrapToken operation middleware
+func (siw *ServerInterfaceWrapper) RedeemBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RedeemBootstrapToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // DeleteCertificateSigningRequests operation middleware
 func (siw *ServerInterfaceWrapper) DeleteCertificateSigningRequests(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -808,8 +919,21 @@ func (siw *ServerInterfaceWrapper) UpdateCertificateSigningRequestApproval(w htt
 func (siw *ServerInterfaceWrapper) DeleteDevices(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteDevicesParams
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteDevices(w, r)
+		siw.Handler.DeleteDevices(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -868,6 +992,30 @@ func (siw *ServerInterfaceWrapper) ListDevices(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// ------------- Optional query parameter "needsAttention" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "needsAttention", r.URL.Query(), &params.NeedsAttention)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "needsAttention", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "groupByLabel" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "groupByLabel", r.URL.Query(), &params.GroupByLabel)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupByLabel", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.ListDevices(w, r, params)
 	}))
@@ -894,6 +1042,21 @@ func (siw *ServerInterfaceWrapper) CreateDevice(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// BatchGetDevices operation middleware
+func (siw *ServerInterfaceWrapper) BatchGetDevices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchGetDevices(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // DeleteDevice operation middleware
 func (siw *ServerInterfaceWrapper) DeleteDevice(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -935,8 +1098,19 @@ func (siw *ServerInterfaceWrapper) ReadDevice(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReadDeviceParams
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fields", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadDevice(w, r, name)
+		siw.Handler.ReadDevice(w, r, name, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -987,8 +1161,27 @@ func (siw *ServerInterfaceWrapper) ReplaceDevice(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReplaceDeviceParams
+
+	// ------------- Optional query parameter "fieldManager" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fieldManager", r.URL.Query(), &params.FieldManager)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fieldManager", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceDevice(w, r, name)
+		siw.Handler.ReplaceDevice(w, r, name, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1050,6 +1243,58 @@ func (siw *ServerInterfaceWrapper) DecommissionDevice(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// ReadDeviceLogs operation middleware
+func (siw *ServerInterfaceWrapper) ReadDeviceLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadDeviceLogs(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceDeviceLogs operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceDeviceLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceDeviceLogs(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetRenderedDeviceSpec operation middleware
 func (siw *ServerInterfaceWrapper) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -1087,6 +1332,43 @@ func (siw *ServerInterfaceWrapper) GetRenderedDeviceSpec(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// ListDeviceRevisions operation middleware
+func (siw *ServerInterfaceWrapper) ListDeviceRevisions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDeviceRevisionsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDeviceRevisions(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // ReadDeviceStatus operation middleware
 func (siw *ServerInterfaceWrapper) ReadDeviceStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -1165,6 +1447,58 @@ func (siw *ServerInterfaceWrapper) ReplaceDeviceStatus(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// ReleaseDeviceUpdateSlot operation middleware
+func (siw *ServerInterfaceWrapper) ReleaseDeviceUpdateSlot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReleaseDeviceUpdateSlot(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AcquireDeviceUpdateSlot operation middleware
+func (siw *ServerInterfaceWrapper) AcquireDeviceUpdateSlot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AcquireDeviceUpdateSlot(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetEnrollmentConfig operation middleware
 func (siw *ServerInterfaceWrapper) GetEnrollmentConfig(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -1249,16 +1583,40 @@ func (siw *ServerInterfaceWrapper) ListEnrollmentRequests(w http.ResponseWriter,
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListEnrollmentRequests(w, r, params)
-	}))
+	// ------------- Optional query parameter "status" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	// ------------- Optional query parameter "createdAfter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdAfter", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdAfter", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdBefore" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdBefore", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdBefore", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListEnrollmentRequests(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
 
 // CreateEnrollmentRequest operation middleware
 func (siw *ServerInterfaceWrapper) CreateEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
@@ -1834,8 +2192,64 @@ func (siw *ServerInterfaceWrapper) ReplaceFleet(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReplaceFleetParams
+
+	// ------------- Optional query parameter "fieldManager" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fieldManager", r.URL.Query(), &params.FieldManager)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fieldManager", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceFleet(w, r, name)
+		siw.Handler.ReplaceFleet(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListFleetRevisions operation middleware
+func (siw *ServerInterfaceWrapper) ListFleetRevisions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListFleetRevisionsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListFleetRevisions(w, r, name, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1923,6 +2337,21 @@ func (siw *ServerInterfaceWrapper) ReplaceFleetStatus(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// GetQuotaUsage operation middleware
+func (siw *ServerInterfaceWrapper) GetQuotaUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetQuotaUsage(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // DeleteRepositories operation middleware
 func (siw *ServerInterfaceWrapper) DeleteRepositories(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -2098,8 +2527,27 @@ func (siw *ServerInterfaceWrapper) ReplaceRepository(w http.ResponseWriter, r *h
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReplaceRepositoryParams
+
+	// ------------- Optional query parameter "fieldManager" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fieldManager", r.URL.Query(), &params.FieldManager)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fieldManager", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceRepository(w, r, name)
+		siw.Handler.ReplaceRepository(w, r, name, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2284,8 +2732,27 @@ func (siw *ServerInterfaceWrapper) ReplaceResourceSync(w http.ResponseWriter, r
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReplaceResourceSyncParams
+
+	// ------------- Optional query parameter "fieldManager" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fieldManager", r.URL.Query(), &params.FieldManager)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fieldManager", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceResourceSync(w, r, name)
+		siw.Handler.ReplaceResourceSync(w, r, name, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2414,6 +2881,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/auth/validate", wrapper.AuthValidate)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/bootstraptokens", wrapper.IssueBootstrapToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/bootstraptokens/redeem", wrapper.RedeemBootstrapToken)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/api/v1/certificatesigningrequests", wrapper.DeleteCertificateSigningRequests)
 	})
@@ -2447,6 +2920,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/devices", wrapper.CreateDevice)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/devices/batchget", wrapper.BatchGetDevices)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/api/v1/devices/{name}", wrapper.DeleteDevice)
 	})
@@ -2465,9 +2941,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/devices/{name}/decommission", wrapper.DecommissionDevice)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/devices/{name}/logs", wrapper.ReadDeviceLogs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/devices/{name}/logs", wrapper.ReplaceDeviceLogs)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/devices/{name}/rendered", wrapper.GetRenderedDeviceSpec)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/devices/{name}/revisions", wrapper.ListDeviceRevisions)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/devices/{name}/status", wrapper.ReadDeviceStatus)
 	})
@@ -2477,6 +2962,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/devices/{name}/status", wrapper.ReplaceDeviceStatus)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/devices/{name}/updateslot", wrapper.ReleaseDeviceUpdateSlot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/devices/{name}/updateslot", wrapper.AcquireDeviceUpdateSlot)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/enrollmentconfig", wrapper.GetEnrollmentConfig)
 	})
@@ -2546,6 +3037,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/fleets/{name}", wrapper.ReplaceFleet)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/fleets/{name}/revisions", wrapper.ListFleetRevisions)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/fleets/{name}/status", wrapper.ReadFleetStatus)
 	})
@@ -2555,6 +3049,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/fleets/{name}/status", wrapper.ReplaceFleetStatus)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/quotas", wrapper.GetQuotaUsage)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/api/v1/repositories", wrapper.DeleteRepositories)
 	})
@@ -2666,6 +3163,130 @@ func (response AuthValidate500JSONResponse) VisitAuthValidateResponse(w http.Res
 	return json.NewEncoder(w).Encode(response)
 }
 
+type IssueBootstrapTokenRequestObject struct {
+	Body *IssueBootstrapTokenJSONRequestBody
+}
+
+type IssueBootstrapTokenResponseObject interface {
+	VisitIssueBootstrapTokenResponse(w http.ResponseWriter) error
+}
+
+type IssueBootstrapToken201JSONResponse BootstrapToken
+
+func (response IssueBootstrapToken201JSONResponse) VisitIssueBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type IssueBootstrapToken400JSONResponse Error
+
+func (response IssueBootstrapToken400JSONResponse) VisitIssueBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type IssueBootstrapToken401JSONResponse Error
+
+func (response IssueBootstrapToken401JSONResponse) VisitIssueBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type IssueBootstrapToken403JSONResponse Error
+
+func (response IssueBootstrapToken403JSONResponse) VisitIssueBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type IssueBootstrapToken404JSONResponse Error
+
+func (response IssueBootstrapToken404JSONResponse) VisitIssueBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type IssueBootstrapToken503JSONResponse Error
+
+func (response IssueBootstrapToken503JSONResponse) VisitIssueBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeemBootstrapTokenRequestObject struct {
+	Body *RedeemBootstrapTokenJSONRequestBody
+}
+
+type RedeemBootstrapTokenResponseObject interface {
+	VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error
+}
+
+type RedeemBootstrapToken200JSONResponse BootstrapTokenRedemptionResponse
+
+func (response RedeemBootstrapToken200JSONResponse) VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeemBootstrapToken400JSONResponse Error
+
+func (response RedeemBootstrapToken400JSONResponse) VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeemBootstrapToken401JSONResponse Error
+
+func (response RedeemBootstrapToken401JSONResponse) VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeemBootstrapToken403JSONResponse Error
+
+func (response RedeemBootstrapToken403JSONResponse) VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeemBootstrapToken404JSONResponse Error
+
+func (response RedeemBootstrapToken404JSONResponse) VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RedeemBootstrapToken503JSONResponse Error
+
+func (response RedeemBootstrapToken503JSONResponse) VisitRedeemBootstrapTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteCertificateSigningRequestsRequestObject struct {
 }
 
@@ -2931,8 +3552,9 @@ func (response ReadCertificateSigningRequest503JSONResponse) VisitReadCertificat
 }
 
 type PatchCertificateSigningRequestRequestObject struct {
-	Name string `json:"name"`
-	Body *PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody
+	Name                              string `json:"name"`
+	ApplicationJSONPatchPlusJSONBody  *PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody
+	ApplicationMergePatchPlusJSONBody *PatchCertificateSigningRequestApplicationMergePatchPlusJSONRequestBody
 }
 
 type PatchCertificateSigningRequestResponseObject interface {
@@ -3156,13 +3778,14 @@ func (response UpdateCertificateSigningRequestApproval503JSONResponse) VisitUpda
 }
 
 type DeleteDevicesRequestObject struct {
+	Params DeleteDevicesParams
 }
 
 type DeleteDevicesResponseObject interface {
 	VisitDeleteDevicesResponse(w http.ResponseWriter) error
 }
 
-type DeleteDevices200JSONResponse Status
+type DeleteDevices200JSONResponse DeleteCollectionResult
 
 func (response DeleteDevices200JSONResponse) VisitDeleteDevicesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -3171,6 +3794,15 @@ func (response DeleteDevices200JSONResponse) VisitDeleteDevicesResponse(w http.R
 	return json.NewEncoder(w).Encode(response)
 }
 
+type DeleteDevices400JSONResponse Error
+
+func (response DeleteDevices400JSONResponse) VisitDeleteDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteDevices401JSONResponse Error
 
 func (response DeleteDevices401JSONResponse) VisitDeleteDevicesResponse(w http.ResponseWriter) error {
@@ -3313,6 +3945,59 @@ func (response CreateDevice503JSONResponse) VisitCreateDeviceResponse(w http.Res
 	return json.NewEncoder(w).Encode(response)
 }
 
+type BatchGetDevicesRequestObject struct {
+	Body *BatchGetDevicesJSONRequestBody
+}
+
+type BatchGetDevicesResponseObject interface {
+	VisitBatchGetDevicesResponse(w http.ResponseWriter) error
+}
+
+type BatchGetDevices200JSONResponse DeviceBatchGetResult
+
+func (response BatchGetDevices200JSONResponse) VisitBatchGetDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetDevices400JSONResponse Error
+
+func (response BatchGetDevices400JSONResponse) VisitBatchGetDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetDevices401JSONResponse Error
+
+func (response BatchGetDevices401JSONResponse) VisitBatchGetDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetDevices403JSONResponse Error
+
+func (response BatchGetDevices403JSONResponse) VisitBatchGetDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetDevices503JSONResponse Error
+
+func (response BatchGetDevices503JSONResponse) VisitBatchGetDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteDeviceRequestObject struct {
 	Name string `json:"name"`
 }
@@ -3367,7 +4052,8 @@ func (response DeleteDevice503JSONResponse) VisitDeleteDeviceResponse(w http.Res
 }
 
 type ReadDeviceRequestObject struct {
-	Name string `json:"name"`
+	Name   string `json:"name"`
+	Params ReadDeviceParams
 }
 
 type ReadDeviceResponseObject interface {
@@ -3383,6 +4069,15 @@ func (response ReadDevice200JSONResponse) VisitReadDeviceResponse(w http.Respons
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ReadDevice400JSONResponse Error
+
+func (response ReadDevice400JSONResponse) VisitReadDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ReadDevice401JSONResponse Error
 
 func (response ReadDevice401JSONResponse) VisitReadDeviceResponse(w http.ResponseWriter) error {
@@ -3420,8 +4115,9 @@ func (response ReadDevice503JSONResponse) VisitReadDeviceResponse(w http.Respons
 }
 
 type PatchDeviceRequestObject struct {
-	Name string `json:"name"`
-	Body *PatchDeviceApplicationJSONPatchPlusJSONRequestBody
+	Name                              string `json:"name"`
+	ApplicationJSONPatchPlusJSONBody  *PatchDeviceApplicationJSONPatchPlusJSONRequestBody
+	ApplicationMergePatchPlusJSONBody *PatchDeviceApplicationMergePatchPlusJSONRequestBody
 }
 
 type PatchDeviceResponseObject interface {
@@ -3492,8 +4188,9 @@ func (response PatchDevice503JSONResponse) VisitPatchDeviceResponse(w http.Respo
 }
 
 type ReplaceDeviceRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceDeviceJSONRequestBody
+	Name   string `json:"name"`
+	Params ReplaceDeviceParams
+	Body   *ReplaceDeviceJSONRequestBody
 }
 
 type ReplaceDeviceResponseObject interface {
@@ -3697,6 +4394,122 @@ func (response DecommissionDevice503JSONResponse) VisitDecommissionDeviceRespons
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ReadDeviceLogsRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadDeviceLogsResponseObject interface {
+	VisitReadDeviceLogsResponse(w http.ResponseWriter) error
+}
+
+type ReadDeviceLogs200JSONResponse DeviceLogs
+
+func (response ReadDeviceLogs200JSONResponse) VisitReadDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceLogs401JSONResponse Error
+
+func (response ReadDeviceLogs401JSONResponse) VisitReadDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceLogs403JSONResponse Error
+
+func (response ReadDeviceLogs403JSONResponse) VisitReadDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceLogs404JSONResponse Error
+
+func (response ReadDeviceLogs404JSONResponse) VisitReadDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceLogs503JSONResponse Error
+
+func (response ReadDeviceLogs503JSONResponse) VisitReadDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceLogsRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceDeviceLogsJSONRequestBody
+}
+
+type ReplaceDeviceLogsResponseObject interface {
+	VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error
+}
+
+type ReplaceDeviceLogs200JSONResponse DeviceLogs
+
+func (response ReplaceDeviceLogs200JSONResponse) VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceLogs400JSONResponse Error
+
+func (response ReplaceDeviceLogs400JSONResponse) VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceLogs401JSONResponse Error
+
+func (response ReplaceDeviceLogs401JSONResponse) VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceLogs403JSONResponse Error
+
+func (response ReplaceDeviceLogs403JSONResponse) VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceLogs404JSONResponse Error
+
+func (response ReplaceDeviceLogs404JSONResponse) VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceLogs503JSONResponse Error
+
+func (response ReplaceDeviceLogs503JSONResponse) VisitReplaceDeviceLogsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetRenderedDeviceSpecRequestObject struct {
 	Name   string `json:"name"`
 	Params GetRenderedDeviceSpecParams
@@ -3706,62 +4519,125 @@ type GetRenderedDeviceSpecResponseObject interface {
 	VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error
 }
 
-type GetRenderedDeviceSpec200JSONResponse RenderedDeviceSpec
+type GetRenderedDeviceSpec200JSONResponse RenderedDeviceSpec
+
+func (response GetRenderedDeviceSpec200JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRenderedDeviceSpec204Response struct {
+}
+
+func (response GetRenderedDeviceSpec204Response) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type GetRenderedDeviceSpec401JSONResponse Error
+
+func (response GetRenderedDeviceSpec401JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRenderedDeviceSpec403JSONResponse Error
+
+func (response GetRenderedDeviceSpec403JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRenderedDeviceSpec404JSONResponse Error
+
+func (response GetRenderedDeviceSpec404JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRenderedDeviceSpec409JSONResponse Error
+
+func (response GetRenderedDeviceSpec409JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRenderedDeviceSpec503JSONResponse Error
+
+func (response GetRenderedDeviceSpec503JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceRevisionsRequestObject struct {
+	Name   string `json:"name"`
+	Params ListDeviceRevisionsParams
+}
+
+type ListDeviceRevisionsResponseObject interface {
+	VisitListDeviceRevisionsResponse(w http.ResponseWriter) error
+}
+
+type ListDeviceRevisions200JSONResponse ResourceRevisionList
 
-func (response GetRenderedDeviceSpec200JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+func (response ListDeviceRevisions200JSONResponse) VisitListDeviceRevisionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRenderedDeviceSpec204Response struct {
-}
-
-func (response GetRenderedDeviceSpec204Response) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
-
-type GetRenderedDeviceSpec401JSONResponse Error
+type ListDeviceRevisions400JSONResponse Error
 
-func (response GetRenderedDeviceSpec401JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+func (response ListDeviceRevisions400JSONResponse) VisitListDeviceRevisionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRenderedDeviceSpec403JSONResponse Error
+type ListDeviceRevisions401JSONResponse Error
 
-func (response GetRenderedDeviceSpec403JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+func (response ListDeviceRevisions401JSONResponse) VisitListDeviceRevisionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRenderedDeviceSpec404JSONResponse Error
+type ListDeviceRevisions403JSONResponse Error
 
-func (response GetRenderedDeviceSpec404JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+func (response ListDeviceRevisions403JSONResponse) VisitListDeviceRevisionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRenderedDeviceSpec409JSONResponse Error
+type ListDeviceRevisions404JSONResponse Error
 
-func (response GetRenderedDeviceSpec409JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+func (response ListDeviceRevisions404JSONResponse) VisitListDeviceRevisionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRenderedDeviceSpec503JSONResponse Error
+type ListDeviceRevisions503JSONResponse Error
 
-func (response GetRenderedDeviceSpec503JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+func (response ListDeviceRevisions503JSONResponse) VisitListDeviceRevisionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(503)
 
@@ -3947,6 +4823,112 @@ func (response ReplaceDeviceStatus503JSONResponse) VisitReplaceDeviceStatusRespo
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ReleaseDeviceUpdateSlotRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReleaseDeviceUpdateSlotResponseObject interface {
+	VisitReleaseDeviceUpdateSlotResponse(w http.ResponseWriter) error
+}
+
+type ReleaseDeviceUpdateSlot200JSONResponse DeviceUpdateSlot
+
+func (response ReleaseDeviceUpdateSlot200JSONResponse) VisitReleaseDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseDeviceUpdateSlot401JSONResponse Error
+
+func (response ReleaseDeviceUpdateSlot401JSONResponse) VisitReleaseDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseDeviceUpdateSlot403JSONResponse Error
+
+func (response ReleaseDeviceUpdateSlot403JSONResponse) VisitReleaseDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseDeviceUpdateSlot404JSONResponse Error
+
+func (response ReleaseDeviceUpdateSlot404JSONResponse) VisitReleaseDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseDeviceUpdateSlot503JSONResponse Error
+
+func (response ReleaseDeviceUpdateSlot503JSONResponse) VisitReleaseDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcquireDeviceUpdateSlotRequestObject struct {
+	Name string `json:"name"`
+}
+
+type AcquireDeviceUpdateSlotResponseObject interface {
+	VisitAcquireDeviceUpdateSlotResponse(w http.ResponseWriter) error
+}
+
+type AcquireDeviceUpdateSlot200JSONResponse DeviceUpdateSlot
+
+func (response AcquireDeviceUpdateSlot200JSONResponse) VisitAcquireDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcquireDeviceUpdateSlot401JSONResponse Error
+
+func (response AcquireDeviceUpdateSlot401JSONResponse) VisitAcquireDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcquireDeviceUpdateSlot403JSONResponse Error
+
+func (response AcquireDeviceUpdateSlot403JSONResponse) VisitAcquireDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcquireDeviceUpdateSlot404JSONResponse Error
+
+func (response AcquireDeviceUpdateSlot404JSONResponse) VisitAcquireDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcquireDeviceUpdateSlot503JSONResponse Error
+
+func (response AcquireDeviceUpdateSlot503JSONResponse) VisitAcquireDeviceUpdateSlotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetEnrollmentConfigRequestObject struct {
 	Params GetEnrollmentConfigParams
 }
@@ -4274,8 +5256,9 @@ func (response ReadEnrollmentRequest503JSONResponse) VisitReadEnrollmentRequestR
 }
 
 type PatchEnrollmentRequestRequestObject struct {
-	Name string `json:"name"`
-	Body *PatchEnrollmentRequestApplicationJSONPatchPlusJSONRequestBody
+	Name                              string `json:"name"`
+	ApplicationJSONPatchPlusJSONBody  *PatchEnrollmentRequestApplicationJSONPatchPlusJSONRequestBody
+	ApplicationMergePatchPlusJSONBody *PatchEnrollmentRequestApplicationMergePatchPlusJSONRequestBody
 }
 
 type PatchEnrollmentRequestResponseObject interface {
@@ -5140,8 +6123,9 @@ func (response ReadFleet503JSONResponse) VisitReadFleetResponse(w http.ResponseW
 }
 
 type PatchFleetRequestObject struct {
-	Name string `json:"name"`
-	Body *PatchFleetApplicationJSONPatchPlusJSONRequestBody
+	Name                              string `json:"name"`
+	ApplicationJSONPatchPlusJSONBody  *PatchFleetApplicationJSONPatchPlusJSONRequestBody
+	ApplicationMergePatchPlusJSONBody *PatchFleetApplicationMergePatchPlusJSONRequestBody
 }
 
 type PatchFleetResponseObject interface {
@@ -5212,8 +6196,9 @@ func (response PatchFleet503JSONResponse) VisitPatchFleetResponse(w http.Respons
 }
 
 type ReplaceFleetRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceFleetJSONRequestBody
+	Name   string `json:"name"`
+	Params ReplaceFleetParams
+	Body   *ReplaceFleetJSONRequestBody
 }
 
 type ReplaceFleetResponseObject interface {
@@ -5292,6 +6277,69 @@ func (response ReplaceFleet503JSONResponse) VisitReplaceFleetResponse(w http.Res
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ListFleetRevisionsRequestObject struct {
+	Name   string `json:"name"`
+	Params ListFleetRevisionsParams
+}
+
+type ListFleetRevisionsResponseObject interface {
+	VisitListFleetRevisionsResponse(w http.ResponseWriter) error
+}
+
+type ListFleetRevisions200JSONResponse ResourceRevisionList
+
+func (response ListFleetRevisions200JSONResponse) VisitListFleetRevisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFleetRevisions400JSONResponse Error
+
+func (response ListFleetRevisions400JSONResponse) VisitListFleetRevisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFleetRevisions401JSONResponse Error
+
+func (response ListFleetRevisions401JSONResponse) VisitListFleetRevisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFleetRevisions403JSONResponse Error
+
+func (response ListFleetRevisions403JSONResponse) VisitListFleetRevisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFleetRevisions404JSONResponse Error
+
+func (response ListFleetRevisions404JSONResponse) VisitListFleetRevisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFleetRevisions503JSONResponse Error
+
+func (response ListFleetRevisions503JSONResponse) VisitListFleetRevisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ReadFleetStatusRequestObject struct {
 	Name string `json:"name"`
 }
@@ -5462,6 +6510,49 @@ func (response ReplaceFleetStatus503JSONResponse) VisitReplaceFleetStatusRespons
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetQuotaUsageRequestObject struct {
+}
+
+type GetQuotaUsageResponseObject interface {
+	VisitGetQuotaUsageResponse(w http.ResponseWriter) error
+}
+
+type GetQuotaUsage200JSONResponse QuotaUsage
+
+func (response GetQuotaUsage200JSONResponse) VisitGetQuotaUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQuotaUsage401JSONResponse Error
+
+func (response GetQuotaUsage401JSONResponse) VisitGetQuotaUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQuotaUsage403JSONResponse Error
+
+func (response GetQuotaUsage403JSONResponse) VisitGetQuotaUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQuotaUsage503JSONResponse Error
+
+func (response GetQuotaUsage503JSONResponse) VisitGetQuotaUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteRepositoriesRequestObject struct {
 }
 
@@ -5727,8 +6818,9 @@ func (response ReadRepository503JSONResponse) VisitReadRepositoryResponse(w http
 }
 
 type PatchRepositoryRequestObject struct {
-	Name string `json:"name"`
-	Body *PatchRepositoryApplicationJSONPatchPlusJSONRequestBody
+	Name                              string `json:"name"`
+	ApplicationJSONPatchPlusJSONBody  *PatchRepositoryApplicationJSONPatchPlusJSONRequestBody
+	ApplicationMergePatchPlusJSONBody *PatchRepositoryApplicationMergePatchPlusJSONRequestBody
 }
 
 type PatchRepositoryResponseObject interface {
@@ -5799,8 +6891,9 @@ func (response PatchRepository503JSONResponse) VisitPatchRepositoryResponse(w ht
 }
 
 type ReplaceRepositoryRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceRepositoryJSONRequestBody
+	Name   string `json:"name"`
+	Params ReplaceRepositoryParams
+	Body   *ReplaceRepositoryJSONRequestBody
 }
 
 type ReplaceRepositoryResponseObject interface {
@@ -6144,8 +7237,9 @@ func (response ReadResourceSync503JSONResponse) VisitReadResourceSyncResponse(w
 }
 
 type PatchResourceSyncRequestObject struct {
-	Name string `json:"name"`
-	Body *PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody
+	Name                              string `json:"name"`
+	ApplicationJSONPatchPlusJSONBody  *PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody
+	ApplicationMergePatchPlusJSONBody *PatchResourceSyncApplicationMergePatchPlusJSONRequestBody
 }
 
 type PatchResourceSyncResponseObject interface {
@@ -6216,8 +7310,9 @@ func (response PatchResourceSync503JSONResponse) VisitPatchResourceSyncResponse(
 }
 
 type ReplaceResourceSyncRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceResourceSyncJSONRequestBody
+	Name   string `json:"name"`
+	Params ReplaceResourceSyncParams
+	Body   *ReplaceResourceSyncJSONRequestBody
 }
 
 type ReplaceResourceSyncResponseObject interface {
@@ -6305,6 +7400,12 @@ type StrictServerInterface interface {
 	// (GET /api/v1/auth/validate)
 	AuthValidate(ctx context.Context, request AuthValidateRequestObject) (AuthValidateResponseObject, error)
 
+	// (POST /api/v1/bootstraptokens)
+	IssueBootstrapToken(ctx context.Context, request IssueBootstrapTokenRequestObject) (IssueBootstrapTokenResponseObject, error)
+
+	// (POST /api/v1/bootstraptokens/redeem)
+	RedeemBootstrapToken(ctx context.Context, request RedeemBootstrapTokenRequestObject) (RedeemBootstrapTokenResponseObject, error)
+
 	// (DELETE /api/v1/certificatesigningrequests)
 	DeleteCertificateSigningRequests(ctx context.Context, request DeleteCertificateSigningRequestsRequestObject) (DeleteCertificateSigningRequestsResponseObject, error)
 
@@ -6338,6 +7439,9 @@ type StrictServerInterface interface {
 	// (POST /api/v1/devices)
 	CreateDevice(ctx context.Context, request CreateDeviceRequestObject) (CreateDeviceResponseObject, error)
 
+	// (POST /api/v1/devices/batchget)
+	BatchGetDevices(ctx context.Context, request BatchGetDevicesRequestObject) (BatchGetDevicesResponseObject, error)
+
 	// (DELETE /api/v1/devices/{name})
 	DeleteDevice(ctx context.Context, request DeleteDeviceRequestObject) (DeleteDeviceResponseObject, error)
 
@@ -6356,9 +7460,18 @@ type StrictServerInterface interface {
 	// (PUT /api/v1/devices/{name}/decommission)
 	DecommissionDevice(ctx context.Context, request DecommissionDeviceRequestObject) (DecommissionDeviceResponseObject, error)
 
+	// (GET /api/v1/devices/{name}/logs)
+	ReadDeviceLogs(ctx context.Context, request ReadDeviceLogsRequestObject) (ReadDeviceLogsResponseObject, error)
+
+	// (PUT /api/v1/devices/{name}/logs)
+	ReplaceDeviceLogs(ctx context.Context, request ReplaceDeviceLogsRequestObject) (ReplaceDeviceLogsResponseObject, error)
+
 	// (GET /api/v1/devices/{name}/rendered)
 	GetRenderedDeviceSpec(ctx context.Context, request GetRenderedDeviceSpecRequestObject) (GetRenderedDeviceSpecResponseObject, error)
 
+	// (GET /api/v1/devices/{name}/revisions)
+	ListDeviceRevisions(ctx context.Context, request ListDeviceRevisionsRequestObject) (ListDeviceRevisionsResponseObject, error)
+
 	// (GET /api/v1/devices/{name}/status)
 	ReadDeviceStatus(ctx context.Context, request ReadDeviceStatusRequestObject) (ReadDeviceStatusResponseObject, error)
 
@@ -6368,6 +7481,12 @@ type StrictServerInterface interface {
 	// (PUT /api/v1/devices/{name}/status)
 	ReplaceDeviceStatus(ctx context.Context, request ReplaceDeviceStatusRequestObject) (ReplaceDeviceStatusResponseObject, error)
 
+	// (DELETE /api/v1/devices/{name}/updateslot)
+	ReleaseDeviceUpdateSlot(ctx context.Context, request ReleaseDeviceUpdateSlotRequestObject) (ReleaseDeviceUpdateSlotResponseObject, error)
+
+	// (PUT /api/v1/devices/{name}/updateslot)
+	AcquireDeviceUpdateSlot(ctx context.Context, request AcquireDeviceUpdateSlotRequestObject) (AcquireDeviceUpdateSlotResponseObject, error)
+
 	// (GET /api/v1/enrollmentconfig)
 	GetEnrollmentConfig(ctx context.Context, request GetEnrollmentConfigRequestObject) (GetEnrollmentConfigResponseObject, error)
 
@@ -6437,6 +7556,9 @@ type StrictServerInterface interface {
 	// (PUT /api/v1/fleets/{name})
 	ReplaceFleet(ctx context.Context, request ReplaceFleetRequestObject) (ReplaceFleetResponseObject, error)
 
+	// (GET /api/v1/fleets/{name}/revisions)
+	ListFleetRevisions(ctx context.Context, request ListFleetRevisionsRequestObject) (ListFleetRevisionsResponseObject, error)
+
 	// (GET /api/v1/fleets/{name}/status)
 	ReadFleetStatus(ctx context.Context, request ReadFleetStatusRequestObject) (ReadFleetStatusResponseObject, error)
 
@@ -6446,6 +7568,9 @@ type StrictServerInterface interface {
 	// (PUT /api/v1/fleets/{name}/status)
 	ReplaceFleetStatus(ctx context.Context, request ReplaceFleetStatusRequestObject) (ReplaceFleetStatusResponseObject, error)
 
+	// (GET /api/v1/quotas)
+	GetQuotaUsage(ctx context.Context, request GetQuotaUsageRequestObject) (GetQuotaUsageResponseObject, error)
+
 	// (DELETE /api/v1/repositories)
 	DeleteRepositories(ctx context.Context, request DeleteRepositoriesRequestObject) (DeleteRepositoriesResponseObject, error)
 
@@ -6518,23 +7643,80 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
-// AuthConfig operation middleware
-func (sh *strictHandler) AuthConfig(w http.ResponseWriter, r *http.Request) {
-	var request AuthConfigRequestObject
+// AuthConfig operation middleware
+func (sh *strictHandler) AuthConfig(w http.ResponseWriter, r *http.Request) {
+	var request AuthConfigRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AuthConfig(ctx, request.(AuthConfigRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AuthConfig")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AuthConfigResponseObject); ok {
+		if err := validResponse.VisitAuthConfigResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AuthValidate operation middleware
+func (sh *strictHandler) AuthValidate(w http.ResponseWriter, r *http.Request, params AuthValidateParams) {
+	var request AuthValidateRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AuthValidate(ctx, request.(AuthValidateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AuthValidate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AuthValidateResponseObject); ok {
+		if err := validResponse.VisitAuthValidateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// IssueBootstrapToken operation middleware
+func (sh *strictHandler) IssueBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	var request IssueBootstrapTokenRequestObject
+
+	var body IssueBootstrapTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AuthConfig(ctx, request.(AuthConfigRequestObject))
+		return sh.ssi.IssueBootstrapToken(ctx, request.(IssueBootstrapTokenRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AuthConfig")
+		handler = middleware(handler, "IssueBootstrapToken")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AuthConfigResponseObject); ok {
-		if err := validResponse.VisitAuthConfigResponse(w); err != nil {
+	} else if validResponse, ok := response.(IssueBootstrapTokenResponseObject); ok {
+		if err := validResponse.VisitIssueBootstrapTokenResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6542,25 +7724,30 @@ func (sh *strictHandler) AuthConfig(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// AuthValidate operation middleware
-func (sh *strictHandler) AuthValidate(w http.ResponseWriter, r *http.Request, params AuthValidateParams) {
-	var request AuthValidateRequestObject
+// RedeemBootstrapToken operation middleware
+func (sh *strictHandler) RedeemBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	var request RedeemBootstrapTokenRequestObject
 
-	request.Params = params
+	var body RedeemBootstrapTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AuthValidate(ctx, request.(AuthValidateRequestObject))
+		return sh.ssi.RedeemBootstrapToken(ctx, request.(RedeemBootstrapTokenRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AuthValidate")
+		handler = middleware(handler, "RedeemBootstrapToken")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AuthValidateResponseObject); ok {
-		if err := validResponse.VisitAuthValidateResponse(w); err != nil {
+	} else if validResponse, ok := response.(RedeemBootstrapTokenResponseObject); ok {
+		if err := validResponse.VisitRedeemBootstrapTokenResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6706,13 +7893,24 @@ func (sh *strictHandler) PatchCertificateSigningRequest(w http.ResponseWriter, r
 	var request PatchCertificateSigningRequestRequestObject
 
 	request.Name = name
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json-patch+json") {
 
-	var body PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+		var body PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationJSONPatchPlusJSONBody = &body
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/merge-patch+json") {
+
+		var body PatchCertificateSigningRequestApplicationMergePatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationMergePatchPlusJSONBody = &body
 	}
-	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.PatchCertificateSigningRequest(ctx, request.(PatchCertificateSigningRequestRequestObject))
@@ -6801,9 +7999,11 @@ func (sh *strictHandler) UpdateCertificateSigningRequestApproval(w http.Response
 }
 
 // DeleteDevices operation middleware
-func (sh *strictHandler) DeleteDevices(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) DeleteDevices(w http.ResponseWriter, r *http.Request, params DeleteDevicesParams) {
 	var request DeleteDevicesRequestObject
 
+	request.Params = params
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteDevices(ctx, request.(DeleteDevicesRequestObject))
 	}
@@ -6881,6 +8081,37 @@ func (sh *strictHandler) CreateDevice(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// BatchGetDevices operation middleware
+func (sh *strictHandler) BatchGetDevices(w http.ResponseWriter, r *http.Request) {
+	var request BatchGetDevicesRequestObject
+
+	var body BatchGetDevicesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.BatchGetDevices(ctx, request.(BatchGetDevicesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BatchGetDevices")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(BatchGetDevicesResponseObject); ok {
+		if err := validResponse.VisitBatchGetDevicesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // DeleteDevice operation middleware
 func (sh *strictHandler) DeleteDevice(w http.ResponseWriter, r *http.Request, name string) {
 	var request DeleteDeviceRequestObject
@@ -6908,10 +8139,11 @@ func (sh *strictHandler) DeleteDevice(w http.ResponseWriter, r *http.Request, na
 }
 
 // ReadDevice operation middleware
-func (sh *strictHandler) ReadDevice(w http.ResponseWriter, r *http.Request, name string) {
+func (sh *strictHandler) ReadDevice(w http.ResponseWriter, r *http.Request, name string, params ReadDeviceParams) {
 	var request ReadDeviceRequestObject
 
 	request.Name = name
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.ReadDevice(ctx, request.(ReadDeviceRequestObject))
@@ -6938,13 +8170,24 @@ func (sh *strictHandler) PatchDevice(w http.ResponseWriter, r *http.Request, nam
 	var request PatchDeviceRequestObject
 
 	request.Name = name
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json-patch+json") {
 
-	var body PatchDeviceApplicationJSONPatchPlusJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+		var body PatchDeviceApplicationJSONPatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationJSONPatchPlusJSONBody = &body
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/merge-patch+json") {
+
+		var body PatchDeviceApplicationMergePatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationMergePatchPlusJSONBody = &body
 	}
-	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.PatchDevice(ctx, request.(PatchDeviceRequestObject))
@@ -6967,10 +8210,11 @@ func (sh *strictHandler) PatchDevice(w http.ResponseWriter, r *http.Request, nam
 }
 
 // ReplaceDevice operation middleware
-func (sh *strictHandler) ReplaceDevice(w http.ResponseWriter, r *http.Request, name string) {
+func (sh *strictHandler) ReplaceDevice(w http.ResponseWriter, r *http.Request, name string, params ReplaceDeviceParams) {
 	var request ReplaceDeviceRequestObject
 
 	request.Name = name
+	request.Params = params
 
 	var body ReplaceDeviceJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -7058,6 +8302,65 @@ func (sh *strictHandler) DecommissionDevice(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// ReadDeviceLogs operation middleware
+func (sh *strictHandler) ReadDeviceLogs(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadDeviceLogsRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadDeviceLogs(ctx, request.(ReadDeviceLogsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadDeviceLogs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadDeviceLogsResponseObject); ok {
+		if err := validResponse.VisitReadDeviceLogsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReplaceDeviceLogs operation middleware
+func (sh *strictHandler) ReplaceDeviceLogs(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceDeviceLogsRequestObject
+
+	request.Name = name
+
+	var body ReplaceDeviceLogsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceDeviceLogs(ctx, request.(ReplaceDeviceLogsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceDeviceLogs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceDeviceLogsResponseObject); ok {
+		if err := validResponse.VisitReplaceDeviceLogsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetRenderedDeviceSpec operation middleware
 func (sh *strictHandler) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams) {
 	var request GetRenderedDeviceSpecRequestObject
@@ -7085,6 +8388,33 @@ func (sh *strictHandler) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// ListDeviceRevisions operation middleware
+func (sh *strictHandler) ListDeviceRevisions(w http.ResponseWriter, r *http.Request, name string, params ListDeviceRevisionsParams) {
+	var request ListDeviceRevisionsRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDeviceRevisions(ctx, request.(ListDeviceRevisionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDeviceRevisions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListDeviceRevisionsResponseObject); ok {
+		if err := validResponse.VisitListDeviceRevisionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ReadDeviceStatus operation middleware
 func (sh *strictHandler) ReadDeviceStatus(w http.ResponseWriter, r *http.Request, name string) {
 	var request ReadDeviceStatusRequestObject
@@ -7177,6 +8507,58 @@ func (sh *strictHandler) ReplaceDeviceStatus(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// ReleaseDeviceUpdateSlot operation middleware
+func (sh *strictHandler) ReleaseDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReleaseDeviceUpdateSlotRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReleaseDeviceUpdateSlot(ctx, request.(ReleaseDeviceUpdateSlotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReleaseDeviceUpdateSlot")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReleaseDeviceUpdateSlotResponseObject); ok {
+		if err := validResponse.VisitReleaseDeviceUpdateSlotResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AcquireDeviceUpdateSlot operation middleware
+func (sh *strictHandler) AcquireDeviceUpdateSlot(w http.ResponseWriter, r *http.Request, name string) {
+	var request AcquireDeviceUpdateSlotRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AcquireDeviceUpdateSlot(ctx, request.(AcquireDeviceUpdateSlotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AcquireDeviceUpdateSlot")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AcquireDeviceUpdateSlotResponseObject); ok {
+		if err := validResponse.VisitAcquireDeviceUpdateSlotResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetEnrollmentConfig operation middleware
 func (sh *strictHandler) GetEnrollmentConfig(w http.ResponseWriter, r *http.Request, params GetEnrollmentConfigParams) {
 	var request GetEnrollmentConfigRequestObject
@@ -7341,13 +8723,24 @@ func (sh *strictHandler) PatchEnrollmentRequest(w http.ResponseWriter, r *http.R
 	var request PatchEnrollmentRequestRequestObject
 
 	request.Name = name
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json-patch+json") {
 
-	var body PatchEnrollmentRequestApplicationJSONPatchPlusJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+		var body PatchEnrollmentRequestApplicationJSONPatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationJSONPatchPlusJSONBody = &body
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/merge-patch+json") {
+
+		var body PatchEnrollmentRequestApplicationMergePatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationMergePatchPlusJSONBody = &body
 	}
-	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.PatchEnrollmentRequest(ctx, request.(PatchEnrollmentRequestRequestObject))
@@ -7773,13 +9166,24 @@ func (sh *strictHandler) PatchFleet(w http.ResponseWriter, r *http.Request, name
 	var request PatchFleetRequestObject
 
 	request.Name = name
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json-patch+json") {
 
-	var body PatchFleetApplicationJSONPatchPlusJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+		var body PatchFleetApplicationJSONPatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationJSONPatchPlusJSONBody = &body
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/merge-patch+json") {
+
+		var body PatchFleetApplicationMergePatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationMergePatchPlusJSONBody = &body
 	}
-	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.PatchFleet(ctx, request.(PatchFleetRequestObject))
@@ -7802,10 +9206,11 @@ func (sh *strictHandler) PatchFleet(w http.ResponseWriter, r *http.Request, name
 }
 
 // ReplaceFleet operation middleware
-func (sh *strictHandler) ReplaceFleet(w http.ResponseWriter, r *http.Request, name string) {
+func (sh *strictHandler) ReplaceFleet(w http.ResponseWriter, r *http.Request, name string, params ReplaceFleetParams) {
 	var request ReplaceFleetRequestObject
 
 	request.Name = name
+	request.Params = params
 
 	var body ReplaceFleetJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -7834,6 +9239,33 @@ func (sh *strictHandler) ReplaceFleet(w http.ResponseWriter, r *http.Request, na
 	}
 }
 
+// ListFleetRevisions operation middleware
+func (sh *strictHandler) ListFleetRevisions(w http.ResponseWriter, r *http.Request, name string, params ListFleetRevisionsParams) {
+	var request ListFleetRevisionsRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFleetRevisions(ctx, request.(ListFleetRevisionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFleetRevisions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFleetRevisionsResponseObject); ok {
+		if err := validResponse.VisitListFleetRevisionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ReadFleetStatus operation middleware
 func (sh *strictHandler) ReadFleetStatus(w http.ResponseWriter, r *http.Request, name string) {
 	var request ReadFleetStatusRequestObject
@@ -7926,6 +9358,30 @@ func (sh *strictHandler) ReplaceFleetStatus(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// GetQuotaUsage operation middleware
+func (sh *strictHandler) GetQuotaUsage(w http.ResponseWriter, r *http.Request) {
+	var request GetQuotaUsageRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetQuotaUsage(ctx, request.(GetQuotaUsageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetQuotaUsage")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetQuotaUsageResponseObject); ok {
+		if err := validResponse.VisitGetQuotaUsageResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // DeleteRepositories operation middleware
 func (sh *strictHandler) DeleteRepositories(w http.ResponseWriter, r *http.Request) {
 	var request DeleteRepositoriesRequestObject
@@ -8064,13 +9520,24 @@ func (sh *strictHandler) PatchRepository(w http.ResponseWriter, r *http.Request,
 	var request PatchRepositoryRequestObject
 
 	request.Name = name
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json-patch+json") {
 
-	var body PatchRepositoryApplicationJSONPatchPlusJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+		var body PatchRepositoryApplicationJSONPatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationJSONPatchPlusJSONBody = &body
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/merge-patch+json") {
+
+		var body PatchRepositoryApplicationMergePatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationMergePatchPlusJSONBody = &body
 	}
-	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.PatchRepository(ctx, request.(PatchRepositoryRequestObject))
@@ -8093,10 +9560,11 @@ func (sh *strictHandler) PatchRepository(w http.ResponseWriter, r *http.Request,
 }
 
 // ReplaceRepository operation middleware
-func (sh *strictHandler) ReplaceRepository(w http.ResponseWriter, r *http.Request, name string) {
+func (sh *strictHandler) ReplaceRepository(w http.ResponseWriter, r *http.Request, name string, params ReplaceRepositoryParams) {
 	var request ReplaceRepositoryRequestObject
 
 	request.Name = name
+	request.Params = params
 
 	var body ReplaceRepositoryJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -8263,13 +9731,24 @@ func (sh *strictHandler) PatchResourceSync(w http.ResponseWriter, r *http.Reques
 	var request PatchResourceSyncRequestObject
 
 	request.Name = name
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json-patch+json") {
 
-	var body PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+		var body PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationJSONPatchPlusJSONBody = &body
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/merge-patch+json") {
+
+		var body PatchResourceSyncApplicationMergePatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationMergePatchPlusJSONBody = &body
 	}
-	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.PatchResourceSync(ctx, request.(PatchResourceSyncRequestObject))
@@ -8292,10 +9771,11 @@ func (sh *strictHandler) PatchResourceSync(w http.ResponseWriter, r *http.Reques
 }
 
 // ReplaceResourceSync operation middleware
-func (sh *strictHandler) ReplaceResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+func (sh *strictHandler) ReplaceResourceSync(w http.ResponseWriter, r *http.Request, name string, params ReplaceResourceSyncParams) {
 	var request ReplaceResourceSyncRequestObject
 
 	request.Name = name
+	request.Params = params
 
 	var body ReplaceResourceSyncJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
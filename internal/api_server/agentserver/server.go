@@ -16,11 +16,14 @@ import (
 	"github.com/flightctl/flightctl/internal/config"
 	"github.com/flightctl/flightctl/internal/crypto"
 	"github.com/flightctl/flightctl/internal/instrumentation"
+	"github.com/flightctl/flightctl/internal/kvstore"
+	"github.com/flightctl/flightctl/internal/notifications"
 	service "github.com/flightctl/flightctl/internal/service/agent"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	oapimiddleware "github.com/oapi-codegen/nethttp-middleware"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
@@ -72,14 +75,31 @@ func (s *AgentServer) GetGRPCServer() *AgentGrpcServer {
 }
 func (s *AgentServer) Run(ctx context.Context) error {
 	s.log.Println("Initializing Agent-side API server")
-	httpAPIHandler, err := s.prepareHTTPHandler()
+	kvStore, err := kvstore.NewKVStore(ctx, s.log, s.cfg.KV.Hostname, s.cfg.KV.Port, s.cfg.KV.Password)
 	if err != nil {
 		return err
 	}
+	httpAPIHandler, err := s.prepareHTTPHandler(kvStore)
+	if err != nil {
+		kvStore.Close()
+		return err
+	}
 
 	grpcServer := s.grpcServer.PrepareGRPCService()
 
-	handler := grpcMuxHandlerFunc(grpcServer, httpAPIHandler)
+	tlsConfig := s.tlsConfig
+	var handler http.Handler = grpcMuxHandlerFunc(grpcServer, httpAPIHandler)
+	if s.cfg.Service.BootstrapTokenEnrollment {
+		// agents redeeming a bootstrap token have no client certificate yet, so it is verified
+		// when given, and required by the handler for every other request
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		handler = service.RequireClientCertificate(handler)
+	}
+	handler, err = s.filterSourceNetworks(handler)
+	if err != nil {
+		return err
+	}
 	srv := tlsmiddleware.NewHTTPServerWithTLSContext(handler, s.log, s.cfg.Service.AgentEndpointAddress, s.cfg)
 
 	go func() {
@@ -90,10 +110,11 @@ func (s *AgentServer) Run(ctx context.Context) error {
 
 		srv.SetKeepAlivesEnabled(false)
 		_ = srv.Shutdown(ctxTimeout)
+		kvStore.Close()
 	}()
 
 	s.log.Printf("Listening on %s...", s.listener.Addr().String())
-	srv.TLSConfig = s.tlsConfig
+	srv.TLSConfig = tlsConfig
 	if err := srv.ServeTLS(s.listener, "", ""); err != nil && !errors.Is(err, net.ErrClosed) {
 		return err
 	}
@@ -101,7 +122,7 @@ func (s *AgentServer) Run(ctx context.Context) error {
 	return nil
 }
 
-func (s *AgentServer) prepareHTTPHandler() (*chi.Mux, error) {
+func (s *AgentServer) prepareHTTPHandler(kvStore kvstore.KVStore) (*chi.Mux, error) {
 	swagger, err := api.GetSwagger()
 	if err != nil {
 		return nil, fmt.Errorf("prepareHTTPHandler: failed loading swagger spec: %w", err)
@@ -113,14 +134,24 @@ func (s *AgentServer) prepareHTTPHandler() (*chi.Mux, error) {
 		ErrorHandler: oapiErrorHandler,
 	}
 
+	unknownFields, err := tlsmiddleware.NewUnknownFieldsChecker(s.log, swagger, s.cfg.Service.UnknownFieldsMode == config.UnknownFieldsModeStrict)
+	if err != nil {
+		return nil, fmt.Errorf("prepareHTTPHandler: failed creating unknown fields checker: %w", err)
+	}
+
 	// request size limits should come before logging to prevent DoS attacks from filling logs
 	middlewares := [](func(http.Handler) http.Handler){
 		middleware.RequestSize(int64(s.cfg.Service.HttpMaxRequestSize)),
 		tlsmiddleware.RequestSizeLimiter(s.cfg.Service.HttpMaxUrlLength, s.cfg.Service.HttpMaxNumHeaders),
 		middleware.RequestID,
+		tlsmiddleware.RequestIDResponse,
 		middleware.Logger,
 		middleware.Recoverer,
-		oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapiOpts),
+	}
+
+	if s.cfg.Service.MaintenanceMode {
+		s.log.Warn("Maintenance mode: rejecting agent requests that modify resources")
+		middlewares = append(middlewares, tlsmiddleware.MaintenanceMode(time.Duration(s.cfg.Service.MaintenanceRetryAfter)))
 	}
 
 	if s.metrics != nil {
@@ -130,13 +161,35 @@ func (s *AgentServer) prepareHTTPHandler() (*chi.Mux, error) {
 	router := chi.NewRouter()
 	router.Use(middlewares...)
 
-	server.HandlerFromMux(
-		server.NewStrictHandler(
-			service.NewAgentServiceHandler(s.store, s.ca, s.log, s.cfg.Service.AgentEndpointAddress), nil),
-		router)
+	router.Group(func(r chi.Router) {
+		r.Use(unknownFields.Handler)
+		r.Use(oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapiOpts))
+		server.HandlerFromMux(
+			server.NewStrictHandler(
+				service.NewAgentServiceHandler(s.store, kvStore, s.ca, s.log, s.emitter(), s.cfg.Service.AgentEndpointAddress, s.cfg.Service.BootstrapTokenEnrollment), nil),
+			r)
+	})
 	return router, nil
 }
 
+// filterSourceNetworks restricts the networks that can reach both the HTTP and the gRPC
+// endpoints, if configured.
+func (s *AgentServer) filterSourceNetworks(next http.Handler) (http.Handler, error) {
+	svc := s.cfg.Service
+	if len(svc.AgentAllowedNetworks) == 0 && len(svc.AgentDeniedNetworks) == 0 {
+		return next, nil
+	}
+	var rejected prometheus.Counter
+	if s.metrics != nil {
+		rejected = s.metrics.AgentRejectedRequests
+	}
+	filter, err := tlsmiddleware.NewIPFilter(s.log, svc.AgentAllowedNetworks, svc.AgentDeniedNetworks, svc.TrustedProxies, rejected)
+	if err != nil {
+		return nil, fmt.Errorf("agent endpoint network filter: %w", err)
+	}
+	return filter.Handler(next), nil
+}
+
 // grpcMuxHandlerFunc dispatches requests to the gRPC server or the HTTP handler based on the request headers
 func grpcMuxHandlerFunc(grpcServer *grpc.Server, otherHandler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -147,3 +200,12 @@ func grpcMuxHandlerFunc(grpcServer *grpc.Server, otherHandler http.Handler) http
 		}
 	})
 }
+
+// emitter returns the emitter of the events the configured webhooks are notified of, or nil if no
+// webhook is configured.
+func (s *AgentServer) emitter() *notifications.Emitter {
+	if !s.cfg.NotificationsEnabled() {
+		return nil
+	}
+	return notifications.NewEmitter(s.store.Outbox(), s.log)
+}
@@ -0,0 +1,92 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// healthCheckTimeout bounds the time a dependency has to answer a readiness probe.
+	healthCheckTimeout = 2 * time.Second
+
+	healthStatusOK     = "ok"
+	healthStatusFailed = "failed"
+)
+
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// HealthChecker serves the liveness and readiness probes of the server. The server is live as
+// long as its process serves requests, and ready when all its dependencies are reachable.
+type HealthChecker struct {
+	log     logrus.FieldLogger
+	timeout time.Duration
+	checks  []healthCheck
+}
+
+type healthStatus struct {
+	Status     string            `json:"status"`
+	Components map[string]string `json:"components,omitempty"`
+}
+
+func NewHealthChecker(log logrus.FieldLogger, timeout time.Duration) *HealthChecker {
+	return &HealthChecker{log: log, timeout: timeout}
+}
+
+// Register adds a dependency that must be reachable for the server to be ready.
+func (h *HealthChecker) Register(name string, check func(ctx context.Context) error) {
+	h.checks = append(h.checks, healthCheck{name: name, check: check})
+}
+
+// Livez reports that the process is up, without checking its dependencies, so that the server is
+// not restarted because of a dependency outage.
+func (h *HealthChecker) Livez(w http.ResponseWriter, r *http.Request) {
+	writeHealthStatus(w, http.StatusOK, healthStatus{Status: healthStatusOK})
+}
+
+// Readyz checks all dependencies concurrently, and fails if any of them is not reachable within
+// the timeout. Errors are only logged, as the endpoint does not require authentication.
+func (h *HealthChecker) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	result := healthStatus{Status: healthStatusOK, Components: map[string]string{}}
+	for _, c := range h.checks {
+		wg.Add(1)
+		go func(c healthCheck) {
+			defer wg.Done()
+			err := c.check(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				h.log.WithError(err).Warnf("readiness check of %s failed", c.name)
+				result.Status = healthStatusFailed
+				result.Components[c.name] = healthStatusFailed
+				return
+			}
+			result.Components[c.name] = healthStatusOK
+		}(c)
+	}
+	wg.Wait()
+
+	statusCode := http.StatusOK
+	if result.Status != healthStatusOK {
+		statusCode = http.StatusServiceUnavailable
+	}
+	writeHealthStatus(w, statusCode, result)
+}
+
+func writeHealthStatus(w http.ResponseWriter, statusCode int, status healthStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(status)
+}
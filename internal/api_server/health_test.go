@@ -0,0 +1,58 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func probe(t *testing.T, handler http.HandlerFunc) (int, healthStatus) {
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	var status healthStatus
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&status))
+	return recorder.Code, status
+}
+
+func TestReadyz(t *testing.T) {
+	require := require.New(t)
+	var kvErr error
+	health := NewHealthChecker(log.InitLogs(), time.Second)
+	health.Register("database", func(ctx context.Context) error { return nil })
+	health.Register("kv", func(ctx context.Context) error { return kvErr })
+	health.Register("queue", func(ctx context.Context) error { return nil })
+
+	code, status := probe(t, health.Readyz)
+	require.Equal(http.StatusOK, code)
+	require.Equal(healthStatus{Status: "ok", Components: map[string]string{"database": "ok", "kv": "ok", "queue": "ok"}}, status)
+
+	kvErr = errors.New("dial tcp 10.0.0.1:6379: connection refused")
+	code, status = probe(t, health.Readyz)
+	require.Equal(http.StatusServiceUnavailable, code)
+	require.Equal(healthStatus{Status: "failed", Components: map[string]string{"database": "ok", "kv": "failed", "queue": "ok"}}, status)
+
+	// liveness does not depend on the dependencies
+	code, status = probe(t, health.Livez)
+	require.Equal(http.StatusOK, code)
+	require.Equal("ok", status.Status)
+}
+
+func TestReadyzTimeout(t *testing.T) {
+	require := require.New(t)
+	health := NewHealthChecker(log.InitLogs(), 50*time.Millisecond)
+	health.Register("database", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	code, status := probe(t, health.Readyz)
+	require.Equal(http.StatusServiceUnavailable, code)
+	require.Equal("failed", status.Components["database"])
+}
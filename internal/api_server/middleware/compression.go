@@ -0,0 +1,250 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	EncodingGzip = "gzip"
+	EncodingZstd = "zstd"
+)
+
+// ValidateCompression checks that the algorithms are supported and listed once.
+func ValidateCompression(algorithms []string) error {
+	seen := map[string]bool{}
+	for _, algorithm := range algorithms {
+		if _, ok := newEncoderPools[algorithm]; !ok {
+			return fmt.Errorf("unsupported compression algorithm %q: must be %q or %q", algorithm, EncodingZstd, EncodingGzip)
+		}
+		if seen[algorithm] {
+			return fmt.Errorf("compression algorithm %q listed more than once", algorithm)
+		}
+		seen[algorithm] = true
+	}
+	return nil
+}
+
+// compressedContentTypes are the prefixes of the content types that are compressed already.
+var compressedContentTypes = []string{
+	"application/gzip",
+	"application/x-gzip",
+	"application/zstd",
+	"application/zip",
+	"image/",
+	"video/",
+	"audio/",
+}
+
+// encoder is a compressing writer that can be reused for another response.
+type encoder interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+var newEncoderPools = map[string]func() *sync.Pool{
+	EncodingGzip: func() *sync.Pool {
+		return &sync.Pool{New: func() any { return gzip.NewWriter(io.Discard) }}
+	},
+	EncodingZstd: func() *sync.Pool {
+		return &sync.Pool{New: func() any {
+			// the zero options are valid, so the error is always nil
+			enc, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderConcurrency(1))
+			return enc
+		}}
+	},
+}
+
+// Compression compresses the responses of at least minSize bytes with the first of the
+// algorithms, in order of preference, that the client accepts in its Accept-Encoding header.
+// Responses that already have a Content-Encoding or a compressed content type are sent as is.
+// Compression is disabled when no algorithm is given.
+func Compression(algorithms []string, minSize int) (func(http.Handler) http.Handler, error) {
+	if err := ValidateCompression(algorithms); err != nil {
+		return nil, err
+	}
+	pools := map[string]*sync.Pool{}
+	for _, algorithm := range algorithms {
+		pools[algorithm] = newEncoderPools[algorithm]()
+	}
+	return func(next http.Handler) http.Handler {
+		if len(algorithms) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), algorithms)
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				pool:           pools[encoding],
+				minSize:        minSize,
+				status:         http.StatusOK,
+			}
+			defer cw.close()
+			next.ServeHTTP(cw, r)
+		})
+	}, nil
+}
+
+// negotiateEncoding returns the first of the algorithms the client accepts, or an empty string.
+func negotiateEncoding(acceptEncoding string, algorithms []string) string {
+	accepted := map[string]bool{}
+	wildcard := false
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		acceptable := true
+		for _, param := range strings.Split(params, ";") {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.EqualFold(strings.TrimSpace(name), "q") {
+				q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				acceptable = err == nil && q > 0
+			}
+		}
+		if coding == "*" {
+			wildcard = acceptable
+			continue
+		}
+		accepted[coding] = acceptable
+	}
+	for _, algorithm := range algorithms {
+		if acceptable, listed := accepted[algorithm]; (listed && acceptable) || (!listed && wildcard) {
+			return algorithm
+		}
+	}
+	return ""
+}
+
+// compressWriter buffers the beginning of the response until it knows whether the response is
+// large enough to be compressed.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	pool     *sync.Pool
+	minSize  int
+
+	status      int
+	wroteHeader bool
+	decided     bool
+	buf         bytes.Buffer
+	encoder     encoder
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = status
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	cw.wroteHeader = true
+	if cw.decided {
+		if cw.encoder != nil {
+			return cw.encoder.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+	cw.buf.Write(p)
+	if cw.buf.Len() >= cw.minSize {
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// decide sends the headers, compressing the response if it is large enough and not compressed
+// already, and then the buffered beginning of the response.
+func (cw *compressWriter) decide(largeEnough bool) error {
+	cw.decided = true
+	header := cw.Header()
+	if largeEnough && cw.compressible() {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		cw.encoder = cw.pool.Get().(encoder)
+		cw.encoder.Reset(cw.ResponseWriter)
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+	if cw.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if cw.encoder != nil {
+		_, err = cw.encoder.Write(cw.buf.Bytes())
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buf.Bytes())
+	}
+	cw.buf.Reset()
+	return err
+}
+
+func (cw *compressWriter) compressible() bool {
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	if cw.status < http.StatusOK || cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, compressed := range compressedContentTypes {
+		if strings.HasPrefix(contentType, compressed) {
+			return false
+		}
+	}
+	return true
+}
+
+// Flush sends what was written so far, deciding whether to compress on what was buffered.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			return
+		}
+		_ = cw.decide(cw.buf.Len() >= cw.minSize)
+	}
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			// the handler wrote nothing, leave the default response to the server
+			return
+		}
+		_ = cw.decide(false)
+	}
+	if cw.encoder != nil {
+		_ = cw.encoder.Close()
+		cw.encoder.Reset(io.Discard)
+		cw.pool.Put(cw.encoder)
+		cw.encoder = nil
+	}
+}
+
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
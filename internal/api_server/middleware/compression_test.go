@@ -0,0 +1,127 @@
+package middleware_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Response compression", func() {
+	largeBody := `{"items":[` + strings.Repeat(`{"metadata":{"name":"device"}},`, 100) + `{}]}`
+
+	request := func(acceptEncoding string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+		compression, err := middleware.Compression([]string{middleware.EncodingZstd, middleware.EncodingGzip}, 1024)
+		Expect(err).ToNot(HaveOccurred())
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/devices", nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		compression(handler).ServeHTTP(rec, req)
+		return rec
+	}
+
+	respond := func(contentType string, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, body)
+		}
+	}
+
+	decompress := func(rec *httptest.ResponseRecorder) string {
+		var reader io.Reader
+		switch rec.Header().Get("Content-Encoding") {
+		case middleware.EncodingGzip:
+			gz, err := gzip.NewReader(rec.Body)
+			Expect(err).ToNot(HaveOccurred())
+			reader = gz
+		case middleware.EncodingZstd:
+			zr, err := zstd.NewReader(rec.Body)
+			Expect(err).ToNot(HaveOccurred())
+			defer zr.Close()
+			reader = zr
+		default:
+			reader = rec.Body
+		}
+		body, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		return string(body)
+	}
+
+	It("compresses with the preferred algorithm the client accepts", func() {
+		rec := request("gzip, deflate, br, zstd", respond("application/json", largeBody))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Encoding")).To(Equal(middleware.EncodingZstd))
+		Expect(rec.Header().Get("Vary")).To(Equal("Accept-Encoding"))
+		Expect(rec.Body.Len()).To(BeNumerically("<", len(largeBody)))
+		Expect(decompress(rec)).To(Equal(largeBody))
+
+		rec = request("gzip;q=0.5, zstd;q=0", respond("application/json", largeBody))
+		Expect(rec.Header().Get("Content-Encoding")).To(Equal(middleware.EncodingGzip))
+		Expect(decompress(rec)).To(Equal(largeBody))
+
+		rec = request("*", respond("application/json", largeBody))
+		Expect(rec.Header().Get("Content-Encoding")).To(Equal(middleware.EncodingZstd))
+	})
+
+	It("compresses responses written in small chunks", func() {
+		rec := request("gzip", func(w http.ResponseWriter, r *http.Request) {
+			for _, chunk := range strings.SplitAfter(largeBody, ",") {
+				_, _ = io.WriteString(w, chunk)
+			}
+		})
+		Expect(rec.Header().Get("Content-Encoding")).To(Equal(middleware.EncodingGzip))
+		Expect(decompress(rec)).To(Equal(largeBody))
+	})
+
+	It("does not compress when the client does not accept a supported algorithm", func() {
+		for _, acceptEncoding := range []string{"", "identity", "br, deflate", "gzip;q=0, zstd;q=0"} {
+			rec := request(acceptEncoding, respond("application/json", largeBody))
+			Expect(rec.Header().Get("Content-Encoding")).To(BeEmpty(), acceptEncoding)
+			Expect(rec.Body.String()).To(Equal(largeBody), acceptEncoding)
+		}
+	})
+
+	It("does not compress small responses", func() {
+		rec := request("gzip", respond("application/json", `{"name":"device"}`))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Encoding")).To(BeEmpty())
+		Expect(rec.Body.String()).To(Equal(`{"name":"device"}`))
+	})
+
+	It("does not compress responses that are compressed already", func() {
+		rec := request("gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "br")
+			_, _ = io.WriteString(w, largeBody)
+		})
+		Expect(rec.Header().Get("Content-Encoding")).To(Equal("br"))
+		Expect(rec.Body.String()).To(Equal(largeBody))
+
+		rec = request("gzip", respond("application/gzip", largeBody))
+		Expect(rec.Header().Get("Content-Encoding")).To(BeEmpty())
+		Expect(rec.Body.String()).To(Equal(largeBody))
+	})
+
+	It("keeps the status of the response", func() {
+		rec := request("gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+		Expect(rec.Header().Get("Content-Encoding")).To(BeEmpty())
+	})
+
+	It("rejects unsupported algorithms", func() {
+		_, err := middleware.Compression([]string{"br"}, 1024)
+		Expect(err).To(MatchError(ContainSubstring("unsupported compression algorithm")))
+		_, err = middleware.Compression([]string{"gzip", "gzip"}, 1024)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// IPFilter rejects requests whose source address is not allowed to reach the server. A source
+// matching a denied network is always rejected; when allowed networks are configured, a source
+// must also match one of them.
+type IPFilter struct {
+	log            logrus.FieldLogger
+	allowed        []*net.IPNet
+	denied         []*net.IPNet
+	trustedProxies []*net.IPNet
+	rejected       prometheus.Counter
+}
+
+// NewIPFilter returns a filter for the given allowed and denied CIDRs. The X-Forwarded-For
+// header is only honored for requests coming from one of the trustedProxies CIDRs. rejected is
+// incremented for every rejected request and may be nil.
+func NewIPFilter(log logrus.FieldLogger, allowed []string, denied []string, trustedProxies []string, rejected prometheus.Counter) (*IPFilter, error) {
+	f := &IPFilter{log: log, rejected: rejected}
+	var err error
+	if f.allowed, err = ParseCIDRs(allowed); err != nil {
+		return nil, fmt.Errorf("allowed networks: %w", err)
+	}
+	if f.denied, err = ParseCIDRs(denied); err != nil {
+		return nil, fmt.Errorf("denied networks: %w", err)
+	}
+	if f.trustedProxies, err = ParseCIDRs(trustedProxies); err != nil {
+		return nil, fmt.Errorf("trusted proxies: %w", err)
+	}
+	return f, nil
+}
+
+// ParseCIDRs parses a list of networks in CIDR notation.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+func (f *IPFilter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := f.ClientIP(r)
+		if !f.Allowed(ip) {
+			f.log.Warnf("rejected request from %s to %s", ip, r.URL.Path)
+			if f.rejected != nil {
+				f.rejected.Inc()
+			}
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Allowed reports whether requests from ip may reach the server. An unknown source is only
+// allowed when no allowed networks are configured.
+func (f *IPFilter) Allowed(ip net.IP) bool {
+	if ip == nil {
+		return len(f.allowed) == 0
+	}
+	if containsIP(f.denied, ip) {
+		return false
+	}
+	return len(f.allowed) == 0 || containsIP(f.allowed, ip)
+}
+
+// ClientIP returns the source address of the request. When the peer is a trusted proxy, the
+// X-Forwarded-For header is walked from the right, skipping the addresses of trusted proxies,
+// so that a client cannot spoof its address by prepending entries to the header.
+func (f *IPFilter) ClientIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !containsIP(f.trustedProxies, ip) {
+		return ip
+	}
+
+	forwarded := []string{}
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if hop == nil {
+			// the source of a request forwarded with a malformed entry is unknown
+			return nil
+		}
+		ip = hop
+		if !containsIP(f.trustedProxies, ip) {
+			break
+		}
+	}
+	return ip
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/pkg/log"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("IP filter", func() {
+	var (
+		rejected prometheus.Counter
+		handler  http.Handler
+	)
+
+	BeforeEach(func() {
+		rejected = prometheus.NewCounter(prometheus.CounterOpts{Name: "rejected"})
+		filter, err := middleware.NewIPFilter(log.InitLogs(),
+			[]string{"10.0.0.0/8", "fd00::/8"},
+			[]string{"10.1.0.0/16"},
+			[]string{"192.168.0.0/24"},
+			rejected)
+		Expect(err).ToNot(HaveOccurred())
+		handler = filter.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	})
+
+	request := func(remoteAddr string, forwardedFor ...string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/enrollmentrequests", nil)
+		req.RemoteAddr = remoteAddr
+		for _, header := range forwardedFor {
+			req.Header.Add("X-Forwarded-For", header)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	DescribeTable("filters on the source address",
+		func(remoteAddr string, forwardedFor []string, expected int) {
+			Expect(request(remoteAddr, forwardedFor...)).To(Equal(expected))
+		},
+		Entry("allowed network", "10.2.3.4:5000", nil, http.StatusOK),
+		Entry("allowed IPv6 network", "[fd00::1]:5000", nil, http.StatusOK),
+		Entry("outside the allowed networks", "172.16.0.1:5000", nil, http.StatusForbidden),
+		Entry("denied network within an allowed one", "10.1.2.3:5000", nil, http.StatusForbidden),
+		Entry("forwarded by a trusted proxy", "192.168.0.1:5000", []string{"10.2.3.4"}, http.StatusOK),
+		Entry("forwarded through several trusted proxies", "192.168.0.1:5000", []string{"10.2.3.4, 192.168.0.2"}, http.StatusOK),
+		Entry("denied client forwarded by a trusted proxy", "192.168.0.1:5000", []string{"172.16.0.1"}, http.StatusForbidden),
+		Entry("spoofed entry prepended by the client", "192.168.0.1:5000", []string{"10.2.3.4, 172.16.0.1"}, http.StatusForbidden),
+		Entry("forwarded header from an untrusted peer", "172.16.0.1:5000", []string{"10.2.3.4"}, http.StatusForbidden),
+		Entry("malformed forwarded header", "192.168.0.1:5000", []string{"not-an-ip"}, http.StatusForbidden),
+	)
+
+	It("counts rejected requests", func() {
+		Expect(request("10.2.3.4:5000")).To(Equal(http.StatusOK))
+		Expect(request("172.16.0.1:5000")).To(Equal(http.StatusForbidden))
+		Expect(request("10.1.0.1:5000")).To(Equal(http.StatusForbidden))
+		Expect(testutil.ToFloat64(rejected)).To(Equal(2.0))
+	})
+
+	It("rejects invalid networks", func() {
+		_, err := middleware.NewIPFilter(log.InitLogs(), []string{"10.0.0.1"}, nil, nil, nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+)
+
+// MaintenanceMode rejects the requests that modify resources with 503 Service Unavailable and a
+// Retry-After header, so that clients retry once the maintenance is over. Reads are still served.
+func MaintenanceMode(retryAfter time.Duration) func(http.Handler) http.Handler {
+	retryAfterSeconds := strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !IsMutatingMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", retryAfterSeconds)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(api.Error{Message: "the service is in maintenance mode, try again later"})
+		})
+	}
+}
+
+// IsMutatingMethod reports whether requests with the given method may modify resources.
+func IsMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,37 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Maintenance mode", func() {
+	request := func(method string, path string) *httptest.ResponseRecorder {
+		handler := middleware.MaintenanceMode(90 * time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader("{}")))
+		return rec
+	}
+
+	It("serves reads", func() {
+		Expect(request(http.MethodGet, "/api/v1/devices").Code).To(Equal(http.StatusOK))
+		Expect(request(http.MethodHead, "/api/v1/devices/device-1").Code).To(Equal(http.StatusOK))
+	})
+
+	It("rejects writes with Retry-After", func() {
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			rec := request(method, "/api/v1/devices/device-1")
+			Expect(rec.Code).To(Equal(http.StatusServiceUnavailable), method)
+			Expect(rec.Header().Get("Retry-After")).To(Equal("90"))
+			Expect(rec.Body.String()).To(ContainSubstring("maintenance mode"))
+		}
+	})
+})
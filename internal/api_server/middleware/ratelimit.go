@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/auth/common"
+	"golang.org/x/time/rate"
+	"k8s.io/utils/clock"
+)
+
+// RateLimitKey returns the key whose budget a request is charged to.
+type RateLimitKey func(r *http.Request) string
+
+// IPRateLimitKey charges requests to their source address.
+func IPRateLimitKey(clientIP func(r *http.Request) net.IP) RateLimitKey {
+	return func(r *http.Request) string {
+		return "ip:" + clientIP(r).String()
+	}
+}
+
+// IdentityRateLimitKey charges requests to the authenticated principal, so that clients sharing
+// a source address have their own budget. Unauthenticated requests are charged to their source
+// address. It must be installed after the auth middleware.
+func IdentityRateLimitKey(clientIP func(r *http.Request) net.IP) RateLimitKey {
+	ipKey := IPRateLimitKey(clientIP)
+	return func(r *http.Request) string {
+		identity, ok := r.Context().Value(common.IdentityCtxKey).(*common.Identity)
+		if !ok || identity == nil {
+			return ipKey(r)
+		}
+		if identity.UID != "" {
+			return "uid:" + identity.UID
+		}
+		if identity.Username != "" {
+			return "user:" + identity.Username
+		}
+		return ipKey(r)
+	}
+}
+
+// RateLimiter rejects the requests exceeding the budget of their key with 429 Too Many Requests
+// and a Retry-After header. Each key may send a burst of requests, refilled evenly over the window.
+type RateLimiter struct {
+	limit rate.Limit
+	burst int
+	key   RateLimitKey
+	clock clock.Clock
+
+	mu        sync.Mutex
+	limiters  map[string]*keyLimiter
+	lastSweep time.Time
+	window    time.Duration
+}
+
+type keyLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewRateLimiter returns a limiter allowing each key the given number of requests per window.
+func NewRateLimiter(requests int, window time.Duration, key RateLimitKey, clock clock.Clock) *RateLimiter {
+	return &RateLimiter{
+		limit:     rate.Limit(float64(requests) / window.Seconds()),
+		burst:     requests,
+		key:       key,
+		clock:     clock,
+		limiters:  map[string]*keyLimiter{},
+		lastSweep: clock.Now(),
+		window:    window,
+	}
+}
+
+func (l *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if delay := l.reserve(l.key(r)); delay > 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			w.WriteHeader(http.StatusTooManyRequests)
+			_ = json.NewEncoder(w).Encode(api.Error{Message: "too many requests, try again later"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// reserve charges a request to the key, and returns how long the key must wait before its
+// next request is allowed if its budget is exhausted.
+func (l *RateLimiter) reserve(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	l.sweep(now)
+
+	entry, ok := l.limiters[key]
+	if !ok {
+		entry = &keyLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[key] = entry
+	}
+	entry.lastSeen = now
+
+	reservation := entry.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay
+	}
+	return 0
+}
+
+// sweep forgets the keys idle for a whole window, whose budget is full again anyway.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	for key, entry := range l.limiters {
+		if now.Sub(entry.lastSeen) >= l.window {
+			delete(l.limiters, key)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/auth/common"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+var _ = Describe("Rate limiter", func() {
+	var clock *clocktesting.FakeClock
+
+	clientIP := func(r *http.Request) net.IP {
+		host, _, _ := net.SplitHostPort(r.RemoteAddr)
+		return net.ParseIP(host)
+	}
+
+	newHandler := func(key middleware.RateLimitKey) http.Handler {
+		limiter := middleware.NewRateLimiter(2, time.Minute, key, clock)
+		return limiter.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+
+	request := func(handler http.Handler, remoteAddr string, identity *common.Identity) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/devices", nil)
+		req.RemoteAddr = remoteAddr
+		if identity != nil {
+			req = req.WithContext(context.WithValue(req.Context(), common.IdentityCtxKey, identity))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	BeforeEach(func() {
+		clock = clocktesting.NewFakeClock(time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC))
+	})
+
+	It("gives identities behind the same address independent budgets", func() {
+		handler := newHandler(middleware.IdentityRateLimitKey(clientIP))
+		tenantA := &common.Identity{Username: "tenant-a", UID: "uid-a"}
+		tenantB := &common.Identity{Username: "tenant-b", UID: "uid-b"}
+
+		Expect(request(handler, "10.0.0.1:1234", tenantA).Code).To(Equal(http.StatusOK))
+		Expect(request(handler, "10.0.0.1:1234", tenantA).Code).To(Equal(http.StatusOK))
+		rec := request(handler, "10.0.0.1:1234", tenantA)
+		Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rec.Header().Get("Retry-After")).To(Equal("30"))
+		Expect(rec.Body.String()).To(ContainSubstring("too many requests"))
+
+		// another tenant sharing the address is not starved
+		Expect(request(handler, "10.0.0.1:1234", tenantB).Code).To(Equal(http.StatusOK))
+		Expect(request(handler, "10.0.0.1:1234", tenantB).Code).To(Equal(http.StatusOK))
+		Expect(request(handler, "10.0.0.1:1234", tenantB).Code).To(Equal(http.StatusTooManyRequests))
+
+		// the budget refills over the window
+		clock.Step(30 * time.Second)
+		Expect(request(handler, "10.0.0.1:1234", tenantA).Code).To(Equal(http.StatusOK))
+		Expect(request(handler, "10.0.0.1:1234", tenantA).Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("charges unauthenticated requests to their source address", func() {
+		handler := newHandler(middleware.IdentityRateLimitKey(clientIP))
+		Expect(request(handler, "10.0.0.1:1234", nil).Code).To(Equal(http.StatusOK))
+		Expect(request(handler, "10.0.0.1:5678", nil).Code).To(Equal(http.StatusOK))
+		Expect(request(handler, "10.0.0.1:1234", nil).Code).To(Equal(http.StatusTooManyRequests))
+		Expect(request(handler, "10.0.0.2:1234", nil).Code).To(Equal(http.StatusOK))
+	})
+
+	It("limits by source address regardless of identity", func() {
+		handler := newHandler(middleware.IPRateLimitKey(clientIP))
+		Expect(request(handler, "10.0.0.1:1234", &common.Identity{UID: "uid-a"}).Code).To(Equal(http.StatusOK))
+		Expect(request(handler, "10.0.0.1:1234", &common.Identity{UID: "uid-b"}).Code).To(Equal(http.StatusOK))
+		Expect(request(handler, "10.0.0.1:1234", &common.Identity{UID: "uid-c"}).Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("combines identity and source address limits", func() {
+		ipLimiter := middleware.NewRateLimiter(3, time.Minute, middleware.IPRateLimitKey(clientIP), clock)
+		handler := ipLimiter.Handler(newHandler(middleware.IdentityRateLimitKey(clientIP)))
+		Expect(request(handler, "10.0.0.1:1234", &common.Identity{UID: "uid-a"}).Code).To(Equal(http.StatusOK))
+		Expect(request(handler, "10.0.0.1:1234", &common.Identity{UID: "uid-a"}).Code).To(Equal(http.StatusOK))
+		Expect(request(handler, "10.0.0.1:1234", &common.Identity{UID: "uid-b"}).Code).To(Equal(http.StatusOK))
+		// uid-b has budget left, but the address does not
+		Expect(request(handler, "10.0.0.1:1234", &common.Identity{UID: "uid-b"}).Code).To(Equal(http.StatusTooManyRequests))
+	})
+})
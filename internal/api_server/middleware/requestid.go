@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestIDResponse returns the ID that middleware.RequestID assigned to the request in the
+// X-Request-ID response header, so that clients can correlate their requests with the logs of the
+// service and of the agents that reconcile the resulting changes.
+func RequestIDResponse(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestID := middleware.GetReqID(r.Context()); requestID != "" {
+			w.Header().Set(middleware.RequestIDHeader, requestID)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,41 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Request ID response", func() {
+	var handlerRequestID string
+
+	request := func(header string) *httptest.ResponseRecorder {
+		handler := chimiddleware.RequestID(middleware.RequestIDResponse(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handlerRequestID = chimiddleware.GetReqID(r.Context())
+			w.WriteHeader(http.StatusOK)
+		})))
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/devices", nil)
+		if header != "" {
+			req.Header.Set(chimiddleware.RequestIDHeader, header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("returns the generated request ID", func() {
+		rec := request("")
+		Expect(handlerRequestID).ToNot(BeEmpty())
+		Expect(rec.Header().Get(chimiddleware.RequestIDHeader)).To(Equal(handlerRequestID))
+	})
+
+	It("returns the request ID sent by the client", func() {
+		rec := request("client-request-1")
+		Expect(handlerRequestID).To(Equal("client-request-1"))
+		Expect(rec.Header().Get(chimiddleware.RequestIDHeader)).To(Equal("client-request-1"))
+	})
+})
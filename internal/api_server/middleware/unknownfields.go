@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/sirupsen/logrus"
+)
+
+// UnknownFieldsChecker detects the fields of JSON request bodies that are not part of the API
+// schema, which are otherwise silently dropped when the body is decoded. This typically happens
+// when a client is newer than the server. In strict mode such requests are rejected; otherwise
+// they are served with a Warning header listing the fields that were dropped.
+type UnknownFieldsChecker struct {
+	log    logrus.FieldLogger
+	router routers.Router
+	strict bool
+}
+
+func NewUnknownFieldsChecker(log logrus.FieldLogger, swagger *openapi3.T, strict bool) (*UnknownFieldsChecker, error) {
+	router, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		return nil, err
+	}
+	return &UnknownFieldsChecker{log: log, router: router, strict: strict}, nil
+}
+
+func (c *UnknownFieldsChecker) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		unknown, err := c.UnknownFields(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(unknown) > 0 {
+			if c.strict {
+				http.Error(w, fmt.Sprintf("unknown fields: %s", strings.Join(unknown, ", ")), http.StatusBadRequest)
+				return
+			}
+			c.log.Debugf("dropping unknown fields of request to %s: %s", r.URL.Path, strings.Join(unknown, ", "))
+			for _, field := range unknown {
+				w.Header().Add("Warning", fmt.Sprintf(`299 - "unknown field \"%s\""`, field))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// UnknownFields returns the paths of the fields of the JSON body of the request that are not
+// defined by the schema of the operation. The body of the request is left unconsumed.
+func (c *UnknownFieldsChecker) UnknownFields(r *http.Request) ([]string, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return nil, nil
+	}
+	route, _, err := c.router.FindRoute(r)
+	if err != nil || route.Operation == nil || route.Operation.RequestBody == nil || route.Operation.RequestBody.Value == nil {
+		return nil, nil
+	}
+	content := route.Operation.RequestBody.Value.Content.Get(mediaType)
+	if content == nil || content.Schema == nil || content.Schema.Value == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading request body: %w", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		// malformed bodies are reported by the request validation
+		return nil, nil
+	}
+	return unknownFields([]*openapi3.Schema{content.Schema.Value}, value, ""), nil
+}
+
+// unknownFields returns the paths of the fields of value that none of the schemas define.
+func unknownFields(schemas []*openapi3.Schema, value any, path string) []string {
+	var flattened []*openapi3.Schema
+	for _, schema := range schemas {
+		flattened = appendComposedSchemas(flattened, schema)
+	}
+
+	var unknown []string
+	switch v := value.(type) {
+	case map[string]any:
+		for _, schema := range flattened {
+			if allowsAnyProperty(schema) {
+				return nil
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			var fieldSchemas []*openapi3.Schema
+			for _, schema := range flattened {
+				if property, exists := schema.Properties[key]; exists && property.Value != nil {
+					fieldSchemas = append(fieldSchemas, property.Value)
+				} else if additional := schema.AdditionalProperties.Schema; additional != nil && additional.Value != nil {
+					fieldSchemas = append(fieldSchemas, additional.Value)
+				}
+			}
+			if len(fieldSchemas) == 0 {
+				unknown = append(unknown, fieldPath)
+				continue
+			}
+			unknown = append(unknown, unknownFields(fieldSchemas, v[key], fieldPath)...)
+		}
+	case []any:
+		var itemSchemas []*openapi3.Schema
+		for _, schema := range flattened {
+			if schema.Items != nil && schema.Items.Value != nil {
+				itemSchemas = append(itemSchemas, schema.Items.Value)
+			}
+		}
+		if len(itemSchemas) == 0 {
+			return nil
+		}
+		for i, item := range v {
+			unknown = append(unknown, unknownFields(itemSchemas, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return unknown
+}
+
+// appendComposedSchemas appends the schema and the schemas it is composed of. A field is known
+// when any of the alternatives of a oneOf or anyOf defines it.
+func appendComposedSchemas(schemas []*openapi3.Schema, schema *openapi3.Schema) []*openapi3.Schema {
+	schemas = append(schemas, schema)
+	for _, refs := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, ref := range refs {
+			if ref.Value != nil {
+				schemas = appendComposedSchemas(schemas, ref.Value)
+			}
+		}
+	}
+	return schemas
+}
+
+// allowsAnyProperty reports whether the schema accepts arbitrary properties, either explicitly
+// or by being an object schema that does not describe its properties at all.
+func allowsAnyProperty(schema *openapi3.Schema) bool {
+	if schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has {
+		return true
+	}
+	return schema.Type.Permits(openapi3.TypeObject) && len(schema.Properties) == 0 && schema.AdditionalProperties.Schema == nil &&
+		len(schema.AllOf) == 0 && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 && schema.Items == nil
+}
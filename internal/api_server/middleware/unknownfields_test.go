@@ -0,0 +1,104 @@
+package middleware_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/pkg/log"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("Unknown fields", func() {
+	const device = `{
+		"apiVersion": "v1alpha1",
+		"kind": "Device",
+		"metadata": {"name": "device-1", "labels": {"region": "eu"}, "finalizers": ["cleanup"]},
+		"spec": {
+			"os": {"image": "quay.io/flightctl/rhel:9.5", "digest": "sha256:abc"},
+			"applications": [{"name": "app", "image": "quay.io/flightctl/app:latest", "envVars": {"FOO": "bar"}, "restartPolicy": "Always"}]
+		}
+	}`
+
+	request := func(strict bool, method string, path string, body string) *httptest.ResponseRecorder {
+		swagger, err := api.GetSwagger()
+		Expect(err).ToNot(HaveOccurred())
+		swagger.Servers = nil
+		checker, err := middleware.NewUnknownFieldsChecker(log.InitLogs(), swagger, strict)
+		Expect(err).ToNot(HaveOccurred())
+
+		var received []byte
+		handler := checker.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			buf := new(bytes.Buffer)
+			_, _ = buf.ReadFrom(r.Body)
+			received = buf.Bytes()
+			w.WriteHeader(http.StatusOK)
+		}))
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		if recorder.Code == http.StatusOK {
+			// the body is still available to the handler
+			Expect(string(received)).To(Equal(body))
+		}
+		return recorder
+	}
+
+	It("rejects unknown fields in strict mode", func() {
+		resp := request(true, http.MethodPost, "/api/v1/devices", device)
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		Expect(resp.Body.String()).To(ContainSubstring("unknown fields: metadata.finalizers, spec.applications[0].restartPolicy, spec.os.digest"))
+	})
+
+	It("drops unknown fields with a warning in lenient mode", func() {
+		resp := request(false, http.MethodPut, "/api/v1/devices/device-1", device)
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Header().Values("Warning")).To(Equal([]string{
+			`299 - "unknown field \"metadata.finalizers\""`,
+			`299 - "unknown field \"spec.applications[0].restartPolicy\""`,
+			`299 - "unknown field \"spec.os.digest\""`,
+		}))
+	})
+
+	It("accepts the example resources in strict mode", func() {
+		paths := map[string]string{
+			api.CertificateSigningRequestKind: "/api/v1/certificatesigningrequests",
+			api.DeviceKind:                    "/api/v1/devices",
+			api.EnrollmentRequestKind:         "/api/v1/enrollmentrequests",
+			api.FleetKind:                     "/api/v1/fleets",
+			api.RepositoryKind:                "/api/v1/repositories",
+			api.ResourceSyncKind:              "/api/v1/resourcesyncs",
+		}
+		files, err := filepath.Glob(filepath.Join("..", "..", "..", "examples", "*.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).ToNot(BeEmpty())
+		for _, file := range files {
+			contents, err := os.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+			body, err := yaml.YAMLToJSON(contents)
+			Expect(err).ToNot(HaveOccurred())
+			var resource struct {
+				Kind string `json:"kind"`
+			}
+			Expect(yaml.Unmarshal(contents, &resource)).To(Succeed())
+			Expect(paths).To(HaveKey(resource.Kind), file)
+
+			resp := request(true, http.MethodPost, paths[resource.Kind], string(body))
+			Expect(resp.Code).To(Equal(http.StatusOK), "%s: %s", file, resp.Body.String())
+			Expect(resp.Header().Values("Warning")).To(BeEmpty(), file)
+		}
+	})
+
+	It("ignores requests that are not JSON", func() {
+		resp := request(true, http.MethodGet, "/api/v1/devices", "")
+		Expect(resp.Code).To(Equal(http.StatusOK))
+	})
+})
@@ -0,0 +1,111 @@
+package apiserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/version"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	openAPIPathPrefix = "/openapi"
+	// serverVersionExtension is the extension of the info object of the served spec holding the
+	// version of the server serving it.
+	serverVersionExtension = "x-flightctl-server-version"
+)
+
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Flight Control API {{ .Version }}</title>
+  <link rel="stylesheet" href="{{ .Dist }}/swagger-ui.css"{{ with .CSSIntegrity }} integrity="{{ . }}" crossorigin="anonymous"{{ end }}>
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{ .Dist }}/swagger-ui-bundle.js"{{ with .BundleIntegrity }} integrity="{{ . }}" crossorigin="anonymous"{{ end }}></script>
+  <script>
+    window.onload = () => { window.ui = SwaggerUIBundle({ url: "{{ .SpecPath }}", dom_id: "#swagger-ui" }); };
+  </script>
+</body>
+</html>
+`))
+
+// SwaggerUIAssets is where the browser loads the Swagger UI assets from, and the Subresource
+// Integrity hashes it checks them against, if any.
+type SwaggerUIAssets struct {
+	Dist            string
+	CSSIntegrity    string
+	BundleIntegrity string
+}
+
+// OpenAPIHandler serves the OpenAPI spec the server was built with, and optionally a Swagger UI
+// browsing it.
+type OpenAPIHandler struct {
+	version   string
+	spec      []byte
+	swaggerUI []byte
+}
+
+// NewOpenAPIHandler returns the handler of the OpenAPI spec, serving a Swagger UI loading the given
+// assets unless swaggerUI is nil.
+func NewOpenAPIHandler(swaggerUI *SwaggerUIAssets) (*OpenAPIHandler, error) {
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed loading swagger spec: %w", err)
+	}
+	if swagger.Info.Extensions == nil {
+		swagger.Info.Extensions = map[string]any{}
+	}
+	swagger.Info.Extensions[serverVersionExtension] = version.Get().String()
+	spec, err := json.Marshal(swagger)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshalling swagger spec: %w", err)
+	}
+
+	h := &OpenAPIHandler{version: swagger.Info.Version, spec: spec}
+	if swaggerUI != nil {
+		var page bytes.Buffer
+		err := swaggerUITemplate.Execute(&page, map[string]string{
+			"Version":         h.version,
+			"Dist":            swaggerUI.Dist,
+			"CSSIntegrity":    swaggerUI.CSSIntegrity,
+			"BundleIntegrity": swaggerUI.BundleIntegrity,
+			"SpecPath":        h.SpecPath(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed rendering swagger UI: %w", err)
+		}
+		h.swaggerUI = page.Bytes()
+	}
+	return h, nil
+}
+
+// SpecPath returns the path the spec is served at, which contains the API version.
+func (h *OpenAPIHandler) SpecPath() string {
+	return fmt.Sprintf("%s/%s.json", openAPIPathPrefix, h.version)
+}
+
+func (h *OpenAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Get(h.SpecPath(), h.serveSpec)
+	if h.swaggerUI != nil {
+		r.Get(openAPIPathPrefix+"/ui", h.serveSwaggerUI)
+	}
+}
+
+func (h *OpenAPIHandler) serveSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(h.spec)
+}
+
+func (h *OpenAPIHandler) serveSwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(h.swaggerUI)
+}
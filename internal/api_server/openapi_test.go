@@ -0,0 +1,79 @@
+package apiserver
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/version"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func serveOpenAPI(t *testing.T, swaggerUI *SwaggerUIAssets) *httptest.Server {
+	h, err := NewOpenAPIHandler(swaggerUI)
+	require.NoError(t, err)
+	router := chi.NewRouter()
+	h.RegisterRoutes(router)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, url string) (*http.Response, []byte) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	require := require.New(t)
+	server := serveOpenAPI(t, nil)
+
+	resp, body := get(t, server.URL+"/openapi/v1alpha1.json")
+	require.Equal(http.StatusOK, resp.StatusCode)
+	require.Equal("application/json", resp.Header.Get("Content-Type"))
+
+	served, err := openapi3.NewLoader().LoadFromData(body)
+	require.NoError(err)
+	// some schema defaults of the spec the API is generated from do not validate against their schema
+	require.NoError(served.Validate(context.Background(), openapi3.DisableSchemaDefaultsValidation()))
+
+	// the served spec is the one the server validates requests with
+	embedded, err := api.GetSwagger()
+	require.NoError(err)
+	require.Equal(embedded.Info.Version, served.Info.Version)
+	require.Equal(version.Get().String(), served.Info.Extensions[serverVersionExtension])
+	require.Equal(embedded.Paths.Len(), served.Paths.Len())
+	require.NotNil(served.Paths.Find("/api/v1/devices"))
+
+	resp, _ = get(t, server.URL+"/openapi/v1.json")
+	require.Equal(http.StatusNotFound, resp.StatusCode)
+
+	// the Swagger UI is disabled by default
+	resp, _ = get(t, server.URL+"/openapi/ui")
+	require.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func TestOpenAPISwaggerUI(t *testing.T) {
+	require := require.New(t)
+	server := serveOpenAPI(t, &SwaggerUIAssets{
+		Dist:            "https://assets.example.com/swagger-ui",
+		CSSIntegrity:    "sha384-css",
+		BundleIntegrity: "sha384-bundle",
+	})
+
+	resp, body := get(t, server.URL+"/openapi/ui")
+	require.Equal(http.StatusOK, resp.StatusCode)
+	require.Equal("text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+	require.Contains(string(body), `url: "\/openapi\/v1alpha1.json"`)
+	require.Contains(string(body), `<link rel="stylesheet" href="https://assets.example.com/swagger-ui/swagger-ui.css" integrity="sha384-css" crossorigin="anonymous">`)
+	require.Contains(string(body), `<script src="https://assets.example.com/swagger-ui/swagger-ui-bundle.js" integrity="sha384-bundle" crossorigin="anonymous"></script>`)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/admission"
 	"github.com/flightctl/flightctl/internal/api/server"
 	tlsmiddleware "github.com/flightctl/flightctl/internal/api_server/middleware"
 	"github.com/flightctl/flightctl/internal/auth"
@@ -17,14 +18,17 @@ import (
 	"github.com/flightctl/flightctl/internal/crypto"
 	"github.com/flightctl/flightctl/internal/instrumentation"
 	"github.com/flightctl/flightctl/internal/kvstore"
+	"github.com/flightctl/flightctl/internal/notifications"
 	"github.com/flightctl/flightctl/internal/service"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/tasks"
 	"github.com/flightctl/flightctl/pkg/queues"
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	oapimiddleware "github.com/oapi-codegen/nethttp-middleware"
 	"github.com/sirupsen/logrus"
+	"k8s.io/utils/clock"
 )
 
 const (
@@ -71,15 +75,11 @@ func oapiErrorHandler(w http.ResponseWriter, message string, statusCode int) {
 
 func (s *Server) Run(ctx context.Context) error {
 	s.log.Println("Initializing async jobs")
-	publisher, err := tasks.TaskQueuePublisher(s.provider)
-	if err != nil {
-		return err
-	}
 	kvStore, err := kvstore.NewKVStore(ctx, s.log, s.cfg.KV.Hostname, s.cfg.KV.Port, s.cfg.KV.Password)
 	if err != nil {
 		return err
 	}
-	callbackManager := tasks.NewCallbackManager(publisher, s.log)
+	callbackManager := tasks.NewOutboxCallbackManager(s.store.Outbox(), s.log)
 
 	s.log.Println("Initializing API server")
 	swagger, err := api.GetSwagger()
@@ -93,11 +93,36 @@ func (s *Server) Run(ctx context.Context) error {
 		ErrorHandler: oapiErrorHandler,
 	}
 
-	authMiddleware, err := auth.CreateAuthMiddleware(s.cfg, s.log)
+	unknownFields, err := tlsmiddleware.NewUnknownFieldsChecker(s.log, swagger, s.cfg.Service.UnknownFieldsMode == config.UnknownFieldsModeStrict)
+	if err != nil {
+		return fmt.Errorf("failed creating unknown fields checker: %w", err)
+	}
+
+	authMiddleware, err := auth.CreateAuthMiddleware(ctx, s.cfg, s.log)
+	if err != nil {
+		return err
+	}
+
+	ipRateLimit, identityRateLimit, err := s.rateLimiters()
 	if err != nil {
 		return err
 	}
 
+	admissionValidator, err := admission.NewFromConfig(s.log.WithField("pkg", "admission"), s.cfg)
+	if err != nil {
+		return fmt.Errorf("failed creating admission webhooks: %w", err)
+	}
+
+	openAPI, err := NewOpenAPIHandler(s.swaggerUI())
+	if err != nil {
+		return err
+	}
+
+	compression, err := tlsmiddleware.Compression(s.cfg.Service.ResponseCompression, s.cfg.Service.ResponseCompressionMinSize)
+	if err != nil {
+		return fmt.Errorf("failed creating response compression: %w", err)
+	}
+
 	router := chi.NewRouter()
 
 	// general middleware stack for all route groups
@@ -106,10 +131,22 @@ func (s *Server) Run(ctx context.Context) error {
 		middleware.RequestSize(int64(s.cfg.Service.HttpMaxRequestSize)),
 		tlsmiddleware.RequestSizeLimiter(s.cfg.Service.HttpMaxUrlLength, s.cfg.Service.HttpMaxNumHeaders),
 		middleware.RequestID,
+		tlsmiddleware.RequestIDResponse,
 		middleware.Logger,
 		middleware.Recoverer,
+		// requests are charged to their source address before authentication, so that
+		// unauthenticated clients cannot flood the auth provider
+		ipRateLimit,
 		authMiddleware,
+		identityRateLimit,
 	)
+	if s.cfg.Service.MaintenanceMode {
+		s.log.Warn("Maintenance mode: rejecting requests that modify resources")
+		router.Use(tlsmiddleware.MaintenanceMode(time.Duration(s.cfg.Service.MaintenanceRetryAfter)))
+	}
+
+	// merge patches are JSON documents, which the request validator does not know about
+	openapi3filter.RegisterBodyDecoder("application/merge-patch+json", openapi3filter.JSONBodyDecoder)
 
 	// a group is a new mux copy, with it's own copy of the middleware stack
 	// this one handles the OpenAPI handling of the service
@@ -119,9 +156,12 @@ func (s *Server) Run(ctx context.Context) error {
 		if s.metrics != nil {
 			r.Use(s.metrics.ApiServerMiddleware)
 		}
+		// compressing only the API responses, as the websocket connections are hijacked
+		r.Use(compression)
+		r.Use(unknownFields.Handler)
 		r.Use(oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapiOpts))
 
-		h := service.NewServiceHandler(s.store, callbackManager, kvStore, s.ca, s.log, s.cfg.Service.BaseAgentEndpointUrl, s.cfg.Service.BaseUIUrl)
+		h := service.NewServiceHandler(s.store, callbackManager, kvStore, s.emitter(), admissionValidator, s.ca, s.log, s.cfg.Service.BaseAgentEndpointUrl, s.cfg.Service.BaseUIUrl, s.cfg.Service.BootstrapTokenEnrollment)
 		server.HandlerFromMux(server.NewStrictHandler(h, nil), r)
 	})
 
@@ -129,7 +169,21 @@ func (s *Server) Run(ctx context.Context) error {
 	ws := service.NewWebsocketHandler(s.store, s.ca, s.log, consoleSessionManager)
 	ws.RegisterRoutes(router)
 
-	srv := tlsmiddleware.NewHTTPServer(router, s.log, s.cfg.Service.Address, s.cfg)
+	// the health probes are served outside of the middleware stack, so that they do not require
+	// authentication and do not fill the logs
+	health := NewHealthChecker(s.log, healthCheckTimeout)
+	health.Register("database", s.store.Ping)
+	health.Register("kv", kvStore.Ping)
+	health.Register("queue", s.provider.Ping)
+	rootRouter := chi.NewRouter()
+	rootRouter.Get("/livez", health.Livez)
+	rootRouter.Get("/readyz", health.Readyz)
+	// the OpenAPI spec describes the API rather than the resources, so it is served without
+	// authentication for integrators and the Swagger UI loaded in browsers
+	openAPI.RegisterRoutes(rootRouter)
+	rootRouter.Mount("/", router)
+
+	srv := tlsmiddleware.NewHTTPServer(rootRouter, s.log, s.cfg.Service.Address, s.cfg)
 
 	go func() {
 		<-ctx.Done()
@@ -151,3 +205,55 @@ func (s *Server) Run(ctx context.Context) error {
 
 	return nil
 }
+
+// rateLimiters returns the middlewares limiting the requests per source address and per
+// authenticated identity, as configured. A limit that is not configured passes requests through.
+func (s *Server) rateLimiters() (func(http.Handler) http.Handler, func(http.Handler) http.Handler, error) {
+	passThrough := func(next http.Handler) http.Handler { return next }
+	svc := s.cfg.Service
+	if svc.RateLimitRequests == 0 && svc.RateLimitIPRequests == 0 {
+		return passThrough, passThrough, nil
+	}
+	filter, err := tlsmiddleware.NewIPFilter(s.log, nil, nil, svc.TrustedProxies, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rate limiter: %w", err)
+	}
+	window := time.Duration(svc.RateLimitWindow)
+
+	ipRequests, identityRequests := svc.RateLimitIPRequests, svc.RateLimitRequests
+	if svc.RateLimitScope == config.RateLimitScopeIP {
+		ipRequests, identityRequests = svc.RateLimitRequests, 0
+	}
+	ipRateLimit, identityRateLimit := passThrough, passThrough
+	if ipRequests > 0 {
+		s.log.Infof("Rate limiting to %d requests per %s per source address", ipRequests, window)
+		ipRateLimit = tlsmiddleware.NewRateLimiter(ipRequests, window, tlsmiddleware.IPRateLimitKey(filter.ClientIP), clock.RealClock{}).Handler
+	}
+	if identityRequests > 0 {
+		s.log.Infof("Rate limiting to %d requests per %s per identity", identityRequests, window)
+		identityRateLimit = tlsmiddleware.NewRateLimiter(identityRequests, window, tlsmiddleware.IdentityRateLimitKey(filter.ClientIP), clock.RealClock{}).Handler
+	}
+	return ipRateLimit, identityRateLimit, nil
+}
+
+// swaggerUI returns the assets of the Swagger UI, or nil if it is not served.
+func (s *Server) swaggerUI() *SwaggerUIAssets {
+	if !s.cfg.Service.SwaggerUI {
+		return nil
+	}
+	assets := &SwaggerUIAssets{Dist: s.cfg.Service.SwaggerUIDist}
+	if integrity := s.cfg.Service.SwaggerUIIntegrity; integrity != nil {
+		assets.CSSIntegrity = integrity.CSS
+		assets.BundleIntegrity = integrity.Bundle
+	}
+	return assets
+}
+
+// emitter returns the emitter of the events the configured webhooks are notified of, or nil if no
+// webhook is configured.
+func (s *Server) emitter() *notifications.Emitter {
+	if !s.cfg.NotificationsEnabled() {
+		return nil
+	}
+	return notifications.NewEmitter(s.store.Outbox(), s.log)
+}
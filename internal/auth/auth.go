@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/flightctl/flightctl/internal/auth/authn"
 	"github.com/flightctl/flightctl/internal/auth/authz"
@@ -65,7 +66,7 @@ func getAuthToken(r *http.Request) (string, bool) {
 	return ParseAuthHeader(authHeader)
 }
 
-func initK8sAuth(cfg *config.Config, log logrus.FieldLogger) error {
+func initK8sAuth(ctx context.Context, cfg *config.Config, log logrus.FieldLogger) error {
 	apiUrl := strings.TrimSuffix(cfg.Auth.K8s.ApiUrl, "/")
 	externalOpenShiftApiUrl := strings.TrimSuffix(cfg.Auth.K8s.ExternalOpenShiftApiUrl, "/")
 	log.Infof("k8s auth enabled: %s", apiUrl)
@@ -80,6 +81,19 @@ func initK8sAuth(cfg *config.Config, log logrus.FieldLogger) error {
 		return fmt.Errorf("failed to create k8s client: %w", err)
 	}
 	authZ = K8sToK8sAuth{K8sAuthZ: authz.K8sAuthZ{K8sClient: k8sClient, Namespace: cfg.Auth.K8s.RBACNs}}
+	// a negative TTL disables caching
+	ttl := time.Duration(cfg.Auth.PermissionCacheTTL)
+	if ttl == 0 {
+		ttl = DefaultPermissionCacheTTL
+	}
+	if ttl > 0 {
+		cachingAuthZ := NewCachingAuthZ(authZ, ttl)
+		// permissions granted or revoked by RBAC changes take effect without waiting for the TTL
+		if err := k8sClient.WatchRBAC(ctx, cfg.Auth.K8s.RBACNs, cachingAuthZ.Invalidate); err != nil {
+			return err
+		}
+		authZ = cachingAuthZ
+	}
 	authN, err = authn.NewK8sAuthN(k8sClient, externalOpenShiftApiUrl)
 	if err != nil {
 		return fmt.Errorf("failed to create k8s AuthN: %w", err)
@@ -108,7 +122,7 @@ func initOIDCAuth(cfg *config.Config, log logrus.FieldLogger) error {
 	return nil
 }
 
-func CreateAuthMiddleware(cfg *config.Config, log logrus.FieldLogger) (func(http.Handler) http.Handler, error) {
+func CreateAuthMiddleware(ctx context.Context, cfg *config.Config, log logrus.FieldLogger) (func(http.Handler) http.Handler, error) {
 	value, exists := os.LookupEnv(DisableAuthEnvKey)
 	if exists && value != "" {
 		log.Warnln("Auth disabled")
@@ -117,7 +131,7 @@ func CreateAuthMiddleware(cfg *config.Config, log logrus.FieldLogger) (func(http
 	} else if cfg.Auth != nil {
 		var err error
 		if cfg.Auth.K8s != nil {
-			err = initK8sAuth(cfg, log)
+			err = initK8sAuth(ctx, cfg, log)
 		} else if cfg.Auth.OIDC != nil {
 			err = initOIDCAuth(cfg, log)
 		}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/auth/common"
+	"github.com/jellydator/ttlcache/v3"
+)
+
+const (
+	// DefaultPermissionCacheTTL is how long a permission check result is reused when the auth
+	// config does not set a TTL.
+	DefaultPermissionCacheTTL = 5 * time.Second
+	// maxPermissionCacheEntries bounds the memory used by the cache; the least recently used
+	// entries are evicted once it is reached.
+	maxPermissionCacheEntries = 10000
+)
+
+type permissionKey struct {
+	identity string
+	resource string
+	op       string
+}
+
+// CachingAuthZ reuses the results of permission checks for a short time, so that bursts of
+// requests from the same caller do not each evaluate the policy. Results are cached per token,
+// so that callers never share each other's decisions, and errors are never cached.
+type CachingAuthZ struct {
+	delegate AuthZMiddleware
+	entries  *ttlcache.Cache[permissionKey, bool]
+}
+
+func NewCachingAuthZ(delegate AuthZMiddleware, ttl time.Duration) *CachingAuthZ {
+	return &CachingAuthZ{
+		delegate: delegate,
+		entries: ttlcache.New[permissionKey, bool](
+			ttlcache.WithTTL[permissionKey, bool](ttl),
+			ttlcache.WithCapacity[permissionKey, bool](maxPermissionCacheEntries),
+			// a result must not outlive its TTL however often it is used
+			ttlcache.WithDisableTouchOnHit[permissionKey, bool](),
+		),
+	}
+}
+
+func (c *CachingAuthZ) CheckPermission(ctx context.Context, resource string, op string) (bool, error) {
+	token, ok := ctx.Value(common.TokenCtxKey).(string)
+	if !ok || token == "" {
+		return c.delegate.CheckPermission(ctx, resource, op)
+	}
+	digest := sha256.Sum256([]byte(token))
+	key := permissionKey{identity: hex.EncodeToString(digest[:]), resource: resource, op: op}
+
+	if item := c.entries.Get(key); item != nil {
+		return item.Value(), nil
+	}
+
+	allowed, err := c.delegate.CheckPermission(ctx, resource, op)
+	if err != nil {
+		return false, err
+	}
+	c.entries.Set(key, allowed, ttlcache.DefaultTTL)
+	return allowed, nil
+}
+
+// Invalidate drops all cached results. It is called when roles or role bindings change so that
+// the new permissions take effect immediately.
+func (c *CachingAuthZ) Invalidate() {
+	c.entries.DeleteAll()
+}
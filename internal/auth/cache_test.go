@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/auth/common"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAuthZ struct {
+	allowed map[string]bool
+	err     error
+	calls   int
+}
+
+func (f *fakeAuthZ) CheckPermission(ctx context.Context, resource string, op string) (bool, error) {
+	f.calls++
+	if f.err != nil {
+		return false, f.err
+	}
+	token, _ := ctx.Value(common.TokenCtxKey).(string)
+	return f.allowed[token+"/"+resource+"/"+op], nil
+}
+
+func withToken(token string) context.Context {
+	return context.WithValue(context.Background(), common.TokenCtxKey, token)
+}
+
+func TestCachingAuthZHit(t *testing.T) {
+	require := require.New(t)
+	delegate := &fakeAuthZ{allowed: map[string]bool{"alice/devices/list": true}}
+	cache := NewCachingAuthZ(delegate, 100*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		allowed, err := cache.CheckPermission(withToken("alice"), "devices", "list")
+		require.NoError(err)
+		require.True(allowed)
+	}
+	require.Equal(1, delegate.calls)
+
+	// a different action is evaluated separately
+	allowed, err := cache.CheckPermission(withToken("alice"), "devices", "delete")
+	require.NoError(err)
+	require.False(allowed)
+	require.Equal(2, delegate.calls)
+
+	// the result expires after the TTL, however often it is used
+	_, err = cache.CheckPermission(withToken("alice"), "devices", "list")
+	require.NoError(err)
+	require.Equal(2, delegate.calls)
+	time.Sleep(150 * time.Millisecond)
+	_, err = cache.CheckPermission(withToken("alice"), "devices", "list")
+	require.NoError(err)
+	require.Equal(3, delegate.calls)
+}
+
+func TestCachingAuthZIsolatesIdentities(t *testing.T) {
+	require := require.New(t)
+	delegate := &fakeAuthZ{allowed: map[string]bool{"alice/devices/list": true}}
+	cache := NewCachingAuthZ(delegate, time.Minute)
+
+	allowed, err := cache.CheckPermission(withToken("alice"), "devices", "list")
+	require.NoError(err)
+	require.True(allowed)
+
+	allowed, err = cache.CheckPermission(withToken("bob"), "devices", "list")
+	require.NoError(err)
+	require.False(allowed)
+
+	// requests without a token are never cached
+	_, err = cache.CheckPermission(context.Background(), "devices", "list")
+	require.NoError(err)
+	_, err = cache.CheckPermission(context.Background(), "devices", "list")
+	require.NoError(err)
+	require.Equal(4, delegate.calls)
+}
+
+func TestCachingAuthZInvalidateOnRoleChange(t *testing.T) {
+	require := require.New(t)
+	delegate := &fakeAuthZ{allowed: map[string]bool{"alice/fleets/update": true}}
+	cache := NewCachingAuthZ(delegate, time.Hour)
+
+	allowed, err := cache.CheckPermission(withToken("alice"), "fleets", "update")
+	require.NoError(err)
+	require.True(allowed)
+
+	// the role binding granting the permission is removed
+	delegate.allowed = map[string]bool{}
+	allowed, err = cache.CheckPermission(withToken("alice"), "fleets", "update")
+	require.NoError(err)
+	require.True(allowed)
+
+	cache.Invalidate()
+	allowed, err = cache.CheckPermission(withToken("alice"), "fleets", "update")
+	require.NoError(err)
+	require.False(allowed)
+}
+
+func TestCachingAuthZDoesNotCacheErrors(t *testing.T) {
+	require := require.New(t)
+	delegate := &fakeAuthZ{err: errors.New("policy engine unavailable")}
+	cache := NewCachingAuthZ(delegate, time.Minute)
+
+	_, err := cache.CheckPermission(withToken("alice"), "devices", "list")
+	require.Error(err)
+
+	delegate.err = nil
+	delegate.allowed = map[string]bool{"alice/devices/list": true}
+	allowed, err := cache.CheckPermission(withToken("alice"), "devices", "list")
+	require.NoError(err)
+	require.True(allowed)
+}
@@ -0,0 +1,61 @@
+package changes
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// RelayInterval is the interval at which the captured changes are published.
+	RelayInterval = time.Second
+
+	// RelayBatchSize is the number of changes claimed from the outbox at once.
+	RelayBatchSize = 20
+)
+
+// Relay publishes the changes captured in the outbox to the sink, in the order they were
+// committed. A change is published at least once: it is published again if the relay stops
+// before marking it sent, so consumers must tolerate duplicates.
+type Relay struct {
+	log    logrus.FieldLogger
+	outbox store.Outbox
+	sink   Sink
+}
+
+func NewRelay(log logrus.FieldLogger, outbox store.Outbox, sink Sink) *Relay {
+	return &Relay{
+		log:    log,
+		outbox: outbox,
+		sink:   sink,
+	}
+}
+
+// Poll publishes the pending changes. The published changes are deleted with the published tasks.
+func (r *Relay) Poll() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := r.Relay(ctx); err != nil {
+		r.log.WithError(err).Error("failed to publish changes")
+	}
+}
+
+// Relay publishes the pending changes in batches and returns the number of changes published. It
+// stops at the first change the sink fails to publish, which holds back the following changes
+// until it is published by a later relay.
+func (r *Relay) Relay(ctx context.Context) (int, error) {
+	publish := func(payload []byte) error {
+		return r.sink.Publish(ctx, payload)
+	}
+	total := 0
+	for {
+		published, err := r.outbox.RelayInOrder(ctx, store.ChangesQueue, RelayBatchSize, publish)
+		total += published
+		if err != nil || published < RelayBatchSize {
+			return total, err
+		}
+	}
+}
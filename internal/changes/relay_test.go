@@ -0,0 +1,104 @@
+package changes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/notifications"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// changeRecorder is a webhook that records the changes it receives, and fails the first failures
+// of them.
+type changeRecorder struct {
+	t        *testing.T
+	mu       sync.Mutex
+	failures int
+	changes  []store.Change
+}
+
+func (c *changeRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures > 0 {
+		c.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	require.NoError(c.t, err)
+	require.Equal(c.t, notifications.Sign("secret", body), r.Header.Get(notifications.SignatureHeader))
+	var change store.Change
+	require.NoError(c.t, json.Unmarshal(body, &change))
+	require.Equal(c.t, string(change.Type), r.Header.Get(ChangeTypeHeader))
+	c.changes = append(c.changes, change)
+}
+
+func TestRelay(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "outbox.db")), &gorm.Config{})
+	require.NoError(err)
+	outbox := store.NewOutbox(db, log.InitLogs())
+	require.NoError(outbox.InitialMigration())
+
+	// more changes than a batch, interleaving the changes of two devices
+	orgId := uuid.New()
+	expected := []string{}
+	for i := 0; i < RelayBatchSize+5; i++ {
+		change := store.Change{Type: store.ChangeUpdated, Kind: api.DeviceKind, OrgID: orgId, Name: fmt.Sprintf("dev-%d", i%2), Timestamp: time.Now()}
+		change.Object = json.RawMessage(fmt.Sprintf(`{"generation":%d}`, i))
+		payload, err := json.Marshal(change)
+		require.NoError(err)
+		require.NoError(outbox.Enqueue(ctx, store.ChangesQueue, payload))
+		expected = append(expected, string(change.Object))
+	}
+
+	webhook := &changeRecorder{t: t, failures: 1}
+	server := httptest.NewServer(webhook)
+	defer server.Close()
+	sink, err := NewWebhookSink(server.URL, "secret", time.Second)
+	require.NoError(err)
+	relay := NewRelay(log.InitLogs(), outbox, sink)
+
+	// a change that is not published holds back the following ones
+	published, err := relay.Relay(ctx)
+	require.Error(err)
+	require.Zero(published)
+	require.Empty(webhook.changes)
+
+	// which are then published in order, and only once
+	published, err = relay.Relay(ctx)
+	require.NoError(err)
+	require.Equal(len(expected), published)
+	published, err = relay.Relay(ctx)
+	require.NoError(err)
+	require.Zero(published)
+
+	received := []string{}
+	for _, change := range webhook.changes {
+		received = append(received, string(change.Object))
+	}
+	require.Equal(expected, received)
+}
+
+func TestNewWebhookSink(t *testing.T) {
+	_, err := NewWebhookSink("https://bridge.example.com/changes", "", time.Second)
+	require.NoError(t, err)
+	_, err = NewWebhookSink("bridge.example.com", "", time.Second)
+	require.Error(t, err)
+}
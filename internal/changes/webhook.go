@@ -0,0 +1,74 @@
+package changes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/notifications"
+	"github.com/flightctl/flightctl/internal/store"
+)
+
+// ChangeTypeHeader holds the type of the change posted to a webhook.
+const ChangeTypeHeader = "X-Flightctl-Change"
+
+// Sink publishes the captured changes to their consumers.
+type Sink interface {
+	// Publish publishes the change in payload, a JSON-encoded store.Change. A change that is not
+	// published is retried, and holds back the following changes until then.
+	Publish(ctx context.Context, payload []byte) error
+}
+
+// WebhookSink posts the changes to a webhook, e.g. an HTTP connector of a message broker.
+type WebhookSink struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// Make sure we conform to Sink interface
+var _ Sink = (*WebhookSink)(nil)
+
+// NewWebhookSink returns a sink posting the changes to the URL, signed with the secret as the
+// notifications are when it is not empty.
+func NewWebhookSink(webhookURL, secret string, timeout time.Duration) (*WebhookSink, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid change webhook url %q", webhookURL)
+	}
+	return &WebhookSink{
+		url:    webhookURL,
+		secret: secret,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, payload []byte) error {
+	var change store.Change
+	if err := json.Unmarshal(payload, &change); err != nil {
+		return fmt.Errorf("invalid change: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ChangeTypeHeader, string(change.Type))
+	if s.secret != "" {
+		req.Header.Set(notifications.SignatureHeader, notifications.Sign(s.secret, payload))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("publishing %s change of %s/%s: %w", change.Type, change.Kind, change.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("publishing %s change of %s/%s: webhook responded with %s", change.Type, change.Kind, change.Name, resp.Status)
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	api "github.com/flightctl/flightctl/api/v1alpha1"
 	apiclient "github.com/flightctl/flightctl/internal/api/client"
 	"github.com/flightctl/flightctl/internal/client"
 	"github.com/spf13/cobra"
@@ -28,9 +29,12 @@ var (
 type ApplyOptions struct {
 	GlobalOptions
 
-	Filenames []string
-	DryRun    bool
-	Recursive bool
+	Filenames      []string
+	DryRun         bool
+	Recursive      bool
+	ServerSide     bool
+	FieldManager   string
+	ForceConflicts bool
 }
 
 func DefaultApplyOptions() *ApplyOptions {
@@ -39,6 +43,8 @@ func DefaultApplyOptions() *ApplyOptions {
 		Filenames:     []string{},
 		DryRun:        false,
 		Recursive:     false,
+		ServerSide:    false,
+		FieldManager:  "flightctl-cli",
 	}
 }
 
@@ -76,6 +82,9 @@ func (o *ApplyOptions) Bind(fs *pflag.FlagSet) {
 	}
 	fs.BoolVarP(&o.DryRun, "dry-run", "", o.DryRun, "Only print the object that would be sent, without sending it.")
 	fs.BoolVarP(&o.Recursive, "recursive", "R", o.Recursive, "Process the directory used in -f, --filename recursively.")
+	fs.BoolVar(&o.ServerSide, "server-side", o.ServerSide, "Merge the resources into the existing ones on the server, tracking which fields are owned by which field manager.")
+	fs.StringVar(&o.FieldManager, "field-manager", o.FieldManager, "Name of the field manager owning the fields set by a server-side apply.")
+	fs.BoolVar(&o.ForceConflicts, "force-conflicts", o.ForceConflicts, "Take ownership of fields owned by other field managers instead of failing on conflicts. Requires --server-side.")
 }
 
 func (o *ApplyOptions) Complete(cmd *cobra.Command, args []string) error {
@@ -97,6 +106,12 @@ func (o *ApplyOptions) Validate(args []string) error {
 	if len(args) > 0 {
 		return fmt.Errorf("unexpected arguments: %v (did you forget to quote wildcards?)", args)
 	}
+	if o.ForceConflicts && !o.ServerSide {
+		return fmt.Errorf("--force-conflicts requires --server-side")
+	}
+	if o.ServerSide && len(o.FieldManager) == 0 {
+		return fmt.Errorf("--field-manager must not be empty")
+	}
 	return nil
 }
 
@@ -110,7 +125,7 @@ func (o *ApplyOptions) Run(ctx context.Context, args []string) error {
 	for _, filename := range o.Filenames {
 		switch {
 		case filename == "-":
-			errs = append(errs, applyFromReader(ctx, c, "<stdin>", os.Stdin, o.DryRun)...)
+			errs = append(errs, o.applyFromReader(ctx, c, "<stdin>", os.Stdin)...)
 		default:
 			expandedFilenames, err := expandIfFilePattern(filename)
 			if err != nil {
@@ -148,7 +163,7 @@ func (o *ApplyOptions) Run(ctx context.Context, args []string) error {
 						return nil
 					}
 					defer r.Close()
-					errs = append(errs, applyFromReader(ctx, c, path, r, o.DryRun)...)
+					errs = append(errs, o.applyFromReader(ctx, c, path, r)...)
 					return nil
 				})
 				if err != nil {
@@ -162,7 +177,7 @@ func (o *ApplyOptions) Run(ctx context.Context, args []string) error {
 
 type genericResource map[string]interface{}
 
-func applyFromReader(ctx context.Context, client *apiclient.ClientWithResponses, filename string, r io.Reader, dryRun bool) []error {
+func (o *ApplyOptions) applyFromReader(ctx context.Context, client *apiclient.ClientWithResponses, filename string, r io.Reader) []error {
 	decoder := yamlutil.NewYAMLOrJSONDecoder(r, 100)
 	resources := []genericResource{}
 
@@ -197,7 +212,7 @@ func applyFromReader(ctx context.Context, client *apiclient.ClientWithResponses,
 			continue
 		}
 
-		if dryRun {
+		if o.DryRun {
 			fmt.Printf("%s: applying %s/%s (dry run only)\n", strings.ToLower(kind), filename, resourceName)
 			continue
 		}
@@ -207,56 +222,22 @@ func applyFromReader(ctx context.Context, client *apiclient.ClientWithResponses,
 			errs = append(errs, fmt.Errorf("%s: skipping resource of kind %q: %w", filename, kind, err))
 		}
 
-		var httpResponse *http.Response
-		var message string
-
-		switch strings.ToLower(kind) {
-		case DeviceKind:
-			var response *apiclient.ReplaceDeviceResponse
-			response, err = client.ReplaceDeviceWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
-			if response != nil {
-				httpResponse = response.HTTPResponse
-				message = string(response.Body)
-			}
-		case EnrollmentRequestKind:
-			var response *apiclient.ReplaceEnrollmentRequestResponse
-			response, err = client.ReplaceEnrollmentRequestWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
-			if response != nil {
-				httpResponse = response.HTTPResponse
-				message = string(response.Body)
-			}
-		case FleetKind:
-			var response *apiclient.ReplaceFleetResponse
-			response, err = client.ReplaceFleetWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
-			if response != nil {
-				httpResponse = response.HTTPResponse
-				message = string(response.Body)
-			}
-		case RepositoryKind:
-			var response *apiclient.ReplaceRepositoryResponse
-			response, err = client.ReplaceRepositoryWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
-			if response != nil {
-				httpResponse = response.HTTPResponse
-				message = string(response.Body)
-			}
-		case ResourceSyncKind:
-			var response *apiclient.ReplaceResourceSyncResponse
-			response, err = client.ReplaceResourceSyncWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
-			if response != nil {
-				httpResponse = response.HTTPResponse
-				message = string(response.Body)
-			}
-		case CertificateSigningRequestKind:
-			var response *apiclient.ReplaceCertificateSigningRequestResponse
-			response, err = client.ReplaceCertificateSigningRequestWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
-			if response != nil {
-				httpResponse = response.HTTPResponse
-				message = string(response.Body)
+		var fieldManager *string
+		var force *bool
+		if o.ServerSide {
+			lowerKind := strings.ToLower(kind)
+			if lowerKind == EnrollmentRequestKind || lowerKind == CertificateSigningRequestKind {
+				errs = append(errs, fmt.Errorf("%s: server-side apply is not supported for kind %q", filename, kind))
+				continue
 			}
-		default:
-			err = fmt.Errorf("%s: skipping resource of unknown kind %q: %v", filename, kind, resource)
+			fieldManager = &o.FieldManager
+			force = &o.ForceConflicts
 		}
 
+		httpResponse, message, err := replaceResource(ctx, client, kind, resourceName, buf, fieldManager, force)
+		if errors.Is(err, errUnknownKind) {
+			err = fmt.Errorf("%s: skipping resource of unknown kind %q: %v", filename, kind, resource)
+		}
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -267,6 +248,9 @@ func applyFromReader(ctx context.Context, client *apiclient.ClientWithResponses,
 			if httpResponse.StatusCode != http.StatusOK && httpResponse.StatusCode != http.StatusCreated {
 				errs = append(errs, fmt.Errorf("%s: failed to apply %s/%s: %s", strings.ToLower(kind), filename, resourceName, httpResponse.Status))
 				fmt.Printf("%s\n", message)
+				if o.ServerSide && httpResponse.StatusCode == http.StatusConflict {
+					fmt.Printf("use --force-conflicts to take ownership of fields owned by other field managers\n")
+				}
 			}
 		}
 
@@ -274,6 +258,64 @@ func applyFromReader(ctx context.Context, client *apiclient.ClientWithResponses,
 	return errs
 }
 
+var errUnknownKind = errors.New("unknown kind")
+
+// replaceResource sends the JSON encoded resource to the replace endpoint of its kind, returning
+// the HTTP response and its body. fieldManager and force are only set for server-side applies.
+func replaceResource(ctx context.Context, client *apiclient.ClientWithResponses, kind string, name string, buf []byte, fieldManager *string, force *bool) (*http.Response, string, error) {
+	var httpResponse *http.Response
+	var message string
+	var err error
+
+	switch strings.ToLower(kind) {
+	case DeviceKind:
+		var response *apiclient.ReplaceDeviceResponse
+		response, err = client.ReplaceDeviceWithBodyWithResponse(ctx, name, &api.ReplaceDeviceParams{FieldManager: fieldManager, Force: force}, "application/json", bytes.NewReader(buf))
+		if response != nil {
+			httpResponse = response.HTTPResponse
+			message = string(response.Body)
+		}
+	case EnrollmentRequestKind:
+		var response *apiclient.ReplaceEnrollmentRequestResponse
+		response, err = client.ReplaceEnrollmentRequestWithBodyWithResponse(ctx, name, "application/json", bytes.NewReader(buf))
+		if response != nil {
+			httpResponse = response.HTTPResponse
+			message = string(response.Body)
+		}
+	case FleetKind:
+		var response *apiclient.ReplaceFleetResponse
+		response, err = client.ReplaceFleetWithBodyWithResponse(ctx, name, &api.ReplaceFleetParams{FieldManager: fieldManager, Force: force}, "application/json", bytes.NewReader(buf))
+		if response != nil {
+			httpResponse = response.HTTPResponse
+			message = string(response.Body)
+		}
+	case RepositoryKind:
+		var response *apiclient.ReplaceRepositoryResponse
+		response, err = client.ReplaceRepositoryWithBodyWithResponse(ctx, name, &api.ReplaceRepositoryParams{FieldManager: fieldManager, Force: force}, "application/json", bytes.NewReader(buf))
+		if response != nil {
+			httpResponse = response.HTTPResponse
+			message = string(response.Body)
+		}
+	case ResourceSyncKind:
+		var response *apiclient.ReplaceResourceSyncResponse
+		response, err = client.ReplaceResourceSyncWithBodyWithResponse(ctx, name, &api.ReplaceResourceSyncParams{FieldManager: fieldManager, Force: force}, "application/json", bytes.NewReader(buf))
+		if response != nil {
+			httpResponse = response.HTTPResponse
+			message = string(response.Body)
+		}
+	case CertificateSigningRequestKind:
+		var response *apiclient.ReplaceCertificateSigningRequestResponse
+		response, err = client.ReplaceCertificateSigningRequestWithBodyWithResponse(ctx, name, "application/json", bytes.NewReader(buf))
+		if response != nil {
+			httpResponse = response.HTTPResponse
+			message = string(response.Body)
+		}
+	default:
+		err = errUnknownKind
+	}
+	return httpResponse, message, err
+}
+
 func expandIfFilePattern(pattern string) ([]string, error) {
 	if _, err := os.Stat(pattern); os.IsNotExist(err) {
 		matches, err := filepath.Glob(pattern)
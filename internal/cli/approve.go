@@ -1,11 +1,15 @@
 package cli
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strings"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
 	apiclient "github.com/flightctl/flightctl/internal/api/client"
@@ -19,21 +23,28 @@ type ApproveOptions struct {
 	GlobalOptions
 
 	ApproveLabels []string
+	AllPending    bool
+	LabelSelector string
+	Yes           bool
 }
 
 func DefaultApproveOptions() *ApproveOptions {
 	return &ApproveOptions{
 		GlobalOptions: DefaultGlobalOptions(),
 		ApproveLabels: []string{},
+		AllPending:    false,
+		LabelSelector: "",
+		Yes:           false,
 	}
 }
 
 func NewCmdApprove() *cobra.Command {
 	o := DefaultApproveOptions()
 	cmd := &cobra.Command{
-		Use:   "approve TYPE/NAME",
-		Short: "Approve a certificate signing or enrollment request.",
-		Args:  cobra.ExactArgs(1),
+		Use:               "approve (TYPE/NAME | --all-pending [--selector=SELECTOR] [--yes])",
+		Short:             "Approve a certificate signing or enrollment request.",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeResourceArg(&o.GlobalOptions, []string{CertificateSigningRequestKind, EnrollmentRequestKind}),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := o.Complete(cmd, args); err != nil {
 				return err
@@ -53,6 +64,9 @@ func (o *ApproveOptions) Bind(fs *pflag.FlagSet) {
 	o.GlobalOptions.Bind(fs)
 
 	fs.StringArrayVarP(&o.ApproveLabels, "label", "l", []string{}, "Labels to add to the device, as a comma-separated list of key=value.")
+	fs.BoolVar(&o.AllPending, "all-pending", o.AllPending, "Approve all pending enrollment requests, or those matching --selector.")
+	fs.StringVar(&o.LabelSelector, "selector", o.LabelSelector, "Selector (label query) restricting the enrollment requests approved with --all-pending, supporting operators like '=', '!=', and 'in' (e.g., --selector='key1=value1,key2!=value2').")
+	fs.BoolVarP(&o.Yes, "yes", "y", o.Yes, "Approve the enrollment requests selected with --all-pending without asking for confirmation.")
 }
 
 func (o *ApproveOptions) Complete(cmd *cobra.Command, args []string) error {
@@ -68,6 +82,16 @@ func (o *ApproveOptions) Validate(args []string) error {
 		return err
 	}
 
+	if o.AllPending {
+		return o.validateAllPending(args)
+	}
+	if len(o.LabelSelector) > 0 || o.Yes {
+		return fmt.Errorf("--selector and --yes only apply with --all-pending")
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("specify a request resource to approve, or --all-pending")
+	}
+
 	kind, name, err := parseAndValidateKindName(args[0])
 	if err != nil {
 		return err
@@ -88,12 +112,33 @@ func (o *ApproveOptions) Validate(args []string) error {
 	return nil
 }
 
+func (o *ApproveOptions) validateAllPending(args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != EnrollmentRequestKind {
+		return fmt.Errorf("--all-pending only applies to %s approval", EnrollmentRequestKind)
+	}
+	if len(name) > 0 {
+		return fmt.Errorf("cannot specify a request name with --all-pending")
+	}
+	return nil
+}
+
 func (o *ApproveOptions) Run(ctx context.Context, args []string) error {
 	c, err := client.NewFromConfigFile(o.ConfigFilePath)
 	if err != nil {
 		return fmt.Errorf("creating client: %w", err)
 	}
 
+	if o.AllPending {
+		return o.approveAllPending(ctx, c, os.Stdin, os.Stdout)
+	}
+
 	kind, name, err := parseAndValidateKindName(args[0])
 	if err != nil {
 		return err
@@ -142,6 +187,84 @@ func (o *ApproveOptions) Run(ctx context.Context, args []string) error {
 	return processApprovalReponse(response, err, kind, name)
 }
 
+// approveAllPending approves the pending enrollment requests matching the selector, after the
+// user confirmed the list of requests to approve unless --yes is set.
+func (o *ApproveOptions) approveAllPending(ctx context.Context, c *apiclient.ClientWithResponses, in io.Reader, out io.Writer) error {
+	names, err := listPendingEnrollmentRequests(ctx, c, o.LabelSelector)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(out, "No pending enrollment requests found.")
+		return nil
+	}
+
+	if !o.Yes {
+		fmt.Fprintf(out, "The following %d enrollment requests will be approved:\n", len(names))
+		for _, name := range names {
+			fmt.Fprintf(out, "  %s\n", name)
+		}
+		fmt.Fprint(out, "Do you want to continue? [y/N]: ")
+		answer, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("reading confirmation: %w", err)
+		}
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			fmt.Fprintln(out, "Approval cancelled.")
+			return nil
+		}
+	}
+
+	labels := util.LabelArrayToMap(o.ApproveLabels)
+	approval := api.EnrollmentRequestApproval{
+		Approved: true,
+		Labels:   &labels,
+	}
+	errs := []error{}
+	for _, name := range names {
+		response, err := c.ApproveEnrollmentRequest(ctx, name, approval)
+		if err := processApprovalReponse(response, err, EnrollmentRequestKind, name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Fprintf(out, "%s/%s approved\n", EnrollmentRequestKind, name)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed approving %d of %d enrollment requests: %w", len(errs), len(names), errors.Join(errs...))
+	}
+	return nil
+}
+
+// listPendingEnrollmentRequests returns the names of the pending enrollment requests matching
+// the label selector, a page at a time.
+func listPendingEnrollmentRequests(ctx context.Context, c *apiclient.ClientWithResponses, labelSelector string) ([]string, error) {
+	names := []string{}
+	pending := api.EnrollmentRequestStatusPending
+	params := &api.ListEnrollmentRequestsParams{
+		LabelSelector: util.StrToPtrWithNilDefault(labelSelector),
+		Status:        &pending,
+	}
+	for {
+		response, err := c.ListEnrollmentRequestsWithResponse(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("listing enrollment requests: %w", err)
+		}
+		if err := validateHttpResponse(response.Body, response.StatusCode(), http.StatusOK); err != nil {
+			return nil, fmt.Errorf("listing enrollment requests: %w", err)
+		}
+		if response.JSON200 == nil {
+			return nil, fmt.Errorf("listing enrollment requests: empty response")
+		}
+		for _, er := range response.JSON200.Items {
+			names = append(names, *er.Metadata.Name)
+		}
+		if response.JSON200.Metadata.Continue == nil {
+			return names, nil
+		}
+		params.Continue = response.JSON200.Metadata.Continue
+	}
+}
+
 func processApprovalReponse(response *http.Response, err error, kind string, name string) error {
 	errorPrefix := fmt.Sprintf("approving %s/%s", kind, name)
 	if err != nil {
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// approveTestServer serves the pending enrollment requests of the given sites, one per page, and
+// records the approvals it receives.
+func approveTestServer(t *testing.T, sites map[string]string, approvals map[string]api.EnrollmentRequestApproval) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/enrollmentrequests":
+			require.Equal(t, string(api.EnrollmentRequestStatusPending), r.URL.Query().Get("status"))
+			selector := r.URL.Query().Get("labelSelector")
+			names := []string{}
+			for name, site := range sites {
+				if _, approved := approvals[name]; !approved && (selector == "" || selector == "site="+site) {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			list := api.EnrollmentRequestList{Items: []api.EnrollmentRequest{}}
+			for _, name := range names {
+				if name > r.URL.Query().Get("continue") {
+					list.Items = append(list.Items, api.EnrollmentRequest{Metadata: api.ObjectMeta{Name: util.StrToPtr(name)}})
+				}
+			}
+			if len(list.Items) > 1 {
+				list.Items = list.Items[:1]
+				list.Metadata.Continue = list.Items[0].Metadata.Name
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(list))
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/approval"):
+			name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/enrollmentrequests/"), "/approval")
+			approval := api.EnrollmentRequestApproval{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&approval))
+			approvals[name] = approval
+			require.NoError(t, json.NewEncoder(w).Encode(api.EnrollmentRequestApproval{}))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestApproveAllPending(t *testing.T) {
+	sites := map[string]string{"er-1": "berlin", "er-2": "madrid", "er-3": "berlin"}
+
+	tests := []struct {
+		name     string
+		selector string
+		yes      bool
+		input    string
+		approved []string
+	}{
+		{
+			name:     "approves all pending requests",
+			yes:      true,
+			approved: []string{"er-1", "er-2", "er-3"},
+		},
+		{
+			name:     "approves the requests matching the selector",
+			selector: "site=berlin",
+			yes:      true,
+			approved: []string{"er-1", "er-3"},
+		},
+		{
+			name:     "approves after confirmation",
+			selector: "site=madrid",
+			input:    "y\n",
+			approved: []string{"er-2"},
+		},
+		{
+			name:     "does not approve without confirmation",
+			input:    "\n",
+			approved: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			approvals := map[string]api.EnrollmentRequestApproval{}
+			server := approveTestServer(t, sites, approvals)
+			defer server.Close()
+			c, err := apiclient.NewClientWithResponses(server.URL)
+			require.NoError(err)
+
+			o := DefaultApproveOptions()
+			o.AllPending = true
+			o.LabelSelector = tt.selector
+			o.Yes = tt.yes
+			o.ApproveLabels = []string{"fleet=factory"}
+			out := &bytes.Buffer{}
+			require.NoError(o.approveAllPending(context.Background(), c, strings.NewReader(tt.input), out))
+
+			approved := []string{}
+			for name, approval := range approvals {
+				require.True(approval.Approved)
+				require.Equal(map[string]string{"fleet": "factory"}, *approval.Labels)
+				approved = append(approved, name)
+			}
+			require.ElementsMatch(tt.approved, approved)
+			if !tt.yes {
+				require.Contains(out.String(), "Do you want to continue?")
+			}
+		})
+	}
+}
+
+func TestApproveAllPendingNoRequests(t *testing.T) {
+	require := require.New(t)
+	server := approveTestServer(t, map[string]string{}, map[string]api.EnrollmentRequestApproval{})
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	o := DefaultApproveOptions()
+	o.AllPending = true
+	out := &bytes.Buffer{}
+	require.NoError(o.approveAllPending(context.Background(), c, strings.NewReader(""), out))
+	require.Equal("No pending enrollment requests found.\n", out.String())
+}
+
+func TestApproveValidateAllPending(t *testing.T) {
+	require := require.New(t)
+	o := DefaultApproveOptions()
+	o.AllPending = true
+	require.NoError(o.validateAllPending(nil))
+	require.NoError(o.validateAllPending([]string{EnrollmentRequestKind}))
+	require.Error(o.validateAllPending([]string{EnrollmentRequestKind + "/er-1"}))
+	require.Error(o.validateAllPending([]string{CertificateSigningRequestKind}))
+}
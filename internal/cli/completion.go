@@ -1,9 +1,16 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/client"
 	"github.com/spf13/cobra"
 )
 
@@ -91,3 +98,141 @@ func (o *CompletionOptions) Validate(args []string) error {
 
 	return nil
 }
+
+// completionTimeout bounds how long dynamic completion waits for the service, so that the
+// shell stays responsive when it is slow or unreachable.
+const completionTimeout = 3 * time.Second
+
+type resourceNameLister func(ctx context.Context, kind string) ([]string, error)
+
+// completeResourceArg returns a cobra ValidArgsFunction completing a TYPE or TYPE/NAME argument.
+// Names are listed from the service; if that fails, only the resource kinds are completed.
+func completeResourceArg(o *GlobalOptions, kinds []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+		defer cancel()
+		lister := func(ctx context.Context, kind string) ([]string, error) {
+			c, err := client.NewFromConfigFile(ConfigFilePath(o.Context))
+			if err != nil {
+				return nil, err
+			}
+			return listResourceNames(ctx, c, kind)
+		}
+		return completeKindNames(ctx, kinds, toComplete, lister), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeKindNames(ctx context.Context, kinds []string, toComplete string, list resourceNameLister) []string {
+	kindArg, namePrefix, found := strings.Cut(toComplete, "/")
+	if !found {
+		completions := []string{}
+		for _, kind := range kinds {
+			if strings.HasPrefix(plural(kind), toComplete) {
+				completions = append(completions, plural(kind))
+			}
+		}
+		return completions
+	}
+
+	kind := fullname(singular(kindArg))
+	if !slices.Contains(kinds, kind) {
+		return nil
+	}
+	names, err := list(ctx, kind)
+	if err != nil {
+		return nil
+	}
+	completions := []string{}
+	for _, name := range names {
+		if strings.HasPrefix(name, namePrefix) {
+			completions = append(completions, kindArg+"/"+name)
+		}
+	}
+	return completions
+}
+
+func listResourceNames(ctx context.Context, c *apiclient.ClientWithResponses, kind string) ([]string, error) {
+	var items []api.ObjectMeta
+	switch kind {
+	case DeviceKind:
+		response, err := c.ListDevicesWithResponse(ctx, &api.ListDevicesParams{})
+		if err != nil {
+			return nil, err
+		}
+		if response.JSON200 == nil {
+			return nil, fmt.Errorf("listing %s: %s", plural(kind), response.Status())
+		}
+		for _, item := range response.JSON200.Items {
+			items = append(items, item.Metadata)
+		}
+	case EnrollmentRequestKind:
+		response, err := c.ListEnrollmentRequestsWithResponse(ctx, &api.ListEnrollmentRequestsParams{})
+		if err != nil {
+			return nil, err
+		}
+		if response.JSON200 == nil {
+			return nil, fmt.Errorf("listing %s: %s", plural(kind), response.Status())
+		}
+		for _, item := range response.JSON200.Items {
+			items = append(items, item.Metadata)
+		}
+	case FleetKind:
+		response, err := c.ListFleetsWithResponse(ctx, &api.ListFleetsParams{})
+		if err != nil {
+			return nil, err
+		}
+		if response.JSON200 == nil {
+			return nil, fmt.Errorf("listing %s: %s", plural(kind), response.Status())
+		}
+		for _, item := range response.JSON200.Items {
+			items = append(items, item.Metadata)
+		}
+	case RepositoryKind:
+		response, err := c.ListRepositoriesWithResponse(ctx, &api.ListRepositoriesParams{})
+		if err != nil {
+			return nil, err
+		}
+		if response.JSON200 == nil {
+			return nil, fmt.Errorf("listing %s: %s", plural(kind), response.Status())
+		}
+		for _, item := range response.JSON200.Items {
+			items = append(items, item.Metadata)
+		}
+	case ResourceSyncKind:
+		response, err := c.ListResourceSyncWithResponse(ctx, &api.ListResourceSyncParams{})
+		if err != nil {
+			return nil, err
+		}
+		if response.JSON200 == nil {
+			return nil, fmt.Errorf("listing %s: %s", plural(kind), response.Status())
+		}
+		for _, item := range response.JSON200.Items {
+			items = append(items, item.Metadata)
+		}
+	case CertificateSigningRequestKind:
+		response, err := c.ListCertificateSigningRequestsWithResponse(ctx, &api.ListCertificateSigningRequestsParams{})
+		if err != nil {
+			return nil, err
+		}
+		if response.JSON200 == nil {
+			return nil, fmt.Errorf("listing %s: %s", plural(kind), response.Status())
+		}
+		for _, item := range response.JSON200.Items {
+			items = append(items, item.Metadata)
+		}
+	default:
+		// template versions are scoped to a fleet and are not completed
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(items))
+	for _, meta := range items {
+		if meta.Name != nil {
+			names = append(names, *meta.Name)
+		}
+	}
+	return names, nil
+}
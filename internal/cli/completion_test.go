@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteKindNames(t *testing.T) {
+	names := map[string][]string{
+		DeviceKind: {"edge-1", "edge-2", "lab-1"},
+		FleetKind:  {"edge"},
+	}
+	lister := func(ctx context.Context, kind string) ([]string, error) {
+		return names[kind], nil
+	}
+	kinds := []string{DeviceKind, FleetKind}
+
+	tests := []struct {
+		name       string
+		toComplete string
+		want       []string
+	}{
+		{name: "all kinds", toComplete: "", want: []string{"devices", "fleets"}},
+		{name: "kind prefix", toComplete: "dev", want: []string{"devices"}},
+		{name: "names of kind", toComplete: "devices/", want: []string{"devices/edge-1", "devices/edge-2", "devices/lab-1"}},
+		{name: "name prefix", toComplete: "devices/edge", want: []string{"devices/edge-1", "devices/edge-2"}},
+		{name: "singular kind", toComplete: "device/lab", want: []string{"device/lab-1"}},
+		{name: "short kind", toComplete: "flt/", want: []string{"flt/edge"}},
+		{name: "no match", toComplete: "fleets/lab", want: []string{}},
+		{name: "unsupported kind", toComplete: "repositories/", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, completeKindNames(context.Background(), kinds, tt.toComplete, lister))
+		})
+	}
+}
+
+func TestCompleteKindNamesOffline(t *testing.T) {
+	lister := func(ctx context.Context, kind string) ([]string, error) {
+		return nil, errors.New("connection refused")
+	}
+	require.Nil(t, completeKindNames(context.Background(), []string{DeviceKind}, "devices/", lister))
+	require.Equal(t, []string{"devices"}, completeKindNames(context.Background(), []string{DeviceKind}, "d", lister))
+}
+
+func TestListResourceNames(t *testing.T) {
+	require := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("/api/v1/devices", r.URL.Path)
+		list := api.DeviceList{Items: []api.Device{
+			{Metadata: api.ObjectMeta{Name: util.StrToPtr("edge-1")}},
+			{Metadata: api.ObjectMeta{Name: util.StrToPtr("edge-2")}},
+		}}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(json.NewEncoder(w).Encode(list))
+	}))
+	defer server.Close()
+
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+	names, err := listResourceNames(context.Background(), c, DeviceKind)
+	require.NoError(err)
+	require.Equal([]string{"edge-1", "edge-2"}, names)
+
+	server.Close()
+	_, err = listResourceNames(context.Background(), c, DeviceKind)
+	require.Error(err)
+}
@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultContextName     = "default"
+	currentContextFileName = "current-context"
+)
+
+var contextNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+func NewCmdConfig() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the named contexts of the client config.",
+		Long: "Manage the named contexts of the client config. Each context holds the server and credentials of one " +
+			"Flight Control service, and commands run against the current context unless --context is given.",
+		Args: cobra.NoArgs,
+	}
+	cmd.AddCommand(NewCmdConfigSetContext())
+	cmd.AddCommand(NewCmdConfigUseContext())
+	cmd.AddCommand(NewCmdConfigGetContexts())
+	return cmd
+}
+
+type SetContextOptions struct {
+	Server             string
+	CAFile             string
+	InsecureSkipVerify bool
+	Token              string
+
+	changed func(name string) bool
+}
+
+func DefaultSetContextOptions() *SetContextOptions {
+	return &SetContextOptions{
+		Server:             "",
+		CAFile:             "",
+		InsecureSkipVerify: false,
+		Token:              "",
+	}
+}
+
+func NewCmdConfigSetContext() *cobra.Command {
+	o := DefaultSetContextOptions()
+	cmd := &cobra.Command{
+		Use:   "set-context NAME [--server=URL] [--certificate-authority=FILE] [--insecure-skip-tls-verify] [--token=TOKEN]",
+		Short: "Create a context, or update the given fields of an existing one.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(os.Stdout, args[0])
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *SetContextOptions) Bind(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Server, "server", o.Server, "URL of the Flight Control API server.")
+	fs.StringVar(&o.CAFile, "certificate-authority", o.CAFile, "Path to a cert file for the certificate authority.")
+	fs.BoolVar(&o.InsecureSkipVerify, "insecure-skip-tls-verify", o.InsecureSkipVerify, "If true, the server's certificate will not be checked for validity.")
+	fs.StringVar(&o.Token, "token", o.Token, "Bearer token for authentication to the API server.")
+}
+
+func (o *SetContextOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.changed = cmd.Flags().Changed
+	return nil
+}
+
+func (o *SetContextOptions) Validate(args []string) error {
+	return validateContextName(args[0])
+}
+
+// Run writes the context's config, keeping the fields of an existing context that are not set.
+func (o *SetContextOptions) Run(out io.Writer, name string) error {
+	changed := o.changed
+	if changed == nil {
+		changed = func(string) bool { return true }
+	}
+
+	path := ConfigFilePath(name)
+	config, err := readContextConfig(path)
+	exists := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if !exists {
+		config = client.NewDefault()
+		if !changed("server") || o.Server == "" {
+			return fmt.Errorf("context '%s' does not exist, --server is required to create it", name)
+		}
+	}
+
+	if changed("server") {
+		config.Service.Server = o.Server
+	}
+	if changed("certificate-authority") {
+		config.Service.CertificateAuthority = ""
+		config.Service.CertificateAuthorityData = nil
+		if o.CAFile != "" {
+			caData, err := os.ReadFile(o.CAFile)
+			if err != nil {
+				return fmt.Errorf("reading certificate authority: %w", err)
+			}
+			config.Service.CertificateAuthorityData = caData
+		}
+	}
+	if changed("insecure-skip-tls-verify") {
+		config.Service.InsecureSkipVerify = o.InsecureSkipVerify
+	}
+	if changed("token") {
+		config.AuthInfo.Token = o.Token
+	}
+
+	if err := config.Persist(path); err != nil {
+		return err
+	}
+	if exists {
+		fmt.Fprintf(out, "Context %q modified.\n", name)
+	} else {
+		fmt.Fprintf(out, "Context %q created.\n", name)
+	}
+	return nil
+}
+
+func NewCmdConfigUseContext() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use-context NAME",
+		Short: "Set the context used by the commands that are not given --context.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return useContext(os.Stdout, args[0])
+		},
+		SilenceUsage: true,
+	}
+	return cmd
+}
+
+func useContext(out io.Writer, name string) error {
+	if err := validateContextName(name); err != nil {
+		return err
+	}
+	if _, err := os.Stat(ConfigFilePath(name)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("context '%s' does not exist", name)
+		}
+		return err
+	}
+	if err := os.MkdirAll(ConfigDir(), 0700); err != nil {
+		return fmt.Errorf("writing current context: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(ConfigDir(), currentContextFileName), []byte(name+"\n"), 0600); err != nil {
+		return fmt.Errorf("writing current context: %w", err)
+	}
+	fmt.Fprintf(out, "Switched to context %q.\n", name)
+	return nil
+}
+
+func NewCmdConfigGetContexts() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-contexts",
+		Short: "List the contexts of the client config.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getContexts(os.Stdout)
+		},
+		SilenceUsage: true,
+	}
+	return cmd
+}
+
+func getContexts(out io.Writer) error {
+	names, err := listContexts()
+	if err != nil {
+		return err
+	}
+	current := CurrentContext()
+	if current == "" {
+		current = defaultContextName
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "CURRENT\tNAME\tSERVER")
+	for _, name := range names {
+		marker := ""
+		if name == current {
+			marker = "*"
+		}
+		server := "<invalid>"
+		if config, err := readContextConfig(ConfigFilePath(name)); err == nil {
+			server = config.Service.Server
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", marker, name, server)
+	}
+	return w.Flush()
+}
+
+// CurrentContext returns the context selected with 'config use-context', or an empty string
+// if none is selected.
+func CurrentContext() string {
+	contents, err := os.ReadFile(filepath.Join(ConfigDir(), currentContextFileName))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(contents))
+}
+
+// listContexts returns the names of the contexts found in the config directory, sorted.
+func listContexts() ([]string, error) {
+	entries, err := os.ReadDir(ConfigDir())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading config directory: %w", err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		filename := entry.Name()
+		switch {
+		case filename == defaultConfigFileName+"."+defaultConfigFileExt:
+			names = append(names, defaultContextName)
+		case strings.HasPrefix(filename, defaultConfigFileName+"_") && strings.HasSuffix(filename, "."+defaultConfigFileExt):
+			names = append(names, strings.TrimSuffix(strings.TrimPrefix(filename, defaultConfigFileName+"_"), "."+defaultConfigFileExt))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// readContextConfig decodes a context's config without validating it, so that an incomplete
+// context can still be updated.
+func readContextConfig(path string) (*client.Config, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := client.NewDefault()
+	if err := yaml.Unmarshal(contents, config); err != nil {
+		return nil, fmt.Errorf("decoding config: %w", err)
+	}
+	return config, nil
+}
+
+func validateContextName(name string) error {
+	if !contextNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid context name %q: must start with a letter or digit and contain only letters, digits, '-', '_' and '.'", name)
+	}
+	return nil
+}
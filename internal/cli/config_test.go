@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigContexts(t *testing.T) {
+	require := require.New(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	out := &bytes.Buffer{}
+
+	// add two contexts
+	o := DefaultSetContextOptions()
+	o.Server = "https://api.staging.example.com"
+	o.Token = "staging-token"
+	require.NoError(o.Run(out, "staging"))
+	o = DefaultSetContextOptions()
+	o.Server = "https://api.prod.example.com"
+	require.NoError(o.Run(out, "prod"))
+
+	// a new context requires a server
+	require.Error(DefaultSetContextOptions().Run(out, "empty"))
+
+	// updating a context keeps the fields that are not set
+	o = DefaultSetContextOptions()
+	o.Token = "prod-token"
+	o.changed = func(name string) bool { return name == "token" }
+	require.NoError(o.Run(out, "prod"))
+	config, err := readContextConfig(ConfigFilePath("prod"))
+	require.NoError(err)
+	require.Equal("https://api.prod.example.com", config.Service.Server)
+	require.Equal("prod-token", config.AuthInfo.Token)
+
+	// switch contexts
+	global := DefaultGlobalOptions()
+	require.NoError(global.Complete(nil, nil))
+	require.Equal(ConfigFilePath(""), global.ConfigFilePath)
+
+	require.NoError(useContext(out, "staging"))
+	require.Equal("staging", CurrentContext())
+	global = DefaultGlobalOptions()
+	require.NoError(global.Complete(nil, nil))
+	require.Equal(ConfigFilePath("staging"), global.ConfigFilePath)
+
+	// --context takes precedence over the current context
+	global = DefaultGlobalOptions()
+	global.Context = "prod"
+	require.NoError(global.Complete(nil, nil))
+	require.Equal(ConfigFilePath("prod"), global.ConfigFilePath)
+
+	require.ErrorContains(useContext(out, "missing"), "does not exist")
+	require.Error(useContext(out, "../client"))
+	require.Equal("staging", CurrentContext())
+
+	// list contexts
+	out.Reset()
+	require.NoError(getContexts(out))
+	require.Equal(
+		"CURRENT\tNAME\tSERVER\n"+
+			"\tprod\thttps://api.prod.example.com\n"+
+			"*\tstaging\thttps://api.staging.example.com\n",
+		out.String())
+}
@@ -97,17 +97,27 @@ func (o *ConsoleOptions) Run(ctx context.Context, args []string) error {
 }
 
 func (o *ConsoleOptions) connectViaWS(ctx context.Context, config *client.Config, deviceName, token string) error {
+	fmt.Printf("Connecting to device %s\n", deviceName)
+	conn, err := dialDeviceConsole(config, deviceName, token)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return forwardStdio(ctx, conn)
+}
 
+// dialDeviceConsole opens a console session to the device through the server's websocket endpoint.
+func dialDeviceConsole(config *client.Config, deviceName, token string) (*websocket.Conn, error) {
 	connURL := fmt.Sprintf("%s/ws/v1/devices/%s/console", config.Service.Server, deviceName)
 	// replace https / http to wss / ws
 	connURL = strings.Replace(connURL, "http", "ws", 1)
 	headers := make(http.Header)
 	headers.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
-	fmt.Printf("Connecting to device %s\n", deviceName)
 	tlsConfig, err := client.CreateTLSConfigFromConfig(config)
 	if err != nil {
-		return fmt.Errorf("creating tls config: %w", err)
+		return nil, fmt.Errorf("creating tls config: %w", err)
 	}
 
 	dialer := &websocket.Dialer{
@@ -116,11 +126,9 @@ func (o *ConsoleOptions) connectViaWS(ctx context.Context, config *client.Config
 
 	conn, _, err := dialer.Dial(connURL, headers)
 	if err != nil {
-		return fmt.Errorf("dialing websocket: %w", err)
+		return nil, fmt.Errorf("dialing websocket: %w", err)
 	}
-	defer conn.Close()
-
-	return forwardStdio(ctx, conn)
+	return conn, nil
 }
 
 func forwardStdio(ctx context.Context, conn *websocket.Conn) error {
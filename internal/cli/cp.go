@@ -0,0 +1,443 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/gorilla/websocket"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	defaultCpMaxSize = "10MiB"
+	// cpMarker prefixes the lines printed on the device around a transfer, so that the shell
+	// prompts and errors printed over the console are told apart from the copied data.
+	cpMarker = "FLIGHTCTL_CP"
+	// cpHeredocDelimiter ends the base64 encoded data sent to the device, it cannot appear in it.
+	cpHeredocDelimiter = "FLIGHTCTL_CP_EOF"
+	// cpChunkSize is the number of bytes of a file sent in one console message, a multiple of the
+	// 57 bytes encoded in each line of 76 base64 characters.
+	cpChunkSize = 57 * 1024
+)
+
+type CpOptions struct {
+	GlobalOptions
+
+	MaxSize string
+}
+
+func DefaultCpOptions() *CpOptions {
+	return &CpOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		MaxSize:       defaultCpMaxSize,
+	}
+}
+
+func NewCmdCp() *cobra.Command {
+	o := DefaultCpOptions()
+
+	cmd := &cobra.Command{
+		Use:   "cp [device/]NAME:SRC DST | SRC [device/]NAME:DST",
+		Short: "Copy a file to or from the remote device through the server.",
+		Example: "  flightctl cp device/my-device:/etc/hostname ./hostname\n" +
+			"  flightctl cp ./app.conf my-device:/etc/app/",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+
+	o.Bind(cmd.Flags())
+
+	return cmd
+}
+
+func (o *CpOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVar(&o.MaxSize, "max-size", o.MaxSize, "The maximum size of the copied file, like 512KiB or 10MiB.")
+}
+
+func (o *CpOptions) Complete(cmd *cobra.Command, args []string) error {
+	return o.GlobalOptions.Complete(cmd, args)
+}
+
+func (o *CpOptions) Validate(args []string) error {
+	src, dst := parseCpPath(args[0]), parseCpPath(args[1])
+	if (src.device == "") == (dst.device == "") {
+		return fmt.Errorf("exactly one of the source and the destination must be on a device, like device/NAME:/path")
+	}
+	for _, p := range []cpPath{src, dst} {
+		if len(p.path) == 0 {
+			return fmt.Errorf("the source and the destination paths must not be empty")
+		}
+	}
+	if _, err := o.maxSize(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *CpOptions) maxSize() (int64, error) {
+	size, err := humanize.ParseBytes(o.MaxSize)
+	if err != nil || size == 0 {
+		return 0, fmt.Errorf("--max-size must be a positive size like 10MiB")
+	}
+	return int64(size), nil
+}
+
+func (o *CpOptions) Run(ctx context.Context, args []string) error {
+	config, err := client.ParseConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("parsing config file: %w", err)
+	}
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	src, dst := parseCpPath(args[0]), parseCpPath(args[1])
+	name := src.device + dst.device
+	maxSize, err := o.maxSize()
+	if err != nil {
+		return err
+	}
+
+	response, err := c.ReadDeviceWithResponse(ctx, name, nil)
+	if err != nil {
+		return fmt.Errorf("reading device: %w", err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("reading device: %s", response.HTTPResponse.Status)
+	}
+	if err := checkDeviceOnline(response.JSON200); err != nil {
+		return err
+	}
+
+	conn, err := dialDeviceConsole(config, name, config.AuthInfo.Token)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream := newConsoleStream(ctx, conn)
+	defer stream.close()
+	if src.device != "" {
+		return stream.pull(src.path, dst.path, maxSize, os.Stderr)
+	}
+	return stream.push(src.path, dst.path, maxSize, os.Stderr)
+}
+
+// cpPath is a path of the cp command, on the device if device is set or else local.
+type cpPath struct {
+	device string
+	path   string
+}
+
+// parseCpPath parses a path on a device, like device/NAME:/path or NAME:/path, or a local path.
+// Local paths containing a colon can be written as ./path.
+func parseCpPath(arg string) cpPath {
+	name, p, found := strings.Cut(arg, ":")
+	name = strings.TrimPrefix(name, DeviceKind+"/")
+	if !found || len(name) == 0 || strings.Contains(name, "/") {
+		return cpPath{path: arg}
+	}
+	return cpPath{device: name, path: p}
+}
+
+// consoleStream runs commands in the shell of a console session and reads their output.
+type consoleStream struct {
+	ctx    context.Context
+	conn   *websocket.Conn
+	pipe   *io.PipeReader
+	reader *bufio.Reader
+}
+
+func newConsoleStream(ctx context.Context, conn *websocket.Conn) *consoleStream {
+	reader, writer := io.Pipe()
+	go func() {
+		for {
+			msgType, frame, err := conn.ReadMessage()
+			if err != nil {
+				_ = writer.CloseWithError(err)
+				return
+			}
+			if msgType == websocket.BinaryMessage || msgType == websocket.TextMessage {
+				if _, err := writer.Write(frame); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	return &consoleStream{ctx: ctx, conn: conn, pipe: reader, reader: bufio.NewReader(reader)}
+}
+
+// close exits the shell of the console session.
+func (s *consoleStream) close() {
+	_ = s.send([]byte("exit\n"))
+	_ = s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second*5))
+	_ = s.pipe.Close()
+}
+
+func (s *consoleStream) send(data []byte) error {
+	if err := s.conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
+		return s.error(fmt.Errorf("writing to websocket: %w", err))
+	}
+	return nil
+}
+
+// error returns the cancellation of the context rather than the error of the closed connection.
+func (s *consoleStream) error(err error) error {
+	if s.ctx.Err() != nil {
+		return s.ctx.Err()
+	}
+	if errors.Is(err, io.EOF) || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		return fmt.Errorf("the console session was closed by the device")
+	}
+	return err
+}
+
+// waitMarker skips the output of the console until one of the markers is printed and returns it
+// with the value printed after it.
+func (s *consoleStream) waitMarker(names ...string) (string, string, error) {
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return "", "", s.error(err)
+		}
+		marker, value, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		for _, name := range names {
+			if marker == cpMarker+"_"+name {
+				return name, strings.TrimSpace(value), nil
+			}
+		}
+	}
+}
+
+// printMarker returns the shell command printing the marker and the value on a line of its own.
+// The marker is split in the command, so that it is not matched if the command is echoed.
+func printMarker(name, value string) string {
+	return fmt.Sprintf(`printf '\n%%s_%%s %%s\n' %s %s %s`, cpMarker, name, value)
+}
+
+// shellQuote quotes the string as a single word for the shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// pull copies the file at remotePath on the device to localPath, or into it if it is a directory.
+func (s *consoleStream) pull(remotePath, localPath string, maxSize int64, progress io.Writer) error {
+	command := fmt.Sprintf(`set +o history; f=%s; if [ -f "$f" ] && [ -r "$f" ]; then %s; else %s; fi`,
+		shellQuote(remotePath), printMarker("SIZE", `"$(wc -c < "$f")"`), printMarker("MISSING", "''"))
+	if err := s.send([]byte(command + "\n")); err != nil {
+		return err
+	}
+	marker, value, err := s.waitMarker("SIZE", "MISSING")
+	if err != nil {
+		return err
+	}
+	if marker == "MISSING" {
+		return fmt.Errorf("%s is not a readable file on the device", remotePath)
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("reading the size of %s on the device: %q", remotePath, value)
+	}
+	if size > maxSize {
+		return fmt.Errorf("%s is %s, more than the maximum of %s set by --max-size",
+			remotePath, humanize.IBytes(uint64(size)), humanize.IBytes(uint64(maxSize)))
+	}
+
+	if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+		localPath = filepath.Join(localPath, path.Base(remotePath))
+	}
+	file, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	err = s.pullData(remotePath, size, file, progress)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(localPath)
+	}
+	return err
+}
+
+func (s *consoleStream) pullData(remotePath string, size int64, out io.Writer, progress io.Writer) error {
+	command := fmt.Sprintf(`%s; cat "$f"; %s`, printMarker("DATA", "''"), printMarker("END", `"$?"`))
+	if err := s.send([]byte(command + "\n")); err != nil {
+		return err
+	}
+	if _, _, err := s.waitMarker("DATA"); err != nil {
+		return err
+	}
+	p := newProgressWriter(progress, path.Base(remotePath), size)
+	if _, err := io.CopyN(io.MultiWriter(out, p), s.reader, size); err != nil {
+		return s.error(err)
+	}
+	p.done()
+
+	// the end marker follows the data right away unless the file changed while it was read
+	line, err := s.reader.ReadString('\n')
+	if err != nil {
+		return s.error(err)
+	}
+	if line != "\n" {
+		return fmt.Errorf("%s changed on the device while it was copied", remotePath)
+	}
+	_, exitCode, err := s.waitMarker("END")
+	if err != nil {
+		return err
+	}
+	if exitCode != "0" {
+		return fmt.Errorf("reading %s on the device failed (exit code %s)", remotePath, exitCode)
+	}
+	return nil
+}
+
+// push copies the file at localPath to remotePath on the device, or into it if it is a directory.
+// The file is sent base64 encoded in a here-document, so that the shell never runs its content,
+// and moved into place once it is complete.
+func (s *consoleStream) push(localPath, remotePath string, maxSize int64, progress io.Writer) error {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a regular file", localPath)
+	}
+	if info.Size() > maxSize {
+		return fmt.Errorf("%s is %s, more than the maximum of %s set by --max-size",
+			localPath, humanize.IBytes(uint64(info.Size())), humanize.IBytes(uint64(maxSize)))
+	}
+
+	command := fmt.Sprintf(`set +o history; d=%s; [ -d "$d" ] && d="${d%%/}"/%s; t="$d.flightctl-cp.$$"; `+
+		`if command -v base64 >/dev/null && : > "$t"; then %s; else %s; fi`,
+		shellQuote(remotePath), shellQuote(filepath.Base(localPath)), printMarker("READY", "''"), printMarker("DENIED", "''"))
+	if err := s.send([]byte(command + "\n")); err != nil {
+		return err
+	}
+	marker, _, err := s.waitMarker("READY", "DENIED")
+	if err != nil {
+		return err
+	}
+	if marker == "DENIED" {
+		return fmt.Errorf("%s cannot be written on the device", remotePath)
+	}
+
+	command = fmt.Sprintf(`base64 -d > "$t" <<'%s' && [ "$(wc -c < "$t")" -eq %d ] && mv -f "$t" "$d"; r=$?; rm -f "$t"; %s`,
+		cpHeredocDelimiter, info.Size(), printMarker("END", `"$r"`))
+	if err := s.send([]byte(command + "\n")); err != nil {
+		return err
+	}
+	p := newProgressWriter(progress, filepath.Base(localPath), info.Size())
+	chunk := make([]byte, cpChunkSize)
+	for {
+		n, err := io.ReadFull(file, chunk)
+		if n > 0 {
+			if err := s.send(encodeLines(chunk[:n])); err != nil {
+				return err
+			}
+			_, _ = p.Write(chunk[:n])
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := s.send([]byte(cpHeredocDelimiter + "\n")); err != nil {
+		return err
+	}
+	p.done()
+
+	_, exitCode, err := s.waitMarker("END")
+	if err != nil {
+		return err
+	}
+	if exitCode != "0" {
+		return fmt.Errorf("writing %s on the device failed (exit code %s)", remotePath, exitCode)
+	}
+	return nil
+}
+
+// encodeLines base64 encodes the data in lines of 76 characters.
+func encodeLines(data []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var b strings.Builder
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76])
+		b.WriteByte('\n')
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded)
+	b.WriteByte('\n')
+	return []byte(b.String())
+}
+
+// progressWriter prints the progress of a transfer each time it advances by a percent.
+type progressWriter struct {
+	out     io.Writer
+	name    string
+	total   int64
+	written int64
+	percent int64
+}
+
+func newProgressWriter(out io.Writer, name string, total int64) *progressWriter {
+	return &progressWriter{out: out, name: name, total: total, percent: -1}
+}
+
+func (p *progressWriter) Write(data []byte) (int, error) {
+	p.written += int64(len(data))
+	percent := int64(100)
+	if p.total > 0 {
+		percent = p.written * 100 / p.total
+	}
+	if percent != p.percent {
+		p.percent = percent
+		fmt.Fprintf(p.out, "\r%s: %s / %s (%d%%)", p.name, humanize.IBytes(uint64(p.written)), humanize.IBytes(uint64(p.total)), percent)
+	}
+	return len(data), nil
+}
+
+// done ends the progress line.
+func (p *progressWriter) done() {
+	if p.percent < 0 {
+		_, _ = p.Write(nil)
+	}
+	fmt.Fprintln(p.out)
+}
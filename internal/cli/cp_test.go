@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// shellConsole serves a websocket that behaves like a device console: like the agent, it runs an
+// interactive bash with its stdin and merged stdout and stderr forwarded over the session.
+func shellConsole(t *testing.T, dir string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrading connection: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		cmd := exec.Command("bash", "--noprofile", "--norc", "-i")
+		cmd.Dir = dir
+		cmd.Env = []string{"HOME=" + dir, "PATH=" + os.Getenv("PATH"), "PS1=[root@device ~]# "}
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			t.Errorf("creating stdin pipe: %v", err)
+			return
+		}
+		output, writer := io.Pipe()
+		cmd.Stdout = writer
+		cmd.Stderr = writer
+		if err := cmd.Start(); err != nil {
+			t.Errorf("starting shell: %v", err)
+			return
+		}
+		go func() {
+			_ = cmd.Wait()
+			_ = writer.Close()
+		}()
+		go func() {
+			defer stdin.Close()
+			for {
+				_, message, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				if _, err := stdin.Write(message); err != nil {
+					return
+				}
+			}
+		}()
+
+		buf := make([]byte, 4096)
+		for {
+			n, err := output.Read(buf)
+			if n > 0 {
+				if err := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+		}
+	}))
+}
+
+func dialShellConsole(t *testing.T, dir string) *consoleStream {
+	server := shellConsole(t, dir)
+	t.Cleanup(server.Close)
+	conn := dialFakeConsole(require.New(t), server)
+	stream := newConsoleStream(context.Background(), conn)
+	t.Cleanup(func() {
+		stream.close()
+		conn.Close()
+	})
+	return stream
+}
+
+func TestCp(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash is not installed")
+	}
+	content := make([]byte, 300*1024)
+	rand.New(rand.NewSource(1)).Read(content)
+	content = append(content, "\nlast line without a newline"...)
+
+	t.Run("push and pull", func(t *testing.T) {
+		require := require.New(t)
+		deviceDir, localDir := t.TempDir(), t.TempDir()
+		stream := dialShellConsole(t, deviceDir)
+		local := filepath.Join(localDir, "data.bin")
+		require.NoError(os.WriteFile(local, content, 0600))
+
+		progress := &bytes.Buffer{}
+		require.NoError(stream.push(local, filepath.Join(deviceDir, "it's here.bin"), 1024*1024, progress))
+		pushed, err := os.ReadFile(filepath.Join(deviceDir, "it's here.bin"))
+		require.NoError(err)
+		require.Equal(content, pushed)
+		require.Contains(progress.String(), "data.bin: 300 KiB / 300 KiB (100%)")
+
+		// copied into a directory with the same name
+		require.NoError(stream.push(local, deviceDir+"/", 1024*1024, io.Discard))
+		pushed, err = os.ReadFile(filepath.Join(deviceDir, "data.bin"))
+		require.NoError(err)
+		require.Equal(content, pushed)
+
+		pulled := filepath.Join(localDir, "pulled.bin")
+		require.NoError(stream.pull(filepath.Join(deviceDir, "it's here.bin"), pulled, 1024*1024, io.Discard))
+		data, err := os.ReadFile(pulled)
+		require.NoError(err)
+		require.Equal(content, data)
+
+		pullDir := filepath.Join(localDir, "dir")
+		require.NoError(os.Mkdir(pullDir, 0700))
+		require.NoError(stream.pull(filepath.Join(deviceDir, "data.bin"), pullDir, 1024*1024, io.Discard))
+		data, err = os.ReadFile(filepath.Join(pullDir, "data.bin"))
+		require.NoError(err)
+		require.Equal(content, data)
+
+		// empty files
+		empty := filepath.Join(localDir, "empty")
+		require.NoError(os.WriteFile(empty, nil, 0600))
+		require.NoError(stream.push(empty, "empty-on-device", 1024, io.Discard))
+		info, err := os.Stat(filepath.Join(deviceDir, "empty-on-device"))
+		require.NoError(err)
+		require.Zero(info.Size())
+	})
+
+	t.Run("size limit", func(t *testing.T) {
+		require := require.New(t)
+		deviceDir, localDir := t.TempDir(), t.TempDir()
+		stream := dialShellConsole(t, deviceDir)
+		local := filepath.Join(localDir, "data.bin")
+		require.NoError(os.WriteFile(local, content, 0600))
+		require.NoError(os.WriteFile(filepath.Join(deviceDir, "data.bin"), content, 0600))
+
+		err := stream.push(local, "copy.bin", 100*1024, io.Discard)
+		require.ErrorContains(err, "more than the maximum of 100 KiB set by --max-size")
+		require.NoFileExists(filepath.Join(deviceDir, "copy.bin"))
+
+		err = stream.pull("data.bin", filepath.Join(localDir, "copy.bin"), 100*1024, io.Discard)
+		require.ErrorContains(err, "data.bin is 300 KiB, more than the maximum of 100 KiB set by --max-size")
+		require.NoFileExists(filepath.Join(localDir, "copy.bin"))
+
+		// the session is still usable
+		require.NoError(stream.pull("data.bin", filepath.Join(localDir, "copy.bin"), 1024*1024, io.Discard))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		require := require.New(t)
+		deviceDir, localDir := t.TempDir(), t.TempDir()
+		stream := dialShellConsole(t, deviceDir)
+		local := filepath.Join(localDir, "data.bin")
+		require.NoError(os.WriteFile(local, content, 0600))
+
+		err := stream.pull("missing", filepath.Join(localDir, "missing"), 1024*1024, io.Discard)
+		require.ErrorContains(err, "missing is not a readable file on the device")
+		require.NoFileExists(filepath.Join(localDir, "missing"))
+
+		err = stream.push(local, "no/such/dir/data.bin", 1024*1024, io.Discard)
+		require.ErrorContains(err, "no/such/dir/data.bin cannot be written on the device")
+
+		err = stream.push(filepath.Join(localDir, "missing"), "data.bin", 1024*1024, io.Discard)
+		require.ErrorIs(err, os.ErrNotExist)
+
+		// the shell never ran the content of the file
+		script := filepath.Join(localDir, "script.sh")
+		require.NoError(os.WriteFile(script, []byte("touch ran\n"), 0600))
+		require.NoError(stream.push(script, "script.sh", 1024, io.Discard))
+		require.NoFileExists(filepath.Join(deviceDir, "ran"))
+		require.FileExists(filepath.Join(deviceDir, "script.sh"))
+	})
+}
+
+func TestParseCpPath(t *testing.T) {
+	require := require.New(t)
+	require.Equal(cpPath{device: "dev", path: "/etc/hostname"}, parseCpPath("device/dev:/etc/hostname"))
+	require.Equal(cpPath{device: "dev", path: "/etc/hostname"}, parseCpPath("dev:/etc/hostname"))
+	require.Equal(cpPath{device: "dev", path: ""}, parseCpPath("dev:"))
+	require.Equal(cpPath{path: "./a:b"}, parseCpPath("./a:b"))
+	require.Equal(cpPath{path: "/tmp/a:b"}, parseCpPath("/tmp/a:b"))
+	require.Equal(cpPath{path: "local"}, parseCpPath("local"))
+
+	o := DefaultCpOptions()
+	require.NoError(o.Validate([]string{"dev:/etc/hostname", "."}))
+	require.ErrorContains(o.Validate([]string{"./a", "./b"}), "exactly one of the source and the destination")
+	require.ErrorContains(o.Validate([]string{"a:/x", "b:/y"}), "exactly one of the source and the destination")
+	require.ErrorContains(o.Validate([]string{"dev:", "."}), "must not be empty")
+	o.MaxSize = "lots"
+	require.ErrorContains(o.Validate([]string{"dev:/etc/hostname", "."}), "--max-size")
+}
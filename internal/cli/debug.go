@@ -0,0 +1,309 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+// maxDebugEvents is the number of most recent events included in a device debug report.
+const maxDebugEvents = 20
+
+var legalDebugOutputTypes = []string{jsonFormat, yamlFormat}
+
+func NewCmdDebug() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Gather the state of a resource for troubleshooting.",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(NewCmdDebugDevice())
+	return cmd
+}
+
+type DebugDeviceOptions struct {
+	GlobalOptions
+
+	Output string
+}
+
+func DefaultDebugDeviceOptions() *DebugDeviceOptions {
+	return &DebugDeviceOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Output:        "",
+	}
+}
+
+func NewCmdDebugDevice() *cobra.Command {
+	o := DefaultDebugDeviceOptions()
+	cmd := &cobra.Command{
+		Use:   "device NAME",
+		Short: "Print a report of the spec, status, conditions, recent events and rendered version of a device.",
+		Long: "Print a report of the spec, status, conditions, recent events and rendered version of a device, " +
+			"along with the logs last shipped by its agent, to share the full context of a device when troubleshooting it.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDeviceName(&o.GlobalOptions),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *DebugDeviceOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVarP(&o.Output, "output", "o", o.Output, fmt.Sprintf("Output format. One of: (%s). Defaults to a readable report.", strings.Join(legalDebugOutputTypes, ", ")))
+}
+
+func (o *DebugDeviceOptions) Complete(cmd *cobra.Command, args []string) error {
+	return o.GlobalOptions.Complete(cmd, args)
+}
+
+func (o *DebugDeviceOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+	if len(args[0]) == 0 {
+		return fmt.Errorf("device name is required")
+	}
+	if len(o.Output) > 0 && !slices.Contains(legalDebugOutputTypes, o.Output) {
+		return fmt.Errorf("output format must be one of (%s)", strings.Join(legalDebugOutputTypes, ", "))
+	}
+	return nil
+}
+
+func (o *DebugDeviceOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	report, err := collectDeviceReport(ctx, c, args[0], time.Now())
+	if err != nil {
+		return err
+	}
+	return o.printDeviceReport(os.Stdout, report)
+}
+
+// deviceReport is the state of a device gathered for troubleshooting.
+type deviceReport struct {
+	CollectedAt time.Time  `json:"collectedAt"`
+	Device      api.Device `json:"device"`
+	// RenderedVersion is the version of the spec last rendered for the device by the service,
+	// while the status of the device holds the version its agent applied
+	RenderedVersion string          `json:"renderedVersion,omitempty"`
+	Events          []deviceEvent   `json:"events"`
+	ShippedLogs     *api.DeviceLogs `json:"shippedLogs,omitempty"`
+	// Errors are the parts of the report that could not be collected
+	Errors []string `json:"errors,omitempty"`
+}
+
+// deviceEvent is a change of the device, either a transition of one of its conditions or a new
+// revision of its spec.
+type deviceEvent struct {
+	Time    time.Time `json:"time"`
+	Source  string    `json:"source"`
+	Message string    `json:"message"`
+}
+
+// collectDeviceReport gathers the report of the device. Only the device itself is required, the
+// parts of the report that cannot be collected are listed in the report instead.
+func collectDeviceReport(ctx context.Context, c *apiclient.ClientWithResponses, name string, now time.Time) (*deviceReport, error) {
+	response, err := c.ReadDeviceWithResponse(ctx, name, &api.ReadDeviceParams{})
+	if err != nil {
+		return nil, fmt.Errorf("reading device/%s: %w", name, err)
+	}
+	if err := validateHttpResponse(response.Body, response.StatusCode(), http.StatusOK); err != nil {
+		return nil, fmt.Errorf("reading device/%s: %w", name, err)
+	}
+	report := &deviceReport{CollectedAt: now, Device: *response.JSON200, Events: []deviceEvent{}}
+
+	rendered, err := c.GetRenderedDeviceSpecWithResponse(ctx, name, &api.GetRenderedDeviceSpecParams{})
+	switch {
+	case err != nil:
+		report.Errors = append(report.Errors, fmt.Sprintf("reading rendered spec: %v", err))
+	case rendered.StatusCode() == http.StatusNoContent:
+		// the spec of the device has not been rendered yet
+	case rendered.JSON200 != nil:
+		report.RenderedVersion = rendered.JSON200.RenderedVersion
+	default:
+		report.Errors = append(report.Errors, fmt.Sprintf("reading rendered spec: %v", validateHttpResponse(rendered.Body, rendered.StatusCode(), http.StatusOK)))
+	}
+
+	if report.Device.Status != nil {
+		for _, condition := range report.Device.Status.Conditions {
+			report.Events = append(report.Events, deviceEvent{
+				Time:    condition.LastTransitionTime,
+				Source:  "Condition",
+				Message: fmt.Sprintf("%s is %s (%s): %s", condition.Type, condition.Status, condition.Reason, condition.Message),
+			})
+		}
+	}
+	revisions, err := listRevisions(ctx, c, DeviceKind, name)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+	for _, revision := range revisions {
+		report.Events = append(report.Events, deviceEvent{
+			Time:    revision.Timestamp,
+			Source:  "Revision",
+			Message: fmt.Sprintf("spec revision %d applied by %s", revision.Revision, util.DefaultIfNil(revision.Actor, "the service")),
+		})
+	}
+	sort.SliceStable(report.Events, func(i, j int) bool { return report.Events[i].Time.After(report.Events[j].Time) })
+	if len(report.Events) > maxDebugEvents {
+		report.Events = report.Events[:maxDebugEvents]
+	}
+
+	logs, err := c.ReadDeviceLogsWithResponse(ctx, name)
+	switch {
+	case err != nil:
+		report.Errors = append(report.Errors, fmt.Sprintf("reading shipped logs: %v", err))
+	case logs.StatusCode() == http.StatusNotFound:
+		// the agent has not shipped any logs
+	case logs.JSON200 != nil:
+		report.ShippedLogs = logs.JSON200
+	default:
+		report.Errors = append(report.Errors, fmt.Sprintf("reading shipped logs: %v", validateHttpResponse(logs.Body, logs.StatusCode(), http.StatusOK)))
+	}
+	return report, nil
+}
+
+func (o *DebugDeviceOptions) printDeviceReport(out io.Writer, report *deviceReport) error {
+	switch o.Output {
+	case jsonFormat:
+		marshalled, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshalling report: %w", err)
+		}
+		fmt.Fprintln(out, string(marshalled))
+		return nil
+	case yamlFormat:
+		marshalled, err := yaml.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("marshalling report: %w", err)
+		}
+		fmt.Fprint(out, string(marshalled))
+		return nil
+	}
+
+	device := report.Device
+	status := device.Status
+	if status == nil {
+		status = &api.DeviceStatus{}
+	}
+	lastSeen := "<never>"
+	if !status.LastSeen.IsZero() {
+		lastSeen = status.LastSeen.Format(time.RFC3339)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintf(w, "Device:\t%s\n", util.DefaultIfNil(device.Metadata.Name, ""))
+	fmt.Fprintf(w, "Collected at:\t%s\n", report.CollectedAt.Format(time.RFC3339))
+	fmt.Fprintf(w, "Owner:\t%s\n", util.DefaultIfNil(device.Metadata.Owner, "<none>"))
+	fmt.Fprintf(w, "Labels:\t%s\n", strings.Join(util.LabelMapToArray(device.Metadata.Labels), ","))
+	fmt.Fprintf(w, "System:\t%s\t%s\n", status.Summary.Status, util.DefaultIfNil(status.Summary.Info, ""))
+	fmt.Fprintf(w, "Updated:\t%s\t%s\n", status.Updated.Status, util.DefaultIfNil(status.Updated.Info, ""))
+	fmt.Fprintf(w, "Applications:\t%s\t%s\n", status.ApplicationsSummary.Status, util.DefaultIfNil(status.ApplicationsSummary.Info, ""))
+	fmt.Fprintf(w, "Last seen:\t%s\n", lastSeen)
+	fmt.Fprintf(w, "Rendered version:\t%s (applied: %s)\n", util.DefaultString(report.RenderedVersion, "<none>"), util.DefaultString(status.Config.RenderedVersion, "<none>"))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "\nSpec:")
+	spec, err := yaml.Marshal(device.Spec)
+	if err != nil {
+		return fmt.Errorf("marshalling spec: %w", err)
+	}
+	printIndented(out, string(spec))
+
+	fmt.Fprintln(out, "\nConditions:")
+	w = tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tLAST TRANSITION\tMESSAGE")
+	for _, condition := range status.Conditions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason,
+			condition.LastTransitionTime.Format(time.RFC3339), condition.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "\nRecent events:")
+	w = tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "  TIME\tSOURCE\tMESSAGE")
+	for _, event := range report.Events {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", event.Time.Format(time.RFC3339), event.Source, event.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if report.ShippedLogs != nil {
+		fmt.Fprintf(out, "\nShipped logs (at %s, %s):\n", report.ShippedLogs.CollectedAt.Format(time.RFC3339), report.ShippedLogs.Reason)
+		printIndented(out, strings.Join(report.ShippedLogs.Lines, "\n"))
+	}
+
+	if len(report.Errors) > 0 {
+		fmt.Fprintln(out, "\nCould not collect:")
+		printIndented(out, strings.Join(report.Errors, "\n"))
+	}
+	return nil
+}
+
+func printIndented(out io.Writer, text string) {
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+}
+
+// completeDeviceName returns a cobra ValidArgsFunction completing a device name.
+func completeDeviceName(o *GlobalOptions) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+		defer cancel()
+		c, err := client.NewFromConfigFile(ConfigFilePath(o.Context))
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names, err := listResourceNames(ctx, c, DeviceKind)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		completions := []string{}
+		for _, name := range names {
+			if strings.HasPrefix(name, toComplete) {
+				completions = append(completions, name)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func debugTestServer(t *testing.T, shippedLogs bool) *httptest.Server {
+	updated := time.Date(2024, 10, 15, 10, 0, 0, 0, time.UTC)
+	device := api.Device{
+		ApiVersion: "v1alpha1",
+		Kind:       "Device",
+		Metadata: api.ObjectMeta{
+			Name:   util.StrToPtr("edge-1"),
+			Owner:  util.StrToPtr("Fleet/berlin"),
+			Labels: &map[string]string{"site": "berlin"},
+		},
+		Spec: &api.DeviceSpec{Os: &api.DeviceOsSpec{Image: "os:3"}},
+		Status: &api.DeviceStatus{
+			Config:   api.DeviceConfigStatus{RenderedVersion: "4"},
+			LastSeen: updated.Add(time.Hour),
+			Summary:  api.DeviceSummaryStatus{Status: api.DeviceSummaryStatusOnline},
+			Updated:  api.DeviceUpdatedStatus{Status: api.DeviceUpdatedStatusUpdating},
+			Conditions: []api.Condition{{
+				Type:               api.DeviceUpdating,
+				Status:             api.ConditionStatusTrue,
+				Reason:             "ApplyingUpdate",
+				Message:            "applying renderedVersion: 5",
+				LastTransitionTime: updated.Add(30 * time.Minute),
+			}},
+		},
+	}
+	revisions := api.ResourceRevisionList{Items: []api.ResourceRevision{
+		{Revision: 3, Timestamp: updated, Actor: util.StrToPtr("alice")},
+		{Revision: 2, Timestamp: updated.Add(-time.Hour)},
+	}}
+	logs := api.DeviceLogs{CollectedAt: updated, Reason: "UpdateFailed", Lines: []string{"starting", "failed"}}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/devices/edge-1":
+			require.NoError(t, json.NewEncoder(w).Encode(device))
+		case "/api/v1/devices/edge-1/rendered":
+			require.NoError(t, json.NewEncoder(w).Encode(api.RenderedDeviceSpec{RenderedVersion: "5"}))
+		case "/api/v1/devices/edge-1/revisions":
+			require.NoError(t, json.NewEncoder(w).Encode(revisions))
+		case "/api/v1/devices/edge-1/logs":
+			if !shippedLogs {
+				w.WriteHeader(http.StatusNotFound)
+				require.NoError(t, json.NewEncoder(w).Encode(api.Error{Message: "the device has not shipped any logs"}))
+				return
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(logs))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			require.NoError(t, json.NewEncoder(w).Encode(api.Error{Message: "not found"}))
+		}
+	}))
+}
+
+func TestCollectDeviceReport(t *testing.T) {
+	require := require.New(t)
+	server := debugTestServer(t, true)
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	now := time.Date(2024, 10, 15, 12, 0, 0, 0, time.UTC)
+	report, err := collectDeviceReport(context.Background(), c, "edge-1", now)
+	require.NoError(err)
+	require.Equal(now, report.CollectedAt)
+	require.Equal("os:3", report.Device.Spec.Os.Image)
+	require.Equal("5", report.RenderedVersion)
+	require.Equal([]string{"starting", "failed"}, report.ShippedLogs.Lines)
+	require.Empty(report.Errors)
+
+	// events are listed newest first
+	require.Len(report.Events, 3)
+	require.Equal("Condition", report.Events[0].Source)
+	require.Equal("Updating is True (ApplyingUpdate): applying renderedVersion: 5", report.Events[0].Message)
+	require.Equal("spec revision 3 applied by alice", report.Events[1].Message)
+	require.Equal("spec revision 2 applied by the service", report.Events[2].Message)
+
+	out := &bytes.Buffer{}
+	o := DefaultDebugDeviceOptions()
+	require.NoError(o.printDeviceReport(out, report))
+	require.Contains(out.String(), "Rendered version:")
+	require.Contains(out.String(), "5 (applied: 4)")
+	require.Contains(out.String(), "image: os:3")
+	require.Contains(out.String(), "Shipped logs (at 2024-10-15T10:00:00Z, UpdateFailed):\n  starting\n  failed\n")
+	require.NotContains(out.String(), "Could not collect")
+
+	out.Reset()
+	o.Output = jsonFormat
+	require.NoError(o.printDeviceReport(out, report))
+	decoded := deviceReport{}
+	require.NoError(json.Unmarshal(out.Bytes(), &decoded))
+	require.Equal("5", decoded.RenderedVersion)
+	require.Len(decoded.Events, 3)
+}
+
+func TestCollectDeviceReportPartial(t *testing.T) {
+	require := require.New(t)
+	server := debugTestServer(t, false)
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	// a device without shipped logs still gets a report
+	report, err := collectDeviceReport(context.Background(), c, "edge-1", time.Now())
+	require.NoError(err)
+	require.Nil(report.ShippedLogs)
+	require.Empty(report.Errors)
+
+	// but a device that cannot be read does not
+	_, err = collectDeviceReport(context.Background(), c, "edge-2", time.Now())
+	require.ErrorContains(err, "reading device/edge-2")
+}
+
+func TestDebugDeviceValidate(t *testing.T) {
+	o := DefaultDebugDeviceOptions()
+	o.ConfigFilePath = t.TempDir()
+	o.Output = "wide"
+	require.ErrorContains(t, o.Validate([]string{"edge-1"}), "output format must be one of (json, yaml)")
+}
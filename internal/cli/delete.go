@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
 	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/util"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -14,23 +18,25 @@ import (
 type DeleteOptions struct {
 	GlobalOptions
 
-	FleetName string
+	FleetName     string
+	LabelSelector string
 }
 
 func DefaultDeleteOptions() *DeleteOptions {
 	return &DeleteOptions{
 		GlobalOptions: DefaultGlobalOptions(),
 		FleetName:     "",
+		LabelSelector: "",
 	}
 }
 
 func NewCmdDelete() *cobra.Command {
 	o := DefaultDeleteOptions()
 	cmd := &cobra.Command{
-		Use:       "delete (TYPE | TYPE/NAME)",
-		Short:     "Delete resources by resources or owner.",
-		Args:      cobra.ExactArgs(1),
-		ValidArgs: getValidResourceKinds(),
+		Use:               "delete (TYPE | TYPE/NAME)",
+		Short:             "Delete resources by resources or owner.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeResourceArg(&o.GlobalOptions, getResourceKinds()),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := o.Complete(cmd, args); err != nil {
 				return err
@@ -50,6 +56,7 @@ func (o *DeleteOptions) Bind(fs *pflag.FlagSet) {
 	o.GlobalOptions.Bind(fs)
 
 	fs.StringVarP(&o.FleetName, "fleetname", "f", o.FleetName, "Fleet name for accessing templateversions.")
+	fs.StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supporting operators like '=', '==', and '!=' (e.g. -l key1=value1,key2=value2).")
 }
 
 func (o *DeleteOptions) Complete(cmd *cobra.Command, args []string) error {
@@ -65,13 +72,16 @@ func (o *DeleteOptions) Validate(args []string) error {
 		return err
 	}
 
-	kind, _, err := parseAndValidateKindName(args[0])
+	kind, name, err := parseAndValidateKindName(args[0])
 	if err != nil {
 		return err
 	}
 	if kind == TemplateVersionKind && len(o.FleetName) == 0 {
 		return fmt.Errorf("fleetname must be specified when deleting templateversions")
 	}
+	if len(o.LabelSelector) > 0 && (kind != DeviceKind || len(name) > 0) {
+		return fmt.Errorf("a selector can only be specified when deleting devices")
+	}
 	return nil
 }
 
@@ -92,7 +102,8 @@ func (o *DeleteOptions) Run(ctx context.Context, args []string) error { //nolint
 	case kind == DeviceKind && len(name) > 0:
 		response, err = c.DeleteDeviceWithResponse(ctx, name)
 	case kind == DeviceKind && len(name) == 0:
-		response, err = c.DeleteDevicesWithResponse(ctx)
+		params := api.DeleteDevicesParams{LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector)}
+		response, err = c.DeleteDevicesWithResponse(ctx, &params)
 	case kind == EnrollmentRequestKind && len(name) > 0:
 		response, err = c.DeleteEnrollmentRequestWithResponse(ctx, name)
 	case kind == EnrollmentRequestKind && len(name) == 0:
@@ -139,5 +150,13 @@ func processDeletionReponse(response interface{}, err error, kind string, name s
 		return fmt.Errorf(errorPrefix+": %s (%d)", v.FieldByName("HTTPResponse").Elem().FieldByName("Status").String(), v.FieldByName("HTTPResponse").Elem().FieldByName("StatusCode").Int())
 	}
 
+	if r, ok := response.(*apiclient.DeleteDevicesResponse); ok && r.JSON200 != nil && r.JSON200.Failures != nil && len(*r.JSON200.Failures) > 0 {
+		failures := []string{}
+		for _, failure := range *r.JSON200.Failures {
+			failures = append(failures, fmt.Sprintf("%s: %s", failure.Name, failure.Message))
+		}
+		return fmt.Errorf("%s: deleted %d, failed to delete %d: %s", errorPrefix, r.JSON200.Deleted, len(failures), strings.Join(failures, "; "))
+	}
+
 	return nil
 }
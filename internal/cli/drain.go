@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const drainPollInterval = 5 * time.Second
+
+type DrainOptions struct {
+	GlobalOptions
+
+	Timeout  time.Duration
+	Uncordon bool
+
+	pollInterval time.Duration
+}
+
+func DefaultDrainOptions() *DrainOptions {
+	return &DrainOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Timeout:       5 * time.Minute,
+		Uncordon:      false,
+		pollInterval:  drainPollInterval,
+	}
+}
+
+func NewCmdDrain() *cobra.Command {
+	o := DefaultDrainOptions()
+	cmd := &cobra.Command{
+		Use:               "drain device/NAME [--timeout=DURATION] [--uncordon]",
+		Short:             "Cordon a device so fleet rollouts skip it, and wait for its ongoing update to finish.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeResourceArg(&o.GlobalOptions, []string{DeviceKind}),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *DrainOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "How long to wait for the device to finish its ongoing update. Zero cordons the device without waiting.")
+	fs.BoolVar(&o.Uncordon, "uncordon", o.Uncordon, "Remove the cordon from the device, letting fleet rollouts update it again.")
+}
+
+func (o *DrainOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *DrainOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != DeviceKind {
+		return fmt.Errorf("kind must be Device")
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific device to drain")
+	}
+	if o.Timeout < 0 {
+		return fmt.Errorf("--timeout must not be negative")
+	}
+	return nil
+}
+
+func (o *DrainOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	return o.drain(ctx, c, os.Stdout, name)
+}
+
+// drain labels the device as cordoned, or removes the label when uncordoning, and then waits
+// until the device no longer reports an update in progress.
+func (o *DrainOptions) drain(ctx context.Context, c *apiclient.ClientWithResponses, out io.Writer, name string) error {
+	device, err := readDevice(ctx, c, name)
+	if err != nil {
+		return err
+	}
+
+	patch := cordonPatch(device, !o.Uncordon)
+	if len(patch) > 0 {
+		response, err := c.PatchDeviceWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx, name, patch)
+		if err != nil {
+			return fmt.Errorf("patching device/%s: %w", name, err)
+		}
+		if err := validateHttpResponse(response.Body, response.StatusCode(), http.StatusOK); err != nil {
+			return fmt.Errorf("patching device/%s: %w", name, err)
+		}
+	}
+
+	if o.Uncordon {
+		fmt.Fprintf(out, "device/%s uncordoned\n", name)
+		return nil
+	}
+	fmt.Fprintf(out, "device/%s cordoned\n", name)
+	if o.Timeout == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+
+	lastState := ""
+	for {
+		device, err := readDevice(ctx, c, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("device/%s did not finish updating within %s", name, o.Timeout)
+			}
+			return err
+		}
+		if !device.IsUpdating() {
+			fmt.Fprintf(out, "device/%s drained\n", name)
+			return nil
+		}
+		if state := updatingState(device); state != lastState {
+			fmt.Fprintf(out, "device/%s: waiting for the ongoing update to finish (%s)\n", name, state)
+			lastState = state
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("device/%s did not finish updating within %s", name, o.Timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// cordonPatch returns the JSON patch setting or removing the cordon label of the device, which is
+// empty if the device already is in the desired state.
+func cordonPatch(device *api.Device, cordon bool) api.PatchRequest {
+	if device.IsCordoned() == cordon {
+		return api.PatchRequest{}
+	}
+
+	var value interface{} = "true"
+	labelPath := "/metadata/labels/" + strings.ReplaceAll(api.DeviceLabelCordoned, "/", "~1")
+	if !cordon {
+		return api.PatchRequest{{Op: api.Remove, Path: labelPath}}
+	}
+	if device.Metadata.Labels == nil {
+		var labels interface{} = map[string]string{api.DeviceLabelCordoned: "true"}
+		return api.PatchRequest{{Op: api.Add, Path: "/metadata/labels", Value: &labels}}
+	}
+	return api.PatchRequest{{Op: api.Add, Path: labelPath, Value: &value}}
+}
+
+func updatingState(device *api.Device) string {
+	condition := api.FindStatusCondition(device.Status.Conditions, api.DeviceUpdating)
+	if condition == nil || len(condition.Reason) == 0 {
+		return "Updating"
+	}
+	return condition.Reason
+}
+
+func readDevice(ctx context.Context, c *apiclient.ClientWithResponses, name string) (*api.Device, error) {
+	response, err := c.ReadDeviceWithResponse(ctx, name, &api.ReadDeviceParams{})
+	if err != nil {
+		return nil, fmt.Errorf("reading device/%s: %w", name, err)
+	}
+	if err := validateHttpResponse(response.Body, response.StatusCode(), http.StatusOK); err != nil {
+		return nil, fmt.Errorf("reading device/%s: %w", name, err)
+	}
+
+	device := &api.Device{}
+	if err := json.Unmarshal(response.Body, device); err != nil {
+		return nil, fmt.Errorf("parsing device/%s: %w", name, err)
+	}
+	return device, nil
+}
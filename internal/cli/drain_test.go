@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCordonPatch(t *testing.T) {
+	labels := func(l map[string]string) *api.Device {
+		return &api.Device{Metadata: api.ObjectMeta{Name: util.StrToPtr("edge-1"), Labels: &l}}
+	}
+
+	tests := []struct {
+		name     string
+		device   *api.Device
+		cordon   bool
+		expected string
+	}{
+		{
+			name:     "cordon device without labels",
+			device:   &api.Device{Metadata: api.ObjectMeta{Name: util.StrToPtr("edge-1")}},
+			cordon:   true,
+			expected: `[{"op":"add","path":"/metadata/labels","value":{"flightctl.io/cordoned":"true"}}]`,
+		},
+		{
+			name:     "cordon device with labels",
+			device:   labels(map[string]string{"region": "eu"}),
+			cordon:   true,
+			expected: `[{"op":"add","path":"/metadata/labels/flightctl.io~1cordoned","value":"true"}]`,
+		},
+		{
+			name:     "cordon cordoned device",
+			device:   labels(map[string]string{api.DeviceLabelCordoned: "true"}),
+			cordon:   true,
+			expected: `[]`,
+		},
+		{
+			name:     "uncordon cordoned device",
+			device:   labels(map[string]string{api.DeviceLabelCordoned: "true", "region": "eu"}),
+			cordon:   false,
+			expected: `[{"op":"remove","path":"/metadata/labels/flightctl.io~1cordoned"}]`,
+		},
+		{
+			name:     "uncordon device that is not cordoned",
+			device:   labels(map[string]string{"region": "eu"}),
+			cordon:   false,
+			expected: `[]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := json.Marshal(cordonPatch(tt.device, tt.cordon))
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expected, string(patch))
+		})
+	}
+}
+
+// drainTestServer serves a device that reports an update in progress for the given number of
+// reads, and records the patches it receives.
+func drainTestServer(t *testing.T, updatingReads int, patches *[]api.PatchRequest) *httptest.Server {
+	var mu sync.Mutex
+	reads := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/devices/edge-1":
+			device := api.Device{
+				ApiVersion: "v1alpha1",
+				Kind:       "Device",
+				Metadata:   api.ObjectMeta{Name: util.StrToPtr("edge-1")},
+				Status:     &api.DeviceStatus{},
+			}
+			if reads > 0 && reads <= updatingReads {
+				device.Status.Conditions = []api.Condition{{
+					Type:   api.DeviceUpdating,
+					Status: api.ConditionStatusTrue,
+					Reason: string(api.UpdateStateApplyingUpdate),
+				}}
+			}
+			reads++
+			require.NoError(t, json.NewEncoder(w).Encode(device))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/devices/edge-1":
+			patch := api.PatchRequest{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+			*patches = append(*patches, patch)
+			require.NoError(t, json.NewEncoder(w).Encode(api.Device{}))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestDrainDevice(t *testing.T) {
+	require := require.New(t)
+	patches := []api.PatchRequest{}
+	server := drainTestServer(t, 2, &patches)
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	out := &bytes.Buffer{}
+	o := DefaultDrainOptions()
+	o.pollInterval = time.Millisecond
+	require.NoError(o.drain(context.Background(), c, out, "edge-1"))
+	require.Len(patches, 1)
+	require.Equal("/metadata/labels", patches[0][0].Path)
+	require.Equal("device/edge-1 cordoned\n"+
+		"device/edge-1: waiting for the ongoing update to finish (ApplyingUpdate)\n"+
+		"device/edge-1 drained\n", out.String())
+}
+
+func TestDrainDeviceTimeout(t *testing.T) {
+	require := require.New(t)
+	patches := []api.PatchRequest{}
+	server := drainTestServer(t, 1000, &patches)
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	o := DefaultDrainOptions()
+	o.pollInterval = time.Millisecond
+	o.Timeout = 20 * time.Millisecond
+	require.ErrorContains(o.drain(context.Background(), c, &bytes.Buffer{}, "edge-1"), "did not finish updating within 20ms")
+}
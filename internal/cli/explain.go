@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/spf13/cobra"
+)
+
+const explainWrapWidth = 80
+
+var explainSchemaNames = map[string]string{
+	DeviceKind:                    api.DeviceKind,
+	EnrollmentRequestKind:         api.EnrollmentRequestKind,
+	FleetKind:                     api.FleetKind,
+	RepositoryKind:                api.RepositoryKind,
+	ResourceSyncKind:              api.ResourceSyncKind,
+	TemplateVersionKind:           api.TemplateVersionKind,
+	CertificateSigningRequestKind: api.CertificateSigningRequestKind,
+}
+
+func NewCmdExplain() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain TYPE[.FIELD...]",
+		Short: "Describe the fields of a resource.",
+		Long: "Describe the fields of a resource, or of one of its nested fields given as a dot-separated path, " +
+			"from the API schema built into flightctl.",
+		Example: "  flightctl explain device\n" +
+			"  flightctl explain device.spec.applications",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: getResourceKinds(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return explain(os.Stdout, args[0])
+		},
+		SilenceUsage: true,
+	}
+	return cmd
+}
+
+// explain prints the description and the fields of the schema at the given path, e.g.
+// "device.spec.applications".
+func explain(out io.Writer, fieldPath string) error {
+	segments := strings.Split(fieldPath, ".")
+	kind, name, err := parseAndValidateKindName(segments[0])
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		return fmt.Errorf("explain describes resource types, not resources: %s", segments[0])
+	}
+
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		return fmt.Errorf("loading API schema: %w", err)
+	}
+	schemaName := explainSchemaNames[kind]
+	schema, ok := swagger.Components.Schemas[schemaName]
+	if !ok || schema.Value == nil {
+		return fmt.Errorf("no schema for resource kind: %s", kind)
+	}
+
+	fields := segments[1:]
+	for i, field := range fields {
+		property, ok := explainProperties(elementSchema(schema))[field]
+		if !ok {
+			return fmt.Errorf("field %q does not exist in %s", field, strings.Join(append([]string{schemaName}, fields[:i]...), "."))
+		}
+		schema = property
+	}
+
+	fmt.Fprintf(out, "KIND:     %s\n", schemaName)
+	fmt.Fprintf(out, "VERSION:  %s\n\n", api.DeviceAPIVersion)
+	if len(fields) > 0 {
+		fmt.Fprintf(out, "FIELD:    %s <%s>\n\n", fields[len(fields)-1], explainTypeName(schema))
+	}
+
+	fmt.Fprintln(out, "DESCRIPTION:")
+	description := schema.Value.Description
+	if description == "" {
+		description = elementSchema(schema).Value.Description
+	}
+	if description == "" {
+		description = "<empty>"
+	}
+	writeWrapped(out, description, "     ")
+	if values := schema.Value.Enum; len(values) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "ENUM:")
+		for _, value := range values {
+			fmt.Fprintf(out, "     %v\n", value)
+		}
+	}
+
+	properties := explainProperties(elementSchema(schema))
+	if len(properties) == 0 {
+		return nil
+	}
+	required := explainRequired(elementSchema(schema))
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "FIELDS:")
+	for _, name := range names {
+		property := properties[name]
+		marker := ""
+		if required[name] {
+			marker = " -required-"
+		}
+		fmt.Fprintf(out, "   %s\t<%s>%s\n", name, explainTypeName(property), marker)
+		description := property.Value.Description
+		if description == "" {
+			description = elementSchema(property).Value.Description
+		}
+		if description != "" {
+			writeWrapped(out, description, "     ")
+		}
+		fmt.Fprintln(out)
+	}
+	return nil
+}
+
+// elementSchema returns the schema of the elements of an array, or the schema itself.
+func elementSchema(schema *openapi3.SchemaRef) *openapi3.SchemaRef {
+	for schema.Value.Type.Is(openapi3.TypeArray) && schema.Value.Items != nil {
+		schema = schema.Value.Items
+	}
+	return schema
+}
+
+// explainProperties returns the properties of a schema, including those of the schemas it is
+// composed of, so that the fields of all the variants of a union are described.
+func explainProperties(schema *openapi3.SchemaRef) map[string]*openapi3.SchemaRef {
+	properties := map[string]*openapi3.SchemaRef{}
+	if schema == nil || schema.Value == nil {
+		return properties
+	}
+	for name, property := range schema.Value.Properties {
+		properties[name] = property
+	}
+	for _, composed := range [][]*openapi3.SchemaRef{schema.Value.AllOf, schema.Value.OneOf, schema.Value.AnyOf} {
+		for _, part := range composed {
+			for name, property := range explainProperties(part) {
+				if _, ok := properties[name]; !ok {
+					properties[name] = property
+				}
+			}
+		}
+	}
+	return properties
+}
+
+// explainRequired returns the required properties of a schema, including those of the schemas
+// it must satisfy all of.
+func explainRequired(schema *openapi3.SchemaRef) map[string]bool {
+	required := map[string]bool{}
+	if schema == nil || schema.Value == nil {
+		return required
+	}
+	for _, name := range schema.Value.Required {
+		required[name] = true
+	}
+	for _, part := range schema.Value.AllOf {
+		for name := range explainRequired(part) {
+			required[name] = true
+		}
+	}
+	return required
+}
+
+func explainTypeName(schema *openapi3.SchemaRef) string {
+	if schema.Ref != "" {
+		return path.Base(schema.Ref)
+	}
+	value := schema.Value
+	switch {
+	case value.Type.Is(openapi3.TypeArray) && value.Items != nil:
+		return "[]" + explainTypeName(value.Items)
+	case value.AdditionalProperties.Schema != nil:
+		return "map[string]" + explainTypeName(value.AdditionalProperties.Schema)
+	case value.Type.Is(openapi3.TypeObject) || len(value.Type.Slice()) == 0:
+		return "Object"
+	default:
+		return strings.Join(value.Type.Slice(), "|")
+	}
+}
+
+// writeWrapped writes the text indented and wrapped at explainWrapWidth columns.
+func writeWrapped(out io.Writer, text string, indent string) {
+	line := indent
+	for _, word := range strings.Fields(text) {
+		if line != indent && len(line)+1+len(word) > explainWrapWidth {
+			fmt.Fprintln(out, line)
+			line = indent
+		}
+		if line != indent {
+			line += " "
+		}
+		line += word
+	}
+	fmt.Fprintln(out, line)
+}
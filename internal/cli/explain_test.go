@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainResource(t *testing.T) {
+	require := require.New(t)
+	out := &bytes.Buffer{}
+	require.NoError(explain(out, "devices"))
+	require.Contains(out.String(), "KIND:     Device\nVERSION:  v1alpha1\n")
+	require.Contains(out.String(), "DESCRIPTION:\n     Device represents a physical device.\n")
+	require.Contains(out.String(), "   metadata\t<ObjectMeta> -required-\n")
+	require.Contains(out.String(), "   spec\t<DeviceSpec>\n     DeviceSpec describes a device.\n")
+	require.NotContains(out.String(), "FIELD: ")
+}
+
+func TestExplainNestedField(t *testing.T) {
+	require := require.New(t)
+	out := &bytes.Buffer{}
+	require.NoError(explain(out, "dev.spec.applications"))
+	require.Contains(out.String(), "FIELD:    applications <[]ApplicationSpec>\n")
+	require.Contains(out.String(), "DESCRIPTION:\n     List of applications.\n")
+	// the fields of the schemas the application spec is composed of
+	require.Contains(out.String(), "   envVars\t<map[string]string>\n")
+	require.Contains(out.String(), "   image\t<string>\n")
+	require.Contains(out.String(), "   name\t<string>\n")
+
+	out.Reset()
+	require.NoError(explain(out, "device.status.summary.status"))
+	require.Contains(out.String(), "ENUM:\n")
+	require.Contains(out.String(), "     Online\n")
+}
+
+func TestExplainErrors(t *testing.T) {
+	require := require.New(t)
+	out := &bytes.Buffer{}
+	require.ErrorContains(explain(out, "widget"), "invalid resource kind")
+	require.ErrorContains(explain(out, "device/my-device"), "not resources")
+	require.ErrorContains(explain(out, "device.spec.nope"), `field "nope" does not exist in Device.spec`)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"reflect"
 	"slices"
 	"strings"
 	"text/tabwriter"
@@ -34,15 +36,25 @@ var (
 type GetOptions struct {
 	GlobalOptions
 
-	LabelSelector string
-	FieldSelector string
-	Output        string
-	Limit         int32
-	Continue      string
-	FleetName     string
-	Rendered      bool
-	Summary       bool
-	SummaryOnly   bool
+	LabelSelector  string
+	FieldSelector  string
+	Output         string
+	Limit          int32
+	Continue       string
+	ChunkSize      int32
+	FleetName      string
+	Rendered       bool
+	Summary        bool
+	SummaryOnly    bool
+	GroupByLabel   string
+	NeedsAttention bool
+	// ShowManagedFields displays the fields owned by each field manager of server-side apply.
+	ShowManagedFields bool
+	// ShowOwner restricts the displayed managed fields to the ones of this field manager.
+	ShowOwner string
+
+	// omitHeaders is set once the table headers were printed with a previous chunk
+	omitHeaders bool
 }
 
 func DefaultGetOptions() *GetOptions {
@@ -60,10 +72,10 @@ func DefaultGetOptions() *GetOptions {
 func NewCmdGet() *cobra.Command {
 	o := DefaultGetOptions()
 	cmd := &cobra.Command{
-		Use:       "get (TYPE | TYPE/NAME)",
-		Short:     "Display one or many resources.",
-		Args:      cobra.ExactArgs(1),
-		ValidArgs: getValidResourceKinds(),
+		Use:               "get (TYPE | TYPE/NAME)",
+		Short:             "Display one or many resources.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeResourceArg(&o.GlobalOptions, getResourceKinds()),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := o.Complete(cmd, args); err != nil {
 				return err
@@ -84,13 +96,18 @@ func (o *GetOptions) Bind(fs *pflag.FlagSet) {
 
 	fs.StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supporting operators like '=', '!=', and 'in' (e.g., -l='key1=value1,key2!=value2,key3 in (value3, value4)').")
 	fs.StringVar(&o.FieldSelector, "field-selector", o.FieldSelector, "Selector (field query) to filter on, supporting operators like '=', '==', and '!=' (e.g., --field-selector='key1=value1,key2!=value2').")
-	fs.StringVarP(&o.Output, "output", "o", o.Output, fmt.Sprintf("Output format. One of: (%s).", strings.Join(legalOutputTypes, ", ")))
+	fs.StringVarP(&o.Output, "output", "o", o.Output, fmt.Sprintf("Output format. One of: (%s, jsonpath=TEMPLATE, custom-columns=SPEC).", strings.Join(legalOutputTypes, ", ")))
 	fs.Int32Var(&o.Limit, "limit", o.Limit, "The maximum number of results returned in the list response.")
 	fs.StringVar(&o.Continue, "continue", o.Continue, "Query more results starting from the value of the 'continue' field in the previous response.")
+	fs.Int32Var(&o.ChunkSize, "chunk-size", o.ChunkSize, "List the resources in chunks of this size, printing each chunk before fetching the next one to bound memory use with large lists. The whole list is fetched at once when 0.")
 	fs.StringVar(&o.FleetName, "fleetname", o.FleetName, "Fleet name for accessing templateversions (use only when getting templateversions).")
 	fs.BoolVar(&o.Rendered, "rendered", false, "Return the rendered device configuration that is presented to the device (use only when getting devices).")
 	fs.BoolVarP(&o.Summary, "summary", "s", false, "Display summary information.")
 	fs.BoolVar(&o.SummaryOnly, "summary-only", false, "Display summary information only.")
+	fs.StringVar(&o.GroupByLabel, "group-by-label", o.GroupByLabel, "Break down the devices of the summary by the values of the label with this key (use only with --summary-only).")
+	fs.BoolVar(&o.NeedsAttention, "needs-attention", false, "List only the devices reporting a non-healthy status or a failed condition (use only when listing devices).")
+	fs.BoolVar(&o.ShowManagedFields, "show-managed-fields", false, "Display the fields owned by each field manager of server-side apply, which are omitted from JSON and YAML output otherwise.")
+	fs.StringVar(&o.ShowOwner, "show-owner", o.ShowOwner, "Display only the managed fields owned by this field manager (implies --show-managed-fields).")
 }
 
 func (o *GetOptions) Complete(cmd *cobra.Command, args []string) error {
@@ -102,6 +119,9 @@ func (o *GetOptions) Complete(cmd *cobra.Command, args []string) error {
 	if o.Rendered && len(o.Output) == 0 {
 		o.Output = jsonFormat
 	}
+	if len(o.ShowOwner) > 0 {
+		o.ShowManagedFields = true
+	}
 	return nil
 }
 
@@ -133,11 +153,30 @@ func (o *GetOptions) Validate(args []string) error {
 			}
 		}
 	}
+	if len(o.GroupByLabel) > 0 && !o.SummaryOnly {
+		return fmt.Errorf("group-by-label must only be specified with 'summary-only'")
+	}
+	if o.NeedsAttention {
+		if kind != DeviceKind || len(name) > 0 {
+			return fmt.Errorf("needs-attention must only be specified when listing devices")
+		}
+		if o.SummaryOnly {
+			return fmt.Errorf("needs-attention is not supported when 'summary-only' is specified")
+		}
+	}
 	if kind == TemplateVersionKind && len(o.FleetName) == 0 {
 		return fmt.Errorf("fleetname must be specified when fetching templateversions")
 	}
-	if len(o.Output) > 0 && !slices.Contains(legalOutputTypes, o.Output) {
-		return fmt.Errorf("output format must be one of (%s)", strings.Join(legalOutputTypes, ", "))
+	if format, arg, ok := parseTemplateOutput(o.Output); ok {
+		if format == customColumnsFormat {
+			if _, err := parseCustomColumns(arg); err != nil {
+				return err
+			}
+		} else if _, err := parseJSONPath("output", arg); err != nil {
+			return err
+		}
+	} else if len(o.Output) > 0 && !slices.Contains(legalOutputTypes, o.Output) {
+		return fmt.Errorf("output format must be one of (%s, jsonpath=TEMPLATE, custom-columns=SPEC)", strings.Join(legalOutputTypes, ", "))
 	}
 	if o.Rendered {
 		if kind != DeviceKind || len(name) == 0 {
@@ -147,9 +186,37 @@ func (o *GetOptions) Validate(args []string) error {
 			return fmt.Errorf("rendered output must be one of (json, yaml)")
 		}
 	}
+	if o.ShowManagedFields || len(o.ShowOwner) > 0 {
+		if o.Rendered || o.SummaryOnly {
+			return fmt.Errorf("show-managed-fields is not supported when 'rendered' or 'summary-only' is specified")
+		}
+		if _, _, ok := parseTemplateOutput(o.Output); ok {
+			return fmt.Errorf("show-managed-fields is not supported with jsonpath and custom-columns output, which can select the managed fields themselves")
+		}
+		if o.ChunkSize > 0 && o.Output != jsonFormat && o.Output != yamlFormat {
+			return fmt.Errorf("show-managed-fields is only supported with json and yaml output when 'chunk-size' is specified")
+		}
+	}
 	if o.Limit < 0 {
 		return fmt.Errorf("limit must be greater than 0")
 	}
+	if o.ChunkSize < 0 {
+		return fmt.Errorf("chunk-size must be greater than or equal to 0")
+	}
+	if o.ChunkSize > 0 {
+		if len(name) > 0 {
+			return fmt.Errorf("cannot specify chunk-size when fetching a single resource")
+		}
+		if o.Limit > 0 || len(o.Continue) > 0 {
+			return fmt.Errorf("flags such as 'limit' and 'continue' are not supported when 'chunk-size' is specified")
+		}
+		if o.Summary || o.SummaryOnly {
+			return fmt.Errorf("summary is not supported when 'chunk-size' is specified")
+		}
+		if _, _, ok := parseTemplateOutput(o.Output); ok {
+			return fmt.Errorf("jsonpath and custom-columns output are not supported when 'chunk-size' is specified")
+		}
+	}
 	return nil
 }
 
@@ -166,120 +233,161 @@ func (o *GetOptions) Run(ctx context.Context, args []string) error { //nolint:go
 		return err
 	}
 	switch {
-	case kind == DeviceKind && len(name) > 0 && !o.Rendered:
-		response, err = c.ReadDeviceWithResponse(ctx, name)
-	case kind == DeviceKind && len(name) > 0 && o.Rendered:
+	case len(name) == 0 && o.ChunkSize > 0:
+		return o.listInChunks(ctx, c, os.Stdout, kind)
+	case len(name) == 0:
+		response, err = o.list(ctx, c, kind, o.Limit, o.Continue)
+	case kind == DeviceKind && !o.Rendered:
+		response, err = c.ReadDeviceWithResponse(ctx, name, nil)
+	case kind == DeviceKind && o.Rendered:
 		response, err = c.GetRenderedDeviceSpecWithResponse(ctx, name, &api.GetRenderedDeviceSpecParams{})
-	case kind == DeviceKind && len(name) == 0:
+	case kind == EnrollmentRequestKind:
+		response, err = c.ReadEnrollmentRequestWithResponse(ctx, name)
+	case kind == FleetKind:
+		response, err = c.ReadFleetWithResponse(ctx, name, nil)
+	case kind == TemplateVersionKind:
+		response, err = c.ReadTemplateVersionWithResponse(ctx, o.FleetName, name)
+	case kind == RepositoryKind:
+		response, err = c.ReadRepositoryWithResponse(ctx, name)
+	case kind == ResourceSyncKind:
+		response, err = c.ReadResourceSyncWithResponse(ctx, name)
+	case kind == CertificateSigningRequestKind:
+		response, err = c.ReadCertificateSigningRequestWithResponse(ctx, name)
+	default:
+		return fmt.Errorf("unsupported resource kind: %s", kind)
+	}
+	return o.processReponse(response, err, kind, name)
+}
+
+// list fetches up to limit resources of the kind, starting from the given continue token.
+func (o *GetOptions) list(ctx context.Context, c *apiclient.ClientWithResponses, kind string, limit int32, cont string) (interface{}, error) {
+	switch kind {
+	case DeviceKind:
 		params := api.ListDevicesParams{
 			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
 			FieldSelector: util.StrToPtrWithNilDefault(o.FieldSelector),
-			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
-			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+			Limit:         util.Int32ToPtrWithNilDefault(limit),
+			Continue:      util.StrToPtrWithNilDefault(cont),
 			SummaryOnly:   util.BoolToPtr(o.SummaryOnly),
+			GroupByLabel:  util.StrToPtrWithNilDefault(o.GroupByLabel),
 		}
-		response, err = c.ListDevicesWithResponse(ctx, &params)
-	case kind == EnrollmentRequestKind && len(name) > 0:
-		response, err = c.ReadEnrollmentRequestWithResponse(ctx, name)
-	case kind == EnrollmentRequestKind && len(name) == 0:
+		if o.NeedsAttention {
+			params.NeedsAttention = util.BoolToPtr(true)
+		}
+		return c.ListDevicesWithResponse(ctx, &params)
+	case EnrollmentRequestKind:
 		params := api.ListEnrollmentRequestsParams{
 			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
 			FieldSelector: util.StrToPtrWithNilDefault(o.FieldSelector),
-			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
-			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+			Limit:         util.Int32ToPtrWithNilDefault(limit),
+			Continue:      util.StrToPtrWithNilDefault(cont),
 		}
-		response, err = c.ListEnrollmentRequestsWithResponse(ctx, &params)
-	case kind == FleetKind && len(name) > 0:
-		response, err = c.ReadFleetWithResponse(ctx, name, nil)
-	case kind == FleetKind && len(name) == 0:
+		return c.ListEnrollmentRequestsWithResponse(ctx, &params)
+	case FleetKind:
 		params := api.ListFleetsParams{
 			LabelSelector:   util.StrToPtrWithNilDefault(o.LabelSelector),
 			FieldSelector:   util.StrToPtrWithNilDefault(o.FieldSelector),
-			Limit:           util.Int32ToPtrWithNilDefault(o.Limit),
-			Continue:        util.StrToPtrWithNilDefault(o.Continue),
+			Limit:           util.Int32ToPtrWithNilDefault(limit),
+			Continue:        util.StrToPtrWithNilDefault(cont),
 			AddDevicesCount: util.BoolToPtr(true),
 		}
-		response, err = c.ListFleetsWithResponse(ctx, &params)
-	case kind == TemplateVersionKind && len(name) > 0:
-		response, err = c.ReadTemplateVersionWithResponse(ctx, o.FleetName, name)
-	case kind == TemplateVersionKind && len(name) == 0:
+		return c.ListFleetsWithResponse(ctx, &params)
+	case TemplateVersionKind:
 		params := api.ListTemplateVersionsParams{
 			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
 			FieldSelector: util.StrToPtrWithNilDefault(o.FieldSelector),
-			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
-			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+			Limit:         util.Int32ToPtrWithNilDefault(limit),
+			Continue:      util.StrToPtrWithNilDefault(cont),
 		}
-		response, err = c.ListTemplateVersionsWithResponse(ctx, o.FleetName, &params)
-	case kind == RepositoryKind && len(name) > 0:
-		response, err = c.ReadRepositoryWithResponse(ctx, name)
-	case kind == RepositoryKind && len(name) == 0:
+		return c.ListTemplateVersionsWithResponse(ctx, o.FleetName, &params)
+	case RepositoryKind:
 		params := api.ListRepositoriesParams{
 			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
 			FieldSelector: util.StrToPtrWithNilDefault(o.FieldSelector),
-			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
-			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+			Limit:         util.Int32ToPtrWithNilDefault(limit),
+			Continue:      util.StrToPtrWithNilDefault(cont),
 		}
-		response, err = c.ListRepositoriesWithResponse(ctx, &params)
-	case kind == ResourceSyncKind && len(name) > 0:
-		response, err = c.ReadResourceSyncWithResponse(ctx, name)
-	case kind == ResourceSyncKind && len(name) == 0:
+		return c.ListRepositoriesWithResponse(ctx, &params)
+	case ResourceSyncKind:
 		params := api.ListResourceSyncParams{
 			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
 			FieldSelector: util.StrToPtrWithNilDefault(o.FieldSelector),
-			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
-			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+			Limit:         util.Int32ToPtrWithNilDefault(limit),
+			Continue:      util.StrToPtrWithNilDefault(cont),
 		}
-		response, err = c.ListResourceSyncWithResponse(ctx, &params)
-	case kind == CertificateSigningRequestKind && len(name) > 0:
-		response, err = c.ReadCertificateSigningRequestWithResponse(ctx, name)
-	case kind == CertificateSigningRequestKind && len(name) == 0:
+		return c.ListResourceSyncWithResponse(ctx, &params)
+	case CertificateSigningRequestKind:
 		params := api.ListCertificateSigningRequestsParams{
 			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
 			FieldSelector: util.StrToPtrWithNilDefault(o.FieldSelector),
-			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
-			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+			Limit:         util.Int32ToPtrWithNilDefault(limit),
+			Continue:      util.StrToPtrWithNilDefault(cont),
 		}
-		response, err = c.ListCertificateSigningRequestsWithResponse(ctx, &params)
+		return c.ListCertificateSigningRequestsWithResponse(ctx, &params)
 	default:
-		return fmt.Errorf("unsupported resource kind: %s", kind)
+		return nil, fmt.Errorf("unsupported resource kind: %s", kind)
 	}
-	return o.processReponse(response, err, kind, name)
 }
 
-func (o *GetOptions) processReponse(response interface{}, err error, kind string, name string) error {
-	errorPrefix := fmt.Sprintf("reading %s/%s", kind, name)
-	if len(name) == 0 {
-		errorPrefix = fmt.Sprintf("listing %s", plural(kind))
-	}
+// listInChunks lists the resources ChunkSize at a time and prints each chunk before fetching the
+// next one, so that the memory used does not grow with the number of resources. The output is the
+// same as when the list is fetched at once, except that the columns of a table are aligned per
+// chunk.
+func (o *GetOptions) listInChunks(ctx context.Context, c *apiclient.ClientWithResponses, out io.Writer, kind string) error {
+	printer := &listPrinter{out: out, format: o.Output}
+	cont := ""
+	for {
+		response, err := o.list(ctx, c, kind, o.ChunkSize, cont)
+		json200, err := checkResponse(response, err, kind, "")
+		if err != nil {
+			return err
+		}
+		switch o.Output {
+		case jsonFormat, yamlFormat:
+			o.filterManagedFields(json200)
+			err = printer.printChunk(json200)
+		default:
+			err = o.printTable(out, response, kind, "")
+			o.omitHeaders = true
+		}
+		if err != nil {
+			return err
+		}
 
-	if err != nil {
-		return fmt.Errorf(errorPrefix+": %w", err)
+		metadata, err := responseField[api.ListMeta](json200, "Metadata")
+		if err != nil {
+			return err
+		}
+		if metadata.Continue == nil || len(*metadata.Continue) == 0 {
+			break
+		}
+		cont = *metadata.Continue
 	}
-
-	httpResponse, err := responseField[*http.Response](response, "HTTPResponse")
-	if err != nil {
-		return err
+	if o.Output == jsonFormat || o.Output == yamlFormat {
+		return printer.finish()
 	}
+	return nil
+}
 
-	responseBody, err := responseField[[]byte](response, "Body")
+func (o *GetOptions) processReponse(response interface{}, err error, kind string, name string) error {
+	json200, err := checkResponse(response, err, kind, name)
 	if err != nil {
 		return err
 	}
 
-	if httpResponse.StatusCode != http.StatusOK {
-		if strings.Contains(httpResponse.Header.Get("Content-Type"), "json") {
-			var dest api.Error
-			if err := json.Unmarshal(responseBody, &dest); err != nil {
-				return fmt.Errorf("unmarshalling error: %w", err)
-			}
-			return fmt.Errorf(errorPrefix+": %d, message: %s", httpResponse.StatusCode, dest.Message)
+	if format, arg, ok := parseTemplateOutput(o.Output); ok {
+		if format == customColumnsFormat {
+			return printCustomColumns(os.Stdout, arg, json200)
 		}
-		return fmt.Errorf(errorPrefix+": %d", httpResponse.StatusCode)
+		if err := printJSONPath(os.Stdout, arg, json200); err != nil {
+			return err
+		}
+		fmt.Println()
+		return nil
 	}
 
-	json200, err := responseField[interface{}](response, "JSON200")
-	if err != nil {
-		return err
+	if o.Output == jsonFormat || o.Output == yamlFormat {
+		o.filterManagedFields(json200)
 	}
 
 	switch o.Output {
@@ -298,13 +406,55 @@ func (o *GetOptions) processReponse(response interface{}, err error, kind string
 		fmt.Printf("%s\n", string(marshalled))
 		return nil
 	default:
-		return o.printTable(response, kind, name)
+		if err := o.printTable(os.Stdout, response, kind, name); err != nil {
+			return err
+		}
+		if o.ShowManagedFields {
+			fmt.Println()
+			o.printManagedFieldsTable(os.Stdout, json200)
+		}
+		return nil
 	}
 }
 
+// checkResponse returns the body of a successful response, or the error the request failed with.
+func checkResponse(response interface{}, err error, kind string, name string) (interface{}, error) {
+	errorPrefix := fmt.Sprintf("reading %s/%s", kind, name)
+	if len(name) == 0 {
+		errorPrefix = fmt.Sprintf("listing %s", plural(kind))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(errorPrefix+": %w", err)
+	}
+
+	httpResponse, err := responseField[*http.Response](response, "HTTPResponse")
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := responseField[[]byte](response, "Body")
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		if strings.Contains(httpResponse.Header.Get("Content-Type"), "json") {
+			var dest api.Error
+			if err := json.Unmarshal(responseBody, &dest); err != nil {
+				return nil, fmt.Errorf("unmarshalling error: %w", err)
+			}
+			return nil, fmt.Errorf(errorPrefix+": %d, message: %s", httpResponse.StatusCode, dest.Message)
+		}
+		return nil, fmt.Errorf(errorPrefix+": %d", httpResponse.StatusCode)
+	}
+
+	return responseField[interface{}](response, "JSON200")
+}
+
 //nolint:gocyclo
-func (o *GetOptions) printTable(response interface{}, kind string, name string) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+func (o *GetOptions) printTable(out io.Writer, response interface{}, kind string, name string) error {
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
 	switch {
 	case kind == DeviceKind && len(name) == 0:
 		if o.SummaryOnly {
@@ -355,6 +505,13 @@ func (o *GetOptions) printNewLine(w *tabwriter.Writer) {
 	fmt.Fprintln(w)
 }
 
+// Helper function to print the header of a table, unless it was printed with a previous chunk
+func (o *GetOptions) printHeader(w *tabwriter.Writer, header string) {
+	if !o.omitHeaders {
+		fmt.Fprintln(w, header)
+	}
+}
+
 func (o *GetOptions) printDevicesSummaryTable(w *tabwriter.Writer, summary *api.DevicesSummary) {
 	fmt.Fprintln(w, "DEVICES")
 	fmt.Fprintf(w, "%s\n", fmt.Sprintf("%d", summary.Total))
@@ -372,13 +529,25 @@ func (o *GetOptions) printDevicesSummaryTable(w *tabwriter.Writer, summary *api.
 	for k, v := range summary.ApplicationStatus {
 		fmt.Fprintf(w, "%s\t%s\t%s\n", "APPLICATIONS", k, fmt.Sprintf("%d", v))
 	}
+
+	if summary.LabelValues != nil {
+		fmt.Fprintf(w, "\n%s\tCOUNT\n", strings.ToUpper(o.GroupByLabel))
+		values := make([]string, 0, len(*summary.LabelValues))
+		for value := range *summary.LabelValues {
+			values = append(values, value)
+		}
+		slices.Sort(values)
+		for _, value := range values {
+			fmt.Fprintf(w, "%s\t%d\n", value, (*summary.LabelValues)[value])
+		}
+	}
 }
 
 func (o *GetOptions) printDevicesTable(w *tabwriter.Writer, devices ...api.Device) {
 	if o.Output == wideFormat {
-		fmt.Fprintln(w, "NAME\tALIAS\tOWNER\tSYSTEM\tUPDATED\tAPPLICATIONS\tLAST SEEN\tLABELS")
+		o.printHeader(w, "NAME\tALIAS\tOWNER\tSYSTEM\tUPDATED\tAPPLICATIONS\tLAST SEEN\tLABELS")
 	} else {
-		fmt.Fprintln(w, "NAME\tALIAS\tOWNER\tSYSTEM\tUPDATED\tAPPLICATIONS\tLAST SEEN")
+		o.printHeader(w, "NAME\tALIAS\tOWNER\tSYSTEM\tUPDATED\tAPPLICATIONS\tLAST SEEN")
 	}
 	for _, d := range devices {
 		lastSeen := "<never>"
@@ -407,7 +576,7 @@ func (o *GetOptions) printDevicesTable(w *tabwriter.Writer, devices ...api.Devic
 }
 
 func (o *GetOptions) printEnrollmentRequestsTable(w *tabwriter.Writer, ers ...api.EnrollmentRequest) {
-	fmt.Fprintln(w, "NAME\tAPPROVAL\tAPPROVER\tAPPROVED LABELS")
+	o.printHeader(w, "NAME\tAPPROVAL\tAPPROVER\tAPPROVED LABELS")
 	for _, e := range ers {
 		approval, approver, approvedLabels := "Pending", "<none>", ""
 		if e.Status.Approval != nil {
@@ -427,7 +596,7 @@ func (o *GetOptions) printEnrollmentRequestsTable(w *tabwriter.Writer, ers ...ap
 }
 
 func (o *GetOptions) printFleetsTable(w *tabwriter.Writer, fleets ...api.Fleet) {
-	fmt.Fprintln(w, "NAME\tOWNER\tSELECTOR\tVALID\tDEVICES")
+	o.printHeader(w, "NAME\tOWNER\tSELECTOR\tVALID\tDEVICES")
 	for i := range fleets {
 		f := fleets[i]
 		selector := "<none>"
@@ -461,14 +630,14 @@ func (o *GetOptions) printFleetsTable(w *tabwriter.Writer, fleets ...api.Fleet)
 }
 
 func (o *GetOptions) printTemplateVersionsTable(w *tabwriter.Writer, tvs ...api.TemplateVersion) {
-	fmt.Fprintln(w, "FLEET\tNAME")
+	o.printHeader(w, "FLEET\tNAME")
 	for _, tv := range tvs {
 		fmt.Fprintf(w, "%s\t%s\n", tv.Spec.Fleet, *tv.Metadata.Name)
 	}
 }
 
 func (o *GetOptions) printRepositoriesTable(w *tabwriter.Writer, repos ...api.Repository) {
-	fmt.Fprintln(w, "NAME\tTYPE\tREPOSITORY URL\tACCESSIBLE")
+	o.printHeader(w, "NAME\tTYPE\tREPOSITORY URL\tACCESSIBLE")
 	for _, r := range repos {
 		accessible := "Unknown"
 		if r.Status != nil {
@@ -491,7 +660,7 @@ func (o *GetOptions) printRepositoriesTable(w *tabwriter.Writer, repos ...api.Re
 }
 
 func (o *GetOptions) printResourceSyncsTable(w *tabwriter.Writer, resourcesyncs ...api.ResourceSync) {
-	fmt.Fprintln(w, "NAME\tREPOSITORY\tPATH\tREVISION\tACCESSIBLE\tSYNCED\tLAST SYNC")
+	o.printHeader(w, "NAME\tREPOSITORY\tPATH\tREVISION\tACCESSIBLE\tSYNCED\tLAST SYNC")
 
 	for _, rs := range resourcesyncs {
 		accessible, synced, lastSynced := "Unknown", "Unknown", "Unknown"
@@ -519,7 +688,7 @@ func (o *GetOptions) printResourceSyncsTable(w *tabwriter.Writer, resourcesyncs
 }
 
 func (o *GetOptions) printCSRTable(w *tabwriter.Writer, csrs ...api.CertificateSigningRequest) {
-	fmt.Fprintln(w, "NAME\tAGE\tSIGNERNAME\tUSERNAME\tREQUESTEDDURATION\tCONDITION")
+	o.printHeader(w, "NAME\tAGE\tSIGNERNAME\tUSERNAME\tREQUESTEDDURATION\tCONDITION")
 
 	for _, csr := range csrs {
 		age := NoneString
@@ -551,3 +720,77 @@ func (o *GetOptions) printCSRTable(w *tabwriter.Writer, csrs ...api.CertificateS
 		)
 	}
 }
+
+// objectMetas returns the metadata of the resource, or of the items of the list, returned by the
+// service. The metadata can be modified in place.
+func objectMetas(resource interface{}) []*api.ObjectMeta {
+	v := reflect.ValueOf(resource)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+	if field := v.FieldByName("Metadata"); field.IsValid() {
+		if metadata, ok := field.Addr().Interface().(*api.ObjectMeta); ok {
+			return []*api.ObjectMeta{metadata}
+		}
+	}
+	items := v.FieldByName("Items")
+	if items.Kind() != reflect.Slice {
+		return nil
+	}
+	metas := []*api.ObjectMeta{}
+	for i := 0; i < items.Len(); i++ {
+		metas = append(metas, objectMetas(items.Index(i).Addr().Interface())...)
+	}
+	return metas
+}
+
+// filterManagedFields omits the managed fields from the resource, or the items of the list,
+// returned by the service unless they were asked for, in which case only the ones of ShowOwner are
+// kept if it is set.
+func (o *GetOptions) filterManagedFields(resource interface{}) {
+	for _, metadata := range objectMetas(resource) {
+		if metadata.ManagedFields == nil {
+			continue
+		}
+		if !o.ShowManagedFields {
+			metadata.ManagedFields = nil
+			continue
+		}
+		if len(o.ShowOwner) > 0 {
+			owned, ok := (*metadata.ManagedFields)[o.ShowOwner]
+			if !ok {
+				metadata.ManagedFields = nil
+				continue
+			}
+			metadata.ManagedFields = &map[string][]string{o.ShowOwner: owned}
+		}
+	}
+}
+
+// printManagedFieldsTable prints the fields owned by each field manager of the resource, or of the
+// items of the list, returned by the service, restricted to the ones of ShowOwner if it is set.
+func (o *GetOptions) printManagedFieldsTable(out io.Writer, resource interface{}) {
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "NAME\tMANAGER\tFIELD")
+	for _, metadata := range objectMetas(resource) {
+		if metadata.ManagedFields == nil {
+			continue
+		}
+		managers := make([]string, 0, len(*metadata.ManagedFields))
+		for manager := range *metadata.ManagedFields {
+			if len(o.ShowOwner) == 0 || manager == o.ShowOwner {
+				managers = append(managers, manager)
+			}
+		}
+		slices.Sort(managers)
+		for _, manager := range managers {
+			fields := slices.Clone((*metadata.ManagedFields)[manager])
+			slices.Sort(fields)
+			for _, field := range fields {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", util.DefaultIfNil(metadata.Name, ""), manager, field)
+			}
+		}
+	}
+	w.Flush()
+}
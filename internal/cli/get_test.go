@@ -0,0 +1,319 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const chunkTestDevices = 25
+
+func chunkTestDevice(i int) api.Device {
+	return api.Device{
+		ApiVersion: "v1alpha1",
+		Kind:       api.DeviceKind,
+		Metadata:   api.ObjectMeta{Name: util.StrToPtr(fmt.Sprintf("device-%02d", i)), Labels: &map[string]string{"site": "factory\nfloor 1"}},
+		Status:     &api.DeviceStatus{},
+	}
+}
+
+// syncBuffer is a buffer the test server can read while the command writes to it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// chunkTestServer serves the devices in pages of the requested size. Before serving a page, it
+// checks that the devices of the previous page were already written to out, and records the
+// requested page sizes.
+func chunkTestServer(t *testing.T, out *syncBuffer, limits *[]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/devices" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		require.NoError(t, err)
+		*limits = append(*limits, limit)
+		start := 0
+		if cont := r.URL.Query().Get("continue"); cont != "" {
+			start, err = strconv.Atoi(cont)
+			require.NoError(t, err)
+			require.Contains(t, out.String(), *chunkTestDevice(start - 1).Metadata.Name, "the previous chunk was not printed before fetching the next one")
+		}
+
+		list := api.DeviceList{ApiVersion: "v1alpha1", Kind: api.DeviceListKind, Items: []api.Device{}}
+		for i := start; i < start+limit && i < chunkTestDevices; i++ {
+			list.Items = append(list.Items, chunkTestDevice(i))
+		}
+		if start+limit < chunkTestDevices {
+			list.Metadata.Continue = util.StrToPtr(strconv.Itoa(start + limit))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(list))
+	}))
+}
+
+func TestGetInChunks(t *testing.T) {
+	all := api.DeviceList{ApiVersion: "v1alpha1", Kind: api.DeviceListKind}
+	for i := 0; i < chunkTestDevices; i++ {
+		all.Items = append(all.Items, chunkTestDevice(i))
+	}
+	marshalledJSON, err := json.Marshal(all)
+	require.NoError(t, err)
+	marshalledYAML, err := yaml.Marshal(all)
+	require.NoError(t, err)
+
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{output: jsonFormat, expected: string(marshalledJSON) + "\n"},
+		{output: yamlFormat, expected: string(marshalledYAML) + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			require := require.New(t)
+			out := &syncBuffer{}
+			limits := []int{}
+			server := chunkTestServer(t, out, &limits)
+			defer server.Close()
+			c, err := apiclient.NewClientWithResponses(server.URL)
+			require.NoError(err)
+
+			o := DefaultGetOptions()
+			o.Output = tt.output
+			o.ChunkSize = 10
+			require.NoError(o.listInChunks(context.Background(), c, out, DeviceKind))
+
+			// no page is larger than the chunk size, and the output is the whole list
+			require.Equal([]int{10, 10, 10}, limits)
+			require.Equal(tt.expected, out.String())
+		})
+	}
+}
+
+func TestGetInChunksTable(t *testing.T) {
+	require := require.New(t)
+	out := &syncBuffer{}
+	limits := []int{}
+	server := chunkTestServer(t, out, &limits)
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	o := DefaultGetOptions()
+	o.ChunkSize = 7
+	require.NoError(o.listInChunks(context.Background(), c, out, DeviceKind))
+	require.Equal([]int{7, 7, 7, 7}, limits)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(lines, chunkTestDevices+1)
+	require.Equal("NAME", strings.Fields(lines[0])[0])
+	for i := 0; i < chunkTestDevices; i++ {
+		require.Equal(*chunkTestDevice(i).Metadata.Name, strings.Fields(lines[i+1])[0])
+	}
+}
+
+func TestGetInChunksEmpty(t *testing.T) {
+	for _, output := range []string{jsonFormat, yamlFormat} {
+		t.Run(output, func(t *testing.T) {
+			require := require.New(t)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				require.NoError(json.NewEncoder(w).Encode(api.FleetList{ApiVersion: "v1alpha1", Kind: api.FleetListKind, Items: []api.Fleet{}}))
+			}))
+			defer server.Close()
+			c, err := apiclient.NewClientWithResponses(server.URL)
+			require.NoError(err)
+
+			out := &syncBuffer{}
+			o := DefaultGetOptions()
+			o.Output = output
+			o.ChunkSize = 10
+			require.NoError(o.listInChunks(context.Background(), c, out, FleetKind))
+
+			var list api.FleetList
+			require.NoError(yaml.Unmarshal([]byte(out.String()), &list))
+			require.Equal(api.FleetListKind, list.Kind)
+			require.Empty(list.Items)
+		})
+	}
+}
+
+func TestGetChunkSizeValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		modify  func(o *GetOptions)
+		wantErr string
+	}{
+		{name: "list", arg: "devices"},
+		{name: "single resource", arg: "device/edge-1", wantErr: "single resource"},
+		{name: "limit", arg: "devices", modify: func(o *GetOptions) { o.Limit = 5 }, wantErr: "'limit' and 'continue'"},
+		{name: "summary", arg: "devices", modify: func(o *GetOptions) { o.Summary = true }, wantErr: "summary"},
+		{name: "jsonpath", arg: "devices", modify: func(o *GetOptions) { o.Output = "jsonpath={.metadata.name}" }, wantErr: "jsonpath"},
+		{name: "negative", arg: "devices", modify: func(o *GetOptions) { o.ChunkSize = -1 }, wantErr: "chunk-size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := DefaultGetOptions()
+			o.ConfigFilePath = t.TempDir()
+			o.ChunkSize = 10
+			if tt.modify != nil {
+				tt.modify(o)
+			}
+			err := o.Validate([]string{tt.arg})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func managedFieldsTestDevice() *api.Device {
+	return &api.Device{
+		ApiVersion: "v1alpha1",
+		Kind:       api.DeviceKind,
+		Metadata: api.ObjectMeta{
+			Name: util.StrToPtr("edge-1"),
+			ManagedFields: &map[string][]string{
+				"gitops":  {"/spec/os/image", "/metadata/labels/site"},
+				"console": {"/metadata/labels/alias"},
+			},
+		},
+	}
+}
+
+func TestGetManagedFieldsTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		owner    string
+		expected []string
+	}{
+		{
+			name: "all managers",
+			expected: []string{
+				"NAME MANAGER FIELD",
+				"edge-1 console /metadata/labels/alias",
+				"edge-1 gitops /metadata/labels/site",
+				"edge-1 gitops /spec/os/image",
+			},
+		},
+		{
+			name:  "single owner",
+			owner: "gitops",
+			expected: []string{
+				"NAME MANAGER FIELD",
+				"edge-1 gitops /metadata/labels/site",
+				"edge-1 gitops /spec/os/image",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := DefaultGetOptions()
+			o.ShowManagedFields = true
+			o.ShowOwner = tt.owner
+			out := &bytes.Buffer{}
+			list := &api.DeviceList{Items: []api.Device{*managedFieldsTestDevice()}}
+			o.printManagedFieldsTable(out, list)
+
+			lines := []string{}
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				lines = append(lines, strings.Join(strings.Fields(line), " "))
+			}
+			require.Equal(t, tt.expected, lines)
+		})
+	}
+}
+
+func TestGetFilterManagedFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		show     bool
+		owner    string
+		expected *map[string][]string
+	}{
+		{name: "omitted by default"},
+		{name: "shown", show: true, expected: managedFieldsTestDevice().Metadata.ManagedFields},
+		{name: "owner", show: true, owner: "console", expected: &map[string][]string{"console": {"/metadata/labels/alias"}}},
+		{name: "unknown owner", show: true, owner: "kubectl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := DefaultGetOptions()
+			o.ShowManagedFields = tt.show
+			o.ShowOwner = tt.owner
+			device := managedFieldsTestDevice()
+			o.filterManagedFields(device)
+			require.Equal(t, tt.expected, device.Metadata.ManagedFields)
+
+			list := &api.DeviceList{Items: []api.Device{*managedFieldsTestDevice()}}
+			o.filterManagedFields(list)
+			require.Equal(t, tt.expected, list.Items[0].Metadata.ManagedFields)
+		})
+	}
+
+	// resources without metadata are left alone
+	DefaultGetOptions().filterManagedFields(&api.RenderedDeviceSpec{})
+}
+
+func TestGetManagedFieldsValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		modify  func(o *GetOptions)
+		wantErr string
+	}{
+		{name: "table", arg: "device/edge-1"},
+		{name: "yaml in chunks", arg: "devices", modify: func(o *GetOptions) { o.Output = yamlFormat; o.ChunkSize = 10 }},
+		{name: "table in chunks", arg: "devices", modify: func(o *GetOptions) { o.ChunkSize = 10 }, wantErr: "chunk-size"},
+		{name: "rendered", arg: "device/edge-1", modify: func(o *GetOptions) { o.Rendered = true; o.Output = jsonFormat }, wantErr: "rendered"},
+		{name: "jsonpath", arg: "devices", modify: func(o *GetOptions) { o.Output = "jsonpath={.metadata.name}" }, wantErr: "jsonpath"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := DefaultGetOptions()
+			o.ConfigFilePath = t.TempDir()
+			o.ShowManagedFields = true
+			if tt.modify != nil {
+				tt.modify(o)
+			}
+			err := o.Validate([]string{tt.arg})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -30,10 +30,13 @@ func DefaultGlobalOptions() GlobalOptions {
 }
 
 func (o *GlobalOptions) Bind(fs *pflag.FlagSet) {
-	fs.StringVarP(&o.Context, "context", "c", o.Context, "Read client config from 'client_<context>.yaml' instead of 'client.yaml'.")
+	fs.StringVarP(&o.Context, "context", "c", o.Context, "Read client config from 'client_<context>.yaml' instead of the current context's file (see 'config use-context').")
 }
 
 func (o *GlobalOptions) Complete(cmd *cobra.Command, args []string) error {
+	if o.Context == "" {
+		o.Context = CurrentContext()
+	}
 	o.ConfigFilePath = ConfigFilePath(o.Context)
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
@@ -45,14 +46,13 @@ var (
 	}
 )
 
-func getValidResourceKinds() []string {
-	resourceKinds := make([]string, len(pluralKinds))
-	i := 0
-	for _, v := range pluralKinds {
-		resourceKinds[i] = v
-		i++
+func getResourceKinds() []string {
+	kinds := make([]string, 0, len(pluralKinds))
+	for k := range pluralKinds {
+		kinds = append(kinds, k)
 	}
-	return resourceKinds
+	sort.Strings(kinds)
+	return kinds
 }
 
 func parseAndValidateKindName(arg string) (string, string, error) {
@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/gorilla/websocket"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	defaultLogsUnit = "flightctl-agent"
+	// logsBeginMarker is printed on the device right before the logs, so that the shell prompt and
+	// the echo of the command sent over the console are not mixed into the output.
+	logsBeginMarker = "FLIGHTCTL_LOGS_BEGIN"
+)
+
+type LogsOptions struct {
+	GlobalOptions
+
+	Since   time.Duration
+	Follow  bool
+	Unit    string
+	Shipped bool
+}
+
+func DefaultLogsOptions() *LogsOptions {
+	return &LogsOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Since:         0,
+		Follow:        false,
+		Unit:          defaultLogsUnit,
+		Shipped:       false,
+	}
+}
+
+func NewCmdLogs() *cobra.Command {
+	o := DefaultLogsOptions()
+
+	cmd := &cobra.Command{
+		Use:   "logs device/NAME",
+		Short: "Print the logs of a systemd unit on the remote device through the server.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+
+	o.Bind(cmd.Flags())
+
+	return cmd
+}
+
+func (o *LogsOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.DurationVar(&o.Since, "since", o.Since, "Only return logs newer than a relative duration like 5s, 2m, or 3h. Defaults to all logs.")
+	fs.BoolVarP(&o.Follow, "follow", "f", o.Follow, "Specify if the logs should be streamed.")
+	fs.StringVarP(&o.Unit, "unit", "u", o.Unit, "The systemd unit to print the logs of.")
+	fs.BoolVar(&o.Shipped, "shipped", o.Shipped, "Print the logs last shipped by the agent to the server, which are available while the device is offline.")
+}
+
+func (o *LogsOptions) Complete(cmd *cobra.Command, args []string) error {
+	return o.GlobalOptions.Complete(cmd, args)
+}
+
+func (o *LogsOptions) Validate(args []string) error {
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	if kind != DeviceKind {
+		return fmt.Errorf("only devices have logs")
+	}
+
+	if len(name) == 0 {
+		return fmt.Errorf("device name is required")
+	}
+	if o.Since < 0 {
+		return fmt.Errorf("--since must be a positive duration")
+	}
+	if len(o.Unit) == 0 {
+		return fmt.Errorf("--unit must not be empty")
+	}
+	if o.Shipped && (o.Follow || o.Since > 0) {
+		return fmt.Errorf("--shipped cannot be combined with --follow or --since")
+	}
+	return nil
+}
+
+func (o *LogsOptions) Run(ctx context.Context, args []string) error {
+	config, err := client.ParseConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("parsing config file: %w", err)
+	}
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	if o.Shipped {
+		return printShippedLogs(ctx, c, os.Stdout, name)
+	}
+
+	response, err := c.ReadDeviceWithResponse(ctx, name, nil)
+	if err != nil {
+		return fmt.Errorf("reading device: %w", err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("reading device: %s", response.HTTPResponse.Status)
+	}
+	if err := checkDeviceOnline(response.JSON200); err != nil {
+		return err
+	}
+
+	conn, err := dialDeviceConsole(config, name, config.AuthInfo.Token)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return streamLogs(ctx, conn, o.journalCommand(), os.Stdout)
+}
+
+// printShippedLogs prints the tail of the logs that the agent of the device last shipped to the
+// server.
+func printShippedLogs(ctx context.Context, c *apiclient.ClientWithResponses, out io.Writer, name string) error {
+	response, err := c.ReadDeviceLogsWithResponse(ctx, name)
+	if err != nil {
+		return fmt.Errorf("reading shipped logs of device/%s: %w", name, err)
+	}
+	if err := validateHttpResponse(response.Body, response.StatusCode(), http.StatusOK); err != nil {
+		return fmt.Errorf("reading shipped logs of device/%s: %w", name, err)
+	}
+
+	logs := response.JSON200
+	truncated := ""
+	if logs.Truncated != nil && *logs.Truncated {
+		truncated = ", older lines truncated"
+	}
+	fmt.Fprintf(out, "# shipped at %s (%s%s)\n", logs.CollectedAt.Format(time.RFC3339), logs.Reason, truncated)
+	for _, line := range logs.Lines {
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDeviceOnline returns an error if the device is not connected to the service,
+// since the logs can only be read and files copied through a live console session.
+func checkDeviceOnline(device *api.Device) error {
+	if device.Status == nil || device.Status.Summary.Status == api.DeviceSummaryStatusUnknown {
+		lastSeen := "never"
+		if device.Status != nil && !device.Status.LastSeen.IsZero() {
+			lastSeen = device.Status.LastSeen.Format(time.RFC3339)
+		}
+		return fmt.Errorf("device %s is offline (last seen: %s), it cannot be reached until it reconnects", *device.Metadata.Name, lastSeen)
+	}
+	return nil
+}
+
+// journalCommand returns the shell command run on the device to print the requested logs.
+func (o *LogsOptions) journalCommand() string {
+	cmd := fmt.Sprintf("printf '%%s_%%s\\n' FLIGHTCTL LOGS_BEGIN; exec journalctl --no-pager -u %s", shellQuote(o.Unit))
+	if o.Since > 0 {
+		cmd += fmt.Sprintf(" --since -%ds", int64(o.Since.Seconds()))
+	}
+	if o.Follow {
+		cmd += " -f"
+	}
+	return cmd + "\n"
+}
+
+// streamLogs runs the command in the console session and copies its output to out until the
+// session is closed by the device or the context is cancelled.
+func streamLogs(ctx context.Context, conn *websocket.Conn, command string, out io.Writer) error {
+	if err := conn.WriteMessage(websocket.BinaryMessage, []byte(command)); err != nil {
+		return fmt.Errorf("writing to websocket: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second*5))
+		conn.Close()
+	}()
+
+	reader, writer := io.Pipe()
+	go func() {
+		for {
+			msgType, frame, err := conn.ReadMessage()
+			if err != nil {
+				_ = writer.CloseWithError(err)
+				return
+			}
+			if msgType == websocket.BinaryMessage || msgType == websocket.TextMessage {
+				if _, err := writer.Write(frame); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	err := copyLogs(reader, out)
+	_ = reader.Close()
+	if ctx.Err() != nil || errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) ||
+		websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		return nil
+	}
+	return err
+}
+
+// copyLogs skips everything the console prints before the logs begin marker and copies the rest.
+func copyLogs(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	started := false
+	for scanner.Scan() {
+		line := bytes.TrimSuffix(scanner.Bytes(), []byte("\r"))
+		if !started {
+			started = string(line) == logsBeginMarker
+			continue
+		}
+		if _, err := fmt.Fprintf(out, "%s\n", line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.EOF
+}
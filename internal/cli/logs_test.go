@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConsole serves a websocket that behaves like a device console: it echoes the
+// received command after a prompt, then sends the given frames and closes the session.
+func fakeConsole(t *testing.T, frames []string, received chan<- string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrading connection: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		_, command, err := conn.ReadMessage()
+		if err != nil {
+			t.Errorf("reading command: %v", err)
+			return
+		}
+		received <- string(command)
+
+		_ = conn.WriteMessage(websocket.BinaryMessage, []byte("[root@device ~]# "+string(command)))
+		for _, frame := range frames {
+			_ = conn.WriteMessage(websocket.BinaryMessage, []byte(frame))
+		}
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}))
+}
+
+func dialFakeConsole(require *require.Assertions, server *httptest.Server) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial(strings.Replace(server.URL, "http", "ws", 1), nil)
+	require.NoError(err)
+	return conn
+}
+
+func TestStreamLogs(t *testing.T) {
+	require := require.New(t)
+	received := make(chan string, 1)
+	server := fakeConsole(t, []string{
+		"FLIGHTCTL_LOGS_BEGIN\r\n",
+		"Oct 15 10:00:00 device flightctl-agent[1]: starting\r\n",
+		"Oct 15 10:00:01 device flightctl-agent[1]: ",
+		"synced\r\n",
+	}, received)
+	defer server.Close()
+
+	conn := dialFakeConsole(require, server)
+	defer conn.Close()
+
+	o := DefaultLogsOptions()
+	o.Since = 2 * time.Minute
+	o.Follow = true
+
+	out := &bytes.Buffer{}
+	require.NoError(streamLogs(context.Background(), conn, o.journalCommand(), out))
+	require.Equal("printf '%s_%s\\n' FLIGHTCTL LOGS_BEGIN; exec journalctl --no-pager -u 'flightctl-agent' --since -120s -f\n", <-received)
+	require.Equal("Oct 15 10:00:00 device flightctl-agent[1]: starting\n"+
+		"Oct 15 10:00:01 device flightctl-agent[1]: synced\n", out.String())
+}
+
+func TestJournalCommandQuotesUnit(t *testing.T) {
+	require := require.New(t)
+	o := DefaultLogsOptions()
+	o.Unit = "x'; reboot #"
+	require.Equal(`printf '%s_%s\n' FLIGHTCTL LOGS_BEGIN; exec journalctl --no-pager -u 'x'\''; reboot #'`+"\n", o.journalCommand())
+}
+
+func TestStreamLogsCancelled(t *testing.T) {
+	require := require.New(t)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// keep the session open like a followed journal until the client goes away
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	conn := dialFakeConsole(require, server)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.NoError(streamLogs(ctx, conn, DefaultLogsOptions().journalCommand(), &bytes.Buffer{}))
+}
+
+func TestCheckDeviceOnline(t *testing.T) {
+	require := require.New(t)
+	device := &api.Device{Metadata: api.ObjectMeta{Name: util.StrToPtr("dev")}}
+	err := checkDeviceOnline(device)
+	require.ErrorContains(err, "device dev is offline (last seen: never)")
+
+	status := api.NewDeviceStatus()
+	status.Summary.Status = api.DeviceSummaryStatusUnknown
+	status.LastSeen = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	device.Status = &status
+	err = checkDeviceOnline(device)
+	require.ErrorContains(err, "last seen: 2024-01-02T03:04:05Z")
+
+	device.Status.Summary.Status = api.DeviceSummaryStatusOnline
+	require.NoError(checkDeviceOnline(device))
+}
+
+func TestPrintShippedLogs(t *testing.T) {
+	require := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/devices/dev/logs":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"collectedAt":"2024-01-02T03:04:05Z","reason":"Error","truncated":true,` +
+				`"lines":["2024-01-02T03:04:00+0000 dev flightctl-agent[1]: failed to apply spec"]}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"message":"the device has not shipped any logs"}`))
+		}
+	}))
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	out := &bytes.Buffer{}
+	require.NoError(printShippedLogs(context.Background(), c, out, "dev"))
+	require.Equal("# shipped at 2024-01-02T03:04:05Z (Error, older lines truncated)\n"+
+		"2024-01-02T03:04:00+0000 dev flightctl-agent[1]: failed to apply spec\n", out.String())
+
+	require.ErrorContains(printShippedLogs(context.Background(), c, out, "other"), "reading shipped logs of device/other")
+}
+
+func TestValidateShippedLogs(t *testing.T) {
+	o := DefaultLogsOptions()
+	o.Shipped = true
+	require.NoError(t, o.Validate([]string{"device/dev"}))
+	o.Follow = true
+	require.ErrorContains(t, o.Validate([]string{"device/dev"}), "--shipped cannot be combined")
+}
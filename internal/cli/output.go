@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	jsonPathFormat      = "jsonpath"
+	customColumnsFormat = "custom-columns"
+)
+
+var relaxedJSONPathRegexp = regexp.MustCompile(`^\{?\.?([^{}]+)\}?$`)
+
+// parseTemplateOutput splits an output flag of the form "jsonpath=TEMPLATE" or
+// "custom-columns=SPEC" into its format and its argument.
+func parseTemplateOutput(output string) (format string, arg string, ok bool) {
+	format, arg, found := strings.Cut(output, "=")
+	if !found || (format != jsonPathFormat && format != customColumnsFormat) {
+		return "", "", false
+	}
+	return format, arg, true
+}
+
+// relaxedJSONPath turns a field path like "status.summary.status" or ".status.summary.status"
+// into the JSONPath template "{.status.summary.status}", as kubectl does.
+func relaxedJSONPath(path string) (string, error) {
+	if strings.HasPrefix(path, "{") && strings.Count(path, "{") > 1 {
+		return path, nil
+	}
+	submatches := relaxedJSONPathRegexp.FindStringSubmatch(path)
+	if submatches == nil {
+		return "", fmt.Errorf("unexpected path string, expected a 'name1.name2' or '.name1.name2' or '{name1.name2}' or '{.name1.name2}': %q", path)
+	}
+	return fmt.Sprintf("{.%s}", submatches[1]), nil
+}
+
+// toGeneric converts a typed API object to the generic form JSONPath is evaluated over, so that
+// field names are the JSON names of the API.
+func toGeneric(obj interface{}) (interface{}, error) {
+	marshalled, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling resource: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(marshalled, &generic); err != nil {
+		return nil, fmt.Errorf("unmarshalling resource: %w", err)
+	}
+	return generic, nil
+}
+
+func parseJSONPath(name string, template string) (*jsonpath.JSONPath, error) {
+	if !strings.Contains(template, "{") {
+		var err error
+		if template, err = relaxedJSONPath(template); err != nil {
+			return nil, err
+		}
+	}
+	parser := jsonpath.New(name)
+	if err := parser.Parse(template); err != nil {
+		return nil, fmt.Errorf("parsing jsonpath %s: %w", template, err)
+	}
+	return parser, nil
+}
+
+// printJSONPath evaluates the JSONPath template over the resource, a single resource or a list.
+func printJSONPath(w io.Writer, template string, obj interface{}) error {
+	parser, err := parseJSONPath("output", template)
+	if err != nil {
+		return err
+	}
+	generic, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+	if err := parser.Execute(w, generic); err != nil {
+		return fmt.Errorf("executing jsonpath %s: %w", template, err)
+	}
+	return nil
+}
+
+type customColumn struct {
+	header string
+	parser *jsonpath.JSONPath
+}
+
+// parseCustomColumns parses a spec of the form "HEADER1:.path1,HEADER2:.path2".
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	if len(spec) == 0 {
+		return nil, fmt.Errorf("custom-columns format specified but no custom columns given")
+	}
+	var columns []customColumn
+	for _, part := range strings.Split(spec, ",") {
+		header, path, found := strings.Cut(part, ":")
+		if !found || len(header) == 0 || len(path) == 0 {
+			return nil, fmt.Errorf("unexpected custom-columns spec: %s, expected <header>:<json-path-expr>", part)
+		}
+		template, err := relaxedJSONPath(path)
+		if err != nil {
+			return nil, err
+		}
+		parser, err := parseJSONPath(header, template)
+		if err != nil {
+			return nil, err
+		}
+		parser.AllowMissingKeys(true)
+		columns = append(columns, customColumn{header: header, parser: parser})
+	}
+	return columns, nil
+}
+
+// printCustomColumns prints a table with one row per item of a list, or a single row for a
+// single resource. Fields missing from a resource are shown as "<none>".
+func printCustomColumns(w io.Writer, spec string, obj interface{}) error {
+	columns, err := parseCustomColumns(spec)
+	if err != nil {
+		return err
+	}
+	generic, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+	rows := []interface{}{generic}
+	if list, ok := generic.(map[string]interface{}); ok {
+		if items, ok := list["items"].([]interface{}); ok {
+			rows = items
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+	headers := make([]string, 0, len(columns))
+	for _, column := range columns {
+		headers = append(headers, column.header)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		values := make([]string, 0, len(columns))
+		for _, column := range columns {
+			results, err := column.parser.FindResults(row)
+			if err != nil {
+				return fmt.Errorf("evaluating column %s: %w", column.header, err)
+			}
+			var fields []string
+			for _, result := range results {
+				for _, value := range result {
+					var buf bytes.Buffer
+					if err := column.parser.PrintResults(&buf, []reflect.Value{value}); err != nil {
+						return fmt.Errorf("printing column %s: %w", column.header, err)
+					}
+					fields = append(fields, buf.String())
+				}
+			}
+			if len(fields) == 0 {
+				values = append(values, "<none>")
+			} else {
+				values = append(values, strings.Join(fields, ","))
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+// listPrinter prints a list in JSON or YAML while its items are fetched in chunks, writing each
+// item as it arrives. The output is the same as marshalling the whole list at once: the fields of
+// the list are printed in the order they are marshalled, with the items and then the kind and the
+// empty metadata of a complete list.
+type listPrinter struct {
+	out        io.Writer
+	format     string
+	apiVersion string
+	kind       string
+	items      int
+}
+
+// printChunk prints the items of a list returned by the service.
+func (p *listPrinter) printChunk(list interface{}) error {
+	if len(p.kind) == 0 {
+		apiVersion, err := responseField[string](list, "ApiVersion")
+		if err != nil {
+			return err
+		}
+		if p.kind, err = responseField[string](list, "Kind"); err != nil {
+			return err
+		}
+		if err := p.printField("apiVersion", apiVersion); err != nil {
+			return err
+		}
+	}
+
+	items, err := responseField[interface{}](list, "Items")
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(items)
+	for i := 0; i < v.Len(); i++ {
+		if err := p.printItem(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finish prints the end of the list once all its items were printed.
+func (p *listPrinter) finish() error {
+	if p.format == jsonFormat {
+		if p.items == 0 {
+			if _, err := io.WriteString(p.out, `"items":[`); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(p.out, "],"); err != nil {
+			return err
+		}
+	} else if p.items == 0 {
+		if _, err := io.WriteString(p.out, "items: []\n"); err != nil {
+			return err
+		}
+	}
+	if err := p.printField("kind", p.kind); err != nil {
+		return err
+	}
+	if p.format == jsonFormat {
+		_, err := io.WriteString(p.out, `"metadata":{}}`+"\n")
+		return err
+	}
+	_, err := io.WriteString(p.out, "metadata: {}\n\n")
+	return err
+}
+
+func (p *listPrinter) printField(name string, value string) error {
+	var err error
+	if p.format == jsonFormat {
+		var marshalled []byte
+		if marshalled, err = json.Marshal(value); err != nil {
+			return err
+		}
+		prefix := ""
+		if name == "apiVersion" {
+			prefix = "{"
+		}
+		_, err = fmt.Fprintf(p.out, "%s%q:%s,", prefix, name, marshalled)
+	} else {
+		var marshalled []byte
+		if marshalled, err = yaml.Marshal(map[string]string{name: value}); err != nil {
+			return err
+		}
+		_, err = p.out.Write(marshalled)
+	}
+	return err
+}
+
+func (p *listPrinter) printItem(item interface{}) error {
+	if p.format == jsonFormat {
+		marshalled, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("marshalling resource: %w", err)
+		}
+		prefix := ","
+		if p.items == 0 {
+			prefix = `"items":[`
+		}
+		p.items++
+		_, err = fmt.Fprintf(p.out, "%s%s", prefix, marshalled)
+		return err
+	}
+
+	marshalled, err := yaml.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("marshalling resource: %w", err)
+	}
+	var b strings.Builder
+	if p.items == 0 {
+		b.WriteString("items:\n")
+	}
+	p.items++
+	// indent the item as an entry of the items sequence
+	for i, line := range strings.Split(strings.TrimSuffix(string(marshalled), "\n"), "\n") {
+		switch {
+		case i == 0:
+			b.WriteString("- ")
+		case len(line) > 0:
+			b.WriteString("  ")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	_, err = io.WriteString(p.out, b.String())
+	return err
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func outputTestDevices() api.DeviceList {
+	return api.DeviceList{
+		Kind: api.DeviceListKind,
+		Items: []api.Device{
+			{
+				Metadata: api.ObjectMeta{Name: util.StrToPtr("device-1"), Labels: &map[string]string{"region": "eu"}},
+				Status: &api.DeviceStatus{
+					Summary: api.DeviceSummaryStatus{Status: api.DeviceSummaryStatusOnline},
+					Updated: api.DeviceUpdatedStatus{Status: api.DeviceUpdatedStatusUpToDate},
+				},
+			},
+			{
+				Metadata: api.ObjectMeta{Name: util.StrToPtr("device-2")},
+				Status: &api.DeviceStatus{
+					Summary: api.DeviceSummaryStatus{Status: api.DeviceSummaryStatusDegraded},
+					Updated: api.DeviceUpdatedStatus{Status: api.DeviceUpdatedStatusOutOfDate},
+				},
+			},
+		},
+	}
+}
+
+func TestPrintJSONPath(t *testing.T) {
+	devices := outputTestDevices()
+	tests := []struct {
+		name     string
+		template string
+		obj      interface{}
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "device status",
+			template: "{.status.summary.status}",
+			obj:      devices.Items[0],
+			want:     "Online",
+		},
+		{
+			name:     "relaxed path",
+			template: ".status.updated.status",
+			obj:      devices.Items[1],
+			want:     "OutOfDate",
+		},
+		{
+			name:     "range over list",
+			template: `{range .items[*]}{.metadata.name}={.status.summary.status}{"\n"}{end}`,
+			obj:      devices,
+			want:     "device-1=Online\ndevice-2=Degraded\n",
+		},
+		{
+			name:     "missing field",
+			template: "{.status.unknown}",
+			obj:      devices.Items[0],
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := printJSONPath(&buf, tt.template, tt.obj)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestPrintCustomColumns(t *testing.T) {
+	require := require.New(t)
+	devices := outputTestDevices()
+
+	var buf bytes.Buffer
+	require.NoError(printCustomColumns(&buf, "NAME:.metadata.name,STATUS:.status.summary.status,REGION:.metadata.labels.region", devices))
+	require.Equal("NAME\t\tSTATUS\t\tREGION\n"+
+		"device-1\tOnline\t\teu\n"+
+		"device-2\tDegraded\t<none>\n", buf.String())
+
+	// a single resource is rendered as a single row
+	buf.Reset()
+	require.NoError(printCustomColumns(&buf, "NAME:{.metadata.name},UPDATED:status.updated.status", devices.Items[1]))
+	require.Equal("NAME\t\tUPDATED\ndevice-2\tOutOfDate\n", buf.String())
+
+	for _, spec := range []string{"", "NAME", "NAME:", ":.metadata.name", "NAME:.metadata.name,STATUS"} {
+		require.Error(printCustomColumns(&buf, spec, devices), spec)
+	}
+}
+
+func TestParseTemplateOutput(t *testing.T) {
+	format, arg, ok := parseTemplateOutput("jsonpath={.metadata.name}")
+	require.True(t, ok)
+	require.Equal(t, jsonPathFormat, format)
+	require.Equal(t, "{.metadata.name}", arg)
+
+	format, arg, ok = parseTemplateOutput("custom-columns=NAME:.metadata.name")
+	require.True(t, ok)
+	require.Equal(t, customColumnsFormat, format)
+	require.Equal(t, "NAME:.metadata.name", arg)
+
+	for _, output := range []string{"", "json", "yaml", "wide", "go-template={{.metadata.name}}"} {
+		_, _, ok = parseTemplateOutput(output)
+		require.False(t, ok, output)
+	}
+}
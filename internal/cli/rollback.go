@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+type RollbackOptions struct {
+	GlobalOptions
+
+	ToRevision int64
+	DryRun     bool
+}
+
+func DefaultRollbackOptions() *RollbackOptions {
+	return &RollbackOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		ToRevision:    0,
+		DryRun:        false,
+	}
+}
+
+func NewCmdRollback() *cobra.Command {
+	o := DefaultRollbackOptions()
+	cmd := &cobra.Command{
+		Use:               "rollback (device|fleet)/NAME [--to-revision=N]",
+		Short:             "Restore the spec of a device or fleet from a previous revision.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeResourceArg(&o.GlobalOptions, []string{DeviceKind, FleetKind}),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *RollbackOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.Int64Var(&o.ToRevision, "to-revision", o.ToRevision, "The revision to roll back to. Defaults to the revision preceding the current one.")
+	fs.BoolVarP(&o.DryRun, "dry-run", "", o.DryRun, "Only print the resource that would be applied, without sending it.")
+}
+
+func (o *RollbackOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *RollbackOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != DeviceKind && kind != FleetKind {
+		return fmt.Errorf("kind must be either %s or %s", DeviceKind, FleetKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific %s to roll back", kind)
+	}
+	if o.ToRevision < 0 {
+		return fmt.Errorf("--to-revision must not be negative")
+	}
+	return nil
+}
+
+func (o *RollbackOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	return o.rollback(ctx, c, os.Stdout, kind, name)
+}
+
+// rollback replaces the spec of the resource with the one of the target revision, going through
+// the same replace path as apply. The resourceVersion of the current resource is sent along, so
+// the rollback fails rather than overwriting a change made in the meantime.
+func (o *RollbackOptions) rollback(ctx context.Context, c *apiclient.ClientWithResponses, out io.Writer, kind string, name string) error {
+	current, err := readResource(ctx, c, kind, name)
+	if err != nil {
+		return err
+	}
+	revisions, err := listRevisions(ctx, c, kind, name)
+	if err != nil {
+		return err
+	}
+
+	metadata, _ := current["metadata"].(map[string]interface{})
+	var generation int64
+	if g, ok := metadata["generation"].(float64); ok {
+		generation = int64(g)
+	}
+
+	target, err := o.targetRevision(revisions, generation)
+	if err != nil {
+		return fmt.Errorf("%s/%s: %w", kind, name, err)
+	}
+
+	current["spec"] = target.Spec
+	delete(current, "status")
+
+	if o.DryRun {
+		marshalled, err := yaml.Marshal(current)
+		if err != nil {
+			return fmt.Errorf("marshalling resource: %w", err)
+		}
+		fmt.Fprintf(out, "%s/%s: rolling back to revision %d (dry run only)\n", kind, name, target.Revision)
+		fmt.Fprintf(out, "%s", string(marshalled))
+		return nil
+	}
+
+	buf, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("marshalling resource: %w", err)
+	}
+	httpResponse, message, err := replaceResource(ctx, c, kind, name, buf, nil, nil)
+	if err != nil {
+		return fmt.Errorf("rolling back %s/%s: %w", kind, name, err)
+	}
+	if httpResponse.StatusCode != http.StatusOK && httpResponse.StatusCode != http.StatusCreated {
+		return fmt.Errorf("rolling back %s/%s: %w", kind, name, validateHttpResponse([]byte(message), httpResponse.StatusCode, http.StatusOK))
+	}
+
+	var updated struct {
+		Metadata api.ObjectMeta `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(message), &updated); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	resourceVersion := NoneString
+	if updated.Metadata.ResourceVersion != nil {
+		resourceVersion = *updated.Metadata.ResourceVersion
+	}
+	fmt.Fprintf(out, "%s/%s rolled back to revision %d (resourceVersion %s)\n", kind, name, target.Revision, resourceVersion)
+	return nil
+}
+
+// targetRevision returns the revision set with --to-revision, or else the newest revision that
+// precedes the current generation.
+func (o *RollbackOptions) targetRevision(revisions []api.ResourceRevision, generation int64) (*api.ResourceRevision, error) {
+	if o.ToRevision > 0 {
+		if o.ToRevision == generation {
+			return nil, fmt.Errorf("already at revision %d", generation)
+		}
+		for i := range revisions {
+			if revisions[i].Revision == o.ToRevision {
+				return &revisions[i], nil
+			}
+		}
+		return nil, fmt.Errorf("revision %d not found", o.ToRevision)
+	}
+
+	// revisions are listed newest first
+	for i := range revisions {
+		if revisions[i].Revision < generation {
+			return &revisions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no revision to roll back to")
+}
+
+func readResource(ctx context.Context, c *apiclient.ClientWithResponses, kind string, name string) (genericResource, error) {
+	var body []byte
+	var statusCode int
+	switch kind {
+	case DeviceKind:
+		response, err := c.ReadDeviceWithResponse(ctx, name, &api.ReadDeviceParams{})
+		if err != nil {
+			return nil, fmt.Errorf("reading %s/%s: %w", kind, name, err)
+		}
+		body, statusCode = response.Body, response.StatusCode()
+	case FleetKind:
+		response, err := c.ReadFleetWithResponse(ctx, name, &api.ReadFleetParams{})
+		if err != nil {
+			return nil, fmt.Errorf("reading %s/%s: %w", kind, name, err)
+		}
+		body, statusCode = response.Body, response.StatusCode()
+	case EnrollmentRequestKind:
+		response, err := c.ReadEnrollmentRequestWithResponse(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s/%s: %w", kind, name, err)
+		}
+		body, statusCode = response.Body, response.StatusCode()
+	case RepositoryKind:
+		response, err := c.ReadRepositoryWithResponse(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s/%s: %w", kind, name, err)
+		}
+		body, statusCode = response.Body, response.StatusCode()
+	case ResourceSyncKind:
+		response, err := c.ReadResourceSyncWithResponse(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s/%s: %w", kind, name, err)
+		}
+		body, statusCode = response.Body, response.StatusCode()
+	case CertificateSigningRequestKind:
+		response, err := c.ReadCertificateSigningRequestWithResponse(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s/%s: %w", kind, name, err)
+		}
+		body, statusCode = response.Body, response.StatusCode()
+	default:
+		return nil, fmt.Errorf("unsupported resource kind: %s", kind)
+	}
+	if err := validateHttpResponse(body, statusCode, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("reading %s/%s: %w", kind, name, err)
+	}
+
+	resource := genericResource{}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return nil, fmt.Errorf("parsing %s/%s: %w", kind, name, err)
+	}
+	return resource, nil
+}
+
+func listRevisions(ctx context.Context, c *apiclient.ClientWithResponses, kind string, name string) ([]api.ResourceRevision, error) {
+	var list *api.ResourceRevisionList
+	var body []byte
+	var statusCode int
+	switch kind {
+	case DeviceKind:
+		response, err := c.ListDeviceRevisionsWithResponse(ctx, name, &api.ListDeviceRevisionsParams{})
+		if err != nil {
+			return nil, fmt.Errorf("listing revisions of %s/%s: %w", kind, name, err)
+		}
+		list, body, statusCode = response.JSON200, response.Body, response.StatusCode()
+	case FleetKind:
+		response, err := c.ListFleetRevisionsWithResponse(ctx, name, &api.ListFleetRevisionsParams{})
+		if err != nil {
+			return nil, fmt.Errorf("listing revisions of %s/%s: %w", kind, name, err)
+		}
+		list, body, statusCode = response.JSON200, response.Body, response.StatusCode()
+	default:
+		return nil, fmt.Errorf("unsupported resource kind: %s", kind)
+	}
+	if err := validateHttpResponse(body, statusCode, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("listing revisions of %s/%s: %w", kind, name, err)
+	}
+	if list == nil {
+		return nil, fmt.Errorf("listing revisions of %s/%s: empty response", kind, name)
+	}
+	return list.Items, nil
+}
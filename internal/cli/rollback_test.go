@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func rollbackTestServer(t *testing.T, replaced *api.Device) *httptest.Server {
+	device := api.Device{
+		ApiVersion: "v1alpha1",
+		Kind:       "Device",
+		Metadata: api.ObjectMeta{
+			Name:            util.StrToPtr("edge-1"),
+			Generation:      util.Int64ToPtr(3),
+			ResourceVersion: util.StrToPtr("7"),
+		},
+		Spec: &api.DeviceSpec{Os: &api.DeviceOsSpec{Image: "os:3"}},
+	}
+	revisions := api.ResourceRevisionList{Items: []api.ResourceRevision{
+		{Revision: 3, Spec: map[string]interface{}{"os": map[string]interface{}{"image": "os:3"}}},
+		{Revision: 2, Spec: map[string]interface{}{"os": map[string]interface{}{"image": "os:2"}}},
+		{Revision: 1, Spec: map[string]interface{}{"os": map[string]interface{}{"image": "os:1"}}},
+	}}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/devices/edge-1":
+			require.NoError(t, json.NewEncoder(w).Encode(device))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/devices/edge-1/revisions":
+			require.NoError(t, json.NewEncoder(w).Encode(revisions))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/devices/edge-1":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(replaced))
+			updated := *replaced
+			updated.Metadata.ResourceVersion = util.StrToPtr("8")
+			require.NoError(t, json.NewEncoder(w).Encode(updated))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRollbackDevice(t *testing.T) {
+	require := require.New(t)
+	replaced := &api.Device{}
+	server := rollbackTestServer(t, replaced)
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	out := &bytes.Buffer{}
+	o := DefaultRollbackOptions()
+	require.NoError(o.rollback(context.Background(), c, out, DeviceKind, "edge-1"))
+	require.Equal("device/edge-1 rolled back to revision 2 (resourceVersion 8)\n", out.String())
+	require.Equal("os:2", replaced.Spec.Os.Image)
+	require.Equal("7", *replaced.Metadata.ResourceVersion)
+	require.Nil(replaced.Status)
+}
+
+func TestRollbackDeviceToRevision(t *testing.T) {
+	require := require.New(t)
+	replaced := &api.Device{}
+	server := rollbackTestServer(t, replaced)
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	o := DefaultRollbackOptions()
+	o.ToRevision = 1
+	require.NoError(o.rollback(context.Background(), c, &bytes.Buffer{}, DeviceKind, "edge-1"))
+	require.Equal("os:1", replaced.Spec.Os.Image)
+
+	o.ToRevision = 3
+	require.ErrorContains(o.rollback(context.Background(), c, &bytes.Buffer{}, DeviceKind, "edge-1"), "already at revision 3")
+	o.ToRevision = 5
+	require.ErrorContains(o.rollback(context.Background(), c, &bytes.Buffer{}, DeviceKind, "edge-1"), "revision 5 not found")
+}
+
+func TestRollbackDeviceDryRun(t *testing.T) {
+	require := require.New(t)
+	replaced := &api.Device{}
+	server := rollbackTestServer(t, replaced)
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	out := &bytes.Buffer{}
+	o := DefaultRollbackOptions()
+	o.DryRun = true
+	require.NoError(o.rollback(context.Background(), c, out, DeviceKind, "edge-1"))
+	require.Contains(out.String(), "rolling back to revision 2 (dry run only)")
+	require.Contains(out.String(), "image: os:2")
+	require.Nil(replaced.Spec)
+}
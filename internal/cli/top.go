@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	sortByCPU    = "cpu"
+	sortByMemory = "memory"
+	sortByDisk   = "disk"
+
+	defaultTopLimit = 10
+	// topPageSize is the number of devices requested per page while collecting device statuses.
+	topPageSize = 1000
+)
+
+var (
+	legalSortByTypes = []string{sortByCPU, sortByMemory, sortByDisk}
+
+	// resourceStatusSeverity ranks the reported resource statuses from least to most severe.
+	resourceStatusSeverity = map[api.DeviceResourceStatusType]int{
+		api.DeviceResourceStatusHealthy:  0,
+		api.DeviceResourceStatusUnknown:  1,
+		api.DeviceResourceStatusWarning:  2,
+		api.DeviceResourceStatusCritical: 3,
+		api.DeviceResourceStatusError:    4,
+	}
+)
+
+type TopOptions struct {
+	GlobalOptions
+
+	LabelSelector string
+	SortBy        string
+	Limit         int
+}
+
+func DefaultTopOptions() *TopOptions {
+	return &TopOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		LabelSelector: "",
+		SortBy:        sortByCPU,
+		Limit:         defaultTopLimit,
+	}
+}
+
+func NewCmdTop() *cobra.Command {
+	o := DefaultTopOptions()
+	cmd := &cobra.Command{
+		Use:       "top devices",
+		Short:     "Display the devices with the most severe resource usage.",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{plural(DeviceKind)},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *TopOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supporting operators like '=', '!=', and 'in' (e.g., -l='key1=value1,key2!=value2,key3 in (value3, value4)').")
+	fs.StringVar(&o.SortBy, "sort-by", o.SortBy, fmt.Sprintf("Resource to sort the devices by. One of: (%s).", strings.Join(legalSortByTypes, ", ")))
+	fs.IntVar(&o.Limit, "limit", o.Limit, "The maximum number of devices to display. Use 0 to display all devices.")
+}
+
+func (o *TopOptions) Complete(cmd *cobra.Command, args []string) error {
+	return o.GlobalOptions.Complete(cmd, args)
+}
+
+func (o *TopOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != DeviceKind {
+		return fmt.Errorf("top is only supported for devices")
+	}
+	if len(name) > 0 {
+		return fmt.Errorf("cannot specify a device name, top always lists devices")
+	}
+	if !slices.Contains(legalSortByTypes, o.SortBy) {
+		return fmt.Errorf("sort-by must be one of (%s)", strings.Join(legalSortByTypes, ", "))
+	}
+	if o.Limit < 0 {
+		return fmt.Errorf("limit must be greater than or equal to 0")
+	}
+	return nil
+}
+
+func (o *TopOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	devices := []api.Device{}
+	params := api.ListDevicesParams{
+		LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
+		Limit:         util.Int32ToPtrWithNilDefault(topPageSize),
+	}
+	for {
+		response, err := c.ListDevicesWithResponse(ctx, &params)
+		if err != nil {
+			return fmt.Errorf("listing devices: %w", err)
+		}
+		if response.HTTPResponse.StatusCode != http.StatusOK || response.JSON200 == nil {
+			return fmt.Errorf("listing devices: %s", response.HTTPResponse.Status)
+		}
+		devices = append(devices, response.JSON200.Items...)
+		if response.JSON200.Metadata.Continue == nil || len(*response.JSON200.Metadata.Continue) == 0 {
+			break
+		}
+		params.Continue = response.JSON200.Metadata.Continue
+	}
+
+	return o.printTopTable(os.Stdout, devices)
+}
+
+// sortDevicesByResource orders the devices from the most to the least severe status of the given
+// resource. Devices with the same status are ordered by the severity of the remaining resources,
+// and then by name.
+func sortDevicesByResource(devices []api.Device, sortBy string) {
+	sort.SliceStable(devices, func(i, j int) bool {
+		si, sj := resourceSeverities(devices[i], sortBy), resourceSeverities(devices[j], sortBy)
+		for k := range si {
+			if si[k] != sj[k] {
+				return si[k] > sj[k]
+			}
+		}
+		return util.DefaultIfNil(devices[i].Metadata.Name, "") < util.DefaultIfNil(devices[j].Metadata.Name, "")
+	})
+}
+
+// deviceResourceStatuses returns the reported status of each resource, defaulting to unknown
+// for devices that have not reported it yet.
+func deviceResourceStatuses(device api.Device) map[string]api.DeviceResourceStatusType {
+	statuses := map[string]api.DeviceResourceStatusType{}
+	if device.Status != nil {
+		statuses[sortByCPU] = device.Status.Resources.Cpu
+		statuses[sortByMemory] = device.Status.Resources.Memory
+		statuses[sortByDisk] = device.Status.Resources.Disk
+	}
+	for _, r := range legalSortByTypes {
+		if len(statuses[r]) == 0 {
+			statuses[r] = api.DeviceResourceStatusUnknown
+		}
+	}
+	return statuses
+}
+
+// resourceSeverities returns the severity of the sortBy resource followed by those of the other
+// resources in cpu, memory, disk order.
+func resourceSeverities(device api.Device, sortBy string) []int {
+	statuses := deviceResourceStatuses(device)
+	severities := []int{resourceStatusSeverity[statuses[sortBy]]}
+	for _, r := range legalSortByTypes {
+		if r != sortBy {
+			severities = append(severities, resourceStatusSeverity[statuses[r]])
+		}
+	}
+	return severities
+}
+
+func (o *TopOptions) printTopTable(out io.Writer, devices []api.Device) error {
+	sortDevicesByResource(devices, o.SortBy)
+	if o.Limit > 0 && len(devices) > o.Limit {
+		devices = devices[:o.Limit]
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "NAME\tCPU\tMEMORY\tDISK\tSYSTEM\tLAST SEEN")
+	for _, d := range devices {
+		statuses := deviceResourceStatuses(d)
+		system := string(api.DeviceSummaryStatusUnknown)
+		lastSeen := "<never>"
+		if d.Status != nil {
+			system = string(d.Status.Summary.Status)
+			if !d.Status.LastSeen.IsZero() {
+				lastSeen = humanize.Time(d.Status.LastSeen)
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			util.DefaultIfNil(d.Metadata.Name, NoneString),
+			statuses[sortByCPU],
+			statuses[sortByMemory],
+			statuses[sortByDisk],
+			system,
+			lastSeen,
+		)
+	}
+	return w.Flush()
+}
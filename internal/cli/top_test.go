@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func topTestDevice(name string, cpu, memory, disk api.DeviceResourceStatusType) api.Device {
+	return api.Device{
+		Metadata: api.ObjectMeta{Name: util.StrToPtr(name)},
+		Status: &api.DeviceStatus{
+			Resources: api.DeviceResourceStatus{Cpu: cpu, Memory: memory, Disk: disk},
+			Summary:   api.DeviceSummaryStatus{Status: api.DeviceSummaryStatusOnline},
+		},
+	}
+}
+
+func topTestDevices() []api.Device {
+	return []api.Device{
+		topTestDevice("healthy", api.DeviceResourceStatusHealthy, api.DeviceResourceStatusHealthy, api.DeviceResourceStatusHealthy),
+		topTestDevice("cpu-warning", api.DeviceResourceStatusWarning, api.DeviceResourceStatusHealthy, api.DeviceResourceStatusHealthy),
+		topTestDevice("cpu-critical", api.DeviceResourceStatusCritical, api.DeviceResourceStatusHealthy, api.DeviceResourceStatusHealthy),
+		topTestDevice("memory-critical", api.DeviceResourceStatusHealthy, api.DeviceResourceStatusCritical, api.DeviceResourceStatusWarning),
+		topTestDevice("disk-critical", api.DeviceResourceStatusHealthy, api.DeviceResourceStatusHealthy, api.DeviceResourceStatusCritical),
+		{Metadata: api.ObjectMeta{Name: util.StrToPtr("no-status")}},
+	}
+}
+
+func deviceNames(devices []api.Device) []string {
+	names := []string{}
+	for _, d := range devices {
+		names = append(names, *d.Metadata.Name)
+	}
+	return names
+}
+
+func TestSortDevicesByResource(t *testing.T) {
+	tests := []struct {
+		sortBy   string
+		expected []string
+	}{
+		{
+			sortBy:   sortByCPU,
+			expected: []string{"cpu-critical", "cpu-warning", "no-status", "memory-critical", "disk-critical", "healthy"},
+		},
+		{
+			sortBy:   sortByMemory,
+			expected: []string{"memory-critical", "no-status", "cpu-critical", "cpu-warning", "disk-critical", "healthy"},
+		},
+		{
+			sortBy:   sortByDisk,
+			expected: []string{"disk-critical", "memory-critical", "no-status", "cpu-critical", "cpu-warning", "healthy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			devices := topTestDevices()
+			sortDevicesByResource(devices, tt.sortBy)
+			require.Equal(t, tt.expected, deviceNames(devices))
+		})
+	}
+}
+
+func TestPrintTopTable(t *testing.T) {
+	require := require.New(t)
+	o := DefaultTopOptions()
+	o.SortBy = sortByMemory
+	o.Limit = 2
+
+	var out bytes.Buffer
+	require.NoError(o.printTopTable(&out, topTestDevices()))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(lines, 3)
+	require.Equal([]string{"NAME", "CPU", "MEMORY", "DISK", "SYSTEM", "LAST", "SEEN"}, strings.Fields(lines[0]))
+	require.Equal([]string{"memory-critical", "Healthy", "Critical", "Warning", "Online", "<never>"}, strings.Fields(lines[1]))
+	require.Equal([]string{"no-status", "Unknown", "Unknown", "Unknown", "Unknown", "<never>"}, strings.Fields(lines[2]))
+}
+
+func TestTopValidate(t *testing.T) {
+	o := DefaultTopOptions()
+	o.ConfigFilePath = t.TempDir()
+
+	require.NoError(t, o.Validate([]string{"devices"}))
+	require.Error(t, o.Validate([]string{"fleets"}))
+	require.Error(t, o.Validate([]string{"device/foo"}))
+
+	o.SortBy = "network"
+	require.Error(t, o.Validate([]string{"devices"}))
+
+	o.SortBy = sortByDisk
+	o.Limit = -1
+	require.Error(t, o.Validate([]string{"devices"}))
+}
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const waitPollInterval = 2 * time.Second
+
+// waitKinds are the kinds of the resources reporting status conditions.
+var waitKinds = []string{DeviceKind, FleetKind, EnrollmentRequestKind, RepositoryKind, ResourceSyncKind, CertificateSigningRequestKind}
+
+type WaitOptions struct {
+	GlobalOptions
+
+	For     string
+	Timeout time.Duration
+
+	pollInterval time.Duration
+}
+
+func DefaultWaitOptions() *WaitOptions {
+	return &WaitOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Timeout:       30 * time.Second,
+		pollInterval:  waitPollInterval,
+	}
+}
+
+func NewCmdWait() *cobra.Command {
+	o := DefaultWaitOptions()
+	cmd := &cobra.Command{
+		Use:               "wait TYPE/NAME --for=condition=CONDITION[=STATUS] [--timeout=DURATION]",
+		Short:             "Wait until a resource reports a condition.",
+		Example:           "  flightctl wait device/foo --for=condition=Updating=False --timeout=5m",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeResourceArg(&o.GlobalOptions, waitKinds),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *WaitOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVar(&o.For, "for", o.For, "The condition to wait for, as condition=CONDITION[=STATUS]. STATUS defaults to True.")
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "How long to wait for the condition before giving up.")
+}
+
+func (o *WaitOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *WaitOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(waitKinds, kind) {
+		return fmt.Errorf("kind must be one of %s", strings.Join(waitKinds, ", "))
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific %s to wait for", kind)
+	}
+	if _, _, err := parseWaitCondition(o.For); err != nil {
+		return err
+	}
+	if o.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+	return nil
+}
+
+func (o *WaitOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	return o.wait(ctx, c, os.Stdout, kind, name)
+}
+
+// wait polls the resource until it reports the condition with the expected status, or the timeout
+// expires.
+func (o *WaitOptions) wait(ctx context.Context, c *apiclient.ClientWithResponses, out io.Writer, kind string, name string) error {
+	conditionType, status, err := parseWaitCondition(o.For)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+
+	timeoutErr := fmt.Errorf("timed out after %s waiting for %s/%s to report condition %s=%s", o.Timeout, kind, name, conditionType, status)
+	for {
+		resource, err := readResource(ctx, c, kind, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return timeoutErr
+			}
+			return err
+		}
+		conditions, err := resourceConditions(resource)
+		if err != nil {
+			return fmt.Errorf("parsing conditions of %s/%s: %w", kind, name, err)
+		}
+		if api.IsStatusConditionPresentAndEqual(conditions, conditionType, status) {
+			fmt.Fprintf(out, "%s/%s condition met\n", kind, name)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return timeoutErr
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseWaitCondition parses the --for flag, condition=CONDITION[=STATUS], into the condition type
+// and the status it is waited for.
+func parseWaitCondition(s string) (api.ConditionType, api.ConditionStatus, error) {
+	condition, ok := strings.CutPrefix(s, "condition=")
+	if !ok {
+		return "", "", fmt.Errorf("--for must be of the form condition=CONDITION[=STATUS]")
+	}
+	conditionType, value, hasValue := strings.Cut(condition, "=")
+	if len(conditionType) == 0 {
+		return "", "", fmt.Errorf("--for must name a condition")
+	}
+	if !hasValue {
+		return api.ConditionType(conditionType), api.ConditionStatusTrue, nil
+	}
+	for _, status := range []api.ConditionStatus{api.ConditionStatusTrue, api.ConditionStatusFalse, api.ConditionStatusUnknown} {
+		if strings.EqualFold(value, string(status)) {
+			return api.ConditionType(conditionType), status, nil
+		}
+	}
+	return "", "", fmt.Errorf("invalid condition status %q: must be True, False or Unknown", value)
+}
+
+// resourceConditions returns the conditions in the status of the resource.
+func resourceConditions(resource genericResource) ([]api.Condition, error) {
+	status, ok := resource["status"]
+	if !ok || status == nil {
+		return nil, nil
+	}
+	marshalled, err := json.Marshal(status)
+	if err != nil {
+		return nil, err
+	}
+	var parsed struct {
+		Conditions []api.Condition `json:"conditions"`
+	}
+	if err := json.Unmarshal(marshalled, &parsed); err != nil {
+		return nil, err
+	}
+	return parsed.Conditions, nil
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWaitCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		flag           string
+		expectedType   api.ConditionType
+		expectedStatus api.ConditionStatus
+		expectedErr    string
+	}{
+		{name: "status defaults to true", flag: "condition=Synced", expectedType: "Synced", expectedStatus: api.ConditionStatusTrue},
+		{name: "explicit status", flag: "condition=Updating=False", expectedType: "Updating", expectedStatus: api.ConditionStatusFalse},
+		{name: "status is case insensitive", flag: "condition=Updating=unknown", expectedType: "Updating", expectedStatus: api.ConditionStatusUnknown},
+		{name: "missing prefix", flag: "Updating", expectedErr: "condition=CONDITION"},
+		{name: "missing condition", flag: "condition=", expectedErr: "must name a condition"},
+		{name: "invalid status", flag: "condition=Updating=maybe", expectedErr: "invalid condition status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditionType, status, err := parseWaitCondition(tt.flag)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedType, conditionType)
+			require.Equal(t, tt.expectedStatus, status)
+		})
+	}
+}
+
+// waitTestServer serves a device that reports an update in progress for the given number of
+// reads, and no longer after.
+func waitTestServer(t *testing.T, updatingReads int) *httptest.Server {
+	var mu sync.Mutex
+	reads := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/devices/edge-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		status := api.ConditionStatusFalse
+		if reads < updatingReads {
+			status = api.ConditionStatusTrue
+		}
+		reads++
+		device := api.Device{
+			ApiVersion: "v1alpha1",
+			Kind:       "Device",
+			Metadata:   api.ObjectMeta{Name: util.StrToPtr("edge-1")},
+			Status: &api.DeviceStatus{
+				Conditions: []api.Condition{{Type: api.DeviceUpdating, Status: status}},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(device))
+	}))
+}
+
+func TestWaitConditionMet(t *testing.T) {
+	require := require.New(t)
+	server := waitTestServer(t, 2)
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	out := &bytes.Buffer{}
+	o := DefaultWaitOptions()
+	o.For = "condition=Updating=False"
+	o.pollInterval = time.Millisecond
+	require.NoError(o.wait(context.Background(), c, out, DeviceKind, "edge-1"))
+	require.Equal("device/edge-1 condition met\n", out.String())
+}
+
+func TestWaitConditionTimeout(t *testing.T) {
+	require := require.New(t)
+	server := waitTestServer(t, 1000)
+	defer server.Close()
+	c, err := apiclient.NewClientWithResponses(server.URL)
+	require.NoError(err)
+
+	o := DefaultWaitOptions()
+	o.For = "condition=Updating=False"
+	o.pollInterval = time.Millisecond
+	o.Timeout = 20 * time.Millisecond
+	err = o.wait(context.Background(), c, &bytes.Buffer{}, DeviceKind, "edge-1")
+	require.ErrorContains(err, "timed out after 20ms waiting for device/edge-1 to report condition Updating=False")
+}
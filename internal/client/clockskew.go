@@ -0,0 +1,114 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ClockSkew tolerates the clock of the client being off when validating the certificates of the
+// server, e.g. on devices whose real-time clock was reset.
+type ClockSkew struct {
+	// Tolerance is how far the certificates of the server may be outside of their validity
+	// period at the time of the client.
+	Tolerance time.Duration
+	// Detected is called when the certificates of the server are outside of their validity
+	// period, with the skew of the clock of the client (positive when the clock is behind), and
+	// whether the skew is within the tolerance.
+	// +optional
+	Detected func(skew time.Duration, tolerated bool)
+	// Now returns the time of the client, time.Now if nil.
+	// +optional
+	Now func() time.Time
+}
+
+// SetClockSkew sets the clock skew tolerated when validating the certificates of the server.
+func (c *Config) SetClockSkew(clockSkew *ClockSkew) {
+	c.clockSkew = clockSkew
+}
+
+// applyToTLSConfig verifies the certificates of the server with the clock skew tolerance. The
+// default verification of crypto/tls is disabled to be replaced, so it must be applied last.
+func (s *ClockSkew) applyToTLSConfig(tlsConfig *tls.Config) {
+	if s == nil || s.Tolerance <= 0 || tlsConfig.InsecureSkipVerify {
+		return
+	}
+	roots := tlsConfig.RootCAs
+	tlsConfig.InsecureSkipVerify = true //nolint:gosec
+	tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+		return s.verify(cs, roots)
+	}
+}
+
+func (s *ClockSkew) verify(cs tls.ConnectionState, roots *x509.CertPool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("tls: server presented no certificates")
+	}
+	now := time.Now()
+	if s.Now != nil {
+		now = s.Now()
+	}
+	opts := x509.VerifyOptions{
+		DNSName:       cs.ServerName,
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   now,
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	var invalidErr x509.CertificateInvalidError
+	if err == nil || !errors.As(err, &invalidErr) || invalidErr.Reason != x509.Expired {
+		return err
+	}
+
+	skew := certificatesSkew(cs.PeerCertificates, now)
+	if skew == 0 {
+		return err
+	}
+	if skew.Abs() > s.Tolerance {
+		s.detected(skew, false)
+		return fmt.Errorf("%w (the clock may be off by %s, beyond the tolerated %s)", err, skew.Abs().Round(time.Second), s.Tolerance)
+	}
+	opts.CurrentTime = now.Add(skew)
+	if _, verifyErr := cs.PeerCertificates[0].Verify(opts); verifyErr != nil {
+		return verifyErr
+	}
+	s.detected(skew, true)
+	return nil
+}
+
+func (s *ClockSkew) detected(skew time.Duration, tolerated bool) {
+	if s.Detected != nil {
+		s.Detected(skew, tolerated)
+	}
+}
+
+// certificatesSkew returns how far the time must be moved for all the certificates to be within
+// their validity period, positive if the certificates are not valid yet and negative if they
+// expired. It returns 0 if no time fits all of them.
+func certificatesSkew(certs []*x509.Certificate, now time.Time) time.Duration {
+	notBefore, notAfter := certs[0].NotBefore, certs[0].NotAfter
+	for _, cert := range certs[1:] {
+		if cert.NotBefore.After(notBefore) {
+			notBefore = cert.NotBefore
+		}
+		if cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+	switch {
+	case notAfter.Before(notBefore):
+		return 0
+	case now.Before(notBefore):
+		return notBefore.Sub(now)
+	case now.After(notAfter):
+		return notAfter.Sub(now)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newSkewServer returns a TLS server whose CA and certificate are valid from notBefore for a day,
+// and the PEM of the CA.
+func newSkewServer(t *testing.T, notBefore time.Time) (*httptest.Server, []byte) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "server"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+}
+
+func TestClockSkew(t *testing.T) {
+	// certificates keep their validity bounds to the second, truncate so that the skew is exact
+	now := time.Now().Truncate(time.Second)
+	testCases := []struct {
+		name          string
+		notBefore     time.Time
+		tolerance     time.Duration
+		expectedSkew  time.Duration
+		expectedError string
+	}{
+		{
+			name:      "valid certificate",
+			notBefore: now.Add(-time.Hour),
+			tolerance: 5 * time.Minute,
+		},
+		{
+			name:         "clock behind within tolerance",
+			notBefore:    now.Add(3 * time.Minute),
+			tolerance:    5 * time.Minute,
+			expectedSkew: 3 * time.Minute,
+		},
+		{
+			name:         "clock ahead within tolerance",
+			notBefore:    now.Add(-24*time.Hour - 3*time.Minute),
+			tolerance:    5 * time.Minute,
+			expectedSkew: -3 * time.Minute,
+		},
+		{
+			name:          "clock behind beyond tolerance",
+			notBefore:     now.Add(2 * time.Hour),
+			tolerance:     5 * time.Minute,
+			expectedSkew:  2 * time.Hour,
+			expectedError: "the clock may be off by 2h0m0s, beyond the tolerated 5m0s",
+		},
+		{
+			name:          "no tolerance",
+			notBefore:     now.Add(3 * time.Minute),
+			expectedError: "certificate has expired or is not yet valid",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			server, caPEM := newSkewServer(t, tt.notBefore)
+
+			var detected []time.Duration
+			var tolerated []bool
+			config := NewDefault()
+			config.Service = Service{Server: server.URL, CertificateAuthorityData: caPEM}
+			config.SetClockSkew(&ClockSkew{
+				Tolerance: tt.tolerance,
+				Detected: func(skew time.Duration, ok bool) {
+					detected = append(detected, skew)
+					tolerated = append(tolerated, ok)
+				},
+				Now: func() time.Time { return now },
+			})
+			httpClient, err := NewHTTPClientFromConfig(config)
+			require.NoError(err)
+
+			resp, err := httpClient.Get(server.URL)
+			if tt.expectedError != "" {
+				require.ErrorContains(err, tt.expectedError)
+			} else {
+				require.NoError(err)
+				resp.Body.Close()
+			}
+			if tt.expectedSkew == 0 || tt.tolerance == 0 {
+				require.Empty(detected)
+				return
+			}
+			require.Len(detected, 1)
+			require.Equal(tt.expectedSkew, detected[0])
+			require.Equal(tt.expectedError == "", tolerated[0])
+		})
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
@@ -35,12 +37,19 @@ const (
 type Config struct {
 	Service  Service  `json:"service"`
 	AuthInfo AuthInfo `json:"authentication"`
+	// Proxy is the proxy used to reach the server.
+	// +optional
+	Proxy *Proxy `json:"proxy,omitempty"`
 
 	// baseDir is used to resolve relative paths
 	// If baseDir is empty, the current working directory is used.
 	baseDir string `json:"-"`
 	// TestRootDir is the root directory for test files.
 	testRootDir string `json:"-"`
+	// clockSkew is the clock skew tolerated when validating the certificates of the server.
+	clockSkew *ClockSkew `json:"-"`
+	// resolver overrides how the host names of the server are resolved.
+	resolver *Resolver `json:"-"`
 }
 
 // Service contains information how to connect to and authenticate the FlightCtl API server.
@@ -56,6 +65,11 @@ type Service struct {
 	// CertificateAuthorityData contains PEM-encoded certificate authority certificates. Overrides CertificateAuthority
 	CertificateAuthorityData []byte `json:"certificate-authority-data,omitempty"`
 	InsecureSkipVerify       bool   `json:"insecureSkipVerify,omitempty"`
+	// PinnedPublicKeys restricts the servers to the ones whose certificate has one of the given
+	// public keys, as "sha256/" followed by the base64-encoded SHA-256 digest of the
+	// SubjectPublicKeyInfo. List the keys of both the current and the next certificate to rotate it.
+	// +optional
+	PinnedPublicKeys []string `json:"pinned-public-keys,omitempty"`
 }
 
 // AuthInfo contains information for authenticating FlightCtl API clients.
@@ -84,7 +98,7 @@ func (c *Config) Equal(c2 *Config) bool {
 	if c == nil || c2 == nil {
 		return false
 	}
-	return c.Service.Equal(&c2.Service) && c.AuthInfo.Equal(&c2.AuthInfo)
+	return c.Service.Equal(&c2.Service) && c.AuthInfo.Equal(&c2.AuthInfo) && c.Proxy.Equal(c2.Proxy)
 }
 
 func (s *Service) Equal(s2 *Service) bool {
@@ -96,7 +110,8 @@ func (s *Service) Equal(s2 *Service) bool {
 	}
 	return s.Server == s2.Server && s.TLSServerName == s2.TLSServerName &&
 		s.CertificateAuthority == s2.CertificateAuthority &&
-		bytes.Equal(s.CertificateAuthorityData, s2.CertificateAuthorityData)
+		bytes.Equal(s.CertificateAuthorityData, s2.CertificateAuthorityData) &&
+		slices.Equal(s.PinnedPublicKeys, s2.PinnedPublicKeys)
 }
 
 func (a *AuthInfo) Equal(a2 *AuthInfo) bool {
@@ -118,8 +133,11 @@ func (c *Config) DeepCopy() *Config {
 	return &Config{
 		Service:     *c.Service.DeepCopy(),
 		AuthInfo:    *c.AuthInfo.DeepCopy(),
+		Proxy:       c.Proxy.DeepCopy(),
 		baseDir:     c.baseDir,
 		testRootDir: c.testRootDir,
+		clockSkew:   c.clockSkew,
+		resolver:    c.resolver,
 	}
 }
 
@@ -129,6 +147,7 @@ func (s *Service) DeepCopy() *Service {
 	}
 	s2 := *s
 	s2.CertificateAuthorityData = bytes.Clone(s.CertificateAuthorityData)
+	s2.PinnedPublicKeys = slices.Clone(s.PinnedPublicKeys)
 	return &s2
 }
 
@@ -208,9 +227,16 @@ func NewHTTPClientFromConfig(config *Config) (*http.Client, error) {
 	}
 	tlsConfig.ServerName = tlsServerName
 
+	proxyFunc, err := config.Proxy.ProxyFunc()
+	if err != nil {
+		return nil, fmt.Errorf("NewHTTPClientFromConfig: %w", err)
+	}
+
 	httpClient := &http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: tlsConfig,
+			Proxy:           proxyFunc,
+			DialContext:     config.resolver.dialContext(),
 		},
 	}
 	return httpClient, nil
@@ -229,6 +255,8 @@ func CreateTLSConfigFromConfig(config *Config) (*tls.Config, error) {
 	if err := addClientCertToTLSConfig(&tlsConfig, config); err != nil {
 		return nil, fmt.Errorf("NewHTTPClientFromConfig: parsing client cert and key: %w", err)
 	}
+	config.clockSkew.applyToTLSConfig(&tlsConfig)
+	applyPublicKeyPinsToTLSConfig(&tlsConfig, config.Service.PinnedPublicKeys)
 	return &tlsConfig, nil
 }
 
@@ -293,12 +321,30 @@ func NewGRPCClientFromConfig(config *Config, endpoint string) (grpc_v1.RouterSer
 		}
 		tlsConfig.Certificates = []tls.Certificate{clientCert}
 	}
+	config.clockSkew.applyToTLSConfig(&tlsConfig)
+	applyPublicKeyPinsToTLSConfig(&tlsConfig, config.Service.PinnedPublicKeys)
 	// our transport is http, but the grpc library has special encoding for the endpoint
 	grpcEndpoint = strings.TrimPrefix(grpcEndpoint, "http://")
 	grpcEndpoint = strings.TrimPrefix(grpcEndpoint, "https://")
 	grpcEndpoint = strings.TrimSuffix(grpcEndpoint, "/")
+	if len(u.Host) > 0 && len(u.Port()) == 0 {
+		// the dialer gets the endpoint as is, without the default port the resolver of grpc adds
+		grpcEndpoint = net.JoinHostPort(u.Hostname(), "443")
+	}
 
-	client, err := grpc.NewClient(grpcEndpoint, grpc.WithTransportCredentials(credentials.NewTLS(&tlsConfig)))
+	dialContext, err := config.Proxy.dialContext(config.resolver.dialContext())
+	if err != nil {
+		return nil, fmt.Errorf("NewGRPCClientFromConfig: %w", err)
+	}
+	// the dialer resolves the endpoint itself, or leaves it to the proxy, rather than the resolver
+	// of grpc
+	grpcEndpoint = "passthrough:///" + grpcEndpoint
+	client, err := grpc.NewClient(grpcEndpoint,
+		grpc.WithTransportCredentials(credentials.NewTLS(&tlsConfig)),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialContext(ctx, "tcp", addr)
+		}),
+	)
 
 	if err != nil {
 		return nil, fmt.Errorf("NewGRPCClientFromConfig: creating gRPC client: %w", err)
@@ -406,6 +452,7 @@ func (c *Config) Validate() error {
 	validationErrors := make([]error, 0)
 	validationErrors = append(validationErrors, validateService(c.Service, c.baseDir, c.testRootDir)...)
 	validationErrors = append(validationErrors, validateAuthInfo(c.AuthInfo, c.baseDir, c.testRootDir)...)
+	validationErrors = append(validationErrors, validateProxy(c.Proxy)...)
 	if len(validationErrors) > 0 {
 		return fmt.Errorf("invalid configuration: %v", utilerrors.NewAggregate(validationErrors).Error())
 	}
@@ -439,6 +486,11 @@ func validateService(service Service, baseDir string, testRootDir string) []erro
 			defer clientCertCA.Close()
 		}
 	}
+	for _, pin := range service.PinnedPublicKeys {
+		if err := validatePublicKeyPin(pin); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
 	return validationErrors
 }
 
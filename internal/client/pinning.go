@@ -0,0 +1,71 @@
+package client
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PublicKeyPinPrefix prefixes the base64-encoded SHA-256 digest of the DER-encoded
+// SubjectPublicKeyInfo of a certificate in a public key pin.
+const PublicKeyPinPrefix = "sha256/"
+
+// PublicKeyPin returns the pin of the public key of the given certificate.
+func PublicKeyPin(cert *x509.Certificate) string {
+	digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return PublicKeyPinPrefix + base64.StdEncoding.EncodeToString(digest[:])
+}
+
+// validatePublicKeyPin returns an error if the pin is not a base64-encoded SHA-256 digest with
+// the PublicKeyPinPrefix.
+func validatePublicKeyPin(pin string) error {
+	encoded, ok := strings.CutPrefix(pin, PublicKeyPinPrefix)
+	if !ok {
+		return fmt.Errorf("invalid public key pin %q: must start with %q", pin, PublicKeyPinPrefix)
+	}
+	digest, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid public key pin %q: %w", pin, err)
+	}
+	if len(digest) != sha256.Size {
+		return fmt.Errorf("invalid public key pin %q: digest must be %d bytes long", pin, sha256.Size)
+	}
+	return nil
+}
+
+// applyPublicKeyPinsToTLSConfig rejects the servers whose certificate does not have one of the
+// pinned public keys, on top of the verification of the certificates. It wraps the verification
+// of the connection set so far, so it must be applied after the clock skew tolerance.
+func applyPublicKeyPinsToTLSConfig(tlsConfig *tls.Config, pins []string) {
+	if len(pins) == 0 {
+		return
+	}
+	verifyConnection := tlsConfig.VerifyConnection
+	tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+		if verifyConnection != nil {
+			if err := verifyConnection(cs); err != nil {
+				return err
+			}
+		}
+		return verifyPublicKeyPins(cs, pins)
+	}
+}
+
+func verifyPublicKeyPins(cs tls.ConnectionState, pins []string) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("tls: server presented no certificates")
+	}
+	// only the certificate of the server counts: the other certificates it presents are not
+	// necessarily the ones its certificate was verified with
+	pin := PublicKeyPin(cs.PeerCertificates[0])
+	for _, pinned := range pins {
+		if pin == pinned {
+			return nil
+		}
+	}
+	return fmt.Errorf("tls: the public key %s of the certificate of server %q does not match any of the pinned public keys", pin, cs.ServerName)
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKeyPins(t *testing.T) {
+	server, caPEM := newSkewServer(t, time.Now().Add(-time.Hour))
+	serverPin := PublicKeyPin(server.Certificate())
+	otherDigest := sha256.Sum256([]byte("other"))
+	otherPin := PublicKeyPinPrefix + base64.StdEncoding.EncodeToString(otherDigest[:])
+
+	testCases := []struct {
+		name               string
+		pins               []string
+		insecureSkipVerify bool
+		clockSkew          *ClockSkew
+		expectedError      string
+	}{
+		{
+			name: "no pins",
+		},
+		{
+			name: "matching pin",
+			pins: []string{serverPin},
+		},
+		{
+			name: "matching pin during rotation",
+			pins: []string{otherPin, serverPin},
+		},
+		{
+			name:          "mismatching pin",
+			pins:          []string{otherPin},
+			expectedError: "does not match any of the pinned public keys",
+		},
+		{
+			name:               "mismatching pin without verification",
+			pins:               []string{otherPin},
+			insecureSkipVerify: true,
+			expectedError:      "does not match any of the pinned public keys",
+		},
+		{
+			name:          "mismatching pin with clock skew tolerance",
+			pins:          []string{otherPin},
+			clockSkew:     &ClockSkew{Tolerance: 5 * time.Minute},
+			expectedError: "does not match any of the pinned public keys",
+		},
+		{
+			name:      "matching pin with clock skew tolerance",
+			pins:      []string{serverPin},
+			clockSkew: &ClockSkew{Tolerance: 5 * time.Minute},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			config := NewDefault()
+			config.Service = Service{
+				Server:                   server.URL,
+				CertificateAuthorityData: caPEM,
+				InsecureSkipVerify:       tt.insecureSkipVerify,
+				PinnedPublicKeys:         tt.pins,
+			}
+			config.SetClockSkew(tt.clockSkew)
+			httpClient, err := NewHTTPClientFromConfig(config)
+			require.NoError(err)
+
+			resp, err := httpClient.Get(server.URL)
+			if tt.expectedError != "" {
+				require.ErrorContains(err, tt.expectedError)
+				require.ErrorContains(err, serverPin)
+				return
+			}
+			require.NoError(err)
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestValidatePublicKeyPin(t *testing.T) {
+	digest := sha256.Sum256([]byte("key"))
+	require.NoError(t, validatePublicKeyPin(PublicKeyPinPrefix+base64.StdEncoding.EncodeToString(digest[:])))
+	require.ErrorContains(t, validatePublicKeyPin(base64.StdEncoding.EncodeToString(digest[:])), "must start with")
+	require.Error(t, validatePublicKeyPin(PublicKeyPinPrefix+"not base64!"))
+	require.ErrorContains(t, validatePublicKeyPin(PublicKeyPinPrefix+base64.StdEncoding.EncodeToString(digest[:16])), "32 bytes")
+}
@@ -0,0 +1,242 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/proxy"
+)
+
+var supportedProxySchemes = []string{"http", "https", "socks5"}
+
+// defaultProxyPorts are the ports of the proxies whose URL has none.
+var defaultProxyPorts = map[string]string{"http": "80", "https": "443", "socks5": "1080"}
+
+// Proxy contains the information needed to reach the FlightCtl API server through a proxy.
+type Proxy struct {
+	// URL is the URL of the proxy, e.g. "http://proxy.example.com:3128" or
+	// "socks5://proxy.example.com:1080". If empty, the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+	// environment variables are used.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// NoProxy lists the hosts, domains and networks reached without going through the proxy.
+	// +optional
+	NoProxy []string `json:"no-proxy,omitempty"`
+	// Username to authenticate with the proxy. Overrides the user set in the URL.
+	// +optional
+	Username string `json:"username,omitempty"`
+	// Password to authenticate with the proxy. Overrides the password set in the URL.
+	// +optional
+	Password string `json:"password,omitempty" datapolicy:"password"`
+}
+
+func (p *Proxy) Equal(p2 *Proxy) bool {
+	if p == p2 {
+		return true
+	}
+	if p == nil || p2 == nil {
+		return false
+	}
+	return p.URL == p2.URL && slices.Equal(p.NoProxy, p2.NoProxy) &&
+		p.Username == p2.Username && p.Password == p2.Password
+}
+
+func (p *Proxy) DeepCopy() *Proxy {
+	if p == nil {
+		return nil
+	}
+	p2 := *p
+	p2.NoProxy = slices.Clone(p.NoProxy)
+	return &p2
+}
+
+// proxyURL returns the URL of the configured proxy, including its credentials.
+func (p *Proxy) proxyURL() (*url.URL, error) {
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy url: %w", err)
+	}
+	if !slices.Contains(supportedProxySchemes, u.Scheme) {
+		return nil, fmt.Errorf("invalid proxy url %q: scheme must be one of %s", p.URL, strings.Join(supportedProxySchemes, ", "))
+	}
+	if len(u.Hostname()) == 0 {
+		return nil, fmt.Errorf("invalid proxy url %q: no hostname", p.URL)
+	}
+	if len(p.Username) > 0 {
+		u.User = url.UserPassword(p.Username, p.Password)
+	}
+	return u, nil
+}
+
+// ProxyFunc returns the function selecting the proxy of a request, to be used as the Proxy of
+// an http.Transport. Requests to the loopback interface never go through a proxy.
+func (p *Proxy) ProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if p == nil || len(p.URL) == 0 {
+		proxyFunc := httpproxy.FromEnvironment().ProxyFunc()
+		return func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}, nil
+	}
+
+	u, err := p.proxyURL()
+	if err != nil {
+		return nil, err
+	}
+	// the URL is passed along as a string so that its credentials are kept
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  u.String(),
+		HTTPSProxy: u.String(),
+		NoProxy:    strings.Join(p.NoProxy, ","),
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+// dialFunc dials a connection to the address on the network.
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+func (f dialFunc) Dial(network, addr string) (net.Conn, error) {
+	return f(context.Background(), network, addr)
+}
+
+func (f dialFunc) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	return f(ctx, network, addr)
+}
+
+// dialContext returns the function dialing the connections to the server through the proxy
+// ProxyFunc selects for it, for the clients that do not go through an http.Transport, like the
+// gRPC one. dial reaches the proxy, or the server if no proxy is selected; nil dials them as usual.
+func (p *Proxy) dialContext(dial dialFunc) (dialFunc, error) {
+	proxyFunc, err := p.ProxyFunc()
+	if err != nil {
+		return nil, err
+	}
+	if dial == nil {
+		dial = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		proxyURL, err := proxyFunc(&http.Request{URL: &url.URL{Scheme: "https", Host: addr}})
+		if err != nil {
+			return nil, err
+		}
+		if proxyURL == nil {
+			return dial(ctx, network, addr)
+		}
+		if proxyURL.Scheme == "socks5" {
+			return dialSOCKS5(ctx, dial, proxyURL, network, addr)
+		}
+		return dialConnect(ctx, dial, proxyURL, addr)
+	}, nil
+}
+
+// proxyAddress returns the address of the proxy, with the default port of its scheme if it has none.
+func proxyAddress(proxyURL *url.URL) string {
+	if len(proxyURL.Port()) > 0 {
+		return proxyURL.Host
+	}
+	return net.JoinHostPort(proxyURL.Hostname(), defaultProxyPorts[proxyURL.Scheme])
+}
+
+// dialSOCKS5 dials the address through the SOCKS5 proxy, authenticating with the credentials of
+// its URL if it has any.
+func dialSOCKS5(ctx context.Context, dial dialFunc, proxyURL *url.URL, network, addr string) (net.Conn, error) {
+	var auth *proxy.Auth
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		auth = &proxy.Auth{User: proxyURL.User.Username(), Password: password}
+	}
+	dialer, err := proxy.SOCKS5("tcp", proxyAddress(proxyURL), auth, dial)
+	if err != nil {
+		return nil, err
+	}
+	return dialer.(proxy.ContextDialer).DialContext(ctx, network, addr)
+}
+
+// dialConnect dials the address through the HTTP(S) proxy with a CONNECT request, authenticating
+// with the credentials of its URL if it has any.
+func dialConnect(ctx context.Context, dial dialFunc, proxyURL *url.URL, addr string) (net.Conn, error) {
+	conn, err := dial(ctx, "tcp", proxyAddress(proxyURL))
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("proxy %s: %w", proxyURL.Host, err)
+		}
+		conn = tlsConn
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer func() { _ = conn.SetDeadline(time.Time{}) }()
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := proxyURL.User.Username() + ":" + password
+		req.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s: %w", proxyURL.Host, err)
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s: %w", proxyURL.Host, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s refused to connect to %s: %s", proxyURL.Host, addr, resp.Status)
+	}
+	if reader.Buffered() > 0 {
+		return &proxiedConn{Conn: conn, reader: reader}, nil
+	}
+	return conn, nil
+}
+
+// proxiedConn is a connection through a proxy whose first bytes were read along with the response
+// of the proxy.
+type proxiedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *proxiedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+func validateProxy(proxy *Proxy) []error {
+	if proxy == nil {
+		return nil
+	}
+	if len(proxy.URL) == 0 {
+		if len(proxy.Username) > 0 || len(proxy.Password) > 0 {
+			return []error{fmt.Errorf("proxy username and password require a proxy url")}
+		}
+		return nil
+	}
+	if _, err := proxy.proxyURL(); err != nil {
+		return []error{err}
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// the server name does not resolve, so requests only succeed when they go through the test
+// proxies, which forward them to the test server
+const proxiedServer = "https://devices.flightctl.invalid"
+
+type testProxy struct {
+	mu      sync.Mutex
+	targets []string
+	auth    []string
+}
+
+func (p *testProxy) record(target string, auth string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.targets = append(p.targets, target)
+	p.auth = append(p.auth, auth)
+}
+
+func (p *testProxy) requests() ([]string, []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.targets, p.auth
+}
+
+func newProxiedServer(t *testing.T) (*httptest.Server, *Config) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	config := NewDefault()
+	config.Service = Service{
+		Server:                   proxiedServer,
+		TLSServerName:            "example.com",
+		CertificateAuthorityData: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+	}
+	return server, config
+}
+
+// newHTTPProxy starts a proxy tunneling CONNECT requests to the given address.
+func newHTTPProxy(t *testing.T, upstream string) (*httptest.Server, *testProxy) {
+	recorded := &testProxy{}
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "only CONNECT is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		recorded.record(r.Host, r.Header.Get("Proxy-Authorization"))
+		upstreamConn, err := net.Dial("tcp", upstream)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			upstreamConn.Close()
+			return
+		}
+		tunnel(bufferedConn{Conn: conn, r: rw.Reader}, upstreamConn)
+	}))
+	t.Cleanup(proxy.Close)
+	return proxy, recorded
+}
+
+// newSOCKS5Proxy starts a SOCKS5 proxy requiring username/password authentication and
+// forwarding connections to the given address.
+func newSOCKS5Proxy(t *testing.T, upstream string) (net.Listener, *testProxy) {
+	recorded := &testProxy{}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				if err := socks5Handshake(conn, recorded); err != nil {
+					conn.Close()
+					return
+				}
+				upstreamConn, err := net.Dial("tcp", upstream)
+				if err != nil {
+					conn.Close()
+					return
+				}
+				tunnel(conn, upstreamConn)
+			}()
+		}
+	}()
+	return listener, recorded
+}
+
+func socks5Handshake(conn net.Conn, recorded *testProxy) error {
+	r := bufio.NewReader(conn)
+	// greeting: version, number of methods, methods
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(r, make([]byte, header[1])); err != nil {
+		return err
+	}
+	// select username/password authentication
+	if _, err := conn.Write([]byte{5, 2}); err != nil {
+		return err
+	}
+	// authentication: version, username, password
+	readString := func() (string, error) {
+		length, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		value := make([]byte, length)
+		_, err = io.ReadFull(r, value)
+		return string(value), err
+	}
+	if _, err := r.ReadByte(); err != nil {
+		return err
+	}
+	username, err := readString()
+	if err != nil {
+		return err
+	}
+	password, err := readString()
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Write([]byte{1, 0}); err != nil {
+		return err
+	}
+	// request: version, command, reserved, address type, address, port
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(r, request); err != nil {
+		return err
+	}
+	var host string
+	switch request[3] {
+	case 1:
+		ip := make([]byte, 4)
+		if _, err := io.ReadFull(r, ip); err != nil {
+			return err
+		}
+		host = net.IP(ip).String()
+	case 3:
+		if host, err = readString(); err != nil {
+			return err
+		}
+	default:
+		return io.ErrUnexpectedEOF
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(r, port); err != nil {
+		return err
+	}
+	recorded.record(net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), username+":"+password)
+	_, err = conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+	return err
+}
+
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func tunnel(a net.Conn, b net.Conn) {
+	go func() {
+		_, _ = io.Copy(a, b)
+		a.Close()
+	}()
+	_, _ = io.Copy(b, a)
+	b.Close()
+}
+
+func getThroughProxy(t *testing.T, config *Config) error {
+	httpClient, err := NewHTTPClientFromConfig(config)
+	require.NoError(t, err)
+	resp, err := httpClient.Get(config.Service.Server + "/api/v1/devices")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "ok", string(body))
+	return nil
+}
+
+func TestHTTPProxy(t *testing.T) {
+	require := require.New(t)
+	server, config := newProxiedServer(t)
+	proxy, recorded := newHTTPProxy(t, server.Listener.Addr().String())
+
+	config.Proxy = &Proxy{URL: proxy.URL, Username: "agent", Password: "s3cret"}
+	require.NoError(getThroughProxy(t, config))
+	targets, auth := recorded.requests()
+	require.Equal([]string{"devices.flightctl.invalid:443"}, targets)
+	require.Equal([]string{"Basic " + base64.StdEncoding.EncodeToString([]byte("agent:s3cret"))}, auth)
+}
+
+func TestHTTPProxyFromEnvironment(t *testing.T) {
+	require := require.New(t)
+	server, config := newProxiedServer(t)
+	proxy, recorded := newHTTPProxy(t, server.Listener.Addr().String())
+
+	t.Setenv("HTTPS_PROXY", proxy.URL)
+	t.Setenv("NO_PROXY", "")
+	require.NoError(getThroughProxy(t, config))
+	targets, _ := recorded.requests()
+	require.Equal([]string{"devices.flightctl.invalid:443"}, targets)
+
+	// hosts excluded from the proxy are reached directly, which fails for the test server
+	t.Setenv("NO_PROXY", ".flightctl.invalid")
+	require.Error(getThroughProxy(t, config))
+	targets, _ = recorded.requests()
+	require.Len(targets, 1)
+}
+
+func TestNoProxy(t *testing.T) {
+	require := require.New(t)
+	server, config := newProxiedServer(t)
+	proxy, recorded := newHTTPProxy(t, server.Listener.Addr().String())
+
+	config.Proxy = &Proxy{URL: proxy.URL, NoProxy: []string{"flightctl.invalid"}}
+	require.Error(getThroughProxy(t, config))
+	targets, _ := recorded.requests()
+	require.Empty(targets)
+}
+
+func TestSOCKS5Proxy(t *testing.T) {
+	require := require.New(t)
+	server, config := newProxiedServer(t)
+	listener, recorded := newSOCKS5Proxy(t, server.Listener.Addr().String())
+
+	config.Proxy = &Proxy{URL: "socks5://" + listener.Addr().String(), Username: "agent", Password: "s3cret"}
+	require.NoError(getThroughProxy(t, config))
+	targets, auth := recorded.requests()
+	require.Equal([]string{"devices.flightctl.invalid:443"}, targets)
+	require.Equal([]string{"agent:s3cret"}, auth)
+}
+
+// streamThroughProxy opens a stream with the gRPC client of the config. It fails past the proxy,
+// as the test server does not serve gRPC.
+func streamThroughProxy(t *testing.T, config *Config) {
+	grpcClient, err := NewGRPCClientFromConfig(config, proxiedServer)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = grpcClient.Stream(ctx)
+}
+
+func TestHTTPProxyGRPC(t *testing.T) {
+	require := require.New(t)
+	server, config := newProxiedServer(t)
+	proxy, recorded := newHTTPProxy(t, server.Listener.Addr().String())
+
+	config.Proxy = &Proxy{URL: proxy.URL, Username: "agent", Password: "s3cret"}
+	streamThroughProxy(t, config)
+	targets, auth := recorded.requests()
+	require.NotEmpty(targets)
+	require.Equal("devices.flightctl.invalid:443", targets[0])
+	require.Equal("Basic "+base64.StdEncoding.EncodeToString([]byte("agent:s3cret")), auth[0])
+}
+
+func TestHTTPProxyFromEnvironmentGRPC(t *testing.T) {
+	require := require.New(t)
+	server, config := newProxiedServer(t)
+	proxy, recorded := newHTTPProxy(t, server.Listener.Addr().String())
+
+	t.Setenv("HTTPS_PROXY", proxy.URL)
+	t.Setenv("NO_PROXY", "")
+	streamThroughProxy(t, config)
+	targets, _ := recorded.requests()
+	require.NotEmpty(targets)
+	require.Equal("devices.flightctl.invalid:443", targets[0])
+}
+
+func TestSOCKS5ProxyGRPC(t *testing.T) {
+	require := require.New(t)
+	server, config := newProxiedServer(t)
+	listener, recorded := newSOCKS5Proxy(t, server.Listener.Addr().String())
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(err)
+	// the proxy is reached with the overrides of the resolver
+	config.Proxy = &Proxy{URL: "socks5://socks.flightctl.invalid:" + port, Username: "agent", Password: "s3cret"}
+	config.SetResolver(&Resolver{Hosts: map[string]string{"socks.flightctl.invalid": "127.0.0.1"}})
+	streamThroughProxy(t, config)
+	targets, auth := recorded.requests()
+	require.NotEmpty(targets)
+	require.Equal("devices.flightctl.invalid:443", targets[0])
+	require.Equal("agent:s3cret", auth[0])
+}
+
+func TestInvalidProxy(t *testing.T) {
+	tests := []struct {
+		name                   string
+		proxy                  Proxy
+		expectedErrorSubstring string
+	}{
+		{name: "unsupported scheme", proxy: Proxy{URL: "ftp://proxy.example.com"}, expectedErrorSubstring: "scheme must be one of"},
+		{name: "no hostname", proxy: Proxy{URL: "http://"}, expectedErrorSubstring: "no hostname"},
+		{name: "credentials without url", proxy: Proxy{Username: "agent"}, expectedErrorSubstring: "require a proxy url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{Service: Service{Server: "https://localhost:3443"}, Proxy: &tt.proxy}
+			require.ErrorContains(t, config.Validate(), tt.expectedErrorSubstring)
+		})
+	}
+}
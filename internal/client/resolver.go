@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const defaultDNSPort = "53"
+
+// Resolver overrides how the host names of the server are resolved, e.g. on devices in networks
+// with broken or captive DNS.
+type Resolver struct {
+	// Servers are the addresses of the DNS servers queried instead of the ones of the system, as
+	// "ip" or "ip:port", tried in turn.
+	// +optional
+	Servers []string `json:"servers,omitempty"`
+	// Hosts maps host names to the IP address they resolve to without querying DNS, like
+	// /etc/hosts.
+	// +optional
+	Hosts map[string]string `json:"hosts,omitempty"`
+}
+
+// SetResolver sets how the host names of the server are resolved.
+func (c *Config) SetResolver(resolver *Resolver) {
+	c.resolver = resolver
+}
+
+// Validate checks that the DNS servers and the host entries are IP addresses.
+func (r *Resolver) Validate() error {
+	if r == nil {
+		return nil
+	}
+	for _, server := range r.Servers {
+		if _, err := dnsServerAddress(server); err != nil {
+			return err
+		}
+	}
+	for host, ip := range r.Hosts {
+		if len(host) == 0 {
+			return fmt.Errorf("dns hosts: host name must not be empty")
+		}
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("dns hosts: %q for %s is not an IP address", ip, host)
+		}
+	}
+	return nil
+}
+
+// dnsServerAddress returns the address of the DNS server with the default port if it has none.
+func dnsServerAddress(server string) (string, error) {
+	if net.ParseIP(server) != nil {
+		return net.JoinHostPort(server, defaultDNSPort), nil
+	}
+	host, port, err := net.SplitHostPort(server)
+	if err != nil || net.ParseIP(host) == nil || len(port) == 0 {
+		return "", fmt.Errorf("dns servers: %q is not an IP address with an optional port", server)
+	}
+	return server, nil
+}
+
+// dialContext returns the function dialing the connections with the overrides, or nil to dial
+// them as usual.
+func (r *Resolver) dialContext() func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if r == nil || (len(r.Servers) == 0 && len(r.Hosts) == 0) {
+		return nil
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if len(r.Servers) > 0 {
+		servers := make([]string, 0, len(r.Servers))
+		for _, server := range r.Servers {
+			// validated with the config
+			address, _ := dnsServerAddress(server)
+			servers = append(servers, address)
+		}
+		var next atomic.Uint32
+		dnsDialer := &net.Dialer{}
+		dialer.Resolver = &net.Resolver{
+			PreferGo: true,
+			// each attempt of a query goes to the next server, so that an unreachable server is
+			// skipped on retry
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				server := servers[int(next.Add(1)-1)%len(servers)]
+				return dnsDialer.DialContext(ctx, network, server)
+			},
+		}
+	}
+	hosts := make(map[string]string, len(r.Hosts))
+	for host, ip := range r.Hosts {
+		hosts[strings.ToLower(host)] = ip
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err == nil {
+			if ip, ok := hosts[strings.ToLower(host)]; ok {
+				addr = net.JoinHostPort(ip, port)
+			}
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// newDNSServer starts a DNS server answering the A queries with the loopback address, and
+// counting the queries it received.
+func newDNSServer(t *testing.T) (string, *atomic.Int32) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	queries := &atomic.Int32{}
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var parser dnsmessage.Parser
+			header, err := parser.Start(buf[:n])
+			if err != nil {
+				continue
+			}
+			question, err := parser.Question()
+			if err != nil {
+				continue
+			}
+			queries.Add(1)
+			builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true})
+			_ = builder.StartQuestions()
+			_ = builder.Question(question)
+			_ = builder.StartAnswers()
+			if question.Type == dnsmessage.TypeA {
+				_ = builder.AResource(
+					dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 60},
+					dnsmessage.AResource{A: [4]byte{127, 0, 0, 1}},
+				)
+			}
+			response, err := builder.Finish()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(response, addr)
+		}
+	}()
+	return conn.LocalAddr().String(), queries
+}
+
+// newUnresolvableServer returns the config of a client of a test server whose host name only
+// resolves with the overrides.
+func newUnresolvableServer(t *testing.T) *Config {
+	server, config := newProxiedServer(t)
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	config.Service.Server = proxiedServer + ":" + port
+	return config
+}
+
+func getServer(t *testing.T, config *Config) error {
+	httpClient, err := NewHTTPClientFromConfig(config)
+	require.NoError(t, err)
+	resp, err := httpClient.Get(config.Service.Server)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func TestResolverHosts(t *testing.T) {
+	require := require.New(t)
+	config := newUnresolvableServer(t)
+	require.Error(getServer(t, config))
+
+	config.SetResolver(&Resolver{Hosts: map[string]string{"Devices.FlightCtl.invalid": "127.0.0.1"}})
+	require.NoError(getServer(t, config))
+
+	// the override does not apply to other host names
+	config.SetResolver(&Resolver{Hosts: map[string]string{"other.flightctl.invalid": "127.0.0.1"}})
+	require.Error(getServer(t, config))
+}
+
+func TestResolverServers(t *testing.T) {
+	require := require.New(t)
+	config := newUnresolvableServer(t)
+	dnsServer, queries := newDNSServer(t)
+
+	config.SetResolver(&Resolver{Servers: []string{dnsServer}})
+	require.NoError(getServer(t, config))
+	require.NotZero(queries.Load())
+
+	// the host entries take precedence over the DNS servers
+	queries.Store(0)
+	config.SetResolver(&Resolver{Servers: []string{dnsServer}, Hosts: map[string]string{"devices.flightctl.invalid": "127.0.0.1"}})
+	require.NoError(getServer(t, config))
+	require.Zero(queries.Load())
+}
+
+func TestResolverGRPC(t *testing.T) {
+	require := require.New(t)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	t.Cleanup(func() { listener.Close() })
+	accepted := make(chan struct{}, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		accepted <- struct{}{}
+		conn.Close()
+	}()
+
+	_, config := newProxiedServer(t)
+	config.SetResolver(&Resolver{Hosts: map[string]string{"devices.flightctl.invalid": "127.0.0.1"}})
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(err)
+	grpcClient, err := NewGRPCClientFromConfig(config, "https://devices.flightctl.invalid:"+port)
+	require.NoError(err)
+	// the connection fails the TLS handshake, but was dialed to the overridden address
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _ = grpcClient.Stream(ctx)
+	select {
+	case <-accepted:
+	case <-ctx.Done():
+		require.Fail("the connection was not dialed to the overridden address")
+	}
+}
+
+func TestResolverValidate(t *testing.T) {
+	require := require.New(t)
+	var resolver *Resolver
+	require.NoError(resolver.Validate())
+	require.NoError((&Resolver{
+		Servers: []string{"192.0.2.53", "192.0.2.54:5353", "2001:db8::53", "[2001:db8::54]:53"},
+		Hosts:   map[string]string{"api.example.com": "192.0.2.1", "agent-api.example.com": "2001:db8::1"},
+	}).Validate())
+
+	for _, server := range []string{"dns.example.com", "192.0.2.53:", "192.0.2.53:53:53"} {
+		err := (&Resolver{Servers: []string{server}}).Validate()
+		require.Error(err, server)
+		require.True(strings.HasPrefix(err.Error(), "dns servers:"), err.Error())
+	}
+	require.ErrorContains((&Resolver{Hosts: map[string]string{"api.example.com": "example.com"}}).Validate(), "is not an IP address")
+	require.ErrorContains((&Resolver{Hosts: map[string]string{"": "192.0.2.1"}}).Validate(), "must not be empty")
+}
@@ -3,16 +3,39 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
 	"sigs.k8s.io/yaml"
 )
 
 const (
 	appName = "flightctl"
+
+	// Sensitive fields may reference their value instead of containing it, e.g.
+	// "file:/run/secrets/kv-password" or "env:KV_PASSWORD".
+	secretRefFilePrefix = "file:"
+	secretRefEnvPrefix  = "env:"
+
+	// UnknownFieldsModeStrict rejects requests with fields unknown to the API.
+	UnknownFieldsModeStrict = "strict"
+	// UnknownFieldsModeLenient drops the fields unknown to the API, with a warning.
+	UnknownFieldsModeLenient = "lenient"
+
+	// RateLimitScopeIdentity charges API requests to the authenticated principal.
+	RateLimitScopeIdentity = "identity"
+	// RateLimitScopeIP charges API requests to their source address.
+	RateLimitScopeIP = "ip"
+
+	// DefaultSwaggerUIDist is the swagger-ui-dist package the Swagger UI assets are loaded from
+	// by default.
+	DefaultSwaggerUIDist = "https://unpkg.com/swagger-ui-dist@5.17.14"
 )
 
 type Config struct {
@@ -21,6 +44,17 @@ type Config struct {
 	KV         *kvConfig         `json:"kv,omitempty"`
 	Auth       *authConfig       `json:"auth,omitempty"`
 	Prometheus *prometheusConfig `json:"prometheus,omitempty"`
+	Workers    *workersConfig    `json:"workers,omitempty"`
+	// Notifications configures the webhooks notified of resource events.
+	Notifications *notificationsConfig `json:"notifications,omitempty"`
+	// Admission configures the webhooks validating the resources created or updated through the
+	// API or synced from repositories by resource syncs, to enforce organizational policies.
+	Admission *admissionConfig `json:"admission,omitempty"`
+	// ChangeCapture configures the stream of the creations, updates and deletions of resources
+	// published to external consumers.
+	ChangeCapture *changeCaptureConfig `json:"changeCapture,omitempty"`
+	// Quotas caps the number of resources each organization can have.
+	Quotas *quotasConfig `json:"quotas,omitempty"`
 }
 
 type dbConfig struct {
@@ -30,6 +64,16 @@ type dbConfig struct {
 	Name     string `json:"name,omitempty"`
 	User     string `json:"user,omitempty"`
 	Password string `json:"password,omitempty"`
+	// MigrationUser is the user running the migrations at startup, which owns the tables. The
+	// services connect as User, which must not own the tables so that the row-level security
+	// policies isolating the organizations apply to it. Migrations run as User when empty.
+	MigrationUser     string `json:"migrationUser,omitempty"`
+	MigrationPassword string `json:"migrationPassword,omitempty"`
+	ReplicaHostname   string `json:"replicaHostname,omitempty"`
+	ReplicaPort       uint   `json:"replicaPort,omitempty"`
+	// SlowQueryThreshold is the duration above which a query is logged and counted as slow. Slow
+	// queries are not tracked when zero.
+	SlowQueryThreshold util.Duration `json:"slowQueryThreshold,omitempty"`
 }
 
 type svcConfig struct {
@@ -53,19 +97,85 @@ type svcConfig struct {
 	HttpMaxHeaderBytes    int           `json:"httpMaxHeaderBytes,omitempty"`
 	HttpMaxUrlLength      int           `json:"httpMaxUrlLength,omitempty"`
 	HttpMaxRequestSize    int           `json:"httpMaxRequestSize,omitempty"`
+	CrlSource             string        `json:"crlSource,omitempty"`
+	CrlRefreshInterval    util.Duration `json:"crlRefreshInterval,omitempty"`
+	RevisionHistoryLimit  int           `json:"revisionHistoryLimit,omitempty"`
+	// OrphanCleanupDelay is how long the rows left behind by deleted resources, e.g. the logs of
+	// a deleted device, stay unchanged before they are deleted.
+	OrphanCleanupDelay util.Duration `json:"orphanCleanupDelay,omitempty"`
+	// AgentAllowedNetworks are the CIDRs allowed to reach the agent endpoint. All networks are
+	// allowed when empty.
+	AgentAllowedNetworks []string `json:"agentAllowedNetworks,omitempty"`
+	// AgentDeniedNetworks are the CIDRs denied from reaching the agent endpoint, even when they
+	// are part of an allowed network.
+	AgentDeniedNetworks []string `json:"agentDeniedNetworks,omitempty"`
+	// TrustedProxies are the CIDRs of the reverse proxies whose X-Forwarded-For header is honored
+	// when determining the source address of a request.
+	TrustedProxies []string `json:"trustedProxies,omitempty"`
+	// UnknownFieldsMode is how request fields unknown to the API are handled: "strict" rejects the
+	// request, "lenient" drops the fields and returns a Warning header.
+	UnknownFieldsMode string `json:"unknownFieldsMode,omitempty"`
+	// MaintenanceMode rejects the requests that modify resources with 503 Service Unavailable,
+	// while reads and health probes are still served.
+	MaintenanceMode bool `json:"maintenanceMode,omitempty"`
+	// MaintenanceRetryAfter is the delay after which clients are asked to retry the requests
+	// rejected in maintenance mode.
+	MaintenanceRetryAfter util.Duration `json:"maintenanceRetryAfter,omitempty"`
+	// RateLimitRequests is the number of API requests each client may send per RateLimitWindow.
+	// Requests are not limited when 0.
+	RateLimitRequests int `json:"rateLimitRequests,omitempty"`
+	// RateLimitWindow is the window over which the request budget of a client refills.
+	RateLimitWindow util.Duration `json:"rateLimitWindow,omitempty"`
+	// RateLimitScope is how clients are told apart: "identity" charges requests to the
+	// authenticated principal, falling back to the source address for unauthenticated requests,
+	// "ip" charges them to the source address.
+	RateLimitScope string `json:"rateLimitScope,omitempty"`
+	// RateLimitIPRequests additionally limits the requests from each source address per
+	// RateLimitWindow in the "identity" scope. Source addresses are not limited when 0.
+	RateLimitIPRequests int `json:"rateLimitIPRequests,omitempty"`
+	// ResponseCompression are the algorithms API responses are compressed with, in order of
+	// preference: "zstd" and "gzip". Responses are not compressed when empty.
+	ResponseCompression []string `json:"responseCompression,omitempty"`
+	// ResponseCompressionMinSize is the size in bytes from which responses are compressed.
+	ResponseCompressionMinSize int `json:"responseCompressionMinSize,omitempty"`
+	// BootstrapTokenEnrollment lets agents without an enrollment certificate redeem a one-time
+	// bootstrap token for one on the agent endpoint, which then accepts TLS connections without a
+	// client certificate for that request only.
+	BootstrapTokenEnrollment bool `json:"bootstrapTokenEnrollment,omitempty"`
+	// SwaggerUI serves a Swagger UI browsing the OpenAPI spec at /openapi/ui. The spec itself is
+	// always served at /openapi/<version>.json.
+	SwaggerUI bool `json:"swaggerUI,omitempty"`
+	// SwaggerUIDist is the location the browser loads the Swagger UI assets, swagger-ui.css and
+	// swagger-ui-bundle.js, from, e.g. a mirror of the swagger-ui-dist package.
+	SwaggerUIDist string `json:"swaggerUIDist,omitempty"`
+	// SwaggerUIIntegrity are the Subresource Integrity hashes the browser checks the Swagger UI
+	// assets against. They are required when the assets are loaded from another origin.
+	SwaggerUIIntegrity *swaggerUIIntegrity `json:"swaggerUIIntegrity,omitempty"`
+}
+
+type swaggerUIIntegrity struct {
+	// CSS is the hash of swagger-ui.css, e.g. "sha384-<base64 digest>".
+	CSS string `json:"css,omitempty"`
+	// Bundle is the hash of swagger-ui-bundle.js.
+	Bundle string `json:"bundle,omitempty"`
 }
 
 type kvConfig struct {
 	Hostname string `json:"hostname,omitempty"`
 	Port     uint   `json:"port,omitempty"`
 	Password string `json:"password,omitempty"`
+	// QueueCompression is the compression of the payloads of queued tasks, "none" or "gzip".
+	QueueCompression string `json:"queueCompression,omitempty"`
+	// QueueCompressionThreshold is the size in bytes from which payloads are compressed.
+	QueueCompressionThreshold int `json:"queueCompressionThreshold,omitempty"`
 }
 
 type authConfig struct {
-	K8s                   *k8sAuth  `json:"k8s,omitempty"`
-	OIDC                  *oidcAuth `json:"oidc,omitempty"`
-	CACert                string    `json:"caCert,omitempty"`
-	InsecureSkipTlsVerify bool      `json:"insecureSkipTlsVerify,omitempty"`
+	K8s                   *k8sAuth      `json:"k8s,omitempty"`
+	OIDC                  *oidcAuth     `json:"oidc,omitempty"`
+	CACert                string        `json:"caCert,omitempty"`
+	InsecureSkipTlsVerify bool          `json:"insecureSkipTlsVerify,omitempty"`
+	PermissionCacheTTL    util.Duration `json:"permissionCacheTTL,omitempty"`
 }
 
 type k8sAuth struct {
@@ -85,6 +195,95 @@ type prometheusConfig struct {
 	ApiLatencyBins []float64 `json:"apiLatencyBins,omitempty"`
 }
 
+type workersConfig struct {
+	// TaskTimeout is the maximum execution time of a task handler.
+	TaskTimeout util.Duration `json:"taskTimeout,omitempty"`
+	// TaskTimeouts overrides TaskTimeout per task type, e.g. "fleet-rollout".
+	TaskTimeouts map[string]util.Duration `json:"taskTimeouts,omitempty"`
+	// MaxTaskRetries is the number of times a task that timed out is retried.
+	MaxTaskRetries int `json:"maxTaskRetries,omitempty"`
+	// DebugAddress is the address of the debug endpoint reporting recent task executions and the
+	// fleet reconcile metrics. The endpoint is disabled when empty.
+	DebugAddress string `json:"debugAddress,omitempty"`
+	// TaskTraceSize is the number of recent task executions reported by the debug endpoint.
+	TaskTraceSize int `json:"taskTraceSize,omitempty"`
+}
+
+type notificationsConfig struct {
+	// Webhooks are the webhooks notified of the events they subscribe to.
+	Webhooks []*webhookConfig `json:"webhooks,omitempty"`
+	// Timeout is the maximum duration of a single delivery of an event to a webhook.
+	Timeout util.Duration `json:"timeout,omitempty"`
+	// MaxRetries is the number of times a failed delivery is retried before the event is dropped
+	// for the webhook.
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// RetryBackoff is the delay before the first retry, doubled with every retry.
+	RetryBackoff util.Duration `json:"retryBackoff,omitempty"`
+}
+
+type webhookConfig struct {
+	Name string `json:"name,omitempty"`
+	Url  string `json:"url,omitempty"`
+	// Secret is the key the body of the requests is signed with using HMAC-SHA256. The requests
+	// are not signed when empty.
+	Secret string `json:"secret,omitempty"`
+	// Format is the format of the body: "json" posts the event, "slack" posts a message for a
+	// Slack incoming webhook.
+	Format string `json:"format,omitempty"`
+	// Reasons are the reasons of the events the webhook subscribes to, e.g. "DeviceFailed". All
+	// reasons when empty.
+	Reasons []string `json:"reasons,omitempty"`
+	// Kinds are the kinds of the resources whose events the webhook subscribes to, e.g. "Device".
+	// All kinds when empty.
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+type changeCaptureConfig struct {
+	// Webhook is the webhook the changes are posted to, one at a time and in order. The changes
+	// are not captured when it is not set.
+	Webhook *changeWebhookConfig `json:"webhook,omitempty"`
+	// Timeout is the maximum duration of a single delivery of a change.
+	Timeout util.Duration `json:"timeout,omitempty"`
+}
+
+type changeWebhookConfig struct {
+	Url string `json:"url,omitempty"`
+	// Secret is the key the body of the requests is signed with using HMAC-SHA256. The requests
+	// are not signed when empty.
+	Secret string `json:"secret,omitempty"`
+}
+
+type admissionConfig struct {
+	// Webhooks are called in order before a resource is persisted, and any of them can reject it.
+	Webhooks []*admissionWebhookConfig `json:"webhooks,omitempty"`
+}
+
+type admissionWebhookConfig struct {
+	Name string `json:"name,omitempty"`
+	Url  string `json:"url,omitempty"`
+	// Kinds are the kinds of the resources validated by the webhook, e.g. "Device". All kinds
+	// when empty.
+	Kinds []string `json:"kinds,omitempty"`
+	// Timeout is how long the webhook has to respond.
+	Timeout util.Duration `json:"timeout,omitempty"`
+	// FailurePolicy is what happens when the webhook cannot be reached or fails: "fail" (the
+	// default) rejects the resource, "ignore" admits it.
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+}
+
+type quotasConfig struct {
+	// Default is the quota of the organizations without one of their own.
+	Default Quota `json:"default,omitempty"`
+	// Organizations are the quotas of specific organizations, by organization ID.
+	Organizations map[string]Quota `json:"organizations,omitempty"`
+}
+
+// Quota caps the number of resources of an organization. A limit of 0 means unlimited.
+type Quota struct {
+	MaxDevices int64 `json:"maxDevices,omitempty"`
+	MaxFleets  int64 `json:"maxFleets,omitempty"`
+}
+
 func ConfigDir() string {
 	return filepath.Join(util.MustString(os.UserHomeDir), "."+appName)
 }
@@ -104,12 +303,13 @@ func CertificateDir() string {
 func NewDefault() *Config {
 	c := &Config{
 		Database: &dbConfig{
-			Type:     "pgsql",
-			Hostname: "localhost",
-			Port:     5432,
-			Name:     "flightctl",
-			User:     "admin",
-			Password: "adminpass",
+			Type:               "pgsql",
+			Hostname:           "localhost",
+			Port:               5432,
+			Name:               "flightctl",
+			User:               "admin",
+			Password:           "adminpass",
+			SlowQueryThreshold: util.Duration(time.Second),
 		},
 		Service: &svcConfig{
 			Address:               ":3443",
@@ -126,17 +326,43 @@ func NewDefault() *Config {
 			HttpMaxHeaderBytes:    32 * 1024, // 32KB
 			HttpMaxUrlLength:      2000,
 			HttpMaxRequestSize:    50 * 1024 * 1024, // 50MB
+			CrlRefreshInterval:    util.Duration(10 * time.Minute),
+			RevisionHistoryLimit:  10,
+			OrphanCleanupDelay:    util.Duration(24 * time.Hour),
+			UnknownFieldsMode:     UnknownFieldsModeLenient,
+			MaintenanceRetryAfter: util.Duration(time.Minute),
+			RateLimitWindow:       util.Duration(time.Minute),
+			RateLimitScope:        RateLimitScopeIdentity,
+			ResponseCompression:   []string{"zstd", "gzip"},
+			// smaller responses fit in a single packet either way
+			ResponseCompressionMinSize: 1024,
+			SwaggerUIDist:              DefaultSwaggerUIDist,
 		},
 		KV: &kvConfig{
-			Hostname: "localhost",
-			Port:     6379,
-			Password: "adminpass",
+			Hostname:                  "localhost",
+			Port:                      6379,
+			Password:                  "adminpass",
+			QueueCompression:          "none",
+			QueueCompressionThreshold: 16 * 1024,
 		},
 		Prometheus: &prometheusConfig{
 			Address:        ":15690",
 			SloMax:         4.0,
 			ApiLatencyBins: []float64{1e-7, 1e-6, 1e-5, 1e-4, 1e-3, 1e-2, 1e-1, 1e0},
 		},
+		Workers: &workersConfig{
+			TaskTimeout:    util.Duration(10 * time.Minute),
+			MaxTaskRetries: 2,
+			TaskTraceSize:  100,
+		},
+		Notifications: &notificationsConfig{
+			Timeout:      util.Duration(10 * time.Second),
+			MaxRetries:   3,
+			RetryBackoff: util.Duration(time.Second),
+		},
+		ChangeCapture: &changeCaptureConfig{
+			Timeout: util.Duration(10 * time.Second),
+		},
 	}
 	return c
 }
@@ -146,6 +372,9 @@ func NewFromFile(cfgFile string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := resolveSecrets(cfg); err != nil {
+		return nil, err
+	}
 	if err := Validate(cfg); err != nil {
 		return nil, err
 	}
@@ -187,10 +416,293 @@ func Save(cfg *Config, cfgFile string) error {
 	return nil
 }
 
+// resolveSecrets replaces the sensitive fields that reference a file or an environment
+// variable with the referenced value.
+func resolveSecrets(cfg *Config) error {
+	secrets := map[string]*string{}
+	if cfg.Database != nil {
+		secrets["database.password"] = &cfg.Database.Password
+		secrets["database.migrationPassword"] = &cfg.Database.MigrationPassword
+	}
+	if cfg.KV != nil {
+		secrets["kv.password"] = &cfg.KV.Password
+	}
+	if cfg.Notifications != nil {
+		for i, webhook := range cfg.Notifications.Webhooks {
+			if webhook != nil {
+				secrets[fmt.Sprintf("notifications.webhooks[%d].secret", i)] = &webhook.Secret
+			}
+		}
+	}
+	if cfg.ChangeCapture != nil && cfg.ChangeCapture.Webhook != nil {
+		secrets["changeCapture.webhook.secret"] = &cfg.ChangeCapture.Webhook.Secret
+	}
+	for field, value := range secrets {
+		resolved, err := resolveSecretRef(*value)
+		if err != nil {
+			return fmt.Errorf("resolving %s: %v", field, err)
+		}
+		*value = resolved
+	}
+	return nil
+}
+
+// resolveSecretRef returns the value referenced by ref, or ref itself if it is not a reference.
+func resolveSecretRef(ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, secretRefFilePrefix):
+		path := strings.TrimPrefix(ref, secretRefFilePrefix)
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading secret file: %v", err)
+		}
+		return strings.TrimRight(string(contents), "\r\n"), nil
+	case strings.HasPrefix(ref, secretRefEnvPrefix):
+		name := strings.TrimPrefix(ref, secretRefEnvPrefix)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return value, nil
+	default:
+		return ref, nil
+	}
+}
+
+// validateSwaggerUI checks that the Swagger UI assets loaded from another origin are checked
+// against their integrity hashes.
+func validateSwaggerUI(svc *svcConfig) error {
+	if !svc.SwaggerUI {
+		return nil
+	}
+	dist, err := url.Parse(svc.SwaggerUIDist)
+	if err != nil || svc.SwaggerUIDist == "" {
+		return fmt.Errorf("invalid service.swaggerUIDist %q: must be a URL", svc.SwaggerUIDist)
+	}
+	integrity := svc.SwaggerUIIntegrity
+	if integrity == nil {
+		integrity = &swaggerUIIntegrity{}
+	}
+	hashes := [][2]string{
+		{"service.swaggerUIIntegrity.css", integrity.CSS},
+		{"service.swaggerUIIntegrity.bundle", integrity.Bundle},
+	}
+	for _, h := range hashes {
+		field, hash := h[0], h[1]
+		if hash == "" {
+			if dist.Host != "" {
+				return fmt.Errorf("%s is required to load the Swagger UI from %s", field, dist.Host)
+			}
+			continue
+		}
+		if !strings.HasPrefix(hash, "sha256-") && !strings.HasPrefix(hash, "sha384-") && !strings.HasPrefix(hash, "sha512-") {
+			return fmt.Errorf("invalid %s %q: must be a sha256, sha384 or sha512 integrity hash", field, hash)
+		}
+	}
+	return nil
+}
+
+func validateRateLimit(svc *svcConfig) error {
+	if svc.RateLimitRequests < 0 {
+		return fmt.Errorf("invalid service.rateLimitRequests %d: must not be negative", svc.RateLimitRequests)
+	}
+	if svc.RateLimitIPRequests < 0 {
+		return fmt.Errorf("invalid service.rateLimitIPRequests %d: must not be negative", svc.RateLimitIPRequests)
+	}
+	if svc.RateLimitRequests == 0 && svc.RateLimitIPRequests == 0 {
+		return nil
+	}
+	if svc.RateLimitWindow <= 0 {
+		return fmt.Errorf("invalid service.rateLimitWindow %s: must be positive", time.Duration(svc.RateLimitWindow))
+	}
+	switch svc.RateLimitScope {
+	case RateLimitScopeIdentity:
+	case RateLimitScopeIP:
+		if svc.RateLimitIPRequests > 0 {
+			return fmt.Errorf("service.rateLimitIPRequests requires service.rateLimitScope %q", RateLimitScopeIdentity)
+		}
+	default:
+		return fmt.Errorf("invalid service.rateLimitScope %q: must be %q or %q",
+			svc.RateLimitScope, RateLimitScopeIdentity, RateLimitScopeIP)
+	}
+	return nil
+}
+
 func Validate(cfg *Config) error {
+	if cfg.Service != nil {
+		networks := map[string][]string{
+			"service.agentAllowedNetworks": cfg.Service.AgentAllowedNetworks,
+			"service.agentDeniedNetworks":  cfg.Service.AgentDeniedNetworks,
+			"service.trustedProxies":       cfg.Service.TrustedProxies,
+		}
+		for field, cidrs := range networks {
+			for _, cidr := range cidrs {
+				if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+					return fmt.Errorf("invalid %s: %v", field, err)
+				}
+			}
+		}
+		switch cfg.Service.UnknownFieldsMode {
+		case "", UnknownFieldsModeStrict, UnknownFieldsModeLenient:
+		default:
+			return fmt.Errorf("invalid service.unknownFieldsMode %q: must be %q or %q",
+				cfg.Service.UnknownFieldsMode, UnknownFieldsModeStrict, UnknownFieldsModeLenient)
+		}
+		if cfg.Service.OrphanCleanupDelay <= 0 {
+			return fmt.Errorf("invalid service.orphanCleanupDelay %s: must be positive", time.Duration(cfg.Service.OrphanCleanupDelay))
+		}
+		if cfg.Service.MaintenanceRetryAfter < 0 {
+			return fmt.Errorf("invalid service.maintenanceRetryAfter %s: must not be negative", time.Duration(cfg.Service.MaintenanceRetryAfter))
+		}
+		if err := validateRateLimit(cfg.Service); err != nil {
+			return err
+		}
+		if err := validateSwaggerUI(cfg.Service); err != nil {
+			return err
+		}
+		for _, algorithm := range cfg.Service.ResponseCompression {
+			switch algorithm {
+			case "zstd", "gzip":
+			default:
+				return fmt.Errorf("invalid service.responseCompression %q: must be \"zstd\" or \"gzip\"", algorithm)
+			}
+		}
+		if cfg.Service.ResponseCompressionMinSize < 0 {
+			return fmt.Errorf("invalid service.responseCompressionMinSize %d: must not be negative", cfg.Service.ResponseCompressionMinSize)
+		}
+	}
+	if cfg.Database != nil && cfg.Database.SlowQueryThreshold < 0 {
+		return fmt.Errorf("invalid database.slowQueryThreshold %s: must not be negative", time.Duration(cfg.Database.SlowQueryThreshold))
+	}
+	if cfg.KV != nil {
+		switch cfg.KV.QueueCompression {
+		case "", "none", "gzip":
+		default:
+			return fmt.Errorf("invalid kv.queueCompression %q: must be \"none\" or \"gzip\"", cfg.KV.QueueCompression)
+		}
+		if cfg.KV.QueueCompressionThreshold < 0 {
+			return fmt.Errorf("invalid kv.queueCompressionThreshold %d: must not be negative", cfg.KV.QueueCompressionThreshold)
+		}
+	}
+	if cfg.Workers != nil && cfg.Workers.TaskTraceSize < 0 {
+		return fmt.Errorf("invalid workers.taskTraceSize %d: must not be negative", cfg.Workers.TaskTraceSize)
+	}
+	if cfg.Notifications != nil {
+		if err := validateNotifications(cfg.Notifications); err != nil {
+			return err
+		}
+	}
+	if cfg.ChangeCapture != nil {
+		if err := validateChangeCapture(cfg.ChangeCapture); err != nil {
+			return err
+		}
+	}
+	if cfg.Admission != nil {
+		if err := validateAdmission(cfg.Admission); err != nil {
+			return err
+		}
+	}
+	if cfg.Quotas != nil {
+		if err := validateQuotas(cfg.Quotas); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateQuotas(q *quotasConfig) error {
+	if err := validateQuota("quotas.default", q.Default); err != nil {
+		return err
+	}
+	for orgId, quota := range q.Organizations {
+		if _, err := uuid.Parse(orgId); err != nil {
+			return fmt.Errorf("invalid quotas.organizations key %q: must be an organization ID", orgId)
+		}
+		if err := validateQuota("quotas.organizations."+orgId, quota); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateQuota(field string, quota Quota) error {
+	if quota.MaxDevices < 0 {
+		return fmt.Errorf("invalid %s.maxDevices %d: must not be negative", field, quota.MaxDevices)
+	}
+	if quota.MaxFleets < 0 {
+		return fmt.Errorf("invalid %s.maxFleets %d: must not be negative", field, quota.MaxFleets)
+	}
+	return nil
+}
+
+func validateAdmission(a *admissionConfig) error {
+	names := map[string]bool{}
+	for i, webhook := range a.Webhooks {
+		if webhook == nil || webhook.Name == "" {
+			return fmt.Errorf("invalid admission.webhooks[%d]: name must not be empty", i)
+		}
+		if names[webhook.Name] {
+			return fmt.Errorf("invalid admission.webhooks[%d]: duplicate name %q", i, webhook.Name)
+		}
+		names[webhook.Name] = true
+		if webhook.Timeout < 0 {
+			return fmt.Errorf("invalid admission.webhooks[%d].timeout %s: must not be negative", i, time.Duration(webhook.Timeout))
+		}
+		switch webhook.FailurePolicy {
+		case "", "fail", "ignore":
+		default:
+			return fmt.Errorf("invalid admission.webhooks[%d].failurePolicy %q: must be \"fail\" or \"ignore\"", i, webhook.FailurePolicy)
+		}
+	}
 	return nil
 }
 
+func validateNotifications(n *notificationsConfig) error {
+	if n.Timeout < 0 {
+		return fmt.Errorf("invalid notifications.timeout %s: must not be negative", time.Duration(n.Timeout))
+	}
+	if n.MaxRetries < 0 {
+		return fmt.Errorf("invalid notifications.maxRetries %d: must not be negative", n.MaxRetries)
+	}
+	if n.RetryBackoff < 0 {
+		return fmt.Errorf("invalid notifications.retryBackoff %s: must not be negative", time.Duration(n.RetryBackoff))
+	}
+	names := map[string]bool{}
+	for i, webhook := range n.Webhooks {
+		if webhook == nil || webhook.Name == "" {
+			return fmt.Errorf("invalid notifications.webhooks[%d]: name must not be empty", i)
+		}
+		if names[webhook.Name] {
+			return fmt.Errorf("invalid notifications.webhooks[%d]: duplicate name %q", i, webhook.Name)
+		}
+		names[webhook.Name] = true
+	}
+	return nil
+}
+
+func validateChangeCapture(c *changeCaptureConfig) error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("invalid changeCapture.timeout %s: must not be negative", time.Duration(c.Timeout))
+	}
+	if c.Webhook != nil {
+		u, err := url.Parse(c.Webhook.Url)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid changeCapture.webhook.url %q: must be an http or https URL", c.Webhook.Url)
+		}
+	}
+	return nil
+}
+
+// ChangeCaptureEnabled returns whether the changes of the resources are captured and published.
+func (cfg *Config) ChangeCaptureEnabled() bool {
+	return cfg.ChangeCapture != nil && cfg.ChangeCapture.Webhook != nil
+}
+
+// NotificationsEnabled returns whether any webhook is configured to be notified of events.
+func (cfg *Config) NotificationsEnabled() bool {
+	return cfg.Notifications != nil && len(cfg.Notifications.Webhooks) > 0
+}
+
 func (cfg *Config) String() string {
 	contents, err := json.Marshal(cfg)
 	if err != nil {
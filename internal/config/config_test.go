@@ -0,0 +1,179 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	cfgFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte(contents), 0600))
+	return cfgFile
+}
+
+func TestSecretReferences(t *testing.T) {
+	require := require.New(t)
+
+	secretFile := filepath.Join(t.TempDir(), "kv-password")
+	require.NoError(os.WriteFile(secretFile, []byte("kvsecret\n"), 0600))
+	t.Setenv("TEST_DB_PASSWORD", "dbsecret")
+
+	cfg, err := NewFromFile(writeConfig(t, "database:\n  password: env:TEST_DB_PASSWORD\nkv:\n  password: file:"+secretFile+"\n"))
+	require.NoError(err)
+	require.Equal("dbsecret", cfg.Database.Password)
+	require.Equal("kvsecret", cfg.KV.Password)
+}
+
+func TestSecretReferencesPlaintext(t *testing.T) {
+	cfg, err := NewFromFile(writeConfig(t, "kv:\n  password: plain\n"))
+	require.NoError(t, err)
+	require.Equal(t, "plain", cfg.KV.Password)
+	require.Equal(t, "adminpass", cfg.Database.Password)
+}
+
+func TestSecretReferencesMissingSource(t *testing.T) {
+	_, err := NewFromFile(writeConfig(t, "kv:\n  password: file:"+filepath.Join(t.TempDir(), "missing")+"\n"))
+	require.ErrorContains(t, err, "kv.password")
+
+	_, err = NewFromFile(writeConfig(t, "database:\n  password: env:TEST_UNSET_DB_PASSWORD\n"))
+	require.ErrorContains(t, err, "environment variable TEST_UNSET_DB_PASSWORD is not set")
+}
+
+func TestAgentNetworksValidation(t *testing.T) {
+	cfg, err := NewFromFile(writeConfig(t, "service:\n  agentAllowedNetworks: [10.0.0.0/8]\n  trustedProxies: [192.168.1.1/32]\n"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.0/8"}, cfg.Service.AgentAllowedNetworks)
+
+	_, err = NewFromFile(writeConfig(t, "service:\n  agentDeniedNetworks: [10.0.0.1]\n"))
+	require.ErrorContains(t, err, "service.agentDeniedNetworks")
+}
+
+func TestUnknownFieldsModeValidation(t *testing.T) {
+	cfg, err := NewFromFile(writeConfig(t, "service:\n  unknownFieldsMode: strict\n"))
+	require.NoError(t, err)
+	require.Equal(t, UnknownFieldsModeStrict, cfg.Service.UnknownFieldsMode)
+
+	_, err = NewFromFile(writeConfig(t, "service:\n  unknownFieldsMode: ignore\n"))
+	require.ErrorContains(t, err, "service.unknownFieldsMode")
+}
+
+func TestMaintenanceModeValidation(t *testing.T) {
+	cfg, err := NewFromFile(writeConfig(t, "service:\n  maintenanceMode: true\n"))
+	require.NoError(t, err)
+	require.True(t, cfg.Service.MaintenanceMode)
+	require.Equal(t, time.Minute, time.Duration(cfg.Service.MaintenanceRetryAfter))
+
+	_, err = NewFromFile(writeConfig(t, "service:\n  maintenanceRetryAfter: -1s\n"))
+	require.ErrorContains(t, err, "service.maintenanceRetryAfter")
+}
+
+func TestQueueCompressionValidation(t *testing.T) {
+	cfg, err := NewFromFile(writeConfig(t, "kv:\n  queueCompression: gzip\n"))
+	require.NoError(t, err)
+	require.Equal(t, "gzip", cfg.KV.QueueCompression)
+	require.Equal(t, 16*1024, cfg.KV.QueueCompressionThreshold)
+
+	_, err = NewFromFile(writeConfig(t, "kv:\n  queueCompression: zstd\n"))
+	require.ErrorContains(t, err, "kv.queueCompression")
+
+	_, err = NewFromFile(writeConfig(t, "kv:\n  queueCompressionThreshold: -1\n"))
+	require.ErrorContains(t, err, "kv.queueCompressionThreshold")
+}
+
+func TestRateLimitValidation(t *testing.T) {
+	cfg, err := NewFromFile(writeConfig(t, "service:\n  rateLimitRequests: 100\n  rateLimitIPRequests: 1000\n"))
+	require.NoError(t, err)
+	require.Equal(t, RateLimitScopeIdentity, cfg.Service.RateLimitScope)
+	require.Equal(t, time.Minute, time.Duration(cfg.Service.RateLimitWindow))
+
+	_, err = NewFromFile(writeConfig(t, "service:\n  rateLimitRequests: -1\n"))
+	require.ErrorContains(t, err, "service.rateLimitRequests")
+	_, err = NewFromFile(writeConfig(t, "service:\n  rateLimitRequests: 100\n  rateLimitWindow: 0s\n"))
+	require.ErrorContains(t, err, "service.rateLimitWindow")
+	_, err = NewFromFile(writeConfig(t, "service:\n  rateLimitRequests: 100\n  rateLimitScope: org\n"))
+	require.ErrorContains(t, err, "service.rateLimitScope")
+	_, err = NewFromFile(writeConfig(t, "service:\n  rateLimitRequests: 100\n  rateLimitScope: ip\n  rateLimitIPRequests: 1000\n"))
+	require.ErrorContains(t, err, "service.rateLimitIPRequests")
+}
+
+func TestNotificationsConfig(t *testing.T) {
+	require := require.New(t)
+	t.Setenv("TEST_WEBHOOK_SECRET", "webhooksecret")
+
+	cfg, err := NewFromFile(writeConfig(t, "notifications:\n  webhooks:\n  - name: ops\n    url: https://hooks.example.com/flightctl\n    secret: env:TEST_WEBHOOK_SECRET\n    reasons: [DeviceFailed]\n"))
+	require.NoError(err)
+	require.True(cfg.NotificationsEnabled())
+	require.Equal("webhooksecret", cfg.Notifications.Webhooks[0].Secret)
+	require.Equal(3, cfg.Notifications.MaxRetries)
+
+	cfg, err = NewFromFile(writeConfig(t, "kv:\n  password: plain\n"))
+	require.NoError(err)
+	require.False(cfg.NotificationsEnabled())
+
+	_, err = NewFromFile(writeConfig(t, "notifications:\n  webhooks:\n  - name: ops\n    url: https://a.example.com\n  - name: ops\n    url: https://b.example.com\n"))
+	require.ErrorContains(err, "duplicate name")
+
+	_, err = NewFromFile(writeConfig(t, "notifications:\n  maxRetries: -1\n"))
+	require.ErrorContains(err, "notifications.maxRetries")
+}
+
+func TestChangeCaptureConfig(t *testing.T) {
+	require := require.New(t)
+	t.Setenv("TEST_CHANGES_SECRET", "changessecret")
+
+	cfg, err := NewFromFile(writeConfig(t, "changeCapture:\n  webhook:\n    url: https://bridge.example.com/changes\n    secret: env:TEST_CHANGES_SECRET\n"))
+	require.NoError(err)
+	require.True(cfg.ChangeCaptureEnabled())
+	require.Equal("changessecret", cfg.ChangeCapture.Webhook.Secret)
+	require.Equal(10*time.Second, time.Duration(cfg.ChangeCapture.Timeout))
+
+	cfg, err = NewFromFile(writeConfig(t, "kv:\n  password: plain\n"))
+	require.NoError(err)
+	require.False(cfg.ChangeCaptureEnabled())
+
+	_, err = NewFromFile(writeConfig(t, "changeCapture:\n  webhook:\n    url: bridge.example.com\n"))
+	require.ErrorContains(err, "changeCapture.webhook.url")
+
+	_, err = NewFromFile(writeConfig(t, "changeCapture:\n  timeout: -1s\n"))
+	require.ErrorContains(err, "changeCapture.timeout")
+}
+
+func TestSwaggerUIValidation(t *testing.T) {
+	cfg, err := NewFromFile(writeConfig(t, "service:\n  swaggerUI: true\n  swaggerUIIntegrity:\n    css: sha384-css\n    bundle: sha384-bundle\n"))
+	require.NoError(t, err)
+	require.Equal(t, DefaultSwaggerUIDist, cfg.Service.SwaggerUIDist)
+
+	// the assets served from the origin of the API server need no integrity hashes
+	_, err = NewFromFile(writeConfig(t, "service:\n  swaggerUI: true\n  swaggerUIDist: /swagger-ui\n"))
+	require.NoError(t, err)
+
+	_, err = NewFromFile(writeConfig(t, "service:\n  swaggerUI: true\n"))
+	require.ErrorContains(t, err, "service.swaggerUIIntegrity.css is required")
+	_, err = NewFromFile(writeConfig(t, "service:\n  swaggerUI: true\n  swaggerUIIntegrity:\n    css: sha384-css\n"))
+	require.ErrorContains(t, err, "service.swaggerUIIntegrity.bundle is required")
+	_, err = NewFromFile(writeConfig(t, "service:\n  swaggerUI: true\n  swaggerUIIntegrity:\n    css: md5-css\n    bundle: sha384-bundle\n"))
+	require.ErrorContains(t, err, "invalid service.swaggerUIIntegrity.css")
+}
+
+func TestAdmissionValidation(t *testing.T) {
+	require := require.New(t)
+
+	cfg, err := NewFromFile(writeConfig(t, "admission:\n  webhooks:\n  - name: policy\n    url: https://policy.example.com/validate\n    kinds: [Device, Fleet]\n    timeout: 5s\n    failurePolicy: ignore\n"))
+	require.NoError(err)
+	require.Len(cfg.Admission.Webhooks, 1)
+	require.Equal([]string{"Device", "Fleet"}, cfg.Admission.Webhooks[0].Kinds)
+	require.Equal(5*time.Second, time.Duration(cfg.Admission.Webhooks[0].Timeout))
+
+	_, err = NewFromFile(writeConfig(t, "admission:\n  webhooks:\n  - url: https://policy.example.com\n"))
+	require.ErrorContains(err, "admission.webhooks[0]")
+
+	_, err = NewFromFile(writeConfig(t, "admission:\n  webhooks:\n  - name: policy\n    url: https://a.example.com\n  - name: policy\n    url: https://b.example.com\n"))
+	require.ErrorContains(err, "duplicate name")
+
+	_, err = NewFromFile(writeConfig(t, "admission:\n  webhooks:\n  - name: policy\n    url: https://a.example.com\n    failurePolicy: retry\n"))
+	require.ErrorContains(err, "admission.webhooks[0].failurePolicy")
+}
@@ -53,6 +53,8 @@ type BootcClient interface {
 	UsrOverlay(ctx context.Context) error
 	// Apply restart or reboot into the new target image.
 	Apply(ctx context.Context) error
+	// Rollback reboots into the previously booted image.
+	Rollback(ctx context.Context) error
 }
 
 var (
@@ -0,0 +1,25 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// NewBootstrapToken returns a random one-time token and the hash the token is stored under, so
+// that the stored hashes cannot be redeemed.
+func NewBootstrapToken() (token string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashBootstrapToken(token), nil
+}
+
+// HashBootstrapToken returns the hash a bootstrap token is stored under.
+func HashBootstrapToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
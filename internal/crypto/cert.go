@@ -108,7 +108,7 @@ func makeSelfSignedCAConfig(subject pkix.Name, caLifetime time.Duration) (*oscry
 
 		SerialNumber: randomSerial(),
 
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
 		BasicConstraintsValid: true,
 		IsCA:                  true,
 
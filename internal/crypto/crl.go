@@ -0,0 +1,194 @@
+package crypto
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	crlFetchTimeout = 30 * time.Second
+
+	// crlRetryBackoff is the delay before reloading a list that failed to load, doubled with
+	// every consecutive failure up to crlMaxRetryBackoff.
+	crlRetryBackoff    = 10 * time.Second
+	crlMaxRetryBackoff = 10 * time.Minute
+)
+
+// CRLChecker rejects client certificates that are listed in a certificate revocation list
+// signed by the CA. The list is read from a file or an http(s) URL and reloaded in the background
+// by Run, once the refresh interval has passed or by the next update the list announces, whichever
+// comes first; if reloading fails, the previously loaded list is kept and reloading is retried
+// with a backoff. Checking a certificate never waits for the list to be reloaded.
+type CRLChecker struct {
+	log             logrus.FieldLogger
+	source          string
+	issuers         []*x509.Certificate
+	refreshInterval time.Duration
+
+	mu         sync.Mutex
+	revoked    map[string]struct{}
+	loadedAt   time.Time
+	nextUpdate time.Time
+	failures   int
+	now        func() time.Time
+}
+
+func NewCRLChecker(log logrus.FieldLogger, source string, caConfig *TLSCertificateConfig, refreshInterval time.Duration) (*CRLChecker, error) {
+	c := &CRLChecker{
+		log:             log,
+		source:          source,
+		issuers:         caConfig.Certs,
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// VerifyPeerCertificate is meant to be set as the tls.Config.VerifyPeerCertificate callback of
+// a server that requires and verifies client certificates.
+func (c *CRLChecker) VerifyPeerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, chain := range verifiedChains {
+		if len(chain) == 0 {
+			continue
+		}
+		cert := chain[0]
+		if _, ok := c.revoked[cert.SerialNumber.String()]; ok {
+			c.log.Warnf("rejecting revoked client certificate %q with serial %s", cert.Subject.CommonName, cert.SerialNumber.Text(16))
+			return fmt.Errorf("client certificate with serial %s is revoked", cert.SerialNumber.Text(16))
+		}
+	}
+	return nil
+}
+
+// Run reloads the list until ctx is canceled.
+func (c *CRLChecker) Run(ctx context.Context) {
+	for {
+		delay, ok := c.refreshDelay()
+		if !ok {
+			<-ctx.Done()
+			return
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		c.refresh()
+	}
+}
+
+// refreshDelay returns the time until the list is to be reloaded, or false if it is never
+// reloaded, i.e. without a refresh interval nor next update.
+func (c *CRLChecker) refreshDelay() (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.failures > 0 {
+		backoff := crlRetryBackoff << min(c.failures-1, 16)
+		return min(backoff, crlMaxRetryBackoff), true
+	}
+
+	now := c.now()
+	var delays []time.Duration
+	if c.refreshInterval > 0 {
+		delays = append(delays, max(c.loadedAt.Add(c.refreshInterval).Sub(now), 0))
+	}
+	if !c.nextUpdate.IsZero() {
+		// a list published past its next update is not reloaded more often than a failing one
+		delays = append(delays, max(c.nextUpdate.Sub(now), crlRetryBackoff))
+	}
+	if len(delays) == 0 {
+		return 0, false
+	}
+	return slices.Min(delays), true
+}
+
+func (c *CRLChecker) refresh() {
+	if err := c.load(); err != nil {
+		c.mu.Lock()
+		c.failures++
+		expired := !c.nextUpdate.IsZero() && c.now().After(c.nextUpdate)
+		c.mu.Unlock()
+		if expired {
+			c.log.Errorf("reloading certificate revocation list past its next update, keeping the previous one: %v", err)
+		} else {
+			c.log.Warnf("reloading certificate revocation list, keeping the previous one: %v", err)
+		}
+	}
+}
+
+func (c *CRLChecker) load() error {
+	contents, err := c.read()
+	if err != nil {
+		return fmt.Errorf("reading certificate revocation list from %s: %w", c.source, err)
+	}
+	if block, _ := pem.Decode(contents); block != nil {
+		contents = block.Bytes
+	}
+	crl, err := x509.ParseRevocationList(contents)
+	if err != nil {
+		return fmt.Errorf("parsing certificate revocation list: %w", err)
+	}
+	if err := c.checkIssuer(crl); err != nil {
+		return err
+	}
+
+	revoked := make(map[string]struct{}, len(crl.RevokedCertificateEntries))
+	for _, entry := range crl.RevokedCertificateEntries {
+		revoked[entry.SerialNumber.String()] = struct{}{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.revoked = revoked
+	c.loadedAt = c.now()
+	c.nextUpdate = crl.NextUpdate
+	c.failures = 0
+	return nil
+}
+
+func (c *CRLChecker) read() ([]byte, error) {
+	if !strings.HasPrefix(c.source, "http://") && !strings.HasPrefix(c.source, "https://") {
+		return os.ReadFile(c.source)
+	}
+	client := &http.Client{Timeout: crlFetchTimeout}
+	resp, err := client.Get(c.source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (c *CRLChecker) checkIssuer(crl *x509.RevocationList) error {
+	// CAs created before the CRL sign key usage was added are still accepted as issuers, so the
+	// signature is checked without crl.CheckSignatureFrom's key usage constraint.
+	for _, issuer := range c.issuers {
+		if issuer.CheckSignature(crl.SignatureAlgorithm, crl.RawTBSRevocationList, crl.Signature) == nil {
+			return nil
+		}
+	}
+	return errors.New("certificate revocation list is not signed by the CA")
+}
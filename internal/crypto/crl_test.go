@@ -0,0 +1,232 @@
+package crypto
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCRL(t *testing.T, ca *CA, path string, number int64, revoked ...*x509.Certificate) {
+	entries := []x509.RevocationListEntry{}
+	for _, cert := range revoked {
+		entries = append(entries, x509.RevocationListEntry{SerialNumber: cert.SerialNumber, RevocationTime: time.Now()})
+	}
+	template := &x509.RevocationList{
+		Number:                    big.NewInt(number),
+		ThisUpdate:                time.Now(),
+		NextUpdate:                time.Now().Add(time.Hour),
+		RevokedCertificateEntries: entries,
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca.Config.Certs[0], ca.Config.Key.(crypto.Signer))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}), 0600))
+}
+
+func makeTestCA(t *testing.T, dir string) *CA {
+	ca, err := MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(t, err)
+	return ca
+}
+
+func makeTestClientCert(t *testing.T, ca *CA, dir string, name string) *x509.Certificate {
+	client, err := ca.MakeClientCertificate(filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key"), name, 1)
+	require.NoError(t, err)
+	return client.Certs[0]
+}
+
+func TestCRLCheckerRejectsRevokedCertificate(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	ca := makeTestCA(t, dir)
+	revoked := makeTestClientCert(t, ca, dir, "revoked")
+	valid := makeTestClientCert(t, ca, dir, "valid")
+
+	crlFile := filepath.Join(dir, "ca.crl")
+	writeCRL(t, ca, crlFile, 1, revoked)
+
+	checker, err := NewCRLChecker(logrus.New(), crlFile, ca.Config, time.Minute)
+	require.NoError(err)
+
+	require.ErrorContains(checker.VerifyPeerCertificate(nil, [][]*x509.Certificate{{revoked, ca.Config.Certs[0]}}), "is revoked")
+	require.NoError(checker.VerifyPeerCertificate(nil, [][]*x509.Certificate{{valid, ca.Config.Certs[0]}}))
+}
+
+func TestCRLCheckerRefresh(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	ca := makeTestCA(t, dir)
+	cert := makeTestClientCert(t, ca, dir, "device")
+	chains := [][]*x509.Certificate{{cert, ca.Config.Certs[0]}}
+
+	crlFile := filepath.Join(dir, "ca.crl")
+	writeCRL(t, ca, crlFile, 1)
+
+	checker, err := NewCRLChecker(logrus.New(), crlFile, ca.Config, time.Minute)
+	require.NoError(err)
+	now := time.Now()
+	checker.now = func() time.Time { return now }
+	require.NoError(checker.VerifyPeerCertificate(nil, chains))
+
+	// checking a certificate does not reload the list
+	writeCRL(t, ca, crlFile, 2, cert)
+	now = now.Add(time.Minute)
+	require.NoError(checker.VerifyPeerCertificate(nil, chains))
+
+	checker.refresh()
+	require.Error(checker.VerifyPeerCertificate(nil, chains))
+
+	// a list that can no longer be read does not drop the previously loaded one
+	require.NoError(os.Remove(crlFile))
+	checker.refresh()
+	require.Error(checker.VerifyPeerCertificate(nil, chains))
+}
+
+func TestCRLCheckerRefreshDelay(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	ca := makeTestCA(t, dir)
+
+	// the list is reloaded after the refresh interval, or by its next update if that comes first
+	crlFile := filepath.Join(dir, "ca.crl")
+	writeCRL(t, ca, crlFile, 1)
+	checker, err := NewCRLChecker(logrus.New(), crlFile, ca.Config, 2*time.Hour)
+	require.NoError(err)
+	now := checker.loadedAt
+	checker.now = func() time.Time { return now }
+	delay, ok := checker.refreshDelay()
+	require.True(ok)
+	require.InDelta(time.Hour, delay, float64(time.Second))
+
+	checker.refreshInterval = time.Minute
+	delay, _ = checker.refreshDelay()
+	require.Equal(time.Minute, delay)
+
+	// a list past its next update is not reloaded in a loop
+	now = now.Add(2 * time.Hour)
+	checker.refreshInterval = 0
+	delay, _ = checker.refreshDelay()
+	require.Equal(crlRetryBackoff, delay)
+
+	// failures are retried with a growing backoff, and reset by a successful reload
+	require.NoError(os.Remove(crlFile))
+	checker.refresh()
+	delay, _ = checker.refreshDelay()
+	require.Equal(crlRetryBackoff, delay)
+	checker.refresh()
+	delay, _ = checker.refreshDelay()
+	require.Equal(2*crlRetryBackoff, delay)
+	for i := 0; i < 20; i++ {
+		checker.refresh()
+	}
+	delay, _ = checker.refreshDelay()
+	require.Equal(crlMaxRetryBackoff, delay)
+
+	writeCRL(t, ca, crlFile, 2)
+	checker.refresh()
+	require.Zero(checker.failures)
+}
+
+func TestCRLCheckerRun(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	ca := makeTestCA(t, dir)
+	cert := makeTestClientCert(t, ca, dir, "device")
+	chains := [][]*x509.Certificate{{cert, ca.Config.Certs[0]}}
+
+	crlFile := filepath.Join(dir, "ca.crl")
+	writeCRL(t, ca, crlFile, 1)
+	checker, err := NewCRLChecker(logrus.New(), crlFile, ca.Config, 10*time.Millisecond)
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go checker.Run(ctx)
+
+	// replace the list at once, for the checker not to read it half-written
+	writeCRL(t, ca, crlFile+".new", 2, cert)
+	require.NoError(os.Rename(crlFile+".new", crlFile))
+	require.Eventually(func() bool {
+		return checker.VerifyPeerCertificate(nil, chains) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCRLCheckerFromURL(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	ca := makeTestCA(t, dir)
+	cert := makeTestClientCert(t, ca, dir, "device")
+
+	crlFile := filepath.Join(dir, "ca.crl")
+	writeCRL(t, ca, crlFile, 1, cert)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, crlFile)
+	}))
+	defer server.Close()
+
+	checker, err := NewCRLChecker(logrus.New(), server.URL, ca.Config, time.Minute)
+	require.NoError(err)
+	require.Error(checker.VerifyPeerCertificate(nil, [][]*x509.Certificate{{cert, ca.Config.Certs[0]}}))
+}
+
+func TestCRLCheckerRejectsUntrustedCRL(t *testing.T) {
+	dir := t.TempDir()
+	ca := makeTestCA(t, dir)
+	other := makeTestCA(t, filepath.Join(dir, "other"))
+
+	crlFile := filepath.Join(dir, "ca.crl")
+	writeCRL(t, other, crlFile, 1)
+
+	_, err := NewCRLChecker(logrus.New(), crlFile, ca.Config, time.Minute)
+	require.ErrorContains(t, err, "not signed by the CA")
+}
+
+func TestCRLCheckerTLSHandshake(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	ca := makeTestCA(t, dir)
+	serverCerts, err := ca.MakeServerCert([]string{"127.0.0.1"}, 1)
+	require.NoError(err)
+	revoked, err := ca.MakeClientCertificate(filepath.Join(dir, "revoked.crt"), filepath.Join(dir, "revoked.key"), "revoked", 1)
+	require.NoError(err)
+	valid, err := ca.MakeClientCertificate(filepath.Join(dir, "valid.crt"), filepath.Join(dir, "valid.key"), "valid", 1)
+	require.NoError(err)
+
+	crlFile := filepath.Join(dir, "ca.crl")
+	writeCRL(t, ca, crlFile, 1, revoked.Certs[0])
+	checker, err := NewCRLChecker(logrus.New(), crlFile, ca.Config, time.Minute)
+	require.NoError(err)
+
+	_, agentTlsConfig, err := TLSConfigForServer(ca.Config, serverCerts)
+	require.NoError(err)
+	agentTlsConfig.VerifyPeerCertificate = checker.VerifyPeerCertificate
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = agentTlsConfig
+	server.StartTLS()
+	defer server.Close()
+
+	get := func(clientCerts *TLSCertificateConfig) error {
+		clientTlsConfig, err := TLSConfigForClient(ca.Config, clientCerts)
+		require.NoError(err)
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTlsConfig}}
+		resp, err := client.Get(server.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+	require.NoError(get(valid))
+	require.Error(get(revoked))
+}
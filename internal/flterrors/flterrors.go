@@ -22,6 +22,7 @@ var (
 	ErrLabelSelectorParseFailed            = errors.New("failed to parse label selector")
 	ErrAnnotationSelectorSyntax            = errors.New("invalid annotation selector syntax")
 	ErrAnnotationSelectorParseFailed       = errors.New("failed to parse annotation selector")
+	ErrQuotaExceeded                       = errors.New("quota exceeded")
 
 	// devices
 	ErrTemplateVersionIsNil   = errors.New("spec.templateVersion not set")
@@ -37,4 +38,9 @@ var (
 	ErrSignature       = errors.New("signature error")
 	ErrSignCert        = errors.New("error signing certificate")
 	ErrEncodeCert      = errors.New("error encoding certificate")
+
+	// bootstrap tokens
+	ErrBootstrapTokenInvalid  = errors.New("invalid bootstrap token")
+	ErrBootstrapTokenRedeemed = errors.New("bootstrap token was already redeemed")
+	ErrBootstrapTokenExpired  = errors.New("bootstrap token expired")
 )
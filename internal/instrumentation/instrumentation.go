@@ -24,10 +24,11 @@ const (
 )
 
 type MetricsServer struct {
-	log      logrus.FieldLogger
-	cfg      *config.Config
-	registry *prometheus.Registry
-	metrics  *ApiMetrics
+	log        logrus.FieldLogger
+	cfg        *config.Config
+	registry   *prometheus.Registry
+	metrics    *ApiMetrics
+	collectors []prometheus.Collector
 }
 
 type ApiMetrics struct {
@@ -39,6 +40,8 @@ type ApiMetrics struct {
 	ApiTraffic   prometheus.Counter
 	AgentTraffic prometheus.Counter
 
+	AgentRejectedRequests prometheus.Counter
+
 	SloViolations prometheus.Counter
 	ClientErrors  prometheus.Counter
 	ServerErrors  prometheus.Counter
@@ -62,6 +65,10 @@ func NewApiMetrics(cfg *config.Config) *ApiMetrics {
 			Name: "flightctl_api_requests_agent_total",
 			Help: "Number of requests to Flightctl Agent server",
 		}),
+		AgentRejectedRequests: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "flightctl_api_requests_agent_rejected_total",
+			Help: "Number of requests to Flightctl Agent server rejected because of their source address",
+		}),
 		SuccessLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
 			Name:    "flightctl_api_latencies_success_seconds",
 			Help:    "Distribution of latencies of Flightctl server responses that encountered no errors",
@@ -103,12 +110,14 @@ func NewMetricsServer(
 	log logrus.FieldLogger,
 	cfg *config.Config,
 	metrics *ApiMetrics,
+	collectors ...prometheus.Collector,
 ) *MetricsServer {
 	return &MetricsServer{
-		log:      log,
-		cfg:      cfg,
-		metrics:  metrics,
-		registry: prometheus.NewRegistry(),
+		log:        log,
+		cfg:        cfg,
+		metrics:    metrics,
+		registry:   prometheus.NewRegistry(),
+		collectors: collectors,
 	}
 }
 
@@ -134,6 +143,9 @@ func (lw *loggingResponseWriter) WriteHeader(statusCode int) {
 
 func (m *MetricsServer) Run(ctx context.Context) error {
 	m.metrics.RegisterWith(m.registry)
+	for _, collector := range m.collectors {
+		m.registry.MustRegister(collector)
+	}
 
 	srv := &http.Server{
 		Addr:         m.cfg.Prometheus.Address,
@@ -243,6 +255,7 @@ func (m *ApiMetrics) RegisterWith(reg *prometheus.Registry) {
 	reg.MustRegister(m.ErrorLatency)
 	reg.MustRegister(m.ApiTraffic)
 	reg.MustRegister(m.AgentTraffic)
+	reg.MustRegister(m.AgentRejectedRequests)
 	reg.MustRegister(m.SloViolations)
 	reg.MustRegister(m.ServerErrors)
 	reg.MustRegister(m.CpuUtilization)
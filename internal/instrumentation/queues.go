@@ -0,0 +1,52 @@
+package instrumentation
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/pkg/queues"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const queueCollectTimeout = 5 * time.Second
+
+// QueueCollector reports the number of messages waiting to be consumed per queue,
+// which tells whether the workers are falling behind.
+type QueueCollector struct {
+	log        logrus.FieldLogger
+	provider   queues.Provider
+	queueNames []string
+	pending    *prometheus.Desc
+}
+
+func NewQueueCollector(log logrus.FieldLogger, provider queues.Provider, queueNames ...string) *QueueCollector {
+	return &QueueCollector{
+		log:        log,
+		provider:   provider,
+		queueNames: queueNames,
+		pending: prometheus.NewDesc(
+			"flightctl_queue_pending_messages",
+			"Number of messages published to a Flightctl queue that were not consumed yet",
+			[]string{"queue"}, nil,
+		),
+	}
+}
+
+func (c *QueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pending
+}
+
+func (c *QueueCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), queueCollectTimeout)
+	defer cancel()
+
+	for _, queueName := range c.queueNames {
+		pending, err := c.provider.PendingMessages(ctx, queueName)
+		if err != nil {
+			c.log.WithError(err).Errorf("could not collect pending messages of queue %s", queueName)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.pending, prometheus.GaugeValue, float64(pending), queueName)
+	}
+}
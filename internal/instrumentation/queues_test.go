@@ -0,0 +1,31 @@
+package instrumentation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/flightctl/flightctl/pkg/queues"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQueueCollector(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := queues.NewMockProvider(ctrl)
+	provider.EXPECT().PendingMessages(gomock.Any(), "task-queue").Return(int64(3), nil)
+	provider.EXPECT().PendingMessages(gomock.Any(), "other-queue").Return(int64(0), errors.New("unavailable"))
+
+	collector := NewQueueCollector(log.InitLogs(), provider, "task-queue", "other-queue")
+	expected := `
+# HELP flightctl_queue_pending_messages Number of messages published to a Flightctl queue that were not consumed yet
+# TYPE flightctl_queue_pending_messages gauge
+flightctl_queue_pending_messages{queue="task-queue"} 3
+`
+	require.NoError(testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
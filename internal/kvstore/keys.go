@@ -76,3 +76,33 @@ func (k *HttpKey) ComposeKey() string {
 	md5sum := md5.Sum([]byte(k.URL)) //nolint: gosec
 	return fmt.Sprintf("v1/%s/%s/%s/http-data/%x", k.OrgID, k.Fleet, k.TemplateVersion, md5sum)
 }
+
+type TaskIdempotencyKey struct {
+	OrgID    uuid.UUID
+	TaskName string
+	Key      string
+}
+
+func (k *TaskIdempotencyKey) ComposeKey() string {
+	return fmt.Sprintf("v1/%s/task-idempotency/%s/%s", k.OrgID, k.TaskName, k.Key)
+}
+
+type UpdateSlotKey struct {
+	OrgID uuid.UUID
+	Group string
+	Slot  int
+}
+
+func (k *UpdateSlotKey) ComposeKey() string {
+	return fmt.Sprintf("v1/%s/update-slots/%s/%d", k.OrgID, k.Group, k.Slot)
+}
+
+// UpdateSlotHolderKey is the key of the UpdateSlotKey of the slot held by a device.
+type UpdateSlotHolderKey struct {
+	OrgID  uuid.UUID
+	Device string
+}
+
+func (k *UpdateSlotHolderKey) ComposeKey() string {
+	return fmt.Sprintf("v1/%s/update-slot-holders/%s", k.OrgID, k.Device)
+}
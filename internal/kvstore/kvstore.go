@@ -11,18 +11,26 @@ import (
 
 type KVStore interface {
 	Close()
+	// Ping checks that the KV store is reachable.
+	Ping(ctx context.Context) error
 	SetNX(ctx context.Context, key string, value []byte) (bool, error)
+	SetNXWithExpiration(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error)
 	Get(ctx context.Context, key string) ([]byte, error)
 	GetOrSetNX(ctx context.Context, key string, value []byte) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	DeleteIfEqual(ctx context.Context, key string, value []byte) (bool, error)
+	SetIfEqualWithExpiration(ctx context.Context, key string, expected []byte, value []byte, expiration time.Duration) (bool, error)
 	DeleteKeysForTemplateVersion(ctx context.Context, key string) error
 	DeleteAllKeys(ctx context.Context) error
 	PrintAllKeys(ctx context.Context) // For debugging
 }
 
 type kvStore struct {
-	log            logrus.FieldLogger
-	client         *redis.Client
-	getSetNxScript *redis.Script
+	log                 logrus.FieldLogger
+	client              *redis.Client
+	getSetNxScript      *redis.Script
+	deleteIfEqualScript *redis.Script
+	setIfEqualScript    *redis.Script
 }
 
 func NewKVStore(ctx context.Context, log logrus.FieldLogger, hostname string, port uint, password string) (KVStore, error) {
@@ -50,10 +58,29 @@ func NewKVStore(ctx context.Context, log logrus.FieldLogger, hostname string, po
 		return value
 	`)
 
+	// Lua script to delete the key only if it holds the given value
+	deleteIfEqualScript := redis.NewScript(`
+		if redis.call('get', KEYS[1]) == ARGV[1] then
+			return redis.call('del', KEYS[1])
+		end
+		return 0
+	`)
+
+	// Lua script to set the key with an expiration in milliseconds only if it holds the given value
+	setIfEqualScript := redis.NewScript(`
+		if redis.call('get', KEYS[1]) == ARGV[1] then
+			redis.call('set', KEYS[1], ARGV[2], 'PX', ARGV[3])
+			return 1
+		end
+		return 0
+	`)
+
 	return &kvStore{
-		log:            log,
-		client:         client,
-		getSetNxScript: luaScript,
+		log:                 log,
+		client:              client,
+		getSetNxScript:      luaScript,
+		deleteIfEqualScript: deleteIfEqualScript,
+		setIfEqualScript:    setIfEqualScript,
 	}, nil
 }
 
@@ -64,6 +91,10 @@ func (s *kvStore) Close() {
 	}
 }
 
+func (s *kvStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
 func (s *kvStore) DeleteAllKeys(ctx context.Context) error {
 	_, err := s.client.FlushAll(ctx).Result()
 	if err != nil {
@@ -81,6 +112,44 @@ func (s *kvStore) SetNX(ctx context.Context, key string, value []byte) (bool, er
 	return success, nil
 }
 
+// Sets the key to value only if the key does Not eXist, expiring the key after the given duration.
+// Returns a boolean indicating if the value was updated by this call.
+func (s *kvStore) SetNXWithExpiration(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	success, err := s.client.SetNX(ctx, key, value, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed storing key: %w", err)
+	}
+	return success, nil
+}
+
+// Deletes the specified key. Deleting a key that does not exist is not an error.
+func (s *kvStore) Delete(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("failed deleting key: %w", err)
+	}
+	return nil
+}
+
+// Deletes the specified key only if it holds the given value, so that a key that expired and was
+// set again by someone else is kept. Returns a boolean indicating if the key was deleted.
+func (s *kvStore) DeleteIfEqual(ctx context.Context, key string, value []byte) (bool, error) {
+	deleted, err := s.deleteIfEqualScript.Run(ctx, s.client, []string{key}, value).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed deleting key: %w", err)
+	}
+	return deleted == 1, nil
+}
+
+// Sets the key to value with the given expiration only if it holds the expected value. Returns a
+// boolean indicating if the value was updated by this call.
+func (s *kvStore) SetIfEqualWithExpiration(ctx context.Context, key string, expected []byte, value []byte, expiration time.Duration) (bool, error) {
+	updated, err := s.setIfEqualScript.Run(ctx, s.client, []string{key}, expected, value, expiration.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed storing key: %w", err)
+	}
+	return updated == 1, nil
+}
+
 // Gets the value for the specified key.
 func (s *kvStore) Get(ctx context.Context, key string) ([]byte, error) {
 	result, err := s.client.Get(ctx, key).Bytes()
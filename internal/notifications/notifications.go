@@ -0,0 +1,127 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/sirupsen/logrus"
+)
+
+// Queue is the outbox queue the events are enqueued to until they are delivered.
+const Queue = "notifications"
+
+// Reason is the reason of an event.
+type Reason string
+
+const (
+	// EnrollmentRequested is emitted when a device requests to be enrolled.
+	EnrollmentRequested Reason = "EnrollmentRequested"
+	// EnrollmentApproved is emitted when the enrollment request of a device is approved.
+	EnrollmentApproved Reason = "EnrollmentApproved"
+	// DeviceFailed is emitted when the summary status of a device becomes Error.
+	DeviceFailed Reason = "DeviceFailed"
+	// DeviceDegraded is emitted when the summary status of a device becomes Degraded.
+	DeviceDegraded Reason = "DeviceDegraded"
+	// DeviceRecovered is emitted when the summary status of a failed or degraded device becomes
+	// Online again.
+	DeviceRecovered Reason = "DeviceRecovered"
+)
+
+// Reasons are the reasons of the events that are emitted.
+var Reasons = []Reason{EnrollmentRequested, EnrollmentApproved, DeviceFailed, DeviceDegraded, DeviceRecovered}
+
+// Event is an occurrence on a resource that subscribers are notified of.
+type Event struct {
+	Reason    Reason    `json:"reason"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func newEvent(reason Reason, kind, name, message string) Event {
+	return Event{
+		Reason:    reason,
+		Kind:      kind,
+		Name:      name,
+		Message:   message,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// EnrollmentRequestEvent returns the event of the enrollment request of the given device.
+func EnrollmentRequestEvent(reason Reason, name string) Event {
+	message := fmt.Sprintf("Device %s requested to be enrolled", name)
+	if reason == EnrollmentApproved {
+		message = fmt.Sprintf("The enrollment request of device %s was approved", name)
+	}
+	return newEvent(reason, api.EnrollmentRequestKind, name, message)
+}
+
+// DeviceStatusEvent returns the event of the change of the summary status of a device from
+// previous to the current status of the device, or nil if the change is not notified.
+func DeviceStatusEvent(previous api.DeviceSummaryStatusType, device *api.Device) *Event {
+	if device == nil || device.Status == nil || device.Metadata.Name == nil {
+		return nil
+	}
+	current := device.Status.Summary.Status
+	if current == previous {
+		return nil
+	}
+
+	var reason Reason
+	switch current {
+	case api.DeviceSummaryStatusError:
+		reason = DeviceFailed
+	case api.DeviceSummaryStatusDegraded:
+		reason = DeviceDegraded
+	case api.DeviceSummaryStatusOnline:
+		if previous != api.DeviceSummaryStatusError && previous != api.DeviceSummaryStatusDegraded {
+			return nil
+		}
+		reason = DeviceRecovered
+	default:
+		return nil
+	}
+
+	message := fmt.Sprintf("Device %s is %s", *device.Metadata.Name, current)
+	if device.Status.Summary.Info != nil {
+		message = fmt.Sprintf("%s: %s", message, *device.Status.Summary.Info)
+	}
+	event := newEvent(reason, api.DeviceKind, *device.Metadata.Name, message)
+	return &event
+}
+
+// Emitter enqueues events to the outbox, from which the workers deliver them to the subscribed
+// webhooks. A nil Emitter drops the events, for when no webhook is configured.
+type Emitter struct {
+	outbox store.Outbox
+	log    logrus.FieldLogger
+}
+
+func NewEmitter(outbox store.Outbox, log logrus.FieldLogger) *Emitter {
+	return &Emitter{
+		outbox: outbox,
+		log:    log,
+	}
+}
+
+// Emit enqueues the event. Failing to do so does not fail the operation the event is about, so
+// the error is only logged.
+func (e *Emitter) Emit(ctx context.Context, event Event) {
+	if e == nil {
+		return
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		e.log.WithError(err).Errorf("failed to marshal %s event of %s/%s", event.Reason, event.Kind, event.Name)
+		return
+	}
+	if err := e.outbox.Enqueue(ctx, Queue, payload); err != nil {
+		e.log.WithError(err).Errorf("failed to enqueue %s event of %s/%s", event.Reason, event.Kind, event.Name)
+	}
+}
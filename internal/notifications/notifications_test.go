@@ -0,0 +1,244 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// webhookRecorder is a webhook that records the requests it receives, and fails the first
+// failures of them with the given status.
+type webhookRecorder struct {
+	mu       sync.Mutex
+	failures int
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (w *webhookRecorder) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	w.requests = append(w.requests, r)
+	w.bodies = append(w.bodies, body)
+	if w.failures > 0 {
+		w.failures--
+		rw.WriteHeader(w.status)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (w *webhookRecorder) events(t *testing.T) []Event {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	events := []Event{}
+	for _, body := range w.bodies {
+		var event Event
+		require.NoError(t, json.Unmarshal(body, &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func newTestSink(t *testing.T, webhooks ...Webhook) *Sink {
+	sink, err := NewSink(log.InitLogs(), webhooks, time.Second, 2, time.Millisecond)
+	require.NoError(t, err)
+	return sink
+}
+
+func payload(t *testing.T, event Event) []byte {
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestWebhookMatches(t *testing.T) {
+	failed := newEvent(DeviceFailed, api.DeviceKind, "mydevice", "")
+	requested := EnrollmentRequestEvent(EnrollmentRequested, "mydevice")
+
+	tests := []struct {
+		name    string
+		webhook Webhook
+		want    []bool
+	}{
+		{name: "all events", webhook: Webhook{}, want: []bool{true, true}},
+		{name: "by reason", webhook: Webhook{Reasons: []Reason{DeviceFailed, DeviceDegraded}}, want: []bool{true, false}},
+		{name: "by kind", webhook: Webhook{Kinds: []string{api.EnrollmentRequestKind}}, want: []bool{false, true}},
+		{name: "by reason and kind", webhook: Webhook{Reasons: []Reason{DeviceFailed}, Kinds: []string{api.EnrollmentRequestKind}}, want: []bool{false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, []bool{tt.webhook.Matches(&failed), tt.webhook.Matches(&requested)})
+		})
+	}
+}
+
+func TestDeviceStatusEvent(t *testing.T) {
+	device := func(status api.DeviceSummaryStatusType) *api.Device {
+		return &api.Device{
+			Metadata: api.ObjectMeta{Name: util.StrToPtr("mydevice")},
+			Status:   &api.DeviceStatus{Summary: api.DeviceSummaryStatus{Status: status, Info: util.StrToPtr("Disk utilization reached critical level")}},
+		}
+	}
+
+	tests := []struct {
+		previous api.DeviceSummaryStatusType
+		current  api.DeviceSummaryStatusType
+		want     Reason
+	}{
+		{previous: api.DeviceSummaryStatusOnline, current: api.DeviceSummaryStatusError, want: DeviceFailed},
+		{previous: api.DeviceSummaryStatusDegraded, current: api.DeviceSummaryStatusError, want: DeviceFailed},
+		{previous: api.DeviceSummaryStatusOnline, current: api.DeviceSummaryStatusDegraded, want: DeviceDegraded},
+		{previous: api.DeviceSummaryStatusError, current: api.DeviceSummaryStatusOnline, want: DeviceRecovered},
+		{previous: api.DeviceSummaryStatusUnknown, current: api.DeviceSummaryStatusOnline},
+		{previous: api.DeviceSummaryStatusError, current: api.DeviceSummaryStatusError},
+		{previous: api.DeviceSummaryStatusOnline, current: api.DeviceSummaryStatusRebooting},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.previous)+"->"+string(tt.current), func(t *testing.T) {
+			event := DeviceStatusEvent(tt.previous, device(tt.current))
+			if tt.want == "" {
+				require.Nil(t, event)
+				return
+			}
+			require.NotNil(t, event)
+			require.Equal(t, tt.want, event.Reason)
+			require.Equal(t, api.DeviceKind, event.Kind)
+			require.Equal(t, "mydevice", event.Name)
+			require.Contains(t, event.Message, "Disk utilization reached critical level")
+		})
+	}
+}
+
+func TestSinkSignsAndFilters(t *testing.T) {
+	require := require.New(t)
+	signed := &webhookRecorder{}
+	signedServer := httptest.NewServer(signed)
+	defer signedServer.Close()
+	slack := &webhookRecorder{}
+	slackServer := httptest.NewServer(slack)
+	defer slackServer.Close()
+
+	sink := newTestSink(t,
+		Webhook{Name: "signed", URL: signedServer.URL, Secret: "s3cr3t", Reasons: []Reason{DeviceFailed}},
+		Webhook{Name: "slack", URL: slackServer.URL, Format: FormatSlack, Kinds: []string{api.EnrollmentRequestKind}},
+	)
+	ctx := context.Background()
+	failed := newEvent(DeviceFailed, api.DeviceKind, "mydevice", "Device mydevice is Error")
+	sink.Deliver(ctx, payload(t, failed))
+	sink.Deliver(ctx, payload(t, EnrollmentRequestEvent(EnrollmentRequested, "mydevice")))
+	sink.Deliver(ctx, payload(t, newEvent(DeviceRecovered, api.DeviceKind, "mydevice", "")))
+
+	// the signed webhook only receives the failure, signed with its secret
+	require.Len(signed.requests, 1)
+	require.Equal(string(DeviceFailed), signed.requests[0].Header.Get(ReasonHeader))
+	require.Equal(Sign("s3cr3t", signed.bodies[0]), signed.requests[0].Header.Get(SignatureHeader))
+	require.NotEqual(Sign("other", signed.bodies[0]), signed.requests[0].Header.Get(SignatureHeader))
+	require.Equal(failed.Message, signed.events(t)[0].Message)
+
+	// the slack webhook only receives the enrollment request, unsigned
+	require.Len(slack.requests, 1)
+	require.Empty(slack.requests[0].Header.Get(SignatureHeader))
+	var message map[string]string
+	require.NoError(json.Unmarshal(slack.bodies[0], &message))
+	require.Contains(message["text"], "EnrollmentRequested")
+	require.Contains(message["text"], "EnrollmentRequest/mydevice")
+}
+
+func TestSinkRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		status   int
+		want     int
+	}{
+		{name: "recovers", failures: 2, status: http.StatusServiceUnavailable, want: 3},
+		{name: "gives up", failures: 5, status: http.StatusBadGateway, want: 3},
+		{name: "throttled", failures: 1, status: http.StatusTooManyRequests, want: 2},
+		{name: "rejected", failures: 1, status: http.StatusBadRequest, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := &webhookRecorder{failures: tt.failures, status: tt.status}
+			server := httptest.NewServer(webhook)
+			defer server.Close()
+
+			sink := newTestSink(t, Webhook{Name: "ops", URL: server.URL})
+			sink.Deliver(context.Background(), payload(t, newEvent(DeviceFailed, api.DeviceKind, "mydevice", "")))
+			require.Len(t, webhook.requests, tt.want)
+		})
+	}
+}
+
+func TestNewSinkValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook Webhook
+		wantErr string
+	}{
+		{name: "valid", webhook: Webhook{Name: "ops", URL: "https://hooks.example.com", Format: FormatSlack, Reasons: Reasons}},
+		{name: "url", webhook: Webhook{Name: "ops", URL: "hooks.example.com"}, wantErr: "invalid url"},
+		{name: "format", webhook: Webhook{Name: "ops", URL: "https://hooks.example.com", Format: "xml"}, wantErr: "invalid format"},
+		{name: "reason", webhook: Webhook{Name: "ops", URL: "https://hooks.example.com", Reasons: []Reason{"DeviceExploded"}}, wantErr: "unknown reason"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSink(log.InitLogs(), []Webhook{tt.webhook}, time.Second, 0, 0)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRelay(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "outbox.db")), &gorm.Config{})
+	require.NoError(err)
+	outbox := store.NewOutbox(db, log.InitLogs())
+	require.NoError(outbox.InitialMigration())
+
+	webhook := &webhookRecorder{}
+	server := httptest.NewServer(webhook)
+	defer server.Close()
+
+	// the events are enqueued by the service, and delivered in order by the workers
+	emitter := NewEmitter(outbox, log.InitLogs())
+	emitter.Emit(ctx, EnrollmentRequestEvent(EnrollmentRequested, "mydevice"))
+	emitter.Emit(ctx, EnrollmentRequestEvent(EnrollmentApproved, "mydevice"))
+	// a nil emitter, when no webhook is configured, drops the events
+	(*Emitter)(nil).Emit(ctx, EnrollmentRequestEvent(EnrollmentRequested, "otherdevice"))
+
+	relay := NewRelay(log.InitLogs(), outbox, newTestSink(t, Webhook{Name: "ops", URL: server.URL}))
+	delivered, err := relay.Relay(ctx)
+	require.NoError(err)
+	require.Equal(2, delivered)
+	events := webhook.events(t)
+	require.Len(events, 2)
+	require.Equal([]Reason{EnrollmentRequested, EnrollmentApproved}, []Reason{events[0].Reason, events[1].Reason})
+
+	// and only once
+	delivered, err = relay.Relay(ctx)
+	require.NoError(err)
+	require.Zero(delivered)
+	require.Len(webhook.events(t), 2)
+}
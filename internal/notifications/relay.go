@@ -0,0 +1,58 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// RelayInterval is the interval at which the enqueued events are delivered.
+	RelayInterval = time.Second
+
+	// RelayBatchSize is the number of events claimed from the outbox at once.
+	RelayBatchSize = 20
+)
+
+// Relay delivers the events enqueued in the outbox to the sink.
+type Relay struct {
+	log    logrus.FieldLogger
+	outbox store.Outbox
+	sink   *Sink
+}
+
+func NewRelay(log logrus.FieldLogger, outbox store.Outbox, sink *Sink) *Relay {
+	return &Relay{
+		log:    log,
+		outbox: outbox,
+		sink:   sink,
+	}
+}
+
+// Poll delivers the pending events. The delivered events are deleted with the published tasks.
+func (r *Relay) Poll() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := r.Relay(ctx); err != nil {
+		r.log.WithError(err).Error("failed to deliver events")
+	}
+}
+
+// Relay delivers the pending events in batches and returns the number of events delivered.
+func (r *Relay) Relay(ctx context.Context) (int, error) {
+	deliver := func(payload []byte) error {
+		r.sink.Deliver(ctx, payload)
+		return ctx.Err()
+	}
+	total := 0
+	for {
+		delivered, err := r.outbox.Relay(ctx, Queue, RelayBatchSize, deliver)
+		total += delivered
+		if err != nil || delivered < RelayBatchSize {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// FormatJSON posts the event as JSON.
+	FormatJSON = "json"
+	// FormatSlack posts the event as a Slack message, for Slack incoming webhooks.
+	FormatSlack = "slack"
+
+	// SignatureHeader holds the HMAC-SHA256 of the body, keyed with the secret of the webhook, as
+	// "sha256=<hex>".
+	SignatureHeader = "X-Flightctl-Signature"
+	// ReasonHeader holds the reason of the event.
+	ReasonHeader = "X-Flightctl-Event"
+)
+
+// Webhook is a subscription of a webhook to the events with the given reasons and kinds.
+type Webhook struct {
+	Name string
+	URL  string
+	// Secret signs the body of the requests, which are not signed if empty.
+	Secret string
+	// Format is the format of the body, FormatJSON if empty.
+	Format string
+	// Reasons filter the events by reason, all reasons match if empty.
+	Reasons []Reason
+	// Kinds filter the events by the kind of their resource, all kinds match if empty.
+	Kinds []string
+}
+
+// Validate checks the URL, format and filters of the webhook.
+func (w *Webhook) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("webhook name must not be empty")
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("webhook %s: invalid url %q", w.Name, w.URL)
+	}
+	switch w.Format {
+	case "", FormatJSON, FormatSlack:
+	default:
+		return fmt.Errorf("webhook %s: invalid format %q: must be %q or %q", w.Name, w.Format, FormatJSON, FormatSlack)
+	}
+	for _, reason := range w.Reasons {
+		if !slices.Contains(Reasons, reason) {
+			return fmt.Errorf("webhook %s: unknown reason %q", w.Name, reason)
+		}
+	}
+	return nil
+}
+
+// Matches returns whether the webhook is subscribed to the event.
+func (w *Webhook) Matches(event *Event) bool {
+	if len(w.Reasons) > 0 && !slices.Contains(w.Reasons, event.Reason) {
+		return false
+	}
+	if len(w.Kinds) > 0 && !slices.Contains(w.Kinds, event.Kind) {
+		return false
+	}
+	return true
+}
+
+func (w *Webhook) body(event *Event) ([]byte, error) {
+	if w.Format == FormatSlack {
+		return json.Marshal(map[string]string{
+			"text": fmt.Sprintf("*%s* %s/%s: %s", event.Reason, event.Kind, event.Name, event.Message),
+		})
+	}
+	return json.Marshal(event)
+}
+
+// Sign returns the signature of body with the given secret, as set in SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sink delivers the events to the webhooks subscribed to them.
+type Sink struct {
+	log          logrus.FieldLogger
+	client       *http.Client
+	webhooks     []Webhook
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// NewSink returns a sink delivering to the given webhooks. A delivery that fails is retried up
+// to maxRetries times, after a backoff that starts at retryBackoff and doubles with every retry.
+func NewSink(log logrus.FieldLogger, webhooks []Webhook, timeout time.Duration, maxRetries int, retryBackoff time.Duration) (*Sink, error) {
+	for i := range webhooks {
+		if err := webhooks[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &Sink{
+		log:          log,
+		client:       &http.Client{Timeout: timeout},
+		webhooks:     webhooks,
+		maxRetries:   maxRetries,
+		retryBackoff: retryBackoff,
+	}, nil
+}
+
+// Deliver posts the event in payload to the webhooks subscribed to it. A webhook that still fails
+// after all retries does not receive the event, so that it does not hold back the following
+// events, and the error is only logged.
+func (s *Sink) Deliver(ctx context.Context, payload []byte) {
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		s.log.WithError(err).Error("dropping invalid event")
+		return
+	}
+	for i := range s.webhooks {
+		webhook := &s.webhooks[i]
+		if !webhook.Matches(&event) {
+			continue
+		}
+		if err := s.deliver(ctx, webhook, &event); err != nil {
+			s.log.WithError(err).Errorf("dropping %s event of %s/%s for webhook %s", event.Reason, event.Kind, event.Name, webhook.Name)
+		}
+	}
+}
+
+func (s *Sink) deliver(ctx context.Context, webhook *Webhook, event *Event) error {
+	body, err := webhook.body(event)
+	if err != nil {
+		return err
+	}
+	backoff := s.retryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.post(ctx, webhook, event, body)
+		if err == nil || !retry || attempt >= s.maxRetries {
+			return err
+		}
+		s.log.WithError(err).Warnf("delivery of %s event to webhook %s failed, retrying in %s", event.Reason, webhook.Name, backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post posts the body to the webhook, and returns whether a failed post is worth retrying.
+func (s *Sink) post(ctx context.Context, webhook *Webhook, event *Event, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ReasonHeader, string(event.Reason))
+	if webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	// a client error other than throttling will not be fixed by sending the event again
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook responded with %s", resp.Status)
+}
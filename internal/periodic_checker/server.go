@@ -1,16 +1,15 @@
 package periodic
 
 import (
-	"context"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/flightctl/flightctl/internal/admission"
 	"github.com/flightctl/flightctl/internal/config"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/tasks"
-	"github.com/flightctl/flightctl/pkg/queues"
 	"github.com/flightctl/flightctl/pkg/thread"
 	"github.com/sirupsen/logrus"
 )
@@ -36,17 +35,7 @@ func New(
 
 // TODO: expose metrics
 func (s *Server) Run() error {
-	provider, err := queues.NewRedisProvider(context.Background(), s.log, s.cfg.KV.Hostname, s.cfg.KV.Port, s.cfg.KV.Password)
-	if err != nil {
-		return err
-	}
-	defer provider.Stop()
-
-	publisher, err := tasks.TaskQueuePublisher(provider)
-	if err != nil {
-		return err
-	}
-	callbackManager := tasks.NewCallbackManager(publisher, s.log)
+	callbackManager := tasks.NewOutboxCallbackManager(s.store.Outbox(), s.log)
 
 	// repository tester
 	repoTester := tasks.NewRepoTester(s.log, s.store)
@@ -56,7 +45,11 @@ func (s *Server) Run() error {
 	defer repoTesterThread.Stop()
 
 	// resource sync
-	resourceSync := tasks.NewResourceSync(callbackManager, s.store, s.log)
+	admissionValidator, err := admission.NewFromConfig(s.log.WithField("pkg", "admission"), s.cfg)
+	if err != nil {
+		return err
+	}
+	resourceSync := tasks.NewResourceSync(callbackManager, s.store, s.log, admissionValidator)
 	resourceSyncThread := thread.New(
 		s.log.WithField("pkg", "resourcesync"), "ResourceSync", 2*time.Minute, resourceSync.Poll)
 	resourceSyncThread.Start()
@@ -69,6 +62,27 @@ func (s *Server) Run() error {
 	deviceDisconnectedThread.Start()
 	defer deviceDisconnectedThread.Stop()
 
+	// batched fleet rollouts
+	fleetRolloutProgress := tasks.NewFleetRolloutProgress(callbackManager, s.log, s.store)
+	fleetRolloutProgressThread := thread.New(
+		s.log.WithField("pkg", "fleet-rollout-progress"), "Fleet rollout progress", tasks.FleetRolloutProgressPollingInterval, fleetRolloutProgress.Poll)
+	fleetRolloutProgressThread.Start()
+	defer fleetRolloutProgressThread.Stop()
+
+	// revision history retention
+	revisionPruner := tasks.NewRevisionPruner(s.log, s.store, s.cfg.Service.RevisionHistoryLimit)
+	revisionPrunerThread := thread.New(
+		s.log.WithField("pkg", "revision-pruner"), "Revision pruner", tasks.RevisionPrunePollingInterval, revisionPruner.Poll)
+	revisionPrunerThread.Start()
+	defer revisionPrunerThread.Stop()
+
+	// orphaned rows cleanup
+	orphanCleanup := tasks.NewOrphanCleanup(s.log, s.store, time.Duration(s.cfg.Service.OrphanCleanupDelay))
+	orphanCleanupThread := thread.New(
+		s.log.WithField("pkg", "orphan-cleanup"), "Orphan cleanup", tasks.OrphanCleanupPollingInterval, orphanCleanup.Poll)
+	orphanCleanupThread.Start()
+	defer orphanCleanupThread.Stop()
+
 	sigShutdown := make(chan os.Signal, 1)
 
 	signal.Notify(sigShutdown, os.Interrupt, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGQUIT)
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/flightctl/flightctl/internal/admission"
+	"github.com/samber/lo"
+)
+
+// admit validates the resource with the admission validators before it is persisted. The
+// resource is rejected if the returned error is an admission.DeniedError, and could not be
+// validated otherwise.
+func (h *ServiceHandler) admit(ctx context.Context, operation admission.Operation, kind string, name *string, object interface{}) error {
+	if h.admission == nil {
+		return nil
+	}
+	err := h.admission.Validate(ctx, admission.Request{
+		Kind:      kind,
+		Name:      lo.FromPtr(name),
+		Operation: operation,
+		Object:    object,
+	})
+	if err != nil && !admission.IsDenied(err) {
+		h.log.WithError(err).Errorf("failed to validate %s/%s", kind, lo.FromPtr(name))
+	}
+	return err
+}
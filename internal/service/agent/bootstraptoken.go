@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	agentServer "github.com/flightctl/flightctl/internal/api/server/agent"
+	"github.com/flightctl/flightctl/internal/service/common"
+)
+
+// bootstrapTokenRedeemPath is the path of the only operation agents can call without a client
+// certificate.
+const bootstrapTokenRedeemPath = "/api/v1/bootstraptokens/redeem"
+
+// (POST /api/v1/bootstraptokens/redeem)
+func (s *AgentServiceHandler) RedeemBootstrapToken(ctx context.Context, request agentServer.RedeemBootstrapTokenRequestObject) (agentServer.RedeemBootstrapTokenResponseObject, error) {
+	if !s.bootstrapTokenEnrollment {
+		return agentServer.RedeemBootstrapToken404JSONResponse{Message: "bootstrap token enrollment is disabled"}, nil
+	}
+
+	serverRequest := server.RedeemBootstrapTokenRequestObject{
+		Body: request.Body,
+	}
+	return common.RedeemBootstrapToken(ctx, s.store, s.ca, s.log, serverRequest)
+}
+
+// RequireClientCertificate rejects the requests without a client certificate, except the
+// redemption of bootstrap tokens. It is used when the server accepts connections without a client
+// certificate, so that agents can redeem bootstrap tokens.
+func RequireClientCertificate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.Method == http.MethodPost && r.URL.Path == bootstrapTokenRedeemPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(api.Error{Message: "client certificate required"})
+	})
+}
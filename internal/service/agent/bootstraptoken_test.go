@@ -0,0 +1,152 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	agentServer "github.com/flightctl/flightctl/internal/api/server/agent"
+	fcrypto "github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type BootstrapTokenStore struct {
+	store.Store
+	tokens *DummyBootstrapToken
+}
+
+func (s *BootstrapTokenStore) BootstrapToken() store.BootstrapToken {
+	return s.tokens
+}
+
+type dummyToken struct {
+	expiresAt time.Time
+	redeemed  bool
+}
+
+type DummyBootstrapToken struct {
+	store.BootstrapTokenStore
+	tokens map[string]*dummyToken
+}
+
+func (s *DummyBootstrapToken) Create(ctx context.Context, orgId uuid.UUID, hash string, expiresAt time.Time) error {
+	s.tokens[hash] = &dummyToken{expiresAt: expiresAt}
+	return nil
+}
+
+func (s *DummyBootstrapToken) Redeem(ctx context.Context, orgId uuid.UUID, hash string, now time.Time, issue func() error) error {
+	token, ok := s.tokens[hash]
+	switch {
+	case !ok:
+		return flterrors.ErrBootstrapTokenInvalid
+	case token.redeemed:
+		return flterrors.ErrBootstrapTokenRedeemed
+	case !now.Before(token.expiresAt):
+		return flterrors.ErrBootstrapTokenExpired
+	}
+	if err := issue(); err != nil {
+		return err
+	}
+	token.redeemed = true
+	return nil
+}
+
+func TestRedeemBootstrapToken(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	ca, err := fcrypto.MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(err)
+	_, privateKey, err := fcrypto.NewKeyPair()
+	require.NoError(err)
+	csr, err := fcrypto.MakeCSR(privateKey.(crypto.Signer), "")
+	require.NoError(err)
+
+	now := time.Now()
+	tokens := &DummyBootstrapToken{tokens: map[string]*dummyToken{}}
+	issue := func(expiresAt time.Time) string {
+		token, hash, err := fcrypto.NewBootstrapToken()
+		require.NoError(err)
+		require.NoError(tokens.Create(context.Background(), store.NullOrgId, hash, expiresAt))
+		return token
+	}
+	valid := issue(now.Add(time.Hour))
+	expired := issue(now.Add(-time.Minute))
+	malformed := issue(now.Add(time.Hour))
+
+	handler := NewAgentServiceHandler(&BootstrapTokenStore{tokens: tokens}, nil, ca, log.InitLogs(), nil, "", true)
+	router := chi.NewRouter()
+	agentServer.HandlerFromMux(agentServer.NewStrictHandler(handler, nil), router)
+
+	redeem := func(token string, csr string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(api.BootstrapTokenRedemption{Token: token, Csr: csr})
+		require.NoError(err)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, bootstrapTokenRedeemPath, bytes.NewReader(body)))
+		return rec
+	}
+
+	// a valid token is redeemed for an enrollment certificate
+	rec := redeem(valid, string(csr))
+	require.Equal(http.StatusOK, rec.Code, rec.Body.String())
+	var resp api.BootstrapTokenRedemptionResponse
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), &resp))
+	block, _ := pem.Decode([]byte(resp.Certificate))
+	require.NotNil(block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(err)
+	require.True(strings.HasPrefix(cert.Subject.CommonName, fcrypto.ClientBootstrapCommonNamePrefix))
+
+	// a reused token is rejected
+	rec = redeem(valid, string(csr))
+	require.Equal(http.StatusUnauthorized, rec.Code)
+	require.Contains(rec.Body.String(), flterrors.ErrBootstrapTokenRedeemed.Error())
+
+	// an expired token is rejected
+	rec = redeem(expired, string(csr))
+	require.Equal(http.StatusUnauthorized, rec.Code)
+	require.Contains(rec.Body.String(), flterrors.ErrBootstrapTokenExpired.Error())
+
+	// an unknown token is rejected
+	rec = redeem("unknown", string(csr))
+	require.Equal(http.StatusUnauthorized, rec.Code)
+	require.Contains(rec.Body.String(), flterrors.ErrBootstrapTokenInvalid.Error())
+
+	// a malformed request does not redeem the token
+	rec = redeem(malformed, "not a csr")
+	require.Equal(http.StatusBadRequest, rec.Code)
+	require.Equal(http.StatusOK, redeem(malformed, string(csr)).Code)
+
+	// tokens cannot be redeemed unless bootstrap token enrollment is enabled
+	handler.bootstrapTokenEnrollment = false
+	require.Equal(http.StatusNotFound, redeem(issue(now.Add(time.Hour)), string(csr)).Code)
+}
+
+func TestRequireClientCertificate(t *testing.T) {
+	require := require.New(t)
+	handler := RequireClientCertificate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, bootstrapTokenRedeemPath, nil))
+	require.Equal(http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devices/foo/rendered", nil))
+	require.Equal(http.StatusUnauthorized, rec.Code)
+}
@@ -9,6 +9,8 @@ import (
 	agentServer "github.com/flightctl/flightctl/internal/api/server/agent"
 	"github.com/flightctl/flightctl/internal/api_server/middleware"
 	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/kvstore"
+	"github.com/flightctl/flightctl/internal/notifications"
 	"github.com/flightctl/flightctl/internal/service/common"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/sirupsen/logrus"
@@ -16,9 +18,13 @@ import (
 
 type AgentServiceHandler struct {
 	store             store.Store
+	kvStore           kvstore.KVStore
 	ca                *crypto.CA
 	log               logrus.FieldLogger
+	emitter           *notifications.Emitter
 	agentGrpcEndpoint string
+	// bootstrapTokenEnrollment enables redeeming bootstrap tokens.
+	bootstrapTokenEnrollment bool
 }
 
 // Make sure we conform to servers Service interface
@@ -54,12 +60,16 @@ func ValidateEnrollmentAccessFromContext(ctx context.Context, log logrus.FieldLo
 	return nil
 }
 
-func NewAgentServiceHandler(store store.Store, ca *crypto.CA, log logrus.FieldLogger, agentGrpcEndpoint string) *AgentServiceHandler {
+func NewAgentServiceHandler(store store.Store, kvStore kvstore.KVStore, ca *crypto.CA, log logrus.FieldLogger, emitter *notifications.Emitter, agentGrpcEndpoint string, bootstrapTokenEnrollment bool) *AgentServiceHandler {
 	return &AgentServiceHandler{
 		store:             store,
+		kvStore:           kvStore,
 		ca:                ca,
 		log:               log,
+		emitter:           emitter,
 		agentGrpcEndpoint: agentGrpcEndpoint,
+
+		bootstrapTokenEnrollment: bootstrapTokenEnrollment,
 	}
 }
 
@@ -92,7 +102,53 @@ func (s *AgentServiceHandler) ReplaceDeviceStatus(ctx context.Context, request a
 		Name: request.Name,
 		Body: request.Body,
 	}
-	return common.ReplaceDeviceStatus(ctx, s.store, s.log, serverRequest)
+	return common.ReplaceDeviceStatus(ctx, s.store, s.log, s.emitter, serverRequest)
+}
+
+// (PUT /api/v1/devices/{name}/logs)
+func (s *AgentServiceHandler) ReplaceDeviceLogs(ctx context.Context, request agentServer.ReplaceDeviceLogsRequestObject) (agentServer.ReplaceDeviceLogsResponseObject, error) {
+
+	if err := ValidateDeviceAccessFromContext(ctx, request.Name, s.log); err != nil {
+		return agentServer.ReplaceDeviceLogs401JSONResponse{
+			Message: err.Error(),
+		}, err
+	}
+
+	serverRequest := server.ReplaceDeviceLogsRequestObject{
+		Name: request.Name,
+		Body: request.Body,
+	}
+	return common.ReplaceDeviceLogs(ctx, s.store, serverRequest)
+}
+
+// (PUT /api/v1/devices/{name}/updateslot)
+func (s *AgentServiceHandler) AcquireDeviceUpdateSlot(ctx context.Context, request agentServer.AcquireDeviceUpdateSlotRequestObject) (agentServer.AcquireDeviceUpdateSlotResponseObject, error) {
+
+	if err := ValidateDeviceAccessFromContext(ctx, request.Name, s.log); err != nil {
+		return agentServer.AcquireDeviceUpdateSlot401JSONResponse{
+			Message: err.Error(),
+		}, err
+	}
+
+	serverRequest := server.AcquireDeviceUpdateSlotRequestObject{
+		Name: request.Name,
+	}
+	return common.AcquireDeviceUpdateSlot(ctx, s.store, s.kvStore, s.log, serverRequest)
+}
+
+// (DELETE /api/v1/devices/{name}/updateslot)
+func (s *AgentServiceHandler) ReleaseDeviceUpdateSlot(ctx context.Context, request agentServer.ReleaseDeviceUpdateSlotRequestObject) (agentServer.ReleaseDeviceUpdateSlotResponseObject, error) {
+
+	if err := ValidateDeviceAccessFromContext(ctx, request.Name, s.log); err != nil {
+		return agentServer.ReleaseDeviceUpdateSlot401JSONResponse{
+			Message: err.Error(),
+		}, err
+	}
+
+	serverRequest := server.ReleaseDeviceUpdateSlotRequestObject{
+		Name: request.Name,
+	}
+	return common.ReleaseDeviceUpdateSlot(ctx, s.store, s.kvStore, serverRequest)
 }
 
 // (POST /api/v1/enrollmentrequests)
@@ -107,7 +163,7 @@ func (s *AgentServiceHandler) CreateEnrollmentRequest(ctx context.Context, reque
 	serverRequest := server.CreateEnrollmentRequestRequestObject{
 		Body: request.Body,
 	}
-	return common.CreateEnrollmentRequest(ctx, s.store, serverRequest)
+	return common.CreateEnrollmentRequest(ctx, s.store, s.emitter, serverRequest)
 }
 
 // (GET /api/v1/enrollmentrequests/{name})
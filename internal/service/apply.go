@@ -0,0 +1,289 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// applyRoots are the parts of a resource that can be set through server-side apply. Everything
+// else is either immutable or managed by the service.
+var applyRoots = []string{"/metadata/labels", "/spec"}
+
+type applyConflict struct {
+	manager string
+	field   string
+}
+
+// ApplyConflictError is returned when an apply request sets fields owned by other field managers
+// to different values.
+type ApplyConflictError struct {
+	conflicts []applyConflict
+}
+
+func (e *ApplyConflictError) Error() string {
+	plural := "s"
+	if len(e.conflicts) == 1 {
+		plural = ""
+	}
+	details := make([]string, 0, len(e.conflicts))
+	for _, c := range e.conflicts {
+		details = append(details, fmt.Sprintf("conflict with %q: %s", c.manager, c.field))
+	}
+	return fmt.Sprintf("apply failed with %d conflict%s: %s", len(e.conflicts), plural, strings.Join(details, ", "))
+}
+
+// serverSideApply merges the fields set in applied into existing on behalf of fieldManager and
+// records them in metadata.managedFields as owned by it. Fields the manager owned before but no
+// longer sets are removed, unless another manager also owns them. Setting a field owned by
+// another manager to a different value is a conflict, unless force is set, in which case the
+// field changes owner. existing is nil when the resource does not exist yet.
+func serverSideApply[T any](existing *T, applied *T, fieldManager string, force bool) (*T, error) {
+	if fieldManager == "" {
+		return nil, errors.New("fieldManager must not be empty")
+	}
+	appliedObj, err := toObject(applied)
+	if err != nil {
+		return nil, err
+	}
+	existingObj := map[string]interface{}{}
+	if existing != nil {
+		if existingObj, err = toObject(existing); err != nil {
+			return nil, err
+		}
+	}
+
+	managed := map[string][]string{}
+	if raw, ok := getField(existingObj, "/metadata/managedFields"); ok {
+		buf, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(buf, &managed); err != nil {
+			return nil, err
+		}
+	}
+
+	appliedFields := []string{}
+	for _, root := range applyRoots {
+		if value, ok := getField(appliedObj, root); ok {
+			appliedFields = append(appliedFields, leafFields(root, value)...)
+		}
+	}
+	sort.Strings(appliedFields)
+
+	conflicts := []applyConflict{}
+	for _, manager := range sortedKeys(managed) {
+		if manager == fieldManager {
+			continue
+		}
+		kept := []string{}
+		for _, owned := range managed[manager] {
+			if !fieldChanged(existingObj, appliedObj, owned, appliedFields) {
+				kept = append(kept, owned)
+				continue
+			}
+			if !force {
+				conflicts = append(conflicts, applyConflict{manager: manager, field: owned})
+				kept = append(kept, owned)
+			}
+		}
+		managed[manager] = kept
+	}
+	if len(conflicts) > 0 {
+		return nil, &ApplyConflictError{conflicts: conflicts}
+	}
+
+	result := deepCopyObject(existingObj)
+	appliedSet := toSet(appliedFields)
+	for _, owned := range managed[fieldManager] {
+		if !appliedSet[owned] && !ownedByOthers(managed, fieldManager, owned) {
+			deleteField(result, owned)
+		}
+	}
+	for _, field := range appliedFields {
+		value, _ := getField(appliedObj, field)
+		setField(result, field, value)
+	}
+
+	managed[fieldManager] = appliedFields
+	for manager, fields := range managed {
+		if len(fields) == 0 {
+			delete(managed, manager)
+		}
+	}
+
+	// only the fields that can be applied are carried over, the rest of the metadata is
+	// managed by the service
+	metadata := map[string]interface{}{"managedFields": managed}
+	if name, ok := getField(appliedObj, "/metadata/name"); ok {
+		metadata["name"] = name
+	}
+	if labels, ok := getField(result, "/metadata/labels"); ok {
+		metadata["labels"] = labels
+	}
+	if version, ok := getField(appliedObj, "/metadata/resourceVersion"); ok {
+		metadata["resourceVersion"] = version
+	} else if version, ok := getField(existingObj, "/metadata/resourceVersion"); ok {
+		metadata["resourceVersion"] = version
+	}
+	result["metadata"] = metadata
+	result["apiVersion"] = appliedObj["apiVersion"]
+	result["kind"] = appliedObj["kind"]
+	delete(result, "status")
+
+	buf, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var merged T
+	if err := json.Unmarshal(buf, &merged); err != nil {
+		return nil, fmt.Errorf("failed to merge applied resource: %w", err)
+	}
+	return &merged, nil
+}
+
+// fieldChanged reports whether the apply request sets the owned field, or a field nested in or
+// containing it, to a value different from the existing one.
+func fieldChanged(existingObj, appliedObj map[string]interface{}, owned string, appliedFields []string) bool {
+	for _, field := range appliedFields {
+		if field != owned && !strings.HasPrefix(field, owned+"/") && !strings.HasPrefix(owned, field+"/") {
+			continue
+		}
+		existingValue, _ := getField(existingObj, field)
+		appliedValue, _ := getField(appliedObj, field)
+		if !reflect.DeepEqual(existingValue, appliedValue) {
+			return true
+		}
+	}
+	return false
+}
+
+func ownedByOthers(managed map[string][]string, fieldManager string, field string) bool {
+	for manager, fields := range managed {
+		if manager != fieldManager && toSet(fields)[field] {
+			return true
+		}
+	}
+	return false
+}
+
+// leafFields returns the JSON pointers of the values nested in value. Lists are treated as
+// atomic values, so they are always owned as a whole, while empty objects and null values do
+// not set anything.
+func leafFields(pointer string, value interface{}) []string {
+	if value == nil {
+		return nil
+	}
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return []string{pointer}
+	}
+	fields := []string{}
+	for _, key := range sortedKeys(obj) {
+		fields = append(fields, leafFields(pointer+"/"+escapePointerToken(key), obj[key])...)
+	}
+	return fields
+}
+
+func getField(obj map[string]interface{}, pointer string) (interface{}, bool) {
+	var current interface{} = obj
+	for _, token := range pointerTokens(pointer) {
+		parent, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = parent[token]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func setField(obj map[string]interface{}, pointer string, value interface{}) {
+	tokens := pointerTokens(pointer)
+	current := obj
+	for _, token := range tokens[:len(tokens)-1] {
+		next, ok := current[token].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[token] = next
+		}
+		current = next
+	}
+	current[tokens[len(tokens)-1]] = value
+}
+
+// deleteField removes the field, along with the objects containing it that become empty.
+func deleteField(obj map[string]interface{}, pointer string) {
+	tokens := pointerTokens(pointer)
+	parents := []map[string]interface{}{obj}
+	for _, token := range tokens[:len(tokens)-1] {
+		next, ok := parents[len(parents)-1][token].(map[string]interface{})
+		if !ok {
+			return
+		}
+		parents = append(parents, next)
+	}
+	for i := len(tokens) - 1; i >= 0; i-- {
+		delete(parents[i], tokens[i])
+		if i == 0 || len(parents[i]) > 0 {
+			return
+		}
+	}
+}
+
+func pointerTokens(pointer string) []string {
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(tokens[i])
+	}
+	return tokens
+}
+
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func toObject(v interface{}) (map[string]interface{}, error) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(buf, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func deepCopyObject(obj map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(obj))
+	for key, value := range obj {
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = deepCopyObject(nested)
+		}
+		result[key] = value
+	}
+	return result
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
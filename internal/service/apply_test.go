@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/auth"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+const osImageField = "/spec/template/spec/os/image"
+
+func applyTestFleet(labels map[string]string, image string) *v1alpha1.Fleet {
+	fleet := &v1alpha1.Fleet{
+		ApiVersion: "v1alpha1",
+		Kind:       "Fleet",
+		Metadata: v1alpha1.ObjectMeta{
+			Name:   util.StrToPtr("fleet"),
+			Labels: &labels,
+		},
+		Spec: v1alpha1.FleetSpec{
+			Selector: &v1alpha1.LabelSelector{MatchLabels: &map[string]string{"site": "a"}},
+		},
+	}
+	fleet.Spec.Template.Spec.Os = &v1alpha1.DeviceOsSpec{Image: image}
+	return fleet
+}
+
+func TestServerSideApplyTracksOwnership(t *testing.T) {
+	require := require.New(t)
+
+	created, err := serverSideApply(nil, applyTestFleet(map[string]string{"env": "prod"}, "os:1"), "gitops", false)
+	require.NoError(err)
+	require.Equal("os:1", created.Spec.Template.Spec.Os.Image)
+	require.Equal(map[string][]string{
+		"gitops": {"/metadata/labels/env", "/spec/selector/matchLabels/site", osImageField},
+	}, *created.Metadata.ManagedFields)
+
+	// another manager sets a label of its own, leaving the fields owned by gitops untouched
+	uiApplied := &v1alpha1.Fleet{ApiVersion: "v1alpha1", Kind: "Fleet", Metadata: v1alpha1.ObjectMeta{
+		Name:   util.StrToPtr("fleet"),
+		Labels: &map[string]string{"team": "edge"},
+	}}
+	updated, err := serverSideApply(created, uiApplied, "ui", false)
+	require.NoError(err)
+	require.Equal(map[string]string{"env": "prod", "team": "edge"}, *updated.Metadata.Labels)
+	require.Equal("os:1", updated.Spec.Template.Spec.Os.Image)
+	require.Equal([]string{"/metadata/labels/team"}, (*updated.Metadata.ManagedFields)["ui"])
+
+	// fields no longer set by a manager are removed
+	updated, err = serverSideApply(updated, applyTestFleet(map[string]string{}, "os:1"), "gitops", false)
+	require.NoError(err)
+	require.Equal(map[string]string{"team": "edge"}, *updated.Metadata.Labels)
+}
+
+func TestServerSideApplyConflict(t *testing.T) {
+	require := require.New(t)
+
+	existing, err := serverSideApply(nil, applyTestFleet(map[string]string{}, "os:1"), "ui", false)
+	require.NoError(err)
+
+	// setting a field owned by another manager to a different value is a conflict
+	_, err = serverSideApply(existing, applyTestFleet(map[string]string{}, "os:2"), "gitops", false)
+	var conflictErr *ApplyConflictError
+	require.ErrorAs(err, &conflictErr)
+	require.Equal(`apply failed with 1 conflict: conflict with "ui": `+osImageField, err.Error())
+
+	// setting it to the same value shares its ownership
+	shared, err := serverSideApply(existing, applyTestFleet(map[string]string{}, "os:1"), "gitops", false)
+	require.NoError(err)
+	require.Contains((*shared.Metadata.ManagedFields)["ui"], osImageField)
+	require.Contains((*shared.Metadata.ManagedFields)["gitops"], osImageField)
+
+	// forcing takes the ownership from the other manager
+	forced, err := serverSideApply(existing, applyTestFleet(map[string]string{}, "os:2"), "gitops", true)
+	require.NoError(err)
+	require.Equal("os:2", forced.Spec.Template.Spec.Os.Image)
+	require.NotContains((*forced.Metadata.ManagedFields)["ui"], osImageField)
+	require.Contains((*forced.Metadata.ManagedFields)["gitops"], osImageField)
+}
+
+func TestReplaceFleetServerSideConflict(t *testing.T) {
+	require := require.New(t)
+	_ = os.Setenv(auth.DisableAuthEnvKey, "true")
+	_, _ = auth.CreateAuthMiddleware(context.Background(), nil, log.InitLogs())
+
+	existing, err := serverSideApply(nil, applyTestFleet(map[string]string{}, "os:1"), "ui", false)
+	require.NoError(err)
+	serviceHandler := ServiceHandler{
+		store:           &FleetStore{FleetVal: *existing},
+		callbackManager: dummyCallbackManager(),
+	}
+
+	request := server.ReplaceFleetRequestObject{
+		Name:   "fleet",
+		Params: v1alpha1.ReplaceFleetParams{FieldManager: util.StrToPtr("gitops")},
+		Body:   applyTestFleet(map[string]string{}, "os:2"),
+	}
+	resp, err := serviceHandler.ReplaceFleet(context.Background(), request)
+	require.NoError(err)
+	conflict, ok := resp.(server.ReplaceFleet409JSONResponse)
+	require.True(ok)
+	require.Contains(conflict.Message, osImageField)
+
+	request.Body = applyTestFleet(map[string]string{}, "os:2")
+	request.Params.Force = util.BoolToPtr(true)
+	resp, err = serviceHandler.ReplaceFleet(context.Background(), request)
+	require.NoError(err)
+	replaced, ok := resp.(server.ReplaceFleet200JSONResponse)
+	require.True(ok)
+	require.Equal("os:2", replaced.Spec.Template.Spec.Os.Image)
+}
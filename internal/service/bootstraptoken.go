@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/auth"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+)
+
+const (
+	// DefaultBootstrapTokenExpiry is how long a bootstrap token can be redeemed by default.
+	DefaultBootstrapTokenExpiry = 24 * time.Hour
+	// MaxBootstrapTokenExpiry is how long a bootstrap token can be redeemed at most.
+	MaxBootstrapTokenExpiry = 30 * 24 * time.Hour
+)
+
+// (POST /api/v1/bootstraptokens)
+func (h *ServiceHandler) IssueBootstrapToken(ctx context.Context, request server.IssueBootstrapTokenRequestObject) (server.IssueBootstrapTokenResponseObject, error) {
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "bootstraptokens", "create")
+	if err != nil {
+		h.log.WithError(err).Error("failed to check authorization permission")
+		return server.IssueBootstrapToken503JSONResponse{Message: AuthorizationServerUnavailable}, nil
+	}
+	if !allowed {
+		return server.IssueBootstrapToken403JSONResponse{Message: Forbidden}, nil
+	}
+	if !h.bootstrapTokenEnrollment {
+		return server.IssueBootstrapToken404JSONResponse{Message: "bootstrap token enrollment is disabled"}, nil
+	}
+	orgId := store.NullOrgId
+
+	expiry := DefaultBootstrapTokenExpiry
+	if request.Body.ExpiresIn != nil {
+		expiry, err = time.ParseDuration(*request.Body.ExpiresIn)
+		if err != nil || expiry <= 0 || expiry > MaxBootstrapTokenExpiry {
+			return server.IssueBootstrapToken400JSONResponse{Message: fmt.Sprintf("invalid expiresIn %q: must be a positive duration of at most %s", *request.Body.ExpiresIn, MaxBootstrapTokenExpiry)}, nil
+		}
+	}
+
+	token, hash, err := crypto.NewBootstrapToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate bootstrap token: %w", err)
+	}
+	expiresAt := time.Now().Add(expiry).UTC()
+	if err := h.store.BootstrapToken().Create(ctx, orgId, hash, expiresAt); err != nil {
+		return nil, err
+	}
+	return server.IssueBootstrapToken201JSONResponse(api.BootstrapToken{Token: token, ExpiresAt: expiresAt}), nil
+}
+
+// (POST /api/v1/bootstraptokens/redeem)
+func (h *ServiceHandler) RedeemBootstrapToken(ctx context.Context, request server.RedeemBootstrapTokenRequestObject) (server.RedeemBootstrapTokenResponseObject, error) {
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "bootstraptokens/redeem", "create")
+	if err != nil {
+		h.log.WithError(err).Error("failed to check authorization permission")
+		return server.RedeemBootstrapToken503JSONResponse{Message: AuthorizationServerUnavailable}, nil
+	}
+	if !allowed {
+		return server.RedeemBootstrapToken403JSONResponse{Message: Forbidden}, nil
+	}
+	if !h.bootstrapTokenEnrollment {
+		return server.RedeemBootstrapToken404JSONResponse{Message: "bootstrap token enrollment is disabled"}, nil
+	}
+
+	return common.RedeemBootstrapToken(ctx, h.store, h.ca, h.log, request)
+}
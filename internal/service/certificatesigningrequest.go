@@ -132,6 +132,7 @@ func (h *ServiceHandler) ListCertificateSigningRequests(ctx context.Context, req
 		return server.ListCertificateSigningRequests403JSONResponse{Message: Forbidden}, nil
 	}
 	orgId := store.NullOrgId
+	ctx = store.WithStaleReads(ctx)
 
 	cont, err := store.ParseContinueString(request.Params.Continue)
 	if err != nil {
@@ -294,7 +295,7 @@ func (h *ServiceHandler) PatchCertificateSigningRequest(ctx context.Context, req
 	}
 
 	newObj := &api.CertificateSigningRequest{}
-	err = ApplyJSONPatch(ctx, currentObj, newObj, *request.Body, "/api/v1/certificatesigningrequests/"+request.Name)
+	err = ApplyPatch(ctx, currentObj, newObj, request.ApplicationJSONPatchPlusJSONBody, request.ApplicationMergePatchPlusJSONBody, "/api/v1/certificatesigningrequests/"+request.Name)
 	if err != nil {
 		return server.PatchCertificateSigningRequest400JSONResponse{Message: err.Error()}, nil
 	}
@@ -312,8 +313,9 @@ func (h *ServiceHandler) PatchCertificateSigningRequest(ctx context.Context, req
 		return server.PatchCertificateSigningRequest400JSONResponse{Message: "status is immutable"}, nil
 	}
 
+	// the resourceVersion is kept, so that the update is conditional on the version the patch was
+	// applied to, or on the one the patch sets, and concurrent changes are not overwritten
 	common.NilOutManagedObjectMetaProperties(&newObj.Metadata)
-	newObj.Metadata.ResourceVersion = nil
 
 	result, err := h.store.CertificateSigningRequest().Update(ctx, orgId, newObj)
 	switch err {
@@ -64,6 +64,19 @@ func validateAgainstSchema(ctx context.Context, obj []byte, objPath string) erro
 	return openapi3filter.ValidateRequest(ctx, requestValidationInput)
 }
 
+// ApplyPatch applies the JSON patch or the JSON merge patch of a PATCH request to obj, validates
+// the result against the schema of the resource at objPath, and decodes it into newObj.
+func ApplyPatch[T any](ctx context.Context, obj T, newObj T, jsonPatch *v1alpha1.PatchRequest, mergePatch *v1alpha1.MergePatchRequest, objPath string) error {
+	switch {
+	case jsonPatch != nil:
+		return ApplyJSONPatch(ctx, obj, newObj, *jsonPatch, objPath)
+	case mergePatch != nil:
+		return ApplyMergePatch(ctx, obj, newObj, *mergePatch, objPath)
+	default:
+		return fmt.Errorf("unsupported patch content type, must be application/json-patch+json or application/merge-patch+json")
+	}
+}
+
 func ApplyJSONPatch[T any](ctx context.Context, obj T, newObj T, patchRequest v1alpha1.PatchRequest, objPath string) error {
 	patch, err := json.Marshal(patchRequest)
 	if err != nil {
@@ -83,8 +96,32 @@ func ApplyJSONPatch[T any](ctx context.Context, obj T, newObj T, patchRequest v1
 		return err
 	}
 
+	return decodePatchedObject(ctx, newJSON, newObj, objPath)
+}
+
+// ApplyMergePatch applies a JSON merge patch (RFC 7386), in which fields set to null are removed
+// and objects are merged recursively.
+func ApplyMergePatch[T any](ctx context.Context, obj T, newObj T, patchRequest v1alpha1.MergePatchRequest, objPath string) error {
+	patch, err := json.Marshal(patchRequest)
+	if err != nil {
+		return err
+	}
+
+	objJSON, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	newJSON, err := jsonpatch.MergePatch(objJSON, patch)
+	if err != nil {
+		return err
+	}
+
+	return decodePatchedObject(ctx, newJSON, newObj, objPath)
+}
+
+func decodePatchedObject[T any](ctx context.Context, newJSON []byte, newObj T, objPath string) error {
 	//validate the new object against OpenAPI schema
-	err = validateAgainstSchema(ctx, newJSON, objPath)
+	err := validateAgainstSchema(ctx, newJSON, objPath)
 	if err != nil {
 		return err
 	}
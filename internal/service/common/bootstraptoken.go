@@ -0,0 +1,61 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// bootstrapEnrollmentCertExpirySeconds is the expiry of the enrollment certificates issued for
+// bootstrap tokens, the same as the default one of enrollment certificates signed from CSRs.
+const bootstrapEnrollmentCertExpirySeconds = 60 * 60 * 24 * 7 // 7 days
+
+// RedeemBootstrapToken issues an enrollment certificate for the CSR of the request, if its
+// bootstrap token is valid.
+func RedeemBootstrapToken(ctx context.Context, st store.Store, ca *crypto.CA, log logrus.FieldLogger, request server.RedeemBootstrapTokenRequestObject) (server.RedeemBootstrapTokenResponseObject, error) {
+	orgId := store.NullOrgId
+
+	if request.Body.Token == "" {
+		return server.RedeemBootstrapToken401JSONResponse{Message: flterrors.ErrBootstrapTokenInvalid.Error()}, nil
+	}
+	csr, err := crypto.ParseCSR([]byte(request.Body.Csr))
+	if err != nil {
+		return server.RedeemBootstrapToken400JSONResponse{Message: fmt.Sprintf("invalid csr: %v", err)}, nil
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return server.RedeemBootstrapToken400JSONResponse{Message: fmt.Sprintf("%v: %v", flterrors.ErrSignature, err)}, nil
+	}
+
+	// the token is redeemed only once the request is known to be valid, and along with issuing
+	// the certificate, so that neither a malformed request nor failing to issue the certificate
+	// spends it
+	var certData []byte
+	err = st.BootstrapToken().Redeem(ctx, orgId, crypto.HashBootstrapToken(request.Body.Token), time.Now(), func() error {
+		csr.Subject.CommonName = crypto.BootstrapCNFromName(uuid.NewString())
+		certData, err = ca.IssueRequestedClientCertificate(csr, bootstrapEnrollmentCertExpirySeconds)
+		if err != nil {
+			return fmt.Errorf("issuing enrollment certificate for bootstrap token: %w", err)
+		}
+		return nil
+	})
+	switch {
+	case err == nil:
+		return server.RedeemBootstrapToken200JSONResponse(api.BootstrapTokenRedemptionResponse{Certificate: string(certData)}), nil
+	case errors.Is(err, flterrors.ErrBootstrapTokenInvalid),
+		errors.Is(err, flterrors.ErrBootstrapTokenRedeemed),
+		errors.Is(err, flterrors.ErrBootstrapTokenExpired):
+		log.Warnf("rejected bootstrap token redemption: %v", err)
+		return server.RedeemBootstrapToken401JSONResponse{Message: err.Error()}, nil
+	default:
+		return nil, err
+	}
+}
@@ -6,6 +6,7 @@ func NilOutManagedObjectMetaProperties(om *v1alpha1.ObjectMeta) {
 	om.Generation = nil
 	om.Owner = nil
 	om.Annotations = nil
+	om.ManagedFields = nil
 	om.CreationTimestamp = nil
 	om.DeletionTimestamp = nil
 }
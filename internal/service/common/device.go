@@ -11,6 +11,7 @@ import (
 	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/notifications"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/flightctl/flightctl/internal/util/validation"
@@ -25,7 +26,7 @@ const (
 	DeviceStatusInfoRebooting      = "The device is rebooting."
 )
 
-func ReplaceDeviceStatus(ctx context.Context, st store.Store, log logrus.FieldLogger, request server.ReplaceDeviceStatusRequestObject) (server.ReplaceDeviceStatusResponseObject, error) {
+func ReplaceDeviceStatus(ctx context.Context, st store.Store, log logrus.FieldLogger, emitter *notifications.Emitter, request server.ReplaceDeviceStatusRequestObject) (server.ReplaceDeviceStatusResponseObject, error) {
 	orgId := store.NullOrgId
 
 	device := request.Body
@@ -53,9 +54,17 @@ func ReplaceDeviceStatus(ctx context.Context, st store.Store, log logrus.FieldLo
 	device.Metadata.Annotations = oldDevice.Metadata.Annotations
 	UpdateServiceSideStatus(ctx, st, log, orgId, device)
 
+	previousStatus := api.DeviceSummaryStatusUnknown
+	if oldDevice.Status != nil {
+		previousStatus = oldDevice.Status.Summary.Status
+	}
+
 	result, err := st.Device().UpdateStatus(ctx, orgId, device)
 	switch err {
 	case nil:
+		if event := notifications.DeviceStatusEvent(previousStatus, result); event != nil {
+			emitter.Emit(ctx, *event)
+		}
 		return server.ReplaceDeviceStatus200JSONResponse(*result), nil
 	case flterrors.ErrResourceIsNil:
 		return server.ReplaceDeviceStatus400JSONResponse{Message: err.Error()}, nil
@@ -68,6 +77,54 @@ func ReplaceDeviceStatus(ctx context.Context, st store.Store, log logrus.FieldLo
 	}
 }
 
+// MaxDeviceLogsBytes is the maximum size of the log lines a device's agent ships at once.
+const MaxDeviceLogsBytes = 1024 * 1024
+
+func ReplaceDeviceLogs(ctx context.Context, st store.Store, request server.ReplaceDeviceLogsRequestObject) (server.ReplaceDeviceLogsResponseObject, error) {
+	orgId := store.NullOrgId
+
+	if errs := validateDeviceLogs(request.Body); len(errs) > 0 {
+		return server.ReplaceDeviceLogs400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+	}
+
+	if _, err := st.Device().Get(ctx, orgId, request.Name); err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.ReplaceDeviceLogs404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+
+	result, err := st.DeviceLogs().Replace(ctx, orgId, request.Name, request.Body)
+	switch err {
+	case nil:
+		return server.ReplaceDeviceLogs200JSONResponse(*result), nil
+	case flterrors.ErrResourceIsNil:
+		return server.ReplaceDeviceLogs400JSONResponse{Message: err.Error()}, nil
+	default:
+		return nil, err
+	}
+}
+
+func validateDeviceLogs(logs *api.DeviceLogs) []error {
+	if logs == nil {
+		return []error{flterrors.ErrResourceIsNil}
+	}
+	allErrs := []error{}
+	switch logs.Reason {
+	case api.DeviceLogsReasonScheduled, api.DeviceLogsReasonError:
+	default:
+		allErrs = append(allErrs, fmt.Errorf("unsupported reason %q", logs.Reason))
+	}
+	size := 0
+	for _, line := range logs.Lines {
+		size += len(line)
+	}
+	if size > MaxDeviceLogsBytes {
+		allErrs = append(allErrs, fmt.Errorf("log lines exceed %d bytes", MaxDeviceLogsBytes))
+	}
+	return allErrs
+}
+
 func validateDeviceStatus(d *api.Device) []error {
 	allErrs := []error{}
 	allErrs = append(allErrs, validation.ValidateResourceName(d.Metadata.Name)...)
@@ -7,6 +7,7 @@ import (
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/notifications"
 	"github.com/flightctl/flightctl/internal/store"
 )
 
@@ -20,7 +21,7 @@ func ValidateAndCompleteEnrollmentRequest(enrollmentRequest *v1alpha1.Enrollment
 	return nil
 }
 
-func CreateEnrollmentRequest(ctx context.Context, st store.Store, request server.CreateEnrollmentRequestRequestObject) (server.CreateEnrollmentRequestResponseObject, error) {
+func CreateEnrollmentRequest(ctx context.Context, st store.Store, emitter *notifications.Emitter, request server.CreateEnrollmentRequestRequestObject) (server.CreateEnrollmentRequestResponseObject, error) {
 	orgId := store.NullOrgId
 
 	// don't set fields that are managed by the service
@@ -38,6 +39,7 @@ func CreateEnrollmentRequest(ctx context.Context, st store.Store, request server
 	result, err := st.EnrollmentRequest().Create(ctx, orgId, request.Body)
 	switch err {
 	case nil:
+		emitter.Emit(ctx, notifications.EnrollmentRequestEvent(notifications.EnrollmentRequested, *result.Metadata.Name))
 		return server.CreateEnrollmentRequest201JSONResponse(*result), nil
 	case flterrors.ErrResourceIsNil, flterrors.ErrIllegalResourceVersionFormat:
 		return server.CreateEnrollmentRequest400JSONResponse{Message: err.Error()}, nil
@@ -0,0 +1,258 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/kvstore"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// UpdateSlotTimeout is how long a device holds an update slot it does not release, e.g. because
+// it went offline while updating.
+const UpdateSlotTimeout = time.Hour
+
+// updateSlotHolder is the value of the key of a held update slot.
+type updateSlotHolder struct {
+	Device    string    `json:"device"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// updateSlots is a semaphore per group of devices, of as many slots as devices of the group may
+// update at the same time. Each slot is a key of the KV store that expires with its holder's slot.
+// The key of the slot held by a device is recorded under the device's UpdateSlotHolderKey, for the
+// slot to be released even if the group of the device changed since it was acquired.
+type updateSlots struct {
+	kvStore kvstore.KVStore
+	timeout time.Duration
+	now     func() time.Time
+}
+
+func newUpdateSlots(kvStore kvstore.KVStore) *updateSlots {
+	return &updateSlots{
+		kvStore: kvStore,
+		timeout: UpdateSlotTimeout,
+		now:     time.Now,
+	}
+}
+
+// heldSlot is the slot held by a device, with the value of its key as it was read.
+type heldSlot struct {
+	key   string
+	value []byte
+}
+
+// acquire grants the device one of the limit slots of the group, unless they are all held by
+// other devices. Acquiring the slot the device already holds grants it again until a new expiry.
+// A slot the device holds in another group is released first.
+func (u *updateSlots) acquire(ctx context.Context, orgId uuid.UUID, group string, limit int, device string) (api.DeviceUpdateSlot, error) {
+	result := api.DeviceUpdateSlot{Group: &group, Limit: &limit}
+
+	held, err := u.held(ctx, orgId, device)
+	if err != nil {
+		return result, err
+	}
+	holder := updateSlotHolder{Device: device, ExpiresAt: u.now().Add(u.timeout).UTC()}
+	value, err := json.Marshal(holder)
+	if err != nil {
+		return result, err
+	}
+
+	if held != nil {
+		if slotOfGroup(orgId, group, limit, held.key) {
+			ok, err := u.kvStore.SetIfEqualWithExpiration(ctx, held.key, held.value, value, u.timeout)
+			if err != nil {
+				return result, err
+			}
+			if ok {
+				if err := u.refreshHolder(ctx, orgId, device, held.key); err != nil {
+					return result, err
+				}
+				result.Granted = true
+				result.ExpiresAt = &holder.ExpiresAt
+				return result, nil
+			}
+			// the slot expired since it was read, acquire a slot anew
+		}
+		if err := u.releaseHeld(ctx, orgId, device, held); err != nil {
+			return result, err
+		}
+	}
+
+	for slot := 0; slot < limit; slot++ {
+		key := kvstore.UpdateSlotKey{OrgID: orgId, Group: group, Slot: slot}
+		ok, err := u.kvStore.SetNXWithExpiration(ctx, key.ComposeKey(), value, u.timeout)
+		if err != nil {
+			return result, err
+		}
+		if !ok {
+			continue
+		}
+		holderKey := kvstore.UpdateSlotHolderKey{OrgID: orgId, Device: device}
+		recorded, err := u.kvStore.SetNXWithExpiration(ctx, holderKey.ComposeKey(), []byte(key.ComposeKey()), u.timeout)
+		if err != nil || !recorded {
+			// the slot could not be recorded as held by the device, e.g. because the device
+			// acquired another one concurrently, so it is not granted
+			_, deleteErr := u.kvStore.DeleteIfEqual(ctx, key.ComposeKey(), value)
+			return result, errors.Join(err, deleteErr)
+		}
+		result.Granted = true
+		result.ExpiresAt = &holder.ExpiresAt
+		return result, nil
+	}
+	return result, nil
+}
+
+// release frees the slot held by the device, if any, whichever group it belongs to.
+func (u *updateSlots) release(ctx context.Context, orgId uuid.UUID, device string) error {
+	held, err := u.held(ctx, orgId, device)
+	if err != nil || held == nil {
+		return err
+	}
+	return u.releaseHeld(ctx, orgId, device, held)
+}
+
+// held returns the slot held by the device, or nil if it holds none. The record of a slot that
+// expired or is held by another device is removed.
+func (u *updateSlots) held(ctx context.Context, orgId uuid.UUID, device string) (*heldSlot, error) {
+	holderKey := kvstore.UpdateSlotHolderKey{OrgID: orgId, Device: device}
+	key, err := u.kvStore.Get(ctx, holderKey.ComposeKey())
+	if err != nil || key == nil {
+		return nil, err
+	}
+	value, err := u.kvStore.Get(ctx, string(key))
+	if err != nil {
+		return nil, err
+	}
+	holder := updateSlotHolder{}
+	if value != nil {
+		if err := json.Unmarshal(value, &holder); err != nil {
+			return nil, fmt.Errorf("decoding holder of update slot %q: %w", string(key), err)
+		}
+	}
+	if holder.Device != device {
+		_, err := u.kvStore.DeleteIfEqual(ctx, holderKey.ComposeKey(), key)
+		return nil, err
+	}
+	return &heldSlot{key: string(key), value: value}, nil
+}
+
+// releaseHeld deletes the slot unless it was acquired by another device since it was read, then
+// the record of the slot held by the device.
+func (u *updateSlots) releaseHeld(ctx context.Context, orgId uuid.UUID, device string, held *heldSlot) error {
+	if _, err := u.kvStore.DeleteIfEqual(ctx, held.key, held.value); err != nil {
+		return err
+	}
+	holderKey := kvstore.UpdateSlotHolderKey{OrgID: orgId, Device: device}
+	_, err := u.kvStore.DeleteIfEqual(ctx, holderKey.ComposeKey(), []byte(held.key))
+	return err
+}
+
+// refreshHolder extends the record of the slot held by the device to the new expiry of the slot.
+func (u *updateSlots) refreshHolder(ctx context.Context, orgId uuid.UUID, device string, key string) error {
+	holderKey := kvstore.UpdateSlotHolderKey{OrgID: orgId, Device: device}
+	_, err := u.kvStore.SetIfEqualWithExpiration(ctx, holderKey.ComposeKey(), []byte(key), []byte(key), u.timeout)
+	return err
+}
+
+// slotOfGroup returns whether the key is the key of one of the limit slots of the group.
+func slotOfGroup(orgId uuid.UUID, group string, limit int, key string) bool {
+	for slot := 0; slot < limit; slot++ {
+		slotKey := kvstore.UpdateSlotKey{OrgID: orgId, Group: group, Slot: slot}
+		if slotKey.ComposeKey() == key {
+			return true
+		}
+	}
+	return false
+}
+
+// updateSlotGroup returns the group of devices the device shares update slots with, made of its
+// fleet and the values of the labels the fleet's disruption allowance groups by, and the number of
+// slots of the group. The limit is zero if the updates of the device are not limited.
+func updateSlotGroup(ctx context.Context, st store.Store, orgId uuid.UUID, device *api.Device) (string, int, error) {
+	ownerKind, fleetName, err := util.GetResourceOwner(device.Metadata.Owner)
+	if err != nil || ownerKind != api.FleetKind {
+		return "", 0, nil
+	}
+	fleet, err := st.Fleet().Get(ctx, orgId, fleetName)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return "", 0, nil
+		}
+		return "", 0, err
+	}
+	policy := fleet.Spec.RolloutPolicy
+	if policy == nil || policy.DisruptionAllowance == nil || policy.DisruptionAllowance.MaxUnavailable == nil {
+		return "", 0, nil
+	}
+
+	group := fmt.Sprintf("%s/%s", api.FleetKind, fleetName)
+	for _, key := range util.FromPtr(policy.DisruptionAllowance.GroupBy) {
+		group += fmt.Sprintf("/%s=%s", key, util.FromPtr(device.Metadata.Labels)[key])
+	}
+	return group, max(*policy.DisruptionAllowance.MaxUnavailable, 1), nil
+}
+
+// AcquireDeviceUpdateSlot grants the device an update slot of its group, if one is free. Devices
+// whose updates are not limited are always granted one.
+func AcquireDeviceUpdateSlot(ctx context.Context, st store.Store, kvStore kvstore.KVStore, log logrus.FieldLogger, request server.AcquireDeviceUpdateSlotRequestObject) (server.AcquireDeviceUpdateSlotResponseObject, error) {
+	orgId := store.NullOrgId
+
+	device, err := st.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.AcquireDeviceUpdateSlot404JSONResponse{Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	group, limit, err := updateSlotGroup(ctx, st, orgId, device)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		return server.AcquireDeviceUpdateSlot200JSONResponse{Granted: true}, nil
+	}
+
+	slot, err := newUpdateSlots(kvStore).acquire(ctx, orgId, group, limit, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !slot.Granted {
+		log.Debugf("device %q is waiting for one of the %d update slots of %s", request.Name, limit, group)
+	}
+	return server.AcquireDeviceUpdateSlot200JSONResponse(slot), nil
+}
+
+// ReleaseDeviceUpdateSlot releases the update slot held by the device, if any.
+func ReleaseDeviceUpdateSlot(ctx context.Context, st store.Store, kvStore kvstore.KVStore, request server.ReleaseDeviceUpdateSlotRequestObject) (server.ReleaseDeviceUpdateSlotResponseObject, error) {
+	orgId := store.NullOrgId
+
+	device, err := st.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.ReleaseDeviceUpdateSlot404JSONResponse{Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	// the slot is released even if the updates of the device are no longer limited
+	if err := newUpdateSlots(kvStore).release(ctx, orgId, request.Name); err != nil {
+		return nil, err
+	}
+	group, limit, err := updateSlotGroup(ctx, st, orgId, device)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		return server.ReleaseDeviceUpdateSlot200JSONResponse{}, nil
+	}
+	return server.ReleaseDeviceUpdateSlot200JSONResponse{Group: &group, Limit: &limit}, nil
+}
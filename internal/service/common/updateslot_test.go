@@ -0,0 +1,171 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/kvstore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// memKVStore is an in-memory KVStore implementing the subset used for update slots. It records
+// the expiration of the keys, without expiring them.
+type memKVStore struct {
+	kvstore.KVStore
+	mu          sync.Mutex
+	keys        map[string][]byte
+	expirations map[string]time.Duration
+}
+
+func newMemKVStore() *memKVStore {
+	return &memKVStore{keys: map[string][]byte{}, expirations: map[string]time.Duration{}}
+}
+
+func (m *memKVStore) SetNXWithExpiration(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.keys[key]; ok {
+		return false, nil
+	}
+	m.keys[key] = value
+	m.expirations[key] = expiration
+	return true, nil
+}
+
+func (m *memKVStore) SetIfEqualWithExpiration(ctx context.Context, key string, expected []byte, value []byte, expiration time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.keys[key]
+	if !ok || !bytes.Equal(current, expected) {
+		return false, nil
+	}
+	m.keys[key] = value
+	m.expirations[key] = expiration
+	return true, nil
+}
+
+func (m *memKVStore) DeleteIfEqual(ctx context.Context, key string, value []byte) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.keys[key]
+	if !ok || !bytes.Equal(current, value) {
+		return false, nil
+	}
+	delete(m.keys, key)
+	delete(m.expirations, key)
+	return true, nil
+}
+
+func (m *memKVStore) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.keys[key], nil
+}
+
+// expire deletes the key as the KV store does when its expiration passes.
+func (m *memKVStore) expire(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.keys, key)
+	delete(m.expirations, key)
+}
+
+func TestUpdateSlotsLimit(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	orgId := uuid.New()
+	slots := newUpdateSlots(newMemKVStore())
+	group := "Fleet/fleet/site=berlin"
+
+	acquire := func(device string) bool {
+		slot, err := slots.acquire(ctx, orgId, group, 2, device)
+		require.NoError(err)
+		require.Equal(group, *slot.Group)
+		require.Equal(2, *slot.Limit)
+		return slot.Granted
+	}
+
+	require.True(acquire("dev-1"))
+	require.True(acquire("dev-2"))
+	require.False(acquire("dev-3"), "all slots of the group are held")
+	require.True(acquire("dev-1"), "acquiring a held slot grants it again")
+
+	// the slots of other groups are independent
+	other, err := slots.acquire(ctx, orgId, "Fleet/fleet/site=madrid", 2, "dev-4")
+	require.NoError(err)
+	require.True(other.Granted)
+
+	// releasing a slot that is not held is a no-op
+	require.NoError(slots.release(ctx, orgId, "dev-3"))
+	require.False(acquire("dev-3"))
+
+	require.NoError(slots.release(ctx, orgId, "dev-1"))
+	require.True(acquire("dev-3"))
+	require.False(acquire("dev-1"))
+}
+
+func TestUpdateSlotsExpiry(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	orgId := uuid.New()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	kvStore := newMemKVStore()
+	slots := newUpdateSlots(kvStore)
+	slots.now = func() time.Time { return now }
+
+	slot, err := slots.acquire(ctx, orgId, "Fleet/fleet", 1, "dev-1")
+	require.NoError(err)
+	require.True(slot.Granted)
+	require.Equal(now.Add(UpdateSlotTimeout), *slot.ExpiresAt)
+
+	// acquiring the held slot again extends its expiry
+	now = now.Add(30 * time.Minute)
+	kvStore.expirations = map[string]time.Duration{}
+	slot, err = slots.acquire(ctx, orgId, "Fleet/fleet", 1, "dev-1")
+	require.NoError(err)
+	require.True(slot.Granted)
+	require.Equal(now.Add(UpdateSlotTimeout), *slot.ExpiresAt)
+	key := kvstore.UpdateSlotKey{OrgID: orgId, Group: "Fleet/fleet", Slot: 0}
+	holderKey := kvstore.UpdateSlotHolderKey{OrgID: orgId, Device: "dev-1"}
+	require.Equal(UpdateSlotTimeout, kvStore.expirations[key.ComposeKey()])
+	require.Equal(UpdateSlotTimeout, kvStore.expirations[holderKey.ComposeKey()])
+
+	// the device does not release the slot acquired by another device once its own expired
+	kvStore.expire(key.ComposeKey())
+	other, err := slots.acquire(ctx, orgId, "Fleet/fleet", 1, "dev-2")
+	require.NoError(err)
+	require.True(other.Granted)
+	require.NoError(slots.release(ctx, orgId, "dev-1"))
+	value, err := kvStore.Get(ctx, key.ComposeKey())
+	require.NoError(err)
+	require.Contains(string(value), "dev-2")
+}
+
+func TestUpdateSlotsReleaseAfterGroupChange(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	orgId := uuid.New()
+	slots := newUpdateSlots(newMemKVStore())
+
+	slot, err := slots.acquire(ctx, orgId, "Fleet/fleet/site=berlin", 1, "dev-1")
+	require.NoError(err)
+	require.True(slot.Granted)
+
+	// the slot is released although the device moved to another group since
+	require.NoError(slots.release(ctx, orgId, "dev-1"))
+	slot, err = slots.acquire(ctx, orgId, "Fleet/fleet/site=berlin", 1, "dev-2")
+	require.NoError(err)
+	require.True(slot.Granted)
+
+	// acquiring a slot in another group releases the one held in the previous group
+	slot, err = slots.acquire(ctx, orgId, "Fleet/fleet/site=madrid", 1, "dev-2")
+	require.NoError(err)
+	require.True(slot.Granted)
+	slot, err = slots.acquire(ctx, orgId, "Fleet/fleet/site=berlin", 1, "dev-3")
+	require.NoError(err)
+	require.True(slot.Granted)
+}
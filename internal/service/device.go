@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/admission"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/auth"
 	"github.com/flightctl/flightctl/internal/flterrors"
@@ -14,6 +15,7 @@ import (
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/store/selector"
+	"github.com/flightctl/flightctl/internal/util/validation"
 	"github.com/go-openapi/swag"
 )
 
@@ -43,9 +45,19 @@ func (h *ServiceHandler) CreateDevice(ctx context.Context, request server.Create
 		return server.CreateDevice400JSONResponse{Message: errors.Join(errs...).Error()}, nil
 	}
 
+	if err := h.admit(ctx, admission.OperationCreate, v1alpha1.DeviceKind, request.Body.Metadata.Name, request.Body); err != nil {
+		if admission.IsDenied(err) {
+			return server.CreateDevice400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.CreateDevice503JSONResponse{Message: err.Error()}, nil
+	}
+
 	common.UpdateServiceSideStatus(ctx, h.store, h.log, orgId, request.Body)
 
 	result, err := h.store.Device().Create(ctx, orgId, request.Body, h.callbackManager.DeviceUpdatedCallback)
+	if errors.Is(err, flterrors.ErrQuotaExceeded) {
+		return server.CreateDevice403JSONResponse{Message: err.Error()}, nil
+	}
 	switch err {
 	case nil:
 		return server.CreateDevice201JSONResponse(*result), nil
@@ -70,6 +82,7 @@ func (h *ServiceHandler) ListDevices(ctx context.Context, request server.ListDev
 	}
 
 	orgId := store.NullOrgId
+	ctx = store.WithStaleReads(ctx)
 
 	var fieldSelector *selector.FieldSelector
 	if request.Params.FieldSelector != nil {
@@ -85,8 +98,31 @@ func (h *ServiceHandler) ListDevices(ctx context.Context, request server.ListDev
 		}
 	}
 
+	var fields []fieldPath
+	if request.Params.Fields != nil {
+		if fields, err = parseFieldPaths(*request.Params.Fields, "Device"); err != nil {
+			return server.ListDevices400JSONResponse{Message: fmt.Sprintf("failed to parse fields: %v", err)}, nil
+		}
+	}
+
+	needsAttention := request.Params.NeedsAttention != nil && *request.Params.NeedsAttention
+
+	if request.Params.GroupByLabel != nil {
+		if request.Params.SummaryOnly == nil || !*request.Params.SummaryOnly {
+			return server.ListDevices400JSONResponse{Message: "the 'groupByLabel' parameter is only supported when 'summaryOnly' is true"}, nil
+		}
+		if errs := validation.ValidateLabelKey(*request.Params.GroupByLabel, "groupByLabel"); len(errs) > 0 {
+			return server.ListDevices400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		}
+	}
+
 	// Check if SummaryOnly is true
 	if request.Params.SummaryOnly != nil && *request.Params.SummaryOnly {
+		if needsAttention {
+			return server.ListDevices400JSONResponse{
+				Message: "the 'needsAttention' parameter is not supported when 'summaryOnly' is true",
+			}, nil
+		}
 		// Check for unsupported parameters
 		if request.Params.Limit != nil ||
 			request.Params.Continue != nil {
@@ -95,16 +131,25 @@ func (h *ServiceHandler) ListDevices(ctx context.Context, request server.ListDev
 			}, nil
 		}
 
-		result, err := h.store.Device().Summary(ctx, orgId, store.ListParams{
+		listParams := store.ListParams{
 			FieldSelector: fieldSelector,
 			LabelSelector: labelSelector,
-		})
+		}
+		result, err := h.store.Device().Summary(ctx, orgId, listParams)
+		if err == nil && request.Params.GroupByLabel != nil {
+			var labelValues map[string]int64
+			labelValues, err = h.store.Device().CountByLabel(ctx, orgId, *request.Params.GroupByLabel, listParams)
+			result.LabelValues = &labelValues
+		}
 
 		switch err {
 		case nil:
 			// Create an empty DeviceList and set the summary
 			emptyList := model.DeviceList.ToApiResource(nil, nil, nil)
 			emptyList.Summary = result
+			if fields != nil {
+				return projectDeviceList(&emptyList, fields)
+			}
 			return server.ListDevices200JSONResponse(emptyList), nil
 		default:
 			return nil, err
@@ -129,8 +174,16 @@ func (h *ServiceHandler) ListDevices(ctx context.Context, request server.ListDev
 		return server.ListDevices400JSONResponse{Message: fmt.Sprintf("limit cannot exceed %d", store.MaxRecordsPerListRequest)}, nil
 	}
 
-	result, err := h.store.Device().List(ctx, orgId, listParams)
+	var result *v1alpha1.DeviceList
+	if needsAttention {
+		result, err = h.store.Device().ListNeedingAttention(ctx, orgId, listParams)
+	} else {
+		result, err = h.store.Device().List(ctx, orgId, listParams)
+	}
 	if err == nil {
+		if fields != nil {
+			return projectDeviceList(result, fields)
+		}
 		return server.ListDevices200JSONResponse(*result), nil
 	}
 
@@ -158,10 +211,26 @@ func (h *ServiceHandler) DeleteDevices(ctx context.Context, request server.Delet
 	}
 	orgId := store.NullOrgId
 
-	err = h.store.Device().DeleteAll(ctx, orgId, h.callbackManager.AllDevicesDeletedCallback)
-	switch err {
-	case nil:
-		return server.DeleteDevices200JSONResponse{}, nil
+	if request.Params.LabelSelector == nil {
+		deleted, err := h.store.Device().DeleteAll(ctx, orgId, h.callbackManager.AllDevicesDeletedCallback)
+		if err != nil {
+			return nil, err
+		}
+		return server.DeleteDevices200JSONResponse{Deleted: deleted, Failures: &[]v1alpha1.DeleteCollectionFailure{}}, nil
+	}
+
+	labelSelector, err := selector.NewLabelSelector(*request.Params.LabelSelector)
+	if err != nil {
+		return server.DeleteDevices400JSONResponse{Message: fmt.Sprintf("failed to parse label selector: %v", err)}, nil
+	}
+
+	deleted, failures, err := h.store.Device().DeleteMatching(ctx, orgId, store.ListParams{LabelSelector: labelSelector}, h.callbackManager.DeviceUpdatedCallback)
+	var se *selector.SelectorError
+	switch {
+	case err == nil:
+		return server.DeleteDevices200JSONResponse{Deleted: deleted, Failures: &failures}, nil
+	case selector.AsSelectorError(err, &se):
+		return server.DeleteDevices400JSONResponse{Message: se.Error()}, nil
 	default:
 		return nil, err
 	}
@@ -179,9 +248,23 @@ func (h *ServiceHandler) ReadDevice(ctx context.Context, request server.ReadDevi
 	}
 	orgId := store.NullOrgId
 
+	var fields []fieldPath
+	if request.Params.Fields != nil {
+		if fields, err = parseFieldPaths(*request.Params.Fields, "Device"); err != nil {
+			return server.ReadDevice400JSONResponse{Message: fmt.Sprintf("failed to parse fields: %v", err)}, nil
+		}
+	}
+
 	result, err := h.store.Device().Get(ctx, orgId, request.Name)
 	switch err {
 	case nil:
+		if fields != nil {
+			projected, err := projectFields(result, fields)
+			if err != nil {
+				return nil, err
+			}
+			return readDeviceProjectedResponse(projected), nil
+		}
 		return server.ReadDevice200JSONResponse(*result), nil
 	case flterrors.ErrResourceNotFound:
 		return server.ReadDevice404JSONResponse{}, nil
@@ -190,6 +273,53 @@ func (h *ServiceHandler) ReadDevice(ctx context.Context, request server.ReadDevi
 	}
 }
 
+// (POST /api/v1/devices/batchget)
+func (h *ServiceHandler) BatchGetDevices(ctx context.Context, request server.BatchGetDevicesRequestObject) (server.BatchGetDevicesResponseObject, error) {
+	// permissions are granted per resource type, so every requested device needs the same one
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "devices", "get")
+	if err != nil {
+		h.log.WithError(err).Error("failed to check authorization permission")
+		return server.BatchGetDevices503JSONResponse{Message: AuthorizationServerUnavailable}, nil
+	}
+	if !allowed {
+		return server.BatchGetDevices403JSONResponse{Message: Forbidden}, nil
+	}
+	orgId := store.NullOrgId
+
+	names := []string{}
+	requested := map[string]bool{}
+	for _, name := range request.Body.Names {
+		if !requested[name] {
+			requested[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return server.BatchGetDevices400JSONResponse{Message: "names must not be empty"}, nil
+	}
+	if len(names) > store.MaxRecordsPerListRequest {
+		return server.BatchGetDevices400JSONResponse{Message: fmt.Sprintf("names cannot exceed %d", store.MaxRecordsPerListRequest)}, nil
+	}
+
+	devices, err := h.store.Device().GetMany(ctx, orgId, names)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]v1alpha1.Device, len(devices))
+	for _, device := range devices {
+		byName[*device.Metadata.Name] = device
+	}
+	result := v1alpha1.DeviceBatchGetResult{Items: []v1alpha1.Device{}, NotFound: []string{}}
+	for _, name := range names {
+		if device, ok := byName[name]; ok {
+			result.Items = append(result.Items, device)
+		} else {
+			result.NotFound = append(result.NotFound, name)
+		}
+	}
+	return server.BatchGetDevices200JSONResponse(result), nil
+}
+
 // (PUT /api/v1/devices/{name})
 func (h *ServiceHandler) ReplaceDevice(ctx context.Context, request server.ReplaceDeviceRequestObject) (server.ReplaceDeviceResponseObject, error) {
 	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "devices", "update")
@@ -212,6 +342,22 @@ func (h *ServiceHandler) ReplaceDevice(ctx context.Context, request server.Repla
 	request.Body.Status = nil
 	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
 
+	if request.Params.FieldManager != nil {
+		existing, err := h.store.Device().Get(ctx, orgId, request.Name)
+		if err != nil && !errors.Is(err, flterrors.ErrResourceNotFound) {
+			return nil, err
+		}
+		applied, err := serverSideApply(existing, request.Body, *request.Params.FieldManager, swag.BoolValue(request.Params.Force))
+		var conflictErr *ApplyConflictError
+		if errors.As(err, &conflictErr) {
+			return server.ReplaceDevice409JSONResponse{Message: err.Error()}, nil
+		}
+		if err != nil {
+			return server.ReplaceDevice400JSONResponse{Message: err.Error()}, nil
+		}
+		request.Body = applied
+	}
+
 	if errs := request.Body.Validate(); len(errs) > 0 {
 		return server.ReplaceDevice400JSONResponse{Message: errors.Join(errs...).Error()}, nil
 	}
@@ -219,9 +365,19 @@ func (h *ServiceHandler) ReplaceDevice(ctx context.Context, request server.Repla
 		return server.ReplaceDevice400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
 	}
 
+	if err := h.admit(ctx, admission.OperationUpdate, v1alpha1.DeviceKind, request.Body.Metadata.Name, request.Body); err != nil {
+		if admission.IsDenied(err) {
+			return server.ReplaceDevice400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.ReplaceDevice503JSONResponse{Message: err.Error()}, nil
+	}
+
 	common.UpdateServiceSideStatus(ctx, h.store, h.log, orgId, request.Body)
 
 	result, created, err := h.store.Device().CreateOrUpdate(ctx, orgId, request.Body, nil, true, h.callbackManager.DeviceUpdatedCallback)
+	if errors.Is(err, flterrors.ErrQuotaExceeded) {
+		return server.ReplaceDevice403JSONResponse{Message: err.Error()}, nil
+	}
 	switch err {
 	case nil:
 		if created {
@@ -298,7 +454,7 @@ func (h *ServiceHandler) ReplaceDeviceStatus(ctx context.Context, request server
 	if !allowed {
 		return server.ReplaceDeviceStatus403JSONResponse{Message: Forbidden}, nil
 	}
-	return common.ReplaceDeviceStatus(ctx, h.store, h.log, request)
+	return common.ReplaceDeviceStatus(ctx, h.store, h.log, h.emitter, request)
 }
 
 // (GET /api/v1/devices/{name}/rendered)
@@ -340,7 +496,7 @@ func (h *ServiceHandler) PatchDevice(ctx context.Context, request server.PatchDe
 	}
 
 	newObj := &v1alpha1.Device{}
-	err = ApplyJSONPatch(ctx, currentObj, newObj, *request.Body, "/api/v1/devices/"+request.Name)
+	err = ApplyPatch(ctx, currentObj, newObj, request.ApplicationJSONPatchPlusJSONBody, request.ApplicationMergePatchPlusJSONBody, "/api/v1/devices/"+request.Name)
 	if err != nil {
 		return server.PatchDevice400JSONResponse{Message: err.Error()}, nil
 	}
@@ -364,15 +520,23 @@ func (h *ServiceHandler) PatchDevice(ctx context.Context, request server.PatchDe
 		return server.PatchDevice400JSONResponse{Message: "spec.decommissioning cannot be changed via patch request"}, nil
 	}
 
+	// the resourceVersion is kept, so that the update is conditional on the version the patch was
+	// applied to, or on the one the patch sets, and concurrent changes are not overwritten
 	common.NilOutManagedObjectMetaProperties(&newObj.Metadata)
-	newObj.Metadata.ResourceVersion = nil
 
-	var updateCallback func(before *model.Device, after *model.Device)
+	var updateCallback func(ctx context.Context, before *model.Device, after *model.Device) error
 
 	if h.callbackManager != nil {
 		updateCallback = h.callbackManager.DeviceUpdatedCallback
 	}
 
+	if err := h.admit(ctx, admission.OperationUpdate, v1alpha1.DeviceKind, newObj.Metadata.Name, newObj); err != nil {
+		if admission.IsDenied(err) {
+			return server.PatchDevice400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.PatchDevice503JSONResponse{Message: err.Error()}, nil
+	}
+
 	common.UpdateServiceSideStatus(ctx, h.store, h.log, orgId, newObj)
 
 	// create
@@ -425,7 +589,7 @@ func (h *ServiceHandler) DecommissionDevice(ctx context.Context, request server.
 	}
 	deviceObj.Spec.Decommissioning = request.Body
 
-	var updateCallback func(before *model.Device, after *model.Device)
+	var updateCallback func(ctx context.Context, before *model.Device, after *model.Device) error
 
 	if h.callbackManager != nil {
 		updateCallback = h.callbackManager.DeviceUpdatedCallback
@@ -445,3 +609,109 @@ func (h *ServiceHandler) DecommissionDevice(ctx context.Context, request server.
 		return nil, err
 	}
 }
+
+// (GET /api/v1/devices/{name}/logs)
+func (h *ServiceHandler) ReadDeviceLogs(ctx context.Context, request server.ReadDeviceLogsRequestObject) (server.ReadDeviceLogsResponseObject, error) {
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "devices/logs", "get")
+	if err != nil {
+		h.log.WithError(err).Error("failed to check authorization permission")
+		return server.ReadDeviceLogs503JSONResponse{Message: AuthorizationServerUnavailable}, nil
+	}
+	if !allowed {
+		return server.ReadDeviceLogs403JSONResponse{Message: Forbidden}, nil
+	}
+	orgId := store.NullOrgId
+
+	if _, err := h.store.Device().Get(ctx, orgId, request.Name); err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.ReadDeviceLogs404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+
+	result, err := h.store.DeviceLogs().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.ReadDeviceLogs200JSONResponse(*result), nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReadDeviceLogs404JSONResponse{Message: "the device has not shipped any logs"}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (PUT /api/v1/devices/{name}/logs)
+func (h *ServiceHandler) ReplaceDeviceLogs(ctx context.Context, request server.ReplaceDeviceLogsRequestObject) (server.ReplaceDeviceLogsResponseObject, error) {
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "devices/logs", "update")
+	if err != nil {
+		h.log.WithError(err).Error("failed to check authorization permission")
+		return server.ReplaceDeviceLogs503JSONResponse{Message: AuthorizationServerUnavailable}, nil
+	}
+	if !allowed {
+		return server.ReplaceDeviceLogs403JSONResponse{Message: Forbidden}, nil
+	}
+
+	return common.ReplaceDeviceLogs(ctx, h.store, request)
+}
+
+// (PUT /api/v1/devices/{name}/updateslot)
+func (h *ServiceHandler) AcquireDeviceUpdateSlot(ctx context.Context, request server.AcquireDeviceUpdateSlotRequestObject) (server.AcquireDeviceUpdateSlotResponseObject, error) {
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "devices/updateslot", "update")
+	if err != nil {
+		h.log.WithError(err).Error("failed to check authorization permission")
+		return server.AcquireDeviceUpdateSlot503JSONResponse{Message: AuthorizationServerUnavailable}, nil
+	}
+	if !allowed {
+		return server.AcquireDeviceUpdateSlot403JSONResponse{Message: Forbidden}, nil
+	}
+
+	return common.AcquireDeviceUpdateSlot(ctx, h.store, h.kvStore, h.log, request)
+}
+
+// (DELETE /api/v1/devices/{name}/updateslot)
+func (h *ServiceHandler) ReleaseDeviceUpdateSlot(ctx context.Context, request server.ReleaseDeviceUpdateSlotRequestObject) (server.ReleaseDeviceUpdateSlotResponseObject, error) {
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "devices/updateslot", "delete")
+	if err != nil {
+		h.log.WithError(err).Error("failed to check authorization permission")
+		return server.ReleaseDeviceUpdateSlot503JSONResponse{Message: AuthorizationServerUnavailable}, nil
+	}
+	if !allowed {
+		return server.ReleaseDeviceUpdateSlot403JSONResponse{Message: Forbidden}, nil
+	}
+
+	return common.ReleaseDeviceUpdateSlot(ctx, h.store, h.kvStore, request)
+}
+
+// (GET /api/v1/devices/{name}/revisions)
+func (h *ServiceHandler) ListDeviceRevisions(ctx context.Context, request server.ListDeviceRevisionsRequestObject) (server.ListDeviceRevisionsResponseObject, error) {
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "devices/revisions", "list")
+	if err != nil {
+		h.log.WithError(err).Error("failed to check authorization permission")
+		return server.ListDeviceRevisions503JSONResponse{Message: AuthorizationServerUnavailable}, nil
+	}
+	if !allowed {
+		return server.ListDeviceRevisions403JSONResponse{Message: Forbidden}, nil
+	}
+	orgId := store.NullOrgId
+
+	limit := 0
+	if request.Params.Limit != nil {
+		if *request.Params.Limit < 0 {
+			return server.ListDeviceRevisions400JSONResponse{Message: "limit must not be negative"}, nil
+		}
+		limit = int(*request.Params.Limit)
+	}
+
+	if _, err := h.store.Device().Get(ctx, orgId, request.Name); err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.ListDeviceRevisions404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+
+	result, err := h.store.ResourceRevision().GetHistory(ctx, orgId, v1alpha1.DeviceKind, request.Name, limit)
+	if err != nil {
+		return nil, err
+	}
+	return server.ListDeviceRevisions200JSONResponse(*result), nil
+}
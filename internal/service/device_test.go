@@ -54,6 +54,9 @@ func (s *DummyDevice) Get(ctx context.Context, orgId uuid.UUID, name string) (*v
 }
 
 func (s *DummyDevice) Update(ctx context.Context, orgId uuid.UUID, device *v1alpha1.Device, fieldsToUnset []string, fromAPI bool, callback store.DeviceStoreCallback) (*v1alpha1.Device, error) {
+	if device.Metadata.ResourceVersion != nil && s.DeviceVal.Metadata.ResourceVersion != nil && *device.Metadata.ResourceVersion != *s.DeviceVal.Metadata.ResourceVersion {
+		return nil, flterrors.ErrResourceVersionConflict
+	}
 	return device, nil
 }
 
@@ -79,15 +82,30 @@ func verifyDevicePatchFailed(require *require.Assertions, resp server.PatchDevic
 }
 
 func testDevicePatch(require *require.Assertions, patch v1alpha1.PatchRequest) (server.PatchDeviceResponseObject, v1alpha1.Device) {
+	return testDevicePatchRequest(require, server.PatchDeviceRequestObject{
+		Name:                             "foo",
+		ApplicationJSONPatchPlusJSONBody: &patch,
+	})
+}
+
+func testDeviceMergePatch(require *require.Assertions, patch v1alpha1.MergePatchRequest) (server.PatchDeviceResponseObject, v1alpha1.Device) {
+	return testDevicePatchRequest(require, server.PatchDeviceRequestObject{
+		Name:                              "foo",
+		ApplicationMergePatchPlusJSONBody: &patch,
+	})
+}
+
+func testDevicePatchRequest(require *require.Assertions, request server.PatchDeviceRequestObject) (server.PatchDeviceResponseObject, v1alpha1.Device) {
 	_ = os.Setenv(auth.DisableAuthEnvKey, "true")
-	_, _ = auth.CreateAuthMiddleware(nil, log.InitLogs())
+	_, _ = auth.CreateAuthMiddleware(context.Background(), nil, log.InitLogs())
 	status := v1alpha1.NewDeviceStatus()
 	device := v1alpha1.Device{
 		ApiVersion: "v1",
 		Kind:       "Device",
 		Metadata: v1alpha1.ObjectMeta{
-			Name:   util.StrToPtr("foo"),
-			Labels: &map[string]string{"labelKey": "labelValue"},
+			Name:            util.StrToPtr("foo"),
+			Labels:          &map[string]string{"labelKey": "labelValue"},
+			ResourceVersion: util.StrToPtr("1"),
 		},
 		Spec: &v1alpha1.DeviceSpec{
 			Os: &v1alpha1.DeviceOsSpec{Image: "img"},
@@ -98,10 +116,7 @@ func testDevicePatch(require *require.Assertions, patch v1alpha1.PatchRequest) (
 		store:           &DeviceStore{DeviceVal: device},
 		callbackManager: dummyCallbackManager(),
 	}
-	resp, err := serviceHandler.PatchDevice(context.Background(), server.PatchDeviceRequestObject{
-		Name: "foo",
-		Body: &patch,
-	})
+	resp, err := serviceHandler.PatchDevice(context.Background(), request)
 	require.NoError(err)
 	return resp, device
 }
@@ -246,9 +261,160 @@ func TestDeviceNonExistingResource(t *testing.T) {
 		}},
 	}
 	resp, err := serviceHandler.PatchDevice(context.Background(), server.PatchDeviceRequestObject{
-		Name: "bar",
-		Body: &pr,
+		Name:                             "bar",
+		ApplicationJSONPatchPlusJSONBody: &pr,
 	})
 	require.NoError(err)
 	require.Equal(server.PatchDevice404JSONResponse{}, resp)
 }
+
+func TestDeviceMergePatch(t *testing.T) {
+	require := require.New(t)
+	patch := v1alpha1.MergePatchRequest{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"labelKey": nil, "region": "eu"},
+		},
+		"spec": map[string]interface{}{
+			"os": map[string]interface{}{"image": "newimg"},
+		},
+	}
+	resp, device := testDeviceMergePatch(require, patch)
+	device.Metadata.Labels = &map[string]string{"region": "eu"}
+	device.Spec.Os.Image = "newimg"
+	verifyDevicePatchSucceeded(require, &device, resp)
+
+	// fields set to null are removed
+	resp, device = testDeviceMergePatch(require, v1alpha1.MergePatchRequest{
+		"spec": map[string]interface{}{"os": nil},
+	})
+	device.Spec.Os = nil
+	verifyDevicePatchSucceeded(require, &device, resp)
+
+	// the result is validated against the schema
+	resp, _ = testDeviceMergePatch(require, v1alpha1.MergePatchRequest{
+		"spec": map[string]interface{}{"os": "foo"},
+	})
+	verifyDevicePatchFailed(require, resp)
+
+	resp, _ = testDeviceMergePatch(require, v1alpha1.MergePatchRequest{
+		"status": map[string]interface{}{"updatedAt": "1234"},
+	})
+	verifyDevicePatchFailed(require, resp)
+
+	resp, _ = testDeviceMergePatch(require, v1alpha1.MergePatchRequest{
+		"spec": map[string]interface{}{"doesnotexist": "foo"},
+	})
+	verifyDevicePatchFailed(require, resp)
+}
+
+func TestDevicePatchConditional(t *testing.T) {
+	require := require.New(t)
+	var currentVersion interface{} = "1"
+	var staleVersion interface{} = "0"
+	var value interface{} = "newimg"
+
+	// a test operation guards the patch against the current state of the device
+	resp, device := testDevicePatch(require, v1alpha1.PatchRequest{
+		{Op: "test", Path: "/metadata/resourceVersion", Value: &currentVersion},
+		{Op: "replace", Path: "/spec/os/image", Value: &value},
+	})
+	device.Spec.Os.Image = "newimg"
+	verifyDevicePatchSucceeded(require, &device, resp)
+
+	resp, _ = testDevicePatch(require, v1alpha1.PatchRequest{
+		{Op: "test", Path: "/metadata/resourceVersion", Value: &staleVersion},
+		{Op: "replace", Path: "/spec/os/image", Value: &value},
+	})
+	verifyDevicePatchFailed(require, resp)
+
+	// a merge patch setting the resourceVersion is only applied to that version
+	resp, device = testDeviceMergePatch(require, v1alpha1.MergePatchRequest{
+		"metadata": map[string]interface{}{"resourceVersion": "1"},
+		"spec":     map[string]interface{}{"os": map[string]interface{}{"image": "newimg"}},
+	})
+	device.Spec.Os.Image = "newimg"
+	verifyDevicePatchSucceeded(require, &device, resp)
+
+	resp, _ = testDeviceMergePatch(require, v1alpha1.MergePatchRequest{
+		"metadata": map[string]interface{}{"resourceVersion": "0"},
+		"spec":     map[string]interface{}{"os": map[string]interface{}{"image": "newimg"}},
+	})
+	require.Equal(server.PatchDevice409JSONResponse{}, resp)
+}
+
+func TestDevicePatchNoBody(t *testing.T) {
+	require := require.New(t)
+	resp, _ := testDevicePatchRequest(require, server.PatchDeviceRequestObject{Name: "foo"})
+	verifyDevicePatchFailed(require, resp)
+}
+
+func (s *DummyDevice) Summary(ctx context.Context, orgId uuid.UUID, listParams store.ListParams) (*v1alpha1.DevicesSummary, error) {
+	return &v1alpha1.DevicesSummary{Total: 3}, nil
+}
+
+func (s *DummyDevice) CountByLabel(ctx context.Context, orgId uuid.UUID, key string, listParams store.ListParams) (map[string]int64, error) {
+	return map[string]int64{"eu": 2, "us": 1}, nil
+}
+
+func TestListDevicesGroupByLabel(t *testing.T) {
+	require := require.New(t)
+	_ = os.Setenv(auth.DisableAuthEnvKey, "true")
+	_, _ = auth.CreateAuthMiddleware(context.Background(), nil, log.InitLogs())
+	serviceHandler := ServiceHandler{store: &DeviceStore{}}
+
+	resp, err := serviceHandler.ListDevices(context.Background(), server.ListDevicesRequestObject{Params: v1alpha1.ListDevicesParams{
+		SummaryOnly:  util.BoolToPtr(true),
+		GroupByLabel: util.StrToPtr("region"),
+	}})
+	require.NoError(err)
+	list, ok := resp.(server.ListDevices200JSONResponse)
+	require.True(ok)
+	require.Equal(int64(3), list.Summary.Total)
+	require.Equal(map[string]int64{"eu": 2, "us": 1}, *list.Summary.LabelValues)
+
+	resp, err = serviceHandler.ListDevices(context.Background(), server.ListDevicesRequestObject{Params: v1alpha1.ListDevicesParams{
+		GroupByLabel: util.StrToPtr("region"),
+	}})
+	require.NoError(err)
+	require.IsType(server.ListDevices400JSONResponse{}, resp)
+
+	resp, err = serviceHandler.ListDevices(context.Background(), server.ListDevicesRequestObject{Params: v1alpha1.ListDevicesParams{
+		SummaryOnly:  util.BoolToPtr(true),
+		GroupByLabel: util.StrToPtr("not a label key"),
+	}})
+	require.NoError(err)
+	require.IsType(server.ListDevices400JSONResponse{}, resp)
+}
+
+func (s *DummyDevice) GetMany(ctx context.Context, orgId uuid.UUID, names []string) ([]v1alpha1.Device, error) {
+	devices := []v1alpha1.Device{}
+	for _, name := range names {
+		if name == *s.DeviceVal.Metadata.Name {
+			devices = append(devices, s.DeviceVal)
+		}
+	}
+	return devices, nil
+}
+
+func TestBatchGetDevices(t *testing.T) {
+	require := require.New(t)
+	_ = os.Setenv(auth.DisableAuthEnvKey, "true")
+	_, _ = auth.CreateAuthMiddleware(context.Background(), nil, log.InitLogs())
+	device := v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{Name: util.StrToPtr("foo")}}
+	serviceHandler := ServiceHandler{store: &DeviceStore{DeviceVal: device}}
+
+	resp, err := serviceHandler.BatchGetDevices(context.Background(), server.BatchGetDevicesRequestObject{
+		Body: &v1alpha1.BatchGetRequest{Names: []string{"bar", "foo", "baz", "foo"}},
+	})
+	require.NoError(err)
+	result, ok := resp.(server.BatchGetDevices200JSONResponse)
+	require.True(ok)
+	require.Equal([]v1alpha1.Device{device}, result.Items)
+	require.Equal([]string{"bar", "baz"}, result.NotFound)
+
+	resp, err = serviceHandler.BatchGetDevices(context.Background(), server.BatchGetDevicesRequestObject{
+		Body: &v1alpha1.BatchGetRequest{Names: []string{}},
+	})
+	require.NoError(err)
+	require.IsType(server.BatchGetDevices400JSONResponse{}, resp)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
@@ -12,6 +13,7 @@ import (
 	"github.com/flightctl/flightctl/internal/auth"
 	"github.com/flightctl/flightctl/internal/crypto"
 	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/notifications"
 	"github.com/flightctl/flightctl/internal/service/common"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/store/selector"
@@ -105,7 +107,7 @@ func (h *ServiceHandler) CreateEnrollmentRequest(ctx context.Context, request se
 	if !allowed {
 		return server.CreateEnrollmentRequest403JSONResponse{Message: Forbidden}, nil
 	}
-	return common.CreateEnrollmentRequest(ctx, h.store, request)
+	return common.CreateEnrollmentRequest(ctx, h.store, h.emitter, request)
 }
 
 // (GET /api/v1/enrollmentrequests)
@@ -119,6 +121,7 @@ func (h *ServiceHandler) ListEnrollmentRequests(ctx context.Context, request ser
 		return server.ListEnrollmentRequests403JSONResponse{Message: Forbidden}, nil
 	}
 	orgId := store.NullOrgId
+	ctx = store.WithStaleReads(ctx)
 
 	cont, err := store.ParseContinueString(request.Params.Continue)
 	if err != nil {
@@ -132,6 +135,16 @@ func (h *ServiceHandler) ListEnrollmentRequests(ctx context.Context, request ser
 		}
 	}
 
+	filterSelector, err := enrollmentRequestFilterSelector(request.Params)
+	if err != nil {
+		return server.ListEnrollmentRequests400JSONResponse{Message: err.Error()}, nil
+	}
+	if fieldSelector == nil {
+		fieldSelector = filterSelector
+	} else {
+		fieldSelector.Add(filterSelector)
+	}
+
 	var labelSelector *selector.LabelSelector
 	if request.Params.LabelSelector != nil {
 		if labelSelector, err = selector.NewLabelSelector(*request.Params.LabelSelector); err != nil {
@@ -167,6 +180,38 @@ func (h *ServiceHandler) ListEnrollmentRequests(ctx context.Context, request ser
 	}
 }
 
+// enrollmentRequestFilterSelector translates the status and creation time filters of the list
+// request into a field selector, or returns nil if no filter is set.
+func enrollmentRequestFilterSelector(params v1alpha1.ListEnrollmentRequestsParams) (*selector.FieldSelector, error) {
+	var requirements []string
+	if params.Status != nil {
+		switch *params.Status {
+		case v1alpha1.EnrollmentRequestStatusPending:
+			requirements = append(requirements, "!status.approval.approved")
+		case v1alpha1.EnrollmentRequestStatusApproved:
+			requirements = append(requirements, "status.approval.approved=true")
+		case v1alpha1.EnrollmentRequestStatusDenied:
+			requirements = append(requirements, "status.approval.approved=false")
+		default:
+			return nil, fmt.Errorf("invalid status %q: must be one of %s, %s, %s", *params.Status,
+				v1alpha1.EnrollmentRequestStatusPending, v1alpha1.EnrollmentRequestStatusApproved, v1alpha1.EnrollmentRequestStatusDenied)
+		}
+	}
+	if params.CreatedAfter != nil {
+		requirements = append(requirements, "metadata.creationTimestamp>="+params.CreatedAfter.UTC().Format(time.RFC3339Nano))
+	}
+	if params.CreatedBefore != nil {
+		requirements = append(requirements, "metadata.creationTimestamp<"+params.CreatedBefore.UTC().Format(time.RFC3339Nano))
+	}
+	if params.CreatedAfter != nil && params.CreatedBefore != nil && !params.CreatedAfter.Before(*params.CreatedBefore) {
+		return nil, fmt.Errorf("createdAfter must be before createdBefore")
+	}
+	if len(requirements) == 0 {
+		return nil, nil
+	}
+	return selector.NewFieldSelector(strings.Join(requirements, ","))
+}
+
 // (DELETE /api/v1/enrollmentrequests)
 func (h *ServiceHandler) DeleteEnrollmentRequests(ctx context.Context, request server.DeleteEnrollmentRequestsRequestObject) (server.DeleteEnrollmentRequestsResponseObject, error) {
 	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "enrollmentrequests", "deletecollection")
@@ -269,7 +314,7 @@ func (h *ServiceHandler) PatchEnrollmentRequest(ctx context.Context, request ser
 	}
 
 	newObj := &v1alpha1.EnrollmentRequest{}
-	err = ApplyJSONPatch(ctx, currentObj, newObj, *request.Body, "/api/v1/enrollmentrequests/"+request.Name)
+	err = ApplyPatch(ctx, currentObj, newObj, request.ApplicationJSONPatchPlusJSONBody, request.ApplicationMergePatchPlusJSONBody, "/api/v1/enrollmentrequests/"+request.Name)
 	if err != nil {
 		return server.PatchEnrollmentRequest400JSONResponse{Message: err.Error()}, nil
 	}
@@ -290,8 +335,9 @@ func (h *ServiceHandler) PatchEnrollmentRequest(ctx context.Context, request ser
 		return server.PatchEnrollmentRequest400JSONResponse{Message: "status is immutable"}, nil
 	}
 
+	// the resourceVersion is kept, so that the update is conditional on the version the patch was
+	// applied to, or on the one the patch sets, and concurrent changes are not overwritten
 	common.NilOutManagedObjectMetaProperties(&newObj.Metadata)
-	newObj.Metadata.ResourceVersion = nil
 
 	result, err := h.store.EnrollmentRequest().Update(ctx, orgId, newObj)
 	switch err {
@@ -400,12 +446,18 @@ func (h *ServiceHandler) ApproveEnrollmentRequest(ctx context.Context, request s
 
 		// in case of error we return 500 as it will be caused by creating device in db and not by problem with enrollment request
 		if err := h.createDeviceFromEnrollmentRequest(ctx, orgId, enrollmentReq); err != nil {
+			if errors.Is(err, flterrors.ErrQuotaExceeded) {
+				return server.ApproveEnrollmentRequest403JSONResponse{Message: err.Error()}, nil
+			}
 			return nil, fmt.Errorf("Error creating device from enrollment request: %v", err.Error())
 		}
 	}
 	_, err = h.store.EnrollmentRequest().UpdateStatus(ctx, orgId, enrollmentReq)
 	switch err {
 	case nil:
+		if request.Body.Approved {
+			h.emitter.Emit(ctx, notifications.EnrollmentRequestEvent(notifications.EnrollmentApproved, request.Name))
+		}
 		return server.ApproveEnrollmentRequest200JSONResponse{}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.ApproveEnrollmentRequest404JSONResponse{}, nil
@@ -3,11 +3,13 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -77,3 +79,81 @@ func TestAlreadyApprovedEnrollmentRequestApprove(t *testing.T) {
 	require.True(ok)
 	require.Equal("Enrollment request is already approved", r.Message)
 }
+
+func TestEnrollmentRequestFilterSelector(t *testing.T) {
+	createdAfter := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	createdBefore := createdAfter.Add(24 * time.Hour)
+	status := func(s v1alpha1.ListEnrollmentRequestsParamsStatus) *v1alpha1.ListEnrollmentRequestsParamsStatus {
+		return &s
+	}
+
+	tests := []struct {
+		name      string
+		params    v1alpha1.ListEnrollmentRequestsParams
+		wantQuery string
+		wantArgs  []any
+		wantErr   bool
+	}{
+		{
+			name:   "no filter",
+			params: v1alpha1.ListEnrollmentRequestsParams{},
+		},
+		{
+			name:      "pending",
+			params:    v1alpha1.ListEnrollmentRequestsParams{Status: status(v1alpha1.EnrollmentRequestStatusPending)},
+			wantQuery: "status -> 'approval' ->> 'approved' IS NULL",
+		},
+		{
+			name:      "denied",
+			params:    v1alpha1.ListEnrollmentRequestsParams{Status: status(v1alpha1.EnrollmentRequestStatusDenied)},
+			wantQuery: "CAST(status -> 'approval' ->> 'approved' AS boolean) = ?",
+			wantArgs:  []any{false},
+		},
+		{
+			name: "pending in creation time range",
+			params: v1alpha1.ListEnrollmentRequestsParams{
+				Status:        status(v1alpha1.EnrollmentRequestStatusPending),
+				CreatedAfter:  &createdAfter,
+				CreatedBefore: &createdBefore,
+			},
+			wantQuery: "(created_at >= ? AND created_at < ? AND status -> 'approval' ->> 'approved' IS NULL)",
+			wantArgs:  []any{createdAfter, createdBefore},
+		},
+		{
+			name:    "invalid status",
+			params:  v1alpha1.ListEnrollmentRequestsParams{Status: status("Unknown")},
+			wantErr: true,
+		},
+		{
+			name:    "empty time range",
+			params:  v1alpha1.ListEnrollmentRequestsParams{CreatedAfter: &createdBefore, CreatedBefore: &createdAfter},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			fieldSelector, err := enrollmentRequestFilterSelector(tt.params)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			if tt.wantQuery == "" {
+				require.Nil(fieldSelector)
+				return
+			}
+			query, args, err := fieldSelector.Parse(context.Background(), &model.EnrollmentRequest{})
+			require.NoError(err)
+			require.Equal(tt.wantQuery, query)
+			require.Len(args, len(tt.wantArgs))
+			for i := range tt.wantArgs {
+				if want, ok := tt.wantArgs[i].(time.Time); ok {
+					require.True(want.Equal(args[i].(time.Time)))
+					continue
+				}
+				require.Equal(tt.wantArgs[i], args[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var ErrorUnknownField = errors.New("unknown field")
+
+// fieldPath is a parsed field selection path, e.g. "status.summary" becomes ["status", "summary"].
+type fieldPath []string
+
+// parseFieldPaths parses a comma-separated list of dot-separated field paths and
+// validates each of them against the named schema of the OpenAPI spec.
+func parseFieldPaths(fields string, schemaName string) ([]fieldPath, error) {
+	swagger, err := v1alpha1.GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	schemaRef, ok := swagger.Components.Schemas[schemaName]
+	if !ok || schemaRef.Value == nil {
+		return nil, fmt.Errorf("schema %q not found", schemaName)
+	}
+
+	paths := []fieldPath{}
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		path := fieldPath(strings.Split(field, "."))
+		schema := schemaRef.Value
+		for _, name := range path {
+			if schema = lookupSchemaField(schema, name); schema == nil {
+				return nil, fmt.Errorf("%w: %s", ErrorUnknownField, field)
+			}
+		}
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: no fields specified", ErrorUnknownField)
+	}
+	return paths, nil
+}
+
+// lookupSchemaField returns the schema of the named field of an object schema, descending
+// into array items and composed schemas. It returns nil if the field does not exist.
+func lookupSchemaField(schema *openapi3.Schema, name string) *openapi3.Schema {
+	if schema.Items != nil && schema.Items.Value != nil {
+		return lookupSchemaField(schema.Items.Value, name)
+	}
+	if prop, ok := schema.Properties[name]; ok && prop.Value != nil {
+		return prop.Value
+	}
+	for _, refs := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, ref := range refs {
+			if ref.Value == nil {
+				continue
+			}
+			if prop := lookupSchemaField(ref.Value, name); prop != nil {
+				return prop
+			}
+		}
+	}
+	if schema.AdditionalProperties.Schema != nil && schema.AdditionalProperties.Schema.Value != nil {
+		return schema.AdditionalProperties.Schema.Value
+	}
+	if schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has {
+		return &openapi3.Schema{}
+	}
+	return nil
+}
+
+// projectFields returns a JSON object containing only the given field paths of obj.
+func projectFields(obj any, paths []fieldPath) (map[string]any, error) {
+	src, err := toJSONObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	return selectFields(src, paths), nil
+}
+
+// projectListFields projects every element of a list's "items" to the given field
+// paths, leaving the list's own fields untouched.
+func projectListFields(list any, paths []fieldPath) (map[string]any, error) {
+	src, err := toJSONObject(list)
+	if err != nil {
+		return nil, err
+	}
+	if items, ok := src["items"].([]any); ok {
+		for i, item := range items {
+			if itemObj, ok := item.(map[string]any); ok {
+				items[i] = selectFields(itemObj, paths)
+			}
+		}
+	}
+	return src, nil
+}
+
+func toJSONObject(obj any) (map[string]any, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	result := map[string]any{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func selectFields(src map[string]any, paths []fieldPath) map[string]any {
+	dst := map[string]any{}
+	for _, path := range paths {
+		copyFieldPath(src, dst, path)
+	}
+	return dst
+}
+
+func copyFieldPath(src map[string]any, dst map[string]any, path fieldPath) {
+	val, ok := src[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		dst[path[0]] = val
+		return
+	}
+
+	switch v := val.(type) {
+	case map[string]any:
+		child, ok := dst[path[0]].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			dst[path[0]] = child
+		}
+		copyFieldPath(v, child, path[1:])
+	case []any:
+		children, ok := dst[path[0]].([]any)
+		if !ok || len(children) != len(v) {
+			children = make([]any, len(v))
+			for i := range children {
+				children[i] = map[string]any{}
+			}
+			dst[path[0]] = children
+		}
+		for i, elem := range v {
+			elemObj, ok := elem.(map[string]any)
+			if !ok {
+				continue
+			}
+			if child, ok := children[i].(map[string]any); ok {
+				copyFieldPath(elemObj, child, path[1:])
+			}
+		}
+	}
+}
+
+type readDeviceProjectedResponse map[string]any
+
+func (response readDeviceProjectedResponse) VisitReadDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type listDevicesProjectedResponse map[string]any
+
+func (response listDevicesProjectedResponse) VisitListDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func projectDeviceList(list *v1alpha1.DeviceList, fields []fieldPath) (server.ListDevicesResponseObject, error) {
+	projected, err := projectListFields(list, fields)
+	if err != nil {
+		return nil, err
+	}
+	return listDevicesProjectedResponse(projected), nil
+}
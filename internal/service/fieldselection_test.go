@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/auth"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testReadDeviceWithFields(require *require.Assertions, fields string) server.ReadDeviceResponseObject {
+	_ = os.Setenv(auth.DisableAuthEnvKey, "true")
+	_, _ = auth.CreateAuthMiddleware(context.Background(), nil, log.InitLogs())
+	status := v1alpha1.NewDeviceStatus()
+	status.Summary.Status = v1alpha1.DeviceSummaryStatusOnline
+	device := v1alpha1.Device{
+		ApiVersion: "v1",
+		Kind:       "Device",
+		Metadata: v1alpha1.ObjectMeta{
+			Name:   util.StrToPtr("foo"),
+			Labels: &map[string]string{"labelKey": "labelValue"},
+		},
+		Spec: &v1alpha1.DeviceSpec{
+			Os: &v1alpha1.DeviceOsSpec{Image: "img"},
+		},
+		Status: &status,
+	}
+	serviceHandler := ServiceHandler{
+		store:           &DeviceStore{DeviceVal: device},
+		callbackManager: dummyCallbackManager(),
+	}
+	resp, err := serviceHandler.ReadDevice(context.Background(), server.ReadDeviceRequestObject{
+		Name:   "foo",
+		Params: v1alpha1.ReadDeviceParams{Fields: &fields},
+	})
+	require.NoError(err)
+	return resp
+}
+
+func TestReadDeviceProjectNameAndStatus(t *testing.T) {
+	require := require.New(t)
+	resp := testReadDeviceWithFields(require, "metadata.name,status.summary")
+
+	projected, ok := resp.(readDeviceProjectedResponse)
+	require.True(ok)
+
+	rec := httptest.NewRecorder()
+	require.NoError(projected.VisitReadDeviceResponse(rec))
+	require.Equal(200, rec.Code)
+
+	var body map[string]any
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(map[string]any{
+		"metadata": map[string]any{"name": "foo"},
+		"status": map[string]any{
+			"summary": map[string]any{"status": string(v1alpha1.DeviceSummaryStatusOnline)},
+		},
+	}, body)
+}
+
+func TestReadDeviceProjectMapAndArrayFields(t *testing.T) {
+	require := require.New(t)
+	resp := testReadDeviceWithFields(require, "metadata.labels.labelKey, status.conditions.type")
+
+	projected, ok := resp.(readDeviceProjectedResponse)
+	require.True(ok)
+	require.Equal(map[string]any{"labelKey": "labelValue"}, projected["metadata"].(map[string]any)["labels"])
+	require.Contains(projected, "status")
+	require.NotContains(projected, "spec")
+}
+
+func TestReadDeviceProjectUnknownField(t *testing.T) {
+	require := require.New(t)
+	for _, fields := range []string{"metadata.nope", "spec.os.image.tag", "unknown", " , "} {
+		resp := testReadDeviceWithFields(require, fields)
+		resp400, ok := resp.(server.ReadDevice400JSONResponse)
+		require.True(ok, "fields %q should be rejected", fields)
+		require.Contains(resp400.Message, ErrorUnknownField.Error())
+	}
+}
+
+func TestProjectListFields(t *testing.T) {
+	require := require.New(t)
+	list := v1alpha1.DeviceList{
+		ApiVersion: "v1",
+		Kind:       "DeviceList",
+		Items: []v1alpha1.Device{
+			{ApiVersion: "v1", Kind: "Device", Metadata: v1alpha1.ObjectMeta{Name: util.StrToPtr("a")}},
+			{ApiVersion: "v1", Kind: "Device", Metadata: v1alpha1.ObjectMeta{Name: util.StrToPtr("b")}},
+		},
+	}
+	paths, err := parseFieldPaths("metadata.name", "Device")
+	require.NoError(err)
+
+	projected, err := projectListFields(list, paths)
+	require.NoError(err)
+	require.Equal("DeviceList", projected["kind"])
+	require.Equal([]any{
+		map[string]any{"metadata": map[string]any{"name": "a"}},
+		map[string]any{"metadata": map[string]any{"name": "b"}},
+	}, projected["items"])
+}
@@ -9,6 +9,7 @@ import (
 	"reflect"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/admission"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/auth"
 	"github.com/flightctl/flightctl/internal/flterrors"
@@ -51,7 +52,17 @@ func (h *ServiceHandler) CreateFleet(ctx context.Context, request server.CreateF
 		return server.CreateFleet400JSONResponse{Message: errors.Join(errs...).Error()}, nil
 	}
 
+	if err := h.admit(ctx, admission.OperationCreate, v1alpha1.FleetKind, request.Body.Metadata.Name, request.Body); err != nil {
+		if admission.IsDenied(err) {
+			return server.CreateFleet400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.CreateFleet503JSONResponse{Message: err.Error()}, nil
+	}
+
 	result, err := h.store.Fleet().Create(ctx, orgId, request.Body, h.callbackManager.FleetUpdatedCallback)
+	if errors.Is(err, flterrors.ErrQuotaExceeded) {
+		return server.CreateFleet403JSONResponse{Message: err.Error()}, nil
+	}
 	switch err {
 	case nil:
 		return server.CreateFleet201JSONResponse(*result), nil
@@ -75,6 +86,7 @@ func (h *ServiceHandler) ListFleets(ctx context.Context, request server.ListFlee
 		return server.ListFleets403JSONResponse{Message: Forbidden}, nil
 	}
 	orgId := store.NullOrgId
+	ctx = store.WithStaleReads(ctx)
 
 	cont, err := store.ParseContinueString(request.Params.Continue)
 	if err != nil {
@@ -186,6 +198,22 @@ func (h *ServiceHandler) ReplaceFleet(ctx context.Context, request server.Replac
 		common.NilOutManagedObjectMetaProperties(request.Body.Spec.Template.Metadata)
 	}
 
+	if request.Params.FieldManager != nil {
+		existing, err := h.store.Fleet().Get(ctx, orgId, request.Name)
+		if err != nil && !errors.Is(err, flterrors.ErrResourceNotFound) {
+			return nil, err
+		}
+		applied, err := serverSideApply(existing, request.Body, *request.Params.FieldManager, swag.BoolValue(request.Params.Force))
+		var conflictErr *ApplyConflictError
+		if errors.As(err, &conflictErr) {
+			return server.ReplaceFleet409JSONResponse{Message: err.Error()}, nil
+		}
+		if err != nil {
+			return server.ReplaceFleet400JSONResponse{Message: err.Error()}, nil
+		}
+		request.Body = applied
+	}
+
 	if errs := request.Body.Validate(); len(errs) > 0 {
 		return server.ReplaceFleet400JSONResponse{Message: errors.Join(errs...).Error()}, nil
 	}
@@ -193,7 +221,17 @@ func (h *ServiceHandler) ReplaceFleet(ctx context.Context, request server.Replac
 		return server.ReplaceFleet400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
 	}
 
+	if err := h.admit(ctx, admission.OperationUpdate, v1alpha1.FleetKind, request.Body.Metadata.Name, request.Body); err != nil {
+		if admission.IsDenied(err) {
+			return server.ReplaceFleet400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.ReplaceFleet503JSONResponse{Message: err.Error()}, nil
+	}
+
 	result, created, err := h.store.Fleet().CreateOrUpdate(ctx, orgId, request.Body, h.callbackManager.FleetUpdatedCallback)
+	if errors.Is(err, flterrors.ErrQuotaExceeded) {
+		return server.ReplaceFleet403JSONResponse{Message: err.Error()}, nil
+	}
 	switch err {
 	case nil:
 		if created {
@@ -318,7 +356,7 @@ func (h *ServiceHandler) PatchFleet(ctx context.Context, request server.PatchFle
 	}
 
 	newObj := &v1alpha1.Fleet{}
-	err = ApplyJSONPatch(ctx, currentObj, newObj, *request.Body, "/api/v1/fleets/"+request.Name)
+	err = ApplyPatch(ctx, currentObj, newObj, request.ApplicationJSONPatchPlusJSONBody, request.ApplicationMergePatchPlusJSONBody, "/api/v1/fleets/"+request.Name)
 	if err != nil {
 		return server.PatchFleet400JSONResponse{Message: err.Error()}, nil
 	}
@@ -339,14 +377,22 @@ func (h *ServiceHandler) PatchFleet(ctx context.Context, request server.PatchFle
 		return server.PatchFleet400JSONResponse{Message: "status is immutable"}, nil
 	}
 
+	// the resourceVersion is kept, so that the update is conditional on the version the patch was
+	// applied to, or on the one the patch sets, and concurrent changes are not overwritten
 	common.NilOutManagedObjectMetaProperties(&newObj.Metadata)
-	newObj.Metadata.ResourceVersion = nil
 
-	var updateCallback func(before *model.Fleet, after *model.Fleet)
+	var updateCallback func(ctx context.Context, before *model.Fleet, after *model.Fleet) error
 
 	if h.callbackManager != nil {
 		updateCallback = h.callbackManager.FleetUpdatedCallback
 	}
+	if err := h.admit(ctx, admission.OperationUpdate, v1alpha1.FleetKind, newObj.Metadata.Name, newObj); err != nil {
+		if admission.IsDenied(err) {
+			return server.PatchFleet400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.PatchFleet503JSONResponse{Message: err.Error()}, nil
+	}
+
 	result, err := h.store.Fleet().Update(ctx, orgId, newObj, updateCallback)
 
 	switch err {
@@ -367,3 +413,37 @@ func (h *ServiceHandler) PatchFleet(ctx context.Context, request server.PatchFle
 func (h *ServiceHandler) PatchFleetStatus(ctx context.Context, request server.PatchFleetStatusRequestObject) (server.PatchFleetStatusResponseObject, error) {
 	return nil, fmt.Errorf("not yet implemented")
 }
+
+// (GET /api/v1/fleets/{name}/revisions)
+func (h *ServiceHandler) ListFleetRevisions(ctx context.Context, request server.ListFleetRevisionsRequestObject) (server.ListFleetRevisionsResponseObject, error) {
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "fleets/revisions", "list")
+	if err != nil {
+		h.log.WithError(err).Error("failed to check authorization permission")
+		return server.ListFleetRevisions503JSONResponse{Message: AuthorizationServerUnavailable}, nil
+	}
+	if !allowed {
+		return server.ListFleetRevisions403JSONResponse{Message: Forbidden}, nil
+	}
+	orgId := store.NullOrgId
+
+	limit := 0
+	if request.Params.Limit != nil {
+		if *request.Params.Limit < 0 {
+			return server.ListFleetRevisions400JSONResponse{Message: "limit must not be negative"}, nil
+		}
+		limit = int(*request.Params.Limit)
+	}
+
+	if _, err := h.store.Fleet().Get(ctx, orgId, request.Name); err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.ListFleetRevisions404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+
+	result, err := h.store.ResourceRevision().GetHistory(ctx, orgId, v1alpha1.FleetKind, request.Name, limit)
+	if err != nil {
+		return nil, err
+	}
+	return server.ListFleetRevisions200JSONResponse(*result), nil
+}
@@ -84,8 +84,8 @@ func testFleetPatch(require *require.Assertions, patch v1alpha1.PatchRequest) (s
 		callbackManager: dummyCallbackManager(),
 	}
 	resp, err := serviceHandler.PatchFleet(context.Background(), server.PatchFleetRequestObject{
-		Name: "foo",
-		Body: &patch,
+		Name:                             "foo",
+		ApplicationJSONPatchPlusJSONBody: &patch,
 	})
 	require.NoError(err)
 	return resp, fleet
@@ -246,8 +246,8 @@ func TestFleetNonExistingResource(t *testing.T) {
 		}},
 	}
 	resp, err := serviceHandler.PatchFleet(context.Background(), server.PatchFleetRequestObject{
-		Name: "bar",
-		Body: &pr,
+		Name:                             "bar",
+		ApplicationJSONPatchPlusJSONBody: &pr,
 	})
 	require.NoError(err)
 	require.Equal(server.PatchFleet404JSONResponse{}, resp)
@@ -1,10 +1,12 @@
 package service
 
 import (
+	"github.com/flightctl/flightctl/internal/admission"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/console"
 	"github.com/flightctl/flightctl/internal/crypto"
 	"github.com/flightctl/flightctl/internal/kvstore"
+	"github.com/flightctl/flightctl/internal/notifications"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/tasks"
 	"github.com/go-chi/chi/v5"
@@ -17,8 +19,12 @@ type ServiceHandler struct {
 	log             logrus.FieldLogger
 	callbackManager tasks.CallbackManager
 	kvStore         kvstore.KVStore
+	emitter         *notifications.Emitter
+	admission       admission.Validator
 	agentEndpoint   string
 	uiUrl           string
+	// bootstrapTokenEnrollment enables issuing bootstrap tokens.
+	bootstrapTokenEnrollment bool
 }
 
 type WebsocketHandler struct {
@@ -31,15 +37,19 @@ type WebsocketHandler struct {
 // Make sure we conform to servers Service interface
 var _ server.Service = (*ServiceHandler)(nil)
 
-func NewServiceHandler(store store.Store, callbackManager tasks.CallbackManager, kvStore kvstore.KVStore, ca *crypto.CA, log logrus.FieldLogger, agentEndpoint string, uiUrl string) *ServiceHandler {
+func NewServiceHandler(store store.Store, callbackManager tasks.CallbackManager, kvStore kvstore.KVStore, emitter *notifications.Emitter, admission admission.Validator, ca *crypto.CA, log logrus.FieldLogger, agentEndpoint string, uiUrl string, bootstrapTokenEnrollment bool) *ServiceHandler {
 	return &ServiceHandler{
 		store:           store,
 		ca:              ca,
 		log:             log,
 		callbackManager: callbackManager,
 		kvStore:         kvStore,
+		emitter:         emitter,
+		admission:       admission,
 		agentEndpoint:   agentEndpoint,
 		uiUrl:           uiUrl,
+
+		bootstrapTokenEnrollment: bootstrapTokenEnrollment,
 	}
 }
 
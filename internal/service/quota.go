@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/auth"
+	"github.com/flightctl/flightctl/internal/store"
+)
+
+// quotaKinds are the kinds of resources with a quota, in the order their usage is listed.
+var quotaKinds = []string{api.DeviceKind, api.FleetKind}
+
+// (GET /api/v1/quotas)
+func (h *ServiceHandler) GetQuotaUsage(ctx context.Context, request server.GetQuotaUsageRequestObject) (server.GetQuotaUsageResponseObject, error) {
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "quotas", "get")
+	if err != nil {
+		h.log.WithError(err).Error("failed to check authorization permission")
+		return server.GetQuotaUsage503JSONResponse{Message: AuthorizationServerUnavailable}, nil
+	}
+	if !allowed {
+		return server.GetQuotaUsage403JSONResponse{Message: Forbidden}, nil
+	}
+	orgId := store.NullOrgId
+
+	usage := api.QuotaUsage{Items: []api.ResourceQuotaUsage{}}
+	for _, kind := range quotaKinds {
+		used, err := h.store.Usage().Count(ctx, orgId, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s resources: %w", kind, err)
+		}
+		item := api.ResourceQuotaUsage{Kind: kind, Used: used}
+		if limit := h.store.Usage().Limit(orgId, kind); limit > 0 {
+			item.Limit = &limit
+		}
+		usage.Items = append(usage.Items, item)
+	}
+	return server.GetQuotaUsage200JSONResponse(usage), nil
+}
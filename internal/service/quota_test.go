@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/auth"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type QuotaStore struct {
+	store.Store
+	devices *QuotaDevices
+}
+
+func (s *QuotaStore) Device() store.Device {
+	return s.devices
+}
+
+func (s *QuotaStore) Usage() store.Usage {
+	return s.devices
+}
+
+// QuotaDevices stores devices by name, and enforces a quota on their number as the store does.
+type QuotaDevices struct {
+	store.Device
+	devices map[string]*v1alpha1.Device
+	limit   int64
+}
+
+func (s *QuotaDevices) create(device *v1alpha1.Device) (bool, error) {
+	_, exists := s.devices[*device.Metadata.Name]
+	if !exists && int64(len(s.devices)) >= s.limit {
+		return false, fmt.Errorf("%w: the organization has %d of at most %d resources of kind Device", flterrors.ErrQuotaExceeded, len(s.devices), s.limit)
+	}
+	s.devices[*device.Metadata.Name] = device
+	return !exists, nil
+}
+
+func (s *QuotaDevices) Create(ctx context.Context, orgId uuid.UUID, device *v1alpha1.Device, callback store.DeviceStoreCallback) (*v1alpha1.Device, error) {
+	_, err := s.create(device)
+	return device, err
+}
+
+func (s *QuotaDevices) CreateOrUpdate(ctx context.Context, orgId uuid.UUID, device *v1alpha1.Device, fieldsToUnset []string, fromAPI bool, callback store.DeviceStoreCallback) (*v1alpha1.Device, bool, error) {
+	created, err := s.create(device)
+	return device, created, err
+}
+
+func (s *QuotaDevices) Count(ctx context.Context, orgId uuid.UUID, kind string) (int64, error) {
+	if kind != v1alpha1.DeviceKind {
+		return 0, nil
+	}
+	return int64(len(s.devices)), nil
+}
+
+func (s *QuotaDevices) Limit(orgId uuid.UUID, kind string) int64 {
+	if kind != v1alpha1.DeviceKind {
+		return 0
+	}
+	return s.limit
+}
+
+func newQuotaTestHandler(limit int64) (*ServiceHandler, *QuotaStore) {
+	_ = os.Setenv(auth.DisableAuthEnvKey, "true")
+	_, _ = auth.CreateAuthMiddleware(context.Background(), nil, log.InitLogs())
+	st := &QuotaStore{devices: &QuotaDevices{devices: map[string]*v1alpha1.Device{}, limit: limit}}
+	return &ServiceHandler{
+		store:           st,
+		callbackManager: dummyCallbackManager(),
+		log:             log.InitLogs(),
+	}, st
+}
+
+func newQuotaTestDevice(name string) *v1alpha1.Device {
+	return &v1alpha1.Device{
+		ApiVersion: "v1",
+		Kind:       v1alpha1.DeviceKind,
+		Metadata:   v1alpha1.ObjectMeta{Name: util.StrToPtr(name)},
+		Spec:       &v1alpha1.DeviceSpec{},
+	}
+}
+
+func TestDeviceQuota(t *testing.T) {
+	require := require.New(t)
+	h, _ := newQuotaTestHandler(2)
+	ctx := context.Background()
+
+	// creating devices within the quota succeeds
+	for _, name := range []string{"device-1", "device-2"} {
+		resp, err := h.CreateDevice(ctx, server.CreateDeviceRequestObject{Body: newQuotaTestDevice(name)})
+		require.NoError(err)
+		require.IsType(server.CreateDevice201JSONResponse{}, resp)
+	}
+
+	// creating a device beyond the quota is rejected with the quota in the message
+	resp, err := h.CreateDevice(ctx, server.CreateDeviceRequestObject{Body: newQuotaTestDevice("device-3")})
+	require.NoError(err)
+	forbidden, ok := resp.(server.CreateDevice403JSONResponse)
+	require.True(ok)
+	require.Equal("quota exceeded: the organization has 2 of at most 2 resources of kind Device", forbidden.Message)
+
+	replaced, err := h.ReplaceDevice(ctx, server.ReplaceDeviceRequestObject{Name: "device-3", Body: newQuotaTestDevice("device-3")})
+	require.NoError(err)
+	require.IsType(server.ReplaceDevice403JSONResponse{}, replaced)
+
+	// replacing an existing device does not use more of the quota
+	replaced, err = h.ReplaceDevice(ctx, server.ReplaceDeviceRequestObject{Name: "device-1", Body: newQuotaTestDevice("device-1")})
+	require.NoError(err)
+	require.IsType(server.ReplaceDevice200JSONResponse{}, replaced)
+}
+
+func TestGetQuotaUsage(t *testing.T) {
+	require := require.New(t)
+	h, st := newQuotaTestHandler(5)
+	st.devices.devices["device-1"] = newQuotaTestDevice("device-1")
+
+	resp, err := h.GetQuotaUsage(context.Background(), server.GetQuotaUsageRequestObject{})
+	require.NoError(err)
+	usage, ok := resp.(server.GetQuotaUsage200JSONResponse)
+	require.True(ok)
+	require.Equal([]v1alpha1.ResourceQuotaUsage{
+		{Kind: v1alpha1.DeviceKind, Used: 1, Limit: util.Int64ToPtr(5)},
+		{Kind: v1alpha1.FleetKind, Used: 0},
+	}, usage.Items)
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/admission"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/auth"
 	"github.com/flightctl/flightctl/internal/flterrors"
@@ -37,6 +38,13 @@ func (h *ServiceHandler) CreateRepository(ctx context.Context, request server.Cr
 		return server.CreateRepository400JSONResponse{Message: errors.Join(errs...).Error()}, nil
 	}
 
+	if err := h.admit(ctx, admission.OperationCreate, v1alpha1.RepositoryKind, request.Body.Metadata.Name, request.Body); err != nil {
+		if admission.IsDenied(err) {
+			return server.CreateRepository400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.CreateRepository503JSONResponse{Message: err.Error()}, nil
+	}
+
 	result, err := h.store.Repository().Create(ctx, orgId, request.Body, h.callbackManager.RepositoryUpdatedCallback)
 	switch err {
 	case nil:
@@ -61,6 +69,7 @@ func (h *ServiceHandler) ListRepositories(ctx context.Context, request server.Li
 		return server.ListRepositories403JSONResponse{Message: Forbidden}, nil
 	}
 	orgId := store.NullOrgId
+	ctx = store.WithStaleReads(ctx)
 
 	cont, err := store.ParseContinueString(request.Params.Continue)
 	if err != nil {
@@ -161,6 +170,22 @@ func (h *ServiceHandler) ReplaceRepository(ctx context.Context, request server.R
 	request.Body.Status = nil
 	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
 
+	if request.Params.FieldManager != nil {
+		existing, err := h.store.Repository().Get(ctx, orgId, request.Name)
+		if err != nil && !errors.Is(err, flterrors.ErrResourceNotFound) {
+			return nil, err
+		}
+		applied, err := serverSideApply(existing, request.Body, *request.Params.FieldManager, swag.BoolValue(request.Params.Force))
+		var conflictErr *ApplyConflictError
+		if errors.As(err, &conflictErr) {
+			return server.ReplaceRepository409JSONResponse{Message: err.Error()}, nil
+		}
+		if err != nil {
+			return server.ReplaceRepository400JSONResponse{Message: err.Error()}, nil
+		}
+		request.Body = applied
+	}
+
 	if errs := request.Body.Validate(); len(errs) > 0 {
 		return server.ReplaceRepository400JSONResponse{Message: errors.Join(errs...).Error()}, nil
 	}
@@ -168,6 +193,13 @@ func (h *ServiceHandler) ReplaceRepository(ctx context.Context, request server.R
 		return server.ReplaceRepository400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
 	}
 
+	if err := h.admit(ctx, admission.OperationUpdate, v1alpha1.RepositoryKind, request.Body.Metadata.Name, request.Body); err != nil {
+		if admission.IsDenied(err) {
+			return server.ReplaceRepository400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.ReplaceRepository503JSONResponse{Message: err.Error()}, nil
+	}
+
 	result, created, err := h.store.Repository().CreateOrUpdate(ctx, orgId, request.Body, h.callbackManager.RepositoryUpdatedCallback)
 	switch err {
 	case nil:
@@ -238,7 +270,7 @@ func (h *ServiceHandler) PatchRepository(ctx context.Context, request server.Pat
 	}
 
 	newObj := &v1alpha1.Repository{}
-	err = ApplyJSONPatch(ctx, currentObj, newObj, *request.Body, "/api/v1/repositories/"+request.Name)
+	err = ApplyPatch(ctx, currentObj, newObj, request.ApplicationJSONPatchPlusJSONBody, request.ApplicationMergePatchPlusJSONBody, "/api/v1/repositories/"+request.Name)
 	if err != nil {
 		return server.PatchRepository400JSONResponse{Message: err.Error()}, nil
 	}
@@ -256,14 +288,22 @@ func (h *ServiceHandler) PatchRepository(ctx context.Context, request server.Pat
 		return server.PatchRepository400JSONResponse{Message: "status is immutable"}, nil
 	}
 
+	// the resourceVersion is kept, so that the update is conditional on the version the patch was
+	// applied to, or on the one the patch sets, and concurrent changes are not overwritten
 	common.NilOutManagedObjectMetaProperties(&newObj.Metadata)
-	newObj.Metadata.ResourceVersion = nil
 
-	var updateCallback func(repo *model.Repository)
+	var updateCallback func(ctx context.Context, repo *model.Repository) error
 
 	if h.callbackManager != nil {
 		updateCallback = h.callbackManager.RepositoryUpdatedCallback
 	}
+	if err := h.admit(ctx, admission.OperationUpdate, v1alpha1.RepositoryKind, newObj.Metadata.Name, newObj); err != nil {
+		if admission.IsDenied(err) {
+			return server.PatchRepository400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.PatchRepository503JSONResponse{Message: err.Error()}, nil
+	}
+
 	result, err := h.store.Repository().Update(ctx, orgId, newObj, updateCallback)
 
 	switch err {
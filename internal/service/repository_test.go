@@ -68,8 +68,8 @@ func testRepositoryPatch(require *require.Assertions, patch v1alpha1.PatchReques
 		callbackManager: dummyCallbackManager(),
 	}
 	resp, err := serviceHandler.PatchRepository(context.Background(), server.PatchRepositoryRequestObject{
-		Name: "foo",
-		Body: &patch,
+		Name:                             "foo",
+		ApplicationJSONPatchPlusJSONBody: &patch,
 	})
 	require.NoError(err)
 	return resp, repository
@@ -197,8 +197,8 @@ func TestRepositoryNonExistingResource(t *testing.T) {
 		}},
 	}
 	resp, err := serviceHandler.PatchRepository(context.Background(), server.PatchRepositoryRequestObject{
-		Name: "bar",
-		Body: &pr,
+		Name:                             "bar",
+		ApplicationJSONPatchPlusJSONBody: &pr,
 	})
 	require.NoError(err)
 	require.Equal(server.PatchRepository404JSONResponse{}, resp)
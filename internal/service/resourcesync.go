@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/admission"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/auth"
 	"github.com/flightctl/flightctl/internal/flterrors"
@@ -36,6 +37,13 @@ func (h *ServiceHandler) CreateResourceSync(ctx context.Context, request server.
 		return server.CreateResourceSync400JSONResponse{Message: errors.Join(errs...).Error()}, nil
 	}
 
+	if err := h.admit(ctx, admission.OperationCreate, v1alpha1.ResourceSyncKind, request.Body.Metadata.Name, request.Body); err != nil {
+		if admission.IsDenied(err) {
+			return server.CreateResourceSync400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.CreateResourceSync503JSONResponse{Message: err.Error()}, nil
+	}
+
 	result, err := h.store.ResourceSync().Create(ctx, orgId, request.Body)
 	switch err {
 	case nil:
@@ -60,6 +68,7 @@ func (h *ServiceHandler) ListResourceSync(ctx context.Context, request server.Li
 		return server.ListResourceSync403JSONResponse{Message: Forbidden}, nil
 	}
 	orgId := store.NullOrgId
+	ctx = store.WithStaleReads(ctx)
 
 	cont, err := store.ParseContinueString(request.Params.Continue)
 	if err != nil {
@@ -167,6 +176,23 @@ func (h *ServiceHandler) ReplaceResourceSync(ctx context.Context, request server
 	// don't overwrite fields that are managed by the service
 	request.Body.Status = nil
 	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+
+	if request.Params.FieldManager != nil {
+		existing, err := h.store.ResourceSync().Get(ctx, orgId, request.Name)
+		if err != nil && !errors.Is(err, flterrors.ErrResourceNotFound) {
+			return nil, err
+		}
+		applied, err := serverSideApply(existing, request.Body, *request.Params.FieldManager, swag.BoolValue(request.Params.Force))
+		var conflictErr *ApplyConflictError
+		if errors.As(err, &conflictErr) {
+			return server.ReplaceResourceSync409JSONResponse{Message: err.Error()}, nil
+		}
+		if err != nil {
+			return server.ReplaceResourceSync400JSONResponse{Message: err.Error()}, nil
+		}
+		request.Body = applied
+	}
+
 	if errs := request.Body.Validate(); len(errs) > 0 {
 		return server.ReplaceResourceSync400JSONResponse{Message: errors.Join(errs...).Error()}, nil
 	}
@@ -174,6 +200,13 @@ func (h *ServiceHandler) ReplaceResourceSync(ctx context.Context, request server
 		return server.ReplaceResourceSync400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
 	}
 
+	if err := h.admit(ctx, admission.OperationUpdate, v1alpha1.ResourceSyncKind, request.Body.Metadata.Name, request.Body); err != nil {
+		if admission.IsDenied(err) {
+			return server.ReplaceResourceSync400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.ReplaceResourceSync503JSONResponse{Message: err.Error()}, nil
+	}
+
 	result, created, err := h.store.ResourceSync().CreateOrUpdate(ctx, orgId, request.Body)
 	switch err {
 	case nil:
@@ -243,7 +276,7 @@ func (h *ServiceHandler) PatchResourceSync(ctx context.Context, request server.P
 	}
 
 	newObj := &v1alpha1.ResourceSync{}
-	err = ApplyJSONPatch(ctx, currentObj, newObj, *request.Body, "/api/v1/resourcesyncs/"+request.Name)
+	err = ApplyPatch(ctx, currentObj, newObj, request.ApplicationJSONPatchPlusJSONBody, request.ApplicationMergePatchPlusJSONBody, "/api/v1/resourcesyncs/"+request.Name)
 	if err != nil {
 		return server.PatchResourceSync400JSONResponse{Message: err.Error()}, nil
 	}
@@ -261,8 +294,16 @@ func (h *ServiceHandler) PatchResourceSync(ctx context.Context, request server.P
 		return server.PatchResourceSync400JSONResponse{Message: "status is immutable"}, nil
 	}
 
+	// the resourceVersion is kept, so that the update is conditional on the version the patch was
+	// applied to, or on the one the patch sets, and concurrent changes are not overwritten
 	common.NilOutManagedObjectMetaProperties(&newObj.Metadata)
-	newObj.Metadata.ResourceVersion = nil
+	if err := h.admit(ctx, admission.OperationUpdate, v1alpha1.ResourceSyncKind, newObj.Metadata.Name, newObj); err != nil {
+		if admission.IsDenied(err) {
+			return server.PatchResourceSync400JSONResponse{Message: err.Error()}, nil
+		}
+		return server.PatchResourceSync503JSONResponse{Message: err.Error()}, nil
+	}
+
 	result, err := h.store.ResourceSync().Update(ctx, orgId, newObj)
 
 	switch err {
@@ -63,8 +63,8 @@ func testResourceSyncPatch(require *require.Assertions, patch v1alpha1.PatchRequ
 		store: &ResourceSyncStore{ResourceSyncVal: resourceSync},
 	}
 	resp, err := serviceHandler.PatchResourceSync(context.Background(), server.PatchResourceSyncRequestObject{
-		Name: "foo",
-		Body: &patch,
+		Name:                             "foo",
+		ApplicationJSONPatchPlusJSONBody: &patch,
 	})
 	require.NoError(err)
 	return resp, resourceSync
@@ -229,8 +229,8 @@ func TestResourceSyncNonExistingResource(t *testing.T) {
 		}},
 	}
 	resp, err := serviceHandler.PatchResourceSync(context.Background(), server.PatchResourceSyncRequestObject{
-		Name: "bar",
-		Body: &pr,
+		Name:                             "bar",
+		ApplicationJSONPatchPlusJSONBody: &pr,
 	})
 	require.NoError(err)
 	require.Equal(server.PatchResourceSync404JSONResponse{}, resp)
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type BootstrapToken interface {
+	InitialMigration() error
+	// Create stores the hash of a new token, which can be redeemed once until it expires.
+	Create(ctx context.Context, orgId uuid.UUID, hash string, expiresAt time.Time) error
+	// Redeem marks the token with the given hash as redeemed and calls issue, in a transaction that
+	// issue failing rolls back, so that the token is not spent without what it is redeemed for. It
+	// fails with ErrBootstrapTokenInvalid if no such token was issued, ErrBootstrapTokenRedeemed if
+	// it was redeemed already, and ErrBootstrapTokenExpired if it expired.
+	Redeem(ctx context.Context, orgId uuid.UUID, hash string, now time.Time, issue func() error) error
+}
+
+type BootstrapTokenStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to BootstrapToken interface
+var _ BootstrapToken = (*BootstrapTokenStore)(nil)
+
+func NewBootstrapToken(db *gorm.DB, log logrus.FieldLogger) BootstrapToken {
+	return &BootstrapTokenStore{db: db, log: log}
+}
+
+func (s *BootstrapTokenStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.BootstrapToken{})
+}
+
+func (s *BootstrapTokenStore) Create(ctx context.Context, orgId uuid.UUID, hash string, expiresAt time.Time) error {
+	token := model.BootstrapToken{OrgID: orgId, Hash: hash, ExpiresAt: expiresAt}
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.Create(&token).Error
+	})
+	if err != nil {
+		return ErrorFromGormError(err)
+	}
+	return nil
+}
+
+func (s *BootstrapTokenStore) Redeem(ctx context.Context, orgId uuid.UUID, hash string, now time.Time, issue func() error) error {
+	return OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		// a single conditional update, so that concurrent redemptions of a token cannot both
+		// succeed: the others wait for the transaction, and fail unless it is rolled back
+		result := tx.Model(&model.BootstrapToken{}).
+			Where("org_id = ? AND hash = ? AND redeemed_at IS NULL AND expires_at > ?", orgId, hash, now).
+			Update("redeemed_at", now)
+		if result.Error != nil {
+			return ErrorFromGormError(result.Error)
+		}
+		if result.RowsAffected == 1 {
+			return issue()
+		}
+
+		token := model.BootstrapToken{OrgID: orgId, Hash: hash}
+		if err := tx.First(&token).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return flterrors.ErrBootstrapTokenInvalid
+			}
+			return ErrorFromGormError(err)
+		}
+		if token.RedeemedAt != nil {
+			return flterrors.ErrBootstrapTokenRedeemed
+		}
+		return flterrors.ErrBootstrapTokenExpired
+	})
+}
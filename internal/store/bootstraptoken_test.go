@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedeemBootstrapTokenRolledBack(t *testing.T) {
+	require := require.New(t)
+	s := openChangesTestStore(t, nil, &model.BootstrapToken{})
+	ctx := context.Background()
+	orgId := uuid.New()
+	now := time.Now()
+	require.NoError(s.BootstrapToken().Create(ctx, orgId, "hash", now.Add(time.Hour)))
+
+	// failing to issue what the token is redeemed for does not spend it
+	failed := errors.New("failed")
+	err := s.BootstrapToken().Redeem(ctx, orgId, "hash", now, func() error { return failed })
+	require.ErrorIs(err, failed)
+
+	issued := 0
+	issue := func() error {
+		issued++
+		return nil
+	}
+	require.NoError(s.BootstrapToken().Redeem(ctx, orgId, "hash", now, issue))
+	require.ErrorIs(s.BootstrapToken().Redeem(ctx, orgId, "hash", now, issue), flterrors.ErrBootstrapTokenRedeemed)
+	require.Equal(1, issued)
+}
@@ -91,11 +91,20 @@ func (s *CertificateSigningRequestStore) Update(ctx context.Context, orgId uuid.
 }
 
 func (s *CertificateSigningRequestStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.CertificateSigningRequestList, error) {
+	var list *api.CertificateSigningRequestList
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) (err error) {
+		list, err = s.list(ctx, tx, orgId, listParams)
+		return err
+	})
+	return list, err
+}
+
+func (s *CertificateSigningRequestStore) list(ctx context.Context, db *gorm.DB, orgId uuid.UUID, listParams ListParams) (*api.CertificateSigningRequestList, error) {
 	var certificateSigningRequests model.CertificateSigningRequestList
 	var nextContinue *string
 	var numRemaining *int64
 
-	query, err := ListQuery(&model.CertificateSigningRequest{}).Build(ctx, s.db, orgId, listParams)
+	query, err := ListQuery(&model.CertificateSigningRequest{}).Build(ctx, db, orgId, listParams)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +130,7 @@ func (s *CertificateSigningRequestStore) List(ctx context.Context, orgId uuid.UU
 				numRemainingVal = 1
 			}
 		} else {
-			countQuery, err := ListQuery(&model.CertificateSigningRequest{}).Build(ctx, s.db, orgId, listParams)
+			countQuery, err := ListQuery(&model.CertificateSigningRequest{}).Build(ctx, db, orgId, listParams)
 			if err != nil {
 				return nil, err
 			}
@@ -140,33 +149,37 @@ func (s *CertificateSigningRequestStore) List(ctx context.Context, orgId uuid.UU
 
 func (s *CertificateSigningRequestStore) DeleteAll(ctx context.Context, orgId uuid.UUID) error {
 	condition := model.CertificateSigningRequest{}
-	result := s.db.Unscoped().Where("org_id = ?", orgId).Delete(&condition)
-	return ErrorFromGormError(result.Error)
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.Unscoped().Where("org_id = ?", orgId).Delete(&condition).Error
+	})
+	return ErrorFromGormError(err)
 }
 
 func (s *CertificateSigningRequestStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.CertificateSigningRequest, error) {
 	certificateSigningRequest := model.CertificateSigningRequest{
 		Resource: model.Resource{OrgID: orgId, Name: name},
 	}
-	result := s.db.First(&certificateSigningRequest)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.First(&certificateSigningRequest).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
 	}
 	apiCertificateSigningRequest := certificateSigningRequest.ToApiResource()
 	return &apiCertificateSigningRequest, nil
 }
 
-func (s *CertificateSigningRequestStore) createCertificateSigningRequest(certificateSigningRequest *model.CertificateSigningRequest) (bool, error) {
+func (s *CertificateSigningRequestStore) createCertificateSigningRequest(db *gorm.DB, certificateSigningRequest *model.CertificateSigningRequest) (bool, error) {
 	certificateSigningRequest.Generation = lo.ToPtr[int64](1)
 	certificateSigningRequest.ResourceVersion = lo.ToPtr[int64](1)
-	if result := s.db.Create(certificateSigningRequest); result.Error != nil {
+	if result := db.Create(certificateSigningRequest); result.Error != nil {
 		err := ErrorFromGormError(result.Error)
 		return err == flterrors.ErrDuplicateName, err
 	}
 	return false, nil
 }
 
-func (s *CertificateSigningRequestStore) updateCertificateSigningRequest(existingRecord, certificateSigningRequest *model.CertificateSigningRequest) (bool, error) {
+func (s *CertificateSigningRequestStore) updateCertificateSigningRequest(db *gorm.DB, existingRecord, certificateSigningRequest *model.CertificateSigningRequest) (bool, error) {
 	updateSpec := certificateSigningRequest.Spec != nil && !reflect.DeepEqual(existingRecord.Spec, certificateSigningRequest.Spec)
 
 	// Update the generation if the spec was updated
@@ -178,7 +191,7 @@ func (s *CertificateSigningRequestStore) updateCertificateSigningRequest(existin
 	}
 	certificateSigningRequest.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
 	where := model.CertificateSigningRequest{Resource: model.Resource{OrgID: certificateSigningRequest.OrgID, Name: certificateSigningRequest.Name}}
-	query := s.db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
+	query := db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
 
 	result := query.Updates(&certificateSigningRequest)
 	if result.Error != nil {
@@ -207,27 +220,30 @@ func (s *CertificateSigningRequestStore) createOrUpdate(orgId uuid.UUID, resourc
 	certificatesigningrequest.Status = nil
 	certificatesigningrequest.Annotations = nil
 
-	existingRecord, err := getExistingRecord[model.CertificateSigningRequest](s.db, certificatesigningrequest.Name, orgId)
-	if err != nil {
-		return nil, false, false, err
-	}
-	exists := existingRecord != nil
-
-	if exists && mode == ModeCreateOnly {
-		return nil, false, false, flterrors.ErrDuplicateName
-	}
-	if !exists && mode == ModeUpdateOnly {
-		return nil, false, false, flterrors.ErrResourceNotFound
-	}
+	var exists, retry bool
+	err = OrgTransaction(s.db, orgId, func(tx *gorm.DB) error {
+		existingRecord, err := getExistingRecord[model.CertificateSigningRequest](tx, certificatesigningrequest.Name, orgId)
+		if err != nil {
+			return err
+		}
+		exists = existingRecord != nil
 
-	if !exists {
-		if retry, err := s.createCertificateSigningRequest(certificatesigningrequest); err != nil {
-			return nil, false, retry, err
+		if exists && mode == ModeCreateOnly {
+			return flterrors.ErrDuplicateName
 		}
-	} else {
-		if retry, err := s.updateCertificateSigningRequest(existingRecord, certificatesigningrequest); err != nil {
-			return nil, false, retry, err
+		if !exists && mode == ModeUpdateOnly {
+			return flterrors.ErrResourceNotFound
+		}
+
+		if !exists {
+			retry, err = s.createCertificateSigningRequest(tx, certificatesigningrequest)
+		} else {
+			retry, err = s.updateCertificateSigningRequest(tx, existingRecord, certificatesigningrequest)
 		}
+		return err
+	})
+	if err != nil {
+		return nil, false, retry, err
 	}
 
 	updatedResource := certificatesigningrequest.ToApiResource()
@@ -250,27 +266,31 @@ func (s *CertificateSigningRequestStore) UpdateStatus(ctx context.Context, orgId
 	certificateSigningRequest := model.CertificateSigningRequest{
 		Resource: model.Resource{OrgID: orgId, Name: *resource.Metadata.Name},
 	}
-	result := s.db.Model(&certificateSigningRequest).Updates(map[string]interface{}{
-		"status":           model.MakeJSONField(resource.Status),
-		"resource_version": gorm.Expr("resource_version + 1"),
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.Model(&certificateSigningRequest).Updates(map[string]interface{}{
+			"status":           model.MakeJSONField(resource.Status),
+			"resource_version": gorm.Expr("resource_version + 1"),
+		}).Error
 	})
-	return resource, ErrorFromGormError(result.Error)
+	return resource, ErrorFromGormError(err)
 }
 
 func (s *CertificateSigningRequestStore) Delete(ctx context.Context, orgId uuid.UUID, name string) error {
 	condition := model.CertificateSigningRequest{
 		Resource: model.Resource{OrgID: orgId, Name: name},
 	}
-	result := s.db.Unscoped().Delete(&condition)
-	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.Unscoped().Delete(&condition).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil
 	}
-	return ErrorFromGormError(result.Error)
+	return ErrorFromGormError(err)
 }
 
-func (s *CertificateSigningRequestStore) updateConditions(orgId uuid.UUID, name string, conditions []api.Condition) (bool, error) {
+func (s *CertificateSigningRequestStore) updateConditions(db *gorm.DB, orgId uuid.UUID, name string, conditions []api.Condition) (bool, error) {
 	existingRecord := model.CertificateSigningRequest{Resource: model.Resource{OrgID: orgId, Name: name}}
-	result := s.db.First(&existingRecord)
+	result := db.First(&existingRecord)
 	if result.Error != nil {
 		return false, ErrorFromGormError(result.Error)
 	}
@@ -289,7 +309,7 @@ func (s *CertificateSigningRequestStore) updateConditions(orgId uuid.UUID, name
 		return false, nil
 	}
 
-	result = s.db.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
+	result = db.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
 		"status":           existingRecord.Status,
 		"resource_version": gorm.Expr("resource_version + 1"),
 	})
@@ -304,7 +324,11 @@ func (s *CertificateSigningRequestStore) updateConditions(orgId uuid.UUID, name
 }
 
 func (s *CertificateSigningRequestStore) UpdateConditions(ctx context.Context, orgId uuid.UUID, name string, conditions []api.Condition) error {
-	return retryUpdate(func() (bool, error) {
-		return s.updateConditions(orgId, name, conditions)
+	return retryUpdate(func() (retry bool, err error) {
+		err = OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+			retry, err = s.updateConditions(tx, orgId, name, conditions)
+			return err
+		})
+		return retry, err
 	})
 }
@@ -0,0 +1,131 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChangesQueue is the outbox queue of the changes of the resources captured for external
+// consumers.
+const ChangesQueue = "changes"
+
+// ChangeType is the type of a change of a resource.
+type ChangeType string
+
+const (
+	ChangeCreated ChangeType = "Created"
+	ChangeUpdated ChangeType = "Updated"
+	ChangeDeleted ChangeType = "Deleted"
+)
+
+// Change is the creation, update or deletion of a resource.
+type Change struct {
+	Type      ChangeType `json:"type"`
+	Kind      string     `json:"kind"`
+	OrgID     uuid.UUID  `json:"orgId"`
+	Name      string     `json:"name"`
+	Timestamp time.Time  `json:"timestamp"`
+	// Object is the resource as written by a creation or update. It is omitted for deletions.
+	Object json.RawMessage `json:"object,omitempty"`
+}
+
+type storeOptions struct {
+	changeCapture bool
+	quotas        *quotas
+}
+
+type Option func(*storeOptions)
+
+// WithChangeCapture captures the creations, updates and deletions of devices, fleets and
+// repositories in the ChangesQueue of the outbox, in the transactions of the writes. Only the
+// committed changes are thus published, in the order of their commits for any single resource.
+// The updates of the status, annotations, rendered specs and conditions of devices and fleets are
+// captured as well, with the resources as they are after the update.
+func WithChangeCapture() Option {
+	return func(o *storeOptions) {
+		o.changeCapture = true
+	}
+}
+
+// changeCapture enqueues the changes of resources to the outbox. A nil changeCapture captures
+// nothing.
+type changeCapture struct {
+	outbox Outbox
+}
+
+func newChangeCapture(outbox Outbox, enabled bool) *changeCapture {
+	if !enabled {
+		return nil
+	}
+	return &changeCapture{outbox: outbox}
+}
+
+// enabled returns whether the changes are captured, for the writes to skip reading back what they
+// would capture otherwise.
+func (c *changeCapture) enabled() bool {
+	return c != nil
+}
+
+// capture enqueues the change in the transaction tx. Failing to do so fails the write, so that no
+// committed change is missing from the stream.
+func (c *changeCapture) capture(ctx context.Context, tx *gorm.DB, changeType ChangeType, kind string, orgId uuid.UUID, name string, object interface{}) error {
+	if c == nil {
+		return nil
+	}
+	change := Change{
+		Type:      changeType,
+		Kind:      kind,
+		OrgID:     orgId,
+		Name:      name,
+		Timestamp: time.Now().UTC(),
+	}
+	if object != nil {
+		marshalled, err := json.Marshal(object)
+		if err != nil {
+			return fmt.Errorf("marshalling %s/%s for change capture: %w", kind, name, err)
+		}
+		change.Object = marshalled
+	}
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("marshalling change of %s/%s: %w", kind, name, err)
+	}
+	return c.outbox.Enqueue(withTransaction(ctx, tx), ChangesQueue, payload)
+}
+
+// captureDeleted captures the deletions of the named resources of the given kind in tx.
+func (c *changeCapture) captureDeleted(ctx context.Context, tx *gorm.DB, kind string, orgId uuid.UUID, names ...string) error {
+	for _, name := range names {
+		if err := c.capture(ctx, tx, ChangeDeleted, kind, orgId, name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pluckNames returns the names of the resources of the organization that db selects, for the
+// deletions of all of them to be captured. It does not query anything when nothing is captured.
+func (c *changeCapture) pluckNames(db *gorm.DB, model interface{}, orgId uuid.UUID) ([]string, error) {
+	if c == nil {
+		return nil, nil
+	}
+	var names []string
+	if err := db.Model(model).Where("org_id = ?", orgId).Order("name").Pluck("name", &names).Error; err != nil {
+		return nil, ErrorFromGormError(err)
+	}
+	return names, nil
+}
+
+// createdOrUpdated returns the type of the change of a resource that existed before the write or
+// not.
+func createdOrUpdated(exists bool) ChangeType {
+	if exists {
+		return ChangeUpdated
+	}
+	return ChangeCreated
+}
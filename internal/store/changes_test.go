@@ -0,0 +1,253 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// openChangesTestStore returns a store with the tables of the given models only, as the indexes of
+// the tables of devices and repositories have the same names, which sqlite does not allow.
+func openChangesTestStore(t *testing.T, opts []Option, models ...interface{}) Store {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "changes.db")), &gorm.Config{IgnoreRelationshipsWhenMigrating: true})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(append(models, &model.OutboxEntry{}, &model.ResourceRevision{})...))
+	return NewStore(db, log.InitLogs(), opts...)
+}
+
+func openDeviceChangesTestStore(t *testing.T, opts ...Option) Store {
+	return openChangesTestStore(t, opts, &model.Device{}, &model.DeviceLogs{})
+}
+
+// relayChanges returns the changes pending in the outbox, in the order they are published.
+func relayChanges(t *testing.T, s Store) []Change {
+	changes := []Change{}
+	_, err := s.Outbox().RelayInOrder(context.Background(), ChangesQueue, 100, func(payload []byte) error {
+		var change Change
+		if err := json.Unmarshal(payload, &change); err != nil {
+			return err
+		}
+		changes = append(changes, change)
+		return nil
+	})
+	require.NoError(t, err)
+	return changes
+}
+
+func changesTestDevice(name string, labels map[string]string) *api.Device {
+	return &api.Device{
+		Metadata: api.ObjectMeta{Name: lo.ToPtr(name), Labels: &labels},
+		Spec:     &api.DeviceSpec{},
+	}
+}
+
+func TestChangeCapture(t *testing.T) {
+	require := require.New(t)
+	s := openDeviceChangesTestStore(t, WithChangeCapture())
+	ctx := context.Background()
+	orgId := uuid.New()
+	noop := func(context.Context, *model.Device, *model.Device) error { return nil }
+
+	_, err := s.Device().Create(ctx, orgId, changesTestDevice("dev-1", map[string]string{"site": "berlin"}), noop)
+	require.NoError(err)
+	_, err = s.Device().Create(ctx, orgId, changesTestDevice("dev-2", nil), noop)
+	require.NoError(err)
+	_, _, err = s.Device().CreateOrUpdate(ctx, orgId, changesTestDevice("dev-1", map[string]string{"site": "madrid"}), nil, true, noop)
+	require.NoError(err)
+	require.NoError(s.Device().Delete(ctx, orgId, "dev-2", noop))
+	_, err = s.Device().DeleteAll(ctx, orgId, func(context.Context, uuid.UUID) error { return nil })
+	require.NoError(err)
+
+	changes := relayChanges(t, s)
+	require.Len(changes, 5)
+	expected := []struct {
+		changeType ChangeType
+		name       string
+		site       string
+	}{
+		{ChangeCreated, "dev-1", "berlin"},
+		{ChangeCreated, "dev-2", ""},
+		{ChangeUpdated, "dev-1", "madrid"},
+		{ChangeDeleted, "dev-2", ""},
+		{ChangeDeleted, "dev-1", ""},
+	}
+	for i, e := range expected {
+		require.Equal(e.changeType, changes[i].Type)
+		require.Equal(api.DeviceKind, changes[i].Kind)
+		require.Equal(orgId, changes[i].OrgID)
+		require.Equal(e.name, changes[i].Name)
+		if e.changeType == ChangeDeleted {
+			require.Nil(changes[i].Object)
+			continue
+		}
+		var device api.Device
+		require.NoError(json.Unmarshal(changes[i].Object, &device))
+		require.Equal(e.site, (*device.Metadata.Labels)["site"])
+	}
+
+	// the changes are only published once
+	require.Empty(relayChanges(t, s))
+}
+
+func TestChangeCaptureRolledBack(t *testing.T) {
+	require := require.New(t)
+	s := openDeviceChangesTestStore(t, WithChangeCapture())
+	ctx := context.Background()
+	orgId := uuid.New()
+	noop := func(context.Context, *model.Device, *model.Device) error { return nil }
+
+	_, err := s.Device().Create(ctx, orgId, changesTestDevice("dev-1", nil), noop)
+	require.NoError(err)
+	// a write that fails captures nothing
+	_, err = s.Device().Create(ctx, orgId, changesTestDevice("dev-1", nil), noop)
+	require.Error(err)
+	stale := changesTestDevice("dev-1", nil)
+	stale.Metadata.ResourceVersion = lo.ToPtr("7")
+	_, err = s.Device().Update(ctx, orgId, stale, nil, true, noop)
+	require.Error(err)
+
+	changes := relayChanges(t, s)
+	require.Len(changes, 1)
+	require.Equal(ChangeCreated, changes[0].Type)
+}
+
+func TestChangeCaptureDisabled(t *testing.T) {
+	require := require.New(t)
+	s := openDeviceChangesTestStore(t)
+	ctx := context.Background()
+
+	_, err := s.Device().Create(ctx, uuid.New(), changesTestDevice("dev-1", nil), func(context.Context, *model.Device, *model.Device) error { return nil })
+	require.NoError(err)
+	require.Empty(relayChanges(t, s))
+}
+
+func TestChangeCaptureRepositories(t *testing.T) {
+	require := require.New(t)
+	s := openChangesTestStore(t, []Option{WithChangeCapture()}, &model.Repository{})
+	ctx := context.Background()
+	orgId := uuid.New()
+	noop := func(context.Context, *model.Repository) error { return nil }
+
+	spec := api.RepositorySpec{}
+	require.NoError(spec.FromGenericRepoSpec(api.GenericRepoSpec{Url: "https://github.com/flightctl/flightctl", Type: api.Git}))
+	repository := &api.Repository{Metadata: api.ObjectMeta{Name: lo.ToPtr("repo")}, Spec: spec}
+	_, err := s.Repository().Create(ctx, orgId, repository, noop)
+	require.NoError(err)
+	_, err = s.Repository().Update(ctx, orgId, repository, noop)
+	require.NoError(err)
+	require.NoError(s.Repository().Delete(ctx, orgId, "repo", noop))
+
+	changes := relayChanges(t, s)
+	require.Len(changes, 3)
+	for i, changeType := range []ChangeType{ChangeCreated, ChangeUpdated, ChangeDeleted} {
+		require.Equal(changeType, changes[i].Type)
+		require.Equal(api.RepositoryKind, changes[i].Kind)
+		require.Equal("repo", changes[i].Name)
+	}
+}
+
+func TestRelayInOrderStopsAtFailure(t *testing.T) {
+	require := require.New(t)
+	_, outbox := openOutboxTestDB(t)
+	ctx := context.Background()
+
+	for _, payload := range []string{"first", "second", "third"} {
+		require.NoError(outbox.Enqueue(ctx, ChangesQueue, []byte(payload)))
+	}
+
+	// a change that cannot be published holds back the following ones
+	attempts := 0
+	published, err := outbox.RelayInOrder(ctx, ChangesQueue, 10, func(payload []byte) error {
+		attempts++
+		if string(payload) == "second" {
+			return errors.New("sink unavailable")
+		}
+		return nil
+	})
+	require.Error(err)
+	require.Equal(1, published)
+	require.Equal(2, attempts)
+
+	publisher := &outboxTestPublisher{}
+	published, err = outbox.RelayInOrder(ctx, ChangesQueue, 10, publisher.publish)
+	require.NoError(err)
+	require.Equal(2, published)
+	require.Equal([][]byte{[]byte("second"), []byte("third")}, publisher.published)
+}
+
+func TestChangeCaptureStatusUpdates(t *testing.T) {
+	require := require.New(t)
+	s := openDeviceChangesTestStore(t, WithChangeCapture())
+	ctx := context.Background()
+	orgId := uuid.New()
+
+	_, err := s.Device().Create(ctx, orgId, changesTestDevice("dev-1", nil), func(context.Context, *model.Device, *model.Device) error { return nil })
+	require.NoError(err)
+	device := changesTestDevice("dev-1", nil)
+	device.Status = &api.DeviceStatus{Summary: api.DeviceSummaryStatus{Status: api.DeviceSummaryStatusOnline}}
+	_, err = s.Device().UpdateStatus(ctx, orgId, device)
+	require.NoError(err)
+	require.NoError(s.Device().UpdateAnnotations(ctx, orgId, "dev-1", map[string]string{"key": "value"}, nil))
+	require.NoError(s.Device().SetServiceConditions(ctx, orgId, "dev-1", []api.Condition{{Type: api.DeviceMultipleOwners, Status: api.ConditionStatusTrue}}))
+	// updating a device that does not exist captures nothing
+	missing := changesTestDevice("dev-2", nil)
+	_, err = s.Device().UpdateStatus(ctx, orgId, missing)
+	require.NoError(err)
+
+	changes := relayChanges(t, s)
+	require.Len(changes, 4)
+	require.Equal(ChangeCreated, changes[0].Type)
+	var updated api.Device
+	for i, change := range changes[1:] {
+		require.Equal(ChangeUpdated, change.Type)
+		require.Equal("dev-1", change.Name)
+		require.NoError(json.Unmarshal(change.Object, &updated))
+		require.Equal(fmt.Sprint(i+2), lo.FromPtr(updated.Metadata.ResourceVersion))
+	}
+	require.Equal(api.DeviceSummaryStatusOnline, updated.Status.Summary.Status)
+	require.Equal("value", (*updated.Metadata.Annotations)["key"])
+}
+
+func TestChangeCaptureFleetStatusUpdates(t *testing.T) {
+	require := require.New(t)
+	s := openChangesTestStore(t, []Option{WithChangeCapture()}, &model.Fleet{})
+	ctx := context.Background()
+	orgId := uuid.New()
+
+	fleet := &api.Fleet{
+		Metadata: api.ObjectMeta{Name: lo.ToPtr("fleet"), Owner: lo.ToPtr("ResourceSync/sync")},
+		Spec:     api.FleetSpec{},
+	}
+	_, err := s.Fleet().Create(ctx, orgId, fleet, func(context.Context, *model.Fleet, *model.Fleet) error { return nil })
+	require.NoError(err)
+	fleet.Status = &api.FleetStatus{Conditions: []api.Condition{}}
+	_, err = s.Fleet().UpdateStatus(ctx, orgId, fleet)
+	require.NoError(err)
+	require.NoError(s.Fleet().UpdateConditions(ctx, orgId, "fleet", []api.Condition{{Type: api.FleetValid, Status: api.ConditionStatusTrue}}))
+	require.NoError(s.Fleet().UpdateAnnotations(ctx, orgId, "fleet", map[string]string{"key": "value"}, nil))
+	require.NoError(s.Fleet().UnsetOwner(ctx, nil, orgId, "ResourceSync/sync"))
+
+	changes := relayChanges(t, s)
+	require.Len(changes, 5)
+	for _, change := range changes[1:] {
+		require.Equal(ChangeUpdated, change.Type)
+		require.Equal(api.FleetKind, change.Kind)
+	}
+	var updated api.Fleet
+	require.NoError(json.Unmarshal(changes[4].Object, &updated))
+	require.Nil(updated.Metadata.Owner)
+	require.Equal("value", (*updated.Metadata.Annotations)["key"])
+	require.Len(updated.Status.Conditions, 1)
+}
@@ -64,7 +64,7 @@ func ListQuery(model any) *listQuery {
 }
 
 func (lq *listQuery) Build(ctx context.Context, db *gorm.DB, orgId uuid.UUID, listParams ListParams) (*gorm.DB, error) {
-	query := db.Model(lq.dest).Order("name")
+	query := db.WithContext(ctx).Model(lq.dest).Order("name")
 	query = query.Where("org_id = ?", orgId)
 
 	if listParams.FieldSelector != nil {
@@ -96,6 +96,30 @@ func (lq *listQuery) Build(ctx context.Context, db *gorm.DB, orgId uuid.UUID, li
 	return query, nil
 }
 
+// ExplainQuery returns the execution plan of a list query built by ListQuery, to check which
+// indexes it uses.
+func ExplainQuery(ctx context.Context, query *gorm.DB, dest any) (string, error) {
+	stmt := query.Session(&gorm.Session{DryRun: true}).Find(dest).Statement
+	rows, err := stmt.ConnPool.QueryContext(ctx, "EXPLAIN "+stmt.SQL.String(), stmt.Vars...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var plan []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return "", err
+		}
+		plan = append(plan, line)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return strings.Join(plan, "\n"), nil
+}
+
 func AddPaginationToQuery(query *gorm.DB, limit int, cont *Continue) *gorm.DB {
 	if limit == 0 {
 		return query
@@ -147,6 +171,39 @@ func CountStatusList(ctx context.Context, query *gorm.DB, status ...string) (Sta
 	return statusCounts, nil
 }
 
+// CountLabelValues returns the number of resources of the list query per value of the label with
+// the given key. The resources without the label are filtered out with an existence check, which
+// is served by the GIN index on the labels.
+func CountLabelValues(ctx context.Context, query *gorm.DB, dest any, key string) (map[string]int64, error) {
+	exists, err := selector.NewLabelSelector(key)
+	if err != nil {
+		return nil, err
+	}
+	q, p, err := exists.Parse(ctx, dest, selector.NewHiddenSelectorName("metadata.labels"))
+	if err != nil {
+		return nil, err
+	}
+
+	var labelCounts []struct {
+		Value string
+		Count int64
+	}
+	queryAggregate := `
+		WITH data AS (?)
+		SELECT labels ->> ? AS value, COUNT(*) AS count
+		FROM data
+		GROUP BY value`
+	if err := query.WithContext(ctx).Raw(queryAggregate, query.Select("labels").Where(q, p...), key).Scan(&labelCounts).Error; err != nil {
+		return nil, ErrorFromGormError(err)
+	}
+
+	res := make(map[string]int64, len(labelCounts))
+	for _, labelCount := range labelCounts {
+		res[labelCount.Value] = labelCount.Count
+	}
+	return res, nil
+}
+
 func GetNonNilFieldsFromResource(resource model.Resource) []string {
 	ret := []string{}
 	if resource.Generation != nil {
@@ -161,6 +218,9 @@ func GetNonNilFieldsFromResource(resource model.Resource) []string {
 	if resource.Annotations != nil {
 		ret = append(ret, "annotations")
 	}
+	if resource.ManagedFields != nil {
+		ret = append(ret, "managed_fields")
+	}
 
 	if resource.Generation != nil {
 		ret = append(ret, "generation")
@@ -42,9 +42,32 @@ func PrepareDBForUnitTests(log *logrus.Logger) (Store, *config.Config, string, *
 		log.Fatalf("running initial migration: %v", err)
 	}
 
+	if err := PrepareOrgBypassRole(db); err != nil {
+		log.Fatalf("preparing the organization bypass role: %v", err)
+	}
+
 	return store, cfg, randomDBName, db
 }
 
+// PrepareOrgBypassRole creates OrgBypassRole and grants it the privileges on the tables, as the
+// deployment does, for the tests running the store against a database of their own.
+func PrepareOrgBypassRole(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	statements := []string{
+		fmt.Sprintf("DO $$ BEGIN CREATE ROLE %s NOLOGIN; EXCEPTION WHEN duplicate_object OR unique_violation THEN NULL; END $$", OrgBypassRole),
+		fmt.Sprintf("GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO %s", OrgBypassRole),
+		fmt.Sprintf("GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO %s", OrgBypassRole),
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func DeleteTestDB(log *logrus.Logger, cfg *config.Config, store Store, dbName string) {
 	err := store.Close()
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
@@ -24,12 +25,16 @@ type Device interface {
 	Create(ctx context.Context, orgId uuid.UUID, device *api.Device, callback DeviceStoreCallback) (*api.Device, error)
 	Update(ctx context.Context, orgId uuid.UUID, device *api.Device, fieldsToUnset []string, fromAPI bool, callback DeviceStoreCallback) (*api.Device, error)
 	List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.DeviceList, error)
+	ListNeedingAttention(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.DeviceList, error)
 	Summary(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.DevicesSummary, error)
+	CountByLabel(ctx context.Context, orgId uuid.UUID, key string, listParams ListParams) (map[string]int64, error)
 	Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Device, error)
+	GetMany(ctx context.Context, orgId uuid.UUID, names []string) ([]api.Device, error)
 	CreateOrUpdate(ctx context.Context, orgId uuid.UUID, device *api.Device, fieldsToUnset []string, fromAPI bool, callback DeviceStoreCallback) (*api.Device, bool, error)
 	UpdateStatus(ctx context.Context, orgId uuid.UUID, device *api.Device) (*api.Device, error)
 	UpdateSummaryStatusBatch(ctx context.Context, orgId uuid.UUID, deviceNames []string, status api.DeviceSummaryStatusType, statusInfo string) error
-	DeleteAll(ctx context.Context, orgId uuid.UUID, callback DeviceStoreAllDeletedCallback) error
+	DeleteAll(ctx context.Context, orgId uuid.UUID, callback DeviceStoreAllDeletedCallback) (int64, error)
+	DeleteMatching(ctx context.Context, orgId uuid.UUID, listParams ListParams, callback DeviceStoreCallback) (int64, []api.DeleteCollectionFailure, error)
 	Delete(ctx context.Context, orgId uuid.UUID, name string, callback DeviceStoreCallback) error
 	UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error
 	UpdateRendered(ctx context.Context, orgId uuid.UUID, name, renderedConfig, renderedApplications string) error
@@ -43,20 +48,26 @@ type Device interface {
 
 type IntegrationTestCallback func()
 type DeviceStore struct {
-	db  *gorm.DB
-	log logrus.FieldLogger
+	db      *gorm.DB
+	log     logrus.FieldLogger
+	changes *changeCapture
+	quotas  *quotas
 
 	IntegrationTestCreateOrUpdateCallback IntegrationTestCallback
 }
 
-type DeviceStoreCallback func(before *model.Device, after *model.Device)
-type DeviceStoreAllDeletedCallback func(orgId uuid.UUID)
+type DeviceStoreCallback func(ctx context.Context, before *model.Device, after *model.Device) error
+type DeviceStoreAllDeletedCallback func(ctx context.Context, orgId uuid.UUID) error
 
 // Make sure we conform to Device interface
 var _ Device = (*DeviceStore)(nil)
 
 func NewDevice(db *gorm.DB, log logrus.FieldLogger) Device {
-	return &DeviceStore{db: db, log: log, IntegrationTestCreateOrUpdateCallback: func() {}}
+	return newDevice(db, log, nil, nil)
+}
+
+func newDevice(db *gorm.DB, log logrus.FieldLogger, changes *changeCapture, quotas *quotas) Device {
+	return &DeviceStore{db: db, log: log, changes: changes, quotas: quotas, IntegrationTestCreateOrUpdateCallback: func() {}}
 }
 
 func (s *DeviceStore) SetIntegrationTestCreateOrUpdateCallback(c IntegrationTestCallback) {
@@ -142,22 +153,84 @@ func (s *DeviceStore) InitialMigration() error {
 		}
 	}
 
+	// Create B-Tree indexes on the expressions generated by the field selectors of the device
+	// statuses, which the GIN index on status cannot serve. This lets the planner combine them
+	// with the GIN index on labels when listing devices by both a label and a status.
+	if s.db.Dialector.Name() == "postgres" {
+		for index, expression := range deviceStatusIndexes {
+			if s.db.Migrator().HasIndex(&model.Device{}, index) {
+				continue
+			}
+			if err := s.db.Exec(fmt.Sprintf("CREATE INDEX %s ON devices USING BTREE ((%s))", index, expression)).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	// Create a partial index on the devices needing attention, so that listing them does not
+	// scan the mostly healthy devices of the organization.
+	if s.db.Dialector.Name() == "postgres" && !s.db.Migrator().HasIndex(&model.Device{}, "idx_device_needs_attention") {
+		if err := s.db.Exec(fmt.Sprintf("CREATE INDEX idx_device_needs_attention ON devices USING BTREE (org_id, name) WHERE %s", DeviceNeedsAttentionCondition)).Error; err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// DeviceNeedsAttentionCondition matches the devices reporting a non-healthy summary, application,
+// or resource status, or a failed condition. It must match the predicate of the
+// idx_device_needs_attention partial index for the planner to use it.
+const DeviceNeedsAttentionCondition = `(status -> 'summary' ->> 'status' IN ('Error', 'Degraded', 'Unknown')
+	OR status -> 'applicationsSummary' ->> 'status' IN ('Error', 'Degraded')
+	OR status -> 'resources' ->> 'cpu' IN ('Warning', 'Critical', 'Error')
+	OR status -> 'resources' ->> 'memory' IN ('Warning', 'Critical', 'Error')
+	OR status -> 'resources' ->> 'disk' IN ('Warning', 'Critical', 'Error')
+	OR status -> 'conditions' @> '[{"type": "SpecValid", "status": "False"}]'
+	OR status -> 'conditions' @> '[{"type": "MultipleOwners", "status": "True"}]')`
+
+// deviceStatusIndexes maps the names of the indexes on device status fields to the expressions
+// the field selectors generate for them.
+var deviceStatusIndexes = map[string]string{
+	"idx_device_summary_status":              "status -> 'summary' ->> 'status'",
+	"idx_device_updated_status":              "status -> 'updated' ->> 'status'",
+	"idx_device_applications_summary_status": "status -> 'applicationsSummary' ->> 'status'",
+}
+
 func (s *DeviceStore) Create(ctx context.Context, orgId uuid.UUID, resource *api.Device, callback DeviceStoreCallback) (*api.Device, error) {
-	updatedResource, _, _, err := s.createOrUpdate(orgId, resource, nil, true, ModeCreateOnly, callback)
+	updatedResource, _, _, err := s.createOrUpdate(ctx, orgId, resource, nil, true, ModeCreateOnly, callback)
 	return updatedResource, err
 }
 
 func (s *DeviceStore) Update(ctx context.Context, orgId uuid.UUID, resource *api.Device, fieldsToUnset []string, fromAPI bool, callback DeviceStoreCallback) (*api.Device, error) {
 	updatedResource, _, err := retryCreateOrUpdate(func() (*api.Device, bool, bool, error) {
-		return s.createOrUpdate(orgId, resource, fieldsToUnset, fromAPI, ModeUpdateOnly, callback)
+		return s.createOrUpdate(ctx, orgId, resource, fieldsToUnset, fromAPI, ModeUpdateOnly, callback)
 	})
 	return updatedResource, err
 }
 
 func (s *DeviceStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.DeviceList, error) {
+	return s.list(ctx, orgId, listParams)
+}
+
+// ListNeedingAttention lists the devices reporting a non-healthy status or a failed condition,
+// using the idx_device_needs_attention partial index.
+func (s *DeviceStore) ListNeedingAttention(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.DeviceList, error) {
+	return s.list(ctx, orgId, listParams, func(query *gorm.DB) *gorm.DB {
+		return query.Where(DeviceNeedsAttentionCondition)
+	})
+}
+
+func (s *DeviceStore) list(ctx context.Context, orgId uuid.UUID, listParams ListParams, scopes ...func(*gorm.DB) *gorm.DB) (*api.DeviceList, error) {
+	var list *api.DeviceList
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) (err error) {
+		list, err = s.queryList(ctx, tx, orgId, listParams, scopes...)
+		return err
+	})
+	return list, err
+}
+
+func (s *DeviceStore) queryList(ctx context.Context, db *gorm.DB, orgId uuid.UUID, listParams ListParams, scopes ...func(*gorm.DB) *gorm.DB) (*api.DeviceList, error) {
 	var devices model.DeviceList
 	var nextContinue *string
 	var numRemaining *int64
@@ -166,10 +239,11 @@ func (s *DeviceStore) List(ctx context.Context, orgId uuid.UUID, listParams List
 		return nil, flterrors.ErrLimitParamOutOfBounds
 	}
 
-	query, err := ListQuery(&model.Device{}).Build(ctx, s.db, orgId, listParams)
+	query, err := ListQuery(&model.Device{}).Build(ctx, db, orgId, listParams)
 	if err != nil {
 		return nil, err
 	}
+	query = query.Scopes(scopes...)
 
 	if listParams.Limit > 0 {
 		// Request 1 more than the user asked for to see if we need to return "continue"
@@ -192,11 +266,11 @@ func (s *DeviceStore) List(ctx context.Context, orgId uuid.UUID, listParams List
 				numRemainingVal = 1
 			}
 		} else {
-			countQuery, err := ListQuery(&model.Device{}).Build(ctx, s.db, orgId, listParams)
+			countQuery, err := ListQuery(&model.Device{}).Build(ctx, db, orgId, listParams)
 			if err != nil {
 				return nil, err
 			}
-			numRemainingVal = CountRemainingItems(countQuery, nextContinueStruct.Name)
+			numRemainingVal = CountRemainingItems(countQuery.Scopes(scopes...), nextContinueStruct.Name)
 		}
 		nextContinueStruct.Count = numRemainingVal
 		contByte, _ := json.Marshal(nextContinueStruct)
@@ -210,20 +284,26 @@ func (s *DeviceStore) List(ctx context.Context, orgId uuid.UUID, listParams List
 }
 
 func (s *DeviceStore) Summary(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.DevicesSummary, error) {
-	query, err := ListQuery(&model.Device{}).Build(ctx, s.db, orgId, listParams)
-	if err != nil {
-		return nil, err
-	}
+	var (
+		devicesCount int64
+		statusCount  StatusCountList
+	)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		query, err := ListQuery(&model.Device{}).Build(ctx, tx, orgId, listParams)
+		if err != nil {
+			return err
+		}
 
-	var devicesCount int64
-	if err := query.Count(&devicesCount).Error; err != nil {
-		return nil, ErrorFromGormError(err)
-	}
+		if err := query.Count(&devicesCount).Error; err != nil {
+			return ErrorFromGormError(err)
+		}
 
-	statusCount, err := CountStatusList(ctx, query,
-		"status.applicationsSummary.status",
-		"status.summary.status",
-		"status.updated.status")
+		statusCount, err = CountStatusList(ctx, query,
+			"status.applicationsSummary.status",
+			"status.summary.status",
+			"status.updated.status")
+		return err
+	})
 	if err != nil {
 		return nil, ErrorFromGormError(err)
 	}
@@ -239,41 +319,145 @@ func (s *DeviceStore) Summary(ctx context.Context, orgId uuid.UUID, listParams L
 	}, nil
 }
 
-func (s *DeviceStore) DeleteAll(ctx context.Context, orgId uuid.UUID, callback DeviceStoreAllDeletedCallback) error {
-	condition := model.Device{}
-	result := s.db.Unscoped().Where("org_id = ?", orgId).Delete(&condition)
+// CountByLabel returns the number of devices matching the list parameters per value of the label
+// with the given key.
+func (s *DeviceStore) CountByLabel(ctx context.Context, orgId uuid.UUID, key string, listParams ListParams) (map[string]int64, error) {
+	var counts map[string]int64
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		query, err := ListQuery(&model.Device{}).Build(ctx, tx, orgId, listParams)
+		if err != nil {
+			return err
+		}
+		counts, err = CountLabelValues(ctx, query, &model.Device{}, key)
+		return err
+	})
+	return counts, err
+}
 
-	if result.Error != nil {
-		return ErrorFromGormError(result.Error)
+func (s *DeviceStore) DeleteAll(ctx context.Context, orgId uuid.UUID, callback DeviceStoreAllDeletedCallback) (int64, error) {
+	var deleted int64
+	err := OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		names, err := s.changes.pluckNames(innerTx, &model.Device{}, orgId)
+		if err != nil {
+			return err
+		}
+		condition := model.Device{}
+		result := innerTx.Unscoped().Where("org_id = ?", orgId).Delete(&condition)
+		if result.Error != nil {
+			return ErrorFromGormError(result.Error)
+		}
+		deleted = result.RowsAffected
+		if err := innerTx.Where("org_id = ?", orgId).Delete(&model.DeviceLogs{}).Error; err != nil {
+			return ErrorFromGormError(err)
+		}
+		if err := s.changes.captureDeleted(ctx, innerTx, api.DeviceKind, orgId, names...); err != nil {
+			return err
+		}
+		return callback(withTransaction(ctx, innerTx), orgId)
+	})
+	if err != nil {
+		return 0, err
 	}
-	callback(orgId)
+	return deleted, nil
+}
 
-	return nil
+// DeleteMatching deletes the devices matching the selectors of listParams, along with their
+// enrollment requests, in a single transaction. Each device is deleted in its own savepoint so
+// that a device that cannot be deleted is reported as a failure without rolling back the others.
+func (s *DeviceStore) DeleteMatching(ctx context.Context, orgId uuid.UUID, listParams ListParams, callback DeviceStoreCallback) (int64, []api.DeleteCollectionFailure, error) {
+	var deleted model.DeviceList
+	failures := []api.DeleteCollectionFailure{}
+	err := OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		var devices model.DeviceList
+		query, err := ListQuery(&model.Device{}).Build(ctx, innerTx, orgId, listParams)
+		if err != nil {
+			return err
+		}
+		if err := query.Find(&devices).Error; err != nil {
+			return ErrorFromGormError(err)
+		}
+
+		for i := range devices {
+			device := devices[i]
+			err := innerTx.Transaction(func(itemTx *gorm.DB) error {
+				if err := itemTx.Unscoped().Delete(&device).Error; err != nil {
+					return ErrorFromGormError(err)
+				}
+				associatedRecord := model.EnrollmentRequest{Resource: model.Resource{OrgID: orgId, Name: device.Name}}
+				if err := itemTx.Unscoped().Delete(&associatedRecord).Error; err != nil {
+					return ErrorFromGormError(err)
+				}
+				if err := itemTx.Delete(&model.DeviceLogs{OrgID: orgId, Name: device.Name}).Error; err != nil {
+					return ErrorFromGormError(err)
+				}
+				return s.changes.captureDeleted(ctx, itemTx, api.DeviceKind, orgId, device.Name)
+			})
+			if err != nil {
+				failures = append(failures, api.DeleteCollectionFailure{Name: device.Name, Message: err.Error()})
+				continue
+			}
+			deleted = append(deleted, device)
+		}
+
+		txCtx := withTransaction(ctx, innerTx)
+		for i := range deleted {
+			if err := callback(txCtx, &deleted[i], nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return int64(len(deleted)), failures, nil
 }
 
 func (s *DeviceStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Device, error) {
 	device := model.Device{
 		Resource: model.Resource{OrgID: orgId, Name: name},
 	}
-	result := s.db.First(&device)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.First(&device).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
 	}
 	apiDevice := device.ToApiResource()
 	return &apiDevice, nil
 }
 
-func (s *DeviceStore) createDevice(device *model.Device) (bool, error) {
+// GetMany returns the devices of the organization with the given names, in no particular order.
+// Names without a device are ignored.
+func (s *DeviceStore) GetMany(ctx context.Context, orgId uuid.UUID, names []string) ([]api.Device, error) {
+	var devices model.DeviceList
+	if len(names) == 0 {
+		return []api.Device{}, nil
+	}
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.Where("org_id = ? AND name IN ?", orgId, names).Find(&devices).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
+	}
+	apiDevices := make([]api.Device, 0, len(devices))
+	for _, device := range devices {
+		apiDevices = append(apiDevices, device.ToApiResource())
+	}
+	return apiDevices, nil
+}
+
+func (s *DeviceStore) createDevice(db *gorm.DB, device *model.Device) (bool, error) {
 	device.Generation = lo.ToPtr[int64](1)
 	device.ResourceVersion = lo.ToPtr[int64](1)
-	if result := s.db.Create(device); result.Error != nil {
+	if result := db.Create(device); result.Error != nil {
 		err := ErrorFromGormError(result.Error)
 		return err == flterrors.ErrDuplicateName, err
 	}
 	return false, nil
 }
 
-func (s *DeviceStore) updateDevice(fromAPI bool, existingRecord, device *model.Device, fieldsToUnset []string) (bool, error) {
+func (s *DeviceStore) updateDevice(db *gorm.DB, fromAPI bool, existingRecord, device *model.Device, fieldsToUnset []string) (bool, error) {
 	// do not update devices with a decommissionRequested, unless this was called via /api/v1/devices/{name}/decommission,
 	// in which case the fromAPI bool is set to false
 	if fromAPI && existingRecord.Spec != nil && existingRecord.Spec.Data.Decommissioning != nil {
@@ -305,7 +489,7 @@ func (s *DeviceStore) updateDevice(fromAPI bool, existingRecord, device *model.D
 	}
 	device.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
 	where := model.Device{Resource: model.Resource{OrgID: device.OrgID, Name: device.Name}}
-	query := s.db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
+	query := db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
 
 	selectFields := []string{"spec", "alias"}
 	selectFields = append(selectFields, GetNonNilFieldsFromResource(device.Resource)...)
@@ -321,7 +505,7 @@ func (s *DeviceStore) updateDevice(fromAPI bool, existingRecord, device *model.D
 	return false, nil
 }
 
-func (s *DeviceStore) createOrUpdate(orgId uuid.UUID, resource *api.Device, fieldsToUnset []string, fromAPI bool, mode CreateOrUpdateMode, callback DeviceStoreCallback) (*api.Device, bool, bool, error) {
+func (s *DeviceStore) createOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.Device, fieldsToUnset []string, fromAPI bool, mode CreateOrUpdateMode, callback DeviceStoreCallback) (*api.Device, bool, bool, error) {
 	if resource == nil {
 		return nil, false, false, flterrors.ErrResourceIsNil
 	}
@@ -343,7 +527,11 @@ func (s *DeviceStore) createOrUpdate(orgId uuid.UUID, resource *api.Device, fiel
 	// Use the dedicated API to update annotations
 	device.Annotations = nil
 
-	existingRecord, err := getExistingRecord[model.Device](s.db, device.Name, orgId)
+	var existingRecord *model.Device
+	err = OrgTransaction(s.db, orgId, func(tx *gorm.DB) (err error) {
+		existingRecord, err = getExistingRecord[model.Device](tx, device.Name, orgId)
+		return err
+	})
 	if err != nil {
 		return nil, false, false, err
 	}
@@ -357,25 +545,39 @@ func (s *DeviceStore) createOrUpdate(orgId uuid.UUID, resource *api.Device, fiel
 	}
 
 	s.IntegrationTestCreateOrUpdateCallback()
-	if !exists {
-		if retry, err := s.createDevice(device); err != nil {
-			return nil, false, retry, err
+	var retry bool
+	err = OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		if !exists {
+			if err := s.quotas.enforce(innerTx, orgId, api.DeviceKind); err != nil {
+				return err
+			}
+			retry, err = s.createDevice(innerTx, device)
+		} else {
+			retry, err = s.updateDevice(innerTx, fromAPI, existingRecord, device, fieldsToUnset)
 		}
-	} else {
-		if retry, err := s.updateDevice(fromAPI, existingRecord, device, fieldsToUnset); err != nil {
-			return nil, false, retry, err
+		if err != nil {
+			return err
 		}
+		updatedResource := device.ToApiResource()
+		if err := s.changes.capture(ctx, innerTx, createdOrUpdated(exists), api.DeviceKind, orgId, device.Name, updatedResource); err != nil {
+			return err
+		}
+		if err := recordRevision(ctx, innerTx, orgId, api.DeviceKind, device.Name, device.Generation, updatedResource.Spec); err != nil {
+			return err
+		}
+		return callback(withTransaction(ctx, innerTx), existingRecord, device)
+	})
+	if err != nil {
+		return nil, false, retry, err
 	}
 
-	callback(existingRecord, device)
-
 	updatedResource := device.ToApiResource()
 	return &updatedResource, !exists, false, nil
 }
 
 func (s *DeviceStore) CreateOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.Device, fieldsToUnset []string, fromAPI bool, callback DeviceStoreCallback) (*api.Device, bool, error) {
 	return retryCreateOrUpdate(func() (*api.Device, bool, bool, error) {
-		return s.createOrUpdate(orgId, resource, fieldsToUnset, fromAPI, ModeCreateOrUpdate, callback)
+		return s.createOrUpdate(ctx, orgId, resource, fieldsToUnset, fromAPI, ModeCreateOrUpdate, callback)
 	})
 }
 
@@ -406,7 +608,12 @@ func (s *DeviceStore) UpdateSummaryStatusBatch(ctx context.Context, orgId uuid.U
 		args[i] = name
 	}
 
-	return s.db.WithContext(ctx).Exec(query, args...).Error
+	return OrgTransaction(s.db.WithContext(ctx), orgId, func(innerTx *gorm.DB) error {
+		if err := innerTx.Exec(query, args...).Error; err != nil {
+			return err
+		}
+		return s.captureUpdated(ctx, innerTx, orgId, deviceNames...)
+	})
 }
 
 func (s *DeviceStore) UpdateStatus(ctx context.Context, orgId uuid.UUID, resource *api.Device) (*api.Device, error) {
@@ -419,17 +626,55 @@ func (s *DeviceStore) UpdateStatus(ctx context.Context, orgId uuid.UUID, resourc
 	device := model.Device{
 		Resource: model.Resource{OrgID: orgId, Name: *resource.Metadata.Name},
 	}
-	result := s.db.Model(&device).Updates(map[string]interface{}{
-		"status":           model.MakeJSONField(resource.Status),
-		"resource_version": gorm.Expr("resource_version + 1"),
+	_, err := s.updateCaptured(ctx, orgId, device.Name, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&device).Updates(map[string]interface{}{
+			"status":           model.MakeJSONField(resource.Status),
+			"resource_version": gorm.Expr("resource_version + 1"),
+		})
+	})
+	return resource, ErrorFromGormError(err)
+}
+
+// updateCaptured runs update in a transaction, in which it captures the update of the named device
+// if update updated it. It returns the number of rows that update updated.
+func (s *DeviceStore) updateCaptured(ctx context.Context, orgId uuid.UUID, name string, update func(tx *gorm.DB) *gorm.DB) (int64, error) {
+	var rowsAffected int64
+	err := OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		result := update(innerTx)
+		if result.Error != nil {
+			return result.Error
+		}
+		rowsAffected = result.RowsAffected
+		if rowsAffected == 0 {
+			return nil
+		}
+		return s.captureUpdated(ctx, innerTx, orgId, name)
 	})
-	return resource, ErrorFromGormError(result.Error)
+	return rowsAffected, err
+}
+
+// captureUpdated captures the updates of the named devices in tx, as read back from tx. It does not
+// query anything when nothing is captured.
+func (s *DeviceStore) captureUpdated(ctx context.Context, tx *gorm.DB, orgId uuid.UUID, names ...string) error {
+	if !s.changes.enabled() || len(names) == 0 {
+		return nil
+	}
+	var devices []model.Device
+	if err := tx.Where("org_id = ? AND name IN ?", orgId, names).Order("name").Find(&devices).Error; err != nil {
+		return ErrorFromGormError(err)
+	}
+	for i := range devices {
+		if err := s.changes.capture(ctx, tx, ChangeUpdated, api.DeviceKind, orgId, devices[i].Name, devices[i].ToApiResource()); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *DeviceStore) Delete(ctx context.Context, orgId uuid.UUID, name string, callback DeviceStoreCallback) error {
 	var existingRecord model.Device
 	log := log.WithReqIDFromCtx(ctx, s.log)
-	err := s.db.Transaction(func(innerTx *gorm.DB) (err error) {
+	err := OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) (err error) {
 		existingRecord = model.Device{Resource: model.Resource{OrgID: orgId, Name: name}}
 		result := innerTx.First(&existingRecord)
 		if result.Error != nil {
@@ -446,7 +691,15 @@ func (s *DeviceStore) Delete(ctx context.Context, orgId uuid.UUID, name string,
 			log.Warningf("failed to delete associated enrollment request: %v", err)
 		}
 
-		return nil
+		if err := innerTx.Delete(&model.DeviceLogs{OrgID: orgId, Name: name}).Error; err != nil {
+			log.Warningf("failed to delete associated device logs: %v", err)
+		}
+
+		if err := s.changes.captureDeleted(ctx, innerTx, api.DeviceKind, orgId, name); err != nil {
+			return err
+		}
+
+		return callback(withTransaction(ctx, innerTx), &existingRecord, nil)
 	})
 
 	if err != nil {
@@ -455,16 +708,16 @@ func (s *DeviceStore) Delete(ctx context.Context, orgId uuid.UUID, name string,
 		}
 		return err
 	}
-
-	callback(&existingRecord, nil)
 	return nil
 }
 
-func (s *DeviceStore) updateAnnotations(orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) (bool, error) {
+func (s *DeviceStore) updateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) (bool, error) {
 	existingRecord := model.Device{Resource: model.Resource{OrgID: orgId, Name: name}}
-	result := s.db.First(&existingRecord)
-	if result.Error != nil {
-		return false, ErrorFromGormError(result.Error)
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.First(&existingRecord).Error
+	})
+	if err != nil {
+		return false, ErrorFromGormError(err)
 	}
 	existingAnnotations := util.EnsureMap(existingRecord.Annotations)
 
@@ -486,16 +739,17 @@ func (s *DeviceStore) updateAnnotations(orgId uuid.UUID, name string, annotation
 		existingAnnotations[api.DeviceAnnotationRenderedVersion] = nextRenderedVersion
 	}
 
-	result = s.db.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
-		"annotations":      model.MakeJSONMap(existingAnnotations),
-		"resource_version": gorm.Expr("resource_version + 1"),
+	rowsAffected, err := s.updateCaptured(ctx, orgId, name, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
+			"annotations":      model.MakeJSONMap(existingAnnotations),
+			"resource_version": gorm.Expr("resource_version + 1"),
+		})
 	})
-
-	err := ErrorFromGormError(result.Error)
+	err = ErrorFromGormError(err)
 	if err != nil {
 		return strings.Contains(err.Error(), "deadlock"), err
 	}
-	if result.RowsAffected == 0 {
+	if rowsAffected == 0 {
 		return true, flterrors.ErrNoRowsUpdated
 	}
 	return false, nil
@@ -503,15 +757,17 @@ func (s *DeviceStore) updateAnnotations(orgId uuid.UUID, name string, annotation
 
 func (s *DeviceStore) UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error {
 	return retryUpdate(func() (bool, error) {
-		return s.updateAnnotations(orgId, name, annotations, deleteKeys)
+		return s.updateAnnotations(ctx, orgId, name, annotations, deleteKeys)
 	})
 }
 
-func (s *DeviceStore) updateRendered(orgId uuid.UUID, name, renderedConfig, renderedApplications string) (retry bool, err error) {
+func (s *DeviceStore) updateRendered(ctx context.Context, orgId uuid.UUID, name, renderedConfig, renderedApplications string) (retry bool, err error) {
 	existingRecord := model.Device{Resource: model.Resource{OrgID: orgId, Name: name}}
-	result := s.db.First(&existingRecord)
-	if result.Error != nil {
-		return false, ErrorFromGormError(result.Error)
+	err = OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.First(&existingRecord).Error
+	})
+	if err != nil {
+		return false, ErrorFromGormError(err)
 	}
 	existingAnnotations := util.EnsureMap(existingRecord.Annotations)
 
@@ -521,24 +777,31 @@ func (s *DeviceStore) updateRendered(orgId uuid.UUID, name, renderedConfig, rend
 	}
 
 	existingAnnotations[api.DeviceAnnotationRenderedVersion] = nextRenderedVersion
+	// keep the ID of the request that caused the rendering, so that the agent can log it
+	if requestID := middleware.GetReqID(ctx); requestID != "" {
+		existingAnnotations[api.DeviceAnnotationRenderedRequestID] = requestID
+	} else {
+		delete(existingAnnotations, api.DeviceAnnotationRenderedRequestID)
+	}
 
 	renderedApplicationsJSON := renderedApplications
 	if strings.TrimSpace(renderedApplications) == "" {
 		renderedApplicationsJSON = "[]"
 	}
 
-	result = s.db.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
-		"annotations":           model.MakeJSONMap(existingAnnotations),
-		"rendered_config":       &renderedConfig,
-		"rendered_applications": &renderedApplicationsJSON,
-		"resource_version":      gorm.Expr("resource_version + 1"),
+	rowsAffected, err := s.updateCaptured(ctx, orgId, name, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
+			"annotations":           model.MakeJSONMap(existingAnnotations),
+			"rendered_config":       &renderedConfig,
+			"rendered_applications": &renderedApplicationsJSON,
+			"resource_version":      gorm.Expr("resource_version + 1"),
+		})
 	})
-
-	err = ErrorFromGormError(result.Error)
+	err = ErrorFromGormError(err)
 	if err != nil {
 		return strings.Contains(err.Error(), "deadlock"), err
 	}
-	if result.RowsAffected == 0 {
+	if rowsAffected == 0 {
 		return true, flterrors.ErrNoRowsUpdated
 	}
 	return false, nil
@@ -561,7 +824,7 @@ func getNextRenderedVersion(annotations map[string]string) (string, error) {
 
 func (s *DeviceStore) UpdateRendered(ctx context.Context, orgId uuid.UUID, name, renderedConfig, renderedApplications string) error {
 	return retryUpdate(func() (bool, error) {
-		return s.updateRendered(orgId, name, renderedConfig, renderedApplications)
+		return s.updateRendered(ctx, orgId, name, renderedConfig, renderedApplications)
 	})
 }
 
@@ -569,9 +832,11 @@ func (s *DeviceStore) GetRendered(ctx context.Context, orgId uuid.UUID, name str
 	device := model.Device{
 		Resource: model.Resource{OrgID: orgId, Name: name},
 	}
-	result := s.db.First(&device)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.First(&device).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
 	}
 
 	annotations := util.EnsureMap(device.Annotations)
@@ -606,15 +871,20 @@ func (s *DeviceStore) GetRendered(ctx context.Context, orgId uuid.UUID, name str
 		UpdatePolicy:    device.Spec.Data.UpdatePolicy,
 		Decommission:    device.Spec.Data.Decommissioning,
 	}
+	if requestID, ok := annotations[api.DeviceAnnotationRenderedRequestID]; ok {
+		renderedConfig.RequestID = &requestID
+	}
 
 	return &renderedConfig, nil
 }
 
-func (s *DeviceStore) setServiceConditions(orgId uuid.UUID, name string, conditions []api.Condition) (retry bool, err error) {
+func (s *DeviceStore) setServiceConditions(ctx context.Context, orgId uuid.UUID, name string, conditions []api.Condition) (retry bool, err error) {
 	existingRecord := model.Device{Resource: model.Resource{OrgID: orgId, Name: name}}
-	result := s.db.First(&existingRecord)
-	if result.Error != nil {
-		return false, ErrorFromGormError(result.Error)
+	err = OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.First(&existingRecord).Error
+	})
+	if err != nil {
+		return false, ErrorFromGormError(err)
 	}
 
 	if existingRecord.ServiceConditions == nil {
@@ -628,15 +898,17 @@ func (s *DeviceStore) setServiceConditions(orgId uuid.UUID, name string, conditi
 		api.SetStatusCondition(existingRecord.ServiceConditions.Data.Conditions, condition)
 	}
 
-	result = s.db.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
-		"service_conditions": existingRecord.ServiceConditions,
-		"resource_version":   gorm.Expr("resource_version + 1"),
+	rowsAffected, err := s.updateCaptured(ctx, orgId, name, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
+			"service_conditions": existingRecord.ServiceConditions,
+			"resource_version":   gorm.Expr("resource_version + 1"),
+		})
 	})
-	err = ErrorFromGormError(result.Error)
+	err = ErrorFromGormError(err)
 	if err != nil {
 		return strings.Contains(err.Error(), "deadlock"), err
 	}
-	if result.RowsAffected == 0 {
+	if rowsAffected == 0 {
 		return true, flterrors.ErrNoRowsUpdated
 	}
 	return false, nil
@@ -644,7 +916,7 @@ func (s *DeviceStore) setServiceConditions(orgId uuid.UUID, name string, conditi
 
 func (s *DeviceStore) SetServiceConditions(ctx context.Context, orgId uuid.UUID, name string, conditions []api.Condition) error {
 	return retryUpdate(func() (bool, error) {
-		return s.setServiceConditions(orgId, name, conditions)
+		return s.setServiceConditions(ctx, orgId, name, conditions)
 	})
 }
 
@@ -653,7 +925,7 @@ func (s *DeviceStore) OverwriteRepositoryRefs(ctx context.Context, orgId uuid.UU
 	for _, repoName := range repositoryNames {
 		repos = append(repos, model.Repository{Resource: model.Resource{OrgID: orgId, Name: repoName}})
 	}
-	return s.db.Transaction(func(innerTx *gorm.DB) error {
+	return OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
 		device := model.Device{Resource: model.Resource{OrgID: orgId, Name: name}}
 		if err := innerTx.Model(&device).Association("Repositories").Replace(repos); err != nil {
 			return ErrorFromGormError(err)
@@ -665,7 +937,9 @@ func (s *DeviceStore) OverwriteRepositoryRefs(ctx context.Context, orgId uuid.UU
 func (s *DeviceStore) GetRepositoryRefs(ctx context.Context, orgId uuid.UUID, name string) (*api.RepositoryList, error) {
 	device := model.Device{Resource: model.Resource{OrgID: orgId, Name: name}}
 	var repos model.RepositoryList
-	err := s.db.Model(&device).Association("Repositories").Find(&repos)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.Model(&device).Association("Repositories").Find(&repos)
+	})
 	if err != nil {
 		return nil, ErrorFromGormError(err)
 	}
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceLogs interface {
+	InitialMigration() error
+	Get(ctx context.Context, orgId uuid.UUID, name string) (*api.DeviceLogs, error)
+	Replace(ctx context.Context, orgId uuid.UUID, name string, logs *api.DeviceLogs) (*api.DeviceLogs, error)
+}
+
+type DeviceLogsStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to DeviceLogs interface
+var _ DeviceLogs = (*DeviceLogsStore)(nil)
+
+func NewDeviceLogs(db *gorm.DB, log logrus.FieldLogger) DeviceLogs {
+	return &DeviceLogsStore{db: db, log: log}
+}
+
+func (s *DeviceLogsStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.DeviceLogs{})
+}
+
+// Get returns the logs most recently shipped by the agent of a device.
+func (s *DeviceLogsStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.DeviceLogs, error) {
+	logs := model.DeviceLogs{OrgID: orgId, Name: name}
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.First(&logs).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
+	}
+	apiLogs := logs.ToApiResource()
+	return &apiLogs, nil
+}
+
+// Replace stores the logs shipped by the agent of a device in place of the previous ones.
+func (s *DeviceLogsStore) Replace(ctx context.Context, orgId uuid.UUID, name string, logs *api.DeviceLogs) (*api.DeviceLogs, error) {
+	if logs == nil {
+		return nil, flterrors.ErrResourceIsNil
+	}
+	record := model.NewDeviceLogsFromApiResource(orgId, name, logs)
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(record).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
+	}
+	apiLogs := record.ToApiResource()
+	return &apiLogs, nil
+}
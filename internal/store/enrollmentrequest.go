@@ -87,6 +87,15 @@ func (s *EnrollmentRequestStore) Update(ctx context.Context, orgId uuid.UUID, re
 }
 
 func (s *EnrollmentRequestStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.EnrollmentRequestList, error) {
+	var list *api.EnrollmentRequestList
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) (err error) {
+		list, err = s.list(ctx, tx, orgId, listParams)
+		return err
+	})
+	return list, err
+}
+
+func (s *EnrollmentRequestStore) list(ctx context.Context, db *gorm.DB, orgId uuid.UUID, listParams ListParams) (*api.EnrollmentRequestList, error) {
 	var enrollmentRequests model.EnrollmentRequestList
 	var nextContinue *string
 	var numRemaining *int64
@@ -95,7 +104,7 @@ func (s *EnrollmentRequestStore) List(ctx context.Context, orgId uuid.UUID, list
 		return nil, flterrors.ErrLimitParamOutOfBounds
 	}
 
-	query, err := ListQuery(&model.EnrollmentRequest{}).Build(ctx, s.db, orgId, listParams)
+	query, err := ListQuery(&model.EnrollmentRequest{}).Build(ctx, db, orgId, listParams)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +130,7 @@ func (s *EnrollmentRequestStore) List(ctx context.Context, orgId uuid.UUID, list
 				numRemainingVal = 1
 			}
 		} else {
-			countQuery, err := ListQuery(&model.EnrollmentRequest{}).Build(ctx, s.db, orgId, listParams)
+			countQuery, err := ListQuery(&model.EnrollmentRequest{}).Build(ctx, db, orgId, listParams)
 			if err != nil {
 				return nil, err
 			}
@@ -140,33 +149,37 @@ func (s *EnrollmentRequestStore) List(ctx context.Context, orgId uuid.UUID, list
 
 func (s *EnrollmentRequestStore) DeleteAll(ctx context.Context, orgId uuid.UUID) error {
 	condition := model.EnrollmentRequest{}
-	result := s.db.Unscoped().Where("org_id = ?", orgId).Delete(&condition)
-	return ErrorFromGormError(result.Error)
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.Unscoped().Where("org_id = ?", orgId).Delete(&condition).Error
+	})
+	return ErrorFromGormError(err)
 }
 
 func (s *EnrollmentRequestStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.EnrollmentRequest, error) {
 	enrollmentRequest := model.EnrollmentRequest{
 		Resource: model.Resource{OrgID: orgId, Name: name},
 	}
-	result := s.db.First(&enrollmentRequest)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.First(&enrollmentRequest).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
 	}
 	apiEnrollmentRequest := enrollmentRequest.ToApiResource()
 	return &apiEnrollmentRequest, nil
 }
 
-func (s *EnrollmentRequestStore) createEnrollmentRequest(enrollmentRequest *model.EnrollmentRequest) (bool, error) {
+func (s *EnrollmentRequestStore) createEnrollmentRequest(db *gorm.DB, enrollmentRequest *model.EnrollmentRequest) (bool, error) {
 	enrollmentRequest.Generation = lo.ToPtr[int64](1)
 	enrollmentRequest.ResourceVersion = lo.ToPtr[int64](1)
-	if result := s.db.Create(enrollmentRequest); result.Error != nil {
+	if result := db.Create(enrollmentRequest); result.Error != nil {
 		err := ErrorFromGormError(result.Error)
 		return err == flterrors.ErrDuplicateName, err
 	}
 	return false, nil
 }
 
-func (s *EnrollmentRequestStore) updateEnrollmentRequest(existingRecord, enrollmentRequest *model.EnrollmentRequest) (bool, error) {
+func (s *EnrollmentRequestStore) updateEnrollmentRequest(db *gorm.DB, existingRecord, enrollmentRequest *model.EnrollmentRequest) (bool, error) {
 	updateSpec := enrollmentRequest.Spec != nil && !reflect.DeepEqual(existingRecord.Spec, enrollmentRequest.Spec)
 
 	// Update the generation if the spec was updated
@@ -178,7 +191,7 @@ func (s *EnrollmentRequestStore) updateEnrollmentRequest(existingRecord, enrollm
 	}
 	enrollmentRequest.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
 	where := model.EnrollmentRequest{Resource: model.Resource{OrgID: enrollmentRequest.OrgID, Name: enrollmentRequest.Name}}
-	query := s.db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
+	query := db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
 
 	result := query.Updates(&enrollmentRequest)
 	if result.Error != nil {
@@ -206,27 +219,30 @@ func (s *EnrollmentRequestStore) createOrUpdate(orgId uuid.UUID, resource *api.E
 	enrollmentrequest.Status = nil
 	enrollmentrequest.Annotations = nil
 
-	existingRecord, err := getExistingRecord[model.EnrollmentRequest](s.db, enrollmentrequest.Name, orgId)
-	if err != nil {
-		return nil, false, false, err
-	}
-	exists := existingRecord != nil
-
-	if exists && mode == ModeCreateOnly {
-		return nil, false, false, flterrors.ErrDuplicateName
-	}
-	if !exists && mode == ModeUpdateOnly {
-		return nil, false, false, flterrors.ErrResourceNotFound
-	}
+	var exists, retry bool
+	err = OrgTransaction(s.db, orgId, func(tx *gorm.DB) error {
+		existingRecord, err := getExistingRecord[model.EnrollmentRequest](tx, enrollmentrequest.Name, orgId)
+		if err != nil {
+			return err
+		}
+		exists = existingRecord != nil
 
-	if !exists {
-		if retry, err := s.createEnrollmentRequest(enrollmentrequest); err != nil {
-			return nil, false, retry, err
+		if exists && mode == ModeCreateOnly {
+			return flterrors.ErrDuplicateName
 		}
-	} else {
-		if retry, err := s.updateEnrollmentRequest(existingRecord, enrollmentrequest); err != nil {
-			return nil, false, retry, err
+		if !exists && mode == ModeUpdateOnly {
+			return flterrors.ErrResourceNotFound
 		}
+
+		if !exists {
+			retry, err = s.createEnrollmentRequest(tx, enrollmentrequest)
+		} else {
+			retry, err = s.updateEnrollmentRequest(tx, existingRecord, enrollmentrequest)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, false, retry, err
 	}
 
 	updatedResource := enrollmentrequest.ToApiResource()
@@ -249,20 +265,24 @@ func (s *EnrollmentRequestStore) UpdateStatus(ctx context.Context, orgId uuid.UU
 	enrollmentRequest := model.EnrollmentRequest{
 		Resource: model.Resource{OrgID: orgId, Name: *resource.Metadata.Name},
 	}
-	result := s.db.Model(&enrollmentRequest).Updates(map[string]interface{}{
-		"status":           model.MakeJSONField(resource.Status),
-		"resource_version": gorm.Expr("resource_version + 1"),
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.Model(&enrollmentRequest).Updates(map[string]interface{}{
+			"status":           model.MakeJSONField(resource.Status),
+			"resource_version": gorm.Expr("resource_version + 1"),
+		}).Error
 	})
-	return resource, ErrorFromGormError(result.Error)
+	return resource, ErrorFromGormError(err)
 }
 
 func (s *EnrollmentRequestStore) Delete(ctx context.Context, orgId uuid.UUID, name string) error {
 	condition := model.EnrollmentRequest{
 		Resource: model.Resource{OrgID: orgId, Name: name},
 	}
-	result := s.db.Unscoped().Delete(&condition)
-	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.Unscoped().Delete(&condition).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil
 	}
-	return ErrorFromGormError(result.Error)
+	return ErrorFromGormError(err)
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Fleet interface {
@@ -34,6 +35,7 @@ type Fleet interface {
 	UnsetOwnerByKind(ctx context.Context, tx *gorm.DB, orgId uuid.UUID, resourceKind string) error
 	ListIgnoreOrg() ([]model.Fleet, error)
 	UpdateConditions(ctx context.Context, orgId uuid.UUID, name string, conditions []api.Condition) error
+	UpdateRolloutStatus(ctx context.Context, orgId uuid.UUID, name string, rollout *api.FleetRolloutStatus) error
 	UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error
 	OverwriteRepositoryRefs(ctx context.Context, orgId uuid.UUID, name string, repositoryNames ...string) error
 	GetRepositoryRefs(ctx context.Context, orgId uuid.UUID, name string) (*api.RepositoryList, error)
@@ -41,18 +43,24 @@ type Fleet interface {
 }
 
 type FleetStore struct {
-	db  *gorm.DB
-	log logrus.FieldLogger
+	db      *gorm.DB
+	log     logrus.FieldLogger
+	changes *changeCapture
+	quotas  *quotas
 }
 
-type FleetStoreCallback func(before *model.Fleet, after *model.Fleet)
-type FleetStoreAllDeletedCallback func(orgId uuid.UUID)
+type FleetStoreCallback func(ctx context.Context, before *model.Fleet, after *model.Fleet) error
+type FleetStoreAllDeletedCallback func(ctx context.Context, orgId uuid.UUID) error
 
 // Make sure we conform to Fleet interface
 var _ Fleet = (*FleetStore)(nil)
 
 func NewFleet(db *gorm.DB, log logrus.FieldLogger) Fleet {
-	return &FleetStore{db: db, log: log}
+	return newFleet(db, log, nil, nil)
+}
+
+func newFleet(db *gorm.DB, log logrus.FieldLogger, changes *changeCapture, quotas *quotas) Fleet {
+	return &FleetStore{db: db, log: log, changes: changes, quotas: quotas}
 }
 
 func (s *FleetStore) InitialMigration() error {
@@ -90,13 +98,13 @@ func (s *FleetStore) InitialMigration() error {
 }
 
 func (s *FleetStore) Create(ctx context.Context, orgId uuid.UUID, resource *api.Fleet, callback FleetStoreCallback) (*api.Fleet, error) {
-	updatedResource, _, _, err := s.createOrUpdate(orgId, resource, ModeCreateOnly, callback)
+	updatedResource, _, _, err := s.createOrUpdate(ctx, orgId, resource, ModeCreateOnly, callback)
 	return updatedResource, err
 }
 
 func (s *FleetStore) Update(ctx context.Context, orgId uuid.UUID, resource *api.Fleet, callback FleetStoreCallback) (*api.Fleet, error) {
 	updatedResource, _, err := retryCreateOrUpdate(func() (*api.Fleet, bool, bool, error) {
-		return s.createOrUpdate(orgId, resource, ModeUpdateOnly, callback)
+		return s.createOrUpdate(ctx, orgId, resource, ModeUpdateOnly, callback)
 	})
 	return updatedResource, err
 }
@@ -125,6 +133,15 @@ func fleetSelectStr(withDeviceCount bool) string {
 }
 
 func (s *FleetStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams, opts ...ListOption) (*api.FleetList, error) {
+	var list *api.FleetList
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) (err error) {
+		list, err = s.list(ctx, tx, orgId, listParams, opts...)
+		return err
+	})
+	return list, err
+}
+
+func (s *FleetStore) list(ctx context.Context, db *gorm.DB, orgId uuid.UUID, listParams ListParams, opts ...ListOption) (*api.FleetList, error) {
 	var fleetsWithCount []fleetWithCount
 	var nextContinue *string
 	var numRemaining *int64
@@ -135,7 +152,7 @@ func (s *FleetStore) List(ctx context.Context, orgId uuid.UUID, listParams ListP
 	}
 
 	lo.ForEach(opts, func(opt ListOption, _ int) { opt(&options) })
-	query, err := ListQuery(&model.Fleet{}).Build(ctx, s.db, orgId, listParams)
+	query, err := ListQuery(&model.Fleet{}).Build(ctx, db, orgId, listParams)
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +179,7 @@ func (s *FleetStore) List(ctx context.Context, orgId uuid.UUID, listParams ListP
 				numRemainingVal = 1
 			}
 		} else {
-			countQuery, err := ListQuery(&model.Fleet{}).Build(ctx, s.db, orgId, listParams)
+			countQuery, err := ListQuery(&model.Fleet{}).Build(ctx, db, orgId, listParams)
 			if err != nil {
 				return nil, err
 			}
@@ -192,20 +209,30 @@ func (s *FleetStore) List(ctx context.Context, orgId uuid.UUID, listParams ListP
 func (s *FleetStore) ListIgnoreOrg() ([]model.Fleet, error) {
 	var fleets model.FleetList
 
-	result := s.db.Model(&fleets).Find(&fleets)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
+	err := OrgBypassTransaction(s.db, func(tx *gorm.DB) error {
+		return tx.Model(&fleets).Find(&fleets).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
 	}
 	return fleets, nil
 }
 
 func (s *FleetStore) DeleteAll(ctx context.Context, orgId uuid.UUID, callback FleetStoreAllDeletedCallback) error {
-	condition := model.Fleet{}
-	result := s.db.Unscoped().Where("org_id = ?", orgId).Delete(&condition)
-	if result.Error == nil {
-		callback(orgId)
-	}
-	return ErrorFromGormError(result.Error)
+	return OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		names, err := s.changes.pluckNames(innerTx, &model.Fleet{}, orgId)
+		if err != nil {
+			return err
+		}
+		condition := model.Fleet{}
+		if err := innerTx.Unscoped().Where("org_id = ?", orgId).Delete(&condition).Error; err != nil {
+			return ErrorFromGormError(err)
+		}
+		if err := s.changes.captureDeleted(ctx, innerTx, api.FleetKind, orgId, names...); err != nil {
+			return err
+		}
+		return callback(withTransaction(ctx, innerTx), orgId)
+	})
 }
 
 type GetOption func(*getOptions)
@@ -226,39 +253,48 @@ func (s *FleetStore) Get(ctx context.Context, orgId uuid.UUID, name string, opts
 		opt(&options)
 	}
 
-	var fleet fleetWithCount
-	result := s.db.Table("fleets").Where("org_id = ? and name = ?", orgId, name).
-		Select(fleetSelectStr(true)).
-		Scan(&fleet)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
-	} else if result.RowsAffected == 0 {
-		return nil, flterrors.ErrResourceNotFound
-	}
+	var (
+		fleet       fleetWithCount
+		statusCount StatusCountList
+	)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		result := tx.Table("fleets").Where("org_id = ? and name = ?", orgId, name).
+			Select(fleetSelectStr(true)).
+			Scan(&fleet)
+		if result.Error != nil {
+			return ErrorFromGormError(result.Error)
+		} else if result.RowsAffected == 0 {
+			return flterrors.ErrResourceNotFound
+		}
+		if !options.withSummary {
+			return nil
+		}
 
-	summary := api.DevicesSummary{
-		Total: fleet.DeviceCount,
-	}
-	if options.withSummary {
 		fs, err := selector.NewFieldSelectorFromMap(
 			map[string]string{"metadata.owner": *util.SetResourceOwner(api.FleetKind, name)}, false)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		deviceQuery, err := ListQuery(&model.Device{}).Build(ctx, s.db, orgId, ListParams{FieldSelector: fs})
+		deviceQuery, err := ListQuery(&model.Device{}).Build(ctx, tx, orgId, ListParams{FieldSelector: fs})
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		statusCount, err := CountStatusList(ctx, deviceQuery,
+		statusCount, err = CountStatusList(ctx, deviceQuery,
 			"status.applicationsSummary.status",
 			"status.summary.status",
 			"status.updated.status")
-		if err != nil {
-			return nil, ErrorFromGormError(err)
-		}
+		return ErrorFromGormError(err)
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	summary := api.DevicesSummary{
+		Total: fleet.DeviceCount,
+	}
+	if options.withSummary {
 		applicationStatus := statusCount.List("status.applicationsSummary.status")
 		summary.ApplicationStatus = applicationStatus
 
@@ -273,21 +309,21 @@ func (s *FleetStore) Get(ctx context.Context, orgId uuid.UUID, name string, opts
 	return &apiFleet, nil
 }
 
-func (s *FleetStore) createFleet(fleet *model.Fleet) (bool, error) {
+func (s *FleetStore) createFleet(db *gorm.DB, fleet *model.Fleet) (bool, error) {
 	if fleet.Spec.Data.Template.Metadata == nil {
 		fleet.Spec.Data.Template.Metadata = &api.ObjectMeta{}
 	}
 	fleet.Spec.Data.Template.Metadata.Generation = lo.ToPtr[int64](1)
 	fleet.Generation = lo.ToPtr[int64](1)
 	fleet.ResourceVersion = lo.ToPtr[int64](1)
-	if result := s.db.Create(fleet); result.Error != nil {
+	if result := db.Create(fleet); result.Error != nil {
 		err := ErrorFromGormError(result.Error)
 		return err == flterrors.ErrDuplicateName, err
 	}
 	return false, nil
 }
 
-func (s *FleetStore) updateFleet(existingRecord, fleet *model.Fleet) (bool, error) {
+func (s *FleetStore) updateFleet(db *gorm.DB, existingRecord, fleet *model.Fleet) (bool, error) {
 	if existingRecord.Owner != nil && *existingRecord.Owner != lo.FromPtr(fleet.Owner) {
 		return false, flterrors.ErrUpdatingResourceWithOwnerNotAllowed
 	}
@@ -312,7 +348,7 @@ func (s *FleetStore) updateFleet(existingRecord, fleet *model.Fleet) (bool, erro
 
 	fleet.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
 
-	query := s.db.Model(&model.Fleet{}).Where("org_id = ? and name = ? and resource_version = ?", fleet.OrgID, fleet.Name, lo.FromPtr(existingRecord.ResourceVersion))
+	query := db.Model(&model.Fleet{}).Where("org_id = ? and name = ? and resource_version = ?", fleet.OrgID, fleet.Name, lo.FromPtr(existingRecord.ResourceVersion))
 
 	selectFields := []string{"spec"}
 	selectFields = append(selectFields, GetNonNilFieldsFromResource(fleet.Resource)...)
@@ -327,7 +363,7 @@ func (s *FleetStore) updateFleet(existingRecord, fleet *model.Fleet) (bool, erro
 	return false, nil
 }
 
-func (s *FleetStore) createOrUpdate(orgId uuid.UUID, resource *api.Fleet, mode CreateOrUpdateMode, callback FleetStoreCallback) (*api.Fleet, bool, bool, error) {
+func (s *FleetStore) createOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.Fleet, mode CreateOrUpdateMode, callback FleetStoreCallback) (*api.Fleet, bool, bool, error) {
 	if resource == nil {
 		return nil, false, false, flterrors.ErrResourceIsNil
 	}
@@ -346,38 +382,55 @@ func (s *FleetStore) createOrUpdate(orgId uuid.UUID, resource *api.Fleet, mode C
 
 	fleet.Owner = resource.Metadata.Owner
 
-	existingRecord, err := getExistingRecord[model.Fleet](s.db, fleet.Name, orgId)
-	if err != nil {
-		return nil, false, false, err
-	}
-	exists := existingRecord != nil
+	var (
+		existingRecord *model.Fleet
+		exists, retry  bool
+	)
+	err = OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		existingRecord, err = getExistingRecord[model.Fleet](innerTx, fleet.Name, orgId)
+		if err != nil {
+			return err
+		}
+		exists = existingRecord != nil
 
-	if exists && mode == ModeCreateOnly {
-		return nil, false, false, flterrors.ErrDuplicateName
-	}
-	if !exists && mode == ModeUpdateOnly {
-		return nil, false, false, flterrors.ErrResourceNotFound
-	}
+		if exists && mode == ModeCreateOnly {
+			return flterrors.ErrDuplicateName
+		}
+		if !exists && mode == ModeUpdateOnly {
+			return flterrors.ErrResourceNotFound
+		}
 
-	if !exists {
-		if retry, err := s.createFleet(fleet); err != nil {
-			return nil, false, retry, err
+		if !exists {
+			if err := s.quotas.enforce(innerTx, orgId, api.FleetKind); err != nil {
+				return err
+			}
+			retry, err = s.createFleet(innerTx, fleet)
+		} else {
+			retry, err = s.updateFleet(innerTx, existingRecord, fleet)
 		}
-	} else {
-		if retry, err := s.updateFleet(existingRecord, fleet); err != nil {
-			return nil, false, retry, err
+		if err != nil {
+			return err
+		}
+		updatedResource := fleet.ToApiResource()
+		if err := s.changes.capture(ctx, innerTx, createdOrUpdated(exists), api.FleetKind, orgId, fleet.Name, updatedResource); err != nil {
+			return err
 		}
+		if err := recordRevision(ctx, innerTx, orgId, api.FleetKind, fleet.Name, fleet.Generation, updatedResource.Spec); err != nil {
+			return err
+		}
+		return callback(withTransaction(ctx, innerTx), existingRecord, fleet)
+	})
+	if err != nil {
+		return nil, false, retry, err
 	}
 
-	callback(existingRecord, fleet)
-
 	updatedResource := fleet.ToApiResource()
 	return &updatedResource, !exists, false, nil
 }
 
 func (s *FleetStore) CreateOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.Fleet, callback FleetStoreCallback) (*api.Fleet, bool, error) {
 	return retryCreateOrUpdate(func() (*api.Fleet, bool, bool, error) {
-		return s.createOrUpdate(orgId, resource, ModeCreateOrUpdate, callback)
+		return s.createOrUpdate(ctx, orgId, resource, ModeCreateOrUpdate, callback)
 	})
 }
 
@@ -394,19 +447,19 @@ func (s *FleetStore) CreateOrUpdateMultiple(ctx context.Context, orgId uuid.UUID
 }
 
 func (s *FleetStore) UpdateStatus(ctx context.Context, orgId uuid.UUID, resource *api.Fleet) (*api.Fleet, error) {
-	return s.updateStatus(s.db, orgId, resource)
+	return s.updateStatus(ctx, orgId, resource)
 }
 
 func (s *FleetStore) UpdateStatusMultiple(ctx context.Context, orgId uuid.UUID, resources ...*api.Fleet) error {
 	var errs []error
 	for _, resource := range resources {
-		_, err := s.updateStatus(s.db, orgId, resource)
+		_, err := s.updateStatus(ctx, orgId, resource)
 		errs = append(errs, err)
 	}
 	return errors.Join(lo.Uniq(errs)...)
 }
 
-func (s *FleetStore) updateStatus(tx *gorm.DB, orgId uuid.UUID, resource *api.Fleet) (*api.Fleet, error) {
+func (s *FleetStore) updateStatus(ctx context.Context, orgId uuid.UUID, resource *api.Fleet) (*api.Fleet, error) {
 	if resource == nil {
 		return nil, flterrors.ErrResourceIsNil
 	}
@@ -416,59 +469,115 @@ func (s *FleetStore) updateStatus(tx *gorm.DB, orgId uuid.UUID, resource *api.Fl
 	fleet := model.Fleet{
 		Resource: model.Resource{OrgID: orgId, Name: *resource.Metadata.Name},
 	}
-	result := tx.Model(&fleet).Updates(map[string]interface{}{
-		"status":           model.MakeJSONField(resource.Status),
-		"resource_version": gorm.Expr("resource_version + 1"),
+	_, err := s.updateCaptured(ctx, orgId, fleet.Name, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&fleet).Updates(map[string]interface{}{
+			"status":           model.MakeJSONField(resource.Status),
+			"resource_version": gorm.Expr("resource_version + 1"),
+		})
 	})
-	return resource, ErrorFromGormError(result.Error)
+	return resource, ErrorFromGormError(err)
 }
 
-func (s *FleetStore) UnsetOwner(ctx context.Context, tx *gorm.DB, orgId uuid.UUID, owner string) error {
-	db := s.db
-	if tx != nil {
-		db = tx
+// updateCaptured runs update in a transaction, in which it captures the update of the named fleet
+// if update updated it. It returns the number of rows that update updated.
+func (s *FleetStore) updateCaptured(ctx context.Context, orgId uuid.UUID, name string, update func(tx *gorm.DB) *gorm.DB) (int64, error) {
+	var rowsAffected int64
+	err := OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		result := update(innerTx)
+		if result.Error != nil {
+			return result.Error
+		}
+		rowsAffected = result.RowsAffected
+		if rowsAffected == 0 {
+			return nil
+		}
+		return s.captureUpdated(ctx, innerTx, orgId, name)
+	})
+	return rowsAffected, err
+}
+
+// captureUpdated captures the updates of the named fleets in tx, as read back from tx. It does not
+// query anything when nothing is captured.
+func (s *FleetStore) captureUpdated(ctx context.Context, tx *gorm.DB, orgId uuid.UUID, names ...string) error {
+	if !s.changes.enabled() || len(names) == 0 {
+		return nil
 	}
-	fleetCondition := model.Fleet{
-		Resource: model.Resource{OrgID: orgId, Owner: &owner},
+	var fleets []model.Fleet
+	if err := tx.Where("org_id = ? AND name IN ?", orgId, names).Order("name").Find(&fleets).Error; err != nil {
+		return ErrorFromGormError(err)
 	}
-	result := db.Model(fleetCondition).Where("org_id = ? and owner = ?", orgId, owner).Updates(map[string]interface{}{
-		"owner":            nil,
-		"resource_version": gorm.Expr("resource_version + 1"),
+	for i := range fleets {
+		if err := s.changes.capture(ctx, tx, ChangeUpdated, api.FleetKind, orgId, fleets[i].Name, fleets[i].ToApiResource()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FleetStore) UnsetOwner(ctx context.Context, tx *gorm.DB, orgId uuid.UUID, owner string) error {
+	return s.unsetOwner(ctx, tx, orgId, func(db *gorm.DB) *gorm.DB {
+		return db.Where("org_id = ? and owner = ?", orgId, owner)
 	})
-	return ErrorFromGormError(result.Error)
 }
 
 func (s *FleetStore) UnsetOwnerByKind(ctx context.Context, tx *gorm.DB, orgId uuid.UUID, resourceKind string) error {
-	db := s.db
-	if tx != nil {
-		db = tx
-	}
-	fleetCondition := model.Fleet{
-		Resource: model.Resource{OrgID: orgId},
-	}
-	result := db.Model(model.Fleet{}).Where(fleetCondition).Where("owner like ?", "%"+resourceKind+"/%").Updates(map[string]interface{}{
-		"owner":            nil,
-		"resource_version": gorm.Expr("resource_version + 1"),
+	return s.unsetOwner(ctx, tx, orgId, func(db *gorm.DB) *gorm.DB {
+		return db.Where("org_id = ? and owner like ?", orgId, "%"+resourceKind+"/%")
 	})
-	return ErrorFromGormError(result.Error)
 }
 
-func (s *FleetStore) Delete(ctx context.Context, orgId uuid.UUID, callback FleetStoreCallback, names ...string) error {
-	deleted := []model.Fleet{}
-	if err := s.db.Raw(`delete from fleets where org_id = ? and name in (?) returning *`, orgId, names).Scan(&deleted).Error; err != nil {
-		return ErrorFromGormError(err)
+// unsetOwner unsets the owner of the fleets that where selects, in tx if it is not nil, capturing
+// their updates.
+func (s *FleetStore) unsetOwner(ctx context.Context, tx *gorm.DB, orgId uuid.UUID, where func(db *gorm.DB) *gorm.DB) error {
+	unset := func(innerTx *gorm.DB) error {
+		var names []string
+		if s.changes.enabled() {
+			// Lock the fleets so that the captured ones are those updated
+			if err := where(innerTx.Model(&model.Fleet{})).Clauses(clause.Locking{Strength: "UPDATE"}).Order("name").Pluck("name", &names).Error; err != nil {
+				return ErrorFromGormError(err)
+			}
+		}
+		result := where(innerTx.Model(&model.Fleet{})).Updates(map[string]interface{}{
+			"owner":            nil,
+			"resource_version": gorm.Expr("resource_version + 1"),
+		})
+		if result.Error != nil {
+			return ErrorFromGormError(result.Error)
+		}
+		return s.captureUpdated(ctx, innerTx, orgId, names...)
 	}
-	for i := range deleted {
-		callback(&deleted[i], nil)
+	if tx != nil {
+		return unset(tx)
 	}
-	return nil
+	return OrgTransaction(s.db, orgId, unset)
+}
+
+func (s *FleetStore) Delete(ctx context.Context, orgId uuid.UUID, callback FleetStoreCallback, names ...string) error {
+	return OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		deleted := []model.Fleet{}
+		if err := innerTx.Raw(`delete from fleets where org_id = ? and name in (?) returning *`, orgId, names).Scan(&deleted).Error; err != nil {
+			return ErrorFromGormError(err)
+		}
+		txCtx := withTransaction(ctx, innerTx)
+		for i := range deleted {
+			if err := s.changes.captureDeleted(ctx, innerTx, api.FleetKind, orgId, deleted[i].Name); err != nil {
+				return err
+			}
+			if err := callback(txCtx, &deleted[i], nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func (s *FleetStore) updateConditions(orgId uuid.UUID, name string, conditions []api.Condition) (bool, error) {
+func (s *FleetStore) updateConditions(ctx context.Context, orgId uuid.UUID, name string, conditions []api.Condition) (bool, error) {
 	existingRecord := model.Fleet{Resource: model.Resource{OrgID: orgId, Name: name}}
-	result := s.db.First(&existingRecord)
-	if result.Error != nil {
-		return false, ErrorFromGormError(result.Error)
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.First(&existingRecord).Error
+	})
+	if err != nil {
+		return false, ErrorFromGormError(err)
 	}
 
 	if existingRecord.Status == nil {
@@ -485,15 +594,17 @@ func (s *FleetStore) updateConditions(orgId uuid.UUID, name string, conditions [
 		return false, nil
 	}
 
-	result = s.db.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
-		"status":           existingRecord.Status,
-		"resource_version": gorm.Expr("resource_version + 1"),
+	rowsAffected, err := s.updateCaptured(ctx, orgId, name, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
+			"status":           existingRecord.Status,
+			"resource_version": gorm.Expr("resource_version + 1"),
+		})
 	})
-	err := ErrorFromGormError(result.Error)
+	err = ErrorFromGormError(err)
 	if err != nil {
 		return strings.Contains(err.Error(), "deadlock"), err
 	}
-	if result.RowsAffected == 0 {
+	if rowsAffected == 0 {
 		return true, flterrors.ErrNoRowsUpdated
 	}
 	return false, nil
@@ -501,15 +612,58 @@ func (s *FleetStore) updateConditions(orgId uuid.UUID, name string, conditions [
 
 func (s *FleetStore) UpdateConditions(ctx context.Context, orgId uuid.UUID, name string, conditions []api.Condition) error {
 	return retryUpdate(func() (bool, error) {
-		return s.updateConditions(orgId, name, conditions)
+		return s.updateConditions(ctx, orgId, name, conditions)
 	})
 }
 
-func (s *FleetStore) updateAnnotations(orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) (bool, error) {
+func (s *FleetStore) updateRolloutStatus(ctx context.Context, orgId uuid.UUID, name string, rollout *api.FleetRolloutStatus) (bool, error) {
 	existingRecord := model.Fleet{Resource: model.Resource{OrgID: orgId, Name: name}}
-	result := s.db.First(&existingRecord)
-	if result.Error != nil {
-		return false, ErrorFromGormError(result.Error)
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.First(&existingRecord).Error
+	})
+	if err != nil {
+		return false, ErrorFromGormError(err)
+	}
+
+	if existingRecord.Status == nil {
+		existingRecord.Status = model.MakeJSONField(api.FleetStatus{})
+	}
+	if existingRecord.Status.Data.Conditions == nil {
+		existingRecord.Status.Data.Conditions = []api.Condition{}
+	}
+	existingRecord.Status.Data.Rollout = rollout
+
+	rowsAffected, err := s.updateCaptured(ctx, orgId, name, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
+			"status":           existingRecord.Status,
+			"resource_version": gorm.Expr("resource_version + 1"),
+		})
+	})
+	err = ErrorFromGormError(err)
+	if err != nil {
+		return strings.Contains(err.Error(), "deadlock"), err
+	}
+	if rowsAffected == 0 {
+		return true, flterrors.ErrNoRowsUpdated
+	}
+	return false, nil
+}
+
+// UpdateRolloutStatus replaces the status of the batched rollout of the fleet, leaving the rest of
+// its status unchanged.
+func (s *FleetStore) UpdateRolloutStatus(ctx context.Context, orgId uuid.UUID, name string, rollout *api.FleetRolloutStatus) error {
+	return retryUpdate(func() (bool, error) {
+		return s.updateRolloutStatus(ctx, orgId, name, rollout)
+	})
+}
+
+func (s *FleetStore) updateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) (bool, error) {
+	existingRecord := model.Fleet{Resource: model.Resource{OrgID: orgId, Name: name}}
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.First(&existingRecord).Error
+	})
+	if err != nil {
+		return false, ErrorFromGormError(err)
 	}
 	existingAnnotations := util.EnsureMap(existingRecord.Annotations)
 	existingAnnotations = util.MergeLabels(existingAnnotations, annotations)
@@ -518,15 +672,17 @@ func (s *FleetStore) updateAnnotations(orgId uuid.UUID, name string, annotations
 		delete(existingAnnotations, deleteKey)
 	}
 
-	result = s.db.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
-		"annotations":      model.MakeJSONMap(existingAnnotations),
-		"resource_version": gorm.Expr("resource_version + 1"),
+	rowsAffected, err := s.updateCaptured(ctx, orgId, name, func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
+			"annotations":      model.MakeJSONMap(existingAnnotations),
+			"resource_version": gorm.Expr("resource_version + 1"),
+		})
 	})
-	err := ErrorFromGormError(result.Error)
+	err = ErrorFromGormError(err)
 	if err != nil {
 		return strings.Contains(err.Error(), "deadlock"), err
 	}
-	if result.RowsAffected == 0 {
+	if rowsAffected == 0 {
 		return true, flterrors.ErrNoRowsUpdated
 	}
 	return false, nil
@@ -534,7 +690,7 @@ func (s *FleetStore) updateAnnotations(orgId uuid.UUID, name string, annotations
 
 func (s *FleetStore) UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error {
 	return retryUpdate(func() (bool, error) {
-		return s.updateAnnotations(orgId, name, annotations, deleteKeys)
+		return s.updateAnnotations(ctx, orgId, name, annotations, deleteKeys)
 	})
 }
 
@@ -543,7 +699,7 @@ func (s *FleetStore) OverwriteRepositoryRefs(ctx context.Context, orgId uuid.UUI
 	for _, repoName := range repositoryNames {
 		repos = append(repos, model.Repository{Resource: model.Resource{OrgID: orgId, Name: repoName}})
 	}
-	return s.db.Transaction(func(innerTx *gorm.DB) error {
+	return OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
 		fleet := model.Fleet{Resource: model.Resource{OrgID: orgId, Name: name}}
 		if err := innerTx.Model(&fleet).Association("Repositories").Replace(repos); err != nil {
 			return ErrorFromGormError(err)
@@ -555,7 +711,9 @@ func (s *FleetStore) OverwriteRepositoryRefs(ctx context.Context, orgId uuid.UUI
 func (s *FleetStore) GetRepositoryRefs(ctx context.Context, orgId uuid.UUID, name string) (*api.RepositoryList, error) {
 	fleet := model.Fleet{Resource: model.Resource{OrgID: orgId, Name: name}}
 	var repos model.RepositoryList
-	err := s.db.Model(&fleet).Association("Repositories").Find(&repos)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.Model(&fleet).Association("Repositories").Find(&repos)
+	})
 	if err != nil {
 		return nil, ErrorFromGormError(err)
 	}
@@ -14,32 +14,53 @@ import (
 	"k8s.io/klog/v2"
 )
 
+func dialector(cfg *config.Config, hostname string, port uint) gorm.Dialector {
+	if cfg.Database.Type != "pgsql" {
+		return sqlite.Open(cfg.Database.Name)
+	}
+	dsn := fmt.Sprintf("host=%s user=%s password=%s port=%d",
+		hostname,
+		cfg.Database.User,
+		cfg.Database.Password,
+		port,
+	)
+	if cfg.Database.Name != "" {
+		dsn = fmt.Sprintf("%s dbname=%s", dsn, cfg.Database.Name)
+	}
+	return postgres.Open(dsn)
+}
+
 func InitDB(cfg *config.Config, log *logrus.Logger) (*gorm.DB, error) {
-	var dia gorm.Dialector
+	return initDB(cfg, log, true)
+}
 
-	if cfg.Database.Type == "pgsql" {
-		dsn := fmt.Sprintf("host=%s user=%s password=%s port=%d",
-			cfg.Database.Hostname,
-			cfg.Database.User,
-			cfg.Database.Password,
-			cfg.Database.Port,
-		)
-		if cfg.Database.Name != "" {
-			dsn = fmt.Sprintf("%s dbname=%s", dsn, cfg.Database.Name)
-		}
-		dia = postgres.Open(dsn)
-	} else {
-		dia = sqlite.Open(cfg.Database.Name)
+// InitMigrationDB connects to the database as the migration user, which owns the tables, or as the
+// database user when no migration user is configured.
+func InitMigrationDB(cfg *config.Config, log *logrus.Logger) (*gorm.DB, error) {
+	if cfg.Database.MigrationUser == "" {
+		return InitDB(cfg, log)
 	}
+	dbCfg := *cfg.Database
+	dbCfg.User = cfg.Database.MigrationUser
+	dbCfg.Password = cfg.Database.MigrationPassword
+	dbCfg.ReplicaHostname = ""
+	migrationCfg := *cfg
+	migrationCfg.Database = &dbCfg
+	return initDB(&migrationCfg, log, false)
+}
+
+func initDB(cfg *config.Config, log *logrus.Logger, checkIsolation bool) (*gorm.DB, error) {
+	dia := dialector(cfg, cfg.Database.Hostname, cfg.Database.Port)
+	slowThreshold := time.Duration(cfg.Database.SlowQueryThreshold)
 
 	newLogger := logger.New(
 		log,
 		logger.Config{
-			SlowThreshold:             time.Second, // Slow SQL threshold
-			LogLevel:                  logger.Warn, // Log level
-			IgnoreRecordNotFoundError: true,        // Ignore ErrRecordNotFound error for logger
-			ParameterizedQueries:      true,        // Don't include params in the SQL log
-			Colorful:                  false,       // Disable color
+			SlowThreshold:             slowThreshold, // Slow SQL threshold
+			LogLevel:                  logger.Warn,   // Log level
+			IgnoreRecordNotFoundError: true,          // Ignore ErrRecordNotFound error for logger
+			ParameterizedQueries:      true,          // Don't include params in the SQL log
+			Colorful:                  false,         // Disable color
 		},
 	)
 
@@ -78,7 +99,58 @@ func InitDB(cfg *config.Config, log *logrus.Logger) (*gorm.DB, error) {
 		}
 
 		klog.Infof("PostgreSQL information: '%s'", minorVersion)
+
+		if checkIsolation {
+			if err := checkRowLevelSecurity(newDB, cfg.Database.User); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if cfg.Database.ReplicaHostname != "" {
+		if err := initReadReplica(cfg, newDB, newLogger); err != nil {
+			return nil, err
+		}
 	}
 
 	return newDB, nil
 }
+
+// checkRowLevelSecurity warns when the database user is exempt from the row-level security policies
+// isolating the organizations, as a superuser, a role with the BYPASSRLS attribute or the owner of
+// the tables.
+func checkRowLevelSecurity(db *gorm.DB, user string) error {
+	var exempt bool
+	query := `SELECT rolsuper OR rolbypassrls OR EXISTS (SELECT FROM pg_tables WHERE tableowner = current_user AND tablename = 'devices')
+		FROM pg_roles WHERE rolname = current_user`
+	if err := db.Raw(query).Scan(&exempt).Error; err != nil {
+		return err
+	}
+	if exempt {
+		klog.Warningf("database user %s is exempt from row-level security, organizations are not isolated by the database", user)
+	}
+	return nil
+}
+
+func initReadReplica(cfg *config.Config, db *gorm.DB, log logger.Interface) error {
+	port := cfg.Database.ReplicaPort
+	if port == 0 {
+		port = cfg.Database.Port
+	}
+	replicaDB, err := gorm.Open(dialector(cfg, cfg.Database.ReplicaHostname, port), &gorm.Config{Logger: log, TranslateError: true})
+	if err != nil {
+		return fmt.Errorf("failed to connect read replica: %w", err)
+	}
+	sqlDB, err := replicaDB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to configure read replica connections: %w", err)
+	}
+	sqlDB.SetMaxIdleConns(10)
+	sqlDB.SetMaxOpenConns(100)
+
+	if err := UseReadReplica(db, replicaDB); err != nil {
+		return fmt.Errorf("failed to route reads to read replica: %w", err)
+	}
+	klog.Infof("Routing stale-tolerant reads to read replica %s:%d", cfg.Database.ReplicaHostname, port)
+	return nil
+}
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+const (
+	metricsStartKey       = "flightctl:metrics_start"
+	metricsBeforeCallback = "flightctl:metrics_before"
+	metricsAfterCallback  = "flightctl:metrics_after"
+)
+
+// MetricsCollector is a gorm plugin recording the duration of the queries run against the
+// database, labeled by operation, and counting the ones slower than a threshold, if set. When
+// collected, it also reports the saturation of the database connection pool.
+type MetricsCollector struct {
+	slowThreshold time.Duration
+	sqlDB         *sql.DB
+
+	queryDuration *prometheus.HistogramVec
+	slowQueries   *prometheus.CounterVec
+
+	maxOpen      *prometheus.Desc
+	open         *prometheus.Desc
+	inUse        *prometheus.Desc
+	idle         *prometheus.Desc
+	waitCount    *prometheus.Desc
+	waitDuration *prometheus.Desc
+}
+
+func NewMetricsCollector(slowThreshold time.Duration) *MetricsCollector {
+	return &MetricsCollector{
+		slowThreshold: slowThreshold,
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "flightctl_db_query_duration_seconds",
+			Help:    "Distribution of the durations of Flightctl database queries",
+			Buckets: []float64{1e-3, 5e-3, 1e-2, 5e-2, 1e-1, 5e-1, 1e0, 5e0},
+		}, []string{"operation"}),
+		slowQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "flightctl_db_slow_queries_total",
+			Help: "Number of Flightctl database queries that took longer than the slow query threshold",
+		}, []string{"operation"}),
+		maxOpen: prometheus.NewDesc(
+			"flightctl_db_connections_max_open",
+			"Maximum number of open connections to the Flightctl database",
+			nil, nil,
+		),
+		open: prometheus.NewDesc(
+			"flightctl_db_connections_open",
+			"Number of open connections to the Flightctl database, in use or idle",
+			nil, nil,
+		),
+		inUse: prometheus.NewDesc(
+			"flightctl_db_connections_in_use",
+			"Number of connections to the Flightctl database currently in use",
+			nil, nil,
+		),
+		idle: prometheus.NewDesc(
+			"flightctl_db_connections_idle",
+			"Number of idle connections to the Flightctl database",
+			nil, nil,
+		),
+		waitCount: prometheus.NewDesc(
+			"flightctl_db_connections_wait_total",
+			"Number of times a query waited for a free connection to the Flightctl database",
+			nil, nil,
+		),
+		waitDuration: prometheus.NewDesc(
+			"flightctl_db_connections_wait_seconds_total",
+			"Total time queries waited for a free connection to the Flightctl database",
+			nil, nil,
+		),
+	}
+}
+
+func (c *MetricsCollector) Name() string {
+	return "flightctl:metrics"
+}
+
+func (c *MetricsCollector) Initialize(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	c.sqlDB = sqlDB
+
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("*").Register(metricsBeforeCallback, c.before),
+		callback.Create().After("*").Register(metricsAfterCallback, c.after("create")),
+		callback.Query().Before("*").Register(metricsBeforeCallback, c.before),
+		callback.Query().After("*").Register(metricsAfterCallback, c.after("query")),
+		callback.Update().Before("*").Register(metricsBeforeCallback, c.before),
+		callback.Update().After("*").Register(metricsAfterCallback, c.after("update")),
+		callback.Delete().Before("*").Register(metricsBeforeCallback, c.before),
+		callback.Delete().After("*").Register(metricsAfterCallback, c.after("delete")),
+		callback.Row().Before("*").Register(metricsBeforeCallback, c.before),
+		callback.Row().After("*").Register(metricsAfterCallback, c.after("row")),
+		callback.Raw().Before("*").Register(metricsBeforeCallback, c.before),
+		callback.Raw().After("*").Register(metricsAfterCallback, c.after("raw")),
+	)
+}
+
+func (c *MetricsCollector) before(tx *gorm.DB) {
+	tx.InstanceSet(metricsStartKey, time.Now())
+}
+
+func (c *MetricsCollector) after(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		value, ok := tx.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		duration := time.Since(start)
+		c.queryDuration.WithLabelValues(operation).Observe(duration.Seconds())
+		if c.slowThreshold > 0 && duration >= c.slowThreshold {
+			c.slowQueries.WithLabelValues(operation).Inc()
+		}
+	}
+}
+
+func (c *MetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.queryDuration.Describe(ch)
+	c.slowQueries.Describe(ch)
+	ch <- c.maxOpen
+	ch <- c.open
+	ch <- c.inUse
+	ch <- c.idle
+	ch <- c.waitCount
+	ch <- c.waitDuration
+}
+
+func (c *MetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.queryDuration.Collect(ch)
+	c.slowQueries.Collect(ch)
+	if c.sqlDB == nil {
+		return
+	}
+	stats := c.sqlDB.Stats()
+	ch <- prometheus.MustNewConstMetric(c.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type metricsTestRecord struct {
+	ID   uint
+	Name string
+}
+
+func openMetricsTestDB(t *testing.T, collector *MetricsCollector) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "metrics.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(collector))
+	require.NoError(t, db.AutoMigrate(&metricsTestRecord{}))
+	return db
+}
+
+func TestMetricsCollectorQueries(t *testing.T) {
+	require := require.New(t)
+	collector := NewMetricsCollector(time.Hour)
+	db := openMetricsTestDB(t, collector)
+
+	require.NoError(db.Create(&metricsTestRecord{ID: 1, Name: "one"}).Error)
+	require.NoError(db.Create(&metricsTestRecord{ID: 2, Name: "two"}).Error)
+	var record metricsTestRecord
+	require.NoError(db.First(&record, 1).Error)
+	require.NoError(db.Model(&record).Update("name", "uno").Error)
+	require.NoError(db.Delete(&record).Error)
+
+	for operation, count := range map[string]uint64{"create": 2, "query": 1, "update": 1, "delete": 1} {
+		require.Equal(count, observedQueries(t, collector, operation), operation)
+	}
+	require.Equal(0, testutil.CollectAndCount(collector.slowQueries))
+}
+
+func observedQueries(t *testing.T, collector *MetricsCollector, operation string) uint64 {
+	metric := &dto.Metric{}
+	require.NoError(t, collector.queryDuration.WithLabelValues(operation).(prometheus.Metric).Write(metric))
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestMetricsCollectorSlowQueries(t *testing.T) {
+	require := require.New(t)
+	collector := NewMetricsCollector(time.Nanosecond)
+	db := openMetricsTestDB(t, collector)
+	collector.slowQueries.Reset()
+
+	require.NoError(db.Create(&metricsTestRecord{ID: 1, Name: "one"}).Error)
+	var records []metricsTestRecord
+	require.NoError(db.Find(&records).Error)
+	require.NoError(db.Find(&records).Error)
+
+	require.Equal(float64(1), testutil.ToFloat64(collector.slowQueries.WithLabelValues("create")))
+	require.Equal(float64(2), testutil.ToFloat64(collector.slowQueries.WithLabelValues("query")))
+}
+
+func TestMetricsCollectorConnectionPool(t *testing.T) {
+	require := require.New(t)
+	collector := NewMetricsCollector(time.Hour)
+	db := openMetricsTestDB(t, collector)
+	sqlDB, err := db.DB()
+	require.NoError(err)
+	sqlDB.SetMaxOpenConns(5)
+
+	// hold a connection so that it is reported as in use
+	conn, err := sqlDB.Conn(db.Statement.Context)
+	require.NoError(err)
+	defer conn.Close()
+
+	expected := `
+# HELP flightctl_db_connections_in_use Number of connections to the Flightctl database currently in use
+# TYPE flightctl_db_connections_in_use gauge
+flightctl_db_connections_in_use 1
+# HELP flightctl_db_connections_max_open Maximum number of open connections to the Flightctl database
+# TYPE flightctl_db_connections_max_open gauge
+flightctl_db_connections_max_open 5
+# HELP flightctl_db_connections_wait_total Number of times a query waited for a free connection to the Flightctl database
+# TYPE flightctl_db_connections_wait_total counter
+flightctl_db_connections_wait_total 0
+`
+	require.NoError(testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"flightctl_db_connections_in_use", "flightctl_db_connections_max_open", "flightctl_db_connections_wait_total"))
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BootstrapToken is a one-time token an agent presents to obtain its enrollment certificate,
+// for devices that are not provisioned with one. Only the hash of the token is stored.
+type BootstrapToken struct {
+	OrgID uuid.UUID `gorm:"type:uuid;primary_key;"`
+
+	// The SHA-256 of the token, hex-encoded.
+	Hash string `gorm:"primary_key;"`
+
+	// The time after which the token can no longer be redeemed.
+	ExpiresAt time.Time
+
+	// The time the token was redeemed, nil while it was not.
+	RedeemedAt *time.Time
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,186 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+// The allocations of the conversions done on every API request. The ceilings fail the test when a
+// change makes the conversions allocate more; lower them when a change makes them allocate less.
+// Measured with "go test -run '^$' -bench ApiResource -benchmem ./internal/store/model/":
+//
+//	                                 before        after
+//	DeviceToApiResource              10 allocs     3 allocs
+//	DeviceListToApiResource (100)    1008 allocs   308 allocs
+//	NewDeviceFromApiResource         8 allocs      5 allocs
+//	FleetToApiResource               8 allocs      3 allocs
+//	FleetListToApiResource (100)     1000 allocs   201 allocs
+//	NewFleetFromApiResource          6 allocs      4 allocs
+const (
+	maxDeviceToApiResourceAllocs      = 3
+	maxDeviceListToApiResourceAllocs  = 308
+	maxNewDeviceFromApiResourceAllocs = 5
+	maxFleetToApiResourceAllocs       = 3
+	maxFleetListToApiResourceAllocs   = 201
+	maxNewFleetFromApiResourceAllocs  = 4
+)
+
+const conversionListSize = 100
+
+func testConversionDevice(name string) Device {
+	status := api.NewDeviceStatus()
+	status.Summary.Status = api.DeviceSummaryStatusOnline
+	status.ApplicationsSummary.Status = api.ApplicationsSummaryStatusHealthy
+	status.Updated.Status = api.DeviceUpdatedStatusUpToDate
+	return Device{
+		Resource: Resource{
+			Name:            name,
+			Labels:          map[string]string{"site": "factory-1", "env": "prod"},
+			Annotations:     map[string]string{"device-controller/renderedVersion": "3"},
+			Generation:      lo.ToPtr(int64(3)),
+			Owner:           util.SetResourceOwner(api.FleetKind, "fleet-1"),
+			ResourceVersion: lo.ToPtr(int64(42)),
+			CreatedAt:       time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+		},
+		Spec:   MakeJSONField(api.DeviceSpec{}),
+		Status: MakeJSONField(status),
+		ServiceConditions: MakeJSONField(ServiceConditions{Conditions: &[]api.Condition{
+			{Type: api.DeviceMultipleOwners, Status: api.ConditionStatusFalse},
+		}}),
+	}
+}
+
+func testConversionFleet(name string) Fleet {
+	return Fleet{
+		Resource: Resource{
+			Name:            name,
+			Labels:          map[string]string{"env": "prod"},
+			Annotations:     map[string]string{},
+			Generation:      lo.ToPtr(int64(2)),
+			ResourceVersion: lo.ToPtr(int64(7)),
+			CreatedAt:       time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+		},
+		Spec: MakeJSONField(api.FleetSpec{}),
+		Status: MakeJSONField(api.FleetStatus{
+			Conditions:     []api.Condition{{Type: api.FleetValid, Status: api.ConditionStatusTrue}},
+			DevicesSummary: &api.DevicesSummary{Total: 10},
+		}),
+	}
+}
+
+func testConversionDeviceList() DeviceList {
+	devices := make(DeviceList, conversionListSize)
+	for i := range devices {
+		devices[i] = testConversionDevice(fmt.Sprintf("device-%d", i))
+	}
+	return devices
+}
+
+func testConversionFleetList() FleetList {
+	fleets := make(FleetList, conversionListSize)
+	for i := range fleets {
+		fleets[i] = testConversionFleet(fmt.Sprintf("fleet-%d", i))
+	}
+	return fleets
+}
+
+func BenchmarkDeviceToApiResource(b *testing.B) {
+	device := testConversionDevice("device-1")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = device.ToApiResource()
+	}
+}
+
+func BenchmarkDeviceListToApiResource(b *testing.B) {
+	devices := testConversionDeviceList()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = devices.ToApiResource(nil, nil)
+	}
+}
+
+func BenchmarkNewDeviceFromApiResource(b *testing.B) {
+	device := testConversionDevice("device-1")
+	resource := device.ToApiResource()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = NewDeviceFromApiResource(&resource)
+	}
+}
+
+func BenchmarkFleetToApiResource(b *testing.B) {
+	fleet := testConversionFleet("fleet-1")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = fleet.ToApiResource()
+	}
+}
+
+func BenchmarkFleetListToApiResource(b *testing.B) {
+	fleets := testConversionFleetList()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = fleets.ToApiResource(nil, nil)
+	}
+}
+
+func BenchmarkNewFleetFromApiResource(b *testing.B) {
+	fleet := testConversionFleet("fleet-1")
+	resource := fleet.ToApiResource()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = NewFleetFromApiResource(&resource)
+	}
+}
+
+func TestConversionAllocations(t *testing.T) {
+	device := testConversionDevice("device-1")
+	deviceResource := device.ToApiResource()
+	devices := testConversionDeviceList()
+	fleet := testConversionFleet("fleet-1")
+	fleetResource := fleet.ToApiResource()
+	fleets := testConversionFleetList()
+
+	tests := []struct {
+		name      string
+		maxAllocs float64
+		convert   func()
+	}{
+		{"DeviceToApiResource", maxDeviceToApiResourceAllocs, func() { _ = device.ToApiResource() }},
+		{"DeviceListToApiResource", maxDeviceListToApiResourceAllocs, func() { _ = devices.ToApiResource(nil, nil) }},
+		{"NewDeviceFromApiResource", maxNewDeviceFromApiResourceAllocs, func() { _, _ = NewDeviceFromApiResource(&deviceResource) }},
+		{"FleetToApiResource", maxFleetToApiResourceAllocs, func() { _ = fleet.ToApiResource() }},
+		{"FleetListToApiResource", maxFleetListToApiResourceAllocs, func() { _ = fleets.ToApiResource(nil, nil) }},
+		{"NewFleetFromApiResource", maxNewFleetFromApiResourceAllocs, func() { _, _ = NewFleetFromApiResource(&fleetResource) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, tt.convert)
+			require.LessOrEqualf(t, allocs, tt.maxAllocs, "%s allocates more than the recorded ceiling", tt.name)
+		})
+	}
+}
+
+func TestDeviceToApiResourceKeepsStoredConditions(t *testing.T) {
+	require := require.New(t)
+	device := testConversionDevice("device-1")
+	conditions := make([]api.Condition, 1, 4)
+	conditions[0] = api.Condition{Type: api.DeviceUpdating, Status: api.ConditionStatusFalse}
+	device.Status.Data.Conditions = conditions
+
+	resource := device.ToApiResource()
+	require.Len(resource.Status.Conditions, 2)
+	require.Equal(api.DeviceMultipleOwners, resource.Status.Conditions[1].Type)
+
+	// the service conditions are not appended to the stored status
+	resource.Status.Conditions[0].Reason = "changed"
+	require.Len(device.Status.Data.Conditions, 1)
+	require.Empty(device.Status.Data.Conditions[0].Reason)
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"strconv"
+	"time"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/flterrors"
@@ -56,9 +57,11 @@ func NewDeviceFromApiResource(resource *api.Device) (*Device, error) {
 		spec = *resource.Spec
 	}
 
-	status := api.NewDeviceStatus()
+	var status api.DeviceStatus
 	if resource.Status != nil {
 		status = *resource.Status
+	} else {
+		status = api.NewDeviceStatus()
 	}
 	if status.Conditions == nil {
 		status.Conditions = []api.Condition{}
@@ -81,8 +84,9 @@ func NewDeviceFromApiResource(resource *api.Device) (*Device, error) {
 	return &Device{
 		Resource: Resource{
 			Name:            *resource.Metadata.Name,
-			Labels:          lo.FromPtrOr(resource.Metadata.Labels, make(map[string]string)),
-			Annotations:     lo.FromPtrOr(resource.Metadata.Annotations, make(map[string]string)),
+			Labels:          util.EnsureMap(lo.FromPtr(resource.Metadata.Labels)),
+			Annotations:     util.EnsureMap(lo.FromPtr(resource.Metadata.Annotations)),
+			ManagedFields:   lo.FromPtr(resource.Metadata.ManagedFields),
 			Generation:      resource.Metadata.Generation,
 			Owner:           resource.Metadata.Owner,
 			ResourceVersion: resourceVersion,
@@ -93,46 +97,66 @@ func NewDeviceFromApiResource(resource *api.Device) (*Device, error) {
 	}, nil
 }
 
+// deviceApiValues holds the values an api.Device points to, so that converting a device takes a
+// single allocation for all of them.
+type deviceApiValues struct {
+	name              string
+	creationTimestamp time.Time
+	labels            map[string]string
+	annotations       map[string]string
+	resourceVersion   string
+	spec              api.DeviceSpec
+	status            api.DeviceStatus
+}
+
 func (d *Device) ToApiResource() api.Device {
 	if d == nil {
 		return api.Device{}
 	}
 
-	spec := api.DeviceSpec{}
+	v := &deviceApiValues{
+		name:              d.Name,
+		creationTimestamp: d.CreatedAt.UTC(),
+		labels:            util.EnsureMap(d.Resource.Labels),
+		annotations:       util.EnsureMap(d.Resource.Annotations),
+	}
 	if d.Spec != nil {
-		spec = d.Spec.Data
+		v.spec = d.Spec.Data
 	}
-
-	status := api.NewDeviceStatus()
 	if d.Status != nil {
-		status = d.Status.Data
+		v.status = d.Status.Data
+	} else {
+		v.status = api.NewDeviceStatus()
 	}
 
 	if d.ServiceConditions != nil && d.ServiceConditions.Data.Conditions != nil {
-		if status.Conditions == nil {
-			status.Conditions = []api.Condition{}
-		}
-		status.Conditions = append(status.Conditions, *d.ServiceConditions.Data.Conditions...)
+		// copy the conditions rather than appending in place, which could write to the stored status
+		serviceConditions := *d.ServiceConditions.Data.Conditions
+		conditions := make([]api.Condition, 0, len(v.status.Conditions)+len(serviceConditions))
+		conditions = append(conditions, v.status.Conditions...)
+		v.status.Conditions = append(conditions, serviceConditions...)
 	}
 
 	var resourceVersion *string
 	if d.ResourceVersion != nil {
-		resourceVersion = lo.ToPtr(strconv.FormatInt(*d.ResourceVersion, 10))
+		v.resourceVersion = strconv.FormatInt(*d.ResourceVersion, 10)
+		resourceVersion = &v.resourceVersion
 	}
 	return api.Device{
 		ApiVersion: api.DeviceAPIVersion,
 		Kind:       api.DeviceKind,
 		Metadata: api.ObjectMeta{
-			Name:              util.StrToPtr(d.Name),
-			CreationTimestamp: util.TimeToPtr(d.CreatedAt.UTC()),
-			Labels:            lo.ToPtr(util.EnsureMap(d.Resource.Labels)),
-			Annotations:       lo.ToPtr(util.EnsureMap(d.Resource.Annotations)),
+			Name:              &v.name,
+			CreationTimestamp: &v.creationTimestamp,
+			Labels:            &v.labels,
+			Annotations:       &v.annotations,
+			ManagedFields:     lo.EmptyableToPtr(map[string][]string(d.Resource.ManagedFields)),
 			Generation:        d.Generation,
 			Owner:             d.Owner,
 			ResourceVersion:   resourceVersion,
 		},
-		Spec:   &spec,
-		Status: &status,
+		Spec:   &v.spec,
+		Status: &v.status,
 	}
 }
 
@@ -149,8 +173,8 @@ func (dl DeviceList) ToApiResource(cont *string, numRemaining *int64) api.Device
 	applicationStatuses := make(map[string]int64)
 	summaryStatuses := make(map[string]int64)
 	updateStatuses := make(map[string]int64)
-	for i, device := range dl {
-		deviceList[i] = device.ToApiResource()
+	for i := range dl {
+		deviceList[i] = dl[i].ToApiResource()
 		applicationStatus := string(deviceList[i].Status.ApplicationsSummary.Status)
 		applicationStatuses[applicationStatus] = applicationStatuses[applicationStatus] + 1
 		summaryStatus := string(deviceList[i].Status.Summary.Status)
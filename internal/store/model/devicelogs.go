@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/google/uuid"
+)
+
+// DeviceLogs is the tail of the logs most recently shipped by the agent of a device. Each
+// shipment replaces the previous one.
+type DeviceLogs struct {
+	OrgID uuid.UUID `gorm:"type:uuid;primary_key;"`
+	Name  string    `gorm:"primary_key;"`
+
+	// The time the agent collected the logs.
+	CollectedAt time.Time
+
+	// What made the agent ship the logs.
+	Reason string
+
+	// Whether the agent dropped the oldest lines to stay within its size limits.
+	Truncated bool
+
+	// The log lines, oldest first, stored as opaque JSON array.
+	Lines *JSONField[[]string] `gorm:"type:jsonb"`
+
+	UpdatedAt time.Time
+}
+
+func (l DeviceLogs) String() string {
+	val, _ := json.Marshal(l)
+	return string(val)
+}
+
+func NewDeviceLogsFromApiResource(orgId uuid.UUID, name string, logs *api.DeviceLogs) *DeviceLogs {
+	return &DeviceLogs{
+		OrgID:       orgId,
+		Name:        name,
+		CollectedAt: logs.CollectedAt.UTC(),
+		Reason:      string(logs.Reason),
+		Truncated:   logs.Truncated != nil && *logs.Truncated,
+		Lines:       MakeJSONField(logs.Lines),
+	}
+}
+
+func (l *DeviceLogs) ToApiResource() api.DeviceLogs {
+	lines := []string{}
+	if l.Lines != nil && l.Lines.Data != nil {
+		lines = l.Lines.Data
+	}
+	truncated := l.Truncated
+	return api.DeviceLogs{
+		CollectedAt: l.CollectedAt.UTC(),
+		Reason:      api.DeviceLogsReason(l.Reason),
+		Truncated:   &truncated,
+		Lines:       lines,
+	}
+}
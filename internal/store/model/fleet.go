@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"strconv"
+	"time"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/flterrors"
@@ -50,8 +51,9 @@ func NewFleetFromApiResource(resource *api.Fleet) (*Fleet, error) {
 	return &Fleet{
 		Resource: Resource{
 			Name:            *resource.Metadata.Name,
-			Labels:          lo.FromPtrOr(resource.Metadata.Labels, make(map[string]string)),
-			Annotations:     lo.FromPtrOr(resource.Metadata.Annotations, make(map[string]string)),
+			Labels:          util.EnsureMap(lo.FromPtr(resource.Metadata.Labels)),
+			Annotations:     util.EnsureMap(lo.FromPtr(resource.Metadata.Annotations)),
+			ManagedFields:   lo.FromPtr(resource.Metadata.ManagedFields),
 			Generation:      resource.Metadata.Generation,
 			Owner:           resource.Metadata.Owner,
 			ResourceVersion: resourceVersion,
@@ -73,6 +75,17 @@ func WithSummary(summary *api.DevicesSummary) APIResourceOption {
 	}
 }
 
+// fleetApiValues holds the values an api.Fleet points to, so that converting a fleet takes a
+// single allocation for all of them.
+type fleetApiValues struct {
+	name              string
+	creationTimestamp time.Time
+	labels            map[string]string
+	annotations       map[string]string
+	resourceVersion   string
+	status            api.FleetStatus
+}
+
 func (f *Fleet) ToApiResource(opts ...APIResourceOption) api.Fleet {
 	if f == nil {
 		return api.Fleet{}
@@ -82,27 +95,42 @@ func (f *Fleet) ToApiResource(opts ...APIResourceOption) api.Fleet {
 	for _, opt := range opts {
 		opt(&options)
 	}
+	return f.toApiResource(options.summary)
+}
 
-	status := api.FleetStatus{Conditions: []api.Condition{}}
+func (f *Fleet) toApiResource(summary *api.DevicesSummary) api.Fleet {
+	v := &fleetApiValues{
+		name:              f.Name,
+		creationTimestamp: f.CreatedAt.UTC(),
+		labels:            util.EnsureMap(f.Resource.Labels),
+		annotations:       util.EnsureMap(f.Resource.Annotations),
+		status:            api.FleetStatus{Conditions: []api.Condition{}},
+	}
 	if f.Status != nil {
-		status = f.Status.Data
+		v.status = f.Status.Data
 	}
-	status.DevicesSummary = options.summary
+	v.status.DevicesSummary = summary
 
+	var resourceVersion *string
+	if f.ResourceVersion != nil {
+		v.resourceVersion = strconv.FormatInt(*f.ResourceVersion, 10)
+		resourceVersion = &v.resourceVersion
+	}
 	return api.Fleet{
 		ApiVersion: api.FleetAPIVersion,
 		Kind:       api.FleetKind,
 		Metadata: api.ObjectMeta{
-			Name:              util.StrToPtr(f.Name),
-			CreationTimestamp: util.TimeToPtr(f.CreatedAt.UTC()),
-			Labels:            lo.ToPtr(util.EnsureMap(f.Resource.Labels)),
-			Annotations:       lo.ToPtr(util.EnsureMap(f.Resource.Annotations)),
+			Name:              &v.name,
+			CreationTimestamp: &v.creationTimestamp,
+			Labels:            &v.labels,
+			Annotations:       &v.annotations,
+			ManagedFields:     lo.EmptyableToPtr(map[string][]string(f.Resource.ManagedFields)),
 			Generation:        f.Generation,
 			Owner:             f.Owner,
-			ResourceVersion:   lo.Ternary(f.ResourceVersion != nil, lo.ToPtr(strconv.FormatInt(lo.FromPtr(f.ResourceVersion), 10)), nil),
+			ResourceVersion:   resourceVersion,
 		},
 		Spec:   f.Spec.Data,
-		Status: &status,
+		Status: &v.status,
 	}
 }
 
@@ -116,12 +144,8 @@ func (dl FleetList) ToApiResource(cont *string, numRemaining *int64) api.FleetLi
 	}
 
 	fleetList := make([]api.Fleet, len(dl))
-	for i, fleet := range dl {
-		var opts []APIResourceOption
-		if fleet.Status.Data.DevicesSummary != nil {
-			opts = append(opts, WithSummary(fleet.Status.Data.DevicesSummary))
-		}
-		fleetList[i] = fleet.ToApiResource(opts...)
+	for i := range dl {
+		fleetList[i] = dl[i].toApiResource(dl[i].Status.Data.DevicesSummary)
 	}
 	ret := api.FleetList{
 		ApiVersion: api.FleetAPIVersion,
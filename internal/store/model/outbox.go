@@ -0,0 +1,31 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxEntry is a message to publish to a queue, written in the same transaction as the change
+// that caused it and published once that transaction is committed.
+type OutboxEntry struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement"`
+
+	// The queue the message is published to.
+	Queue string `gorm:"index"`
+
+	// The message, opaque to the store.
+	Payload []byte
+
+	CreatedAt time.Time
+
+	// The time the message was published, nil while it is pending.
+	SentAt *time.Time `gorm:"index"`
+
+	// The time until which a relay publishing the message holds it, nil while no relay does.
+	ClaimedUntil *time.Time
+}
+
+func (e OutboxEntry) String() string {
+	val, _ := json.Marshal(e)
+	return string(val)
+}
@@ -55,6 +55,7 @@ func NewRepositoryFromApiResource(resource *api.Repository) (*Repository, error)
 			Name:            *resource.Metadata.Name,
 			Labels:          lo.FromPtrOr(resource.Metadata.Labels, make(map[string]string)),
 			Annotations:     lo.FromPtrOr(resource.Metadata.Annotations, make(map[string]string)),
+			ManagedFields:   lo.FromPtr(resource.Metadata.ManagedFields),
 			ResourceVersion: resourceVersion,
 		},
 		Spec:   MakeJSONField(resource.Spec),
@@ -114,6 +115,7 @@ func (f *Repository) ToApiResource() (api.Repository, error) {
 			CreationTimestamp: util.TimeToPtr(f.CreatedAt.UTC()),
 			Labels:            lo.ToPtr(util.EnsureMap(f.Resource.Labels)),
 			Annotations:       lo.ToPtr(util.EnsureMap(f.Resource.Annotations)),
+			ManagedFields:     lo.EmptyableToPtr(map[string][]string(f.Resource.ManagedFields)),
 			ResourceVersion:   lo.Ternary(f.ResourceVersion != nil, lo.ToPtr(strconv.FormatInt(lo.FromPtr(f.ResourceVersion), 10)), nil),
 		},
 		Spec:   spec,
@@ -31,6 +31,9 @@ type Resource struct {
 	// Similar to labels, annotations are stored as a JSONB object to support flexible indexing and querying.
 	Annotations JSONMap[string, string] `gorm:"type:jsonb" selector:"metadata.annotations,hidden,private"`
 
+	// The fields set through server-side apply, keyed by the name of the field manager that owns them.
+	ManagedFields JSONMap[string, []string] `gorm:"type:jsonb"`
+
 	Generation      *int64
 	ResourceVersion *int64
 	CreatedAt       time.Time `selector:"metadata.creationTimestamp"`
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// ResourceRevision is a past version of the spec of a resource, identified by the generation the
+// resource had with that spec.
+type ResourceRevision struct {
+	OrgID    uuid.UUID `gorm:"type:uuid;primary_key;"`
+	Kind     string    `gorm:"primary_key;"`
+	Name     string    `gorm:"primary_key;"`
+	Revision int64     `gorm:"primary_key;"`
+
+	// The user that applied the spec, empty if it was set by the service.
+	Actor string
+
+	// The spec of the resource, stored as opaque JSON object.
+	Spec *JSONField[map[string]interface{}] `gorm:"type:jsonb"`
+
+	CreatedAt time.Time
+}
+
+type ResourceRevisionList []ResourceRevision
+
+func (r ResourceRevision) String() string {
+	val, _ := json.Marshal(r)
+	return string(val)
+}
+
+func (r *ResourceRevision) ToApiResource() api.ResourceRevision {
+	var spec map[string]interface{}
+	if r.Spec != nil {
+		spec = r.Spec.Data
+	}
+	return api.ResourceRevision{
+		Revision:  r.Revision,
+		Timestamp: r.CreatedAt.UTC(),
+		Actor:     lo.EmptyableToPtr(r.Actor),
+		Spec:      spec,
+	}
+}
+
+func (rl ResourceRevisionList) ToApiResource() api.ResourceRevisionList {
+	items := make([]api.ResourceRevision, len(rl))
+	for i := range rl {
+		items[i] = rl[i].ToApiResource()
+	}
+	return api.ResourceRevisionList{
+		ApiVersion: api.ResourceRevisionAPIVersion,
+		Kind:       api.ResourceRevisionListKind,
+		Items:      items,
+		Metadata:   api.ListMeta{},
+	}
+}
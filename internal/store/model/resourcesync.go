@@ -2,7 +2,9 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
+	"strings"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/flterrors"
@@ -49,6 +51,7 @@ func NewResourceSyncFromApiResource(resource *api.ResourceSync) (*ResourceSync,
 			Name:            *resource.Metadata.Name,
 			Labels:          lo.FromPtrOr(resource.Metadata.Labels, make(map[string]string)),
 			Annotations:     lo.FromPtrOr(resource.Metadata.Annotations, make(map[string]string)),
+			ManagedFields:   lo.FromPtr(resource.Metadata.ManagedFields),
 			ResourceVersion: resourceVersion,
 		},
 		Spec:   MakeJSONField(resource.Spec),
@@ -79,6 +82,7 @@ func (r *ResourceSync) ToApiResource() api.ResourceSync {
 			CreationTimestamp: util.TimeToPtr(r.CreatedAt.UTC()),
 			Labels:            lo.ToPtr(util.EnsureMap(r.Resource.Labels)),
 			Annotations:       lo.ToPtr(util.EnsureMap(r.Resource.Annotations)),
+			ManagedFields:     lo.EmptyableToPtr(map[string][]string(r.Resource.ManagedFields)),
 			Generation:        r.Generation,
 			ResourceVersion:   lo.Ternary(r.ResourceVersion != nil, lo.ToPtr(strconv.FormatInt(lo.FromPtr(r.ResourceVersion), 10)), nil),
 		},
@@ -168,3 +172,40 @@ func (rs *ResourceSync) AddResourceParsedCondition(err error) {
 func (rs *ResourceSync) AddSyncedCondition(err error) {
 	rs.SetCondition(api.ResourceSyncSynced, "Success", "Fail", err)
 }
+
+// SetDrift reports the synced resources whose live state differs from the last synced commit.
+// Drift that was corrected is only reported in the condition.
+func (rs *ResourceSync) SetDrift(drift []api.ResourceSyncDrift, corrected bool) {
+	rs.ensureConditionsNotNil()
+	condition := api.Condition{
+		Type:    api.ResourceSyncDrifted,
+		Status:  api.ConditionStatusFalse,
+		Reason:  "NoDrift",
+		Message: "live state matches the repository",
+	}
+	rs.Status.Data.Drift = nil
+	switch {
+	case len(drift) == 0:
+	case corrected:
+		condition.Reason = "Corrected"
+		condition.Message = fmt.Sprintf("corrected drift of %s", driftSummary(drift))
+	default:
+		condition.Status = api.ConditionStatusTrue
+		condition.Reason = "Drifted"
+		condition.Message = fmt.Sprintf("live state differs from the repository: %s", driftSummary(drift))
+		rs.Status.Data.Drift = &drift
+	}
+	api.SetStatusCondition(&rs.Status.Data.Conditions, condition)
+}
+
+func driftSummary(drift []api.ResourceSyncDrift) string {
+	resources := make([]string, len(drift))
+	for i, d := range drift {
+		details := strings.Join(d.Fields, ", ")
+		if lo.FromPtr(d.Deleted) {
+			details = "deleted"
+		}
+		resources[i] = fmt.Sprintf("%s/%s (%s)", d.Kind, d.Name, details)
+	}
+	return strings.Join(resources, ", ")
+}
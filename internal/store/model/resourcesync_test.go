@@ -88,3 +88,30 @@ func getTestRS(obsHash *string, obsGen *int64) ResourceSync {
 	}
 	return rs
 }
+
+func TestSetDrift(t *testing.T) {
+	require := require.New(t)
+	rs := getTestRS(util.StrToPtr("hash"), util.Int64ToPtr(1))
+	drift := []api.ResourceSyncDrift{
+		{Kind: api.FleetKind, Name: "fleet-1", Fields: []string{"metadata.labels.env", "spec.template.spec.os.image"}},
+		{Kind: api.FleetKind, Name: "fleet-2", Fields: []string{}, Deleted: util.BoolToPtr(true)},
+	}
+
+	rs.SetDrift(drift, false)
+	condition := api.FindStatusCondition(rs.Status.Data.Conditions, api.ResourceSyncDrifted)
+	require.NotNil(condition)
+	require.Equal(api.ConditionStatusTrue, condition.Status)
+	require.Equal("live state differs from the repository: Fleet/fleet-1 (metadata.labels.env, spec.template.spec.os.image), Fleet/fleet-2 (deleted)", condition.Message)
+	require.Equal(drift, *rs.Status.Data.Drift)
+
+	rs.SetDrift(drift, true)
+	condition = api.FindStatusCondition(rs.Status.Data.Conditions, api.ResourceSyncDrifted)
+	require.Equal(api.ConditionStatusFalse, condition.Status)
+	require.Equal("Corrected", condition.Reason)
+	require.Nil(rs.Status.Data.Drift)
+
+	rs.SetDrift(nil, false)
+	condition = api.FindStatusCondition(rs.Status.Data.Conditions, api.ResourceSyncDrifted)
+	require.Equal(api.ConditionStatusFalse, condition.Status)
+	require.Equal("NoDrift", condition.Reason)
+}
@@ -0,0 +1,89 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Orphans finds and deletes the rows left behind by resources that no longer exist, e.g. when
+// deleting a device failed half-way. Deleting a resource normally deletes these rows as well.
+type Orphans interface {
+	// Count returns the number of orphaned rows of each kind.
+	Count(ctx context.Context, orgId uuid.UUID) (map[string]int64, error)
+	// Delete deletes the orphaned rows that have not changed since olderThan, and returns the
+	// number of deleted rows of each kind.
+	Delete(ctx context.Context, orgId uuid.UUID, olderThan time.Time) (map[string]int64, error)
+}
+
+type OrphansStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to Orphans interface
+var _ Orphans = (*OrphansStore)(nil)
+
+const (
+	OrphanedDeviceLogs         = "DeviceLogs"
+	OrphanedEnrollmentRequests = "EnrollmentRequests"
+)
+
+// orphanedRows maps the kinds of orphaned rows to their table, and the condition under which a row
+// of the table is orphaned.
+var orphanedRows = map[string]struct {
+	table     string
+	condition string
+}{
+	// logs shipped by devices that were deleted
+	OrphanedDeviceLogs: {
+		table:     "device_logs",
+		condition: `NOT EXISTS (SELECT 1 FROM devices d WHERE d.org_id = device_logs.org_id AND d.name = device_logs.name)`,
+	},
+	// approved enrollment requests of devices that were deleted, which keep the device from
+	// enrolling again; pending requests have no device yet
+	OrphanedEnrollmentRequests: {
+		table: "enrollment_requests",
+		condition: `status -> 'conditions' @> '[{"type": "Approved", "status": "True"}]'
+			AND NOT EXISTS (SELECT 1 FROM devices d WHERE d.org_id = enrollment_requests.org_id AND d.name = enrollment_requests.name)`,
+	},
+}
+
+func NewOrphans(db *gorm.DB, log logrus.FieldLogger) Orphans {
+	return &OrphansStore{db: db, log: log}
+}
+
+func (s *OrphansStore) Count(ctx context.Context, orgId uuid.UUID) (map[string]int64, error) {
+	counts := map[string]int64{}
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		for kind, rows := range orphanedRows {
+			var count int64
+			if err := tx.Table(rows.table).Where("org_id = ? AND "+rows.condition, orgId).Count(&count).Error; err != nil {
+				return err
+			}
+			counts[kind] = count
+		}
+		return nil
+	})
+	return counts, ErrorFromGormError(err)
+}
+
+func (s *OrphansStore) Delete(ctx context.Context, orgId uuid.UUID, olderThan time.Time) (map[string]int64, error) {
+	deleted := map[string]int64{}
+	for kind, rows := range orphanedRows {
+		var rowsAffected int64
+		err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+			result := tx.Exec(`DELETE FROM `+rows.table+` WHERE org_id = ? AND updated_at < ? AND `+rows.condition, orgId, olderThan)
+			rowsAffected = result.RowsAffected
+			return result.Error
+		})
+		if err != nil {
+			return deleted, ErrorFromGormError(err)
+		}
+		deleted[kind] = rowsAffected
+	}
+	return deleted, nil
+}
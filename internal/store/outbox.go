@@ -0,0 +1,179 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxClaimTimeout is the time a relay holds the messages it publishes. The messages a relay
+// did not mark sent within that time, e.g. because it stopped, are published again.
+const OutboxClaimTimeout = 5 * time.Minute
+
+// Outbox stores the messages to publish to the queues. The stores call the callbacks of a write
+// in the transaction of the write, with a context carrying that transaction, so that the messages
+// the callbacks enqueue are committed or rolled back together with the write. A relay then
+// publishes the committed messages, which are therefore not lost if the process stops between
+// the commit and the publication.
+type Outbox interface {
+	InitialMigration() error
+	// Enqueue writes a message for the queue, in the transaction ctx carries if there is one.
+	Enqueue(ctx context.Context, queue string, payload []byte) error
+	// Relay claims up to limit pending messages of the queue, publishes them in the order they
+	// were enqueued and marks them sent. The messages are published outside of any transaction,
+	// the claim keeping other relays from publishing them until OutboxClaimTimeout. It stops at
+	// the first message that cannot be published, which is released for the next relay to retry,
+	// and returns the number of messages published.
+	Relay(ctx context.Context, queue string, limit int, publish func(payload []byte) error) (int, error)
+	// RelayInOrder is Relay for the queues whose messages must be published in the order they
+	// were enqueued across all relays: it publishes nothing while another relay holds messages
+	// of the queue, instead of skipping the messages that relay holds.
+	RelayInOrder(ctx context.Context, queue string, limit int, publish func(payload []byte) error) (int, error)
+	// DeleteSent deletes the messages sent before the given time.
+	DeleteSent(ctx context.Context, before time.Time) (int64, error)
+}
+
+type OutboxStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to Outbox interface
+var _ Outbox = (*OutboxStore)(nil)
+
+func NewOutbox(db *gorm.DB, log logrus.FieldLogger) Outbox {
+	return &OutboxStore{db: db, log: log}
+}
+
+func (s *OutboxStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.OutboxEntry{})
+}
+
+func (s *OutboxStore) Enqueue(ctx context.Context, queue string, payload []byte) error {
+	entry := model.OutboxEntry{Queue: queue, Payload: payload}
+	return ErrorFromGormError(dbFromContext(ctx, s.db).WithContext(ctx).Create(&entry).Error)
+}
+
+func (s *OutboxStore) Relay(ctx context.Context, queue string, limit int, publish func(payload []byte) error) (int, error) {
+	return s.relay(ctx, queue, limit, publish, false)
+}
+
+func (s *OutboxStore) RelayInOrder(ctx context.Context, queue string, limit int, publish func(payload []byte) error) (int, error) {
+	return s.relay(ctx, queue, limit, publish, true)
+}
+
+func (s *OutboxStore) relay(ctx context.Context, queue string, limit int, publish func(payload []byte) error, inOrder bool) (int, error) {
+	entries, err := s.claim(ctx, queue, limit, inOrder)
+	if err != nil || len(entries) == 0 {
+		return 0, err
+	}
+
+	var published []uint64
+	var publishErr error
+	for i := range entries {
+		if publishErr = publish(entries[i].Payload); publishErr != nil {
+			break
+		}
+		published = append(published, entries[i].ID)
+	}
+	released := lo.Map(entries[len(published):], func(entry model.OutboxEntry, _ int) uint64 { return entry.ID })
+
+	// the relay may have been canceled while publishing, which must not keep the published
+	// messages from being marked sent
+	err = s.db.WithContext(context.WithoutCancel(ctx)).Transaction(func(innerTx *gorm.DB) error {
+		if len(published) > 0 {
+			err := innerTx.Model(&model.OutboxEntry{}).Where("id IN ?", published).
+				Updates(map[string]interface{}{"sent_at": time.Now(), "claimed_until": nil}).Error
+			if err != nil {
+				return ErrorFromGormError(err)
+			}
+		}
+		if len(released) > 0 {
+			return ErrorFromGormError(innerTx.Model(&model.OutboxEntry{}).Where("id IN ?", released).Update("claimed_until", nil).Error)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(published), publishErr
+}
+
+// claim returns up to limit pending messages of the queue that no other relay holds, and holds
+// them until OutboxClaimTimeout. In order, it returns no messages while another relay holds
+// messages of the queue.
+func (s *OutboxStore) claim(ctx context.Context, queue string, limit int, inOrder bool) ([]model.OutboxEntry, error) {
+	var entries []model.OutboxEntry
+	now := time.Now()
+	err := s.db.WithContext(ctx).Transaction(func(innerTx *gorm.DB) error {
+		query := innerTx.Where("queue = ? AND sent_at IS NULL", queue)
+		if inOrder {
+			locked, err := s.lockQueue(innerTx, queue)
+			if err != nil || !locked {
+				return err
+			}
+			var held int64
+			if err := innerTx.Model(&model.OutboxEntry{}).Where("queue = ? AND sent_at IS NULL AND claimed_until > ?", queue, now).Count(&held).Error; err != nil {
+				return ErrorFromGormError(err)
+			}
+			if held > 0 {
+				return nil
+			}
+		} else {
+			// Skip the messages held or being claimed by other relays rather than publishing them twice
+			query = query.Where("claimed_until IS NULL OR claimed_until <= ?", now).
+				Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+		if err := query.Order("id").Limit(limit).Find(&entries).Error; err != nil {
+			return ErrorFromGormError(err)
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		ids := lo.Map(entries, func(entry model.OutboxEntry, _ int) uint64 { return entry.ID })
+		return ErrorFromGormError(innerTx.Model(&model.OutboxEntry{}).Where("id IN ?", ids).Update("claimed_until", now.Add(OutboxClaimTimeout)).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// lockQueue takes the lock of the queue for the rest of the transaction tx, and returns false if
+// another transaction holds it, i.e. another relay is claiming messages of the queue. Without postgres there is a single relay.
+func (s *OutboxStore) lockQueue(tx *gorm.DB, queue string) (bool, error) {
+	if tx.Dialector.Name() != "postgres" {
+		return true, nil
+	}
+	var locked bool
+	if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", "outbox/"+queue).Scan(&locked).Error; err != nil {
+		return false, ErrorFromGormError(err)
+	}
+	return locked, nil
+}
+
+func (s *OutboxStore) DeleteSent(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Where("sent_at < ?", before).Delete(&model.OutboxEntry{})
+	return result.RowsAffected, ErrorFromGormError(result.Error)
+}
+
+type transactionKey struct{}
+
+// withTransaction returns a context carrying the transaction, for the callbacks of a write to
+// enqueue their messages in it.
+func withTransaction(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, transactionKey{}, tx)
+}
+
+// dbFromContext returns the transaction ctx carries, or db if it carries none.
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,167 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openOutboxTestDB(t *testing.T) (*gorm.DB, Outbox) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "outbox.db")), &gorm.Config{})
+	require.NoError(t, err)
+	outbox := NewOutbox(db, log.InitLogs())
+	require.NoError(t, outbox.InitialMigration())
+	return db, outbox
+}
+
+type outboxTestPublisher struct {
+	published [][]byte
+	err       error
+}
+
+func (p *outboxTestPublisher) publish(payload []byte) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, payload)
+	return nil
+}
+
+func TestOutboxEnqueueInTransaction(t *testing.T) {
+	require := require.New(t)
+	db, outbox := openOutboxTestDB(t)
+	ctx := context.Background()
+
+	// the messages of a write that is rolled back are never published
+	err := db.Transaction(func(tx *gorm.DB) error {
+		require.NoError(outbox.Enqueue(withTransaction(ctx, tx), "queue", []byte("rolled-back")))
+		return errors.New("write failed")
+	})
+	require.Error(err)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return outbox.Enqueue(withTransaction(ctx, tx), "queue", []byte("committed"))
+	})
+	require.NoError(err)
+	require.NoError(outbox.Enqueue(ctx, "other", []byte("other queue")))
+
+	publisher := &outboxTestPublisher{}
+	published, err := outbox.Relay(ctx, "queue", 10, publisher.publish)
+	require.NoError(err)
+	require.Equal(1, published)
+	require.Equal([][]byte{[]byte("committed")}, publisher.published)
+}
+
+func TestOutboxRelay(t *testing.T) {
+	require := require.New(t)
+	_, outbox := openOutboxTestDB(t)
+	ctx := context.Background()
+
+	for _, payload := range []string{"first", "second", "third"} {
+		require.NoError(outbox.Enqueue(ctx, "queue", []byte(payload)))
+	}
+
+	// messages that cannot be published stay pending
+	publisher := &outboxTestPublisher{err: errors.New("queue unavailable")}
+	published, err := outbox.Relay(ctx, "queue", 10, publisher.publish)
+	require.Error(err)
+	require.Zero(published)
+
+	// they are published in order, in batches, and only once
+	publisher.err = nil
+	published, err = outbox.Relay(ctx, "queue", 2, publisher.publish)
+	require.NoError(err)
+	require.Equal(2, published)
+	published, err = outbox.Relay(ctx, "queue", 2, publisher.publish)
+	require.NoError(err)
+	require.Equal(1, published)
+	published, err = outbox.Relay(ctx, "queue", 2, publisher.publish)
+	require.NoError(err)
+	require.Zero(published)
+	require.Equal([][]byte{[]byte("first"), []byte("second"), []byte("third")}, publisher.published)
+
+	deleted, err := outbox.DeleteSent(ctx, time.Now().Add(-time.Hour))
+	require.NoError(err)
+	require.Zero(deleted)
+	deleted, err = outbox.DeleteSent(ctx, time.Now().Add(time.Second))
+	require.NoError(err)
+	require.Equal(int64(3), deleted)
+}
+
+func TestOutboxRelayClaimsMessages(t *testing.T) {
+	require := require.New(t)
+	db, outbox := openOutboxTestDB(t)
+	ctx := context.Background()
+
+	for _, payload := range []string{"first", "second", "third"} {
+		require.NoError(outbox.Enqueue(ctx, "queue", []byte(payload)))
+	}
+
+	// the messages are published outside of the transaction claiming them, and another relay
+	// skips the messages held by the first one
+	var nested []int
+	publisher := &outboxTestPublisher{}
+	publish := func(payload []byte) error {
+		published, err := outbox.Relay(ctx, "queue", 10, publisher.publish)
+		require.NoError(err)
+		nested = append(nested, published)
+		return publisher.publish(payload)
+	}
+	published, err := outbox.Relay(ctx, "queue", 2, publish)
+	require.NoError(err)
+	require.Equal(2, published)
+	require.Equal([]int{1, 0}, nested)
+	require.Equal([][]byte{[]byte("third"), []byte("first"), []byte("second")}, publisher.published)
+
+	// a relay stopping before marking its messages sent publishes them again once its claim expires
+	require.NoError(outbox.Enqueue(ctx, "queue", []byte("fourth")))
+	require.NoError(db.Model(&model.OutboxEntry{}).Where("sent_at IS NULL").Update("claimed_until", time.Now().Add(time.Minute)).Error)
+	published, err = outbox.Relay(ctx, "queue", 10, publisher.publish)
+	require.NoError(err)
+	require.Zero(published)
+	require.NoError(db.Model(&model.OutboxEntry{}).Where("sent_at IS NULL").Update("claimed_until", time.Now().Add(-time.Second)).Error)
+	published, err = outbox.Relay(ctx, "queue", 10, publisher.publish)
+	require.NoError(err)
+	require.Equal(1, published)
+}
+
+func TestOutboxRelayInOrder(t *testing.T) {
+	require := require.New(t)
+	_, outbox := openOutboxTestDB(t)
+	ctx := context.Background()
+
+	for _, payload := range []string{"first", "second", "third"} {
+		require.NoError(outbox.Enqueue(ctx, "queue", []byte(payload)))
+	}
+
+	// a relay publishes nothing while another one holds messages of the queue
+	publisher := &outboxTestPublisher{}
+	publish := func(payload []byte) error {
+		published, err := outbox.RelayInOrder(ctx, "queue", 10, publisher.publish)
+		require.NoError(err)
+		require.Zero(published)
+		return publisher.publish(payload)
+	}
+	published, err := outbox.RelayInOrder(ctx, "queue", 1, publish)
+	require.NoError(err)
+	require.Equal(1, published)
+
+	// the messages a relay failed to publish are released for the next one
+	publisher.err = errors.New("sink unavailable")
+	published, err = outbox.RelayInOrder(ctx, "queue", 10, publisher.publish)
+	require.Error(err)
+	require.Zero(published)
+	publisher.err = nil
+	published, err = outbox.RelayInOrder(ctx, "queue", 10, publisher.publish)
+	require.NoError(err)
+	require.Equal(2, published)
+	require.Equal([][]byte{[]byte("first"), []byte("second"), []byte("third")}, publisher.published)
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"fmt"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WithQuotas caps the number of resources each organization can have, falling back to the
+// default quota for the organizations without one of their own. The organizations are keyed by
+// organization ID, the keys that are not are ignored.
+func WithQuotas(defaultQuota config.Quota, organizations map[string]config.Quota) Option {
+	return func(o *storeOptions) {
+		o.quotas = &quotas{
+			defaultQuota:  defaultQuota,
+			organizations: map[uuid.UUID]config.Quota{},
+		}
+		for orgId, quota := range organizations {
+			if id, err := uuid.Parse(orgId); err == nil {
+				o.quotas.organizations[id] = quota
+			}
+		}
+	}
+}
+
+// quotas caps the number of resources each organization can have. A nil quotas caps nothing.
+type quotas struct {
+	defaultQuota  config.Quota
+	organizations map[uuid.UUID]config.Quota
+}
+
+// limit returns the number of resources of the kind the organization can have, 0 if unlimited.
+func (q *quotas) limit(orgId uuid.UUID, kind string) int64 {
+	if q == nil {
+		return 0
+	}
+	quota, ok := q.organizations[orgId]
+	if !ok {
+		quota = q.defaultQuota
+	}
+	switch kind {
+	case api.DeviceKind:
+		return quota.MaxDevices
+	case api.FleetKind:
+		return quota.MaxFleets
+	default:
+		return 0
+	}
+}
+
+// enforce returns ErrQuotaExceeded if the organization cannot have another resource of the kind.
+// It is called in the transaction tx creating the resource, and with postgres holds the lock of
+// the quota until the end of tx, so that concurrent creations cannot exceed the quota together.
+func (q *quotas) enforce(tx *gorm.DB, orgId uuid.UUID, kind string) error {
+	limit := q.limit(orgId, kind)
+	if limit == 0 {
+		return nil
+	}
+	if tx.Dialector.Name() == "postgres" {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", fmt.Sprintf("quota/%s/%s", orgId, kind)).Error; err != nil {
+			return ErrorFromGormError(err)
+		}
+	}
+	used, err := count(tx, orgId, kind)
+	if err != nil {
+		return err
+	}
+	if used >= limit {
+		return fmt.Errorf("%w: the organization has %d of at most %d resources of kind %s", flterrors.ErrQuotaExceeded, used, limit, kind)
+	}
+	return nil
+}
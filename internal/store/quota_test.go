@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceQuota(t *testing.T) {
+	require := require.New(t)
+	s := openDeviceChangesTestStore(t, WithQuotas(config.Quota{MaxDevices: 2}, nil))
+	ctx := context.Background()
+	orgId := uuid.New()
+	callback := func(context.Context, *model.Device, *model.Device) error { return nil }
+
+	for _, name := range []string{"device-1", "device-2"} {
+		_, err := s.Device().Create(ctx, orgId, changesTestDevice(name, nil), callback)
+		require.NoError(err)
+	}
+
+	// creating a device beyond the quota fails, replacing an existing one does not
+	_, err := s.Device().Create(ctx, orgId, changesTestDevice("device-3", nil), callback)
+	require.ErrorIs(err, flterrors.ErrQuotaExceeded)
+	require.EqualError(err, "quota exceeded: the organization has 2 of at most 2 resources of kind Device")
+	_, _, err = s.Device().CreateOrUpdate(ctx, orgId, changesTestDevice("device-3", nil), nil, true, callback)
+	require.ErrorIs(err, flterrors.ErrQuotaExceeded)
+	_, created, err := s.Device().CreateOrUpdate(ctx, orgId, changesTestDevice("device-1", map[string]string{"key": "value"}), nil, true, callback)
+	require.NoError(err)
+	require.False(created)
+
+	// the quota is per organization
+	_, err = s.Device().Create(ctx, uuid.New(), changesTestDevice("device-3", nil), callback)
+	require.NoError(err)
+}
+
+func TestFleetQuotaOfResourceSync(t *testing.T) {
+	require := require.New(t)
+	orgId := uuid.New()
+	s := openChangesTestStore(t, []Option{WithQuotas(config.Quota{}, map[string]config.Quota{orgId.String(): {MaxFleets: 1}})}, &model.Fleet{})
+	ctx := context.Background()
+
+	fleet := func(name string) *api.Fleet {
+		return &api.Fleet{Metadata: api.ObjectMeta{Name: lo.ToPtr(name), Owner: lo.ToPtr("ResourceSync/sync")}}
+	}
+	// the fleets applied by a resourcesync are subject to the quota as well
+	err := s.Fleet().CreateOrUpdateMultiple(ctx, orgId, func(context.Context, *model.Fleet, *model.Fleet) error { return nil }, fleet("fleet-1"), fleet("fleet-2"))
+	require.ErrorIs(err, flterrors.ErrQuotaExceeded)
+	count, err := s.Usage().Count(ctx, orgId, api.FleetKind)
+	require.NoError(err)
+	require.Equal(int64(1), count)
+
+	require.Equal(int64(1), s.Usage().Limit(orgId, api.FleetKind))
+	require.Equal(int64(0), s.Usage().Limit(orgId, api.DeviceKind))
+	require.Equal(int64(0), s.Usage().Limit(uuid.New(), api.FleetKind))
+}
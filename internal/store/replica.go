@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type staleReadsKey struct{}
+
+// WithStaleReads marks the operations run with the returned context as tolerating data that lags
+// behind the primary database, allowing their List and Get queries to be served by the read
+// replica when one is configured.
+func WithStaleReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, staleReadsKey{}, true)
+}
+
+func staleReadsAllowed(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	allowed, _ := ctx.Value(staleReadsKey{}).(bool)
+	return allowed
+}
+
+// readReplicaPlugin is the name under which UseReadReplica registers the replica with the database.
+const readReplicaPlugin = "flightctl:read_replica"
+
+type readReplica struct {
+	pool gorm.ConnPool
+}
+
+func (r *readReplica) Name() string {
+	return readReplicaPlugin
+}
+
+func (r *readReplica) Initialize(db *gorm.DB) error {
+	route := func(tx *gorm.DB) {
+		if !staleReadsAllowed(tx.Statement.Context) {
+			return
+		}
+		if _, inTransaction := tx.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+			return
+		}
+		tx.Statement.ConnPool = r.pool
+	}
+
+	if err := db.Callback().Query().Before("gorm:query").Register(readReplicaPlugin, route); err != nil {
+		return err
+	}
+	return db.Callback().Row().Before("gorm:row").Register(readReplicaPlugin, route)
+}
+
+// UseReadReplica routes queries that tolerate stale reads to the replica. Writes, queries run
+// inside a transaction and queries without the stale reads flag keep using the primary.
+func UseReadReplica(db *gorm.DB, replica *gorm.DB) error {
+	return db.Use(&readReplica{pool: replica.Config.ConnPool})
+}
+
+// replicaSession returns a session of db running its statements, transactions included, on the read
+// replica when the context tolerates stale reads and a replica is configured.
+func replicaSession(ctx context.Context, db *gorm.DB) *gorm.DB {
+	session := db.WithContext(ctx)
+	if !staleReadsAllowed(ctx) {
+		return session
+	}
+	if _, inTransaction := session.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+		return session
+	}
+	if replica, ok := db.Config.Plugins[readReplicaPlugin].(*readReplica); ok {
+		session.Statement.ConnPool = replica.pool
+	}
+	return session
+}
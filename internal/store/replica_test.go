@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type replicaTestRecord struct {
+	ID   uint
+	Name string
+}
+
+func openReplicaTestDB(t *testing.T, name string) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), name+".db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&replicaTestRecord{}))
+	require.NoError(t, db.Create(&replicaTestRecord{ID: 1, Name: name}).Error)
+	return db
+}
+
+func TestReadReplicaRouting(t *testing.T) {
+	require := require.New(t)
+	primary := openReplicaTestDB(t, "primary")
+	replica := openReplicaTestDB(t, "replica")
+	require.NoError(UseReadReplica(primary, replica))
+
+	ctx := context.Background()
+	staleCtx := WithStaleReads(ctx)
+
+	var record replicaTestRecord
+	require.NoError(primary.WithContext(staleCtx).First(&record, 1).Error)
+	require.Equal("replica", record.Name)
+
+	var records []replicaTestRecord
+	require.NoError(primary.WithContext(staleCtx).Table("replica_test_records").Scan(&records).Error)
+	require.Equal([]replicaTestRecord{{ID: 1, Name: "replica"}}, records)
+
+	// reads without the flag go to the primary
+	require.NoError(primary.WithContext(ctx).First(&record, 1).Error)
+	require.Equal("primary", record.Name)
+	require.NoError(primary.First(&record, 1).Error)
+	require.Equal("primary", record.Name)
+
+	// writes and reads inside a transaction stay on the primary
+	require.NoError(primary.WithContext(staleCtx).Create(&replicaTestRecord{ID: 2, Name: "written"}).Error)
+	var written replicaTestRecord
+	err := primary.WithContext(staleCtx).Transaction(func(tx *gorm.DB) error {
+		return tx.First(&written, 2).Error
+	})
+	require.NoError(err)
+	require.Equal("written", written.Name)
+
+	var count int64
+	require.NoError(replica.Model(&replicaTestRecord{}).Count(&count).Error)
+	require.Equal(int64(1), count)
+}
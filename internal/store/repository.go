@@ -32,18 +32,23 @@ type Repository interface {
 }
 
 type RepositoryStore struct {
-	db  *gorm.DB
-	log logrus.FieldLogger
+	db      *gorm.DB
+	log     logrus.FieldLogger
+	changes *changeCapture
 }
 
-type RepositoryStoreCallback func(*model.Repository)
-type RepositoryStoreAllDeletedCallback func(uuid.UUID)
+type RepositoryStoreCallback func(context.Context, *model.Repository) error
+type RepositoryStoreAllDeletedCallback func(context.Context, uuid.UUID) error
 
 // Make sure we conform to Repository interface
 var _ Repository = (*RepositoryStore)(nil)
 
 func NewRepository(db *gorm.DB, log logrus.FieldLogger) Repository {
-	return &RepositoryStore{db: db, log: log}
+	return newRepository(db, log, nil)
+}
+
+func newRepository(db *gorm.DB, log logrus.FieldLogger, changes *changeCapture) Repository {
+	return &RepositoryStore{db: db, log: log, changes: changes}
 }
 
 func (s *RepositoryStore) InitialMigration() error {
@@ -87,12 +92,21 @@ func (s *RepositoryStore) Create(ctx context.Context, orgId uuid.UUID, resource
 
 func (s *RepositoryStore) Update(ctx context.Context, orgId uuid.UUID, resource *api.Repository, callback RepositoryStoreCallback) (*api.Repository, error) {
 	updatedResource, _, err := retryCreateOrUpdate(func() (*api.Repository, bool, bool, error) {
-		return s.createOrUpdate(orgId, resource, ModeUpdateOnly, callback)
+		return s.createOrUpdate(ctx, orgId, resource, ModeUpdateOnly, callback)
 	})
 	return updatedResource, err
 }
 
 func (s *RepositoryStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.RepositoryList, error) {
+	var list *api.RepositoryList
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) (err error) {
+		list, err = s.list(ctx, tx, orgId, listParams)
+		return err
+	})
+	return list, err
+}
+
+func (s *RepositoryStore) list(ctx context.Context, db *gorm.DB, orgId uuid.UUID, listParams ListParams) (*api.RepositoryList, error) {
 	var repositories model.RepositoryList
 	var nextContinue *string
 	var numRemaining *int64
@@ -101,7 +115,7 @@ func (s *RepositoryStore) List(ctx context.Context, orgId uuid.UUID, listParams
 		return nil, flterrors.ErrLimitParamOutOfBounds
 	}
 
-	query, err := ListQuery(&model.Repository{}).Build(ctx, s.db, orgId, listParams)
+	query, err := ListQuery(&model.Repository{}).Build(ctx, db, orgId, listParams)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +141,7 @@ func (s *RepositoryStore) List(ctx context.Context, orgId uuid.UUID, listParams
 				numRemainingVal = 1
 			}
 		} else {
-			countQuery, err := ListQuery(&model.Repository{}).Build(ctx, s.db, orgId, listParams)
+			countQuery, err := ListQuery(&model.Repository{}).Build(ctx, db, orgId, listParams)
 			if err != nil {
 				return nil, err
 			}
@@ -154,20 +168,30 @@ func (s *RepositoryStore) List(ctx context.Context, orgId uuid.UUID, listParams
 func (s *RepositoryStore) ListIgnoreOrg() ([]model.Repository, error) {
 	var repositories model.RepositoryList
 
-	result := s.db.Model(&repositories).Where("spec IS NOT NULL").Find(&repositories)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
+	err := OrgBypassTransaction(s.db, func(tx *gorm.DB) error {
+		return tx.Model(&repositories).Where("spec IS NOT NULL").Find(&repositories).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
 	}
 	return repositories, nil
 }
 
 func (s *RepositoryStore) DeleteAll(ctx context.Context, orgId uuid.UUID, callback RepositoryStoreAllDeletedCallback) error {
-	condition := model.Repository{}
-	result := s.db.Unscoped().Where("spec IS NOT NULL AND org_id = ?", orgId).Delete(&condition)
-	if result.Error == nil {
-		callback(orgId)
-	}
-	return ErrorFromGormError(result.Error)
+	return OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		names, err := s.changes.pluckNames(innerTx.Where("spec IS NOT NULL"), &model.Repository{}, orgId)
+		if err != nil {
+			return err
+		}
+		condition := model.Repository{}
+		if err := innerTx.Unscoped().Where("spec IS NOT NULL AND org_id = ?", orgId).Delete(&condition).Error; err != nil {
+			return ErrorFromGormError(err)
+		}
+		if err := s.changes.captureDeleted(ctx, innerTx, api.RepositoryKind, orgId, names...); err != nil {
+			return err
+		}
+		return callback(withTransaction(ctx, innerTx), orgId)
+	})
 }
 
 func (s *RepositoryStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Repository, error) {
@@ -183,24 +207,26 @@ func (s *RepositoryStore) GetInternal(ctx context.Context, orgId uuid.UUID, name
 	repository := model.Repository{
 		Resource: model.Resource{OrgID: orgId, Name: name},
 	}
-	result := s.db.Where("spec IS NOT NULL").First(&repository)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.Where("spec IS NOT NULL").First(&repository).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
 	}
 	return &repository, nil
 }
 
-func (s *RepositoryStore) createRepository(repository *model.Repository) (bool, error) {
+func (s *RepositoryStore) createRepository(db *gorm.DB, repository *model.Repository) (bool, error) {
 	repository.Generation = lo.ToPtr[int64](1)
 	repository.ResourceVersion = lo.ToPtr[int64](1)
-	if result := s.db.Create(repository); result.Error != nil {
+	if result := db.Create(repository); result.Error != nil {
 		err := ErrorFromGormError(result.Error)
 		return err == flterrors.ErrDuplicateName, err
 	}
 	return false, nil
 }
 
-func (s *RepositoryStore) updateRepository(existingRecord, repository *model.Repository) (bool, error) {
+func (s *RepositoryStore) updateRepository(db *gorm.DB, existingRecord, repository *model.Repository) (bool, error) {
 	updateSpec := repository.Spec != nil && !reflect.DeepEqual(existingRecord.Spec, repository.Spec)
 
 	// Update the generation if the spec was updated
@@ -212,7 +238,7 @@ func (s *RepositoryStore) updateRepository(existingRecord, repository *model.Rep
 	}
 	repository.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
 	where := model.Repository{Resource: model.Resource{OrgID: repository.OrgID, Name: repository.Name}}
-	query := s.db.Model(where).Where("(resource_version is null or resource_version = ?)", lo.FromPtr(existingRecord.ResourceVersion))
+	query := db.Model(where).Where("(resource_version is null or resource_version = ?)", lo.FromPtr(existingRecord.ResourceVersion))
 
 	result := query.Updates(&repository)
 	if result.Error != nil {
@@ -224,7 +250,7 @@ func (s *RepositoryStore) updateRepository(existingRecord, repository *model.Rep
 	return false, nil
 }
 
-func (s *RepositoryStore) createOrUpdate(orgId uuid.UUID, resource *api.Repository, mode CreateOrUpdateMode, callback RepositoryStoreCallback) (*api.Repository, bool, bool, error) {
+func (s *RepositoryStore) createOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.Repository, mode CreateOrUpdateMode, callback RepositoryStoreCallback) (*api.Repository, bool, bool, error) {
 	if resource == nil {
 		return nil, false, false, flterrors.ErrResourceIsNil
 	}
@@ -240,37 +266,61 @@ func (s *RepositoryStore) createOrUpdate(orgId uuid.UUID, resource *api.Reposito
 	repository.Status = nil
 	repository.Annotations = nil
 
-	existingRecord, err := getExistingRecord[model.Repository](s.db, repository.Name, orgId)
-	if err != nil {
-		return nil, false, false, err
-	}
-	exists := existingRecord != nil
+	var (
+		existingRecord *model.Repository
+		exists, retry  bool
+	)
+	err = OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		existingRecord, err = getExistingRecord[model.Repository](innerTx, repository.Name, orgId)
+		if err != nil {
+			return err
+		}
+		exists = existingRecord != nil
 
-	if exists && mode == ModeCreateOnly {
-		return nil, false, false, flterrors.ErrDuplicateName
-	}
-	if !exists && mode == ModeUpdateOnly {
-		return nil, false, false, flterrors.ErrResourceNotFound
-	}
+		if exists && mode == ModeCreateOnly {
+			return flterrors.ErrDuplicateName
+		}
+		if !exists && mode == ModeUpdateOnly {
+			return flterrors.ErrResourceNotFound
+		}
 
-	if !exists {
-		if retry, err := s.createRepository(repository); err != nil {
-			return nil, false, retry, err
+		if !exists {
+			retry, err = s.createRepository(innerTx, repository)
+		} else {
+			retry, err = s.updateRepository(innerTx, existingRecord, repository)
+		}
+		if err != nil {
+			return err
 		}
-	} else {
-		if retry, err := s.updateRepository(existingRecord, repository); err != nil {
-			return nil, false, retry, err
+		if err := s.captureCreatedOrUpdated(ctx, innerTx, !exists || existingRecord.Spec == nil, repository); err != nil {
+			return err
 		}
+		return callback(withTransaction(ctx, innerTx), repository)
+	})
+	if err != nil {
+		return nil, false, retry, err
 	}
-	callback(repository)
 
 	updatedResource, err := repository.ToApiResource()
 	return &updatedResource, !exists || existingRecord.Spec == nil, false, err
 }
 
+// captureCreatedOrUpdated captures the change of a repository. A repository without a spec is
+// only a placeholder for the references to it, so writing its spec creates it.
+func (s *RepositoryStore) captureCreatedOrUpdated(ctx context.Context, tx *gorm.DB, created bool, repository *model.Repository) error {
+	if s.changes == nil {
+		return nil
+	}
+	apiRepository, err := repository.ToApiResource()
+	if err != nil {
+		return err
+	}
+	return s.changes.capture(ctx, tx, createdOrUpdated(!created), api.RepositoryKind, repository.OrgID, repository.Name, apiRepository)
+}
+
 func (s *RepositoryStore) CreateOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.Repository, callback RepositoryStoreCallback) (*api.Repository, bool, error) {
 	return retryCreateOrUpdate(func() (*api.Repository, bool, bool, error) {
-		return s.createOrUpdate(orgId, resource, ModeCreateOrUpdate, callback)
+		return s.createOrUpdate(ctx, orgId, resource, ModeCreateOrUpdate, callback)
 	})
 }
 
@@ -278,27 +328,39 @@ func (s *RepositoryStore) UpdateStatusIgnoreOrg(resource *model.Repository) erro
 	repository := model.Repository{
 		Resource: model.Resource{OrgID: resource.OrgID, Name: resource.Name},
 	}
-	result := s.db.Model(&repository).Updates(map[string]interface{}{
-		"status": model.MakeJSONField(resource.Status),
+	err := OrgTransaction(s.db, resource.OrgID, func(tx *gorm.DB) error {
+		return tx.Model(&repository).Updates(map[string]interface{}{
+			"status": model.MakeJSONField(resource.Status),
+		}).Error
 	})
-	return ErrorFromGormError(result.Error)
+	return ErrorFromGormError(err)
 }
 
 func (s *RepositoryStore) Delete(ctx context.Context, orgId uuid.UUID, name string, callback RepositoryStoreCallback) error {
-	var existingRecords []*model.Repository
-	if err := s.db.Raw(`delete from repositories where org_id = ? and name = ? and spec is not null returning *`, orgId, name).Scan(&existingRecords).Error; err != nil {
-		return ErrorFromGormError(err)
-	}
-	for i := range existingRecords {
-		callback(existingRecords[i])
-	}
-	return nil
+	return OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		var existingRecords []*model.Repository
+		if err := innerTx.Raw(`delete from repositories where org_id = ? and name = ? and spec is not null returning *`, orgId, name).Scan(&existingRecords).Error; err != nil {
+			return ErrorFromGormError(err)
+		}
+		txCtx := withTransaction(ctx, innerTx)
+		for i := range existingRecords {
+			if err := s.changes.captureDeleted(ctx, innerTx, api.RepositoryKind, orgId, existingRecords[i].Name); err != nil {
+				return err
+			}
+			if err := callback(txCtx, existingRecords[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (s *RepositoryStore) GetFleetRefs(ctx context.Context, orgId uuid.UUID, name string) (*api.FleetList, error) {
 	repository := model.Repository{Resource: model.Resource{OrgID: orgId, Name: name}}
 	var fleets model.FleetList
-	err := s.db.Model(&repository).Association("Fleets").Find(&fleets)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.Model(&repository).Association("Fleets").Find(&fleets)
+	})
 	if err != nil {
 		return nil, ErrorFromGormError(err)
 	}
@@ -309,7 +371,9 @@ func (s *RepositoryStore) GetFleetRefs(ctx context.Context, orgId uuid.UUID, nam
 func (s *RepositoryStore) GetDeviceRefs(ctx context.Context, orgId uuid.UUID, name string) (*api.DeviceList, error) {
 	repository := model.Repository{Resource: model.Resource{OrgID: orgId, Name: name}}
 	var devices model.DeviceList
-	err := s.db.Model(&repository).Association("Devices").Find(&devices)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.Model(&repository).Association("Devices").Find(&devices)
+	})
 	if err != nil {
 		return nil, ErrorFromGormError(err)
 	}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/auth/common"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ResourceRevision interface {
+	InitialMigration() error
+	GetHistory(ctx context.Context, orgId uuid.UUID, kind string, name string, limit int) (*api.ResourceRevisionList, error)
+	Prune(ctx context.Context, orgId uuid.UUID, keep int) (int64, error)
+}
+
+type ResourceRevisionStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to ResourceRevision interface
+var _ ResourceRevision = (*ResourceRevisionStore)(nil)
+
+// revisionedResources maps the kinds of the resources whose spec history is recorded to their tables.
+var revisionedResources = map[string]string{
+	api.DeviceKind: "devices",
+	api.FleetKind:  "fleets",
+}
+
+func NewResourceRevision(db *gorm.DB, log logrus.FieldLogger) ResourceRevision {
+	return &ResourceRevisionStore{db: db, log: log}
+}
+
+func (s *ResourceRevisionStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.ResourceRevision{})
+}
+
+// GetHistory returns the recorded revisions of a resource, newest first. A limit of 0 returns all of them.
+func (s *ResourceRevisionStore) GetHistory(ctx context.Context, orgId uuid.UUID, kind string, name string, limit int) (*api.ResourceRevisionList, error) {
+	var revisions model.ResourceRevisionList
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		query := tx.Where("org_id = ? AND kind = ? AND name = ?", orgId, kind, name).Order("revision DESC")
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+		return query.Find(&revisions).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
+	}
+	apiRevisions := revisions.ToApiResource()
+	return &apiRevisions, nil
+}
+
+// Prune deletes the revisions of resources that no longer exist, and all but the newest keep
+// revisions of the others. It returns the number of deleted revisions.
+func (s *ResourceRevisionStore) Prune(ctx context.Context, orgId uuid.UUID, keep int) (int64, error) {
+	// each statement runs in a transaction of its own, so that the revisions deleted before a failure
+	// stay deleted and counted
+	exec := func(sql string, values ...interface{}) (int64, error) {
+		var rowsAffected int64
+		err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+			result := tx.Exec(sql, values...)
+			rowsAffected = result.RowsAffected
+			return result.Error
+		})
+		return rowsAffected, ErrorFromGormError(err)
+	}
+
+	var deleted int64
+	for kind, table := range revisionedResources {
+		rowsAffected, err := exec(`DELETE FROM resource_revisions WHERE org_id = ? AND kind = ? AND NOT EXISTS
+			(SELECT 1 FROM `+table+` r WHERE r.org_id = resource_revisions.org_id AND r.name = resource_revisions.name)`, orgId, kind)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += rowsAffected
+	}
+
+	rowsAffected, err := exec(`DELETE FROM resource_revisions WHERE org_id = ? AND (kind, name, revision) IN
+		(SELECT kind, name, revision FROM
+			(SELECT kind, name, revision, ROW_NUMBER() OVER (PARTITION BY kind, name ORDER BY revision DESC) AS row_num
+			FROM resource_revisions WHERE org_id = ?) AS ranked
+		WHERE row_num > ?)`, orgId, orgId, keep)
+	if err != nil {
+		return deleted, err
+	}
+	return deleted + rowsAffected, nil
+}
+
+// recordRevision adds the spec of a created or updated resource to its revision history, unless
+// its generation is already recorded. It runs in the transaction persisting the resource, so that
+// the resource does not change without its revision being recorded.
+func recordRevision(ctx context.Context, tx *gorm.DB, orgId uuid.UUID, kind string, name string, generation *int64, spec interface{}) error {
+	if generation == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("recording revision %d of %s/%s: %w", *generation, kind, name, err)
+	}
+	var specMap map[string]interface{}
+	if err := json.Unmarshal(data, &specMap); err != nil {
+		return fmt.Errorf("recording revision %d of %s/%s: %w", *generation, kind, name, err)
+	}
+
+	revision := model.ResourceRevision{
+		OrgID:    orgId,
+		Kind:     kind,
+		Name:     name,
+		Revision: *generation,
+		Spec:     model.MakeJSONField(specMap),
+	}
+	if identity, ok := ctx.Value(common.IdentityCtxKey).(*common.Identity); ok && identity != nil {
+		revision.Actor = identity.Username
+	}
+
+	// the first generation belongs to a newly created resource, so whatever is recorded under its
+	// name is the history of a deleted one
+	if *generation == 1 {
+		if err := tx.Where("org_id = ? AND kind = ? AND name = ?", orgId, kind, name).Delete(&model.ResourceRevision{}).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&revision).Error
+}
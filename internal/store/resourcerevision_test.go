@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRevisionFailureRollsBackWrite(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	orgId := uuid.New()
+	noop := func(context.Context, *model.Device, *model.Device) error { return nil }
+
+	// the revisions table is missing, so that the revision of the write cannot be recorded
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "store.db")), &gorm.Config{IgnoreRelationshipsWhenMigrating: true})
+	require.NoError(err)
+	require.NoError(db.AutoMigrate(&model.Device{}, &model.DeviceLogs{}, &model.OutboxEntry{}))
+	s := NewStore(db, log.InitLogs())
+
+	_, err = s.Device().Create(ctx, orgId, changesTestDevice("dev-1", nil), noop)
+	require.ErrorContains(err, "resource_revisions")
+
+	// the device is not committed without its revision
+	_, err = s.Device().Get(ctx, orgId, "dev-1")
+	require.ErrorIs(err, flterrors.ErrResourceNotFound)
+}
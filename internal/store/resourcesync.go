@@ -92,6 +92,15 @@ func (s *ResourceSyncStore) Update(ctx context.Context, orgId uuid.UUID, resourc
 }
 
 func (s *ResourceSyncStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.ResourceSyncList, error) {
+	var list *api.ResourceSyncList
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) (err error) {
+		list, err = s.list(ctx, tx, orgId, listParams)
+		return err
+	})
+	return list, err
+}
+
+func (s *ResourceSyncStore) list(ctx context.Context, db *gorm.DB, orgId uuid.UUID, listParams ListParams) (*api.ResourceSyncList, error) {
 	var resourceSyncs model.ResourceSyncList
 	var nextContinue *string
 	var numRemaining *int64
@@ -100,7 +109,7 @@ func (s *ResourceSyncStore) List(ctx context.Context, orgId uuid.UUID, listParam
 		return nil, flterrors.ErrLimitParamOutOfBounds
 	}
 
-	query, err := ListQuery(&model.ResourceSync{}).Build(ctx, s.db, orgId, listParams)
+	query, err := ListQuery(&model.ResourceSync{}).Build(ctx, db, orgId, listParams)
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +135,7 @@ func (s *ResourceSyncStore) List(ctx context.Context, orgId uuid.UUID, listParam
 				numRemainingVal = 1
 			}
 		} else {
-			countQuery, err := ListQuery(&model.ResourceSync{}).Build(ctx, s.db, orgId, listParams)
+			countQuery, err := ListQuery(&model.ResourceSync{}).Build(ctx, db, orgId, listParams)
 			if err != nil {
 				return nil, err
 			}
@@ -144,7 +153,7 @@ func (s *ResourceSyncStore) List(ctx context.Context, orgId uuid.UUID, listParam
 }
 
 func (s *ResourceSyncStore) DeleteAll(ctx context.Context, orgId uuid.UUID, callback removeAllResourceSyncOwnerCallback) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	return OrgTransaction(s.db, orgId, func(tx *gorm.DB) error {
 		if err := tx.Unscoped().Where("org_id = ?", orgId).Delete(&model.ResourceSync{}).Error; err != nil {
 			return ErrorFromGormError(err)
 		}
@@ -156,25 +165,27 @@ func (s *ResourceSyncStore) Get(ctx context.Context, orgId uuid.UUID, name strin
 	resourcesync := model.ResourceSync{
 		Resource: model.Resource{OrgID: orgId, Name: name},
 	}
-	result := s.db.First(&resourcesync)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.First(&resourcesync).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
 	}
 	apiResourceSync := resourcesync.ToApiResource()
 	return &apiResourceSync, nil
 }
 
-func (s *ResourceSyncStore) createResourceSync(resourceSync *model.ResourceSync) (bool, error) {
+func (s *ResourceSyncStore) createResourceSync(db *gorm.DB, resourceSync *model.ResourceSync) (bool, error) {
 	resourceSync.Generation = lo.ToPtr[int64](1)
 	resourceSync.ResourceVersion = lo.ToPtr[int64](1)
-	if result := s.db.Create(resourceSync); result.Error != nil {
+	if result := db.Create(resourceSync); result.Error != nil {
 		err := ErrorFromGormError(result.Error)
 		return err == flterrors.ErrDuplicateName, err
 	}
 	return false, nil
 }
 
-func (s *ResourceSyncStore) updateResourceSync(existingRecord, resourceSync *model.ResourceSync) (bool, error) {
+func (s *ResourceSyncStore) updateResourceSync(db *gorm.DB, existingRecord, resourceSync *model.ResourceSync) (bool, error) {
 	updateSpec := resourceSync.Spec != nil && !reflect.DeepEqual(existingRecord.Spec, resourceSync.Spec)
 
 	// Update the generation if the spec was updated
@@ -186,7 +197,7 @@ func (s *ResourceSyncStore) updateResourceSync(existingRecord, resourceSync *mod
 	}
 	resourceSync.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
 	where := model.ResourceSync{Resource: model.Resource{OrgID: resourceSync.OrgID, Name: resourceSync.Name}}
-	query := s.db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
+	query := db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
 
 	result := query.Updates(&resourceSync)
 	if result.Error != nil {
@@ -214,27 +225,30 @@ func (s *ResourceSyncStore) createOrUpdate(orgId uuid.UUID, resource *api.Resour
 	resourceSync.Status = nil
 	resourceSync.Annotations = nil
 
-	existingRecord, err := getExistingRecord[model.ResourceSync](s.db, resourceSync.Name, orgId)
-	if err != nil {
-		return nil, false, false, err
-	}
-	exists := existingRecord != nil
-
-	if exists && mode == ModeCreateOnly {
-		return nil, false, false, flterrors.ErrDuplicateName
-	}
-	if !exists && mode == ModeUpdateOnly {
-		return nil, false, false, flterrors.ErrResourceNotFound
-	}
+	var exists, retry bool
+	err = OrgTransaction(s.db, orgId, func(tx *gorm.DB) error {
+		existingRecord, err := getExistingRecord[model.ResourceSync](tx, resourceSync.Name, orgId)
+		if err != nil {
+			return err
+		}
+		exists = existingRecord != nil
 
-	if !exists {
-		if retry, err := s.createResourceSync(resourceSync); err != nil {
-			return nil, false, retry, err
+		if exists && mode == ModeCreateOnly {
+			return flterrors.ErrDuplicateName
+		}
+		if !exists && mode == ModeUpdateOnly {
+			return flterrors.ErrResourceNotFound
 		}
-	} else {
-		if retry, err := s.updateResourceSync(existingRecord, resourceSync); err != nil {
-			return nil, false, retry, err
+
+		if !exists {
+			retry, err = s.createResourceSync(tx, resourceSync)
+		} else {
+			retry, err = s.updateResourceSync(tx, existingRecord, resourceSync)
 		}
+		return err
+	})
+	if err != nil {
+		return nil, false, retry, err
 	}
 
 	updatedResource := resourceSync.ToApiResource()
@@ -251,16 +265,18 @@ func (s *ResourceSyncStore) UpdateStatusIgnoreOrg(resource *model.ResourceSync)
 	resourcesync := model.ResourceSync{
 		Resource: model.Resource{OrgID: resource.OrgID, Name: resource.Name},
 	}
-	result := s.db.Model(&resourcesync).Updates(map[string]interface{}{
-		"status":           model.MakeJSONField(resource.Status),
-		"resource_version": gorm.Expr("resource_version + 1"),
+	err := OrgTransaction(s.db, resource.OrgID, func(tx *gorm.DB) error {
+		return tx.Model(&resourcesync).Updates(map[string]interface{}{
+			"status":           model.MakeJSONField(resource.Status),
+			"resource_version": gorm.Expr("resource_version + 1"),
+		}).Error
 	})
-	return ErrorFromGormError(result.Error)
+	return ErrorFromGormError(err)
 }
 
 func (s *ResourceSyncStore) Delete(ctx context.Context, orgId uuid.UUID, name string, callback removeOwnerCallback) error {
 	existingRecord := model.ResourceSync{Resource: model.Resource{OrgID: orgId, Name: name}}
-	err := s.db.Transaction(func(innerTx *gorm.DB) (err error) {
+	err := OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) (err error) {
 		result := innerTx.First(&existingRecord)
 		if result.Error != nil {
 			return ErrorFromGormError(result.Error)
@@ -288,9 +304,11 @@ func (s *ResourceSyncStore) Delete(ctx context.Context, orgId uuid.UUID, name st
 // TODO: Add pagination, perhaps via gorm scopes.
 func (s *ResourceSyncStore) ListIgnoreOrg() ([]model.ResourceSync, error) {
 	var resourcesyncs model.ResourceSyncList
-	result := s.db.Model(&resourcesyncs).Find(&resourcesyncs)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
+	err := OrgBypassTransaction(s.db, func(tx *gorm.DB) error {
+		return tx.Model(&resourcesyncs).Find(&resourcesyncs).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
 	}
 	return resourcesyncs, nil
 }
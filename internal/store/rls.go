@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// orgIdSetting is the session variable the row-level security policies restrict the rows of the
+	// organization-scoped tables to.
+	orgIdSetting = "flightctl.org_id"
+	// orgIsolationPolicy is the name of the row-level security policy restricting each
+	// organization-scoped table to the organization of the transaction.
+	orgIsolationPolicy = "org_isolation"
+	// orgBypassPolicy is the name of the row-level security policy letting the rows of all
+	// organizations through to OrgBypassRole.
+	orgBypassPolicy = "org_bypass"
+	// OrgBypassRole is the role to which the policies let through the rows of all organizations. The
+	// service switches to it for the duration of a transaction, for the tasks spanning organizations.
+	// The role is created by the deployment, which grants it to the role the service connects as.
+	OrgBypassRole = "flightctl_org_bypass"
+	// orgIdFunction returns the organization set in orgIdSetting, and fails the statement when it is
+	// not set, so that a query run outside of an organization transaction fails rather than seeing no
+	// rows. A setting set for a transaction only is reset to an empty string rather than removed once
+	// it ends. It returns no organization to the bypass role, whose rows orgBypassPolicy lets through.
+	orgIdFunction = "flightctl_org_id"
+)
+
+// orgScopedTables are the tables whose rows belong to an organization, through their org_id column.
+var orgScopedTables = []string{
+	"devices",
+	"enrollment_requests",
+	"certificate_signing_requests",
+	"fleets",
+	"template_versions",
+	"repositories",
+	"resource_syncs",
+	"resource_revisions",
+	"device_logs",
+	"bootstrap_tokens",
+}
+
+// enableRowLevelSecurity adds policies to each organization-scoped table restricting its rows to the
+// organization of the transaction, as a defense in depth against a query missing its org_id filter.
+// The policies apply to the role the service connects as, which must neither own the tables nor be
+// a superuser or have the BYPASSRLS attribute; the owner running the migrations is not subject to
+// them.
+func enableRowLevelSecurity(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		function := fmt.Sprintf(`CREATE OR REPLACE FUNCTION %s() RETURNS uuid LANGUAGE plpgsql STABLE AS $$
+			DECLARE
+				org_id text := current_setting('%s', true);
+			BEGIN
+				IF current_user = '%s' THEN
+					RETURN NULL;
+				END IF;
+				IF org_id IS NULL OR org_id = '' THEN
+					RAISE EXCEPTION 'organization-scoped rows accessed outside of an organization transaction'
+						USING ERRCODE = 'insufficient_privilege';
+				END IF;
+				RETURN org_id::uuid;
+			END
+		$$`, orgIdFunction, orgIdSetting, OrgBypassRole)
+		if err := tx.Exec(function).Error; err != nil {
+			return fmt.Errorf("creating function %s: %w", orgIdFunction, err)
+		}
+		for _, table := range orgScopedTables {
+			statements := []string{
+				fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table),
+				fmt.Sprintf("ALTER TABLE %s NO FORCE ROW LEVEL SECURITY", table),
+				fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", orgIsolationPolicy, table),
+				fmt.Sprintf("CREATE POLICY %s ON %s USING (org_id = %s()) WITH CHECK (org_id = %s())", orgIsolationPolicy, table, orgIdFunction, orgIdFunction),
+				fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", orgBypassPolicy, table),
+				fmt.Sprintf("CREATE POLICY %s ON %s USING (current_user = '%s') WITH CHECK (current_user = '%s')", orgBypassPolicy, table, OrgBypassRole, OrgBypassRole),
+			}
+			for _, statement := range statements {
+				if err := tx.Exec(statement).Error; err != nil {
+					return fmt.Errorf("enabling row-level security on %s: %w", table, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// OrgTransaction runs fn in a transaction in which the row-level security policies restrict the
+// organization-scoped tables to the rows of the organization, whatever the queries filter on.
+func OrgTransaction(db *gorm.DB, orgId uuid.UUID, fn func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT set_config(?, ?, true)", orgIdSetting, orgId.String()).Error; err != nil {
+				return err
+			}
+		}
+		return fn(tx)
+	})
+}
+
+// OrgBypassTransaction runs fn in a transaction switched to the bypass role, in which the
+// organization-scoped tables show the rows of all organizations. It is reserved to the tasks
+// spanning organizations.
+func OrgBypassTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SET LOCAL ROLE " + OrgBypassRole).Error; err != nil {
+				return err
+			}
+		}
+		return fn(tx)
+	})
+}
+
+// orgReadTransaction runs fn in an organization transaction on the read replica when the context
+// tolerates stale reads and a replica is configured, see OrgTransaction. fn must not write.
+func orgReadTransaction(ctx context.Context, db *gorm.DB, orgId uuid.UUID, fn func(tx *gorm.DB) error) error {
+	return OrgTransaction(replicaSession(ctx, db), orgId, fn)
+}
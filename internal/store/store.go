@@ -1,10 +1,12 @@
 package store
 
 import (
+	"context"
 	b64 "encoding/base64"
 	"encoding/json"
 	"fmt"
 
+	"github.com/flightctl/flightctl/internal/config"
 	"github.com/flightctl/flightctl/internal/store/selector"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -25,7 +27,15 @@ type Store interface {
 	TemplateVersion() TemplateVersion
 	Repository() Repository
 	ResourceSync() ResourceSync
+	ResourceRevision() ResourceRevision
+	DeviceLogs() DeviceLogs
+	BootstrapToken() BootstrapToken
+	Orphans() Orphans
+	Usage() Usage
+	Outbox() Outbox
 	InitialMigration() error
+	// Ping checks that the database is reachable.
+	Ping(ctx context.Context) error
 	Close() error
 }
 
@@ -37,23 +47,53 @@ type DataStore struct {
 	templateVersion           TemplateVersion
 	repository                Repository
 	resourceSync              ResourceSync
+	resourceRevision          ResourceRevision
+	deviceLogs                DeviceLogs
+	bootstrapToken            BootstrapToken
+	orphans                   Orphans
+	usage                     Usage
+	outbox                    Outbox
 
 	db *gorm.DB
 }
 
-func NewStore(db *gorm.DB, log logrus.FieldLogger) Store {
+func NewStore(db *gorm.DB, log logrus.FieldLogger, opts ...Option) Store {
+	options := storeOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	outbox := NewOutbox(db, log)
+	changes := newChangeCapture(outbox, options.changeCapture)
 	return &DataStore{
-		device:                    NewDevice(db, log),
+		device:                    newDevice(db, log, changes, options.quotas),
 		enrollmentRequest:         NewEnrollmentRequest(db, log),
 		certificateSigningRequest: NewCertificateSigningRequest(db, log),
-		fleet:                     NewFleet(db, log),
+		fleet:                     newFleet(db, log, changes, options.quotas),
 		templateVersion:           NewTemplateVersion(db, log),
-		repository:                NewRepository(db, log),
+		repository:                newRepository(db, log, changes),
 		resourceSync:              NewResourceSync(db, log),
+		resourceRevision:          NewResourceRevision(db, log),
+		deviceLogs:                NewDeviceLogs(db, log),
+		bootstrapToken:            NewBootstrapToken(db, log),
+		orphans:                   NewOrphans(db, log),
+		usage:                     newUsage(db, log, options.quotas),
+		outbox:                    outbox,
 		db:                        db,
 	}
 }
 
+// OptionsFromConfig returns the options of the store of the services with the given configuration.
+func OptionsFromConfig(cfg *config.Config) []Option {
+	opts := []Option{}
+	if cfg.ChangeCaptureEnabled() {
+		opts = append(opts, WithChangeCapture())
+	}
+	if cfg.Quotas != nil {
+		opts = append(opts, WithQuotas(cfg.Quotas.Default, cfg.Quotas.Organizations))
+	}
+	return opts
+}
+
 func (s *DataStore) Repository() Repository {
 	return s.repository
 }
@@ -82,6 +122,30 @@ func (s *DataStore) ResourceSync() ResourceSync {
 	return s.resourceSync
 }
 
+func (s *DataStore) ResourceRevision() ResourceRevision {
+	return s.resourceRevision
+}
+
+func (s *DataStore) DeviceLogs() DeviceLogs {
+	return s.deviceLogs
+}
+
+func (s *DataStore) BootstrapToken() BootstrapToken {
+	return s.bootstrapToken
+}
+
+func (s *DataStore) Orphans() Orphans {
+	return s.orphans
+}
+
+func (s *DataStore) Usage() Usage {
+	return s.usage
+}
+
+func (s *DataStore) Outbox() Outbox {
+	return s.outbox
+}
+
 func (s *DataStore) InitialMigration() error {
 	if err := s.Device().InitialMigration(); err != nil {
 		return err
@@ -104,6 +168,18 @@ func (s *DataStore) InitialMigration() error {
 	if err := s.ResourceSync().InitialMigration(); err != nil {
 		return err
 	}
+	if err := s.ResourceRevision().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.DeviceLogs().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.BootstrapToken().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.Outbox().InitialMigration(); err != nil {
+		return err
+	}
 	return s.customizeMigration()
 }
 
@@ -118,7 +194,15 @@ func (s *DataStore) customizeMigration() error {
 			return err
 		}
 	}
-	return nil
+	return enableRowLevelSecurity(s.db)
+}
+
+func (s *DataStore) Ping(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
 }
 
 func (s *DataStore) Close() error {
@@ -31,7 +31,7 @@ type TemplateVersionStore struct {
 	log logrus.FieldLogger
 }
 
-type TemplateVersionStoreCallback func(tv *model.TemplateVersion)
+type TemplateVersionStoreCallback func(ctx context.Context, tv *model.TemplateVersion) error
 
 // Make sure we conform to TemplateVersion interface
 var _ TemplateVersion = (*TemplateVersionStore)(nil)
@@ -87,14 +87,28 @@ func (s *TemplateVersionStore) Create(ctx context.Context, orgId uuid.UUID, reso
 	templateVersion.Generation = lo.ToPtr[int64](1)
 	templateVersion.ResourceVersion = lo.ToPtr[int64](1)
 
-	if err = s.db.Create(templateVersion).Error; err != nil {
-		return nil, ErrorFromGormError(err)
+	err = OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		if err := innerTx.Create(templateVersion).Error; err != nil {
+			return ErrorFromGormError(err)
+		}
+		return callback(withTransaction(ctx, innerTx), templateVersion)
+	})
+	if err != nil {
+		return nil, err
 	}
-	callback(templateVersion)
-	return lo.ToPtr(templateVersion.ToApiResource()), err
+	return lo.ToPtr(templateVersion.ToApiResource()), nil
 }
 
 func (s *TemplateVersionStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.TemplateVersionList, error) {
+	var list *api.TemplateVersionList
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) (err error) {
+		list, err = s.list(ctx, tx, orgId, listParams)
+		return err
+	})
+	return list, err
+}
+
+func (s *TemplateVersionStore) list(ctx context.Context, db *gorm.DB, orgId uuid.UUID, listParams ListParams) (*api.TemplateVersionList, error) {
 	var templateVersions model.TemplateVersionList
 	var nextContinue *string
 	var numRemaining *int64
@@ -103,7 +117,7 @@ func (s *TemplateVersionStore) List(ctx context.Context, orgId uuid.UUID, listPa
 		return nil, flterrors.ErrLimitParamOutOfBounds
 	}
 
-	query, err := ListQuery(&templateVersions).Build(ctx, s.db, orgId, listParams)
+	query, err := ListQuery(&templateVersions).Build(ctx, db, orgId, listParams)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +143,7 @@ func (s *TemplateVersionStore) List(ctx context.Context, orgId uuid.UUID, listPa
 				numRemainingVal = 1
 			}
 		} else {
-			countQuery, err := ListQuery(&templateVersions).Build(ctx, s.db, orgId, listParams)
+			countQuery, err := ListQuery(&templateVersions).Build(ctx, db, orgId, listParams)
 			if err != nil {
 				return nil, err
 			}
@@ -148,9 +162,11 @@ func (s *TemplateVersionStore) List(ctx context.Context, orgId uuid.UUID, listPa
 
 func (s *TemplateVersionStore) GetLatest(ctx context.Context, orgId uuid.UUID, fleet string) (*api.TemplateVersion, error) {
 	var templateVersion model.TemplateVersion
-	result := s.db.Model(&templateVersion).Where("org_id = ? AND fleet_name = ?", orgId, fleet).Order("created_at DESC").First(&templateVersion)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.Model(&templateVersion).Where("org_id = ? AND fleet_name = ?", orgId, fleet).Order("created_at DESC").First(&templateVersion).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
 	}
 	apiResource := templateVersion.ToApiResource()
 	return &apiResource, nil
@@ -158,16 +174,17 @@ func (s *TemplateVersionStore) GetLatest(ctx context.Context, orgId uuid.UUID, f
 
 func (s *TemplateVersionStore) DeleteAll(ctx context.Context, orgId uuid.UUID, fleet *string) error {
 	condition := model.TemplateVersion{}
-	unscoped := s.db.Unscoped()
-	var whereQuery *gorm.DB
-	if fleet != nil {
-		whereQuery = unscoped.Where("org_id = ? AND fleet_name = ?", orgId, *fleet)
-	} else {
-		whereQuery = unscoped.Where("org_id = ?", orgId)
-	}
-
-	result := whereQuery.Delete(&condition)
-	return ErrorFromGormError(result.Error)
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		unscoped := tx.Unscoped()
+		var whereQuery *gorm.DB
+		if fleet != nil {
+			whereQuery = unscoped.Where("org_id = ? AND fleet_name = ?", orgId, *fleet)
+		} else {
+			whereQuery = unscoped.Where("org_id = ?", orgId)
+		}
+		return whereQuery.Delete(&condition).Error
+	})
+	return ErrorFromGormError(err)
 }
 
 func (s *TemplateVersionStore) Get(ctx context.Context, orgId uuid.UUID, fleet string, name string) (*api.TemplateVersion, error) {
@@ -176,9 +193,11 @@ func (s *TemplateVersionStore) Get(ctx context.Context, orgId uuid.UUID, fleet s
 		FleetName: fleet,
 		Name:      name,
 	}
-	result := s.db.First(&templateVersion)
-	if result.Error != nil {
-		return nil, ErrorFromGormError(result.Error)
+	err := orgReadTransaction(ctx, s.db, orgId, func(tx *gorm.DB) error {
+		return tx.First(&templateVersion).Error
+	})
+	if err != nil {
+		return nil, ErrorFromGormError(err)
 	}
 	apiTemplateVersion := templateVersion.ToApiResource()
 	return &apiTemplateVersion, nil
@@ -190,11 +209,13 @@ func (s *TemplateVersionStore) Delete(ctx context.Context, orgId uuid.UUID, flee
 		FleetName: fleet,
 		Name:      name,
 	}
-	result := s.db.Unscoped().Delete(&condition)
-	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) error {
+		return tx.Unscoped().Delete(&condition).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil
 	}
-	return ErrorFromGormError(result.Error)
+	return ErrorFromGormError(err)
 }
 
 func (s *TemplateVersionStore) UpdateStatus(ctx context.Context, orgId uuid.UUID, resource *api.TemplateVersion, valid *bool, callback TemplateVersionStoreCallback) error {
@@ -219,13 +240,15 @@ func (s *TemplateVersionStore) UpdateStatus(ctx context.Context, orgId uuid.UUID
 		updates["valid"] = valid
 	}
 
-	result := s.db.Model(&templateVersion).Updates(updates)
-	if result.Error != nil {
-		return ErrorFromGormError(result.Error)
-	}
-
-	if valid != nil && *valid {
-		callback(&templateVersion)
-	}
-	return nil
+	return OrgTransaction(s.db, orgId, func(innerTx *gorm.DB) error {
+		if err := innerTx.Model(&templateVersion).Updates(updates).Error; err != nil {
+			return ErrorFromGormError(err)
+		}
+		if valid != nil && *valid {
+			if err := callback(withTransaction(ctx, innerTx), &templateVersion); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
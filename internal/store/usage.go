@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Usage counts the resources of an organization, e.g. to enforce its quota.
+type Usage interface {
+	// Count returns the number of resources of the kind, e.g. "Device", the organization has.
+	Count(ctx context.Context, orgId uuid.UUID, kind string) (int64, error)
+	// Limit returns the number of resources of the kind the organization can have, 0 if unlimited.
+	Limit(orgId uuid.UUID, kind string) int64
+}
+
+type UsageStore struct {
+	db     *gorm.DB
+	log    logrus.FieldLogger
+	quotas *quotas
+}
+
+// Make sure we conform to Usage interface
+var _ Usage = (*UsageStore)(nil)
+
+// usageModels maps the kinds of resources that are counted to their model.
+var usageModels = map[string]any{
+	api.DeviceKind: &model.Device{},
+	api.FleetKind:  &model.Fleet{},
+}
+
+func NewUsage(db *gorm.DB, log logrus.FieldLogger) Usage {
+	return newUsage(db, log, nil)
+}
+
+func newUsage(db *gorm.DB, log logrus.FieldLogger, quotas *quotas) Usage {
+	return &UsageStore{db: db, log: log, quotas: quotas}
+}
+
+func (s *UsageStore) Count(ctx context.Context, orgId uuid.UUID, kind string) (int64, error) {
+	var n int64
+	err := OrgTransaction(s.db.WithContext(ctx), orgId, func(tx *gorm.DB) (err error) {
+		n, err = count(tx, orgId, kind)
+		return err
+	})
+	return n, err
+}
+
+func (s *UsageStore) Limit(orgId uuid.UUID, kind string) int64 {
+	return s.quotas.limit(orgId, kind)
+}
+
+// count returns the number of resources of the kind the organization has, as seen by db.
+func count(db *gorm.DB, orgId uuid.UUID, kind string) (int64, error) {
+	m, ok := usageModels[kind]
+	if !ok {
+		return 0, fmt.Errorf("counting %s: unsupported kind", kind)
+	}
+	var count int64
+	if err := db.Model(m).Where("org_id = ?", orgId).Count(&count).Error; err != nil {
+		return 0, ErrorFromGormError(err)
+	}
+	return count, nil
+}
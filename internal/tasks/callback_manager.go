@@ -1,14 +1,17 @@
 package tasks
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/flightctl/flightctl/pkg/queues"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
@@ -30,20 +33,23 @@ const (
 	RepositoryUpdatesTask = "repository-updates"
 )
 
+// CallbackManager submits the tasks following the writes of resources. The callbacks are invoked
+// in the transactions of the writes, which are rolled back if a task could not be submitted.
 type CallbackManager interface {
-	FleetUpdatedCallback(before *model.Fleet, after *model.Fleet)
-	RepositoryUpdatedCallback(repository *model.Repository)
-	AllRepositoriesDeletedCallback(orgId uuid.UUID)
-	AllFleetsDeletedCallback(orgId uuid.UUID)
-	AllDevicesDeletedCallback(orgId uuid.UUID)
-	DeviceUpdatedCallback(before *model.Device, after *model.Device)
-	TemplateVersionCreatedCallback(templateVersion *model.TemplateVersion)
-	FleetSourceUpdated(orgId uuid.UUID, name string)
-	DeviceSourceUpdated(orgId uuid.UUID, name string)
+	FleetUpdatedCallback(ctx context.Context, before *model.Fleet, after *model.Fleet) error
+	RepositoryUpdatedCallback(ctx context.Context, repository *model.Repository) error
+	AllRepositoriesDeletedCallback(ctx context.Context, orgId uuid.UUID) error
+	AllFleetsDeletedCallback(ctx context.Context, orgId uuid.UUID) error
+	AllDevicesDeletedCallback(ctx context.Context, orgId uuid.UUID) error
+	DeviceUpdatedCallback(ctx context.Context, before *model.Device, after *model.Device) error
+	TemplateVersionCreatedCallback(ctx context.Context, templateVersion *model.TemplateVersion) error
+	FleetSourceUpdated(ctx context.Context, orgId uuid.UUID, name string) error
+	DeviceSourceUpdated(ctx context.Context, orgId uuid.UUID, name string) error
 }
 
 type callbackManager struct {
 	publisher queues.Publisher
+	outbox    store.Outbox
 	log       logrus.FieldLogger
 }
 
@@ -62,27 +68,47 @@ func NewCallbackManager(publisher queues.Publisher, log logrus.FieldLogger) Call
 	}
 }
 
-func (t *callbackManager) submitTask(taskName string, resource ResourceReference, op string) {
+// NewOutboxCallbackManager returns a callback manager that enqueues the tasks in the outbox, in
+// the transaction of the write that submits them, rather than publishing them directly. The tasks
+// are published by the OutboxRelay once the write is committed.
+func NewOutboxCallbackManager(outbox store.Outbox, log logrus.FieldLogger) CallbackManager {
+	return &callbackManager{
+		outbox: outbox,
+		log:    log,
+	}
+}
+
+// submitTask enqueues the task in the outbox, or publishes it. The returned error fails the write
+// submitting the task, so that no committed write misses its tasks.
+func (t *callbackManager) submitTask(ctx context.Context, taskName string, resource ResourceReference, op string) error {
 	resource.TaskName = taskName
 	resource.Op = op
+	resource.IdempotencyKey = uuid.NewString()
+	resource.RequestID = middleware.GetReqID(ctx)
 	b, err := json.Marshal(&resource)
 	if err != nil {
-		t.log.WithError(err).Error("failed to marshal payload")
-		return
+		return fmt.Errorf("failed to marshal payload of task %s: %w", taskName, err)
+	}
+	if t.outbox != nil {
+		if err = t.outbox.Enqueue(ctx, TaskQueue, b); err != nil {
+			return fmt.Errorf("failed to enqueue task %s: %w", taskName, err)
+		}
+		return nil
 	}
 	if err = t.publisher.Publish(b); err != nil {
-		t.log.WithError(err).Error("failed to publish resource")
+		return fmt.Errorf("failed to publish task %s: %w", taskName, err)
 	}
+	return nil
 }
 
-func (t *callbackManager) FleetUpdatedCallback(before *model.Fleet, after *model.Fleet) {
+func (t *callbackManager) FleetUpdatedCallback(ctx context.Context, before *model.Fleet, after *model.Fleet) error {
 	var templateUpdated bool
 	var selectorUpdated bool
 	var fleet *model.Fleet
 
 	if before == nil && after == nil {
 		// Shouldn't be here, return
-		return
+		return nil
 	}
 	if before == nil {
 		// New fleet
@@ -103,44 +129,49 @@ func (t *callbackManager) FleetUpdatedCallback(before *model.Fleet, after *model
 	ref := ResourceReference{OrgID: fleet.OrgID, Kind: api.FleetKind, Name: fleet.Name}
 	if templateUpdated {
 		// If the template was updated, start rolling out the new spec
-		t.submitTask(FleetValidateTask, ref, FleetValidateOpUpdate)
+		if err := t.submitTask(ctx, FleetValidateTask, ref, FleetValidateOpUpdate); err != nil {
+			return err
+		}
 	}
 	if selectorUpdated {
 		op := FleetSelectorMatchOpUpdate
 		if fleet.Status != nil && fleet.Status.Data.Conditions != nil && api.IsStatusConditionTrue(fleet.Status.Data.Conditions, api.FleetOverlappingSelectors) {
 			op = FleetSelectorMatchOpUpdateOverlap
 		}
-		t.submitTask(FleetSelectorMatchTask, ref, op)
+		if err := t.submitTask(ctx, FleetSelectorMatchTask, ref, op); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func (t *callbackManager) FleetSourceUpdated(orgId uuid.UUID, name string) {
+func (t *callbackManager) FleetSourceUpdated(ctx context.Context, orgId uuid.UUID, name string) error {
 	ref := ResourceReference{OrgID: orgId, Kind: api.FleetKind, Name: name}
-	t.submitTask(FleetValidateTask, ref, FleetValidateOpUpdate)
+	return t.submitTask(ctx, FleetValidateTask, ref, FleetValidateOpUpdate)
 }
 
-func (t *callbackManager) RepositoryUpdatedCallback(repository *model.Repository) {
+func (t *callbackManager) RepositoryUpdatedCallback(ctx context.Context, repository *model.Repository) error {
 	resourceRef := ResourceReference{
 		OrgID: repository.OrgID,
 		Kind:  api.RepositoryKind,
 		Name:  repository.Name,
 	}
-	t.submitTask(RepositoryUpdatesTask, resourceRef, RepositoryUpdateOpUpdate)
+	return t.submitTask(ctx, RepositoryUpdatesTask, resourceRef, RepositoryUpdateOpUpdate)
 }
 
-func (t *callbackManager) AllRepositoriesDeletedCallback(orgId uuid.UUID) {
-	t.submitTask(RepositoryUpdatesTask, ResourceReference{OrgID: orgId, Kind: api.RepositoryKind}, RepositoryUpdateOpDeleteAll)
+func (t *callbackManager) AllRepositoriesDeletedCallback(ctx context.Context, orgId uuid.UUID) error {
+	return t.submitTask(ctx, RepositoryUpdatesTask, ResourceReference{OrgID: orgId, Kind: api.RepositoryKind}, RepositoryUpdateOpDeleteAll)
 }
 
-func (t *callbackManager) AllFleetsDeletedCallback(orgId uuid.UUID) {
-	t.submitTask(FleetSelectorMatchTask, ResourceReference{OrgID: orgId, Kind: api.FleetKind}, FleetSelectorMatchOpDeleteAll)
+func (t *callbackManager) AllFleetsDeletedCallback(ctx context.Context, orgId uuid.UUID) error {
+	return t.submitTask(ctx, FleetSelectorMatchTask, ResourceReference{OrgID: orgId, Kind: api.FleetKind}, FleetSelectorMatchOpDeleteAll)
 }
 
-func (t *callbackManager) AllDevicesDeletedCallback(orgId uuid.UUID) {
-	t.submitTask(FleetSelectorMatchTask, ResourceReference{OrgID: orgId, Kind: api.DeviceKind}, FleetSelectorMatchOpDeleteAll)
+func (t *callbackManager) AllDevicesDeletedCallback(ctx context.Context, orgId uuid.UUID) error {
+	return t.submitTask(ctx, FleetSelectorMatchTask, ResourceReference{OrgID: orgId, Kind: api.DeviceKind}, FleetSelectorMatchOpDeleteAll)
 }
 
-func (t *callbackManager) DeviceUpdatedCallback(before *model.Device, after *model.Device) {
+func (t *callbackManager) DeviceUpdatedCallback(ctx context.Context, before *model.Device, after *model.Device) error {
 	var labelsUpdated bool
 	var ownerUpdated bool
 	var specUpdated bool
@@ -148,7 +179,7 @@ func (t *callbackManager) DeviceUpdatedCallback(before *model.Device, after *mod
 
 	if before == nil && after == nil {
 		// Shouldn't be here, return
-		return
+		return nil
 	}
 	if before == nil {
 		// New device
@@ -175,7 +206,9 @@ func (t *callbackManager) DeviceUpdatedCallback(before *model.Device, after *mod
 	if ownerUpdated || labelsUpdated {
 		// If the device's owner was updated, or if labels were updating that might affect parametrers,
 		// check if we need to update its spec according to its new fleet
-		t.submitTask(FleetRolloutTask, ref, FleetRolloutOpUpdate)
+		if err := t.submitTask(ctx, FleetRolloutTask, ref, FleetRolloutOpUpdate); err != nil {
+			return err
+		}
 	}
 	if labelsUpdated {
 		// Check if the new labels cause the device to move to a different fleet
@@ -184,23 +217,26 @@ func (t *callbackManager) DeviceUpdatedCallback(before *model.Device, after *mod
 		if api.IsStatusConditionTrue(device.Status.Data.Conditions, api.DeviceMultipleOwners) {
 			op = FleetSelectorMatchOpUpdateOverlap
 		}
-		t.submitTask(FleetSelectorMatchTask, ref, op)
+		if err := t.submitTask(ctx, FleetSelectorMatchTask, ref, op); err != nil {
+			return err
+		}
 	}
 	if specUpdated {
-		t.submitTask(DeviceRenderTask, ref, DeviceRenderOpUpdate)
+		return t.submitTask(ctx, DeviceRenderTask, ref, DeviceRenderOpUpdate)
 	}
+	return nil
 }
 
-func (t *callbackManager) DeviceSourceUpdated(orgId uuid.UUID, name string) {
+func (t *callbackManager) DeviceSourceUpdated(ctx context.Context, orgId uuid.UUID, name string) error {
 	ref := ResourceReference{OrgID: orgId, Kind: api.DeviceKind, Name: name}
-	t.submitTask(DeviceRenderTask, ref, DeviceRenderOpUpdate)
+	return t.submitTask(ctx, DeviceRenderTask, ref, DeviceRenderOpUpdate)
 }
 
-func (t *callbackManager) TemplateVersionCreatedCallback(templateVersion *model.TemplateVersion) {
+func (t *callbackManager) TemplateVersionCreatedCallback(ctx context.Context, templateVersion *model.TemplateVersion) error {
 	resourceRef := ResourceReference{
 		OrgID: templateVersion.OrgID,
 		Kind:  api.FleetKind,
 		Name:  templateVersion.FleetName,
 	}
-	t.submitTask(FleetRolloutTask, resourceRef, FleetRolloutOpUpdate)
+	return t.submitTask(ctx, FleetRolloutTask, resourceRef, FleetRolloutOpUpdate)
 }
@@ -1,11 +1,13 @@
 package tasks
 
 import (
+	"context"
 	"encoding/json"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/store/model"
 	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -44,7 +46,7 @@ var _ = Describe("FleetUpdatedCallback", func() {
 
 	When("both before and after are nil", func() {
 		It("does nothing", func() {
-			callbacksManager.FleetUpdatedCallback(nil, nil)
+			Expect(callbacksManager.FleetUpdatedCallback(context.Background(), nil, nil)).To(Succeed())
 			Expect(mockPublisher.publishedResources).To(BeEmpty())
 		})
 	})
@@ -52,7 +54,7 @@ var _ = Describe("FleetUpdatedCallback", func() {
 	When("before is nil and after is not nil", func() {
 		It("submits FleetValidateTask and FleetSelectorMatchTask", func() {
 			after := CreateTestingFleet(orgId, "after", "image1", &map[string]string{"labelKey": "selector"})
-			callbacksManager.FleetUpdatedCallback(nil, after)
+			Expect(callbacksManager.FleetUpdatedCallback(context.Background(), nil, after)).To(Succeed())
 
 			Expect(mockPublisher.publishedResources).To(HaveLen(2))
 
@@ -73,7 +75,7 @@ var _ = Describe("FleetUpdatedCallback", func() {
 	When("before is not nil and after is nil", func() {
 		It("submits FleetSelectorMatchTask", func() {
 			before := CreateTestingFleet(orgId, "before", "image1", &map[string]string{"labelKey": "selector"})
-			callbacksManager.FleetUpdatedCallback(before, nil)
+			Expect(callbacksManager.FleetUpdatedCallback(context.Background(), before, nil)).To(Succeed())
 
 			Expect(mockPublisher.publishedResources).To(HaveLen(1))
 
@@ -89,7 +91,7 @@ var _ = Describe("FleetUpdatedCallback", func() {
 		It("submits FleetValidateTask and FleetSelectorMatchTask", func() {
 			before := CreateTestingFleet(orgId, "before", "image1", &map[string]string{"labelKey": "selector1"})
 			after := CreateTestingFleet(orgId, "after", "image2", &map[string]string{"labelKey": "selector2"})
-			callbacksManager.FleetUpdatedCallback(before, after)
+			Expect(callbacksManager.FleetUpdatedCallback(context.Background(), before, after)).To(Succeed())
 
 			Expect(mockPublisher.publishedResources).To(HaveLen(2))
 
@@ -111,7 +113,7 @@ var _ = Describe("FleetUpdatedCallback", func() {
 		It("submits FleetSelectorMatchTask", func() {
 			before := CreateTestingFleet(orgId, "before", "image1", &map[string]string{"labelKey": "selector1"})
 			after := CreateTestingFleet(orgId, "after", "image1", &map[string]string{"labelKey": "selector2"})
-			callbacksManager.FleetUpdatedCallback(before, after)
+			Expect(callbacksManager.FleetUpdatedCallback(context.Background(), before, after)).To(Succeed())
 
 			Expect(mockPublisher.publishedResources).To(HaveLen(1))
 
@@ -133,7 +135,7 @@ var _ = Describe("DeviceUpdatedCallback", func() {
 
 	When("both before and after are nil", func() {
 		It("does nothing", func() {
-			callbacksManager.DeviceUpdatedCallback(nil, nil)
+			Expect(callbacksManager.DeviceUpdatedCallback(context.Background(), nil, nil)).To(Succeed())
 			Expect(mockPublisher.publishedResources).To(BeEmpty())
 		})
 	})
@@ -141,7 +143,7 @@ var _ = Describe("DeviceUpdatedCallback", func() {
 	When("before is nil and after is not nil", func() {
 		It("submits FleetRolloutTask, FleetSelectorMatchTask and DeviceRenderTask", func() {
 			after := CreateTestingDevice(orgId, "after", &map[string]string{"labelKey": "label1"}, "os1")
-			callbacksManager.DeviceUpdatedCallback(nil, after)
+			Expect(callbacksManager.DeviceUpdatedCallback(context.Background(), nil, after)).To(Succeed())
 
 			Expect(mockPublisher.publishedResources).To(HaveLen(3))
 
@@ -168,7 +170,7 @@ var _ = Describe("DeviceUpdatedCallback", func() {
 	When("before is not nil and after is nil", func() {
 		It("submits FleetRolloutTask and FleetSelectorMatchTask", func() {
 			before := CreateTestingDevice(orgId, "before", &map[string]string{"labelKey": "label1"}, "os1")
-			callbacksManager.DeviceUpdatedCallback(before, nil)
+			Expect(callbacksManager.DeviceUpdatedCallback(context.Background(), before, nil)).To(Succeed())
 
 			Expect(mockPublisher.publishedResources).To(HaveLen(2))
 
@@ -190,7 +192,7 @@ var _ = Describe("DeviceUpdatedCallback", func() {
 		It("submits FleetRolloutTask, FleetSelectorMatchTask and DeviceRenderTask", func() {
 			before := CreateTestingDevice(orgId, "before", &map[string]string{"labelKey": "label1"}, "os1")
 			after := CreateTestingDevice(orgId, "after", &map[string]string{"labelKey": "label2"}, "os2")
-			callbacksManager.DeviceUpdatedCallback(before, after)
+			Expect(callbacksManager.DeviceUpdatedCallback(context.Background(), before, after)).To(Succeed())
 
 			Expect(mockPublisher.publishedResources).To(HaveLen(3))
 
@@ -218,7 +220,7 @@ var _ = Describe("DeviceUpdatedCallback", func() {
 		It("submits FleetRolloutTask, FleetSelectorMatchTask and DeviceRenderTask", func() {
 			before := CreateTestingDevice(orgId, "before", &map[string]string{"labelKey": "label1"}, "os1")
 			after := CreateTestingDevice(orgId, "after", &map[string]string{"labelKey": "label2"}, "os2")
-			callbacksManager.DeviceUpdatedCallback(before, after)
+			Expect(callbacksManager.DeviceUpdatedCallback(context.Background(), before, after)).To(Succeed())
 
 			Expect(mockPublisher.publishedResources).To(HaveLen(3))
 
@@ -251,7 +253,7 @@ var _ = Describe("FleetSourceUpdated", func() {
 	})
 
 	It("submits FleetValidateTask", func() {
-		callbacksManager.FleetSourceUpdated(orgId, "name")
+		Expect(callbacksManager.FleetSourceUpdated(context.Background(), orgId, "name")).To(Succeed())
 
 		Expect(mockPublisher.publishedResources).To(HaveLen(1))
 
@@ -271,7 +273,7 @@ var _ = Describe("DeviceSourceUpdated", func() {
 	})
 
 	It("submits DeviceRenderTask", func() {
-		callbacksManager.DeviceSourceUpdated(orgId, "name")
+		Expect(callbacksManager.DeviceSourceUpdated(context.Background(), orgId, "name")).To(Succeed())
 
 		Expect(mockPublisher.publishedResources).To(HaveLen(1))
 
@@ -281,6 +283,13 @@ var _ = Describe("DeviceSourceUpdated", func() {
 		Expect(publishedResource.TaskName).To(Equal(DeviceRenderTask))
 		Expect(publishedResource.Op).To(Equal(DeviceRenderOpUpdate))
 	})
+	It("passes the request ID on to the task", func() {
+		ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "host/abc-000001")
+		Expect(callbacksManager.DeviceSourceUpdated(ctx, orgId, "name")).To(Succeed())
+
+		Expect(mockPublisher.publishedResources).To(HaveLen(1))
+		Expect(mockPublisher.publishedResources[0].RequestID).To(Equal("host/abc-000001"))
+	})
 })
 
 var _ = Describe("RepositoryUpdatedCallback", func() {
@@ -292,7 +301,7 @@ var _ = Describe("RepositoryUpdatedCallback", func() {
 
 	It("submits RepositoryUpdatesTask", func() {
 		repository := CreateTestingRepository(orgId, "name", "url")
-		callbacksManager.RepositoryUpdatedCallback(repository)
+		Expect(callbacksManager.RepositoryUpdatedCallback(context.Background(), repository)).To(Succeed())
 
 		Expect(mockPublisher.publishedResources).To(HaveLen(1))
 
@@ -312,7 +321,7 @@ var _ = Describe("AllRepositoriesDeletedCallback", func() {
 	})
 
 	It("submits RepositoryUpdatesTask", func() {
-		callbacksManager.AllRepositoriesDeletedCallback(orgId)
+		Expect(callbacksManager.AllRepositoriesDeletedCallback(context.Background(), orgId)).To(Succeed())
 
 		Expect(mockPublisher.publishedResources).To(HaveLen(1))
 
@@ -332,7 +341,7 @@ var _ = Describe("AllFleetsDeletedCallback", func() {
 	})
 
 	It("submits FleetSelectorMatchTask", func() {
-		callbacksManager.AllFleetsDeletedCallback(orgId)
+		Expect(callbacksManager.AllFleetsDeletedCallback(context.Background(), orgId)).To(Succeed())
 
 		Expect(mockPublisher.publishedResources).To(HaveLen(1))
 
@@ -352,7 +361,7 @@ var _ = Describe("AllDevicesDeletedCallback", func() {
 	})
 
 	It("submits FleetSelectorMatchTask", func() {
-		callbacksManager.AllDevicesDeletedCallback(orgId)
+		Expect(callbacksManager.AllDevicesDeletedCallback(context.Background(), orgId)).To(Succeed())
 
 		Expect(mockPublisher.publishedResources).To(HaveLen(1))
 
@@ -373,7 +382,7 @@ var _ = Describe("TemplateVersionCreatedCallback", func() {
 
 	It("submits FleetRolloutTask", func() {
 		templateVersion := CreateTestingTemplateVersion(orgId, "name", "template")
-		callbacksManager.TemplateVersionCreatedCallback(templateVersion)
+		Expect(callbacksManager.TemplateVersionCreatedCallback(context.Background(), templateVersion)).To(Succeed())
 
 		Expect(mockPublisher.publishedResources).To(HaveLen(1))
 
@@ -29,6 +29,12 @@ type ResourceReference struct {
 	Kind     string
 	Name     string
 	Owner    string
+	// IdempotencyKey identifies a single submission of the task, so that a redelivery of the
+	// same message can be told apart from a new submission for the same resource.
+	IdempotencyKey string
+	// RequestID is the ID of the request that submitted the task. It is passed on to the tasks the
+	// task submits in turn, so that the logs of a request and of all the work it caused share it.
+	RequestID string
 }
 
 var (
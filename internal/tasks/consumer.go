@@ -1,42 +1,196 @@
 package tasks
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/flightctl/flightctl/internal/kvstore"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/pkg/k8sclient"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
 	"github.com/flightctl/flightctl/pkg/queues"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
 const TaskQueue = "task-queue"
 
-func dispatchTasks(store store.Store, callbackManager CallbackManager, k8sClient k8sclient.K8SClient, kvStore kvstore.KVStore) queues.ConsumeHandler {
+// IdempotencyWindow is how long a processed task is remembered, so that redeliveries of it
+// within the window are skipped.
+const IdempotencyWindow = 30 * time.Minute
+
+var (
+	// idempotencyLease is how long the idempotency key of a running task is held without being
+	// renewed, which bounds how long a redelivery waits for a worker that stopped.
+	idempotencyLease = 30 * time.Second
+	// idempotencyPollInterval is how often a redelivery checks the outcome of the running task.
+	idempotencyPollInterval = time.Second
+	idempotencyDone         = []byte("done")
+)
+
+var ErrTaskTimeout = errors.New("task execution timed out")
+
+// TaskTimeouts bounds the execution time of task handlers.
+type TaskTimeouts struct {
+	// Default applies to all task types without an entry in PerTask. Zero disables the timeout.
+	Default time.Duration
+	// PerTask maps a task name to its timeout.
+	PerTask map[string]time.Duration
+	// MaxRetries is the number of times a task that timed out is retried before giving up.
+	MaxRetries int
+}
+
+func (t TaskTimeouts) timeoutFor(taskName string) time.Duration {
+	if timeout, ok := t.PerTask[taskName]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// executeWithTimeout runs the task with a deadline, retrying it if it failed because the deadline
+// was exceeded. A task that returns successfully is not retried even if it finished after the
+// deadline, as its side effects already happened.
+func executeWithTimeout(ctx context.Context, reference *ResourceReference, timeouts TaskTimeouts, log logrus.FieldLogger, run func(ctx context.Context) error) error {
+	timeout := timeouts.timeoutFor(reference.TaskName)
+	if timeout <= 0 {
+		return run(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		taskCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := run(taskCtx)
+		timedOut := errors.Is(err, context.DeadlineExceeded) && errors.Is(taskCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+		cancel()
+		if !timedOut {
+			return err
+		}
+
+		log.Warnf("task %s, op %s, kind %s, name %s timed out after %s (attempt %d of %d)",
+			reference.TaskName, reference.Op, reference.Kind, reference.Name, time.Since(start), attempt, timeouts.MaxRetries+1)
+		if attempt > timeouts.MaxRetries {
+			return fmt.Errorf("%w: %s after %d attempts", ErrTaskTimeout, reference.TaskName, attempt)
+		}
+	}
+}
+
+// executeOnce runs the task unless a delivery with the same idempotency key was already
+// processed within the idempotency window. The key holds a lease while the task runs, so that a
+// concurrent delivery waits for the outcome of the task rather than running it again, and is only
+// recorded as processed once the task succeeds. If the worker running the task stops, the lease
+// expires and a redelivery runs the task.
+func executeOnce(ctx context.Context, reference *ResourceReference, kvStore kvstore.KVStore, log logrus.FieldLogger, run func(ctx context.Context) error) error {
+	if reference.IdempotencyKey == "" || kvStore == nil {
+		return run(ctx)
+	}
+
+	key := kvstore.TaskIdempotencyKey{OrgID: reference.OrgID, TaskName: reference.TaskName, Key: reference.IdempotencyKey}
+	lease := []byte("in-progress/" + uuid.NewString())
+	for {
+		claimed, err := kvStore.SetNXWithExpiration(ctx, key.ComposeKey(), lease, idempotencyLease)
+		if err != nil {
+			return fmt.Errorf("claiming idempotency key: %w", err)
+		}
+		if claimed {
+			break
+		}
+		value, err := kvStore.Get(ctx, key.ComposeKey())
+		if err != nil {
+			return fmt.Errorf("reading idempotency key: %w", err)
+		}
+		if bytes.Equal(value, idempotencyDone) {
+			log.Infof("skipping duplicate delivery of task %s, op %s, kind %s, name %s",
+				reference.TaskName, reference.Op, reference.Kind, reference.Name)
+			return nil
+		}
+		// another worker is running the task, wait until it succeeds, fails or stops
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(idempotencyPollInterval):
+		}
+	}
+
+	renewCtx, stopRenewing := context.WithCancel(ctx)
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		renewIdempotencyLease(renewCtx, kvStore, key.ComposeKey(), lease, log)
+	}()
+	err := run(ctx)
+	stopRenewing()
+	<-renewed
+
+	ctx = context.WithoutCancel(ctx)
+	if err != nil {
+		if _, releaseErr := kvStore.DeleteIfEqual(ctx, key.ComposeKey(), lease); releaseErr != nil {
+			log.WithError(releaseErr).Warnf("failed releasing idempotency key of task %s", reference.TaskName)
+		}
+		return err
+	}
+	if _, err := kvStore.SetIfEqualWithExpiration(ctx, key.ComposeKey(), lease, idempotencyDone, IdempotencyWindow); err != nil {
+		log.WithError(err).Warnf("failed recording idempotency key of task %s", reference.TaskName)
+	}
+	return nil
+}
+
+// renewIdempotencyLease extends the lease on the idempotency key of a running task until the
+// context is cancelled.
+func renewIdempotencyLease(ctx context.Context, kvStore kvstore.KVStore, key string, lease []byte, log logrus.FieldLogger) {
+	ticker := time.NewTicker(idempotencyLease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := kvStore.SetIfEqualWithExpiration(ctx, key, lease, lease, idempotencyLease); err != nil && ctx.Err() == nil {
+				log.WithError(err).Warn("failed renewing idempotency key lease")
+			}
+		}
+	}
+}
+
+func dispatchTasks(store store.Store, callbackManager CallbackManager, k8sClient k8sclient.K8SClient, kvStore kvstore.KVStore, timeouts TaskTimeouts, trace *TaskTrace, reconciles *FleetReconcileMetrics) queues.ConsumeHandler {
 	return func(ctx context.Context, payload []byte, log logrus.FieldLogger) error {
 		var reference ResourceReference
 		if err := json.Unmarshal(payload, &reference); err != nil {
 			log.WithError(err).Error("failed to unmarshal consume payload")
 			return err
 		}
+		// continue the request that submitted the task, rather than the delivery of the message
+		if reference.RequestID != "" {
+			ctx = context.WithValue(ctx, middleware.RequestIDKey, reference.RequestID)
+			log = flightlog.WithReqID(reference.RequestID, log)
+		}
 		log.Infof("dispatching task %s, op %s, kind %s, orgID %s, name %s",
 			reference.TaskName, reference.Op, reference.Kind, reference.OrgID, reference.Name)
-		switch reference.TaskName {
-		case FleetRolloutTask:
-			return fleetRollout(ctx, &reference, store, callbackManager, log)
-		case FleetSelectorMatchTask:
-			return fleetSelectorMatching(ctx, &reference, store, callbackManager, log)
-		case FleetValidateTask:
-			return fleetValidate(ctx, &reference, store, callbackManager, k8sClient, log)
-		case DeviceRenderTask:
-			return deviceRender(ctx, &reference, store, callbackManager, k8sClient, kvStore, log)
-		case RepositoryUpdatesTask:
-			return repositoryUpdate(ctx, &reference, store, callbackManager, log)
-		default:
-			return fmt.Errorf("unexpected task name %s", reference.TaskName)
-		}
+		startedAt := time.Now()
+		err := executeOnce(ctx, &reference, kvStore, log, func(ctx context.Context) error {
+			return executeWithTimeout(ctx, &reference, timeouts, log, func(ctx context.Context) error {
+				switch reference.TaskName {
+				case FleetRolloutTask:
+					return fleetRollout(ctx, &reference, store, callbackManager, reconciles, log)
+				case FleetSelectorMatchTask:
+					return fleetSelectorMatching(ctx, &reference, store, callbackManager, log)
+				case FleetValidateTask:
+					return fleetValidate(ctx, &reference, store, callbackManager, k8sClient, log)
+				case DeviceRenderTask:
+					return deviceRender(ctx, &reference, store, callbackManager, k8sClient, kvStore, log)
+				case RepositoryUpdatesTask:
+					return repositoryUpdate(ctx, &reference, store, callbackManager, log)
+				default:
+					return fmt.Errorf("unexpected task name %s", reference.TaskName)
+				}
+			})
+		})
+		trace.Record(&reference, startedAt, time.Since(startedAt), err)
+		return err
 	}
 }
 
@@ -46,6 +200,9 @@ func LaunchConsumers(ctx context.Context,
 	callbackManager CallbackManager,
 	k8sClient k8sclient.K8SClient,
 	kvStore kvstore.KVStore,
+	timeouts TaskTimeouts,
+	trace *TaskTrace,
+	reconciles *FleetReconcileMetrics,
 	numConsumers, threadsPerConsumer int) error {
 	for i := 0; i != numConsumers; i++ {
 		consumer, err := provider.NewConsumer(TaskQueue)
@@ -53,7 +210,7 @@ func LaunchConsumers(ctx context.Context,
 			return err
 		}
 		for j := 0; j != threadsPerConsumer; j++ {
-			if err = consumer.Consume(ctx, dispatchTasks(store, callbackManager, k8sClient, kvStore)); err != nil {
+			if err = consumer.Consume(ctx, dispatchTasks(store, callbackManager, k8sClient, kvStore, timeouts, trace, reconciles)); err != nil {
 				return err
 			}
 		}
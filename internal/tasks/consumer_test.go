@@ -0,0 +1,322 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/kvstore"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteWithTimeoutRetriesSlowHandler(t *testing.T) {
+	require := require.New(t)
+	reference := &ResourceReference{TaskName: FleetRolloutTask, Kind: "Fleet", Name: "fleet"}
+	timeouts := TaskTimeouts{
+		Default:    time.Hour,
+		PerTask:    map[string]time.Duration{FleetRolloutTask: 50 * time.Millisecond},
+		MaxRetries: 1,
+	}
+
+	attempts := 0
+	err := executeWithTimeout(context.Background(), reference, timeouts, log.InitLogs(), func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			// hang until the deadline cancels the first attempt
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+	require.NoError(err)
+	require.Equal(2, attempts)
+}
+
+func TestExecuteWithTimeoutGivesUp(t *testing.T) {
+	require := require.New(t)
+	reference := &ResourceReference{TaskName: DeviceRenderTask, Kind: "Device", Name: "device"}
+	timeouts := TaskTimeouts{Default: 20 * time.Millisecond, MaxRetries: 2}
+
+	attempts := 0
+	start := time.Now()
+	err := executeWithTimeout(context.Background(), reference, timeouts, log.InitLogs(), func(ctx context.Context) error {
+		attempts++
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.ErrorIs(err, ErrTaskTimeout)
+	require.Equal(3, attempts)
+	require.Less(time.Since(start), time.Second)
+}
+
+func TestExecuteWithTimeoutDoesNotRetryLateSuccess(t *testing.T) {
+	require := require.New(t)
+	reference := &ResourceReference{TaskName: FleetRolloutTask}
+	timeouts := TaskTimeouts{Default: 20 * time.Millisecond, MaxRetries: 3}
+
+	// the handler ignores the deadline and succeeds after it
+	attempts := 0
+	err := executeWithTimeout(context.Background(), reference, timeouts, log.InitLogs(), func(ctx context.Context) error {
+		attempts++
+		<-ctx.Done()
+		return nil
+	})
+	require.NoError(err)
+	require.Equal(1, attempts)
+
+	// or fails for another reason
+	handlerErr := errors.New("failed")
+	attempts = 0
+	err = executeWithTimeout(context.Background(), reference, timeouts, log.InitLogs(), func(ctx context.Context) error {
+		attempts++
+		<-ctx.Done()
+		return handlerErr
+	})
+	require.ErrorIs(err, handlerErr)
+	require.Equal(1, attempts)
+}
+
+func TestExecuteWithTimeoutDoesNotRetryFailures(t *testing.T) {
+	require := require.New(t)
+	reference := &ResourceReference{TaskName: FleetValidateTask}
+	timeouts := TaskTimeouts{Default: time.Second, MaxRetries: 3}
+	handlerErr := errors.New("failed")
+
+	attempts := 0
+	err := executeWithTimeout(context.Background(), reference, timeouts, log.InitLogs(), func(ctx context.Context) error {
+		attempts++
+		return handlerErr
+	})
+	require.ErrorIs(err, handlerErr)
+	require.Equal(1, attempts)
+}
+
+// memKVStore is an in-memory KVStore implementing the subset used for idempotency keys.
+type memKVStore struct {
+	kvstore.KVStore
+	mu      sync.Mutex
+	keys    map[string][]byte
+	expires map[string]time.Time
+}
+
+func newMemKVStore() *memKVStore {
+	return &memKVStore{keys: map[string][]byte{}, expires: map[string]time.Time{}}
+}
+
+// get returns the value of the key, expiring it first if needed. The lock must be held.
+func (m *memKVStore) get(key string) ([]byte, bool) {
+	if expires, ok := m.expires[key]; ok && !time.Now().Before(expires) {
+		delete(m.keys, key)
+		delete(m.expires, key)
+	}
+	value, ok := m.keys[key]
+	return value, ok
+}
+
+func (m *memKVStore) set(key string, value []byte, expiration time.Duration) {
+	m.keys[key] = value
+	m.expires[key] = time.Now().Add(expiration)
+}
+
+func (m *memKVStore) SetNXWithExpiration(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.get(key); ok {
+		return false, nil
+	}
+	m.set(key, value, expiration)
+	return true, nil
+}
+
+func (m *memKVStore) SetIfEqualWithExpiration(ctx context.Context, key string, expected []byte, value []byte, expiration time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, ok := m.get(key); !ok || string(current) != string(expected) {
+		return false, nil
+	}
+	m.set(key, value, expiration)
+	return true, nil
+}
+
+func (m *memKVStore) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, _ := m.get(key)
+	return value, nil
+}
+
+func (m *memKVStore) DeleteIfEqual(ctx context.Context, key string, value []byte) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, ok := m.get(key); !ok || string(current) != string(value) {
+		return false, nil
+	}
+	delete(m.keys, key)
+	return true, nil
+}
+
+func TestExecuteOnceSkipsRedelivery(t *testing.T) {
+	require := require.New(t)
+	kvStore := newMemKVStore()
+	reference := ResourceReference{TaskName: FleetRolloutTask, OrgID: uuid.New(), Kind: "Fleet", Name: "fleet", IdempotencyKey: uuid.NewString()}
+
+	sideEffects := 0
+	run := func(ctx context.Context) error {
+		sideEffects++
+		return nil
+	}
+	for i := 0; i < 2; i++ {
+		delivered := reference
+		require.NoError(executeOnce(context.Background(), &delivered, kvStore, log.InitLogs(), run))
+	}
+	require.Equal(1, sideEffects)
+
+	// a new submission for the same resource is not a duplicate
+	reference.IdempotencyKey = uuid.NewString()
+	require.NoError(executeOnce(context.Background(), &reference, kvStore, log.InitLogs(), run))
+	require.Equal(2, sideEffects)
+}
+
+func TestExecuteOnceRetriesFailedDelivery(t *testing.T) {
+	require := require.New(t)
+	kvStore := newMemKVStore()
+	reference := &ResourceReference{TaskName: FleetValidateTask, OrgID: uuid.New(), Kind: "Fleet", Name: "fleet", IdempotencyKey: uuid.NewString()}
+	handlerErr := errors.New("failed")
+
+	attempts := 0
+	run := func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			return handlerErr
+		}
+		return nil
+	}
+	require.ErrorIs(executeOnce(context.Background(), reference, kvStore, log.InitLogs(), run), handlerErr)
+	require.NoError(executeOnce(context.Background(), reference, kvStore, log.InitLogs(), run))
+	require.NoError(executeOnce(context.Background(), reference, kvStore, log.InitLogs(), run))
+	require.Equal(2, attempts)
+}
+
+func TestExecuteOnceWaitsForConcurrentDelivery(t *testing.T) {
+	require := require.New(t)
+	defer setIdempotencyTiming(time.Minute, 10*time.Millisecond)()
+	kvStore := newMemKVStore()
+	reference := ResourceReference{TaskName: DeviceRenderTask, OrgID: uuid.New(), Kind: "Device", Name: "device", IdempotencyKey: uuid.NewString()}
+
+	var mu sync.Mutex
+	sideEffects := 0
+	started := make(chan struct{})
+	release := make(chan struct{})
+	run := func(ctx context.Context) error {
+		mu.Lock()
+		sideEffects++
+		mu.Unlock()
+		close(started)
+		<-release
+		return nil
+	}
+
+	first := make(chan error)
+	go func() {
+		delivered := reference
+		first <- executeOnce(context.Background(), &delivered, kvStore, log.InitLogs(), run)
+	}()
+	<-started
+
+	// the duplicate waits for the running task and skips it once it succeeded
+	second := make(chan error)
+	go func() {
+		delivered := reference
+		second <- executeOnce(context.Background(), &delivered, kvStore, log.InitLogs(), run)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	require.NoError(<-first)
+	require.NoError(<-second)
+	require.Equal(1, sideEffects)
+}
+
+func TestExecuteOnceRunsTaskOfStoppedWorker(t *testing.T) {
+	require := require.New(t)
+	defer setIdempotencyTiming(100*time.Millisecond, 10*time.Millisecond)()
+	kvStore := newMemKVStore()
+	reference := &ResourceReference{TaskName: FleetRolloutTask, OrgID: uuid.New(), Kind: "Fleet", Name: "fleet", IdempotencyKey: uuid.NewString()}
+
+	// a worker claimed the task and stopped before finishing it
+	key := kvstore.TaskIdempotencyKey{OrgID: reference.OrgID, TaskName: reference.TaskName, Key: reference.IdempotencyKey}
+	_, err := kvStore.SetNXWithExpiration(context.Background(), key.ComposeKey(), []byte("in-progress/stopped"), idempotencyLease)
+	require.NoError(err)
+
+	// so the redelivered task runs once its lease expires
+	attempts := 0
+	require.NoError(executeOnce(context.Background(), reference, kvStore, log.InitLogs(), func(ctx context.Context) error {
+		attempts++
+		return nil
+	}))
+	require.Equal(1, attempts)
+}
+
+func TestExecuteOnceRenewsLease(t *testing.T) {
+	require := require.New(t)
+	defer setIdempotencyTiming(60*time.Millisecond, 10*time.Millisecond)()
+	kvStore := newMemKVStore()
+	reference := ResourceReference{TaskName: FleetValidateTask, OrgID: uuid.New(), Kind: "Fleet", Name: "fleet", IdempotencyKey: uuid.NewString()}
+
+	// a task running longer than the lease keeps its key
+	attempts := 0
+	run := func(ctx context.Context) error {
+		attempts++
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	}
+	done := make(chan error)
+	go func() {
+		delivered := reference
+		done <- executeOnce(context.Background(), &delivered, kvStore, log.InitLogs(), run)
+	}()
+	time.Sleep(150 * time.Millisecond)
+	require.NoError(executeOnce(context.Background(), &reference, kvStore, log.InitLogs(), run))
+	require.NoError(<-done)
+	require.Equal(1, attempts)
+}
+
+func setIdempotencyTiming(lease time.Duration, pollInterval time.Duration) func() {
+	previousLease, previousPollInterval := idempotencyLease, idempotencyPollInterval
+	idempotencyLease, idempotencyPollInterval = lease, pollInterval
+	return func() {
+		idempotencyLease, idempotencyPollInterval = previousLease, previousPollInterval
+	}
+}
+
+func TestExecuteOnceWithoutKey(t *testing.T) {
+	reference := &ResourceReference{TaskName: FleetRolloutTask}
+	attempts := 0
+	for i := 0; i < 2; i++ {
+		require.NoError(t, executeOnce(context.Background(), reference, newMemKVStore(), log.InitLogs(), func(ctx context.Context) error {
+			attempts++
+			return nil
+		}))
+	}
+	require.Equal(t, 2, attempts)
+}
+
+func TestDispatchTasksContinuesRequest(t *testing.T) {
+	require := require.New(t)
+	payload, err := json.Marshal(ResourceReference{TaskName: "unknown", OrgID: uuid.New(), Kind: "Device", Name: "device", RequestID: "host/abc-000001"})
+	require.NoError(err)
+
+	// the message is delivered with a request ID of its own, which the submitting request replaces
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "consumer-request")
+	trace := NewTaskTrace(1)
+	require.Error(dispatchTasks(nil, nil, nil, nil, TaskTimeouts{}, trace, nil)(ctx, payload, log.InitLogs()))
+
+	executions := trace.Executions()
+	require.Len(executions, 1)
+	require.Equal("host/abc-000001", executions[0].RequestID)
+}
@@ -107,6 +107,11 @@ func (t *DeviceRenderLogic) RenderDevice(ctx context.Context) error {
 	}
 
 	err = t.store.Device().UpdateRendered(ctx, t.resourceRef.OrgID, t.resourceRef.Name, string(renderedConfig), string(renderedApplications))
+	if err == nil && len(util.DefaultIfNil(t.templateVersion, "")) > 0 {
+		// batched rollouts wait for the devices to be rendered from the template version they roll out
+		annotations := map[string]string{api.DeviceAnnotationRenderedTemplateVersion: *t.templateVersion}
+		err = t.store.Device().UpdateAnnotations(ctx, t.resourceRef.OrgID, t.resourceRef.Name, annotations, nil)
+	}
 	return t.setStatus(ctx, err)
 }
 
@@ -262,8 +267,9 @@ func renderImageApplicationProvider(app *api.ApplicationSpec) (*string, *api.Ren
 
 	appName := util.FromPtr(app.Name)
 	renderedApp := api.RenderedApplicationSpec{
-		Name:    app.Name,
-		EnvVars: app.EnvVars,
+		Name:      app.Name,
+		EnvVars:   app.EnvVars,
+		DependsOn: app.DependsOn,
 	}
 	if err := renderedApp.FromImageApplicationProvider(imageProvider); err != nil {
 		return &appName, nil, fmt.Errorf("failed rendering application %s: %w", appName, err)
@@ -399,6 +405,10 @@ func (t *DeviceRenderLogic) renderInlineConfig(configItem *api.ConfigProviderSpe
 		if file.ContentEncoding != nil && *file.ContentEncoding == api.Base64 {
 			isBase64 = true
 		}
+		if lo.FromPtr(file.Template) {
+			ignitionWrapper.SetTemplateFile(file.Path, []byte(file.Content), mode, isBase64, file.User, file.Group)
+			continue
+		}
 		ignitionWrapper.SetFile(file.Path, []byte(file.Content), mode, isBase64, file.User, file.Group)
 	}
 
@@ -0,0 +1,54 @@
+package tasks
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	ReconcileResultSuccess = "success"
+	ReconcileResultSkip    = "skip"
+	ReconcileResultError   = "error"
+)
+
+// FleetReconcileMetrics counts the outcomes of the rollouts of each fleet, so that operators can
+// tell which fleets stall and why without going through the worker logs.
+type FleetReconcileMetrics struct {
+	reconciles *prometheus.CounterVec
+}
+
+func NewFleetReconcileMetrics() *FleetReconcileMetrics {
+	return &FleetReconcileMetrics{
+		reconciles: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "flightctl_worker_fleet_reconciles_total",
+			Help: "Number of fleet rollouts, by result: success when devices were updated, skip when no device needed an update, error when the rollout failed.",
+		}, []string{"org_id", "fleet", "result"}),
+	}
+}
+
+// Record counts the rollout of the fleet, which updated the given number of devices. It is a no-op
+// on nil metrics.
+func (m *FleetReconcileMetrics) Record(reference *ResourceReference, updated int, err error) {
+	if m == nil {
+		return
+	}
+	m.reconciles.WithLabelValues(reference.OrgID.String(), reference.Name, reconcileResult(updated, err)).Inc()
+}
+
+func (m *FleetReconcileMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.reconciles.Describe(ch)
+}
+
+func (m *FleetReconcileMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.reconciles.Collect(ch)
+}
+
+func reconcileResult(updated int, err error) string {
+	switch {
+	case err != nil:
+		return ReconcileResultError
+	case updated == 0:
+		return ReconcileResultSkip
+	default:
+		return ReconcileResultSuccess
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
 	"text/template"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
@@ -15,7 +16,7 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func fleetRollout(ctx context.Context, resourceRef *ResourceReference, store store.Store, callbackManager CallbackManager, log logrus.FieldLogger) error {
+func fleetRollout(ctx context.Context, resourceRef *ResourceReference, store store.Store, callbackManager CallbackManager, reconciles *FleetReconcileMetrics, log logrus.FieldLogger) error {
 	if resourceRef.Op != FleetRolloutOpUpdate {
 		log.Errorf("received unknown op %s", resourceRef.Op)
 		return nil
@@ -23,10 +24,12 @@ func fleetRollout(ctx context.Context, resourceRef *ResourceReference, store sto
 	logic := NewFleetRolloutsLogic(callbackManager, log, store, *resourceRef)
 	switch resourceRef.Kind {
 	case api.FleetKind:
-		err := logic.RolloutFleet(ctx)
+		updated, err := logic.rolloutFleet(ctx)
 		if err != nil {
 			log.Errorf("failed rolling out fleet %s/%s: %v", resourceRef.OrgID, resourceRef.Name, err)
 		}
+		reconciles.Record(resourceRef, updated, err)
+		setFleetReconciledCondition(ctx, store, resourceRef, err, log)
 		return err
 	case api.DeviceKind:
 		err := logic.RolloutDevice(ctx)
@@ -39,6 +42,26 @@ func fleetRollout(ctx context.Context, resourceRef *ResourceReference, store sto
 	}
 }
 
+// setFleetReconciledCondition surfaces the result of the last rollout of the fleet in its status,
+// so that the reason of a stalled rollout can be seen without going through the worker logs.
+func setFleetReconciledCondition(ctx context.Context, store store.Store, resourceRef *ResourceReference, reconcileErr error, log logrus.FieldLogger) {
+	condition := api.Condition{Type: api.FleetReconciled}
+
+	if reconcileErr == nil {
+		condition.Status = api.ConditionStatusTrue
+		condition.Reason = "Reconciled"
+	} else {
+		condition.Status = api.ConditionStatusFalse
+		condition.Reason = "ReconcileFailed"
+		condition.Message = reconcileErr.Error()
+	}
+
+	err := store.Fleet().UpdateConditions(ctx, resourceRef.OrgID, resourceRef.Name, []api.Condition{condition})
+	if err != nil {
+		log.Errorf("Failed setting condition for fleet %s/%s: %v", resourceRef.OrgID, resourceRef.Name, err)
+	}
+}
+
 type FleetRolloutsLogic struct {
 	callbackManager CallbackManager
 	log             logrus.FieldLogger
@@ -67,24 +90,78 @@ func (f *FleetRolloutsLogic) SetItemsPerPage(items int) {
 }
 
 func (f FleetRolloutsLogic) RolloutFleet(ctx context.Context) error {
+	_, err := f.rolloutFleet(ctx)
+	return err
+}
+
+// rolloutFleet rolls out the latest templateVersion of the fleet to its devices, and returns the
+// number of devices it updated.
+func (f FleetRolloutsLogic) rolloutFleet(ctx context.Context) (int, error) {
 	f.log.Infof("Rolling out fleet %s/%s", f.resourceRef.OrgID, f.resourceRef.Name)
 
 	templateVersion, err := f.tvStore.GetLatest(ctx, f.resourceRef.OrgID, f.resourceRef.Name)
 	if err != nil {
-		return fmt.Errorf("failed to get templateVersion: %w", err)
+		return 0, fmt.Errorf("failed to get templateVersion: %w", err)
 	}
 
-	failureCount := 0
 	owner := util.SetResourceOwner(api.FleetKind, f.resourceRef.Name)
 	f.owner = *owner
 
-	fs, err := selector.NewFieldSelectorFromMap(map[string]string{"metadata.owner": *owner}, false)
+	fleet, err := f.fleetStore.Get(ctx, f.resourceRef.OrgID, f.resourceRef.Name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fleet: %w", err)
+	}
+	batches, err := rolloutBatches(fleet.Spec.RolloutPolicy)
+	if err != nil {
+		return 0, err
+	}
+	if len(batches) > 0 {
+		return f.rolloutFleetInBatches(ctx, fleet, batches, templateVersion)
+	}
+	if fleet.Status != nil && fleet.Status.Rollout != nil {
+		// the rollout policy of the fleet no longer defines batches
+		if err := f.fleetStore.UpdateRolloutStatus(ctx, f.resourceRef.OrgID, f.resourceRef.Name, nil); err != nil {
+			return 0, fmt.Errorf("failed clearing rollout status: %w", err)
+		}
+	}
+	return f.rolloutAllDevices(ctx, templateVersion)
+}
+
+// rolloutAllDevices rolls out the templateVersion to all devices of the fleet at once, and returns
+// the number of devices it updated.
+func (f FleetRolloutsLogic) rolloutAllDevices(ctx context.Context, templateVersion *api.TemplateVersion) (int, error) {
+	failureCount := 0
+	updateCount := 0
+	err := f.forEachFleetDevice(ctx, func(device *api.Device) {
+		updated, err := f.updateDeviceToFleetTemplate(ctx, device, templateVersion, 0)
+		if err != nil {
+			f.log.Errorf("failed to update target generation for device %s (fleet %s): %v", *device.Metadata.Name, f.resourceRef.Name, err)
+			failureCount++
+		} else if updated {
+			updateCount++
+		}
+	})
+	if err != nil {
+		return updateCount, err
+	}
+
+	if failureCount != 0 {
+		// TODO: Retry when we have a mechanism that allows it
+		return updateCount, fmt.Errorf("failed updating %d devices", failureCount)
+	}
+
+	return updateCount, nil
+}
+
+// forEachFleetDevice calls fn with each device of the fleet, a page of devices at a time.
+func (f FleetRolloutsLogic) forEachFleetDevice(ctx context.Context, fn func(device *api.Device)) error {
+	fs, err := selector.NewFieldSelectorFromMap(map[string]string{"metadata.owner": f.owner}, false)
 	if err != nil {
 		return err
 	}
 
 	listParams := store.ListParams{
-		Limit:         ItemsPerPage,
+		Limit:         f.itemsPerPage,
 		FieldSelector: fs,
 	}
 
@@ -96,31 +173,18 @@ func (f FleetRolloutsLogic) RolloutFleet(ctx context.Context) error {
 		}
 
 		for devIndex := range devices.Items {
-			device := &devices.Items[devIndex]
-			err = f.updateDeviceToFleetTemplate(ctx, device, templateVersion)
-			if err != nil {
-				f.log.Errorf("failed to update target generation for device %s (fleet %s): %v", *device.Metadata.Name, f.resourceRef.Name, err)
-				failureCount++
-			}
+			fn(&devices.Items[devIndex])
 		}
 
 		if devices.Metadata.Continue == nil {
-			break
-		} else {
-			cont, err := store.ParseContinueString(devices.Metadata.Continue)
-			if err != nil {
-				return fmt.Errorf("failed to parse continuation for paging: %w", err)
-			}
-			listParams.Continue = cont
+			return nil
 		}
+		cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		if err != nil {
+			return fmt.Errorf("failed to parse continuation for paging: %w", err)
+		}
+		listParams.Continue = cont
 	}
-
-	if failureCount != 0 {
-		// TODO: Retry when we have a mechanism that allows it
-		return fmt.Errorf("failed updating %d devices", failureCount)
-	}
-
-	return nil
 }
 
 // The device's owner was changed, roll out if necessary
@@ -154,10 +218,34 @@ func (f FleetRolloutsLogic) RolloutDevice(ctx context.Context) error {
 		return fmt.Errorf("failed to get templateVersion: %w", err)
 	}
 
-	return f.updateDeviceToFleetTemplate(ctx, device, templateVersion)
+	if util.FromPtr(device.Metadata.Annotations)[api.DeviceAnnotationTemplateVersion] != *templateVersion.Metadata.Name {
+		fleet, err := f.fleetStore.Get(ctx, f.resourceRef.OrgID, ownerName)
+		if err != nil {
+			return fmt.Errorf("failed to get fleet: %w", err)
+		}
+		batches, err := rolloutBatches(fleet.Spec.RolloutPolicy)
+		if err != nil {
+			return err
+		}
+		if len(batches) > 0 && !rolloutCompleted(fleet, templateVersion) {
+			f.log.Infof("Not rolling out device %s/%s because it is left to the batches of the rollout of fleet %s", f.resourceRef.OrgID, f.resourceRef.Name, ownerName)
+			return nil
+		}
+	}
+
+	_, err = f.updateDeviceToFleetTemplate(ctx, device, templateVersion, 0)
+	return err
 }
 
-func (f FleetRolloutsLogic) updateDeviceToFleetTemplate(ctx context.Context, device *api.Device, templateVersion *api.TemplateVersion) error {
+// updateDeviceToFleetTemplate updates the spec of the device to the templateVersion, and returns
+// whether the device needed an update. The batch is the number of the batch of a batched rollout
+// the device is updated in, or 0.
+func (f FleetRolloutsLogic) updateDeviceToFleetTemplate(ctx context.Context, device *api.Device, templateVersion *api.TemplateVersion, batch int) (bool, error) {
+	if device.IsCordoned() {
+		f.log.Infof("Not rolling out device %s/%s because it is cordoned", f.resourceRef.OrgID, *device.Metadata.Name)
+		return false, nil
+	}
+
 	currentVersion := ""
 	if device.Metadata.Annotations != nil {
 		v, ok := (*device.Metadata.Annotations)[api.DeviceAnnotationTemplateVersion]
@@ -184,7 +272,7 @@ func (f FleetRolloutsLogic) updateDeviceToFleetTemplate(ctx context.Context, dev
 	errs = append(errs, appErrs...)
 
 	if len(errs) > 0 {
-		return fmt.Errorf("failed generating device spec for %s/%s: %w", f.resourceRef.OrgID, *device.Metadata.Name, errors.Join(errs...))
+		return false, fmt.Errorf("failed generating device spec for %s/%s: %w", f.resourceRef.OrgID, *device.Metadata.Name, errors.Join(errs...))
 	}
 
 	newDeviceSpec := api.DeviceSpec{
@@ -198,29 +286,40 @@ func (f FleetRolloutsLogic) updateDeviceToFleetTemplate(ctx context.Context, dev
 
 	errs = newDeviceSpec.Validate(false)
 	if len(errs) > 0 {
-		return fmt.Errorf("failed validating device spec for %s/%s: %w", f.resourceRef.OrgID, *device.Metadata.Name, errors.Join(errs...))
+		return false, fmt.Errorf("failed validating device spec for %s/%s: %w", f.resourceRef.OrgID, *device.Metadata.Name, errors.Join(errs...))
 	}
 
 	if currentVersion == *templateVersion.Metadata.Name && api.DeviceSpecsAreEqual(newDeviceSpec, *device.Spec) {
 		f.log.Debugf("Not rolling out device %s/%s because it is already at templateVersion %s", f.resourceRef.OrgID, *device.Metadata.Name, *templateVersion.Metadata.Name)
-		return nil
+		return false, nil
 	}
 
 	f.log.Infof("Rolling out device %s/%s to templateVersion %s", f.resourceRef.OrgID, *device.Metadata.Name, *templateVersion.Metadata.Name)
+	specChanged := device.Spec == nil || !api.DeviceSpecsAreEqual(newDeviceSpec, *device.Spec)
 	err := f.updateDeviceInStore(ctx, device, &newDeviceSpec)
 	if err != nil {
-		return fmt.Errorf("failed updating device spec: %w", err)
+		return false, fmt.Errorf("failed updating device spec: %w", err)
 	}
 
 	annotations := map[string]string{
 		api.DeviceAnnotationTemplateVersion: *templateVersion.Metadata.Name,
 	}
-	err = f.devStore.UpdateAnnotations(ctx, f.resourceRef.OrgID, *device.Metadata.Name, annotations, nil)
+	if !specChanged {
+		// the device is not rendered again, as its rendered spec would not change
+		annotations[api.DeviceAnnotationRenderedTemplateVersion] = *templateVersion.Metadata.Name
+	}
+	var deleteKeys []string
+	if batch > 0 {
+		annotations[api.DeviceAnnotationRolloutBatch] = strconv.Itoa(batch)
+	} else {
+		deleteKeys = append(deleteKeys, api.DeviceAnnotationRolloutBatch)
+	}
+	err = f.devStore.UpdateAnnotations(ctx, f.resourceRef.OrgID, *device.Metadata.Name, annotations, deleteKeys)
 	if err != nil {
-		return fmt.Errorf("failed updating templateVersion annotation: %w", err)
+		return true, fmt.Errorf("failed updating templateVersion annotation: %w", err)
 	}
 
-	return err
+	return true, nil
 }
 
 func (f FleetRolloutsLogic) getDeviceApps(device *api.Device, templateVersion *api.TemplateVersion) (*[]api.ApplicationSpec, []error) {
@@ -405,6 +504,11 @@ func (f FleetRolloutsLogic) replaceInlineConfigParameters(device *api.Device, co
 			errs = append(errs, fmt.Errorf("failed replacing parameters in path for file %d in inline config %s: %w", fileIndex, inlineSpec.Name, err))
 		}
 
+		if util.FromPtr(file.Template) {
+			// the contents are rendered by the agent on the device
+			continue
+		}
+
 		if file.ContentEncoding == nil {
 			decodedBytes = []byte(file.Content)
 		} else {
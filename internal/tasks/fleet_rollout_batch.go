@@ -0,0 +1,401 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// FleetRolloutProgressPollingInterval is the interval at which the batched rollouts in progress
+	// are advanced, as the devices report the results of their updates.
+	FleetRolloutProgressPollingInterval = 1 * time.Minute
+
+	// defaultSuccessThreshold is the percentage of the devices of a batch that must update
+	// successfully when the rollout policy does not set one.
+	defaultSuccessThreshold = 100
+)
+
+// rolloutBatch is a batch of a batched rollout.
+type rolloutBatch struct {
+	// selector selects the devices of the batch among the devices of the fleet.
+	selector labels.Selector
+	// limit is the maximum number or percentage of the selected devices updated in the batch, all
+	// of them when nil.
+	limit *api.Batch_Limit
+	// successThreshold is the percentage of the devices of the batch that must update successfully
+	// for the rollout to continue with the next batch.
+	successThreshold int
+}
+
+// rolloutBatches returns the batches of the rollout policy, followed by a last batch updating the
+// remaining devices of the fleet, or nil when the policy does not roll out in batches.
+func rolloutBatches(policy *api.RolloutPolicy) ([]rolloutBatch, error) {
+	if policy == nil || policy.DeviceSelection == nil {
+		return nil, nil
+	}
+	if policy.DeviceSelection.Strategy != "BatchSequence" {
+		return nil, nil
+	}
+	sequence, err := policy.DeviceSelection.AsBatchSequence()
+	if err != nil {
+		return nil, fmt.Errorf("invalid rollout device selection: %w", err)
+	}
+	if len(lo.FromPtr(sequence.Sequence)) == 0 {
+		return nil, nil
+	}
+
+	successThreshold := defaultSuccessThreshold
+	if policy.SuccessThreshold != nil {
+		if successThreshold, err = parsePercentage(*policy.SuccessThreshold); err != nil {
+			return nil, fmt.Errorf("invalid rollout success threshold: %w", err)
+		}
+	}
+
+	batches := []rolloutBatch{}
+	for i, batch := range *sequence.Sequence {
+		b := rolloutBatch{selector: labels.Everything(), limit: batch.Limit, successThreshold: successThreshold}
+		if batch.Selector != nil {
+			if b.selector, err = labelSelector(batch.Selector); err != nil {
+				return nil, fmt.Errorf("invalid selector of rollout batch %d: %w", i+1, err)
+			}
+		}
+		if batch.SuccessThreshold != nil {
+			if b.successThreshold, err = parsePercentage(*batch.SuccessThreshold); err != nil {
+				return nil, fmt.Errorf("invalid success threshold of rollout batch %d: %w", i+1, err)
+			}
+		}
+		batches = append(batches, b)
+	}
+	return append(batches, rolloutBatch{selector: labels.Everything(), successThreshold: successThreshold}), nil
+}
+
+func labelSelector(selector *api.LabelSelector) (labels.Selector, error) {
+	requirements := []string{}
+	for key, value := range lo.FromPtr(selector.MatchLabels) {
+		requirements = append(requirements, key+"="+value)
+	}
+	if selector.MatchExpressions != nil {
+		requirements = append(requirements, api.MatchExpressionsToString(*selector.MatchExpressions...))
+	}
+	return labels.Parse(strings.Join(requirements, ","))
+}
+
+func parsePercentage(percentage api.Percentage) (int, error) {
+	value, err := strconv.Atoi(strings.TrimSuffix(percentage, "%"))
+	if err != nil || !strings.HasSuffix(percentage, "%") || value < 0 || value > 100 {
+		return 0, fmt.Errorf("%q is not a percentage", percentage)
+	}
+	return value, nil
+}
+
+// size returns the number of devices of the batch, out of the devices of the fleet it selects.
+func (b rolloutBatch) size(selected int) (int, error) {
+	if b.limit == nil {
+		return selected, nil
+	}
+	if percentage, err := b.limit.AsPercentage(); err == nil {
+		value, err := parsePercentage(percentage)
+		if err != nil {
+			return 0, fmt.Errorf("invalid batch limit: %w", err)
+		}
+		return int(math.Ceil(float64(selected) * float64(value) / 100)), nil
+	}
+	limit, err := b.limit.AsBatchLimit1()
+	if err != nil {
+		return 0, fmt.Errorf("invalid batch limit: %w", err)
+	}
+	return min(limit, selected), nil
+}
+
+// rolloutCompleted is true if the batched rollout of the templateVersion to the fleet completed,
+// so that the devices joining the fleet are updated right away.
+func rolloutCompleted(fleet *api.Fleet, templateVersion *api.TemplateVersion) bool {
+	if fleet.Status == nil || fleet.Status.Rollout == nil {
+		return false
+	}
+	rollout := fleet.Status.Rollout
+	return lo.FromPtr(rollout.TemplateVersion) == *templateVersion.Metadata.Name && lo.FromPtr(rollout.State) == api.FleetRolloutCompleted
+}
+
+type deviceRolloutResult int
+
+const (
+	deviceRolloutPending deviceRolloutResult = iota
+	deviceRolloutSucceeded
+	deviceRolloutFailed
+)
+
+// deviceRollout returns whether the device updated to the templateVersion it was rolled out,
+// and is healthy.
+func deviceRollout(device *api.Device, templateVersion string, batchStartedAt time.Time, updateTimeout time.Duration, now time.Time) deviceRolloutResult {
+	if device.Status != nil {
+		updating := api.FindStatusCondition(device.Status.Conditions, api.DeviceUpdating)
+		// a failure reported before the batch started is that of a previous update
+		if updating != nil && updating.Reason == string(api.UpdateStateError) && updating.LastTransitionTime.After(batchStartedAt) {
+			return deviceRolloutFailed
+		}
+	}
+	annotations := util.FromPtr(device.Metadata.Annotations)
+	updated := annotations[api.DeviceAnnotationRenderedTemplateVersion] == templateVersion &&
+		device.Status != nil && !device.IsUpdating() && device.IsUpdatedToDeviceSpec() &&
+		!device.IsDisconnected(api.DeviceDisconnectedTimeout)
+	if updated {
+		if device.Status.ApplicationsSummary.Status == api.ApplicationsSummaryStatusError {
+			return deviceRolloutFailed
+		}
+		return deviceRolloutSucceeded
+	}
+	if updateTimeout > 0 && now.Sub(batchStartedAt) > updateTimeout {
+		return deviceRolloutFailed
+	}
+	return deviceRolloutPending
+}
+
+// rolloutFleetInBatches rolls out the templateVersion to the devices of the fleet one batch after
+// the other, and returns the number of devices it updated. It only moves on to the next batch once
+// enough devices of the current batch updated and are healthy, and it halts the rollout when too
+// many of them failed. It is called again as the devices report their status, to advance the
+// rollout, and records the progress of the rollout in the status of the fleet.
+func (f FleetRolloutsLogic) rolloutFleetInBatches(ctx context.Context, fleet *api.Fleet, batches []rolloutBatch, templateVersion *api.TemplateVersion) (int, error) {
+	policy := fleet.Spec.RolloutPolicy
+	pause, err := parseRolloutDuration(policy.PauseBetweenBatches)
+	if err != nil {
+		return 0, fmt.Errorf("invalid pause between batches: %w", err)
+	}
+	updateTimeout, err := parseRolloutDuration(policy.DefaultUpdateTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid default update timeout: %w", err)
+	}
+	maxUnavailable := math.MaxInt
+	if policy.DisruptionAllowance != nil && policy.DisruptionAllowance.MaxUnavailable != nil {
+		maxUnavailable = max(*policy.DisruptionAllowance.MaxUnavailable, 1)
+	}
+
+	tvName := *templateVersion.Metadata.Name
+	now := time.Now()
+	var previous api.FleetRolloutStatus
+	if fleet.Status != nil && fleet.Status.Rollout != nil {
+		previous = *fleet.Status.Rollout
+	}
+	status := previous
+	if lo.FromPtr(status.TemplateVersion) != tvName {
+		f.log.Infof("Starting the rollout of templateVersion %s to fleet %s/%s in %d batches", tvName, f.resourceRef.OrgID, f.resourceRef.Name, len(batches))
+		status = api.FleetRolloutStatus{
+			TemplateVersion: &tvName,
+			State:           lo.ToPtr(api.FleetRolloutRolling),
+			CurrentBatch:    lo.ToPtr(1),
+			BatchStartedAt:  &now,
+		}
+	}
+
+	updateCount := 0
+	failureCount := 0
+	for done := false; !done; {
+		switch lo.FromPtr(status.State) {
+		case api.FleetRolloutCompleted:
+			// the devices that joined the fleet since are updated right away
+			return f.rolloutAllDevices(ctx, templateVersion)
+		case api.FleetRolloutHalted:
+			done = true
+			continue
+		case api.FleetRolloutWaiting:
+			if now.Before(lo.FromPtr(status.NextBatchAt)) {
+				done = true
+				continue
+			}
+			status.State = lo.ToPtr(api.FleetRolloutRolling)
+			status.CurrentBatch = lo.ToPtr(lo.FromPtr(status.CurrentBatch) + 1)
+			status.BatchStartedAt = &now
+			status.NextBatchAt = nil
+		}
+
+		current := lo.FromPtr(status.CurrentBatch)
+		if current < 1 || current > len(batches) {
+			return updateCount, fmt.Errorf("rollout status refers to batch %d of %d", current, len(batches))
+		}
+		batch := batches[current-1]
+		batchStartedAt := lo.FromPtr(status.BatchStartedAt)
+
+		var members, candidates []*api.Device
+		selected := 0
+		err := f.forEachFleetDevice(ctx, func(device *api.Device) {
+			annotations := util.FromPtr(device.Metadata.Annotations)
+			if annotations[api.DeviceAnnotationTemplateVersion] == tvName {
+				if annotations[api.DeviceAnnotationRolloutBatch] == strconv.Itoa(current) {
+					members = append(members, device)
+				}
+			}
+			if device.IsCordoned() || !batch.selector.Matches(labels.Set(util.FromPtr(device.Metadata.Labels))) {
+				return
+			}
+			selected++
+			if annotations[api.DeviceAnnotationTemplateVersion] != tvName {
+				candidates = append(candidates, device)
+			}
+		})
+		if err != nil {
+			return updateCount, err
+		}
+
+		succeeded, failed, pending := 0, 0, 0
+		for _, device := range members {
+			switch deviceRollout(device, tvName, batchStartedAt, updateTimeout, now) {
+			case deviceRolloutSucceeded:
+				succeeded++
+			case deviceRolloutFailed:
+				failed++
+			default:
+				pending++
+			}
+		}
+
+		size, err := batch.size(selected)
+		if err != nil {
+			return updateCount, err
+		}
+		remaining := min(max(size-len(members), 0), len(candidates))
+		expected := len(members) + remaining
+		required := int(math.Ceil(float64(expected) * float64(batch.successThreshold) / 100))
+
+		if failed > expected-required {
+			f.log.Warnf("Halting the rollout of templateVersion %s to fleet %s/%s: %d devices of batch %d failed", tvName, f.resourceRef.OrgID, f.resourceRef.Name, failed, current)
+			status.State = lo.ToPtr(api.FleetRolloutHalted)
+		} else if remaining > 0 {
+			// update the devices of the batch, as long as few enough of them are unavailable
+			sort.Slice(candidates, func(i, j int) bool { return *candidates[i].Metadata.Name < *candidates[j].Metadata.Name })
+			for _, device := range candidates[:max(min(remaining, maxUnavailable-pending-failed), 0)] {
+				if _, err := f.updateDeviceToFleetTemplate(ctx, device, templateVersion, current); err != nil {
+					f.log.Errorf("failed to update target generation for device %s (fleet %s): %v", *device.Metadata.Name, f.resourceRef.Name, err)
+					failureCount++
+					continue
+				}
+				updateCount++
+				pending++
+			}
+		} else if pending == 0 {
+			f.log.Infof("Batch %d of the rollout of templateVersion %s to fleet %s/%s completed", current, tvName, f.resourceRef.OrgID, f.resourceRef.Name)
+			switch {
+			case current == len(batches):
+				status.State = lo.ToPtr(api.FleetRolloutCompleted)
+			case pause > 0:
+				status.State = lo.ToPtr(api.FleetRolloutWaiting)
+				status.NextBatchAt = lo.ToPtr(now.Add(pause))
+			default:
+				status.State = lo.ToPtr(api.FleetRolloutWaiting)
+				status.NextBatchAt = &now
+				// move on to the next batch right away
+				continue
+			}
+		}
+
+		status.BatchSucceeded = &succeeded
+		status.BatchFailed = &failed
+		status.BatchPending = &pending
+		status.Message = lo.ToPtr(rolloutMessage(status, len(batches), expected))
+		done = true
+	}
+
+	if !reflect.DeepEqual(status, previous) {
+		if err := f.fleetStore.UpdateRolloutStatus(ctx, f.resourceRef.OrgID, f.resourceRef.Name, &status); err != nil {
+			return updateCount, fmt.Errorf("failed updating rollout status: %w", err)
+		}
+	}
+	if failureCount != 0 {
+		return updateCount, fmt.Errorf("failed updating %d devices", failureCount)
+	}
+	return updateCount, nil
+}
+
+func rolloutMessage(status api.FleetRolloutStatus, batches int, expected int) string {
+	progress := fmt.Sprintf("batch %d of %d: %d of %d devices updated, %d failed", lo.FromPtr(status.CurrentBatch), batches,
+		lo.FromPtr(status.BatchSucceeded), expected, lo.FromPtr(status.BatchFailed))
+	switch lo.FromPtr(status.State) {
+	case api.FleetRolloutHalted:
+		return "Rollout halted at " + progress
+	case api.FleetRolloutWaiting:
+		return fmt.Sprintf("Waiting until %s to start the next batch after %s", status.NextBatchAt.UTC().Format(time.RFC3339), progress)
+	case api.FleetRolloutCompleted:
+		return "Rollout completed"
+	default:
+		return "Rolling out " + progress
+	}
+}
+
+func parseRolloutDuration(duration *api.Duration) (time.Duration, error) {
+	if duration == nil {
+		return 0, nil
+	}
+	return time.ParseDuration(*duration)
+}
+
+// FleetRolloutProgress advances the batched rollouts in progress, which are otherwise only
+// evaluated when the fleet or its template change.
+type FleetRolloutProgress struct {
+	callbackManager CallbackManager
+	log             logrus.FieldLogger
+	store           store.Store
+}
+
+func NewFleetRolloutProgress(callbackManager CallbackManager, log logrus.FieldLogger, store store.Store) *FleetRolloutProgress {
+	return &FleetRolloutProgress{
+		callbackManager: callbackManager,
+		log:             log,
+		store:           store,
+	}
+}
+
+// Poll rolls out the fleets whose batched rollout is rolling out a batch, or waiting to start the next one.
+func (t *FleetRolloutProgress) Poll() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// TODO: one thread per org?
+	orgID := uuid.UUID{}
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	for {
+		fleets, err := t.store.Fleet().List(ctx, orgID, listParams)
+		if err != nil {
+			t.log.WithError(err).Error("failed to list fleets")
+			return
+		}
+
+		for _, fleet := range fleets.Items {
+			if fleet.Status == nil || fleet.Status.Rollout == nil {
+				continue
+			}
+			state := lo.FromPtr(fleet.Status.Rollout.State)
+			if state != api.FleetRolloutRolling && state != api.FleetRolloutWaiting {
+				continue
+			}
+			ref := ResourceReference{OrgID: orgID, Kind: api.FleetKind, Name: *fleet.Metadata.Name}
+			logic := NewFleetRolloutsLogic(t.callbackManager, t.log, t.store, ref)
+			if err := logic.RolloutFleet(ctx); err != nil {
+				t.log.Errorf("failed advancing the rollout of fleet %s/%s: %v", orgID, *fleet.Metadata.Name, err)
+			}
+		}
+
+		if fleets.Metadata.Continue == nil {
+			return
+		}
+		cont, err := store.ParseContinueString(fleets.Metadata.Continue)
+		if err != nil {
+			t.log.WithError(err).Error("failed to parse continuation for paging")
+			return
+		}
+		listParams.Continue = cont
+	}
+}
@@ -0,0 +1,324 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+type rolloutTestStore struct {
+	store.Store
+	fleet           *rolloutTestFleetStore
+	device          *rolloutTestDeviceStore
+	templateVersion *rolloutTestTemplateVersionStore
+}
+
+func (s *rolloutTestStore) Fleet() store.Fleet                     { return s.fleet }
+func (s *rolloutTestStore) Device() store.Device                   { return s.device }
+func (s *rolloutTestStore) TemplateVersion() store.TemplateVersion { return s.templateVersion }
+
+type rolloutTestFleetStore struct {
+	store.Fleet
+	conditions []api.Condition
+	fleet      api.Fleet
+}
+
+func (s *rolloutTestFleetStore) UpdateConditions(ctx context.Context, orgId uuid.UUID, name string, conditions []api.Condition) error {
+	s.conditions = conditions
+	return nil
+}
+
+func (s *rolloutTestFleetStore) Get(ctx context.Context, orgId uuid.UUID, name string, opts ...store.GetOption) (*api.Fleet, error) {
+	fleet := s.fleet
+	return &fleet, nil
+}
+
+func (s *rolloutTestFleetStore) UpdateRolloutStatus(ctx context.Context, orgId uuid.UUID, name string, rollout *api.FleetRolloutStatus) error {
+	if s.fleet.Status == nil {
+		s.fleet.Status = &api.FleetStatus{}
+	}
+	s.fleet.Status.Rollout = rollout
+	return nil
+}
+
+// rolloutTestDeviceStore keeps the devices of the fleet in memory.
+type rolloutTestDeviceStore struct {
+	store.Device
+	devices map[string]*api.Device
+}
+
+func (s *rolloutTestDeviceStore) List(ctx context.Context, orgId uuid.UUID, listParams store.ListParams) (*api.DeviceList, error) {
+	list := &api.DeviceList{}
+	for _, device := range s.devices {
+		list.Items = append(list.Items, *device)
+	}
+	return list, nil
+}
+
+func (s *rolloutTestDeviceStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Device, error) {
+	device := *s.devices[name]
+	return &device, nil
+}
+
+func (s *rolloutTestDeviceStore) Update(ctx context.Context, orgId uuid.UUID, device *api.Device, fieldsToUnset []string, fromAPI bool, callback store.DeviceStoreCallback) (*api.Device, error) {
+	s.devices[*device.Metadata.Name].Spec = device.Spec
+	return device, nil
+}
+
+func (s *rolloutTestDeviceStore) UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error {
+	device := s.devices[name]
+	merged := util.MergeLabels(util.FromPtr(device.Metadata.Annotations), annotations)
+	for _, key := range deleteKeys {
+		delete(merged, key)
+	}
+	device.Metadata.Annotations = &merged
+	return nil
+}
+
+type rolloutTestTemplateVersionStore struct {
+	store.TemplateVersion
+	err error
+}
+
+func (s *rolloutTestTemplateVersionStore) GetLatest(ctx context.Context, orgId uuid.UUID, fleet string) (*api.TemplateVersion, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &api.TemplateVersion{
+		Metadata: api.ObjectMeta{Name: &fleet},
+		Status:   &api.TemplateVersionStatus{Os: &api.DeviceOsSpec{Image: "quay.io/flightctl/os:" + fleet}},
+	}, nil
+}
+
+func newRolloutTestStore(templateVersionErr error) *rolloutTestStore {
+	return &rolloutTestStore{
+		fleet:           &rolloutTestFleetStore{fleet: api.Fleet{Metadata: api.ObjectMeta{Name: lo.ToPtr("fleet")}}},
+		device:          &rolloutTestDeviceStore{devices: map[string]*api.Device{}},
+		templateVersion: &rolloutTestTemplateVersionStore{err: templateVersionErr},
+	}
+}
+
+type rolloutTestCallbackManager struct {
+	CallbackManager
+}
+
+func (c *rolloutTestCallbackManager) DeviceUpdatedCallback(ctx context.Context, before *model.Device, after *model.Device) error {
+	return nil
+}
+
+// newBatchedRolloutTestStore returns a store with a fleet of 5 devices, rolled out in a first
+// batch of 2 devices, and a last batch of the 3 remaining ones.
+func newBatchedRolloutTestStore(t *testing.T) *rolloutTestStore {
+	testStore := newRolloutTestStore(nil)
+	limit := api.Batch_Limit{}
+	require.NoError(t, limit.FromBatchLimit1(2))
+	selection := api.RolloutDeviceSelection{}
+	require.NoError(t, selection.FromBatchSequence(api.BatchSequence{Sequence: &[]api.Batch{{Limit: &limit}}}))
+	testStore.fleet.fleet.Spec.RolloutPolicy = &api.RolloutPolicy{
+		DeviceSelection:     &selection,
+		PauseBetweenBatches: lo.ToPtr("1h"),
+	}
+	for i := 1; i <= 5; i++ {
+		name := fmt.Sprintf("dev-%d", i)
+		status := api.NewDeviceStatus()
+		testStore.device.devices[name] = &api.Device{
+			Metadata: api.ObjectMeta{
+				Name:   lo.ToPtr(name),
+				Owner:  util.SetResourceOwner(api.FleetKind, "fleet"),
+				Labels: &map[string]string{"site": "factory"},
+			},
+			Spec:   &api.DeviceSpec{},
+			Status: &status,
+		}
+	}
+	return testStore
+}
+
+// rolledOut returns the names of the devices the templateVersion was rolled out to.
+func (s *rolloutTestDeviceStore) rolledOut() []string {
+	names := []string{}
+	for name, device := range s.devices {
+		if util.FromPtr(device.Metadata.Annotations)[api.DeviceAnnotationTemplateVersion] == "fleet" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// reportUpdate sets the status the device reports after applying the templateVersion.
+func (s *rolloutTestDeviceStore) reportUpdate(name string, failed bool) {
+	device := s.devices[name]
+	annotations := util.MergeLabels(util.FromPtr(device.Metadata.Annotations), map[string]string{
+		api.DeviceAnnotationRenderedTemplateVersion: "fleet",
+		api.DeviceAnnotationRenderedVersion:         "2",
+	})
+	device.Metadata.Annotations = &annotations
+	status := api.NewDeviceStatus()
+	status.LastSeen = time.Now()
+	status.Config.RenderedVersion = "2"
+	updating := api.Condition{Type: api.DeviceUpdating, Status: api.ConditionStatusFalse, Reason: string(api.UpdateStateUpdated)}
+	if failed {
+		status.Config.RenderedVersion = "1"
+		updating.Reason = string(api.UpdateStateError)
+	}
+	api.SetStatusCondition(&status.Conditions, updating)
+	device.Status = &status
+}
+
+func TestFleetRolloutInBatches(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	testStore := newBatchedRolloutTestStore(t)
+	ref := ResourceReference{Op: FleetRolloutOpUpdate, OrgID: store.NullOrgId, Kind: api.FleetKind, Name: "fleet"}
+	logic := NewFleetRolloutsLogic(&rolloutTestCallbackManager{}, log.InitLogs(), testStore, ref)
+
+	updated, err := logic.rolloutFleet(ctx)
+	require.NoError(err)
+	require.Equal(2, updated)
+	require.ElementsMatch([]string{"dev-1", "dev-2"}, testStore.device.rolledOut())
+	rollout := testStore.fleet.fleet.Status.Rollout
+	require.Equal(api.FleetRolloutRolling, *rollout.State)
+	require.Equal(1, *rollout.CurrentBatch)
+	require.Equal(2, *rollout.BatchPending)
+
+	// a device joining the fleet is left to the batches
+	deviceRef := ResourceReference{Op: FleetRolloutOpUpdate, OrgID: store.NullOrgId, Kind: api.DeviceKind, Name: "dev-5"}
+	require.NoError(NewFleetRolloutsLogic(&rolloutTestCallbackManager{}, log.InitLogs(), testStore, deviceRef).RolloutDevice(ctx))
+	require.Len(testStore.device.rolledOut(), 2)
+
+	// the rollout waits once the devices of the first batch updated
+	testStore.device.reportUpdate("dev-1", false)
+	testStore.device.reportUpdate("dev-2", false)
+	updated, err = logic.rolloutFleet(ctx)
+	require.NoError(err)
+	require.Zero(updated)
+	rollout = testStore.fleet.fleet.Status.Rollout
+	require.Equal(api.FleetRolloutWaiting, *rollout.State)
+	require.Equal(2, *rollout.BatchSucceeded)
+	require.True(rollout.NextBatchAt.After(time.Now()))
+
+	// and rolls out to the remaining devices after the pause
+	rollout.NextBatchAt = lo.ToPtr(time.Now().Add(-time.Second))
+	updated, err = logic.rolloutFleet(ctx)
+	require.NoError(err)
+	require.Equal(3, updated)
+	require.Len(testStore.device.rolledOut(), 5)
+	rollout = testStore.fleet.fleet.Status.Rollout
+	require.Equal(api.FleetRolloutRolling, *rollout.State)
+	require.Equal(2, *rollout.CurrentBatch)
+
+	for _, name := range []string{"dev-3", "dev-4", "dev-5"} {
+		testStore.device.reportUpdate(name, false)
+	}
+	updated, err = logic.rolloutFleet(ctx)
+	require.NoError(err)
+	require.Zero(updated)
+	require.Equal(api.FleetRolloutCompleted, *testStore.fleet.fleet.Status.Rollout.State)
+	require.Equal("Rollout completed", *testStore.fleet.fleet.Status.Rollout.Message)
+}
+
+func TestFleetRolloutInBatchesHalts(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	testStore := newBatchedRolloutTestStore(t)
+	ref := ResourceReference{Op: FleetRolloutOpUpdate, OrgID: store.NullOrgId, Kind: api.FleetKind, Name: "fleet"}
+	logic := NewFleetRolloutsLogic(&rolloutTestCallbackManager{}, log.InitLogs(), testStore, ref)
+
+	_, err := logic.rolloutFleet(ctx)
+	require.NoError(err)
+	testStore.device.reportUpdate("dev-1", false)
+	testStore.device.reportUpdate("dev-2", true)
+
+	_, err = logic.rolloutFleet(ctx)
+	require.NoError(err)
+	rollout := testStore.fleet.fleet.Status.Rollout
+	require.Equal(api.FleetRolloutHalted, *rollout.State)
+	require.Equal(1, *rollout.BatchFailed)
+	require.Contains(*rollout.Message, "halted")
+
+	// the rollout stays halted
+	_, err = logic.rolloutFleet(ctx)
+	require.NoError(err)
+	require.ElementsMatch([]string{"dev-1", "dev-2"}, testStore.device.rolledOut())
+}
+
+func TestRolloutBatchSize(t *testing.T) {
+	require := require.New(t)
+	count := api.Batch_Limit{}
+	require.NoError(count.FromBatchLimit1(2))
+	percentage := api.Batch_Limit{}
+	require.NoError(percentage.FromPercentage("25%"))
+
+	tests := []struct {
+		limit    *api.Batch_Limit
+		selected int
+		size     int
+	}{
+		{nil, 7, 7},
+		{&count, 7, 2},
+		{&count, 1, 1},
+		{&percentage, 7, 2},
+		{&percentage, 0, 0},
+	}
+	for _, tt := range tests {
+		size, err := rolloutBatch{limit: tt.limit}.size(tt.selected)
+		require.NoError(err)
+		require.Equal(tt.size, size)
+	}
+}
+
+func TestFleetRolloutReconcileFailure(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	ref := &ResourceReference{Op: FleetRolloutOpUpdate, OrgID: store.NullOrgId, Kind: api.FleetKind, Name: "fleet"}
+	testStore := newRolloutTestStore(errors.New("database is down"))
+	reconciles := NewFleetReconcileMetrics()
+
+	err := fleetRollout(ctx, ref, testStore, nil, reconciles, log.InitLogs())
+	require.ErrorContains(err, "database is down")
+
+	require.Len(testStore.fleet.conditions, 1)
+	condition := testStore.fleet.conditions[0]
+	require.Equal(api.FleetReconciled, condition.Type)
+	require.Equal(api.ConditionStatusFalse, condition.Status)
+	require.Equal("ReconcileFailed", condition.Reason)
+	require.Contains(condition.Message, "database is down")
+
+	require.Equal(1.0, testutil.ToFloat64(reconciles.reconciles.WithLabelValues(ref.OrgID.String(), "fleet", ReconcileResultError)))
+	require.Equal(0.0, testutil.ToFloat64(reconciles.reconciles.WithLabelValues(ref.OrgID.String(), "fleet", ReconcileResultSuccess)))
+
+	// The next successful rollout clears the error
+	testStore.templateVersion.err = nil
+	err = fleetRollout(ctx, ref, testStore, nil, reconciles, log.InitLogs())
+	require.NoError(err)
+
+	require.Len(testStore.fleet.conditions, 1)
+	condition = testStore.fleet.conditions[0]
+	require.Equal(api.ConditionStatusTrue, condition.Status)
+	require.Empty(condition.Message)
+
+	// No device needed an update
+	require.Equal(1.0, testutil.ToFloat64(reconciles.reconciles.WithLabelValues(ref.OrgID.String(), "fleet", ReconcileResultSkip)))
+}
+
+func TestReconcileResult(t *testing.T) {
+	require := require.New(t)
+	require.Equal(ReconcileResultError, reconcileResult(2, errors.New("failed updating 1 devices")))
+	require.Equal(ReconcileResultSkip, reconcileResult(0, nil))
+	require.Equal(ReconcileResultSuccess, reconcileResult(2, nil))
+
+	var reconciles *FleetReconcileMetrics
+	reconciles.Record(&ResourceReference{Name: "fleet"}, 0, nil)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/go-git/go-billy/v5"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
 	gitplumbing "github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	gitclient "github.com/go-git/go-git/v5/plumbing/transport/client"
@@ -88,6 +89,36 @@ func CloneGitRepo(repo *model.Repository, revision *string, depth *int) (billy.F
 	return mfs, hash, nil
 }
 
+// a function to list the tags of a git repo, for mockable unit testing
+type listGitRepoTagsFunc func(repo *model.Repository) ([]string, error)
+
+// ListGitRepoTags returns the names of the tags of the repository, without cloning it.
+func ListGitRepoTags(repo *model.Repository) ([]string, error) {
+	if repo.Spec == nil {
+		return nil, fmt.Errorf("repository has no spec")
+	}
+	repoURL, err := repo.Spec.Data.GetRepoURL()
+	if err != nil {
+		return nil, err
+	}
+	auth, err := GetAuth(repo)
+	if err != nil {
+		return nil, err
+	}
+	remote := git.NewRemote(gitmemory.NewStorage(), &gitconfig.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{repoURL}})
+	refs, err := remote.List(&git.ListOptions{Auth: auth})
+	if err != nil {
+		return nil, fmt.Errorf("failed listing git repo references: %w", err)
+	}
+	tags := []string{}
+	for _, ref := range refs {
+		if ref.Name().IsTag() {
+			tags = append(tags, ref.Name().Short())
+		}
+	}
+	return tags, nil
+}
+
 // Read repository's ssh/http config and create transport.AuthMethod.
 // If no ssh/http config is defined a nil is returned.
 func GetAuth(repository *model.Repository) (transport.AuthMethod, error) {
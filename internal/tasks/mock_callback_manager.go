@@ -10,6 +10,7 @@
 package tasks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	model "github.com/flightctl/flightctl/internal/store/model"
@@ -41,109 +42,127 @@ func (m *MockCallbackManager) EXPECT() *MockCallbackManagerMockRecorder {
 }
 
 // AllDevicesDeletedCallback mocks base method.
-func (m *MockCallbackManager) AllDevicesDeletedCallback(orgId uuid.UUID) {
+func (m *MockCallbackManager) AllDevicesDeletedCallback(ctx context.Context, orgId uuid.UUID) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AllDevicesDeletedCallback", orgId)
+	ret := m.ctrl.Call(m, "AllDevicesDeletedCallback", ctx, orgId)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // AllDevicesDeletedCallback indicates an expected call of AllDevicesDeletedCallback.
-func (mr *MockCallbackManagerMockRecorder) AllDevicesDeletedCallback(orgId any) *gomock.Call {
+func (mr *MockCallbackManagerMockRecorder) AllDevicesDeletedCallback(ctx, orgId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllDevicesDeletedCallback", reflect.TypeOf((*MockCallbackManager)(nil).AllDevicesDeletedCallback), orgId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllDevicesDeletedCallback", reflect.TypeOf((*MockCallbackManager)(nil).AllDevicesDeletedCallback), ctx, orgId)
 }
 
 // AllFleetsDeletedCallback mocks base method.
-func (m *MockCallbackManager) AllFleetsDeletedCallback(orgId uuid.UUID) {
+func (m *MockCallbackManager) AllFleetsDeletedCallback(ctx context.Context, orgId uuid.UUID) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AllFleetsDeletedCallback", orgId)
+	ret := m.ctrl.Call(m, "AllFleetsDeletedCallback", ctx, orgId)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // AllFleetsDeletedCallback indicates an expected call of AllFleetsDeletedCallback.
-func (mr *MockCallbackManagerMockRecorder) AllFleetsDeletedCallback(orgId any) *gomock.Call {
+func (mr *MockCallbackManagerMockRecorder) AllFleetsDeletedCallback(ctx, orgId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllFleetsDeletedCallback", reflect.TypeOf((*MockCallbackManager)(nil).AllFleetsDeletedCallback), orgId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllFleetsDeletedCallback", reflect.TypeOf((*MockCallbackManager)(nil).AllFleetsDeletedCallback), ctx, orgId)
 }
 
 // AllRepositoriesDeletedCallback mocks base method.
-func (m *MockCallbackManager) AllRepositoriesDeletedCallback(orgId uuid.UUID) {
+func (m *MockCallbackManager) AllRepositoriesDeletedCallback(ctx context.Context, orgId uuid.UUID) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AllRepositoriesDeletedCallback", orgId)
+	ret := m.ctrl.Call(m, "AllRepositoriesDeletedCallback", ctx, orgId)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // AllRepositoriesDeletedCallback indicates an expected call of AllRepositoriesDeletedCallback.
-func (mr *MockCallbackManagerMockRecorder) AllRepositoriesDeletedCallback(orgId any) *gomock.Call {
+func (mr *MockCallbackManagerMockRecorder) AllRepositoriesDeletedCallback(ctx, orgId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllRepositoriesDeletedCallback", reflect.TypeOf((*MockCallbackManager)(nil).AllRepositoriesDeletedCallback), orgId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllRepositoriesDeletedCallback", reflect.TypeOf((*MockCallbackManager)(nil).AllRepositoriesDeletedCallback), ctx, orgId)
 }
 
 // DeviceSourceUpdated mocks base method.
-func (m *MockCallbackManager) DeviceSourceUpdated(orgId uuid.UUID, name string) {
+func (m *MockCallbackManager) DeviceSourceUpdated(ctx context.Context, orgId uuid.UUID, name string) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "DeviceSourceUpdated", orgId, name)
+	ret := m.ctrl.Call(m, "DeviceSourceUpdated", ctx, orgId, name)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // DeviceSourceUpdated indicates an expected call of DeviceSourceUpdated.
-func (mr *MockCallbackManagerMockRecorder) DeviceSourceUpdated(orgId, name any) *gomock.Call {
+func (mr *MockCallbackManagerMockRecorder) DeviceSourceUpdated(ctx, orgId, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeviceSourceUpdated", reflect.TypeOf((*MockCallbackManager)(nil).DeviceSourceUpdated), orgId, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeviceSourceUpdated", reflect.TypeOf((*MockCallbackManager)(nil).DeviceSourceUpdated), ctx, orgId, name)
 }
 
 // DeviceUpdatedCallback mocks base method.
-func (m *MockCallbackManager) DeviceUpdatedCallback(before, after *model.Device) {
+func (m *MockCallbackManager) DeviceUpdatedCallback(ctx context.Context, before, after *model.Device) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "DeviceUpdatedCallback", before, after)
+	ret := m.ctrl.Call(m, "DeviceUpdatedCallback", ctx, before, after)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // DeviceUpdatedCallback indicates an expected call of DeviceUpdatedCallback.
-func (mr *MockCallbackManagerMockRecorder) DeviceUpdatedCallback(before, after any) *gomock.Call {
+func (mr *MockCallbackManagerMockRecorder) DeviceUpdatedCallback(ctx, before, after any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeviceUpdatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).DeviceUpdatedCallback), before, after)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeviceUpdatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).DeviceUpdatedCallback), ctx, before, after)
 }
 
 // FleetSourceUpdated mocks base method.
-func (m *MockCallbackManager) FleetSourceUpdated(orgId uuid.UUID, name string) {
+func (m *MockCallbackManager) FleetSourceUpdated(ctx context.Context, orgId uuid.UUID, name string) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "FleetSourceUpdated", orgId, name)
+	ret := m.ctrl.Call(m, "FleetSourceUpdated", ctx, orgId, name)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // FleetSourceUpdated indicates an expected call of FleetSourceUpdated.
-func (mr *MockCallbackManagerMockRecorder) FleetSourceUpdated(orgId, name any) *gomock.Call {
+func (mr *MockCallbackManagerMockRecorder) FleetSourceUpdated(ctx, orgId, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetSourceUpdated", reflect.TypeOf((*MockCallbackManager)(nil).FleetSourceUpdated), orgId, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetSourceUpdated", reflect.TypeOf((*MockCallbackManager)(nil).FleetSourceUpdated), ctx, orgId, name)
 }
 
 // FleetUpdatedCallback mocks base method.
-func (m *MockCallbackManager) FleetUpdatedCallback(before, after *model.Fleet) {
+func (m *MockCallbackManager) FleetUpdatedCallback(ctx context.Context, before, after *model.Fleet) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "FleetUpdatedCallback", before, after)
+	ret := m.ctrl.Call(m, "FleetUpdatedCallback", ctx, before, after)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // FleetUpdatedCallback indicates an expected call of FleetUpdatedCallback.
-func (mr *MockCallbackManagerMockRecorder) FleetUpdatedCallback(before, after any) *gomock.Call {
+func (mr *MockCallbackManagerMockRecorder) FleetUpdatedCallback(ctx, before, after any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetUpdatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).FleetUpdatedCallback), before, after)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetUpdatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).FleetUpdatedCallback), ctx, before, after)
 }
 
 // RepositoryUpdatedCallback mocks base method.
-func (m *MockCallbackManager) RepositoryUpdatedCallback(repository *model.Repository) {
+func (m *MockCallbackManager) RepositoryUpdatedCallback(ctx context.Context, repository *model.Repository) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RepositoryUpdatedCallback", repository)
+	ret := m.ctrl.Call(m, "RepositoryUpdatedCallback", ctx, repository)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // RepositoryUpdatedCallback indicates an expected call of RepositoryUpdatedCallback.
-func (mr *MockCallbackManagerMockRecorder) RepositoryUpdatedCallback(repository any) *gomock.Call {
+func (mr *MockCallbackManagerMockRecorder) RepositoryUpdatedCallback(ctx, repository any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepositoryUpdatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).RepositoryUpdatedCallback), repository)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepositoryUpdatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).RepositoryUpdatedCallback), ctx, repository)
 }
 
 // TemplateVersionCreatedCallback mocks base method.
-func (m *MockCallbackManager) TemplateVersionCreatedCallback(templateVersion *model.TemplateVersion) {
+func (m *MockCallbackManager) TemplateVersionCreatedCallback(ctx context.Context, templateVersion *model.TemplateVersion) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "TemplateVersionCreatedCallback", templateVersion)
+	ret := m.ctrl.Call(m, "TemplateVersionCreatedCallback", ctx, templateVersion)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // TemplateVersionCreatedCallback indicates an expected call of TemplateVersionCreatedCallback.
-func (mr *MockCallbackManagerMockRecorder) TemplateVersionCreatedCallback(templateVersion any) *gomock.Call {
+func (mr *MockCallbackManagerMockRecorder) TemplateVersionCreatedCallback(ctx, templateVersion any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateVersionCreatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).TemplateVersionCreatedCallback), templateVersion)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateVersionCreatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).TemplateVersionCreatedCallback), ctx, templateVersion)
 }
@@ -0,0 +1,71 @@
+package tasks
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// OrphanCleanupPollingInterval is the interval at which orphaned rows are looked for.
+	OrphanCleanupPollingInterval = 1 * time.Hour
+)
+
+type OrphanCleanup struct {
+	log   logrus.FieldLogger
+	store store.Store
+	delay time.Duration
+	now   func() time.Time
+}
+
+// NewOrphanCleanup returns a task deleting the rows left behind by deleted resources, once they
+// have not changed for the given delay. The delay leaves time to operations still in flight, and
+// to operators to notice the reported orphans before they are deleted.
+func NewOrphanCleanup(log logrus.FieldLogger, store store.Store, delay time.Duration) *OrphanCleanup {
+	return &OrphanCleanup{
+		log:   log,
+		store: store,
+		delay: delay,
+		now:   time.Now,
+	}
+}
+
+// Poll reports the orphaned rows and deletes the ones older than the delay.
+func (t *OrphanCleanup) Poll() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// TODO: one thread per org?
+	orgID := uuid.UUID{}
+	found, err := t.store.Orphans().Count(ctx, orgID)
+	if err != nil {
+		t.log.WithError(err).Error("failed to count orphaned rows")
+		return
+	}
+	total := int64(0)
+	for _, count := range found {
+		total += count
+	}
+	if total == 0 {
+		return
+	}
+
+	deleted, err := t.store.Orphans().Delete(ctx, orgID, t.now().Add(-t.delay))
+	if err != nil {
+		t.log.WithError(err).Error("failed to delete orphaned rows")
+	}
+	kinds := make([]string, 0, len(found))
+	for kind := range found {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		if found[kind] > 0 {
+			t.log.Infof("Found %d orphaned %s, deleted %d unchanged for %s", found[kind], kind, deleted[kind], t.delay)
+		}
+	}
+}
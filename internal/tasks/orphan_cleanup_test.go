@@ -0,0 +1,69 @@
+package tasks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type orphanTestStore struct {
+	store.Store
+	orphans *orphanTestOrphansStore
+}
+
+func (s *orphanTestStore) Orphans() store.Orphans { return s.orphans }
+
+// orphanTestOrphansStore holds orphaned rows by kind, as the time they last changed.
+type orphanTestOrphansStore struct {
+	rows map[string][]time.Time
+}
+
+func (s *orphanTestOrphansStore) Count(ctx context.Context, orgId uuid.UUID) (map[string]int64, error) {
+	counts := map[string]int64{}
+	for kind, rows := range s.rows {
+		counts[kind] = int64(len(rows))
+	}
+	return counts, nil
+}
+
+func (s *orphanTestOrphansStore) Delete(ctx context.Context, orgId uuid.UUID, olderThan time.Time) (map[string]int64, error) {
+	deleted := map[string]int64{}
+	for kind, rows := range s.rows {
+		kept := []time.Time{}
+		for _, updatedAt := range rows {
+			if updatedAt.Before(olderThan) {
+				deleted[kind]++
+			} else {
+				kept = append(kept, updatedAt)
+			}
+		}
+		s.rows[kind] = kept
+	}
+	return deleted, nil
+}
+
+func TestOrphanCleanup(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	orphans := &orphanTestOrphansStore{rows: map[string][]time.Time{
+		store.OrphanedDeviceLogs:         {now.Add(-48 * time.Hour), now.Add(-time.Hour)},
+		store.OrphanedEnrollmentRequests: {now.Add(-25 * time.Hour)},
+	}}
+
+	cleanup := NewOrphanCleanup(log.InitLogs(), &orphanTestStore{orphans: orphans}, 24*time.Hour)
+	cleanup.now = func() time.Time { return now }
+	cleanup.Poll()
+
+	// orphans are only deleted once the safety delay elapsed
+	require.Equal([]time.Time{now.Add(-time.Hour)}, orphans.rows[store.OrphanedDeviceLogs])
+	require.Empty(orphans.rows[store.OrphanedEnrollmentRequests])
+
+	cleanup.now = func() time.Time { return now.Add(24 * time.Hour) }
+	cleanup.Poll()
+	require.Empty(orphans.rows[store.OrphanedDeviceLogs])
+}
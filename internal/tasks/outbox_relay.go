@@ -0,0 +1,62 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/pkg/queues"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// OutboxRelayInterval is the interval at which the committed tasks are published.
+	OutboxRelayInterval = time.Second
+
+	// OutboxRelayBatchSize is the number of tasks published in a single transaction.
+	OutboxRelayBatchSize = 100
+
+	// OutboxRetention is how long the published tasks are kept in the outbox.
+	OutboxRetention = time.Hour
+)
+
+// OutboxRelay publishes to the task queue the tasks that were enqueued in the outbox by committed
+// writes, including the ones whose publication was interrupted by a restart.
+type OutboxRelay struct {
+	log       logrus.FieldLogger
+	outbox    store.Outbox
+	publisher queues.Publisher
+}
+
+func NewOutboxRelay(log logrus.FieldLogger, outbox store.Outbox, publisher queues.Publisher) *OutboxRelay {
+	return &OutboxRelay{
+		log:       log,
+		outbox:    outbox,
+		publisher: publisher,
+	}
+}
+
+// Poll publishes the pending tasks and deletes the ones published more than OutboxRetention ago.
+func (r *OutboxRelay) Poll() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := r.Relay(ctx); err != nil {
+		r.log.WithError(err).Error("failed to relay outbox tasks")
+	}
+	if _, err := r.outbox.DeleteSent(ctx, time.Now().Add(-OutboxRetention)); err != nil {
+		r.log.WithError(err).Error("failed to delete sent outbox tasks")
+	}
+}
+
+// Relay publishes the pending tasks in batches and returns the number of tasks published.
+func (r *OutboxRelay) Relay(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		published, err := r.outbox.Relay(ctx, TaskQueue, OutboxRelayBatchSize, r.publisher.Publish)
+		total += published
+		if err != nil || published < OutboxRelayBatchSize {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type failingPublisher struct{}
+
+func (p *failingPublisher) Publish(payload []byte) error {
+	return errors.New("connection refused")
+}
+
+func (p *failingPublisher) Close() {}
+
+func TestOutboxRelayRecoversAfterCrash(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	log := flightlog.InitLogs()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "outbox.db")), &gorm.Config{})
+	require.NoError(err)
+	outbox := store.NewOutbox(db, log)
+	require.NoError(outbox.InitialMigration())
+
+	// the write is committed together with its tasks, then the process stops before the relay
+	// publishes them
+	orgId := uuid.New()
+	device := &model.Device{
+		Resource: model.Resource{OrgID: orgId, Name: "mydevice"},
+		Spec:     model.MakeJSONField(api.DeviceSpec{Os: &api.DeviceOsSpec{Image: "os1"}}),
+	}
+	require.NoError(NewOutboxCallbackManager(outbox, log).DeviceUpdatedCallback(ctx, nil, device))
+
+	// the relay of the restarted process cannot reach the queue at first
+	published, err := NewOutboxRelay(log, outbox, &failingPublisher{}).Relay(ctx)
+	require.Error(err)
+	require.Zero(published)
+
+	// the task is published once the queue is back
+	publisher := &MockPublisher{}
+	relay := NewOutboxRelay(log, outbox, publisher)
+	published, err = relay.Relay(ctx)
+	require.NoError(err)
+	require.Equal(1, published)
+	require.Len(publisher.publishedResources, 1)
+	resource := publisher.publishedResources[0]
+	require.Equal(DeviceRenderTask, resource.TaskName)
+	require.Equal(DeviceRenderOpUpdate, resource.Op)
+	require.Equal(ResourceReference{OrgID: orgId, Kind: api.DeviceKind, Name: "mydevice"}, ResourceReference{OrgID: resource.OrgID, Kind: resource.Kind, Name: resource.Name})
+
+	// and only once
+	published, err = relay.Relay(ctx)
+	require.NoError(err)
+	require.Zero(published)
+	require.Len(publisher.publishedResources, 1)
+}
+
+func TestOutboxCallbackFailureRollsBackWrite(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	log := flightlog.InitLogs()
+
+	// the outbox table is missing, so that the tasks of the write cannot be enqueued
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "store.db")), &gorm.Config{IgnoreRelationshipsWhenMigrating: true})
+	require.NoError(err)
+	require.NoError(db.AutoMigrate(&model.Device{}, &model.DeviceLogs{}, &model.ResourceRevision{}))
+	st := store.NewStore(db, log)
+	callbackManager := NewOutboxCallbackManager(st.Outbox(), log)
+
+	orgId := uuid.New()
+	device := &api.Device{
+		Metadata: api.ObjectMeta{Name: lo.ToPtr("mydevice")},
+		Spec:     &api.DeviceSpec{Os: &api.DeviceOsSpec{Image: "os1"}},
+	}
+	_, err = st.Device().Create(ctx, orgId, device, callbackManager.DeviceUpdatedCallback)
+	require.ErrorContains(err, "failed to enqueue task "+DeviceRenderTask)
+
+	// the device is not committed without its tasks
+	_, err = st.Device().Get(ctx, orgId, "mydevice")
+	require.ErrorIs(err, flterrors.ErrResourceNotFound)
+}
@@ -47,7 +47,9 @@ func (t *RepositoryUpdateLogic) HandleRepositoryUpdate(ctx context.Context) erro
 	}
 
 	for _, fleet := range fleets.Items {
-		t.callbackManager.FleetSourceUpdated(t.resourceRef.OrgID, *fleet.Metadata.Name)
+		if err := t.callbackManager.FleetSourceUpdated(ctx, t.resourceRef.OrgID, *fleet.Metadata.Name); err != nil {
+			return fmt.Errorf("updating fleet %s: %w", *fleet.Metadata.Name, err)
+		}
 	}
 
 	devices, err := t.store.Repository().GetDeviceRefs(ctx, t.resourceRef.OrgID, t.resourceRef.Name)
@@ -56,7 +58,9 @@ func (t *RepositoryUpdateLogic) HandleRepositoryUpdate(ctx context.Context) erro
 	}
 
 	for _, device := range devices.Items {
-		t.callbackManager.DeviceSourceUpdated(t.resourceRef.OrgID, *device.Metadata.Name)
+		if err := t.callbackManager.DeviceSourceUpdated(ctx, t.resourceRef.OrgID, *device.Metadata.Name); err != nil {
+			return fmt.Errorf("updating device %s: %w", *device.Metadata.Name, err)
+		}
 	}
 
 	return nil
@@ -76,7 +80,9 @@ func (t *RepositoryUpdateLogic) HandleAllRepositoriesDeleted(ctx context.Context
 		}
 
 		if hasReference {
-			t.callbackManager.FleetSourceUpdated(t.resourceRef.OrgID, *fleet.Metadata.Name)
+			if err := t.callbackManager.FleetSourceUpdated(ctx, t.resourceRef.OrgID, *fleet.Metadata.Name); err != nil {
+				return fmt.Errorf("updating fleet %s: %w", *fleet.Metadata.Name, err)
+			}
 		}
 	}
 
@@ -93,7 +99,9 @@ func (t *RepositoryUpdateLogic) HandleAllRepositoriesDeleted(ctx context.Context
 		}
 
 		if hasReference {
-			t.callbackManager.DeviceSourceUpdated(t.resourceRef.OrgID, *device.Metadata.Name)
+			if err := t.callbackManager.DeviceSourceUpdated(ctx, t.resourceRef.OrgID, *device.Metadata.Name); err != nil {
+				return fmt.Errorf("updating device %s: %w", *device.Metadata.Name, err)
+			}
 		}
 	}
 
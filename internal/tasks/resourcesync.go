@@ -1,14 +1,21 @@
 package tasks
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/coreos/go-semver/semver"
+	jsonpatch "github.com/evanphx/json-patch"
 	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/admission"
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/service/common"
 	"github.com/flightctl/flightctl/internal/store"
@@ -19,6 +26,7 @@ import (
 	"github.com/flightctl/flightctl/pkg/reqid"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-git/go-billy/v5"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
 )
@@ -27,18 +35,27 @@ type ResourceSync struct {
 	log             logrus.FieldLogger
 	store           store.Store
 	callbackManager CallbackManager
+	// admission validates the fleets before they are applied, as for the fleets of the API
+	admission       admission.Validator
+	listGitRepoTags listGitRepoTagsFunc
+	// synced holds the fleets synced by each resourcesync, by syncedFleetsKey
+	synced map[string]syncedFleets
 }
 
 type genericResourceMap map[string]interface{}
 
 var validFileExtensions = []string{"json", "yaml", "yml"}
+var kustomizationFileNames = []string{"kustomization.yaml", "kustomization.yml"}
 var supportedResources = []string{api.FleetKind}
 
-func NewResourceSync(callbackManager CallbackManager, store store.Store, log logrus.FieldLogger) *ResourceSync {
+func NewResourceSync(callbackManager CallbackManager, store store.Store, log logrus.FieldLogger, admission admission.Validator) *ResourceSync {
 	return &ResourceSync{
 		log:             log,
 		store:           store,
 		callbackManager: callbackManager,
+		admission:       admission,
+		listGitRepoTags: ListGitRepoTags,
+		synced:          map[string]syncedFleets{},
 	}
 }
 
@@ -56,10 +73,18 @@ func (r *ResourceSync) Poll() {
 		return
 	}
 
+	keys := map[string]bool{}
 	for i := range resourcesyncs {
 		rs := &resourcesyncs[i]
+		keys[syncedFleetsKey(rs)] = true
 		_ = r.run(ctx, log, rs)
 	}
+	// forget the fleets of the deleted resourcesyncs
+	for key := range r.synced {
+		if !keys[key] {
+			delete(r.synced, key)
+		}
+	}
 }
 
 func (r *ResourceSync) run(ctx context.Context, log logrus.FieldLogger, rs *model.ResourceSync) error {
@@ -72,16 +97,23 @@ func (r *ResourceSync) run(ctx context.Context, log logrus.FieldLogger, rs *mode
 		return err
 	}
 	rs.AddRepoNotFoundCondition(nil)
-	resources, err := r.parseAndValidateResources(rs, repo, CloneGitRepo)
-	if err != nil {
-		log.Errorf("resourcesync/%s: parsing failed. error: %s", rs.Name, err.Error())
-		return err
+	resources, parseErr := r.parseAndValidateResources(rs, repo, CloneGitRepo)
+	if parseErr != nil {
+		if resources == nil {
+			log.Errorf("resourcesync/%s: parsing failed. error: %s", rs.Name, parseErr.Error())
+			return parseErr
+		}
+		log.Warnf("resourcesync/%s: skipping invalid resource documents. error: %s", rs.Name, parseErr.Error())
 	}
 	if resources == nil {
-		// No resources to sync
+		// No new commits to sync, check that the synced resources were not changed since
+		if err := r.detectDrift(ctx, log, rs, repo, CloneGitRepo); err != nil {
+			log.Warnf("resourcesync/%s: failed to detect drift. error: %s", rs.Name, err.Error())
+		}
 		return nil
 	}
 
+	delete(r.synced, syncedFleetsKey(rs))
 	owner := util.SetResourceOwner(api.ResourceSyncKind, rs.Name)
 	fleets, err := r.parseFleets(resources, owner)
 	if err != nil {
@@ -90,38 +122,27 @@ func (r *ResourceSync) run(ctx context.Context, log logrus.FieldLogger, rs *mode
 		rs.AddResourceParsedCondition(err)
 		return err
 	}
-	rs.AddResourceParsedCondition(nil)
+	rs.AddResourceParsedCondition(parseErr)
 
-	fleetsPreOwned := make([]api.Fleet, 0)
-
-	fs, err := selector.NewFieldSelectorFromMap(map[string]string{"metadata.owner": *owner}, false)
+	fleetsPreOwned, err := r.listOwnedFleets(ctx, rs, owner)
 	if err != nil {
+		log.Errorf("%e", err)
 		return err
 	}
 
-	listParams := store.ListParams{
-		Limit:         100,
-		FieldSelector: fs,
-	}
-	for {
-		listRes, err := r.store.Fleet().List(ctx, rs.OrgID, listParams)
-		if err != nil {
-			err := fmt.Errorf("resourcesync/%s: failed to list owned fleets. error: %w", rs.Name, err)
-			log.Errorf("%e", err)
-			return err
-		}
-		fleetsPreOwned = append(fleetsPreOwned, listRes.Items...)
-		if listRes.Metadata.Continue == nil {
-			break
-		}
-		cont, err := store.ParseContinueString(listRes.Metadata.Continue)
-		if err != nil {
-			return fmt.Errorf("resourcesync/%s: failed to parse continuation for paging: %w", rs.Name, err)
-		}
-		listParams.Continue = cont
+	fleetsToRemove := fleetsDelta(fleetsPreOwned, fleets)
+	if parseErr != nil && len(fleetsToRemove) > 0 {
+		// A fleet missing from the parsed resources may be defined in an invalid document
+		r.log.Infof("resourcesync/%s: not removing #%d fleets while resource documents are invalid", rs.Name, len(fleetsToRemove))
+		fleetsToRemove = nil
 	}
 
-	fleetsToRemove := fleetsDelta(fleetsPreOwned, fleets)
+	if err := r.admit(ctx, fleetsPreOwned, fleets); err != nil {
+		err = fmt.Errorf("resourcesync/%s: %w", rs.Name, err)
+		log.Errorf("%s", err.Error())
+		rs.AddSyncedCondition(err)
+		return err
+	}
 
 	r.log.Infof("resourcesync/%s: applying #%d fleets ", rs.Name, len(fleets))
 	err = r.store.Fleet().CreateOrUpdateMultiple(ctx, rs.OrgID, r.callbackManager.FleetUpdatedCallback, fleets...)
@@ -143,10 +164,72 @@ func (r *ResourceSync) run(ctx context.Context, log logrus.FieldLogger, rs *mode
 		return err
 	}
 	rs.Status.Data.ObservedGeneration = rs.Generation
+	rs.SetDrift(nil, false)
+	r.setSyncedFleets(rs, lo.FromPtr(rs.Status.Data.ObservedCommit), fleets)
 	r.log.Infof("resourcesync/%s: #%d fleets applied successfully\n", rs.Name, len(fleets))
 	return nil
 }
 
+func (r *ResourceSync) listOwnedFleets(ctx context.Context, rs *model.ResourceSync, owner *string) ([]api.Fleet, error) {
+	fleets := make([]api.Fleet, 0)
+
+	fs, err := selector.NewFieldSelectorFromMap(map[string]string{"metadata.owner": *owner}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	listParams := store.ListParams{
+		Limit:         100,
+		FieldSelector: fs,
+	}
+	for {
+		listRes, err := r.store.Fleet().List(ctx, rs.OrgID, listParams)
+		if err != nil {
+			return nil, fmt.Errorf("resourcesync/%s: failed to list owned fleets. error: %w", rs.Name, err)
+		}
+		fleets = append(fleets, listRes.Items...)
+		if listRes.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(listRes.Metadata.Continue)
+		if err != nil {
+			return nil, fmt.Errorf("resourcesync/%s: failed to parse continuation for paging: %w", rs.Name, err)
+		}
+		listParams.Continue = cont
+	}
+	return fleets, nil
+}
+
+// admit validates the fleets with the admission validators before they are applied. The fleets
+// owned already are updated and the others created. None of the fleets is applied if any of them
+// is rejected or could not be validated.
+func (r *ResourceSync) admit(ctx context.Context, owned []api.Fleet, fleets []*api.Fleet) error {
+	if r.admission == nil {
+		return nil
+	}
+	ownedNames := map[string]bool{}
+	for _, fleet := range owned {
+		ownedNames[lo.FromPtr(fleet.Metadata.Name)] = true
+	}
+	for _, fleet := range fleets {
+		name := lo.FromPtr(fleet.Metadata.Name)
+		operation := admission.OperationCreate
+		if ownedNames[name] {
+			operation = admission.OperationUpdate
+		}
+		err := r.admission.Validate(ctx, admission.Request{
+			Kind:      api.FleetKind,
+			Name:      name,
+			Operation: operation,
+			Object:    fleet,
+		})
+		if err != nil {
+			return fmt.Errorf("fleet %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
 // Returns a list of names that are no longer present
 func fleetsDelta(owned []api.Fleet, newOwned []*api.Fleet) []string {
 	dfleets := make([]string, 0)
@@ -169,8 +252,17 @@ func fleetsDelta(owned []api.Fleet, newOwned []*api.Fleet) []string {
 
 func (r *ResourceSync) parseAndValidateResources(rs *model.ResourceSync, repo *model.Repository, gitCloneRepo cloneGitRepoFunc) ([]genericResourceMap, error) {
 	path := rs.Spec.Data.Path
-	revision := rs.Spec.Data.TargetRevision
-	mfs, hash, err := gitCloneRepo(repo, &revision, util.IntToPtr(1))
+	revision, depth, err := r.resolveRevision(rs, repo)
+	if err != nil {
+		rs.AddRepoAccessCondition(err)
+		return nil, err
+	}
+	if rs.Spec.Data.GetUpdatePolicy() == api.ResourceSyncUpdatePolicyPinCommit && !rs.NeedsSyncToHash(revision) {
+		// a pinned commit never changes, no need to clone the repository again
+		r.log.Infof("resourcesync/%s: pinned to synced commit %s. skipping", rs.Name, revision)
+		return nil, nil
+	}
+	mfs, hash, err := gitCloneRepo(repo, &revision, depth)
 	if err != nil {
 		// Cant fetch git repo
 		rs.AddRepoAccessCondition(err)
@@ -186,6 +278,7 @@ func (r *ResourceSync) parseAndValidateResources(rs *model.ResourceSync, repo *m
 	rs.AddSyncedCondition(fmt.Errorf("out of sync"))
 
 	rs.Status.Data.ObservedCommit = util.StrToPtr(hash)
+	rs.Status.Data.ObservedRevision = util.StrToPtr(revision)
 
 	// Open files
 	fileInfo, err := mfs.Stat(path)
@@ -195,24 +288,89 @@ func (r *ResourceSync) parseAndValidateResources(rs *model.ResourceSync, repo *m
 		return nil, err
 	}
 	rs.AddPathAccessCondition(nil)
-	var resources []genericResourceMap
-	if fileInfo.IsDir() {
-		resources, err = r.extractResourcesFromDir(mfs, path)
-	} else {
-		resources, err = r.extractResourcesFromFile(mfs, path)
-	}
-	if err != nil {
+	resources, err := r.extractResources(mfs, fileInfo, path)
+	var parseErrs resourceParseErrors
+	if err != nil && (!errors.As(err, &parseErrs) || len(resources) == 0) {
 		// Failed to parse resources
 		rs.AddResourceParsedCondition(err)
 		return nil, err
 
 	}
-	rs.AddResourceParsedCondition(nil)
-	return resources, nil
+	// Invalid documents are reported but don't prevent syncing the valid ones
+	rs.AddResourceParsedCondition(err)
+	return resources, err
+}
+
+// resolveRevision returns the revision to clone according to the update policy of the
+// resourcesync, and the depth of the clone.
+func (r *ResourceSync) resolveRevision(rs *model.ResourceSync, repo *model.Repository) (string, *int, error) {
+	spec := rs.Spec.Data
+	switch spec.GetUpdatePolicy() {
+	case api.ResourceSyncUpdatePolicyPinCommit:
+		// the pinned commit may not be the tip of a branch, so the history is needed to check it out
+		return spec.TargetRevision, nil, nil
+	case api.ResourceSyncUpdatePolicySemverTagRange:
+		tags, err := r.listGitRepoTags(repo)
+		if err != nil {
+			return "", nil, err
+		}
+		tag, err := highestTagInRange(tags, spec.TargetRevision)
+		if err != nil {
+			return "", nil, err
+		}
+		return tag, util.IntToPtr(1), nil
+	default:
+		return spec.TargetRevision, util.IntToPtr(1), nil
+	}
+}
+
+// highestTagInRange returns the tag with the highest semantic version within the range. Tags
+// that are not semantic versions are ignored.
+func highestTagInRange(tags []string, semverRange string) (string, error) {
+	versionRange, err := util.ParseSemverRange(semverRange)
+	if err != nil {
+		return "", err
+	}
+	highestTag := ""
+	var highest *semver.Version
+	for _, tag := range tags {
+		version, err := util.ParseSemver(tag)
+		if err != nil || !versionRange.Contains(*version) {
+			continue
+		}
+		if highest == nil || highest.LessThan(*version) {
+			highest = version
+			highestTag = tag
+		}
+	}
+	if highest == nil {
+		return "", fmt.Errorf("no tag within semantic version range %q", semverRange)
+	}
+	return highestTag, nil
+}
+
+func (r *ResourceSync) extractResources(mfs billy.Filesystem, fileInfo os.FileInfo, path string) ([]genericResourceMap, error) {
+	if fileInfo.IsDir() {
+		return r.extractResourcesFromDir(mfs, path)
+	}
+	return r.extractResourcesFromFile(mfs, path)
 }
 
 func (r *ResourceSync) extractResourcesFromDir(mfs billy.Filesystem, path string) ([]genericResourceMap, error) {
+	return r.extractResourcesFromDirVisited(mfs, path, map[string]bool{})
+}
+
+func (r *ResourceSync) extractResourcesFromDirVisited(mfs billy.Filesystem, path string, visited map[string]bool) ([]genericResourceMap, error) {
+	kustomizationFile, err := findKustomizationFile(mfs, path)
+	if err != nil {
+		return nil, err
+	}
+	if kustomizationFile != "" {
+		return r.extractResourcesFromKustomization(mfs, kustomizationFile, visited)
+	}
+
 	genericResources := []genericResourceMap{}
+	var parseErrs resourceParseErrors
 	files, err := mfs.ReadDir(path)
 	if err != nil {
 		return nil, err
@@ -220,17 +378,21 @@ func (r *ResourceSync) extractResourcesFromDir(mfs billy.Filesystem, path string
 	for _, file := range files {
 		if !file.IsDir() && isValidFile(file.Name()) { // Not going recursively into subfolders
 			resources, err := r.extractResourcesFromFile(mfs, mfs.Join(path, file.Name()))
-			if err != nil {
+			if !parseErrs.add(err) {
 				return nil, err
 			}
 			genericResources = append(genericResources, resources...)
 		}
 	}
-	return genericResources, nil
+	return genericResources, parseErrs.errorOrNil()
 }
 
+// extractResourcesFromFile returns the resources defined in the documents of a (multi-document)
+// YAML or JSON file. Documents that are not valid resource definitions are reported in a
+// resourceParseErrors error, and the resources of the other documents are still returned.
 func (r *ResourceSync) extractResourcesFromFile(mfs billy.Filesystem, path string) ([]genericResourceMap, error) {
 	genericResources := []genericResourceMap{}
+	var parseErrs resourceParseErrors
 
 	file, err := mfs.Open(path)
 	if err != nil {
@@ -238,40 +400,202 @@ func (r *ResourceSync) extractResourcesFromFile(mfs billy.Filesystem, path strin
 		return nil, err
 	}
 	defer file.Close()
-	decoder := yamlutil.NewYAMLOrJSONDecoder(file, 100)
+	reader := yamlutil.NewYAMLReader(bufio.NewReader(file))
 
-	for {
-		var resource genericResourceMap
-		err = decoder.Decode(&resource)
-		if err != nil {
+	for doc := 1; ; doc++ {
+		data, err := reader.Read()
+		if errors.Is(err, io.EOF) {
 			break
 		}
-		kind, kindok := resource["kind"].(string)
-		meta, metaok := resource["metadata"].(map[string]interface{})
-		if !kindok || !metaok {
-			return nil, fmt.Errorf("invalid resource definition at '%s'", path)
+		if err != nil {
+			return nil, err
 		}
-		_, nameok := meta["name"].(string)
-		if !nameok {
-			return nil, fmt.Errorf("invalid resource definition at '%s'. resource name missing", path)
+		resource, err := parseResourceDocument(data)
+		if err != nil {
+			parseErrs = append(parseErrs, fmt.Errorf("invalid resource definition at '%s' (document %d): %w", path, doc, err))
+			continue
 		}
-		isSupportedResource := false
-		for _, supportedResource := range supportedResources {
-			if kind == supportedResource {
-				isSupportedResource = true
-				break
-			}
+		if resource != nil {
+			genericResources = append(genericResources, resource)
 		}
-		if !isSupportedResource {
-			return nil, fmt.Errorf("invalid resource type at '%s'. unsupported kind '%s'", path, kind)
+	}
+	return genericResources, parseErrs.errorOrNil()
+}
+
+// parseResourceDocument parses a single YAML or JSON document. It returns a nil resource for
+// documents that are empty or only contain comments.
+func parseResourceDocument(data []byte) (genericResourceMap, error) {
+	var resource genericResourceMap
+	if err := yamlutil.Unmarshal(data, &resource); err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, nil
+	}
+	kind, kindok := resource["kind"].(string)
+	meta, metaok := resource["metadata"].(map[string]interface{})
+	if !kindok || !metaok {
+		return nil, fmt.Errorf("kind or metadata missing")
+	}
+	_, nameok := meta["name"].(string)
+	if !nameok {
+		return nil, fmt.Errorf("resource name missing")
+	}
+	if !slices.Contains(supportedResources, kind) {
+		return nil, fmt.Errorf("unsupported kind '%s'", kind)
+	}
+	return resource, nil
+}
+
+// kustomization lists the resources of an overlay directory and the patches applied on top of
+// them, in the spirit of a Kustomize kustomization.yaml.
+type kustomization struct {
+	// Resources are files or directories, relative to the kustomization file, to read the base
+	// resources from. Directories may contain a kustomization file themselves.
+	Resources []string `json:"resources,omitempty"`
+	// Patches are files, relative to the kustomization file, whose documents are applied as JSON
+	// merge patches to the resource with the same kind and name.
+	Patches []struct {
+		Path string `json:"path"`
+	} `json:"patches,omitempty"`
+}
+
+func findKustomizationFile(mfs billy.Filesystem, dir string) (string, error) {
+	for _, name := range kustomizationFileNames {
+		path := mfs.Join(dir, name)
+		if _, err := mfs.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
 		}
-		genericResources = append(genericResources, resource)
 	}
-	if !errors.Is(err, io.EOF) {
+	return "", nil
+}
+
+func (r *ResourceSync) extractResourcesFromKustomization(mfs billy.Filesystem, path string, visited map[string]bool) ([]genericResourceMap, error) {
+	if visited[path] {
+		return nil, fmt.Errorf("kustomization '%s' includes itself", path)
+	}
+	visited[path] = true
+	defer delete(visited, path)
+
+	file, err := mfs.Open(path)
+	if err != nil {
 		return nil, err
 	}
-	return genericResources, nil
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	var k kustomization
+	if err := yamlutil.Unmarshal(data, &k); err != nil {
+		return nil, fmt.Errorf("invalid kustomization at '%s': %w", path, err)
+	}
+
+	genericResources := []genericResourceMap{}
+	var parseErrs resourceParseErrors
+	dir := filepath.Dir(path)
+	for _, resourcePath := range k.Resources {
+		resourcePath = mfs.Join(dir, resourcePath)
+		fileInfo, err := mfs.Stat(resourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("kustomization '%s': %w", path, err)
+		}
+		var resources []genericResourceMap
+		if fileInfo.IsDir() {
+			resources, err = r.extractResourcesFromDirVisited(mfs, resourcePath, visited)
+		} else {
+			resources, err = r.extractResourcesFromFile(mfs, resourcePath)
+		}
+		if !parseErrs.add(err) {
+			return nil, err
+		}
+		genericResources = append(genericResources, resources...)
+	}
+
+	for _, patch := range k.Patches {
+		patchPath := mfs.Join(dir, patch.Path)
+		patches, err := r.extractResourcesFromFile(mfs, patchPath)
+		if !parseErrs.add(err) {
+			return nil, err
+		}
+		for _, p := range patches {
+			if err := applyResourcePatch(genericResources, p); err != nil {
+				parseErrs = append(parseErrs, fmt.Errorf("invalid patch at '%s': %w", patchPath, err))
+			}
+		}
+	}
+	return genericResources, parseErrs.errorOrNil()
+}
+
+// applyResourcePatch merges the patch into the resource with the same kind and name.
+func applyResourcePatch(resources []genericResourceMap, patch genericResourceMap) error {
+	kind, name := resourceKindAndName(patch)
+	for i, resource := range resources {
+		if k, n := resourceKindAndName(resource); k != kind || n != name {
+			continue
+		}
+		original, err := json.Marshal(resource)
+		if err != nil {
+			return err
+		}
+		patchData, err := json.Marshal(patch)
+		if err != nil {
+			return err
+		}
+		patched, err := jsonpatch.MergePatch(original, patchData)
+		if err != nil {
+			return fmt.Errorf("patching %s/%s: %w", kind, name, err)
+		}
+		var patchedResource genericResourceMap
+		if err := json.Unmarshal(patched, &patchedResource); err != nil {
+			return err
+		}
+		resources[i] = patchedResource
+		return nil
+	}
+	return fmt.Errorf("no resource %s/%s to patch", kind, name)
+}
+
+func resourceKindAndName(resource genericResourceMap) (string, string) {
+	kind, _ := resource["kind"].(string)
+	meta, _ := resource["metadata"].(map[string]interface{})
+	name, _ := meta["name"].(string)
+	return kind, name
+}
 
+// resourceParseErrors holds the errors of the resource documents that could not be parsed.
+// The resources of the remaining documents are still synced.
+type resourceParseErrors []error
+
+func (e resourceParseErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// add collects the document errors of err. It returns false if err is any other error, which
+// aborts the parsing.
+func (e *resourceParseErrors) add(err error) bool {
+	if err == nil {
+		return true
+	}
+	var parseErrs resourceParseErrors
+	if !errors.As(err, &parseErrs) {
+		return false
+	}
+	*e = append(*e, parseErrs...)
+	return true
+}
+
+func (e resourceParseErrors) errorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
 }
 
 func (r ResourceSync) parseFleets(resources []genericResourceMap, owner *string) ([]*api.Fleet, error) {
@@ -0,0 +1,234 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// detectDrift compares the live state of the fleets synced by the resourcesync with their
+// definition in the last synced commit, and reports the fields that were changed outside of the
+// repository. If the resourcesync auto-corrects drift, the fleets are restored to their definition.
+func (r *ResourceSync) detectDrift(ctx context.Context, log logrus.FieldLogger, rs *model.ResourceSync, repo *model.Repository, gitCloneRepo cloneGitRepoFunc) error {
+	if rs.Status == nil {
+		return nil
+	}
+	revision := lo.FromPtr(rs.Status.Data.ObservedRevision)
+	commit := lo.FromPtr(rs.Status.Data.ObservedCommit)
+	if revision == "" || commit == "" {
+		return nil
+	}
+	fleets, err := r.getSyncedFleets(rs, repo, revision, commit, gitCloneRepo)
+	if err != nil || fleets == nil {
+		return err
+	}
+	owner := util.SetResourceOwner(api.ResourceSyncKind, rs.Name)
+	liveFleets, err := r.listOwnedFleets(ctx, rs, owner)
+	if err != nil {
+		return err
+	}
+
+	drift, err := fleetsDrift(fleets, liveFleets)
+	if err != nil {
+		return err
+	}
+	if len(drift) == 0 || !lo.FromPtr(rs.Spec.Data.AutoCorrectDrift) {
+		if len(drift) > 0 {
+			log.Warnf("resourcesync/%s: #%d fleets differ from commit %s", rs.Name, len(drift), commit)
+		}
+		rs.SetDrift(drift, false)
+		return nil
+	}
+
+	driftedFleets := lo.Filter(fleets, func(fleet *api.Fleet, _ int) bool {
+		return lo.ContainsBy(drift, func(d api.ResourceSyncDrift) bool { return d.Name == *fleet.Metadata.Name })
+	})
+	err = r.store.Fleet().CreateOrUpdateMultiple(ctx, rs.OrgID, r.callbackManager.FleetUpdatedCallback, driftedFleets...)
+	if err != nil {
+		rs.SetDrift(drift, false)
+		return fmt.Errorf("correcting drift: %w", err)
+	}
+	log.Infof("resourcesync/%s: restored #%d fleets to commit %s", rs.Name, len(driftedFleets), commit)
+	rs.SetDrift(drift, true)
+	return nil
+}
+
+// syncedFleets are the fleets parsed from the commit and path that a resourcesync last synced.
+type syncedFleets struct {
+	commit string
+	path   string
+	fleets []*api.Fleet
+}
+
+func syncedFleetsKey(rs *model.ResourceSync) string {
+	return rs.OrgID.String() + "/" + rs.Name
+}
+
+// setSyncedFleets keeps the fleets synced from the commit by the resourcesync, for drift to be
+// detected against them without cloning the repository again.
+func (r *ResourceSync) setSyncedFleets(rs *model.ResourceSync, commit string, fleets []*api.Fleet) {
+	r.synced[syncedFleetsKey(rs)] = syncedFleets{
+		commit: commit,
+		path:   rs.Spec.Data.Path,
+		fleets: fleets,
+	}
+}
+
+// getSyncedFleets returns the fleets defined in the synced commit of the resourcesync. They are
+// only parsed from the repository if they are not known yet, e.g. after a restart, as a pinned
+// commit requires a full clone. It returns nil if the revision moved on since the sync.
+func (r *ResourceSync) getSyncedFleets(rs *model.ResourceSync, repo *model.Repository, revision, commit string, gitCloneRepo cloneGitRepoFunc) ([]*api.Fleet, error) {
+	path := rs.Spec.Data.Path
+	if synced, ok := r.synced[syncedFleetsKey(rs)]; ok && synced.commit == commit && synced.path == path {
+		return synced.fleets, nil
+	}
+
+	depth := util.IntToPtr(1)
+	if revision == commit {
+		// the commit may not be the tip of a branch, so the history is needed to check it out
+		depth = nil
+	}
+	mfs, hash, err := gitCloneRepo(repo, &revision, depth)
+	if err != nil {
+		return nil, err
+	}
+	if hash != commit {
+		// the revision moved on, the drift is detected against the new commit once it is synced
+		return nil, nil
+	}
+
+	fileInfo, err := mfs.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := r.extractResources(mfs, fileInfo, path)
+	var parseErrs resourceParseErrors
+	if err != nil && !errors.As(err, &parseErrs) {
+		return nil, err
+	}
+	fleets, err := r.parseFleets(resources, util.SetResourceOwner(api.ResourceSyncKind, rs.Name))
+	if err != nil {
+		return nil, err
+	}
+	r.setSyncedFleets(rs, commit, fleets)
+	return fleets, nil
+}
+
+// fleetsDrift returns how the live fleets differ from their definitions. Live fleets that are
+// not defined anymore are removed by the next sync, and are not reported.
+func fleetsDrift(fleets []*api.Fleet, liveFleets []api.Fleet) ([]api.ResourceSyncDrift, error) {
+	live := lo.SliceToMap(liveFleets, func(fleet api.Fleet) (string, *api.Fleet) {
+		return lo.FromPtr(fleet.Metadata.Name), &fleet
+	})
+
+	drift := []api.ResourceSyncDrift{}
+	for _, fleet := range fleets {
+		name := lo.FromPtr(fleet.Metadata.Name)
+		liveFleet, found := live[name]
+		if !found {
+			drift = append(drift, api.ResourceSyncDrift{Kind: api.FleetKind, Name: name, Fields: []string{}, Deleted: lo.ToPtr(true)})
+			continue
+		}
+		fields, err := fleetDriftedFields(fleet, liveFleet)
+		if err != nil {
+			return nil, fmt.Errorf("comparing fleet %s: %w", name, err)
+		}
+		if len(fields) > 0 {
+			drift = append(drift, api.ResourceSyncDrift{Kind: api.FleetKind, Name: name, Fields: fields})
+		}
+	}
+	return drift, nil
+}
+
+// fleetDriftedFields returns the paths of the labels and spec fields of the live fleet that
+// differ from its definition. Properties managed by the service are ignored.
+func fleetDriftedFields(fleet *api.Fleet, liveFleet *api.Fleet) ([]string, error) {
+	desired, err := comparableFleet(fleet)
+	if err != nil {
+		return nil, err
+	}
+	live, err := comparableFleet(liveFleet)
+	if err != nil {
+		return nil, err
+	}
+	return diffFields("", desired, live), nil
+}
+
+func comparableFleet(fleet *api.Fleet) (map[string]interface{}, error) {
+	// copy the spec, as the managed properties of the template are cleared
+	buf, err := json.Marshal(fleet.Spec)
+	if err != nil {
+		return nil, err
+	}
+	var spec api.FleetSpec
+	if err := json.Unmarshal(buf, &spec); err != nil {
+		return nil, err
+	}
+	if spec.Template.Metadata != nil {
+		common.NilOutManagedObjectMetaProperties(spec.Template.Metadata)
+	}
+
+	buf, err = json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": fleet.Metadata.Labels},
+		"spec":     spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(buf, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// diffFields returns the dot-separated paths of the values that differ. Objects are compared
+// field by field, any other value as a whole. A missing value equals an empty one.
+func diffFields(path string, desired, live interface{}) []string {
+	desiredMap, desiredIsMap := desired.(map[string]interface{})
+	liveMap, liveIsMap := live.(map[string]interface{})
+	if desiredIsMap && live == nil || liveIsMap && desired == nil {
+		desiredIsMap, liveIsMap = true, true
+	}
+	if !desiredIsMap || !liveIsMap {
+		if reflect.DeepEqual(desired, live) || isEmptyValue(desired) && isEmptyValue(live) {
+			return nil
+		}
+		return []string{path}
+	}
+
+	keys := lo.Union(lo.Keys(desiredMap), lo.Keys(liveMap))
+	slices.Sort(keys)
+	fields := []string{}
+	for _, key := range keys {
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		fields = append(fields, diffFields(fieldPath, desiredMap[key], liveMap[key])...)
+	}
+	return fields
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,145 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func testSyncedFleets(t *testing.T) []*api.Fleet {
+	mfs := memfs.New()
+	writeFile(mfs, "/fleets.yaml", multiDocFleets)
+	rsTask := NewResourceSync(resourceSyncParams(t))
+	resources, err := rsTask.extractResourcesFromFile(mfs, "/fleets.yaml")
+	require.Error(t, err)
+	fleets, err := rsTask.parseFleets(resources, util.SetResourceOwner(api.ResourceSyncKind, "foo"))
+	require.NoError(t, err)
+	require.Len(t, fleets, 2)
+	return fleets
+}
+
+// liveFleet returns the fleet as it is stored by the service
+func liveFleet(t *testing.T, fleet *api.Fleet) api.Fleet {
+	buf, err := json.Marshal(fleet)
+	require.NoError(t, err)
+	var live api.Fleet
+	require.NoError(t, json.Unmarshal(buf, &live))
+	live.Metadata.Generation = util.Int64ToPtr(1)
+	live.Metadata.Annotations = &map[string]string{api.FleetAnnotationTemplateVersion: "1"}
+	live.Spec.Template.Metadata = &api.ObjectMeta{Generation: util.Int64ToPtr(1)}
+	live.Status = &api.FleetStatus{Conditions: []api.Condition{}}
+	return live
+}
+
+func TestFleetsDrift_noDrift(t *testing.T) {
+	require := require.New(t)
+	fleets := testSyncedFleets(t)
+
+	// properties managed by the service are not drift
+	drift, err := fleetsDrift(fleets, []api.Fleet{liveFleet(t, fleets[0]), liveFleet(t, fleets[1])})
+	require.NoError(err)
+	require.Empty(drift)
+}
+
+func TestFleetsDrift(t *testing.T) {
+	require := require.New(t)
+	fleets := testSyncedFleets(t)
+
+	// fleet-1 is edited manually and fleet-2 is deleted
+	live := liveFleet(t, fleets[0])
+	live.Metadata.Labels = &map[string]string{"env": "prod"}
+	live.Spec.Template.Spec.Os = &api.DeviceOsSpec{Image: "quay.io/redhat/rhde:9.3"}
+	live.Spec.Selector.MatchLabels = &map[string]string{"fleet": "fleet-1", "site": "a"}
+	// fleets that are not defined anymore are removed by the next sync
+	unknown := liveFleet(t, fleets[1])
+	unknown.Metadata.Name = lo.ToPtr("fleet-3")
+
+	drift, err := fleetsDrift(fleets, []api.Fleet{live, unknown})
+	require.NoError(err)
+	require.Equal([]api.ResourceSyncDrift{
+		{
+			Kind: api.FleetKind,
+			Name: "fleet-1",
+			Fields: []string{
+				"metadata.labels.env",
+				"spec.selector.matchLabels.site",
+				"spec.template.spec.os.image",
+			},
+		},
+		{
+			Kind:    api.FleetKind,
+			Name:    "fleet-2",
+			Fields:  []string{},
+			Deleted: lo.ToPtr(true),
+		},
+	}, drift)
+}
+
+func TestDiffFields(t *testing.T) {
+	require := require.New(t)
+
+	desired := map[string]interface{}{
+		"a": map[string]interface{}{"b": "x", "c": []interface{}{"1", "2"}},
+		"d": []interface{}{},
+		"e": map[string]interface{}{"f": "y"},
+	}
+	require.Empty(diffFields("", desired, desired))
+
+	live := map[string]interface{}{
+		"a": map[string]interface{}{"b": "x", "c": []interface{}{"2", "1"}},
+		"g": map[string]interface{}{},
+	}
+	require.Equal([]string{"a.c", "e.f"}, diffFields("", desired, live))
+}
+
+func TestDetectDrift_revisionMoved(t *testing.T) {
+	require := require.New(t)
+	rs := testResourceSync()
+	repo, err := testRepo()
+	require.NoError(err)
+	rsTask := NewResourceSync(resourceSyncParams(t))
+	rs.Status.Data.ObservedRevision = lo.ToPtr("main")
+	rs.Status.Data.ObservedCommit = lo.ToPtr("0123456789abcdef0123456789abcdef01234567")
+
+	// the branch moved on since the sync, the fleets are not compared to an unsynced commit
+	moved := "89abcdef0123456789abcdef0123456789abcdef"
+	cloned := []string{}
+	err = rsTask.detectDrift(context.Background(), rsTask.log, &rs, &repo, testCloneFleetGitRepo(&moved, &cloned))
+	require.NoError(err)
+	require.Equal([]string{"main"}, cloned)
+	require.Nil(api.FindStatusCondition(rs.Status.Data.Conditions, api.ResourceSyncDrifted))
+}
+
+func TestGetSyncedFleets(t *testing.T) {
+	require := require.New(t)
+	rs := testResourceSync()
+	rs.Spec.Data.Path = "/examples/fleet.yaml"
+	repo, err := testRepo()
+	require.NoError(err)
+	rsTask := NewResourceSync(resourceSyncParams(t))
+
+	pinned := "0123456789abcdef0123456789abcdef01234567"
+	cloned := []string{}
+	fleets, err := rsTask.getSyncedFleets(&rs, &repo, pinned, pinned, testCloneFleetGitRepo(&pinned, &cloned))
+	require.NoError(err)
+	require.Len(fleets, 1)
+	require.Equal([]string{pinned}, cloned)
+
+	// the fleets of the synced commit are only parsed once
+	cached, err := rsTask.getSyncedFleets(&rs, &repo, pinned, pinned, testCloneFleetGitRepo(&pinned, &cloned))
+	require.NoError(err)
+	require.Equal(fleets, cached)
+	require.Len(cloned, 1)
+
+	// a sync to another commit replaces them
+	rsTask.setSyncedFleets(&rs, "89abcdef0123456789abcdef0123456789abcdef", nil)
+	_, err = rsTask.getSyncedFleets(&rs, &repo, "main", pinned, testCloneFleetGitRepo(&pinned, &cloned))
+	require.NoError(err)
+	require.Equal([]string{pinned, "main"}, cloned)
+}
@@ -1,11 +1,13 @@
 package tasks
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/admission"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
@@ -19,10 +21,10 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
-func resourceSyncParams(t *testing.T) (CallbackManager, store.Store, logrus.FieldLogger) {
+func resourceSyncParams(t *testing.T) (CallbackManager, store.Store, logrus.FieldLogger, admission.Validator) {
 	ctrl := gomock.NewController(t)
 	l := flightlog.InitLogs()
-	return NewCallbackManager(queues.NewMockPublisher(ctrl), l), nil, l
+	return NewCallbackManager(queues.NewMockPublisher(ctrl), l), nil, l, nil
 }
 
 func TestIsValidFile_invalid(t *testing.T) {
@@ -121,6 +123,106 @@ func TestParseAndValidate_singleFile(t *testing.T) {
 	require.Equal(resources[0]["kind"], api.FleetKind)
 }
 
+// testCloneFleetGitRepo returns a clone function for a repo with a single fleet at the given
+// commit, which records the revisions it is asked to clone.
+func testCloneFleetGitRepo(commit *string, cloned *[]string) cloneGitRepoFunc {
+	return func(_ *model.Repository, revision *string, _ *int) (billy.Filesystem, string, error) {
+		*cloned = append(*cloned, *revision)
+		memfs := memfs.New()
+		_ = memfs.MkdirAll("/examples", 0666)
+		writeCopy(memfs, "../../examples/fleet.yaml", "/examples/fleet.yaml")
+		return memfs, *commit, nil
+	}
+}
+
+func TestParseAndValidate_pinCommit(t *testing.T) {
+	require := require.New(t)
+	rs := testResourceSync()
+	repo, err := testRepo()
+	require.NoError(err)
+	rsTask := NewResourceSync(resourceSyncParams(t))
+
+	pinned := "0123456789abcdef0123456789abcdef01234567"
+	rs.Spec.Data.Path = "/examples/fleet.yaml"
+	rs.Spec.Data.TargetRevision = pinned
+	rs.Spec.Data.UpdatePolicy = lo.ToPtr(api.ResourceSyncUpdatePolicyPinCommit)
+
+	cloned := []string{}
+	resources, err := rsTask.parseAndValidateResources(&rs, &repo, testCloneFleetGitRepo(&pinned, &cloned))
+	require.NoError(err)
+	require.Len(resources, 1)
+	require.Equal([]string{pinned}, cloned)
+	require.Equal(pinned, *rs.Status.Data.ObservedCommit)
+	rs.AddSyncedCondition(nil)
+	rs.Status.Data.ObservedGeneration = rs.Generation
+
+	// the pinned commit is synced, the repo is not cloned again
+	resources, err = rsTask.parseAndValidateResources(&rs, &repo, testCloneFleetGitRepo(&pinned, &cloned))
+	require.NoError(err)
+	require.Nil(resources)
+	require.Len(cloned, 1)
+}
+
+func TestParseAndValidate_trackBranch(t *testing.T) {
+	require := require.New(t)
+	rs := testResourceSync()
+	repo, err := testRepo()
+	require.NoError(err)
+	rsTask := NewResourceSync(resourceSyncParams(t))
+
+	rs.Spec.Data.Path = "/examples/fleet.yaml"
+	rs.Spec.Data.TargetRevision = "main"
+
+	commit := gitRepoCommit
+	cloned := []string{}
+	resources, err := rsTask.parseAndValidateResources(&rs, &repo, testCloneFleetGitRepo(&commit, &cloned))
+	require.NoError(err)
+	require.Len(resources, 1)
+	rs.AddSyncedCondition(nil)
+	rs.Status.Data.ObservedGeneration = rs.Generation
+
+	// no new commit on the branch
+	resources, err = rsTask.parseAndValidateResources(&rs, &repo, testCloneFleetGitRepo(&commit, &cloned))
+	require.NoError(err)
+	require.Nil(resources)
+
+	// a new commit on the branch is synced
+	commit = "fedcba987"
+	resources, err = rsTask.parseAndValidateResources(&rs, &repo, testCloneFleetGitRepo(&commit, &cloned))
+	require.NoError(err)
+	require.Len(resources, 1)
+	require.Equal([]string{"main", "main", "main"}, cloned)
+	require.Equal("fedcba987", *rs.Status.Data.ObservedCommit)
+	require.Equal("main", *rs.Status.Data.ObservedRevision)
+}
+
+func TestParseAndValidate_semverTagRange(t *testing.T) {
+	require := require.New(t)
+	rs := testResourceSync()
+	repo, err := testRepo()
+	require.NoError(err)
+	rsTask := NewResourceSync(resourceSyncParams(t))
+	rsTask.listGitRepoTags = func(_ *model.Repository) ([]string, error) {
+		return []string{"v1.1.0", "v1.10.0", "v2.0.0", "latest", "v1.2.0"}, nil
+	}
+
+	rs.Spec.Data.Path = "/examples/fleet.yaml"
+	rs.Spec.Data.TargetRevision = ">=1.2.0 <2.0.0"
+	rs.Spec.Data.UpdatePolicy = lo.ToPtr(api.ResourceSyncUpdatePolicySemverTagRange)
+
+	commit := gitRepoCommit
+	cloned := []string{}
+	resources, err := rsTask.parseAndValidateResources(&rs, &repo, testCloneFleetGitRepo(&commit, &cloned))
+	require.NoError(err)
+	require.Len(resources, 1)
+	require.Equal([]string{"v1.10.0"}, cloned)
+	require.Equal("v1.10.0", *rs.Status.Data.ObservedRevision)
+
+	rs.Spec.Data.TargetRevision = ">=3.0.0"
+	_, err = rsTask.parseAndValidateResources(&rs, &repo, testCloneFleetGitRepo(&commit, &cloned))
+	require.ErrorContains(err, "no tag within semantic version range")
+}
+
 func TestExtractResourceFromFile(t *testing.T) {
 	require := require.New(t)
 
@@ -165,6 +267,165 @@ func TestExtractResourceFromFile_incompatible(t *testing.T) {
 	require.Error(err)
 }
 
+func writeFile(fs billy.Filesystem, path, contents string) {
+	f, err := fs.Create(path)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+	if _, err := f.Write([]byte(contents)); err != nil {
+		panic(err)
+	}
+}
+
+const multiDocFleets = `apiVersion: v1alpha1
+kind: Fleet
+metadata:
+  name: fleet-1
+spec:
+  selector:
+    matchLabels:
+      fleet: fleet-1
+  template:
+    spec:
+      os:
+        image: quay.io/redhat/rhde:9.2
+---
+apiVersion: v1alpha1
+kind: Fleet
+metadata: {}
+---
+# only a comment
+---
+apiVersion: v1alpha1
+kind: Fleet
+metadata:
+  name: fleet-2
+spec:
+  selector:
+    matchLabels:
+      fleet: fleet-2
+  template:
+    spec:
+      os:
+        image: quay.io/redhat/rhde:9.2
+`
+
+func TestExtractResourceFromFile_multiDocument(t *testing.T) {
+	require := require.New(t)
+
+	memfs := memfs.New()
+	writeFile(memfs, "/fleets.yaml", multiDocFleets)
+
+	rsTask := NewResourceSync(resourceSyncParams(t))
+
+	genericResources, err := rsTask.extractResourcesFromFile(memfs, "/fleets.yaml")
+	var parseErrs resourceParseErrors
+	require.ErrorAs(err, &parseErrs)
+	require.Len(parseErrs, 1)
+	require.ErrorContains(parseErrs[0], "(document 2): resource name missing")
+	require.Len(genericResources, 2)
+	require.Equal("fleet-1", genericResources[0]["metadata"].(map[string]interface{})["name"])
+	require.Equal("fleet-2", genericResources[1]["metadata"].(map[string]interface{})["name"])
+}
+
+func TestParseAndValidate_invalidDocument(t *testing.T) {
+	require := require.New(t)
+	rs := testResourceSync()
+	repo, err := testRepo()
+	require.NoError(err)
+	rsTask := NewResourceSync(resourceSyncParams(t))
+
+	rs.Spec.Data.Path = "/fleets.yaml"
+	resources, err := rsTask.parseAndValidateResources(&rs, &repo, func(_ *model.Repository, _ *string, _ *int) (billy.Filesystem, string, error) {
+		memfs := memfs.New()
+		writeFile(memfs, "/fleets.yaml", multiDocFleets)
+		return memfs, gitRepoCommit, nil
+	})
+	require.Error(err)
+	require.Len(resources, 2)
+	require.True(api.IsStatusConditionFalse(rs.Status.Data.Conditions, api.ResourceSyncResourceParsed))
+}
+
+func TestExtractResourceFromDir_kustomization(t *testing.T) {
+	require := require.New(t)
+
+	memfs := memfs.New()
+	require.NoError(memfs.MkdirAll("/base", 0666))
+	require.NoError(memfs.MkdirAll("/overlays/prod", 0666))
+	writeCopy(memfs, "../../examples/fleet.yaml", "/base/fleet.yaml")
+	writeCopy(memfs, "../../examples/fleet-b.yaml", "/base/fleet-b.yaml")
+	writeFile(memfs, "/overlays/prod/kustomization.yaml", `resources:
+  - ../../base
+patches:
+  - path: os.yaml
+`)
+	writeFile(memfs, "/overlays/prod/os.yaml", `kind: Fleet
+metadata:
+  name: fleet-b
+spec:
+  template:
+    spec:
+      os:
+        image: quay.io/redhat/rhde:9.4
+`)
+
+	rsTask := NewResourceSync(resourceSyncParams(t))
+
+	genericResources, err := rsTask.extractResourcesFromDir(memfs, "/overlays/prod")
+	require.NoError(err)
+	require.Len(genericResources, 2)
+
+	owner := util.SetResourceOwner(api.ResourceSyncKind, "foo")
+	fleets, err := rsTask.parseFleets(genericResources, owner)
+	require.NoError(err)
+	require.Len(fleets, 2)
+	images := map[string]string{}
+	for _, fleet := range fleets {
+		images[*fleet.Metadata.Name] = fleet.Spec.Template.Spec.Os.Image
+	}
+	require.Equal("quay.io/redhat/rhde:9.2", images["default"])
+	require.Equal("quay.io/redhat/rhde:9.4", images["fleet-b"])
+}
+
+func TestExtractResourceFromDir_kustomizationPatchWithoutTarget(t *testing.T) {
+	require := require.New(t)
+
+	memfs := memfs.New()
+	require.NoError(memfs.MkdirAll("/overlay", 0666))
+	writeCopy(memfs, "../../examples/fleet.yaml", "/overlay/fleet.yaml")
+	writeFile(memfs, "/overlay/kustomization.yml", `resources:
+  - fleet.yaml
+patches:
+  - path: patch.yaml
+`)
+	writeFile(memfs, "/overlay/patch.yaml", `kind: Fleet
+metadata:
+  name: missing
+`)
+
+	rsTask := NewResourceSync(resourceSyncParams(t))
+
+	genericResources, err := rsTask.extractResourcesFromDir(memfs, "/overlay")
+	require.ErrorContains(err, "no resource Fleet/missing to patch")
+	require.Len(genericResources, 1)
+}
+
+func TestExtractResourceFromDir_kustomizationCycle(t *testing.T) {
+	require := require.New(t)
+
+	memfs := memfs.New()
+	require.NoError(memfs.MkdirAll("/overlay", 0666))
+	writeFile(memfs, "/overlay/kustomization.yaml", `resources:
+  - .
+`)
+
+	rsTask := NewResourceSync(resourceSyncParams(t))
+
+	_, err := rsTask.extractResourcesFromDir(memfs, "/overlay")
+	require.ErrorContains(err, "includes itself")
+}
+
 func TestParseFleet(t *testing.T) {
 	require := require.New(t)
 
@@ -316,3 +577,38 @@ func writeCopy(fs billy.Filesystem, localPath, path string) {
 		panic(err)
 	}
 }
+
+type denyingValidator struct {
+	requests []admission.Request
+}
+
+func (v *denyingValidator) Validate(ctx context.Context, request admission.Request) error {
+	v.requests = append(v.requests, request)
+	if request.Name == "denied" {
+		return &admission.DeniedError{Validator: "policy", Message: "not allowed"}
+	}
+	return nil
+}
+
+func TestAdmitFleets(t *testing.T) {
+	require := require.New(t)
+	callbackManager, st, log, _ := resourceSyncParams(t)
+	validator := &denyingValidator{}
+	rsTask := NewResourceSync(callbackManager, st, log, validator)
+
+	owned := []api.Fleet{{Metadata: api.ObjectMeta{Name: lo.ToPtr("owned")}}}
+	fleets := []*api.Fleet{
+		{Metadata: api.ObjectMeta{Name: lo.ToPtr("owned")}},
+		{Metadata: api.ObjectMeta{Name: lo.ToPtr("new")}},
+	}
+	require.NoError(rsTask.admit(context.Background(), owned, fleets))
+	require.Len(validator.requests, 2)
+	require.Equal(admission.OperationUpdate, validator.requests[0].Operation)
+	require.Equal(admission.OperationCreate, validator.requests[1].Operation)
+	require.Equal(api.FleetKind, validator.requests[1].Kind)
+
+	fleets = append(fleets, &api.Fleet{Metadata: api.ObjectMeta{Name: lo.ToPtr("denied")}})
+	err := rsTask.admit(context.Background(), owned, fleets)
+	require.True(admission.IsDenied(err))
+	require.ErrorContains(err, "fleet denied")
+}
@@ -0,0 +1,47 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// RevisionPrunePollingInterval is the interval at which the revision history is pruned.
+	RevisionPrunePollingInterval = 10 * time.Minute
+)
+
+type RevisionPruner struct {
+	log   logrus.FieldLogger
+	store store.Store
+	keep  int
+}
+
+// NewRevisionPruner returns a task keeping the newest keep revisions of each resource.
+func NewRevisionPruner(log logrus.FieldLogger, store store.Store, keep int) *RevisionPruner {
+	return &RevisionPruner{
+		log:   log,
+		store: store,
+		keep:  keep,
+	}
+}
+
+// Poll deletes the revisions exceeding the configured history limit, and the revisions of deleted resources.
+func (t *RevisionPruner) Poll() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// TODO: one thread per org?
+	orgID := uuid.UUID{}
+	deleted, err := t.store.ResourceRevision().Prune(ctx, orgID, t.keep)
+	if err != nil {
+		t.log.WithError(err).Error("failed to prune revision history")
+		return
+	}
+	if deleted > 0 {
+		t.log.Infof("Pruned %d revisions from the revision history", deleted)
+	}
+}
@@ -0,0 +1,96 @@
+package tasks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	TaskResultSucceeded = "Succeeded"
+	TaskResultFailed    = "Failed"
+	TaskResultTimedOut  = "TimedOut"
+)
+
+// TaskExecution describes one execution of a task by the worker.
+type TaskExecution struct {
+	Task      string    `json:"task"`
+	Op        string    `json:"op"`
+	Key       string    `json:"key"`
+	RequestID string    `json:"requestID,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	Duration  string    `json:"duration"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// TaskTrace keeps the most recent task executions in a ring buffer, so that operators can see
+// what the worker did without enabling verbose logging.
+type TaskTrace struct {
+	mu         sync.Mutex
+	executions []TaskExecution
+	next       int
+	full       bool
+}
+
+func NewTaskTrace(capacity int) *TaskTrace {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &TaskTrace{executions: make([]TaskExecution, capacity)}
+}
+
+// Record adds the execution of the task, evicting the oldest execution when the trace is full.
+// It is a no-op on a nil trace.
+func (t *TaskTrace) Record(reference *ResourceReference, startedAt time.Time, duration time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	execution := TaskExecution{
+		Task:      reference.TaskName,
+		Op:        reference.Op,
+		Key:       reference.OrgID.String() + "/" + reference.Kind + "/" + reference.Name,
+		RequestID: reference.RequestID,
+		StartedAt: startedAt,
+		Duration:  duration.String(),
+		Result:    TaskResultSucceeded,
+	}
+	if err != nil {
+		execution.Result = TaskResultFailed
+		if errors.Is(err, ErrTaskTimeout) {
+			execution.Result = TaskResultTimedOut
+		}
+		execution.Error = err.Error()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.executions[t.next] = execution
+	t.next = (t.next + 1) % len(t.executions)
+	if t.next == 0 {
+		t.full = true
+	}
+}
+
+// Executions returns the recorded executions, most recent first.
+func (t *TaskTrace) Executions() []TaskExecution {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	count := t.next
+	if t.full {
+		count = len(t.executions)
+	}
+	executions := make([]TaskExecution, 0, count)
+	for i := 1; i <= count; i++ {
+		executions = append(executions, t.executions[(t.next-i+len(t.executions))%len(t.executions)])
+	}
+	return executions
+}
+
+// ServeHTTP returns the recorded executions as JSON.
+func (t *TaskTrace) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(t.Executions())
+}
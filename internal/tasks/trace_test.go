@@ -0,0 +1,76 @@
+package tasks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func traceNames(executions []TaskExecution) []string {
+	names := []string{}
+	for _, e := range executions {
+		names = append(names, e.Key)
+	}
+	return names
+}
+
+func TestTaskTrace(t *testing.T) {
+	require := require.New(t)
+	orgID := uuid.New()
+	trace := NewTaskTrace(3)
+	require.Empty(trace.Executions())
+
+	record := func(name string, err error) {
+		reference := &ResourceReference{TaskName: DeviceRenderTask, Op: DeviceRenderOpUpdate, OrgID: orgID, Kind: "Device", Name: name}
+		trace.Record(reference, time.Now(), time.Second, err)
+	}
+	key := func(name string) string {
+		return fmt.Sprintf("%s/Device/%s", orgID, name)
+	}
+
+	record("device-1", nil)
+	record("device-2", errors.New("boom"))
+	require.Equal([]string{key("device-2"), key("device-1")}, traceNames(trace.Executions()))
+
+	// the oldest executions are evicted once the capacity is reached
+	record("device-3", fmt.Errorf("%w: %s after 3 attempts", ErrTaskTimeout, DeviceRenderTask))
+	record("device-4", nil)
+	record("device-5", nil)
+	executions := trace.Executions()
+	require.Equal([]string{key("device-5"), key("device-4"), key("device-3")}, traceNames(executions))
+
+	require.Equal(TaskResultSucceeded, executions[0].Result)
+	require.Empty(executions[0].Error)
+	require.Equal(TaskResultTimedOut, executions[2].Result)
+	require.Contains(executions[2].Error, "timed out")
+	require.Equal(DeviceRenderTask, executions[2].Task)
+	require.Equal("1s", executions[2].Duration)
+
+	// a nil trace records nothing
+	var disabled *TaskTrace
+	disabled.Record(&ResourceReference{}, time.Now(), 0, nil)
+}
+
+func TestTaskTraceServeHTTP(t *testing.T) {
+	require := require.New(t)
+	trace := NewTaskTrace(10)
+	trace.Record(&ResourceReference{TaskName: FleetRolloutTask, Kind: "Fleet", Name: "fleet"}, time.Now(), time.Millisecond, errors.New("boom"))
+
+	recorder := httptest.NewRecorder()
+	trace.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/tasks", nil))
+	require.Equal(http.StatusOK, recorder.Code)
+	require.Equal("application/json", recorder.Header().Get("Content-Type"))
+	var executions []TaskExecution
+	require.NoError(json.NewDecoder(recorder.Body).Decode(&executions))
+	require.Len(executions, 1)
+	require.Equal(FleetRolloutTask, executions[0].Task)
+	require.Equal(TaskResultFailed, executions[0].Result)
+	require.Equal("boom", executions[0].Error)
+}
@@ -1,6 +1,8 @@
 package tpm
 
 import (
+	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -51,3 +53,48 @@ func (t *TPM) GetPCRValues(measurements map[string]string) error {
 	}
 	return nil
 }
+
+// GetEndorsementKeyPublic returns the DER encoded public part of the RSA endorsement key of the
+// TPM, derived from the default template of the TCG EK Credential Profile. The key is the same
+// every time it is derived, for as long as the endorsement hierarchy of the TPM is not cleared.
+func (t *TPM) GetEndorsementKeyPublic() ([]byte, error) {
+	if t == nil {
+		return nil, fmt.Errorf("no TPM")
+	}
+	handle, publicKey, err := tpm2.CreatePrimary(t.channel, tpm2.HandleEndorsement, tpm2.PCRSelection{}, "", "", endorsementKeyTemplateRSA())
+	if err != nil {
+		return nil, fmt.Errorf("creating endorsement key: %w", err)
+	}
+	defer func() {
+		_ = tpm2.FlushContext(t.channel, handle)
+	}()
+	return x509.MarshalPKIXPublicKey(publicKey)
+}
+
+// endorsementKeyTemplateRSA returns the default RSA endorsement key template as specified in
+// Credential_Profile_EK_V2.0, section 2.1.5.1.
+func endorsementKeyTemplateRSA() tpm2.Public {
+	// the policy is PolicySecret(TPM_RH_ENDORSEMENT), section 2.1.5.3
+	command, err := tpmutil.Pack(tpm2.CmdPolicySecret, tpm2.HandleEndorsement)
+	if err != nil {
+		panic(err)
+	}
+	digest := sha256.Sum256(append(make([]byte, sha256.Size), command...))
+	policy := sha256.Sum256(digest[:])
+
+	return tpm2.Public{
+		Type:       tpm2.AlgRSA,
+		NameAlg:    tpm2.AlgSHA256,
+		Attributes: (tpm2.FlagStorageDefault | tpm2.FlagAdminWithPolicy) & ^tpm2.FlagUserWithAuth,
+		AuthPolicy: policy[:],
+		RSAParameters: &tpm2.RSAParams{
+			Symmetric: &tpm2.SymScheme{
+				Alg:     tpm2.AlgAES,
+				KeyBits: 128,
+				Mode:    tpm2.AlgCFB,
+			},
+			KeyBits:    2048,
+			ModulusRaw: make([]byte, 256),
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// SemverRange is a set of constraints on semantic versions, such as ">=1.2.0 <2.0.0", that a
+// version must all satisfy to be within the range.
+type SemverRange []semverConstraint
+
+type semverConstraint struct {
+	op      string
+	version semver.Version
+}
+
+// semverOperators are ordered so that the two-character operators match first.
+var semverOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ParseSemverRange parses space- or comma-separated constraints, each made of an optional
+// operator (>=, <=, >, <, =, != and = by default) and a version with an optional "v" prefix.
+func ParseSemverRange(s string) (SemverRange, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' })
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty semantic version range")
+	}
+	r := make(SemverRange, 0, len(fields))
+	for _, field := range fields {
+		op := "="
+		for _, candidate := range semverOperators {
+			if strings.HasPrefix(field, candidate) {
+				op = candidate
+				field = strings.TrimPrefix(field, candidate)
+				break
+			}
+		}
+		version, err := ParseSemver(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid semantic version range %q: %w", s, err)
+		}
+		r = append(r, semverConstraint{op: op, version: *version})
+	}
+	return r, nil
+}
+
+// ParseSemver parses a semantic version with an optional "v" prefix.
+func ParseSemver(s string) (*semver.Version, error) {
+	return semver.NewVersion(strings.TrimPrefix(s, "v"))
+}
+
+// Contains returns true if the version satisfies all the constraints of the range.
+func (r SemverRange) Contains(version semver.Version) bool {
+	for _, c := range r {
+		cmp := version.Compare(c.version)
+		var ok bool
+		switch c.op {
+		case ">=":
+			ok = cmp >= 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case "<":
+			ok = cmp < 0
+		case "!=":
+			ok = cmp != 0
+		default:
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -23,5 +23,27 @@ var _ = Describe("Test util", func() {
 			Expect(LabelsMatchLabelSelector(map[string]string{"key1": "val1", "key2": "val2"}, map[string]string{"key1": "val1"})).To(BeTrue())
 			Expect(LabelsMatchLabelSelector(map[string]string{"key1": "val1"}, map[string]string{"key1": "val1", "key2": "val2"})).To(BeFalse())
 		})
+
+		It("SemverRange", func() {
+			r, err := ParseSemverRange(">=1.2.0, <2.0.0 !=1.5.0")
+			Expect(err).ToNot(HaveOccurred())
+			for version, expected := range map[string]bool{
+				"v1.2.0":      true,
+				"1.9.9":       true,
+				"1.5.0":       false,
+				"1.1.9":       false,
+				"2.0.0":       false,
+				"v1.2.0-rc.1": false,
+			} {
+				v, err := ParseSemver(version)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(r.Contains(*v)).To(Equal(expected), version)
+			}
+
+			_, err = ParseSemverRange(" ")
+			Expect(err).To(HaveOccurred())
+			_, err = ParseSemverRange(">=1.2")
+			Expect(err).To(HaveOccurred())
+		})
 	})
 })
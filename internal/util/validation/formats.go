@@ -52,3 +52,14 @@ var GitRevisionRegexp = regexp.MustCompile("^" + GitRevisionFmt + "$")
 func ValidateGitRevision(name *string, path string) []error {
 	return ValidateString(name, path, 1, GitRevisionMaxLength, GitRevisionRegexp, GitRevisionFmt)
 }
+
+const (
+	GitCommitHashFmt string = `[0-9a-f]{40}`
+)
+
+var GitCommitHashRegexp = regexp.MustCompile("^" + GitCommitHashFmt + "$")
+
+// Validates a full (SHA-1) git commit hash.
+func ValidateGitCommitHash(hash *string, path string) []error {
+	return ValidateString(hash, path, 40, 40, GitCommitHashRegexp, GitCommitHashFmt)
+}
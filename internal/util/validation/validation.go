@@ -47,6 +47,11 @@ func ValidateLabelsWithPath(labels *map[string]string, path string) []error {
 	return asErrors(errs)
 }
 
+// ValidateLabelKey validates that a key is a valid K8s label key, with path being the path to the key.
+func ValidateLabelKey(key string, path string) []error {
+	return asErrors(k8smetav1validation.ValidateLabelName(key, fieldPathFor(path)))
+}
+
 // ValidateStringMap validates that the k,v elements in a map are correctly defined as a string.
 func ValidateStringMap(m *map[string]string, path string, minLen int, maxLen int, patternRegexp *regexp.Regexp, patternFmt string, patternExample ...string) []error {
 	allErrs := []error{}
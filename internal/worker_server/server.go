@@ -2,16 +2,24 @@ package workerserver
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/flightctl/flightctl/internal/changes"
 	"github.com/flightctl/flightctl/internal/config"
 	"github.com/flightctl/flightctl/internal/kvstore"
+	"github.com/flightctl/flightctl/internal/notifications"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/tasks"
 	"github.com/flightctl/flightctl/pkg/k8sclient"
 	"github.com/flightctl/flightctl/pkg/queues"
+	"github.com/flightctl/flightctl/pkg/thread"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 )
 
@@ -52,8 +60,51 @@ func (s *Server) Run(ctx context.Context) error {
 		s.log.WithError(err).Error("failed to create kvStore")
 		return err
 	}
-	callbackManager := tasks.NewCallbackManager(publisher, s.log)
-	if err = tasks.LaunchConsumers(ctx, s.provider, s.store, callbackManager, s.k8sClient, kvStore, 1, 1); err != nil {
+	callbackManager := tasks.NewOutboxCallbackManager(s.store.Outbox(), s.log)
+	var trace *tasks.TaskTrace
+	var reconciles *tasks.FleetReconcileMetrics
+	if s.cfg.Workers != nil && s.cfg.Workers.DebugAddress != "" {
+		trace = tasks.NewTaskTrace(s.cfg.Workers.TaskTraceSize)
+		reconciles = tasks.NewFleetReconcileMetrics()
+		go s.runDebugServer(ctx, trace, reconciles)
+	}
+
+	// publish the tasks committed to the outbox, including the ones left over by a restart
+	outboxRelay := tasks.NewOutboxRelay(s.log, s.store.Outbox(), publisher)
+	outboxRelayThread := thread.New(
+		s.log.WithField("pkg", "outbox-relay"), "Outbox relay", tasks.OutboxRelayInterval, outboxRelay.Poll)
+	outboxRelayThread.Start()
+	defer outboxRelayThread.Stop()
+
+	if s.cfg.NotificationsEnabled() {
+		sink, err := notifications.NewSink(s.log, s.webhooks(), time.Duration(s.cfg.Notifications.Timeout),
+			s.cfg.Notifications.MaxRetries, time.Duration(s.cfg.Notifications.RetryBackoff))
+		if err != nil {
+			s.log.WithError(err).Error("failed to create notification sink")
+			return err
+		}
+		notificationRelay := notifications.NewRelay(s.log, s.store.Outbox(), sink)
+		notificationRelayThread := thread.New(
+			s.log.WithField("pkg", "notification-relay"), "Notification relay", notifications.RelayInterval, notificationRelay.Poll)
+		notificationRelayThread.Start()
+		defer notificationRelayThread.Stop()
+	}
+
+	if s.cfg.ChangeCaptureEnabled() {
+		sink, err := changes.NewWebhookSink(s.cfg.ChangeCapture.Webhook.Url, s.cfg.ChangeCapture.Webhook.Secret,
+			time.Duration(s.cfg.ChangeCapture.Timeout))
+		if err != nil {
+			s.log.WithError(err).Error("failed to create change sink")
+			return err
+		}
+		changeRelay := changes.NewRelay(s.log, s.store.Outbox(), sink)
+		changeRelayThread := thread.New(
+			s.log.WithField("pkg", "change-relay"), "Change relay", changes.RelayInterval, changeRelay.Poll)
+		changeRelayThread.Start()
+		defer changeRelayThread.Stop()
+	}
+
+	if err = tasks.LaunchConsumers(ctx, s.provider, s.store, callbackManager, s.k8sClient, kvStore, s.taskTimeouts(), trace, reconciles, 1, 1); err != nil {
 		s.log.WithError(err).Error("failed to launch consumers")
 		return err
 	}
@@ -69,3 +120,63 @@ func (s *Server) Run(ctx context.Context) error {
 
 	return nil
 }
+
+// runDebugServer serves the recent task executions and the fleet reconcile metrics on the debug
+// address until the context is canceled.
+func (s *Server) runDebugServer(ctx context.Context, trace *tasks.TaskTrace, reconciles *tasks.FleetReconcileMetrics) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(reconciles)
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/tasks", trace)
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	srv := &http.Server{
+		Addr:              s.cfg.Workers.DebugAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		ctxTimeout, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctxTimeout)
+	}()
+
+	s.log.Printf("Serving task executions on %s/debug/tasks and metrics on %s/metrics", s.cfg.Workers.DebugAddress, s.cfg.Workers.DebugAddress)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.log.WithError(err).Error("debug server failed")
+	}
+}
+
+func (s *Server) taskTimeouts() tasks.TaskTimeouts {
+	timeouts := tasks.TaskTimeouts{PerTask: map[string]time.Duration{}}
+	if s.cfg.Workers == nil {
+		return timeouts
+	}
+	timeouts.Default = time.Duration(s.cfg.Workers.TaskTimeout)
+	timeouts.MaxRetries = s.cfg.Workers.MaxTaskRetries
+	for taskName, timeout := range s.cfg.Workers.TaskTimeouts {
+		timeouts.PerTask[taskName] = time.Duration(timeout)
+	}
+	return timeouts
+}
+
+func (s *Server) webhooks() []notifications.Webhook {
+	webhooks := []notifications.Webhook{}
+	for _, webhook := range s.cfg.Notifications.Webhooks {
+		reasons := []notifications.Reason{}
+		for _, reason := range webhook.Reasons {
+			reasons = append(reasons, notifications.Reason(reason))
+		}
+		webhooks = append(webhooks, notifications.Webhook{
+			Name:    webhook.Name,
+			URL:     webhook.Url,
+			Secret:  webhook.Secret,
+			Format:  webhook.Format,
+			Reasons: reasons,
+			Kinds:   webhook.Kinds,
+		})
+	}
+	return webhooks
+}
@@ -13,8 +13,13 @@ import (
 
 const initialIgnition = `{"ignition": {"version": "3.4.0"}}`
 
+// TemplateMediaType is the media type of the data URL of files whose content is a template
+// rendered by the agent on the device.
+const TemplateMediaType = "text/x-flightctl-template"
+
 type Wrapper interface {
 	SetFile(filePath string, contents []byte, mode int, base64 bool, user *string, group *string)
+	SetTemplateFile(filePath string, contents []byte, mode int, base64 bool, user *string, group *string)
 	ChangeMountPath(mountPath string)
 	AsJson() ([]byte, error)
 	AsMap() (map[string]interface{}, error)
@@ -52,6 +57,15 @@ func NewWrapperFromIgnition(ign config_latest_types.Config) Wrapper {
 }
 
 func (w *wrapper) SetFile(filePath string, contents []byte, mode int, base64 bool, user *string, group *string) {
+	w.setFile(filePath, contents, mode, base64, user, group, "text/plain")
+}
+
+// SetTemplateFile sets a file whose content is rendered by the agent on the device.
+func (w *wrapper) SetTemplateFile(filePath string, contents []byte, mode int, base64 bool, user *string, group *string) {
+	w.setFile(filePath, contents, mode, base64, user, group, TemplateMediaType)
+}
+
+func (w *wrapper) setFile(filePath string, contents []byte, mode int, base64 bool, user *string, group *string, mediaType string) {
 	file := config_latest_types.File{
 		Node: config_latest_types.Node{
 			Path:      filePath,
@@ -66,11 +80,11 @@ func (w *wrapper) SetFile(filePath string, contents []byte, mode int, base64 boo
 	}
 
 	if base64 {
-		url := dataurl.New(contents, "text/plain;base64")
+		url := dataurl.New(contents, mediaType+";base64")
 		url.Encoding = dataurl.EncodingASCII // Otherwise the library will double base64 encode
 		file.FileEmbedded1.Contents.Source = lo.ToPtr(url.String())
 	} else {
-		file.FileEmbedded1.Contents.Source = lo.ToPtr(dataurl.New(contents, "text/plain").String())
+		file.FileEmbedded1.Contents.Source = lo.ToPtr(dataurl.New(contents, mediaType).String())
 	}
 	if user != nil {
 		file.Node.User = userStringToNodeUser(*user)
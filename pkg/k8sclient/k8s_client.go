@@ -6,8 +6,10 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -17,6 +19,9 @@ const (
 type K8SClient interface {
 	GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error)
 	PostCRD(ctx context.Context, crdGVK string, body []byte, opts ...Option) ([]byte, error)
+	// WatchRBAC calls onChange whenever a role or role binding of the namespace, or a cluster role
+	// or cluster role binding, is added, updated or deleted, until ctx is done.
+	WatchRBAC(ctx context.Context, namespace string, onChange func()) error
 }
 
 type k8sClient struct {
@@ -70,6 +75,29 @@ func (k *k8sClient) PostCRD(ctx context.Context, crdGVK string, body []byte, opt
 	return req.DoRaw(ctx)
 }
 
+func (k *k8sClient) WatchRBAC(ctx context.Context, namespace string, onChange func()) error {
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { onChange() },
+		UpdateFunc: func(interface{}, interface{}) { onChange() },
+		DeleteFunc: func(interface{}) { onChange() },
+	}
+	namespaced := informers.NewSharedInformerFactoryWithOptions(k.clientset, 0, informers.WithNamespace(namespace))
+	cluster := informers.NewSharedInformerFactory(k.clientset, 0)
+	for _, informer := range []cache.SharedIndexInformer{
+		namespaced.Rbac().V1().Roles().Informer(),
+		namespaced.Rbac().V1().RoleBindings().Informer(),
+		cluster.Rbac().V1().ClusterRoles().Informer(),
+		cluster.Rbac().V1().ClusterRoleBindings().Informer(),
+	} {
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			return fmt.Errorf("failed to watch RBAC: %w", err)
+		}
+	}
+	namespaced.Start(ctx.Done())
+	cluster.Start(ctx.Done())
+	return nil
+}
+
 type Option func(*rest.Request)
 
 func WithToken(token string) Option {
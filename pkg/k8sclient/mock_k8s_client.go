@@ -74,3 +74,17 @@ func (mr *MockK8SClientMockRecorder) PostCRD(ctx, crdGVK, body any, opts ...any)
 	varargs := append([]any{ctx, crdGVK, body}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostCRD", reflect.TypeOf((*MockK8SClient)(nil).PostCRD), varargs...)
 }
+
+// WatchRBAC mocks base method.
+func (m *MockK8SClient) WatchRBAC(ctx context.Context, namespace string, onChange func()) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchRBAC", ctx, namespace, onChange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchRBAC indicates an expected call of WatchRBAC.
+func (mr *MockK8SClientMockRecorder) WatchRBAC(ctx, namespace, onChange any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchRBAC", reflect.TypeOf((*MockK8SClient)(nil).WatchRBAC), ctx, namespace, onChange)
+}
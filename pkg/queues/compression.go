@@ -0,0 +1,76 @@
+package queues
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// Compression is the algorithm the payloads of published messages are compressed with.
+type Compression string
+
+const (
+	// CompressionNone publishes the payloads as they are.
+	CompressionNone Compression = "none"
+	// CompressionGzip compresses the payloads with gzip.
+	CompressionGzip Compression = "gzip"
+
+	// DefaultCompressionThreshold is the size in bytes from which payloads are compressed.
+	DefaultCompressionThreshold = 16 * 1024
+)
+
+// gzipMagic are the first bytes of gzip data. Payloads are JSON documents, so a payload starting
+// with them is a compressed one.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// payloadCodec compresses the payloads of published messages above a threshold, and decompresses
+// the payloads of consumed messages. Compressed payloads are recognized by their content, so that
+// consumers decode them whether or not compression is enabled for their own messages.
+type payloadCodec struct {
+	compression Compression
+	threshold   int
+}
+
+func newPayloadCodec(compression Compression, threshold int) (payloadCodec, error) {
+	switch compression {
+	case "", CompressionNone, CompressionGzip:
+	default:
+		return payloadCodec{}, fmt.Errorf("unsupported compression %q", compression)
+	}
+	if threshold < 0 {
+		return payloadCodec{}, fmt.Errorf("invalid compression threshold %d: must not be negative", threshold)
+	}
+	return payloadCodec{compression: compression, threshold: threshold}, nil
+}
+
+func (c payloadCodec) encode(payload []byte) ([]byte, error) {
+	if c.compression != CompressionGzip || len(payload) < c.threshold {
+		return payload, nil
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(payload); err != nil {
+		return nil, fmt.Errorf("compressing payload: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("compressing payload: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (c payloadCodec) decode(payload []byte) ([]byte, error) {
+	if !bytes.HasPrefix(payload, gzipMagic) {
+		return payload, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("decompressing payload: %w", err)
+	}
+	defer r.Close()
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing payload: %w", err)
+	}
+	return decoded, nil
+}
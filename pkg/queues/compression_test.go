@@ -0,0 +1,70 @@
+package queues
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func largePayload(t *testing.T) []byte {
+	// a rendered spec with many applications
+	spec := map[string]interface{}{}
+	for i := 0; i < 2000; i++ {
+		spec[fmt.Sprintf("app-%d", i)] = map[string]string{
+			"image": fmt.Sprintf("quay.io/flightctl/app-%d:v1", i),
+			"path":  "/etc/compose/manifests",
+		}
+	}
+	payload, err := json.Marshal(map[string]interface{}{"kind": "Fleet", "spec": spec})
+	require.NoError(t, err)
+	return payload
+}
+
+func TestPayloadCodec(t *testing.T) {
+	require := require.New(t)
+	codec, err := newPayloadCodec(CompressionGzip, DefaultCompressionThreshold)
+	require.NoError(err)
+
+	payload := largePayload(t)
+	require.Greater(len(payload), DefaultCompressionThreshold)
+	encoded, err := codec.encode(payload)
+	require.NoError(err)
+	require.True(bytes.HasPrefix(encoded, gzipMagic))
+	require.Less(len(encoded), len(payload)/4)
+	decoded, err := codec.decode(encoded)
+	require.NoError(err)
+	require.Equal(payload, decoded)
+
+	// small payloads are not compressed
+	small := []byte(`{"kind":"Fleet"}`)
+	encoded, err = codec.encode(small)
+	require.NoError(err)
+	require.Equal(small, encoded)
+
+	// compressed payloads are decoded without compression enabled
+	codec, err = newPayloadCodec(CompressionNone, DefaultCompressionThreshold)
+	require.NoError(err)
+	decoded, err = codec.decode(encoded)
+	require.NoError(err)
+	require.Equal(small, decoded)
+	encoded, err = codec.encode(payload)
+	require.NoError(err)
+	require.Equal(payload, encoded)
+}
+
+func TestPayloadCodecInvalid(t *testing.T) {
+	require := require.New(t)
+
+	_, err := newPayloadCodec("zstd", DefaultCompressionThreshold)
+	require.ErrorContains(err, `unsupported compression "zstd"`)
+	_, err = newPayloadCodec(CompressionGzip, -1)
+	require.ErrorContains(err, "must not be negative")
+
+	codec, err := newPayloadCodec(CompressionGzip, 0)
+	require.NoError(err)
+	_, err = codec.decode(append(gzipMagic, []byte("truncated")...))
+	require.ErrorContains(err, "decompressing payload")
+}
@@ -12,6 +12,7 @@ package queues
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -69,6 +70,49 @@ func (mr *MockProviderMockRecorder) NewPublisher(queueName any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewPublisher", reflect.TypeOf((*MockProvider)(nil).NewPublisher), queueName)
 }
 
+// PendingMessages mocks base method.
+func (m *MockProvider) PendingMessages(ctx context.Context, queueName string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingMessages", ctx, queueName)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingMessages indicates an expected call of PendingMessages.
+func (mr *MockProviderMockRecorder) PendingMessages(ctx, queueName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingMessages", reflect.TypeOf((*MockProvider)(nil).PendingMessages), ctx, queueName)
+}
+
+// Ping mocks base method.
+func (m *MockProvider) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockProviderMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockProvider)(nil).Ping), ctx)
+}
+
+// PublishAfter mocks base method.
+func (m *MockProvider) PublishAfter(ctx context.Context, queueName string, payload []byte, delay time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishAfter", ctx, queueName, payload, delay)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishAfter indicates an expected call of PublishAfter.
+func (mr *MockProviderMockRecorder) PublishAfter(ctx, queueName, payload, delay any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishAfter", reflect.TypeOf((*MockProvider)(nil).PublishAfter), ctx, queueName, payload, delay)
+}
+
 // Stop mocks base method.
 func (m *MockProvider) Stop() {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package queues
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -9,6 +10,14 @@ import (
 type Provider interface {
 	NewConsumer(queueName string) (Consumer, error)
 	NewPublisher(queueName string) (Publisher, error)
+	// PublishAfter publishes the payload to the queue once the delay has elapsed. Scheduled
+	// messages are kept by the provider's backend, so they are published even if the process that
+	// scheduled them restarts.
+	PublishAfter(ctx context.Context, queueName string, payload []byte, delay time.Duration) error
+	// PendingMessages returns the number of messages published to the queue that were not consumed yet.
+	PendingMessages(ctx context.Context, queueName string) (int64, error)
+	// Ping checks that the provider's backend is reachable.
+	Ping(ctx context.Context) error
 	Stop()
 	Wait()
 }
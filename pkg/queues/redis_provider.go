@@ -11,20 +11,75 @@ import (
 	"github.com/flightctl/flightctl/pkg/log"
 	"github.com/flightctl/flightctl/pkg/reqid"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// delayedQueuesKey is the set of queues with scheduled messages, so that the messages of all
+	// queues are published when due, including those scheduled before a restart.
+	delayedQueuesKey = "queues:delayed"
+	// delayedSuffix is appended to the name of a queue to get the sorted set of its scheduled
+	// messages, scored by the time they are due in milliseconds.
+	delayedSuffix = ":delayed"
+	// schedulerInterval is how often scheduled messages are checked.
+	schedulerInterval = time.Second
+	// schedulerBatchSize bounds the number of messages published per queue and check.
+	schedulerBatchSize = 100
+)
+
+// publishDueScript moves the scheduled messages that are due from the sorted set to the stream.
+// It runs atomically, so that a message is published only once when several providers run the
+// scheduler. A scheduled message is the 36 character UUID making it unique, a separator and the
+// payload.
+var publishDueScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, member in ipairs(due) do
+	redis.call('XADD', KEYS[2], '*', 'body', string.sub(member, 38))
+	redis.call('ZREM', KEYS[1], member)
+end
+return #due
+`)
+
 type redisProvider struct {
 	client  *redis.Client
 	log     logrus.FieldLogger
 	wg      *sync.WaitGroup
 	queues  []*redisQueue
 	stopped atomic.Bool
+	stopCh  chan struct{}
 	mu      sync.Mutex
+	codec   payloadCodec
 }
 
-func NewRedisProvider(ctx context.Context, log logrus.FieldLogger, hostname string, port uint, password string) (Provider, error) {
+// RedisOption configures the Redis provider.
+type RedisOption func(*redisProvider) error
+
+// WithCompression compresses the payloads of the published messages that are at least threshold
+// bytes long. Consumers decompress the payloads whatever the compression of the provider.
+func WithCompression(compression Compression, threshold int) RedisOption {
+	return func(r *redisProvider) error {
+		codec, err := newPayloadCodec(compression, threshold)
+		if err != nil {
+			return err
+		}
+		r.codec = codec
+		return nil
+	}
+}
+
+func NewRedisProvider(ctx context.Context, log logrus.FieldLogger, hostname string, port uint, password string, opts ...RedisOption) (Provider, error) {
+	provider := &redisProvider{
+		log:    log,
+		stopCh: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		if err := opt(provider); err != nil {
+			return nil, err
+		}
+	}
+
 	var wg sync.WaitGroup
 	wg.Add(1)
 	client := redis.NewClient(&redis.Options{
@@ -41,11 +96,11 @@ func NewRedisProvider(ctx context.Context, log logrus.FieldLogger, hostname stri
 	}
 	log.Info("successfully connected to the Redis queue")
 
-	return &redisProvider{
-		client: client,
-		log:    log,
-		wg:     &wg,
-	}, nil
+	provider.client = client
+	provider.wg = &wg
+	wg.Add(1)
+	go provider.runScheduler()
+	return provider, nil
 }
 
 func (r *redisProvider) newQueue(queueName string) (*redisQueue, error) {
@@ -59,6 +114,7 @@ func (r *redisProvider) newQueue(queueName string) (*redisQueue, error) {
 		client: r.client,
 		log:    r.log,
 		wg:     r.wg,
+		codec:  r.codec,
 	}
 	r.queues = append(r.queues, queue)
 	return queue, nil
@@ -72,6 +128,78 @@ func (r *redisProvider) NewPublisher(queueName string) (Publisher, error) {
 	return r.newQueue(queueName)
 }
 
+func (r *redisProvider) PublishAfter(ctx context.Context, queueName string, payload []byte, delay time.Duration) error {
+	if r.stopped.Load() {
+		return errors.New("provider is stopped")
+	}
+	payload, err := r.codec.encode(payload)
+	if err != nil {
+		return err
+	}
+	due := time.Now().Add(delay).UnixMilli()
+	member := uuid.NewString() + ":" + string(payload)
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, delayedQueuesKey, queueName)
+		pipe.ZAdd(ctx, queueName+delayedSuffix, redis.Z{Score: float64(due), Member: member})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule message: %w", err)
+	}
+	return nil
+}
+
+// runScheduler periodically publishes the scheduled messages that are due until the provider
+// is stopped.
+func (r *redisProvider) runScheduler() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			if err := r.publishDue(context.Background()); err != nil && !r.stopped.Load() {
+				r.log.WithError(err).Error("failed to publish scheduled messages")
+			}
+		}
+	}
+}
+
+func (r *redisProvider) publishDue(ctx context.Context) error {
+	queueNames, err := r.client.SMembers(ctx, delayedQueuesKey).Result()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	for _, queueName := range queueNames {
+		for {
+			published, err := publishDueScript.Run(ctx, r.client, []string{queueName + delayedSuffix, queueName}, now, schedulerBatchSize).Int()
+			if err != nil {
+				return fmt.Errorf("queue %s: %w", queueName, err)
+			}
+			if published < schedulerBatchSize {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (r *redisProvider) PendingMessages(ctx context.Context, queueName string) (int64, error) {
+	// consumed messages are deleted from the stream, so its length is the consumer lag
+	length, err := r.client.XLen(ctx, queueName).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get length of stream %s: %w", queueName, err)
+	}
+	return length, nil
+}
+
+func (r *redisProvider) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
 func (r *redisProvider) Stop() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -79,6 +207,7 @@ func (r *redisProvider) Stop() {
 		return
 	}
 	defer r.wg.Done()
+	close(r.stopCh)
 	r.client.Close()
 }
 
@@ -92,13 +221,18 @@ type redisQueue struct {
 	log    logrus.FieldLogger
 	wg     *sync.WaitGroup
 	closed atomic.Bool
+	codec  payloadCodec
 }
 
 func (r *redisQueue) Publish(payload []byte) error {
 	if r.closed.Load() {
 		return errors.New("queue is closed")
 	}
-	_, err := r.client.XAdd(context.Background(), &redis.XAddArgs{
+	payload, err := r.codec.encode(payload)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.XAdd(context.Background(), &redis.XAddArgs{
 		Stream: r.name,
 		Values: map[string]interface{}{"body": payload},
 	}).Result()
@@ -137,7 +271,9 @@ func (r *redisQueue) Consume(ctx context.Context, handler ConsumeHandler) error
 					requestID := reqid.NextRequestID()
 					reqCtx := context.WithValue(ctx, middleware.RequestIDKey, requestID)
 					log := log.WithReqIDFromCtx(reqCtx, r.log)
-					if err := handler(reqCtx, body, log); err != nil {
+					if body, err = r.codec.decode(body); err != nil {
+						log.WithError(err).Errorf("failed to decode message %s", entry.ID)
+					} else if err := handler(reqCtx, body, log); err != nil {
 						log.WithError(err).Errorf("failed to consume message: %s", string(body))
 					}
 					_, err := r.client.XDel(ctx, r.name, entry.ID).Result()
@@ -200,7 +200,7 @@ var _ = Describe("cli operation", func() {
 
 			By("Verifying Device update")
 			devName := *device.Metadata.Name
-			dev, err := harness.Client.ReadDeviceWithResponse(harness.Context, devName)
+			dev, err := harness.Client.ReadDeviceWithResponse(harness.Context, devName, nil)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(dev.JSON200).ToNot(BeNil(), "failed to read updated device")
 			responseLabelValue := (*dev.JSON200.Metadata.Labels)[newTestKey]
@@ -211,7 +211,7 @@ var _ = Describe("cli operation", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			// Verify deletion
-			dev, err = harness.Client.ReadDeviceWithResponse(harness.Context, devName)
+			dev, err = harness.Client.ReadDeviceWithResponse(harness.Context, devName, nil)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(dev.JSON404).ToNot(BeNil(), "device should not exist after deletion")
 
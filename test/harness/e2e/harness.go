@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/lifecycle"
 	apiclient "github.com/flightctl/flightctl/internal/api/client"
 	client "github.com/flightctl/flightctl/internal/client"
 	service "github.com/flightctl/flightctl/internal/service/common"
@@ -118,9 +119,15 @@ func (h *Harness) Cleanup(printConsole bool) {
 }
 
 func (h *Harness) GetEnrollmentIDFromConsole() string {
-	// wait for the enrollment ID on the console
+	// wait for the enrollment ID, preferring the structured banner when the agent writes one
 	enrollmentId := ""
 	Eventually(func() string {
+		if stdout, err := h.VM.RunSSH([]string{"sudo", "cat", lifecycle.BannerJSONFile}, nil); err == nil {
+			if banner, err := lifecycle.ParseBanner(stdout.Bytes()); err == nil {
+				enrollmentId = banner.EnrollmentID
+				return enrollmentId
+			}
+		}
 		consoleOutput := h.VM.GetConsoleOutput()
 		enrollmentId = util.GetEnrollmentIdFromText(consoleOutput)
 		return enrollmentId
@@ -170,7 +177,7 @@ func (h *Harness) StartVMAndEnroll() string {
 }
 
 func (h *Harness) GetDeviceWithStatusSystem(enrollmentID string) *apiclient.ReadDeviceResponse {
-	device, err := h.Client.ReadDeviceWithResponse(h.Context, enrollmentID)
+	device, err := h.Client.ReadDeviceWithResponse(h.Context, enrollmentID, nil)
 	Expect(err).NotTo(HaveOccurred())
 	// we keep waiting for a 200 response, with filled in Status.SystemInfo
 	if device.JSON200 == nil || device.JSON200.Status == nil || device.JSON200.Status.SystemInfo.IsEmpty() {
@@ -180,7 +187,7 @@ func (h *Harness) GetDeviceWithStatusSystem(enrollmentID string) *apiclient.Read
 }
 
 func (h *Harness) GetDeviceWithStatusSummary(enrollmentID string) v1alpha1.DeviceSummaryStatusType {
-	device, err := h.Client.ReadDeviceWithResponse(h.Context, enrollmentID)
+	device, err := h.Client.ReadDeviceWithResponse(h.Context, enrollmentID, nil)
 	Expect(err).NotTo(HaveOccurred())
 	// we keep waiting for a 200 response, with filled in Status.SystemInfo
 	if device == nil || device.JSON200 == nil || device.JSON200.Status == nil || device.JSON200.Status.Summary.Status == "" {
@@ -190,7 +197,7 @@ func (h *Harness) GetDeviceWithStatusSummary(enrollmentID string) v1alpha1.Devic
 }
 
 func (h *Harness) GetDeviceWithUpdateStatus(enrollmentID string) v1alpha1.DeviceUpdatedStatusType {
-	device, err := h.Client.ReadDeviceWithResponse(h.Context, enrollmentID)
+	device, err := h.Client.ReadDeviceWithResponse(h.Context, enrollmentID, nil)
 	Expect(err).NotTo(HaveOccurred())
 	// we keep waiting for a 200 response, with filled in Status.SystemInfo
 	if device == nil || device.JSON200 == nil || device.JSON200.Status == nil {
@@ -298,7 +305,7 @@ func (h *Harness) SH(command string, args ...string) (string, error) {
 
 func (h *Harness) UpdateDeviceWithRetries(deviceId string, updateFunction func(*v1alpha1.Device)) {
 	Eventually(func(updFunction func(*v1alpha1.Device)) error {
-		response, err := h.Client.ReadDeviceWithResponse(h.Context, deviceId)
+		response, err := h.Client.ReadDeviceWithResponse(h.Context, deviceId, nil)
 		Expect(err).NotTo(HaveOccurred())
 		if response.JSON200 == nil {
 			logrus.Errorf("An error happened retrieving device: %+v", response)
@@ -308,7 +315,7 @@ func (h *Harness) UpdateDeviceWithRetries(deviceId string, updateFunction func(*
 
 		updFunction(device)
 
-		resp, err := h.Client.ReplaceDeviceWithResponse(h.Context, deviceId, *device)
+		resp, err := h.Client.ReplaceDeviceWithResponse(h.Context, deviceId, nil, *device)
 
 		// if a conflict happens (the device updated status or object since we read it) we retry
 		if resp.JSON409 != nil {
@@ -336,7 +343,7 @@ func (h *Harness) WaitForDeviceContents(deviceId string, description string, con
 
 	Eventually(func() error {
 		logrus.Infof("Waiting for condition: %q to be met", description)
-		response, err := h.Client.ReadDeviceWithResponse(h.Context, deviceId)
+		response, err := h.Client.ReadDeviceWithResponse(h.Context, deviceId, nil)
 		Expect(err).NotTo(HaveOccurred())
 		if response.JSON200 == nil {
 			logrus.Errorf("An error happened retrieving device: %+v", response)
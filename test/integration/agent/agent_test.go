@@ -180,7 +180,7 @@ func enrollAndWaitForDevice(h *harness.TestHarness, approval *v1alpha1.Enrollmen
 	approveEnrollment(h, deviceName, approval)
 
 	// verify that the device is created
-	dev, err := h.Client.ReadDeviceWithResponse(h.Context, deviceName)
+	dev, err := h.Client.ReadDeviceWithResponse(h.Context, deviceName, nil)
 	Expect(err).ToNot(HaveOccurred())
 	return dev.JSON200
 }
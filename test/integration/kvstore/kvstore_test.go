@@ -2,6 +2,7 @@ package kvstore_test
 
 import (
 	"context"
+	"time"
 
 	"github.com/flightctl/flightctl/internal/kvstore"
 	flightlog "github.com/flightctl/flightctl/pkg/log"
@@ -72,6 +73,61 @@ var _ = Describe("FleetSelector", func() {
 		})
 	})
 
+	When("claiming a task idempotency key", func() {
+		It("claims the key once until it expires or is deleted", func() {
+			key := kvstore.TaskIdempotencyKey{
+				OrgID:    orgId,
+				TaskName: "fleet-rollout",
+				Key:      uuid.NewString(),
+			}
+
+			claimed, err := kvStore.SetNXWithExpiration(ctx, key.ComposeKey(), []byte("myfleet"), time.Second)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+
+			claimed, err = kvStore.SetNXWithExpiration(ctx, key.ComposeKey(), []byte("myfleet"), time.Second)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeFalse())
+
+			Expect(kvStore.Delete(ctx, key.ComposeKey())).To(Succeed())
+			claimed, err = kvStore.SetNXWithExpiration(ctx, key.ComposeKey(), []byte("myfleet"), 100*time.Millisecond)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+
+			Eventually(func() ([]byte, error) {
+				return kvStore.Get(ctx, key.ComposeKey())
+			}).WithTimeout(2 * time.Second).Should(BeEmpty())
+		})
+		It("updates and deletes a key only if it holds the expected value", func() {
+			key := kvstore.TaskIdempotencyKey{
+				OrgID:    orgId,
+				TaskName: "fleet-rollout",
+				Key:      uuid.NewString(),
+			}
+
+			claimed, err := kvStore.SetNXWithExpiration(ctx, key.ComposeKey(), []byte("lease-1"), time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+
+			updated, err := kvStore.SetIfEqualWithExpiration(ctx, key.ComposeKey(), []byte("lease-2"), []byte("done"), time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeFalse())
+			deleted, err := kvStore.DeleteIfEqual(ctx, key.ComposeKey(), []byte("lease-2"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+
+			updated, err = kvStore.SetIfEqualWithExpiration(ctx, key.ComposeKey(), []byte("lease-1"), []byte("done"), time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeTrue())
+			Expect(kvStore.Get(ctx, key.ComposeKey())).To(Equal([]byte("done")))
+
+			deleted, err = kvStore.DeleteIfEqual(ctx, key.ComposeKey(), []byte("done"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+			Expect(kvStore.Get(ctx, key.ComposeKey())).To(BeEmpty())
+		})
+	})
+
 	When("setting a repo URL", func() {
 		It("stores what is passed if the key doesn't exist", func() {
 			key := kvstore.RepositoryUrlKey{
@@ -0,0 +1,122 @@
+package queues_test
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/flightctl/flightctl/pkg/queues"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+func TestQueues(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Queues Suite")
+}
+
+var _ = Describe("RedisProvider", func() {
+	var (
+		ctx       context.Context
+		provider  queues.Provider
+		queueName string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		queueName = "test-queue-" + uuid.NewString()
+		var err error
+		provider, err = queues.NewRedisProvider(ctx, flightlog.InitLogs(), "localhost", 6379, "adminpass")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		provider.Stop()
+		provider.Wait()
+	})
+
+	When("publishing without consuming", func() {
+		It("reports increasing pending messages", func() {
+			pending, err := provider.PendingMessages(ctx, queueName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(BeZero())
+
+			publisher, err := provider.NewPublisher(queueName)
+			Expect(err).ToNot(HaveOccurred())
+			for i := 1; i <= 3; i++ {
+				Expect(publisher.Publish([]byte("payload"))).To(Succeed())
+				pending, err = provider.PendingMessages(ctx, queueName)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pending).To(Equal(int64(i)))
+			}
+		})
+	})
+
+	When("compressing payloads", func() {
+		It("delivers large payloads decompressed", func() {
+			provider.Stop()
+			provider.Wait()
+			var err error
+			provider, err = queues.NewRedisProvider(ctx, flightlog.InitLogs(), "localhost", 6379, "adminpass",
+				queues.WithCompression(queues.CompressionGzip, 1024))
+			Expect(err).ToNot(HaveOccurred())
+
+			payload := []byte(`{"spec":"` + strings.Repeat("rendered spec ", 10000) + `"}`)
+			consumed := make(chan []byte, 2)
+			consumer, err := provider.NewConsumer(queueName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(consumer.Consume(ctx, func(ctx context.Context, payload []byte, log logrus.FieldLogger) error {
+				consumed <- payload
+				return nil
+			})).To(Succeed())
+
+			publisher, err := provider.NewPublisher(queueName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(publisher.Publish(payload)).To(Succeed())
+			Eventually(consumed, 5*time.Second).Should(Receive(Equal(payload)))
+
+			Expect(provider.PublishAfter(ctx, queueName, payload, time.Second)).To(Succeed())
+			Eventually(consumed, 5*time.Second).Should(Receive(Equal(payload)))
+		})
+	})
+
+	When("publishing with a delay", func() {
+		It("does not deliver the message before it is due", func() {
+			var consumedAt atomic.Int64
+			consumer, err := provider.NewConsumer(queueName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(consumer.Consume(ctx, func(ctx context.Context, payload []byte, log logrus.FieldLogger) error {
+				if string(payload) == "delayed" {
+					consumedAt.Store(time.Now().UnixMilli())
+				}
+				return nil
+			})).To(Succeed())
+
+			publishedAt := time.Now()
+			delay := 3 * time.Second
+			Expect(provider.PublishAfter(ctx, queueName, []byte("delayed"), delay)).To(Succeed())
+
+			Consistently(consumedAt.Load, delay-time.Second, 100*time.Millisecond).Should(BeZero())
+			Eventually(consumedAt.Load, 5*time.Second, 100*time.Millisecond).ShouldNot(BeZero())
+			Expect(time.UnixMilli(consumedAt.Load())).To(BeTemporally(">=", publishedAt.Add(delay)))
+		})
+
+		It("delivers the messages scheduled before a restart", func() {
+			Expect(provider.PublishAfter(ctx, queueName, []byte("delayed"), time.Second)).To(Succeed())
+			provider.Stop()
+			provider.Wait()
+
+			var err error
+			provider, err = queues.NewRedisProvider(ctx, flightlog.InitLogs(), "localhost", 6379, "adminpass")
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(func() (int64, error) {
+				return provider.PendingMessages(ctx, queueName)
+			}, 5*time.Second, 100*time.Millisecond).Should(Equal(int64(1)))
+		})
+	})
+})
@@ -0,0 +1,107 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("BootstrapTokenStore", func() {
+	var (
+		log       *logrus.Logger
+		ctx       context.Context
+		orgId     uuid.UUID
+		storeInst store.Store
+		cfg       *config.Config
+		dbName    string
+		now       time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		orgId = store.NullOrgId
+		log = flightlog.InitLogs()
+		storeInst, cfg, dbName, _ = store.PrepareDBForUnitTests(log)
+		now = time.Now()
+	})
+
+	AfterEach(func() {
+		store.DeleteTestDB(log, cfg, storeInst, dbName)
+	})
+
+	issue := func(expiresAt time.Time) string {
+		token, hash, err := crypto.NewBootstrapToken()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(storeInst.BootstrapToken().Create(ctx, orgId, hash, expiresAt)).To(Succeed())
+		return token
+	}
+
+	redeem := func(token string, at time.Time) error {
+		return storeInst.BootstrapToken().Redeem(ctx, orgId, crypto.HashBootstrapToken(token), at, func() error { return nil })
+	}
+
+	It("redeems a valid token", func() {
+		token := issue(now.Add(time.Hour))
+		Expect(redeem(token, now)).To(Succeed())
+	})
+
+	It("does not redeem a token if issuing fails", func() {
+		token := issue(now.Add(time.Hour))
+		failed := errors.New("failed")
+		err := storeInst.BootstrapToken().Redeem(ctx, orgId, crypto.HashBootstrapToken(token), now, func() error { return failed })
+		Expect(err).To(MatchError(failed))
+		Expect(redeem(token, now)).To(Succeed())
+	})
+
+	It("rejects a reused token", func() {
+		token := issue(now.Add(time.Hour))
+		Expect(redeem(token, now)).To(Succeed())
+		Expect(redeem(token, now.Add(time.Minute))).To(MatchError(flterrors.ErrBootstrapTokenRedeemed))
+	})
+
+	It("rejects an expired token", func() {
+		token := issue(now.Add(time.Hour))
+		Expect(redeem(token, now.Add(2*time.Hour))).To(MatchError(flterrors.ErrBootstrapTokenExpired))
+	})
+
+	It("rejects an unknown token", func() {
+		issue(now.Add(time.Hour))
+		token, _, err := crypto.NewBootstrapToken()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(redeem(token, now)).To(MatchError(flterrors.ErrBootstrapTokenInvalid))
+	})
+
+	It("redeems a token only once when redeemed concurrently", func() {
+		token := issue(now.Add(time.Hour))
+		var wg sync.WaitGroup
+		errs := make([]error, 5)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = redeem(token, now)
+			}(i)
+		}
+		wg.Wait()
+		redeemed := 0
+		for _, err := range errs {
+			if err == nil {
+				redeemed++
+				continue
+			}
+			Expect(err).To(MatchError(flterrors.ErrBootstrapTokenRedeemed))
+		}
+		Expect(redeemed).To(Equal(1))
+	})
+})
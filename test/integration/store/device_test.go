@@ -15,6 +15,7 @@ import (
 	"github.com/flightctl/flightctl/internal/util"
 	flightlog "github.com/flightctl/flightctl/pkg/log"
 	testutil "github.com/flightctl/flightctl/test/util"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -52,8 +53,8 @@ var _ = Describe("DeviceStore create", func() {
 		storeInst, cfg, dbName, db = store.PrepareDBForUnitTests(log)
 		devStore = storeInst.Device()
 		called = false
-		callback = store.DeviceStoreCallback(func(before *model.Device, after *model.Device) { called = true })
-		allDeletedCallback = store.DeviceStoreAllDeletedCallback(func(orgId uuid.UUID) { called = true })
+		callback = store.DeviceStoreCallback(func(ctx context.Context, before *model.Device, after *model.Device) error { called = true; return nil })
+		allDeletedCallback = store.DeviceStoreAllDeletedCallback(func(ctx context.Context, orgId uuid.UUID) error { called = true; return nil })
 
 		testutil.CreateTestDevices(ctx, 3, devStore, orgId, nil, false)
 	})
@@ -165,6 +166,20 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(err).Should(MatchError(flterrors.ErrResourceNotFound))
 		})
 
+		It("Get many devices", func() {
+			devices, err := devStore.GetMany(ctx, orgId, []string{"mydevice-1", "nonexistent", "mydevice-3"})
+			Expect(err).ToNot(HaveOccurred())
+			names := lo.Map(devices, func(dev api.Device, _ int) string { return *dev.Metadata.Name })
+			Expect(names).To(ConsistOf("mydevice-1", "mydevice-3"))
+		})
+
+		It("Get many devices - wrong org - none found", func() {
+			badOrgId, _ := uuid.NewUUID()
+			devices, err := devStore.GetMany(ctx, badOrgId, []string{"mydevice-1", "mydevice-2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(devices).To(BeEmpty())
+		})
+
 		It("Delete device success", func() {
 			err := devStore.Delete(ctx, orgId, "mydevice-1", callback)
 			Expect(err).ToNot(HaveOccurred())
@@ -179,8 +194,9 @@ var _ = Describe("DeviceStore create", func() {
 
 		It("Delete all devices in org", func() {
 			otherOrgId, _ := uuid.NewUUID()
-			err := devStore.DeleteAll(ctx, otherOrgId, allDeletedCallback)
+			deleted, err := devStore.DeleteAll(ctx, otherOrgId, allDeletedCallback)
 			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeZero())
 			Expect(called).To(BeTrue())
 
 			listParams := store.ListParams{Limit: 1000}
@@ -189,8 +205,9 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(devices.Items).To(HaveLen(numDevices))
 
 			called = false
-			err = devStore.DeleteAll(ctx, orgId, allDeletedCallback)
+			deleted, err = devStore.DeleteAll(ctx, orgId, allDeletedCallback)
 			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(Equal(int64(numDevices)))
 			Expect(called).To(BeTrue())
 
 			devices, err = devStore.List(ctx, orgId, listParams)
@@ -198,6 +215,28 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(devices.Items).To(HaveLen(0))
 		})
 
+		It("Delete devices matching a label selector", func() {
+			deletedNames := []string{}
+			deleteCallback := store.DeviceStoreCallback(func(ctx context.Context, before *model.Device, after *model.Device) error {
+				Expect(after).To(BeNil())
+				deletedNames = append(deletedNames, before.Name)
+				return nil
+			})
+			listParams := store.ListParams{
+				LabelSelector: selector.NewLabelSelectorOrDie("key in (value-1, value-2)"),
+			}
+			deleted, failures, err := devStore.DeleteMatching(ctx, orgId, listParams, deleteCallback)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(Equal(int64(2)))
+			Expect(failures).To(BeEmpty())
+			Expect(deletedNames).To(ConsistOf("mydevice-1", "mydevice-2"))
+
+			devices, err := devStore.List(ctx, orgId, store.ListParams{Limit: 1000})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(devices.Items).To(HaveLen(1))
+			Expect(*devices.Items[0].Metadata.Name).To(Equal("mydevice-3"))
+		})
+
 		It("List with summary", func() {
 			allDevices, err := devStore.List(ctx, orgId, store.ListParams{})
 			Expect(err).ToNot(HaveOccurred())
@@ -235,6 +274,32 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(allDevicesSummary.Total).To(Equal(int64(3)))
 		})
 
+		It("CountByLabel", func() {
+			testutil.CreateTestDevice(ctx, devStore, orgId, "region-1", nil, nil, &map[string]string{"region": "eu", "site": "berlin"})
+			testutil.CreateTestDevice(ctx, devStore, orgId, "region-2", nil, nil, &map[string]string{"region": "eu", "site": "madrid"})
+			testutil.CreateTestDevice(ctx, devStore, orgId, "region-3", nil, nil, &map[string]string{"region": "us", "site": "boston"})
+			testutil.CreateTestDevice(ctx, devStore, orgId, "region-4", nil, nil, &map[string]string{"region": ""})
+
+			counts, err := devStore.CountByLabel(ctx, orgId, "region", store.ListParams{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int64{"eu": 2, "us": 1, "": 1}))
+
+			// the devices without the label are not counted
+			counts, err = devStore.CountByLabel(ctx, orgId, "otherkey", store.ListParams{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int64{"othervalue": int64(numDevices)}))
+
+			labelSelector, err := selector.NewLabelSelector("site in (berlin,boston)")
+			Expect(err).ToNot(HaveOccurred())
+			counts, err = devStore.CountByLabel(ctx, orgId, "region", store.ListParams{LabelSelector: labelSelector})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int64{"eu": 1, "us": 1}))
+
+			counts, err = devStore.CountByLabel(ctx, orgId, "missing", store.ListParams{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(counts).To(BeEmpty())
+		})
+
 		It("List with paging", func() {
 			listParams := store.ListParams{Limit: 1000}
 			allDevices, err := devStore.List(ctx, orgId, listParams)
@@ -543,6 +608,26 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(*renderedConfig.Config).To(Equal("this is the second config"))
 			Expect(renderedConfig.Os.Image).To(Equal("os"))
 			Expect(renderedConfig.RenderedVersion).To(Equal("2"))
+			Expect(renderedConfig.RequestID).To(BeNil())
+		})
+
+		It("GetRendered returns the request that rendered the version", func() {
+			testutil.CreateTestDevice(ctx, storeInst.Device(), orgId, "dev", nil, nil, nil)
+
+			reqCtx := context.WithValue(ctx, middleware.RequestIDKey, "host/abc-000001")
+			err := devStore.UpdateRendered(reqCtx, orgId, "dev", "this is the first config", "")
+			Expect(err).ToNot(HaveOccurred())
+
+			renderedConfig, err := devStore.GetRendered(ctx, orgId, "dev", nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renderedConfig.RequestID).To(Equal(lo.ToPtr("host/abc-000001")))
+
+			// rendering without a request drops the ID of the previous one
+			err = devStore.UpdateRendered(ctx, orgId, "dev", "this is the second config", "")
+			Expect(err).ToNot(HaveOccurred())
+			renderedConfig, err = devStore.GetRendered(ctx, orgId, "dev", nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renderedConfig.RequestID).To(BeNil())
 		})
 
 		It("OverwriteRepositoryRefs", func() {
@@ -605,9 +690,224 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(repos.Items).To(HaveLen(1))
 			Expect(*(repos.Items[0]).Metadata.Name).To(Equal("myrepository-1"))
 
-			err = devStore.DeleteAll(ctx, orgId, allDeletedCallback)
+			_, err = devStore.DeleteAll(ctx, orgId, allDeletedCallback)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(called).To(BeTrue())
 		})
 	})
 })
+
+var _ = Describe("DeviceStore list query plan", func() {
+	const seededDevices = 20000
+	isDegraded := func(i int) bool { return i%7 == 0 }
+
+	var (
+		log       *logrus.Logger
+		ctx       context.Context
+		orgId     uuid.UUID
+		storeInst store.Store
+		devStore  store.Device
+		cfg       *config.Config
+		db        *gorm.DB
+		dbName    string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		orgId, _ = uuid.NewUUID()
+		log = flightlog.InitLogs()
+		storeInst, cfg, dbName, db = store.PrepareDBForUnitTests(log)
+		devStore = storeInst.Device()
+
+		// seed devices spread over 100 sites, one in seven of which is degraded
+		devices := make([]*model.Device, 0, seededDevices)
+		for i := 0; i < seededDevices; i++ {
+			status := api.NewDeviceStatus()
+			status.Summary.Status = api.DeviceSummaryStatusOnline
+			if isDegraded(i) {
+				status.Summary.Status = api.DeviceSummaryStatusDegraded
+			}
+			device, err := model.NewDeviceFromApiResource(&api.Device{
+				Metadata: api.ObjectMeta{
+					Name:   lo.ToPtr(fmt.Sprintf("device-%05d", i)),
+					Labels: &map[string]string{"site": fmt.Sprintf("site-%d", i%100), "tier": "edge"},
+				},
+				Spec:   &api.DeviceSpec{},
+				Status: &status,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			device.OrgID = orgId
+			devices = append(devices, device)
+		}
+		Expect(db.CreateInBatches(devices, 1000).Error).ToNot(HaveOccurred())
+		Expect(db.Exec("ANALYZE devices").Error).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		store.DeleteTestDB(log, cfg, storeInst, dbName)
+	})
+
+	It("combines the label and field selectors in indexed conditions", func() {
+		listParams := store.ListParams{
+			LabelSelector: selector.NewLabelSelectorFromMapOrDie(map[string]string{"site": "site-50"}, false),
+			FieldSelector: selector.NewFieldSelectorOrDie("status.summary.status=Degraded", selector.WithPrivateSelectors()),
+		}
+		query, err := store.ListQuery(&model.Device{}).Build(ctx, db, orgId, listParams)
+		Expect(err).ToNot(HaveOccurred())
+		plan, err := store.ExplainQuery(ctx, query, &model.DeviceList{})
+		Expect(err).ToNot(HaveOccurred())
+		GinkgoWriter.Println(plan)
+
+		// both selectors are evaluated by the database, using the indexes rather than scanning
+		Expect(plan).ToNot(ContainSubstring("Seq Scan"))
+		Expect(plan).To(Or(ContainSubstring("idx_device_labels"), ContainSubstring("idx_device_summary_status")))
+
+		start := time.Now()
+		devices, err := devStore.List(ctx, orgId, listParams)
+		Expect(err).ToNot(HaveOccurred())
+		GinkgoWriter.Printf("listed %d of %d devices in %s\n", len(devices.Items), seededDevices, time.Since(start))
+		Expect(devices.Items).To(HaveLen(lo.CountBy(lo.Range(seededDevices), func(i int) bool { return i%100 == 50 && isDegraded(i) })))
+		for _, device := range devices.Items {
+			Expect((*device.Metadata.Labels)["site"]).To(Equal("site-50"))
+			Expect(device.Status.Summary.Status).To(Equal(api.DeviceSummaryStatusDegraded))
+		}
+	})
+})
+
+var _ = Describe("DeviceStore devices needing attention", func() {
+	const seededDevices = 20000
+
+	// seededStatus returns the status of the i-th seeded device and whether it needs attention:
+	// the devices of two fleets, most of which are healthy, mixed with the kinds of problems
+	// that need attention.
+	seededStatus := func(i int) (api.DeviceStatus, bool) {
+		status := api.NewDeviceStatus()
+		status.Summary.Status = api.DeviceSummaryStatusOnline
+		status.ApplicationsSummary.Status = api.ApplicationsSummaryStatusHealthy
+		status.Resources = api.DeviceResourceStatus{Cpu: api.DeviceResourceStatusHealthy, Memory: api.DeviceResourceStatusHealthy, Disk: api.DeviceResourceStatusHealthy}
+		switch i % 50 {
+		case 1:
+			status.Summary.Status = api.DeviceSummaryStatusError
+		case 2:
+			status.Summary.Status = api.DeviceSummaryStatusUnknown
+		case 3:
+			status.ApplicationsSummary.Status = api.ApplicationsSummaryStatusDegraded
+		case 4:
+			status.Resources.Disk = api.DeviceResourceStatusCritical
+		case 5:
+			status.Conditions = []api.Condition{{Type: api.DeviceSpecValid, Status: api.ConditionStatusFalse}}
+		case 6:
+			// valid specs and rebooting devices do not need attention
+			status.Conditions = []api.Condition{{Type: api.DeviceSpecValid, Status: api.ConditionStatusTrue}}
+			status.Summary.Status = api.DeviceSummaryStatusRebooting
+			return status, false
+		default:
+			return status, false
+		}
+		return status, true
+	}
+	seededOwner := func(i int) string {
+		return *util.SetResourceOwner(api.FleetKind, fmt.Sprintf("fleet-%d", i%2))
+	}
+
+	var (
+		log       *logrus.Logger
+		ctx       context.Context
+		orgId     uuid.UUID
+		storeInst store.Store
+		devStore  store.Device
+		cfg       *config.Config
+		db        *gorm.DB
+		dbName    string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		orgId, _ = uuid.NewUUID()
+		log = flightlog.InitLogs()
+		storeInst, cfg, dbName, db = store.PrepareDBForUnitTests(log)
+		devStore = storeInst.Device()
+
+		devices := make([]*model.Device, 0, seededDevices)
+		for i := 0; i < seededDevices; i++ {
+			status, _ := seededStatus(i)
+			device, err := model.NewDeviceFromApiResource(&api.Device{
+				Metadata: api.ObjectMeta{
+					Name:  lo.ToPtr(fmt.Sprintf("device-%05d", i)),
+					Owner: lo.ToPtr(seededOwner(i)),
+				},
+				Spec:   &api.DeviceSpec{},
+				Status: &status,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			device.OrgID = orgId
+			devices = append(devices, device)
+		}
+		Expect(db.CreateInBatches(devices, 1000).Error).ToNot(HaveOccurred())
+		Expect(db.Exec("ANALYZE devices").Error).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		store.DeleteTestDB(log, cfg, storeInst, dbName)
+	})
+
+	expectedNames := func(owner string) []string {
+		names := []string{}
+		for i := 0; i < seededDevices; i++ {
+			if _, needsAttention := seededStatus(i); needsAttention && (owner == "" || seededOwner(i) == owner) {
+				names = append(names, fmt.Sprintf("device-%05d", i))
+			}
+		}
+		return names
+	}
+
+	listAll := func(listParams store.ListParams) []string {
+		names := []string{}
+		for {
+			devices, err := devStore.ListNeedingAttention(ctx, orgId, listParams)
+			Expect(err).ToNot(HaveOccurred())
+			for _, device := range devices.Items {
+				names = append(names, *device.Metadata.Name)
+			}
+			if devices.Metadata.Continue == nil {
+				return names
+			}
+			listParams.Continue, err = store.ParseContinueString(devices.Metadata.Continue)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	}
+
+	It("lists the devices needing attention page by page", func() {
+		devices, err := devStore.ListNeedingAttention(ctx, orgId, store.ListParams{Limit: 100})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(devices.Items).To(HaveLen(100))
+		Expect(*devices.Metadata.RemainingItemCount).To(Equal(int64(len(expectedNames("")) - 100)))
+
+		Expect(listAll(store.ListParams{Limit: 1000})).To(Equal(expectedNames("")))
+	})
+
+	It("filters the devices needing attention by fleet", func() {
+		owner := *util.SetResourceOwner(api.FleetKind, "fleet-1")
+		listParams := store.ListParams{
+			Limit:         500,
+			FieldSelector: selector.NewFieldSelectorFromMapOrDie(map[string]string{"metadata.owner": owner}, false),
+		}
+		Expect(listAll(listParams)).To(Equal(expectedNames(owner)))
+
+		// other organizations are not listed
+		devices, err := devStore.ListNeedingAttention(ctx, uuid.New(), store.ListParams{Limit: 100})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(devices.Items).To(BeEmpty())
+	})
+
+	It("uses the partial index rather than scanning the devices", func() {
+		query, err := store.ListQuery(&model.Device{}).Build(ctx, db, orgId, store.ListParams{})
+		Expect(err).ToNot(HaveOccurred())
+		plan, err := store.ExplainQuery(ctx, query.Where(store.DeviceNeedsAttentionCondition).Limit(100), &model.DeviceList{})
+		Expect(err).ToNot(HaveOccurred())
+		GinkgoWriter.Println(plan)
+
+		Expect(plan).ToNot(ContainSubstring("Seq Scan"))
+		Expect(plan).To(ContainSubstring("idx_device_needs_attention"))
+	})
+})
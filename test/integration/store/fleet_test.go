@@ -145,8 +145,9 @@ var _ = Describe("FleetStore create", func() {
 
 		It("Delete fleet success", func() {
 			called := false
-			callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {
+			callback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error {
 				called = true
+				return nil
 			})
 			err := storeInst.Fleet().Delete(ctx, orgId, callback, "myfleet-1")
 			Expect(err).ToNot(HaveOccurred())
@@ -155,8 +156,9 @@ var _ = Describe("FleetStore create", func() {
 
 		It("Delete fleet success when not found", func() {
 			called := false
-			callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {
+			callback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error {
 				called = true
+				return nil
 			})
 			err := storeInst.Fleet().Delete(ctx, orgId, callback, "nonexistent")
 			Expect(err).ToNot(HaveOccurred())
@@ -165,8 +167,9 @@ var _ = Describe("FleetStore create", func() {
 
 		It("Delete all fleets in org", func() {
 			called := false
-			callback := store.FleetStoreAllDeletedCallback(func(orgId uuid.UUID) {
+			callback := store.FleetStoreAllDeletedCallback(func(ctx context.Context, orgId uuid.UUID) error {
 				called = true
+				return nil
 			})
 
 			otherOrgId, _ := uuid.NewUUID()
@@ -374,8 +377,9 @@ var _ = Describe("FleetStore create", func() {
 				Status: nil,
 			}
 			called := false
-			callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {
+			callback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error {
 				called = true
+				return nil
 			})
 			_, created, err := storeInst.Fleet().CreateOrUpdate(ctx, orgId, &fleet, callback)
 			Expect(called).To(BeTrue())
@@ -417,8 +421,9 @@ var _ = Describe("FleetStore create", func() {
 			updatedFleet.Spec.Selector = &api.LabelSelector{MatchLabels: &map[string]string{"key": "value"}}
 
 			called := false
-			callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {
+			callback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error {
 				called = true
+				return nil
 			})
 			_, created, err := storeInst.Fleet().CreateOrUpdate(ctx, orgId, updatedFleet, callback)
 			Expect(err).ToNot(HaveOccurred())
@@ -443,8 +448,9 @@ var _ = Describe("FleetStore create", func() {
 			fleet.Status = nil
 
 			called := false
-			callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {
+			callback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error {
 				called = true
+				return nil
 			})
 			_, created, err := storeInst.Fleet().CreateOrUpdate(ctx, orgId, fleet, callback)
 			Expect(called).To(BeTrue())
@@ -469,8 +475,9 @@ var _ = Describe("FleetStore create", func() {
 			fleet.Status = nil
 
 			called := false
-			callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {
+			callback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error {
 				called = true
+				return nil
 			})
 			fleet.Metadata.Owner = util.StrToPtr("test")
 			_, created, err := storeInst.Fleet().CreateOrUpdate(ctx, orgId, fleet, callback)
@@ -519,8 +526,9 @@ var _ = Describe("FleetStore create", func() {
 			fleet.Metadata.Owner = util.StrToPtr("owner")
 			fleet2.Metadata.Owner = util.StrToPtr("owner2")
 			called := false
-			callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {
+			callback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error {
 				called = true
+				return nil
 			})
 			_, created, err := storeInst.Fleet().CreateOrUpdate(ctx, orgId, fleet, callback)
 			Expect(err).ToNot(HaveOccurred())
@@ -569,8 +577,9 @@ var _ = Describe("FleetStore create", func() {
 				Status: nil,
 			}
 			called := 0
-			callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {
+			callback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error {
 				called++
+				return nil
 			})
 			err := storeInst.Fleet().CreateOrUpdateMultiple(ctx, orgId, callback, &fleet, &fleet2)
 			Expect(called).To(Equal(2))
@@ -618,8 +627,9 @@ var _ = Describe("FleetStore create", func() {
 				Status: nil,
 			}
 			called := 0
-			callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {
+			callback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error {
 				called++
+				return nil
 			})
 			err := storeInst.Fleet().CreateOrUpdateMultiple(ctx, orgId, callback, &fleet, &fleet2)
 			Expect(called).To(Equal(1))
@@ -660,7 +670,7 @@ var _ = Describe("FleetStore create", func() {
 					map[string]string{"metadata.owner": owner}, false, selector.WithPrivateSelectors()),
 			}
 
-			callback := store.FleetStoreAllDeletedCallback(func(orgId uuid.UUID) {})
+			callback := store.FleetStoreAllDeletedCallback(func(ctx context.Context, orgId uuid.UUID) error { return nil })
 			err := storeInst.Fleet().DeleteAll(ctx, orgId, callback)
 			Expect(err).ToNot(HaveOccurred())
 			testutil.CreateTestFleets(ctx, numFleets, storeInst.Fleet(), orgId, "myfleet", true, util.StrToPtr(owner))
@@ -710,6 +720,28 @@ var _ = Describe("FleetStore create", func() {
 			Expect(updatedFleet.Status.Conditions[0].Status).To(Equal(api.ConditionStatusFalse))
 		})
 
+		It("UpdateRolloutStatus", func() {
+			rollout := &api.FleetRolloutStatus{
+				TemplateVersion: lo.ToPtr("myfleet-1-tv"),
+				State:           lo.ToPtr(api.FleetRolloutWaiting),
+				CurrentBatch:    lo.ToPtr(1),
+			}
+
+			err := storeInst.Fleet().UpdateRolloutStatus(ctx, orgId, "myfleet-1", rollout)
+			Expect(err).ToNot(HaveOccurred())
+			updatedFleet, err := storeInst.Fleet().Get(ctx, orgId, "myfleet-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updatedFleet.Status.Rollout).ToNot(BeNil())
+			Expect(*updatedFleet.Status.Rollout.State).To(Equal(api.FleetRolloutWaiting))
+			Expect(*updatedFleet.Status.Rollout.CurrentBatch).To(Equal(1))
+
+			err = storeInst.Fleet().UpdateRolloutStatus(ctx, orgId, "myfleet-1", nil)
+			Expect(err).ToNot(HaveOccurred())
+			updatedFleet, err = storeInst.Fleet().Get(ctx, orgId, "myfleet-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updatedFleet.Status.Rollout).To(BeNil())
+		})
+
 		It("OverwriteRepositoryRefs", func() {
 			err := testutil.CreateRepositories(ctx, 2, storeInst, orgId)
 			Expect(err).ToNot(HaveOccurred())
@@ -755,8 +787,9 @@ var _ = Describe("FleetStore create", func() {
 			Expect(*(repos.Items[0]).Metadata.Name).To(Equal("myrepository-1"))
 
 			called := false
-			callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {
+			callback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error {
 				called = true
+				return nil
 			})
 			err = storeInst.Fleet().Delete(ctx, orgId, callback, "myfleet-1")
 			Expect(err).ToNot(HaveOccurred())
@@ -775,8 +808,9 @@ var _ = Describe("FleetStore create", func() {
 			Expect(*(repos.Items[0]).Metadata.Name).To(Equal("myrepository-1"))
 
 			called := false
-			callback := store.FleetStoreAllDeletedCallback(func(orgId uuid.UUID) {
+			callback := store.FleetStoreAllDeletedCallback(func(ctx context.Context, orgId uuid.UUID) error {
 				called = true
+				return nil
 			})
 			err = storeInst.Fleet().DeleteAll(ctx, orgId, callback)
 			Expect(err).ToNot(HaveOccurred())
@@ -0,0 +1,119 @@
+package store_test
+
+import (
+	"context"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/util"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	testutil "github.com/flightctl/flightctl/test/util"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("OrphansStore", func() {
+	var (
+		log       *logrus.Logger
+		ctx       context.Context
+		orgId     uuid.UUID
+		storeInst store.Store
+		cfg       *config.Config
+		dbName    string
+		db        *gorm.DB
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		orgId = store.NullOrgId
+		log = flightlog.InitLogs()
+		storeInst, cfg, dbName, db = store.PrepareDBForUnitTests(log)
+	})
+
+	AfterEach(func() {
+		store.DeleteTestDB(log, cfg, storeInst, dbName)
+	})
+
+	shipLogs := func(name string) {
+		_, err := storeInst.DeviceLogs().Replace(ctx, orgId, name, &api.DeviceLogs{
+			CollectedAt: time.Now(),
+			Lines:       []string{"line"},
+			Reason:      api.DeviceLogsReasonScheduled,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	createEnrollmentRequest := func(name string, approved bool) {
+		er := api.EnrollmentRequest{
+			Metadata: api.ObjectMeta{Name: util.StrToPtr(name)},
+			Spec:     api.EnrollmentRequestSpec{Csr: "csr string"},
+		}
+		_, err := storeInst.EnrollmentRequest().Create(ctx, orgId, &er)
+		Expect(err).ToNot(HaveOccurred())
+		if approved {
+			er.Status = &api.EnrollmentRequestStatus{Conditions: []api.Condition{{
+				Type:   api.EnrollmentRequestApproved,
+				Status: api.ConditionStatusTrue,
+				Reason: "ManuallyApproved",
+			}}}
+			_, err = storeInst.EnrollmentRequest().UpdateStatus(ctx, orgId, &er)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	}
+
+	backdate := func(table string, age time.Duration) {
+		Expect(db.Exec("UPDATE "+table+" SET updated_at = ?", time.Now().Add(-age)).Error).ToNot(HaveOccurred())
+	}
+
+	It("deletes the orphaned rows once they are older than the delay", func() {
+		// rows of an existing device
+		testutil.CreateTestDevice(ctx, storeInst.Device(), orgId, "device", nil, nil, nil)
+		shipLogs("device")
+		createEnrollmentRequest("device", true)
+		// rows of a deleted device
+		shipLogs("deleted-device")
+		createEnrollmentRequest("deleted-device", true)
+		// pending enrollment request, whose device does not exist yet
+		createEnrollmentRequest("pending-device", false)
+
+		counts, err := storeInst.Orphans().Count(ctx, orgId)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(counts).To(Equal(map[string]int64{
+			store.OrphanedDeviceLogs:         1,
+			store.OrphanedEnrollmentRequests: 1,
+		}))
+
+		// recently changed orphans are kept
+		deleted, err := storeInst.Orphans().Delete(ctx, orgId, time.Now().Add(-time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deleted).To(Equal(map[string]int64{
+			store.OrphanedDeviceLogs:         0,
+			store.OrphanedEnrollmentRequests: 0,
+		}))
+
+		backdate("device_logs", 2*time.Hour)
+		backdate("enrollment_requests", 2*time.Hour)
+		deleted, err = storeInst.Orphans().Delete(ctx, orgId, time.Now().Add(-time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deleted).To(Equal(map[string]int64{
+			store.OrphanedDeviceLogs:         1,
+			store.OrphanedEnrollmentRequests: 1,
+		}))
+
+		_, err = storeInst.DeviceLogs().Get(ctx, orgId, "deleted-device")
+		Expect(err).To(HaveOccurred())
+		_, err = storeInst.EnrollmentRequest().Get(ctx, orgId, "deleted-device")
+		Expect(err).To(HaveOccurred())
+		_, err = storeInst.DeviceLogs().Get(ctx, orgId, "device")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = storeInst.EnrollmentRequest().Get(ctx, orgId, "device")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = storeInst.EnrollmentRequest().Get(ctx, orgId, "pending-device")
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,148 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/store"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	outboxTestQueue  = "test-queue"
+	outboxMessages   = 100
+	outboxRelayCount = 8
+)
+
+var _ = Describe("Outbox", func() {
+	var (
+		log       *logrus.Logger
+		ctx       context.Context
+		storeInst store.Store
+		cfg       *config.Config
+		dbName    string
+		db        *gorm.DB
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		log = flightlog.InitLogs()
+		storeInst, cfg, dbName, db = store.PrepareDBForUnitTests(log)
+
+		for i := 0; i < outboxMessages; i++ {
+			Expect(storeInst.Outbox().Enqueue(ctx, outboxTestQueue, []byte(strconv.Itoa(i)))).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		store.DeleteTestDB(log, cfg, storeInst, dbName)
+	})
+
+	// runRelays runs relay concurrently in outboxRelayCount relays until the messages are all
+	// published, and returns the messages in the order they were published.
+	runRelays := func(relay func(ctx context.Context, queue string, limit int, publish func(payload []byte) error) (int, error)) []int {
+		var (
+			mu        sync.Mutex
+			published []int
+			wg        sync.WaitGroup
+		)
+		publish := func(payload []byte) error {
+			// keep the messages claimed for a while, for the relays to contend for the queue
+			time.Sleep(time.Millisecond)
+			i, err := strconv.Atoi(string(payload))
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			published = append(published, i)
+			return nil
+		}
+		done := func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(published) >= outboxMessages
+		}
+
+		deadline := time.Now().Add(30 * time.Second)
+		for r := 0; r < outboxRelayCount; r++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for !done() && time.Now().Before(deadline) {
+					_, err := relay(ctx, outboxTestQueue, 3, publish)
+					Expect(err).ToNot(HaveOccurred())
+				}
+			}()
+		}
+		wg.Wait()
+		return published
+	}
+
+	expectedMessages := func() []int {
+		messages := make([]int, outboxMessages)
+		for i := range messages {
+			messages[i] = i
+		}
+		return messages
+	}
+
+	It("publishes each message once across concurrent relays", func() {
+		published := runRelays(storeInst.Outbox().Relay)
+		Expect(published).To(ConsistOf(expectedMessages()))
+
+		// nothing is left to publish
+		n, err := storeInst.Outbox().Relay(ctx, outboxTestQueue, outboxMessages, func([]byte) error {
+			return fmt.Errorf("unexpected message")
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(BeZero())
+	})
+
+	It("publishes the messages in order across concurrent in-order relays", func() {
+		published := runRelays(storeInst.Outbox().RelayInOrder)
+		Expect(published).To(Equal(expectedMessages()))
+	})
+
+	It("skips the messages another transaction is claiming", func() {
+		tx := db.Begin()
+		defer tx.Rollback()
+		var locked []uint64
+		Expect(tx.Raw("SELECT id FROM outbox_entries WHERE queue = ? ORDER BY id LIMIT 2 FOR UPDATE", outboxTestQueue).Scan(&locked).Error).To(Succeed())
+		Expect(locked).To(HaveLen(2))
+
+		var published []string
+		n, err := storeInst.Outbox().Relay(ctx, outboxTestQueue, 2, func(payload []byte) error {
+			published = append(published, string(payload))
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(2))
+		Expect(published).To(Equal([]string{"2", "3"}))
+	})
+
+	It("publishes nothing in order while another transaction is claiming messages of the queue", func() {
+		tx := db.Begin()
+		defer tx.Rollback()
+		Expect(tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "outbox/"+outboxTestQueue).Error).To(Succeed())
+
+		publish := func([]byte) error { return nil }
+		n, err := storeInst.Outbox().RelayInOrder(ctx, outboxTestQueue, 2, publish)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(BeZero())
+
+		Expect(tx.Rollback().Error).To(Succeed())
+		n, err = storeInst.Outbox().RelayInOrder(ctx, outboxTestQueue, 2, publish)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(2))
+	})
+})
@@ -0,0 +1,114 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/auth/common"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("ResourceRevisionStore", func() {
+	var (
+		log       *logrus.Logger
+		ctx       context.Context
+		orgId     uuid.UUID
+		storeInst store.Store
+		cfg       *config.Config
+		dbName    string
+		callback  store.DeviceStoreCallback
+	)
+
+	BeforeEach(func() {
+		ctx = context.WithValue(context.Background(), common.IdentityCtxKey, &common.Identity{Username: "alice"})
+		orgId, _ = uuid.NewUUID()
+		log = flightlog.InitLogs()
+		storeInst, cfg, dbName, _ = store.PrepareDBForUnitTests(log)
+		callback = store.DeviceStoreCallback(func(ctx context.Context, before *model.Device, after *model.Device) error { return nil })
+	})
+
+	AfterEach(func() {
+		store.DeleteTestDB(log, cfg, storeInst, dbName)
+	})
+
+	applyImage := func(image string) {
+		device := api.Device{
+			Metadata: api.ObjectMeta{Name: util.StrToPtr("mydevice")},
+			Spec:     &api.DeviceSpec{Os: &api.DeviceOsSpec{Image: image}},
+		}
+		_, _, err := storeInst.Device().CreateOrUpdate(ctx, orgId, &device, nil, true, callback)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	imagesOf := func(revisions *api.ResourceRevisionList) []string {
+		return lo.Map(revisions.Items, func(r api.ResourceRevision, _ int) string {
+			return r.Spec["os"].(map[string]interface{})["image"].(string)
+		})
+	}
+
+	It("records and lists the revisions of a device updated several times", func() {
+		for i := 1; i <= 3; i++ {
+			applyImage(fmt.Sprintf("os:%d", i))
+		}
+		// updates that leave the spec unchanged do not add revisions
+		applyImage("os:3")
+
+		revisions, err := storeInst.ResourceRevision().GetHistory(ctx, orgId, api.DeviceKind, "mydevice", 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revisions.Kind).To(Equal(api.ResourceRevisionListKind))
+		Expect(revisions.Items).To(HaveLen(3))
+		Expect(lo.Map(revisions.Items, func(r api.ResourceRevision, _ int) int64 { return r.Revision })).To(Equal([]int64{3, 2, 1}))
+		Expect(imagesOf(revisions)).To(Equal([]string{"os:3", "os:2", "os:1"}))
+		Expect(*revisions.Items[0].Actor).To(Equal("alice"))
+		Expect(revisions.Items[0].Timestamp).ToNot(BeZero())
+
+		revisions, err = storeInst.ResourceRevision().GetHistory(ctx, orgId, api.DeviceKind, "mydevice", 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(imagesOf(revisions)).To(Equal([]string{"os:3", "os:2"}))
+	})
+
+	It("starts a new history when a device is recreated", func() {
+		applyImage("os:1")
+		applyImage("os:2")
+		Expect(storeInst.Device().Delete(ctx, orgId, "mydevice", callback)).To(Succeed())
+		applyImage("os:new")
+
+		revisions, err := storeInst.ResourceRevision().GetHistory(ctx, orgId, api.DeviceKind, "mydevice", 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(imagesOf(revisions)).To(Equal([]string{"os:new"}))
+	})
+
+	It("prunes revisions beyond the limit and of deleted resources", func() {
+		for i := 1; i <= 5; i++ {
+			applyImage(fmt.Sprintf("os:%d", i))
+		}
+		other := api.Device{
+			Metadata: api.ObjectMeta{Name: util.StrToPtr("otherdevice")},
+			Spec:     &api.DeviceSpec{Os: &api.DeviceOsSpec{Image: "os:1"}},
+		}
+		_, _, err := storeInst.Device().CreateOrUpdate(ctx, orgId, &other, nil, true, callback)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(storeInst.Device().Delete(ctx, orgId, "otherdevice", callback)).To(Succeed())
+
+		deleted, err := storeInst.ResourceRevision().Prune(ctx, orgId, 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deleted).To(Equal(int64(4)))
+
+		revisions, err := storeInst.ResourceRevision().GetHistory(ctx, orgId, api.DeviceKind, "mydevice", 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(imagesOf(revisions)).To(Equal([]string{"os:5", "os:4"}))
+		revisions, err = storeInst.ResourceRevision().GetHistory(ctx, orgId, api.DeviceKind, "otherdevice", 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(revisions.Items).To(BeEmpty())
+	})
+})
@@ -0,0 +1,248 @@
+package store_test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	testutil "github.com/flightctl/flightctl/test/util"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// rlsTestRole is a role without the superuser and BYPASSRLS attributes the test database user may
+// have, which would exempt it from the row-level security policies.
+const rlsTestRole = "flightctl_rls_test"
+
+var _ = Describe("Row-level security", func() {
+	var (
+		log       *logrus.Logger
+		ctx       context.Context
+		orgA      uuid.UUID
+		orgB      uuid.UUID
+		storeInst store.Store
+		cfg       *config.Config
+		dbName    string
+		db        *gorm.DB
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		orgA = uuid.New()
+		orgB = uuid.New()
+		log = flightlog.InitLogs()
+		storeInst, cfg, dbName, db = store.PrepareDBForUnitTests(log)
+
+		Expect(db.Exec(`DO $$ BEGIN CREATE ROLE ` + rlsTestRole + `; EXCEPTION WHEN duplicate_object THEN NULL; END $$`).Error).ToNot(HaveOccurred())
+		Expect(db.Exec("GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO " + rlsTestRole).Error).ToNot(HaveOccurred())
+		Expect(db.Exec("GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO " + rlsTestRole).Error).ToNot(HaveOccurred())
+		Expect(db.Exec("GRANT " + rlsTestRole + " TO CURRENT_USER").Error).ToNot(HaveOccurred())
+
+		testutil.CreateTestDevices(ctx, 2, storeInst.Device(), orgA, nil, false)
+		testutil.CreateTestDevices(ctx, 3, storeInst.Device(), orgB, nil, false)
+		testutil.CreateTestFleets(ctx, 1, storeInst.Fleet(), orgA, "fleet-a", false, nil)
+		testutil.CreateTestFleets(ctx, 2, storeInst.Fleet(), orgB, "fleet-b", false, nil)
+	})
+
+	AfterEach(func() {
+		store.DeleteTestDB(log, cfg, storeInst, dbName)
+	})
+
+	// inOrg runs fn in a transaction of the organization, as a role subject to row-level security.
+	inOrg := func(orgId uuid.UUID, fn func(tx *gorm.DB) error) error {
+		return store.OrgTransaction(db, orgId, func(tx *gorm.DB) error {
+			if err := tx.Exec("SET LOCAL ROLE " + rlsTestRole).Error; err != nil {
+				return err
+			}
+			return fn(tx)
+		})
+	}
+
+	It("hides the rows of other organizations from queries without an org_id filter", func() {
+		err := inOrg(orgA, func(tx *gorm.DB) error {
+			var devices []model.Device
+			Expect(tx.Find(&devices).Error).ToNot(HaveOccurred())
+			Expect(devices).To(HaveLen(2))
+			for _, device := range devices {
+				Expect(device.OrgID).To(Equal(orgA))
+			}
+
+			var count int64
+			Expect(tx.Model(&model.Fleet{}).Count(&count).Error).ToNot(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+
+			// even a query filtering on the other organization
+			Expect(tx.Model(&model.Device{}).Where("org_id = ?", orgB).Count(&count).Error).ToNot(HaveOccurred())
+			Expect(count).To(BeZero())
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = inOrg(orgB, func(tx *gorm.DB) error {
+			var count int64
+			Expect(tx.Model(&model.Device{}).Count(&count).Error).ToNot(HaveOccurred())
+			Expect(count).To(Equal(int64(3)))
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("prevents changing the rows of other organizations", func() {
+		err := inOrg(orgA, func(tx *gorm.DB) error {
+			result := tx.Exec("UPDATE devices SET alias = 'hijacked'")
+			Expect(result.Error).ToNot(HaveOccurred())
+			Expect(result.RowsAffected).To(Equal(int64(2)))
+
+			result = tx.Exec("DELETE FROM fleets WHERE org_id = ?", orgB)
+			Expect(result.Error).ToNot(HaveOccurred())
+			Expect(result.RowsAffected).To(BeZero())
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		var count int64
+		Expect(db.Model(&model.Device{}).Where("org_id = ? AND alias = 'hijacked'", orgB).Count(&count).Error).ToNot(HaveOccurred())
+		Expect(count).To(BeZero())
+		Expect(db.Model(&model.Fleet{}).Where("org_id = ?", orgB).Count(&count).Error).ToNot(HaveOccurred())
+		Expect(count).To(Equal(int64(2)))
+
+		err = inOrg(orgA, func(tx *gorm.DB) error {
+			return tx.Exec("INSERT INTO fleets (org_id, name) VALUES (?, 'planted')", orgB).Error
+		})
+		Expect(err).To(MatchError(ContainSubstring("row-level security")))
+	})
+
+	It("fails the queries run outside of an organization transaction", func() {
+		outsideOrg := func() error {
+			return db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec("SET LOCAL ROLE " + rlsTestRole).Error; err != nil {
+					return err
+				}
+				var count int64
+				return tx.Model(&model.Device{}).Count(&count).Error
+			})
+		}
+		Expect(outsideOrg()).To(MatchError(ContainSubstring("outside of an organization transaction")))
+
+		// the organization of a transaction does not leak into the next one on the same connection
+		Expect(inOrg(orgA, func(tx *gorm.DB) error { return nil })).To(Succeed())
+		Expect(outsideOrg()).To(MatchError(ContainSubstring("outside of an organization transaction")))
+	})
+
+	It("lets all rows through to the bypass role", func() {
+		err := store.OrgBypassTransaction(db, func(tx *gorm.DB) error {
+			var count int64
+			Expect(tx.Model(&model.Device{}).Count(&count).Error).ToNot(HaveOccurred())
+			Expect(count).To(Equal(int64(5)))
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("with the service connected as a role subject to row-level security", func() {
+		var (
+			rlsDB    *gorm.DB
+			rlsStore store.Store
+		)
+
+		BeforeEach(func() {
+			Expect(db.Exec("ALTER ROLE " + rlsTestRole + " LOGIN PASSWORD '" + rlsTestRole + "'").Error).ToNot(HaveOccurred())
+			Expect(db.Exec("GRANT " + store.OrgBypassRole + " TO " + rlsTestRole).Error).ToNot(HaveOccurred())
+
+			rlsCfg := *cfg
+			rlsCfg.Database.User = rlsTestRole
+			rlsCfg.Database.Password = rlsTestRole
+			var err error
+			rlsDB, err = store.InitDB(&rlsCfg, log)
+			Expect(err).ToNot(HaveOccurred())
+
+			// drop the org_id filters the store adds to its queries, leaving the policies alone
+			// between the organizations
+			stripOrgFilter := func(tx *gorm.DB) {
+				where, ok := tx.Statement.Clauses["WHERE"]
+				if !ok {
+					return
+				}
+				expression, ok := where.Expression.(clause.Where)
+				if !ok {
+					return
+				}
+				expression.Exprs = lo.Reject(expression.Exprs, func(expr clause.Expression, _ int) bool {
+					e, ok := expr.(clause.Expr)
+					return ok && strings.Contains(e.SQL, "org_id")
+				})
+				where.Expression = expression
+				tx.Statement.Clauses["WHERE"] = where
+			}
+			Expect(rlsDB.Callback().Query().Before("gorm:query").Register("test:strip_org_filter", stripOrgFilter)).To(Succeed())
+			rlsStore = store.NewStore(rlsDB, log.WithField("pkg", "store"))
+		})
+
+		AfterEach(func() {
+			store.CloseDB(rlsDB)
+		})
+
+		It("restricts reads to the organization without an org_id filter", func() {
+			devices, err := rlsStore.Device().List(ctx, orgA, store.ListParams{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(devices.Items).To(HaveLen(2))
+
+			devices, err = rlsStore.Device().List(ctx, orgB, store.ListParams{Limit: 2})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(devices.Items).To(HaveLen(2))
+			Expect(devices.Metadata.RemainingItemCount).To(Equal(lo.ToPtr(int64(1))))
+
+			summary, err := rlsStore.Device().Summary(ctx, orgB, store.ListParams{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(summary.Total).To(Equal(int64(3)))
+
+			fleets, err := rlsStore.Fleet().List(ctx, orgA, store.ListParams{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fleets.Items).To(HaveLen(1))
+		})
+
+		It("sees the rows with no organization only as the bypass role", func() {
+			var count int64
+			Expect(rlsDB.Model(&model.Device{}).Count(&count).Error).To(MatchError(ContainSubstring("outside of an organization transaction")))
+
+			fleets, err := rlsStore.Fleet().ListIgnoreOrg()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fleets).To(HaveLen(3))
+		})
+
+		It("keeps the store working for the role", func() {
+			callback := func(context.Context, *model.Device, *model.Device) error { return nil }
+			device := testutil.ReturnTestDevice(orgA, "rls-device", nil, nil, nil)
+			_, err := rlsStore.Device().Create(ctx, orgA, &device, callback)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rlsStore.Device().UpdateAnnotations(ctx, orgA, "rls-device", map[string]string{"key": "value"}, nil)).To(Succeed())
+
+			created, err := rlsStore.Device().Get(ctx, orgA, "rls-device")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*created.Metadata.Annotations).To(HaveKeyWithValue("key", "value"))
+			_, err = rlsStore.Device().Get(ctx, orgB, "rls-device")
+			Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+
+			Expect(rlsStore.Device().Delete(ctx, orgA, "rls-device", callback)).To(Succeed())
+			_, err = rlsStore.Device().Get(ctx, orgA, "rls-device")
+			Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+		})
+	})
+
+	It("keeps the store working in organization transactions", func() {
+		Expect(storeInst.Device().Delete(ctx, orgA, "mydevice-1", func(context.Context, *model.Device, *model.Device) error { return nil })).To(Succeed())
+		_, err := storeInst.Device().Get(ctx, orgA, "mydevice-1")
+		Expect(err).To(HaveOccurred())
+		_, err = storeInst.Device().Get(ctx, orgB, "mydevice-1")
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -126,6 +126,23 @@ var _ = Describe("FleetRollout", func() {
 			Expect((*dev.Metadata.Annotations)[api.DeviceAnnotationTemplateVersion]).To(Equal("1.0.0"))
 		})
 
+		It("a cordoned device is not rolled out", func() {
+			testutil.CreateTestFleet(ctx, fleetStore, orgId, fleetName, nil, nil)
+			labels := map[string]string{api.DeviceLabelCordoned: "true"}
+			testutil.CreateTestDevice(ctx, deviceStore, orgId, "mydevice-1", util.StrToPtr("Fleet/myfleet"), nil, &labels)
+
+			logic := tasks.NewFleetRolloutsLogic(callbackManager, log, storeInst, tasks.ResourceReference{OrgID: orgId, Name: fleetName})
+			err := testutil.CreateTestTemplateVersion(ctx, tvStore, orgId, fleetName, "1.0.0", nil)
+			Expect(err).ToNot(HaveOccurred())
+			err = logic.RolloutFleet(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			dev, err := deviceStore.Get(ctx, orgId, "mydevice-1")
+			Expect(err).ToNot(HaveOccurred())
+			if dev.Metadata.Annotations != nil {
+				Expect(*dev.Metadata.Annotations).ToNot(HaveKey(api.DeviceAnnotationTemplateVersion))
+			}
+		})
+
 		When("the fleet is valid and contains parameters", func() {
 			var (
 				gitConfig    *api.GitConfigProviderSpec
@@ -76,7 +76,7 @@ var _ = Describe("FleetValidate", func() {
 			Spec: specHttp,
 		}
 
-		repoCallback := store.RepositoryStoreCallback(func(*model.Repository) {})
+		repoCallback := store.RepositoryStoreCallback(func(context.Context, *model.Repository) error { return nil })
 		_, err = storeInst.Repository().Create(ctx, orgId, repository, repoCallback)
 		Expect(err).ToNot(HaveOccurred())
 		_, err = storeInst.Repository().Create(ctx, orgId, repositoryHttp, repoCallback)
@@ -134,7 +134,7 @@ var _ = Describe("FleetValidate", func() {
 		badHttpConfig.HttpRef.FilePath = "http-path"
 		badHttpConfig.HttpRef.Suffix = util.StrToPtr("/suffix")
 
-		callback = store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {})
+		callback = store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error { return nil })
 	})
 
 	AfterEach(func() {
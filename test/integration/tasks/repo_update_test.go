@@ -128,7 +128,7 @@ var _ = Describe("RepoUpdate", func() {
 		}
 		fleet2.Spec.Template.Spec = api.DeviceSpec{Config: &config2}
 
-		fleetCallback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {})
+		fleetCallback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error { return nil })
 		_, err = storeInst.Fleet().Create(ctx, orgId, &fleet1, fleetCallback)
 		Expect(err).ToNot(HaveOccurred())
 		err = storeInst.Fleet().OverwriteRepositoryRefs(ctx, orgId, "fleet1", "myrepository-1")
@@ -153,7 +153,7 @@ var _ = Describe("RepoUpdate", func() {
 			},
 		}
 
-		devCallback := store.DeviceStoreCallback(func(before *model.Device, after *model.Device) {})
+		devCallback := store.DeviceStoreCallback(func(ctx context.Context, before *model.Device, after *model.Device) error { return nil })
 		_, err = storeInst.Device().Create(ctx, orgId, &device1, devCallback)
 		Expect(err).ToNot(HaveOccurred())
 		err = storeInst.Device().OverwriteRepositoryRefs(ctx, orgId, "device1", "myrepository-1")
@@ -45,7 +45,7 @@ func createRepository(ctx context.Context, repostore store.Repository, orgId uui
 		Spec: spec,
 	}
 
-	callback := store.RepositoryStoreCallback(func(*model.Repository) {})
+	callback := store.RepositoryStoreCallback(func(context.Context, *model.Repository) error { return nil })
 	_, err = repostore.Create(ctx, orgId, &resource, callback)
 	return err
 }
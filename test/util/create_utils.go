@@ -75,7 +75,7 @@ func ReturnTestDevice(orgId uuid.UUID, name string, owner *string, tv *string, l
 
 func CreateTestDevice(ctx context.Context, deviceStore store.Device, orgId uuid.UUID, name string, owner *string, tv *string, labels *map[string]string) {
 	resource := ReturnTestDevice(orgId, name, owner, tv, labels)
-	callback := store.DeviceStoreCallback(func(before *model.Device, after *model.Device) {})
+	callback := store.DeviceStoreCallback(func(ctx context.Context, before *model.Device, after *model.Device) error { return nil })
 	_, _, err := deviceStore.CreateOrUpdate(ctx, orgId, &resource, nil, false, callback)
 	if err != nil {
 		log.Fatalf("creating device: %v", err)
@@ -111,7 +111,7 @@ func CreateTestFleet(ctx context.Context, fleetStore store.Fleet, orgId uuid.UUI
 	if selector != nil {
 		resource.Spec.Selector = &api.LabelSelector{MatchLabels: selector}
 	}
-	callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {})
+	callback := store.FleetStoreCallback(func(ctx context.Context, before *model.Fleet, after *model.Fleet) error { return nil })
 	_, err := fleetStore.Create(ctx, orgId, &resource, callback)
 	if err != nil {
 		log.Fatalf("creating fleet: %v", err)
@@ -144,7 +144,7 @@ func CreateTestTemplateVersion(ctx context.Context, tvStore store.TemplateVersio
 		resource.Status = status
 	}
 
-	callback := store.TemplateVersionStoreCallback(func(tv *model.TemplateVersion) {})
+	callback := store.TemplateVersionStoreCallback(func(ctx context.Context, tv *model.TemplateVersion) error { return nil })
 	_, err := tvStore.Create(ctx, orgId, &resource, callback)
 
 	return err
@@ -178,7 +178,7 @@ func CreateRepositories(ctx context.Context, numRepositories int, storeInst stor
 			Spec: spec,
 		}
 
-		callback := store.RepositoryStoreCallback(func(*model.Repository) {})
+		callback := store.RepositoryStoreCallback(func(context.Context, *model.Repository) error { return nil })
 		_, err = storeInst.Repository().Create(ctx, orgId, &resource, callback)
 		if err != nil {
 			return err
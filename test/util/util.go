@@ -11,6 +11,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/client"
@@ -40,6 +41,7 @@ const (
 type testProvider struct {
 	queue   chan []byte
 	stopped atomic.Bool
+	mu      sync.Mutex
 	wg      *sync.WaitGroup
 	log     logrus.FieldLogger
 }
@@ -62,7 +64,28 @@ func (t *testProvider) NewConsumer(_ string) (queues.Consumer, error) {
 	return t, nil
 }
 
+func (t *testProvider) PendingMessages(_ context.Context, _ string) (int64, error) {
+	return int64(len(t.queue)), nil
+}
+
+func (t *testProvider) PublishAfter(_ context.Context, _ string, payload []byte, delay time.Duration) error {
+	time.AfterFunc(delay, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if !t.stopped.Load() {
+			t.queue <- payload
+		}
+	})
+	return nil
+}
+
+func (t *testProvider) Ping(_ context.Context) error {
+	return nil
+}
+
 func (t *testProvider) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	if !t.stopped.Swap(true) {
 		t.wg.Done()
 		close(t.queue)
@@ -168,6 +191,9 @@ func NewTestStore(cfg config.Config, log *logrus.Logger) (store.Store, string, e
 	if err != nil {
 		return nil, "", fmt.Errorf("NewTestStore: performing initial migration: %w", err)
 	}
+	if err = store.PrepareOrgBypassRole(db); err != nil {
+		return nil, "", fmt.Errorf("NewTestStore: preparing the organization bypass role: %w", err)
+	}
 
 	return dbStore, randomDBName, nil
 }